@@ -0,0 +1,50 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// errAdminDisabled is returned for admin requests when admin token isn't configured.
+var errAdminDisabled = errors.New("admin API is disabled")
+
+// authorizeAdmin checks that request to admin operation of service is authorized with admin token.
+// All admin operations of API share the single token from configuration.
+func (ar *Runner) authorizeAdmin(r *http.Request, service string) error {
+	token := ar.cfg.AdminToken
+	if token == "" {
+		return errors.Wrapf(errAdminDisabled, "[ %s ]", service)
+	}
+	return errors.Wrapf(checkAdminToken(r, token), "[ %s ]", service)
+}
+
+// checkAdminToken checks that request is authorized with token in "Authorization: Bearer <token>" header.
+func checkAdminToken(r *http.Request, token string) error {
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, "Bearer ") {
+		return errors.New("admin token is required")
+	}
+	if subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(header, "Bearer ")), []byte(token)) != 1 {
+		return errors.New("invalid admin token")
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+)
+
+func TestRunner_AuthorizeAdmin(t *testing.T) {
+	cfg := configuration.NewAPIRunner()
+	runner := &Runner{cfg: &cfg}
+
+	err := runner.authorizeAdmin(deployRequest("secret"), "TestService")
+	require.Equal(t, errAdminDisabled, errors.Cause(err))
+	require.Contains(t, err.Error(), "[ TestService ]")
+
+	cfg.AdminToken = "secret"
+	err = runner.authorizeAdmin(deployRequest(""), "TestService")
+	require.EqualError(t, err, "[ TestService ]: admin token is required")
+	err = runner.authorizeAdmin(deployRequest("wrong"), "TestService")
+	require.EqualError(t, err, "[ TestService ]: invalid admin token")
+	require.NoError(t, runner.authorizeAdmin(deployRequest("secret"), "TestService"))
+}
@@ -0,0 +1,115 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/application/extractor"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// aliasPrefix marks alias given instead of base58 reference, prefix is optional in aliases.Resolve.
+const aliasPrefix = "@"
+
+// AliasesResolveArgs is arguments of Aliases.Resolve request.
+type AliasesResolveArgs struct {
+	Alias string
+}
+
+// AliasesResolveReply is reply for Aliases.Resolve request.
+type AliasesResolveReply struct {
+	Alias     string
+	Reference string
+	Owner     string
+}
+
+// AliasesService is a service that resolves aliases of objects registered in root domain.
+type AliasesService struct {
+	runner *Runner
+}
+
+// NewAliasesService creates new AliasesService instance.
+func NewAliasesService(runner *Runner) *AliasesService {
+	return &AliasesService{runner: runner}
+}
+
+// Resolve returns reference of object and its owner registered under alias.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "aliases.Resolve",
+//	  "params": {
+//	    "Alias": str // alias of object, with or without leading "@"
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	  Response structure:
+//	  {
+//	    "jsonrpc": "2.0",
+//	    "result": {
+//	      "Alias": str, // alias without leading "@"
+//	      "Reference": str, // reference of object
+//	      "Owner": str // reference of member owning alias
+//	    },
+//	    "id": str|int|null // same as in request
+//	  }
+func (s *AliasesService) Resolve(r *http.Request, args *AliasesResolveArgs, reply *AliasesResolveReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ AliasesService.Resolve ] Incoming request: %s", r.RequestURI)
+
+	alias := strings.TrimPrefix(args.Alias, aliasPrefix)
+	if alias == "" {
+		return errors.New("[ AliasesService.Resolve ] alias is empty")
+	}
+	ref, err := s.runner.callAlias(ctx, "ResolveAlias", alias)
+	if err != nil {
+		return errors.Wrap(err, "[ AliasesService.Resolve ] can't resolve alias")
+	}
+	owner, err := s.runner.callAlias(ctx, "GetAliasOwner", alias)
+	if err != nil {
+		return errors.Wrap(err, "[ AliasesService.Resolve ] can't get alias owner")
+	}
+
+	reply.Alias = alias
+	reply.Reference = ref
+	reply.Owner = owner
+	return nil
+}
+
+// callAlias calls alias getter method of root domain.
+func (ar *Runner) callAlias(ctx context.Context, method string, alias string) (string, error) {
+	res, err := ar.ContractRequester.SendRequest(
+		ctx,
+		ar.CertificateManager.GetCertificate().GetRootDomainReference(),
+		method,
+		[]interface{}{alias},
+	)
+	if err != nil {
+		return "", errors.Wrapf(err, "[ callAlias ] Can't call %s", method)
+	}
+	return extractor.AliasResponse(res.(*reply.CallMethod).Result)
+}
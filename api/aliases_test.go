@@ -0,0 +1,78 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestAliasesService_Resolve(t *testing.T) {
+	rootDomain := testutils.RandomRef()
+	object := testutils.RandomRef()
+	owner := testutils.RandomRef()
+
+	cert := testutils.NewCertificateMock(t)
+	cert.GetRootDomainReferenceFunc = func() *core.RecordRef {
+		return &rootDomain
+	}
+	cm := testutils.NewCertificateManagerMock(t)
+	cm.GetCertificateFunc = func() core.Certificate {
+		return cert
+	}
+
+	cr := testutils.NewContractRequesterMock(t)
+	cr.SendRequestFunc = func(ctx context.Context, ref *core.RecordRef, method string, args []interface{}) (core.Reply, error) {
+		require.Equal(t, rootDomain, *ref)
+		require.Equal(t, []interface{}{"my.wallet"}, args)
+		var result string
+		var contractErr *foundation.Error
+		switch method {
+		case "ResolveAlias":
+			result = object.String()
+		case "GetAliasOwner":
+			result = owner.String()
+		default:
+			t.Fatalf("unexpected method %s", method)
+		}
+		data, err := core.MarshalArgs(result, contractErr)
+		require.NoError(t, err)
+		return &reply.CallMethod{Result: data}, nil
+	}
+
+	service := NewAliasesService(&Runner{ContractRequester: cr, CertificateManager: cm})
+
+	var rep AliasesResolveReply
+	err := service.Resolve(&http.Request{}, &AliasesResolveArgs{Alias: "@my.wallet"}, &rep)
+	require.NoError(t, err)
+	require.Equal(t, AliasesResolveReply{
+		Alias:     "my.wallet",
+		Reference: object.String(),
+		Owner:     owner.String(),
+	}, rep)
+
+	err = service.Resolve(&http.Request{}, &AliasesResolveArgs{Alias: "@"}, &rep)
+	require.Error(t, err)
+}
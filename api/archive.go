@@ -0,0 +1,186 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// ArchiveArgs is arguments of Archive service requests.
+type ArchiveArgs struct {
+	Request string
+}
+
+// ArchiveService is a service that provides API for querying requests and their results regardless of age.
+// Light material nodes keep records for a few pulses only, older queries are routed to heavy material node.
+// Service is served by heavy material and observer nodes, so load of archival queries doesn't affect executors.
+type ArchiveService struct {
+	runner *Runner
+}
+
+// NewArchiveService creates new ArchiveService instance.
+func NewArchiveService(runner *Runner) *ArchiveService {
+	return &ArchiveService{runner: runner}
+}
+
+// Get returns outcome of API request registered on ledger. Reply is the same as reply of requests.Get, but it
+// doesn't depend on node which accepted the request or retention of outcomes. Result and Error are empty if
+// request isn't executed yet, Time is a time of pulse of request.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "archive.Get",
+//	  "params": {
+//	    "Request": str // reference of request returned by call API
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	  Response structure:
+//	  {
+//	    "jsonrpc": "2.0",
+//	    "result": {
+//	      "QID": str,
+//	      "Member": str, // reference of member who signed the request
+//	      "Method": str,
+//	      "Request": str, // reference of registered request
+//	      "Result": any, // result of the call
+//	      "Error": str,
+//	      "Time": int // unix time of pulse of request
+//	    },
+//	    "id": str|int|null // same as in request
+//	  }
+func (s *ArchiveService) Get(r *http.Request, args *ArchiveArgs, reply *RequestsReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ ArchiveService.Get ] Incoming request: %s, request: %s", r.RequestURI, args.Request)
+
+	request, err := s.parseRequest(args)
+	if err != nil {
+		return errors.Wrap(err, "[ ArchiveService.Get ]")
+	}
+
+	parcel, _, err := s.runner.LedgerArchive.GetRequest(ctx, *request)
+	if err == core.ErrNotFound {
+		return errors.New("[ ArchiveService.Get ] request is not registered")
+	}
+	if err != nil {
+		return errors.Wrap(err, "[ ArchiveService.Get ] Can't get request")
+	}
+	msg, ok := parcel.Message().(*message.CallMethod)
+	if !ok || msg.APIRequest == nil {
+		return errors.New("[ ArchiveService.Get ] request isn't API request")
+	}
+
+	reply.QID = msg.APIRequest.QID
+	reply.Member = msg.APIRequest.Member.String()
+	reply.Method = apiMethod(msg)
+	reply.Request = request.String()
+	reply.Time = s.pulseTime(ctx, request.Record().Pulse())
+
+	payload, err := s.runner.LedgerArchive.GetResult(ctx, *request)
+	if err == core.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "[ ArchiveService.Get ] Can't get result")
+	}
+	reply.Result, reply.Error, err = callResult(payload)
+	return errors.Wrap(err, "[ ArchiveService.Get ]")
+}
+
+// Result returns result registered on ledger for request. Reply is the same as reply of requests.Result.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "archive.Result",
+//	  "params": {
+//	    "Request": str // reference of request returned by call API
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	  Response structure:
+//	  {
+//	    "jsonrpc": "2.0",
+//	    "result": {
+//	      "Result": any, // result of the call
+//	      "Error": str // error returned by called method
+//	    },
+//	    "id": str|int|null // same as in request
+//	  }
+func (s *ArchiveService) Result(r *http.Request, args *ArchiveArgs, reply *RequestsResultReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ ArchiveService.Result ] Incoming request: %s, request: %s", r.RequestURI, args.Request)
+
+	request, err := s.parseRequest(args)
+	if err != nil {
+		return errors.Wrap(err, "[ ArchiveService.Result ]")
+	}
+
+	payload, err := s.runner.LedgerArchive.GetResult(ctx, *request)
+	if err == core.ErrNotFound {
+		return errors.New("[ ArchiveService.Result ] result is not registered")
+	}
+	if err != nil {
+		return errors.Wrap(err, "[ ArchiveService.Result ] Can't get result")
+	}
+	reply.Result, reply.Error, err = callResult(payload)
+	return errors.Wrap(err, "[ ArchiveService.Result ]")
+}
+
+// parseRequest checks node serves archival queries and parses reference of request.
+func (s *ArchiveService) parseRequest(args *ArchiveArgs) (*core.RecordRef, error) {
+	role := s.runner.CertificateManager.GetCertificate().GetRole()
+	if role != core.StaticRoleHeavyMaterial && role != core.StaticRoleObserver {
+		return nil, errors.New("archival queries are served by heavy material and observer nodes")
+	}
+	request, err := core.ParseRef(args.Request)
+	return request, errors.Wrap(err, "Failed to parse request reference")
+}
+
+// pulseTime returns unix time of stored pulse, zero if pulse isn't stored.
+func (s *ArchiveService) pulseTime(ctx context.Context, pulse core.PulseNumber) int64 {
+	history, err := s.runner.PulseHistory.GetPulses(ctx, pulse, pulse, 1)
+	if err != nil || len(history.Pulses) == 0 {
+		return 0
+	}
+	return history.Pulses[0].Pulse.PulseTimestamp
+}
+
+// apiMethod returns method of API request, member's "Call" carries it as the second argument.
+func apiMethod(msg *message.CallMethod) string {
+	var args []interface{}
+	if msg.Method != "Call" || core.Deserialize(msg.Arguments, &args) != nil || len(args) < 2 {
+		return msg.Method
+	}
+	if method, ok := args[1].(string); ok {
+		return method
+	}
+	return msg.Method
+}
@@ -0,0 +1,129 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
+	"github.com/insolar/insolar/testutils"
+)
+
+type ledgerArchive struct {
+	requests map[core.RecordRef]core.Parcel
+	results  map[core.RecordRef][]byte
+}
+
+func (a *ledgerArchive) GetRequest(ctx context.Context, request core.RecordRef) (core.Parcel, uint64, error) {
+	parcel, ok := a.requests[request]
+	if !ok {
+		return nil, 0, core.ErrNotFound
+	}
+	return parcel, 1, nil
+}
+
+func (a *ledgerArchive) GetResult(ctx context.Context, request core.RecordRef) ([]byte, error) {
+	payload, ok := a.results[request]
+	if !ok {
+		return nil, core.ErrNotFound
+	}
+	return payload, nil
+}
+
+func newArchiveRunner(t *testing.T, role core.StaticRole, archive *ledgerArchive, history *pulseHistory) *Runner {
+	cert := testutils.NewCertificateMock(t)
+	cert.GetRoleMock.Return(role)
+	cm := testutils.NewCertificateManagerMock(t)
+	cm.GetCertificateMock.Return(cert)
+	return &Runner{CertificateManager: cm, LedgerArchive: archive, PulseHistory: history}
+}
+
+func TestArchiveService_Get(t *testing.T) {
+	member := testutils.RandomRef()
+	request := *core.NewRecordRef(testutils.RandomID(), *core.NewRecordID(core.FirstPulseNumber+10, nil))
+	pending := *core.NewRecordRef(testutils.RandomID(), *core.NewRecordID(core.FirstPulseNumber+20, nil))
+	args, err := core.MarshalArgs(testutils.RandomRef(), "wallet.transfer", []byte("{}"), []byte{}, []byte{})
+	require.NoError(t, err)
+	call := &message.Parcel{Msg: &message.CallMethod{
+		BaseLogicMessage: message.BaseLogicMessage{APIRequest: &core.APIRequest{Member: member, QID: "q1"}},
+		ObjectRef:        member,
+		Method:           "Call",
+		Arguments:        args,
+	}}
+	var contractErr *foundation.Error
+	payload, err := core.MarshalArgs("OK", contractErr)
+	require.NoError(t, err)
+	archive := &ledgerArchive{
+		requests: map[core.RecordRef]core.Parcel{request: call, pending: call},
+		results:  map[core.RecordRef][]byte{request: payload},
+	}
+	history := &pulseHistory{result: &core.PulseHistoryResult{Pulses: []core.StoredPulse{
+		{Pulse: core.Pulse{PulseNumber: core.FirstPulseNumber + 10, PulseTimestamp: 1500000000}},
+	}}}
+
+	var rep RequestsReply
+	service := NewArchiveService(newArchiveRunner(t, core.StaticRoleVirtual, archive, history))
+	err = service.Get(&http.Request{}, &ArchiveArgs{Request: request.String()}, &rep)
+	require.Contains(t, err.Error(), "served by heavy material and observer nodes")
+
+	service = NewArchiveService(newArchiveRunner(t, core.StaticRoleHeavyMaterial, archive, history))
+	err = service.Get(&http.Request{}, &ArchiveArgs{Request: testutils.RandomRef().String()}, &rep)
+	require.Contains(t, err.Error(), "request is not registered")
+
+	require.NoError(t, service.Get(&http.Request{}, &ArchiveArgs{Request: request.String()}, &rep))
+	require.Equal(t, RequestsReply{
+		QID:     "q1",
+		Member:  member.String(),
+		Method:  "wallet.transfer",
+		Request: request.String(),
+		Result:  json.RawMessage(`"OK"`),
+		Time:    1500000000,
+	}, rep)
+
+	rep = RequestsReply{}
+	history.result = &core.PulseHistoryResult{}
+	require.NoError(t, service.Get(&http.Request{}, &ArchiveArgs{Request: pending.String()}, &rep))
+	require.Equal(t, RequestsReply{
+		QID:     "q1",
+		Member:  member.String(),
+		Method:  "wallet.transfer",
+		Request: pending.String(),
+	}, rep, "result and time are empty for pending request in unknown pulse")
+}
+
+func TestArchiveService_Result(t *testing.T) {
+	request := testutils.RandomRef()
+	var contractErr *foundation.Error
+	payload, err := core.MarshalArgs("OK", contractErr)
+	require.NoError(t, err)
+	archive := &ledgerArchive{results: map[core.RecordRef][]byte{request: payload}}
+	service := NewArchiveService(newArchiveRunner(t, core.StaticRoleObserver, archive, nil))
+
+	var rep RequestsResultReply
+	err = service.Result(&http.Request{}, &ArchiveArgs{Request: testutils.RandomRef().String()}, &rep)
+	require.Contains(t, err.Error(), "result is not registered")
+
+	require.NoError(t, service.Result(&http.Request{}, &ArchiveArgs{Request: request.String()}, &rep))
+	require.Equal(t, RequestsResultReply{Result: json.RawMessage(`"OK"`)}, rep)
+}
@@ -0,0 +1,164 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+const (
+	defaultAuditLimit = 20
+	maxAuditLimit     = 1000
+)
+
+// AuditArgs is arguments that Audit service accepts.
+type AuditArgs struct {
+	Limit int
+}
+
+// AuditRecord is a signed record of admin operation.
+type AuditRecord struct {
+	Operation string
+	Target    string
+	Reason    string
+	Remote    string
+	Error     string
+	Node      string
+	Time      int64
+	Signature string
+	Valid     bool
+}
+
+// AuditReply is reply for Audit service requests.
+type AuditReply struct {
+	Records []AuditRecord
+}
+
+// AuditService is a service that provides API for reviewing admin operations made on the node.
+type AuditService struct {
+	runner *Runner
+}
+
+// NewAuditService creates new AuditService instance.
+func NewAuditService(runner *Runner) *AuditService {
+	return &AuditService{runner: runner}
+}
+
+// List returns most recent admin operations made on the node, newest first. Request must be authorized
+// with admin token.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "audit.List",
+//	  "params": {
+//	    "Limit": int // max count of records, 20 by default
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	  Response structure:
+//	  {
+//	    "jsonrpc": "2.0",
+//	    "result": {
+//	      "Records": [
+//	        {
+//	          "Operation": str, // API method, e.g. "objects.Reset"
+//	          "Target": str, // object, jet or contract operation is applied to
+//	          "Reason": str,
+//	          "Remote": str, // address request came from
+//	          "Error": str, // empty if operation succeeded
+//	          "Node": str, // reference of node which made the record
+//	          "Time": int, // unix time of operation
+//	          "Signature": str, // base64 encoded signature of the node
+//	          "Valid": bool // whether signature matches the record
+//	        }
+//	      ]
+//	    },
+//	    "id": str|int|null // same as in request
+//	  }
+func (s *AuditService) List(r *http.Request, args *AuditArgs, reply *AuditReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ AuditService.List ] Incoming request: %s", r.RequestURI)
+
+	if err := s.runner.authorizeAdmin(r, "AuditService"); err != nil {
+		inslog.Warnf("[ AuditService.List ] unauthorized request from %s: %s", r.RemoteAddr, err)
+		return err
+	}
+
+	limit := args.Limit
+	if limit <= 0 {
+		limit = defaultAuditLimit
+	}
+	if limit > maxAuditLimit {
+		limit = maxAuditLimit
+	}
+
+	records, err := s.runner.AuditLog.GetAuditRecords(ctx, limit)
+	if err != nil {
+		return errors.Wrap(err, "[ AuditService.List ] Can't get audit records")
+	}
+
+	reply.Records = make([]AuditRecord, 0, len(records))
+	for i := range records {
+		record := &records[i]
+		reply.Records = append(reply.Records, AuditRecord{
+			Operation: record.Operation,
+			Target:    record.Target,
+			Reason:    record.Reason,
+			Remote:    record.Remote,
+			Error:     record.Error,
+			Node:      record.Node.String(),
+			Time:      record.Time.Unix(),
+			Signature: base64.StdEncoding.EncodeToString(record.Signature),
+			Valid:     s.runner.AuditLog.VerifyAuditRecord(record),
+		})
+	}
+	return nil
+}
+
+// audit records admin operation and its outcome to audit log. Operation isn't rolled back if record
+// can't be written, failure is logged.
+func (ar *Runner) audit(ctx context.Context, r *http.Request, operation, target, reason string, opErr error) {
+	if ar.AuditLog == nil {
+		return
+	}
+	record := &core.AuditRecord{
+		Operation: operation,
+		Target:    target,
+		Reason:    reason,
+		Remote:    r.RemoteAddr,
+	}
+	if opErr != nil {
+		record.Error = opErr.Error()
+	}
+	if ref := ar.CertificateManager.GetCertificate().GetNodeRef(); ref != nil {
+		record.Node = *ref
+	}
+	if err := ar.AuditLog.AppendAuditRecord(ctx, record); err != nil {
+		inslogger.FromContext(ctx).Errorf("[ audit ] failed to record %s of %s: %s", operation, target, err)
+	}
+}
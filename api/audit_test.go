@@ -0,0 +1,107 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+)
+
+type auditLog struct {
+	records []core.AuditRecord
+	limit   int
+}
+
+func (l *auditLog) AppendAuditRecord(ctx context.Context, record *core.AuditRecord) error {
+	record.Time = time.Unix(1546300800, 0)
+	record.Signature = []byte(record.Operation)
+	l.records = append([]core.AuditRecord{*record}, l.records...)
+	return nil
+}
+
+func (l *auditLog) GetAuditRecords(ctx context.Context, limit int) ([]core.AuditRecord, error) {
+	l.limit = limit
+	if limit < len(l.records) {
+		return l.records[:limit], nil
+	}
+	return l.records, nil
+}
+
+func (l *auditLog) VerifyAuditRecord(record *core.AuditRecord) bool {
+	return string(record.Signature) == record.Operation
+}
+
+func TestAuditService_List(t *testing.T) {
+	archive := &auditLog{}
+	service := NewAuditService(&Runner{cfg: &configuration.APIRunner{AdminToken: "secret"}, AuditLog: archive})
+
+	var rep AuditReply
+	err := service.List(deployRequest(""), &AuditArgs{}, &rep)
+	require.Contains(t, err.Error(), "admin token is required")
+
+	require.NoError(t, service.List(deployRequest("secret"), &AuditArgs{}, &rep))
+	require.Empty(t, rep.Records)
+	require.Equal(t, defaultAuditLimit, archive.limit)
+
+	require.NoError(t, service.List(deployRequest("secret"), &AuditArgs{Limit: maxAuditLimit + 1}, &rep))
+	require.Equal(t, maxAuditLimit, archive.limit)
+}
+
+func TestRunner_AuditAdminOperation(t *testing.T) {
+	node := testutils.RandomRef()
+	cert := testutils.NewCertificateMock(t)
+	cert.GetNodeRefMock.Return(&node)
+	cm := testutils.NewCertificateManagerMock(t)
+	cm.GetCertificateMock.Return(cert)
+
+	archive := &auditLog{}
+	resetter := &stateResetter{err: errors.New("object is being executed")}
+	runner := &Runner{
+		cfg:                &configuration.APIRunner{AdminToken: "secret"},
+		CertificateManager: cm,
+		StateResetter:      resetter,
+		AuditLog:           archive,
+	}
+	object := testutils.RandomRef()
+	r := deployRequest("secret")
+	r.RemoteAddr = "127.0.0.1:1234"
+
+	var resetReply ObjectsResetReply
+	require.Error(t, NewObjectsService(runner).Reset(r, &ObjectsResetArgs{Reference: object.String(), Reason: "stuck"}, &resetReply))
+
+	var rep AuditReply
+	require.NoError(t, NewAuditService(runner).List(r, &AuditArgs{}, &rep))
+	require.Equal(t, []AuditRecord{{
+		Operation: "objects.Reset",
+		Target:    object.String(),
+		Reason:    "stuck",
+		Remote:    "127.0.0.1:1234",
+		Error:     "object is being executed",
+		Node:      node.String(),
+		Time:      1546300800,
+		Signature: "b2JqZWN0cy5SZXNldA==",
+		Valid:     true,
+	}}, rep.Records)
+}
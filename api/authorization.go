@@ -0,0 +1,120 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/application/extractor"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/reply"
+)
+
+// roleCacheTTL is a time role of member is trusted without asking root domain again, so revoked roles
+// stop working shortly.
+const roleCacheTTL = 10 * time.Second
+
+type roleLookup func(ctx context.Context, member string) (core.APIRole, error)
+
+// methodAuthorizer checks that member calling a method has the role method requires.
+type methodAuthorizer struct {
+	roles map[string]core.APIRole
+
+	lock  sync.Mutex
+	cache map[string]cachedRole
+}
+
+type cachedRole struct {
+	role    core.APIRole
+	expires time.Time
+}
+
+func newMethodAuthorizer(roles map[string]string) (*methodAuthorizer, error) {
+	// config keys are lowercased by viper, so methods are matched case-insensitively
+	parsed := make(map[string]core.APIRole, len(roles))
+	for method, name := range roles {
+		role, err := core.ParseAPIRole(name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "bad role of method %s", method)
+		}
+		parsed[strings.ToLower(method)] = role
+	}
+	return &methodAuthorizer{
+		roles: parsed,
+		cache: map[string]cachedRole{},
+	}, nil
+}
+
+// required returns role required by method, methods without configured role require member role.
+func (a *methodAuthorizer) required(method string) core.APIRole {
+	if role, ok := a.roles[strings.ToLower(method)]; ok {
+		return role
+	}
+	return core.APIRoleMember
+}
+
+// authorize checks role of member which signature of request is already verified. Member role is granted by
+// the signature itself, more privileged roles are looked up in root domain.
+func (a *methodAuthorizer) authorize(ctx context.Context, member string, method string, lookup roleLookup) error {
+	required := a.required(method)
+	if required <= core.APIRoleMember {
+		return nil
+	}
+
+	now := time.Now()
+	a.lock.Lock()
+	cached, ok := a.cache[member]
+	a.lock.Unlock()
+	if !ok || now.After(cached.expires) {
+		role, err := lookup(ctx, member)
+		if err != nil {
+			return errors.Wrap(err, "[ authorize ] Can't get role of member")
+		}
+		cached = cachedRole{role: role, expires: now.Add(roleCacheTTL)}
+		a.lock.Lock()
+		a.cache[member] = cached
+		a.lock.Unlock()
+	}
+
+	if cached.role < required {
+		return errors.Errorf("[ authorize ] Method %s requires %s role, member has %s role", method, required, cached.role)
+	}
+	return nil
+}
+
+// getMemberRole asks root domain for role of member.
+func (ar *Runner) getMemberRole(ctx context.Context, member string) (core.APIRole, error) {
+	res, err := ar.ContractRequester.SendRequest(
+		ctx,
+		ar.CertificateManager.GetCertificate().GetRootDomainReference(),
+		"GetMemberRole",
+		[]interface{}{member},
+	)
+	if err != nil {
+		return core.APIRolePublic, errors.Wrap(err, "[ getMemberRole ] Can't get role")
+	}
+	name, err := extractor.MemberRoleResponse(res.(*reply.CallMethod).Result)
+	if err != nil {
+		return core.APIRolePublic, errors.Wrap(err, "[ getMemberRole ] Can't extract response")
+	}
+	return core.ParseAPIRole(name)
+}
@@ -0,0 +1,55 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+)
+
+func TestMethodAuthorizer(t *testing.T) {
+	a, err := newMethodAuthorizer(map[string]string{
+		"dumpallusers":  "operator",
+		"SetMemberRole": "root",
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, core.APIRoleOperator, a.required("DumpAllUsers"))
+	require.Equal(t, core.APIRoleRoot, a.required("setmemberrole"))
+	require.Equal(t, core.APIRoleMember, a.required("GetBalance"), "methods without roles require member")
+
+	lookups := 0
+	lookup := func(ctx context.Context, member string) (core.APIRole, error) {
+		lookups++
+		return core.APIRoleOperator, nil
+	}
+	ctx := context.Background()
+
+	require.NoError(t, a.authorize(ctx, "member", "GetBalance", lookup))
+	require.Equal(t, 0, lookups, "member role is granted by signature")
+
+	require.NoError(t, a.authorize(ctx, "member", "DumpAllUsers", lookup))
+	require.Error(t, a.authorize(ctx, "member", "SetMemberRole", lookup))
+	require.Equal(t, 1, lookups, "role is cached")
+
+	_, err = newMethodAuthorizer(map[string]string{"DumpAllUsers": "admin"})
+	require.Error(t, err)
+}
@@ -0,0 +1,112 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"crypto"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/application/proxy/wallet"
+	"github.com/insolar/insolar/core"
+)
+
+// BalanceProof is a balance attestation signed by API node, it is returned with GetBalance result on request.
+// Signature is made over CBOR-serialized (Member, Balance, Pulse), see BalanceProof.Verify.
+type BalanceProof struct {
+	Member    string           `json:"member"`
+	Balance   uint64           `json:"balance"`
+	Pulse     core.PulseNumber `json:"pulse"`
+	State     string           `json:"state"`
+	Node      string           `json:"node"`
+	Signature []byte           `json:"signature"`
+}
+
+// Verify checks that proof was signed with provided key of API node.
+func (p *BalanceProof) Verify(key crypto.PublicKey) error {
+	data, err := p.signedData()
+	if err != nil {
+		return errors.Wrap(err, "[ BalanceProof.Verify ]")
+	}
+	if !scheme.Verifier(key).Verify(core.SignatureFromBytes(p.Signature), data) {
+		return errors.New("[ BalanceProof.Verify ] incorrect signature")
+	}
+	return nil
+}
+
+func (p *BalanceProof) signedData() ([]byte, error) {
+	member, err := core.ParseRef(p.Member)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse member reference")
+	}
+	return core.MarshalArgs(*member, p.Balance, p.Pulse)
+}
+
+// makeBalanceProof signs balance returned by GetBalance call with node key.
+func (ar *Runner) makeBalanceProof(ctx context.Context, params Request, result interface{}) (*BalanceProof, error) {
+	var memberStr string
+	if err := core.Deserialize(params.Params, []interface{}{&memberStr}); err != nil {
+		return nil, errors.Wrap(err, "[ makeBalanceProof ] can't unmarshal params")
+	}
+	member, err := core.ParseRef(memberStr)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ makeBalanceProof ] failed to parse member reference")
+	}
+
+	var balance uint64
+	switch b := result.(type) {
+	case uint64:
+		balance = b
+	case int64:
+		balance = uint64(b)
+	default:
+		return nil, errors.Errorf("[ makeBalanceProof ] unexpected balance type %T", result)
+	}
+
+	pulse, err := ar.PulseStorage.Current(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ makeBalanceProof ] can't get current pulse")
+	}
+
+	walletRef, err := ar.ArtifactManager.GetDelegate(ctx, *member, *wallet.PrototypeReference)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ makeBalanceProof ] can't get member wallet")
+	}
+	desc, err := ar.ArtifactManager.GetObject(ctx, *walletRef, nil, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ makeBalanceProof ] can't get wallet state")
+	}
+
+	proof := &BalanceProof{
+		Member:  member.String(),
+		Balance: balance,
+		Pulse:   pulse.PulseNumber,
+		State:   desc.StateID().String(),
+		Node:    ar.NodeNetwork.GetOrigin().ID().String(),
+	}
+	data, err := proof.signedData()
+	if err != nil {
+		return nil, errors.Wrap(err, "[ makeBalanceProof ]")
+	}
+	signature, err := ar.CryptographyService.Sign(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ makeBalanceProof ] can't sign proof")
+	}
+	proof.Signature = signature.Bytes()
+	return proof, nil
+}
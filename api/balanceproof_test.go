@@ -0,0 +1,78 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/application/proxy/wallet"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/cryptography"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+	"github.com/insolar/insolar/testutils/network"
+)
+
+func TestRunner_makeBalanceProof(t *testing.T) {
+	ctx := context.Background()
+	kp := platformpolicy.NewKeyProcessor()
+	privateKey, err := kp.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	member, walletRef, nodeRef := testutils.RandomRef(), testutils.RandomRef(), testutils.RandomRef()
+	state := testutils.RandomID()
+
+	ps := testutils.NewPulseStorageMock(t)
+	ps.CurrentMock.Return(&core.Pulse{PulseNumber: core.FirstPulseNumber + 1}, nil)
+	desc := testutils.NewObjectDescriptorMock(t)
+	desc.StateIDMock.Return(&state)
+	am := testutils.NewArtifactManagerMock(t)
+	am.GetDelegateMock.Expect(ctx, member, *wallet.PrototypeReference).Return(&walletRef, nil)
+	am.GetObjectMock.Expect(ctx, walletRef, nil, false).Return(desc, nil)
+	node := network.NewNodeMock(t)
+	node.IDMock.Return(nodeRef)
+	nn := network.NewNodeNetworkMock(t)
+	nn.GetOriginMock.Return(node)
+
+	runner := &Runner{
+		PulseStorage:        ps,
+		ArtifactManager:     am,
+		NodeNetwork:         nn,
+		CryptographyService: cryptography.NewKeyBoundCryptographyService(privateKey),
+	}
+
+	params, err := core.MarshalArgs(member.String())
+	require.NoError(t, err)
+	proof, err := runner.makeBalanceProof(ctx, Request{Method: "GetBalance", Params: params}, uint64(1000))
+	require.NoError(t, err)
+
+	require.Equal(t, member.String(), proof.Member)
+	require.Equal(t, uint64(1000), proof.Balance)
+	require.Equal(t, core.PulseNumber(core.FirstPulseNumber+1), proof.Pulse)
+	require.Equal(t, state.String(), proof.State)
+	require.Equal(t, nodeRef.String(), proof.Node)
+	require.NoError(t, proof.Verify(kp.ExtractPublicKey(privateKey)))
+
+	proof.Balance++
+	require.Error(t, proof.Verify(kp.ExtractPublicKey(privateKey)))
+
+	_, err = runner.makeBalanceProof(ctx, Request{Method: "GetBalance", Params: params}, "1000")
+	require.Error(t, err)
+}
@@ -0,0 +1,93 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// ErrBodyTooLarge is returned when request body exceeds configured MaxBodySize.
+var ErrBodyTooLarge = errors.New("request body is too large")
+
+// limitedBody fails reading of request body with ErrBodyTooLarge as soon as more than left bytes are read,
+// so oversized requests without Content-Length are rejected without buffering them.
+type limitedBody struct {
+	io.ReadCloser
+	left int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.left < 0 {
+		return 0, ErrBodyTooLarge
+	}
+	if int64(len(p)) > b.left+1 {
+		p = p[:b.left+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	if int64(n) > b.left {
+		n = int(b.left)
+		b.left = -1
+		return n, ErrBodyTooLarge
+	}
+	b.left -= int64(n)
+	return n, err
+}
+
+// limitBody limits size of request body passed to handler. Requests declaring too large Content-Length
+// are rejected right away.
+func (ar *Runner) limitBody(handler http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(response http.ResponseWriter, req *http.Request) {
+		max := ar.cfg.MaxBodySize
+		if max <= 0 {
+			handler(response, req)
+			return
+		}
+		if req.ContentLength > max {
+			writeBodyTooLarge(response, max)
+			return
+		}
+		req.Body = &limitedBody{ReadCloser: req.Body, left: max}
+		handler(response, req)
+	})
+}
+
+func writeBodyTooLarge(response http.ResponseWriter, max int64) {
+	traceID := utils.RandTraceID()
+	_, insLog := inslogger.WithTraceField(context.Background(), traceID)
+
+	resp := answer{
+		Error:   errors.Wrapf(ErrBodyTooLarge, "[ limitBody ] max size is %d bytes", max).Error(),
+		TraceID: traceID,
+	}
+	res, err := json.MarshalIndent(resp, "", "    ")
+	if err != nil {
+		res = []byte(`{"error": "can't marshal answer to json'"}`)
+	}
+	response.Header().Add("Content-Type", "application/json")
+	response.WriteHeader(http.StatusRequestEntityTooLarge)
+	if _, err = response.Write(res); err != nil {
+		insLog.Errorf("Can't write response\n")
+	}
+}
@@ -0,0 +1,70 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+)
+
+func TestRunner_limitBody(t *testing.T) {
+	ar := &Runner{cfg: &configuration.APIRunner{MaxBodySize: 16}}
+	handler := ar.limitBody(ar.callHandler())
+	body := []byte(`{"reference": "` + strings.Repeat("1", 32) + `"}`)
+
+	t.Run("declared length", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/call", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+		var resp answer
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		require.Contains(t, resp.Error, ErrBodyTooLarge.Error())
+		require.NotEmpty(t, resp.TraceID)
+	})
+
+	t.Run("streamed body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/call", bytes.NewReader(body))
+		req.ContentLength = -1
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+		var resp answer
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		require.Contains(t, resp.Error, ErrBodyTooLarge.Error())
+	})
+}
+
+func TestLimitedBody_Read(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("12345"))
+
+	var params interface{}
+	req.Body = &limitedBody{ReadCloser: req.Body, left: 5}
+	err := UnmarshalRequest(req, &params)
+	require.NoError(t, err, "body of exactly max size is accepted")
+	require.Equal(t, float64(12345), params)
+}
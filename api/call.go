@@ -19,8 +19,9 @@ package api
 import (
 	"context"
 	"encoding/json"
-	"io/ioutil"
+	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/insolar/insolar/api/seedmanager"
@@ -31,6 +32,7 @@ import (
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/instrumentation/instracer"
 	"github.com/insolar/insolar/metrics"
+	"github.com/insolar/insolar/netparams"
 	"github.com/insolar/insolar/platformpolicy"
 
 	"github.com/pkg/errors"
@@ -45,29 +47,67 @@ type Request struct {
 	Params    []byte `json:"params"`
 	Seed      []byte `json:"seed"`
 	Signature []byte `json:"signature"`
+	QID       string `json:"qid,omitempty"`
+	// Proof requests signed BalanceProof for GetBalance calls.
+	Proof bool `json:"proof,omitempty"`
+	// Session is a token of conversational session, related calls of session reuse cached object descriptors.
+	// Any non-empty value which isn't an active token (e.g. "new") starts new session.
+	Session string `json:"session,omitempty"`
+	// Fields are dot separated paths of answer fields to return, e.g. "result.balance", all fields by default.
+	Fields []string `json:"fields,omitempty"`
+	// Compact disables indentation of answer.
+	Compact bool `json:"compact,omitempty"`
+	// Priority marks call as latency-sensitive, it's queued for execution and delivered ahead of regular calls
+	// while member doesn't exceed its quota of prioritized calls per pulse.
+	Priority bool `json:"priority,omitempty"`
+	// Async makes call to be executed in background, job ID is returned immediately and result is polled
+	// with jobs.Get. It's meant for long operations like "DumpAllUsers" which exceed timeouts of requests.
+	Async bool `json:"async,omitempty"`
 }
 
 type answer struct {
-	Error   string      `json:"error,omitempty"`
-	Result  interface{} `json:"result,omitempty"`
-	TraceID string      `json:"traceID,omitempty"`
+	Error   string        `json:"error,omitempty"`
+	Result  interface{}   `json:"result,omitempty"`
+	TraceID string        `json:"traceID,omitempty"`
+	Proof   *BalanceProof `json:"proof,omitempty"`
+	// Request is a reference of registered request, it's used to query finality of result later.
+	Request string `json:"request,omitempty"`
+	// Pulse is a pulse number of execution.
+	Pulse uint32 `json:"pulse,omitempty"`
+	// Finality is a finality of result at the moment of reply.
+	Finality string `json:"finality,omitempty"`
+	// Busy is set when request was rejected because of concurrency limit of method or because node is overloaded,
+	// client should retry later.
+	Busy bool `json:"busy,omitempty"`
+	// Paused is set when mutating request was rejected because network is paused by root authority.
+	Paused bool `json:"paused,omitempty"`
+	// Session is a token of session call belongs to, client should pass it with next calls of session
+	// to the same API node.
+	Session string `json:"session,omitempty"`
+	// ErrorCode is a code of error (see core.ErrorCode), it's set for errors which can be classified.
+	ErrorCode core.ErrorCode `json:"errorCode,omitempty"`
+	// Retryable is set when call failed because of transient error, client may retry the same call later.
+	Retryable bool `json:"retryable,omitempty"`
+	// Prioritized is set when call requested as latency-sensitive was prioritized, calls over quota are
+	// executed as regular ones.
+	Prioritized bool `json:"prioritized,omitempty"`
+	// Job is an ID of job of asynchronous call, its progress and result are polled with jobs.Get.
+	Job string `json:"job,omitempty"`
 }
 
-// UnmarshalRequest unmarshals request to api
-func UnmarshalRequest(req *http.Request, params interface{}) ([]byte, error) {
-	body, err := ioutil.ReadAll(req.Body)
-	if err != nil {
-		return nil, errors.Wrap(err, "[ UnmarshalRequest ] Can't read body. So strange")
+// UnmarshalRequest unmarshals request to api decoding body as a stream.
+func UnmarshalRequest(req *http.Request, params interface{}) error {
+	err := json.NewDecoder(req.Body).Decode(params)
+	if err == io.EOF {
+		return errors.New("[ UnmarshalRequest ] Empty body")
 	}
-	if len(body) == 0 {
-		return nil, errors.New("[ UnmarshalRequest ] Empty body")
+	if err == ErrBodyTooLarge {
+		return errors.Wrap(err, "[ UnmarshalRequest ] Can't read body")
 	}
-
-	err = json.Unmarshal(body, &params)
 	if err != nil {
-		return body, errors.Wrap(err, "[ UnmarshalRequest ] Can't unmarshal input params")
+		return errors.Wrap(err, "[ UnmarshalRequest ] Can't unmarshal input params")
 	}
-	return body, nil
+	return nil
 }
 
 func (ar *Runner) verifySignature(ctx context.Context, params Request) error {
@@ -78,7 +118,7 @@ func (ar *Runner) verifySignature(ctx context.Context, params Request) error {
 	if key == "" {
 		return errors.New("[ VerifySignature ] Not found public key for this member")
 	}
-	ref, err := core.NewRefFromBase58(params.Reference)
+	ref, err := core.ParseRef(params.Reference)
 	if err != nil {
 		return errors.Wrap(err, "[ VerifySignature ] failed to parse params.Reference")
 	}
@@ -92,8 +132,14 @@ func (ar *Runner) verifySignature(ctx context.Context, params Request) error {
 		return errors.Wrap(err, "[ VerifySignature ] Can't marshal arguments for verify signature")
 	}
 	verifier := scheme.Verifier(key)
+	if platformpolicy.IsSecp256k1Key(key) {
+		verifier = platformpolicy.NewEthereumVerifier(key)
+	}
 	verified := verifier.Verify(core.SignatureFromBytes(params.Signature), args)
 	if !verified {
+		if address, err := platformpolicy.EthereumAddress(key); err == nil {
+			return errors.Errorf("[ VerifySignature ] Incorrect signature, request must be signed by wallet %s", address)
+		}
 		return errors.New("[ VerifySignature ] Incorrect signature")
 	}
 	return nil
@@ -112,15 +158,35 @@ func (ar *Runner) checkSeed(paramsSeed []byte) error {
 	return nil
 }
 
-func (ar *Runner) makeCall(ctx context.Context, params Request) (interface{}, error) {
+func (ar *Runner) makeCall(ctx context.Context, params Request) (result interface{}, rep *reply.CallMethod, err error) {
 	ctx, span := instracer.StartSpan(ctx, "SendRequest "+params.Method)
 	defer span.End()
 
-	reference, err := core.NewRefFromBase58(params.Reference)
+	reference, err := core.ParseRef(params.Reference)
 	if err != nil {
-		return nil, errors.Wrap(err, "[ makeCall ] failed to parse params.Reference")
+		return nil, nil, errors.Wrap(err, "[ makeCall ] failed to parse params.Reference")
+	}
+
+	apiRequest := ar.makeAPIRequest(ctx, *reference, params.QID)
+	apiRequest.Session = params.Session
+	apiRequest.Priority = params.Priority
+	apiRequest.Nonce = params.Seed
+	ctx = core.ContextWithAPIRequest(ctx, apiRequest)
+	if ar.hints != nil {
+		ctx = core.ContextWithRoutingHints(ctx, ar.hints)
 	}
 
+	ar.Timeline.Record(ctx, core.TimelineEvent{Stage: core.TimelineAPIReceived, QID: apiRequest.QID})
+	var request *core.RecordRef
+	defer func() {
+		event := core.TimelineEvent{Stage: core.TimelineAPIReplied, QID: apiRequest.QID, Request: request}
+		if err != nil {
+			event.Error = err.Error()
+		}
+		ar.Timeline.Record(ctx, event)
+		ar.archiveOutcome(ctx, apiRequest, params.Method, request, result, err)
+	}()
+
 	res, err := ar.ContractRequester.SendRequest(
 		ctx,
 		reference,
@@ -129,27 +195,93 @@ func (ar *Runner) makeCall(ctx context.Context, params Request) (interface{}, er
 	)
 
 	if err != nil {
-		return nil, errors.Wrap(err, "[ makeCall ] Can't send request")
+		return nil, nil, errors.Wrap(err, "[ makeCall ] Can't send request")
 	}
 
-	result, contractErr, err := extractor.CallResponse(res.(*reply.CallMethod).Result)
+	rep = res.(*reply.CallMethod)
+	request = &rep.Request
+	result, contractErr, err := extractor.CallResponse(rep.Result)
 
 	if err != nil {
-		return nil, errors.Wrap(err, "[ makeCall ] Can't extract response")
+		return nil, nil, errors.Wrap(err, "[ makeCall ] Can't extract response")
 	}
 
 	if contractErr != nil {
-		return nil, errors.Wrap(errors.New(contractErr.S), "[ makeCall ] Error in called method")
+		return nil, nil, errors.Wrap(errors.New(contractErr.S), "[ makeCall ] Error in called method")
+	}
+
+	return result, rep, nil
+}
+
+// archiveOutcome saves outcome of API request so client can query it by QID later.
+func (ar *Runner) archiveOutcome(
+	ctx context.Context, apiRequest *core.APIRequest, method string, request *core.RecordRef, result interface{}, callErr error,
+) {
+	outcome := &core.APIRequestOutcome{
+		QID:     apiRequest.QID,
+		Member:  apiRequest.Member,
+		Method:  method,
+		Request: request,
+		Time:    time.Now(),
+	}
+	if callErr != nil {
+		outcome.Error = callErr.Error()
+	} else {
+		var err error
+		outcome.Result, err = json.Marshal(result)
+		if err != nil {
+			inslogger.FromContext(ctx).Error(errors.Wrap(err, "[ archiveOutcome ] Can't marshal result"))
+			return
+		}
+	}
+	err := ar.APIRequestArchive.SetAPIRequestOutcome(ctx, outcome)
+	if err != nil {
+		inslogger.FromContext(ctx).Error(errors.Wrap(err, "[ archiveOutcome ] Can't archive outcome"))
 	}
+}
 
-	return result, nil
+// makeAPIRequest makes metadata of API request passed to contracts. Trace id is used as query id if client didn't provide one.
+func (ar *Runner) makeAPIRequest(ctx context.Context, member core.RecordRef, qid string) *core.APIRequest {
+	traceID := inslogger.TraceID(ctx)
+	if qid == "" {
+		qid = traceID
+	}
+	return &core.APIRequest{
+		Member:  member,
+		QID:     qid,
+		APINode: ar.NodeNetwork.GetOrigin().ID(),
+		TraceID: traceID,
+	}
 }
 
 func processError(err error, extraMsg string, resp *answer, insLog core.Logger) {
 	resp.Error = err.Error()
+	resp.ErrorCode = core.ErrorCodeOf(err)
+	resp.Retryable = core.IsRetryable(err)
 	insLog.Error(errors.Wrapf(err, "[ CallHandler ] %s", extraMsg))
 }
 
+// callTimeout returns timeout of calls, network-wide parameter overrides local configuration.
+func (ar *Runner) callTimeout() time.Duration {
+	return netparams.Duration(ar.NetworkParameters, core.NetworkParameterCallTimeout, time.Duration(ar.cfg.Timeout)*time.Second)
+}
+
+// allowedOnPause reports whether member method may be called while network is paused. Only methods
+// which don't change state are allowed, and network parameters may be set so root member can lift the pause.
+func (ar *Runner) allowedOnPause(method string) bool {
+	return method == "SetNetworkParameter" || ar.readOnly(method)
+}
+
+// readOnly reports whether member method doesn't change state.
+func (ar *Runner) readOnly(method string) bool {
+	for _, m := range ar.cfg.ReadOnlyMethods {
+		if strings.EqualFold(m, method) {
+			return true
+		}
+	}
+	return false
+}
+
 func (ar *Runner) callHandler() func(http.ResponseWriter, *http.Request) {
 	return func(response http.ResponseWriter, req *http.Request) {
 		traceID := utils.RandTraceID()
@@ -160,6 +292,7 @@ func (ar *Runner) callHandler() func(http.ResponseWriter, *http.Request) {
 
 		params := Request{}
 		resp := answer{}
+		status := http.StatusOK
 
 		startTime := time.Now()
 		defer func() {
@@ -175,23 +308,34 @@ func (ar *Runner) callHandler() func(http.ResponseWriter, *http.Request) {
 		insLog.Infof("[ callHandler ] Incoming request: %s", req.RequestURI)
 
 		defer func() {
-			res, err := json.MarshalIndent(resp, "", "    ")
+			res, err := requestFormat(req, &params).marshal(resp)
 			if err != nil {
 				res = []byte(`{"error": "can't marshal answer to json'"}`)
 			}
 			response.Header().Add("Content-Type", "application/json")
+			response.WriteHeader(status)
 			_, err = response.Write(res)
 			if err != nil {
 				insLog.Errorf("Can't write response\n")
 			}
 		}()
 
-		_, err := UnmarshalRequest(req, &params)
+		err := UnmarshalRequest(req, &params)
 		if err != nil {
+			if errors.Cause(err) == ErrBodyTooLarge {
+				status = http.StatusRequestEntityTooLarge
+			}
 			processError(err, "Can't unmarshal request", &resp, insLog)
 			return
 		}
 
+		_, err = core.ParseRef(params.Reference)
+		if err != nil {
+			status = http.StatusBadRequest
+			processError(err, "Bad reference", &resp, insLog)
+			return
+		}
+
 		err = ar.checkSeed(params.Seed)
 		if err != nil {
 			processError(err, "Can't checkSeed", &resp, insLog)
@@ -204,10 +348,68 @@ func (ar *Runner) callHandler() func(http.ResponseWriter, *http.Request) {
 			return
 		}
 
+		err = ar.authorizer.authorize(ctx, params.Reference, params.Method, ar.getMemberRole)
+		if err != nil {
+			status = http.StatusForbidden
+			processError(err, "Method is not allowed", &resp, insLog)
+			return
+		}
+
+		if reason, paused := netparams.Paused(ar.NetworkParameters); paused && !ar.allowedOnPause(params.Method) {
+			status = http.StatusServiceUnavailable
+			resp.Paused = true
+			processError(errors.Errorf("network is paused: %s", reason), "Method is paused", &resp, insLog)
+			return
+		}
+
+		if params.Session != "" {
+			// reference is already checked on unmarshal
+			member, _ := core.ParseRef(params.Reference)
+			params.Session = ar.sessions.resolve(*member, params.Session)
+			resp.Session = params.Session
+		}
+
+		if params.Priority {
+			member, _ := core.ParseRef(params.Reference)
+			params.Priority = ar.prioritize(ctx, *member)
+			resp.Prioritized = params.Priority
+		}
+
+		if ar.readOnly(params.Method) && !ar.admit(req, params.Method) {
+			status = http.StatusServiceUnavailable
+			resp.Busy = true
+			response.Header().Set("Retry-After", shedRetryAfter)
+			processError(errors.New("node is overloaded, read-only calls are shed"), "Method is shed", &resp, insLog)
+			return
+		}
+
+		if params.Async {
+			resp.Job, err = ar.submitCall(ctx, params)
+			if err == errJobQueueFull {
+				status = http.StatusServiceUnavailable
+				resp.Busy = true
+			}
+			if err != nil {
+				processError(err, "Can't submit job", &resp, insLog)
+				return
+			}
+			status = http.StatusAccepted
+			return
+		}
+
+		if !ar.limiter.acquire(params.Method) {
+			status = http.StatusServiceUnavailable
+			resp.Busy = true
+			processError(errors.Errorf("too many concurrent calls of %s", params.Method), "Method is busy", &resp, insLog)
+			return
+		}
+
 		var result interface{}
+		var rep *reply.CallMethod
 		ch := make(chan interface{}, 1)
 		go func() {
-			result, err = ar.makeCall(ctx, params)
+			defer ar.limiter.release(params.Method)
+			result, rep, err = ar.makeCall(ctx, params)
 			ch <- nil
 		}()
 		select {
@@ -218,8 +420,23 @@ func (ar *Runner) callHandler() func(http.ResponseWriter, *http.Request) {
 				return
 			}
 			resp.Result = result
+			resp.Request = rep.Request.String()
+			resp.Pulse = uint32(rep.Pulse)
+			resp.Finality = rep.Finality.String()
+
+			if params.Method == "Transfer" && result == nil {
+				go ar.pushTransferReceipt(ctx, params, rep)
+			}
+
+			if params.Proof && params.Method == "GetBalance" {
+				resp.Proof, err = ar.makeBalanceProof(ctx, params, result)
+				if err != nil {
+					processError(err, "Can't make balance proof", &resp, insLog)
+					return
+				}
+			}
 
-		case <-time.After(time.Duration(ar.cfg.Timeout) * time.Second):
+		case <-time.After(ar.limiter.timeout(params.Method, ar.callTimeout())):
 			resp.Error = "Messagebus timeout exceeded"
 			return
 
@@ -28,9 +28,13 @@ import (
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/core/reply"
 	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/instrumentation/timeline"
 	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
+	"github.com/insolar/insolar/netparams"
+	"github.com/insolar/insolar/network/nodenetwork"
 	"github.com/insolar/insolar/platformpolicy"
 	"github.com/insolar/insolar/testutils"
+	"github.com/insolar/insolar/testutils/network"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
@@ -43,15 +47,21 @@ type TimeoutSuite struct {
 	api   *Runner
 	user  *requester.UserConfigJSON
 	delay bool
+
+	origin     core.RecordRef
+	apiRequest *core.APIRequest
+	archive    *requestArchive
 }
 
 type APIresp struct {
-	Result string
-	Error  string
+	Result   string
+	Error    string
+	Pulse    uint32
+	Finality string
 }
 
 func (suite *TimeoutSuite) TestRunner_callHandler() {
-	seed, err := suite.api.SeedGenerator.Next()
+	seed, err := suite.api.SeedGenerator.Next(core.FirstPulseNumber, suite.origin)
 	suite.NoError(err)
 	suite.api.SeedManager.Add(*seed)
 
@@ -69,10 +79,25 @@ func (suite *TimeoutSuite) TestRunner_callHandler() {
 	suite.NoError(err)
 	suite.Equal("", result.Error)
 	suite.Equal("OK", result.Result)
+	suite.Equal(uint32(core.FirstPulseNumber), result.Pulse)
+	suite.Equal("executed", result.Finality)
+
+	suite.Require().NotNil(suite.apiRequest)
+	suite.Equal(suite.user.Caller, suite.apiRequest.Member.String())
+	suite.Equal(suite.origin, suite.apiRequest.APINode)
+	suite.NotEmpty(suite.apiRequest.TraceID)
+	suite.Equal(suite.apiRequest.TraceID, suite.apiRequest.QID)
+
+	outcome, err := suite.archive.GetAPIRequestOutcome(suite.ctx, suite.apiRequest.QID)
+	suite.NoError(err)
+	suite.Require().NotNil(outcome)
+	suite.Equal(suite.apiRequest.Member, outcome.Member)
+	suite.Equal(`"OK"`, string(outcome.Result))
+	suite.Empty(outcome.Error)
 }
 
 func (suite *TimeoutSuite) TestRunner_callHandlerTimeout() {
-	seed, err := suite.api.SeedGenerator.Next()
+	seed, err := suite.api.SeedGenerator.Next(core.FirstPulseNumber, suite.origin)
 	suite.NoError(err)
 	suite.api.SeedManager.Add(*seed)
 
@@ -137,6 +162,7 @@ func TestTimeoutSuite(t *testing.T) {
 				Result: data,
 			}, nil
 		default:
+			timeoutSuite.apiRequest = core.APIRequestFromContext(p)
 			if timeoutSuite.delay {
 				time.Sleep(time.Second * 21)
 			}
@@ -144,13 +170,29 @@ func TestTimeoutSuite(t *testing.T) {
 			var contractErr *foundation.Error
 			data, _ := core.MarshalArgs(result, contractErr)
 			return &reply.CallMethod{
-				Result: data,
+				Result:   data,
+				Pulse:    core.FirstPulseNumber,
+				Finality: core.FinalityExecuted,
 			}, nil
 		}
 	}
 
+	timeoutSuite.origin = testutils.RandomRef()
+	nk := network.NewNodeKeeperMock(t)
+	nk.GetOriginMock.Return(nodenetwork.NewNode(timeoutSuite.origin, core.StaticRoleVirtual, nil, "127.0.0.1:1", ""))
+
 	timeoutSuite.api.ContractRequester = cr
 	timeoutSuite.api.CertificateManager = cm
+	timeoutSuite.api.NodeNetwork = nk
+	timeoutSuite.api.Timeline = timeline.NewJournal(configuration.NewTimeline())
+	timeoutSuite.archive = newRequestArchive()
+	timeoutSuite.api.APIRequestArchive = timeoutSuite.archive
+	timeoutSuite.api.NetworkParameters = netparams.New()
+
+	mb := testutils.NewMessageBusMock(t)
+	mb.MustRegisterMock.Return()
+	timeoutSuite.api.MessageBus = mb
+	timeoutSuite.api.NodeMessenger = &nodeMessenger{}
 	timeoutSuite.api.Start(timeoutSuite.ctx)
 
 	requester.SetTimeout(25)
@@ -158,3 +200,14 @@ func TestTimeoutSuite(t *testing.T) {
 
 	timeoutSuite.api.Stop(timeoutSuite.ctx)
 }
+
+func TestRunner_allowedOnPause(t *testing.T) {
+	cfg := configuration.NewAPIRunner()
+	ar := &Runner{cfg: &cfg}
+
+	require.True(t, ar.allowedOnPause("GetBalance"))
+	require.True(t, ar.allowedOnPause("getbalance"), "methods are case-insensitive")
+	require.True(t, ar.allowedOnPause("SetNetworkParameter"), "root member must be able to lift pause")
+	require.False(t, ar.allowedOnPause("Transfer"))
+	require.False(t, ar.allowedOnPause("CreateMember"))
+}
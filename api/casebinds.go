@@ -0,0 +1,136 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
+)
+
+// CaseBindsArgs is arguments that CaseBinds service accepts.
+type CaseBindsArgs struct {
+	Object string
+	Pulse  uint32
+}
+
+// CaseBindRequest is request of case bind with its result and outgoing calls.
+type CaseBindRequest struct {
+	Request     string
+	MessageType string
+	Message     []byte
+	Reply       []byte
+	Error       string
+	Validate    bool
+	Tape        []byte
+}
+
+// CaseBindsReply is reply for CaseBinds service requests.
+type CaseBindsReply struct {
+	Version  int
+	Object   string
+	Pulse    uint32
+	Executor string
+	Requests []CaseBindRequest
+}
+
+// CaseBindsService is a service that exports execution registries for external validators.
+type CaseBindsService struct {
+	runner *Runner
+}
+
+// NewCaseBindsService creates new CaseBindsService instance.
+func NewCaseBindsService(runner *Runner) *CaseBindsService {
+	return &CaseBindsService{runner: runner}
+}
+
+// Export returns case bind of object executed by the node in pulse in portable format.
+// Binary fields are base64 encoded.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "casebinds.Export",
+//	  "params": {
+//	    "Object": str, // reference of executed object
+//	    "Pulse": int // pulse object was executed in
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	  Response structure:
+//	  {
+//	    "jsonrpc": "2.0",
+//	    "result": {
+//	      "Version": int, // version of case bind format
+//	      "Object": str,
+//	      "Pulse": int,
+//	      "Executor": str, // reference of executor node
+//	      "Requests": [
+//	        {
+//	          "Request": str, // reference of request
+//	          "MessageType": str,
+//	          "Message": str, // serialized request message
+//	          "Reply": str, // serialized reply, empty on error
+//	          "Error": str,
+//	          "Validate": bool, // whether request was chosen for validation
+//	          "Tape": str // recorded outgoing calls, empty if not recorded
+//	        }
+//	      ]
+//	    },
+//	    "id": str|int|null // same as in request
+//	  }
+func (s *CaseBindsService) Export(r *http.Request, args *CaseBindsArgs, reply *CaseBindsReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ CaseBindsService.Export ] Incoming request: %s", r.RequestURI)
+
+	object, err := core.NewRefFromBase58(args.Object)
+	if err != nil {
+		return errors.Wrap(err, "[ CaseBindsService.Export ] Can't parse object reference")
+	}
+
+	bind, err := s.runner.CaseBinds.ExportCaseBind(ctx, *object, core.PulseNumber(args.Pulse))
+	if err != nil {
+		return errors.Wrap(err, "[ CaseBindsService.Export ] Can't export case bind")
+	}
+	if bind == nil {
+		return errors.Errorf("[ CaseBindsService.Export ] No case bind of object %s in pulse %d", args.Object, args.Pulse)
+	}
+
+	reply.Version = bind.Version
+	reply.Object = bind.Object.String()
+	reply.Pulse = uint32(bind.Pulse)
+	reply.Executor = bind.Executor.String()
+	reply.Requests = make([]CaseBindRequest, 0, len(bind.Requests))
+	for _, req := range bind.Requests {
+		reply.Requests = append(reply.Requests, CaseBindRequest{
+			Request:     req.Request.String(),
+			MessageType: req.MessageType,
+			Message:     req.Message,
+			Reply:       req.Reply,
+			Error:       req.Error,
+			Validate:    req.Validate,
+			Tape:        req.Tape,
+		})
+	}
+	return nil
+}
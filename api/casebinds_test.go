@@ -0,0 +1,87 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+)
+
+type caseBindExporter struct {
+	binds map[core.RecordRef]*core.PortableCaseBind
+}
+
+func (e *caseBindExporter) ExportCaseBind(
+	ctx context.Context, object core.RecordRef, pulse core.PulseNumber,
+) (*core.PortableCaseBind, error) {
+	bind, ok := e.binds[object]
+	if !ok || bind.Pulse != pulse {
+		return nil, nil
+	}
+	return bind, nil
+}
+
+func TestCaseBindsService_Export(t *testing.T) {
+	object, executor, request := testutils.RandomRef(), testutils.RandomRef(), testutils.RandomRef()
+	exporter := &caseBindExporter{binds: map[core.RecordRef]*core.PortableCaseBind{
+		object: {
+			Version:  core.CaseBindFormatVersion,
+			Object:   object,
+			Pulse:    core.FirstPulseNumber,
+			Executor: executor,
+			Requests: []core.PortableCaseRequest{{
+				Request:     request,
+				MessageType: "TypeCallMethod",
+				Message:     []byte{1, 2},
+				Reply:       []byte{3},
+				Validate:    true,
+				Tape:        []byte{4},
+			}},
+		},
+	}}
+	service := NewCaseBindsService(&Runner{CaseBinds: exporter})
+
+	var rep CaseBindsReply
+	err := service.Export(&http.Request{}, &CaseBindsArgs{Object: "bad"}, &rep)
+	require.Error(t, err)
+
+	err = service.Export(&http.Request{}, &CaseBindsArgs{Object: object.String(), Pulse: uint32(core.FirstPulseNumber) + 1}, &rep)
+	require.Error(t, err)
+
+	err = service.Export(&http.Request{}, &CaseBindsArgs{Object: object.String(), Pulse: uint32(core.FirstPulseNumber)}, &rep)
+	require.NoError(t, err)
+	require.Equal(t, CaseBindsReply{
+		Version:  core.CaseBindFormatVersion,
+		Object:   object.String(),
+		Pulse:    uint32(core.FirstPulseNumber),
+		Executor: executor.String(),
+		Requests: []CaseBindRequest{{
+			Request:     request.String(),
+			MessageType: "TypeCallMethod",
+			Message:     []byte{1, 2},
+			Reply:       []byte{3},
+			Validate:    true,
+			Tape:        []byte{4},
+		}},
+	}, rep)
+}
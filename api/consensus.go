@@ -0,0 +1,205 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// DurationPercentiles are percentiles of durations in milliseconds.
+type DurationPercentiles struct {
+	Samples int
+	P50     float64
+	P90     float64
+	P99     float64
+	Max     float64
+}
+
+// PhaseTimings is a time profile of consensus phase over recent pulses.
+type PhaseTimings struct {
+	Phase   string
+	Send    DurationPercentiles
+	Wait    DurationPercentiles
+	Process DurationPercentiles
+}
+
+// ConsensusPhasesReply is reply for Consensus.Phases request.
+type ConsensusPhasesReply struct {
+	Phases []PhaseTimings
+}
+
+// PeerRTT is round trip time to consensus participant in milliseconds.
+type PeerRTT struct {
+	Node    string
+	Address string
+	Samples int
+	Min     float64
+	P50     float64
+	Max     float64
+}
+
+// RTTSummary is a summary of round trip times to consensus participants measured during pulse.
+type RTTSummary struct {
+	Pulse uint32
+	Peers []PeerRTT
+	P50   float64
+	P90   float64
+	Max   float64
+}
+
+// ConsensusRTTReply is reply for Consensus.RTT request.
+type ConsensusRTTReply struct {
+	Pulses []RTTSummary
+}
+
+// ConsensusService is a service that provides API for profiling of consensus.
+type ConsensusService struct {
+	runner *Runner
+}
+
+// NewConsensusService creates new ConsensusService instance.
+func NewConsensusService(runner *Runner) *ConsensusService {
+	return &ConsensusService{runner: runner}
+}
+
+// Phases returns percentiles of consensus phases durations over recent pulses.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "consensus.Phases",
+//	  "params": {},
+//	  "id": str|int|null
+//	}
+//
+//	  Response structure:
+//	  {
+//	    "jsonrpc": "2.0",
+//	    "result": {
+//	      "Phases": [{
+//	        "Phase": str, // name of phase: phase1, phase2, phase21 or phase3
+//	        "Send": { // duration of sending phase packets to all participants
+//	          "Samples": int, // number of pulses percentiles are calculated over
+//	          "P50": float, // milliseconds
+//	          "P90": float,
+//	          "P99": float,
+//	          "Max": float
+//	        },
+//	        "Wait": {...}, // duration until the last packet from participants is received
+//	        "Process": {...} // duration of phase without packets exchange
+//	      }]
+//	    },
+//	    "id": str|int|null // same as in request
+//	  }
+func (s *ConsensusService) Phases(r *http.Request, args *struct{}, reply *ConsensusPhasesReply) error {
+	_, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ ConsensusService.Phases ] Incoming request: %s", r.RequestURI)
+
+	timings := s.runner.ConsensusProfiler.GetPhaseTimings()
+	reply.Phases = make([]PhaseTimings, len(timings))
+	for i, t := range timings {
+		reply.Phases[i] = PhaseTimings{
+			Phase:   t.Phase,
+			Send:    toMilliseconds(t.Send),
+			Wait:    toMilliseconds(t.Wait),
+			Process: toMilliseconds(t.Process),
+		}
+	}
+	return nil
+}
+
+// RTT returns round trip times to consensus participants measured during recent pulses.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "consensus.RTT",
+//	  "params": {},
+//	  "id": str|int|null
+//	}
+//
+//	  Response structure:
+//	  {
+//	    "jsonrpc": "2.0",
+//	    "result": {
+//	      "Pulses": [{ // the latest pulse is the last
+//	        "Pulse": int,
+//	        "Peers": [{
+//	          "Node": str, // reference of participant
+//	          "Address": str,
+//	          "Samples": int, // number of request/response exchanges measured
+//	          "Min": float, // milliseconds
+//	          "P50": float,
+//	          "Max": float
+//	        }],
+//	        "P50": float, // percentiles of medians of round trip times to participants in milliseconds
+//	        "P90": float,
+//	        "Max": float
+//	      }]
+//	    },
+//	    "id": str|int|null // same as in request
+//	  }
+func (s *ConsensusService) RTT(r *http.Request, args *struct{}, reply *ConsensusRTTReply) error {
+	_, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ ConsensusService.RTT ] Incoming request: %s", r.RequestURI)
+
+	summaries := s.runner.RTT.GetRTT()
+	reply.Pulses = make([]RTTSummary, len(summaries))
+	for i, summary := range summaries {
+		peers := make([]PeerRTT, len(summary.Peers))
+		for j, peer := range summary.Peers {
+			peers[j] = PeerRTT{
+				Node:    peer.Node.String(),
+				Address: peer.Address,
+				Samples: peer.Samples,
+				Min:     ms(peer.Min),
+				P50:     ms(peer.P50),
+				Max:     ms(peer.Max),
+			}
+		}
+		reply.Pulses[i] = RTTSummary{
+			Pulse: uint32(summary.Pulse),
+			Peers: peers,
+			P50:   ms(summary.P50),
+			P90:   ms(summary.P90),
+			Max:   ms(summary.Max),
+		}
+	}
+	return nil
+}
+
+func ms(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func toMilliseconds(p core.DurationPercentiles) DurationPercentiles {
+	return DurationPercentiles{
+		Samples: p.Samples,
+		P50:     ms(p.P50),
+		P90:     ms(p.P90),
+		P99:     ms(p.P99),
+		Max:     ms(p.Max),
+	}
+}
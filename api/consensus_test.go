@@ -0,0 +1,77 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+)
+
+type consensusProfiler []core.PhaseTimings
+
+func (p consensusProfiler) GetPhaseTimings() []core.PhaseTimings {
+	return p
+}
+
+func TestConsensusService_Phases(t *testing.T) {
+	service := NewConsensusService(&Runner{ConsensusProfiler: consensusProfiler{{
+		Phase: "phase1",
+		Send:  core.DurationPercentiles{Samples: 2, P50: time.Millisecond, P90: 1500 * time.Microsecond},
+		Wait:  core.DurationPercentiles{Samples: 2, Max: time.Second},
+	}}})
+
+	var rep ConsensusPhasesReply
+	require.NoError(t, service.Phases(&http.Request{}, &struct{}{}, &rep))
+	require.Equal(t, []PhaseTimings{{
+		Phase: "phase1",
+		Send:  DurationPercentiles{Samples: 2, P50: 1, P90: 1.5},
+		Wait:  DurationPercentiles{Samples: 2, Max: 1000},
+	}}, rep.Phases)
+}
+
+type rttProvider []core.RTTSummary
+
+func (p rttProvider) GetRTT() []core.RTTSummary {
+	return p
+}
+
+func TestConsensusService_RTT(t *testing.T) {
+	node := testutils.RandomRef()
+	service := NewConsensusService(&Runner{RTT: rttProvider{{
+		Pulse: 65537,
+		Peers: []core.PeerRTT{{Node: node, Address: "127.0.0.1:1", Samples: 3, Min: time.Millisecond, P50: 2 * time.Millisecond, Max: 5 * time.Millisecond}},
+		P50:   2 * time.Millisecond,
+		P90:   2 * time.Millisecond,
+		Max:   2 * time.Millisecond,
+	}}})
+
+	var rep ConsensusRTTReply
+	require.NoError(t, service.RTT(&http.Request{}, &struct{}{}, &rep))
+	require.Equal(t, []RTTSummary{{
+		Pulse: 65537,
+		Peers: []PeerRTT{{Node: node.String(), Address: "127.0.0.1:1", Samples: 3, Min: 1, P50: 2, Max: 5}},
+		P50:   2,
+		P90:   2,
+		Max:   2,
+	}}, rep.Pulses)
+}
@@ -0,0 +1,167 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/genesis"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/logicrunner/goplugin/preprocessor"
+)
+
+// contractName is used in paths of builder, so it must be a plain identifier.
+var contractName = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// DeployArgs is arguments of Deploy.Contract request, exactly one of Source and Plugin must be set. Schema is
+// optional and used only with Plugin, for Source it is generated from contract methods.
+type DeployArgs struct {
+	Name   string
+	Source string
+	Plugin []byte
+	Schema json.RawMessage
+}
+
+// DeployReply is reply for Deploy service requests.
+type DeployReply struct {
+	Prototype string
+	Code      string
+}
+
+// DeployService is a service that provides API for deployment of contracts.
+type DeployService struct {
+	runner *Runner
+}
+
+// NewDeployService creates new DeployService instance.
+func NewDeployService(runner *Runner) *DeployService {
+	return &DeployService{runner: runner}
+}
+
+// Contract compiles contract from Go source or takes prebuilt plugin, registers its code on ledger
+// and returns reference of the prototype. Request must be authorized with admin token.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "deploy.Contract",
+//	  "params": {
+//	    "Name": str, // name of contract, lower case identifier
+//	    "Source": str, // Go source of contract
+//	    "Plugin": str, // base64 encoded prebuilt plugin, if Source is not set
+//	    "Schema": object // optional schema of plugin methods, see core.ContractSchema
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	Response structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "result": {
+//	    "Prototype": str, // reference of contract prototype
+//	    "Code": str // reference of code record
+//	  },
+//	  "id": str|int|null // same as in request
+//	}
+func (s *DeployService) Contract(r *http.Request, args *DeployArgs, reply *DeployReply) (err error) {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ DeployService.Contract ] Incoming request: %s, contract: %s", r.RequestURI, args.Name)
+
+	if err := s.runner.authorizeAdmin(r, "DeployService"); err != nil {
+		inslog.Warn("[ DeployService.Contract ] unauthorized request: ", err)
+		return err
+	}
+	defer func() {
+		s.runner.audit(ctx, r, "deploy.Contract", args.Name, "", err)
+	}()
+	if !contractName.MatchString(args.Name) {
+		return errors.Errorf("[ DeployService.Contract ] invalid contract name %q", args.Name)
+	}
+	if (args.Source == "") == (len(args.Plugin) == 0) {
+		return errors.New("[ DeployService.Contract ] exactly one of Source and Plugin must be set")
+	}
+	if len(args.Schema) != 0 {
+		if args.Source != "" {
+			return errors.New("[ DeployService.Contract ] schema can be set for Plugin only")
+		}
+		if _, err := core.ParseContractSchema(args.Schema); err != nil {
+			return errors.Wrap(err, "[ DeployService.Contract ] invalid schema")
+		}
+	}
+
+	rootDomain := s.runner.GenesisDataProvider.GetRootDomain(ctx)
+	if rootDomain == nil {
+		return errors.New("[ DeployService.Contract ] root domain isn't available")
+	}
+	domain := rootDomain.Record()
+
+	cb := genesis.NewContractBuilder(s.runner.ArtifactManager)
+	if cb == nil {
+		return errors.New("[ DeployService.Contract ] failed to create builder")
+	}
+	defer cb.Clean()
+	cb.Sandbox = true
+	cb.BuildTimeout = s.runner.cfg.Deploy.BuildTimeout
+
+	if args.Source != "" {
+		parsed, err := parseContract(args.Name, args.Source)
+		if err != nil {
+			return errors.Wrap(err, "[ DeployService.Contract ] failed to parse contract")
+		}
+		err = cb.Build(ctx, map[string]*preprocessor.ParsedFile{args.Name: parsed}, domain)
+		if err != nil {
+			return errors.Wrap(err, "[ DeployService.Contract ] failed to build contract")
+		}
+	} else {
+		err := cb.DeployPlugin(ctx, args.Name, args.Plugin, args.Schema, domain)
+		if err != nil {
+			return errors.Wrap(err, "[ DeployService.Contract ] failed to deploy plugin")
+		}
+	}
+
+	reply.Prototype = cb.Prototypes[args.Name].String()
+	reply.Code = cb.Codes[args.Name].String()
+	inslog.Infof("[ DeployService.Contract ] contract %s deployed, prototype: %s", args.Name, reply.Prototype)
+	return nil
+}
+
+// parseContract parses contract source via temporary file, since preprocessor works with files only.
+func parseContract(name string, source string) (*preprocessor.ParsedFile, error) {
+	dir, err := ioutil.TempDir("", "contract-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir) // nolint: errcheck
+
+	file := filepath.Join(dir, name+".go")
+	err = ioutil.WriteFile(file, []byte(source), 0600)
+	if err != nil {
+		return nil, err
+	}
+	return preprocessor.ParseFile(file)
+}
@@ -0,0 +1,132 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+)
+
+type genesisDataProvider struct {
+	rootDomain *core.RecordRef
+}
+
+func (p *genesisDataProvider) GetRootDomain(ctx context.Context) *core.RecordRef {
+	return p.rootDomain
+}
+
+func (p *genesisDataProvider) GetNodeDomain(ctx context.Context) (*core.RecordRef, error) {
+	return nil, nil
+}
+
+func (p *genesisDataProvider) GetRootMember(ctx context.Context) (*core.RecordRef, error) {
+	return nil, nil
+}
+
+func deployRequest(token string) *http.Request {
+	r := &http.Request{Header: http.Header{}}
+	if token != "" {
+		r.Header.Set("Authorization", "Bearer "+token)
+	}
+	return r
+}
+
+func TestDeployService_Contract_Validation(t *testing.T) {
+	cfg := configuration.NewAPIRunner()
+	service := NewDeployService(&Runner{cfg: &cfg})
+	plugin := &DeployArgs{Name: "contract", Plugin: []byte{1}}
+	var rep DeployReply
+
+	err := service.Contract(deployRequest("secret"), plugin, &rep)
+	require.Contains(t, err.Error(), "disabled")
+
+	cfg.AdminToken = "secret"
+	err = service.Contract(deployRequest(""), plugin, &rep)
+	require.Contains(t, err.Error(), "admin token is required")
+	err = service.Contract(deployRequest("wrong"), plugin, &rep)
+	require.Contains(t, err.Error(), "invalid admin token")
+
+	err = service.Contract(deployRequest("secret"), &DeployArgs{Name: "../contract", Plugin: []byte{1}}, &rep)
+	require.Contains(t, err.Error(), "invalid contract name")
+	err = service.Contract(deployRequest("secret"), &DeployArgs{Name: "contract"}, &rep)
+	require.Contains(t, err.Error(), "exactly one")
+	err = service.Contract(deployRequest("secret"), &DeployArgs{Name: "contract", Source: "package main", Plugin: []byte{1}}, &rep)
+	require.Contains(t, err.Error(), "exactly one")
+
+	badSchema := &DeployArgs{Name: "contract", Plugin: []byte{1}, Schema: []byte("{")}
+	err = service.Contract(deployRequest("secret"), badSchema, &rep)
+	require.Contains(t, err.Error(), "invalid schema")
+	sourceSchema := &DeployArgs{Name: "contract", Source: "package main", Schema: []byte("{}")}
+	err = service.Contract(deployRequest("secret"), sourceSchema, &rep)
+	require.Contains(t, err.Error(), "Plugin only")
+}
+
+func TestDeployService_Contract_Plugin(t *testing.T) {
+	cfg := configuration.NewAPIRunner()
+	cfg.AdminToken = "secret"
+
+	domain := testutils.RandomRef()
+	genesisRef := testutils.RandomRef()
+	plugin := []byte("plugin binary")
+	schema := []byte(`{"methods":{"Get":{"arguments":[],"results":[{"type":"int"}]}}}`)
+
+	requests := 0
+	am := testutils.NewArtifactManagerMock(t)
+	am.RegisterRequestFunc = func(ctx context.Context, obj core.RecordRef, parcel core.Parcel) (*core.RecordID, uint64, error) {
+		requests++
+		id := testutils.RandomID()
+		return &id, uint64(requests), nil
+	}
+	codeID := testutils.RandomID()
+	am.DeployCodeFunc = func(
+		ctx context.Context, d core.RecordRef, req core.RecordRef, code []byte, mt core.MachineType, s []byte,
+	) (*core.RecordID, error) {
+		require.Equal(t, plugin, code)
+		require.Equal(t, core.MachineTypeGoPlugin, mt)
+		require.Equal(t, schema, s)
+		return &codeID, nil
+	}
+	am.RegisterResultFunc = func(ctx context.Context, obj core.RecordRef, req core.RecordRef, payload []byte) (*core.RecordID, error) {
+		return &core.RecordID{}, nil
+	}
+	am.GenesisRefFunc = func() *core.RecordRef { return &genesisRef }
+	am.ActivatePrototypeFunc = func(ctx context.Context, d core.RecordRef, proto core.RecordRef, parent core.RecordRef, code core.RecordRef, memory []byte) (core.ObjectDescriptor, error) {
+		require.Equal(t, *core.NewRecordRef(*domain.Record(), codeID), code)
+		return nil, nil
+	}
+
+	service := NewDeployService(&Runner{
+		cfg:                 &cfg,
+		ArtifactManager:     am,
+		GenesisDataProvider: &genesisDataProvider{rootDomain: &domain},
+	})
+
+	var rep DeployReply
+	args := &DeployArgs{Name: "contract", Plugin: plugin, Schema: schema}
+	err := service.Contract(deployRequest("secret"), args, &rep)
+	require.NoError(t, err)
+	require.Equal(t, 2, requests)
+	require.Equal(t, core.NewRecordRef(*domain.Record(), codeID).String(), rep.Code)
+	require.NotEmpty(t, rep.Prototype)
+}
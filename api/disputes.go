@@ -0,0 +1,146 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultDisputesLimit = 20
+	maxDisputesLimit     = 1000
+)
+
+// DisputesArgs is arguments that Disputes service accepts.
+type DisputesArgs struct {
+	Limit int
+}
+
+// DisputeVerdict is a validation result reported by a validator.
+type DisputeVerdict struct {
+	Node  string
+	Steps int
+	Error string
+}
+
+// Dispute is a disagreement between executor and validators of an object.
+type Dispute struct {
+	Object             string
+	Pulse              uint32
+	Executor           string
+	ExecutorResultHash string
+	Requests           int
+	Verdicts           []DisputeVerdict
+	Approved           bool
+	Time               int64
+}
+
+// DisputesReply is reply for Disputes service requests.
+type DisputesReply struct {
+	Disputes []Dispute
+}
+
+// DisputesService is a service that provides API for investigating validation disputes.
+type DisputesService struct {
+	runner *Runner
+}
+
+// NewDisputesService creates new DisputesService instance.
+func NewDisputesService(runner *Runner) *DisputesService {
+	return &DisputesService{runner: runner}
+}
+
+// List returns most recent validation disputes archived by the node, newest first.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "disputes.List",
+//	  "params": {
+//	    "Limit": int // max count of disputes, 20 by default
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	  Response structure:
+//	  {
+//	    "jsonrpc": "2.0",
+//	    "result": {
+//	      "Disputes": [
+//	        {
+//	          "Object": str, // reference of validated object
+//	          "Pulse": int,
+//	          "Executor": str, // reference of executor node
+//	          "ExecutorResultHash": str, // hex encoded hash of executor results
+//	          "Requests": int, // count of requests executor reported
+//	          "Verdicts": [
+//	            {
+//	              "Node": str, // reference of validator node
+//	              "Steps": int, // count of requests validator agreed with
+//	              "Error": str
+//	            }
+//	          ],
+//	          "Approved": bool, // whether validation consensus approved executor results
+//	          "Time": int // unix time the dispute was recorded
+//	        }
+//	      ]
+//	    },
+//	    "id": str|int|null // same as in request
+//	  }
+func (s *DisputesService) List(r *http.Request, args *DisputesArgs, reply *DisputesReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ DisputesService.List ] Incoming request: %s", r.RequestURI)
+
+	limit := args.Limit
+	if limit <= 0 {
+		limit = defaultDisputesLimit
+	}
+	if limit > maxDisputesLimit {
+		limit = maxDisputesLimit
+	}
+
+	disputes, err := s.runner.DisputeArchive.GetValidationDisputes(ctx, limit)
+	if err != nil {
+		return errors.Wrap(err, "[ DisputesService.List ] Can't get disputes")
+	}
+
+	reply.Disputes = make([]Dispute, 0, len(disputes))
+	for _, d := range disputes {
+		dispute := Dispute{
+			Object:             d.Object.String(),
+			Pulse:              uint32(d.Pulse),
+			Executor:           d.Executor.String(),
+			ExecutorResultHash: hex.EncodeToString(d.ExecutorResultHash),
+			Requests:           d.Requests,
+			Verdicts:           make([]DisputeVerdict, 0, len(d.Verdicts)),
+			Approved:           d.Approved,
+			Time:               d.Time.Unix(),
+		}
+		for _, v := range d.Verdicts {
+			dispute.Verdicts = append(dispute.Verdicts, DisputeVerdict{Node: v.Node.String(), Steps: v.Steps, Error: v.Error})
+		}
+		reply.Disputes = append(reply.Disputes, dispute)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+)
+
+type disputeArchive struct {
+	disputes []core.ValidationDispute
+	limit    int
+}
+
+func (a *disputeArchive) SetValidationDispute(ctx context.Context, dispute *core.ValidationDispute) error {
+	a.disputes = append([]core.ValidationDispute{*dispute}, a.disputes...)
+	return nil
+}
+
+func (a *disputeArchive) GetValidationDisputes(ctx context.Context, limit int) ([]core.ValidationDispute, error) {
+	a.limit = limit
+	if limit < len(a.disputes) {
+		return a.disputes[:limit], nil
+	}
+	return a.disputes, nil
+}
+
+func TestDisputesService_List(t *testing.T) {
+	archive := &disputeArchive{}
+	service := NewDisputesService(&Runner{DisputeArchive: archive})
+
+	var rep DisputesReply
+	require.NoError(t, service.List(&http.Request{}, &DisputesArgs{}, &rep))
+	require.Empty(t, rep.Disputes)
+	require.Equal(t, defaultDisputesLimit, archive.limit)
+
+	require.NoError(t, service.List(&http.Request{}, &DisputesArgs{Limit: maxDisputesLimit + 1}, &rep))
+	require.Equal(t, maxDisputesLimit, archive.limit)
+
+	object, executor, validator := testutils.RandomRef(), testutils.RandomRef(), testutils.RandomRef()
+	err := archive.SetValidationDispute(context.Background(), &core.ValidationDispute{
+		Object:             object,
+		Pulse:              core.FirstPulseNumber,
+		Executor:           executor,
+		ExecutorResultHash: []byte{0xab, 0xcd},
+		Requests:           2,
+		Verdicts:           []core.ValidatorVerdict{{Node: validator, Steps: 1, Error: "mismatch"}},
+		Time:               time.Unix(1546300800, 0),
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, service.List(&http.Request{}, &DisputesArgs{Limit: 1}, &rep))
+	require.Equal(t, []Dispute{{
+		Object:             object.String(),
+		Pulse:              uint32(core.FirstPulseNumber),
+		Executor:           executor.String(),
+		ExecutorResultHash: "abcd",
+		Requests:           2,
+		Verdicts:           []DisputeVerdict{{Node: validator.String(), Steps: 1, Error: "mismatch"}},
+		Time:               1546300800,
+	}}, rep.Disputes)
+}
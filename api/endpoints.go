@@ -0,0 +1,272 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package api
+
+import (
+	"context"
+	"crypto"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/network"
+)
+
+const (
+	// endpointTopic is a topic of node messages API nodes ask each other public URL of API with.
+	endpointTopic = "api.endpoint"
+	// endpointTimeout limits time of asking public URL of one node.
+	endpointTimeout = 2 * time.Second
+)
+
+// endpointRoles are roles of nodes serving API calls of clients.
+var endpointRoles = []core.StaticRole{core.StaticRoleVirtual, core.StaticRoleAPIGateway}
+
+// Endpoint is an API node clients may send requests to.
+type Endpoint struct {
+	Reference string `json:"reference"`
+	Role      string `json:"role"`
+	URL       string `json:"url"`
+	// LoadHint is a CPU usage of node in percents self-reported to the network, zero if unknown
+	LoadHint uint8 `json:"loadHint"`
+}
+
+// EndpointList is a list of API nodes of the network signed by API node, clients balance load between listed nodes.
+// Signature is made over CBOR-serialized (Pulse, Node, Endpoints), see EndpointList.Verify.
+type EndpointList struct {
+	Pulse     core.PulseNumber `json:"pulse"`
+	Node      string           `json:"node"`
+	Endpoints []Endpoint       `json:"endpoints"`
+	Signature []byte           `json:"signature"`
+}
+
+// Verify checks that list was signed with provided key of API node.
+func (l *EndpointList) Verify(key crypto.PublicKey) error {
+	data, err := l.signedData()
+	if err != nil {
+		return errors.Wrap(err, "[ EndpointList.Verify ]")
+	}
+	if !scheme.Verifier(key).Verify(core.SignatureFromBytes(l.Signature), data) {
+		return errors.New("[ EndpointList.Verify ] incorrect signature")
+	}
+	return nil
+}
+
+func (l *EndpointList) signedData() ([]byte, error) {
+	return core.MarshalArgs(l.Pulse, l.Node, l.Endpoints)
+}
+
+// endpointDirectory caches public API URLs of other nodes, URL of node doesn't change while it's active.
+type endpointDirectory struct {
+	lock sync.RWMutex
+	urls map[core.RecordRef]string
+}
+
+func newEndpointDirectory() *endpointDirectory {
+	return &endpointDirectory{urls: map[core.RecordRef]string{}}
+}
+
+func (d *endpointDirectory) get(node core.RecordRef) (string, bool) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	url, ok := d.urls[node]
+	return url, ok
+}
+
+func (d *endpointDirectory) set(node core.RecordRef, url string) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.urls[node] = url
+}
+
+// forget drops URLs of nodes which left the network.
+func (d *endpointDirectory) forget(active map[core.RecordRef]bool) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	for node := range d.urls {
+		if !active[node] {
+			delete(d.urls, node)
+		}
+	}
+}
+
+// publicURL returns URL clients reach API of this node at.
+func (ar *Runner) publicURL() string {
+	if ar.cfg.PublicURL != "" {
+		return ar.cfg.PublicURL
+	}
+	if ar.cfg.Address == "" {
+		return ""
+	}
+	return "http://" + ar.cfg.Address + ar.cfg.RPC
+}
+
+// endpointHandler is a node message handler which replies with public URL of API of this node.
+func (ar *Runner) endpointHandler(ctx context.Context, sender core.RecordRef, payload []byte) ([]byte, error) {
+	return []byte(ar.publicURL()), nil
+}
+
+// resolveEndpoints asks nodes for their public API URLs concurrently. Nodes which don't reply in time or don't
+// serve API over TCP are skipped.
+func (ar *Runner) resolveEndpoints(ctx context.Context, nodes []core.Node) map[core.RecordRef]string {
+	origin := ar.NodeNetwork.GetOrigin().ID()
+	result := make(map[core.RecordRef]string, len(nodes))
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	for _, node := range nodes {
+		id := node.ID()
+		if id.Equal(origin) {
+			result[id] = ar.publicURL()
+			continue
+		}
+		if url, ok := ar.endpoints.get(id); ok {
+			result[id] = url
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, endpointTimeout)
+			defer cancel()
+			url, err := ar.NodeMessenger.SendToNode(ctx, id, endpointTopic, nil)
+			if err != nil {
+				inslogger.FromContext(ctx).Debugf("failed to get API endpoint of node %s: %s", id, err)
+				return
+			}
+			ar.endpoints.set(id, string(url))
+			lock.Lock()
+			result[id] = string(url)
+			lock.Unlock()
+		}()
+	}
+	wg.Wait()
+	return result
+}
+
+// makeEndpointList lists working API nodes of the network with their public URLs and signs the list with node key.
+func (ar *Runner) makeEndpointList(ctx context.Context) (*EndpointList, error) {
+	pulse, err := ar.PulseStorage.Current(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ makeEndpointList ] can't get current pulse")
+	}
+
+	working := ar.NodeNetwork.GetWorkingNodes()
+	active := make(map[core.RecordRef]bool, len(working))
+	nodes := make([]core.Node, 0, len(working))
+	for _, node := range working {
+		active[node.ID()] = true
+		for _, role := range endpointRoles {
+			if node.Role() == role {
+				nodes = append(nodes, node)
+				break
+			}
+		}
+	}
+	ar.endpoints.forget(active)
+
+	loads := map[core.RecordRef]uint8{}
+	if history, ok := ar.NodeNetwork.(network.LoadHistory); ok {
+		for _, load := range history.GetLoads() {
+			loads[load.NodeID] = load.CPU
+		}
+	}
+
+	urls := ar.resolveEndpoints(ctx, nodes)
+	list := &EndpointList{
+		Pulse:     pulse.PulseNumber,
+		Node:      ar.NodeNetwork.GetOrigin().ID().String(),
+		Endpoints: []Endpoint{},
+	}
+	for _, node := range nodes {
+		url := urls[node.ID()]
+		if url == "" {
+			continue
+		}
+		list.Endpoints = append(list.Endpoints, Endpoint{
+			Reference: node.ID().String(),
+			Role:      node.Role().String(),
+			URL:       url,
+			LoadHint:  loads[node.ID()],
+		})
+	}
+
+	data, err := list.signedData()
+	if err != nil {
+		return nil, errors.Wrap(err, "[ makeEndpointList ]")
+	}
+	signature, err := ar.CryptographyService.Sign(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ makeEndpointList ] can't sign list")
+	}
+	list.Signature = signature.Bytes()
+	return list, nil
+}
+
+// EndpointsService is a service that provides list of API nodes for client-side load balancing.
+type EndpointsService struct {
+	runner *Runner
+}
+
+// NewEndpointsService creates new EndpointsService instance.
+func NewEndpointsService(runner *Runner) *EndpointsService {
+	return &EndpointsService{runner: runner}
+}
+
+// List returns signed list of working API nodes of the network built in current pulse. Clients should refresh it
+// when pulse changes and fail over to other listed nodes when the node doesn't respond.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "endpoints.List",
+//	  "params": {},
+//	  "id": str|int|null
+//	}
+//
+//	  Response structure:
+//	  {
+//	    "jsonrpc": "2.0",
+//	    "result": {
+//	      "pulse": int, // pulse the list was built in
+//	      "node": str, // reference of node signed the list
+//	      "endpoints": [{
+//	        "reference": str, // reference of API node
+//	        "role": str, // role of API node
+//	        "url": str, // URL of JSON-RPC API of node
+//	        "loadHint": int // CPU usage of node in percents, zero if unknown
+//	      }],
+//	      "signature": str // base64 signature of node, see EndpointList.Verify
+//	    },
+//	    "id": str|int|null // same as in request
+//	  }
+func (s *EndpointsService) List(r *http.Request, args *struct{}, reply *EndpointList) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ EndpointsService.List ] Incoming request: %s", r.RequestURI)
+
+	list, err := s.runner.makeEndpointList(ctx)
+	if err != nil {
+		return err
+	}
+	*reply = *list
+	return nil
+}
@@ -0,0 +1,111 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package api
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/cryptography"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+	"github.com/insolar/insolar/testutils/network"
+)
+
+// nodeMessenger replies to node messages with handlers of receiver nodes.
+type nodeMessenger struct {
+	handlers map[core.RecordRef]core.NodeMessageHandler
+	sent     int32
+}
+
+func (m *nodeMessenger) SendToNode(
+	ctx context.Context, node core.RecordRef, topic string, payload []byte,
+) ([]byte, error) {
+	atomic.AddInt32(&m.sent, 1)
+	handler, ok := m.handlers[node]
+	if !ok {
+		return nil, errors.New("node is unreachable")
+	}
+	return handler(ctx, core.RecordRef{}, payload)
+}
+
+func (m *nodeMessenger) RegisterNodeHandler(topic string, handler core.NodeMessageHandler) {}
+
+func TestRunner_makeEndpointList(t *testing.T) {
+	ctx := context.Background()
+	kp := platformpolicy.NewKeyProcessor()
+	privateKey, err := kp.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	newNode := func(role core.StaticRole) core.Node {
+		node := network.NewNodeMock(t)
+		node.IDMock.Return(testutils.RandomRef())
+		node.RoleMock.Return(role)
+		return node
+	}
+	origin := newNode(core.StaticRoleVirtual)
+	gateway := newNode(core.StaticRoleAPIGateway)
+	light := newNode(core.StaticRoleLightMaterial)
+	unreachable := newNode(core.StaticRoleVirtual)
+
+	nn := network.NewNodeNetworkMock(t)
+	nn.GetOriginMock.Return(origin)
+	nn.GetWorkingNodesMock.Return([]core.Node{origin, gateway, light, unreachable})
+	ps := testutils.NewPulseStorageMock(t)
+	ps.CurrentMock.Return(&core.Pulse{PulseNumber: core.FirstPulseNumber + 1}, nil)
+
+	cfg := configuration.NewAPIRunner()
+	cfg.Address = "10.0.0.1:19101"
+	gatewayCfg := configuration.NewAPIRunner()
+	gatewayCfg.PublicURL = "https://gateway.example/api/rpc"
+	messenger := &nodeMessenger{handlers: map[core.RecordRef]core.NodeMessageHandler{
+		gateway.ID(): (&Runner{cfg: &gatewayCfg}).endpointHandler,
+	}}
+
+	runner := &Runner{
+		cfg:                 &cfg,
+		NodeNetwork:         nn,
+		PulseStorage:        ps,
+		NodeMessenger:       messenger,
+		CryptographyService: cryptography.NewKeyBoundCryptographyService(privateKey),
+		endpoints:           newEndpointDirectory(),
+	}
+
+	list, err := runner.makeEndpointList(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, core.PulseNumber(core.FirstPulseNumber+1), list.Pulse)
+	assert.Equal(t, origin.ID().String(), list.Node)
+	assert.Equal(t, []Endpoint{
+		{Reference: origin.ID().String(), Role: "virtual", URL: "http://10.0.0.1:19101/api/rpc"},
+		{Reference: gateway.ID().String(), Role: "api_gateway", URL: "https://gateway.example/api/rpc"},
+	}, list.Endpoints)
+	require.NoError(t, list.Verify(kp.ExtractPublicKey(privateKey)))
+
+	list.Endpoints = list.Endpoints[:1]
+	require.Error(t, list.Verify(kp.ExtractPublicKey(privateKey)))
+
+	// resolved endpoints are cached, unreachable node is asked again
+	_, err = runner.makeEndpointList(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&messenger.sent))
+}
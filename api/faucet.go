@@ -0,0 +1,88 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
+)
+
+// FaucetArgs is arguments that Faucet service accepts.
+type FaucetArgs struct {
+	To     string
+	Amount uint
+}
+
+// FaucetReply is reply for Faucet service requests.
+type FaucetReply struct {
+	Amount uint
+}
+
+// FaucetService is a service that provides API for getting tokens on test networks.
+type FaucetService struct {
+	runner *Runner
+}
+
+// NewFaucetService creates new FaucetService instance.
+func NewFaucetService(runner *Runner) *FaucetService {
+	return &FaucetService{runner: runner}
+}
+
+// Give transfers tokens from faucet member to recipient. Amount is capped by faucet configuration and every
+// recipient can get tokens once per configured period. Faucet is available only if it's enabled in node config.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "faucet.Give",
+//	  "params": {
+//	    "To": str, // reference of recipient member
+//	    "Amount": int // requested amount, maximum amount is given if it's zero or too large
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	  Response structure:
+//	  {
+//	    "jsonrpc": "2.0",
+//	    "result": {
+//	      "Amount": int // transferred amount
+//	    },
+//	    "id": str|int|null // same as in request
+//	  }
+func (s *FaucetService) Give(r *http.Request, args *FaucetArgs, reply *FaucetReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ FaucetService.Give ] Incoming request: %s", r.RequestURI)
+
+	to, err := core.ParseRef(args.To)
+	if err != nil {
+		return errors.Wrap(err, "[ FaucetService.Give ] Failed to parse recipient reference")
+	}
+
+	amount, err := s.runner.Faucet.Give(ctx, *to, args.Amount)
+	if err != nil {
+		return errors.Wrap(err, "[ FaucetService.Give ] Can't give tokens")
+	}
+	reply.Amount = amount
+	return nil
+}
@@ -0,0 +1,50 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+)
+
+type faucet struct {
+	to core.RecordRef
+}
+
+func (f *faucet) Give(ctx context.Context, to core.RecordRef, amount uint) (uint, error) {
+	f.to = to
+	return amount / 2, nil
+}
+
+func TestFaucetService_Give(t *testing.T) {
+	f := &faucet{}
+	service := NewFaucetService(&Runner{Faucet: f})
+
+	var rep FaucetReply
+	require.Error(t, service.Give(&http.Request{}, &FaucetArgs{To: "bad ref", Amount: 10}, &rep))
+
+	to := testutils.RandomRef()
+	require.NoError(t, service.Give(&http.Request{}, &FaucetArgs{To: to.String(), Amount: 10}, &rep))
+	require.Equal(t, to, f.to)
+	require.Equal(t, uint(5), rep.Amount)
+}
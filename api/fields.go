@@ -0,0 +1,115 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// answerFormat is how answer is written to client.
+type answerFormat struct {
+	// fields are dot separated paths of answer fields client wants, e.g. "result.balance". Empty means all fields.
+	fields []string
+	// compact disables indentation.
+	compact bool
+}
+
+// requestFormat returns format of answer requested in body of request or in its URL query, e.g.
+// "?fields=result,pulse&compact=true". Body takes precedence.
+func requestFormat(req *http.Request, params *Request) answerFormat {
+	format := answerFormat{fields: params.Fields, compact: params.Compact}
+	query := req.URL.Query()
+	if len(format.fields) == 0 && query.Get("fields") != "" {
+		format.fields = strings.Split(query.Get("fields"), ",")
+	}
+	if !format.compact {
+		switch strings.ToLower(query.Get("compact")) {
+		case "1", "true":
+			format.compact = true
+		}
+	}
+	return format
+}
+
+// marshal marshals answer in format. Error is always kept, so clients selecting fields don't miss failures.
+func (f answerFormat) marshal(resp interface{}) ([]byte, error) {
+	if len(f.fields) > 0 {
+		selected, err := selectFields(resp, f.fields)
+		if err != nil {
+			return nil, err
+		}
+		resp = selected
+	}
+	if f.compact {
+		return json.Marshal(resp)
+	}
+	return json.MarshalIndent(resp, "", "    ")
+}
+
+// selectFields returns JSON object with requested fields of resp only. Missing fields are skipped.
+func selectFields(resp interface{}, fields []string) (map[string]interface{}, error) {
+	raw, err := json.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+	var full map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&full); err != nil {
+		return nil, err
+	}
+
+	selected := map[string]interface{}{}
+	if e, ok := full["error"]; ok {
+		selected["error"] = e
+	}
+	for _, field := range fields {
+		path := strings.Split(strings.TrimSpace(field), ".")
+		if value, ok := lookupField(full, path); ok {
+			setField(selected, path, value)
+		}
+	}
+	return selected, nil
+}
+
+func lookupField(obj map[string]interface{}, path []string) (interface{}, bool) {
+	value, ok := obj[path[0]]
+	if !ok || len(path) == 1 {
+		return value, ok
+	}
+	nested, isObj := value.(map[string]interface{})
+	if !isObj {
+		return nil, false
+	}
+	return lookupField(nested, path[1:])
+}
+
+func setField(obj map[string]interface{}, path []string, value interface{}) {
+	if len(path) == 1 {
+		obj[path[0]] = value
+		return
+	}
+	nested, ok := obj[path[0]].(map[string]interface{})
+	if !ok {
+		nested = map[string]interface{}{}
+		obj[path[0]] = nested
+	}
+	setField(nested, path[1:], value)
+}
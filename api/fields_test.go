@@ -0,0 +1,63 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestFormat(t *testing.T) {
+	req, err := http.NewRequest("POST", "/api/call?fields=result,%20pulse&compact=true", nil)
+	require.NoError(t, err)
+	require.Equal(t, answerFormat{fields: []string{"result", " pulse"}, compact: true}, requestFormat(req, &Request{}))
+
+	params := &Request{Fields: []string{"traceID"}}
+	require.Equal(t, answerFormat{fields: []string{"traceID"}, compact: true}, requestFormat(req, params))
+
+	req, err = http.NewRequest("POST", "/api/call", nil)
+	require.NoError(t, err)
+	require.Equal(t, answerFormat{}, requestFormat(req, &Request{}))
+}
+
+func TestAnswerFormat_Marshal(t *testing.T) {
+	resp := answer{
+		Result:  map[string]interface{}{"balance": 1000000000000000001, "currency": "XNS"},
+		TraceID: "trace",
+		Pulse:   65537,
+	}
+
+	res, err := answerFormat{}.marshal(resp)
+	require.NoError(t, err)
+	require.Contains(t, string(res), "\n    ")
+
+	res, err = answerFormat{compact: true}.marshal(resp)
+	require.NoError(t, err)
+	require.Equal(t, `{"result":{"balance":1000000000000000001,"currency":"XNS"},"traceID":"trace","pulse":65537}`, string(res))
+
+	format := answerFormat{fields: []string{"result.balance", "pulse", "missing", "traceID.nested"}, compact: true}
+	res, err = format.marshal(resp)
+	require.NoError(t, err)
+	require.Equal(t, `{"pulse":65537,"result":{"balance":1000000000000000001}}`, string(res))
+
+	resp.Error = "failed"
+	res, err = format.marshal(resp)
+	require.NoError(t, err)
+	require.Equal(t, `{"error":"failed","pulse":65537,"result":{"balance":1000000000000000001}}`, string(res))
+}
@@ -0,0 +1,130 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// GenesisReplayArgs is arguments of Genesis.Replay request.
+type GenesisReplayArgs struct {
+	Depth int
+}
+
+// GenesisReplayedObject is a system object reconstructed from ledger records.
+type GenesisReplayedObject struct {
+	Object      string
+	Parent      string
+	States      int
+	LatestState string
+	Deactivated bool
+	Children    int
+	Mismatches  []string
+}
+
+// GenesisReplayReply is reply for Genesis.Replay request.
+type GenesisReplayReply struct {
+	Consistent bool
+	Objects    []GenesisReplayedObject
+}
+
+// GenesisService is a service that provides admin API for verification of system objects created by genesis.
+type GenesisService struct {
+	runner *Runner
+}
+
+// NewGenesisService creates new GenesisService instance.
+func NewGenesisService(runner *Runner) *GenesisService {
+	return &GenesisService{runner: runner}
+}
+
+// Replay reconstructs root domain and its descendants purely from ledger records starting from pulse zero and
+// compares them with live state. Only heavy material node stores all records, request must be authorized with admin
+// token.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "genesis.Replay",
+//	  "params": {
+//	    "Depth": int // levels below root domain to replay, default covers members and nodes
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	Response structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "result": {
+//	    "Consistent": bool, // true if all reconstructed objects match live state
+//	    "Objects": [
+//	      {
+//	        "Object": str, // reference of object
+//	        "Parent": str,
+//	        "States": int, // number of states from activation
+//	        "LatestState": str,
+//	        "Deactivated": bool,
+//	        "Children": int,
+//	        "Mismatches": [str] // differences of reconstructed and live state
+//	      }
+//	    ]
+//	  },
+//	  "id": str|int|null // same as in request
+//	}
+func (s *GenesisService) Replay(r *http.Request, args *GenesisReplayArgs, reply *GenesisReplayReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ GenesisService.Replay ] Incoming request: %s, depth: %d", r.RequestURI, args.Depth)
+
+	if err := s.runner.authorizeAdmin(r, "GenesisService"); err != nil {
+		inslog.Warnf("[ GenesisService.Replay ] unauthorized request from %s: %s", r.RemoteAddr, err)
+		return err
+	}
+	if s.runner.CertificateManager.GetCertificate().GetRole() != core.StaticRoleHeavyMaterial {
+		return errors.New("[ GenesisService.Replay ] only heavy material node stores records from pulse zero")
+	}
+
+	report, err := s.runner.GenesisReplayer.ReplayGenesis(ctx, args.Depth)
+	if err != nil {
+		return errors.Wrap(err, "[ GenesisService.Replay ] failed to replay genesis")
+	}
+	if !report.Consistent() {
+		inslog.Warn("[ GenesisService.Replay ] system objects differ from their ledger records")
+	}
+
+	reply.Consistent = report.Consistent()
+	reply.Objects = make([]GenesisReplayedObject, 0, len(report.Objects))
+	for _, obj := range report.Objects {
+		reply.Objects = append(reply.Objects, GenesisReplayedObject{
+			Object:      obj.Object.String(),
+			Parent:      obj.Parent.String(),
+			States:      obj.States,
+			LatestState: obj.LatestState.String(),
+			Deactivated: obj.Deactivated,
+			Children:    obj.Children,
+			Mismatches:  obj.Mismatches,
+		})
+	}
+	return nil
+}
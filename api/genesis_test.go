@@ -0,0 +1,91 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+)
+
+type genesisReplayer struct {
+	report *core.GenesisReplayReport
+	depth  int
+}
+
+func (r *genesisReplayer) ReplayGenesis(ctx context.Context, depth int) (*core.GenesisReplayReport, error) {
+	r.depth = depth
+	return r.report, nil
+}
+
+func TestGenesisService_Replay(t *testing.T) {
+	rootDomain, member, state := testutils.RandomRef(), testutils.RandomRef(), testutils.RandomID()
+	replayer := &genesisReplayer{report: &core.GenesisReplayReport{Objects: []core.ReplayedObject{
+		{Object: rootDomain, States: 1, LatestState: state, Children: 1},
+		{Object: member, Parent: rootDomain, Deactivated: true, Mismatches: []string{"live object isn't deactivated"}},
+	}}}
+	cert := testutils.NewCertificateMock(t)
+	cm := testutils.NewCertificateManagerMock(t)
+	cm.GetCertificateMock.Return(cert)
+	service := NewGenesisService(&Runner{
+		cfg:                &configuration.APIRunner{AdminToken: "secret"},
+		CertificateManager: cm,
+		GenesisReplayer:    replayer,
+	})
+
+	var (
+		rep       GenesisReplayReply
+		zeroState core.RecordID
+	)
+	err := service.Replay(&http.Request{Header: http.Header{}}, &GenesisReplayArgs{}, &rep)
+	require.Contains(t, err.Error(), "admin token is required")
+
+	r := &http.Request{Header: http.Header{}}
+	r.Header.Set("Authorization", "Bearer secret")
+	cert.GetRoleMock.Return(core.StaticRoleLightMaterial)
+	err = service.Replay(r, &GenesisReplayArgs{}, &rep)
+	require.Contains(t, err.Error(), "only heavy material node")
+
+	cert.GetRoleMock.Return(core.StaticRoleHeavyMaterial)
+	require.NoError(t, service.Replay(r, &GenesisReplayArgs{Depth: 3}, &rep))
+	require.Equal(t, 3, replayer.depth)
+	require.Equal(t, GenesisReplayReply{
+		Consistent: false,
+		Objects: []GenesisReplayedObject{
+			{
+				Object:      rootDomain.String(),
+				Parent:      core.RecordRef{}.String(),
+				States:      1,
+				LatestState: state.String(),
+				Children:    1,
+			},
+			{
+				Object:      member.String(),
+				Parent:      rootDomain.String(),
+				LatestState: zeroState.String(),
+				Deactivated: true,
+				Mismatches:  []string{"live object isn't deactivated"},
+			},
+		},
+	}, rep)
+}
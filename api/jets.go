@@ -0,0 +1,374 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage/jet"
+)
+
+// JetLoad is an average drop size of jet over recent pulses.
+type JetLoad struct {
+	Jet         string
+	AvgDropSize uint64
+	Drops       int
+}
+
+// JetMerge is a pair of sibling jets recommended to merge.
+type JetMerge struct {
+	Parent string
+	Left   JetLoad
+	Right  JetLoad
+}
+
+// JetsPlanReply is reply for Jets.Plan request.
+type JetsPlanReply struct {
+	Pulse    uint32
+	Splits   []JetLoad
+	Merges   []JetMerge
+	Executed bool
+}
+
+// JetsRolesArgs is arguments of Jets.Roles request.
+type JetsRolesArgs struct {
+	Reference string
+	Pulse     uint32
+}
+
+// JetsRolesReply is reply for Jets.Roles request.
+type JetsRolesReply struct {
+	Pulse             uint32
+	VirtualExecutor   string
+	VirtualValidators []string
+	LightExecutor     string
+	LightValidators   []string
+	Heavy             string
+}
+
+// JetsMigrateArgs is arguments of Jets.Migrate request.
+type JetsMigrateArgs struct {
+	Jet    string
+	Target string
+}
+
+// JetsMigrateReply is reply for Jets.Migrate request.
+type JetsMigrateReply struct {
+	Objects  int
+	Requests int
+	Checksum string
+}
+
+// JetSyncProgress is replication progress of jet to heavy node.
+type JetSyncProgress struct {
+	Jet    string
+	Synced uint32
+	InSync uint32
+	Stored int
+	Nodes  map[string]uint32
+}
+
+// JetsSyncReply is reply for Jets.Sync request.
+type JetsSyncReply struct {
+	Jets []JetSyncProgress
+}
+
+// JetsService is a service that provides API for jet tree management.
+type JetsService struct {
+	runner *Runner
+}
+
+// NewJetsService creates new JetsService instance.
+func NewJetsService(runner *Runner) *JetsService {
+	return &JetsService{runner: runner}
+}
+
+// Plan returns last plan of jets rebalancing made by the node. Only light material nodes make plans for jets they execute.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "jets.Plan",
+//	  "params": {},
+//	  "id": str|int|null
+//	}
+//
+//	  Response structure:
+//	  {
+//	    "jsonrpc": "2.0",
+//	    "result": {
+//	      "Pulse": int, // pulse plan was made on, zero if there is no plan
+//	      "Splits": [{ // jets recommended to split
+//	        "Jet": str,
+//	        "AvgDropSize": int, // average drop size in bytes
+//	        "Drops": int // number of drops average is calculated over
+//	      }],
+//	      "Merges": [{ // sibling jets recommended to merge
+//	        "Parent": str,
+//	        "Left": {...},
+//	        "Right": {...}
+//	      }],
+//	      "Executed": bool // whether recommended splits are performed
+//	    },
+//	    "id": str|int|null // same as in request
+//	  }
+func (s *JetsService) Plan(r *http.Request, args *struct{}, reply *JetsPlanReply) error {
+	_, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ JetsService.Plan ] Incoming request: %s", r.RequestURI)
+
+	plan := s.runner.JetPlanner.LastPlan()
+	if plan == nil {
+		return nil
+	}
+	reply.Pulse = uint32(plan.Pulse)
+	reply.Executed = plan.Executed
+	for _, load := range plan.Splits {
+		reply.Splits = append(reply.Splits, jetLoad(load))
+	}
+	for _, merge := range plan.Merges {
+		reply.Merges = append(reply.Merges, JetMerge{
+			Parent: merge.Parent.DebugString(),
+			Left:   jetLoad(merge.Left),
+			Right:  jetLoad(merge.Right),
+		})
+	}
+	return nil
+}
+
+func jetLoad(load core.JetLoad) JetLoad {
+	return JetLoad{Jet: load.Jet.DebugString(), AvgDropSize: load.AvgDropSize, Drops: load.Drops}
+}
+
+// Roles returns nodes which are calculated by the node as responsible for object in pulse, so it's easy to tell
+// which node should be handling the object. Light material roles depend on jet tree known to the node.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "jets.Roles",
+//	  "params": {
+//	    "Reference": str, // reference of object
+//	    "Pulse": int // pulse number, current pulse if zero or omitted
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	Response structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "result": {
+//	    "Pulse": int, // pulse roles are calculated for
+//	    "VirtualExecutor": str,
+//	    "VirtualValidators": [str],
+//	    "LightExecutor": str,
+//	    "LightValidators": [str],
+//	    "Heavy": str
+//	  },
+//	  "id": str|int|null // same as in request
+//	}
+func (s *JetsService) Roles(r *http.Request, args *JetsRolesArgs, reply *JetsRolesReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ JetsService.Roles ] Incoming request: %s, object: %s", r.RequestURI, args.Reference)
+
+	object, err := core.ParseRef(args.Reference)
+	if err != nil {
+		return errors.Wrap(err, "[ JetsService.Roles ] failed to parse reference")
+	}
+	pulse := core.PulseNumber(args.Pulse)
+	if pulse == 0 {
+		current, err := s.runner.PulseStorage.Current(ctx)
+		if err != nil {
+			return errors.Wrap(err, "[ JetsService.Roles ] failed to get current pulse")
+		}
+		pulse = current.PulseNumber
+	}
+
+	jc := s.runner.JetCoordinator
+	objID := *object.Record()
+	virtualExecutor, err := jc.VirtualExecutorForObject(ctx, objID, pulse)
+	if err != nil {
+		return errors.Wrap(err, "[ JetsService.Roles ] failed to calculate virtual executor")
+	}
+	virtualValidators, err := jc.VirtualValidatorsForObject(ctx, objID, pulse)
+	if err != nil {
+		return errors.Wrap(err, "[ JetsService.Roles ] failed to calculate virtual validators")
+	}
+	lightExecutor, err := jc.LightExecutorForObject(ctx, objID, pulse)
+	if err != nil {
+		return errors.Wrap(err, "[ JetsService.Roles ] failed to calculate light executor")
+	}
+	lightValidators, err := jc.LightValidatorsForObject(ctx, objID, pulse)
+	if err != nil {
+		return errors.Wrap(err, "[ JetsService.Roles ] failed to calculate light validators")
+	}
+	heavy, err := jc.Heavy(ctx, pulse)
+	if err != nil {
+		return errors.Wrap(err, "[ JetsService.Roles ] failed to calculate heavy")
+	}
+
+	reply.Pulse = uint32(pulse)
+	reply.VirtualExecutor = virtualExecutor.String()
+	reply.VirtualValidators = refsToStrings(virtualValidators)
+	reply.LightExecutor = lightExecutor.String()
+	reply.LightValidators = refsToStrings(lightValidators)
+	reply.Heavy = heavy.String()
+	return nil
+}
+
+// Migrate copies hot data of jet executed by the node (indexes and pending requests) to other light material node
+// ahead of planned topology change. Migration succeeds when target confirms by checksum it stored all the data.
+// Request must be authorized with admin token.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "jets.Migrate",
+//	  "params": {
+//	    "Jet": str, // jet prefix as bit string, e.g. "01" for [JET 2 01], empty for root jet
+//	    "Target": str // reference of light material node to copy hot data to
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	Response structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "result": {
+//	    "Objects": int, // number of migrated object indexes
+//	    "Requests": int, // number of migrated pending requests
+//	    "Checksum": str // hex encoded checksum of migrated data
+//	  },
+//	  "id": str|int|null // same as in request
+//	}
+func (s *JetsService) Migrate(r *http.Request, args *JetsMigrateArgs, reply *JetsMigrateReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ JetsService.Migrate ] Incoming request: %s, jet: %s, target: %s", r.RequestURI, args.Jet, args.Target)
+
+	if err := s.runner.authorizeAdmin(r, "JetsService"); err != nil {
+		inslog.Warnf("[ JetsService.Migrate ] unauthorized request from %s: %s", r.RemoteAddr, err)
+		return err
+	}
+	jetID, err := parseJet(args.Jet)
+	if err != nil {
+		return errors.Wrap(err, "[ JetsService.Migrate ] failed to parse jet")
+	}
+	target, err := core.ParseRef(args.Target)
+	if err != nil {
+		return errors.Wrap(err, "[ JetsService.Migrate ] failed to parse target")
+	}
+
+	migration, err := s.runner.HotDataMigrator.MigrateHotData(ctx, jetID, *target)
+	s.runner.audit(ctx, r, "jets.Migrate", jetID.DebugString()+" to "+target.String(), "", err)
+	if err != nil {
+		return errors.Wrap(err, "[ JetsService.Migrate ] migration failed")
+	}
+	reply.Objects = migration.Objects
+	reply.Requests = migration.Requests
+	reply.Checksum = hex.EncodeToString(migration.Checksum)
+	return nil
+}
+
+// Sync returns replication progress of jets from light material nodes to heavy node. Only heavy material nodes
+// replicate jets. Request must be authorized with admin token.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "jets.Sync",
+//	  "params": {},
+//	  "id": str|int|null
+//	}
+//
+//	Response structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "result": {
+//	    "Jets": [{
+//	      "Jet": str,
+//	      "Synced": int, // last pulse of jet confirmed by heavy
+//	      "InSync": int, // pulse being synced, zero if none
+//	      "Stored": int, // number of payloads of pulse being synced already stored
+//	      "Nodes": {str: int} // last confirmed pulse per light material node
+//	    }]
+//	  },
+//	  "id": str|int|null // same as in request
+//	}
+func (s *JetsService) Sync(r *http.Request, args *struct{}, reply *JetsSyncReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ JetsService.Sync ] Incoming request: %s", r.RequestURI)
+
+	if err := s.runner.authorizeAdmin(r, "JetsService"); err != nil {
+		inslog.Warnf("[ JetsService.Sync ] unauthorized request from %s: %s", r.RemoteAddr, err)
+		return err
+	}
+	if s.runner.CertificateManager.GetCertificate().GetRole() != core.StaticRoleHeavyMaterial {
+		return errors.New("[ JetsService.Sync ] only heavy material node replicates jets")
+	}
+
+	reply.Jets = []JetSyncProgress{}
+	for _, progress := range s.runner.HeavySync.Progress(ctx) {
+		nodes := make(map[string]uint32, len(progress.Nodes))
+		for node, pn := range progress.Nodes {
+			nodes[node.String()] = uint32(pn)
+		}
+		reply.Jets = append(reply.Jets, JetSyncProgress{
+			Jet:    progress.Jet.DebugString(),
+			Synced: uint32(progress.Synced),
+			InSync: uint32(progress.InSync),
+			Stored: progress.Stored,
+			Nodes:  nodes,
+		})
+	}
+	return nil
+}
+
+// parseJet makes jet id from its prefix written as bit string.
+func parseJet(bits string) (core.RecordID, error) {
+	if len(bits) > (core.RecordHashSize-1)*8 {
+		return core.RecordID{}, errors.New("jet prefix is too long")
+	}
+	prefix := make([]byte, core.RecordHashSize-1)
+	for i, bit := range bits {
+		switch bit {
+		case '0':
+		case '1':
+			prefix[i/8] |= 0x80 >> uint(i%8)
+		default:
+			return core.RecordID{}, errors.Errorf("bad bit %q in jet prefix", bit)
+		}
+	}
+	return *jet.NewID(uint8(len(bits)), prefix), nil
+}
+
+func refsToStrings(refs []core.RecordRef) []string {
+	res := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		res = append(res, ref.String())
+	}
+	return res
+}
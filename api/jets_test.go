@@ -0,0 +1,188 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/ledger/storage/jet"
+	"github.com/insolar/insolar/testutils"
+)
+
+type jetPlanner struct {
+	plan *core.JetRebalancePlan
+}
+
+func (p jetPlanner) LastPlan() *core.JetRebalancePlan {
+	return p.plan
+}
+
+func TestJetsService_Plan(t *testing.T) {
+	var rep JetsPlanReply
+	require.NoError(t, NewJetsService(&Runner{JetPlanner: jetPlanner{}}).Plan(&http.Request{}, &struct{}{}, &rep))
+	require.Equal(t, JetsPlanReply{}, rep)
+
+	left, right := jet.Children(jet.ZeroJetID)
+	service := NewJetsService(&Runner{JetPlanner: jetPlanner{plan: &core.JetRebalancePlan{
+		Pulse:    core.FirstPulseNumber,
+		Splits:   []core.JetLoad{{Jet: left, AvgDropSize: 2000, Drops: 5}},
+		Merges:   []core.JetMerge{{Parent: jet.ZeroJetID, Left: core.JetLoad{Jet: left}, Right: core.JetLoad{Jet: right}}},
+		Executed: true,
+	}}})
+	require.NoError(t, service.Plan(&http.Request{}, &struct{}{}, &rep))
+	require.Equal(t, JetsPlanReply{
+		Pulse:  uint32(core.FirstPulseNumber),
+		Splits: []JetLoad{{Jet: left.DebugString(), AvgDropSize: 2000, Drops: 5}},
+		Merges: []JetMerge{{
+			Parent: jet.ZeroJetID.DebugString(),
+			Left:   JetLoad{Jet: left.DebugString()},
+			Right:  JetLoad{Jet: right.DebugString()},
+		}},
+		Executed: true,
+	}, rep)
+}
+
+func TestJetsService_Roles(t *testing.T) {
+	object := testutils.RandomRef()
+	virtual, light, heavy := testutils.RandomRef(), testutils.RandomRef(), testutils.RandomRef()
+	validator := testutils.RandomRef()
+	pulse := core.PulseNumber(core.FirstPulseNumber + 10)
+
+	jc := testutils.NewJetCoordinatorMock(t)
+	checkArgs := func(id core.RecordID, pn core.PulseNumber) {
+		require.Equal(t, *object.Record(), id)
+		require.Equal(t, pulse, pn)
+	}
+	jc.VirtualExecutorForObjectFunc = func(_ context.Context, id core.RecordID, pn core.PulseNumber) (*core.RecordRef, error) {
+		checkArgs(id, pn)
+		return &virtual, nil
+	}
+	jc.VirtualValidatorsForObjectFunc = func(_ context.Context, id core.RecordID, pn core.PulseNumber) ([]core.RecordRef, error) {
+		checkArgs(id, pn)
+		return []core.RecordRef{validator}, nil
+	}
+	jc.LightExecutorForObjectFunc = func(_ context.Context, id core.RecordID, pn core.PulseNumber) (*core.RecordRef, error) {
+		checkArgs(id, pn)
+		return &light, nil
+	}
+	jc.LightValidatorsForObjectFunc = func(_ context.Context, id core.RecordID, pn core.PulseNumber) ([]core.RecordRef, error) {
+		checkArgs(id, pn)
+		return nil, nil
+	}
+	jc.HeavyFunc = func(_ context.Context, pn core.PulseNumber) (*core.RecordRef, error) {
+		require.Equal(t, pulse, pn)
+		return &heavy, nil
+	}
+	ps := testutils.NewPulseStorageMock(t)
+	ps.CurrentMock.Return(&core.Pulse{PulseNumber: pulse}, nil)
+
+	service := NewJetsService(&Runner{JetCoordinator: jc, PulseStorage: ps})
+	var rep JetsRolesReply
+	require.NoError(t, service.Roles(&http.Request{}, &JetsRolesArgs{Reference: object.String()}, &rep))
+	require.Equal(t, JetsRolesReply{
+		Pulse:             uint32(pulse),
+		VirtualExecutor:   virtual.String(),
+		VirtualValidators: []string{validator.String()},
+		LightExecutor:     light.String(),
+		LightValidators:   []string{},
+		Heavy:             heavy.String(),
+	}, rep)
+
+	err := service.Roles(&http.Request{}, &JetsRolesArgs{Reference: "bad"}, &rep)
+	require.Error(t, err)
+}
+
+type hotDataMigrator struct {
+	jet    core.RecordID
+	target core.RecordRef
+}
+
+func (m *hotDataMigrator) MigrateHotData(ctx context.Context, jet core.RecordID, target core.RecordRef) (*core.HotDataMigration, error) {
+	m.jet, m.target = jet, target
+	return &core.HotDataMigration{Jet: jet, Target: target, Objects: 2, Requests: 3, Checksum: []byte{0xab}}, nil
+}
+
+func TestJetsService_Migrate(t *testing.T) {
+	migrator := &hotDataMigrator{}
+	service := NewJetsService(&Runner{
+		cfg:             &configuration.APIRunner{AdminToken: "secret"},
+		HotDataMigrator: migrator,
+	})
+	target := testutils.RandomRef()
+	args := &JetsMigrateArgs{Jet: "01", Target: target.String()}
+
+	var rep JetsMigrateReply
+	require.Error(t, service.Migrate(&http.Request{Header: http.Header{}}, args, &rep), "admin token is required")
+
+	r := &http.Request{Header: http.Header{}}
+	r.Header.Set("Authorization", "Bearer secret")
+	require.NoError(t, service.Migrate(r, args, &rep))
+	require.Equal(t, JetsMigrateReply{Objects: 2, Requests: 3, Checksum: "ab"}, rep)
+
+	left, _ := jet.Children(jet.ZeroJetID)
+	_, leftRight := jet.Children(left)
+	require.Equal(t, leftRight, migrator.jet)
+	require.Equal(t, target, migrator.target)
+
+	args.Jet = "012"
+	require.Error(t, service.Migrate(r, args, &rep))
+}
+
+func TestJetsService_Sync(t *testing.T) {
+	jetID := testutils.RandomJet()
+	node := testutils.RandomRef()
+	heavySync := testutils.NewHeavySyncMock(t)
+	heavySync.ProgressMock.Return([]core.HeavySyncProgress{{
+		Jet:    jetID,
+		Synced: core.FirstPulseNumber + 1,
+		InSync: core.FirstPulseNumber + 2,
+		Stored: 3,
+		Nodes:  map[core.RecordRef]core.PulseNumber{node: core.FirstPulseNumber + 1},
+	}})
+	cert := testutils.NewCertificateMock(t)
+	cm := testutils.NewCertificateManagerMock(t)
+	cm.GetCertificateMock.Return(cert)
+	service := NewJetsService(&Runner{
+		cfg:                &configuration.APIRunner{AdminToken: "secret"},
+		CertificateManager: cm,
+		HeavySync:          heavySync,
+	})
+
+	var rep JetsSyncReply
+	require.Error(t, service.Sync(&http.Request{Header: http.Header{}}, &struct{}{}, &rep), "admin token is required")
+
+	r := &http.Request{Header: http.Header{}}
+	r.Header.Set("Authorization", "Bearer secret")
+	cert.GetRoleMock.Return(core.StaticRoleLightMaterial)
+	require.Error(t, service.Sync(r, &struct{}{}, &rep), "only heavy replicates jets")
+
+	cert.GetRoleMock.Return(core.StaticRoleHeavyMaterial)
+	require.NoError(t, service.Sync(r, &struct{}{}, &rep))
+	require.Equal(t, JetsSyncReply{Jets: []JetSyncProgress{{
+		Jet:    jetID.DebugString(),
+		Synced: uint32(core.FirstPulseNumber + 1),
+		InSync: uint32(core.FirstPulseNumber + 2),
+		Stored: 3,
+		Nodes:  map[string]uint32{node.String(): uint32(core.FirstPulseNumber + 1)},
+	}}}, rep)
+}
@@ -0,0 +1,412 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// States of asynchronous jobs.
+const (
+	jobQueued    = "queued"
+	jobRunning   = "running"
+	jobDone      = "done"
+	jobFailed    = "failed"
+	jobCancelled = "cancelled"
+)
+
+var errJobQueueFull = errors.New("too many queued jobs")
+
+// jobOutcome is a result of successfully finished job.
+type jobOutcome struct {
+	Result   interface{}
+	Request  string
+	Pulse    uint32
+	Finality string
+}
+
+type apiJob struct {
+	id     string
+	member core.RecordRef
+	method string
+	ctx    context.Context
+	run    func(ctx context.Context) (*jobOutcome, error)
+	cancel context.CancelFunc
+
+	state    string
+	queued   time.Time
+	started  time.Time
+	finished time.Time
+	outcome  *jobOutcome
+	err      error
+	// position is a number of queued jobs ahead of this one, it's filled in snapshots.
+	position int
+}
+
+// jobQueue executes long operations in bounded pool of workers. Jobs are local to API node, clients poll
+// progress and results by job ID, which is random token, so only client who submitted job knows it.
+type jobQueue struct {
+	cfg configuration.APIJobs
+	now func() time.Time
+
+	lock     sync.Mutex
+	jobs     map[string]*apiJob
+	pending  chan *apiJob
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+func newJobQueue(cfg configuration.APIJobs) *jobQueue {
+	size := cfg.QueueSize
+	if size < 0 {
+		size = 0
+	}
+	return &jobQueue{
+		cfg:     cfg,
+		now:     time.Now,
+		jobs:    map[string]*apiJob{},
+		pending: make(chan *apiJob, size),
+		done:    make(chan struct{}),
+	}
+}
+
+// start runs workers of queue.
+func (q *jobQueue) start() {
+	for i := 0; i < q.cfg.Workers; i++ {
+		go q.work()
+	}
+}
+
+// stop stops workers and cancels running jobs, queued jobs are left unexecuted.
+func (q *jobQueue) stop() {
+	q.stopOnce.Do(func() {
+		close(q.done)
+
+		q.lock.Lock()
+		defer q.lock.Unlock()
+		for _, job := range q.jobs {
+			if job.state == jobRunning {
+				job.cancel()
+			}
+		}
+	})
+}
+
+// submit queues job of member and returns its ID. errJobQueueFull is returned if queue is full.
+func (q *jobQueue) submit(
+	ctx context.Context, member core.RecordRef, method string, run func(ctx context.Context) (*jobOutcome, error),
+) (string, error) {
+	if q.cfg.Workers <= 0 {
+		return "", errors.New("asynchronous calls are disabled")
+	}
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	now := q.now()
+	q.expire(now)
+	job := &apiJob{
+		id:     newSessionToken(),
+		member: member,
+		method: method,
+		ctx:    ctx,
+		run:    run,
+		state:  jobQueued,
+		queued: now,
+	}
+	select {
+	case q.pending <- job:
+	default:
+		return "", errJobQueueFull
+	}
+	q.jobs[job.id] = job
+	return job.id, nil
+}
+
+// get returns snapshot of job, false is returned if job is unknown or expired.
+func (q *jobQueue) get(id string) (apiJob, bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	q.expire(q.now())
+	job, ok := q.jobs[id]
+	if !ok {
+		return apiJob{}, false
+	}
+	return q.snapshot(job), true
+}
+
+// cancel cancels queued or running job and returns its snapshot. Finished jobs are left as is.
+func (q *jobQueue) cancel(id string) (apiJob, bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	q.expire(q.now())
+	job, ok := q.jobs[id]
+	if !ok {
+		return apiJob{}, false
+	}
+	switch job.state {
+	case jobQueued:
+		job.state = jobCancelled
+		job.finished = q.now()
+	case jobRunning:
+		// job is finished by worker when its run returns
+		job.state = jobCancelled
+		job.cancel()
+	}
+	return q.snapshot(job), true
+}
+
+func (q *jobQueue) work() {
+	for {
+		select {
+		case <-q.done:
+			return
+		case job := <-q.pending:
+			q.execute(job)
+		}
+	}
+}
+
+func (q *jobQueue) execute(job *apiJob) {
+	q.lock.Lock()
+	if job.state != jobQueued {
+		q.lock.Unlock()
+		return
+	}
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+	)
+	if q.cfg.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(job.ctx, q.cfg.Timeout)
+	} else {
+		ctx, cancel = context.WithCancel(job.ctx)
+	}
+	job.state, job.started, job.cancel = jobRunning, q.now(), cancel
+	q.lock.Unlock()
+
+	outcome, err := job.run(ctx)
+	cancel()
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	job.finished = q.now()
+	if job.state == jobCancelled {
+		return
+	}
+	if err != nil {
+		job.state, job.err = jobFailed, err
+		return
+	}
+	job.state, job.outcome = jobDone, outcome
+}
+
+// expire drops jobs finished longer than TTL ago. Lock must be held by caller.
+func (q *jobQueue) expire(now time.Time) {
+	for id, job := range q.jobs {
+		if !job.finished.IsZero() && now.Sub(job.finished) > q.cfg.TTL {
+			delete(q.jobs, id)
+		}
+	}
+}
+
+// snapshot copies job filling its position in queue. Lock must be held by caller.
+func (q *jobQueue) snapshot(job *apiJob) apiJob {
+	snapshot := *job
+	if job.state != jobQueued {
+		return snapshot
+	}
+	for _, other := range q.jobs {
+		if other.state == jobQueued && other.queued.Before(job.queued) {
+			snapshot.position++
+		}
+	}
+	return snapshot
+}
+
+// submitCall queues call to be executed in background and returns ID of its job.
+func (ar *Runner) submitCall(ctx context.Context, params Request) (string, error) {
+	// reference is already checked on unmarshal
+	member, _ := core.ParseRef(params.Reference)
+	return ar.jobs.submit(ctx, *member, params.Method, func(ctx context.Context) (*jobOutcome, error) {
+		result, rep, err := ar.makeCall(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		if params.Method == "Transfer" && result == nil {
+			go ar.pushTransferReceipt(ctx, params, rep)
+		}
+		return &jobOutcome{
+			Result:   result,
+			Request:  rep.Request.String(),
+			Pulse:    uint32(rep.Pulse),
+			Finality: rep.Finality.String(),
+		}, nil
+	})
+}
+
+// JobsArgs is arguments of Jobs service requests.
+type JobsArgs struct {
+	ID string
+}
+
+// JobReply is a progress or result of asynchronous call.
+type JobReply struct {
+	ID     string
+	Member string
+	Method string
+	State  string
+	// Position is a number of jobs queued ahead of this one.
+	Position int
+	Queued   int64
+	Started  int64
+	Finished int64
+
+	Result    interface{}
+	Request   string
+	Pulse     uint32
+	Finality  string
+	Error     string
+	ErrorCode core.ErrorCode
+	Retryable bool
+}
+
+// JobsService is a service that provides API for polling and cancellation of asynchronous calls.
+type JobsService struct {
+	runner *Runner
+}
+
+// NewJobsService creates new JobsService instance.
+func NewJobsService(runner *Runner) *JobsService {
+	return &JobsService{runner: runner}
+}
+
+// Get returns progress of job of asynchronous call, and its result when it's finished. Results are kept on API node
+// which accepted the call for configured TTL after job is finished.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "jobs.Get",
+//	  "params": {
+//	    "ID": str // job ID returned by call with "async" flag
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	Response structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "result": {
+//	    "ID": str,
+//	    "Member": str, // reference of calling member
+//	    "Method": str,
+//	    "State": str, // queued, running, done, failed or cancelled
+//	    "Position": int, // number of jobs queued ahead
+//	    "Queued": int, // unix time, zero if it hasn't happened yet
+//	    "Started": int,
+//	    "Finished": int,
+//	    "Result": any, // result of method when job is done
+//	    "Request": str, // reference of registered request
+//	    "Pulse": int,
+//	    "Finality": str,
+//	    "Error": str, // error when job failed
+//	    "ErrorCode": int,
+//	    "Retryable": bool
+//	  },
+//	  "id": str|int|null // same as in request
+//	}
+func (s *JobsService) Get(r *http.Request, args *JobsArgs, reply *JobReply) error {
+	_, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ JobsService.Get ] Incoming request: %s", r.RequestURI)
+
+	job, ok := s.runner.jobs.get(args.ID)
+	if !ok {
+		return errors.New("[ JobsService.Get ] job not found")
+	}
+	fillJobReply(reply, &job)
+	return nil
+}
+
+// Cancel cancels queued or running job of asynchronous call and returns its progress. Cancellation of running job
+// interrupts waiting for result, but call may still be executed by network.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "jobs.Cancel",
+//	  "params": {
+//	    "ID": str // job ID returned by call with "async" flag
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	Response structure is the same as of jobs.Get.
+func (s *JobsService) Cancel(r *http.Request, args *JobsArgs, reply *JobReply) error {
+	_, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ JobsService.Cancel ] Incoming request: %s", r.RequestURI)
+
+	job, ok := s.runner.jobs.cancel(args.ID)
+	if !ok {
+		return errors.New("[ JobsService.Cancel ] job not found")
+	}
+	fillJobReply(reply, &job)
+	return nil
+}
+
+func fillJobReply(reply *JobReply, job *apiJob) {
+	reply.ID = job.id
+	reply.Member = job.member.String()
+	reply.Method = job.method
+	reply.State = job.state
+	reply.Position = job.position
+	reply.Queued = unixTime(job.queued)
+	reply.Started = unixTime(job.started)
+	reply.Finished = unixTime(job.finished)
+	if job.outcome != nil {
+		reply.Result = job.outcome.Result
+		reply.Request = job.outcome.Request
+		reply.Pulse = job.outcome.Pulse
+		reply.Finality = job.outcome.Finality
+	}
+	if job.err != nil {
+		reply.Error = job.err.Error()
+		reply.ErrorCode = core.ErrorCodeOf(job.err)
+		reply.Retryable = core.IsRetryable(job.err)
+	}
+}
+
+func unixTime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
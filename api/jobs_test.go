@@ -0,0 +1,143 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestJobQueue(t *testing.T) {
+	q := newJobQueue(configuration.APIJobs{Workers: 1, QueueSize: 2, TTL: time.Minute})
+	clock := time.Now()
+	q.now = func() time.Time {
+		clock = clock.Add(time.Millisecond)
+		return clock
+	}
+	member := testutils.RandomRef()
+	ctx := context.Background()
+
+	release := make(chan struct{})
+	blocked, err := q.submit(ctx, member, "DumpAllUsers", func(ctx context.Context) (*jobOutcome, error) {
+		<-release
+		return &jobOutcome{Result: "users", Pulse: 1}, nil
+	})
+	require.NoError(t, err)
+	failing, err := q.submit(ctx, member, "BulkCreateMembers", func(ctx context.Context) (*jobOutcome, error) {
+		return nil, errors.New("failed to create")
+	})
+	require.NoError(t, err)
+	_, err = q.submit(ctx, member, "DumpAllUsers", nil)
+	require.Equal(t, errJobQueueFull, err)
+
+	job, ok := q.get(failing)
+	require.True(t, ok)
+	require.Equal(t, jobQueued, job.state)
+	require.Equal(t, 1, job.position)
+
+	q.start()
+	defer q.stop()
+	waitJob(t, q, blocked, func(job apiJob) bool { return job.state == jobRunning })
+
+	close(release)
+	waitJob(t, q, failing, func(job apiJob) bool { return job.state == jobFailed })
+
+	job, _ = q.get(blocked)
+	require.Equal(t, jobDone, job.state)
+	require.Equal(t, "users", job.outcome.Result)
+	job, _ = q.get(failing)
+	require.EqualError(t, job.err, "failed to create")
+
+	q.now = func() time.Time { return clock.Add(2 * time.Minute) }
+	_, ok = q.get(blocked)
+	require.False(t, ok)
+}
+
+func TestJobQueue_Cancel(t *testing.T) {
+	q := newJobQueue(configuration.APIJobs{Workers: 1, QueueSize: 2, TTL: time.Minute})
+	member := testutils.RandomRef()
+
+	queued, err := q.submit(context.Background(), member, "DumpAllUsers", func(ctx context.Context) (*jobOutcome, error) {
+		t.Error("cancelled job must not be executed")
+		return nil, nil
+	})
+	require.NoError(t, err)
+	job, ok := q.cancel(queued)
+	require.True(t, ok)
+	require.Equal(t, jobCancelled, job.state)
+
+	running, err := q.submit(context.Background(), member, "DumpAllUsers", func(ctx context.Context) (*jobOutcome, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	require.NoError(t, err)
+	q.start()
+	defer q.stop()
+	waitJob(t, q, running, func(job apiJob) bool { return job.state == jobRunning })
+
+	job, _ = q.cancel(running)
+	require.Equal(t, jobCancelled, job.state)
+	waitJob(t, q, running, func(job apiJob) bool { return !job.finished.IsZero() })
+	job, _ = q.get(running)
+	require.Equal(t, jobCancelled, job.state)
+	require.NoError(t, job.err)
+
+	_, ok = q.cancel("unknown")
+	require.False(t, ok)
+}
+
+func TestJobsService(t *testing.T) {
+	runner := &Runner{jobs: newJobQueue(configuration.APIJobs{Workers: 0})}
+	member := testutils.RandomRef()
+	_, err := runner.jobs.submit(context.Background(), member, "DumpAllUsers", nil)
+	require.Error(t, err)
+
+	runner.jobs = newJobQueue(configuration.APIJobs{Workers: 1, QueueSize: 1, TTL: time.Minute})
+	id, err := runner.jobs.submit(context.Background(), member, "DumpAllUsers", nil)
+	require.NoError(t, err)
+
+	service := NewJobsService(runner)
+	var rep JobReply
+	require.Error(t, service.Get(&http.Request{}, &JobsArgs{ID: "unknown"}, &rep))
+	require.NoError(t, service.Cancel(&http.Request{}, &JobsArgs{ID: id}, &rep))
+	require.Equal(t, id, rep.ID)
+	require.Equal(t, member.String(), rep.Member)
+	require.Equal(t, "DumpAllUsers", rep.Method)
+	require.Equal(t, jobCancelled, rep.State)
+	require.NotZero(t, rep.Queued)
+	require.Zero(t, rep.Started)
+	require.NotZero(t, rep.Finished)
+}
+
+// waitJob waits until snapshot of job satisfies condition.
+func waitJob(t *testing.T, q *jobQueue, id string, condition func(job apiJob) bool) {
+	for i := 0; i < 100; i++ {
+		if job, _ := q.get(id); condition(job) {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("job didn't reach expected state")
+}
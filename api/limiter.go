@@ -0,0 +1,79 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/configuration"
+)
+
+// methodLimiter enforces timeouts and concurrency limits of calls of particular member methods.
+type methodLimiter struct {
+	limits map[string]configuration.MethodLimits
+
+	lock    sync.Mutex
+	running map[string]int
+}
+
+func newMethodLimiter(limits map[string]configuration.MethodLimits) *methodLimiter {
+	// config keys are lowercased by viper, so methods are matched case-insensitively
+	lowered := make(map[string]configuration.MethodLimits, len(limits))
+	for method, l := range limits {
+		lowered[strings.ToLower(method)] = l
+	}
+	return &methodLimiter{
+		limits:  lowered,
+		running: map[string]int{},
+	}
+}
+
+// acquire takes execution slot of method, it returns false if method reached its concurrency limit.
+// Acquired slot must be released with release.
+func (l *methodLimiter) acquire(method string) bool {
+	method = strings.ToLower(method)
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	max := l.limits[method].MaxConcurrent
+	if max > 0 && l.running[method] >= max {
+		return false
+	}
+	l.running[method]++
+	return true
+}
+
+func (l *methodLimiter) release(method string) {
+	method = strings.ToLower(method)
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.running[method]--
+	if l.running[method] <= 0 {
+		delete(l.running, method)
+	}
+}
+
+// timeout returns timeout of method calls, def is used if method has no own timeout.
+func (l *methodLimiter) timeout(method string, def time.Duration) time.Duration {
+	if t := l.limits[strings.ToLower(method)].Timeout; t > 0 {
+		return t
+	}
+	return def
+}
@@ -0,0 +1,43 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+)
+
+func TestMethodLimiter(t *testing.T) {
+	l := newMethodLimiter(map[string]configuration.MethodLimits{
+		"DumpAllUsers": {Timeout: time.Minute, MaxConcurrent: 1},
+	})
+
+	require.True(t, l.acquire("DumpAllUsers"))
+	require.False(t, l.acquire("dumpallusers"), "limit is reached")
+	require.True(t, l.acquire("GetBalance"), "methods without limits aren't limited")
+	require.True(t, l.acquire("GetBalance"))
+
+	l.release("DumpAllUsers")
+	require.True(t, l.acquire("DumpAllUsers"), "released slot is taken again")
+
+	require.Equal(t, time.Minute, l.timeout("DumpAllUsers", time.Second))
+	require.Equal(t, time.Second, l.timeout("GetBalance", time.Second))
+}
@@ -20,8 +20,11 @@ import (
 	"context"
 	"crypto"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"net/http"
+	"os"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -40,19 +43,70 @@ import (
 
 // Runner implements Component for API
 type Runner struct {
-	CertificateManager  core.CertificateManager  `inject:""`
-	StorageExporter     core.StorageExporter     `inject:""`
-	ContractRequester   core.ContractRequester   `inject:""`
-	NetworkCoordinator  core.NetworkCoordinator  `inject:""`
-	GenesisDataProvider core.GenesisDataProvider `inject:""`
-	NetworkSwitcher     core.NetworkSwitcher     `inject:""`
-	NodeNetwork         core.NodeNetwork         `inject:""`
-	PulseStorage        core.PulseStorage        `inject:""`
+	CertificateManager  core.CertificateManager     `inject:""`
+	StorageExporter     core.StorageExporter        `inject:""`
+	ContractRequester   core.ContractRequester      `inject:""`
+	NetworkCoordinator  core.NetworkCoordinator     `inject:""`
+	GenesisDataProvider core.GenesisDataProvider    `inject:""`
+	NetworkSwitcher     core.NetworkSwitcher        `inject:""`
+	NodeNetwork         core.NodeNetwork            `inject:""`
+	PulseStorage        core.PulseStorage           `inject:""`
+	MessageBus          core.MessageBus             `inject:""`
+	Scheduler           core.Scheduler              `inject:""`
+	ActiveNodes         core.ActiveNodesProvider    `inject:""`
+	PulseHistory        core.PulseHistory           `inject:""`
+	ArtifactManager     core.ArtifactManager        `inject:""`
+	Traffic             core.TrafficProvider        `inject:""`
+	CryptographyService core.CryptographyService    `inject:""`
+	Timeline            core.Timeline               `inject:""`
+	ConsensusProfiler   core.ConsensusProfiler      `inject:""`
+	RTT                 core.RTTProvider            `inject:""`
+	JetPlanner          core.JetPlanner             `inject:""`
+	JetCoordinator      core.JetCoordinator         `inject:""`
+	APIRequestArchive   core.APIRequestArchive      `inject:""`
+	StateResetter       core.ObjectStateResetter    `inject:""`
+	IdentityReloader    core.IdentityReloader       `inject:""`
+	DisputeArchive      core.DisputeArchive         `inject:""`
+	NetworkParameters   core.NetworkParameters      `inject:""`
+	FinalityChecker     core.FinalityChecker        `inject:""`
+	Faucet              core.Faucet                 `inject:""`
+	HotDataMigrator     core.HotDataMigrator        `inject:""`
+	HeavySync           core.HeavySync              `inject:""`
+	NodeLoadReporter    core.NodeLoadReporter       `inject:""`
+	ConsensusRounds     core.ConsensusRounds        `inject:""`
+	Replication         core.ReplicationMonitor     `inject:""`
+	SendStats           core.SendStatsProvider      `inject:""`
+	AuditLog            core.AuditLog               `inject:""`
+	KeyRotator          core.StorageKeyRotator      `inject:""`
+	CaseBinds           core.CaseBindExporter       `inject:""`
+	TraceTargets        core.TraceTargets           `inject:""`
+	Migrator            core.ObjectMigrator         `inject:""`
+	MethodStats         core.MethodStatsProvider    `inject:""`
+	Maintenance         core.MaintenancePlanner     `inject:""`
+	Upgrades            core.UpgradeCoordinator     `inject:""`
+	LedgerQuerier       core.LedgerQuerier          `inject:""`
+	Packets             core.PacketCapture          `inject:""`
+	LoadShedder         core.LoadShedder            `inject:""`
+	Schemas             core.SchemaRegistry         `inject:""`
+	NodeMessenger       core.NodeMessenger          `inject:""`
+	GenesisReplayer     core.GenesisReplayer        `inject:""`
+	Revocations         core.CertificateRevocations `inject:""`
+	LedgerArchive       core.LedgerArchive          `inject:""`
+	NodeModes           core.NodeModes              `inject:""`
 	server              *http.Server
 	rpcServer           *rpc.Server
 	cfg                 *configuration.APIRunner
 	keyCache            map[string]crypto.PublicKey
 	cacheLock           *sync.RWMutex
+	limiter             *methodLimiter
+	authorizer          *methodAuthorizer
+	sessions            *sessionRegistry
+	subscriptions       *statusSubscriptions
+	receipts            *receiptSubscriptions
+	hints               *routingHints
+	endpoints           *endpointDirectory
+	jobs                *jobQueue
+	priorities          *priorityQuota
 	SeedManager         *seedmanager.SeedManager
 	SeedGenerator       seedmanager.SeedGenerator
 }
@@ -61,8 +115,8 @@ func checkConfig(cfg *configuration.APIRunner) error {
 	if cfg == nil {
 		return errors.New("[ checkConfig ] config is nil")
 	}
-	if cfg.Address == "" {
-		return errors.New("[ checkConfig ] Address must not be empty")
+	if cfg.Address == "" && cfg.UnixSocket == "" {
+		return errors.New("[ checkConfig ] Address must not be empty when UnixSocket is not set")
 	}
 	if len(cfg.Call) == 0 {
 		return errors.New("[ checkConfig ] Call must exist")
@@ -103,6 +157,161 @@ func (ar *Runner) registerServices(rpcServer *rpc.Server) error {
 		return errors.New("[ registerServices ] Can't RegisterService: cert")
 	}
 
+	err = rpcServer.RegisterService(NewVersionService(ar), "version")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: version")
+	}
+
+	err = rpcServer.RegisterService(NewSchedulerService(ar), "scheduler")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: scheduler")
+	}
+
+	err = rpcServer.RegisterService(NewNodesService(ar), "nodes")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: nodes")
+	}
+
+	err = rpcServer.RegisterService(NewPulsesService(ar), "pulses")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: pulses")
+	}
+
+	err = rpcServer.RegisterService(NewDeployService(ar), "deploy")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: deploy")
+	}
+
+	err = rpcServer.RegisterService(NewTimelineService(ar), "timeline")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: timeline")
+	}
+
+	err = rpcServer.RegisterService(NewConsensusService(ar), "consensus")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: consensus")
+	}
+
+	err = rpcServer.RegisterService(NewJetsService(ar), "jets")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: jets")
+	}
+
+	err = rpcServer.RegisterService(NewRequestsService(ar), "requests")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: requests")
+	}
+
+	err = rpcServer.RegisterService(NewObjectsService(ar), "objects")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: objects")
+	}
+
+	err = rpcServer.RegisterService(NewDisputesService(ar), "disputes")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: disputes")
+	}
+
+	err = rpcServer.RegisterService(NewFaucetService(ar), "faucet")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: faucet")
+	}
+
+	err = rpcServer.RegisterService(NewAuditService(ar), "audit")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: audit")
+	}
+
+	err = rpcServer.RegisterService(NewStorageService(ar), "storage")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: storage")
+	}
+
+	err = rpcServer.RegisterService(NewAliasesService(ar), "aliases")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: aliases")
+	}
+
+	err = rpcServer.RegisterService(NewCaseBindsService(ar), "casebinds")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: casebinds")
+	}
+
+	err = rpcServer.RegisterService(NewTracingService(ar), "tracing")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: tracing")
+	}
+
+	err = rpcServer.RegisterService(NewMigrationService(ar), "migration")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: migration")
+	}
+
+	err = rpcServer.RegisterService(NewMethodsService(ar), "methods")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: methods")
+	}
+
+	err = rpcServer.RegisterService(NewMaintenanceService(ar), "maintenance")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: maintenance")
+	}
+
+	err = rpcServer.RegisterService(NewNetworkService(ar), "network")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: network")
+	}
+
+	err = rpcServer.RegisterService(NewUpgradeService(ar), "upgrade")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: upgrade")
+	}
+
+	err = rpcServer.RegisterService(NewReceiptsService(ar), "receipts")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: receipts")
+	}
+
+	err = rpcServer.RegisterService(NewSchemasService(ar), "schemas")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: schemas")
+	}
+
+	err = rpcServer.RegisterService(NewEndpointsService(ar), "endpoints")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: endpoints")
+	}
+
+	err = rpcServer.RegisterService(NewGenesisService(ar), "genesis")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: genesis")
+	}
+
+	err = rpcServer.RegisterService(NewJobsService(ar), "jobs")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: jobs")
+	}
+
+	err = rpcServer.RegisterService(NewRevocationsService(ar), "revocations")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: revocations")
+	}
+
+	err = rpcServer.RegisterService(NewArchiveService(ar), "archive")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: archive")
+	}
+
+	err = rpcServer.RegisterService(NewTransactionsService(ar), "transactions")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: transactions")
+	}
+
+	err = rpcServer.RegisterService(NewNodeModesService(ar), "nodemodes")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: nodemodes")
+	}
+
 	return nil
 }
 
@@ -113,14 +322,28 @@ func NewRunner(cfg *configuration.APIRunner) (*Runner, error) {
 		return nil, errors.Wrap(err, "[ NewAPIRunner ] Bad config")
 	}
 
+	authorizer, err := newMethodAuthorizer(cfg.MethodRoles)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NewAPIRunner ] Bad method roles")
+	}
+
 	addrStr := fmt.Sprint(cfg.Address)
 	rpcServer := rpc.NewServer()
 	ar := Runner{
-		server:    &http.Server{Addr: addrStr},
+		server:    newHTTPServer(addrStr, cfg.Server),
 		rpcServer: rpcServer,
 		cfg:       cfg,
 		keyCache:  make(map[string]crypto.PublicKey),
 		cacheLock: &sync.RWMutex{},
+		limiter:   newMethodLimiter(cfg.Methods),
+		sessions:  newSessionRegistry(cfg.SessionTTL),
+
+		authorizer:    authorizer,
+		subscriptions: newStatusSubscriptions(cfg.StatusWebhooks),
+		receipts:      newReceiptSubscriptions(cfg.Receipts),
+		endpoints:     newEndpointDirectory(),
+		priorities:    newPriorityQuota(cfg.PriorityCallsPerPulse),
+		jobs:          newJobQueue(cfg.Jobs),
 	}
 
 	rpcServer.RegisterCodec(jsonrpc.NewCodec(), "application/json")
@@ -140,21 +363,117 @@ func (ar *Runner) IsAPIRunner() bool {
 // Start runs api server
 func (ar *Runner) Start(ctx context.Context) error {
 	ar.SeedManager = seedmanager.New()
-	http.HandleFunc(ar.cfg.Call, ar.callHandler())
-	http.Handle(ar.cfg.RPC, ar.rpcServer)
+	ar.hints = newRoutingHints(ar.JetCoordinator, ar.PulseStorage, ar.NodeNetwork)
+	ar.MessageBus.MustRegister(core.TypeGetNodeVersion, ar.getNodeVersionHandler)
+	ar.MessageBus.MustRegister(core.TypeGetTimeline, ar.getTimelineHandler)
+	ar.MessageBus.MustRegister(core.TypePendingRequestStatus, ar.pendingRequestStatusHandler)
+	ar.NodeMessenger.RegisterNodeHandler(endpointTopic, ar.endpointHandler)
+	http.Handle(ar.cfg.Call, ar.limitBody(ar.callHandler()))
+	http.Handle(ar.cfg.RPC, ar.limitBody(ar.shedRPC(ar.rpcServer.ServeHTTP)))
+	if ar.cfg.Query != "" {
+		http.Handle(ar.cfg.Query, ar.limitBody(ar.shedQuery(ar.queryHandler())))
+	}
+	ar.server.Handler = ar.httpHandler(http.DefaultServeMux)
 	inslog := inslogger.FromContext(ctx)
 	inslog.Info("Starting ApiRunner ...")
 	inslog.Info("Config: ", ar.cfg)
-	listener, err := net.Listen("tcp", ar.server.Addr)
+	listeners, err := ar.listen()
 	if err != nil {
-		return errors.Wrap(err, "Can't start listening")
+		return err
 	}
+	if ar.cfg.UnixSocket != "" {
+		inslog.Info("Listening on unix socket ", ar.cfg.UnixSocket)
+	}
+
+	ar.jobs.start()
+	ar.subscriptions.start()
+	for _, listener := range listeners {
+		ar.serve(ctx, listener)
+	}
+	return nil
+}
+
+// listen opens listeners of all configured addresses. If any of them fails, the already opened ones are closed,
+// so the server is either reachable on every address or on none.
+func (ar *Runner) listen() ([]net.Listener, error) {
+	var listeners []net.Listener
+	if ar.cfg.Address != "" {
+		listener, err := net.Listen("tcp", ar.server.Addr)
+		if err != nil {
+			return nil, errors.Wrap(err, "Can't start listening")
+		}
+		listeners = append(listeners, listener)
+	}
+
+	if ar.cfg.UnixSocket != "" {
+		listener, err := listenUnix(ar.cfg.UnixSocket, os.FileMode(ar.cfg.UnixSocketMode))
+		if err != nil {
+			for _, l := range listeners {
+				l.Close() //nolint
+			}
+			return nil, errors.Wrap(err, "Can't start listening on unix socket")
+		}
+		listeners = append(listeners, listener)
+	}
+	return listeners, nil
+}
+
+func (ar *Runner) serve(ctx context.Context, listener net.Listener) {
+	listener = ar.limitConnections(listener)
 	go func() {
-		if err := ar.server.Serve(listener); err != nil {
-			inslog.Error("Httpserver: ListenAndServe() error: ", err)
+		if err := ar.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			inslogger.FromContext(ctx).Error("Httpserver: ListenAndServe() error: ", err)
 		}
 	}()
-	return nil
+}
+
+// listenUnix listens on unix socket with path, removing stale socket file left by previous run.
+// Socket is created in a private directory and moved to path only after its permissions are set,
+// so it is never reachable with default permissions. Socket file is removed when listener is closed.
+func listenUnix(path string, mode os.FileMode) (net.Listener, error) {
+	if fi, err := os.Stat(path); err == nil {
+		if fi.Mode()&os.ModeSocket == 0 {
+			return nil, errors.Errorf("%s exists and is not a socket", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, errors.Wrap(err, "can't remove stale socket")
+		}
+	}
+
+	// TempDir creates directory accessible by owner only
+	dir, err := ioutil.TempDir(filepath.Dir(path), ".socket")
+	if err != nil {
+		return nil, errors.Wrap(err, "can't create socket directory")
+	}
+	defer os.RemoveAll(dir) //nolint
+
+	tmp := filepath.Join(dir, filepath.Base(path))
+	listener, err := net.ListenUnix("unix", &net.UnixAddr{Name: tmp, Net: "unix"})
+	if err != nil {
+		return nil, err
+	}
+	listener.SetUnlinkOnClose(false)
+	if err := os.Chmod(tmp, mode); err != nil {
+		listener.Close() //nolint
+		return nil, errors.Wrap(err, "can't set socket permissions")
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		listener.Close() //nolint
+		return nil, errors.Wrap(err, "can't move socket in place")
+	}
+	return &unixListener{UnixListener: listener, path: path}, nil
+}
+
+// unixListener removes socket file moved in place by listenUnix on close.
+type unixListener struct {
+	*net.UnixListener
+	path string
+}
+
+func (l *unixListener) Close() error {
+	err := l.UnixListener.Close()
+	os.Remove(l.path) //nolint
+	return err
 }
 
 // Stop stops api server
@@ -162,6 +481,8 @@ func (ar *Runner) Stop(ctx context.Context) error {
 	const timeOut = 5
 
 	inslogger.FromContext(ctx).Infof("Shutting down server gracefully ...(waiting for %d seconds)", timeOut)
+	ar.jobs.stop()
+	ar.subscriptions.stop()
 	ctxWithTimeout, cancel := context.WithTimeout(ctx, time.Duration(timeOut)*time.Second)
 	defer cancel()
 	err := ar.server.Shutdown(ctxWithTimeout)
@@ -180,7 +501,7 @@ func (ar *Runner) getMemberPubKey(ctx context.Context, ref string) (crypto.Publi
 		return publicKey, nil
 	}
 
-	reference, err := core.NewRefFromBase58(ref)
+	reference, err := core.ParseRef(ref)
 	if err != nil {
 		return nil, errors.Wrap(err, "[ getMemberPubKey ] Can't parse ref")
 	}
@@ -194,8 +515,11 @@ func (ar *Runner) getMemberPubKey(ctx context.Context, ref string) (crypto.Publi
 		return nil, errors.Wrap(err, "[ getMemberPubKey ] Can't extract response")
 	}
 
-	kp := platformpolicy.NewKeyProcessor()
-	publicKey, err = kp.ImportPublicKeyPEM([]byte(publicKeyString))
+	if ar.cfg.Secp256k1Keys {
+		publicKey, err = platformpolicy.ImportMemberPublicKey([]byte(publicKeyString))
+	} else {
+		publicKey, err = platformpolicy.NewKeyProcessor().ImportPublicKeyPEM([]byte(publicKeyString))
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, "Failed to convert public key")
 	}
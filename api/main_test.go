@@ -29,6 +29,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/testutils"
 )
 
 const HOST = "http://localhost:19101"
@@ -104,6 +105,10 @@ func TestMainTestSuite(t *testing.T) {
 
 	cm := certificate.NewCertificateManager(&certificate.Certificate{})
 	api.CertificateManager = cm
+	mb := testutils.NewMessageBusMock(t)
+	mb.MustRegisterMock.Return()
+	api.MessageBus = mb
+	api.NodeMessenger = &nodeMessenger{}
 	api.Start(ctx)
 
 	suite.Run(t, new(MainAPISuite))
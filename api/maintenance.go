@@ -0,0 +1,151 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// MaintenanceScheduleArgs is arguments of Maintenance.Schedule request.
+type MaintenanceScheduleArgs struct {
+	Start  uint32
+	End    uint32
+	Note   string
+	Reason string
+}
+
+// MaintenanceWindowReply is maintenance window agreed by the network.
+type MaintenanceWindowReply struct {
+	Issuer string
+	Start  uint32
+	End    uint32
+	Note   string
+}
+
+// MaintenanceWindowsReply is reply for Maintenance.Windows request.
+type MaintenanceWindowsReply struct {
+	Windows []MaintenanceWindowReply
+}
+
+// MaintenanceService is a service that provides API for network-wide maintenance windows.
+type MaintenanceService struct {
+	runner *Runner
+}
+
+// NewMaintenanceService creates new MaintenanceService instance.
+func NewMaintenanceService(runner *Runner) *MaintenanceService {
+	return &MaintenanceService{runner: runner}
+}
+
+// Schedule announces maintenance window to the network. During the window executors are not handed over and jets
+// are not split. Window must start at least 3 pulses after the next pulse and last at most 10 pulses. Only discovery
+// nodes can schedule maintenance. Request must be authorized with admin token.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "maintenance.Schedule",
+//	  "params": {
+//	    "Start": int, // first pulse number of the window
+//	    "End": int, // last pulse number of the window
+//	    "Note": str, // optional, operator note announced to the network, truncated to 64 bytes
+//	    "Reason": str // optional, reason recorded in audit log
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	Response structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "result": {
+//	    "Issuer": str, // reference of the node
+//	    "Start": int,
+//	    "End": int,
+//	    "Note": str
+//	  },
+//	  "id": str|int|null // same as in request
+//	}
+func (s *MaintenanceService) Schedule(r *http.Request, args *MaintenanceScheduleArgs, reply *MaintenanceWindowReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ MaintenanceService.Schedule ] Incoming request: %s, pulses: %d-%d", r.RequestURI, args.Start, args.End)
+
+	if err := s.runner.authorizeAdmin(r, "MaintenanceService"); err != nil {
+		inslog.Warnf("[ MaintenanceService.Schedule ] unauthorized request from %s: %s", r.RemoteAddr, err)
+		return err
+	}
+
+	err := s.runner.Maintenance.ScheduleMaintenance(ctx, core.PulseNumber(args.Start), core.PulseNumber(args.End), args.Note)
+	s.runner.audit(ctx, r, "maintenance.Schedule", fmt.Sprintf("%d-%d", args.Start, args.End), args.Reason, err)
+	if err != nil {
+		return errors.Wrap(err, "[ MaintenanceService.Schedule ] failed to schedule maintenance")
+	}
+	reply.Issuer = s.runner.CertificateManager.GetCertificate().GetNodeRef().String()
+	reply.Start = args.Start
+	reply.End = args.End
+	reply.Note = args.Note
+	return nil
+}
+
+// Windows returns maintenance windows agreed by the network, sorted by start.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "maintenance.Windows",
+//	  "params": {},
+//	  "id": str|int|null
+//	}
+//
+//	Response structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "result": {
+//	    "Windows": [
+//	      {
+//	        "Issuer": str, // reference of discovery node which scheduled the window
+//	        "Start": int,
+//	        "End": int,
+//	        "Note": str
+//	      }
+//	    ]
+//	  },
+//	  "id": str|int|null // same as in request
+//	}
+func (s *MaintenanceService) Windows(r *http.Request, args *struct{}, reply *MaintenanceWindowsReply) error {
+	inslogger.FromContext(context.Background()).Infof("[ MaintenanceService.Windows ] Incoming request: %s", r.RequestURI)
+
+	windows := s.runner.Maintenance.MaintenanceWindows()
+	reply.Windows = make([]MaintenanceWindowReply, 0, len(windows))
+	for _, w := range windows {
+		reply.Windows = append(reply.Windows, MaintenanceWindowReply{
+			Issuer: w.Issuer.String(),
+			Start:  uint32(w.Start),
+			End:    uint32(w.End),
+			Note:   w.Note,
+		})
+	}
+	return nil
+}
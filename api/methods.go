@@ -0,0 +1,144 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// MethodsLatencyArgs is arguments of Methods.Latency request, empty fields match any prototype or method.
+type MethodsLatencyArgs struct {
+	Prototype string
+	Method    string
+}
+
+// LatencyBucketReply is bucket of method latency histogram.
+type LatencyBucketReply struct {
+	UpperBoundMs float64
+	Count        uint64
+}
+
+// MethodLatencyReply is histogram of method execution durations.
+type MethodLatencyReply struct {
+	Prototype string
+	Method    string
+	Count     uint64
+	SumMs     float64
+	Buckets   []LatencyBucketReply
+}
+
+// MethodsLatencyReply is reply for Methods.Latency request.
+type MethodsLatencyReply struct {
+	Methods []MethodLatencyReply
+}
+
+// MethodsService is a service that provides admin API for statistics of contract methods executed by node.
+type MethodsService struct {
+	runner *Runner
+}
+
+// NewMethodsService creates new MethodsService instance.
+func NewMethodsService(runner *Runner) *MethodsService {
+	return &MethodsService{runner: runner}
+}
+
+// Latency returns histograms of contract method execution durations collected by node since start.
+// Methods beyond cardinality limit are collected under "other" prototype and method.
+// Request must be authorized with admin token.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "methods.Latency",
+//	  "params": {
+//	    "Prototype": str, // optional, reference of prototype
+//	    "Method": str // optional, method name, constructors are prefixed with "constructor:"
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	Response structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "result": {
+//	    "Methods": [
+//	      {
+//	        "Prototype": str,
+//	        "Method": str,
+//	        "Count": int, // number of executions
+//	        "SumMs": float, // total duration of executions in milliseconds
+//	        "Buckets": [
+//	          {
+//	            "UpperBoundMs": float, // zero for the last unbounded bucket
+//	            "Count": int
+//	          }
+//	        ]
+//	      }
+//	    ]
+//	  },
+//	  "id": str|int|null // same as in request
+//	}
+func (s *MethodsService) Latency(r *http.Request, args *MethodsLatencyArgs, reply *MethodsLatencyReply) error {
+	_, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ MethodsService.Latency ] Incoming request: %s", r.RequestURI)
+
+	if err := s.runner.authorizeAdmin(r, "MethodsService"); err != nil {
+		inslog.Warnf("[ MethodsService.Latency ] unauthorized request from %s: %s", r.RemoteAddr, err)
+		return err
+	}
+
+	latencies := s.runner.MethodStats.MethodLatencies()
+	if latencies == nil {
+		return errors.New("[ MethodsService.Latency ] method latency collection is disabled")
+	}
+
+	reply.Methods = make([]MethodLatencyReply, 0, len(latencies))
+	for _, l := range latencies {
+		if (args.Prototype != "" && args.Prototype != l.Prototype) || (args.Method != "" && args.Method != l.Method) {
+			continue
+		}
+		reply.Methods = append(reply.Methods, methodLatencyReply(l))
+	}
+	return nil
+}
+
+func methodLatencyReply(l core.MethodLatency) MethodLatencyReply {
+	buckets := make([]LatencyBucketReply, 0, len(l.Buckets))
+	for _, b := range l.Buckets {
+		buckets = append(buckets, LatencyBucketReply{UpperBoundMs: durationMs(b.UpperBound), Count: b.Count})
+	}
+	return MethodLatencyReply{
+		Prototype: l.Prototype,
+		Method:    l.Method,
+		Count:     l.Count,
+		SumMs:     durationMs(l.Sum),
+		Buckets:   buckets,
+	}
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
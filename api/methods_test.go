@@ -0,0 +1,83 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+)
+
+type methodStats []core.MethodLatency
+
+func (s methodStats) MethodLatencies() []core.MethodLatency {
+	return s
+}
+
+func TestMethodsService_Latency(t *testing.T) {
+	cfg := configuration.NewAPIRunner()
+	stats := methodStats{
+		{
+			Prototype: "proto",
+			Method:    "GetBalance",
+			Count:     2,
+			Sum:       3 * time.Millisecond,
+			Buckets: []core.LatencyBucket{
+				{UpperBound: time.Millisecond, Count: 1},
+				{UpperBound: 5 * time.Millisecond, Count: 1},
+				{Count: 0},
+			},
+		},
+		{Prototype: "proto", Method: "Transfer", Count: 1, Sum: 1500 * time.Microsecond},
+	}
+	service := NewMethodsService(&Runner{cfg: &cfg, MethodStats: stats})
+	var rep MethodsLatencyReply
+
+	err := service.Latency(deployRequest("secret"), &MethodsLatencyArgs{}, &rep)
+	require.Contains(t, err.Error(), "disabled")
+
+	cfg.AdminToken = "secret"
+	err = service.Latency(deployRequest("wrong"), &MethodsLatencyArgs{}, &rep)
+	require.Contains(t, err.Error(), "invalid admin token")
+
+	require.NoError(t, service.Latency(deployRequest("secret"), &MethodsLatencyArgs{}, &rep))
+	require.Len(t, rep.Methods, 2)
+	require.Equal(t, MethodLatencyReply{
+		Prototype: "proto",
+		Method:    "GetBalance",
+		Count:     2,
+		SumMs:     3,
+		Buckets:   []LatencyBucketReply{{UpperBoundMs: 1, Count: 1}, {UpperBoundMs: 5, Count: 1}, {}},
+	}, rep.Methods[0])
+
+	rep = MethodsLatencyReply{}
+	require.NoError(t, service.Latency(deployRequest("secret"), &MethodsLatencyArgs{Method: "Transfer"}, &rep))
+	require.Len(t, rep.Methods, 1)
+	require.Equal(t, 1.5, rep.Methods[0].SumMs)
+
+	rep = MethodsLatencyReply{}
+	require.NoError(t, service.Latency(deployRequest("secret"), &MethodsLatencyArgs{Prototype: "unknown"}, &rep))
+	require.Empty(t, rep.Methods)
+
+	service = NewMethodsService(&Runner{cfg: &cfg, MethodStats: methodStats(nil)})
+	err = service.Latency(deployRequest("secret"), &MethodsLatencyArgs{}, &rep)
+	require.Contains(t, err.Error(), "collection is disabled")
+}
@@ -0,0 +1,234 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// MigrationObject is exported state of object.
+type MigrationObject struct {
+	Head       string
+	Parent     string
+	Prototype  string
+	IsDelegate bool
+	Memory     []byte
+}
+
+// MigrationTree is exported subtree of objects.
+type MigrationTree struct {
+	Version int
+	Root    string
+	Objects []MigrationObject
+}
+
+// MigrationExportArgs is arguments of Migration.Export request.
+type MigrationExportArgs struct {
+	Root string
+}
+
+// MigrationImportArgs is arguments of Migration.Import request.
+type MigrationImportArgs struct {
+	Tree       MigrationTree
+	Parent     string
+	Prototypes map[string]string
+	Reason     string
+}
+
+// MigrationImportReply is reply for Migration.Import request.
+type MigrationImportReply struct {
+	References map[string]string
+}
+
+// MigrationService is a service that provides admin API for moving objects between networks.
+type MigrationService struct {
+	runner *Runner
+}
+
+// NewMigrationService creates new MigrationService instance.
+func NewMigrationService(runner *Runner) *MigrationService {
+	return &MigrationService{runner: runner}
+}
+
+// Export returns latest states of object and all its descendants, parents go before their children.
+// Request must be authorized with admin token. Memory is base64 encoded.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "migration.Export",
+//	  "params": {
+//	    "Root": str // reference of subtree root
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	Response structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "result": {
+//	    "Version": int, // version of format
+//	    "Root": str,
+//	    "Objects": [
+//	      {
+//	        "Head": str, // reference of object
+//	        "Parent": str,
+//	        "Prototype": str,
+//	        "IsDelegate": bool,
+//	        "Memory": str
+//	      }
+//	    ]
+//	  },
+//	  "id": str|int|null // same as in request
+//	}
+func (s *MigrationService) Export(r *http.Request, args *MigrationExportArgs, reply *MigrationTree) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ MigrationService.Export ] Incoming request: %s, root: %s", r.RequestURI, args.Root)
+
+	if err := s.runner.authorizeAdmin(r, "MigrationService"); err != nil {
+		inslog.Warnf("[ MigrationService.Export ] unauthorized request from %s: %s", r.RemoteAddr, err)
+		return err
+	}
+	root, err := core.ParseRef(args.Root)
+	if err != nil {
+		return errors.Wrap(err, "[ MigrationService.Export ] failed to parse reference")
+	}
+
+	tree, err := s.runner.Migrator.ExportObjects(ctx, *root)
+	if err != nil {
+		return errors.Wrap(err, "[ MigrationService.Export ] failed to export objects")
+	}
+
+	reply.Version = tree.Version
+	reply.Root = tree.Root.String()
+	reply.Objects = make([]MigrationObject, 0, len(tree.Objects))
+	for _, obj := range tree.Objects {
+		reply.Objects = append(reply.Objects, MigrationObject{
+			Head:       obj.Head.String(),
+			Parent:     obj.Parent.String(),
+			Prototype:  obj.Prototype.String(),
+			IsDelegate: obj.IsDelegate,
+			Memory:     obj.Memory,
+		})
+	}
+	return nil
+}
+
+// Import activates exported objects under parent in current pulse. Prototypes of source network are replaced with
+// mapped ones, unmapped prototypes must exist in this network. References of imported objects are replaced in their
+// memory. Request must be authorized with admin token, every import is logged with its reason.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "migration.Import",
+//	  "params": {
+//	    "Tree": {...}, // result of migration.Export
+//	    "Parent": str, // reference of new parent of subtree root
+//	    "Prototypes": {str: str}, // prototypes of this network by prototypes of source network
+//	    "Reason": str // why objects are imported, required for audit
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	Response structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "result": {
+//	    "References": {str: str} // new references by references in source network
+//	  },
+//	  "id": str|int|null // same as in request
+//	}
+func (s *MigrationService) Import(r *http.Request, args *MigrationImportArgs, reply *MigrationImportReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ MigrationService.Import ] Incoming request: %s, root: %s", r.RequestURI, args.Tree.Root)
+
+	if err := s.runner.authorizeAdmin(r, "MigrationService"); err != nil {
+		inslog.Warnf("[ MigrationService.Import ] unauthorized request from %s: %s", r.RemoteAddr, err)
+		return err
+	}
+	if args.Reason == "" {
+		return errors.New("[ MigrationService.Import ] reason is required")
+	}
+	parent, err := core.ParseRef(args.Parent)
+	if err != nil {
+		return errors.Wrap(err, "[ MigrationService.Import ] failed to parse parent reference")
+	}
+	tree, err := parseMigrationTree(&args.Tree)
+	if err != nil {
+		return errors.Wrap(err, "[ MigrationService.Import ] failed to parse tree")
+	}
+	prototypes := make(map[core.RecordRef]core.RecordRef, len(args.Prototypes))
+	for old, ref := range args.Prototypes {
+		oldRef, err := core.ParseRef(old)
+		if err != nil {
+			return errors.Wrap(err, "[ MigrationService.Import ] failed to parse prototype reference")
+		}
+		newRef, err := core.ParseRef(ref)
+		if err != nil {
+			return errors.Wrap(err, "[ MigrationService.Import ] failed to parse prototype reference")
+		}
+		prototypes[*oldRef] = *newRef
+	}
+
+	refs, err := s.runner.Migrator.ImportObjects(ctx, tree, *parent, prototypes)
+	s.runner.audit(ctx, r, "migration.Import", tree.Root.String(), args.Reason, err)
+	if err != nil {
+		return errors.Wrap(err, "[ MigrationService.Import ] failed to import objects")
+	}
+
+	reply.References = make(map[string]string, len(refs))
+	for old, ref := range refs {
+		reply.References[old.String()] = ref.String()
+	}
+	return nil
+}
+
+func parseMigrationTree(t *MigrationTree) (*core.ObjectTree, error) {
+	root, err := core.ParseRef(t.Root)
+	if err != nil {
+		return nil, err
+	}
+	tree := &core.ObjectTree{Version: t.Version, Root: *root, Objects: make([]core.ExportedObject, 0, len(t.Objects))}
+	for _, obj := range t.Objects {
+		var refs [3]core.RecordRef
+		for i, str := range []string{obj.Head, obj.Parent, obj.Prototype} {
+			ref, err := core.NewRefFromBase58(str)
+			if err != nil {
+				return nil, err
+			}
+			refs[i] = *ref
+		}
+		tree.Objects = append(tree.Objects, core.ExportedObject{
+			Head:       refs[0],
+			Parent:     refs[1],
+			Prototype:  refs[2],
+			IsDelegate: obj.IsDelegate,
+			Memory:     obj.Memory,
+		})
+	}
+	return tree, nil
+}
@@ -0,0 +1,101 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+)
+
+type objectMigrator struct {
+	tree       *core.ObjectTree
+	parent     core.RecordRef
+	prototypes map[core.RecordRef]core.RecordRef
+}
+
+func (m *objectMigrator) ExportObjects(ctx context.Context, root core.RecordRef) (*core.ObjectTree, error) {
+	return m.tree, nil
+}
+
+func (m *objectMigrator) ImportObjects(
+	ctx context.Context, tree *core.ObjectTree, parent core.RecordRef, prototypes map[core.RecordRef]core.RecordRef,
+) (map[core.RecordRef]core.RecordRef, error) {
+	m.parent, m.prototypes = parent, prototypes
+	refs := map[core.RecordRef]core.RecordRef{}
+	for _, obj := range tree.Objects {
+		refs[obj.Head] = testutils.RandomRef()
+	}
+	return refs, nil
+}
+
+func TestMigrationService(t *testing.T) {
+	cfg := configuration.NewAPIRunner()
+	root, child, domain, proto := testutils.RandomRef(), testutils.RandomRef(), testutils.RandomRef(), testutils.RandomRef()
+	migrator := &objectMigrator{tree: &core.ObjectTree{
+		Version: core.ObjectTreeFormatVersion,
+		Root:    root,
+		Objects: []core.ExportedObject{
+			{Head: root, Parent: domain, Prototype: proto, Memory: []byte{1}},
+			{Head: child, Parent: root, Prototype: proto, IsDelegate: true},
+		},
+	}}
+	service := NewMigrationService(&Runner{cfg: &cfg, Migrator: migrator})
+
+	var tree MigrationTree
+	err := service.Export(deployRequest("secret"), &MigrationExportArgs{Root: root.String()}, &tree)
+	require.Contains(t, err.Error(), "disabled")
+
+	cfg.AdminToken = "secret"
+	err = service.Export(deployRequest("wrong"), &MigrationExportArgs{Root: root.String()}, &tree)
+	require.Contains(t, err.Error(), "invalid admin token")
+	err = service.Export(deployRequest("secret"), &MigrationExportArgs{Root: "bad"}, &tree)
+	require.Contains(t, err.Error(), "failed to parse reference")
+
+	require.NoError(t, service.Export(deployRequest("secret"), &MigrationExportArgs{Root: root.String()}, &tree))
+	require.Equal(t, MigrationTree{
+		Version: core.ObjectTreeFormatVersion,
+		Root:    root.String(),
+		Objects: []MigrationObject{
+			{Head: root.String(), Parent: domain.String(), Prototype: proto.String(), Memory: []byte{1}},
+			{Head: child.String(), Parent: root.String(), Prototype: proto.String(), IsDelegate: true},
+		},
+	}, tree)
+
+	newDomain, newProto := testutils.RandomRef(), testutils.RandomRef()
+	args := &MigrationImportArgs{
+		Tree:       tree,
+		Parent:     newDomain.String(),
+		Prototypes: map[string]string{proto.String(): newProto.String()},
+	}
+	var rep MigrationImportReply
+	err = service.Import(deployRequest("secret"), args, &rep)
+	require.Contains(t, err.Error(), "reason is required")
+
+	args.Reason = "staging"
+	require.NoError(t, service.Import(deployRequest("secret"), args, &rep))
+	require.Equal(t, newDomain, migrator.parent)
+	require.Equal(t, map[core.RecordRef]core.RecordRef{proto: newProto}, migrator.prototypes)
+	require.Len(t, rep.References, 2)
+	require.Contains(t, rep.References, root.String())
+	require.Contains(t, rep.References, child.String())
+}
@@ -0,0 +1,114 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// NetworkStatsReply is reply for Network.Stats request.
+type NetworkStatsReply struct {
+	PulseNumber       uint32
+	PulseTimestamp    int64
+	ActiveNodes       int
+	NodesByRole       map[string]int
+	ConsensusDuration float64
+	ParcelsSent       int
+	Executions        uint64
+}
+
+// NetworkService is a service that provides public aggregate statistics of the network for status pages.
+type NetworkService struct {
+	runner *Runner
+}
+
+// NewNetworkService creates new NetworkService instance.
+func NewNetworkService(runner *Runner) *NetworkService {
+	return &NetworkService{runner: runner}
+}
+
+// Stats returns network height, active nodes and recent activity as seen by this node. It doesn't require admin
+// token, all values are computed from data node already has.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "network.Stats",
+//	  "params": {},
+//	  "id": str|int|null
+//	}
+//
+//	  Response structure:
+//	  {
+//	    "jsonrpc": "2.0",
+//	    "result": {
+//	      "PulseNumber": int, // latest pulse
+//	      "PulseTimestamp": int, // latest pulse timestamp, Unix time in seconds
+//	      "ActiveNodes": int, // size of active list
+//	      "NodesByRole": { // number of active nodes by role
+//	        "virtual": int,
+//	        "light_material": int,
+//	        ...
+//	      },
+//	      "ConsensusDuration": float, // median of consensus duration over recent pulses in milliseconds
+//	      "ParcelsSent": int, // number of parcels sent by node during previous pulse
+//	      "Executions": int // number of contract methods executed by node since start
+//	    },
+//	    "id": str|int|null // same as in request
+//	  }
+func (s *NetworkService) Stats(r *http.Request, args *struct{}, reply *NetworkStatsReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ NetworkService.Stats ] Incoming request: %s", r.RequestURI)
+
+	pulse, err := s.runner.PulseStorage.Current(ctx)
+	if err != nil {
+		return errors.Wrap(err, "[ NetworkService.Stats ] failed to get current pulse")
+	}
+	reply.PulseNumber = uint32(pulse.PulseNumber)
+	reply.PulseTimestamp = pulse.PulseTimestamp
+
+	nodes := s.runner.ActiveNodes.GetActiveNodesInfo(nil, nil)
+	reply.ActiveNodes = len(nodes)
+	reply.NodesByRole = map[string]int{}
+	for _, node := range nodes {
+		reply.NodesByRole[node.Role.String()]++
+	}
+
+	reply.ConsensusDuration = consensusDuration(s.runner.ConsensusProfiler.GetPhaseTimings())
+	reply.ParcelsSent = s.runner.SendStats.GetSendStats().Sent
+	for _, method := range s.runner.MethodStats.MethodLatencies() {
+		reply.Executions += method.Count
+	}
+	return nil
+}
+
+// consensusDuration sums medians of consensus phases durations in milliseconds.
+func consensusDuration(timings []core.PhaseTimings) float64 {
+	var total float64
+	for _, t := range timings {
+		total += ms(t.Send.P50) + ms(t.Wait.P50) + ms(t.Process.P50)
+	}
+	return total
+}
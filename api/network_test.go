@@ -0,0 +1,59 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestNetworkService_Stats(t *testing.T) {
+	pulseStorage := testutils.NewPulseStorageMock(t)
+	pulseStorage.CurrentMock.Return(&core.Pulse{PulseNumber: 65537, PulseTimestamp: 1000}, nil)
+	service := NewNetworkService(&Runner{
+		PulseStorage: pulseStorage,
+		ActiveNodes: &activeNodesProvider{nodes: []core.ActiveNodeInfo{
+			{ID: testutils.RandomRef(), Role: core.StaticRoleVirtual},
+			{ID: testutils.RandomRef(), Role: core.StaticRoleVirtual},
+			{ID: testutils.RandomRef(), Role: core.StaticRoleHeavyMaterial},
+		}},
+		ConsensusProfiler: consensusProfiler{
+			{Phase: "phase1", Send: core.DurationPercentiles{P50: time.Millisecond}, Wait: core.DurationPercentiles{P50: 2 * time.Millisecond}},
+			{Phase: "phase2", Process: core.DurationPercentiles{P50: 500 * time.Microsecond}},
+		},
+		SendStats:   selfCheckState{sent: core.SendStats{Sent: 42, Failed: 1}},
+		MethodStats: methodStats{{Method: "GetBalance", Count: 3}, {Method: "Transfer", Count: 2}},
+	})
+
+	var rep NetworkStatsReply
+	require.NoError(t, service.Stats(&http.Request{}, &struct{}{}, &rep))
+	require.Equal(t, NetworkStatsReply{
+		PulseNumber:       65537,
+		PulseTimestamp:    1000,
+		ActiveNodes:       3,
+		NodesByRole:       map[string]int{"virtual": 2, "heavy_material": 1},
+		ConsensusDuration: 3.5,
+		ParcelsSent:       42,
+		Executions:        5,
+	}, rep)
+}
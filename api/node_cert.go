@@ -53,7 +53,7 @@ func (s *NodeCertService) Get(r *http.Request, args *NodeCertArgs, reply *NodeCe
 
 	inslog.Infof("[ NodeCertService.Get ] Incoming request: %s", r.RequestURI)
 
-	nodeRef, err := core.NewRefFromBase58(args.Ref)
+	nodeRef, err := core.ParseRef(args.Ref)
 	if err != nil {
 		return errors.Wrap(err, "[ NodeCertService.Get ] failed to parse args.Ref")
 	}
@@ -65,3 +65,55 @@ func (s *NodeCertService) Get(r *http.Request, args *NodeCertArgs, reply *NodeCe
 	reply.Cert = cert.(*certificate.Certificate)
 	return nil
 }
+
+// NodeCertReloadArgs is arguments of Reload request.
+type NodeCertReloadArgs struct {
+	Reason string
+}
+
+// Reload switches node to renewed certificate and keys from disk without restart. Files are validated
+// before switching: certificate must belong to this node, match the keys and be signed by discovery nodes.
+// Request must be authorized with admin token.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "cert.Reload",
+//	  "params": {
+//	    "Reason": str // why certificate is reloaded, required for audit
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	Response structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "result": {
+//	    "cert": { ... } // certificate node is switched to
+//	  },
+//	  "id": str|int|null // same as in request
+//	}
+func (s *NodeCertService) Reload(r *http.Request, args *NodeCertReloadArgs, reply *NodeCertReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ NodeCertService.Reload ] Incoming request: %s", r.RequestURI)
+
+	if err := s.runner.authorizeAdmin(r, "NodeCertService"); err != nil {
+		inslog.Warnf("[ NodeCertService.Reload ] unauthorized request from %s: %s", r.RemoteAddr, err)
+		return err
+	}
+	if args.Reason == "" {
+		return errors.New("[ NodeCertService.Reload ] reason is required")
+	}
+
+	inslog.Warnf("[ NodeCertService.Reload ] reloading certificate by request from %s, reason: %s", r.RemoteAddr, args.Reason)
+	cert, err := s.runner.IdentityReloader.ReloadIdentity(ctx)
+	s.runner.audit(ctx, r, "cert.Reload", "", args.Reason, err)
+	if err != nil {
+		inslog.Warnf("[ NodeCertService.Reload ] failed to reload certificate: %s", err)
+		return errors.Wrap(err, "[ NodeCertService.Reload ]")
+	}
+
+	reply.Cert = cert.(*certificate.Certificate)
+	return nil
+}
@@ -0,0 +1,69 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/certificate"
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+)
+
+type identityReloader struct {
+	cert     core.Certificate
+	err      error
+	reloaded int
+}
+
+func (r *identityReloader) ReloadIdentity(ctx context.Context) (core.Certificate, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	r.reloaded++
+	return r.cert, nil
+}
+
+func TestNodeCertService_Reload(t *testing.T) {
+	cfg := configuration.NewAPIRunner()
+	reloader := &identityReloader{cert: &certificate.Certificate{}}
+	service := NewNodeCertService(&Runner{cfg: &cfg, IdentityReloader: reloader})
+	args := &NodeCertReloadArgs{Reason: "certificate is renewed"}
+	var rep NodeCertReply
+
+	err := service.Reload(deployRequest("secret"), args, &rep)
+	require.Contains(t, err.Error(), "disabled")
+
+	cfg.AdminToken = "secret"
+	err = service.Reload(deployRequest("wrong"), args, &rep)
+	require.Contains(t, err.Error(), "invalid admin token")
+	err = service.Reload(deployRequest("secret"), &NodeCertReloadArgs{}, &rep)
+	require.Contains(t, err.Error(), "reason is required")
+	require.Equal(t, 0, reloader.reloaded)
+
+	require.NoError(t, service.Reload(deployRequest("secret"), args, &rep))
+	require.Equal(t, 1, reloader.reloaded)
+	require.Equal(t, reloader.cert, rep.Cert)
+
+	reloader.err = errors.New("certificate expired")
+	err = service.Reload(deployRequest("secret"), args, &rep)
+	require.Contains(t, err.Error(), "certificate expired")
+}
@@ -0,0 +1,168 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// NodeModesRequestArgs is arguments of NodeModes.Request request.
+type NodeModesRequestArgs struct {
+	Mode   string
+	Reason string
+	Leave  string
+}
+
+// NodeModeTransition is a change of node mode.
+type NodeModeTransition struct {
+	From      string
+	To        string
+	Pulse     core.PulseNumber
+	Reason    string
+	Requested bool
+	Time      int64
+}
+
+// NodeModesReply is reply for NodeModes.Get and NodeModes.Request requests.
+type NodeModesReply struct {
+	Mode    string
+	History []NodeModeTransition
+}
+
+// NodeModesService is a service that provides API for operational mode of the node.
+type NodeModesService struct {
+	runner *Runner
+}
+
+// NewNodeModesService creates new NodeModesService instance.
+func NewNodeModesService(runner *Runner) *NodeModesService {
+	return &NodeModesService{runner: runner}
+}
+
+// Get returns current mode of the node and its recent transitions.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "nodemodes.Get",
+//	  "params": {},
+//	  "id": str|int|null
+//	}
+//
+//	Response structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "result": {
+//	    "Mode": str, // bootstrapping, syncing, participant, cordoned, draining or stopped
+//	    "History": [
+//	      {
+//	        "From": str,
+//	        "To": str,
+//	        "Pulse": int, // zero if pulse was unknown
+//	        "Reason": str,
+//	        "Requested": bool, // true if transition was requested by operator
+//	        "Time": int // unix time of transition
+//	      }
+//	    ]
+//	  },
+//	  "id": str|int|null // same as in request
+//	}
+func (s *NodeModesService) Get(r *http.Request, args *struct{}, reply *NodeModesReply) error {
+	inslogger.FromContext(context.Background()).Infof("[ NodeModesService.Get ] Incoming request: %s", r.RequestURI)
+
+	s.fill(reply)
+	return nil
+}
+
+// Request switches node to provided mode. Operator can cordon syncing or participant node, so it stays in the
+// network but doesn't take executor and validator roles, uncordon it back to participant and drain node, so it
+// announces leave to the network with leave reason and operator reason as a note. Other transitions are made by
+// node itself and are rejected.
+// Request must be authorized with admin token.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "nodemodes.Request",
+//	  "params": {
+//	    "Mode": str, // cordoned, participant or draining
+//	    "Reason": str, // optional, reason recorded in mode history and audit log
+//	    "Leave": str // optional for draining mode, maintenance (default), upgrade or decommission
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	Response structure is the same as for nodemodes.Get.
+func (s *NodeModesService) Request(r *http.Request, args *NodeModesRequestArgs, reply *NodeModesReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ NodeModesService.Request ] Incoming request: %s, mode: %s", r.RequestURI, args.Mode)
+
+	if err := s.runner.authorizeAdmin(r, "NodeModesService"); err != nil {
+		inslog.Warnf("[ NodeModesService.Request ] unauthorized request from %s: %s", r.RemoteAddr, err)
+		return err
+	}
+	mode, err := core.ParseNodeMode(args.Mode)
+	if err != nil {
+		return errors.Wrap(err, "[ NodeModesService.Request ] invalid mode")
+	}
+
+	if mode == core.NodeModeDraining {
+		leave := core.LeaveReasonMaintenance
+		if args.Leave != "" {
+			leave, err = core.ParseLeaveReason(args.Leave)
+			if err != nil {
+				return errors.Wrap(err, "[ NodeModesService.Request ] invalid leave reason")
+			}
+		}
+		err = s.runner.NodeModes.DrainNode(ctx, leave, args.Reason)
+	} else {
+		if args.Leave != "" {
+			return errors.New("[ NodeModesService.Request ] leave reason can be set for draining mode only")
+		}
+		err = s.runner.NodeModes.RequestNodeMode(ctx, mode, args.Reason)
+	}
+	s.runner.audit(ctx, r, "nodemodes.Request", args.Mode, args.Reason, err)
+	if err != nil {
+		return errors.Wrap(err, "[ NodeModesService.Request ] failed to switch mode")
+	}
+	s.fill(reply)
+	return nil
+}
+
+func (s *NodeModesService) fill(reply *NodeModesReply) {
+	history := s.runner.NodeModes.NodeModeHistory()
+	reply.Mode = s.runner.NodeModes.NodeMode().String()
+	reply.History = make([]NodeModeTransition, 0, len(history))
+	for _, t := range history {
+		reply.History = append(reply.History, NodeModeTransition{
+			From:      t.From.String(),
+			To:        t.To.String(),
+			Pulse:     t.Pulse,
+			Reason:    t.Reason,
+			Requested: t.Requested,
+			Time:      t.Time.Unix(),
+		})
+	}
+}
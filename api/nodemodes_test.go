@@ -0,0 +1,110 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+)
+
+type nodeModes struct {
+	mode    core.NodeMode
+	history []core.NodeModeTransition
+	leave   core.LeaveReason
+}
+
+func (m *nodeModes) NodeMode() core.NodeMode {
+	return m.mode
+}
+
+func (m *nodeModes) RequestNodeMode(ctx context.Context, mode core.NodeMode, reason string) error {
+	if mode == core.NodeModeStopped {
+		return errors.New("node can't be switched from participant to stopped mode")
+	}
+	m.history = append(m.history, core.NodeModeTransition{
+		From: m.mode, To: mode, Pulse: core.FirstPulseNumber, Reason: reason, Requested: true, Time: time.Unix(10, 0),
+	})
+	m.mode = mode
+	return nil
+}
+
+func (m *nodeModes) DrainNode(ctx context.Context, leave core.LeaveReason, note string) error {
+	m.leave = leave
+	return m.RequestNodeMode(ctx, core.NodeModeDraining, note)
+}
+
+func (m *nodeModes) NodeModeHistory() []core.NodeModeTransition {
+	return m.history
+}
+
+func TestNodeModesService_Request(t *testing.T) {
+	fake := &nodeModes{mode: core.NodeModeParticipant}
+	service := NewNodeModesService(&Runner{
+		cfg:       &configuration.APIRunner{AdminToken: "secret"},
+		NodeModes: fake,
+	})
+
+	var rep NodeModesReply
+	err := service.Request(&http.Request{Header: http.Header{}}, &NodeModesRequestArgs{Mode: "cordoned"}, &rep)
+	require.Contains(t, err.Error(), "admin token is required")
+
+	r := &http.Request{Header: http.Header{}}
+	r.Header.Set("Authorization", "Bearer secret")
+	err = service.Request(r, &NodeModesRequestArgs{Mode: "paused"}, &rep)
+	require.Contains(t, err.Error(), "unknown node mode paused")
+
+	err = service.Request(r, &NodeModesRequestArgs{Mode: "stopped"}, &rep)
+	require.Contains(t, err.Error(), "can't be switched from participant to stopped mode")
+
+	require.NoError(t, service.Request(r, &NodeModesRequestArgs{Mode: "cordoned", Reason: "disk replacement"}, &rep))
+	require.Equal(t, NodeModesReply{
+		Mode: "cordoned",
+		History: []NodeModeTransition{{
+			From:      "participant",
+			To:        "cordoned",
+			Pulse:     core.FirstPulseNumber,
+			Reason:    "disk replacement",
+			Requested: true,
+			Time:      10,
+		}},
+	}, rep)
+
+	err = service.Request(r, &NodeModesRequestArgs{Mode: "cordoned", Leave: "upgrade"}, &rep)
+	require.Contains(t, err.Error(), "draining mode only")
+	err = service.Request(r, &NodeModesRequestArgs{Mode: "draining", Leave: "vacation"}, &rep)
+	require.Contains(t, err.Error(), "unknown leave reason vacation")
+
+	require.NoError(t, service.Request(r, &NodeModesRequestArgs{Mode: "draining", Reason: "v2", Leave: "upgrade"}, &rep))
+	require.Equal(t, core.LeaveReasonUpgrade, fake.leave)
+	require.Equal(t, "v2", rep.History[1].Reason)
+}
+
+func TestNodeModesService_Get(t *testing.T) {
+	service := NewNodeModesService(&Runner{NodeModes: &nodeModes{mode: core.NodeModeSyncing}})
+
+	var rep NodeModesReply
+	require.NoError(t, service.Get(&http.Request{}, &struct{}{}, &rep))
+	require.Equal(t, NodeModesReply{Mode: "syncing", History: []NodeModeTransition{}}, rep)
+}
@@ -0,0 +1,302 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+var nodeStates = []core.NodeState{core.NodeDiscovery, core.NodeJoining, core.NodeReady}
+
+// ActiveNode is a node from active list of the network.
+type ActiveNode struct {
+	Reference string
+	Role      string
+	Address   string
+	JoinPulse uint32
+	State     string
+}
+
+// NodesReply is reply for Nodes service requests.
+type NodesReply struct {
+	Nodes []ActiveNode
+}
+
+// NodesActiveArgs is arguments of Nodes.Active request.
+type NodesActiveArgs struct {
+	Roles  []string
+	States []string
+}
+
+// NodesService is a service that provides API for getting active list of the network.
+type NodesService struct {
+	runner *Runner
+}
+
+// NewNodesService creates new NodesService instance.
+func NewNodesService(runner *Runner) *NodesService {
+	return &NodesService{runner: runner}
+}
+
+// Active returns active nodes of the network with one of requested roles and states.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "nodes.Active",
+//	  "params": {
+//	    "Roles": [str], // optional, "virtual", "light_material", "heavy_material", "api_gateway" or "observer"
+//	    "States": [str] // optional, "discovery", "joining" or "ready"
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	  Response structure:
+//	  {
+//	    "jsonrpc": "2.0",
+//	    "result": {
+//	      "Nodes": [{
+//	        "Reference": str, // reference of node
+//	        "Role": str, // role of node
+//	        "Address": str, // network address of node
+//	        "JoinPulse": int, // first pulse when node was seen in active list, zero if unknown
+//	        "State": str // state of node
+//	      }]
+//	    },
+//	    "id": str|int|null // same as in request
+//	  }
+func (s *NodesService) Active(r *http.Request, args *NodesActiveArgs, reply *NodesReply) error {
+	_, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ NodesService.Active ] Incoming request: %s", r.RequestURI)
+
+	roles := make([]core.StaticRole, 0, len(args.Roles))
+	for _, name := range args.Roles {
+		role := core.GetStaticRoleFromString(name)
+		if role == core.StaticRoleUnknown {
+			return errors.Errorf("[ NodesService.Active ] unknown role %s", name)
+		}
+		roles = append(roles, role)
+	}
+	states := make([]core.NodeState, 0, len(args.States))
+	for _, name := range args.States {
+		state, err := parseNodeState(name)
+		if err != nil {
+			return errors.Wrap(err, "[ NodesService.Active ] failed to parse state")
+		}
+		states = append(states, state)
+	}
+
+	infos := s.runner.ActiveNodes.GetActiveNodesInfo(roles, states)
+	reply.Nodes = make([]ActiveNode, len(infos))
+	for i, info := range infos {
+		reply.Nodes[i] = ActiveNode{
+			Reference: info.ID.String(),
+			Role:      info.Role.String(),
+			Address:   info.Address,
+			JoinPulse: uint32(info.JoinPulse),
+			State:     nodeStateName(info.State),
+		}
+	}
+	return nil
+}
+
+// PeerTraffic is a traffic exchanged with remote peer by packets of one type.
+type PeerTraffic struct {
+	Reference   string
+	Role        string
+	Address     string
+	Type        string
+	SentBytes   uint64
+	SentPackets uint64
+	RecvBytes   uint64
+	RecvPackets uint64
+}
+
+// NodesTrafficReply is reply for Nodes.Traffic request.
+type NodesTrafficReply struct {
+	Traffic []PeerTraffic
+}
+
+// Traffic returns network traffic of the node since start aggregated by remote peer and packet type.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "nodes.Traffic",
+//	  "params": {},
+//	  "id": str|int|null
+//	}
+//
+//	  Response structure:
+//	  {
+//	    "jsonrpc": "2.0",
+//	    "result": {
+//	      "Traffic": [{
+//	        "Reference": str, // reference of remote node, empty if node isn't in active list
+//	        "Role": str, // role of remote node, empty if node isn't in active list
+//	        "Address": str, // network address of remote node
+//	        "Type": str, // type of packets, phase for consensus packets
+//	        "SentBytes": int,
+//	        "SentPackets": int,
+//	        "RecvBytes": int,
+//	        "RecvPackets": int
+//	      }]
+//	    },
+//	    "id": str|int|null // same as in request
+//	  }
+func (s *NodesService) Traffic(r *http.Request, args *struct{}, reply *NodesTrafficReply) error {
+	_, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ NodesService.Traffic ] Incoming request: %s", r.RequestURI)
+
+	traffic := s.runner.Traffic.GetTraffic()
+	reply.Traffic = make([]PeerTraffic, len(traffic))
+	for i, t := range traffic {
+		reply.Traffic[i] = PeerTraffic{
+			Address:     t.Address,
+			Type:        t.Type,
+			SentBytes:   t.SentBytes,
+			SentPackets: t.SentPackets,
+			RecvBytes:   t.RecvBytes,
+			RecvPackets: t.RecvPackets,
+		}
+		if !t.Node.IsEmpty() {
+			reply.Traffic[i].Reference = t.Node.String()
+		}
+		if t.Role != core.StaticRoleUnknown {
+			reply.Traffic[i].Role = t.Role.String()
+		}
+	}
+	return nil
+}
+
+// CapturedPacket is metadata of packet sent or received by the node.
+type CapturedPacket struct {
+	Time      int64
+	Direction string
+	Type      string
+	Size      int
+	Reference string
+	Address   string
+	Pulse     uint32
+	Hash      string
+}
+
+// NodesPacketsArgs is arguments of Nodes.Packets request.
+type NodesPacketsArgs struct {
+	Seconds int
+}
+
+// NodesPacketsReply is reply for Nodes.Packets request.
+type NodesPacketsReply struct {
+	Packets []CapturedPacket
+}
+
+// Packets dumps metadata of packets sent and received by the node in capture window, it's used to reconstruct
+// what node saw around consensus failure. Request must be authorized with admin token.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "nodes.Packets",
+//	  "params": {
+//	    "Seconds": int // optional, only packets of last seconds are returned, whole capture window by default
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	  Response structure:
+//	  {
+//	    "jsonrpc": "2.0",
+//	    "result": {
+//	      "Packets": [{
+//	        "Time": int, // unix time of capture in nanoseconds
+//	        "Direction": str, // "sent" or "received"
+//	        "Type": str, // type of packet, phase for consensus packets
+//	        "Size": int, // size of packet in bytes
+//	        "Reference": str, // reference of remote node, empty if node isn't known
+//	        "Address": str, // network address of remote node
+//	        "Pulse": int, // pulse node was in
+//	        "Hash": str // hex encoded hash, the same packet has the same hash on sender and receiver
+//	      }]
+//	    },
+//	    "id": str|int|null // same as in request
+//	  }
+func (s *NodesService) Packets(r *http.Request, args *NodesPacketsArgs, reply *NodesPacketsReply) error {
+	_, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ NodesService.Packets ] Incoming request: %s", r.RequestURI)
+
+	if err := s.runner.authorizeAdmin(r, "NodesService"); err != nil {
+		inslog.Warnf("[ NodesService.Packets ] unauthorized request from %s: %s", r.RemoteAddr, err)
+		return err
+	}
+
+	var since time.Time
+	if args.Seconds > 0 {
+		since = time.Now().Add(-time.Duration(args.Seconds) * time.Second)
+	}
+	packets := s.runner.Packets.GetCapturedPackets()
+	reply.Packets = make([]CapturedPacket, 0, len(packets))
+	for _, p := range packets {
+		if p.Time.Before(since) {
+			continue
+		}
+		captured := CapturedPacket{
+			Time:      p.Time.UnixNano(),
+			Direction: "received",
+			Type:      p.Type,
+			Size:      p.Size,
+			Address:   p.Address,
+			Pulse:     uint32(p.Pulse),
+			Hash:      hex.EncodeToString(p.Hash),
+		}
+		if p.Sent {
+			captured.Direction = "sent"
+		}
+		if !p.Node.IsEmpty() {
+			captured.Reference = p.Node.String()
+		}
+		reply.Packets = append(reply.Packets, captured)
+	}
+	return nil
+}
+
+// nodeStateName returns name of state without "Node" prefix in lower case, e.g. "ready" for core.NodeReady.
+func nodeStateName(state core.NodeState) string {
+	return strings.ToLower(strings.TrimPrefix(state.String(), "Node"))
+}
+
+func parseNodeState(name string) (core.NodeState, error) {
+	for _, state := range nodeStates {
+		if strings.EqualFold(name, nodeStateName(state)) || strings.EqualFold(name, state.String()) {
+			return state, nil
+		}
+	}
+	return 0, errors.Errorf("unknown node state %s", name)
+}
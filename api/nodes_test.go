@@ -0,0 +1,140 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+)
+
+type activeNodesProvider struct {
+	roles  []core.StaticRole
+	states []core.NodeState
+	nodes  []core.ActiveNodeInfo
+}
+
+func (p *activeNodesProvider) GetActiveNodesInfo(roles []core.StaticRole, states []core.NodeState) []core.ActiveNodeInfo {
+	p.roles, p.states = roles, states
+	return p.nodes
+}
+
+func TestNodesService_Active(t *testing.T) {
+	ref := testutils.RandomRef()
+	provider := &activeNodesProvider{nodes: []core.ActiveNodeInfo{{
+		ID:        ref,
+		Role:      core.StaticRoleLightMaterial,
+		Address:   "127.0.0.1:1",
+		JoinPulse: core.FirstPulseNumber,
+		State:     core.NodeReady,
+	}}}
+	service := NewNodesService(&Runner{ActiveNodes: provider})
+
+	var rep NodesReply
+	err := service.Active(&http.Request{}, &NodesActiveArgs{
+		Roles:  []string{"light_material"},
+		States: []string{"ready", "NodeJoining"},
+	}, &rep)
+	require.NoError(t, err)
+	require.Equal(t, []core.StaticRole{core.StaticRoleLightMaterial}, provider.roles)
+	require.Equal(t, []core.NodeState{core.NodeReady, core.NodeJoining}, provider.states)
+	require.Equal(t, []ActiveNode{{
+		Reference: ref.String(),
+		Role:      "light_material",
+		Address:   "127.0.0.1:1",
+		JoinPulse: uint32(core.FirstPulseNumber),
+		State:     "ready",
+	}}, rep.Nodes)
+
+	require.Error(t, service.Active(&http.Request{}, &NodesActiveArgs{Roles: []string{"pulsar"}}, &rep))
+	require.Error(t, service.Active(&http.Request{}, &NodesActiveArgs{States: []string{"gone"}}, &rep))
+}
+
+type trafficProvider []core.PeerTraffic
+
+func (p trafficProvider) GetTraffic() []core.PeerTraffic {
+	return p
+}
+
+func TestNodesService_Traffic(t *testing.T) {
+	ref := testutils.RandomRef()
+	service := NewNodesService(&Runner{Traffic: trafficProvider{
+		{
+			Node: ref, Role: core.StaticRoleVirtual, Address: "127.0.0.1:1", Type: "RPC",
+			SentBytes: 10, SentPackets: 1, RecvBytes: 20, RecvPackets: 2,
+		},
+		{Address: "127.0.0.1:2", Type: "Phase1", RecvBytes: 5, RecvPackets: 1},
+	}})
+
+	var rep NodesTrafficReply
+	require.NoError(t, service.Traffic(&http.Request{}, &struct{}{}, &rep))
+	require.Equal(t, []PeerTraffic{
+		{
+			Reference: ref.String(), Role: "virtual", Address: "127.0.0.1:1", Type: "RPC",
+			SentBytes: 10, SentPackets: 1, RecvBytes: 20, RecvPackets: 2,
+		},
+		{Address: "127.0.0.1:2", Type: "Phase1", RecvBytes: 5, RecvPackets: 1},
+	}, rep.Traffic)
+}
+
+type packetCapture []core.CapturedPacket
+
+func (c packetCapture) GetCapturedPackets() []core.CapturedPacket {
+	return c
+}
+
+func TestNodesService_Packets(t *testing.T) {
+	ref := testutils.RandomRef()
+	now := time.Now()
+	service := NewNodesService(&Runner{
+		cfg: &configuration.APIRunner{AdminToken: "secret"},
+		Packets: packetCapture{
+			{
+				Time: now.Add(-time.Minute), Sent: true, Type: "Phase1", Size: 100,
+				Address: "127.0.0.1:2", Pulse: 1, Hash: []byte{1},
+			},
+			{Time: now, Type: "RPC", Size: 10, Node: ref, Address: "127.0.0.1:1", Pulse: 2, Hash: []byte{0xab}},
+		},
+	})
+
+	var rep NodesPacketsReply
+	require.Error(t, service.Packets(deployRequest(""), &NodesPacketsArgs{}, &rep))
+	require.Error(t, service.Packets(deployRequest("wrong"), &NodesPacketsArgs{}, &rep))
+
+	require.NoError(t, service.Packets(deployRequest("secret"), &NodesPacketsArgs{}, &rep))
+	require.Equal(t, []CapturedPacket{
+		{
+			Time: now.Add(-time.Minute).UnixNano(), Direction: "sent", Type: "Phase1", Size: 100,
+			Address: "127.0.0.1:2", Pulse: 1, Hash: "01",
+		},
+		{
+			Time: now.UnixNano(), Direction: "received", Type: "RPC", Size: 10,
+			Reference: ref.String(), Address: "127.0.0.1:1", Pulse: 2, Hash: "ab",
+		},
+	}, rep.Packets)
+
+	rep = NodesPacketsReply{}
+	require.NoError(t, service.Packets(deployRequest("secret"), &NodesPacketsArgs{Seconds: 10}, &rep))
+	require.Len(t, rep.Packets, 1)
+	require.Equal(t, "RPC", rep.Packets[0].Type)
+}
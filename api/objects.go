@@ -0,0 +1,110 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// ObjectsResetArgs is arguments of Objects.Reset request.
+type ObjectsResetArgs struct {
+	Reference string
+	Reason    string
+}
+
+// ObjectsResetReply is reply for Objects.Reset request, it describes dropped execution state.
+type ObjectsResetReply struct {
+	InPending             bool
+	PendingConfirmed      bool
+	QueueLength           int
+	LedgerHasMoreRequests bool
+}
+
+// ObjectsService is a service that provides admin API for objects executed by the node.
+type ObjectsService struct {
+	runner *Runner
+}
+
+// NewObjectsService creates new ObjectsService instance.
+func NewObjectsService(runner *Runner) *ObjectsService {
+	return &ObjectsService{runner: runner}
+}
+
+// Reset drops execution state of object stuck on the node, e.g. in pending with no confirmations from previous
+// executor, and rebuilds it from requests pending on ledger. Request must be authorized with admin token,
+// every reset is logged with its reason.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "objects.Reset",
+//	  "params": {
+//	    "Reference": str, // reference of object
+//	    "Reason": str // why object is reset, required for audit
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	Response structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "result": { // dropped execution state
+//	    "InPending": bool,
+//	    "PendingConfirmed": bool,
+//	    "QueueLength": int,
+//	    "LedgerHasMoreRequests": bool
+//	  },
+//	  "id": str|int|null // same as in request
+//	}
+func (s *ObjectsService) Reset(r *http.Request, args *ObjectsResetArgs, reply *ObjectsResetReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ ObjectsService.Reset ] Incoming request: %s, object: %s", r.RequestURI, args.Reference)
+
+	if err := s.runner.authorizeAdmin(r, "ObjectsService"); err != nil {
+		inslog.Warnf("[ ObjectsService.Reset ] unauthorized request from %s: %s", r.RemoteAddr, err)
+		return err
+	}
+	if args.Reason == "" {
+		return errors.New("[ ObjectsService.Reset ] reason is required")
+	}
+	object, err := core.ParseRef(args.Reference)
+	if err != nil {
+		return errors.Wrap(err, "[ ObjectsService.Reset ] failed to parse reference")
+	}
+
+	inslog.Warnf("[ ObjectsService.Reset ] resetting execution state of %s by request from %s, reason: %s", object, r.RemoteAddr, args.Reason)
+	summary, err := s.runner.StateResetter.ResetExecutionState(ctx, *object)
+	s.runner.audit(ctx, r, "objects.Reset", object.String(), args.Reason, err)
+	if err != nil {
+		inslog.Warnf("[ ObjectsService.Reset ] failed to reset execution state of %s: %s", object, err)
+		return errors.Wrap(err, "[ ObjectsService.Reset ] failed to reset execution state")
+	}
+
+	reply.InPending = summary.InPending
+	reply.PendingConfirmed = summary.PendingConfirmed
+	reply.QueueLength = summary.QueueLength
+	reply.LedgerHasMoreRequests = summary.LedgerHasMoreRequests
+	return nil
+}
@@ -0,0 +1,82 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+)
+
+type stateResetter struct {
+	reset []core.RecordRef
+	err   error
+}
+
+func (r *stateResetter) ResetExecutionState(ctx context.Context, object core.RecordRef) (*core.ExecutionStateSummary, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	r.reset = append(r.reset, object)
+	return &core.ExecutionStateSummary{InPending: true, QueueLength: 3}, nil
+}
+
+func TestObjectsService_Reset(t *testing.T) {
+	cfg := configuration.NewAPIRunner()
+	resetter := &stateResetter{}
+	service := NewObjectsService(&Runner{cfg: &cfg, StateResetter: resetter})
+	object := testutils.RandomRef()
+	args := &ObjectsResetArgs{Reference: object.String(), Reason: "stuck in pending"}
+	var rep ObjectsResetReply
+
+	err := service.Reset(deployRequest("secret"), args, &rep)
+	require.Contains(t, err.Error(), "disabled")
+
+	cfg.AdminToken = "secret"
+	err = service.Reset(deployRequest(""), args, &rep)
+	require.Contains(t, err.Error(), "admin token is required")
+	err = service.Reset(deployRequest("wrong"), args, &rep)
+	require.Contains(t, err.Error(), "invalid admin token")
+	err = service.Reset(deployRequest("secret"), &ObjectsResetArgs{Reference: object.String()}, &rep)
+	require.Contains(t, err.Error(), "reason is required")
+	err = service.Reset(deployRequest("secret"), &ObjectsResetArgs{Reference: "bad", Reason: "stuck"}, &rep)
+	require.Contains(t, err.Error(), "failed to parse reference")
+	require.Empty(t, resetter.reset)
+
+	require.NoError(t, service.Reset(deployRequest("secret"), args, &rep))
+	require.Equal(t, []core.RecordRef{object}, resetter.reset)
+	require.Equal(t, ObjectsResetReply{InPending: true, QueueLength: 3}, rep)
+
+	resetter.err = errors.New("object is being executed")
+	err = service.Reset(deployRequest("secret"), args, &rep)
+	require.Contains(t, err.Error(), "object is being executed")
+}
+
+func TestCheckAdminToken(t *testing.T) {
+	r, err := http.NewRequest("POST", "/api/rpc", nil)
+	require.NoError(t, err)
+	require.Error(t, checkAdminToken(r, "secret"))
+	r.Header.Set("Authorization", "Bearer secret")
+	require.NoError(t, checkAdminToken(r, "secret"))
+}
@@ -0,0 +1,67 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package api
+
+import (
+	"context"
+	"sync"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// priorityQuota limits number of calls each member marks as latency-sensitive in one pulse, so prioritization
+// can't be abused to push calls of other members back. Counters are dropped on pulse change.
+type priorityQuota struct {
+	limit int
+
+	lock  sync.Mutex
+	pulse core.PulseNumber
+	used  map[core.RecordRef]int
+}
+
+func newPriorityQuota(limit int) *priorityQuota {
+	return &priorityQuota{limit: limit, used: map[core.RecordRef]int{}}
+}
+
+// take accounts prioritized call of member in pulse, false is returned if member exhausted its quota.
+func (q *priorityQuota) take(member core.RecordRef, pulse core.PulseNumber) bool {
+	if q.limit <= 0 {
+		return false
+	}
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if q.pulse != pulse {
+		q.pulse = pulse
+		q.used = map[core.RecordRef]int{}
+	}
+	if q.used[member] >= q.limit {
+		return false
+	}
+	q.used[member]++
+	return true
+}
+
+// prioritize checks if call of member requested as latency-sensitive may be prioritized in current pulse.
+func (ar *Runner) prioritize(ctx context.Context, member core.RecordRef) bool {
+	pulse, err := ar.PulseStorage.Current(ctx)
+	if err != nil {
+		inslogger.FromContext(ctx).Warn("can't get current pulse, call isn't prioritized: ", err)
+		return false
+	}
+	return ar.priorities.take(member, pulse.PulseNumber)
+}
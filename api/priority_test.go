@@ -0,0 +1,41 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestPriorityQuota(t *testing.T) {
+	a, b := testutils.RandomRef(), testutils.RandomRef()
+	q := newPriorityQuota(2)
+
+	assert.True(t, q.take(a, core.FirstPulseNumber))
+	assert.True(t, q.take(a, core.FirstPulseNumber))
+	assert.False(t, q.take(a, core.FirstPulseNumber))
+	assert.True(t, q.take(b, core.FirstPulseNumber))
+
+	// quota is renewed in the next pulse
+	assert.True(t, q.take(a, core.FirstPulseNumber+1))
+
+	disabled := newPriorityQuota(0)
+	assert.False(t, disabled.take(a, core.FirstPulseNumber))
+}
@@ -0,0 +1,143 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+const (
+	defaultPulsesPageSize = 100
+	maxPulsesPageSize     = 1000
+)
+
+// PulseInfo is a stored pulse.
+type PulseInfo struct {
+	PulseNumber uint32
+	Prev        *uint32
+	Next        *uint32
+	Timestamp   int64
+	EntropyHash string
+	Signed      bool
+}
+
+// PulsesListArgs is arguments of Pulses.List request.
+type PulsesListArgs struct {
+	From uint32
+	To   uint32
+	Size int
+}
+
+// PulsesReply is reply for Pulses service requests.
+type PulsesReply struct {
+	Pulses   []PulseInfo
+	NextFrom *uint32
+}
+
+// PulsesService is a service that provides API for getting stored pulses.
+type PulsesService struct {
+	runner *Runner
+}
+
+// NewPulsesService creates new PulsesService instance.
+func NewPulsesService(runner *Runner) *PulsesService {
+	return &PulsesService{runner: runner}
+}
+
+// List returns page of stored pulses.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "pulses.List",
+//	  "params": {
+//	    // Pulse number from which pulses should be returned, use 0 to start from the first stored pulse.
+//	    "From": int,
+//	    // Last pulse number of range, use 0 to return pulses up to the latest one.
+//	    "To": int,
+//	    // Max number of pulses in reply, 100 by default, 1000 at most.
+//	    "Size": int
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	Response structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "result": {
+//	    "Pulses": [{
+//	      "PulseNumber": int,
+//	      "Prev": int|null, // previous stored pulse
+//	      "Next": int|null, // next stored pulse
+//	      "Timestamp": int, // pulse timestamp, Unix time in seconds
+//	      "EntropyHash": str, // hex encoded SHA-256 of pulse entropy
+//	      "Signed": bool // pulse has pulsar signatures
+//	    }],
+//	    "NextFrom": int|null // Put it as "From" param to get next page, null if there are no more pulses in range.
+//	  },
+//	  "id": str|int|null // same as in request
+//	}
+func (s *PulsesService) List(r *http.Request, args *PulsesListArgs, reply *PulsesReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ PulsesService.List ] Incoming request: %s", r.RequestURI)
+
+	size := args.Size
+	if size == 0 {
+		size = defaultPulsesPageSize
+	}
+	if size < 0 || size > maxPulsesPageSize {
+		return errors.Errorf("[ PulsesService.List ] size must be in range [1, %d]", maxPulsesPageSize)
+	}
+
+	result, err := s.runner.PulseHistory.GetPulses(ctx, core.PulseNumber(args.From), core.PulseNumber(args.To), size)
+	if err != nil {
+		return errors.Wrap(err, "[ PulsesService.List ] failed to get pulses")
+	}
+
+	reply.Pulses = make([]PulseInfo, len(result.Pulses))
+	for i, p := range result.Pulses {
+		entropyHash := sha256.Sum256(p.Pulse.Entropy[:])
+		reply.Pulses[i] = PulseInfo{
+			PulseNumber: uint32(p.Pulse.PulseNumber),
+			Prev:        pulseNumberPtr(p.Prev),
+			Next:        pulseNumberPtr(p.Next),
+			Timestamp:   p.Pulse.PulseTimestamp,
+			EntropyHash: hex.EncodeToString(entropyHash[:]),
+			Signed:      len(p.Pulse.Signs) > 0,
+		}
+	}
+	reply.NextFrom = pulseNumberPtr(result.NextFrom)
+	return nil
+}
+
+func pulseNumberPtr(pn *core.PulseNumber) *uint32 {
+	if pn == nil {
+		return nil
+	}
+	n := uint32(*pn)
+	return &n
+}
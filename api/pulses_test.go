@@ -0,0 +1,81 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+)
+
+type pulseHistory struct {
+	from, to core.PulseNumber
+	size     int
+	result   *core.PulseHistoryResult
+}
+
+func (h *pulseHistory) GetPulses(ctx context.Context, from, to core.PulseNumber, size int) (*core.PulseHistoryResult, error) {
+	h.from, h.to, h.size = from, to, size
+	return h.result, nil
+}
+
+func TestPulsesService_List(t *testing.T) {
+	prev, next := core.PulseNumber(core.FirstPulseNumber), core.PulseNumber(core.FirstPulseNumber+20)
+	entropy := core.Entropy{1, 2, 3}
+	history := &pulseHistory{result: &core.PulseHistoryResult{
+		Pulses: []core.StoredPulse{{
+			Pulse: core.Pulse{
+				PulseNumber:    core.FirstPulseNumber + 10,
+				PulseTimestamp: 42,
+				Entropy:        entropy,
+				Signs:          map[string]core.PulseSenderConfirmation{"pulsar": {}},
+			},
+			Prev: &prev,
+			Next: &next,
+		}},
+		NextFrom: &next,
+	}}
+	service := NewPulsesService(&Runner{PulseHistory: history})
+
+	var rep PulsesReply
+	err := service.List(&http.Request{}, &PulsesListArgs{From: 1, To: 2}, &rep)
+	require.NoError(t, err)
+	require.Equal(t, core.PulseNumber(1), history.from)
+	require.Equal(t, core.PulseNumber(2), history.to)
+	require.Equal(t, defaultPulsesPageSize, history.size)
+
+	hash := sha256.Sum256(entropy[:])
+	prevNumber, nextNumber := uint32(prev), uint32(next)
+	require.Equal(t, []PulseInfo{{
+		PulseNumber: uint32(core.FirstPulseNumber + 10),
+		Prev:        &prevNumber,
+		Next:        &nextNumber,
+		Timestamp:   42,
+		EntropyHash: hex.EncodeToString(hash[:]),
+		Signed:      true,
+	}}, rep.Pulses)
+	require.Equal(t, nextNumber, *rep.NextFrom)
+
+	err = service.List(&http.Request{}, &PulsesListArgs{Size: maxPulsesPageSize + 1}, &rep)
+	require.Error(t, err)
+}
@@ -0,0 +1,110 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// QueriedRecord is a line of ledger query response.
+type QueriedRecord struct {
+	ID     string
+	Jet    string
+	Pulse  uint32
+	Type   string
+	Object string `json:",omitempty"`
+	Data   interface{}
+}
+
+// queryError is the last line of ledger query response if query failed after records were streamed.
+type queryError struct {
+	Error string
+}
+
+// queryHandler streams records stored on heavy node matching ledger query as JSON lines. Query is passed
+// in "q" parameter, e.g. "type=request pulse=65537..65600 object=4K3N limit=100", see exporter.Query for syntax.
+// Queries are served by heavy material nodes only and must be authorized with admin token.
+func (ar *Runner) queryHandler() http.HandlerFunc {
+	return func(response http.ResponseWriter, req *http.Request) {
+		ctx, inslog := inslogger.WithTraceField(req.Context(), utils.RandTraceID())
+		query := req.FormValue("q")
+
+		inslog.Infof("[ queryHandler ] Incoming request: %s, query: %q", req.RequestURI, query)
+
+		if err := ar.authorizeAdmin(req, "queryHandler"); err != nil {
+			if errors.Cause(err) == errAdminDisabled {
+				http.Error(response, err.Error(), http.StatusForbidden)
+				return
+			}
+			inslog.Warnf("[ queryHandler ] unauthorized request from %s: %s", req.RemoteAddr, err)
+			http.Error(response, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if ar.CertificateManager.GetCertificate().GetRole() != core.StaticRoleHeavyMaterial {
+			http.Error(response, "ledger queries are served by heavy material nodes only", http.StatusNotImplemented)
+			return
+		}
+
+		streamed := false
+		flusher, _ := response.(http.Flusher)
+		encoder := json.NewEncoder(response)
+		err := ar.LedgerQuerier.QueryRecords(ctx, query, func(rec core.QueriedRecord) error {
+			if !streamed {
+				response.Header().Set("Content-Type", "application/x-ndjson")
+				streamed = true
+			}
+			if err := encoder.Encode(queriedRecord(rec)); err != nil {
+				return errors.Wrap(err, "failed to write record")
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+			return nil
+		})
+		if err == nil {
+			return
+		}
+		inslog.Warnf("[ queryHandler ] query %q failed: %s", query, err)
+		if !streamed {
+			http.Error(response, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// status is already sent with the first record, so error is reported in the last line
+		_ = encoder.Encode(queryError{Error: err.Error()})
+	}
+}
+
+func queriedRecord(rec core.QueriedRecord) QueriedRecord {
+	res := QueriedRecord{
+		ID:    rec.ID.String(),
+		Jet:   rec.Jet.DebugString(),
+		Pulse: uint32(rec.ID.Pulse()),
+		Type:  rec.Type,
+		Data:  rec.Data,
+	}
+	if rec.Object != nil {
+		res.Object = rec.Object.String()
+	}
+	return res
+}
@@ -0,0 +1,100 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+)
+
+type ledgerQuerier struct {
+	query   string
+	records []core.QueriedRecord
+	err     error
+}
+
+func (q *ledgerQuerier) QueryRecords(ctx context.Context, query string, fn func(core.QueriedRecord) error) error {
+	q.query = query
+	for _, rec := range q.records {
+		if err := fn(rec); err != nil {
+			return err
+		}
+	}
+	return q.err
+}
+
+func TestRunner_QueryHandler(t *testing.T) {
+	cert := testutils.NewCertificateMock(t)
+	cert.GetRoleMock.Return(core.StaticRoleHeavyMaterial)
+	cm := testutils.NewCertificateManagerMock(t)
+	cm.GetCertificateMock.Return(cert)
+
+	object := testutils.RandomID()
+	querier := &ledgerQuerier{records: []core.QueriedRecord{
+		{ID: testutils.RandomID(), Jet: core.TODOJetID, Type: "RequestRecord", Object: &object},
+		{ID: testutils.RandomID(), Jet: core.TODOJetID, Type: "GenesisRecord"},
+	}}
+	cfg := configuration.NewAPIRunner()
+	runner := &Runner{cfg: &cfg, CertificateManager: cm, LedgerQuerier: querier}
+
+	query := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/query?q=type%3Drequest+limit%3D2", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		runner.queryHandler()(rec, req)
+		return rec
+	}
+
+	require.Equal(t, http.StatusForbidden, query("secret").Code)
+	cfg.AdminToken = "secret"
+	require.Equal(t, http.StatusUnauthorized, query("wrong").Code)
+
+	rec := query("secret")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "type=request limit=2", querier.query)
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	require.Len(t, lines, 2)
+	var first QueriedRecord
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	require.Equal(t, querier.records[0].ID.String(), first.ID)
+	require.Equal(t, object.String(), first.Object)
+	require.Equal(t, "RequestRecord", first.Type)
+
+	// error after records are streamed is reported in the last line
+	querier.err = errors.New("disk failure")
+	rec = query("secret")
+	lines = strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	require.Len(t, lines, 3)
+	require.Contains(t, lines[2], "disk failure")
+
+	querier.records = nil
+	require.Equal(t, http.StatusBadRequest, query("secret").Code)
+
+	cert.GetRoleMock.Return(core.StaticRoleVirtual)
+	require.Equal(t, http.StatusNotImplemented, query("secret").Code)
+}
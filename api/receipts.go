@@ -0,0 +1,400 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/platformpolicy"
+)
+
+// TransferReceipt is a JSON body of receipt pushed to webhooks of member when transfer credits its wallet.
+// Signature is made by API node which served the transfer over CBOR-serialized (Member, From, Amount, Request, Pulse),
+// see TransferReceipt.Verify. Receipt may be delivered more than once, receivers deduplicate receipts by Request.
+type TransferReceipt struct {
+	Member    string           `json:"member"`
+	From      string           `json:"from"`
+	Amount    uint64           `json:"amount"`
+	Request   string           `json:"request"`
+	Pulse     core.PulseNumber `json:"pulse"`
+	Node      string           `json:"node"`
+	Signature []byte           `json:"signature"`
+}
+
+// Verify checks that receipt was signed with provided key of API node. Receivers should take the key from
+// receipts.Subscribe reply and never from receipt itself.
+func (r *TransferReceipt) Verify(key crypto.PublicKey) error {
+	data, err := r.signedData()
+	if err != nil {
+		return errors.Wrap(err, "[ TransferReceipt.Verify ]")
+	}
+	if !scheme.Verifier(key).Verify(core.SignatureFromBytes(r.Signature), data) {
+		return errors.New("[ TransferReceipt.Verify ] incorrect signature")
+	}
+	return nil
+}
+
+func (r *TransferReceipt) signedData() ([]byte, error) {
+	member, err := core.ParseRef(r.Member)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse member reference")
+	}
+	from, err := core.ParseRef(r.From)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse sender reference")
+	}
+	request, err := core.ParseRef(r.Request)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse request reference")
+	}
+	return core.MarshalArgs(*member, *from, r.Amount, *request, r.Pulse)
+}
+
+// receiptSubscriptions keeps webhooks members subscribed to receipts of incoming transfers.
+type receiptSubscriptions struct {
+	cfg    configuration.ReceiptWebhooks
+	client *http.Client
+
+	lock sync.RWMutex
+	subs map[core.RecordRef][]string
+}
+
+func newReceiptSubscriptions(cfg configuration.ReceiptWebhooks) *receiptSubscriptions {
+	return &receiptSubscriptions{
+		cfg:    cfg,
+		client: &http.Client{Timeout: pushTimeout},
+		subs:   map[core.RecordRef][]string{},
+	}
+}
+
+// subscribe adds webhook of member, subscribing the same webhook again does nothing.
+func (s *receiptSubscriptions) subscribe(member core.RecordRef, webhook string) error {
+	if s.cfg.MaxPerMember <= 0 {
+		return errors.New("receipt webhooks are disabled on this node")
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, u := range s.subs[member] {
+		if u == webhook {
+			return nil
+		}
+	}
+	if len(s.subs[member]) >= s.cfg.MaxPerMember {
+		return errors.Errorf("member may subscribe at most %d webhooks", s.cfg.MaxPerMember)
+	}
+	s.subs[member] = append(s.subs[member], webhook)
+	return nil
+}
+
+// unsubscribe removes webhook of member and reports whether it was subscribed.
+func (s *receiptSubscriptions) unsubscribe(member core.RecordRef, webhook string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	urls := s.subs[member]
+	for i, u := range urls {
+		if u != webhook {
+			continue
+		}
+		urls = append(urls[:i:i], urls[i+1:]...)
+		if len(urls) == 0 {
+			delete(s.subs, member)
+		} else {
+			s.subs[member] = urls
+		}
+		return true
+	}
+	return false
+}
+
+// empty reports whether no member is subscribed, so transfers needn't be inspected.
+func (s *receiptSubscriptions) empty() bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return len(s.subs) == 0
+}
+
+func (s *receiptSubscriptions) webhooks(member core.RecordRef) []string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return append([]string(nil), s.subs[member]...)
+}
+
+// push delivers receipt to webhooks of its member.
+func (s *receiptSubscriptions) push(ctx context.Context, member core.RecordRef, receipt *TransferReceipt) {
+	urls := s.webhooks(member)
+	if len(urls) == 0 {
+		return
+	}
+	body, err := json.Marshal(receipt)
+	if err != nil {
+		inslogger.FromContext(ctx).Error(errors.Wrap(err, "[ push ] Can't marshal receipt"))
+		return
+	}
+	for _, webhook := range urls {
+		go func(webhook string) {
+			if err := s.deliver(webhook, body); err != nil {
+				inslogger.FromContext(ctx).Warn(errors.Wrapf(err, "[ push ] Can't push receipt of %s", receipt.Request))
+			}
+		}(webhook)
+	}
+}
+
+// deliver posts receipt to webhook until it replies with 2xx status, delay between attempts doubles.
+func (s *receiptSubscriptions) deliver(webhook string, body []byte) error {
+	var err error
+	delay := s.cfg.RetryDelay
+	for attempt := 1; ; attempt++ {
+		err = s.post(webhook, body)
+		if err == nil || attempt >= s.cfg.Attempts {
+			return err
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+func (s *receiptSubscriptions) post(webhook string, body []byte) error {
+	resp, err := s.client.Post(webhook, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close() // nolint: errcheck
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("webhook replied with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// pushTransferReceipt pushes receipt of direct transfer served by the node to webhooks of recipient. Transfers held
+// until confirmation don't credit recipient at once and aren't reported.
+func (ar *Runner) pushTransferReceipt(ctx context.Context, params Request, rep *reply.CallMethod) {
+	if ar.receipts.empty() {
+		return
+	}
+
+	var amount uint64
+	var toStr string
+	if err := core.Deserialize(params.Params, []interface{}{&amount, &toStr}); err != nil {
+		inslogger.FromContext(ctx).Warn(errors.Wrap(err, "[ pushTransferReceipt ] can't unmarshal params"))
+		return
+	}
+	if strings.HasPrefix(toStr, aliasPrefix) {
+		var err error
+		toStr, err = ar.callAlias(ctx, "ResolveAlias", strings.TrimPrefix(toStr, aliasPrefix))
+		if err != nil {
+			inslogger.FromContext(ctx).Warn(errors.Wrap(err, "[ pushTransferReceipt ] can't resolve recipient"))
+			return
+		}
+	}
+	to, err := core.ParseRef(toStr)
+	if err != nil {
+		inslogger.FromContext(ctx).Warn(errors.Wrap(err, "[ pushTransferReceipt ] failed to parse recipient"))
+		return
+	}
+	if len(ar.receipts.webhooks(*to)) == 0 {
+		return
+	}
+
+	receipt := &TransferReceipt{
+		Member:  to.String(),
+		From:    params.Reference,
+		Amount:  amount,
+		Request: rep.Request.String(),
+		Pulse:   rep.Pulse,
+		Node:    ar.NodeNetwork.GetOrigin().ID().String(),
+	}
+	data, err := receipt.signedData()
+	if err != nil {
+		inslogger.FromContext(ctx).Error(errors.Wrap(err, "[ pushTransferReceipt ]"))
+		return
+	}
+	signature, err := ar.CryptographyService.Sign(data)
+	if err != nil {
+		inslogger.FromContext(ctx).Error(errors.Wrap(err, "[ pushTransferReceipt ] can't sign receipt"))
+		return
+	}
+	receipt.Signature = signature.Bytes()
+	ar.receipts.push(ctx, *to, receipt)
+}
+
+// ReceiptsArgs is arguments of Receipts service requests. Member signs them the way it signs calls: signature
+// is made over (Reference, Method, Params, Seed) where Method is "SubscribeReceipts" or "UnsubscribeReceipts"
+// and Params are CBOR-serialized [URL].
+type ReceiptsArgs struct {
+	Reference string
+	URL       string
+	Seed      []byte
+	Signature []byte
+}
+
+// ReceiptsReply is reply for Receipts service requests.
+type ReceiptsReply struct {
+	// Node is a reference of API node which signs receipts.
+	Node string
+	// NodeKey is a PEM encoded public key receipts are verified with.
+	NodeKey string
+}
+
+// ReceiptsService is a service that allows members to subscribe webhooks to receipts of incoming transfers.
+//
+// Receipt is POSTed to webhook as JSON encoded TransferReceipt once transfer served by the node credits wallet
+// of member. Delivery is retried until webhook replies with 2xx status, so receiver should:
+//   - verify Signature with NodeKey returned on subscription, see TransferReceipt.Verify;
+//   - check that Node is the node it subscribed at;
+//   - deduplicate receipts by Request and reply with 2xx status to duplicates too.
+//
+// Subscriptions are kept in memory of API node, member subscribes again after node restart.
+type ReceiptsService struct {
+	runner *Runner
+}
+
+// NewReceiptsService creates new ReceiptsService instance.
+func NewReceiptsService(runner *Runner) *ReceiptsService {
+	return &ReceiptsService{runner: runner}
+}
+
+// Subscribe adds webhook receipts of incoming transfers of member are pushed to.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "receipts.Subscribe",
+//	  "params": {
+//	    "Reference": str, // reference of member
+//	    "URL": str, // http or https URL of webhook
+//	    "Seed": str, // base64 encoded seed got from seed.Get
+//	    "Signature": str // base64 encoded signature of member with method "SubscribeReceipts"
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	  Response structure:
+//	  {
+//	    "jsonrpc": "2.0",
+//	    "result": {
+//	      "Node": str, // reference of node which signs receipts
+//	      "NodeKey": str // PEM encoded public key of node
+//	    },
+//	    "id": str|int|null // same as in request
+//	  }
+func (s *ReceiptsService) Subscribe(r *http.Request, args *ReceiptsArgs, reply *ReceiptsReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ ReceiptsService.Subscribe ] Incoming request: %s", r.RequestURI)
+
+	member, err := s.runner.checkReceiptsArgs(ctx, "SubscribeReceipts", args)
+	if err != nil {
+		return errors.Wrap(err, "[ ReceiptsService.Subscribe ]")
+	}
+	err = s.runner.receipts.subscribe(*member, args.URL)
+	if err != nil {
+		return errors.Wrap(err, "[ ReceiptsService.Subscribe ]")
+	}
+
+	reply.Node = s.runner.NodeNetwork.GetOrigin().ID().String()
+	nodeKey := s.runner.CertificateManager.GetCertificate().GetPublicKey()
+	key, err := platformpolicy.NewKeyProcessor().ExportPublicKeyPEM(nodeKey)
+	if err != nil {
+		return errors.Wrap(err, "[ ReceiptsService.Subscribe ] can't export node key")
+	}
+	reply.NodeKey = string(key)
+	return nil
+}
+
+// Unsubscribe removes webhook of member.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "receipts.Unsubscribe",
+//	  "params": {
+//	    "Reference": str, // reference of member
+//	    "URL": str, // subscribed webhook
+//	    "Seed": str, // base64 encoded seed got from seed.Get
+//	    "Signature": str // base64 encoded signature of member with method "UnsubscribeReceipts"
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	  Response structure:
+//	  {
+//	    "jsonrpc": "2.0",
+//	    "result": {},
+//	    "id": str|int|null // same as in request
+//	  }
+func (s *ReceiptsService) Unsubscribe(r *http.Request, args *ReceiptsArgs, reply *ReceiptsReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ ReceiptsService.Unsubscribe ] Incoming request: %s", r.RequestURI)
+
+	member, err := s.runner.checkReceiptsArgs(ctx, "UnsubscribeReceipts", args)
+	if err != nil {
+		return errors.Wrap(err, "[ ReceiptsService.Unsubscribe ]")
+	}
+	if !s.runner.receipts.unsubscribe(*member, args.URL) {
+		return errors.New("[ ReceiptsService.Unsubscribe ] webhook isn't subscribed")
+	}
+	return nil
+}
+
+// checkReceiptsArgs checks webhook URL, seed and signature of member, and returns reference of member.
+func (ar *Runner) checkReceiptsArgs(ctx context.Context, method string, args *ReceiptsArgs) (*core.RecordRef, error) {
+	member, err := core.ParseRef(args.Reference)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse member reference")
+	}
+	webhook, err := url.Parse(args.URL)
+	if err != nil || (webhook.Scheme != "http" && webhook.Scheme != "https") || webhook.Host == "" {
+		return nil, errors.New("URL must be absolute http or https URL")
+	}
+	if err := ar.checkSeed(args.Seed); err != nil {
+		return nil, err
+	}
+	params, err := core.MarshalArgs(args.URL)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't marshal params")
+	}
+	err = ar.verifySignature(ctx, Request{
+		Reference: args.Reference,
+		Method:    method,
+		Params:    params,
+		Seed:      args.Seed,
+		Signature: args.Signature,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return member, nil
+}
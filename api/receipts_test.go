@@ -0,0 +1,190 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"crypto"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/api/seedmanager"
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/cryptography"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+	"github.com/insolar/insolar/testutils/network"
+)
+
+func TestReceiptSubscriptions(t *testing.T) {
+	member := testutils.RandomRef()
+
+	s := newReceiptSubscriptions(configuration.ReceiptWebhooks{})
+	require.Error(t, s.subscribe(member, "http://merchant/a"), "subscriptions are disabled")
+
+	s = newReceiptSubscriptions(configuration.ReceiptWebhooks{MaxPerMember: 2})
+	require.True(t, s.empty())
+	require.NoError(t, s.subscribe(member, "http://merchant/a"))
+	require.NoError(t, s.subscribe(member, "http://merchant/a"))
+	require.NoError(t, s.subscribe(member, "http://merchant/b"))
+	require.Error(t, s.subscribe(member, "http://merchant/c"))
+	require.Equal(t, []string{"http://merchant/a", "http://merchant/b"}, s.webhooks(member))
+	require.Empty(t, s.webhooks(testutils.RandomRef()))
+
+	require.True(t, s.unsubscribe(member, "http://merchant/a"))
+	require.False(t, s.unsubscribe(member, "http://merchant/a"))
+	require.Equal(t, []string{"http://merchant/b"}, s.webhooks(member))
+	require.True(t, s.unsubscribe(member, "http://merchant/b"))
+	require.True(t, s.empty())
+}
+
+func TestRunner_pushTransferReceipt(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	kp := platformpolicy.NewKeyProcessor()
+	privateKey, err := kp.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	var attempts int32
+	received := make(chan TransferReceipt, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var receipt TransferReceipt
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&receipt))
+		received <- receipt
+	}))
+	defer server.Close()
+
+	from, to, request, nodeRef := testutils.RandomRef(), testutils.RandomRef(), testutils.RandomRef(), testutils.RandomRef()
+	node := network.NewNodeMock(t)
+	node.IDMock.Return(nodeRef)
+	nn := network.NewNodeNetworkMock(t)
+	nn.GetOriginMock.Return(node)
+
+	runner := &Runner{
+		NodeNetwork:         nn,
+		CryptographyService: cryptography.NewKeyBoundCryptographyService(privateKey),
+		receipts: newReceiptSubscriptions(configuration.ReceiptWebhooks{
+			MaxPerMember: 1,
+			Attempts:     2,
+			RetryDelay:   time.Millisecond,
+		}),
+	}
+	require.NoError(t, runner.receipts.subscribe(to, server.URL))
+
+	params, err := core.MarshalArgs(uint64(100), to.String())
+	require.NoError(t, err)
+	rep := &reply.CallMethod{Request: request, Pulse: core.FirstPulseNumber + 1}
+	runner.pushTransferReceipt(ctx, Request{Reference: from.String(), Method: "Transfer", Params: params}, rep)
+
+	select {
+	case receipt := <-received:
+		require.Equal(t, to.String(), receipt.Member)
+		require.Equal(t, from.String(), receipt.From)
+		require.Equal(t, uint64(100), receipt.Amount)
+		require.Equal(t, request.String(), receipt.Request)
+		require.Equal(t, core.PulseNumber(core.FirstPulseNumber+1), receipt.Pulse)
+		require.Equal(t, nodeRef.String(), receipt.Node)
+		require.NoError(t, receipt.Verify(kp.ExtractPublicKey(privateKey)))
+
+		receipt.Amount++
+		require.Error(t, receipt.Verify(kp.ExtractPublicKey(privateKey)))
+	case <-time.After(pushTimeout):
+		t.Fatal("receipt isn't pushed")
+	}
+	require.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+}
+
+func TestReceiptsService_Subscribe(t *testing.T) {
+	kp := platformpolicy.NewKeyProcessor()
+	memberKey, err := kp.GeneratePrivateKey()
+	require.NoError(t, err)
+	nodeKey, err := kp.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	member, nodeRef := testutils.RandomRef(), testutils.RandomRef()
+	node := network.NewNodeMock(t)
+	node.IDMock.Return(nodeRef)
+	nn := network.NewNodeNetworkMock(t)
+	nn.GetOriginMock.Return(node)
+	cert := testutils.NewCertificateMock(t)
+	cert.GetPublicKeyMock.Return(kp.ExtractPublicKey(nodeKey))
+	cm := testutils.NewCertificateManagerMock(t)
+	cm.GetCertificateMock.Return(cert)
+
+	runner := &Runner{
+		NodeNetwork:        nn,
+		CertificateManager: cm,
+		SeedManager:        seedmanager.New(),
+		keyCache:           map[string]crypto.PublicKey{member.String(): kp.ExtractPublicKey(memberKey)},
+		cacheLock:          &sync.RWMutex{},
+		receipts:           newReceiptSubscriptions(configuration.ReceiptWebhooks{MaxPerMember: 1}),
+	}
+	// seed is consumed by every request, so each one is signed with a new seed
+	var seed seedmanager.Seed
+	sign := func(method string, url string) []byte {
+		seed[0]++
+		runner.SeedManager.Add(seed)
+		params, err := core.MarshalArgs(url)
+		require.NoError(t, err)
+		data, err := core.MarshalArgs(member, method, []byte(params), seed[:])
+		require.NoError(t, err)
+		signature, err := cryptography.NewKeyBoundCryptographyService(memberKey).Sign(data)
+		require.NoError(t, err)
+		return signature.Bytes()
+	}
+
+	s := NewReceiptsService(runner)
+	args := &ReceiptsArgs{
+		Reference: member.String(),
+		URL:       "https://merchant/receipts",
+		Seed:      seed[:], // shares array with seed, so it's always the seed of last signature
+		Signature: sign("UnsubscribeReceipts", "https://merchant/receipts"),
+	}
+	var subscribed ReceiptsReply
+	require.Error(t, s.Subscribe(&http.Request{}, args, &subscribed), "signature of other method")
+	require.True(t, runner.receipts.empty())
+
+	args.Signature = sign("SubscribeReceipts", "https://merchant/receipts")
+	require.NoError(t, s.Subscribe(&http.Request{}, args, &subscribed))
+	require.Equal(t, nodeRef.String(), subscribed.Node)
+	key, err := kp.ImportPublicKeyPEM([]byte(subscribed.NodeKey))
+	require.NoError(t, err)
+	require.Equal(t, kp.ExtractPublicKey(nodeKey), key)
+	require.Equal(t, []string{"https://merchant/receipts"}, runner.receipts.webhooks(member))
+
+	args.Signature = sign("UnsubscribeReceipts", "https://merchant/receipts")
+	require.NoError(t, s.Unsubscribe(&http.Request{}, args, &ReceiptsReply{}))
+	require.True(t, runner.receipts.empty())
+	args.Signature = sign("UnsubscribeReceipts", "https://merchant/receipts")
+	require.Error(t, s.Unsubscribe(&http.Request{}, args, &ReceiptsReply{}))
+
+	args.URL = "ftp://merchant/receipts"
+	args.Signature = sign("SubscribeReceipts", args.URL)
+	require.Error(t, s.Subscribe(&http.Request{}, args, &subscribed))
+}
@@ -38,6 +38,10 @@ type UserConfigJSON struct {
 type RequestConfigJSON struct {
 	Params []interface{} `json:"params"`
 	Method string        `json:"method"`
+	// Proof requests signed balance proof in GetBalance response
+	Proof bool `json:"proof,omitempty"`
+	// Session is a token of conversational session, "new" starts new session
+	Session string `json:"session,omitempty"`
 }
 
 func readFile(path string, configType interface{}) error {
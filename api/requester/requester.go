@@ -176,13 +176,20 @@ func SendWithSeed(ctx context.Context, url string, userCfg *UserConfigJSON, reqC
 	}
 	verboseInfo(ctx, "Signing request completed")
 
-	body, err := GetResponseBody(url, PostParams{
+	postParams := PostParams{
 		"params":    params,
 		"method":    reqCfg.Method,
 		"reference": userCfg.Caller,
 		"seed":      seed,
 		"signature": signature.Bytes(),
-	})
+	}
+	if reqCfg.Proof {
+		postParams["proof"] = true
+	}
+	if reqCfg.Session != "" {
+		postParams["session"] = reqCfg.Session
+	}
+	body, err := GetResponseBody(url, postParams)
 
 	if err != nil {
 		return nil, errors.Wrap(err, "[ Send ] Problem with sending target request")
@@ -208,6 +215,52 @@ func Send(ctx context.Context, url string, userCfg *UserConfigJSON, reqCfg *Requ
 	return response, nil
 }
 
+// SignTransaction creates offline transaction which calls method of caller with params, it's valid until expiry
+// pulse. Transaction is signed without network access and can be submitted later by anyone with SubmitTransaction.
+func SignTransaction(
+	userCfg *UserConfigJSON, reqCfg *RequestConfigJSON, nonce uint64, expiry core.PulseNumber,
+) (*core.Transaction, error) {
+	if userCfg == nil || reqCfg == nil {
+		return nil, errors.New("[ SignTransaction ] Configs must be initialized")
+	}
+
+	params, err := constructParams(reqCfg.Params)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ SignTransaction ] Problem with serializing params")
+	}
+
+	tx := &core.Transaction{
+		Reference: userCfg.Caller,
+		Method:    reqCfg.Method,
+		Params:    params,
+		Nonce:     nonce,
+		Expiry:    expiry,
+	}
+	payload, err := tx.SignedPayload()
+	if err != nil {
+		return nil, errors.Wrap(err, "[ SignTransaction ] Problem with serializing transaction")
+	}
+
+	signature, err := scheme.Signer(userCfg.privateKeyObject).Sign(payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ SignTransaction ] Problem with signing transaction")
+	}
+	tx.Signature = signature.Bytes()
+	return tx, nil
+}
+
+// SubmitTransaction makes rpc request to transactions.Submit method and returns response body.
+func SubmitTransaction(url string, tx *core.Transaction) ([]byte, error) {
+	params := getDefaultRPCParams("transactions.Submit")
+	params["params"] = map[string]interface{}{"Transaction": tx}
+
+	body, err := GetResponseBody(url+"/rpc", params)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ SubmitTransaction ]")
+	}
+	return body, nil
+}
+
 func getDefaultRPCParams(method string) PostParams {
 	return PostParams{
 		"jsonrpc": "2.0",
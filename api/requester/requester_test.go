@@ -28,8 +28,11 @@ import (
 	"time"
 
 	"github.com/insolar/insolar/api"
+	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/log"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
@@ -62,7 +65,7 @@ func FakeHandler(response http.ResponseWriter, req *http.Request) {
 	response.Header().Add("Content-Type", "application/json")
 
 	params := api.Request{}
-	_, err := api.UnmarshalRequest(req, &params)
+	err := api.UnmarshalRequest(req, &params)
 	if err != nil {
 		log.Errorf("Can't read request\n")
 		return
@@ -85,7 +88,7 @@ func FakeRPCHandler(response http.ResponseWriter, req *http.Request) {
 		"id":      "",
 	}
 	rpcReq := rpcRequest{}
-	_, err := api.UnmarshalRequest(req, &rpcReq)
+	err := api.UnmarshalRequest(req, &rpcReq)
 	if err != nil {
 		log.Errorf("Can't read request\n")
 		return
@@ -255,6 +258,24 @@ func TestSendWithSeed_NilConfigs(t *testing.T) {
 	require.EqualError(t, err, "[ Send ] Configs must be initialized")
 }
 
+func TestSignTransaction(t *testing.T) {
+	userConf, reqConf := readConfigs(t)
+	userConf.Caller = core.NewRecordRef(testutils.RandomID(), *core.NewRecordID(core.FirstPulseNumber, nil)).String()
+	tx, err := SignTransaction(userConf, reqConf, 42, core.FirstPulseNumber+100)
+	require.NoError(t, err)
+	require.Equal(t, userConf.Caller, tx.Reference)
+	require.Equal(t, reqConf.Method, tx.Method)
+	require.Equal(t, uint64(42), tx.Nonce)
+
+	payload, err := tx.SignedPayload()
+	require.NoError(t, err)
+	publicKey := platformpolicy.NewKeyProcessor().ExtractPublicKey(userConf.privateKeyObject)
+	require.True(t, scheme.Verifier(publicKey).Verify(core.SignatureFromBytes(tx.Signature), payload))
+
+	_, err = SignTransaction(nil, nil, 0, 0)
+	require.EqualError(t, err, "[ SignTransaction ] Configs must be initialized")
+}
+
 func TestInfo(t *testing.T) {
 	resp, err := Info(URL)
 	require.NoError(t, err)
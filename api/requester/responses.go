@@ -33,6 +33,7 @@ type rpcSeedResponse struct {
 // StatusResponse represents response from rpc on status.Get method
 type StatusResponse struct {
 	NetworkState string `json:"NetworkState"`
+	PulseNumber  uint32 `json:"PulseNumber"`
 }
 
 type rpcStatusResponse struct {
@@ -0,0 +1,323 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/insolar/insolar/application/extractor"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
+)
+
+// RequestsArgs is arguments that Requests service accepts.
+type RequestsArgs struct {
+	QID string
+}
+
+// RequestsReply is reply for Requests service requests.
+type RequestsReply struct {
+	QID     string
+	Member  string
+	Method  string
+	Request string
+	Result  json.RawMessage
+	Error   string
+	Time    int64
+}
+
+// RequestsService is a service that provides API for querying outcomes of API requests.
+type RequestsService struct {
+	runner *Runner
+}
+
+// NewRequestsService creates new RequestsService instance.
+func NewRequestsService(runner *Runner) *RequestsService {
+	return &RequestsService{runner: runner}
+}
+
+// Get returns outcome of API request by its QID. Outcomes are kept by the node which accepted the request
+// for a retention period, so client which lost the reply should query the same node.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "requests.Get",
+//	  "params": {
+//	    "QID": str // query id of the request, trace id is used if client didn't provide one
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	  Response structure:
+//	  {
+//	    "jsonrpc": "2.0",
+//	    "result": {
+//	      "QID": str,
+//	      "Member": str, // reference of member who signed the request
+//	      "Method": str,
+//	      "Request": str, // reference of registered request, empty if request wasn't registered
+//	      "Result": any, // result of the call
+//	      "Error": str,
+//	      "Time": int // unix time of reply
+//	    },
+//	    "id": str|int|null // same as in request
+//	  }
+func (s *RequestsService) Get(r *http.Request, args *RequestsArgs, reply *RequestsReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ RequestsService.Get ] Incoming request: %s", r.RequestURI)
+
+	if args.QID == "" {
+		return errors.New("[ RequestsService.Get ] QID is required")
+	}
+
+	outcome, err := s.runner.APIRequestArchive.GetAPIRequestOutcome(ctx, args.QID)
+	if err != nil {
+		return errors.Wrap(err, "[ RequestsService.Get ] Can't get request outcome")
+	}
+	if outcome == nil {
+		return errors.New("[ RequestsService.Get ] request not found or expired")
+	}
+
+	reply.QID = outcome.QID
+	reply.Member = outcome.Member.String()
+	reply.Method = outcome.Method
+	if outcome.Request != nil {
+		reply.Request = outcome.Request.String()
+	}
+	reply.Result = outcome.Result
+	reply.Error = outcome.Error
+	reply.Time = outcome.Time.Unix()
+	return nil
+}
+
+// RequestsSubscribeArgs is arguments of Requests.Subscribe request. Member who signed API request signs them
+// the way it signs calls: signature is made over (Reference, "SubscribeRequestStatus", Params, Seed) where Params
+// are CBOR-serialized [QID, URL].
+type RequestsSubscribeArgs struct {
+	Reference string
+	QID       string
+	URL       string
+	Seed      []byte
+	Signature []byte
+}
+
+// RequestsSubscribeReply is reply for Requests.Subscribe request.
+type RequestsSubscribeReply struct{}
+
+// Subscribe registers webhook status updates of API request are pushed to while request is pending.
+// Updates are pushed by the node which accepted the request, so client should subscribe on the same node.
+// Webhook receives POST with JSON body {"qid": str, "request": str, "status": "pending", "pulses": int}.
+// Webhook must target one of hosts allowed in configuration. Only member who signed API request receives
+// its updates.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "requests.Subscribe",
+//	  "params": {
+//	    "Reference": str, // reference of member who signed the request
+//	    "QID": str, // query id of the request
+//	    "URL": str, // http or https URL of webhook
+//	    "Seed": str, // base64 encoded seed got from seed.Get
+//	    "Signature": str // base64 encoded signature of member with method "SubscribeRequestStatus"
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	  Response structure:
+//	  {
+//	    "jsonrpc": "2.0",
+//	    "result": {},
+//	    "id": str|int|null // same as in request
+//	  }
+func (s *RequestsService) Subscribe(r *http.Request, args *RequestsSubscribeArgs, reply *RequestsSubscribeReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ RequestsService.Subscribe ] Incoming request: %s", r.RequestURI)
+
+	if args.QID == "" {
+		return errors.New("[ RequestsService.Subscribe ] QID is required")
+	}
+	member, err := core.ParseRef(args.Reference)
+	if err != nil {
+		return errors.Wrap(err, "[ RequestsService.Subscribe ] Failed to parse member reference")
+	}
+	webhook, err := url.Parse(args.URL)
+	if err != nil || (webhook.Scheme != "http" && webhook.Scheme != "https") || webhook.Host == "" {
+		return errors.New("[ RequestsService.Subscribe ] URL must be absolute http or https URL")
+	}
+	if err := s.runner.checkSeed(args.Seed); err != nil {
+		return errors.Wrap(err, "[ RequestsService.Subscribe ]")
+	}
+	params, err := core.MarshalArgs(args.QID, args.URL)
+	if err != nil {
+		return errors.Wrap(err, "[ RequestsService.Subscribe ] can't marshal params")
+	}
+	err = s.runner.verifySignature(ctx, Request{
+		Reference: args.Reference,
+		Method:    "SubscribeRequestStatus",
+		Params:    params,
+		Seed:      args.Seed,
+		Signature: args.Signature,
+	})
+	if err != nil {
+		inslog.Warnf("[ RequestsService.Subscribe ] unauthorized request from %s: %s", r.RemoteAddr, err)
+		return errors.Wrap(err, "[ RequestsService.Subscribe ]")
+	}
+
+	if err := s.runner.subscriptions.subscribe(*member, args.QID, webhook); err != nil {
+		return errors.Wrap(err, "[ RequestsService.Subscribe ] can't subscribe")
+	}
+	return nil
+}
+
+// RequestsFinalityArgs is arguments of Requests.Finality request.
+type RequestsFinalityArgs struct {
+	Object  string
+	Request string
+}
+
+// RequestsFinalityReply is reply for Requests.Finality request.
+type RequestsFinalityReply struct {
+	Pulse    uint32
+	Finality string
+}
+
+// Finality returns current finality of request result. Result is irreversible when finality is "replicated".
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "requests.Finality",
+//	  "params": {
+//	    "Object": str, // reference of called object, it's the member for requests made via call API
+//	    "Request": str // reference of request returned by call API
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	  Response structure:
+//	  {
+//	    "jsonrpc": "2.0",
+//	    "result": {
+//	      "Pulse": int, // pulse number of request
+//	      "Finality": str // "executed", "validated" or "replicated"
+//	    },
+//	    "id": str|int|null // same as in request
+//	  }
+func (s *RequestsService) Finality(r *http.Request, args *RequestsFinalityArgs, reply *RequestsFinalityReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ RequestsService.Finality ] Incoming request: %s", r.RequestURI)
+
+	object, err := core.ParseRef(args.Object)
+	if err != nil {
+		return errors.Wrap(err, "[ RequestsService.Finality ] Failed to parse object reference")
+	}
+	request, err := core.ParseRef(args.Request)
+	if err != nil {
+		return errors.Wrap(err, "[ RequestsService.Finality ] Failed to parse request reference")
+	}
+
+	finality, err := s.runner.FinalityChecker.GetFinality(ctx, *object, *request)
+	if err != nil {
+		return errors.Wrap(err, "[ RequestsService.Finality ] Can't get finality")
+	}
+
+	reply.Pulse = uint32(request.Record().Pulse())
+	reply.Finality = finality.String()
+	return nil
+}
+
+// RequestsResultArgs is arguments of Requests.Result request.
+type RequestsResultArgs struct {
+	Request string
+}
+
+// RequestsResultReply is reply for Requests.Result request.
+type RequestsResultReply struct {
+	Result json.RawMessage
+	Error  string
+}
+
+// Result returns result registered on ledger for request. Unlike Get, it doesn't depend on node which accepted
+// the request, so any node can answer it.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "requests.Result",
+//	  "params": {
+//	    "Request": str // reference of request returned by call API
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	  Response structure:
+//	  {
+//	    "jsonrpc": "2.0",
+//	    "result": {
+//	      "Result": any, // result of the call
+//	      "Error": str // error returned by called method
+//	    },
+//	    "id": str|int|null // same as in request
+//	  }
+func (s *RequestsService) Result(r *http.Request, args *RequestsResultArgs, reply *RequestsResultReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ RequestsService.Result ] Incoming request: %s", r.RequestURI)
+
+	request, err := core.ParseRef(args.Request)
+	if err != nil {
+		return errors.Wrap(err, "[ RequestsService.Result ] Failed to parse request reference")
+	}
+
+	payload, err := s.runner.ArtifactManager.GetResult(ctx, *request)
+	if err == core.ErrNotFound {
+		return errors.New("[ RequestsService.Result ] result is not registered")
+	}
+	if err != nil {
+		return errors.Wrap(err, "[ RequestsService.Result ] Can't get result")
+	}
+
+	reply.Result, reply.Error, err = callResult(payload)
+	return errors.Wrap(err, "[ RequestsService.Result ]")
+}
+
+// callResult decodes payload of result registered on ledger to JSON result and error returned by called method.
+func callResult(payload []byte) (json.RawMessage, string, error) {
+	result, contractErr, err := extractor.CallResponse(payload)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "Can't extract response")
+	}
+	if contractErr != nil {
+		return nil, contractErr.S, nil
+	}
+
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "Can't marshal result")
+	}
+	return raw, "", nil
+}
@@ -0,0 +1,198 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/api/seedmanager"
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/cryptography"
+	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+)
+
+type requestArchive struct {
+	lock     sync.Mutex
+	outcomes map[string]*core.APIRequestOutcome
+}
+
+func newRequestArchive() *requestArchive {
+	return &requestArchive{outcomes: map[string]*core.APIRequestOutcome{}}
+}
+
+func (a *requestArchive) SetAPIRequestOutcome(ctx context.Context, outcome *core.APIRequestOutcome) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.outcomes[outcome.QID] = outcome
+	return nil
+}
+
+func (a *requestArchive) GetAPIRequestOutcome(ctx context.Context, qid string) (*core.APIRequestOutcome, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return a.outcomes[qid], nil
+}
+
+func TestRequestsService_Get(t *testing.T) {
+	archive := newRequestArchive()
+	service := NewRequestsService(&Runner{APIRequestArchive: archive})
+
+	var rep RequestsReply
+	require.Error(t, service.Get(&http.Request{}, &RequestsArgs{}, &rep))
+	require.Error(t, service.Get(&http.Request{}, &RequestsArgs{QID: "missing"}, &rep))
+
+	member := testutils.RandomRef()
+	request := testutils.RandomRef()
+	replied := time.Unix(1546300800, 0)
+	archive.outcomes["q1"] = &core.APIRequestOutcome{
+		QID: "q1", Member: member, Method: "Transfer", Request: &request, Result: []byte(`"OK"`), Time: replied,
+	}
+	archive.outcomes["q2"] = &core.APIRequestOutcome{QID: "q2", Member: member, Method: "Transfer", Error: "failed", Time: replied}
+
+	require.NoError(t, service.Get(&http.Request{}, &RequestsArgs{QID: "q1"}, &rep))
+	require.Equal(t, RequestsReply{
+		QID:     "q1",
+		Member:  member.String(),
+		Method:  "Transfer",
+		Request: request.String(),
+		Result:  json.RawMessage(`"OK"`),
+		Time:    replied.Unix(),
+	}, rep)
+
+	rep = RequestsReply{}
+	require.NoError(t, service.Get(&http.Request{}, &RequestsArgs{QID: "q2"}, &rep))
+	require.Equal(t, RequestsReply{
+		QID:    "q2",
+		Member: member.String(),
+		Method: "Transfer",
+		Error:  "failed",
+		Time:   replied.Unix(),
+	}, rep)
+}
+
+type finalityChecker struct {
+	finality core.Finality
+}
+
+func (c finalityChecker) GetFinality(ctx context.Context, object core.RecordRef, request core.RecordRef) (core.Finality, error) {
+	return c.finality, nil
+}
+
+func TestRequestsService_Subscribe(t *testing.T) {
+	kp := platformpolicy.NewKeyProcessor()
+	memberKey, err := kp.GeneratePrivateKey()
+	require.NoError(t, err)
+	member := testutils.RandomRef()
+
+	cfg := &configuration.APIRunner{
+		StatusWebhooks: configuration.StatusWebhooks{Hosts: []string{"hooks.example.com"}},
+	}
+	runner := &Runner{
+		cfg:           cfg,
+		SeedManager:   seedmanager.New(),
+		keyCache:      map[string]crypto.PublicKey{member.String(): kp.ExtractPublicKey(memberKey)},
+		cacheLock:     &sync.RWMutex{},
+		subscriptions: newStatusSubscriptions(cfg.StatusWebhooks),
+	}
+	service := NewRequestsService(runner)
+
+	// seed is consumed by every request, so each one is signed with a new seed
+	var seed seedmanager.Seed
+	signed := func(method string, qid string, url string) *RequestsSubscribeArgs {
+		seed[0]++
+		runner.SeedManager.Add(seed)
+		params, err := core.MarshalArgs(qid, url)
+		require.NoError(t, err)
+		data, err := core.MarshalArgs(member, method, []byte(params), seed[:])
+		require.NoError(t, err)
+		signature, err := cryptography.NewKeyBoundCryptographyService(memberKey).Sign(data)
+		require.NoError(t, err)
+		return &RequestsSubscribeArgs{
+			Reference: member.String(),
+			QID:       qid,
+			URL:       url,
+			Seed:      append([]byte(nil), seed[:]...),
+			Signature: signature.Bytes(),
+		}
+	}
+
+	args := signed("SubscribeReceipts", "qid", "https://hooks.example.com/status")
+	err = service.Subscribe(&http.Request{}, args, &RequestsSubscribeReply{})
+	require.Contains(t, err.Error(), "Incorrect signature", "signature of other method")
+
+	args = signed("SubscribeRequestStatus", "qid", "https://hooks.example.com/status")
+	args.QID = "other"
+	err = service.Subscribe(&http.Request{}, args, &RequestsSubscribeReply{})
+	require.Contains(t, err.Error(), "Incorrect signature", "signature of other request")
+
+	args = signed("SubscribeRequestStatus", "qid", "https://hooks.example.com/status")
+	require.NoError(t, service.Subscribe(&http.Request{}, args, &RequestsSubscribeReply{}))
+	err = service.Subscribe(&http.Request{}, args, &RequestsSubscribeReply{})
+	require.Contains(t, err.Error(), "Incorrect seed", "seed is used once")
+
+	internal := signed("SubscribeRequestStatus", "qid", "http://localhost:8500/")
+	err = service.Subscribe(&http.Request{}, internal, &RequestsSubscribeReply{})
+	require.Contains(t, err.Error(), "webhook host localhost:8500 is not allowed")
+
+	require.Equal(t, []string{"https://hooks.example.com/status"}, runner.subscriptions.webhooks(member, "qid"))
+	require.Empty(t, runner.subscriptions.webhooks(testutils.RandomRef(), "qid"))
+}
+
+func TestRequestsService_Finality(t *testing.T) {
+	service := NewRequestsService(&Runner{FinalityChecker: finalityChecker{finality: core.FinalityReplicated}})
+
+	var rep RequestsFinalityReply
+	require.Error(t, service.Finality(&http.Request{}, &RequestsFinalityArgs{}, &rep))
+
+	request := core.NewRecordRef(core.RecordID{}, *core.NewRecordID(core.FirstPulseNumber+10, nil))
+	args := &RequestsFinalityArgs{Object: testutils.RandomRef().String(), Request: request.String()}
+	require.NoError(t, service.Finality(&http.Request{}, args, &rep))
+	require.Equal(t, RequestsFinalityReply{Pulse: core.FirstPulseNumber + 10, Finality: "replicated"}, rep)
+}
+
+func TestRequestsService_Result(t *testing.T) {
+	am := testutils.NewArtifactManagerMock(t)
+	service := NewRequestsService(&Runner{ArtifactManager: am})
+
+	var rep RequestsResultReply
+	require.Error(t, service.Result(&http.Request{}, &RequestsResultArgs{}, &rep))
+
+	request := testutils.RandomRef()
+	am.GetResultMock.Return(nil, core.ErrNotFound)
+	require.Error(t, service.Result(&http.Request{}, &RequestsResultArgs{Request: request.String()}, &rep))
+
+	var contractErr *foundation.Error
+	payload, err := core.MarshalArgs("OK", contractErr)
+	require.NoError(t, err)
+	am.GetResultMock.Set(func(ctx context.Context, p core.RecordRef) ([]byte, error) {
+		require.Equal(t, request, p)
+		return payload, nil
+	})
+	require.NoError(t, service.Result(&http.Request{}, &RequestsResultArgs{Request: request.String()}, &rep))
+	require.Equal(t, RequestsResultReply{Result: json.RawMessage(`"OK"`)}, rep)
+}
@@ -0,0 +1,180 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// RevocationsArgs is arguments of Revocations.Revoke and Revocations.Restore requests.
+type RevocationsArgs struct {
+	Node string
+}
+
+// RevocationsReply is reply for Revocations.Revoke and Revocations.Restore requests.
+type RevocationsReply struct {
+	Success bool
+}
+
+// RevocationList is a revocation list signed by discovery node.
+type RevocationList struct {
+	Pulse   core.PulseNumber
+	Signer  string
+	Revoked []string
+}
+
+// RevocationsListReply is reply for Revocations.List request.
+type RevocationsListReply struct {
+	Lists []RevocationList
+}
+
+// RevocationsService is a service that provides admin API for revocation of node certificates.
+type RevocationsService struct {
+	runner *Runner
+}
+
+// NewRevocationsService creates new RevocationsService instance.
+func NewRevocationsService(runner *Runner) *RevocationsService {
+	return &RevocationsService{runner: runner}
+}
+
+// Revoke adds node to revocation list of this discovery node. List is distributed to all nodes every pulse,
+// certificate is revoked when majority of discovery nodes list it. Request must be authorized with admin token.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "revocations.Revoke",
+//	  "params": {
+//	    "Node": str // reference of node
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	Response structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "result": {
+//	    "Success": bool
+//	  },
+//	  "id": str|int|null // same as in request
+//	}
+func (s *RevocationsService) Revoke(r *http.Request, args *RevocationsArgs, reply *RevocationsReply) error {
+	return s.update(r, "Revoke", args, reply, s.runner.Revocations.Revoke)
+}
+
+// Restore removes node from revocation list of this discovery node. Request must be authorized with admin token.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "revocations.Restore",
+//	  "params": {
+//	    "Node": str // reference of node
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	Response structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "result": {
+//	    "Success": bool
+//	  },
+//	  "id": str|int|null // same as in request
+//	}
+func (s *RevocationsService) Restore(r *http.Request, args *RevocationsArgs, reply *RevocationsReply) error {
+	return s.update(r, "Restore", args, reply, s.runner.Revocations.Restore)
+}
+
+// List returns revocation lists of discovery nodes known to this node.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "revocations.List",
+//	  "params": {},
+//	  "id": str|int|null
+//	}
+//
+//	Response structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "result": {
+//	    "Lists": [
+//	      {
+//	        "Pulse": int, // pulse in which list was signed
+//	        "Signer": str, // reference of discovery node
+//	        "Revoked": [str] // references of revoked nodes
+//	      }
+//	    ]
+//	  },
+//	  "id": str|int|null // same as in request
+//	}
+func (s *RevocationsService) List(r *http.Request, args *struct{}, reply *RevocationsListReply) error {
+	_, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ RevocationsService.List ] Incoming request: %s", r.RequestURI)
+
+	lists := s.runner.Revocations.RevocationLists()
+	reply.Lists = make([]RevocationList, 0, len(lists))
+	for _, list := range lists {
+		revoked := make([]string, 0, len(list.Revoked))
+		for _, node := range list.Revoked {
+			revoked = append(revoked, node.String())
+		}
+		reply.Lists = append(reply.Lists, RevocationList{
+			Pulse:   list.Pulse,
+			Signer:  list.Signer.String(),
+			Revoked: revoked,
+		})
+	}
+	return nil
+}
+
+func (s *RevocationsService) update(
+	r *http.Request,
+	method string,
+	args *RevocationsArgs,
+	reply *RevocationsReply,
+	apply func(context.Context, core.RecordRef) error,
+) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ RevocationsService.%s ] Incoming request: %s, node: %s", method, r.RequestURI, args.Node)
+
+	if err := s.runner.authorizeAdmin(r, "RevocationsService"); err != nil {
+		inslog.Warnf("[ RevocationsService.%s ] unauthorized request from %s: %s", method, r.RemoteAddr, err)
+		return err
+	}
+	node, err := core.NewRefFromBase58(args.Node)
+	if err != nil {
+		return errors.Wrapf(err, "[ RevocationsService.%s ] invalid reference of node", method)
+	}
+	if err := apply(ctx, *node); err != nil {
+		return errors.Wrapf(err, "[ RevocationsService.%s ]", method)
+	}
+	reply.Success = true
+	return nil
+}
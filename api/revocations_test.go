@@ -0,0 +1,98 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+)
+
+type revocations struct {
+	revoked map[core.RecordRef]bool
+	lists   []core.RevocationList
+}
+
+func (r *revocations) IsRevoked(node core.RecordRef) bool {
+	return r.revoked[node]
+}
+
+func (r *revocations) Revoke(ctx context.Context, node core.RecordRef) error {
+	if r.revoked == nil {
+		return errors.New("only discovery node revokes certificates")
+	}
+	r.revoked[node] = true
+	return nil
+}
+
+func (r *revocations) Restore(ctx context.Context, node core.RecordRef) error {
+	delete(r.revoked, node)
+	return nil
+}
+
+func (r *revocations) RevocationLists() []core.RevocationList {
+	return r.lists
+}
+
+func TestRevocationsService_Revoke(t *testing.T) {
+	fake := &revocations{revoked: map[core.RecordRef]bool{}}
+	service := NewRevocationsService(&Runner{
+		cfg:         &configuration.APIRunner{AdminToken: "secret"},
+		Revocations: fake,
+	})
+	node := testutils.RandomRef()
+
+	var rep RevocationsReply
+	err := service.Revoke(&http.Request{Header: http.Header{}}, &RevocationsArgs{Node: node.String()}, &rep)
+	require.Contains(t, err.Error(), "admin token is required")
+
+	r := &http.Request{Header: http.Header{}}
+	r.Header.Set("Authorization", "Bearer secret")
+	err = service.Revoke(r, &RevocationsArgs{Node: "bad"}, &rep)
+	require.Contains(t, err.Error(), "invalid reference of node")
+
+	require.NoError(t, service.Revoke(r, &RevocationsArgs{Node: node.String()}, &rep))
+	require.True(t, rep.Success)
+	require.True(t, fake.IsRevoked(node))
+
+	require.NoError(t, service.Restore(r, &RevocationsArgs{Node: node.String()}, &rep))
+	require.False(t, fake.IsRevoked(node))
+
+	fake.revoked = nil
+	err = service.Revoke(r, &RevocationsArgs{Node: node.String()}, &rep)
+	require.Contains(t, err.Error(), "only discovery node revokes certificates")
+}
+
+func TestRevocationsService_List(t *testing.T) {
+	signer, node := testutils.RandomRef(), testutils.RandomRef()
+	service := NewRevocationsService(&Runner{Revocations: &revocations{lists: []core.RevocationList{
+		{Pulse: core.FirstPulseNumber, Signer: signer, Revoked: []core.RecordRef{node}},
+	}}})
+
+	var rep RevocationsListReply
+	require.NoError(t, service.List(&http.Request{}, &struct{}{}, &rep))
+	require.Equal(t, RevocationsListReply{Lists: []RevocationList{
+		{Pulse: core.FirstPulseNumber, Signer: signer.String(), Revoked: []string{node.String()}},
+	}}, rep)
+}
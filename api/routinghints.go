@@ -0,0 +1,109 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"sync"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/metrics"
+)
+
+const (
+	hintHit       = "hit"
+	hintMiss      = "miss"
+	hintForgotten = "forgotten"
+)
+
+// routingHints remembers virtual executors of objects called via API in current pulse. Calls to the objects are sent
+// to their executors directly instead of being routed by role on every send. Hints are dropped on pulse change.
+type routingHints struct {
+	coordinator core.JetCoordinator
+	pulses      core.PulseStorage
+	nodes       core.NodeNetwork
+
+	lock      sync.Mutex
+	pulse     core.PulseNumber
+	executors map[core.RecordID]core.RecordRef
+}
+
+func newRoutingHints(coordinator core.JetCoordinator, pulses core.PulseStorage, nodes core.NodeNetwork) *routingHints {
+	return &routingHints{
+		coordinator: coordinator,
+		pulses:      pulses,
+		nodes:       nodes,
+		executors:   map[core.RecordID]core.RecordRef{},
+	}
+}
+
+// Executor implements core.RoutingHints. Nil is returned if this node is the executor itself, messages are delivered
+// locally then.
+func (h *routingHints) Executor(ctx context.Context, object core.RecordRef) *core.RecordRef {
+	pulse, err := h.pulses.Current(ctx)
+	if err != nil {
+		inslogger.FromContext(ctx).Debug("routing hints: can't get current pulse: ", err)
+		return nil
+	}
+
+	h.lock.Lock()
+	if h.pulse != pulse.PulseNumber {
+		h.pulse = pulse.PulseNumber
+		h.executors = map[core.RecordID]core.RecordRef{}
+	}
+	executor, ok := h.executors[*object.Record()]
+	h.lock.Unlock()
+
+	if ok {
+		metrics.APIRoutingHints.WithLabelValues(hintHit).Inc()
+		return h.remote(executor)
+	}
+	metrics.APIRoutingHints.WithLabelValues(hintMiss).Inc()
+
+	node, err := h.coordinator.VirtualExecutorForObject(ctx, *object.Record(), pulse.PulseNumber)
+	if err != nil {
+		inslogger.FromContext(ctx).Debug("routing hints: can't calculate executor: ", err)
+		return nil
+	}
+
+	h.lock.Lock()
+	if h.pulse == pulse.PulseNumber {
+		h.executors[*object.Record()] = *node
+	}
+	h.lock.Unlock()
+
+	return h.remote(*node)
+}
+
+// Forget implements core.RoutingHints.
+func (h *routingHints) Forget(object core.RecordRef) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if _, ok := h.executors[*object.Record()]; ok {
+		delete(h.executors, *object.Record())
+		metrics.APIRoutingHints.WithLabelValues(hintForgotten).Inc()
+	}
+}
+
+func (h *routingHints) remote(executor core.RecordRef) *core.RecordRef {
+	if executor.Equal(h.nodes.GetOrigin().ID()) {
+		return nil
+	}
+	return &executor
+}
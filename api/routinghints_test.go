@@ -0,0 +1,72 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+	"github.com/insolar/insolar/testutils/network"
+)
+
+func TestRoutingHints_Executor(t *testing.T) {
+	ctx := context.Background()
+	object, other := testutils.RandomRef(), testutils.RandomRef()
+	executor, origin := testutils.RandomRef(), testutils.RandomRef()
+
+	pulse := core.PulseNumber(core.FirstPulseNumber)
+	ps := testutils.NewPulseStorageMock(t)
+	ps.CurrentFunc = func(context.Context) (*core.Pulse, error) {
+		return &core.Pulse{PulseNumber: pulse}, nil
+	}
+	jc := testutils.NewJetCoordinatorMock(t)
+	jc.VirtualExecutorForObjectFunc = func(
+		_ context.Context, obj core.RecordID, pn core.PulseNumber,
+	) (*core.RecordRef, error) {
+		require.Equal(t, pulse, pn)
+		if obj == *other.Record() {
+			return &origin, nil
+		}
+		return &executor, nil
+	}
+	node := network.NewNodeMock(t)
+	node.IDMock.Return(origin)
+	nn := network.NewNodeNetworkMock(t)
+	nn.GetOriginMock.Return(node)
+
+	hints := newRoutingHints(jc, ps, nn)
+
+	require.Equal(t, &executor, hints.Executor(ctx, object))
+	require.Equal(t, &executor, hints.Executor(ctx, object))
+	require.Equal(t, uint64(1), jc.VirtualExecutorForObjectCounter)
+
+	// Self executor isn't hinted, message is delivered locally.
+	require.Nil(t, hints.Executor(ctx, other))
+
+	hints.Forget(object)
+	require.Equal(t, &executor, hints.Executor(ctx, object))
+	require.Equal(t, uint64(3), jc.VirtualExecutorForObjectCounter)
+
+	// Hints are dropped on pulse change.
+	pulse++
+	require.Equal(t, &executor, hints.Executor(ctx, object))
+	require.Equal(t, uint64(4), jc.VirtualExecutorForObjectCounter)
+}
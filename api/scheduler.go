@@ -0,0 +1,153 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// Task is a state of periodic node task.
+type Task struct {
+	Name        string
+	Every       string
+	EveryPulses int
+	Running     bool
+	Runs        int
+	LastRun     string
+	LastPulse   uint32
+	LastError   string
+}
+
+// SchedulerReply is reply for Scheduler service requests.
+type SchedulerReply struct {
+	Tasks []Task
+}
+
+// SchedulerRunArgs is arguments of Scheduler.Run request.
+type SchedulerRunArgs struct {
+	Name string
+}
+
+// SchedulerService is a service that provides API for periodic tasks of node.
+type SchedulerService struct {
+	runner *Runner
+}
+
+// NewSchedulerService creates new SchedulerService instance.
+func NewSchedulerService(runner *Runner) *SchedulerService {
+	return &SchedulerService{runner: runner}
+}
+
+// List returns state of periodic tasks of node.
+//
+//	  Request structure:
+//	  {
+//	    "jsonrpc": "2.0",
+//	    "method": "scheduler.List",
+//	    "id": str|int|null
+//	  }
+//
+//	    Response structure:
+//		{
+//			"jsonrpc": "2.0",
+//			"result": {
+//				"Tasks": [{
+//					"Name": str, // name of task
+//					"Every": str, // interval of wall time, empty if task isn't scheduled by time
+//					"EveryPulses": int, // interval in pulses, zero if task isn't scheduled by pulses
+//					"Running": bool, // task is running at the moment
+//					"Runs": int, // number of finished runs
+//					"LastRun": str, // start time of last run in RFC3339, empty if task never run
+//					"LastPulse": int, // pulse number of last run
+//					"LastError": str // error of last run, if any
+//				}]
+//			},
+//			"id": str|int|null // same as in request
+//		}
+func (s *SchedulerService) List(r *http.Request, args *interface{}, reply *SchedulerReply) error {
+	_, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ SchedulerService.List ] Incoming request: %s", r.RequestURI)
+
+	reply.Tasks = tasksReply(s.runner.Scheduler.Tasks())
+	return nil
+}
+
+// Run runs periodic task out of schedule, waits for it to finish and returns state of the task.
+// Request must be authorized with admin token.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "scheduler.Run",
+//	  "params": {
+//	    "Name": str // name of task
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	  Response structure is the same as for scheduler.List with single task.
+func (s *SchedulerService) Run(r *http.Request, args *SchedulerRunArgs, reply *SchedulerReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ SchedulerService.Run ] Incoming request: %s, task: %s", r.RequestURI, args.Name)
+
+	if err := s.runner.authorizeAdmin(r, "SchedulerService"); err != nil {
+		inslog.Warnf("[ SchedulerService.Run ] unauthorized request from %s: %s", r.RemoteAddr, err)
+		return err
+	}
+	err := s.runner.Scheduler.Trigger(ctx, args.Name)
+	if err != nil {
+		return errors.Wrap(err, "[ SchedulerService.Run ] task failed")
+	}
+
+	for _, t := range tasksReply(s.runner.Scheduler.Tasks()) {
+		if t.Name == args.Name {
+			reply.Tasks = []Task{t}
+		}
+	}
+	return nil
+}
+
+func tasksReply(infos []core.TaskInfo) []Task {
+	tasks := make([]Task, len(infos))
+	for i, info := range infos {
+		tasks[i] = Task{
+			Name:        info.Name,
+			EveryPulses: info.Schedule.Pulses,
+			Running:     info.Running,
+			Runs:        info.Runs,
+			LastPulse:   uint32(info.LastPulse),
+			LastError:   info.LastError,
+		}
+		if info.Schedule.Every > 0 {
+			tasks[i].Every = info.Schedule.Every.String()
+		}
+		if !info.LastRun.IsZero() {
+			tasks[i].LastRun = info.LastRun.Format(time.RFC3339)
+		}
+	}
+	return tasks
+}
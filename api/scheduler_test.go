@@ -0,0 +1,60 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+)
+
+type triggerScheduler struct {
+	triggered []string
+}
+
+func (s *triggerScheduler) Schedule(task core.PeriodicTask) error {
+	return nil
+}
+
+func (s *triggerScheduler) Tasks() []core.TaskInfo {
+	return []core.TaskInfo{{Name: "task"}}
+}
+
+func (s *triggerScheduler) Trigger(ctx context.Context, name string) error {
+	s.triggered = append(s.triggered, name)
+	return nil
+}
+
+func TestSchedulerService_Run(t *testing.T) {
+	scheduler := &triggerScheduler{}
+	cfg := &configuration.APIRunner{AdminToken: "secret"}
+	service := NewSchedulerService(&Runner{cfg: cfg, Scheduler: scheduler})
+	args := &SchedulerRunArgs{Name: "task"}
+
+	err := service.Run(&http.Request{Header: http.Header{}}, args, &SchedulerReply{})
+	require.Contains(t, err.Error(), "admin token is required")
+	require.Empty(t, scheduler.triggered)
+
+	r := &http.Request{Header: http.Header{"Authorization": {"Bearer secret"}}}
+	require.NoError(t, service.Run(r, args, &SchedulerReply{}))
+	require.Equal(t, []string{"task"}, scheduler.triggered)
+}
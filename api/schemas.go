@@ -0,0 +1,98 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// SchemasGetArgs is arguments of Schemas.Get request.
+type SchemasGetArgs struct {
+	Prototype string
+}
+
+// SchemasGetReply is reply for Schemas.Get request.
+type SchemasGetReply struct {
+	Prototype string
+	Schema    *core.ContractSchema
+}
+
+// SchemasService is a service that provides schemas of contract methods for decoding of calls.
+type SchemasService struct {
+	runner *Runner
+}
+
+// NewSchemasService creates new SchemasService instance.
+func NewSchemasService(runner *Runner) *SchemasService {
+	return &SchemasService{runner: runner}
+}
+
+// Get returns schema of arguments and results of methods of contract prototype.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "schemas.Get",
+//	  "params": {
+//	    "Prototype": str // reference of contract prototype
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	Response structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "result": {
+//	    "Prototype": str, // reference of contract prototype
+//	    "Schema": {
+//	      "methods": {
+//	        str: { // name of method or constructor
+//	          "arguments": [{"name": str, "type": str}, ...],
+//	          "results": [{"name": str, "type": str}, ...] // name is omitted for unnamed results
+//	        }, ...
+//	      }
+//	    }
+//	  },
+//	  "id": str|int|null // same as in request
+//	}
+func (s *SchemasService) Get(r *http.Request, args *SchemasGetArgs, reply *SchemasGetReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ SchemasService.Get ] Incoming request: %s", r.RequestURI)
+
+	prototype, err := core.NewRefFromBase58(args.Prototype)
+	if err != nil {
+		return errors.Wrap(err, "[ SchemasService.Get ] invalid prototype reference")
+	}
+	schema, err := s.runner.Schemas.Schema(ctx, *prototype)
+	if err == core.ErrNotFound {
+		return errors.Errorf("[ SchemasService.Get ] prototype %s was deployed without schema", args.Prototype)
+	}
+	if err != nil {
+		return errors.Wrap(err, "[ SchemasService.Get ] failed to fetch schema")
+	}
+
+	reply.Prototype = prototype.String()
+	reply.Schema = schema
+	return nil
+}
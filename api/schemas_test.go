@@ -0,0 +1,60 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+)
+
+type schemaRegistry map[core.RecordRef]*core.ContractSchema
+
+func (r schemaRegistry) Schema(ctx context.Context, prototype core.RecordRef) (*core.ContractSchema, error) {
+	schema, ok := r[prototype]
+	if !ok {
+		return nil, core.ErrNotFound
+	}
+	return schema, nil
+}
+
+func TestSchemasService_Get(t *testing.T) {
+	prototype := testutils.RandomRef()
+	schema := &core.ContractSchema{Methods: map[string]core.MethodSchema{
+		"Transfer": {
+			Arguments: []core.FieldSchema{{Name: "amount", Type: "uint"}, {Name: "to", Type: "*core.RecordRef"}},
+			Results:   []core.FieldSchema{{Type: "error"}},
+		},
+	}}
+	service := NewSchemasService(&Runner{Schemas: schemaRegistry{prototype: schema}})
+
+	var rep SchemasGetReply
+	err := service.Get(&http.Request{}, &SchemasGetArgs{Prototype: prototype.String()}, &rep)
+	require.NoError(t, err)
+	require.Equal(t, prototype.String(), rep.Prototype)
+	require.Equal(t, schema, rep.Schema)
+
+	err = service.Get(&http.Request{}, &SchemasGetArgs{Prototype: testutils.RandomRef().String()}, &rep)
+	require.Contains(t, err.Error(), "without schema")
+
+	err = service.Get(&http.Request{}, &SchemasGetArgs{Prototype: "bad"}, &rep)
+	require.Contains(t, err.Error(), "invalid prototype reference")
+}
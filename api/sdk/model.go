@@ -29,3 +29,9 @@ func NewMember(ref string, key string) *Member {
 		PrivateKey: key,
 	}
 }
+
+// BulkMember is a result of member creation in bulk request, Member is nil if creation failed
+type BulkMember struct {
+	Member *Member
+	Error  string
+}
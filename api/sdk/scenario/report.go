@@ -0,0 +1,133 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package scenario
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+)
+
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Skipped  int          `xml:"skipped,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Skipped  int         `xml:"skipped,attr"`
+	Time     string      `xml:"time,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *struct{}     `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+// WriteJUnit writes results of scenarios as JUnit XML report, every scenario is a test suite and every step
+// is a test case.
+func WriteJUnit(w io.Writer, results []*Result) error {
+	report := junitSuites{}
+	for _, res := range results {
+		suite := junitSuite{Name: res.Scenario, Time: seconds(res.Duration)}
+		for i, s := range res.Steps {
+			c := junitCase{
+				Name:      fmt.Sprintf("%02d %s", i+1, s.Name),
+				Classname: res.Scenario,
+				Time:      seconds(s.Duration),
+			}
+			if s.TraceID != "" {
+				c.SystemOut = "trace " + s.TraceID
+			}
+			switch {
+			case s.Skipped:
+				c.Skipped = &struct{}{}
+				suite.Skipped++
+			case s.Err != nil:
+				c.Failure = &junitFailure{Message: s.Err.Error(), Text: fmt.Sprintf("%+v", s.Err)}
+				suite.Failures++
+			}
+			suite.Cases = append(suite.Cases, c)
+		}
+		suite.Tests = len(suite.Cases)
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Skipped += suite.Skipped
+		report.Suites = append(report.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// Write writes human readable result of scenario.
+func (r *Result) Write(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "Scenario %s\n", r.Scenario); err != nil {
+		return err
+	}
+	for _, s := range r.Steps {
+		status := "OK"
+		switch {
+		case s.Skipped:
+			status = "SKIPPED"
+		case s.Err != nil:
+			status = "FAIL: " + s.Err.Error()
+		}
+		trace := ""
+		if s.TraceID != "" {
+			trace = " trace " + s.TraceID
+		}
+		_, err := fmt.Fprintf(w, "  %-40s %10s%s %s\n", s.Name, s.Duration.Round(time.Millisecond), trace, status)
+		if err != nil {
+			return err
+		}
+	}
+	result := "PASSED"
+	if !r.Passed() {
+		result = "FAILED"
+	}
+	_, err := fmt.Fprintf(w, "Scenario %s %s\n", r.Scenario, result)
+	return err
+}
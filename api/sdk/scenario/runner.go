@@ -0,0 +1,290 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package scenario
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/insolar/insolar/api/sdk"
+	"github.com/pkg/errors"
+)
+
+// Client is a part of SDK used by scenarios.
+type Client interface {
+	CreateMember() (*sdk.Member, string, error)
+	Transfer(amount uint, from *sdk.Member, to *sdk.Member) (string, error)
+	GetBalance(m *sdk.Member) (uint64, error)
+	Call(m *sdk.Member, method string, params []interface{}) (interface{}, string, error)
+	CurrentPulse() (uint32, error)
+}
+
+// Config is configuration of scenario runner.
+type Config struct {
+	// BalanceTimeout is how long balance checks wait for expected balance by default.
+	BalanceTimeout time.Duration
+	// PulseTimeout is how long to wait for every next pulse.
+	PulseTimeout time.Duration
+	// PollInterval is interval between checks of balances and pulses.
+	PollInterval time.Duration
+}
+
+// NewConfig creates config with default values.
+func NewConfig() Config {
+	return Config{
+		BalanceTimeout: 30 * time.Second,
+		PulseTimeout:   time.Minute,
+		PollInterval:   time.Second,
+	}
+}
+
+// StepResult is result of scenario step.
+type StepResult struct {
+	Name     string
+	Duration time.Duration
+	TraceID  string
+	Err      error
+	// Skipped is true if step wasn't run because previous step failed.
+	Skipped bool
+}
+
+// Passed returns true if step was run and succeeded.
+func (s StepResult) Passed() bool {
+	return !s.Skipped && s.Err == nil
+}
+
+// Result is result of scenario.
+type Result struct {
+	Scenario string
+	Duration time.Duration
+	Steps    []StepResult
+}
+
+// Passed returns true if all steps succeeded.
+func (r *Result) Passed() bool {
+	for _, s := range r.Steps {
+		if !s.Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+type runner struct {
+	cfg    Config
+	client Client
+
+	members map[string]*sdk.Member
+	// balances are balances seen by previous check of every member, base of expected changes.
+	balances map[string]uint64
+}
+
+// Run runs steps of scenario in order using client. Steps after the first failed one are skipped.
+func Run(ctx context.Context, cfg Config, client Client, s *Scenario) *Result {
+	r := &runner{
+		cfg:      cfg,
+		client:   client,
+		members:  map[string]*sdk.Member{},
+		balances: map[string]uint64{},
+	}
+	for name, account := range s.Members {
+		r.members[name] = sdk.NewMember(account.Reference, account.privateKey)
+	}
+
+	res := &Result{Scenario: s.Name}
+	start := time.Now()
+	failed := false
+	for i := range s.Steps {
+		step := &s.Steps[i]
+		if failed {
+			res.Steps = append(res.Steps, StepResult{Name: step.Title(), Skipped: true})
+			continue
+		}
+		stepStart := time.Now()
+		traceID, err := r.step(ctx, step)
+		res.Steps = append(res.Steps, StepResult{
+			Name:     step.Title(),
+			Duration: time.Since(stepStart),
+			TraceID:  traceID,
+			Err:      err,
+		})
+		failed = err != nil
+	}
+	res.Duration = time.Since(start)
+	return res
+}
+
+func (r *runner) step(ctx context.Context, st *Step) (string, error) {
+	switch {
+	case st.CheckBalance != nil:
+		return "", r.checkBalance(ctx, st.CheckBalance)
+	case st.WaitPulses != 0:
+		return "", r.waitPulses(ctx, st.WaitPulses)
+	}
+
+	traceID, err := r.act(st)
+	if st.ExpectError == "" {
+		return traceID, err
+	}
+	if err == nil {
+		return traceID, errors.Errorf("expected error %q, got success", st.ExpectError)
+	}
+	if !strings.Contains(err.Error(), st.ExpectError) {
+		return traceID, errors.Errorf("expected error %q, got %q", st.ExpectError, err)
+	}
+	return traceID, nil
+}
+
+// act runs action which can be expected to fail.
+func (r *runner) act(st *Step) (string, error) {
+	switch {
+	case st.CreateMember != "":
+		m, traceID, err := r.client.CreateMember()
+		if err != nil {
+			return traceID, err
+		}
+		balance, err := r.client.GetBalance(m)
+		if err != nil {
+			return traceID, errors.Wrap(err, "can't get balance of created member")
+		}
+		r.members[st.CreateMember] = m
+		r.balances[st.CreateMember] = balance
+		return traceID, nil
+	case st.Transfer != nil:
+		return r.client.Transfer(st.Transfer.Amount, r.members[st.Transfer.From], r.members[st.Transfer.To])
+	case st.Call != nil:
+		return r.call(st.Call)
+	}
+	return "", errors.New("no action")
+}
+
+func (r *runner) call(c *Call) (string, error) {
+	result, traceID, err := r.client.Call(r.members[c.Member], c.Method, normalize(c.Params).([]interface{}))
+	if err != nil || c.Result == nil {
+		return traceID, err
+	}
+	got, err := json.Marshal(result)
+	if err != nil {
+		return traceID, errors.Wrap(err, "can't marshal result")
+	}
+	expected, err := json.Marshal(normalize(c.Result))
+	if err != nil {
+		return traceID, errors.Wrap(err, "can't marshal expected result")
+	}
+	if string(got) != string(expected) {
+		return traceID, errors.Errorf("result is %s, expected %s", got, expected)
+	}
+	return traceID, nil
+}
+
+func (r *runner) checkBalance(ctx context.Context, c *CheckBalance) error {
+	var expected uint64
+	if c.Equals != nil {
+		expected = *c.Equals
+	} else {
+		base, ok := r.balances[c.Member]
+		if !ok {
+			b, err := r.client.GetBalance(r.members[c.Member])
+			if err != nil {
+				return errors.Wrap(err, "can't get base balance")
+			}
+			base = b
+		}
+		expected = uint64(int64(base) + *c.Change)
+	}
+
+	timeout := c.Timeout
+	if timeout == 0 {
+		timeout = r.cfg.BalanceTimeout
+	}
+	return poll(ctx, timeout, r.cfg.PollInterval, func() error {
+		balance, err := r.client.GetBalance(r.members[c.Member])
+		if err != nil {
+			return err
+		}
+		if balance != expected {
+			return errors.Errorf("balance of %s is %d, expected %d", c.Member, balance, expected)
+		}
+		r.balances[c.Member] = balance
+		return nil
+	})
+}
+
+func (r *runner) waitPulses(ctx context.Context, count int) error {
+	current, err := r.client.CurrentPulse()
+	if err != nil {
+		return errors.Wrap(err, "can't get current pulse")
+	}
+	for i := 0; i < count; i++ {
+		err := poll(ctx, r.cfg.PulseTimeout, r.cfg.PollInterval, func() error {
+			pulse, err := r.client.CurrentPulse()
+			if err != nil {
+				return err
+			}
+			if pulse <= current {
+				return errors.Errorf("pulse %d hasn't changed", pulse)
+			}
+			current = pulse
+			return nil
+		})
+		if err != nil {
+			return errors.Wrapf(err, "%d of %d pulses passed", i, count)
+		}
+	}
+	return nil
+}
+
+// normalize converts maps decoded from YAML to maps with string keys which can be marshaled to JSON.
+func normalize(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			m[fmt.Sprint(key)] = normalize(value)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(v))
+		for i, value := range v {
+			l[i] = normalize(value)
+		}
+		return l
+	}
+	return v
+}
+
+// poll calls f until it succeeds or timeout expires, returns last error of f on timeout.
+func poll(ctx context.Context, timeout, interval time.Duration, f func() error) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		err := f()
+		if err == nil {
+			return nil
+		}
+		if time.Now().Add(interval).After(deadline) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
@@ -0,0 +1,236 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+// Package scenario runs functional test scenarios described in YAML against live network. Scenario is a sequence
+// of API calls signed by members it creates or declares: member creation, transfers, arbitrary calls, checks of
+// balances and results, expected errors and waits for pulses. Results are reported in JUnit format.
+package scenario
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Scenario is a named sequence of steps.
+type Scenario struct {
+	Name string `yaml:"name"`
+	// Members declares existing members of network by name, their keys are read from files.
+	Members map[string]*Account `yaml:"members"`
+	Steps   []Step              `yaml:"steps"`
+}
+
+// Account is an existing member of network.
+type Account struct {
+	Reference string `yaml:"reference"`
+	// KeysFile is a path to JSON file with "private_key" field, relative path is resolved against scenario file.
+	KeysFile string `yaml:"keys_file"`
+
+	privateKey string
+}
+
+// Step is a single action of scenario. Exactly one action is set.
+type Step struct {
+	Name string `yaml:"name"`
+
+	// CreateMember creates member with random keys and registers it under provided name.
+	CreateMember string        `yaml:"create_member"`
+	Transfer     *Transfer     `yaml:"transfer"`
+	Call         *Call         `yaml:"call"`
+	CheckBalance *CheckBalance `yaml:"check_balance"`
+	// WaitPulses waits until provided number of pulses pass.
+	WaitPulses int `yaml:"wait_pulses"`
+
+	// ExpectError is a substring of error the action must fail with. Step fails if action succeeds.
+	ExpectError string `yaml:"expect_error"`
+}
+
+// Transfer moves amount from one member to another.
+type Transfer struct {
+	From   string `yaml:"from"`
+	To     string `yaml:"to"`
+	Amount uint   `yaml:"amount"`
+}
+
+// Call calls method of API on behalf of member.
+type Call struct {
+	Member string        `yaml:"member"`
+	Method string        `yaml:"method"`
+	Params []interface{} `yaml:"params"`
+	// Result is an expected result of call compared as JSON, nil skips the check.
+	Result interface{} `yaml:"result"`
+}
+
+// CheckBalance polls balance of member until it matches expectation or timeout expires.
+type CheckBalance struct {
+	Member string `yaml:"member"`
+	// Equals is an expected balance.
+	Equals *uint64 `yaml:"equals"`
+	// Change is an expected difference from balance seen by previous check or on member creation.
+	Change *int64 `yaml:"change"`
+	// Timeout overrides default time of waiting for balance.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// Parse decodes scenario from YAML and checks it against schema: unknown fields, missing or ambiguous actions
+// and references to undeclared members are errors.
+func Parse(data []byte) (*Scenario, error) {
+	var s Scenario
+	if err := yaml.UnmarshalStrict(data, &s); err != nil {
+		return nil, errors.Wrap(err, "[ Parse ] invalid scenario")
+	}
+	if err := s.validate(); err != nil {
+		return nil, errors.Wrapf(err, "[ Parse ] invalid scenario %q", s.Name)
+	}
+	return &s, nil
+}
+
+// Load reads scenario from file and keys of its declared members.
+func Load(path string) (*Scenario, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ Load ] can't read scenario")
+	}
+	s, err := Parse(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[ Load ] %s", path)
+	}
+	if s.Name == "" {
+		s.Name = filepath.Base(path)
+	}
+
+	for name, account := range s.Members {
+		keysPath := account.KeysFile
+		if !filepath.IsAbs(keysPath) {
+			keysPath = filepath.Join(filepath.Dir(path), keysPath)
+		}
+		raw, err := ioutil.ReadFile(keysPath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "[ Load ] can't read keys of member %s", name)
+		}
+		var keys struct {
+			Private string `json:"private_key"`
+		}
+		if err := json.Unmarshal(raw, &keys); err != nil {
+			return nil, errors.Wrapf(err, "[ Load ] can't unmarshal keys of member %s", name)
+		}
+		account.privateKey = keys.Private
+	}
+	return s, nil
+}
+
+func (s *Scenario) validate() error {
+	if len(s.Steps) == 0 {
+		return errors.New("no steps")
+	}
+	known := map[string]bool{}
+	for name, account := range s.Members {
+		if account == nil || account.Reference == "" || account.KeysFile == "" {
+			return errors.Errorf("member %s: reference and keys_file are required", name)
+		}
+		known[name] = true
+	}
+	for i := range s.Steps {
+		if err := s.Steps[i].validate(known); err != nil {
+			return errors.Wrapf(err, "step %d (%s)", i+1, s.Steps[i].Title())
+		}
+	}
+	return nil
+}
+
+func (st *Step) validate(known map[string]bool) error {
+	actions := 0
+	for _, set := range []bool{
+		st.CreateMember != "", st.Transfer != nil, st.Call != nil, st.CheckBalance != nil, st.WaitPulses != 0,
+	} {
+		if set {
+			actions++
+		}
+	}
+	if actions != 1 {
+		return errors.Errorf("exactly one action is expected, got %d", actions)
+	}
+
+	member := func(name string) error {
+		if !known[name] {
+			return errors.Errorf("member %q isn't created or declared", name)
+		}
+		return nil
+	}
+	switch {
+	case st.CreateMember != "":
+		if known[st.CreateMember] {
+			return errors.Errorf("member %q already exists", st.CreateMember)
+		}
+		if st.ExpectError == "" {
+			known[st.CreateMember] = true
+		}
+	case st.Transfer != nil:
+		if st.Transfer.Amount == 0 {
+			return errors.New("transfer amount is required")
+		}
+		if err := member(st.Transfer.From); err != nil {
+			return err
+		}
+		return member(st.Transfer.To)
+	case st.Call != nil:
+		if st.Call.Method == "" {
+			return errors.New("call method is required")
+		}
+		if st.ExpectError != "" && st.Call.Result != nil {
+			return errors.New("call can't expect both result and error")
+		}
+		return member(st.Call.Member)
+	case st.CheckBalance != nil:
+		if (st.CheckBalance.Equals == nil) == (st.CheckBalance.Change == nil) {
+			return errors.New("exactly one of balance equals and change is expected")
+		}
+		if st.ExpectError != "" {
+			return errors.New("balance check can't expect error")
+		}
+		return member(st.CheckBalance.Member)
+	case st.WaitPulses < 0:
+		return errors.New("number of pulses must be positive")
+	case st.ExpectError != "":
+		return errors.New("waiting for pulses can't expect error")
+	}
+	return nil
+}
+
+// Title returns name of step, description of its action if name isn't set.
+func (st *Step) Title() string {
+	if st.Name != "" {
+		return st.Name
+	}
+	switch {
+	case st.CreateMember != "":
+		return "create member " + st.CreateMember
+	case st.Transfer != nil:
+		return fmt.Sprintf("transfer %d from %s to %s", st.Transfer.Amount, st.Transfer.From, st.Transfer.To)
+	case st.Call != nil:
+		return fmt.Sprintf("call %s by %s", st.Call.Method, st.Call.Member)
+	case st.CheckBalance != nil:
+		return "check balance of " + st.CheckBalance.Member
+	case st.WaitPulses != 0:
+		return fmt.Sprintf("wait %d pulses", st.WaitPulses)
+	}
+	return "empty step"
+}
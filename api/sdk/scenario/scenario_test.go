@@ -0,0 +1,189 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package scenario
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/insolar/insolar/api/sdk"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClient struct {
+	balances map[string]uint64
+	pulse    uint32
+	calls    []string
+}
+
+func (c *fakeClient) CreateMember() (*sdk.Member, string, error) {
+	m := sdk.NewMember(string(rune('a'+len(c.balances))), "key")
+	c.balances[m.Reference] = 1000
+	return m, "trace", nil
+}
+
+func (c *fakeClient) Transfer(amount uint, from *sdk.Member, to *sdk.Member) (string, error) {
+	if c.balances[from.Reference] < uint64(amount) {
+		return "trace", errors.New("not enough balance")
+	}
+	c.balances[from.Reference] -= uint64(amount)
+	c.balances[to.Reference] += uint64(amount)
+	return "trace", nil
+}
+
+func (c *fakeClient) GetBalance(m *sdk.Member) (uint64, error) {
+	return c.balances[m.Reference], nil
+}
+
+func (c *fakeClient) Call(m *sdk.Member, method string, params []interface{}) (interface{}, string, error) {
+	c.calls = append(c.calls, method)
+	return map[string]interface{}{"member": m.Reference, "params": params}, "trace", nil
+}
+
+func (c *fakeClient) CurrentPulse() (uint32, error) {
+	c.pulse++
+	return c.pulse, nil
+}
+
+func testConfig() Config {
+	cfg := NewConfig()
+	cfg.BalanceTimeout = 10 * time.Millisecond
+	cfg.PulseTimeout = 10 * time.Millisecond
+	cfg.PollInterval = time.Millisecond
+	return cfg
+}
+
+const transferScenario = `
+name: transfer
+steps:
+  - create_member: alice
+  - create_member: bob
+  - transfer: {from: alice, to: bob, amount: 100}
+  - check_balance: {member: alice, change: -100}
+  - check_balance: {member: bob, equals: 1100}
+  - name: overdraft
+    transfer: {from: alice, to: bob, amount: 10000}
+    expect_error: not enough balance
+  - wait_pulses: 2
+  - call:
+      member: bob
+      method: GetInfo
+      params: [{key: value}]
+      result: {member: b, params: [{key: value}]}
+`
+
+func TestRun(t *testing.T) {
+	s, err := Parse([]byte(transferScenario))
+	require.NoError(t, err)
+
+	client := &fakeClient{balances: map[string]uint64{}}
+	res := Run(context.Background(), testConfig(), client, s)
+	for _, step := range res.Steps {
+		require.NoError(t, step.Err, step.Name)
+	}
+	require.True(t, res.Passed())
+	require.Len(t, res.Steps, 8)
+	require.Equal(t, "overdraft", res.Steps[5].Name)
+	require.Equal(t, []string{"GetInfo"}, client.calls)
+
+	var buf bytes.Buffer
+	require.NoError(t, res.Write(&buf))
+	require.Contains(t, buf.String(), "Scenario transfer PASSED")
+}
+
+func TestRun_FailureSkipsSteps(t *testing.T) {
+	s, err := Parse([]byte(`
+name: failing
+steps:
+  - create_member: alice
+  - check_balance: {member: alice, equals: 1}
+  - create_member: bob
+`))
+	require.NoError(t, err)
+
+	res := Run(context.Background(), testConfig(), &fakeClient{balances: map[string]uint64{}}, s)
+	require.False(t, res.Passed())
+	require.True(t, res.Steps[0].Passed())
+	require.Contains(t, res.Steps[1].Err.Error(), "balance of alice is 1000, expected 1")
+	require.True(t, res.Steps[2].Skipped)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteJUnit(&buf, []*Result{res}))
+	out := buf.String()
+	require.Contains(t, out, `<testsuite name="failing" tests="3" failures="1" skipped="1"`)
+	require.Contains(t, out, `<failure message="balance of alice is 1000, expected 1">`)
+	require.Contains(t, out, `<skipped></skipped>`)
+}
+
+func TestRun_UnexpectedSuccess(t *testing.T) {
+	s, err := Parse([]byte(`
+steps:
+  - create_member: alice
+  - create_member: bob
+  - transfer: {from: alice, to: bob, amount: 1}
+    expect_error: not enough balance
+`))
+	require.NoError(t, err)
+
+	res := Run(context.Background(), testConfig(), &fakeClient{balances: map[string]uint64{}}, s)
+	require.False(t, res.Passed())
+	require.Contains(t, res.Steps[2].Err.Error(), "got success")
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for name, data := range map[string]string{
+		"unknown field":     "steps: [{create_member: a, unknown: 1}]",
+		"no steps":          "name: empty",
+		"no action":         "steps: [{name: nothing}]",
+		"two actions":       "steps: [{create_member: a, wait_pulses: 1}]",
+		"undeclared member": "steps: [{check_balance: {member: a, equals: 1}}]",
+		"duplicate member":  "steps: [{create_member: a}, {create_member: a}]",
+		"zero amount":       "steps: [{create_member: a}, {transfer: {from: a, to: a}}]",
+		"both expectations": "steps: [{create_member: a}, {check_balance: {member: a, equals: 1, change: 1}}]",
+		"member keys":       "members: {root: {reference: ref}}\nsteps: [{wait_pulses: 1}]",
+	} {
+		_, err := Parse([]byte(data))
+		require.Error(t, err, name)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "scenario")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	keys := `{"private_key": "private", "public_key": "public"}`
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "keys.json"), []byte(keys), 0600))
+	data := "members: {root: {reference: ref, keys_file: keys.json}}\nsteps: [{check_balance: {member: root, change: 0}}]"
+	path := filepath.Join(dir, "root.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(data), 0600))
+
+	s, err := Load(path)
+	require.NoError(t, err)
+	require.Equal(t, "root.yaml", s.Name)
+	require.Equal(t, "private", s.Members["root"].privateKey)
+
+	client := &fakeClient{balances: map[string]uint64{"ref": 5}}
+	res := Run(context.Background(), testConfig(), client, s)
+	require.True(t, res.Passed())
+}
@@ -18,6 +18,7 @@ package sdk
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"io/ioutil"
 	"sync"
@@ -157,6 +158,79 @@ func (sdk *SDK) CreateMember() (*Member, string, error) {
 	return NewMember(response.Result.(string), string(privateKeyStr)), response.TraceID, nil
 }
 
+// BulkCreateMembers api request creates count members with new random keys in one call
+func (sdk *SDK) BulkCreateMembers(count int) ([]BulkMember, string, error) {
+	ctx := inslogger.ContextWithTrace(context.Background(), "BulkCreateMembers")
+	ks := platformpolicy.NewKeyProcessor()
+
+	names := make([]string, count)
+	pubKeys := make([]string, count)
+	privateKeys := make([]string, count)
+	for i := 0; i < count; i++ {
+		privateKey, err := ks.GeneratePrivateKey()
+		if err != nil {
+			return nil, "", errors.Wrap(err, "[ BulkCreateMembers ] can't generate private key")
+		}
+
+		privateKeyStr, err := ks.ExportPrivateKeyPEM(privateKey)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "[ BulkCreateMembers ] can't export private key")
+		}
+
+		pubKeyStr, err := ks.ExportPublicKeyPEM(ks.ExtractPublicKey(privateKey))
+		if err != nil {
+			return nil, "", errors.Wrap(err, "[ BulkCreateMembers ] can't extract public key")
+		}
+
+		names[i] = testutils.RandomString()
+		pubKeys[i] = string(pubKeyStr)
+		privateKeys[i] = string(privateKeyStr)
+	}
+
+	params := []interface{}{names, pubKeys}
+	body, err := sdk.sendRequest(ctx, "BulkCreateMembers", params, sdk.rootMember)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "[ BulkCreateMembers ] can't send request")
+	}
+
+	response, err := sdk.getResponse(body)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "[ BulkCreateMembers ] can't get response")
+	}
+
+	if response.Error != "" {
+		return nil, response.TraceID, errors.New(response.Error)
+	}
+
+	encoded, ok := response.Result.(string)
+	if !ok {
+		return nil, response.TraceID, errors.New("[ BulkCreateMembers ] unexpected result type")
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, response.TraceID, errors.Wrap(err, "[ BulkCreateMembers ] can't decode result")
+	}
+	var items []struct {
+		Reference string
+		Error     string
+	}
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, response.TraceID, errors.Wrap(err, "[ BulkCreateMembers ] can't unmarshal result")
+	}
+	if len(items) != count {
+		return nil, response.TraceID, errors.Errorf("[ BulkCreateMembers ] got %d results for %d members", len(items), count)
+	}
+
+	members := make([]BulkMember, count)
+	for i, item := range items {
+		members[i].Error = item.Error
+		if item.Error == "" {
+			members[i].Member = NewMember(item.Reference, privateKeys[i])
+		}
+	}
+	return members, response.TraceID, nil
+}
+
 // Transfer method send money from one member to another
 func (sdk *SDK) Transfer(amount uint, from *Member, to *Member) (string, error) {
 	ctx := inslogger.ContextWithTrace(context.Background(), "Transfer")
@@ -183,6 +257,61 @@ func (sdk *SDK) Transfer(amount uint, from *Member, to *Member) (string, error)
 	return response.TraceID, nil
 }
 
+// TransferWithConfirmation method send money from one member to another, transfer above threshold of large
+// transfers is held until it is confirmed by ConfirmTransfer. It returns reference of held transfer,
+// reference is empty if transfer was made at once
+func (sdk *SDK) TransferWithConfirmation(amount uint, from *Member, to *Member) (string, string, error) {
+	ctx := inslogger.ContextWithTrace(context.Background(), "TransferWithConfirmation")
+	params := []interface{}{amount, to.Reference}
+	config, err := requester.CreateUserConfig(from.Reference, from.PrivateKey)
+	if err != nil {
+		return "", "", errors.Wrap(err, "[ TransferWithConfirmation ] can't create user config")
+	}
+
+	body, err := sdk.sendRequest(ctx, "Transfer", params, config)
+	if err != nil {
+		return "", "", errors.Wrap(err, "[ TransferWithConfirmation ] can't send request")
+	}
+
+	response, err := sdk.getResponse(body)
+	if err != nil {
+		return "", "", errors.Wrap(err, "[ TransferWithConfirmation ] can't get response")
+	}
+
+	if response.Error != "" {
+		return "", response.TraceID, errors.New(response.Error)
+	}
+
+	transferRef, _ := response.Result.(string)
+	return transferRef, response.TraceID, nil
+}
+
+// ConfirmTransfer method confirms large transfer held by TransferWithConfirmation
+func (sdk *SDK) ConfirmTransfer(from *Member, transferRef string) (string, error) {
+	ctx := inslogger.ContextWithTrace(context.Background(), "ConfirmTransfer")
+	params := []interface{}{transferRef}
+	config, err := requester.CreateUserConfig(from.Reference, from.PrivateKey)
+	if err != nil {
+		return "", errors.Wrap(err, "[ ConfirmTransfer ] can't create user config")
+	}
+
+	body, err := sdk.sendRequest(ctx, "ConfirmTransfer", params, config)
+	if err != nil {
+		return "", errors.Wrap(err, "[ ConfirmTransfer ] can't send request")
+	}
+
+	response, err := sdk.getResponse(body)
+	if err != nil {
+		return "", errors.Wrap(err, "[ ConfirmTransfer ] can't get response")
+	}
+
+	if response.Error != "" {
+		return response.TraceID, errors.New(response.Error)
+	}
+
+	return response.TraceID, nil
+}
+
 // GetBalance returns current balance of the given member.
 func (sdk *SDK) GetBalance(m *Member) (uint64, error) {
 	ctx := inslogger.ContextWithTrace(context.Background(), "GetBalance")
@@ -209,3 +338,37 @@ func (sdk *SDK) GetBalance(m *Member) (uint64, error) {
 	// TODO FIXME don't transfer money in floats!
 	return uint64(response.Result.(float64)), nil
 }
+
+// Call calls method of API on behalf of the given member and returns its result.
+func (sdk *SDK) Call(m *Member, method string, params []interface{}) (interface{}, string, error) {
+	ctx := inslogger.ContextWithTrace(context.Background(), method)
+	config, err := requester.CreateUserConfig(m.Reference, m.PrivateKey)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "[ Call ] can't create user config")
+	}
+
+	body, err := sdk.sendRequest(ctx, method, params, config)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "[ Call ] can't send request")
+	}
+
+	response, err := sdk.getResponse(body)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "[ Call ] can't get response")
+	}
+
+	if response.Error != "" {
+		return nil, response.TraceID, errors.New(response.Error)
+	}
+
+	return response.Result, response.TraceID, nil
+}
+
+// CurrentPulse returns number of current pulse of the network.
+func (sdk *SDK) CurrentPulse() (uint32, error) {
+	status, err := requester.Status(sdk.apiURLs.next())
+	if err != nil {
+		return 0, errors.Wrap(err, "[ CurrentPulse ] can't get status")
+	}
+	return status.PulseNumber, nil
+}
@@ -0,0 +1,280 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+// Package smoke runs golden path scenario against live network: waits until network is ready,
+// creates members, transfers money between them and checks balances.
+package smoke
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/insolar/insolar/api/requester"
+	"github.com/insolar/insolar/api/sdk"
+	"github.com/insolar/insolar/core"
+	"github.com/pkg/errors"
+)
+
+// Client is a part of SDK used by smoke test.
+type Client interface {
+	CreateMember() (*sdk.Member, string, error)
+	Transfer(amount uint, from *sdk.Member, to *sdk.Member) (string, error)
+	GetBalance(m *sdk.Member) (uint64, error)
+}
+
+// Config is configuration of smoke test.
+type Config struct {
+	APIURLs        []string
+	RootMemberKeys string
+
+	// Amount is transferred between created members.
+	Amount uint
+	// MaxLatency fails step which took longer, zero disables the check.
+	MaxLatency time.Duration
+	// ReadyTimeout is how long to wait for network to become ready.
+	ReadyTimeout time.Duration
+	// BalanceTimeout is how long to wait for balance of recipient to reflect transfer.
+	BalanceTimeout time.Duration
+	// PollInterval is interval between checks of network state and balances.
+	PollInterval time.Duration
+}
+
+// NewConfig creates config with default values.
+func NewConfig() Config {
+	return Config{
+		APIURLs:        []string{"http://localhost:19101/api"},
+		Amount:         10,
+		MaxLatency:     10 * time.Second,
+		ReadyTimeout:   time.Minute,
+		BalanceTimeout: 30 * time.Second,
+		PollInterval:   time.Second,
+	}
+}
+
+// Step is result of scenario step.
+type Step struct {
+	Name     string
+	Duration time.Duration
+	TraceID  string
+	Err      error
+}
+
+// Passed returns true if step succeeded.
+func (s Step) Passed() bool {
+	return s.Err == nil
+}
+
+// Report is result of smoke test.
+type Report struct {
+	Steps []Step
+}
+
+// Passed returns true if all steps succeeded.
+func (r *Report) Passed() bool {
+	for _, s := range r.Steps {
+		if !s.Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+// Write writes human readable report.
+func (r *Report) Write(w io.Writer) error {
+	for _, s := range r.Steps {
+		status := "OK"
+		if !s.Passed() {
+			status = "FAIL: " + s.Err.Error()
+		}
+		trace := ""
+		if s.TraceID != "" {
+			trace = " trace " + s.TraceID
+		}
+		_, err := fmt.Fprintf(w, "%-20s %10s%s %s\n", s.Name, s.Duration.Round(time.Millisecond), trace, status)
+		if err != nil {
+			return err
+		}
+	}
+	result := "PASSED"
+	if !r.Passed() {
+		result = "FAILED"
+	}
+	_, err := fmt.Fprintf(w, "Smoke test %s\n", result)
+	return err
+}
+
+type scenario struct {
+	cfg    Config
+	report *Report
+}
+
+// step runs f and records its result. Returns false if step failed.
+func (s *scenario) step(name string, f func() (string, error)) bool {
+	start := time.Now()
+	traceID, err := f()
+	duration := time.Since(start)
+	if err == nil && s.cfg.MaxLatency > 0 && duration > s.cfg.MaxLatency {
+		err = errors.Errorf("took longer than %s", s.cfg.MaxLatency)
+	}
+	s.report.Steps = append(s.report.Steps, Step{Name: name, Duration: duration, TraceID: traceID, Err: err})
+	return err == nil
+}
+
+// Run waits until network is ready and runs golden path scenario using SDK.
+func Run(ctx context.Context, cfg Config) *Report {
+	s := &scenario{cfg: cfg, report: &Report{}}
+	if len(cfg.APIURLs) == 0 {
+		s.report.Steps = append(s.report.Steps, Step{Name: "Bootstrap", Err: errors.New("no API urls")})
+		return s.report
+	}
+
+	ok := s.step("Bootstrap", func() (string, error) {
+		return "", WaitReady(ctx, cfg)
+	})
+	if !ok {
+		return s.report
+	}
+
+	var client *sdk.SDK
+	ok = s.step("Connect", func() (string, error) {
+		var err error
+		client, err = sdk.NewSDK(cfg.APIURLs, cfg.RootMemberKeys)
+		return "", err
+	})
+	if !ok {
+		return s.report
+	}
+
+	s.run(ctx, client)
+	return s.report
+}
+
+// RunScenario runs golden path scenario using client, network is expected to be ready.
+func RunScenario(ctx context.Context, cfg Config, client Client) *Report {
+	s := &scenario{cfg: cfg, report: &Report{}}
+	s.run(ctx, client)
+	return s.report
+}
+
+func (s *scenario) run(ctx context.Context, client Client) {
+	var from, to *sdk.Member
+	ok := s.step("CreateMember", func() (string, error) {
+		var traceID string
+		var err error
+		from, traceID, err = client.CreateMember()
+		return traceID, err
+	}) && s.step("CreateMember", func() (string, error) {
+		var traceID string
+		var err error
+		to, traceID, err = client.CreateMember()
+		return traceID, err
+	})
+	if !ok {
+		return
+	}
+
+	var fromBalance, toBalance uint64
+	ok = s.step("GetBalance", func() (string, error) {
+		var err error
+		fromBalance, err = client.GetBalance(from)
+		if err != nil {
+			return "", err
+		}
+		toBalance, err = client.GetBalance(to)
+		return "", err
+	})
+	if !ok {
+		return
+	}
+	if fromBalance < uint64(s.cfg.Amount) {
+		s.report.Steps = append(s.report.Steps, Step{
+			Name: "SendMoney",
+			Err:  errors.Errorf("balance of new member %d is less than amount %d", fromBalance, s.cfg.Amount),
+		})
+		return
+	}
+
+	ok = s.step("SendMoney", func() (string, error) {
+		return client.Transfer(s.cfg.Amount, from, to)
+	})
+	if !ok {
+		return
+	}
+
+	s.step("CheckBalance", func() (string, error) {
+		return "", s.waitBalances(ctx, client, from, fromBalance-uint64(s.cfg.Amount), to, toBalance+uint64(s.cfg.Amount))
+	})
+}
+
+func (s *scenario) waitBalances(ctx context.Context, client Client, from *sdk.Member, fromExpected uint64, to *sdk.Member, toExpected uint64) error {
+	return poll(ctx, s.cfg.BalanceTimeout, s.cfg.PollInterval, func() error {
+		fromBalance, err := client.GetBalance(from)
+		if err != nil {
+			return err
+		}
+		toBalance, err := client.GetBalance(to)
+		if err != nil {
+			return err
+		}
+		if fromBalance != fromExpected || toBalance != toExpected {
+			return errors.Errorf(
+				"balances are %d and %d, expected %d and %d", fromBalance, toBalance, fromExpected, toExpected,
+			)
+		}
+		return nil
+	})
+}
+
+// WaitReady waits until all nodes behind API urls report complete network state.
+func WaitReady(ctx context.Context, cfg Config) error {
+	for _, url := range cfg.APIURLs {
+		err := poll(ctx, cfg.ReadyTimeout, cfg.PollInterval, func() error {
+			status, err := requester.Status(url)
+			if err != nil {
+				return err
+			}
+			if status.NetworkState != core.CompleteNetworkState.String() {
+				return errors.Errorf("network state is %s", status.NetworkState)
+			}
+			return nil
+		})
+		if err != nil {
+			return errors.Wrapf(err, "[ WaitReady ] node %s isn't ready", url)
+		}
+	}
+	return nil
+}
+
+// poll calls f until it succeeds or timeout expires, returns last error of f on timeout.
+func poll(ctx context.Context, timeout, interval time.Duration, f func() error) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		err := f()
+		if err == nil {
+			return nil
+		}
+		if time.Now().Add(interval).After(deadline) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
@@ -0,0 +1,107 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package smoke
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/insolar/insolar/api/sdk"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClient struct {
+	balances    map[string]uint64
+	createErr   error
+	transferErr error
+	lost        bool
+	delay       time.Duration
+}
+
+func (c *fakeClient) CreateMember() (*sdk.Member, string, error) {
+	if c.createErr != nil {
+		return nil, "trace", c.createErr
+	}
+	m := sdk.NewMember(string(rune('a'+len(c.balances))), "key")
+	c.balances[m.Reference] = 1000
+	return m, "trace", nil
+}
+
+func (c *fakeClient) Transfer(amount uint, from *sdk.Member, to *sdk.Member) (string, error) {
+	time.Sleep(c.delay)
+	if c.transferErr != nil {
+		return "trace", c.transferErr
+	}
+	c.balances[from.Reference] -= uint64(amount)
+	if !c.lost {
+		c.balances[to.Reference] += uint64(amount)
+	}
+	return "trace", nil
+}
+
+func (c *fakeClient) GetBalance(m *sdk.Member) (uint64, error) {
+	return c.balances[m.Reference], nil
+}
+
+func testConfig() Config {
+	cfg := NewConfig()
+	cfg.BalanceTimeout = 10 * time.Millisecond
+	cfg.PollInterval = time.Millisecond
+	return cfg
+}
+
+func TestRunScenario(t *testing.T) {
+	report := RunScenario(context.Background(), testConfig(), &fakeClient{balances: map[string]uint64{}})
+	require.True(t, report.Passed())
+	require.Len(t, report.Steps, 5)
+
+	var buf bytes.Buffer
+	require.NoError(t, report.Write(&buf))
+	require.Contains(t, buf.String(), "Smoke test PASSED")
+}
+
+func TestRunScenario_Failures(t *testing.T) {
+	report := RunScenario(context.Background(), testConfig(), &fakeClient{
+		balances:  map[string]uint64{},
+		createErr: errors.New("no way"),
+	})
+	require.False(t, report.Passed())
+	require.Len(t, report.Steps, 1)
+
+	report = RunScenario(context.Background(), testConfig(), &fakeClient{
+		balances: map[string]uint64{},
+		lost:     true,
+	})
+	require.False(t, report.Passed())
+	require.Equal(t, "CheckBalance", report.Steps[len(report.Steps)-1].Name)
+
+	cfg := testConfig()
+	cfg.MaxLatency = time.Millisecond
+	report = RunScenario(context.Background(), cfg, &fakeClient{
+		balances: map[string]uint64{},
+		delay:    5 * time.Millisecond,
+	})
+	require.False(t, report.Passed())
+	require.Equal(t, "SendMoney", report.Steps[len(report.Steps)-1].Name)
+
+	var buf bytes.Buffer
+	require.NoError(t, report.Write(&buf))
+	require.Contains(t, buf.String(), "Smoke test FAILED")
+}
@@ -44,7 +44,8 @@ func NewSeedService(runner *Runner) *SeedService {
 	return &SeedService{runner: runner}
 }
 
-// Get returns new active seed.
+// Get returns new active seed. Seed is bound to current pulse and this node, so request signed with it
+// is accepted only by this node and only for a few pulses.
 //
 //   Request structure:
 //   {
@@ -65,11 +66,15 @@ func NewSeedService(runner *Runner) *SeedService {
 //
 func (s *SeedService) Get(r *http.Request, args *SeedArgs, reply *SeedReply) error {
 	traceID := utils.RandTraceID()
-	_, inslog := inslogger.WithTraceField(context.Background(), traceID)
+	ctx, inslog := inslogger.WithTraceField(context.Background(), traceID)
 
 	inslog.Infof("[ SeedService.Get ] Incoming request: %s", r.RequestURI)
 
-	seed, err := s.runner.SeedGenerator.Next()
+	pulse, err := s.runner.PulseStorage.Current(ctx)
+	if err != nil {
+		return errors.Wrap(err, "[ GetSeed ] Can't get current pulse")
+	}
+	seed, err := s.runner.SeedGenerator.Next(pulse.PulseNumber, s.runner.NodeNetwork.GetOrigin().ID())
 	if err != nil {
 		return errors.Wrap(err, "[ GetSeed ]")
 	}
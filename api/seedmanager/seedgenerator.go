@@ -19,11 +19,12 @@ package seedmanager
 import (
 	"crypto/rand"
 
+	"github.com/insolar/insolar/core"
 	"github.com/pkg/errors"
 )
 
 // SeedSize is size of seed
-const SeedSize uint = 32
+const SeedSize uint = core.APISeedSize
 
 // Seed is a type of seed
 type Seed = [SeedSize]byte
@@ -32,13 +33,14 @@ type Seed = [SeedSize]byte
 type SeedGenerator struct {
 }
 
-// Next returns next random seed
-func (sg *SeedGenerator) Next() (*Seed, error) {
+// Next returns next random seed bound to pulse and API node which issues it
+func (sg *SeedGenerator) Next(pulse core.PulseNumber, node core.RecordRef) (*Seed, error) {
 	seed := Seed{}
 	_, err := rand.Read(seed[:])
 	if err != nil {
 		return nil, errors.Wrap(err, "[ SeedGenerator::Next ]")
 	}
+	core.BindAPISeed(seed[:], pulse, node)
 
 	return &seed, nil
 }
@@ -21,6 +21,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
 	"github.com/stretchr/testify/require"
 )
 
@@ -47,11 +49,26 @@ func TestNew(t *testing.T) {
 
 func getSeed(t *testing.T) Seed {
 	sg := SeedGenerator{}
-	seed, err := sg.Next()
+	seed, err := sg.Next(core.FirstPulseNumber, testutils.RandomRef())
 	require.NoError(t, err)
 	return *seed
 }
 
+func TestSeedGenerator_Next(t *testing.T) {
+	sg := SeedGenerator{}
+	node := testutils.RandomRef()
+	seed, err := sg.Next(core.FirstPulseNumber+10, node)
+	require.NoError(t, err)
+
+	require.Equal(t, core.PulseNumber(core.FirstPulseNumber+10), core.APISeedPulse(seed[:]))
+	require.True(t, core.APISeedIssuedBy(seed[:], node))
+	require.False(t, core.APISeedIssuedBy(seed[:], testutils.RandomRef()))
+
+	other, err := sg.Next(core.FirstPulseNumber+10, node)
+	require.NoError(t, err)
+	require.NotEqual(t, *seed, *other)
+}
+
 func TestSeedManager_Add(t *testing.T) {
 	sm := NewSpecified(time.Duration(5*time.Millisecond), DefaultCleanPeriod)
 	seed := getSeed(t)
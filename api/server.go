@@ -0,0 +1,60 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"net"
+	"net/http"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"golang.org/x/net/netutil"
+
+	"github.com/insolar/insolar/configuration"
+)
+
+// newHTTPServer creates HTTP server with configured timeouts and limits.
+func newHTTPServer(addr string, cfg configuration.APIServer) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		ReadTimeout:       cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		MaxHeaderBytes:    cfg.MaxHeaderBytes,
+	}
+}
+
+// httpHandler returns handler of API requests, it serves HTTP/2 without TLS if it's enabled.
+func (ar *Runner) httpHandler(mux http.Handler) http.Handler {
+	cfg := ar.cfg.Server
+	if !cfg.HTTP2 {
+		return mux
+	}
+	return h2c.NewHandler(mux, &http2.Server{
+		MaxConcurrentStreams: cfg.MaxConcurrentStreams,
+		IdleTimeout:          cfg.IdleTimeout,
+	})
+}
+
+// limitConnections limits number of connections open at once on listener.
+func (ar *Runner) limitConnections(listener net.Listener) net.Listener {
+	if ar.cfg.Server.MaxConnections <= 0 {
+		return listener
+	}
+	return netutil.LimitListener(listener, ar.cfg.Server.MaxConnections)
+}
@@ -0,0 +1,121 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"bufio"
+	"crypto/tls"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+
+	"github.com/insolar/insolar/configuration"
+)
+
+func serveProto(t *testing.T, cfg configuration.APIServer) (string, func()) {
+	apiCfg := configuration.NewAPIRunner()
+	apiCfg.Server = cfg
+	ar := &Runner{cfg: &apiCfg}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := newHTTPServer("", cfg)
+	server.Handler = ar.httpHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Proto)) // nolint
+	}))
+	go server.Serve(ar.limitConnections(listener)) // nolint
+
+	return "http://" + listener.Addr().String(), func() { server.Close() } // nolint
+}
+
+func get(t *testing.T, client *http.Client, url string) string {
+	resp, err := client.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestNewHTTPServer(t *testing.T) {
+	cfg := configuration.NewAPIRunner().Server
+	server := newHTTPServer("localhost:19101", cfg)
+	require.Equal(t, "localhost:19101", server.Addr)
+	require.Equal(t, cfg.ReadHeaderTimeout, server.ReadHeaderTimeout)
+	require.Equal(t, cfg.ReadTimeout, server.ReadTimeout)
+	require.Equal(t, cfg.WriteTimeout, server.WriteTimeout)
+	require.Equal(t, cfg.IdleTimeout, server.IdleTimeout)
+	require.Equal(t, cfg.MaxHeaderBytes, server.MaxHeaderBytes)
+}
+
+func TestRunner_HTTP2(t *testing.T) {
+	h2client := &http.Client{Transport: &http2.Transport{
+		AllowHTTP: true,
+		DialTLS: func(network, addr string, cfg *tls.Config) (net.Conn, error) {
+			return net.Dial(network, addr)
+		},
+	}}
+
+	url, stop := serveProto(t, configuration.NewAPIRunner().Server)
+	require.Equal(t, "HTTP/2.0", get(t, h2client, url))
+	require.Equal(t, "HTTP/1.1", get(t, http.DefaultClient, url))
+	stop()
+
+	cfg := configuration.NewAPIRunner().Server
+	cfg.HTTP2 = false
+	url, stop = serveProto(t, cfg)
+	defer stop()
+	_, err := h2client.Get(url)
+	require.Error(t, err)
+	require.Equal(t, "HTTP/1.1", get(t, http.DefaultClient, url))
+}
+
+func TestRunner_MaxConnections(t *testing.T) {
+	cfg := configuration.NewAPIRunner().Server
+	cfg.MaxConnections = 1
+	url, stop := serveProto(t, cfg)
+	defer stop()
+	addr := url[len("http://"):]
+
+	request := func(conn net.Conn) error {
+		if _, err := conn.Write([]byte("GET / HTTP/1.1\r\nHost: api\r\n\r\n")); err != nil {
+			return err
+		}
+		conn.SetReadDeadline(time.Now().Add(200 * time.Millisecond)) // nolint
+		_, err := http.ReadResponse(bufio.NewReader(conn), nil)
+		return err
+	}
+
+	first, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	require.NoError(t, request(first))
+
+	second, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer second.Close()
+	require.Error(t, request(second), "second connection waits until first is closed")
+
+	first.Close()
+	second.SetReadDeadline(time.Now().Add(time.Second)) // nolint
+	_, err = http.ReadResponse(bufio.NewReader(second), nil)
+	require.NoError(t, err)
+}
@@ -0,0 +1,84 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package api
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/core"
+)
+
+// sessionTokenSize is a number of random bytes in session token.
+const sessionTokenSize = 16
+
+type apiSession struct {
+	member  core.RecordRef
+	expires time.Time
+}
+
+// sessionRegistry keeps tokens of conversational sessions of clients. Sessions are local to API node,
+// so client keeps sending calls of session to the node which issued the token.
+type sessionRegistry struct {
+	ttl time.Duration
+	now func() time.Time
+
+	lock     sync.Mutex
+	sessions map[string]apiSession
+}
+
+func newSessionRegistry(ttl time.Duration) *sessionRegistry {
+	return &sessionRegistry{
+		ttl:      ttl,
+		now:      time.Now,
+		sessions: map[string]apiSession{},
+	}
+}
+
+// resolve returns token of member session. Known token is prolonged, unknown or expired one is replaced
+// with new token. Empty token is returned if sessions are disabled.
+func (r *sessionRegistry) resolve(member core.RecordRef, token string) string {
+	if r.ttl <= 0 {
+		return ""
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := r.now()
+	for t, s := range r.sessions {
+		if now.After(s.expires) {
+			delete(r.sessions, t)
+		}
+	}
+
+	if s, ok := r.sessions[token]; !ok || s.member != member {
+		token = newSessionToken()
+	}
+	r.sessions[token] = apiSession{member: member, expires: now.Add(r.ttl)}
+	return token
+}
+
+func newSessionToken() string {
+	b := make([]byte, sessionTokenSize)
+	_, err := rand.Read(b)
+	if err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
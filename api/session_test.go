@@ -0,0 +1,42 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestSessionRegistry(t *testing.T) {
+	now := time.Now()
+	r := newSessionRegistry(time.Minute)
+	r.now = func() time.Time { return now }
+	member := testutils.RandomRef()
+
+	token := r.resolve(member, "new")
+	require.NotEqual(t, "new", token, "unknown token is replaced")
+	require.Equal(t, token, r.resolve(member, token), "active token is kept")
+	require.NotEqual(t, token, r.resolve(testutils.RandomRef(), token), "token isn't shared with other member")
+
+	now = now.Add(2 * time.Minute)
+	require.NotEqual(t, token, r.resolve(member, token), "expired token is replaced")
+
+	require.Equal(t, "", newSessionRegistry(0).resolve(member, "new"), "sessions are disabled")
+}
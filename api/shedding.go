@@ -0,0 +1,101 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// shedRetryAfter is a number of seconds clients are advised to wait before retrying shed request.
+const shedRetryAfter = "1"
+
+// admit reports whether low priority work of kind may be done now, node sheds it when it's overloaded.
+func (ar *Runner) admit(req *http.Request, kind string) bool {
+	if ar.LoadShedder == nil {
+		return true
+	}
+	return ar.LoadShedder.Admit(req.Context(), core.PriorityLow, kind)
+}
+
+// isShedService reports whether JSON-RPC method (e.g. "pulses.List") is an explorer query shed under pressure.
+func (ar *Runner) isShedService(method string) bool {
+	service := method
+	if i := strings.Index(method, "."); i >= 0 {
+		service = method[:i]
+	}
+	for _, s := range ar.cfg.ShedServices {
+		if strings.EqualFold(s, method) || strings.EqualFold(s, service) {
+			return true
+		}
+	}
+	return false
+}
+
+// shedRPC rejects JSON-RPC explorer queries while node is overloaded. Body is buffered to find out method
+// and is passed to handler as it was read, including read error.
+func (ar *Runner) shedRPC(handler http.HandlerFunc) http.HandlerFunc {
+	return func(response http.ResponseWriter, req *http.Request) {
+		if len(ar.cfg.ShedServices) == 0 {
+			handler(response, req)
+			return
+		}
+
+		body, _ := ioutil.ReadAll(req.Body)
+		req.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), req.Body), req.Body}
+
+		var call struct {
+			Method string `json:"method"`
+		}
+		// malformed requests are reported by handler
+		_ = json.Unmarshal(body, &call)
+		if ar.isShedService(call.Method) && !ar.admit(req, call.Method) {
+			writeShed(response, req, call.Method)
+			return
+		}
+		handler(response, req)
+	}
+}
+
+// shedQuery rejects ledger queries while node is overloaded.
+func (ar *Runner) shedQuery(handler http.HandlerFunc) http.HandlerFunc {
+	return func(response http.ResponseWriter, req *http.Request) {
+		if !ar.admit(req, "query") {
+			writeShed(response, req, "query")
+			return
+		}
+		handler(response, req)
+	}
+}
+
+func writeShed(response http.ResponseWriter, req *http.Request, kind string) {
+	inslogger.FromContext(req.Context()).Infof(
+		"[ API ] %s request from %s is shed, node is overloaded", kind, req.RemoteAddr,
+	)
+	response.Header().Set("Retry-After", shedRetryAfter)
+	http.Error(response, "node is overloaded, retry later", http.StatusServiceUnavailable)
+}
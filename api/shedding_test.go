@@ -0,0 +1,100 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+)
+
+// overloaded sheds all low priority work and records its kinds.
+type overloaded struct {
+	shed []string
+}
+
+func (o *overloaded) Admit(ctx context.Context, priority core.WorkPriority, kind string) bool {
+	if priority == core.PriorityCritical {
+		return true
+	}
+	o.shed = append(o.shed, kind)
+	return false
+}
+
+func TestRunner_isShedService(t *testing.T) {
+	ar := &Runner{cfg: &configuration.APIRunner{ShedServices: []string{"pulses", "requests.Get"}}}
+
+	require.True(t, ar.isShedService("pulses.List"))
+	require.True(t, ar.isShedService("Requests.get"))
+	require.False(t, ar.isShedService("requests.Subscribe"))
+	require.False(t, ar.isShedService("status.Get"))
+	require.False(t, ar.isShedService(""))
+}
+
+func TestRunner_shedRPC(t *testing.T) {
+	shedder := &overloaded{}
+	ar := &Runner{
+		cfg:         &configuration.APIRunner{ShedServices: []string{"pulses"}},
+		LoadShedder: shedder,
+	}
+	var passed string
+	handler := ar.shedRPC(func(response http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		passed = string(body)
+	})
+
+	body := `{"jsonrpc": "2.0", "method": "pulses.List", "id": 1}`
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodPost, "/api/rpc", strings.NewReader(body)))
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	require.Equal(t, shedRetryAfter, rec.Header().Get("Retry-After"))
+	require.Equal(t, []string{"pulses.List"}, shedder.shed)
+	require.Empty(t, passed)
+
+	body = `{"jsonrpc": "2.0", "method": "status.Get", "id": 1}`
+	rec = httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodPost, "/api/rpc", strings.NewReader(body)))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, body, passed, "handler reads the whole body")
+}
+
+func TestRunner_shedQuery(t *testing.T) {
+	called := false
+	handler := func(response http.ResponseWriter, req *http.Request) {
+		called = true
+	}
+
+	ar := &Runner{cfg: &configuration.APIRunner{}}
+	ar.shedQuery(handler)(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/query", nil))
+	require.True(t, called, "nothing is shed without shedder")
+
+	called = false
+	ar.LoadShedder = &overloaded{}
+	rec := httptest.NewRecorder()
+	ar.shedQuery(handler)(rec, httptest.NewRequest(http.MethodGet, "/api/query", nil))
+	require.False(t, called)
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
@@ -19,7 +19,10 @@ package api
 import (
 	"context"
 	"net/http"
+	"time"
 
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/core/utils"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/network"
@@ -42,6 +45,50 @@ type StatusReply struct {
 	Entropy             []byte
 	NodeState           string
 	AdditionalNodeState string
+	LeftNodes           []LeftNode
+	NodeLoads           []NodeLoad
+	SelfCheck           SelfCheck
+}
+
+// Names of node self-checks.
+const (
+	CheckPulseAge        = "pulse_age"
+	CheckConsensusStreak = "consensus_streak"
+	CheckStorageHeadroom = "storage_headroom"
+	CheckReplicationLag  = "replication_lag"
+	CheckSendErrorRate   = "send_error_rate"
+)
+
+// SelfCheck is a result of node self-check against configured thresholds.
+type SelfCheck struct {
+	// Alert is set if any of checks is flagged.
+	Alert  bool
+	Checks []SelfCheckItem
+}
+
+// SelfCheckItem is a value of node self-check compared against threshold.
+type SelfCheckItem struct {
+	Name      string
+	Value     float64
+	Threshold float64
+	Alert     bool
+}
+
+// LeftNode is a graceful leave of node from the network.
+type LeftNode struct {
+	Reference string
+	Reason    string
+	Note      string
+	Time      time.Time
+}
+
+// NodeLoad is a last load self-reported by active node.
+type NodeLoad struct {
+	Reference       string
+	CPU             uint8
+	QueueDepth      uint16
+	StorageHeadroom uint8
+	Time            time.Time
 }
 
 // StatusService is a service that provides API for getting status of node.
@@ -87,6 +134,29 @@ func (s *StatusService) Get(r *http.Request, args *interface{}, reply *StatusRep
 		Role:      origin.Role().String(),
 	}
 
+	if history, ok := s.runner.NodeNetwork.(network.LeaveHistory); ok {
+		for _, leave := range history.GetLeaves() {
+			reply.LeftNodes = append(reply.LeftNodes, LeftNode{
+				Reference: leave.NodeID.String(),
+				Reason:    leave.Reason.String(),
+				Note:      leave.Note,
+				Time:      leave.Time,
+			})
+		}
+	}
+
+	if history, ok := s.runner.NodeNetwork.(network.LoadHistory); ok {
+		for _, load := range history.GetLoads() {
+			reply.NodeLoads = append(reply.NodeLoads, NodeLoad{
+				Reference:       load.NodeID.String(),
+				CPU:             load.CPU,
+				QueueDepth:      load.QueueDepth,
+				StorageHeadroom: load.StorageHeadroom,
+				Time:            load.Time,
+			})
+		}
+	}
+
 	pulse, err := s.runner.PulseStorage.Current(ctx)
 	if err != nil {
 		return err
@@ -94,6 +164,46 @@ func (s *StatusService) Get(r *http.Request, args *interface{}, reply *StatusRep
 
 	reply.PulseNumber = uint32(pulse.PulseNumber)
 	reply.Entropy = pulse.Entropy[:]
+	if s.runner.cfg != nil {
+		reply.SelfCheck = s.selfCheck(ctx, s.runner.cfg.SelfCheck, pulse)
+	}
 
 	return nil
 }
+
+// selfCheck compares node state with thresholds, checks with zero thresholds are skipped.
+func (s *StatusService) selfCheck(ctx context.Context, cfg configuration.SelfCheck, pulse *core.Pulse) SelfCheck {
+	var result SelfCheck
+	check := func(name string, value, threshold float64, alert bool) {
+		if threshold == 0 {
+			return
+		}
+		result.Checks = append(result.Checks, SelfCheckItem{
+			Name:      name,
+			Value:     value,
+			Threshold: threshold,
+			Alert:     alert,
+		})
+		result.Alert = result.Alert || alert
+	}
+
+	age := time.Since(time.Unix(pulse.PulseTimestamp, 0))
+	check(CheckPulseAge, age.Seconds(), cfg.MaxPulseAge.Seconds(), age > cfg.MaxPulseAge)
+
+	streak := s.runner.ConsensusRounds.ConsensusStreak()
+	check(CheckConsensusStreak, float64(streak), float64(cfg.MinConsensusStreak), streak < cfg.MinConsensusStreak)
+
+	headroom := s.runner.NodeLoadReporter.NodeLoad(ctx).StorageHeadroom
+	check(CheckStorageHeadroom, float64(headroom), float64(cfg.MinStorageHeadroom), headroom < cfg.MinStorageHeadroom)
+
+	lag := s.runner.Replication.ReplicationLag(ctx)
+	check(CheckReplicationLag, float64(lag), float64(cfg.MaxReplicationLag), lag > cfg.MaxReplicationLag)
+
+	var rate float64
+	if stats := s.runner.SendStats.GetSendStats(); stats.Sent > 0 {
+		rate = float64(stats.Failed) / float64(stats.Sent)
+	}
+	check(CheckSendErrorRate, rate, cfg.MaxSendErrorRate, rate > cfg.MaxSendErrorRate)
+
+	return result
+}
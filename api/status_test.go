@@ -0,0 +1,88 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+)
+
+type selfCheckState struct {
+	streak   int
+	headroom uint8
+	lag      int
+	sent     core.SendStats
+}
+
+func (s selfCheckState) ConsensusStreak() int {
+	return s.streak
+}
+
+func (s selfCheckState) NodeLoad(ctx context.Context) core.NodeLoad {
+	return core.NodeLoad{StorageHeadroom: s.headroom}
+}
+
+func (s selfCheckState) ReplicationLag(ctx context.Context) int {
+	return s.lag
+}
+
+func (s selfCheckState) GetSendStats() core.SendStats {
+	return s.sent
+}
+
+func newSelfCheckService(state selfCheckState) *StatusService {
+	return NewStatusService(&Runner{
+		ConsensusRounds:  state,
+		NodeLoadReporter: state,
+		Replication:      state,
+		SendStats:        state,
+	})
+}
+
+func TestStatusService_SelfCheck(t *testing.T) {
+	ctx := context.Background()
+	cfg := configuration.NewSelfCheck()
+	pulse := &core.Pulse{PulseTimestamp: time.Now().Unix()}
+
+	service := newSelfCheckService(selfCheckState{streak: 5, headroom: 50, lag: 2, sent: core.SendStats{Sent: 100, Failed: 1}})
+	check := service.selfCheck(ctx, cfg, pulse)
+	require.False(t, check.Alert)
+	require.Len(t, check.Checks, 5)
+	require.Equal(t, SelfCheckItem{Name: CheckSendErrorRate, Value: 0.01, Threshold: 0.05}, check.Checks[4])
+
+	service = newSelfCheckService(selfCheckState{streak: 0, headroom: 5, lag: 20, sent: core.SendStats{Sent: 10, Failed: 5}})
+	check = service.selfCheck(ctx, cfg, &core.Pulse{PulseTimestamp: time.Now().Add(-time.Minute).Unix()})
+	require.True(t, check.Alert)
+	for _, item := range check.Checks {
+		require.True(t, item.Alert, item.Name)
+	}
+}
+
+func TestStatusService_SelfCheckDisabled(t *testing.T) {
+	service := newSelfCheckService(selfCheckState{headroom: 5})
+	check := service.selfCheck(context.Background(), configuration.SelfCheck{MinStorageHeadroom: 10}, &core.Pulse{})
+	require.Equal(t, SelfCheck{
+		Alert:  true,
+		Checks: []SelfCheckItem{{Name: CheckStorageHeadroom, Value: 5, Threshold: 10, Alert: true}},
+	}, check)
+}
@@ -0,0 +1,149 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// StorageRotateKeyArgs is arguments of Storage.RotateKey request.
+type StorageRotateKeyArgs struct {
+	KeyID  uint32
+	Reason string
+}
+
+// StorageKeyRotationReply is reply for Storage service requests.
+type StorageKeyRotationReply struct {
+	KeyID     uint32
+	Started   string
+	Finished  string
+	Rewritten uint64
+	Skipped   uint64
+	Running   bool
+}
+
+// StorageService is a service that provides API for storage encryption of node.
+type StorageService struct {
+	runner *Runner
+}
+
+// NewStorageService creates new StorageService instance.
+func NewStorageService(runner *Runner) *StorageService {
+	return &StorageService{runner: runner}
+}
+
+// RotateKey switches storage encryption to key from keys file and starts background re-encryption
+// of values encrypted with previous keys. Request must be authorized with admin token.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "storage.RotateKey",
+//	  "params": {
+//	    "KeyID": int, // id of key in keys file
+//	    "Reason": str // why key is rotated, required for audit
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	  Response structure is the same as for storage.KeyRotation.
+func (s *StorageService) RotateKey(r *http.Request, args *StorageRotateKeyArgs, reply *StorageKeyRotationReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ StorageService.RotateKey ] Incoming request: %s, key: %d", r.RequestURI, args.KeyID)
+
+	if err := s.runner.authorizeAdmin(r, "StorageService"); err != nil {
+		inslog.Warnf("[ StorageService.RotateKey ] unauthorized request from %s: %s", r.RemoteAddr, err)
+		return err
+	}
+	if args.Reason == "" {
+		return errors.New("[ StorageService.RotateKey ] reason is required")
+	}
+
+	rotation, err := s.runner.KeyRotator.RotateKey(ctx, args.KeyID)
+	s.runner.audit(ctx, r, "storage.RotateKey", strconv.FormatUint(uint64(args.KeyID), 10), args.Reason, err)
+	if err != nil {
+		return errors.Wrap(err, "[ StorageService.RotateKey ] failed to rotate key")
+	}
+	*reply = keyRotationReply(rotation)
+	return nil
+}
+
+// KeyRotation returns progress of last storage key rotation. Request must be authorized with admin token.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "storage.KeyRotation",
+//	  "id": str|int|null
+//	}
+//
+//	Response structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "result": {
+//	    "KeyID": int, // id of current key, zero if key was never rotated
+//	    "Started": str, // start time of rotation in RFC3339
+//	    "Finished": str, // time re-encryption finished in RFC3339, empty while it's running
+//	    "Rewritten": int, // number of re-encrypted values
+//	    "Skipped": int, // number of values which can't be decoded and are left as is
+//	    "Running": bool // re-encryption is in progress
+//	  },
+//	  "id": str|int|null // same as in request
+//	}
+func (s *StorageService) KeyRotation(r *http.Request, args *interface{}, reply *StorageKeyRotationReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ StorageService.KeyRotation ] Incoming request: %s", r.RequestURI)
+
+	if err := s.runner.authorizeAdmin(r, "StorageService"); err != nil {
+		inslog.Warnf("[ StorageService.KeyRotation ] unauthorized request from %s: %s", r.RemoteAddr, err)
+		return err
+	}
+
+	rotation, err := s.runner.KeyRotator.KeyRotation(ctx)
+	if err != nil {
+		return errors.Wrap(err, "[ StorageService.KeyRotation ] failed to get key rotation")
+	}
+	if rotation != nil {
+		*reply = keyRotationReply(rotation)
+	}
+	return nil
+}
+
+func keyRotationReply(rotation *core.StorageKeyRotation) StorageKeyRotationReply {
+	reply := StorageKeyRotationReply{
+		KeyID:     rotation.KeyID,
+		Started:   rotation.Started.Format(time.RFC3339),
+		Rewritten: rotation.Rewritten,
+		Skipped:   rotation.Skipped,
+		Running:   rotation.Finished.IsZero(),
+	}
+	if !rotation.Finished.IsZero() {
+		reply.Finished = rotation.Finished.Format(time.RFC3339)
+	}
+	return reply
+}
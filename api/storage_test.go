@@ -0,0 +1,76 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+)
+
+type testKeyRotator struct {
+	rotation *core.StorageKeyRotation
+}
+
+func (r *testKeyRotator) RotateKey(ctx context.Context, keyID uint32) (*core.StorageKeyRotation, error) {
+	if keyID != 2 {
+		return nil, errors.New("unknown key")
+	}
+	r.rotation = &core.StorageKeyRotation{KeyID: keyID, Started: time.Now()}
+	return r.rotation, nil
+}
+
+func (r *testKeyRotator) KeyRotation(ctx context.Context) (*core.StorageKeyRotation, error) {
+	return r.rotation, nil
+}
+
+func TestStorageService_RotateKey(t *testing.T) {
+	rotator := &testKeyRotator{}
+	cfg := &configuration.APIRunner{AdminToken: "secret"}
+	service := NewStorageService(&Runner{cfg: cfg, KeyRotator: rotator})
+	admin := &http.Request{Header: http.Header{"Authorization": {"Bearer secret"}}}
+
+	err := service.RotateKey(&http.Request{Header: http.Header{}}, &StorageRotateKeyArgs{KeyID: 2, Reason: "r"},
+		&StorageKeyRotationReply{})
+	require.Contains(t, err.Error(), "admin token is required")
+	require.Nil(t, rotator.rotation)
+
+	err = service.RotateKey(admin, &StorageRotateKeyArgs{KeyID: 2}, &StorageKeyRotationReply{})
+	require.Contains(t, err.Error(), "reason is required")
+
+	err = service.RotateKey(admin, &StorageRotateKeyArgs{KeyID: 3, Reason: "r"}, &StorageKeyRotationReply{})
+	require.Contains(t, err.Error(), "unknown key")
+
+	reply := &StorageKeyRotationReply{}
+	require.NoError(t, service.RotateKey(admin, &StorageRotateKeyArgs{KeyID: 2, Reason: "r"}, reply))
+	require.Equal(t, uint32(2), reply.KeyID)
+	require.True(t, reply.Running)
+	require.Empty(t, reply.Finished)
+
+	rotator.rotation.Finished = time.Now()
+	reply = &StorageKeyRotationReply{}
+	require.NoError(t, service.KeyRotation(admin, nil, reply))
+	require.False(t, reply.Running)
+	require.NotEmpty(t, reply.Finished)
+}
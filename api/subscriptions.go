@@ -0,0 +1,226 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// pushTimeout limits time of delivery of status update to client webhook.
+const pushTimeout = 5 * time.Second
+
+// RequestStatus is a JSON body of status update pushed to webhooks subscribed to API request.
+type RequestStatus struct {
+	QID     string `json:"qid"`
+	Request string `json:"request"`
+	Status  string `json:"status"`
+	// Pulses is a number of pulses request is pending for.
+	Pulses int `json:"pulses"`
+}
+
+// defaultStatusSubscriptionTTL is a time subscription lives if TTL isn't configured.
+const defaultStatusSubscriptionTTL = time.Hour
+
+type statusSubscription struct {
+	url     string
+	expires time.Time
+}
+
+// statusKey identifies API request by member who signed it and its QID, QIDs are chosen by clients and
+// aren't unique across members.
+type statusKey struct {
+	member core.RecordRef
+	qid    string
+}
+
+// statusSubscriptions keeps webhooks clients subscribed to status updates of their API requests.
+type statusSubscriptions struct {
+	cfg    configuration.StatusWebhooks
+	ttl    time.Duration
+	client *http.Client
+
+	lock  sync.Mutex
+	subs  map[statusKey][]statusSubscription
+	count int
+
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+func newStatusSubscriptions(cfg configuration.StatusWebhooks) *statusSubscriptions {
+	ttl := cfg.TTL
+	if ttl <= 0 {
+		ttl = defaultStatusSubscriptionTTL
+	}
+	return &statusSubscriptions{
+		cfg:    cfg,
+		ttl:    ttl,
+		client: &http.Client{Timeout: pushTimeout},
+		subs:   map[statusKey][]statusSubscription{},
+		done:   make(chan struct{}),
+	}
+}
+
+// start runs periodic removal of expired subscriptions.
+func (s *statusSubscriptions) start() {
+	go func() {
+		ticker := time.NewTicker(s.ttl)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.done:
+				return
+			case now := <-ticker.C:
+				s.sweep(now)
+			}
+		}
+	}()
+}
+
+func (s *statusSubscriptions) stop() {
+	s.stopOnce.Do(func() { close(s.done) })
+}
+
+// subscribe adds webhook of member request if its host is allowed and limits aren't exceeded.
+func (s *statusSubscriptions) subscribe(member core.RecordRef, qid string, webhook *url.URL) error {
+	if !s.allowed(webhook) {
+		return errors.Errorf("webhook host %s is not allowed", webhook.Host)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	key := statusKey{member: member, qid: qid}
+	if s.cfg.MaxPerRequest > 0 && len(s.subs[key]) >= s.cfg.MaxPerRequest {
+		return errors.Errorf("request already has %d webhooks", len(s.subs[key]))
+	}
+	if s.cfg.MaxSubscriptions > 0 && s.count >= s.cfg.MaxSubscriptions {
+		s.sweepLocked(time.Now())
+		if s.count >= s.cfg.MaxSubscriptions {
+			return errors.New("too many webhooks, try later")
+		}
+	}
+	s.subs[key] = append(s.subs[key], statusSubscription{url: webhook.String(), expires: time.Now().Add(s.ttl)})
+	s.count++
+	return nil
+}
+
+// allowed checks webhook host against configured hosts.
+func (s *statusSubscriptions) allowed(webhook *url.URL) bool {
+	host := strings.ToLower(webhook.Hostname())
+	hostPort := strings.ToLower(webhook.Host)
+	for _, h := range s.cfg.Hosts {
+		h = strings.ToLower(h)
+		if h == hostPort || h == host {
+			return true
+		}
+	}
+	return false
+}
+
+// webhooks returns active webhooks of member request and forgets expired ones.
+func (s *statusSubscriptions) webhooks(member core.RecordRef, qid string) []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	active := s.active(statusKey{member: member, qid: qid}, time.Now())
+	urls := make([]string, 0, len(active))
+	for _, sub := range active {
+		urls = append(urls, sub.url)
+	}
+	return urls
+}
+
+// sweep forgets expired subscriptions of all requests.
+func (s *statusSubscriptions) sweep(now time.Time) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.sweepLocked(now)
+}
+
+func (s *statusSubscriptions) sweepLocked(now time.Time) {
+	for key := range s.subs {
+		s.active(key, now)
+	}
+}
+
+// active forgets expired subscriptions of request and returns the rest, lock must be held.
+func (s *statusSubscriptions) active(key statusKey, now time.Time) []statusSubscription {
+	var active []statusSubscription
+	for _, sub := range s.subs[key] {
+		if now.After(sub.expires) {
+			s.count--
+			continue
+		}
+		active = append(active, sub)
+	}
+	if len(active) == 0 {
+		delete(s.subs, key)
+	} else {
+		s.subs[key] = active
+	}
+	return active
+}
+
+// push delivers status update to webhooks of member request.
+func (s *statusSubscriptions) push(ctx context.Context, member core.RecordRef, status RequestStatus) {
+	urls := s.webhooks(member, status.QID)
+	if len(urls) == 0 {
+		return
+	}
+	body, err := json.Marshal(status)
+	if err != nil {
+		inslogger.FromContext(ctx).Error(errors.Wrap(err, "[ push ] Can't marshal status"))
+		return
+	}
+	for _, url := range urls {
+		go func(url string) {
+			resp, err := s.client.Post(url, "application/json", bytes.NewReader(body))
+			if err != nil {
+				inslogger.FromContext(ctx).Warn(errors.Wrapf(err, "[ push ] Can't push status of %s", status.QID))
+				return
+			}
+			resp.Body.Close() // nolint: errcheck
+		}(url)
+	}
+}
+
+// pendingRequestStatusHandler is MessageBus handler which pushes status of pending request to subscribed clients.
+func (ar *Runner) pendingRequestStatusHandler(ctx context.Context, p core.Parcel) (core.Reply, error) {
+	msg := p.Message().(*message.PendingRequestStatus)
+	ar.subscriptions.push(ctx, msg.Member, RequestStatus{
+		QID:     msg.QID,
+		Request: msg.Request.String(),
+		Status:  "pending",
+		Pulses:  msg.Pulses,
+	})
+	return &reply.OK{}, nil
+}
@@ -0,0 +1,99 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestStatusSubscriptions_Push(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	received := make(chan RequestStatus, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var status RequestStatus
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&status))
+		received <- status
+	}))
+	defer server.Close()
+
+	webhook, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	cfg := configuration.StatusWebhooks{Hosts: []string{webhook.Host}, TTL: time.Minute}
+	s := newStatusSubscriptions(cfg)
+	member := testutils.RandomRef()
+	require.NoError(t, s.subscribe(member, "qid", webhook))
+	s.push(ctx, member, RequestStatus{QID: "other", Status: "pending"})
+	s.push(ctx, testutils.RandomRef(), RequestStatus{QID: "qid", Status: "pending"})
+	s.push(ctx, member, RequestStatus{QID: "qid", Request: "request", Status: "pending", Pulses: 3})
+
+	select {
+	case status := <-received:
+		require.Equal(t, RequestStatus{QID: "qid", Request: "request", Status: "pending", Pulses: 3}, status)
+	case <-time.After(pushTimeout):
+		t.Fatal("status isn't pushed")
+	}
+
+	cfg.TTL = time.Nanosecond
+	s = newStatusSubscriptions(cfg)
+	require.NoError(t, s.subscribe(member, "qid", webhook))
+	time.Sleep(time.Millisecond)
+	require.Empty(t, s.webhooks(member, "qid"), "subscription is expired")
+}
+
+func TestStatusSubscriptions_Limits(t *testing.T) {
+	parse := func(raw string) *url.URL {
+		u, err := url.Parse(raw)
+		require.NoError(t, err)
+		return u
+	}
+	member := testutils.RandomRef()
+	s := newStatusSubscriptions(configuration.StatusWebhooks{
+		Hosts:            []string{"hooks.example.com", "127.0.0.1:8080"},
+		MaxSubscriptions: 3,
+		MaxPerRequest:    2,
+		TTL:              time.Minute,
+	})
+
+	require.NoError(t, s.subscribe(member, "qid", parse("https://hooks.example.com:8443/a")))
+	require.NoError(t, s.subscribe(member, "qid", parse("http://127.0.0.1:8080/b")))
+	err := s.subscribe(member, "other", parse("http://127.0.0.1:9090/"))
+	require.EqualError(t, err, "webhook host 127.0.0.1:9090 is not allowed")
+	err = s.subscribe(member, "other", parse("http://169.254.169.254/"))
+	require.EqualError(t, err, "webhook host 169.254.169.254 is not allowed")
+
+	err = s.subscribe(member, "qid", parse("https://hooks.example.com/c"))
+	require.EqualError(t, err, "request already has 2 webhooks")
+	require.NoError(t, s.subscribe(member, "other", parse("https://hooks.example.com/c")))
+	err = s.subscribe(member, "third", parse("https://hooks.example.com/d"))
+	require.EqualError(t, err, "too many webhooks, try later")
+
+	// sweep forgets expired subscriptions of all requests
+	s.sweep(time.Now().Add(2 * time.Minute))
+	require.Empty(t, s.subs)
+	require.Equal(t, 0, s.count)
+	require.NoError(t, s.subscribe(member, "third", parse("https://hooks.example.com/d")))
+}
@@ -0,0 +1,189 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/instrumentation/timeline"
+	"github.com/insolar/insolar/network"
+)
+
+// TimelineArgs is arguments that Timeline service accepts.
+type TimelineArgs struct {
+	QID     string
+	Request string
+}
+
+// TimelineEvent is an event of request processing.
+type TimelineEvent struct {
+	Time    time.Time
+	Node    string
+	Stage   string
+	QID     string
+	Request string
+	Error   string
+}
+
+// TimelineReply is reply for Timeline service requests.
+type TimelineReply struct {
+	Events      []TimelineEvent
+	Unreachable []string
+}
+
+// TimelineService is a service that provides API for tracing request processing across nodes.
+type TimelineService struct {
+	runner *Runner
+}
+
+// NewTimelineService creates new TimelineService instance.
+func NewTimelineService(runner *Runner) *TimelineService {
+	return &TimelineService{runner: runner}
+}
+
+// Get returns ordered events of request processing gathered from all active nodes. Request is found by
+// query id or reference of request, events of API node and executors are returned.
+//
+//   Request structure:
+//   {
+//     "jsonrpc": "2.0",
+//     "method": "timeline.Get",
+//     "params": {
+//       "QID": str, // query id of request or trace id returned by API
+//       "Request": str // reference of request
+//     },
+//     "id": str|int|null
+//   }
+//
+//     Response structure:
+// 	{
+// 		"jsonrpc": "2.0",
+// 		"result": {
+// 			"Events": [{
+// 				"Time": str, // time when event was observed
+// 				"Node": str, // reference of node which observed event
+// 				"Stage": str, // one of api_received, request_registered, execution_started, execution_finished,
+// 				              // result_registered, validation_done, api_replied
+// 				"QID": str, // query id of request
+// 				"Request": str, // reference of request
+// 				"Error": str // error of stage, if any
+// 			}],
+// 			"Unreachable": [str] // references of nodes which didn't reply
+// 		},
+// 		"id": str|int|null // same as in request
+// 	}
+//
+func (s *TimelineService) Get(r *http.Request, args *TimelineArgs, reply *TimelineReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ TimelineService.Get ] Incoming request: %s", r.RequestURI)
+
+	if args.QID == "" && args.Request == "" {
+		return errors.New("[ TimelineService.Get ] QID or Request must be provided")
+	}
+	var request *core.RecordRef
+	if args.Request != "" {
+		ref, err := core.ParseRef(args.Request)
+		if err != nil {
+			return errors.Wrap(err, "[ TimelineService.Get ] failed to parse Request")
+		}
+		request = ref
+	}
+
+	events := s.runner.Timeline.Events(args.QID, request)
+	// API node knows request of query, so other nodes can find events of internal calls too.
+	for _, e := range events {
+		if request == nil && e.Request != nil {
+			request = e.Request
+		}
+	}
+
+	origin := s.runner.NodeNetwork.GetOrigin()
+	timeout := time.Duration(s.runner.cfg.Timeout) * time.Second
+	msg := &message.GetTimeline{QID: args.QID, Request: request}
+
+	lock := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	for _, node := range s.runner.NodeNetwork.(network.NodeKeeper).GetActiveNodes() {
+		if node.ID().Equal(origin.ID()) {
+			continue
+		}
+		wg.Add(1)
+		go func(ref core.RecordRef) {
+			defer wg.Done()
+			nodeEvents, err := s.runner.requestNodeTimeline(ctx, ref, msg, timeout)
+
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				inslog.Warn(errors.Wrapf(err, "[ TimelineService.Get ] Can't get timeline of node %s", ref))
+				reply.Unreachable = append(reply.Unreachable, ref.String())
+				return
+			}
+			events = append(events, nodeEvents...)
+		}(node.ID())
+	}
+	wg.Wait()
+
+	timeline.Sort(events)
+	reply.Events = make([]TimelineEvent, 0, len(events))
+	for _, e := range events {
+		event := TimelineEvent{
+			Time:  e.Time,
+			Node:  e.Node.String(),
+			Stage: string(e.Stage),
+			QID:   e.QID,
+			Error: e.Error,
+		}
+		if e.Request != nil {
+			event.Request = e.Request.String()
+		}
+		reply.Events = append(reply.Events, event)
+	}
+	return nil
+}
+
+// requestNodeTimeline requests events of request processing observed by node via MessageBus.
+func (ar *Runner) requestNodeTimeline(
+	ctx context.Context, node core.RecordRef, msg *message.GetTimeline, timeout time.Duration,
+) ([]core.TimelineEvent, error) {
+	rep, err := ar.sendToNode(ctx, node, msg, timeout)
+	if err != nil {
+		return nil, err
+	}
+	t, ok := rep.(*reply.Timeline)
+	if !ok {
+		return nil, errors.Errorf("unexpected reply %T", rep)
+	}
+	return t.Events, nil
+}
+
+// getTimelineHandler is MessageBus handler which replies with events of request processing observed by node.
+func (ar *Runner) getTimelineHandler(ctx context.Context, p core.Parcel) (core.Reply, error) {
+	msg := p.Message().(*message.GetTimeline)
+	return &reply.Timeline{Events: ar.Timeline.Events(msg.QID, msg.Request)}, nil
+}
@@ -0,0 +1,100 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/instrumentation/timeline"
+	"github.com/insolar/insolar/network/nodenetwork"
+	"github.com/insolar/insolar/testutils"
+	"github.com/insolar/insolar/testutils/network"
+)
+
+func TestTimelineService_Get(t *testing.T) {
+	ctx := context.Background()
+	origin := nodenetwork.NewNode(testutils.RandomRef(), core.StaticRoleVirtual, nil, "127.0.0.1:1", "")
+	executor := nodenetwork.NewNode(testutils.RandomRef(), core.StaticRoleVirtual, nil, "127.0.0.1:2", "")
+	unavailable := nodenetwork.NewNode(testutils.RandomRef(), core.StaticRoleLightMaterial, nil, "127.0.0.1:3", "")
+
+	nk := network.NewNodeKeeperMock(t)
+	nk.GetOriginMock.Return(origin)
+	nk.GetActiveNodesMock.Return([]core.Node{origin, executor, unavailable})
+
+	request := testutils.RandomRef()
+	start := time.Now()
+	journal := timeline.NewJournal(configuration.NewTimeline())
+	journal.NodeNetwork = nk
+	journal.Record(ctx, core.TimelineEvent{Stage: core.TimelineAPIReceived, QID: "q1", Time: start})
+	journal.Record(ctx, core.TimelineEvent{Stage: core.TimelineAPIReplied, QID: "q1", Request: &request, Time: start.Add(3 * time.Second)})
+	journal.Record(ctx, core.TimelineEvent{Stage: core.TimelineAPIReceived, QID: "q2", Time: start})
+
+	mb := testutils.NewMessageBusMock(t)
+	mb.SendMock.Set(func(ctx context.Context, msg core.Message, opts *core.MessageSendOptions) (core.Reply, error) {
+		require.Equal(t, &message.GetTimeline{QID: "q1", Request: &request}, msg)
+		if *opts.Receiver != executor.ID() {
+			return nil, errors.New("node is unavailable")
+		}
+		return &reply.Timeline{Events: []core.TimelineEvent{
+			{Stage: core.TimelineExecutionFinished, Node: executor.ID(), Request: &request, Time: start.Add(2 * time.Second)},
+			{Stage: core.TimelineRequestRegistered, Node: executor.ID(), Request: &request, Time: start.Add(time.Second)},
+		}}, nil
+	})
+
+	cfg := configuration.NewAPIRunner()
+	runner := &Runner{NodeNetwork: nk, MessageBus: mb, Timeline: journal, cfg: &cfg}
+	service := NewTimelineService(runner)
+
+	var rep TimelineReply
+	require.Error(t, service.Get(&http.Request{}, &TimelineArgs{}, &rep))
+	require.Error(t, service.Get(&http.Request{}, &TimelineArgs{Request: "invalid"}, &rep))
+
+	require.NoError(t, service.Get(&http.Request{}, &TimelineArgs{QID: "q1"}, &rep))
+	var stages []string
+	for _, e := range rep.Events {
+		stages = append(stages, e.Stage)
+	}
+	require.Equal(t, []string{"api_received", "request_registered", "execution_finished", "api_replied"}, stages)
+	require.Equal(t, origin.ID().String(), rep.Events[0].Node)
+	require.Equal(t, executor.ID().String(), rep.Events[1].Node)
+	require.Equal(t, request.String(), rep.Events[1].Request)
+	require.Equal(t, []string{unavailable.ID().String()}, rep.Unreachable)
+}
+
+func TestRunner_getTimelineHandler(t *testing.T) {
+	ctx := context.Background()
+	journal := timeline.NewJournal(configuration.NewTimeline())
+	request := testutils.RandomRef()
+	journal.Record(ctx, core.TimelineEvent{Stage: core.TimelineExecutionStarted, Request: &request})
+	journal.Record(ctx, core.TimelineEvent{Stage: core.TimelineExecutionStarted, Request: &core.RecordRef{}})
+
+	runner := &Runner{Timeline: journal}
+	rep, err := runner.getTimelineHandler(ctx, &message.Parcel{Msg: &message.GetTimeline{Request: &request}})
+	require.NoError(t, err)
+	require.Len(t, rep.(*reply.Timeline).Events, 1)
+	require.Equal(t, &request, rep.(*reply.Timeline).Events[0].Request)
+}
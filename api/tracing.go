@@ -0,0 +1,192 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// TracingArgs is arguments of Tracing.Add and Tracing.Remove requests.
+type TracingArgs struct {
+	Reference string
+	Reason    string
+}
+
+// TracingReply is reply for Tracing service requests, it lists objects and members which flows are traced.
+type TracingReply struct {
+	Targets []string
+}
+
+// TracingService is a service that provides admin API for selective tracing of objects and members.
+type TracingService struct {
+	runner *Runner
+}
+
+// NewTracingService creates new TracingService instance.
+func NewTracingService(runner *Runner) *TracingService {
+	return &TracingService{runner: runner}
+}
+
+// Add enables full tracing of flows touching object or member: their parcels are logged at debug level and
+// all their spans are captured, on every node flows reach. Request must be authorized with admin token.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "tracing.Add",
+//	  "params": {
+//	    "Reference": str, // reference of object or member
+//	    "Reason": str // why object is traced, required for audit
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	Response structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "result": {
+//	    "Targets": [str] // references of traced objects and members
+//	  },
+//	  "id": str|int|null // same as in request
+//	}
+func (s *TracingService) Add(r *http.Request, args *TracingArgs, reply *TracingReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ TracingService.Add ] Incoming request: %s, target: %s", r.RequestURI, args.Reference)
+
+	target, err := s.parseArgs(r, args)
+	if err != nil {
+		inslog.Warnf("[ TracingService.Add ] bad request from %s: %s", r.RemoteAddr, err)
+		return errors.Wrap(err, "[ TracingService.Add ]")
+	}
+
+	s.runner.TraceTargets.AddTraceTarget(*target)
+	s.runner.audit(ctx, r, "tracing.Add", target.String(), args.Reason, nil)
+
+	reply.Targets = s.targets()
+	return nil
+}
+
+// Remove disables full tracing of object or member. Request must be authorized with admin token.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "tracing.Remove",
+//	  "params": {
+//	    "Reference": str, // reference of object or member
+//	    "Reason": str // required for audit
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	Response structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "result": {
+//	    "Targets": [str] // references of traced objects and members
+//	  },
+//	  "id": str|int|null // same as in request
+//	}
+func (s *TracingService) Remove(r *http.Request, args *TracingArgs, reply *TracingReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ TracingService.Remove ] Incoming request: %s, target: %s", r.RequestURI, args.Reference)
+
+	target, err := s.parseArgs(r, args)
+	if err != nil {
+		inslog.Warnf("[ TracingService.Remove ] bad request from %s: %s", r.RemoteAddr, err)
+		return errors.Wrap(err, "[ TracingService.Remove ]")
+	}
+
+	if !s.runner.TraceTargets.RemoveTraceTarget(*target) {
+		err = errors.Errorf("%s is not traced", target)
+	}
+	s.runner.audit(ctx, r, "tracing.Remove", target.String(), args.Reason, err)
+	if err != nil {
+		return errors.Wrap(err, "[ TracingService.Remove ]")
+	}
+
+	reply.Targets = s.targets()
+	return nil
+}
+
+// List returns objects and members which flows are traced. Request must be authorized with admin token.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "tracing.List",
+//	  "params": {},
+//	  "id": str|int|null
+//	}
+//
+//	Response structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "result": {
+//	    "Targets": [str] // references of traced objects and members
+//	  },
+//	  "id": str|int|null // same as in request
+//	}
+func (s *TracingService) List(r *http.Request, args *struct{}, reply *TracingReply) error {
+	_, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ TracingService.List ] Incoming request: %s", r.RequestURI)
+
+	if err := s.runner.authorizeAdmin(r, "TracingService"); err != nil {
+		inslog.Warnf("[ TracingService.List ] unauthorized request from %s: %s", r.RemoteAddr, err)
+		return err
+	}
+
+	reply.Targets = s.targets()
+	return nil
+}
+
+// parseArgs authorizes request and parses target reference.
+func (s *TracingService) parseArgs(r *http.Request, args *TracingArgs) (*core.RecordRef, error) {
+	if err := s.runner.authorizeAdmin(r, "TracingService"); err != nil {
+		return nil, err
+	}
+	if args.Reason == "" {
+		return nil, errors.New("reason is required")
+	}
+	target, err := core.ParseRef(args.Reference)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse reference")
+	}
+	return target, nil
+}
+
+// targets returns sorted references of traced objects and members.
+func (s *TracingService) targets() []string {
+	refs := s.runner.TraceTargets.GetTraceTargets()
+	targets := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		targets = append(targets, ref.String())
+	}
+	sort.Strings(targets)
+	return targets
+}
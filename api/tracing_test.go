@@ -0,0 +1,82 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+)
+
+type traceTargets map[core.RecordRef]struct{}
+
+func (tt traceTargets) AddTraceTarget(target core.RecordRef) {
+	tt[target] = struct{}{}
+}
+
+func (tt traceTargets) RemoveTraceTarget(target core.RecordRef) bool {
+	_, ok := tt[target]
+	delete(tt, target)
+	return ok
+}
+
+func (tt traceTargets) GetTraceTargets() []core.RecordRef {
+	var targets []core.RecordRef
+	for target := range tt {
+		targets = append(targets, target)
+	}
+	return targets
+}
+
+func TestTracingService(t *testing.T) {
+	cfg := configuration.NewAPIRunner()
+	targets := traceTargets{}
+	service := NewTracingService(&Runner{cfg: &cfg, TraceTargets: targets})
+	object := testutils.RandomRef()
+	args := &TracingArgs{Reference: object.String(), Reason: "failing transfers"}
+	var rep TracingReply
+
+	err := service.Add(deployRequest("secret"), args, &rep)
+	require.Contains(t, err.Error(), "disabled")
+
+	cfg.AdminToken = "secret"
+	err = service.Add(deployRequest("wrong"), args, &rep)
+	require.Contains(t, err.Error(), "invalid admin token")
+	err = service.List(deployRequest(""), &struct{}{}, &rep)
+	require.Contains(t, err.Error(), "admin token is required")
+	err = service.Add(deployRequest("secret"), &TracingArgs{Reference: object.String()}, &rep)
+	require.Contains(t, err.Error(), "reason is required")
+	err = service.Add(deployRequest("secret"), &TracingArgs{Reference: "bad", Reason: "failing transfers"}, &rep)
+	require.Contains(t, err.Error(), "failed to parse reference")
+	require.Empty(t, targets)
+
+	require.NoError(t, service.Add(deployRequest("secret"), args, &rep))
+	require.Equal(t, []string{object.String()}, rep.Targets)
+
+	rep = TracingReply{}
+	require.NoError(t, service.List(deployRequest("secret"), &struct{}{}, &rep))
+	require.Equal(t, []string{object.String()}, rep.Targets)
+
+	require.NoError(t, service.Remove(deployRequest("secret"), args, &rep))
+	require.Empty(t, rep.Targets)
+	err = service.Remove(deployRequest("secret"), args, &rep)
+	require.Contains(t, err.Error(), "is not traced")
+}
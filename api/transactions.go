@@ -0,0 +1,155 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/netparams"
+	"github.com/pkg/errors"
+)
+
+// TransactionArgs is arguments that Transactions service accepts.
+type TransactionArgs struct {
+	Transaction core.Transaction
+}
+
+// TransactionReply is reply for Transactions service requests.
+type TransactionReply struct {
+	Result   interface{}
+	Request  string
+	Pulse    uint32
+	Finality string
+	TraceID  string
+}
+
+// TransactionsService is a service that executes member calls signed offline.
+type TransactionsService struct {
+	runner *Runner
+}
+
+// NewTransactionsService creates new Transactions service instance.
+func NewTransactionsService(runner *Runner) *TransactionsService {
+	return &TransactionsService{runner: runner}
+}
+
+// Submit executes offline transaction. Anyone may submit transaction, it's executed on behalf of member
+// who signed it if signature is valid and expiry pulse hasn't passed. Transaction with the same nonce is
+// executed once.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "transactions.Submit",
+//	  "params": {
+//	    "Transaction": {
+//	      "reference": str, // reference of member
+//	      "method": str, // method of member
+//	      "params": str, // base64 of serialized arguments
+//	      "nonce": int, // number chosen by signer
+//	      "expiry": int, // the last pulse transaction may be executed in
+//	      "signature": str // base64 of signature
+//	    }
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	  Response structure:
+//	  {
+//	    "jsonrpc": "2.0",
+//	    "result": {
+//	      "Result": any, // result of method
+//	      "Request": str, // reference of registered request
+//	      "Pulse": int, // pulse of execution
+//	      "Finality": str, // finality of result at the moment of reply
+//	      "TraceID": str
+//	    },
+//	    "id": str|int|null // same as in request
+//	  }
+func (s *TransactionsService) Submit(r *http.Request, args *TransactionArgs, reply *TransactionReply) error {
+	traceID := utils.RandTraceID()
+	ctx, inslog := inslogger.WithTraceField(context.Background(), traceID)
+
+	inslog.Infof("[ TransactionsService.Submit ] Incoming request: %s", r.RequestURI)
+
+	params, err := s.runner.checkTransaction(ctx, &args.Transaction)
+	if err != nil {
+		return errors.Wrap(err, "[ TransactionsService.Submit ] Transaction is rejected")
+	}
+
+	if !s.runner.limiter.acquire(params.Method) {
+		return errors.Errorf("[ TransactionsService.Submit ] too many concurrent calls of %s", params.Method)
+	}
+	defer s.runner.limiter.release(params.Method)
+
+	result, rep, err := s.runner.makeCall(ctx, params)
+	if err != nil {
+		return errors.Wrap(err, "[ TransactionsService.Submit ] Can't make call")
+	}
+	reply.Result = result
+	reply.Request = rep.Request.String()
+	reply.Pulse = uint32(rep.Pulse)
+	reply.Finality = rep.Finality.String()
+	reply.TraceID = traceID
+	return nil
+}
+
+// checkTransaction checks offline transaction before execution: it isn't expired, it's signed by member and method
+// is allowed to member. Returns request of call transaction makes, member contract checks signature and expiry
+// again and ledger rejects reused nonce.
+func (ar *Runner) checkTransaction(ctx context.Context, tx *core.Transaction) (Request, error) {
+	_, err := core.ParseRef(tx.Reference)
+	if err != nil {
+		return Request{}, errors.Wrap(err, "bad reference")
+	}
+
+	pulse, err := ar.PulseStorage.Current(ctx)
+	if err != nil {
+		return Request{}, errors.Wrap(err, "can't get current pulse")
+	}
+	if tx.Expiry < pulse.PulseNumber {
+		return Request{}, errors.Errorf(
+			"transaction expired on pulse %d, current pulse is %d", tx.Expiry, pulse.PulseNumber,
+		)
+	}
+
+	params := Request{
+		Reference: tx.Reference,
+		Method:    tx.Method,
+		Params:    tx.Params,
+		Seed:      tx.Seed(),
+		Signature: tx.Signature,
+	}
+	err = ar.verifySignature(ctx, params)
+	if err != nil {
+		return Request{}, err
+	}
+
+	err = ar.authorizer.authorize(ctx, params.Reference, params.Method, ar.getMemberRole)
+	if err != nil {
+		return Request{}, errors.Wrap(err, "method is not allowed")
+	}
+
+	if reason, paused := netparams.Paused(ar.NetworkParameters); paused && !ar.allowedOnPause(params.Method) {
+		return Request{}, errors.Errorf("network is paused: %s", reason)
+	}
+	return params, nil
+}
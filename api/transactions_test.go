@@ -0,0 +1,104 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"crypto"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+)
+
+type networkParameters map[string]string
+
+func (p networkParameters) GetNetworkParameter(name string) (string, bool) {
+	value, ok := p[name]
+	return value, ok
+}
+
+func TestRunner_checkTransaction(t *testing.T) {
+	ctx := context.Background()
+	kp := platformpolicy.NewKeyProcessor()
+	privateKey, err := kp.GeneratePrivateKey()
+	require.NoError(t, err)
+	member := *core.NewRecordRef(testutils.RandomID(), *core.NewRecordID(core.FirstPulseNumber+1, nil))
+
+	ps := testutils.NewPulseStorageMock(t)
+	ps.CurrentMock.Return(&core.Pulse{PulseNumber: core.FirstPulseNumber + 10}, nil)
+	authorizer, err := newMethodAuthorizer(nil)
+	require.NoError(t, err)
+	params := networkParameters{}
+	runner := &Runner{
+		cfg:               &configuration.APIRunner{},
+		PulseStorage:      ps,
+		NetworkParameters: params,
+		authorizer:        authorizer,
+		keyCache:          map[string]crypto.PublicKey{member.String(): kp.ExtractPublicKey(privateKey)},
+		cacheLock:         &sync.RWMutex{},
+	}
+
+	sign := func(tx core.Transaction) core.Transaction {
+		payload, err := tx.SignedPayload()
+		require.NoError(t, err)
+		signature, err := scheme.Signer(privateKey).Sign(payload)
+		require.NoError(t, err)
+		tx.Signature = signature.Bytes()
+		return tx
+	}
+	tx := sign(core.Transaction{
+		Reference: member.String(),
+		Method:    "Transfer",
+		Params:    []byte{1, 2, 3},
+		Nonce:     7,
+		Expiry:    core.FirstPulseNumber + 10,
+	})
+
+	req, err := runner.checkTransaction(ctx, &tx)
+	require.NoError(t, err)
+	require.Equal(t, tx.Seed(), req.Seed)
+	require.Equal(t, tx.Signature, req.Signature)
+	require.Equal(t, "Transfer", req.Method)
+
+	tampered := tx
+	tampered.Nonce++
+	_, err = runner.checkTransaction(ctx, &tampered)
+	require.Contains(t, err.Error(), "Incorrect signature")
+
+	expired := sign(core.Transaction{Reference: member.String(), Method: "Transfer", Expiry: core.FirstPulseNumber + 9})
+	_, err = runner.checkTransaction(ctx, &expired)
+	require.Contains(t, err.Error(), "transaction expired")
+
+	params[core.NetworkParameterEmergencyPause] = "incident"
+	_, err = runner.checkTransaction(ctx, &tx)
+	require.Contains(t, err.Error(), "network is paused")
+}
+
+func TestTransactionsService_Submit_Rejected(t *testing.T) {
+	service := NewTransactionsService(&Runner{})
+
+	var reply TransactionReply
+	err := service.Submit(&http.Request{}, &TransactionArgs{Transaction: core.Transaction{Reference: "bad"}}, &reply)
+	require.Contains(t, err.Error(), "bad reference")
+}
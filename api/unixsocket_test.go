@@ -0,0 +1,152 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestRunner_UnixSocket(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	dir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	socket := filepath.Join(dir, "api.sock")
+	// stale socket file of previous run
+	stale, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+
+	http.DefaultServeMux = new(http.ServeMux)
+	cfg := configuration.NewAPIRunner()
+	cfg.Address = ""
+	cfg.UnixSocket = socket
+	cfg.UnixSocketMode = 0600
+	api, err := NewRunner(&cfg)
+	require.NoError(t, err)
+
+	mb := testutils.NewMessageBusMock(t)
+	mb.MustRegisterMock.Return()
+	api.MessageBus = mb
+	api.NodeMessenger = &nodeMessenger{}
+	require.NoError(t, api.Start(ctx))
+
+	fi, err := os.Stat(socket)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+
+	client := http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return net.Dial("unix", socket)
+			},
+		},
+	}
+	resp, err := client.Get("http://unix" + cfg.Call)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	require.NoError(t, api.Stop(ctx))
+	_, err = os.Stat(socket)
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestListenUnix_PrivateBeforeListen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "api.sock")
+	listener, err := listenUnix(path, 0600)
+	require.NoError(t, err)
+
+	fi, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+
+	// private directory socket was created in is removed
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	conn, err := net.Dial("unix", path)
+	require.NoError(t, err)
+	conn.Close()
+
+	require.NoError(t, listener.Close())
+	_, err = os.Stat(path)
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestListenUnix_NotSocket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "file")
+	require.NoError(t, ioutil.WriteFile(path, nil, 0600))
+
+	_, err = listenUnix(path, 0600)
+	require.Error(t, err)
+}
+
+func TestRunner_UnixSocketFailureClosesTCP(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	dir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "file")
+	require.NoError(t, ioutil.WriteFile(path, nil, 0600))
+
+	free, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := free.Addr().String()
+	free.Close()
+
+	http.DefaultServeMux = new(http.ServeMux)
+	cfg := configuration.NewAPIRunner()
+	cfg.Address = address
+	cfg.UnixSocket = path
+	api, err := NewRunner(&cfg)
+	require.NoError(t, err)
+
+	mb := testutils.NewMessageBusMock(t)
+	mb.MustRegisterMock.Return()
+	api.MessageBus = mb
+	api.NodeMessenger = &nodeMessenger{}
+	require.Error(t, api.Start(ctx))
+
+	// tcp listener is closed, so the address is free again
+	listener, err := net.Listen("tcp", address)
+	require.NoError(t, err)
+	listener.Close()
+}
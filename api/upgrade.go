@@ -0,0 +1,202 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// UpgradeArgs is arguments of Upgrade.Request and Upgrade.Release requests.
+type UpgradeArgs struct {
+	Reason string
+}
+
+// RestartSlotReply is restart slot granted to node.
+type RestartSlotReply struct {
+	Node    string
+	Role    string
+	Granted uint32
+	Expires uint32
+}
+
+// UpgradeRequestReply is reply for Upgrade.Request request.
+type UpgradeRequestReply struct {
+	Granted bool
+	Slot    *RestartSlotReply
+	Reason  string
+}
+
+// UpgradeSlotsReply is reply for Upgrade.Slots request.
+type UpgradeSlotsReply struct {
+	Slots []RestartSlotReply
+}
+
+// UpgradeService is a service that provides API for coordination of node restarts during rolling upgrades.
+type UpgradeService struct {
+	runner *Runner
+}
+
+// NewUpgradeService creates new UpgradeService instance.
+func NewUpgradeService(runner *Runner) *UpgradeService {
+	return &UpgradeService{runner: runner}
+}
+
+// Request registers intent of this node to restart and asks the network for restart slot. Slot isn't granted if
+// too many nodes of the same role restart at the moment or if light node validates the same jet as restarting node,
+// node should ask again later then. Request must be authorized with admin token.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "upgrade.Request",
+//	  "params": {
+//	    "Reason": str // optional, reason recorded in audit log
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	Response structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "result": {
+//	    "Granted": bool, // node can restart
+//	    "Slot": { // granted slot, null if node should wait
+//	      "Node": str, // reference of the node
+//	      "Role": str,
+//	      "Granted": int, // pulse number slot was granted in
+//	      "Expires": int // pulse number slot is released in if node doesn't release it
+//	    },
+//	    "Reason": str // why slot isn't granted
+//	  },
+//	  "id": str|int|null // same as in request
+//	}
+func (s *UpgradeService) Request(r *http.Request, args *UpgradeArgs, reply *UpgradeRequestReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ UpgradeService.Request ] Incoming request: %s", r.RequestURI)
+
+	if err := s.runner.authorizeAdmin(r, "UpgradeService"); err != nil {
+		inslog.Warnf("[ UpgradeService.Request ] unauthorized request from %s: %s", r.RemoteAddr, err)
+		return err
+	}
+
+	node := s.runner.CertificateManager.GetCertificate().GetNodeRef().String()
+	decision, err := s.runner.Upgrades.RequestRestart(ctx)
+	s.runner.audit(ctx, r, "upgrade.Request", node, args.Reason, err)
+	if err != nil {
+		return errors.Wrap(err, "[ UpgradeService.Request ] failed to request restart slot")
+	}
+	reply.Granted = decision.Slot != nil
+	if decision.Slot != nil {
+		slot := restartSlotReply(*decision.Slot)
+		reply.Slot = &slot
+	}
+	reply.Reason = decision.Reason
+	return nil
+}
+
+// Release returns restart slot of this node, node should release it once it's back after restart.
+// Request must be authorized with admin token.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "upgrade.Release",
+//	  "params": {
+//	    "Reason": str // optional, reason recorded in audit log
+//	  },
+//	  "id": str|int|null
+//	}
+//
+//	Response structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "result": {},
+//	  "id": str|int|null // same as in request
+//	}
+func (s *UpgradeService) Release(r *http.Request, args *UpgradeArgs, reply *struct{}) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ UpgradeService.Release ] Incoming request: %s", r.RequestURI)
+
+	if err := s.runner.authorizeAdmin(r, "UpgradeService"); err != nil {
+		inslog.Warnf("[ UpgradeService.Release ] unauthorized request from %s: %s", r.RemoteAddr, err)
+		return err
+	}
+
+	node := s.runner.CertificateManager.GetCertificate().GetNodeRef().String()
+	err := s.runner.Upgrades.ReleaseRestart(ctx)
+	s.runner.audit(ctx, r, "upgrade.Release", node, args.Reason, err)
+	return errors.Wrap(err, "[ UpgradeService.Release ] failed to release restart slot")
+}
+
+// Slots returns restart slots held by nodes, sorted by node reference.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "upgrade.Slots",
+//	  "params": {},
+//	  "id": str|int|null
+//	}
+//
+//	Response structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "result": {
+//	    "Slots": [
+//	      {
+//	        "Node": str,
+//	        "Role": str,
+//	        "Granted": int,
+//	        "Expires": int
+//	      }
+//	    ]
+//	  },
+//	  "id": str|int|null // same as in request
+//	}
+func (s *UpgradeService) Slots(r *http.Request, args *struct{}, reply *UpgradeSlotsReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ UpgradeService.Slots ] Incoming request: %s", r.RequestURI)
+
+	slots, err := s.runner.Upgrades.RestartSlots(ctx)
+	if err != nil {
+		return errors.Wrap(err, "[ UpgradeService.Slots ] failed to get restart slots")
+	}
+	reply.Slots = make([]RestartSlotReply, 0, len(slots))
+	for _, slot := range slots {
+		reply.Slots = append(reply.Slots, restartSlotReply(slot))
+	}
+	return nil
+}
+
+func restartSlotReply(slot core.RestartSlot) RestartSlotReply {
+	return RestartSlotReply{
+		Node:    slot.Node.String(),
+		Role:    slot.Role.String(),
+		Granted: uint32(slot.Granted),
+		Expires: uint32(slot.Expires),
+	}
+}
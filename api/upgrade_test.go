@@ -0,0 +1,86 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+)
+
+type upgradeCoordinator struct {
+	decision *core.RestartDecision
+	released bool
+	slots    []core.RestartSlot
+}
+
+func (c *upgradeCoordinator) RequestRestart(ctx context.Context) (*core.RestartDecision, error) {
+	return c.decision, nil
+}
+
+func (c *upgradeCoordinator) ReleaseRestart(ctx context.Context) error {
+	c.released = true
+	return nil
+}
+
+func (c *upgradeCoordinator) RestartSlots(ctx context.Context) ([]core.RestartSlot, error) {
+	return c.slots, nil
+}
+
+func TestUpgradeService(t *testing.T) {
+	node := testutils.RandomRef()
+	cert := testutils.NewCertificateMock(t)
+	cert.GetNodeRefMock.Return(&node)
+	cm := testutils.NewCertificateManagerMock(t)
+	cm.GetCertificateMock.Return(cert)
+
+	slot := core.RestartSlot{Node: node, Role: core.StaticRoleVirtual, Granted: 100, Expires: 400}
+	coordinator := &upgradeCoordinator{decision: &core.RestartDecision{Reason: "1 of 1 allowed virtual nodes are restarting"}}
+	cfg := configuration.NewAPIRunner()
+	service := NewUpgradeService(&Runner{cfg: &cfg, CertificateManager: cm, Upgrades: coordinator})
+
+	var rep UpgradeRequestReply
+	err := service.Request(deployRequest("secret"), &UpgradeArgs{}, &rep)
+	require.Contains(t, err.Error(), "disabled")
+
+	cfg.AdminToken = "secret"
+	require.Error(t, service.Release(deployRequest("wrong"), &UpgradeArgs{}, &struct{}{}))
+	require.False(t, coordinator.released)
+
+	require.NoError(t, service.Request(deployRequest("secret"), &UpgradeArgs{}, &rep))
+	require.Equal(t, UpgradeRequestReply{Reason: "1 of 1 allowed virtual nodes are restarting"}, rep)
+
+	coordinator.decision = &core.RestartDecision{Slot: &slot}
+	rep = UpgradeRequestReply{}
+	require.NoError(t, service.Request(deployRequest("secret"), &UpgradeArgs{}, &rep))
+	expected := RestartSlotReply{Node: node.String(), Role: "virtual", Granted: 100, Expires: 400}
+	require.Equal(t, UpgradeRequestReply{Granted: true, Slot: &expected}, rep)
+
+	require.NoError(t, service.Release(deployRequest("secret"), &UpgradeArgs{}, &struct{}{}))
+	require.True(t, coordinator.released)
+
+	// slots are public
+	coordinator.slots = []core.RestartSlot{slot}
+	var slots UpgradeSlotsReply
+	require.NoError(t, service.Slots(deployRequest(""), &struct{}{}, &slots))
+	require.Equal(t, []RestartSlotReply{expected}, slots.Slots)
+}
@@ -0,0 +1,164 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/version"
+)
+
+// NodeVersion is a version of software of single node.
+type NodeVersion struct {
+	Reference string
+	Role      string
+	Version   string
+	Error     string
+}
+
+// VersionReply is reply for Version service requests.
+type VersionReply struct {
+	Nodes        []NodeVersion
+	Distribution map[string]int
+}
+
+// VersionService is a service that provides API for getting versions of active nodes.
+type VersionService struct {
+	runner *Runner
+}
+
+// NewVersionService creates new VersionService instance.
+func NewVersionService(runner *Runner) *VersionService {
+	return &VersionService{runner: runner}
+}
+
+// GetNodes returns versions of software of all active nodes.
+//
+//   Request structure:
+//   {
+//     "jsonrpc": "2.0",
+//     "method": "version.GetNodes",
+//     "id": str|int|null
+//   }
+//
+//     Response structure:
+// 	{
+// 		"jsonrpc": "2.0",
+// 		"result": {
+// 			"Nodes": [{
+// 				"Reference": str, // reference of node
+// 				"Role": str, // static role of node
+// 				"Version": str, // version of node software
+// 				"Error": str // error occurred while requesting version, if any
+// 			}],
+// 			"Distribution": {str: int} // number of nodes per version
+// 		},
+// 		"id": str|int|null // same as in request
+// 	}
+//
+func (s *VersionService) GetNodes(r *http.Request, args *interface{}, reply *VersionReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ VersionService.GetNodes ] Incoming request: %s", r.RequestURI)
+
+	activeNodes := s.runner.NodeNetwork.(network.NodeKeeper).GetActiveNodes()
+	origin := s.runner.NodeNetwork.GetOrigin()
+	timeout := time.Duration(s.runner.cfg.Timeout) * time.Second
+
+	reply.Nodes = make([]NodeVersion, len(activeNodes))
+	wg := sync.WaitGroup{}
+	for i, node := range activeNodes {
+		reply.Nodes[i] = NodeVersion{
+			Reference: node.ID().String(),
+			Role:      node.Role().String(),
+		}
+		if node.ID().Equal(origin.ID()) {
+			reply.Nodes[i].Version = version.GetNodeVersion()
+			continue
+		}
+
+		wg.Add(1)
+		go func(nv *NodeVersion, ref core.RecordRef) {
+			defer wg.Done()
+			v, err := s.runner.requestNodeVersion(ctx, ref, timeout)
+			if err != nil {
+				inslog.Warn(errors.Wrapf(err, "[ VersionService.GetNodes ] Can't get version of node %s", ref))
+				nv.Error = err.Error()
+				return
+			}
+			nv.Version = v
+		}(&reply.Nodes[i], node.ID())
+	}
+	wg.Wait()
+
+	reply.Distribution = make(map[string]int)
+	for _, nv := range reply.Nodes {
+		if nv.Error == "" {
+			reply.Distribution[nv.Version]++
+		}
+	}
+	return nil
+}
+
+// requestNodeVersion requests version of node software via MessageBus.
+func (ar *Runner) requestNodeVersion(ctx context.Context, node core.RecordRef, timeout time.Duration) (string, error) {
+	rep, err := ar.sendToNode(ctx, node, &message.GetNodeVersion{}, timeout)
+	if err != nil {
+		return "", err
+	}
+	v, ok := rep.(*reply.NodeVersion)
+	if !ok {
+		return "", errors.Errorf("unexpected reply %T", rep)
+	}
+	return v.Version, nil
+}
+
+// sendToNode sends message to node via MessageBus and waits for reply no longer than timeout.
+func (ar *Runner) sendToNode(ctx context.Context, node core.RecordRef, msg core.Message, timeout time.Duration) (core.Reply, error) {
+	type result struct {
+		rep core.Reply
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		rep, err := ar.MessageBus.Send(ctx, msg, &core.MessageSendOptions{Receiver: &node})
+		done <- result{rep: rep, err: err}
+	}()
+
+	select {
+	case res := <-done:
+		return res.rep, res.err
+	case <-time.After(timeout):
+		return nil, errors.New("timeout")
+	}
+}
+
+// getNodeVersionHandler is MessageBus handler which replies with version of node software.
+func (ar *Runner) getNodeVersionHandler(ctx context.Context, p core.Parcel) (core.Reply, error) {
+	return &reply.NodeVersion{Version: version.GetNodeVersion()}, nil
+}
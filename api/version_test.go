@@ -0,0 +1,80 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/network/nodenetwork"
+	"github.com/insolar/insolar/testutils"
+	"github.com/insolar/insolar/testutils/network"
+	"github.com/insolar/insolar/version"
+)
+
+func TestVersionService_GetNodes(t *testing.T) {
+	origin := nodenetwork.NewNode(testutils.RandomRef(), core.StaticRoleVirtual, nil, "127.0.0.1:1", "")
+	upToDate := nodenetwork.NewNode(testutils.RandomRef(), core.StaticRoleLightMaterial, nil, "127.0.0.1:2", "")
+	outdated := nodenetwork.NewNode(testutils.RandomRef(), core.StaticRoleHeavyMaterial, nil, "127.0.0.1:3", "")
+	unavailable := nodenetwork.NewNode(testutils.RandomRef(), core.StaticRoleVirtual, nil, "127.0.0.1:4", "")
+
+	nk := network.NewNodeKeeperMock(t)
+	nk.GetOriginMock.Return(origin)
+	nk.GetActiveNodesMock.Return([]core.Node{origin, upToDate, outdated, unavailable})
+
+	mb := testutils.NewMessageBusMock(t)
+	mb.SendMock.Set(func(ctx context.Context, msg core.Message, opts *core.MessageSendOptions) (core.Reply, error) {
+		require.IsType(t, &message.GetNodeVersion{}, msg)
+		switch *opts.Receiver {
+		case upToDate.ID():
+			return &reply.NodeVersion{Version: version.GetNodeVersion()}, nil
+		case outdated.ID():
+			return &reply.NodeVersion{Version: "v0.0.1-old"}, nil
+		}
+		return nil, errors.New("node is unavailable")
+	})
+
+	cfg := configuration.NewAPIRunner()
+	runner := &Runner{NodeNetwork: nk, MessageBus: mb, cfg: &cfg}
+
+	var rep VersionReply
+	err := NewVersionService(runner).GetNodes(&http.Request{}, nil, &rep)
+	require.NoError(t, err)
+
+	require.Len(t, rep.Nodes, 4)
+	require.Equal(t, version.GetNodeVersion(), rep.Nodes[0].Version)
+	require.Equal(t, version.GetNodeVersion(), rep.Nodes[1].Version)
+	require.Equal(t, "v0.0.1-old", rep.Nodes[2].Version)
+	require.Equal(t, "", rep.Nodes[3].Version)
+	require.Contains(t, rep.Nodes[3].Error, "unavailable")
+	require.Equal(t, map[string]int{version.GetNodeVersion(): 2, "v0.0.1-old": 1}, rep.Distribution)
+}
+
+func TestRunner_getNodeVersionHandler(t *testing.T) {
+	runner := &Runner{}
+	rep, err := runner.getNodeVersionHandler(context.Background(), &message.Parcel{Msg: &message.GetNodeVersion{}})
+	require.NoError(t, err)
+	require.Equal(t, &reply.NodeVersion{Version: version.GetNodeVersion()}, rep)
+}
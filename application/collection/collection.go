@@ -0,0 +1,195 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+// Package collection provides collections for contracts which keep elements in child objects of the contract.
+// State of the contract holds only keys and references of elements, so contracts with thousands of entries
+// don't deserialize all values on every call: value is loaded from its child object when it's accessed.
+//
+// Collections can be used only inside of contract methods, because elements are created as children of the
+// called object.
+//
+//	type Registry struct {
+//	    foundation.BaseContract
+//	    Nodes collection.OrderedMap
+//	}
+//
+//	func (r *Registry) Register(key string, info NodeInfo) error {
+//	    return r.Nodes.Set(key, info)
+//	}
+package collection
+
+import (
+	"fmt"
+
+	"github.com/insolar/insolar/application/proxy/entry"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
+	"github.com/insolar/insolar/logicrunner/goplugin/proxyctx"
+)
+
+// OrderedMap is a map with string keys which is iterated in order of insertion. Zero value is an empty map.
+type OrderedMap struct {
+	Keys    []string
+	Entries map[string]core.RecordRef
+}
+
+// Len returns number of entries in map.
+func (m *OrderedMap) Len() int {
+	return len(m.Keys)
+}
+
+// Has checks that map has entry with key without loading its value.
+func (m *OrderedMap) Has(key string) bool {
+	_, ok := m.Entries[key]
+	return ok
+}
+
+// Get loads value of key into value, which should be a pointer. Returns false if there is no such key.
+func (m *OrderedMap) Get(key string, value interface{}) (bool, error) {
+	ref, ok := m.Entries[key]
+	if !ok {
+		return false, nil
+	}
+	data, err := entry.GetObject(ref).GetValue()
+	if err != nil {
+		return false, fmt.Errorf("[ OrderedMap.Get ] Can't load value of %s: %s", key, err.Error())
+	}
+	err = proxyctx.Current.Deserialize(data, value)
+	if err != nil {
+		return false, fmt.Errorf("[ OrderedMap.Get ] Can't deserialize value of %s: %s", key, err.Error())
+	}
+	return true, nil
+}
+
+// Set sets value of key. New key is added to the end of map.
+func (m *OrderedMap) Set(key string, value interface{}) error {
+	var data []byte
+	err := proxyctx.Current.Serialize(value, &data)
+	if err != nil {
+		return fmt.Errorf("[ OrderedMap.Set ] Can't serialize value of %s: %s", key, err.Error())
+	}
+
+	if ref, ok := m.Entries[key]; ok {
+		err = entry.GetObject(ref).SetValue(data)
+		if err != nil {
+			return fmt.Errorf("[ OrderedMap.Set ] Can't update value of %s: %s", key, err.Error())
+		}
+		return nil
+	}
+
+	e, err := entry.New(key, data).AsChild(*foundation.GetContext().Callee)
+	if err != nil {
+		return fmt.Errorf("[ OrderedMap.Set ] Can't save entry %s as child: %s", key, err.Error())
+	}
+	if m.Entries == nil {
+		m.Entries = make(map[string]core.RecordRef)
+	}
+	m.Entries[key] = e.GetReference()
+	m.Keys = append(m.Keys, key)
+	return nil
+}
+
+// Delete removes entry of key. Returns false if there is no such key.
+func (m *OrderedMap) Delete(key string) (bool, error) {
+	ref, ok := m.Entries[key]
+	if !ok {
+		return false, nil
+	}
+	err := entry.GetObject(ref).Destroy()
+	if err != nil {
+		return false, fmt.Errorf("[ OrderedMap.Delete ] Can't destroy entry %s: %s", key, err.Error())
+	}
+	delete(m.Entries, key)
+	for i, k := range m.Keys {
+		if k == key {
+			m.Keys = append(m.Keys[:i], m.Keys[i+1:]...)
+			break
+		}
+	}
+	return true, nil
+}
+
+// Iterator returns iterator over map in order of insertion. Map shouldn't be changed while it's iterated.
+func (m *OrderedMap) Iterator() *Iterator {
+	return &Iterator{m: m, pos: -1}
+}
+
+// Iterator iterates over keys of map, values are loaded only on Value call.
+type Iterator struct {
+	m   *OrderedMap
+	pos int
+}
+
+// HasNext returns true if there are more keys.
+func (it *Iterator) HasNext() bool {
+	return it.pos+1 < len(it.m.Keys)
+}
+
+// Next moves iterator to the next key and returns it.
+func (it *Iterator) Next() (string, error) {
+	if !it.HasNext() {
+		return "", fmt.Errorf("[ Iterator.Next ] No more keys")
+	}
+	it.pos++
+	return it.m.Keys[it.pos], nil
+}
+
+// Value loads value of the current key into value, which should be a pointer.
+func (it *Iterator) Value(value interface{}) error {
+	if it.pos < 0 {
+		return fmt.Errorf("[ Iterator.Value ] Next wasn't called")
+	}
+	key := it.m.Keys[it.pos]
+	_, err := it.m.Get(key, value)
+	return err
+}
+
+// Set is a set of strings which is iterated in order of insertion. Zero value is an empty set.
+type Set struct {
+	Elements OrderedMap
+}
+
+// Len returns number of elements in set.
+func (s *Set) Len() int {
+	return s.Elements.Len()
+}
+
+// Has checks that set contains element.
+func (s *Set) Has(element string) bool {
+	return s.Elements.Has(element)
+}
+
+// Add adds element to set. Returns false if set already contains it.
+func (s *Set) Add(element string) (bool, error) {
+	if s.Elements.Has(element) {
+		return false, nil
+	}
+	err := s.Elements.Set(element, nil)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Remove removes element from set. Returns false if set doesn't contain it.
+func (s *Set) Remove(element string) (bool, error) {
+	return s.Elements.Delete(element)
+}
+
+// Iterator returns iterator over elements of set in order of insertion.
+func (s *Set) Iterator() *Iterator {
+	return s.Elements.Iterator()
+}
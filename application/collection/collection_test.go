@@ -0,0 +1,165 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package collection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	entrycontract "github.com/insolar/insolar/application/contract/entry"
+	"github.com/insolar/insolar/application/proxy/entry"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/logicrunner/goplugin/contracttest"
+	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
+	"github.com/insolar/insolar/testutils"
+)
+
+type registry struct {
+	foundation.BaseContract
+	Items OrderedMap
+	Tags  Set
+}
+
+func (r *registry) Put(key string, value uint) error {
+	return r.Items.Set(key, value)
+}
+
+func (r *registry) Get(key string) (uint, bool, error) {
+	var value uint
+	ok, err := r.Items.Get(key, &value)
+	return value, ok, err
+}
+
+func (r *registry) Remove(key string) (bool, error) {
+	return r.Items.Delete(key)
+}
+
+func (r *registry) Sum() ([]string, uint, error) {
+	var keys []string
+	var sum uint
+	it := r.Items.Iterator()
+	for it.HasNext() {
+		key, err := it.Next()
+		if err != nil {
+			return nil, 0, err
+		}
+		var value uint
+		err = it.Value(&value)
+		if err != nil {
+			return nil, 0, err
+		}
+		keys = append(keys, key)
+		sum += value
+	}
+	return keys, sum, nil
+}
+
+func (r *registry) Tag(tag string) (bool, error) {
+	return r.Tags.Add(tag)
+}
+
+func newHarness(t *testing.T) (*contracttest.Harness, core.RecordRef) {
+	h := contracttest.New()
+	h.Handle(entry.GetPrototype(), "New", func(call contracttest.Call) ([]interface{}, error) {
+		e, err := entrycontract.New(call.Args[0].(string), call.Args[1].([]byte))
+		return []interface{}{e}, err
+	})
+	forward := func(call contracttest.Call) ([]interface{}, error) {
+		return h.Call(call.Object, &entrycontract.Entry{}, call.Method, call.Args...)
+	}
+	for _, method := range []string{"GetValue", "SetValue", "Destroy"} {
+		h.Handle(entry.GetPrototype(), method, forward)
+	}
+
+	object, err := h.Deploy(&registry{}, testutils.RandomRef(), core.RecordRef{})
+	require.NoError(t, err)
+	return h, object
+}
+
+func TestOrderedMap(t *testing.T) {
+	h, object := newHarness(t)
+	defer h.Close()
+
+	var r registry
+	for i, key := range []string{"b", "a", "c"} {
+		_, err := h.Call(object, &r, "Put", key, uint(i+1))
+		require.NoError(t, err)
+	}
+	require.Equal(t, 3, r.Items.Len())
+	require.Len(t, h.CallsOf("New"), 3)
+
+	// update doesn't create new entry
+	_, err := h.Call(object, &r, "Put", "a", uint(10))
+	require.NoError(t, err)
+	require.Len(t, h.CallsOf("New"), 3)
+	require.Len(t, h.CallsOf("SetValue"), 1)
+
+	res, err := h.Call(object, &r, "Get", "a")
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{uint(10), true}, res)
+	res, err = h.Call(object, &r, "Get", "unknown")
+	require.NoError(t, err)
+	require.Equal(t, false, res[1])
+
+	// state of contract holds only references, values are loaded from entries
+	h.Reset()
+	res, err = h.Call(object, &r, "Sum")
+	require.NoError(t, err)
+	require.Equal(t, []string{"b", "a", "c"}, res[0])
+	require.Equal(t, uint(14), res[1])
+	require.Len(t, h.CallsOf("GetValue"), 3)
+
+	removed := r.Items.Entries["a"]
+	res, err = h.Call(object, &r, "Remove", "a")
+	require.NoError(t, err)
+	require.Equal(t, true, res[0])
+	require.True(t, h.Deactivated(removed))
+	require.Equal(t, []string{"b", "c"}, r.Items.Keys)
+	res, err = h.Call(object, &r, "Remove", "a")
+	require.NoError(t, err)
+	require.Equal(t, false, res[0])
+}
+
+func TestEntry_OnlyOwner(t *testing.T) {
+	h, object := newHarness(t)
+	defer h.Close()
+
+	var r registry
+	_, err := h.Call(object, &r, "Put", "a", uint(1))
+	require.NoError(t, err)
+
+	h.Caller = testutils.RandomRef()
+	_, err = h.Call(r.Items.Entries["a"], &entrycontract.Entry{}, "GetValue")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Only owner")
+}
+
+func TestSet(t *testing.T) {
+	h, object := newHarness(t)
+	defer h.Close()
+
+	var r registry
+	res, err := h.Call(object, &r, "Tag", "x")
+	require.NoError(t, err)
+	require.Equal(t, true, res[0])
+	res, err = h.Call(object, &r, "Tag", "x")
+	require.NoError(t, err)
+	require.Equal(t, false, res[0])
+	require.Equal(t, 1, r.Tags.Len())
+	require.True(t, r.Tags.Has("x"))
+}
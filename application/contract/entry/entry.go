@@ -0,0 +1,68 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package entry
+
+import (
+	"fmt"
+
+	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
+)
+
+// Entry is an element of collection from application/collection package. Entries are children of object
+// which owns collection, so value of entry is loaded only when the owner accesses it.
+type Entry struct {
+	foundation.BaseContract
+	Key   string
+	Value []byte
+}
+
+// New creates entry with serialized value
+func New(key string, value []byte) (*Entry, error) {
+	return &Entry{Key: key, Value: value}, nil
+}
+
+func (e *Entry) checkOwner(method string) error {
+	if *(e.GetContext().Caller) != *(e.GetContext().Parent) {
+		return fmt.Errorf("[ %s ] Only owner of collection can access its entries", method)
+	}
+	return nil
+}
+
+// GetValue returns serialized value of entry
+func (e *Entry) GetValue() ([]byte, error) {
+	if err := e.checkOwner("GetValue"); err != nil {
+		return nil, err
+	}
+	return e.Value, nil
+}
+
+// SetValue replaces serialized value of entry
+func (e *Entry) SetValue(value []byte) error {
+	if err := e.checkOwner("SetValue"); err != nil {
+		return err
+	}
+	e.Value = value
+	return nil
+}
+
+// Destroy removes entry from collection
+func (e *Entry) Destroy() error {
+	if err := e.checkOwner("Destroy"); err != nil {
+		return err
+	}
+	return e.SelfDestruct()
+}
@@ -18,9 +18,12 @@ package member
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/insolar/insolar/application/contract/member/signer"
+	"github.com/insolar/insolar/application/proxy/member"
 	"github.com/insolar/insolar/application/proxy/nodedomain"
+	"github.com/insolar/insolar/application/proxy/pendingtransfer"
 	"github.com/insolar/insolar/application/proxy/rootdomain"
 	"github.com/insolar/insolar/application/proxy/wallet"
 	"github.com/insolar/insolar/core"
@@ -31,6 +34,18 @@ type Member struct {
 	foundation.BaseContract
 	Name      string
 	PublicKey string
+	// ConfirmKey is an optional second key which signs confirmations of large transfers
+	ConfirmKey string
+	// SealedFields holds fields encrypted client-side by member, keyed by field name
+	SealedFields map[string]*foundation.SealedField
+	// MaxTransferAmount limits amount of a single transfer, zero means no limit
+	MaxTransferAmount uint
+	// MaxWindowAmount limits total amount of transfers within WindowPulses pulses, zero means no limit
+	MaxWindowAmount uint
+	WindowPulses    uint
+	// WindowStart is a pulse current spending window started on, WindowSpent is an amount transferred within it
+	WindowStart core.PulseNumber
+	WindowSpent uint
 }
 
 func (m *Member) GetName() (string, error) {
@@ -59,6 +74,10 @@ func (m *Member) verifySig(method string, params []byte, seed []byte, sign []byt
 	if err != nil {
 		return fmt.Errorf("[ verifySig ]: %s", err.Error())
 	}
+	// confirm key is changed only with confirm key, so primary key alone can't bypass confirmations
+	if (method == "ConfirmTransfer" || method == "SetConfirmKey") && m.ConfirmKey != "" {
+		key = m.ConfirmKey
+	}
 
 	publicKey, err := foundation.ImportPublicKey(key)
 	if err != nil {
@@ -72,6 +91,35 @@ func (m *Member) verifySig(method string, params []byte, seed []byte, sign []byt
 	return nil
 }
 
+// checkSeed checks that seed of signed request was issued by API node which accepted the request and isn't expired.
+// Seed of offline transaction is checked against its expiry pulse instead. Ledger registers request with the same
+// seed once, so the same signed request can't be executed twice via any node.
+func (m *Member) checkSeed(seed []byte) error {
+	ctx := m.GetContext()
+	current := ctx.Pulse.PulseNumber
+	delta := core.PulseNumber(1)
+	if ctx.Pulse.NextPulseNumber > current {
+		delta = ctx.Pulse.NextPulseNumber - current
+	}
+
+	if expiry, ok := core.TransactionSeedExpiry(seed); ok {
+		if expiry < current {
+			return fmt.Errorf("[ checkSeed ] Transaction is expired")
+		}
+		if expiry > current+core.TransactionMaxLifetime*delta {
+			return fmt.Errorf("[ checkSeed ] Transaction expiry is too far")
+		}
+	} else if ctx.APIRequest != nil {
+		if !core.APISeedIssuedBy(seed, ctx.APIRequest.APINode) {
+			return fmt.Errorf("[ checkSeed ] Seed is issued by another node")
+		}
+		if core.SignedNonceExpiry(seed, delta) < current {
+			return fmt.Errorf("[ checkSeed ] Seed is expired")
+		}
+	}
+	return nil
+}
+
 var INSATTR_Call_API = true
 
 // Call method for authorized calls
@@ -80,16 +128,31 @@ func (m *Member) Call(rootDomain core.RecordRef, method string, params []byte, s
 	if err := m.verifySig(method, params, seed, sign); err != nil {
 		return nil, fmt.Errorf("[ Call ]: %s", err.Error())
 	}
+	if err := m.checkSeed(seed); err != nil {
+		return nil, fmt.Errorf("[ Call ]: %s", err.Error())
+	}
 
 	switch method {
 	case "CreateMember":
 		return m.createMemberCall(rootDomain, params)
+	case "BulkCreateMembers":
+		return m.bulkCreateMembersCall(rootDomain, params)
 	case "GetMyBalance":
 		return m.getMyBalanceCall()
 	case "GetBalance":
-		return m.getBalanceCall(params)
+		return m.getBalanceCall(rootDomain, params)
 	case "Transfer":
-		return m.transferCall(params)
+		return m.transferCall(rootDomain, params)
+	case "ConfirmTransfer":
+		return m.confirmTransferCall(params)
+	case "GetTransferStatus":
+		return m.getTransferStatusCall(params)
+	case "SetConfirmKey":
+		return m.setConfirmKeyCall(params)
+	case "SetSpendingLimits":
+		return m.setSpendingLimitsCall(params)
+	case "GetSpendingLimits":
+		return m.getSpendingLimitsCall()
 	case "DumpUserInfo":
 		return m.dumpUserInfoCall(rootDomain, params)
 	case "DumpAllUsers":
@@ -98,6 +161,36 @@ func (m *Member) Call(rootDomain core.RecordRef, method string, params []byte, s
 		return m.registerNodeCall(rootDomain, params)
 	case "GetNodeRef":
 		return m.getNodeRefCall(rootDomain, params)
+	case "RegisterPrototype":
+		return m.registerPrototypeCall(rootDomain, params)
+	case "GetPrototypeByName":
+		return m.getPrototypeByNameCall(rootDomain, params)
+	case "ListPrototypes":
+		return m.listPrototypesCall(rootDomain)
+	case "SetNetworkParameter":
+		return m.setNetworkParameterCall(rootDomain, params)
+	case "GetNetworkParameters":
+		return m.getNetworkParametersCall(rootDomain)
+	case "SetMemberRole":
+		return m.setMemberRoleCall(rootDomain, params)
+	case "GetMemberRole":
+		return m.getMemberRoleCall(rootDomain, params)
+	case "RegisterAlias":
+		return m.registerAliasCall(rootDomain, params)
+	case "TransferAlias":
+		return m.transferAliasCall(rootDomain, params)
+	case "RemoveAlias":
+		return m.removeAliasCall(rootDomain, params)
+	case "ResolveAlias":
+		return m.resolveAliasCall(rootDomain, params)
+	case "SetSealedField":
+		return m.setSealedFieldCall(params)
+	case "GrantSealedField":
+		return m.grantSealedFieldCall(rootDomain, params)
+	case "RevokeSealedField":
+		return m.revokeSealedFieldCall(rootDomain, params)
+	case "GetSealedField":
+		return m.getSealedFieldCall(rootDomain, params)
 	}
 	return nil, &foundation.Error{S: "Unknown method"}
 }
@@ -112,6 +205,16 @@ func (m *Member) createMemberCall(ref core.RecordRef, params []byte) (interface{
 	return rootDomain.CreateMember(name, key)
 }
 
+func (m *Member) bulkCreateMembersCall(ref core.RecordRef, params []byte) (interface{}, error) {
+	rootDomain := rootdomain.GetObject(ref)
+	var names []string
+	var keys []string
+	if err := signer.UnmarshalParams(params, &names, &keys); err != nil {
+		return nil, fmt.Errorf("[ bulkCreateMembersCall ]: %s", err.Error())
+	}
+	return rootDomain.BulkCreateMembers(names, keys)
+}
+
 func (m *Member) getMyBalanceCall() (interface{}, error) {
 	w, err := wallet.GetImplementationFrom(m.GetReference())
 	if err != nil {
@@ -121,12 +224,12 @@ func (m *Member) getMyBalanceCall() (interface{}, error) {
 	return w.GetBalance()
 }
 
-func (m *Member) getBalanceCall(params []byte) (interface{}, error) {
+func (m *Member) getBalanceCall(ref core.RecordRef, params []byte) (interface{}, error) {
 	var member string
 	if err := signer.UnmarshalParams(params, &member); err != nil {
 		return nil, fmt.Errorf("[ getBalanceCall ] : %s", err.Error())
 	}
-	memberRef, err := core.NewRefFromBase58(member)
+	memberRef, err := resolveReference(ref, member)
 	if err != nil {
 		return nil, fmt.Errorf("[ getBalanceCall ] : %s", err.Error())
 	}
@@ -138,13 +241,13 @@ func (m *Member) getBalanceCall(params []byte) (interface{}, error) {
 	return w.GetBalance()
 }
 
-func (m *Member) transferCall(params []byte) (interface{}, error) {
+func (m *Member) transferCall(ref core.RecordRef, params []byte) (interface{}, error) {
 	var amount uint
 	var toStr string
 	if err := signer.UnmarshalParams(params, &amount, &toStr); err != nil {
 		return nil, fmt.Errorf("[ transferCall ] Can't unmarshal params: %s", err.Error())
 	}
-	to, err := core.NewRefFromBase58(toStr)
+	to, err := resolveReference(ref, toStr)
 	if err != nil {
 		return nil, fmt.Errorf("[ transferCall ] Failed to parse 'to' param: %s", err.Error())
 	}
@@ -156,7 +259,135 @@ func (m *Member) transferCall(params []byte) (interface{}, error) {
 		return nil, fmt.Errorf("[ transferCall ] Can't get implementation: %s", err.Error())
 	}
 
-	return nil, w.Transfer(amount, to)
+	threshold, pulses, err := rootdomain.GetObject(ref).GetLargeTransferPolicy()
+	if err != nil {
+		return nil, fmt.Errorf("[ transferCall ] Can't get large transfer policy: %s", err.Error())
+	}
+	if err := m.checkSpendingLimits(amount); err != nil {
+		return nil, fmt.Errorf("[ transferCall ] %s", err.Error())
+	}
+	if threshold == 0 || amount <= threshold {
+		if err := w.Transfer(amount, to); err != nil {
+			return nil, err
+		}
+		m.recordSpending(amount)
+		return nil, nil
+	}
+
+	expirePulse := m.GetContext().Pulse.PulseNumber + core.PulseNumber(pulses)
+	transferRef, err := w.TransferWithConfirmation(amount, to, expirePulse)
+	if err != nil {
+		return nil, err
+	}
+	m.recordSpending(amount)
+	return transferRef.String(), nil
+}
+
+func (m *Member) confirmTransferCall(params []byte) (interface{}, error) {
+	var transferStr string
+	if err := signer.UnmarshalParams(params, &transferStr); err != nil {
+		return nil, fmt.Errorf("[ confirmTransferCall ] Can't unmarshal params: %s", err.Error())
+	}
+	transferRef, err := core.NewRefFromBase58(transferStr)
+	if err != nil {
+		return nil, fmt.Errorf("[ confirmTransferCall ] Failed to parse transfer reference: %s", err.Error())
+	}
+	w, err := wallet.GetImplementationFrom(m.GetReference())
+	if err != nil {
+		return nil, fmt.Errorf("[ confirmTransferCall ] Can't get implementation: %s", err.Error())
+	}
+
+	return nil, w.ConfirmTransfer(transferRef)
+}
+
+func (m *Member) getTransferStatusCall(params []byte) (interface{}, error) {
+	var transferStr string
+	if err := signer.UnmarshalParams(params, &transferStr); err != nil {
+		return nil, fmt.Errorf("[ getTransferStatusCall ] Can't unmarshal params: %s", err.Error())
+	}
+	transferRef, err := core.NewRefFromBase58(transferStr)
+	if err != nil {
+		return nil, fmt.Errorf("[ getTransferStatusCall ] Failed to parse transfer reference: %s", err.Error())
+	}
+
+	return pendingtransfer.GetObject(*transferRef).GetStatus()
+}
+
+func (m *Member) setConfirmKeyCall(params []byte) (interface{}, error) {
+	var key string
+	if err := signer.UnmarshalParams(params, &key); err != nil {
+		return nil, fmt.Errorf("[ setConfirmKeyCall ] Can't unmarshal params: %s", err.Error())
+	}
+	if key != "" {
+		if _, err := foundation.ImportPublicKey(key); err != nil {
+			return nil, fmt.Errorf("[ setConfirmKeyCall ] Invalid public key")
+		}
+	}
+	m.ConfirmKey = key
+	return nil, nil
+}
+
+func (m *Member) setSpendingLimitsCall(params []byte) (interface{}, error) {
+	var maxTransfer, maxWindow, windowPulses uint
+	if err := signer.UnmarshalParams(params, &maxTransfer, &maxWindow, &windowPulses); err != nil {
+		return nil, fmt.Errorf("[ setSpendingLimitsCall ] Can't unmarshal params: %s", err.Error())
+	}
+	if maxWindow > 0 && windowPulses == 0 {
+		return nil, fmt.Errorf("[ setSpendingLimitsCall ] Window limit requires window length in pulses")
+	}
+	if windowPulses != m.WindowPulses {
+		m.WindowStart, m.WindowSpent = 0, 0
+	}
+	m.MaxTransferAmount = maxTransfer
+	m.MaxWindowAmount = maxWindow
+	m.WindowPulses = windowPulses
+	return nil, nil
+}
+
+func (m *Member) getSpendingLimitsCall() (interface{}, error) {
+	return map[string]uint{
+		"maxTransferAmount": m.MaxTransferAmount,
+		"maxWindowAmount":   m.MaxWindowAmount,
+		"windowPulses":      m.WindowPulses,
+		"windowSpent":       m.windowSpent(),
+	}, nil
+}
+
+// windowSpent returns amount transferred within current spending window, it's zero if window is over.
+func (m *Member) windowSpent() uint {
+	if m.WindowPulses == 0 || m.WindowStart+core.PulseNumber(m.WindowPulses) <= m.GetContext().Pulse.PulseNumber {
+		return 0
+	}
+	return m.WindowSpent
+}
+
+// checkSpendingLimits checks that transfer of amount doesn't exceed spending limits of member.
+func (m *Member) checkSpendingLimits(amount uint) error {
+	if m.MaxTransferAmount > 0 && amount > m.MaxTransferAmount {
+		return fmt.Errorf("Transfer amount %d exceeds limit %d", amount, m.MaxTransferAmount)
+	}
+	if m.MaxWindowAmount > 0 {
+		spent := m.windowSpent()
+		if amount > m.MaxWindowAmount || spent > m.MaxWindowAmount-amount {
+			return fmt.Errorf(
+				"Transfer amount %d exceeds limit %d per %d pulses, %d is already spent",
+				amount, m.MaxWindowAmount, m.WindowPulses, spent,
+			)
+		}
+	}
+	return nil
+}
+
+// recordSpending adds amount to current spending window, new window starts if previous one is over.
+func (m *Member) recordSpending(amount uint) {
+	if m.WindowPulses == 0 {
+		return
+	}
+	spent := m.windowSpent()
+	if spent == 0 {
+		m.WindowStart = m.GetContext().Pulse.PulseNumber
+	}
+	m.WindowSpent = spent + amount
 }
 
 func (m *Member) dumpUserInfoCall(ref core.RecordRef, params []byte) (interface{}, error) {
@@ -195,6 +426,125 @@ func (m *Member) registerNodeCall(ref core.RecordRef, params []byte) (interface{
 	return string(cert), nil
 }
 
+func (m *Member) registerPrototypeCall(ref core.RecordRef, params []byte) (interface{}, error) {
+	var name string
+	var prototype string
+	if err := signer.UnmarshalParams(params, &name, &prototype); err != nil {
+		return nil, fmt.Errorf("[ registerPrototypeCall ] Can't unmarshal params: %s", err.Error())
+	}
+	rootDomain := rootdomain.GetObject(ref)
+	return nil, rootDomain.RegisterPrototype(name, prototype)
+}
+
+func (m *Member) getPrototypeByNameCall(ref core.RecordRef, params []byte) (interface{}, error) {
+	var name string
+	if err := signer.UnmarshalParams(params, &name); err != nil {
+		return nil, fmt.Errorf("[ getPrototypeByNameCall ] Can't unmarshal params: %s", err.Error())
+	}
+	rootDomain := rootdomain.GetObject(ref)
+	return rootDomain.GetPrototypeByName(name)
+}
+
+func (m *Member) listPrototypesCall(ref core.RecordRef) (interface{}, error) {
+	rootDomain := rootdomain.GetObject(ref)
+	return rootDomain.ListPrototypes()
+}
+
+func (m *Member) setNetworkParameterCall(ref core.RecordRef, params []byte) (interface{}, error) {
+	var name string
+	var value string
+	if err := signer.UnmarshalParams(params, &name, &value); err != nil {
+		return nil, fmt.Errorf("[ setNetworkParameterCall ] Can't unmarshal params: %s", err.Error())
+	}
+	rootDomain := rootdomain.GetObject(ref)
+	return nil, rootDomain.SetNetworkParameter(name, value)
+}
+
+func (m *Member) getNetworkParametersCall(ref core.RecordRef) (interface{}, error) {
+	rootDomain := rootdomain.GetObject(ref)
+	return rootDomain.GetNetworkParameters()
+}
+
+func (m *Member) setMemberRoleCall(ref core.RecordRef, params []byte) (interface{}, error) {
+	var reference string
+	var role string
+	if err := signer.UnmarshalParams(params, &reference, &role); err != nil {
+		return nil, fmt.Errorf("[ setMemberRoleCall ] Can't unmarshal params: %s", err.Error())
+	}
+	rootDomain := rootdomain.GetObject(ref)
+	return nil, rootDomain.SetMemberRole(reference, role)
+}
+
+func (m *Member) getMemberRoleCall(ref core.RecordRef, params []byte) (interface{}, error) {
+	var reference string
+	if err := signer.UnmarshalParams(params, &reference); err != nil {
+		return nil, fmt.Errorf("[ getMemberRoleCall ] Can't unmarshal params: %s", err.Error())
+	}
+	rootDomain := rootdomain.GetObject(ref)
+	return rootDomain.GetMemberRole(reference)
+}
+
+func (m *Member) registerAliasCall(ref core.RecordRef, params []byte) (interface{}, error) {
+	var alias string
+	var object string
+	if err := signer.UnmarshalParams(params, &alias, &object); err != nil {
+		return nil, fmt.Errorf("[ registerAliasCall ] Can't unmarshal params: %s", err.Error())
+	}
+	objectRef, err := resolveReference(ref, object)
+	if err != nil {
+		return nil, fmt.Errorf("[ registerAliasCall ] Failed to parse 'object' param: %s", err.Error())
+	}
+	rootDomain := rootdomain.GetObject(ref)
+	return nil, rootDomain.RegisterAlias(strings.TrimPrefix(alias, aliasPrefix), objectRef.String())
+}
+
+func (m *Member) transferAliasCall(ref core.RecordRef, params []byte) (interface{}, error) {
+	var alias string
+	var owner string
+	if err := signer.UnmarshalParams(params, &alias, &owner); err != nil {
+		return nil, fmt.Errorf("[ transferAliasCall ] Can't unmarshal params: %s", err.Error())
+	}
+	ownerRef, err := resolveReference(ref, owner)
+	if err != nil {
+		return nil, fmt.Errorf("[ transferAliasCall ] Failed to parse 'owner' param: %s", err.Error())
+	}
+	rootDomain := rootdomain.GetObject(ref)
+	return nil, rootDomain.TransferAlias(strings.TrimPrefix(alias, aliasPrefix), ownerRef.String())
+}
+
+func (m *Member) removeAliasCall(ref core.RecordRef, params []byte) (interface{}, error) {
+	var alias string
+	if err := signer.UnmarshalParams(params, &alias); err != nil {
+		return nil, fmt.Errorf("[ removeAliasCall ] Can't unmarshal params: %s", err.Error())
+	}
+	rootDomain := rootdomain.GetObject(ref)
+	return nil, rootDomain.RemoveAlias(strings.TrimPrefix(alias, aliasPrefix))
+}
+
+func (m *Member) resolveAliasCall(ref core.RecordRef, params []byte) (interface{}, error) {
+	var alias string
+	if err := signer.UnmarshalParams(params, &alias); err != nil {
+		return nil, fmt.Errorf("[ resolveAliasCall ] Can't unmarshal params: %s", err.Error())
+	}
+	rootDomain := rootdomain.GetObject(ref)
+	return rootDomain.ResolveAlias(strings.TrimPrefix(alias, aliasPrefix))
+}
+
+// aliasPrefix marks reference params given as alias registered in root domain instead of base58 reference
+const aliasPrefix = "@"
+
+// resolveReference parses reference param, params starting with aliasPrefix are resolved through root domain
+func resolveReference(rootDomainRef core.RecordRef, param string) (*core.RecordRef, error) {
+	if !strings.HasPrefix(param, aliasPrefix) {
+		return core.NewRefFromBase58(param)
+	}
+	refStr, err := rootdomain.GetObject(rootDomainRef).ResolveAlias(strings.TrimPrefix(param, aliasPrefix))
+	if err != nil {
+		return nil, err
+	}
+	return core.NewRefFromBase58(refStr)
+}
+
 func (m *Member) getNodeRefCall(ref core.RecordRef, params []byte) (interface{}, error) {
 	var publicKey string
 	if err := signer.UnmarshalParams(params, &publicKey); err != nil {
@@ -215,3 +565,147 @@ func (m *Member) getNodeRefCall(ref core.RecordRef, params []byte) (interface{},
 
 	return nodeRef, nil
 }
+
+const (
+	// maxSealedFields is a number of sealed fields member can store
+	maxSealedFields = 100
+	// maxSealedFieldSize is a max size of sealed field ciphertext
+	maxSealedFieldSize = 64 << 10
+	// maxSealedFieldGrants is a number of members sealed field can be shared with, including owner
+	maxSealedFieldGrants = 100
+)
+
+// setSealedFieldCall stores field encrypted client-side. Ciphertext must be signed by member,
+// data key is sealed to member's own public key.
+func (m *Member) setSealedFieldCall(params []byte) (interface{}, error) {
+	var name string
+	var ciphertext []byte
+	var signature []byte
+	var sealedKey []byte
+	if err := signer.UnmarshalParams(params, &name, &ciphertext, &signature, &sealedKey); err != nil {
+		return nil, fmt.Errorf("[ setSealedFieldCall ] Can't unmarshal params: %s", err.Error())
+	}
+	if name == "" {
+		return nil, fmt.Errorf("[ setSealedFieldCall ] Field name is required")
+	}
+	if len(ciphertext) > maxSealedFieldSize {
+		return nil, fmt.Errorf("[ setSealedFieldCall ] Field is larger than %d bytes", maxSealedFieldSize)
+	}
+	if len(sealedKey) == 0 {
+		return nil, fmt.Errorf("[ setSealedFieldCall ] Sealed key is required")
+	}
+	if _, ok := m.SealedFields[name]; !ok && len(m.SealedFields) >= maxSealedFields {
+		return nil, fmt.Errorf("[ setSealedFieldCall ] Too many sealed fields")
+	}
+
+	field := &foundation.SealedField{
+		Ciphertext: ciphertext,
+		Digest:     foundation.SealedDigest(ciphertext),
+		Signature:  signature,
+		Keys:       map[string][]byte{m.GetReference().String(): sealedKey},
+	}
+	publicKey, err := foundation.ImportPublicKey(m.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("[ setSealedFieldCall ] Invalid public key")
+	}
+	if err := foundation.VerifySealedField(field, publicKey); err != nil {
+		return nil, fmt.Errorf("[ setSealedFieldCall ] %s", err.Error())
+	}
+
+	if m.SealedFields == nil {
+		m.SealedFields = map[string]*foundation.SealedField{}
+	}
+	m.SealedFields[name] = field
+	return nil, nil
+}
+
+// grantSealedFieldCall shares field with another member, data key is sealed to grantee's public key client-side.
+func (m *Member) grantSealedFieldCall(ref core.RecordRef, params []byte) (interface{}, error) {
+	var name string
+	var grantee string
+	var sealedKey []byte
+	if err := signer.UnmarshalParams(params, &name, &grantee, &sealedKey); err != nil {
+		return nil, fmt.Errorf("[ grantSealedFieldCall ] Can't unmarshal params: %s", err.Error())
+	}
+	field, ok := m.SealedFields[name]
+	if !ok {
+		return nil, fmt.Errorf("[ grantSealedFieldCall ] Unknown field %s", name)
+	}
+	granteeRef, err := resolveReference(ref, grantee)
+	if err != nil {
+		return nil, fmt.Errorf("[ grantSealedFieldCall ] Failed to parse 'grantee' param: %s", err.Error())
+	}
+	if len(sealedKey) == 0 {
+		return nil, fmt.Errorf("[ grantSealedFieldCall ] Sealed key is required")
+	}
+	if _, ok := field.Keys[granteeRef.String()]; !ok && len(field.Keys) >= maxSealedFieldGrants {
+		return nil, fmt.Errorf("[ grantSealedFieldCall ] Too many grants")
+	}
+
+	field.Keys[granteeRef.String()] = sealedKey
+	return nil, nil
+}
+
+// revokeSealedFieldCall stops sharing field with member. Member may still have copy of data it already read.
+func (m *Member) revokeSealedFieldCall(ref core.RecordRef, params []byte) (interface{}, error) {
+	var name string
+	var grantee string
+	if err := signer.UnmarshalParams(params, &name, &grantee); err != nil {
+		return nil, fmt.Errorf("[ revokeSealedFieldCall ] Can't unmarshal params: %s", err.Error())
+	}
+	field, ok := m.SealedFields[name]
+	if !ok {
+		return nil, fmt.Errorf("[ revokeSealedFieldCall ] Unknown field %s", name)
+	}
+	granteeRef, err := resolveReference(ref, grantee)
+	if err != nil {
+		return nil, fmt.Errorf("[ revokeSealedFieldCall ] Failed to parse 'grantee' param: %s", err.Error())
+	}
+	if *granteeRef == m.GetReference() {
+		return nil, fmt.Errorf("[ revokeSealedFieldCall ] Owner's access can't be revoked")
+	}
+	if _, ok := field.Keys[granteeRef.String()]; !ok {
+		return nil, fmt.Errorf("[ revokeSealedFieldCall ] Access is not granted to %s", granteeRef)
+	}
+
+	delete(field.Keys, granteeRef.String())
+	return nil, nil
+}
+
+// getSealedFieldCall returns field of member or field other member shared with it, with the only data key
+// sealed to member.
+func (m *Member) getSealedFieldCall(ref core.RecordRef, params []byte) (interface{}, error) {
+	var owner string
+	var name string
+	if err := signer.UnmarshalParams(params, &owner, &name); err != nil {
+		return nil, fmt.Errorf("[ getSealedFieldCall ] Can't unmarshal params: %s", err.Error())
+	}
+	if owner == "" {
+		return m.sealedFieldFor(name, m.GetReference())
+	}
+	ownerRef, err := resolveReference(ref, owner)
+	if err != nil {
+		return nil, fmt.Errorf("[ getSealedFieldCall ] Failed to parse 'owner' param: %s", err.Error())
+	}
+	if *ownerRef == m.GetReference() {
+		return m.sealedFieldFor(name, m.GetReference())
+	}
+	return member.GetObject(*ownerRef).GetSealedField(name)
+}
+
+// GetSealedField returns field to member which calls it if owner granted access to it.
+func (m *Member) GetSealedField(name string) (*foundation.SealedField, error) {
+	caller := m.GetContext().Caller
+	if caller == nil {
+		return nil, fmt.Errorf("[ GetSealedField ] Unknown caller")
+	}
+	return m.sealedFieldFor(name, *caller)
+}
+
+func (m *Member) sealedFieldFor(name string, reader core.RecordRef) (*foundation.SealedField, error) {
+	field, ok := m.SealedFields[name]
+	if !ok {
+		return nil, fmt.Errorf("[ sealedFieldFor ] Unknown field %s", name)
+	}
+	return field.SealFor(reader)
+}
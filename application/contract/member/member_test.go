@@ -0,0 +1,231 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package member
+
+import (
+	"crypto"
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/require"
+	"github.com/tylerb/gls"
+)
+
+func TestMember_checkSeed(t *testing.T) {
+	defer gls.Cleanup()
+	apiNode := testutils.RandomRef()
+	callCtx := &core.LogicCallContext{
+		Pulse: core.Pulse{
+			PulseNumber:     core.FirstPulseNumber + 100,
+			NextPulseNumber: core.FirstPulseNumber + 110,
+		},
+		APIRequest: &core.APIRequest{APINode: apiNode},
+	}
+	gls.Set("callCtx", callCtx)
+
+	newSeed := func(pulse core.PulseNumber, node core.RecordRef) []byte {
+		random := testutils.RandomRef()
+		seed := random[:core.APISeedSize]
+		core.BindAPISeed(seed, pulse, node)
+		return seed
+	}
+
+	m := &Member{}
+	seed := newSeed(core.FirstPulseNumber+90, apiNode)
+	require.NoError(t, m.checkSeed(seed))
+	require.NoError(t, m.checkSeed(seed), "replayed seeds are rejected by ledger, member keeps no state")
+
+	err := m.checkSeed(newSeed(core.FirstPulseNumber+100, testutils.RandomRef()))
+	require.EqualError(t, err, "[ checkSeed ] Seed is issued by another node")
+
+	err = m.checkSeed(newSeed(core.FirstPulseNumber-10, apiNode))
+	require.EqualError(t, err, "[ checkSeed ] Seed is expired")
+}
+
+func TestMember_checkSeed_Transaction(t *testing.T) {
+	defer gls.Cleanup()
+	callCtx := &core.LogicCallContext{
+		Pulse: core.Pulse{
+			PulseNumber:     core.FirstPulseNumber + 100,
+			NextPulseNumber: core.FirstPulseNumber + 110,
+		},
+		APIRequest: &core.APIRequest{APINode: testutils.RandomRef()},
+	}
+	gls.Set("callCtx", callCtx)
+
+	m := &Member{}
+	tx := core.Transaction{Nonce: 1, Expiry: core.FirstPulseNumber + 200}
+	require.NoError(t, m.checkSeed(tx.Seed()))
+
+	expired := core.Transaction{Nonce: 2, Expiry: core.FirstPulseNumber + 90}
+	require.EqualError(t, m.checkSeed(expired.Seed()), "[ checkSeed ] Transaction is expired")
+
+	tooFar := core.Transaction{Nonce: 3, Expiry: core.FirstPulseNumber + 100 + (core.TransactionMaxLifetime+1)*10}
+	require.EqualError(t, m.checkSeed(tooFar.Seed()), "[ checkSeed ] Transaction expiry is too far")
+}
+
+func TestMember_SealedFields(t *testing.T) {
+	defer gls.Cleanup()
+	owner := testutils.RandomRef()
+	grantee := testutils.RandomRef()
+	stranger := testutils.RandomRef()
+	callCtx := &core.LogicCallContext{Callee: &owner}
+	gls.Set("callCtx", callCtx)
+
+	ownerKey, err := foundation.GeneratePrivateKey()
+	require.NoError(t, err)
+	publicKey, err := foundation.ExportPublicKey(foundation.ExtractPublicKey(ownerKey))
+	require.NoError(t, err)
+	granteeKey, err := foundation.GeneratePrivateKey()
+	require.NoError(t, err)
+	m := &Member{PublicKey: publicKey}
+
+	params := func(args ...interface{}) []byte {
+		data, err := core.Serialize(args)
+		require.NoError(t, err)
+		return data
+	}
+
+	payload := []byte("passport number")
+	field, err := foundation.SealField(payload, owner, ownerKey)
+	require.NoError(t, err)
+	require.NotContains(t, string(field.Ciphertext), string(payload))
+
+	_, err = m.setSealedFieldCall(params("passport", field.Ciphertext, field.Signature[1:], field.Keys[owner.String()]))
+	require.EqualError(t, err, "[ setSealedFieldCall ] [ VerifySealedField ] Incorrect signature")
+	_, err = m.setSealedFieldCall(params("passport", field.Ciphertext, field.Signature, field.Keys[owner.String()]))
+	require.NoError(t, err)
+
+	own, err := m.getSealedFieldCall(core.RecordRef{}, params("", "passport"))
+	require.NoError(t, err)
+	opened, err := foundation.OpenSealedField(own.(*foundation.SealedField), owner, ownerKey)
+	require.NoError(t, err)
+	require.Equal(t, payload, opened)
+
+	// grantee can't read field until owner shares data key with it
+	callCtx.Caller = &grantee
+	_, err = m.GetSealedField("passport")
+	require.Error(t, err)
+
+	sealedKey, err := foundation.SealKeyFor(field, owner, ownerKey, foundation.ExtractPublicKey(granteeKey))
+	require.NoError(t, err)
+	_, err = m.grantSealedFieldCall(core.RecordRef{}, params("passport", grantee.String(), sealedKey))
+	require.NoError(t, err)
+
+	shared, err := m.GetSealedField("passport")
+	require.NoError(t, err)
+	require.Len(t, shared.Keys, 1)
+	opened, err = foundation.OpenSealedField(shared, grantee, granteeKey)
+	require.NoError(t, err)
+	require.Equal(t, payload, opened)
+
+	callCtx.Caller = &stranger
+	_, err = m.GetSealedField("passport")
+	require.Error(t, err)
+
+	_, err = m.revokeSealedFieldCall(core.RecordRef{}, params("passport", owner.String()))
+	require.EqualError(t, err, "[ revokeSealedFieldCall ] Owner's access can't be revoked")
+	_, err = m.revokeSealedFieldCall(core.RecordRef{}, params("passport", grantee.String()))
+	require.NoError(t, err)
+	callCtx.Caller = &grantee
+	_, err = m.GetSealedField("passport")
+	require.Error(t, err)
+}
+
+func TestMember_SpendingLimits(t *testing.T) {
+	defer gls.Cleanup()
+	callCtx := &core.LogicCallContext{Pulse: core.Pulse{PulseNumber: core.FirstPulseNumber}}
+	gls.Set("callCtx", callCtx)
+
+	params := func(args ...interface{}) []byte {
+		data, err := core.Serialize(args)
+		require.NoError(t, err)
+		return data
+	}
+
+	m := &Member{}
+	require.NoError(t, m.checkSpendingLimits(1000000), "no limits by default")
+
+	_, err := m.setSpendingLimitsCall(params(uint(0), uint(150), uint(0)))
+	require.EqualError(t, err, "[ setSpendingLimitsCall ] Window limit requires window length in pulses")
+	_, err = m.setSpendingLimitsCall(params(uint(100), uint(150), uint(10)))
+	require.NoError(t, err)
+
+	require.EqualError(t, m.checkSpendingLimits(101), "Transfer amount 101 exceeds limit 100")
+	require.NoError(t, m.checkSpendingLimits(100))
+	m.recordSpending(100)
+
+	callCtx.Pulse.PulseNumber += 5
+	err = m.checkSpendingLimits(60)
+	require.EqualError(t, err, "Transfer amount 60 exceeds limit 150 per 10 pulses, 100 is already spent")
+	require.NoError(t, m.checkSpendingLimits(50))
+	m.recordSpending(50)
+
+	limits, err := m.getSpendingLimitsCall()
+	require.NoError(t, err)
+	require.Equal(t, map[string]uint{
+		"maxTransferAmount": 100, "maxWindowAmount": 150, "windowPulses": 10, "windowSpent": 150,
+	}, limits)
+
+	// new window starts when previous one is over
+	callCtx.Pulse.PulseNumber += 5
+	require.NoError(t, m.checkSpendingLimits(100))
+	m.recordSpending(100)
+	require.Equal(t, uint(100), m.windowSpent())
+	require.Equal(t, callCtx.Pulse.PulseNumber, m.WindowStart)
+}
+
+func TestMember_SetConfirmKey_RequiresConfirmKey(t *testing.T) {
+	defer gls.Cleanup()
+	callee := testutils.RandomRef()
+	gls.Set("callCtx", &core.LogicCallContext{Callee: &callee})
+
+	newKey := func() (crypto.PrivateKey, string) {
+		private, err := foundation.GeneratePrivateKey()
+		require.NoError(t, err)
+		public, err := foundation.ExportPublicKey(foundation.ExtractPublicKey(private))
+		require.NoError(t, err)
+		return private, public
+	}
+	sign := func(key crypto.PrivateKey, method string, params []byte, seed []byte) []byte {
+		args, err := core.MarshalArgs(callee, method, params, seed)
+		require.NoError(t, err)
+		signature, err := foundation.Sign(args, key)
+		require.NoError(t, err)
+		return signature
+	}
+	primary, primaryPublic := newKey()
+	confirm, confirmPublic := newKey()
+	_, otherPublic := newKey()
+	m := &Member{PublicKey: primaryPublic}
+
+	params, err := core.Serialize([]interface{}{confirmPublic})
+	require.NoError(t, err)
+	seed := []byte("seed")
+	require.NoError(t, m.verifySig("SetConfirmKey", params, seed, sign(primary, "SetConfirmKey", params, seed)))
+	_, err = m.setConfirmKeyCall(params)
+	require.NoError(t, err)
+
+	// rotation signed with primary key only is rejected once confirm key is set
+	params, err = core.Serialize([]interface{}{otherPublic})
+	require.NoError(t, err)
+	err = m.verifySig("SetConfirmKey", params, seed, sign(primary, "SetConfirmKey", params, seed))
+	require.EqualError(t, err, "[ verifySig ] Incorrect signature")
+	require.NoError(t, m.verifySig("SetConfirmKey", params, seed, sign(confirm, "SetConfirmKey", params, seed)))
+}
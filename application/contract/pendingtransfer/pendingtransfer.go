@@ -0,0 +1,96 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package pendingtransfer
+
+import (
+	"fmt"
+
+	"github.com/insolar/insolar/application/proxy/wallet"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
+)
+
+// States of pending transfer
+const (
+	StatePending   = "pending"
+	StateConfirmed = "confirmed"
+	StateExpired   = "expired"
+)
+
+// PendingTransfer is a large transfer which waits for confirmation of the sender.
+// Amount is held by the sender's wallet until transfer is confirmed or expired.
+type PendingTransfer struct {
+	foundation.BaseContract
+	To          core.RecordRef
+	Amount      uint
+	ExpirePulse core.PulseNumber
+	State       string
+}
+
+func (pt *PendingTransfer) isExpired() bool {
+	return pt.GetContext().Pulse.PulseNumber > pt.ExpirePulse
+}
+
+func (pt *PendingTransfer) checkOwner(method string) error {
+	if *(pt.GetContext().Caller) != *(pt.GetContext().Parent) {
+		return fmt.Errorf("[ %s ] Only owner wallet can manage pending transfer", method)
+	}
+	return nil
+}
+
+// Confirm moves transfer to confirmed state and returns recipient and amount to send
+func (pt *PendingTransfer) Confirm() (core.RecordRef, uint, error) {
+	if err := pt.checkOwner("Confirm"); err != nil {
+		return core.RecordRef{}, 0, err
+	}
+	if pt.State != StatePending {
+		return core.RecordRef{}, 0, fmt.Errorf("[ Confirm ] Transfer is already %s", pt.State)
+	}
+	if pt.isExpired() {
+		return core.RecordRef{}, 0, fmt.Errorf("[ Confirm ] Transfer expired at pulse %d", pt.ExpirePulse)
+	}
+	pt.State = StateConfirmed
+	return pt.To, pt.Amount, nil
+}
+
+// GetExpiredAmount moves expired transfer to expired state and returns held amount to the owner
+func (pt *PendingTransfer) GetExpiredAmount() (uint, error) {
+	if err := pt.checkOwner("GetExpiredAmount"); err != nil {
+		return 0, err
+	}
+	if pt.State != StatePending || !pt.isExpired() {
+		return 0, nil
+	}
+	pt.State = StateExpired
+	return pt.Amount, nil
+}
+
+// GetStatus returns state of transfer, pending transfer is reported as expired after expiration pulse
+func (pt *PendingTransfer) GetStatus() (string, error) {
+	if pt.State == StatePending && pt.isExpired() {
+		return StateExpired, nil
+	}
+	return pt.State, nil
+}
+
+// New checks that caller is wallet and makes new pending transfer
+func New(to *core.RecordRef, amount uint, expirePulse core.PulseNumber) (*PendingTransfer, error) {
+	if !wallet.PrototypeReference.Equal(*foundation.GetContext().CallerPrototype) {
+		return nil, fmt.Errorf("[ New PendingTransfer ] : Can't create pending transfer from not wallet contract")
+	}
+	return &PendingTransfer{To: *to, Amount: amount, ExpirePulse: expirePulse, State: StatePending}, nil
+}
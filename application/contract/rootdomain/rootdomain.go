@@ -19,6 +19,8 @@ package rootdomain
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/insolar/insolar/application/proxy/member"
 	"github.com/insolar/insolar/application/proxy/wallet"
@@ -31,23 +33,81 @@ type RootDomain struct {
 	foundation.BaseContract
 	RootMember    core.RecordRef
 	NodeDomainRef core.RecordRef
+	// Prototypes is a registry of prototypes by human-readable names
+	Prototypes map[string]core.RecordRef
+	// LargeTransferThreshold is an amount above which transfer requires confirmation, zero disables confirmation
+	LargeTransferThreshold uint
+	// LargeTransferPulses is a number of pulses to confirm large transfer
+	LargeTransferPulses uint
+	// NetworkParameters are network-wide parameters by names, nodes apply them on pulse boundaries
+	NetworkParameters map[string]string
+	// MemberRoles is a registry of privileged API roles of members by references, other members have member role
+	MemberRoles map[string]string
+	// Aliases is a registry of objects by human-readable aliases
+	Aliases map[string]core.RecordRef
+	// AliasOwners holds members which registered aliases, only owner can change or transfer alias
+	AliasOwners map[string]core.RecordRef
 }
 
+// maxBulkMembers is a maximum number of members created by one BulkCreateMembers request
+const maxBulkMembers = 1000
+
+// minAliasLength and maxAliasLength are bounds of alias length
+const (
+	minAliasLength = 3
+	maxAliasLength = 64
+)
+
 // CreateMember processes create member request
 func (rd *RootDomain) CreateMember(name string, key string) (string, error) {
 	if *rd.GetContext().Caller != rd.RootMember {
 		return "", fmt.Errorf("[ CreateMember ] Only Root member can create members")
 	}
+	ref, err := rd.createMember(name, key)
+	if err != nil {
+		return "", fmt.Errorf("[ CreateMember ] %s", err.Error())
+	}
+	return ref, nil
+}
+
+// BulkCreateMembers processes create members request, names and keys are matched by index.
+// Result is a list with reference or error for every requested member.
+func (rd *RootDomain) BulkCreateMembers(names []string, keys []string) ([]byte, error) {
+	if *rd.GetContext().Caller != rd.RootMember {
+		return nil, fmt.Errorf("[ BulkCreateMembers ] Only Root member can create members")
+	}
+	if len(names) != len(keys) {
+		return nil, fmt.Errorf("[ BulkCreateMembers ] Got %d names and %d keys", len(names), len(keys))
+	}
+	if len(names) > maxBulkMembers {
+		return nil, fmt.Errorf("[ BulkCreateMembers ] Can't create more than %d members at once", maxBulkMembers)
+	}
+
+	res := make([]map[string]string, 0, len(names))
+	for i, name := range names {
+		item := map[string]string{"name": name}
+		ref, err := rd.createMember(name, keys[i])
+		if err != nil {
+			item["error"] = err.Error()
+		} else {
+			item["reference"] = ref
+		}
+		res = append(res, item)
+	}
+	return json.Marshal(res)
+}
+
+func (rd *RootDomain) createMember(name string, key string) (string, error) {
 	memberHolder := member.New(name, key)
 	m, err := memberHolder.AsChild(rd.GetReference())
 	if err != nil {
-		return "", fmt.Errorf("[ CreateMember ] Can't save as child: %s", err.Error())
+		return "", fmt.Errorf("Can't save as child: %s", err.Error())
 	}
 
 	wHolder := wallet.New(1000 * 1000 * 1000)
 	_, err = wHolder.AsDelegate(m.GetReference())
 	if err != nil {
-		return "", fmt.Errorf("[ CreateMember ] Can't save as delegate: %s", err.Error())
+		return "", fmt.Errorf("Can't save as delegate: %s", err.Error())
 	}
 
 	return m.GetReference().String(), nil
@@ -86,7 +146,7 @@ func (rd *RootDomain) DumpUserInfo(reference string) ([]byte, error) {
 	if err != nil {
 		return nil, fmt.Errorf("[ DumpUserInfo ] Failed to parse reference: %s", err.Error())
 	}
-	if *ref != caller && caller != rd.RootMember {
+	if *ref != caller && rd.roleOf(caller) < core.APIRoleOperator {
 		return nil, fmt.Errorf("[ DumpUserInfo ] You can dump only yourself")
 	}
 	m := member.GetObject(*ref)
@@ -101,8 +161,8 @@ func (rd *RootDomain) DumpUserInfo(reference string) ([]byte, error) {
 
 // DumpAllUsers processes dump all users request
 func (rd *RootDomain) DumpAllUsers() ([]byte, error) {
-	if *rd.GetContext().Caller != rd.RootMember {
-		return nil, fmt.Errorf("[ DumpAllUsers ] Only root can call this method")
+	if rd.roleOf(*rd.GetContext().Caller) < core.APIRoleOperator {
+		return nil, fmt.Errorf("[ DumpAllUsers ] Only root or operator can call this method")
 	}
 	res := []map[string]interface{}{}
 	iterator, err := rd.NewChildrenTypedIterator(member.GetPrototype())
@@ -145,11 +205,246 @@ func (rd *RootDomain) Info() (interface{}, error) {
 	return resJSON, nil
 }
 
+// GetLargeTransferPolicy returns threshold above which transfer requires confirmation and number of pulses to confirm it
+func (rd *RootDomain) GetLargeTransferPolicy() (uint, uint, error) {
+	return rd.LargeTransferThreshold, rd.LargeTransferPulses, nil
+}
+
 // GetNodeDomainRef returns reference of NodeDomain instance
 func (rd *RootDomain) GetNodeDomainRef() (core.RecordRef, error) {
 	return rd.NodeDomainRef, nil
 }
 
+// RegisterPrototype adds prototype to registry under provided name, existing name is overwritten
+func (rd *RootDomain) RegisterPrototype(name string, prototype string) error {
+	if *rd.GetContext().Caller != rd.RootMember {
+		return fmt.Errorf("[ RegisterPrototype ] Only Root member can register prototypes")
+	}
+	if name == "" {
+		return fmt.Errorf("[ RegisterPrototype ] Name must not be empty")
+	}
+	ref, err := core.NewRefFromBase58(prototype)
+	if err != nil {
+		return fmt.Errorf("[ RegisterPrototype ] Failed to parse prototype reference: %s", err.Error())
+	}
+	if rd.Prototypes == nil {
+		rd.Prototypes = map[string]core.RecordRef{}
+	}
+	rd.Prototypes[name] = *ref
+	return nil
+}
+
+// GetPrototypeByName returns reference of prototype registered under provided name
+func (rd *RootDomain) GetPrototypeByName(name string) (string, error) {
+	ref, ok := rd.Prototypes[name]
+	if !ok {
+		return "", fmt.Errorf("[ GetPrototypeByName ] Prototype %s is not registered", name)
+	}
+	return ref.String(), nil
+}
+
+// ListPrototypes returns all registered prototypes by names
+func (rd *RootDomain) ListPrototypes() (map[string]string, error) {
+	res := make(map[string]string, len(rd.Prototypes))
+	for name, ref := range rd.Prototypes {
+		res[name] = ref.String()
+	}
+	return res, nil
+}
+
+// SetNetworkParameter sets network-wide parameter, empty value removes it so nodes use their local configuration
+func (rd *RootDomain) SetNetworkParameter(name string, value string) error {
+	if *rd.GetContext().Caller != rd.RootMember {
+		return fmt.Errorf("[ SetNetworkParameter ] Only Root member can set network parameters")
+	}
+	if value == "" {
+		delete(rd.NetworkParameters, name)
+		return nil
+	}
+	if err := validateNetworkParameter(name, value); err != nil {
+		return fmt.Errorf("[ SetNetworkParameter ] %s", err.Error())
+	}
+	if rd.NetworkParameters == nil {
+		rd.NetworkParameters = map[string]string{}
+	}
+	rd.NetworkParameters[name] = value
+	return nil
+}
+
+func validateNetworkParameter(name string, value string) error {
+	switch name {
+	case core.NetworkParameterMaxQueueLength:
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return fmt.Errorf("%s must be non-negative integer", name)
+		}
+	case core.NetworkParameterWriteQuota:
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return fmt.Errorf("%s must be non-negative integer", name)
+		}
+	case core.NetworkParameterCallTimeout:
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("%s must be positive duration", name)
+		}
+	case core.NetworkParameterEmergencyPause:
+		// any reason pauses the network, empty value lifts the pause
+	case core.NetworkParameterFeeSchedule:
+		if !json.Valid([]byte(value)) {
+			return fmt.Errorf("%s must be valid JSON", name)
+		}
+	default:
+		return fmt.Errorf("Unknown network parameter %s", name)
+	}
+	return nil
+}
+
+// GetNetworkParameters returns all network-wide parameters by names
+func (rd *RootDomain) GetNetworkParameters() (map[string]string, error) {
+	res := make(map[string]string, len(rd.NetworkParameters))
+	for name, value := range rd.NetworkParameters {
+		res[name] = value
+	}
+	return res, nil
+}
+
+// SetMemberRole grants API role to member, member role revokes privileged role
+func (rd *RootDomain) SetMemberRole(reference string, role string) error {
+	if *rd.GetContext().Caller != rd.RootMember {
+		return fmt.Errorf("[ SetMemberRole ] Only Root member can set roles")
+	}
+	ref, err := core.NewRefFromBase58(reference)
+	if err != nil {
+		return fmt.Errorf("[ SetMemberRole ] Failed to parse reference: %s", err.Error())
+	}
+	if *ref == rd.RootMember {
+		return fmt.Errorf("[ SetMemberRole ] Role of Root member can't be changed")
+	}
+	r, err := core.ParseAPIRole(role)
+	if err != nil {
+		return fmt.Errorf("[ SetMemberRole ] %s", err.Error())
+	}
+	switch r {
+	case core.APIRoleMember:
+		delete(rd.MemberRoles, ref.String())
+	case core.APIRoleOperator:
+		if rd.MemberRoles == nil {
+			rd.MemberRoles = map[string]string{}
+		}
+		rd.MemberRoles[ref.String()] = r.String()
+	default:
+		return fmt.Errorf("[ SetMemberRole ] Role %s can't be granted", r)
+	}
+	return nil
+}
+
+// GetMemberRole returns API role of member
+func (rd *RootDomain) GetMemberRole(reference string) (string, error) {
+	ref, err := core.NewRefFromBase58(reference)
+	if err != nil {
+		return "", fmt.Errorf("[ GetMemberRole ] Failed to parse reference: %s", err.Error())
+	}
+	return rd.roleOf(*ref).String(), nil
+}
+
+func (rd *RootDomain) roleOf(ref core.RecordRef) core.APIRole {
+	if ref == rd.RootMember {
+		return core.APIRoleRoot
+	}
+	if role, err := core.ParseAPIRole(rd.MemberRoles[ref.String()]); err == nil {
+		return role
+	}
+	return core.APIRoleMember
+}
+
+// RegisterAlias registers alias of object owned by caller, alias owned by caller is pointed to new object
+func (rd *RootDomain) RegisterAlias(alias string, object string) error {
+	if err := validateAlias(alias); err != nil {
+		return fmt.Errorf("[ RegisterAlias ] %s", err.Error())
+	}
+	ref, err := core.NewRefFromBase58(object)
+	if err != nil {
+		return fmt.Errorf("[ RegisterAlias ] Failed to parse object reference: %s", err.Error())
+	}
+	caller := *rd.GetContext().Caller
+	if owner, ok := rd.AliasOwners[alias]; ok && owner != caller {
+		return fmt.Errorf("[ RegisterAlias ] Alias %s is owned by another member", alias)
+	}
+	if rd.Aliases == nil {
+		rd.Aliases = map[string]core.RecordRef{}
+		rd.AliasOwners = map[string]core.RecordRef{}
+	}
+	rd.Aliases[alias] = *ref
+	rd.AliasOwners[alias] = caller
+	return nil
+}
+
+// TransferAlias passes ownership of alias to another member, only owner can transfer alias
+func (rd *RootDomain) TransferAlias(alias string, owner string) error {
+	current, ok := rd.AliasOwners[alias]
+	if !ok {
+		return fmt.Errorf("[ TransferAlias ] Alias %s is not registered", alias)
+	}
+	if current != *rd.GetContext().Caller {
+		return fmt.Errorf("[ TransferAlias ] Only owner can transfer alias %s", alias)
+	}
+	ref, err := core.NewRefFromBase58(owner)
+	if err != nil {
+		return fmt.Errorf("[ TransferAlias ] Failed to parse owner reference: %s", err.Error())
+	}
+	rd.AliasOwners[alias] = *ref
+	return nil
+}
+
+// RemoveAlias removes alias, alias can be removed by its owner or Root member
+func (rd *RootDomain) RemoveAlias(alias string) error {
+	owner, ok := rd.AliasOwners[alias]
+	if !ok {
+		return fmt.Errorf("[ RemoveAlias ] Alias %s is not registered", alias)
+	}
+	caller := *rd.GetContext().Caller
+	if caller != owner && caller != rd.RootMember {
+		return fmt.Errorf("[ RemoveAlias ] Only owner or Root member can remove alias %s", alias)
+	}
+	delete(rd.Aliases, alias)
+	delete(rd.AliasOwners, alias)
+	return nil
+}
+
+// ResolveAlias returns reference of object registered under alias
+func (rd *RootDomain) ResolveAlias(alias string) (string, error) {
+	ref, ok := rd.Aliases[alias]
+	if !ok {
+		return "", fmt.Errorf("[ ResolveAlias ] Alias %s is not registered", alias)
+	}
+	return ref.String(), nil
+}
+
+// GetAliasOwner returns reference of member owning alias
+func (rd *RootDomain) GetAliasOwner(alias string) (string, error) {
+	owner, ok := rd.AliasOwners[alias]
+	if !ok {
+		return "", fmt.Errorf("[ GetAliasOwner ] Alias %s is not registered", alias)
+	}
+	return owner.String(), nil
+}
+
+// validateAlias checks that alias consists of lower case letters, digits, dots, dashes and underscores
+func validateAlias(alias string) error {
+	if len(alias) < minAliasLength || len(alias) > maxAliasLength {
+		return fmt.Errorf("Alias length must be from %d to %d", minAliasLength, maxAliasLength)
+	}
+	for _, c := range alias {
+		switch {
+		case c >= 'a' && c <= 'z', c >= '0' && c <= '9', c == '.', c == '-', c == '_':
+		default:
+			return fmt.Errorf("Alias may contain only lower case letters, digits, dots, dashes and underscores")
+		}
+	}
+	return nil
+}
+
 // NewRootDomain creates new RootDomain
 func NewRootDomain() (*RootDomain, error) {
 	return &RootDomain{}, nil
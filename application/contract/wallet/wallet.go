@@ -21,6 +21,7 @@ import (
 
 	"github.com/insolar/insolar/application/contract/wallet/safemath"
 	"github.com/insolar/insolar/application/proxy/allowance"
+	"github.com/insolar/insolar/application/proxy/pendingtransfer"
 	"github.com/insolar/insolar/application/proxy/wallet"
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
@@ -34,31 +35,76 @@ type Wallet struct {
 
 // Transfer transfers money to given wallet
 func (w *Wallet) Transfer(amount uint, to *core.RecordRef) error {
+	if err := w.RequireValidation(); err != nil {
+		return fmt.Errorf("[ Transfer ] Can't require validation: %s", err.Error())
+	}
 
 	toWallet, err := wallet.GetImplementationFrom(*to)
 	if err != nil {
 		return fmt.Errorf("[ Transfer ] Can't get implementation: %s", err.Error())
 	}
 
-	toWalletRef := toWallet.GetReference()
-
 	newBalance, err := safemath.Sub(w.Balance, amount)
 	if err != nil {
 		return fmt.Errorf("[ Transfer ] Not enough balance for transfer: %s", err.Error())
 	}
 
-	ah := allowance.New(&toWalletRef, amount, w.GetContext().Time.Unix()+10)
-	a, err := ah.AsChild(w.GetReference())
-	if err != nil {
-		return fmt.Errorf("[ Transfer ] Can't save as child: %s", err.Error())
+	if err := w.sendAllowance(amount, toWallet); err != nil {
+		return fmt.Errorf("[ Transfer ] %s", err.Error())
 	}
 
 	// Changing balance only after allowance was successfully create
 	w.Balance = newBalance
+	return nil
+}
+
+// TransferWithConfirmation holds money for transfer to given wallet until it is confirmed by ConfirmTransfer.
+// Held money returns to balance if transfer isn't confirmed till expirePulse.
+func (w *Wallet) TransferWithConfirmation(amount uint, to *core.RecordRef, expirePulse core.PulseNumber) (core.RecordRef, error) {
+	toWallet, err := wallet.GetImplementationFrom(*to)
+	if err != nil {
+		return core.RecordRef{}, fmt.Errorf("[ TransferWithConfirmation ] Can't get implementation: %s", err.Error())
+	}
+
+	newBalance, err := safemath.Sub(w.Balance, amount)
+	if err != nil {
+		return core.RecordRef{}, fmt.Errorf("[ TransferWithConfirmation ] Not enough balance for transfer: %s", err.Error())
+	}
+
+	toWalletRef := toWallet.GetReference()
+	ph := pendingtransfer.New(&toWalletRef, amount, expirePulse)
+	pt, err := ph.AsChild(w.GetReference())
+	if err != nil {
+		return core.RecordRef{}, fmt.Errorf("[ TransferWithConfirmation ] Can't save as child: %s", err.Error())
+	}
+
+	w.Balance = newBalance
+	return pt.GetReference(), nil
+}
+
+// ConfirmTransfer sends money held by pending transfer
+func (w *Wallet) ConfirmTransfer(transferRef *core.RecordRef) error {
+	to, amount, err := pendingtransfer.GetObject(*transferRef).Confirm()
+	if err != nil {
+		return fmt.Errorf("[ ConfirmTransfer ] Can't confirm transfer: %s", err.Error())
+	}
+
+	if err := w.sendAllowance(amount, wallet.GetObject(to)); err != nil {
+		return fmt.Errorf("[ ConfirmTransfer ] %s", err.Error())
+	}
+	return nil
+}
+
+func (w *Wallet) sendAllowance(amount uint, toWallet *wallet.Wallet) error {
+	toWalletRef := toWallet.GetReference()
+	ah := allowance.New(&toWalletRef, amount, w.GetContext().Time.Unix()+10)
+	a, err := ah.AsChild(w.GetReference())
+	if err != nil {
+		return fmt.Errorf("Can't save as child: %s", err.Error())
+	}
 
 	r := a.GetReference()
-	err = toWallet.AcceptNoWait(&r)
-	return err
+	return toWallet.AcceptNoWait(&r)
 }
 
 // Accept transforms allowance to balance
@@ -102,6 +148,30 @@ func (w *Wallet) GetBalance() (uint, error) {
 			}
 		}
 	}
+
+	ptIterator, err := w.NewChildrenTypedIterator(pendingtransfer.GetPrototype())
+	if err != nil {
+		return 0, fmt.Errorf("[ GetBalance ] Can't get pending transfers: %s", err.Error())
+	}
+
+	for ptIterator.HasNext() {
+		cref, err := ptIterator.Next()
+		if err != nil {
+			return 0, fmt.Errorf("[ GetBalance ] Can't get next pending transfer: %s", err.Error())
+		}
+
+		if !cref.IsEmpty() {
+			amount, err := pendingtransfer.GetObject(cref).GetExpiredAmount()
+			if err != nil {
+				return 0, fmt.Errorf("[ GetBalance ] Can't get expired amount: %s", err.Error())
+			}
+
+			w.Balance, err = safemath.Add(w.Balance, amount)
+			if err != nil {
+				return 0, fmt.Errorf("[ GetBalance ] Couldn't add expired transfer to balance: %s", err.Error())
+			}
+		}
+	}
 	return w.Balance, nil
 }
 
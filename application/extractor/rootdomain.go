@@ -52,3 +52,30 @@ func InfoResponse(data []byte) (*Info, error) {
 
 	return &info, nil
 }
+
+// NetworkParametersResponse returns response from GetNetworkParameters() method of RootDomain contract
+func NetworkParametersResponse(data []byte) (map[string]string, error) {
+	var params map[string]string
+	var contractErr *foundation.Error
+	_, err := core.UnMarshalResponse(data, []interface{}{&params, &contractErr})
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NetworkParametersResponse ] Can't unmarshal")
+	}
+	if contractErr != nil {
+		return nil, errors.Wrap(contractErr, "[ NetworkParametersResponse ] Has error in response")
+	}
+	if params == nil {
+		params = map[string]string{}
+	}
+	return params, nil
+}
+
+// MemberRoleResponse returns response from GetMemberRole() method of RootDomain contract
+func MemberRoleResponse(data []byte) (string, error) {
+	return stringResponse(data)
+}
+
+// AliasResponse returns response from ResolveAlias() and GetAliasOwner() methods of RootDomain contract
+func AliasResponse(data []byte) (string, error) {
+	return stringResponse(data)
+}
@@ -70,3 +70,48 @@ func TestInfoResponse_UnmarshalError(t *testing.T) {
 	require.Contains(t, err.Error(), "Can't unmarshal")
 	require.Nil(t, info)
 }
+
+func TestNetworkParametersResponse(t *testing.T) {
+	testValue := map[string]string{core.NetworkParameterMaxQueueLength: "10"}
+
+	data, err := core.Serialize([]interface{}{testValue, nil})
+	require.NoError(t, err)
+
+	params, err := NetworkParametersResponse(data)
+
+	require.NoError(t, err)
+	require.Equal(t, testValue, params)
+}
+
+func TestNetworkParametersResponse_ErrorResponse(t *testing.T) {
+	contractErr := &foundation.Error{S: "Custom test error"}
+
+	data, err := core.Serialize([]interface{}{nil, contractErr})
+	require.NoError(t, err)
+
+	params, err := NetworkParametersResponse(data)
+
+	require.Contains(t, err.Error(), "Has error in response")
+	require.Contains(t, err.Error(), "Custom test error")
+	require.Nil(t, params)
+}
+
+func TestMemberRoleResponse(t *testing.T) {
+	data, err := core.Serialize([]interface{}{core.APIRoleOperator.String(), nil})
+	require.NoError(t, err)
+
+	role, err := MemberRoleResponse(data)
+
+	require.NoError(t, err)
+	require.Equal(t, "operator", role)
+}
+
+func TestAliasResponse(t *testing.T) {
+	data, err := core.Serialize([]interface{}{"test_reference", nil})
+	require.NoError(t, err)
+
+	ref, err := AliasResponse(data)
+
+	require.NoError(t, err)
+	require.Equal(t, "test_reference", ref)
+}
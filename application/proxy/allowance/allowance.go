@@ -17,6 +17,7 @@
 package allowance
 
 import (
+	"context"
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
 	"github.com/insolar/insolar/logicrunner/goplugin/proxyctx"
@@ -106,14 +107,14 @@ func (r *Allowance) GetPrototype() (core.RecordRef, error) {
 		var ret1 *foundation.Error
 		ret[1] = &ret1
 
-		res, err := proxyctx.Current.RouteCall(r.Reference, true, "GetPrototype", make([]byte, 0), *PrototypeReference)
+		res, err := proxyctx.Current.RouteCall(context.Background(), r.Reference, true, "GetPrototype", make([]byte, 0), *PrototypeReference)
 		if err != nil {
-			return ret0, err
+			return ret0, foundation.NewCallError(r.Reference, "GetPrototype", err)
 		}
 
 		err = proxyctx.Current.Deserialize(res, &ret)
 		if err != nil {
-			return ret0, err
+			return ret0, foundation.NewCallError(r.Reference, "GetPrototype", err)
 		}
 
 		if ret1 != nil {
@@ -136,14 +137,14 @@ func (r *Allowance) GetCode() (core.RecordRef, error) {
 		var ret1 *foundation.Error
 		ret[1] = &ret1
 
-		res, err := proxyctx.Current.RouteCall(r.Reference, true, "GetCode", make([]byte, 0), *PrototypeReference)
+		res, err := proxyctx.Current.RouteCall(context.Background(), r.Reference, true, "GetCode", make([]byte, 0), *PrototypeReference)
 		if err != nil {
-			return ret0, err
+			return ret0, foundation.NewCallError(r.Reference, "GetCode", err)
 		}
 
 		err = proxyctx.Current.Deserialize(res, &ret)
 		if err != nil {
-			return ret0, err
+			return ret0, foundation.NewCallError(r.Reference, "GetCode", err)
 		}
 
 		if ret1 != nil {
@@ -158,6 +159,11 @@ func (r *Allowance) GetCode() (core.RecordRef, error) {
 
 // TakeAmount is proxy generated method
 func (r *Allowance) TakeAmount() (uint, error) {
+	return r.TakeAmountWithContext(context.Background())
+}
+
+// TakeAmountWithContext is proxy generated method, call is aborted when ctx is done
+func (r *Allowance) TakeAmountWithContext(ctx context.Context) (uint, error) {
 	var args [0]interface{}
 
 	var argsSerialized []byte
@@ -170,17 +176,17 @@ func (r *Allowance) TakeAmount() (uint, error) {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "TakeAmount", err)
 	}
 
-	res, err := proxyctx.Current.RouteCall(r.Reference, true, "TakeAmount", argsSerialized, *PrototypeReference)
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "TakeAmount", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "TakeAmount", err)
 	}
 
 	err = proxyctx.Current.Deserialize(res, &ret)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "TakeAmount", err)
 	}
 
 	if ret1 != nil {
@@ -197,12 +203,12 @@ func (r *Allowance) TakeAmountNoWait() error {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "TakeAmount", err)
 	}
 
-	_, err = proxyctx.Current.RouteCall(r.Reference, false, "TakeAmount", argsSerialized, *PrototypeReference)
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "TakeAmount", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "TakeAmount", err)
 	}
 
 	return nil
@@ -210,6 +216,11 @@ func (r *Allowance) TakeAmountNoWait() error {
 
 // GetBalanceForOwner is proxy generated method
 func (r *Allowance) GetBalanceForOwner() (uint, error) {
+	return r.GetBalanceForOwnerWithContext(context.Background())
+}
+
+// GetBalanceForOwnerWithContext is proxy generated method, call is aborted when ctx is done
+func (r *Allowance) GetBalanceForOwnerWithContext(ctx context.Context) (uint, error) {
 	var args [0]interface{}
 
 	var argsSerialized []byte
@@ -222,17 +233,17 @@ func (r *Allowance) GetBalanceForOwner() (uint, error) {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "GetBalanceForOwner", err)
 	}
 
-	res, err := proxyctx.Current.RouteCall(r.Reference, true, "GetBalanceForOwner", argsSerialized, *PrototypeReference)
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "GetBalanceForOwner", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "GetBalanceForOwner", err)
 	}
 
 	err = proxyctx.Current.Deserialize(res, &ret)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "GetBalanceForOwner", err)
 	}
 
 	if ret1 != nil {
@@ -249,12 +260,12 @@ func (r *Allowance) GetBalanceForOwnerNoWait() error {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "GetBalanceForOwner", err)
 	}
 
-	_, err = proxyctx.Current.RouteCall(r.Reference, false, "GetBalanceForOwner", argsSerialized, *PrototypeReference)
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "GetBalanceForOwner", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "GetBalanceForOwner", err)
 	}
 
 	return nil
@@ -262,6 +273,11 @@ func (r *Allowance) GetBalanceForOwnerNoWait() error {
 
 // GetExpiredBalance is proxy generated method
 func (r *Allowance) GetExpiredBalance() (uint, error) {
+	return r.GetExpiredBalanceWithContext(context.Background())
+}
+
+// GetExpiredBalanceWithContext is proxy generated method, call is aborted when ctx is done
+func (r *Allowance) GetExpiredBalanceWithContext(ctx context.Context) (uint, error) {
 	var args [0]interface{}
 
 	var argsSerialized []byte
@@ -274,17 +290,17 @@ func (r *Allowance) GetExpiredBalance() (uint, error) {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "GetExpiredBalance", err)
 	}
 
-	res, err := proxyctx.Current.RouteCall(r.Reference, true, "GetExpiredBalance", argsSerialized, *PrototypeReference)
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "GetExpiredBalance", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "GetExpiredBalance", err)
 	}
 
 	err = proxyctx.Current.Deserialize(res, &ret)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "GetExpiredBalance", err)
 	}
 
 	if ret1 != nil {
@@ -301,12 +317,12 @@ func (r *Allowance) GetExpiredBalanceNoWait() error {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "GetExpiredBalance", err)
 	}
 
-	_, err = proxyctx.Current.RouteCall(r.Reference, false, "GetExpiredBalance", argsSerialized, *PrototypeReference)
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "GetExpiredBalance", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "GetExpiredBalance", err)
 	}
 
 	return nil
@@ -0,0 +1,326 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package entry
+
+import (
+	"context"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
+	"github.com/insolar/insolar/logicrunner/goplugin/proxyctx"
+)
+
+// PrototypeReference to prototype of this contract
+// error checking hides in generator
+var PrototypeReference, _ = core.NewRefFromBase58("1111dzm8THDRpadRo64VTDsWEjadqZsMkobS3c32E1.11111111111111111111111111111111")
+
+// Entry holds proxy type
+type Entry struct {
+	Reference core.RecordRef
+	Prototype core.RecordRef
+	Code      core.RecordRef
+}
+
+// ContractConstructorHolder holds logic with object construction
+type ContractConstructorHolder struct {
+	constructorName string
+	argsSerialized  []byte
+}
+
+// AsChild saves object as child
+func (r *ContractConstructorHolder) AsChild(objRef core.RecordRef) (*Entry, error) {
+	ref, err := proxyctx.Current.SaveAsChild(objRef, *PrototypeReference, r.constructorName, r.argsSerialized)
+	if err != nil {
+		return nil, err
+	}
+	return &Entry{Reference: ref}, nil
+}
+
+// AsDelegate saves object as delegate
+func (r *ContractConstructorHolder) AsDelegate(objRef core.RecordRef) (*Entry, error) {
+	ref, err := proxyctx.Current.SaveAsDelegate(objRef, *PrototypeReference, r.constructorName, r.argsSerialized)
+	if err != nil {
+		return nil, err
+	}
+	return &Entry{Reference: ref}, nil
+}
+
+// GetObject returns proxy object
+func GetObject(ref core.RecordRef) (r *Entry) {
+	return &Entry{Reference: ref}
+}
+
+// GetPrototype returns reference to the prototype
+func GetPrototype() core.RecordRef {
+	return *PrototypeReference
+}
+
+// GetImplementationFrom returns proxy to delegate of given type
+func GetImplementationFrom(object core.RecordRef) (*Entry, error) {
+	ref, err := proxyctx.Current.GetDelegate(object, *PrototypeReference)
+	if err != nil {
+		return nil, err
+	}
+	return GetObject(ref), nil
+}
+
+// New is constructor
+func New(key string, value []byte) *ContractConstructorHolder {
+	var args [2]interface{}
+	args[0] = key
+	args[1] = value
+
+	var argsSerialized []byte
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		panic(err)
+	}
+
+	return &ContractConstructorHolder{constructorName: "New", argsSerialized: argsSerialized}
+}
+
+// GetReference returns reference of the object
+func (r *Entry) GetReference() core.RecordRef {
+	return r.Reference
+}
+
+// GetPrototype returns reference to the code
+func (r *Entry) GetPrototype() (core.RecordRef, error) {
+	if r.Prototype.IsEmpty() {
+		ret := [2]interface{}{}
+		var ret0 core.RecordRef
+		ret[0] = &ret0
+		var ret1 *foundation.Error
+		ret[1] = &ret1
+
+		res, err := proxyctx.Current.RouteCall(context.Background(), r.Reference, true, "GetPrototype", make([]byte, 0), *PrototypeReference)
+		if err != nil {
+			return ret0, foundation.NewCallError(r.Reference, "GetPrototype", err)
+		}
+
+		err = proxyctx.Current.Deserialize(res, &ret)
+		if err != nil {
+			return ret0, foundation.NewCallError(r.Reference, "GetPrototype", err)
+		}
+
+		if ret1 != nil {
+			return ret0, ret1
+		}
+
+		r.Prototype = ret0
+	}
+
+	return r.Prototype, nil
+
+}
+
+// GetCode returns reference to the code
+func (r *Entry) GetCode() (core.RecordRef, error) {
+	if r.Code.IsEmpty() {
+		ret := [2]interface{}{}
+		var ret0 core.RecordRef
+		ret[0] = &ret0
+		var ret1 *foundation.Error
+		ret[1] = &ret1
+
+		res, err := proxyctx.Current.RouteCall(context.Background(), r.Reference, true, "GetCode", make([]byte, 0), *PrototypeReference)
+		if err != nil {
+			return ret0, foundation.NewCallError(r.Reference, "GetCode", err)
+		}
+
+		err = proxyctx.Current.Deserialize(res, &ret)
+		if err != nil {
+			return ret0, foundation.NewCallError(r.Reference, "GetCode", err)
+		}
+
+		if ret1 != nil {
+			return ret0, ret1
+		}
+
+		r.Code = ret0
+	}
+
+	return r.Code, nil
+}
+
+// GetValue is proxy generated method
+func (r *Entry) GetValue() ([]byte, error) {
+	return r.GetValueWithContext(context.Background())
+}
+
+// GetValueWithContext is proxy generated method, call is aborted when ctx is done
+func (r *Entry) GetValueWithContext(ctx context.Context) ([]byte, error) {
+	var args [0]interface{}
+
+	var argsSerialized []byte
+
+	ret := [2]interface{}{}
+	var ret0 []byte
+	ret[0] = &ret0
+	var ret1 *foundation.Error
+	ret[1] = &ret1
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return ret0, foundation.NewCallError(r.Reference, "GetValue", err)
+	}
+
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "GetValue", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return ret0, foundation.NewCallError(r.Reference, "GetValue", err)
+	}
+
+	err = proxyctx.Current.Deserialize(res, &ret)
+	if err != nil {
+		return ret0, foundation.NewCallError(r.Reference, "GetValue", err)
+	}
+
+	if ret1 != nil {
+		return ret0, ret1
+	}
+	return ret0, nil
+}
+
+// GetValueNoWait is proxy generated method
+func (r *Entry) GetValueNoWait() error {
+	var args [0]interface{}
+
+	var argsSerialized []byte
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "GetValue", err)
+	}
+
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "GetValue", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "GetValue", err)
+	}
+
+	return nil
+}
+
+// SetValue is proxy generated method
+func (r *Entry) SetValue(value []byte) error {
+	return r.SetValueWithContext(context.Background(), value)
+}
+
+// SetValueWithContext is proxy generated method, call is aborted when ctx is done
+func (r *Entry) SetValueWithContext(ctx context.Context, value []byte) error {
+	var args [1]interface{}
+	args[0] = value
+
+	var argsSerialized []byte
+
+	ret := [1]interface{}{}
+	var ret0 *foundation.Error
+	ret[0] = &ret0
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "SetValue", err)
+	}
+
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "SetValue", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "SetValue", err)
+	}
+
+	err = proxyctx.Current.Deserialize(res, &ret)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "SetValue", err)
+	}
+
+	if ret0 != nil {
+		return ret0
+	}
+	return nil
+}
+
+// SetValueNoWait is proxy generated method
+func (r *Entry) SetValueNoWait(value []byte) error {
+	var args [1]interface{}
+	args[0] = value
+
+	var argsSerialized []byte
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "SetValue", err)
+	}
+
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "SetValue", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "SetValue", err)
+	}
+
+	return nil
+}
+
+// Destroy is proxy generated method
+func (r *Entry) Destroy() error {
+	return r.DestroyWithContext(context.Background())
+}
+
+// DestroyWithContext is proxy generated method, call is aborted when ctx is done
+func (r *Entry) DestroyWithContext(ctx context.Context) error {
+	var args [0]interface{}
+
+	var argsSerialized []byte
+
+	ret := [1]interface{}{}
+	var ret0 *foundation.Error
+	ret[0] = &ret0
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "Destroy", err)
+	}
+
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "Destroy", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "Destroy", err)
+	}
+
+	err = proxyctx.Current.Deserialize(res, &ret)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "Destroy", err)
+	}
+
+	if ret0 != nil {
+		return ret0
+	}
+	return nil
+}
+
+// DestroyNoWait is proxy generated method
+func (r *Entry) DestroyNoWait() error {
+	var args [0]interface{}
+
+	var argsSerialized []byte
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "Destroy", err)
+	}
+
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "Destroy", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "Destroy", err)
+	}
+
+	return nil
+}
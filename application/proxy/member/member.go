@@ -17,6 +17,7 @@
 package member
 
 import (
+	"context"
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
 	"github.com/insolar/insolar/logicrunner/goplugin/proxyctx"
@@ -24,7 +25,7 @@ import (
 
 // PrototypeReference to prototype of this contract
 // error checking hides in generator
-var PrototypeReference, _ = core.NewRefFromBase58("1111DojgftvVq6ZYswwJEcjpBU6kgR9Cqq4hKMrgWr.11111111111111111111111111111111")
+var PrototypeReference, _ = core.NewRefFromBase58("11113D4TmUP4ktSp4boBxVy9GhHUrnKrqJN82u1GeZU.11111111111111111111111111111111")
 
 // Member holds proxy type
 type Member struct {
@@ -105,14 +106,14 @@ func (r *Member) GetPrototype() (core.RecordRef, error) {
 		var ret1 *foundation.Error
 		ret[1] = &ret1
 
-		res, err := proxyctx.Current.RouteCall(r.Reference, true, "GetPrototype", make([]byte, 0), *PrototypeReference)
+		res, err := proxyctx.Current.RouteCall(context.Background(), r.Reference, true, "GetPrototype", make([]byte, 0), *PrototypeReference)
 		if err != nil {
-			return ret0, err
+			return ret0, foundation.NewCallError(r.Reference, "GetPrototype", err)
 		}
 
 		err = proxyctx.Current.Deserialize(res, &ret)
 		if err != nil {
-			return ret0, err
+			return ret0, foundation.NewCallError(r.Reference, "GetPrototype", err)
 		}
 
 		if ret1 != nil {
@@ -135,14 +136,14 @@ func (r *Member) GetCode() (core.RecordRef, error) {
 		var ret1 *foundation.Error
 		ret[1] = &ret1
 
-		res, err := proxyctx.Current.RouteCall(r.Reference, true, "GetCode", make([]byte, 0), *PrototypeReference)
+		res, err := proxyctx.Current.RouteCall(context.Background(), r.Reference, true, "GetCode", make([]byte, 0), *PrototypeReference)
 		if err != nil {
-			return ret0, err
+			return ret0, foundation.NewCallError(r.Reference, "GetCode", err)
 		}
 
 		err = proxyctx.Current.Deserialize(res, &ret)
 		if err != nil {
-			return ret0, err
+			return ret0, foundation.NewCallError(r.Reference, "GetCode", err)
 		}
 
 		if ret1 != nil {
@@ -157,6 +158,11 @@ func (r *Member) GetCode() (core.RecordRef, error) {
 
 // GetName is proxy generated method
 func (r *Member) GetName() (string, error) {
+	return r.GetNameWithContext(context.Background())
+}
+
+// GetNameWithContext is proxy generated method, call is aborted when ctx is done
+func (r *Member) GetNameWithContext(ctx context.Context) (string, error) {
 	var args [0]interface{}
 
 	var argsSerialized []byte
@@ -169,17 +175,17 @@ func (r *Member) GetName() (string, error) {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "GetName", err)
 	}
 
-	res, err := proxyctx.Current.RouteCall(r.Reference, true, "GetName", argsSerialized, *PrototypeReference)
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "GetName", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "GetName", err)
 	}
 
 	err = proxyctx.Current.Deserialize(res, &ret)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "GetName", err)
 	}
 
 	if ret1 != nil {
@@ -196,12 +202,12 @@ func (r *Member) GetNameNoWait() error {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "GetName", err)
 	}
 
-	_, err = proxyctx.Current.RouteCall(r.Reference, false, "GetName", argsSerialized, *PrototypeReference)
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "GetName", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "GetName", err)
 	}
 
 	return nil
@@ -209,6 +215,11 @@ func (r *Member) GetNameNoWait() error {
 
 // GetPublicKey is proxy generated method
 func (r *Member) GetPublicKey() (string, error) {
+	return r.GetPublicKeyWithContext(context.Background())
+}
+
+// GetPublicKeyWithContext is proxy generated method, call is aborted when ctx is done
+func (r *Member) GetPublicKeyWithContext(ctx context.Context) (string, error) {
 	var args [0]interface{}
 
 	var argsSerialized []byte
@@ -221,17 +232,17 @@ func (r *Member) GetPublicKey() (string, error) {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "GetPublicKey", err)
 	}
 
-	res, err := proxyctx.Current.RouteCall(r.Reference, true, "GetPublicKey", argsSerialized, *PrototypeReference)
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "GetPublicKey", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "GetPublicKey", err)
 	}
 
 	err = proxyctx.Current.Deserialize(res, &ret)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "GetPublicKey", err)
 	}
 
 	if ret1 != nil {
@@ -248,12 +259,12 @@ func (r *Member) GetPublicKeyNoWait() error {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "GetPublicKey", err)
 	}
 
-	_, err = proxyctx.Current.RouteCall(r.Reference, false, "GetPublicKey", argsSerialized, *PrototypeReference)
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "GetPublicKey", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "GetPublicKey", err)
 	}
 
 	return nil
@@ -261,6 +272,11 @@ func (r *Member) GetPublicKeyNoWait() error {
 
 // Call is proxy generated method
 func (r *Member) Call(rootDomain core.RecordRef, method string, params []byte, seed []byte, sign []byte) (interface{}, error) {
+	return r.CallWithContext(context.Background(), rootDomain, method, params, seed, sign)
+}
+
+// CallWithContext is proxy generated method, call is aborted when ctx is done
+func (r *Member) CallWithContext(ctx context.Context, rootDomain core.RecordRef, method string, params []byte, seed []byte, sign []byte) (interface{}, error) {
 	var args [5]interface{}
 	args[0] = rootDomain
 	args[1] = method
@@ -278,17 +294,17 @@ func (r *Member) Call(rootDomain core.RecordRef, method string, params []byte, s
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "Call", err)
 	}
 
-	res, err := proxyctx.Current.RouteCall(r.Reference, true, "Call", argsSerialized, *PrototypeReference)
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "Call", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "Call", err)
 	}
 
 	err = proxyctx.Current.Deserialize(res, &ret)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "Call", err)
 	}
 
 	if ret1 != nil {
@@ -310,12 +326,71 @@ func (r *Member) CallNoWait(rootDomain core.RecordRef, method string, params []b
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "Call", err)
+	}
+
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "Call", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "Call", err)
+	}
+
+	return nil
+}
+
+// GetSealedField is proxy generated method
+func (r *Member) GetSealedField(name string) (*foundation.SealedField, error) {
+	return r.GetSealedFieldWithContext(context.Background(), name)
+}
+
+// GetSealedFieldWithContext is proxy generated method, call is aborted when ctx is done
+func (r *Member) GetSealedFieldWithContext(ctx context.Context, name string) (*foundation.SealedField, error) {
+	var args [1]interface{}
+	args[0] = name
+
+	var argsSerialized []byte
+
+	ret := [2]interface{}{}
+	var ret0 *foundation.SealedField
+	ret[0] = &ret0
+	var ret1 *foundation.Error
+	ret[1] = &ret1
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return ret0, foundation.NewCallError(r.Reference, "GetSealedField", err)
+	}
+
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "GetSealedField", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return ret0, foundation.NewCallError(r.Reference, "GetSealedField", err)
+	}
+
+	err = proxyctx.Current.Deserialize(res, &ret)
+	if err != nil {
+		return ret0, foundation.NewCallError(r.Reference, "GetSealedField", err)
+	}
+
+	if ret1 != nil {
+		return ret0, ret1
+	}
+	return ret0, nil
+}
+
+// GetSealedFieldNoWait is proxy generated method
+func (r *Member) GetSealedFieldNoWait(name string) error {
+	var args [1]interface{}
+	args[0] = name
+
+	var argsSerialized []byte
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "GetSealedField", err)
 	}
 
-	_, err = proxyctx.Current.RouteCall(r.Reference, false, "Call", argsSerialized, *PrototypeReference)
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "GetSealedField", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "GetSealedField", err)
 	}
 
 	return nil
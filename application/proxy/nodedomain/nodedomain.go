@@ -17,6 +17,7 @@
 package nodedomain
 
 import (
+	"context"
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
 	"github.com/insolar/insolar/logicrunner/goplugin/proxyctx"
@@ -103,14 +104,14 @@ func (r *NodeDomain) GetPrototype() (core.RecordRef, error) {
 		var ret1 *foundation.Error
 		ret[1] = &ret1
 
-		res, err := proxyctx.Current.RouteCall(r.Reference, true, "GetPrototype", make([]byte, 0), *PrototypeReference)
+		res, err := proxyctx.Current.RouteCall(context.Background(), r.Reference, true, "GetPrototype", make([]byte, 0), *PrototypeReference)
 		if err != nil {
-			return ret0, err
+			return ret0, foundation.NewCallError(r.Reference, "GetPrototype", err)
 		}
 
 		err = proxyctx.Current.Deserialize(res, &ret)
 		if err != nil {
-			return ret0, err
+			return ret0, foundation.NewCallError(r.Reference, "GetPrototype", err)
 		}
 
 		if ret1 != nil {
@@ -133,14 +134,14 @@ func (r *NodeDomain) GetCode() (core.RecordRef, error) {
 		var ret1 *foundation.Error
 		ret[1] = &ret1
 
-		res, err := proxyctx.Current.RouteCall(r.Reference, true, "GetCode", make([]byte, 0), *PrototypeReference)
+		res, err := proxyctx.Current.RouteCall(context.Background(), r.Reference, true, "GetCode", make([]byte, 0), *PrototypeReference)
 		if err != nil {
-			return ret0, err
+			return ret0, foundation.NewCallError(r.Reference, "GetCode", err)
 		}
 
 		err = proxyctx.Current.Deserialize(res, &ret)
 		if err != nil {
-			return ret0, err
+			return ret0, foundation.NewCallError(r.Reference, "GetCode", err)
 		}
 
 		if ret1 != nil {
@@ -155,6 +156,11 @@ func (r *NodeDomain) GetCode() (core.RecordRef, error) {
 
 // RegisterNode is proxy generated method
 func (r *NodeDomain) RegisterNode(publicKey string, role string) (string, error) {
+	return r.RegisterNodeWithContext(context.Background(), publicKey, role)
+}
+
+// RegisterNodeWithContext is proxy generated method, call is aborted when ctx is done
+func (r *NodeDomain) RegisterNodeWithContext(ctx context.Context, publicKey string, role string) (string, error) {
 	var args [2]interface{}
 	args[0] = publicKey
 	args[1] = role
@@ -169,17 +175,17 @@ func (r *NodeDomain) RegisterNode(publicKey string, role string) (string, error)
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "RegisterNode", err)
 	}
 
-	res, err := proxyctx.Current.RouteCall(r.Reference, true, "RegisterNode", argsSerialized, *PrototypeReference)
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "RegisterNode", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "RegisterNode", err)
 	}
 
 	err = proxyctx.Current.Deserialize(res, &ret)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "RegisterNode", err)
 	}
 
 	if ret1 != nil {
@@ -198,12 +204,12 @@ func (r *NodeDomain) RegisterNodeNoWait(publicKey string, role string) error {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "RegisterNode", err)
 	}
 
-	_, err = proxyctx.Current.RouteCall(r.Reference, false, "RegisterNode", argsSerialized, *PrototypeReference)
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "RegisterNode", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "RegisterNode", err)
 	}
 
 	return nil
@@ -211,6 +217,11 @@ func (r *NodeDomain) RegisterNodeNoWait(publicKey string, role string) error {
 
 // GetNodeRefByPK is proxy generated method
 func (r *NodeDomain) GetNodeRefByPK(publicKey string) (string, error) {
+	return r.GetNodeRefByPKWithContext(context.Background(), publicKey)
+}
+
+// GetNodeRefByPKWithContext is proxy generated method, call is aborted when ctx is done
+func (r *NodeDomain) GetNodeRefByPKWithContext(ctx context.Context, publicKey string) (string, error) {
 	var args [1]interface{}
 	args[0] = publicKey
 
@@ -224,17 +235,17 @@ func (r *NodeDomain) GetNodeRefByPK(publicKey string) (string, error) {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "GetNodeRefByPK", err)
 	}
 
-	res, err := proxyctx.Current.RouteCall(r.Reference, true, "GetNodeRefByPK", argsSerialized, *PrototypeReference)
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "GetNodeRefByPK", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "GetNodeRefByPK", err)
 	}
 
 	err = proxyctx.Current.Deserialize(res, &ret)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "GetNodeRefByPK", err)
 	}
 
 	if ret1 != nil {
@@ -252,12 +263,12 @@ func (r *NodeDomain) GetNodeRefByPKNoWait(publicKey string) error {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "GetNodeRefByPK", err)
 	}
 
-	_, err = proxyctx.Current.RouteCall(r.Reference, false, "GetNodeRefByPK", argsSerialized, *PrototypeReference)
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "GetNodeRefByPK", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "GetNodeRefByPK", err)
 	}
 
 	return nil
@@ -265,6 +276,11 @@ func (r *NodeDomain) GetNodeRefByPKNoWait(publicKey string) error {
 
 // RemoveNode is proxy generated method
 func (r *NodeDomain) RemoveNode(nodeRef core.RecordRef) error {
+	return r.RemoveNodeWithContext(context.Background(), nodeRef)
+}
+
+// RemoveNodeWithContext is proxy generated method, call is aborted when ctx is done
+func (r *NodeDomain) RemoveNodeWithContext(ctx context.Context, nodeRef core.RecordRef) error {
 	var args [1]interface{}
 	args[0] = nodeRef
 
@@ -276,17 +292,17 @@ func (r *NodeDomain) RemoveNode(nodeRef core.RecordRef) error {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "RemoveNode", err)
 	}
 
-	res, err := proxyctx.Current.RouteCall(r.Reference, true, "RemoveNode", argsSerialized, *PrototypeReference)
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "RemoveNode", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "RemoveNode", err)
 	}
 
 	err = proxyctx.Current.Deserialize(res, &ret)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "RemoveNode", err)
 	}
 
 	if ret0 != nil {
@@ -304,12 +320,12 @@ func (r *NodeDomain) RemoveNodeNoWait(nodeRef core.RecordRef) error {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "RemoveNode", err)
 	}
 
-	_, err = proxyctx.Current.RouteCall(r.Reference, false, "RemoveNode", argsSerialized, *PrototypeReference)
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "RemoveNode", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "RemoveNode", err)
 	}
 
 	return nil
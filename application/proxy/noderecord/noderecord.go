@@ -17,6 +17,7 @@
 package noderecord
 
 import (
+	"context"
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
 	"github.com/insolar/insolar/logicrunner/goplugin/proxyctx"
@@ -110,14 +111,14 @@ func (r *NodeRecord) GetPrototype() (core.RecordRef, error) {
 		var ret1 *foundation.Error
 		ret[1] = &ret1
 
-		res, err := proxyctx.Current.RouteCall(r.Reference, true, "GetPrototype", make([]byte, 0), *PrototypeReference)
+		res, err := proxyctx.Current.RouteCall(context.Background(), r.Reference, true, "GetPrototype", make([]byte, 0), *PrototypeReference)
 		if err != nil {
-			return ret0, err
+			return ret0, foundation.NewCallError(r.Reference, "GetPrototype", err)
 		}
 
 		err = proxyctx.Current.Deserialize(res, &ret)
 		if err != nil {
-			return ret0, err
+			return ret0, foundation.NewCallError(r.Reference, "GetPrototype", err)
 		}
 
 		if ret1 != nil {
@@ -140,14 +141,14 @@ func (r *NodeRecord) GetCode() (core.RecordRef, error) {
 		var ret1 *foundation.Error
 		ret[1] = &ret1
 
-		res, err := proxyctx.Current.RouteCall(r.Reference, true, "GetCode", make([]byte, 0), *PrototypeReference)
+		res, err := proxyctx.Current.RouteCall(context.Background(), r.Reference, true, "GetCode", make([]byte, 0), *PrototypeReference)
 		if err != nil {
-			return ret0, err
+			return ret0, foundation.NewCallError(r.Reference, "GetCode", err)
 		}
 
 		err = proxyctx.Current.Deserialize(res, &ret)
 		if err != nil {
-			return ret0, err
+			return ret0, foundation.NewCallError(r.Reference, "GetCode", err)
 		}
 
 		if ret1 != nil {
@@ -162,6 +163,11 @@ func (r *NodeRecord) GetCode() (core.RecordRef, error) {
 
 // GetNodeInfo is proxy generated method
 func (r *NodeRecord) GetNodeInfo() (RecordInfo, error) {
+	return r.GetNodeInfoWithContext(context.Background())
+}
+
+// GetNodeInfoWithContext is proxy generated method, call is aborted when ctx is done
+func (r *NodeRecord) GetNodeInfoWithContext(ctx context.Context) (RecordInfo, error) {
 	var args [0]interface{}
 
 	var argsSerialized []byte
@@ -174,17 +180,17 @@ func (r *NodeRecord) GetNodeInfo() (RecordInfo, error) {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "GetNodeInfo", err)
 	}
 
-	res, err := proxyctx.Current.RouteCall(r.Reference, true, "GetNodeInfo", argsSerialized, *PrototypeReference)
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "GetNodeInfo", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "GetNodeInfo", err)
 	}
 
 	err = proxyctx.Current.Deserialize(res, &ret)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "GetNodeInfo", err)
 	}
 
 	if ret1 != nil {
@@ -201,12 +207,12 @@ func (r *NodeRecord) GetNodeInfoNoWait() error {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "GetNodeInfo", err)
 	}
 
-	_, err = proxyctx.Current.RouteCall(r.Reference, false, "GetNodeInfo", argsSerialized, *PrototypeReference)
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "GetNodeInfo", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "GetNodeInfo", err)
 	}
 
 	return nil
@@ -214,6 +220,11 @@ func (r *NodeRecord) GetNodeInfoNoWait() error {
 
 // GetPublicKey is proxy generated method
 func (r *NodeRecord) GetPublicKey() (string, error) {
+	return r.GetPublicKeyWithContext(context.Background())
+}
+
+// GetPublicKeyWithContext is proxy generated method, call is aborted when ctx is done
+func (r *NodeRecord) GetPublicKeyWithContext(ctx context.Context) (string, error) {
 	var args [0]interface{}
 
 	var argsSerialized []byte
@@ -226,17 +237,17 @@ func (r *NodeRecord) GetPublicKey() (string, error) {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "GetPublicKey", err)
 	}
 
-	res, err := proxyctx.Current.RouteCall(r.Reference, true, "GetPublicKey", argsSerialized, *PrototypeReference)
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "GetPublicKey", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "GetPublicKey", err)
 	}
 
 	err = proxyctx.Current.Deserialize(res, &ret)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "GetPublicKey", err)
 	}
 
 	if ret1 != nil {
@@ -253,12 +264,12 @@ func (r *NodeRecord) GetPublicKeyNoWait() error {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "GetPublicKey", err)
 	}
 
-	_, err = proxyctx.Current.RouteCall(r.Reference, false, "GetPublicKey", argsSerialized, *PrototypeReference)
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "GetPublicKey", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "GetPublicKey", err)
 	}
 
 	return nil
@@ -266,6 +277,11 @@ func (r *NodeRecord) GetPublicKeyNoWait() error {
 
 // GetRole is proxy generated method
 func (r *NodeRecord) GetRole() (core.StaticRole, error) {
+	return r.GetRoleWithContext(context.Background())
+}
+
+// GetRoleWithContext is proxy generated method, call is aborted when ctx is done
+func (r *NodeRecord) GetRoleWithContext(ctx context.Context) (core.StaticRole, error) {
 	var args [0]interface{}
 
 	var argsSerialized []byte
@@ -278,17 +294,17 @@ func (r *NodeRecord) GetRole() (core.StaticRole, error) {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "GetRole", err)
 	}
 
-	res, err := proxyctx.Current.RouteCall(r.Reference, true, "GetRole", argsSerialized, *PrototypeReference)
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "GetRole", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "GetRole", err)
 	}
 
 	err = proxyctx.Current.Deserialize(res, &ret)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "GetRole", err)
 	}
 
 	if ret1 != nil {
@@ -305,12 +321,12 @@ func (r *NodeRecord) GetRoleNoWait() error {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "GetRole", err)
 	}
 
-	_, err = proxyctx.Current.RouteCall(r.Reference, false, "GetRole", argsSerialized, *PrototypeReference)
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "GetRole", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "GetRole", err)
 	}
 
 	return nil
@@ -318,6 +334,11 @@ func (r *NodeRecord) GetRoleNoWait() error {
 
 // Destroy is proxy generated method
 func (r *NodeRecord) Destroy() error {
+	return r.DestroyWithContext(context.Background())
+}
+
+// DestroyWithContext is proxy generated method, call is aborted when ctx is done
+func (r *NodeRecord) DestroyWithContext(ctx context.Context) error {
 	var args [0]interface{}
 
 	var argsSerialized []byte
@@ -328,17 +349,17 @@ func (r *NodeRecord) Destroy() error {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "Destroy", err)
 	}
 
-	res, err := proxyctx.Current.RouteCall(r.Reference, true, "Destroy", argsSerialized, *PrototypeReference)
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "Destroy", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "Destroy", err)
 	}
 
 	err = proxyctx.Current.Deserialize(res, &ret)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "Destroy", err)
 	}
 
 	if ret0 != nil {
@@ -355,12 +376,12 @@ func (r *NodeRecord) DestroyNoWait() error {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "Destroy", err)
 	}
 
-	_, err = proxyctx.Current.RouteCall(r.Reference, false, "Destroy", argsSerialized, *PrototypeReference)
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "Destroy", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "Destroy", err)
 	}
 
 	return nil
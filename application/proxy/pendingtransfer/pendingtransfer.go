@@ -0,0 +1,331 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package pendingtransfer
+
+import (
+	"context"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
+	"github.com/insolar/insolar/logicrunner/goplugin/proxyctx"
+)
+
+// PrototypeReference to prototype of this contract
+// error checking hides in generator
+var PrototypeReference, _ = core.NewRefFromBase58("1111uiYg9mWVGTd3KnUujcxUjDU1ZLmheogzaJ9wnE.11111111111111111111111111111111")
+
+// PendingTransfer holds proxy type
+type PendingTransfer struct {
+	Reference core.RecordRef
+	Prototype core.RecordRef
+	Code      core.RecordRef
+}
+
+// ContractConstructorHolder holds logic with object construction
+type ContractConstructorHolder struct {
+	constructorName string
+	argsSerialized  []byte
+}
+
+// AsChild saves object as child
+func (r *ContractConstructorHolder) AsChild(objRef core.RecordRef) (*PendingTransfer, error) {
+	ref, err := proxyctx.Current.SaveAsChild(objRef, *PrototypeReference, r.constructorName, r.argsSerialized)
+	if err != nil {
+		return nil, err
+	}
+	return &PendingTransfer{Reference: ref}, nil
+}
+
+// AsDelegate saves object as delegate
+func (r *ContractConstructorHolder) AsDelegate(objRef core.RecordRef) (*PendingTransfer, error) {
+	ref, err := proxyctx.Current.SaveAsDelegate(objRef, *PrototypeReference, r.constructorName, r.argsSerialized)
+	if err != nil {
+		return nil, err
+	}
+	return &PendingTransfer{Reference: ref}, nil
+}
+
+// GetObject returns proxy object
+func GetObject(ref core.RecordRef) (r *PendingTransfer) {
+	return &PendingTransfer{Reference: ref}
+}
+
+// GetPrototype returns reference to the prototype
+func GetPrototype() core.RecordRef {
+	return *PrototypeReference
+}
+
+// GetImplementationFrom returns proxy to delegate of given type
+func GetImplementationFrom(object core.RecordRef) (*PendingTransfer, error) {
+	ref, err := proxyctx.Current.GetDelegate(object, *PrototypeReference)
+	if err != nil {
+		return nil, err
+	}
+	return GetObject(ref), nil
+}
+
+// New is constructor
+func New(to *core.RecordRef, amount uint, expirePulse core.PulseNumber) *ContractConstructorHolder {
+	var args [3]interface{}
+	args[0] = to
+	args[1] = amount
+	args[2] = expirePulse
+
+	var argsSerialized []byte
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		panic(err)
+	}
+
+	return &ContractConstructorHolder{constructorName: "New", argsSerialized: argsSerialized}
+}
+
+// GetReference returns reference of the object
+func (r *PendingTransfer) GetReference() core.RecordRef {
+	return r.Reference
+}
+
+// GetPrototype returns reference to the code
+func (r *PendingTransfer) GetPrototype() (core.RecordRef, error) {
+	if r.Prototype.IsEmpty() {
+		ret := [2]interface{}{}
+		var ret0 core.RecordRef
+		ret[0] = &ret0
+		var ret1 *foundation.Error
+		ret[1] = &ret1
+
+		res, err := proxyctx.Current.RouteCall(context.Background(), r.Reference, true, "GetPrototype", make([]byte, 0), *PrototypeReference)
+		if err != nil {
+			return ret0, foundation.NewCallError(r.Reference, "GetPrototype", err)
+		}
+
+		err = proxyctx.Current.Deserialize(res, &ret)
+		if err != nil {
+			return ret0, foundation.NewCallError(r.Reference, "GetPrototype", err)
+		}
+
+		if ret1 != nil {
+			return ret0, ret1
+		}
+
+		r.Prototype = ret0
+	}
+
+	return r.Prototype, nil
+
+}
+
+// GetCode returns reference to the code
+func (r *PendingTransfer) GetCode() (core.RecordRef, error) {
+	if r.Code.IsEmpty() {
+		ret := [2]interface{}{}
+		var ret0 core.RecordRef
+		ret[0] = &ret0
+		var ret1 *foundation.Error
+		ret[1] = &ret1
+
+		res, err := proxyctx.Current.RouteCall(context.Background(), r.Reference, true, "GetCode", make([]byte, 0), *PrototypeReference)
+		if err != nil {
+			return ret0, foundation.NewCallError(r.Reference, "GetCode", err)
+		}
+
+		err = proxyctx.Current.Deserialize(res, &ret)
+		if err != nil {
+			return ret0, foundation.NewCallError(r.Reference, "GetCode", err)
+		}
+
+		if ret1 != nil {
+			return ret0, ret1
+		}
+
+		r.Code = ret0
+	}
+
+	return r.Code, nil
+}
+
+// Confirm is proxy generated method
+func (r *PendingTransfer) Confirm() (core.RecordRef, uint, error) {
+	return r.ConfirmWithContext(context.Background())
+}
+
+// ConfirmWithContext is proxy generated method, call is aborted when ctx is done
+func (r *PendingTransfer) ConfirmWithContext(ctx context.Context) (core.RecordRef, uint, error) {
+	var args [0]interface{}
+
+	var argsSerialized []byte
+
+	ret := [3]interface{}{}
+	var ret0 core.RecordRef
+	ret[0] = &ret0
+	var ret1 uint
+	ret[1] = &ret1
+	var ret2 *foundation.Error
+	ret[2] = &ret2
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return ret0, ret1, foundation.NewCallError(r.Reference, "Confirm", err)
+	}
+
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "Confirm", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return ret0, ret1, foundation.NewCallError(r.Reference, "Confirm", err)
+	}
+
+	err = proxyctx.Current.Deserialize(res, &ret)
+	if err != nil {
+		return ret0, ret1, foundation.NewCallError(r.Reference, "Confirm", err)
+	}
+
+	if ret2 != nil {
+		return ret0, ret1, ret2
+	}
+	return ret0, ret1, nil
+}
+
+// ConfirmNoWait is proxy generated method
+func (r *PendingTransfer) ConfirmNoWait() error {
+	var args [0]interface{}
+
+	var argsSerialized []byte
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "Confirm", err)
+	}
+
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "Confirm", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "Confirm", err)
+	}
+
+	return nil
+}
+
+// GetExpiredAmount is proxy generated method
+func (r *PendingTransfer) GetExpiredAmount() (uint, error) {
+	return r.GetExpiredAmountWithContext(context.Background())
+}
+
+// GetExpiredAmountWithContext is proxy generated method, call is aborted when ctx is done
+func (r *PendingTransfer) GetExpiredAmountWithContext(ctx context.Context) (uint, error) {
+	var args [0]interface{}
+
+	var argsSerialized []byte
+
+	ret := [2]interface{}{}
+	var ret0 uint
+	ret[0] = &ret0
+	var ret1 *foundation.Error
+	ret[1] = &ret1
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return ret0, foundation.NewCallError(r.Reference, "GetExpiredAmount", err)
+	}
+
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "GetExpiredAmount", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return ret0, foundation.NewCallError(r.Reference, "GetExpiredAmount", err)
+	}
+
+	err = proxyctx.Current.Deserialize(res, &ret)
+	if err != nil {
+		return ret0, foundation.NewCallError(r.Reference, "GetExpiredAmount", err)
+	}
+
+	if ret1 != nil {
+		return ret0, ret1
+	}
+	return ret0, nil
+}
+
+// GetExpiredAmountNoWait is proxy generated method
+func (r *PendingTransfer) GetExpiredAmountNoWait() error {
+	var args [0]interface{}
+
+	var argsSerialized []byte
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "GetExpiredAmount", err)
+	}
+
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "GetExpiredAmount", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "GetExpiredAmount", err)
+	}
+
+	return nil
+}
+
+// GetStatus is proxy generated method
+func (r *PendingTransfer) GetStatus() (string, error) {
+	return r.GetStatusWithContext(context.Background())
+}
+
+// GetStatusWithContext is proxy generated method, call is aborted when ctx is done
+func (r *PendingTransfer) GetStatusWithContext(ctx context.Context) (string, error) {
+	var args [0]interface{}
+
+	var argsSerialized []byte
+
+	ret := [2]interface{}{}
+	var ret0 string
+	ret[0] = &ret0
+	var ret1 *foundation.Error
+	ret[1] = &ret1
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return ret0, foundation.NewCallError(r.Reference, "GetStatus", err)
+	}
+
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "GetStatus", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return ret0, foundation.NewCallError(r.Reference, "GetStatus", err)
+	}
+
+	err = proxyctx.Current.Deserialize(res, &ret)
+	if err != nil {
+		return ret0, foundation.NewCallError(r.Reference, "GetStatus", err)
+	}
+
+	if ret1 != nil {
+		return ret0, ret1
+	}
+	return ret0, nil
+}
+
+// GetStatusNoWait is proxy generated method
+func (r *PendingTransfer) GetStatusNoWait() error {
+	var args [0]interface{}
+
+	var argsSerialized []byte
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "GetStatus", err)
+	}
+
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "GetStatus", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "GetStatus", err)
+	}
+
+	return nil
+}
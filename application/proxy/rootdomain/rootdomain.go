@@ -17,6 +17,7 @@
 package rootdomain
 
 import (
+	"context"
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
 	"github.com/insolar/insolar/logicrunner/goplugin/proxyctx"
@@ -24,7 +25,7 @@ import (
 
 // PrototypeReference to prototype of this contract
 // error checking hides in generator
-var PrototypeReference, _ = core.NewRefFromBase58("1111C3jrgHyV9RYvRQjnZDcZb58a5ymPrgKt6o7N89.11111111111111111111111111111111")
+var PrototypeReference, _ = core.NewRefFromBase58("1111zttZLvMJPxXFZr1TVvNHKUKCULFYnsHocQHT4o.11111111111111111111111111111111")
 
 // RootDomain holds proxy type
 type RootDomain struct {
@@ -103,14 +104,14 @@ func (r *RootDomain) GetPrototype() (core.RecordRef, error) {
 		var ret1 *foundation.Error
 		ret[1] = &ret1
 
-		res, err := proxyctx.Current.RouteCall(r.Reference, true, "GetPrototype", make([]byte, 0), *PrototypeReference)
+		res, err := proxyctx.Current.RouteCall(context.Background(), r.Reference, true, "GetPrototype", make([]byte, 0), *PrototypeReference)
 		if err != nil {
-			return ret0, err
+			return ret0, foundation.NewCallError(r.Reference, "GetPrototype", err)
 		}
 
 		err = proxyctx.Current.Deserialize(res, &ret)
 		if err != nil {
-			return ret0, err
+			return ret0, foundation.NewCallError(r.Reference, "GetPrototype", err)
 		}
 
 		if ret1 != nil {
@@ -133,14 +134,14 @@ func (r *RootDomain) GetCode() (core.RecordRef, error) {
 		var ret1 *foundation.Error
 		ret[1] = &ret1
 
-		res, err := proxyctx.Current.RouteCall(r.Reference, true, "GetCode", make([]byte, 0), *PrototypeReference)
+		res, err := proxyctx.Current.RouteCall(context.Background(), r.Reference, true, "GetCode", make([]byte, 0), *PrototypeReference)
 		if err != nil {
-			return ret0, err
+			return ret0, foundation.NewCallError(r.Reference, "GetCode", err)
 		}
 
 		err = proxyctx.Current.Deserialize(res, &ret)
 		if err != nil {
-			return ret0, err
+			return ret0, foundation.NewCallError(r.Reference, "GetCode", err)
 		}
 
 		if ret1 != nil {
@@ -155,6 +156,11 @@ func (r *RootDomain) GetCode() (core.RecordRef, error) {
 
 // CreateMember is proxy generated method
 func (r *RootDomain) CreateMember(name string, key string) (string, error) {
+	return r.CreateMemberWithContext(context.Background(), name, key)
+}
+
+// CreateMemberWithContext is proxy generated method, call is aborted when ctx is done
+func (r *RootDomain) CreateMemberWithContext(ctx context.Context, name string, key string) (string, error) {
 	var args [2]interface{}
 	args[0] = name
 	args[1] = key
@@ -169,17 +175,17 @@ func (r *RootDomain) CreateMember(name string, key string) (string, error) {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "CreateMember", err)
 	}
 
-	res, err := proxyctx.Current.RouteCall(r.Reference, true, "CreateMember", argsSerialized, *PrototypeReference)
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "CreateMember", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "CreateMember", err)
 	}
 
 	err = proxyctx.Current.Deserialize(res, &ret)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "CreateMember", err)
 	}
 
 	if ret1 != nil {
@@ -198,12 +204,73 @@ func (r *RootDomain) CreateMemberNoWait(name string, key string) error {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "CreateMember", err)
+	}
+
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "CreateMember", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "CreateMember", err)
+	}
+
+	return nil
+}
+
+// BulkCreateMembers is proxy generated method
+func (r *RootDomain) BulkCreateMembers(names []string, keys []string) ([]byte, error) {
+	return r.BulkCreateMembersWithContext(context.Background(), names, keys)
+}
+
+// BulkCreateMembersWithContext is proxy generated method, call is aborted when ctx is done
+func (r *RootDomain) BulkCreateMembersWithContext(ctx context.Context, names []string, keys []string) ([]byte, error) {
+	var args [2]interface{}
+	args[0] = names
+	args[1] = keys
+
+	var argsSerialized []byte
+
+	ret := [2]interface{}{}
+	var ret0 []byte
+	ret[0] = &ret0
+	var ret1 *foundation.Error
+	ret[1] = &ret1
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return ret0, foundation.NewCallError(r.Reference, "BulkCreateMembers", err)
+	}
+
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "BulkCreateMembers", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return ret0, foundation.NewCallError(r.Reference, "BulkCreateMembers", err)
+	}
+
+	err = proxyctx.Current.Deserialize(res, &ret)
+	if err != nil {
+		return ret0, foundation.NewCallError(r.Reference, "BulkCreateMembers", err)
+	}
+
+	if ret1 != nil {
+		return ret0, ret1
+	}
+	return ret0, nil
+}
+
+// BulkCreateMembersNoWait is proxy generated method
+func (r *RootDomain) BulkCreateMembersNoWait(names []string, keys []string) error {
+	var args [2]interface{}
+	args[0] = names
+	args[1] = keys
+
+	var argsSerialized []byte
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "BulkCreateMembers", err)
 	}
 
-	_, err = proxyctx.Current.RouteCall(r.Reference, false, "CreateMember", argsSerialized, *PrototypeReference)
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "BulkCreateMembers", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "BulkCreateMembers", err)
 	}
 
 	return nil
@@ -211,6 +278,11 @@ func (r *RootDomain) CreateMemberNoWait(name string, key string) error {
 
 // GetRootMemberRef is proxy generated method
 func (r *RootDomain) GetRootMemberRef() (*core.RecordRef, error) {
+	return r.GetRootMemberRefWithContext(context.Background())
+}
+
+// GetRootMemberRefWithContext is proxy generated method, call is aborted when ctx is done
+func (r *RootDomain) GetRootMemberRefWithContext(ctx context.Context) (*core.RecordRef, error) {
 	var args [0]interface{}
 
 	var argsSerialized []byte
@@ -223,17 +295,17 @@ func (r *RootDomain) GetRootMemberRef() (*core.RecordRef, error) {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "GetRootMemberRef", err)
 	}
 
-	res, err := proxyctx.Current.RouteCall(r.Reference, true, "GetRootMemberRef", argsSerialized, *PrototypeReference)
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "GetRootMemberRef", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "GetRootMemberRef", err)
 	}
 
 	err = proxyctx.Current.Deserialize(res, &ret)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "GetRootMemberRef", err)
 	}
 
 	if ret1 != nil {
@@ -250,12 +322,12 @@ func (r *RootDomain) GetRootMemberRefNoWait() error {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "GetRootMemberRef", err)
 	}
 
-	_, err = proxyctx.Current.RouteCall(r.Reference, false, "GetRootMemberRef", argsSerialized, *PrototypeReference)
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "GetRootMemberRef", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "GetRootMemberRef", err)
 	}
 
 	return nil
@@ -263,6 +335,11 @@ func (r *RootDomain) GetRootMemberRefNoWait() error {
 
 // DumpUserInfo is proxy generated method
 func (r *RootDomain) DumpUserInfo(reference string) ([]byte, error) {
+	return r.DumpUserInfoWithContext(context.Background(), reference)
+}
+
+// DumpUserInfoWithContext is proxy generated method, call is aborted when ctx is done
+func (r *RootDomain) DumpUserInfoWithContext(ctx context.Context, reference string) ([]byte, error) {
 	var args [1]interface{}
 	args[0] = reference
 
@@ -276,17 +353,17 @@ func (r *RootDomain) DumpUserInfo(reference string) ([]byte, error) {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "DumpUserInfo", err)
 	}
 
-	res, err := proxyctx.Current.RouteCall(r.Reference, true, "DumpUserInfo", argsSerialized, *PrototypeReference)
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "DumpUserInfo", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "DumpUserInfo", err)
 	}
 
 	err = proxyctx.Current.Deserialize(res, &ret)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "DumpUserInfo", err)
 	}
 
 	if ret1 != nil {
@@ -304,12 +381,12 @@ func (r *RootDomain) DumpUserInfoNoWait(reference string) error {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "DumpUserInfo", err)
 	}
 
-	_, err = proxyctx.Current.RouteCall(r.Reference, false, "DumpUserInfo", argsSerialized, *PrototypeReference)
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "DumpUserInfo", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "DumpUserInfo", err)
 	}
 
 	return nil
@@ -317,6 +394,11 @@ func (r *RootDomain) DumpUserInfoNoWait(reference string) error {
 
 // DumpAllUsers is proxy generated method
 func (r *RootDomain) DumpAllUsers() ([]byte, error) {
+	return r.DumpAllUsersWithContext(context.Background())
+}
+
+// DumpAllUsersWithContext is proxy generated method, call is aborted when ctx is done
+func (r *RootDomain) DumpAllUsersWithContext(ctx context.Context) ([]byte, error) {
 	var args [0]interface{}
 
 	var argsSerialized []byte
@@ -329,17 +411,17 @@ func (r *RootDomain) DumpAllUsers() ([]byte, error) {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "DumpAllUsers", err)
 	}
 
-	res, err := proxyctx.Current.RouteCall(r.Reference, true, "DumpAllUsers", argsSerialized, *PrototypeReference)
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "DumpAllUsers", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "DumpAllUsers", err)
 	}
 
 	err = proxyctx.Current.Deserialize(res, &ret)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "DumpAllUsers", err)
 	}
 
 	if ret1 != nil {
@@ -356,12 +438,12 @@ func (r *RootDomain) DumpAllUsersNoWait() error {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "DumpAllUsers", err)
 	}
 
-	_, err = proxyctx.Current.RouteCall(r.Reference, false, "DumpAllUsers", argsSerialized, *PrototypeReference)
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "DumpAllUsers", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "DumpAllUsers", err)
 	}
 
 	return nil
@@ -369,6 +451,11 @@ func (r *RootDomain) DumpAllUsersNoWait() error {
 
 // Info is proxy generated method
 func (r *RootDomain) Info() (interface{}, error) {
+	return r.InfoWithContext(context.Background())
+}
+
+// InfoWithContext is proxy generated method, call is aborted when ctx is done
+func (r *RootDomain) InfoWithContext(ctx context.Context) (interface{}, error) {
 	var args [0]interface{}
 
 	var argsSerialized []byte
@@ -381,17 +468,17 @@ func (r *RootDomain) Info() (interface{}, error) {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "Info", err)
 	}
 
-	res, err := proxyctx.Current.RouteCall(r.Reference, true, "Info", argsSerialized, *PrototypeReference)
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "Info", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "Info", err)
 	}
 
 	err = proxyctx.Current.Deserialize(res, &ret)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "Info", err)
 	}
 
 	if ret1 != nil {
@@ -408,12 +495,71 @@ func (r *RootDomain) InfoNoWait() error {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "Info", err)
+	}
+
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "Info", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "Info", err)
+	}
+
+	return nil
+}
+
+// GetLargeTransferPolicy is proxy generated method
+func (r *RootDomain) GetLargeTransferPolicy() (uint, uint, error) {
+	return r.GetLargeTransferPolicyWithContext(context.Background())
+}
+
+// GetLargeTransferPolicyWithContext is proxy generated method, call is aborted when ctx is done
+func (r *RootDomain) GetLargeTransferPolicyWithContext(ctx context.Context) (uint, uint, error) {
+	var args [0]interface{}
+
+	var argsSerialized []byte
+
+	ret := [3]interface{}{}
+	var ret0 uint
+	ret[0] = &ret0
+	var ret1 uint
+	ret[1] = &ret1
+	var ret2 *foundation.Error
+	ret[2] = &ret2
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return ret0, ret1, foundation.NewCallError(r.Reference, "GetLargeTransferPolicy", err)
+	}
+
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "GetLargeTransferPolicy", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return ret0, ret1, foundation.NewCallError(r.Reference, "GetLargeTransferPolicy", err)
+	}
+
+	err = proxyctx.Current.Deserialize(res, &ret)
+	if err != nil {
+		return ret0, ret1, foundation.NewCallError(r.Reference, "GetLargeTransferPolicy", err)
+	}
+
+	if ret2 != nil {
+		return ret0, ret1, ret2
+	}
+	return ret0, ret1, nil
+}
+
+// GetLargeTransferPolicyNoWait is proxy generated method
+func (r *RootDomain) GetLargeTransferPolicyNoWait() error {
+	var args [0]interface{}
+
+	var argsSerialized []byte
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "GetLargeTransferPolicy", err)
 	}
 
-	_, err = proxyctx.Current.RouteCall(r.Reference, false, "Info", argsSerialized, *PrototypeReference)
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "GetLargeTransferPolicy", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "GetLargeTransferPolicy", err)
 	}
 
 	return nil
@@ -421,6 +567,11 @@ func (r *RootDomain) InfoNoWait() error {
 
 // GetNodeDomainRef is proxy generated method
 func (r *RootDomain) GetNodeDomainRef() (core.RecordRef, error) {
+	return r.GetNodeDomainRefWithContext(context.Background())
+}
+
+// GetNodeDomainRefWithContext is proxy generated method, call is aborted when ctx is done
+func (r *RootDomain) GetNodeDomainRefWithContext(ctx context.Context) (core.RecordRef, error) {
 	var args [0]interface{}
 
 	var argsSerialized []byte
@@ -433,17 +584,17 @@ func (r *RootDomain) GetNodeDomainRef() (core.RecordRef, error) {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "GetNodeDomainRef", err)
 	}
 
-	res, err := proxyctx.Current.RouteCall(r.Reference, true, "GetNodeDomainRef", argsSerialized, *PrototypeReference)
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "GetNodeDomainRef", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "GetNodeDomainRef", err)
 	}
 
 	err = proxyctx.Current.Deserialize(res, &ret)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "GetNodeDomainRef", err)
 	}
 
 	if ret1 != nil {
@@ -460,12 +611,714 @@ func (r *RootDomain) GetNodeDomainRefNoWait() error {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "GetNodeDomainRef", err)
+	}
+
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "GetNodeDomainRef", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "GetNodeDomainRef", err)
+	}
+
+	return nil
+}
+
+// RegisterPrototype is proxy generated method
+func (r *RootDomain) RegisterPrototype(name string, prototype string) error {
+	return r.RegisterPrototypeWithContext(context.Background(), name, prototype)
+}
+
+// RegisterPrototypeWithContext is proxy generated method, call is aborted when ctx is done
+func (r *RootDomain) RegisterPrototypeWithContext(ctx context.Context, name string, prototype string) error {
+	var args [2]interface{}
+	args[0] = name
+	args[1] = prototype
+
+	var argsSerialized []byte
+
+	ret := [1]interface{}{}
+	var ret0 *foundation.Error
+	ret[0] = &ret0
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "RegisterPrototype", err)
+	}
+
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "RegisterPrototype", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "RegisterPrototype", err)
+	}
+
+	err = proxyctx.Current.Deserialize(res, &ret)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "RegisterPrototype", err)
+	}
+
+	if ret0 != nil {
+		return ret0
+	}
+	return nil
+}
+
+// RegisterPrototypeNoWait is proxy generated method
+func (r *RootDomain) RegisterPrototypeNoWait(name string, prototype string) error {
+	var args [2]interface{}
+	args[0] = name
+	args[1] = prototype
+
+	var argsSerialized []byte
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "RegisterPrototype", err)
+	}
+
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "RegisterPrototype", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "RegisterPrototype", err)
+	}
+
+	return nil
+}
+
+// GetPrototypeByName is proxy generated method
+func (r *RootDomain) GetPrototypeByName(name string) (string, error) {
+	return r.GetPrototypeByNameWithContext(context.Background(), name)
+}
+
+// GetPrototypeByNameWithContext is proxy generated method, call is aborted when ctx is done
+func (r *RootDomain) GetPrototypeByNameWithContext(ctx context.Context, name string) (string, error) {
+	var args [1]interface{}
+	args[0] = name
+
+	var argsSerialized []byte
+
+	ret := [2]interface{}{}
+	var ret0 string
+	ret[0] = &ret0
+	var ret1 *foundation.Error
+	ret[1] = &ret1
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return ret0, foundation.NewCallError(r.Reference, "GetPrototypeByName", err)
+	}
+
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "GetPrototypeByName", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return ret0, foundation.NewCallError(r.Reference, "GetPrototypeByName", err)
+	}
+
+	err = proxyctx.Current.Deserialize(res, &ret)
+	if err != nil {
+		return ret0, foundation.NewCallError(r.Reference, "GetPrototypeByName", err)
+	}
+
+	if ret1 != nil {
+		return ret0, ret1
+	}
+	return ret0, nil
+}
+
+// GetPrototypeByNameNoWait is proxy generated method
+func (r *RootDomain) GetPrototypeByNameNoWait(name string) error {
+	var args [1]interface{}
+	args[0] = name
+
+	var argsSerialized []byte
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "GetPrototypeByName", err)
+	}
+
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "GetPrototypeByName", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "GetPrototypeByName", err)
+	}
+
+	return nil
+}
+
+// ListPrototypes is proxy generated method
+func (r *RootDomain) ListPrototypes() (map[string]string, error) {
+	return r.ListPrototypesWithContext(context.Background())
+}
+
+// ListPrototypesWithContext is proxy generated method, call is aborted when ctx is done
+func (r *RootDomain) ListPrototypesWithContext(ctx context.Context) (map[string]string, error) {
+	var args [0]interface{}
+
+	var argsSerialized []byte
+
+	ret := [2]interface{}{}
+	var ret0 map[string]string
+	ret[0] = &ret0
+	var ret1 *foundation.Error
+	ret[1] = &ret1
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return ret0, foundation.NewCallError(r.Reference, "ListPrototypes", err)
+	}
+
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "ListPrototypes", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return ret0, foundation.NewCallError(r.Reference, "ListPrototypes", err)
+	}
+
+	err = proxyctx.Current.Deserialize(res, &ret)
+	if err != nil {
+		return ret0, foundation.NewCallError(r.Reference, "ListPrototypes", err)
+	}
+
+	if ret1 != nil {
+		return ret0, ret1
+	}
+	return ret0, nil
+}
+
+// ListPrototypesNoWait is proxy generated method
+func (r *RootDomain) ListPrototypesNoWait() error {
+	var args [0]interface{}
+
+	var argsSerialized []byte
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "ListPrototypes", err)
+	}
+
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "ListPrototypes", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "ListPrototypes", err)
+	}
+
+	return nil
+}
+
+// SetNetworkParameter is proxy generated method
+func (r *RootDomain) SetNetworkParameter(name string, value string) error {
+	return r.SetNetworkParameterWithContext(context.Background(), name, value)
+}
+
+// SetNetworkParameterWithContext is proxy generated method, call is aborted when ctx is done
+func (r *RootDomain) SetNetworkParameterWithContext(ctx context.Context, name string, value string) error {
+	var args [2]interface{}
+	args[0] = name
+	args[1] = value
+
+	var argsSerialized []byte
+
+	ret := [1]interface{}{}
+	var ret0 *foundation.Error
+	ret[0] = &ret0
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "SetNetworkParameter", err)
+	}
+
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "SetNetworkParameter", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "SetNetworkParameter", err)
+	}
+
+	err = proxyctx.Current.Deserialize(res, &ret)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "SetNetworkParameter", err)
+	}
+
+	if ret0 != nil {
+		return ret0
+	}
+	return nil
+}
+
+// SetNetworkParameterNoWait is proxy generated method
+func (r *RootDomain) SetNetworkParameterNoWait(name string, value string) error {
+	var args [2]interface{}
+	args[0] = name
+	args[1] = value
+
+	var argsSerialized []byte
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "SetNetworkParameter", err)
+	}
+
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "SetNetworkParameter", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "SetNetworkParameter", err)
+	}
+
+	return nil
+}
+
+// GetNetworkParameters is proxy generated method
+func (r *RootDomain) GetNetworkParameters() (map[string]string, error) {
+	return r.GetNetworkParametersWithContext(context.Background())
+}
+
+// GetNetworkParametersWithContext is proxy generated method, call is aborted when ctx is done
+func (r *RootDomain) GetNetworkParametersWithContext(ctx context.Context) (map[string]string, error) {
+	var args [0]interface{}
+
+	var argsSerialized []byte
+
+	ret := [2]interface{}{}
+	var ret0 map[string]string
+	ret[0] = &ret0
+	var ret1 *foundation.Error
+	ret[1] = &ret1
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return ret0, foundation.NewCallError(r.Reference, "GetNetworkParameters", err)
+	}
+
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "GetNetworkParameters", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return ret0, foundation.NewCallError(r.Reference, "GetNetworkParameters", err)
+	}
+
+	err = proxyctx.Current.Deserialize(res, &ret)
+	if err != nil {
+		return ret0, foundation.NewCallError(r.Reference, "GetNetworkParameters", err)
+	}
+
+	if ret1 != nil {
+		return ret0, ret1
+	}
+	return ret0, nil
+}
+
+// GetNetworkParametersNoWait is proxy generated method
+func (r *RootDomain) GetNetworkParametersNoWait() error {
+	var args [0]interface{}
+
+	var argsSerialized []byte
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "GetNetworkParameters", err)
+	}
+
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "GetNetworkParameters", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "GetNetworkParameters", err)
+	}
+
+	return nil
+}
+
+// SetMemberRole is proxy generated method
+func (r *RootDomain) SetMemberRole(reference string, role string) error {
+	return r.SetMemberRoleWithContext(context.Background(), reference, role)
+}
+
+// SetMemberRoleWithContext is proxy generated method, call is aborted when ctx is done
+func (r *RootDomain) SetMemberRoleWithContext(ctx context.Context, reference string, role string) error {
+	var args [2]interface{}
+	args[0] = reference
+	args[1] = role
+
+	var argsSerialized []byte
+
+	ret := [1]interface{}{}
+	var ret0 *foundation.Error
+	ret[0] = &ret0
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "SetMemberRole", err)
+	}
+
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "SetMemberRole", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "SetMemberRole", err)
+	}
+
+	err = proxyctx.Current.Deserialize(res, &ret)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "SetMemberRole", err)
+	}
+
+	if ret0 != nil {
+		return ret0
+	}
+	return nil
+}
+
+// SetMemberRoleNoWait is proxy generated method
+func (r *RootDomain) SetMemberRoleNoWait(reference string, role string) error {
+	var args [2]interface{}
+	args[0] = reference
+	args[1] = role
+
+	var argsSerialized []byte
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "SetMemberRole", err)
+	}
+
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "SetMemberRole", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "SetMemberRole", err)
+	}
+
+	return nil
+}
+
+// GetMemberRole is proxy generated method
+func (r *RootDomain) GetMemberRole(reference string) (string, error) {
+	return r.GetMemberRoleWithContext(context.Background(), reference)
+}
+
+// GetMemberRoleWithContext is proxy generated method, call is aborted when ctx is done
+func (r *RootDomain) GetMemberRoleWithContext(ctx context.Context, reference string) (string, error) {
+	var args [1]interface{}
+	args[0] = reference
+
+	var argsSerialized []byte
+
+	ret := [2]interface{}{}
+	var ret0 string
+	ret[0] = &ret0
+	var ret1 *foundation.Error
+	ret[1] = &ret1
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return ret0, foundation.NewCallError(r.Reference, "GetMemberRole", err)
+	}
+
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "GetMemberRole", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return ret0, foundation.NewCallError(r.Reference, "GetMemberRole", err)
+	}
+
+	err = proxyctx.Current.Deserialize(res, &ret)
+	if err != nil {
+		return ret0, foundation.NewCallError(r.Reference, "GetMemberRole", err)
+	}
+
+	if ret1 != nil {
+		return ret0, ret1
+	}
+	return ret0, nil
+}
+
+// GetMemberRoleNoWait is proxy generated method
+func (r *RootDomain) GetMemberRoleNoWait(reference string) error {
+	var args [1]interface{}
+	args[0] = reference
+
+	var argsSerialized []byte
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "GetMemberRole", err)
+	}
+
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "GetMemberRole", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "GetMemberRole", err)
+	}
+
+	return nil
+}
+
+// RegisterAlias is proxy generated method
+func (r *RootDomain) RegisterAlias(alias string, object string) error {
+	return r.RegisterAliasWithContext(context.Background(), alias, object)
+}
+
+// RegisterAliasWithContext is proxy generated method, call is aborted when ctx is done
+func (r *RootDomain) RegisterAliasWithContext(ctx context.Context, alias string, object string) error {
+	var args [2]interface{}
+	args[0] = alias
+	args[1] = object
+
+	var argsSerialized []byte
+
+	ret := [1]interface{}{}
+	var ret0 *foundation.Error
+	ret[0] = &ret0
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "RegisterAlias", err)
+	}
+
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "RegisterAlias", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "RegisterAlias", err)
+	}
+
+	err = proxyctx.Current.Deserialize(res, &ret)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "RegisterAlias", err)
+	}
+
+	if ret0 != nil {
+		return ret0
+	}
+	return nil
+}
+
+// RegisterAliasNoWait is proxy generated method
+func (r *RootDomain) RegisterAliasNoWait(alias string, object string) error {
+	var args [2]interface{}
+	args[0] = alias
+	args[1] = object
+
+	var argsSerialized []byte
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "RegisterAlias", err)
+	}
+
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "RegisterAlias", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "RegisterAlias", err)
+	}
+
+	return nil
+}
+
+// TransferAlias is proxy generated method
+func (r *RootDomain) TransferAlias(alias string, owner string) error {
+	return r.TransferAliasWithContext(context.Background(), alias, owner)
+}
+
+// TransferAliasWithContext is proxy generated method, call is aborted when ctx is done
+func (r *RootDomain) TransferAliasWithContext(ctx context.Context, alias string, owner string) error {
+	var args [2]interface{}
+	args[0] = alias
+	args[1] = owner
+
+	var argsSerialized []byte
+
+	ret := [1]interface{}{}
+	var ret0 *foundation.Error
+	ret[0] = &ret0
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "TransferAlias", err)
+	}
+
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "TransferAlias", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "TransferAlias", err)
+	}
+
+	err = proxyctx.Current.Deserialize(res, &ret)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "TransferAlias", err)
+	}
+
+	if ret0 != nil {
+		return ret0
+	}
+	return nil
+}
+
+// TransferAliasNoWait is proxy generated method
+func (r *RootDomain) TransferAliasNoWait(alias string, owner string) error {
+	var args [2]interface{}
+	args[0] = alias
+	args[1] = owner
+
+	var argsSerialized []byte
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "TransferAlias", err)
+	}
+
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "TransferAlias", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "TransferAlias", err)
+	}
+
+	return nil
+}
+
+// RemoveAlias is proxy generated method
+func (r *RootDomain) RemoveAlias(alias string) error {
+	return r.RemoveAliasWithContext(context.Background(), alias)
+}
+
+// RemoveAliasWithContext is proxy generated method, call is aborted when ctx is done
+func (r *RootDomain) RemoveAliasWithContext(ctx context.Context, alias string) error {
+	var args [1]interface{}
+	args[0] = alias
+
+	var argsSerialized []byte
+
+	ret := [1]interface{}{}
+	var ret0 *foundation.Error
+	ret[0] = &ret0
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "RemoveAlias", err)
+	}
+
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "RemoveAlias", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "RemoveAlias", err)
+	}
+
+	err = proxyctx.Current.Deserialize(res, &ret)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "RemoveAlias", err)
+	}
+
+	if ret0 != nil {
+		return ret0
+	}
+	return nil
+}
+
+// RemoveAliasNoWait is proxy generated method
+func (r *RootDomain) RemoveAliasNoWait(alias string) error {
+	var args [1]interface{}
+	args[0] = alias
+
+	var argsSerialized []byte
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "RemoveAlias", err)
+	}
+
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "RemoveAlias", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "RemoveAlias", err)
+	}
+
+	return nil
+}
+
+// ResolveAlias is proxy generated method
+func (r *RootDomain) ResolveAlias(alias string) (string, error) {
+	return r.ResolveAliasWithContext(context.Background(), alias)
+}
+
+// ResolveAliasWithContext is proxy generated method, call is aborted when ctx is done
+func (r *RootDomain) ResolveAliasWithContext(ctx context.Context, alias string) (string, error) {
+	var args [1]interface{}
+	args[0] = alias
+
+	var argsSerialized []byte
+
+	ret := [2]interface{}{}
+	var ret0 string
+	ret[0] = &ret0
+	var ret1 *foundation.Error
+	ret[1] = &ret1
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return ret0, foundation.NewCallError(r.Reference, "ResolveAlias", err)
+	}
+
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "ResolveAlias", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return ret0, foundation.NewCallError(r.Reference, "ResolveAlias", err)
+	}
+
+	err = proxyctx.Current.Deserialize(res, &ret)
+	if err != nil {
+		return ret0, foundation.NewCallError(r.Reference, "ResolveAlias", err)
+	}
+
+	if ret1 != nil {
+		return ret0, ret1
+	}
+	return ret0, nil
+}
+
+// ResolveAliasNoWait is proxy generated method
+func (r *RootDomain) ResolveAliasNoWait(alias string) error {
+	var args [1]interface{}
+	args[0] = alias
+
+	var argsSerialized []byte
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "ResolveAlias", err)
+	}
+
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "ResolveAlias", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "ResolveAlias", err)
+	}
+
+	return nil
+}
+
+// GetAliasOwner is proxy generated method
+func (r *RootDomain) GetAliasOwner(alias string) (string, error) {
+	return r.GetAliasOwnerWithContext(context.Background(), alias)
+}
+
+// GetAliasOwnerWithContext is proxy generated method, call is aborted when ctx is done
+func (r *RootDomain) GetAliasOwnerWithContext(ctx context.Context, alias string) (string, error) {
+	var args [1]interface{}
+	args[0] = alias
+
+	var argsSerialized []byte
+
+	ret := [2]interface{}{}
+	var ret0 string
+	ret[0] = &ret0
+	var ret1 *foundation.Error
+	ret[1] = &ret1
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return ret0, foundation.NewCallError(r.Reference, "GetAliasOwner", err)
+	}
+
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "GetAliasOwner", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return ret0, foundation.NewCallError(r.Reference, "GetAliasOwner", err)
+	}
+
+	err = proxyctx.Current.Deserialize(res, &ret)
+	if err != nil {
+		return ret0, foundation.NewCallError(r.Reference, "GetAliasOwner", err)
+	}
+
+	if ret1 != nil {
+		return ret0, ret1
+	}
+	return ret0, nil
+}
+
+// GetAliasOwnerNoWait is proxy generated method
+func (r *RootDomain) GetAliasOwnerNoWait(alias string) error {
+	var args [1]interface{}
+	args[0] = alias
+
+	var argsSerialized []byte
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "GetAliasOwner", err)
 	}
 
-	_, err = proxyctx.Current.RouteCall(r.Reference, false, "GetNodeDomainRef", argsSerialized, *PrototypeReference)
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "GetAliasOwner", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "GetAliasOwner", err)
 	}
 
 	return nil
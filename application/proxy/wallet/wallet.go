@@ -17,6 +17,7 @@
 package wallet
 
 import (
+	"context"
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
 	"github.com/insolar/insolar/logicrunner/goplugin/proxyctx"
@@ -24,7 +25,7 @@ import (
 
 // PrototypeReference to prototype of this contract
 // error checking hides in generator
-var PrototypeReference, _ = core.NewRefFromBase58("11112cpXtm7VKupDkbunmHxLBuKQ7t2oNHCD9LuDrEA.11111111111111111111111111111111")
+var PrototypeReference, _ = core.NewRefFromBase58("11112WbWXcz1ZX1saqNcZffJkVMcUWENtStnbxazaXF.11111111111111111111111111111111")
 
 // Wallet holds proxy type
 type Wallet struct {
@@ -104,14 +105,14 @@ func (r *Wallet) GetPrototype() (core.RecordRef, error) {
 		var ret1 *foundation.Error
 		ret[1] = &ret1
 
-		res, err := proxyctx.Current.RouteCall(r.Reference, true, "GetPrototype", make([]byte, 0), *PrototypeReference)
+		res, err := proxyctx.Current.RouteCall(context.Background(), r.Reference, true, "GetPrototype", make([]byte, 0), *PrototypeReference)
 		if err != nil {
-			return ret0, err
+			return ret0, foundation.NewCallError(r.Reference, "GetPrototype", err)
 		}
 
 		err = proxyctx.Current.Deserialize(res, &ret)
 		if err != nil {
-			return ret0, err
+			return ret0, foundation.NewCallError(r.Reference, "GetPrototype", err)
 		}
 
 		if ret1 != nil {
@@ -134,14 +135,14 @@ func (r *Wallet) GetCode() (core.RecordRef, error) {
 		var ret1 *foundation.Error
 		ret[1] = &ret1
 
-		res, err := proxyctx.Current.RouteCall(r.Reference, true, "GetCode", make([]byte, 0), *PrototypeReference)
+		res, err := proxyctx.Current.RouteCall(context.Background(), r.Reference, true, "GetCode", make([]byte, 0), *PrototypeReference)
 		if err != nil {
-			return ret0, err
+			return ret0, foundation.NewCallError(r.Reference, "GetCode", err)
 		}
 
 		err = proxyctx.Current.Deserialize(res, &ret)
 		if err != nil {
-			return ret0, err
+			return ret0, foundation.NewCallError(r.Reference, "GetCode", err)
 		}
 
 		if ret1 != nil {
@@ -156,6 +157,11 @@ func (r *Wallet) GetCode() (core.RecordRef, error) {
 
 // Transfer is proxy generated method
 func (r *Wallet) Transfer(amount uint, to *core.RecordRef) error {
+	return r.TransferWithContext(context.Background(), amount, to)
+}
+
+// TransferWithContext is proxy generated method, call is aborted when ctx is done
+func (r *Wallet) TransferWithContext(ctx context.Context, amount uint, to *core.RecordRef) error {
 	var args [2]interface{}
 	args[0] = amount
 	args[1] = to
@@ -168,17 +174,17 @@ func (r *Wallet) Transfer(amount uint, to *core.RecordRef) error {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "Transfer", err)
 	}
 
-	res, err := proxyctx.Current.RouteCall(r.Reference, true, "Transfer", argsSerialized, *PrototypeReference)
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "Transfer", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "Transfer", err)
 	}
 
 	err = proxyctx.Current.Deserialize(res, &ret)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "Transfer", err)
 	}
 
 	if ret0 != nil {
@@ -197,12 +203,132 @@ func (r *Wallet) TransferNoWait(amount uint, to *core.RecordRef) error {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "Transfer", err)
+	}
+
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "Transfer", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "Transfer", err)
+	}
+
+	return nil
+}
+
+// TransferWithConfirmation is proxy generated method
+func (r *Wallet) TransferWithConfirmation(amount uint, to *core.RecordRef, expirePulse core.PulseNumber) (core.RecordRef, error) {
+	return r.TransferWithConfirmationWithContext(context.Background(), amount, to, expirePulse)
+}
+
+// TransferWithConfirmationWithContext is proxy generated method, call is aborted when ctx is done
+func (r *Wallet) TransferWithConfirmationWithContext(ctx context.Context, amount uint, to *core.RecordRef, expirePulse core.PulseNumber) (core.RecordRef, error) {
+	var args [3]interface{}
+	args[0] = amount
+	args[1] = to
+	args[2] = expirePulse
+
+	var argsSerialized []byte
+
+	ret := [2]interface{}{}
+	var ret0 core.RecordRef
+	ret[0] = &ret0
+	var ret1 *foundation.Error
+	ret[1] = &ret1
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return ret0, foundation.NewCallError(r.Reference, "TransferWithConfirmation", err)
+	}
+
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "TransferWithConfirmation", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return ret0, foundation.NewCallError(r.Reference, "TransferWithConfirmation", err)
+	}
+
+	err = proxyctx.Current.Deserialize(res, &ret)
+	if err != nil {
+		return ret0, foundation.NewCallError(r.Reference, "TransferWithConfirmation", err)
+	}
+
+	if ret1 != nil {
+		return ret0, ret1
+	}
+	return ret0, nil
+}
+
+// TransferWithConfirmationNoWait is proxy generated method
+func (r *Wallet) TransferWithConfirmationNoWait(amount uint, to *core.RecordRef, expirePulse core.PulseNumber) error {
+	var args [3]interface{}
+	args[0] = amount
+	args[1] = to
+	args[2] = expirePulse
+
+	var argsSerialized []byte
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "TransferWithConfirmation", err)
 	}
 
-	_, err = proxyctx.Current.RouteCall(r.Reference, false, "Transfer", argsSerialized, *PrototypeReference)
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "TransferWithConfirmation", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "TransferWithConfirmation", err)
+	}
+
+	return nil
+}
+
+// ConfirmTransfer is proxy generated method
+func (r *Wallet) ConfirmTransfer(transferRef *core.RecordRef) error {
+	return r.ConfirmTransferWithContext(context.Background(), transferRef)
+}
+
+// ConfirmTransferWithContext is proxy generated method, call is aborted when ctx is done
+func (r *Wallet) ConfirmTransferWithContext(ctx context.Context, transferRef *core.RecordRef) error {
+	var args [1]interface{}
+	args[0] = transferRef
+
+	var argsSerialized []byte
+
+	ret := [1]interface{}{}
+	var ret0 *foundation.Error
+	ret[0] = &ret0
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "ConfirmTransfer", err)
+	}
+
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "ConfirmTransfer", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "ConfirmTransfer", err)
+	}
+
+	err = proxyctx.Current.Deserialize(res, &ret)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "ConfirmTransfer", err)
+	}
+
+	if ret0 != nil {
+		return ret0
+	}
+	return nil
+}
+
+// ConfirmTransferNoWait is proxy generated method
+func (r *Wallet) ConfirmTransferNoWait(transferRef *core.RecordRef) error {
+	var args [1]interface{}
+	args[0] = transferRef
+
+	var argsSerialized []byte
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "ConfirmTransfer", err)
+	}
+
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "ConfirmTransfer", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return foundation.NewCallError(r.Reference, "ConfirmTransfer", err)
 	}
 
 	return nil
@@ -210,6 +336,11 @@ func (r *Wallet) TransferNoWait(amount uint, to *core.RecordRef) error {
 
 // Accept is proxy generated method
 func (r *Wallet) Accept(aRef *core.RecordRef) error {
+	return r.AcceptWithContext(context.Background(), aRef)
+}
+
+// AcceptWithContext is proxy generated method, call is aborted when ctx is done
+func (r *Wallet) AcceptWithContext(ctx context.Context, aRef *core.RecordRef) error {
 	var args [1]interface{}
 	args[0] = aRef
 
@@ -221,17 +352,17 @@ func (r *Wallet) Accept(aRef *core.RecordRef) error {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "Accept", err)
 	}
 
-	res, err := proxyctx.Current.RouteCall(r.Reference, true, "Accept", argsSerialized, *PrototypeReference)
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "Accept", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "Accept", err)
 	}
 
 	err = proxyctx.Current.Deserialize(res, &ret)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "Accept", err)
 	}
 
 	if ret0 != nil {
@@ -249,12 +380,12 @@ func (r *Wallet) AcceptNoWait(aRef *core.RecordRef) error {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "Accept", err)
 	}
 
-	_, err = proxyctx.Current.RouteCall(r.Reference, false, "Accept", argsSerialized, *PrototypeReference)
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "Accept", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "Accept", err)
 	}
 
 	return nil
@@ -262,6 +393,11 @@ func (r *Wallet) AcceptNoWait(aRef *core.RecordRef) error {
 
 // GetBalance is proxy generated method
 func (r *Wallet) GetBalance() (uint, error) {
+	return r.GetBalanceWithContext(context.Background())
+}
+
+// GetBalanceWithContext is proxy generated method, call is aborted when ctx is done
+func (r *Wallet) GetBalanceWithContext(ctx context.Context) (uint, error) {
 	var args [0]interface{}
 
 	var argsSerialized []byte
@@ -274,17 +410,17 @@ func (r *Wallet) GetBalance() (uint, error) {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "GetBalance", err)
 	}
 
-	res, err := proxyctx.Current.RouteCall(r.Reference, true, "GetBalance", argsSerialized, *PrototypeReference)
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "GetBalance", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "GetBalance", err)
 	}
 
 	err = proxyctx.Current.Deserialize(res, &ret)
 	if err != nil {
-		return ret0, err
+		return ret0, foundation.NewCallError(r.Reference, "GetBalance", err)
 	}
 
 	if ret1 != nil {
@@ -301,12 +437,12 @@ func (r *Wallet) GetBalanceNoWait() error {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "GetBalance", err)
 	}
 
-	_, err = proxyctx.Current.RouteCall(r.Reference, false, "GetBalance", argsSerialized, *PrototypeReference)
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "GetBalance", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "GetBalance", err)
 	}
 
 	return nil
@@ -25,6 +25,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/log"
@@ -93,9 +94,19 @@ type Certificate struct {
 	PulsarPublicKeys    []string        `json:"pulsar_public_keys"`
 	RootDomainReference string          `json:"root_domain_ref"`
 	BootstrapNodes      []BootstrapNode `json:"bootstrap_nodes"`
+	// FailureDomains maps node reference to failure domain (zone, operator, datacenter) declared by network operators
+	FailureDomains map[string]string `json:"failure_domains,omitempty"`
+	// PulseDuration limits pulse duration (in seconds) pulsars may set, zero bound isn't checked
+	PulseDuration struct {
+		Min uint32 `json:"min"`
+		Max uint32 `json:"max"`
+	} `json:"pulse_duration"`
+	// ExpiresAt is a unix time after which certificate should be renewed, zero means certificate never expires
+	ExpiresAt int64 `json:"expires_at,omitempty"`
 
 	// preprocessed fields
 	pulsarPublicKey []crypto.PublicKey
+	failureDomains  map[core.RecordRef]string
 }
 
 func newCertificate(publicKey crypto.PublicKey, keyProcessor core.KeyProcessor, data []byte) (*Certificate, error) {
@@ -141,6 +152,21 @@ func (cert *Certificate) SerializeNetworkPart() []byte {
 	sort.Strings(nodes)
 	out += strings.Join(nodes, "")
 
+	domains := make([]string, 0, len(cert.FailureDomains))
+	for node, domain := range cert.FailureDomains {
+		domains = append(domains, node+domain)
+	}
+	sort.Strings(domains)
+	out += strings.Join(domains, "")
+
+	if cert.PulseDuration.Min != 0 || cert.PulseDuration.Max != 0 {
+		out += strconv.Itoa(int(cert.PulseDuration.Min)) + "-" + strconv.Itoa(int(cert.PulseDuration.Max))
+	}
+
+	if cert.ExpiresAt != 0 {
+		out += strconv.FormatInt(cert.ExpiresAt, 10)
+	}
+
 	return []byte(out)
 }
 
@@ -178,6 +204,24 @@ func (cert *Certificate) fillExtraFields(keyProcessor core.KeyProcessor) error {
 		currentNode.nodePublicKey = importedBNodePubKey
 	}
 
+	if cert.PulseDuration.Max != 0 && cert.PulseDuration.Min > cert.PulseDuration.Max {
+		return errors.Errorf(
+			"[ fillExtraFields ] Min pulse duration %d is greater than max %d",
+			cert.PulseDuration.Min, cert.PulseDuration.Max,
+		)
+	}
+
+	if len(cert.FailureDomains) > 0 {
+		cert.failureDomains = make(map[core.RecordRef]string, len(cert.FailureDomains))
+		for node, domain := range cert.FailureDomains {
+			ref, err := core.NewRefFromBase58(node)
+			if err != nil {
+				return errors.Wrapf(err, "[ fillExtraFields ] Bad failure domain node reference: %s", node)
+			}
+			cert.failureDomains[*ref] = domain
+		}
+	}
+
 	return nil
 }
 
@@ -201,6 +245,24 @@ func (cert *Certificate) GetDiscoveryNodes() []core.DiscoveryNode {
 	return result
 }
 
+// GetFailureDomains returns failure domains of nodes declared in certificate
+func (cert *Certificate) GetFailureDomains() map[core.RecordRef]string {
+	return cert.failureDomains
+}
+
+// GetPulseDurationBounds returns limits of pulse duration declared in certificate
+func (cert *Certificate) GetPulseDurationBounds() (min, max time.Duration) {
+	return time.Duration(cert.PulseDuration.Min) * time.Second, time.Duration(cert.PulseDuration.Max) * time.Second
+}
+
+// GetExpiration returns time after which certificate should be renewed, zero time means certificate never expires
+func (cert *Certificate) GetExpiration() time.Time {
+	if cert.ExpiresAt == 0 {
+		return time.Time{}
+	}
+	return time.Unix(cert.ExpiresAt, 0)
+}
+
 // Dump returns all info about certificate in json format
 func (cert *Certificate) Dump() (string, error) {
 	result, err := json.MarshalIndent(cert, "", "    ")
@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/cryptography"
@@ -246,3 +247,91 @@ func TestSerializeDeserialize(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, cert, deserializedCert)
 }
+
+func TestReadCertificateFromReader_FailureDomains(t *testing.T) {
+	kp := platformpolicy.NewKeyProcessor()
+	privateKey, _ := kp.GeneratePrivateKey()
+	nodePublicKey := kp.ExtractPublicKey(privateKey)
+	publicKey, _ := kp.ExportPublicKeyPEM(nodePublicKey)
+	node := "1tJDXVqB8L4AwVGdQKoECgM2TJeMZV2otQuw5X4Uta.1tJEEuxPAn8JgS3dxxxYnLASSHEeb54DpwiGntisn6"
+
+	info := map[string]interface{}{
+		"public_key":      string(publicKey),
+		"failure_domains": map[string]string{node: "zone-1"},
+	}
+	certJson, err := json.Marshal(info)
+	require.NoError(t, err)
+
+	cert, err := ReadCertificateFromReader(nodePublicKey, kp, bytes.NewReader(certJson))
+	require.NoError(t, err)
+	ref, err := core.NewRefFromBase58(node)
+	require.NoError(t, err)
+	require.Equal(t, map[core.RecordRef]string{*ref: "zone-1"}, cert.GetFailureDomains())
+	require.Contains(t, string(cert.SerializeNetworkPart()), node+"zone-1")
+
+	info["failure_domains"] = map[string]string{"not a ref": "zone-1"}
+	certJson, err = json.Marshal(info)
+	require.NoError(t, err)
+	_, err = ReadCertificateFromReader(nodePublicKey, kp, bytes.NewReader(certJson))
+	require.Error(t, err)
+}
+
+func TestReadCertificateFromReader_PulseDuration(t *testing.T) {
+	kp := platformpolicy.NewKeyProcessor()
+	privateKey, _ := kp.GeneratePrivateKey()
+	nodePublicKey := kp.ExtractPublicKey(privateKey)
+	publicKey, _ := kp.ExportPublicKeyPEM(nodePublicKey)
+
+	info := map[string]interface{}{
+		"public_key": string(publicKey),
+	}
+	certJson, err := json.Marshal(info)
+	require.NoError(t, err)
+	cert, err := ReadCertificateFromReader(nodePublicKey, kp, bytes.NewReader(certJson))
+	require.NoError(t, err)
+	withoutBounds := cert.SerializeNetworkPart()
+	min, max := cert.GetPulseDurationBounds()
+	require.Zero(t, min)
+	require.Zero(t, max)
+
+	info["pulse_duration"] = map[string]uint32{"min": 5, "max": 20}
+	certJson, err = json.Marshal(info)
+	require.NoError(t, err)
+	cert, err = ReadCertificateFromReader(nodePublicKey, kp, bytes.NewReader(certJson))
+	require.NoError(t, err)
+	min, max = cert.GetPulseDurationBounds()
+	require.Equal(t, 5*time.Second, min)
+	require.Equal(t, 20*time.Second, max)
+	require.NotEqual(t, withoutBounds, cert.SerializeNetworkPart())
+
+	info["pulse_duration"] = map[string]uint32{"min": 20, "max": 5}
+	certJson, err = json.Marshal(info)
+	require.NoError(t, err)
+	_, err = ReadCertificateFromReader(nodePublicKey, kp, bytes.NewReader(certJson))
+	require.Error(t, err)
+}
+
+func TestReadCertificateFromReader_Expiration(t *testing.T) {
+	kp := platformpolicy.NewKeyProcessor()
+	privateKey, _ := kp.GeneratePrivateKey()
+	nodePublicKey := kp.ExtractPublicKey(privateKey)
+	publicKey, _ := kp.ExportPublicKeyPEM(nodePublicKey)
+
+	info := map[string]interface{}{
+		"public_key": string(publicKey),
+	}
+	certJson, err := json.Marshal(info)
+	require.NoError(t, err)
+	cert, err := ReadCertificateFromReader(nodePublicKey, kp, bytes.NewReader(certJson))
+	require.NoError(t, err)
+	withoutExpiration := cert.SerializeNetworkPart()
+	require.True(t, cert.GetExpiration().IsZero())
+
+	info["expires_at"] = 1577836800
+	certJson, err = json.Marshal(info)
+	require.NoError(t, err)
+	cert, err = ReadCertificateFromReader(nodePublicKey, kp, bytes.NewReader(certJson))
+	require.NoError(t, err)
+	require.Equal(t, time.Unix(1577836800, 0), cert.GetExpiration())
+	require.NotEqual(t, withoutExpiration, cert.SerializeNetworkPart())
+}
@@ -0,0 +1,93 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package certificate
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+)
+
+// NewEphemeralCertificate creates certificate of node with key of cs, provided role and random reference.
+// Network part is taken from template certificate, node part is signed by the node itself instead of discovery
+// nodes (see IsSelfSigned). Such certificates are for test networks only.
+func NewEphemeralCertificate(
+	cs core.CryptographyService,
+	keyProcessor core.KeyProcessor,
+	role core.StaticRole,
+	template io.Reader,
+) (*Certificate, error) {
+	if role == core.StaticRoleUnknown {
+		return nil, errors.New("[ NewEphemeralCertificate ] unknown node role")
+	}
+	data, err := ioutil.ReadAll(template)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NewEphemeralCertificate ] failed to read certificate template")
+	}
+	cert := Certificate{}
+	err = json.Unmarshal(data, &cert)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NewEphemeralCertificate ] failed to parse certificate template json")
+	}
+
+	publicKey, err := cs.GetPublicKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NewEphemeralCertificate ] failed to retrieve node public key")
+	}
+	pub, err := keyProcessor.ExportPublicKeyPEM(publicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NewEphemeralCertificate ] failed to export node public key")
+	}
+	cert.PublicKey = string(pub)
+	cert.Reference = testutils.RandomRef().String()
+	cert.Role = role.String()
+	for i := range cert.BootstrapNodes {
+		cert.BootstrapNodes[i].NodeSign = nil
+	}
+
+	err = cert.fillExtraFields(keyProcessor)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NewEphemeralCertificate ] Incorrect fields")
+	}
+
+	sign, err := cs.Sign(cert.SerializeNodePart())
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NewEphemeralCertificate ] failed to sign node part")
+	}
+	cert.DiscoverySigns = map[core.RecordRef][]byte{*cert.GetNodeRef(): sign.Bytes()}
+
+	return &cert, nil
+}
+
+// IsSelfSigned checks if node part of certificate is signed by the node itself only, as ephemeral certificates are.
+func IsSelfSigned(authCert core.AuthorizationCertificate) bool {
+	ref := authCert.GetNodeRef()
+	signs := authCert.GetDiscoverySigns()
+	if ref == nil || authCert.GetPublicKey() == nil || len(signs) != 1 {
+		return false
+	}
+	sign, ok := signs[*ref]
+	if !ok {
+		return false
+	}
+	return scheme.Verifier(authCert.GetPublicKey()).Verify(core.SignatureFromBytes(sign), authCert.SerializeNodePart())
+}
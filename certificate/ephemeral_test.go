@@ -0,0 +1,75 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package certificate
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/cryptography"
+	"github.com/insolar/insolar/platformpolicy"
+)
+
+func TestNewEphemeralCertificate(t *testing.T) {
+	kp := platformpolicy.NewKeyProcessor()
+	key, err := kp.GeneratePrivateKey()
+	require.NoError(t, err)
+	cs := cryptography.NewKeyBoundCryptographyService(key)
+
+	template, err := os.Open(TestCert)
+	require.NoError(t, err)
+	defer template.Close()
+
+	cert, err := NewEphemeralCertificate(cs, kp, core.StaticRoleLightMaterial, template)
+	require.NoError(t, err)
+	checkKeys(cert, cs, t)
+	require.Equal(t, core.StaticRoleLightMaterial, cert.GetRole())
+	require.NotEqual(t, "1474sCnj1DggSggkNZLry55pcbjWhSss1WSXj6W9XwhT.1tJEEuxPAn8JgS3dxxxYnLASSHEeb54DpwiGntisn6",
+		cert.Reference)
+	require.Equal(t, 7, cert.MajorityRule)
+	require.Len(t, cert.GetDiscoveryNodes(), 3)
+	require.True(t, IsSelfSigned(cert))
+
+	// discovery node checks certificate deserialized from authorization request
+	data, err := Serialize(cert)
+	require.NoError(t, err)
+	authCert, err := Deserialize(data, kp)
+	require.NoError(t, err)
+	require.True(t, IsSelfSigned(authCert))
+
+	_, err = NewEphemeralCertificate(cs, kp, core.StaticRoleUnknown, strings.NewReader("{}"))
+	require.Error(t, err)
+}
+
+func TestIsSelfSigned_DiscoverySigned(t *testing.T) {
+	cs, err := cryptography.NewStorageBoundCryptographyService(TestKeys)
+	require.NoError(t, err)
+	pk, err := cs.GetPublicKey()
+	require.NoError(t, err)
+
+	cert, err := ReadCertificate(pk, platformpolicy.NewKeyProcessor(), TestCert)
+	require.NoError(t, err)
+	require.False(t, IsSelfSigned(cert))
+
+	// forged self sign
+	cert.DiscoverySigns = map[core.RecordRef][]byte{*cert.GetNodeRef(): []byte("forged")}
+	require.False(t, IsSelfSigned(cert))
+}
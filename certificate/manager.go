@@ -19,6 +19,7 @@ package certificate
 import (
 	"crypto"
 	"io"
+	"sync"
 
 	"github.com/insolar/insolar/core"
 	"github.com/pkg/errors"
@@ -27,6 +28,7 @@ import (
 // CertificateManager is a component for working with current node certificate
 type CertificateManager struct {
 	CS          core.CryptographyService `inject:""`
+	lock        sync.RWMutex
 	certificate core.Certificate
 }
 
@@ -37,12 +39,22 @@ func NewCertificateManager(cert core.Certificate) *CertificateManager {
 
 // GetCertificate returns current node certificate
 func (m *CertificateManager) GetCertificate() core.Certificate {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
 	return m.certificate
 }
 
+func (m *CertificateManager) setCertificate(cert core.Certificate) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.certificate = cert
+}
+
 // VerifyAuthorizationCertificate verifies certificate from some node
 func (m *CertificateManager) VerifyAuthorizationCertificate(authCert core.AuthorizationCertificate) (bool, error) {
-	discoveryNodes := m.certificate.GetDiscoveryNodes()
+	discoveryNodes := m.GetCertificate().GetDiscoveryNodes()
 	if len(discoveryNodes) != len(authCert.GetDiscoverySigns()) {
 		return false, nil
 	}
@@ -59,7 +71,7 @@ func (m *CertificateManager) VerifyAuthorizationCertificate(authCert core.Author
 
 // NewUnsignedCertificate returns new certificate
 func (m *CertificateManager) NewUnsignedCertificate(pKey string, role string, ref string) (core.Certificate, error) {
-	cert := m.certificate.(*Certificate)
+	cert := m.GetCertificate().(*Certificate)
 	newCert := Certificate{
 		MajorityRule: cert.MajorityRule,
 		MinRoles:     cert.MinRoles,
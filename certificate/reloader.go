@@ -0,0 +1,166 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package certificate
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// Reloader is a component which re-reads node certificate and keys from disk after renewal.
+// New identity is validated before switching: it must belong to the same node and be trusted by current discovery nodes.
+type Reloader struct {
+	KeyStore     core.KeyStore     `inject:""`
+	KeyProcessor core.KeyProcessor `inject:""`
+	NodeNetwork  core.NodeNetwork  `inject:""`
+
+	manager  *CertificateManager
+	certPath string
+	lock     sync.Mutex
+}
+
+// NewReloader creates Reloader which replaces certificate of manager by certificate from certPath.
+func NewReloader(manager *CertificateManager, certPath string) *Reloader {
+	return &Reloader{manager: manager, certPath: certPath}
+}
+
+// ReloadIdentity reads keys and certificate, checks them and switches node to them.
+func (r *Reloader) ReloadIdentity(ctx context.Context) (core.Certificate, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	keyStore, ok := r.KeyStore.(core.ReloadableKeyStore)
+	if !ok {
+		return nil, errors.New("[ ReloadIdentity ] key store doesn't support reload")
+	}
+	privateKey, err := keyStore.ReadPrivateKey("")
+	if err != nil {
+		return nil, errors.Wrap(err, "[ ReloadIdentity ] failed to read keys")
+	}
+	publicKey := r.KeyProcessor.ExtractPublicKey(privateKey)
+
+	// certificate is read with new public key, so keys and certificate are checked to be a pair
+	cert, err := ReadCertificate(publicKey, r.KeyProcessor, r.certPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ ReloadIdentity ] failed to read certificate")
+	}
+	err = r.checkIdentity(cert, publicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ ReloadIdentity ] new identity is rejected")
+	}
+
+	keyStore.SetPrivateKey("", privateKey)
+	r.manager.setCertificate(cert)
+
+	inslogger.FromContext(ctx).Infof("[ ReloadIdentity ] certificate and keys of node %s are reloaded from %s", cert.GetNodeRef(), r.certPath)
+	return cert, nil
+}
+
+// expiryCheckInterval is an interval between checks of certificate expiration.
+const expiryCheckInterval = time.Hour
+
+// expiryWarningPeriod is a period before certificate expiration when operator is warned to renew it.
+const expiryWarningPeriod = 7 * 24 * time.Hour
+
+// PeriodicTasks returns check of certificate expiration to be run by scheduler.
+func (r *Reloader) PeriodicTasks() []core.PeriodicTask {
+	return []core.PeriodicTask{{
+		Name:     "certificate.expiry",
+		Schedule: core.TaskSchedule{Every: expiryCheckInterval},
+		Run:      r.checkExpiration,
+	}}
+}
+
+// checkExpiration warns when certificate expires soon and fails when it is expired, so scheduler reports the error.
+func (r *Reloader) checkExpiration(ctx context.Context) error {
+	provider, ok := r.manager.GetCertificate().(core.CertificateExpirationProvider)
+	if !ok {
+		return nil
+	}
+	expiration := provider.GetExpiration()
+	if expiration.IsZero() {
+		return nil
+	}
+	left := time.Until(expiration)
+	if left <= 0 {
+		return errors.Errorf("[ checkExpiration ] certificate expired at %s", expiration)
+	}
+	if left < expiryWarningPeriod {
+		inslogger.FromContext(ctx).Warnf("[ checkExpiration ] certificate expires at %s, renew and reload it", expiration)
+	}
+	return nil
+}
+
+func (r *Reloader) checkIdentity(cert *Certificate, publicKey crypto.PublicKey) error {
+	current := r.manager.GetCertificate()
+	if !cert.GetNodeRef().Equal(*current.GetNodeRef()) {
+		return errors.Errorf("node reference is changed from %s to %s", current.GetNodeRef(), cert.GetNodeRef())
+	}
+	if cert.GetRole() != current.GetRole() {
+		return errors.Errorf("node role is changed from %s to %s", current.GetRole(), cert.GetRole())
+	}
+	expiration := cert.GetExpiration()
+	if !expiration.IsZero() && expiration.Before(time.Now()) {
+		return errors.Errorf("certificate expired at %s", expiration)
+	}
+
+	// other nodes know node by public key from its join claim, so new keys are accepted only after rejoin
+	same, err := r.sameKeys(r.NodeNetwork.GetOrigin().PublicKey(), publicKey)
+	if err != nil {
+		return err
+	}
+	if !same {
+		return errors.New("public key differs from key node is registered with in network, restart node to rejoin with new keys")
+	}
+
+	// certificate is checked the same way as discovery nodes check it on join, unless current one isn't valid either
+	valid, err := r.manager.VerifyAuthorizationCertificate(current)
+	if err != nil {
+		return errors.Wrap(err, "failed to verify current certificate")
+	}
+	if !valid {
+		return nil
+	}
+	valid, err = r.manager.VerifyAuthorizationCertificate(cert)
+	if err != nil {
+		return errors.Wrap(err, "failed to verify certificate")
+	}
+	if !valid {
+		return errors.New("certificate isn't signed by discovery nodes")
+	}
+	return nil
+}
+
+func (r *Reloader) sameKeys(a, b crypto.PublicKey) (bool, error) {
+	aPEM, err := r.KeyProcessor.ExportPublicKeyPEM(a)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to export public key")
+	}
+	bPEM, err := r.KeyProcessor.ExportPublicKeyPEM(b)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to export public key")
+	}
+	return bytes.Equal(aPEM, bPEM), nil
+}
@@ -0,0 +1,151 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package certificate
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gojuno/minimock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+	"github.com/insolar/insolar/testutils/network"
+)
+
+type reloadableKeyStore struct {
+	current crypto.PrivateKey
+	next    crypto.PrivateKey
+}
+
+func (ks *reloadableKeyStore) GetPrivateKey(string) (crypto.PrivateKey, error) {
+	return ks.current, nil
+}
+
+func (ks *reloadableKeyStore) ReadPrivateKey(string) (crypto.PrivateKey, error) {
+	return ks.next, nil
+}
+
+func (ks *reloadableKeyStore) SetPrivateKey(_ string, key crypto.PrivateKey) {
+	ks.current = key
+}
+
+func writeCertificate(t *testing.T, path string, info map[string]interface{}) {
+	data, err := json.Marshal(info)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(path, data, 0600))
+}
+
+func TestReloader_ReloadIdentity(t *testing.T) {
+	ctx := context.Background()
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	dir, err := ioutil.TempDir("", "reloader")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	certPath := filepath.Join(dir, "cert.json")
+
+	kp := platformpolicy.NewKeyProcessor()
+	privateKey, _ := kp.GeneratePrivateKey()
+	publicKey := kp.ExtractPublicKey(privateKey)
+	publicKeyPEM, _ := kp.ExportPublicKeyPEM(publicKey)
+	ref := testutils.RandomRef()
+
+	info := map[string]interface{}{
+		"public_key": string(publicKeyPEM),
+		"reference":  ref.String(),
+		"role":       "virtual",
+	}
+	writeCertificate(t, certPath, info)
+	current, err := ReadCertificate(publicKey, kp, certPath)
+	require.NoError(t, err)
+	manager := NewCertificateManager(current)
+
+	origin := network.NewNodeMock(mc)
+	origin.PublicKeyMock.Return(publicKey)
+	nodeNetwork := network.NewNodeNetworkMock(mc)
+	nodeNetwork.GetOriginMock.Return(origin)
+	keyStore := &reloadableKeyStore{current: privateKey, next: privateKey}
+
+	reloader := NewReloader(manager, certPath)
+	reloader.KeyStore = keyStore
+	reloader.KeyProcessor = kp
+	reloader.NodeNetwork = nodeNetwork
+
+	// renewed certificate is accepted
+	expiresAt := time.Now().Add(time.Hour).Unix()
+	info["expires_at"] = expiresAt
+	writeCertificate(t, certPath, info)
+	cert, err := reloader.ReloadIdentity(ctx)
+	require.NoError(t, err)
+	require.Equal(t, cert, manager.GetCertificate())
+	require.Equal(t, time.Unix(expiresAt, 0), manager.GetCertificate().(core.CertificateExpirationProvider).GetExpiration())
+
+	// certificate of another node
+	info["reference"] = testutils.RandomRef().String()
+	writeCertificate(t, certPath, info)
+	_, err = reloader.ReloadIdentity(ctx)
+	require.Contains(t, err.Error(), "node reference is changed")
+	info["reference"] = ref.String()
+
+	// expired certificate
+	info["expires_at"] = time.Now().Add(-time.Hour).Unix()
+	writeCertificate(t, certPath, info)
+	_, err = reloader.ReloadIdentity(ctx)
+	require.Contains(t, err.Error(), "certificate expired")
+	delete(info, "expires_at")
+
+	// keys don't match certificate
+	otherKey, _ := kp.GeneratePrivateKey()
+	keyStore.next = otherKey
+	writeCertificate(t, certPath, info)
+	_, err = reloader.ReloadIdentity(ctx)
+	require.Contains(t, err.Error(), "Different public keys")
+
+	// keys are rotated, but network knows node by old key
+	otherPEM, _ := kp.ExportPublicKeyPEM(kp.ExtractPublicKey(otherKey))
+	info["public_key"] = string(otherPEM)
+	writeCertificate(t, certPath, info)
+	_, err = reloader.ReloadIdentity(ctx)
+	require.Contains(t, err.Error(), "restart node to rejoin")
+
+	// rejected reloads keep current identity
+	require.Equal(t, cert, manager.GetCertificate())
+	require.Equal(t, privateKey, keyStore.current)
+}
+
+func TestReloader_checkExpiration(t *testing.T) {
+	ctx := context.Background()
+	cert := &Certificate{}
+	reloader := NewReloader(NewCertificateManager(cert), "")
+	require.Len(t, reloader.PeriodicTasks(), 1)
+
+	require.NoError(t, reloader.checkExpiration(ctx), "certificate without expiration")
+	cert.ExpiresAt = time.Now().Add(time.Hour).Unix()
+	require.NoError(t, reloader.checkExpiration(ctx), "expiring certificate is only warned about")
+	cert.ExpiresAt = time.Now().Add(-time.Hour).Unix()
+	require.Contains(t, reloader.checkExpiration(ctx).Error(), "certificate expired at")
+}
@@ -0,0 +1,260 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package certificate
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+)
+
+// revocationListPulses is a number of pulses revocation list of discovery node is kept without refresh.
+const revocationListPulses = 3
+
+// Revocations keeps revocation list of this node, if it's discovery one, and verified revocation lists
+// of discovery nodes. Certificate of node is revoked when it's listed by majority of discovery nodes, so single
+// compromised discovery node can't expel others.
+type Revocations struct {
+	certificate core.Certificate
+	cs          core.CryptographyService
+
+	lock  sync.RWMutex
+	local map[core.RecordRef]struct{}
+	lists map[core.RecordRef]core.RevocationList
+	// path is a file revocation list of this node is kept in between restarts, empty if list isn't persisted
+	path string
+}
+
+// NewRevocations creates revocations of node with certificate, revoked nodes form initial list of this node.
+func NewRevocations(cert core.Certificate, cs core.CryptographyService, revoked []core.RecordRef) *Revocations {
+	r := &Revocations{
+		certificate: cert,
+		cs:          cs,
+		local:       make(map[core.RecordRef]struct{}, len(revoked)),
+		lists:       map[core.RecordRef]core.RevocationList{},
+	}
+	for _, node := range revoked {
+		r.local[node] = struct{}{}
+	}
+	return r
+}
+
+// IsRevoked checks if certificate of node is revoked by majority of discovery nodes.
+func (r *Revocations) IsRevoked(node core.RecordRef) bool {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	count := 0
+	for _, list := range r.lists {
+		for _, revoked := range list.Revoked {
+			if revoked == node {
+				count++
+				break
+			}
+		}
+	}
+	return count > 0 && count >= len(r.certificate.GetDiscoveryNodes())/2+1
+}
+
+// Revoke adds node to revocation list of this discovery node.
+func (r *Revocations) Revoke(node core.RecordRef) error {
+	if err := r.checkDiscovery(); err != nil {
+		return err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.local[node] = struct{}{}
+	return r.save()
+}
+
+// Restore removes node from revocation list of this discovery node. Other nodes keep the previous list for
+// a few pulses.
+func (r *Revocations) Restore(node core.RecordRef) error {
+	if err := r.checkDiscovery(); err != nil {
+		return err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.local, node)
+	return r.save()
+}
+
+// Persist keeps revocation list of this node in file at path, so revocations survive restarts. List saved
+// earlier is merged with the current one.
+func (r *Revocations) Persist(path string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "[ Persist ] failed to read revocation list")
+	}
+	if err == nil {
+		var saved []string
+		if err := json.Unmarshal(data, &saved); err != nil {
+			return errors.Wrap(err, "[ Persist ] failed to parse revocation list")
+		}
+		for _, node := range saved {
+			ref, err := core.NewRefFromBase58(node)
+			if err != nil {
+				return errors.Wrapf(err, "[ Persist ] invalid reference of revoked node %s", node)
+			}
+			r.local[*ref] = struct{}{}
+		}
+	}
+	r.path = path
+	return r.save()
+}
+
+// Revoked returns revocation list of this node ordered by reference.
+func (r *Revocations) Revoked() []core.RecordRef {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.revoked()
+}
+
+// Lists returns revocation lists of discovery nodes ordered by signer.
+func (r *Revocations) Lists() []core.RevocationList {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	result := make([]core.RevocationList, 0, len(r.lists))
+	for _, list := range r.lists {
+		result = append(result, list)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return bytes.Compare(result[i].Signer[:], result[j].Signer[:]) < 0
+	})
+	return result
+}
+
+// Sign returns revocation list of this node signed in pulse. Nil is returned if list is empty, since lists expire
+// if they're not distributed.
+func (r *Revocations) Sign(pulse core.PulseNumber) (*core.RevocationList, error) {
+	revoked := r.Revoked()
+	if len(revoked) == 0 {
+		return nil, nil
+	}
+
+	list := &core.RevocationList{Pulse: pulse, Signer: *r.certificate.GetNodeRef(), Revoked: revoked}
+	data, err := list.SignedData()
+	if err != nil {
+		return nil, errors.Wrap(err, "[ Sign ] failed to serialize revocation list")
+	}
+	signature, err := r.cs.Sign(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ Sign ] failed to sign revocation list")
+	}
+	list.Signature = signature.Bytes()
+	return list, nil
+}
+
+// Accept verifies revocation list of discovery node and replaces its previous list. Lists older than known one
+// are ignored.
+func (r *Revocations) Accept(list core.RevocationList) error {
+	var signer core.DiscoveryNode
+	for _, node := range r.certificate.GetDiscoveryNodes() {
+		if node.GetNodeRef().Equal(list.Signer) {
+			signer = node
+			break
+		}
+	}
+	if signer == nil {
+		return errors.Errorf("[ Accept ] signer %s isn't discovery node", list.Signer)
+	}
+	data, err := list.SignedData()
+	if err != nil {
+		return errors.Wrap(err, "[ Accept ] failed to serialize revocation list")
+	}
+	if !r.cs.Verify(signer.GetPublicKey(), core.SignatureFromBytes(list.Signature), data) {
+		return errors.New("[ Accept ] invalid signature of revocation list")
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if known, ok := r.lists[list.Signer]; ok && known.Pulse >= list.Pulse {
+		return nil
+	}
+	r.lists[list.Signer] = list
+	return nil
+}
+
+// Expire drops lists which weren't refreshed for several pulses, e.g. because discovery node emptied its list.
+func (r *Revocations) Expire(pulse core.Pulse) {
+	delta := core.PulseNumber(1)
+	if pulse.NextPulseNumber > pulse.PulseNumber {
+		delta = pulse.NextPulseNumber - pulse.PulseNumber
+	}
+	maxAge := revocationListPulses * delta
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for signer, list := range r.lists {
+		if list.Pulse+maxAge < pulse.PulseNumber {
+			delete(r.lists, signer)
+		}
+	}
+}
+
+func (r *Revocations) revoked() []core.RecordRef {
+	revoked := make([]core.RecordRef, 0, len(r.local))
+	for node := range r.local {
+		revoked = append(revoked, node)
+	}
+	sort.Slice(revoked, func(i, j int) bool {
+		return bytes.Compare(revoked[i][:], revoked[j][:]) < 0
+	})
+	return revoked
+}
+
+// save writes revocation list of this node to file, r.lock should be held.
+func (r *Revocations) save() error {
+	if r.path == "" {
+		return nil
+	}
+	revoked := r.revoked()
+	refs := make([]string, len(revoked))
+	for i, node := range revoked {
+		refs[i] = node.String()
+	}
+	data, err := json.Marshal(refs)
+	if err != nil {
+		return errors.Wrap(err, "[ save ] failed to serialize revocation list")
+	}
+	tmp := r.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return errors.Wrap(err, "[ save ] failed to write revocation list")
+	}
+	return errors.Wrap(os.Rename(tmp, r.path), "[ save ] failed to replace revocation list")
+}
+
+func (r *Revocations) checkDiscovery() error {
+	origin := r.certificate.GetNodeRef()
+	for _, node := range r.certificate.GetDiscoveryNodes() {
+		if node.GetNodeRef().Equal(*origin) {
+			return nil
+		}
+	}
+	return errors.New("only discovery node revokes certificates")
+}
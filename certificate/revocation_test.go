@@ -0,0 +1,142 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package certificate
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/cryptography"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+type revocationNode struct {
+	cert        *Certificate
+	revocations *Revocations
+}
+
+// newRevocationNodes creates discovery nodes sharing the list of discovery nodes in their certificates.
+func newRevocationNodes(t *testing.T, count int) []revocationNode {
+	kp := platformpolicy.NewKeyProcessor()
+	services := make([]core.CryptographyService, count)
+	discovery := make([]BootstrapNode, count)
+	for i := range discovery {
+		privateKey, err := kp.GeneratePrivateKey()
+		require.NoError(t, err)
+		services[i] = cryptography.NewKeyBoundCryptographyService(privateKey)
+		publicKey := kp.ExtractPublicKey(privateKey)
+		discovery[i] = *NewBootstrapNode(publicKey, "", "", testutils.RandomRef().String())
+	}
+
+	nodes := make([]revocationNode, count)
+	for i := range nodes {
+		cert := &Certificate{BootstrapNodes: discovery}
+		cert.Reference = discovery[i].NodeRef
+		nodes[i] = revocationNode{cert: cert, revocations: NewRevocations(cert, services[i], nil)}
+	}
+	return nodes
+}
+
+func TestRevocations_Majority(t *testing.T) {
+	nodes := newRevocationNodes(t, 3)
+	target := testutils.RandomRef()
+	observer := nodes[2].revocations
+
+	for i, node := range nodes[:2] {
+		require.NoError(t, node.revocations.Revoke(target))
+		list, err := node.revocations.Sign(core.FirstPulseNumber)
+		require.NoError(t, err)
+		require.NotNil(t, list)
+		require.NoError(t, observer.Accept(*list))
+
+		require.Equal(t, i == 1, observer.IsRevoked(target))
+	}
+	require.Len(t, observer.Lists(), 2)
+
+	require.NoError(t, nodes[0].revocations.Restore(target))
+	list, err := nodes[0].revocations.Sign(core.FirstPulseNumber + 10)
+	require.NoError(t, err)
+	require.Nil(t, list)
+}
+
+func TestRevocations_AcceptRejectsForgedList(t *testing.T) {
+	nodes := newRevocationNodes(t, 2)
+	require.NoError(t, nodes[0].revocations.Revoke(testutils.RandomRef()))
+	list, err := nodes[0].revocations.Sign(core.FirstPulseNumber)
+	require.NoError(t, err)
+
+	forged := *list
+	forged.Revoked = append(forged.Revoked, testutils.RandomRef())
+	require.EqualError(t, nodes[1].revocations.Accept(forged), "[ Accept ] invalid signature of revocation list")
+
+	stranger := *list
+	stranger.Signer = testutils.RandomRef()
+	require.Error(t, nodes[1].revocations.Accept(stranger))
+	require.Empty(t, nodes[1].revocations.Lists())
+}
+
+func TestRevocations_Expire(t *testing.T) {
+	nodes := newRevocationNodes(t, 1)
+	target := testutils.RandomRef()
+	r := nodes[0].revocations
+	require.NoError(t, r.Revoke(target))
+	list, err := r.Sign(core.FirstPulseNumber)
+	require.NoError(t, err)
+	require.NoError(t, r.Accept(*list))
+	require.True(t, r.IsRevoked(target))
+
+	r.Expire(core.Pulse{PulseNumber: core.FirstPulseNumber + 30, NextPulseNumber: core.FirstPulseNumber + 40})
+	require.True(t, r.IsRevoked(target))
+	r.Expire(core.Pulse{PulseNumber: core.FirstPulseNumber + 31, NextPulseNumber: core.FirstPulseNumber + 41})
+	require.False(t, r.IsRevoked(target))
+}
+
+func TestRevocations_RevokeByOrdinaryNode(t *testing.T) {
+	nodes := newRevocationNodes(t, 1)
+	cert := &Certificate{BootstrapNodes: nodes[0].cert.BootstrapNodes}
+	cert.Reference = testutils.RandomRef().String()
+	r := NewRevocations(cert, cryptography.NewCryptographyService(), nil)
+
+	require.EqualError(t, r.Revoke(testutils.RandomRef()), "only discovery node revokes certificates")
+}
+
+func TestRevocations_Persist(t *testing.T) {
+	dir, err := ioutil.TempDir("", "revocations")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "revoked.json")
+
+	node := newRevocationNodes(t, 1)[0]
+	configured, revoked := testutils.RandomRef(), testutils.RandomRef()
+	r := NewRevocations(node.cert, cryptography.NewCryptographyService(), []core.RecordRef{configured})
+	require.NoError(t, r.Persist(path))
+	require.NoError(t, r.Revoke(revoked))
+
+	restarted := NewRevocations(node.cert, cryptography.NewCryptographyService(), nil)
+	require.NoError(t, restarted.Persist(path))
+	require.ElementsMatch(t, []core.RecordRef{configured, revoked}, restarted.Revoked())
+
+	require.NoError(t, restarted.Restore(configured))
+	restarted = NewRevocations(node.cert, cryptography.NewCryptographyService(), nil)
+	require.NoError(t, restarted.Persist(path))
+	require.Equal(t, []core.RecordRef{revoked}, restarted.Revoked())
+}
@@ -45,7 +45,7 @@ var (
 )
 
 func parseInputParams() {
-	pflag.StringVarP(&role, "role", "r", "virtual", "The role of the new node")
+	pflag.StringVarP(&role, "role", "r", "virtual", "The role of the new node: virtual, light_material, heavy_material, api_gateway or observer")
 	pflag.StringVarP(&api, "url", "h", defaultURL, "Insolar API URL")
 	pflag.BoolVarP(&verbose, "verbose", "v", false, "Be verbose (default false)")
 	pflag.StringVarP(&keysFileOut, "keys-file", "k", "keys.json", "The OUT file for public/private keys of the node")
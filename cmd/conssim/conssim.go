@@ -0,0 +1,76 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/consensus"
+	"github.com/insolar/insolar/log"
+)
+
+func main() {
+	nodes := pflag.IntP("nodes", "n", 100, "number of consensus participants")
+	rtt := pflag.Duration("rtt", 50*time.Millisecond, "median round trip time between nodes")
+	sigma := pflag.Float64("rtt-sigma", 0.5, "standard deviation of logarithm of round trip time")
+	loss := pflag.Float64("loss", 0, "probability of packet loss")
+	pulse := pflag.DurationP("pulse", "p", 10*time.Second, "pulse duration")
+	rounds := pflag.IntP("rounds", "r", 100, "number of simulated rounds")
+	seed := pflag.Int64("seed", time.Now().UnixNano(), "random seed")
+	configPath := pflag.StringP("config", "c", "", "insolard config to take consensus settings from")
+	pflag.Parse()
+
+	cfg := configuration.NewConsensus()
+	if *configPath != "" {
+		holder := configuration.NewHolder()
+		if err := holder.LoadFromFile(*configPath); err != nil {
+			log.Errorf("Failed to load config: %s", err)
+			os.Exit(2)
+		}
+		cfg = holder.Configuration.Service.Consensus
+	}
+
+	rep, err := consensus.Simulate(consensus.SimulationParams{
+		Nodes:         *nodes,
+		RTT:           consensus.RTTDistribution{Median: *rtt, Sigma: *sigma},
+		Loss:          *loss,
+		PulseDuration: *pulse,
+		Rounds:        *rounds,
+		Seed:          *seed,
+		Consensus:     cfg,
+	})
+	if err != nil {
+		log.Errorf("Failed to simulate consensus: %s", err)
+		os.Exit(2)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PHASE\tEXECUTED\tDEADLINE\tTIMEOUTS\tROUNDS WITH TIMEOUTS\tMEAN\tP99")
+	for _, phase := range rep.Phases {
+		fmt.Fprintf(w, "%s\t%d/%d\t%v\t%.2f%%\t%.2f%%\t%v\t%v\n",
+			phase.Name, phase.Executed, rep.Rounds, phase.Deadline,
+			phase.TimeoutRate*100, phase.RoundTimeoutRate*100, phase.Mean, phase.P99,
+		)
+	}
+	w.Flush()
+}
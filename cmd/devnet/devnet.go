@@ -0,0 +1,95 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/insolar/insolar/log"
+	"github.com/insolar/insolar/testutils/devnet"
+)
+
+// logTester reports failed expectations of devnet mocks to log instead of failing a test.
+type logTester struct{}
+
+func (logTester) Error(args ...interface{}) {
+	log.Error(args...)
+}
+
+func (logTester) Errorf(format string, args ...interface{}) {
+	log.Errorf(format, args...)
+}
+
+func (logTester) Fatal(args ...interface{}) {
+	log.Fatal(args...)
+}
+
+func (logTester) Fatalf(format string, args ...interface{}) {
+	log.Fatalf(format, args...)
+}
+
+func (logTester) FailNow() {
+	log.Fatal("devnet mock failed")
+}
+
+func main() {
+	bootstrap := pflag.IntP("bootstrap", "b", 3, "number of bootstrap nodes")
+	nodes := pflag.IntP("nodes", "n", 0, "number of common nodes joining after bootstrap")
+	topologyPath := pflag.StringP("topology", "t", "", "topology file, overrides node counts")
+	artifacts := pflag.StringP("artifacts", "a", "", "directory to collect log and metrics snapshots of nodes to")
+	duration := pflag.DurationP("duration", "d", 0, "time to run network for, zero runs it until interrupted")
+	pflag.Parse()
+
+	var d *devnet.Devnet
+	if *topologyPath != "" {
+		topo, err := devnet.LoadTopology(*topologyPath)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(2)
+		}
+		d = devnet.NewFromTopology(logTester{}, topo)
+	} else {
+		d = devnet.New(logTester{}, *bootstrap, *nodes)
+	}
+	if *artifacts != "" {
+		d.CollectArtifactsTo(*artifacts)
+	}
+
+	err := d.Run(func(d *devnet.Devnet) error {
+		log.Infof("Devnet of %d nodes is started", len(d.Nodes()))
+		stop := make(chan os.Signal, 1)
+		signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+		var timeout <-chan time.Time
+		if *duration > 0 {
+			timeout = time.After(*duration)
+		}
+		select {
+		case <-stop:
+		case <-timeout:
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
@@ -23,8 +23,10 @@ import (
 	"net/rpc"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/metrics"
@@ -33,6 +35,7 @@ import (
 
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/log"
+	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
 	"github.com/insolar/insolar/logicrunner/goplugin/ginsider"
 )
 
@@ -45,6 +48,9 @@ func main() {
 	metricsAddress := pflag.String("metrics", "", "address and port of prometheus metrics")
 	code := pflag.String("code", "", "add pre-compiled code to cache (<ref>:</path/to/plugin.so>)")
 	logLevel := pflag.String("log-level", "debug", "log level")
+	memoryLimit := pflag.Uint64("memory-limit", 0, "resident memory in MB runner is restarted after, 0 disables limit")
+	memoryCheck := pflag.Duration("memory-check-interval", 10*time.Second, "how often memory limit is checked")
+	secp256k1Keys := pflag.Bool("secp256k1-keys", false, "allow secp256k1 keys of members, must match node config")
 
 	pflag.Parse()
 
@@ -53,8 +59,9 @@ func main() {
 		log.Fatalf("Couldn't set log level to %q: %s", *logLevel, err)
 	}
 
+	var tmpDir string
 	if *path == "" {
-		tmpDir, err := ioutil.TempDir("", "contractcache-")
+		tmpDir, err = ioutil.TempDir("", "contractcache-")
 		if err != nil {
 			log.Fatal("Couldn't create temp cache dir: ", err)
 			os.Exit(1)
@@ -64,6 +71,7 @@ func main() {
 		log.Debug("ginsider cache dir is " + tmpDir)
 	}
 
+	foundation.AllowSecp256k1Keys(*secp256k1Keys)
 	insider := ginsider.NewGoInsider(*path, *rpcProtocol, *rpcAddress)
 
 	if *code != "" {
@@ -86,7 +94,12 @@ func main() {
 		}
 	}
 
-	err = rpc.Register(&ginsider.RPC{GI: insider})
+	var guard *ginsider.MemoryGuard
+	if *memoryLimit > 0 {
+		guard = ginsider.NewMemoryGuard(*memoryLimit << 20)
+	}
+
+	err = rpc.Register(&ginsider.RPC{GI: insider, Guard: guard})
 	if err != nil {
 		log.Fatal("Couldn't register RPC interface: ", err)
 		os.Exit(1)
@@ -133,9 +146,54 @@ func main() {
 		defer m.Stop(ctx) // nolint: errcheck
 	}
 
+	if recycled, err := strconv.Atoi(os.Getenv(recycledEnv)); err == nil {
+		metrics.InsgorundRecycledTotal.Add(float64(recycled))
+	}
+
 	log.Debug("ginsider launched, listens " + *listen)
 	go rpc.Accept(listener)
 
+	if guard != nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			if guard.Watch(ctx, *memoryCheck) {
+				recycle(listener, tmpDir)
+			}
+		}()
+	}
+
 	<-waitChannel
 	log.Debug("bye\n")
 }
+
+// recycledEnv passes number of restarts to the new runner process, so metric isn't reset by restart.
+const recycledEnv = "INSGORUND_RECYCLED"
+
+// recycle replaces the process with a fresh one started with the same arguments, all memory is released this way.
+func recycle(listener net.Listener, tmpDir string) {
+	err := listener.Close()
+	if err != nil {
+		log.Warn("couldn't close listener before restart: ", err)
+	}
+	if tmpDir != "" {
+		os.RemoveAll(tmpDir) // nolint: errcheck
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		log.Fatal("couldn't find executable to restart: ", err)
+	}
+	recycled, _ := strconv.Atoi(os.Getenv(recycledEnv))
+	var env []string
+	for _, kv := range os.Environ() {
+		if !strings.HasPrefix(kv, recycledEnv+"=") {
+			env = append(env, kv)
+		}
+	}
+	env = append(env, recycledEnv+"="+strconv.Itoa(recycled+1))
+
+	log.Info("restarting runner to release memory")
+	err = syscall.Exec(exe, os.Args, env)
+	log.Fatal("couldn't restart runner: ", err)
+}
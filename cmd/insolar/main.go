@@ -18,15 +18,19 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"reflect"
 
 	"github.com/insolar/insolar/api/requester"
 	"github.com/insolar/insolar/certificate"
 	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/cryptography"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/log"
@@ -92,12 +96,15 @@ var (
 	verbose            bool
 	sendUrls           string
 	rootAsCaller       bool
+	nonce              uint64
+	expiry             uint32
 )
 
 func parseInputParams() {
 	var rootCmd = &cobra.Command{}
 	rootCmd.Flags().StringVarP(&cmd, "cmd", "c", "",
-		"available commands: default_config | random_ref | version | gen_keys | gen_certificate | send_request | gen_send_configs")
+		"available commands: default_config | random_ref | version | gen_keys | gen_certificate | send_request | gen_send_configs"+
+			" | sign_transaction | submit_transaction")
 	rootCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "be verbose (default false)")
 	rootCmd.Flags().StringVarP(&output, "output", "o", defaultStdoutPath, "output file (use - for STDOUT)")
 	rootCmd.Flags().StringVarP(&sendUrls, "url", "u", defaultURL, "api url")
@@ -105,6 +112,8 @@ func parseInputParams() {
 	rootCmd.Flags().StringVarP(&configPath, "config", "g", "config.json", "path to configuration file")
 	rootCmd.Flags().StringVarP(&paramsPath, "params", "p", "", "path to params file (default params.json)")
 	rootCmd.Flags().BoolVarP(&rootAsCaller, "root_as_caller", "r", false, "use root member as caller")
+	rootCmd.Flags().Uint64Var(&nonce, "nonce", 0, "nonce of offline transaction (default random)")
+	rootCmd.Flags().Uint32Var(&expiry, "expiry", 0, "the last pulse offline transaction may be executed in")
 	err := rootCmd.Execute()
 	check("Wrong input params:", err)
 
@@ -203,6 +212,51 @@ func sendRequest(out io.Writer) {
 	writeToOutput(out, string(response))
 }
 
+func signTransaction(out io.Writer) {
+	userCfg, err := requester.ReadUserConfigFromFile(configPath)
+	check("[ signTransaction ]", err)
+
+	pPath := paramsPath
+	if len(pPath) == 0 {
+		pPath = configPath
+	}
+	reqCfg, err := requester.ReadRequestConfigFromFile(pPath)
+	check("[ signTransaction ]", err)
+
+	if expiry == 0 {
+		check("[ signTransaction ]", errors.New("expiry pulse is required"))
+	}
+	if nonce == 0 {
+		var buf [8]byte
+		_, err := rand.Read(buf[:])
+		check("[ signTransaction ] can't generate nonce", err)
+		nonce = binary.BigEndian.Uint64(buf[:])
+	}
+
+	tx, err := requester.SignTransaction(userCfg, reqCfg, nonce, core.PulseNumber(expiry))
+	check("[ signTransaction ]", err)
+
+	result, err := json.MarshalIndent(tx, "", "    ")
+	check("[ signTransaction ] Problems with marshaling transaction:", err)
+	writeToOutput(out, string(result)+"\n")
+}
+
+func submitTransaction(out io.Writer) {
+	pPath := paramsPath
+	if len(pPath) == 0 {
+		pPath = configPath
+	}
+	raw, err := ioutil.ReadFile(pPath)
+	check("[ submitTransaction ] Can't read transaction", err)
+	tx := &core.Transaction{}
+	err = json.Unmarshal(raw, tx)
+	check("[ submitTransaction ] Can't unmarshal transaction", err)
+
+	response, err := requester.SubmitTransaction(sendUrls, tx)
+	check("[ submitTransaction ]", err)
+	writeToOutput(out, string(response))
+}
+
 func genSendConfigs(out io.Writer) {
 	reqConf, err := genDefaultConfig(requester.RequestConfigJSON{})
 	check("[ genSendConfigs ]", err)
@@ -238,5 +292,9 @@ func main() {
 		sendRequest(out)
 	case "gen_send_configs":
 		genSendConfigs(out)
+	case "sign_transaction":
+		signTransaction(out)
+	case "submit_transaction":
+		submitTransaction(out)
 	}
 }
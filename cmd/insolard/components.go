@@ -18,29 +18,27 @@ package main
 
 import (
 	"context"
+	"os"
 
 	"github.com/insolar/insolar/api"
 	"github.com/insolar/insolar/certificate"
 	"github.com/insolar/insolar/component"
 	"github.com/insolar/insolar/configuration"
-	"github.com/insolar/insolar/contractrequester"
 	"github.com/insolar/insolar/core"
-	"github.com/insolar/insolar/core/delegationtoken"
 	"github.com/insolar/insolar/cryptography"
+	"github.com/insolar/insolar/faucet"
 	"github.com/insolar/insolar/genesis"
-	"github.com/insolar/insolar/genesisdataprovider"
+	"github.com/insolar/insolar/instrumentation/crashreport"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/instrumentation/watchdog"
 	"github.com/insolar/insolar/keystore"
-	"github.com/insolar/insolar/ledger"
-	"github.com/insolar/insolar/logicrunner"
-	"github.com/insolar/insolar/messagebus"
+	"github.com/insolar/insolar/ledger/storage"
+	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
 	"github.com/insolar/insolar/metrics"
-	"github.com/insolar/insolar/network/nodenetwork"
-	"github.com/insolar/insolar/network/servicenetwork"
-	"github.com/insolar/insolar/network/state"
-	"github.com/insolar/insolar/networkcoordinator"
+	"github.com/insolar/insolar/migration"
+	"github.com/insolar/insolar/nodecomponents"
 	"github.com/insolar/insolar/platformpolicy"
-	"github.com/insolar/insolar/pulsar"
-	"github.com/insolar/insolar/pulsar/entropygenerator"
+	"github.com/insolar/insolar/scheduler"
 	"github.com/insolar/insolar/version/manager"
 )
 
@@ -54,12 +52,20 @@ type bootstrapComponents struct {
 func initBootstrapComponents(ctx context.Context, cfg configuration.Configuration) bootstrapComponents {
 	earlyComponents := component.Manager{}
 
-	keyStore, err := keystore.NewKeyStore(cfg.KeysPath)
-	checkError(ctx, err, "failed to load KeyStore: ")
-
 	platformCryptographyScheme := platformpolicy.NewPlatformCryptographyScheme()
 	keyProcessor := platformpolicy.NewKeyProcessor()
 
+	var keyStore core.KeyStore
+	var err error
+	if cfg.Ephemeral.Enabled {
+		inslogger.FromContext(ctx).Warn("EPHEMERAL MODE: node keys are generated on startup, NOT FOR PRODUCTION")
+		keyStore, err = keystore.NewEphemeralKeyStore(keyProcessor)
+		checkError(ctx, err, "failed to generate ephemeral KeyStore: ")
+	} else {
+		keyStore, err = keystore.NewKeyStore(cfg.KeysPath)
+		checkError(ctx, err, "failed to load KeyStore: ")
+	}
+
 	cryptographyService := cryptography.NewCryptographyService()
 	earlyComponents.Register(platformCryptographyScheme, keyStore)
 	earlyComponents.Inject(cryptographyService, keyProcessor)
@@ -88,6 +94,8 @@ func initCertificateManager(
 	if isBootstrap {
 		certManager, err = certificate.NewManagerCertificateWithKeys(publicKey, keyProcessor)
 		checkError(ctx, err, "failed to start Certificate (bootstrap mode)")
+	} else if cfg.Ephemeral.Enabled {
+		certManager = initEphemeralCertificateManager(ctx, cfg, cryptographyService, keyProcessor)
 	} else {
 		certManager, err = certificate.NewManagerReadCertificate(publicKey, keyProcessor, cfg.CertificatePath)
 		checkError(ctx, err, "failed to start Certificate")
@@ -96,6 +104,26 @@ func initCertificateManager(
 	return certManager
 }
 
+// initEphemeralCertificateManager creates certificate manager with self-signed certificate of ephemeral node,
+// certificate from cfg.CertificatePath is a template of network part.
+func initEphemeralCertificateManager(
+	ctx context.Context,
+	cfg configuration.Configuration,
+	cryptographyService core.CryptographyService,
+	keyProcessor core.KeyProcessor,
+) *certificate.CertificateManager {
+	template, err := os.Open(cfg.CertificatePath)
+	checkError(ctx, err, "failed to open certificate template")
+	defer template.Close()
+
+	role := core.GetStaticRoleFromString(cfg.Ephemeral.Role)
+	cert, err := certificate.NewEphemeralCertificate(cryptographyService, keyProcessor, role, template)
+	checkError(ctx, err, "failed to create ephemeral Certificate")
+	inslogger.FromContext(ctx).Warnf("EPHEMERAL MODE: node %s uses self-signed certificate", cert.GetNodeRef())
+
+	return certificate.NewCertificateManager(cert)
+}
+
 // initComponents creates and links all insolard components
 func initComponents(
 	ctx context.Context,
@@ -104,95 +132,63 @@ func initComponents(
 	platformCryptographyScheme core.PlatformCryptographyScheme,
 	keyStore core.KeyStore,
 	keyProcessor core.KeyProcessor,
-	certManager core.CertificateManager,
+	certManager *certificate.CertificateManager,
 	isGenesis bool,
 	genesisConfigPath string,
 	genesisKeyOut string,
-
+	crashReporter *crashreport.Reporter,
 ) (*component.Manager, error) {
 	cm := component.Manager{}
-	terminationHandler := core.NewTerminationHandler()
-
-	nodeNetwork, err := nodenetwork.NewNodeNetwork(cfg.Host, certManager.GetCertificate())
-	checkError(ctx, err, "failed to start NodeNetwork")
-
-	logicRunner, err := logicrunner.NewLogicRunner(&cfg.LogicRunner)
-	checkError(ctx, err, "failed to start LogicRunner")
+	cm.Register(crashReporter)
 
-	nw, err := servicenetwork.NewServiceNetwork(cfg, &cm, isGenesis)
-	checkError(ctx, err, "failed to start Network")
-
-	delegationTokenFactory := delegationtoken.NewDelegationTokenFactory()
-	parcelFactory := messagebus.NewParcelFactory()
-
-	messageBus, err := messagebus.NewMessageBus(cfg)
-	checkError(ctx, err, "failed to start MessageBus")
+	components, err := nodecomponents.Register(
+		ctx,
+		cfg,
+		&cm,
+		cryptographyService,
+		platformCryptographyScheme,
+		keyStore,
+		keyProcessor,
+		certManager,
+		isGenesis,
+	)
+	checkError(ctx, err, "failed to start node components")
 
 	var gen core.Genesis
 	if isGenesis {
 		gen, err = genesis.NewGenesis(isGenesis, genesisConfigPath, genesisKeyOut)
 		checkError(ctx, err, "failed to start Bootstrapper (bootstraper mode)")
+		components = append(components, gen)
 	}
 
-	contractRequester, err := contractrequester.New()
-	checkError(ctx, err, "failed to start ContractRequester")
-
-	genesisDataProvider, err := genesisdataprovider.New()
-	checkError(ctx, err, "failed to start GenesisDataProvider")
-
 	apiRunner, err := api.NewRunner(&cfg.APIRunner)
 	checkError(ctx, err, "failed to start ApiRunner")
+	foundation.AllowSecp256k1Keys(cfg.APIRunner.Secp256k1Keys)
 
 	metricsHandler, err := metrics.NewMetrics(ctx, cfg.Metrics, metrics.GetInsolarRegistry())
 	checkError(ctx, err, "failed to start Metrics")
 
-	networkSwitcher, err := state.NewNetworkSwitcher()
-	checkError(ctx, err, "failed to start NetworkSwitcher")
-
-	networkCoordinator, err := networkcoordinator.New()
-	checkError(ctx, err, "failed to start NetworkCoordinator")
+	watchdogComponent := watchdog.NewWatchdog(cfg.Watchdog)
 
 	_, err = manager.NewVersionManager(cfg.VersionManager)
 	checkError(ctx, err, "failed to load VersionManager: ")
 
-	// move to logic runner ??
-	err = logicRunner.OnPulse(ctx, *pulsar.NewPulse(cfg.Pulsar.NumberDelta, 0, &entropygenerator.StandardEntropyGenerator{}))
-	checkError(ctx, err, "failed init pulse for LogicRunner")
-
-	cm.Register(
-		terminationHandler,
-		platformCryptographyScheme,
-		keyStore,
-		cryptographyService,
-		keyProcessor,
-		certManager,
-		nodeNetwork,
-		nw,
-	)
-
-	components := ledger.GetLedgerComponents(cfg.Ledger, certManager.GetCertificate())
-	ld := ledger.Ledger{} // TODO: remove me with cmOld
-
 	components = append(components, []interface{}{
-		messageBus,
-		contractRequester,
-		&ld,
-		logicRunner,
-		delegationTokenFactory,
-		parcelFactory,
-	}...)
-	if gen != nil {
-		components = append(components, gen)
-	}
-	components = append(components, []interface{}{
-		genesisDataProvider,
 		apiRunner,
+		certificate.NewReloader(certManager, cfg.CertificatePath),
+		storage.NewAPIRequestStorage(cfg.APIRunner.RequestRetention),
+		storage.NewAuditStorage(),
+		faucet.New(cfg.Faucet),
+		migration.New(),
 		metricsHandler,
-		networkSwitcher,
-		networkCoordinator,
-		cryptographyService,
+		watchdogComponent,
 	}...)
 
+	schedulerComponent := scheduler.NewScheduler(cfg.Scheduler)
+	err = schedulerComponent.AddTasksFrom(components...)
+	checkError(ctx, err, "failed to schedule periodic tasks")
+	components = append(components, schedulerComponent)
+
 	cm.Inject(components...)
 
 	return &cm, nil
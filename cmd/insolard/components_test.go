@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/instrumentation/crashreport"
 	"github.com/stretchr/testify/require"
 )
 
@@ -49,6 +50,7 @@ func TestInitComponents(t *testing.T) {
 		false,
 		"",
 		"",
+		crashreport.NewReporter(cfg.CrashReport),
 	)
 	require.NoError(t, err)
 	require.NotNil(t, cm)
@@ -19,6 +19,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"os/signal"
@@ -30,8 +31,10 @@ import (
 
 	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/crashreport"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/instrumentation/instracer"
+	"github.com/insolar/insolar/instrumentation/resources"
 	"github.com/insolar/insolar/log"
 	"github.com/insolar/insolar/version"
 )
@@ -91,17 +94,25 @@ func main() {
 		log.Warnln("failed to load configuration from env:", err.Error())
 	}
 
+	err = cfgHolder.ResolveSecrets()
+	if err != nil {
+		log.Fatal("failed to resolve secrets in configuration: ", err.Error())
+	}
+
 	cfg := &cfgHolder.Configuration
 	cfg.Metrics.Namespace = "insolard"
 
 	traceID := "main_" + utils.RandTraceID()
 	ctx, inslog := initLogger(context.Background(), cfg.Log, traceID)
+	crashReporter := crashreport.NewReporter(cfg.CrashReport)
+	inslog.SetOutput(io.MultiWriter(os.Stderr, crashReporter.LogTail()))
 	log.SetGlobalLogger(inslog)
 
 	if params.isGenesis {
 		removeLedgerDataDir(ctx, cfg)
 		cfg.Ledger.PulseManager.HeavySyncEnabled = false
 	}
+	resources.Apply(ctx, cfg)
 
 	bootstrapComponents := initBootstrapComponents(ctx, *cfg)
 	certManager := initCertificateManager(
@@ -112,7 +123,9 @@ func main() {
 		bootstrapComponents.KeyProcessor,
 	)
 
-	fmt.Println("Starts with configuration:\n", configuration.ToString(cfgHolder.Configuration))
+	fmt.Println("Starts with configuration:\n", cfgHolder.RedactedString())
+
+	runSelfTest(ctx, *cfg, bootstrapComponents.CryptographyService, certManager.GetCertificate(), params.isGenesis)
 
 	jaegerflush := func() {}
 	if params.traceEnabled {
@@ -140,6 +153,7 @@ func main() {
 		params.isGenesis,
 		params.genesisConfigPath,
 		params.genesisKeyOut,
+		crashReporter,
 	)
 	checkError(ctx, err, "failed to init components")
 
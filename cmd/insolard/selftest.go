@@ -0,0 +1,94 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/instrumentation/selftest"
+	"github.com/insolar/insolar/ledger/storage"
+	"github.com/insolar/insolar/version"
+)
+
+// selfTestChecks returns checks of node self-test. Genesis node creates storage and network from scratch,
+// so discovery connectivity isn't checked for it.
+func selfTestChecks(
+	cfg configuration.Configuration,
+	cryptographyService core.CryptographyService,
+	cert core.Certificate,
+	isGenesis bool,
+) []selftest.Check {
+	checks := []selftest.Check{
+		selftest.Keys(cryptographyService, cert, time.Now),
+		{Name: "storage", Run: func(ctx context.Context) error {
+			_, err := storage.CheckSchemaVersion(ctx, cfg.Ledger)
+			return err
+		}},
+		selftest.Clock(time.Now, version.BuildDate),
+		selftest.Ports(
+			selftest.Endpoint{
+				Name:    "transport",
+				Network: transportNetwork(cfg.Host.Transport.Protocol),
+				Address: cfg.Host.Transport.Address,
+			},
+			selftest.Endpoint{Name: "api", Network: "tcp", Address: cfg.APIRunner.Address},
+			selftest.Endpoint{Name: "metrics", Network: "tcp", Address: cfg.Metrics.ListenAddress},
+			selftest.Endpoint{Name: "logicrunner", Network: cfg.LogicRunner.RPCProtocol, Address: cfg.LogicRunner.RPCListen},
+		),
+	}
+	if !isGenesis {
+		checks = append(checks, selftest.Discovery(cert, cfg.Host.Transport.Protocol, cfg.SelfTest.DiscoveryTimeout))
+	}
+	return checks
+}
+
+// runSelfTest checks node environment before components are created and stops node with consolidated report
+// in fail-fast mode.
+func runSelfTest(
+	ctx context.Context,
+	cfg configuration.Configuration,
+	cryptographyService core.CryptographyService,
+	cert core.Certificate,
+	isGenesis bool,
+) {
+	if !cfg.SelfTest.Enabled {
+		return
+	}
+	inslog := inslogger.FromContext(ctx)
+
+	report := selftest.Run(ctx, selfTestChecks(cfg, cryptographyService, cert, isGenesis)...)
+	if len(report.Failed()) == 0 {
+		inslog.Infof("Self-test passed:\n%s", report)
+		return
+	}
+	if cfg.SelfTest.FailFast {
+		inslog.Fatalf("Self-test failed, node is stopped:\n%s%s", report, report.Summary())
+	}
+	inslog.Warnf("Self-test failed, node starts anyway:\n%s%s", report, report.Summary())
+}
+
+// transportNetwork returns network of host transport protocol, QUIC and PURE_UDP work over UDP.
+func transportNetwork(protocol string) string {
+	if protocol == "TCP" {
+		return "tcp"
+	}
+	return "udp"
+}
@@ -0,0 +1,206 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/component"
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/contractrequester"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/delegationtoken"
+	"github.com/insolar/insolar/cryptography"
+	"github.com/insolar/insolar/instrumentation/timeline"
+	"github.com/insolar/insolar/ledger/artifactmanager"
+	"github.com/insolar/insolar/ledger/jetcoordinator"
+	"github.com/insolar/insolar/ledger/storage"
+	"github.com/insolar/insolar/logicrunner"
+	"github.com/insolar/insolar/messagebus"
+	"github.com/insolar/insolar/network/clockskew"
+	"github.com/insolar/insolar/network/nodenetwork"
+	"github.com/insolar/insolar/platformpolicy"
+)
+
+// initComponents links LogicRunner with ledger storage from node snapshot and replayer instead of message bus.
+func initComponents(
+	ctx context.Context,
+	cfg configuration.Configuration,
+	capture *messagebus.Capture,
+) (*messagebus.Replayer, *component.Manager, error) {
+	scheme := platformpolicy.NewPlatformCryptographyScheme()
+	keyProcessor := platformpolicy.NewKeyProcessor()
+	// parcels are never sent to network in replay, so the node key is not required to sign them
+	key, err := keyProcessor.GeneratePrivateKey()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to generate replay key")
+	}
+
+	replayer, err := messagebus.NewReplayer(capture, scheme)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db, err := storage.NewDB(cfg.Ledger, nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to open ledger snapshot")
+	}
+
+	nodes := newNodeNetwork(capture)
+	pulseTracker := storage.NewPulseTrackerMemory()
+	err = pulseTracker.AddPulse(ctx, capture.Pulse)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to add captured pulse")
+	}
+	pulseStorage := storage.NewPulseStorage()
+	pulseStorage.Set(&capture.Pulse)
+	nodeStorage := storage.NewNodeStorage()
+	err = nodeStorage.SetActiveNodes(capture.Pulse.PulseNumber, nodes.GetWorkingNodes())
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to set captured active nodes")
+	}
+
+	logicRunner, err := logicrunner.NewLogicRunner(&cfg.LogicRunner)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to create LogicRunner")
+	}
+	contractRequester, err := contractrequester.New(cfg.Requester)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to create ContractRequester")
+	}
+	artifactManager := artifactmanager.NewArtifactManger()
+	jetCoordinator := jetcoordinator.NewJetCoordinator(cfg.Ledger.LightChainLimit, cfg.Ledger.Globule)
+
+	cm := &component.Manager{}
+	cm.Inject(
+		scheme,
+		keyProcessor,
+		cryptography.NewKeyBoundCryptographyService(key),
+		delegationtoken.NewDelegationTokenFactory(),
+		messagebus.NewParcelFactory(),
+		replayer,
+		nodes,
+		db,
+		pulseTracker,
+		pulseStorage,
+		nodeStorage,
+		storage.NewJetStorage(),
+		storage.NewObjectStorage(),
+		storage.NewDropStorage(cfg.Ledger.JetSizesHistoryDepth),
+		storage.NewGenesisInitializer(),
+		storage.NewDisputeStorage(),
+		jetCoordinator,
+		artifactManager,
+		&ledger{artifactManager: artifactManager, jetCoordinator: jetCoordinator},
+		contractRequester,
+		clockskew.NewMonitor(cfg.ClockSkew),
+		timeline.NewJournal(cfg.Timeline),
+		&networkParameters{},
+		logicRunner,
+	)
+	return replayer, cm, nil
+}
+
+// nodeNetwork is a NodeNetwork with active nodes of the captured pulse.
+type nodeNetwork struct {
+	origin core.Node
+	nodes  []core.Node
+	byRef  map[core.RecordRef]core.Node
+}
+
+func newNodeNetwork(capture *messagebus.Capture) *nodeNetwork {
+	nn := &nodeNetwork{byRef: map[core.RecordRef]core.Node{}}
+	for _, captured := range capture.Nodes {
+		node := nodenetwork.NewNode(captured.ID, captured.Role, nil, "", "")
+		nn.nodes = append(nn.nodes, node)
+		nn.byRef[captured.ID] = node
+	}
+	nn.origin = nn.byRef[capture.Origin]
+	if nn.origin == nil {
+		nn.origin = nodenetwork.NewNode(capture.Origin, core.StaticRoleVirtual, nil, "", "")
+	}
+	return nn
+}
+
+func (nn *nodeNetwork) GetState() core.NodeNetworkState {
+	return core.ReadyNodeNetworkState
+}
+
+func (nn *nodeNetwork) GetOrigin() core.Node {
+	return nn.origin
+}
+
+func (nn *nodeNetwork) GetWorkingNode(ref core.RecordRef) core.Node {
+	return nn.byRef[ref]
+}
+
+func (nn *nodeNetwork) GetWorkingNodes() []core.Node {
+	return nn.nodes
+}
+
+func (nn *nodeNetwork) GetWorkingNodesByRole(role core.DynamicRole) []core.RecordRef {
+	var static core.StaticRole
+	switch role {
+	case core.DynamicRoleVirtualExecutor, core.DynamicRoleVirtualValidator:
+		static = core.StaticRoleVirtual
+	case core.DynamicRoleLightExecutor, core.DynamicRoleLightValidator:
+		static = core.StaticRoleLightMaterial
+	case core.DynamicRoleHeavyExecutor:
+		static = core.StaticRoleHeavyMaterial
+	default:
+		return nil
+	}
+
+	var result []core.RecordRef
+	for _, node := range nn.nodes {
+		if node.Role() == static {
+			result = append(result, node.ID())
+		}
+	}
+	return result
+}
+
+// ledger provides deprecated Ledger interface for LogicRunner, pulse manager and local storage are not used in replay.
+type ledger struct {
+	artifactManager core.ArtifactManager
+	jetCoordinator  core.JetCoordinator
+}
+
+func (l *ledger) GetArtifactManager() core.ArtifactManager {
+	return l.artifactManager
+}
+
+func (l *ledger) GetJetCoordinator() core.JetCoordinator {
+	return l.jetCoordinator
+}
+
+func (l *ledger) GetPulseManager() core.PulseManager {
+	return nil
+}
+
+func (l *ledger) GetLocalStorage() core.LocalStorage {
+	return nil
+}
+
+// networkParameters makes LogicRunner use its configured defaults, root domain is not available offline.
+type networkParameters struct{}
+
+func (*networkParameters) GetNetworkParameter(name string) (string, bool) {
+	return "", false
+}
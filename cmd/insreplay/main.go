@@ -0,0 +1,134 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/log"
+	"github.com/insolar/insolar/messagebus"
+)
+
+// insreplay re-runs LogicRunner processing of a single captured pulse offline.
+//
+// It takes a capture written by a node with non-empty Capture.Dir and the node's configuration. Ledger data directory
+// from the configuration should point to a copy of the node storage, the tool opens it for reading jet trees and
+// genesis state. All messages LogicRunner sends are answered from the capture, so no network is required. Contracts
+// are executed by builtin executor or by insgorund configured in LogicRunner.GoPlugin.
+func main() {
+	configPath := pflag.StringP("config", "c", "", "path to config file of the captured node")
+	capturePath := pflag.StringP("capture", "f", "", "path to capture file")
+	wait := pflag.Duration("wait", 5*time.Second, "time to wait for asynchronous executions to finish after replay")
+	pflag.Parse()
+
+	if *capturePath == "" {
+		log.Error("capture file is required")
+		os.Exit(2)
+	}
+
+	cfgHolder := configuration.NewHolder()
+	var err error
+	if *configPath != "" {
+		err = cfgHolder.LoadFromFile(*configPath)
+	} else {
+		err = cfgHolder.Load()
+	}
+	if err != nil {
+		log.Warnln("failed to load configuration from file: ", err.Error())
+	}
+	cfg := cfgHolder.Configuration
+	// replay must not produce captures of itself
+	cfg.Capture.Dir = ""
+
+	ctx := context.Background()
+	inslog, err := log.NewLog(cfg.Log)
+	checkError(ctx, err, "failed to create logger")
+	ctx = inslogger.SetLogger(ctx, inslog)
+
+	capture, err := readCapture(*capturePath)
+	checkError(ctx, err, "failed to read capture")
+
+	replayer, cm, err := initComponents(ctx, cfg, capture)
+	checkError(ctx, err, "failed to create components")
+
+	err = cm.Init(ctx)
+	checkError(ctx, err, "failed to init components")
+	err = cm.Start(ctx)
+	checkError(ctx, err, "failed to start components")
+
+	result, err := replayer.Replay(ctx)
+	checkError(ctx, err, "failed to replay capture")
+
+	time.Sleep(*wait)
+	report(capture, result, replayer)
+
+	err = cm.Stop(ctx)
+	checkError(ctx, err, "failed to stop components")
+
+	if len(result.Mismatches) > 0 || len(replayer.Unexpected()) > 0 || len(replayer.Unsent()) > 0 {
+		os.Exit(1)
+	}
+}
+
+func readCapture(path string) (*messagebus.Capture, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() // nolint: errcheck
+	return messagebus.ReadCapture(f)
+}
+
+func report(capture *messagebus.Capture, result *messagebus.ReplayResult, replayer *messagebus.Replayer) {
+	fmt.Printf("Pulse %d, node %s\n", capture.Pulse.PulseNumber, capture.Origin)
+	fmt.Printf("Delivered %d of %d inbound messages, %d without handler\n",
+		result.Delivered, len(capture.Inbound), result.Skipped)
+
+	for _, m := range result.Mismatches {
+		fmt.Printf("Reply mismatch for inbound #%d %s: captured %s, replayed %s\n", m.Index, m.Type, m.Expected, m.Got)
+	}
+	for _, t := range replayer.Unexpected() {
+		fmt.Printf("Sent message %s which is absent in capture\n", t)
+	}
+
+	unsent := replayer.Unsent()
+	types := make([]string, 0, len(unsent))
+	counts := map[string]int{}
+	for t, count := range unsent {
+		types = append(types, t.String())
+		counts[t.String()] = count
+	}
+	sort.Strings(types)
+	for _, t := range types {
+		fmt.Printf("Captured %d message(s) %s which were not sent in replay\n", counts[t], t)
+	}
+}
+
+func checkError(ctx context.Context, err error, message string) {
+	if err == nil {
+		return
+	}
+	inslogger.FromContext(ctx).Fatalf("%v: %v", message, err.Error())
+}
@@ -41,6 +41,7 @@ import (
 	"github.com/insolar/insolar/pulsar"
 	"github.com/insolar/insolar/pulsar/entropygenerator"
 	pulsarstorage "github.com/insolar/insolar/pulsar/storage"
+	"github.com/insolar/insolar/pulsar/testpulsar"
 	"github.com/insolar/insolar/version"
 	"github.com/spf13/cobra"
 	jww "github.com/spf13/jwalterweatherman"
@@ -104,6 +105,11 @@ func main() {
 	}
 	defer jaegerflush()
 
+	if cfgHolder.Configuration.Pulsar.TestSchedule != "" {
+		runTestPulsar(ctx, cfgHolder.Configuration.Pulsar)
+		return
+	}
+
 	cm, server, storage := initPulsar(ctx, cfgHolder.Configuration)
 	server.ID = traceID
 
@@ -193,6 +199,9 @@ func initPulsar(ctx context.Context, cfg configuration.Configuration) (*componen
 	return cm, server, storage
 }
 
+// adaptivePulseCheckInterval is how often pulsar checks if it's time to start next pulse of adaptive duration
+const adaptivePulseCheckInterval = 100 * time.Millisecond
+
 func runPulsar(ctx context.Context, server *pulsar.Pulsar, cfg configuration.Pulsar) (pulseTicker *time.Ticker, refreshTicker *time.Ticker) {
 	server.CheckConnectionsToPulsars(ctx)
 
@@ -203,10 +212,24 @@ func runPulsar(ctx context.Context, server *pulsar.Pulsar, cfg configuration.Pul
 		inslogger.FromContext(ctx).Fatal(err)
 		panic(err)
 	}
-	pulseTicker = time.NewTicker(time.Duration(cfg.PulseTime) * time.Millisecond)
+	if cfg.MinNumberDelta != 0 && cfg.MaxNumberDelta != 0 {
+		// pulse duration is adaptive, so next pulse starts when time reaches its number announced in the last pulse
+		pulseTicker = time.NewTicker(adaptivePulseCheckInterval)
+	} else {
+		pulseTicker = time.NewTicker(time.Duration(cfg.PulseTime) * time.Millisecond)
+	}
 	go func() {
 		for range pulseTicker.C {
-			err = server.StartConsensusProcess(ctx, core.PulseNumber(server.GetLastPulse().PulseNumber+core.PulseNumber(cfg.NumberDelta)))
+			lastPulse := server.GetLastPulse()
+			nextPulseNumber := core.PulseNumber(lastPulse.PulseNumber + core.PulseNumber(cfg.NumberDelta))
+			if cfg.MinNumberDelta != 0 && cfg.MaxNumberDelta != 0 {
+				// last pulse may be not known yet or it's not time for the next one
+				nextPulseNumber = lastPulse.NextPulseNumber
+				if nextPulseNumber <= lastPulse.PulseNumber || core.CalculatePulseNumber(time.Now()) < nextPulseNumber {
+					continue
+				}
+			}
+			err = server.StartConsensusProcess(ctx, nextPulseNumber)
 			if err != nil {
 				inslogger.FromContext(ctx).Fatal(err)
 				panic(err)
@@ -224,6 +247,42 @@ func runPulsar(ctx context.Context, server *pulsar.Pulsar, cfg configuration.Pul
 	return
 }
 
+// runTestPulsar emits pulses on configured schedule till pulsar is stopped.
+func runTestPulsar(ctx context.Context, cfg configuration.Pulsar) {
+	schedule, err := testpulsar.ParseSchedule(cfg.TestSchedule)
+	if err != nil {
+		inslogger.FromContext(ctx).Fatal(err)
+	}
+	inslogger.FromContext(ctx).Warnf("Starts test pulsar with schedule %q", cfg.TestSchedule)
+
+	tp, err := transport.NewTransport(cfg.DistributionTransport, relay.NewProxy())
+	if err != nil {
+		inslogger.FromContext(ctx).Fatal(err)
+	}
+	pulseDistributor, err := pulsenetwork.NewDistributor(cfg.PulseDistributor)
+	if err != nil {
+		inslogger.FromContext(ctx).Fatal(err)
+	}
+
+	cm := &component.Manager{}
+	cm.Inject(tp, pulseDistributor, testpulsar.New(schedule, 0))
+	if err = cm.Init(ctx); err != nil {
+		inslogger.FromContext(ctx).Fatal(err)
+	}
+	if err = cm.Start(ctx); err != nil {
+		inslogger.FromContext(ctx).Fatal(err)
+	}
+
+	var gracefulStop = make(chan os.Signal, 1)
+	signal.Notify(gracefulStop, syscall.SIGTERM)
+	signal.Notify(gracefulStop, syscall.SIGINT)
+	<-gracefulStop
+
+	if err = cm.Stop(ctx); err != nil {
+		inslogger.FromContext(ctx).Error(err)
+	}
+}
+
 func initLogger(ctx context.Context, cfg configuration.Log, traceid string) (context.Context, core.Logger) {
 	inslog, err := log.NewLog(cfg)
 	if err != nil {
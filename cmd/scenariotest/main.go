@@ -0,0 +1,115 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/insolar/insolar/api/sdk"
+	"github.com/insolar/insolar/api/sdk/scenario"
+	"github.com/insolar/insolar/api/sdk/smoke"
+	"github.com/insolar/insolar/log"
+	"github.com/spf13/pflag"
+)
+
+func main() {
+	cfg := scenario.NewConfig()
+	readyCfg := smoke.NewConfig()
+	var rootMemberKeys, junitPath, logLevel string
+	var check bool
+	pflag.StringArrayVarP(&readyCfg.APIURLs, "apiurl", "u", readyCfg.APIURLs, "url to api")
+	pflag.StringVarP(&rootMemberKeys, "rootmemberkeys", "k", "", "path to file with RootMember keys")
+	pflag.StringVarP(&junitPath, "junit", "j", "", "path to JUnit XML report")
+	pflag.BoolVar(&check, "check", false, "only check scenarios against schema")
+	pflag.DurationVar(&readyCfg.ReadyTimeout, "readytimeout", readyCfg.ReadyTimeout, "how long to wait for network")
+	pflag.DurationVar(&cfg.BalanceTimeout, "balancetimeout", cfg.BalanceTimeout, "default time of waiting for balance")
+	pflag.DurationVar(&cfg.PulseTimeout, "pulsetimeout", cfg.PulseTimeout, "how long to wait for every pulse")
+	pflag.StringVarP(&logLevel, "loglevel", "l", "warn", "log level")
+	pflag.Parse()
+
+	err := log.SetLevel(logLevel)
+	if err != nil {
+		fmt.Printf("Can't set '%s' level on logger: %s\n", logLevel, err)
+		os.Exit(1)
+	}
+
+	if pflag.NArg() == 0 {
+		fmt.Println("No scenario files")
+		os.Exit(1)
+	}
+	var scenarios []*scenario.Scenario
+	for _, path := range pflag.Args() {
+		s, err := scenario.Load(path)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		scenarios = append(scenarios, s)
+	}
+	if check {
+		fmt.Printf("%d scenarios are valid\n", len(scenarios))
+		return
+	}
+
+	ctx := context.Background()
+	err = smoke.WaitReady(ctx, readyCfg)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	client, err := sdk.NewSDK(readyCfg.APIURLs, rootMemberKeys)
+	if err != nil {
+		fmt.Println("Can't create SDK:", err)
+		os.Exit(1)
+	}
+
+	passed := true
+	var results []*scenario.Result
+	for _, s := range scenarios {
+		res := scenario.Run(ctx, cfg, client, s)
+		if err := res.Write(os.Stdout); err != nil {
+			fmt.Println("Can't write report:", err)
+		}
+		passed = passed && res.Passed()
+		results = append(results, res)
+	}
+
+	if junitPath != "" {
+		err := writeJUnit(junitPath, results)
+		if err != nil {
+			fmt.Println("Can't write JUnit report:", err)
+			os.Exit(1)
+		}
+	}
+	if !passed {
+		os.Exit(1)
+	}
+}
+
+func writeJUnit(path string, results []*scenario.Result) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	err = scenario.WriteJUnit(f, results)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -0,0 +1,55 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/insolar/insolar/api/sdk/smoke"
+	"github.com/insolar/insolar/log"
+	"github.com/spf13/pflag"
+)
+
+func main() {
+	cfg := smoke.NewConfig()
+	var logLevel string
+	pflag.StringArrayVarP(&cfg.APIURLs, "apiurl", "u", cfg.APIURLs, "url to api")
+	pflag.StringVarP(&cfg.RootMemberKeys, "rootmemberkeys", "k", "", "path to file with RootMember keys")
+	pflag.UintVarP(&cfg.Amount, "amount", "a", cfg.Amount, "amount to transfer between members")
+	pflag.DurationVarP(&cfg.MaxLatency, "maxlatency", "t", cfg.MaxLatency, "max duration of one step (0 disables check)")
+	pflag.DurationVar(&cfg.ReadyTimeout, "readytimeout", cfg.ReadyTimeout, "how long to wait for network to become ready")
+	pflag.DurationVar(&cfg.BalanceTimeout, "balancetimeout", cfg.BalanceTimeout, "how long to wait for balances after transfer")
+	pflag.StringVarP(&logLevel, "loglevel", "l", "warn", "log level")
+	pflag.Parse()
+
+	err := log.SetLevel(logLevel)
+	if err != nil {
+		fmt.Printf("Can't set '%s' level on logger: %s\n", logLevel, err)
+		os.Exit(1)
+	}
+
+	report := smoke.Run(context.Background(), cfg)
+	err = report.Write(os.Stdout)
+	if err != nil {
+		fmt.Println("Can't write report:", err)
+	}
+	if !report.Passed() {
+		os.Exit(1)
+	}
+}
@@ -18,6 +18,7 @@ package configuration
 
 import (
 	"fmt"
+	"time"
 )
 
 // APIRunner holds configuration for api
@@ -25,7 +26,137 @@ type APIRunner struct {
 	Address string
 	Call    string
 	RPC     string
+	// Query is a path ledger queries are served on by heavy material nodes, records are streamed as JSON lines.
+	// Empty path disables queries.
+	Query   string
 	Timeout uint32
+	// UnixSocket is a path of Unix domain socket API is served on in addition to Address, empty disables it.
+	UnixSocket string
+	// UnixSocketMode is a permission bits of UnixSocket file.
+	UnixSocketMode uint32
+	// Deploy is a configuration of contract deployment API.
+	Deploy ContractDeploy
+	// AdminToken is a secret which must be passed in "Authorization: Bearer <token>" header of admin operations
+	// like resetting execution state of object. Empty token disables admin operations.
+	AdminToken string
+	// RequestRetention is a period outcomes of API requests are kept for querying by QID, zero keeps them forever.
+	RequestRetention time.Duration
+	// MaxBodySize is a max size of request body in bytes, larger requests are rejected with 413 status.
+	// Zero means unlimited.
+	MaxBodySize int64
+	// Methods holds limits of particular member methods like "GetBalance" or "DumpAllUsers",
+	// methods not listed here use Timeout and aren't limited in concurrency. Method names are case-insensitive.
+	Methods map[string]MethodLimits
+	// SessionTTL is a time session of conversational calls lives after the last call, zero disables sessions.
+	SessionTTL time.Duration
+	// Secp256k1Keys allows members to have secp256k1 keys of Ethereum-style wallets. Requests of such members
+	// are signed the way wallets sign messages (personal_sign). Contract runners (insgorund) must be started
+	// with the same setting.
+	Secp256k1Keys bool
+	// ReadOnlyMethods are member methods which don't change state, they're still allowed while network
+	// is paused by root authority. Method names are case-insensitive.
+	ReadOnlyMethods []string
+	// ShedServices are JSON-RPC services ("pulses") or methods ("requests.Get") of explorer queries. Like calls of
+	// ReadOnlyMethods they're low priority work shed first when node is overloaded. Names are case-insensitive.
+	ShedServices []string
+	// MethodRoles holds roles members need to call particular member methods: public, member, operator or root.
+	// Operators are granted by root member in root domain. Methods not listed here require member role.
+	// Method names are case-insensitive.
+	MethodRoles map[string]string
+	// SelfCheck holds thresholds of node self-check reported by status API.
+	SelfCheck SelfCheck
+	// Server holds limits of HTTP server API is served by.
+	Server APIServer
+	// Receipts holds configuration of webhooks members subscribe to receipts of incoming transfers with.
+	Receipts ReceiptWebhooks
+	// StatusWebhooks holds configuration of webhooks status updates of pending API requests are pushed to.
+	StatusWebhooks StatusWebhooks
+	// PublicURL is a URL of JSON-RPC API clients reach this node at, it's announced to other nodes for client-side
+	// load balancing. Empty URL means "http://" + Address + RPC.
+	PublicURL string
+	// PriorityCallsPerPulse is a number of calls each member may mark as latency-sensitive in one pulse, calls over
+	// the quota are executed as regular ones. Zero disables prioritization.
+	PriorityCallsPerPulse int
+	// Jobs holds configuration of asynchronous calls, which return job ID immediately and are executed
+	// in background.
+	Jobs APIJobs
+}
+
+// APIJobs holds configuration of asynchronous calls for long operations like "DumpAllUsers" which exceed
+// timeouts of requests. Jobs are local to API node, their progress and results are polled from the same node.
+type APIJobs struct {
+	// Workers is a number of jobs executed at once, zero disables asynchronous calls.
+	Workers int
+	// QueueSize is a max number of jobs waiting for execution, new jobs are rejected with busy error above it.
+	QueueSize int
+	// Timeout limits time of job execution.
+	Timeout time.Duration
+	// TTL is a time progress and result of job are kept after it's finished.
+	TTL time.Duration
+}
+
+// ReceiptWebhooks holds configuration of transfer receipt webhooks. Subscriptions are local to API node,
+// receipts are pushed for transfers served by the node.
+type ReceiptWebhooks struct {
+	// MaxPerMember is a max number of webhooks one member may subscribe, zero disables subscriptions.
+	MaxPerMember int
+	// Attempts is a number of attempts to deliver receipt to webhook.
+	Attempts int
+	// RetryDelay is a delay before the second attempt, it doubles with each next attempt.
+	RetryDelay time.Duration
+}
+
+// StatusWebhooks holds configuration of webhooks of pending API requests. Subscriptions are local to API node and
+// signed by member who made the request, webhooks may target allowed hosts only.
+type StatusWebhooks struct {
+	// Hosts are hosts webhooks may target, "host" allows any port of host, "host:port" allows the port only.
+	// Empty list disables subscriptions.
+	Hosts []string
+	// MaxSubscriptions is a max number of subscriptions kept at once, new ones are rejected above it.
+	MaxSubscriptions int
+	// MaxPerRequest is a max number of webhooks of one request.
+	MaxPerRequest int
+	// TTL is a time subscription lives, zero means one hour.
+	TTL time.Duration
+}
+
+// APIServer holds configuration of HTTP server of API. Timeouts and limits protect node from slowloris-style
+// exhaustion, zero values disable them.
+type APIServer struct {
+	// HTTP2 enables HTTP/2 without TLS (h2c) along with HTTP/1.1, clients making many small requests
+	// reuse one connection for them.
+	HTTP2 bool
+	// MaxConcurrentStreams is a max number of HTTP/2 requests served at once on one connection.
+	MaxConcurrentStreams uint32
+	// ReadHeaderTimeout limits time of reading request headers.
+	ReadHeaderTimeout time.Duration
+	// ReadTimeout limits time of reading whole request including body.
+	ReadTimeout time.Duration
+	// WriteTimeout limits time from the end of reading request headers to the end of writing response,
+	// it must exceed timeouts of calls.
+	WriteTimeout time.Duration
+	// IdleTimeout limits time keep-alive connection waits for next request.
+	IdleTimeout time.Duration
+	// MaxHeaderBytes is a max size of request headers in bytes.
+	MaxHeaderBytes int
+	// MaxConnections is a max number of connections open at once on each listener, connections above it wait
+	// until others are closed.
+	MaxConnections int
+}
+
+// MethodLimits holds limits of calls of a member method.
+type MethodLimits struct {
+	// Timeout limits time of call execution, zero means Timeout of API is used.
+	Timeout time.Duration
+	// MaxConcurrent is a max number of calls of method executed at once, calls above it are rejected
+	// with busy error. Zero means unlimited.
+	MaxConcurrent int
+}
+
+// ContractDeploy holds configuration of contract deployment API.
+type ContractDeploy struct {
+	// BuildTimeout limits time of compilation of contract.
+	BuildTimeout time.Duration
 }
 
 // NewAPIRunner creates new api config
@@ -34,11 +165,73 @@ func NewAPIRunner() APIRunner {
 		Address: "localhost:19101",
 		Call:    "/api/call",
 		RPC:     "/api/rpc",
+		Query:   "/api/query",
 		Timeout: 15,
+
+		UnixSocket:     "",
+		UnixSocketMode: 0660,
+
+		Deploy: ContractDeploy{
+			BuildTimeout: 2 * time.Minute,
+		},
+
+		RequestRetention: 24 * time.Hour,
+		MaxBodySize:      4 << 20,
+		SessionTTL:       time.Minute,
+		AdminToken:       "",
+
+		// keys are lowercase like viper loads them, otherwise loaded config gets both spellings
+		Methods: map[string]MethodLimits{
+			"dumpallusers": {Timeout: time.Minute, MaxConcurrent: 1},
+		},
+		ReadOnlyMethods: []string{
+			"GetMyBalance", "GetBalance", "GetTransferStatus", "DumpUserInfo", "DumpAllUsers",
+			"GetNodeRef", "GetPrototypeByName", "ListPrototypes", "GetNetworkParameters", "ResolveAlias",
+			"GetSpendingLimits",
+		},
+		ShedServices: []string{"exporter", "pulses", "timeline", "requests.Get", "nodes.Active"},
+		// keys are lowercase like viper loads them, otherwise loaded config gets both spellings
+		MethodRoles: map[string]string{
+			"dumpallusers":        "operator",
+			"createmember":        "root",
+			"bulkcreatemembers":   "root",
+			"registernode":        "root",
+			"registerprototype":   "root",
+			"setnetworkparameter": "root",
+			"setmemberrole":       "root",
+		},
+		SelfCheck: NewSelfCheck(),
+		Server: APIServer{
+			HTTP2:                true,
+			MaxConcurrentStreams: 250,
+			ReadHeaderTimeout:    5 * time.Second,
+			ReadTimeout:          30 * time.Second,
+			WriteTimeout:         2 * time.Minute,
+			IdleTimeout:          2 * time.Minute,
+			MaxHeaderBytes:       64 << 10,
+			MaxConnections:       1000,
+		},
+		Receipts: ReceiptWebhooks{
+			MaxPerMember: 5,
+			Attempts:     5,
+			RetryDelay:   time.Second,
+		},
+		StatusWebhooks: StatusWebhooks{
+			MaxSubscriptions: 10000,
+			MaxPerRequest:    4,
+			TTL:              time.Hour,
+		},
+		PriorityCallsPerPulse: 10,
+		Jobs: APIJobs{
+			Workers:   2,
+			QueueSize: 100,
+			Timeout:   10 * time.Minute,
+			TTL:       time.Hour,
+		},
 	}
 }
 
 func (ar *APIRunner) String() string {
-	res := fmt.Sprintln("Addr ->", ar.Address, ", Call ->", ar.Call, ", RPC ->", ar.RPC)
+	res := fmt.Sprintln("Addr ->", ar.Address, ", UnixSocket ->", ar.UnixSocket, ", Call ->", ar.Call, ", RPC ->", ar.RPC)
 	return res
 }
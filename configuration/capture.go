@@ -0,0 +1,28 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package configuration
+
+// Capture holds configuration for recording of messages processed by node, captures are used for offline replay.
+type Capture struct {
+	// Dir is a directory to write one capture file per pulse to. Empty value disables capture.
+	Dir string
+}
+
+// NewCapture creates new default configuration for message capture.
+func NewCapture() Capture {
+	return Capture{}
+}
@@ -0,0 +1,40 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package configuration
+
+import (
+	"time"
+)
+
+// ClockSkew holds configuration for clock skew monitor.
+type ClockSkew struct {
+	// Threshold is a maximum allowed difference between local clock and network time.
+	Threshold time.Duration
+	// Window is a number of recent samples skew is estimated by (median is used).
+	Window int
+	// RefuseExecutorRoles makes node refuse executor roles while its clock is skewed.
+	RefuseExecutorRoles bool
+}
+
+// NewClockSkew creates new default configuration for clock skew monitor.
+func NewClockSkew() ClockSkew {
+	return ClockSkew{
+		Threshold:           3 * time.Second,
+		Window:              5,
+		RefuseExecutorRoles: false,
+	}
+}
@@ -40,12 +40,29 @@ type Configuration struct {
 	KeysPath        string
 	CertificatePath string
 	Tracer          Tracer
+	Secrets         Secrets
+	CrashReport     CrashReport
+	Watchdog        Watchdog
+	Scheduler       Scheduler
+	ClockSkew       ClockSkew
+	Timeline        Timeline
+	Faucet          Faucet
+	Notifier        Notifier
+	Capture         Capture
+	Membership      Membership
+	SelfTest        SelfTest
+	Ephemeral       Ephemeral
+	LoadShedding    LoadShedding
+	Requester       ContractRequester
+	Resources       Resources
 }
 
 // Holder provides methods to manage configuration
 type Holder struct {
 	Configuration Configuration
 	viper         *viper.Viper
+	// secrets are paths of values resolved from secret references
+	secrets []string
 }
 
 // NewConfiguration creates new default configuration
@@ -63,6 +80,21 @@ func NewConfiguration() Configuration {
 		KeysPath:        "./",
 		CertificatePath: "",
 		Tracer:          NewTracer(),
+		Secrets:         NewSecrets(),
+		CrashReport:     NewCrashReport(),
+		Watchdog:        NewWatchdog(),
+		Scheduler:       NewScheduler(),
+		ClockSkew:       NewClockSkew(),
+		Timeline:        NewTimeline(),
+		Faucet:          NewFaucet(),
+		Notifier:        NewNotifier(),
+		Capture:         NewCapture(),
+		Membership:      NewMembership(),
+		SelfTest:        NewSelfTest(),
+		Ephemeral:       NewEphemeral(),
+		LoadShedding:    NewLoadShedding(),
+		Requester:       NewContractRequester(),
+		Resources:       NewResources(),
 	}
 
 	return cfg
@@ -94,6 +126,10 @@ func (c *Holder) Init(required bool) (*Holder, error) {
 	if err != nil {
 		return nil, err
 	}
+	err = c.ResolveSecrets()
+	if err != nil {
+		return nil, err
+	}
 	return c, nil
 }
 
@@ -130,6 +166,19 @@ func (c *Holder) LoadEnv() error {
 	return c.viper.Unmarshal(&c.Configuration)
 }
 
+// ResolveSecrets replaces secret references (file://, env://, vault://) in configuration with secret values
+func (c *Holder) ResolveSecrets() error {
+	resolver := NewSecretResolver(c.Configuration.Secrets)
+	err := resolver.ResolveAll(&c.Configuration)
+	c.secrets = append(c.secrets, resolver.Resolved()...)
+	return err
+}
+
+// RedactedString returns yaml dump of configuration with resolved secrets redacted
+func (c *Holder) RedactedString() string {
+	return ToRedactedString(c.Configuration, c.secrets)
+}
+
 // LoadFromFile method reads configuration from particular file path
 func (c *Holder) LoadFromFile(path string) error {
 	c.viper.SetConfigFile(path)
@@ -0,0 +1,93 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package configuration
+
+import (
+	"time"
+)
+
+// ConsensusFanout holds configuration of sending packets of consensus phase to participants.
+type ConsensusFanout struct {
+	// BatchSize is a number of packets sent at once, zero sends all packets at once.
+	BatchSize int
+	// PacingRatio is a portion of phase window batches are spread over, zero sends batches without pauses.
+	PacingRatio float64
+	// Retries is a number of retries of failed send, retries are made within phase deadline only.
+	Retries int
+	// RetryDelay is a pause before retry of failed send.
+	RetryDelay time.Duration
+}
+
+// ConsensusEviction holds configuration of evicting nodes which keep missing consensus rounds.
+type ConsensusEviction struct {
+	// MissedRounds is a number of consecutive rounds node misses phase 1 before it is evicted, zero disables eviction.
+	MissedRounds int
+	// RejoinPulses is a number of pulses join claims of evicted node are ignored for.
+	RejoinPulses int
+}
+
+// ConsensusDeadlines holds configuration of deadlines of consensus phases 2, 2.1 and 3.
+type ConsensusDeadlines struct {
+	// Adaptive enables picking deadlines from round trip times to participants measured in previous pulse.
+	Adaptive bool
+	// RTTFactor is a multiplier of the largest median round trip time to participant.
+	RTTFactor float64
+	// MinFraction and MaxFraction are bounds of deadline as portions of pulse duration,
+	// MaxFraction is used as is if adaptive deadlines are disabled or there are no measurements yet.
+	MinFraction float64
+	MaxFraction float64
+}
+
+// Consensus holds configuration of consensus phases.
+type Consensus struct {
+	Phase1 ConsensusFanout
+	Phase2 ConsensusFanout
+	Phase3 ConsensusFanout
+
+	Eviction ConsensusEviction
+
+	Deadlines ConsensusDeadlines
+
+	// VerifyWorkers is a number of workers verifying signatures of received phase packets in parallel,
+	// zero means number of CPUs available to the process.
+	VerifyWorkers int
+}
+
+// NewConsensus creates new default configuration of consensus phases.
+func NewConsensus() Consensus {
+	fanout := ConsensusFanout{
+		BatchSize:   16,
+		PacingRatio: 0.2,
+		Retries:     2,
+		RetryDelay:  10 * time.Millisecond,
+	}
+	return Consensus{
+		Phase1: fanout,
+		Phase2: fanout,
+		Phase3: fanout,
+		Eviction: ConsensusEviction{
+			MissedRounds: 3,
+			RejoinPulses: 10,
+		},
+		Deadlines: ConsensusDeadlines{
+			Adaptive:    false,
+			RTTFactor:   4,
+			MinFraction: 0.02,
+			MaxFraction: 0.05,
+		},
+	}
+}
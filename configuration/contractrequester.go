@@ -0,0 +1,32 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package configuration
+
+// ContractRequester holds configuration of contract requester which sends calls to executors of objects.
+type ContractRequester struct {
+	// PulseTail is a share of pulse at its end in percents. Calls issued in the tail are delayed to the next pulse,
+	// since they're likely to race handover of executors and fail or time out. Latency-sensitive calls aren't
+	// delayed. Zero disables delays.
+	PulseTail uint8
+}
+
+// NewContractRequester creates new default configuration of contract requester, delays are disabled.
+func NewContractRequester() ContractRequester {
+	return ContractRequester{
+		PulseTail: 0,
+	}
+}
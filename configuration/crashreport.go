@@ -0,0 +1,34 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package configuration
+
+// CrashReport holds configuration for panic recovery and crash reports.
+type CrashReport struct {
+	// Directory is a directory where crash report files are written,
+	// if empty crash reports are only logged.
+	Directory string
+	// LogTailSize is a number of recent log lines included in crash report.
+	LogTailSize int
+}
+
+// NewCrashReport creates new default configuration for crash reports.
+func NewCrashReport() CrashReport {
+	return CrashReport{
+		Directory:   "./data/crashes",
+		LogTailSize: 200,
+	}
+}
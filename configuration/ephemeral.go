@@ -0,0 +1,38 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package configuration
+
+// Ephemeral holds configuration of ephemeral identity of test and devnet nodes.
+//
+// NOT FOR PRODUCTION: ephemeral certificate is signed by the node itself, so networks which admit such nodes
+// (see HostNetwork.AllowEphemeral) can be joined by anyone.
+type Ephemeral struct {
+	// Enabled makes node generate its keys and certificate on startup instead of reading them from KeysPath and
+	// CertificatePath. Certificate from CertificatePath is used as a template of network part then, e.g. discovery
+	// nodes, pulsar keys and root domain, its node part is ignored.
+	Enabled bool
+	// Role is a static role of ephemeral node, e.g. "virtual".
+	Role string
+}
+
+// NewEphemeral creates new default configuration of ephemeral identity, it is disabled.
+func NewEphemeral() Ephemeral {
+	return Ephemeral{
+		Enabled: false,
+		Role:    "virtual",
+	}
+}
@@ -0,0 +1,43 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package configuration
+
+import (
+	"time"
+)
+
+// Faucet holds configuration of token faucet. Faucet must be enabled only on test networks.
+type Faucet struct {
+	// Enabled turns on giving tokens via faucet API.
+	Enabled bool
+	// MemberFile is a path of JSON file with reference ("caller") and private key ("private_key") of faucet member.
+	MemberFile string
+	// MaxAmount is a maximum amount of tokens given by one request.
+	MaxAmount uint
+	// Period is a minimal period between two requests for the same recipient.
+	Period time.Duration
+}
+
+// NewFaucet creates new default configuration of token faucet.
+func NewFaucet() Faucet {
+	return Faucet{
+		Enabled:    false,
+		MemberFile: "",
+		MaxAmount:  1000,
+		Period:     time.Hour,
+	}
+}
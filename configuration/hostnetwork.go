@@ -16,6 +16,10 @@
 
 package configuration
 
+import (
+	"time"
+)
+
 // Transport holds transport protocol configuration for HostNetwork
 type Transport struct {
 	// protocol type
@@ -24,24 +28,71 @@ type Transport struct {
 	Address string
 	// if true transport will use network traversal technique(like STUN) to get PublicAddress
 	BehindNAT bool
+	// KeepAlive is a period of TCP keep-alive probes of node connections, zero means system default
+	KeepAlive time.Duration
+	// ReconnectAttempts is a number of attempts to restore connection closed by remote host, zero disables reconnect
+	ReconnectAttempts int
+	// ReconnectBackoff configures delays between reconnect attempts
+	ReconnectBackoff Backoff
+	// MaxStreams limits number of packets simultaneously sent over one connection, zero means no limit
+	MaxStreams int
+	// StreamTimeout is a deadline for sending one packet over connection, zero means no deadline
+	StreamTimeout time.Duration
+	// Bandwidth caps traffic classes (consensus, replication, contract) in bytes per second,
+	// classes without cap or with zero cap aren't limited
+	Bandwidth map[string]int64
+	// ConsensusPriority delays capped classes while consensus packets are being sent
+	ConsensusPriority bool
 }
 
 // HostNetwork holds configuration for HostNetwork
 type HostNetwork struct {
 	Transport           Transport
-	IsRelay             bool  // set if node must be relay explicit
-	InfinityBootstrap   bool  // set true for infinity tries to bootstrap
-	MinTimeout          int   // bootstrap timeout min
-	MaxTimeout          int   // bootstrap timeout max
-	TimeoutMult         int   // bootstrap timout multiplier
-	SignMessages        bool  // signing a messages if true
-	HandshakeSessionTTL int32 // ms
+	IsRelay             bool   // set if node must be relay explicit
+	InfinityBootstrap   bool   // set true for infinity tries to bootstrap
+	MinTimeout          int    // bootstrap timeout min
+	MaxTimeout          int    // bootstrap timeout max
+	TimeoutMult         int    // bootstrap timout multiplier
+	SignMessages        bool   // signing a messages if true
+	SessionKeys         bool   // authenticate ordinary messages with per-pulse session keys instead of signatures if SignMessages is true
+	HandshakeSessionTTL int32  // ms
+	AllowObservers      bool   // admit nodes with observer role to the network
+	AllowEphemeral      bool   // admit nodes with self-signed ephemeral certificates, NEVER enable it in production
+	ParcelTTL           uint32 // number of pulses parcel stays valid after pulse it was sent in, zero disables expiration
+	MaxNodeMessageSize  int    // max size of payload of application messages sent directly to nodes, in bytes
+	PacketCapture       PacketCapture
+}
+
+// PacketCapture holds configuration of in-memory capture of metadata of packets sent and received by node.
+type PacketCapture struct {
+	// Window is a period captured packets are kept for.
+	Window time.Duration
+	// MaxPackets is a max number of kept packets, the oldest packets are dropped above it. Zero disables capture.
+	MaxPackets int
 }
 
 // NewHostNetwork creates new default HostNetwork configuration
 func NewHostNetwork() HostNetwork {
 	// IP address should not be 0.0.0.0!!!
-	transport := Transport{Protocol: "TCP", Address: "127.0.0.1:0", BehindNAT: false}
+	transport := Transport{
+		Protocol:          "TCP",
+		Address:           "127.0.0.1:0",
+		BehindNAT:         false,
+		KeepAlive:         15 * time.Second,
+		ReconnectAttempts: 5,
+		ReconnectBackoff: Backoff{
+			Jitter: true,
+			Min:    100 * time.Millisecond,
+			Max:    5 * time.Second,
+			Factor: 2,
+		},
+		MaxStreams:    64,
+		StreamTimeout: 10 * time.Second,
+		Bandwidth: map[string]int64{
+			"replication": 32 * 1024 * 1024,
+		},
+		ConsensusPriority: true,
+	}
 
 	return HostNetwork{
 		Transport:           transport,
@@ -51,6 +102,15 @@ func NewHostNetwork() HostNetwork {
 		TimeoutMult:         2,
 		InfinityBootstrap:   false,
 		SignMessages:        false,
+		SessionKeys:         false,
 		HandshakeSessionTTL: 5000,
+		AllowObservers:      true,
+		AllowEphemeral:      false,
+		ParcelTTL:           2,
+		MaxNodeMessageSize:  1024 * 1024,
+		PacketCapture: PacketCapture{
+			Window:     time.Minute,
+			MaxPackets: 100000,
+		},
 	}
 }
@@ -27,6 +27,37 @@ type Storage struct {
 	// TxRetriesOnConflict defines how many retries on transaction conflicts
 	// storage update methods should do.
 	TxRetriesOnConflict int
+	// GCInterval is an interval of garbage collection of database value log, zero disables collection.
+	GCInterval time.Duration
+	// Quota configures monitoring of free space of storage.
+	Quota StorageQuota
+	// Encryption configures at-rest encryption of storage values.
+	Encryption StorageEncryption
+}
+
+// StorageEncryption holds configuration of at-rest encryption of storage values.
+type StorageEncryption struct {
+	// KeysFile is a json file with hex encoded 32 bytes AES keys by their ids, e.g. {"1": "8f0a..."}.
+	// Empty file disables encryption. Keys of values which are not re-encrypted yet must stay in the file.
+	KeysFile string
+	// KeyID is an id of key new values are encrypted with. Key rotation replaces it until the next rotation.
+	KeyID uint32
+	// RotationBatch is a number of values checked by one step of key rotation. Values of a step are rewritten in one
+	// transaction, so the batch must fit into transaction size limit of storage.
+	RotationBatch int
+	// RotationPause is an interval between steps of key rotation, it throttles load of rotation on storage.
+	RotationPause time.Duration
+}
+
+// StorageQuota holds configuration of monitoring of free space of storage.
+type StorageQuota struct {
+	// CheckInterval is an interval of checks of free space, zero disables monitoring.
+	CheckInterval time.Duration
+	// WarningHeadroom is a free space in percents below which warnings are emitted.
+	WarningHeadroom uint8
+	// CriticalHeadroom is a free space in percents below which node stops taking executor and validator roles
+	// until space is freed.
+	CriticalHeadroom uint8
 }
 
 // PulseManager holds configuration for PulseManager.
@@ -39,6 +70,26 @@ type PulseManager struct {
 	HeavyBackoff Backoff
 	// SplitThreshold is a drop size threshold in bytes to perform split.
 	SplitThreshold uint64
+	// ChaosScenario is a path to JSON file with pulse faults to inject, it's used only in builds with "chaos" tag.
+	ChaosScenario string
+	// HeavyMaxCongestionDelay caps delay between payloads suggested by congested heavy, zero ignores suggestions.
+	HeavyMaxCongestionDelay time.Duration
+	// HeavySyncConcurrency is a max number of jets replicated to heavy at once, zero means number of CPUs
+	// available to the process, negative value means unlimited.
+	HeavySyncConcurrency int
+}
+
+// HeavyCongestion configures congestion signal heavy node sends to light nodes in replication acks.
+type HeavyCongestion struct {
+	// QueueDepth is a number of payloads stored at once above which light nodes are asked to slow down.
+	QueueDepth int
+	// StoreLatency is an average duration of storing payload above which heavy is considered congested,
+	// e.g. during compaction of database.
+	StoreLatency time.Duration
+	// DelayStep is a delay suggested per payload in excess of QueueDepth.
+	DelayStep time.Duration
+	// MaxDelay caps suggested delay.
+	MaxDelay time.Duration
 }
 
 // Backoff configures retry backoff algorithm
@@ -50,6 +101,34 @@ type Backoff struct {
 	Min, Max time.Duration
 }
 
+// JetPlanner holds configuration for planner of jet tree rebalancing.
+type JetPlanner struct {
+	// Window is a number of recent drops average drop size of jet is calculated over.
+	// It shouldn't be greater than JetSizesHistoryDepth.
+	Window int
+	// MergeThreshold is an average drop size in bytes, sibling jets both below it are recommended to merge.
+	MergeThreshold uint64
+	// Execute approves pulse manager to perform recommended splits instead of manual ones.
+	Execute bool
+}
+
+// Upgrade holds configuration of restart slots granted to nodes during rolling upgrades.
+// It's used by discovery node which grants slots.
+type Upgrade struct {
+	// MaxRestartsPerRole is a max number of nodes of one role restarting at once.
+	MaxRestartsPerRole int
+	// SlotPulses is a number of pulses slot is held for if node doesn't release it.
+	SlotPulses int
+}
+
+// Scrubber holds configuration for background verification of data stored on heavy.
+type Scrubber struct {
+	// Interval is an interval between verification rounds, zero disables verification.
+	Interval time.Duration
+	// BatchSize is a number of entries verified per round.
+	BatchSize int
+}
+
 // RecentStorage holds configuration for RecentStorage
 type RecentStorage struct {
 	// Default TTL is a value of default ttl for redirects
@@ -60,6 +139,21 @@ type RecentStorage struct {
 type Exporter struct {
 	// ExportLag is lag in second before we start to export pulse
 	ExportLag uint32
+	// Analytics holds configuration of scheduled anonymized export for analytics.
+	Analytics Analytics
+}
+
+// Analytics holds configuration of anonymized analytics export made by heavy node.
+type Analytics struct {
+	// Interval is an interval between exports, zero disables export.
+	Interval time.Duration
+	// BucketSize is a width of bucket in pulse numbers, statistics are aggregated per bucket.
+	BucketSize uint32
+	// Dir is a directory export files are written to.
+	Dir string
+	// Salt is mixed into hashes of member references. It should be kept secret and stay the same
+	// between exports, otherwise hashes of the same member can't be matched.
+	Salt string
 }
 
 // Ledger holds configuration for ledger.
@@ -70,6 +164,12 @@ type Ledger struct {
 	PulseManager PulseManager
 	// RecentStorage holds configuration for RecentStorage
 	RecentStorage RecentStorage
+	// JetPlanner holds configuration for planner of jet tree rebalancing.
+	JetPlanner JetPlanner
+	// Scrubber holds configuration for background verification of data stored on heavy.
+	Scrubber Scrubber
+	// Upgrade holds configuration of restart slots granted to nodes during rolling upgrades.
+	Upgrade Upgrade
 
 	// common/sharable values:
 
@@ -88,6 +188,53 @@ type Ledger struct {
 	// PendingRequestsLimit holds a number of pending requests, what can be stored in the system
 	// before they are declined
 	PendingRequestsLimit int
+
+	// PendingNotificationPulses holds a number of pulses abandoned request stays pending for before API node
+	// which accepted it is notified, notifications are repeated every pulse after that. Zero disables notifications.
+	PendingNotificationPulses int
+
+	// LostRequestsScanPulses holds a number of recent pulses light material node scans for requests registered
+	// without results when it starts serving a jet for the first time since start. Requests hot data doesn't list
+	// are put pending and executors of their objects are notified to execute them. Zero disables scan.
+	LostRequestsScanPulses int
+
+	// WriteQuota holds a number of bytes of records and blobs one caller (member or node) can write per pulse,
+	// requests of the caller are declined until next pulse when quota is exceeded. Zero disables quota.
+	WriteQuota int
+
+	// Globule holds globule membership of nodes.
+	//
+	// IMPORTANT: It should be the same on ALL nodes, except of ID.
+	Globule Globule
+
+	// MemorySnapshotInterval is a number of amends after which full object memory is stored, amends in between
+	// store memory as diffs against previous state. Zero disables diffs.
+	MemorySnapshotInterval int
+
+	// HeavyReplicas holds references of heavy material nodes which serve as read replicas of the primary heavy.
+	// Replicas receive records from the primary and serve reads of old pulses, the primary handles writes.
+	//
+	// IMPORTANT: It should be the same on ALL nodes.
+	HeavyReplicas []string
+
+	// HeavyCongestion configures congestion signal of heavy node.
+	HeavyCongestion HeavyCongestion
+}
+
+// Globule holds configuration of globule membership of nodes used for role calculations.
+type Globule struct {
+	// ID is a globule of this node.
+	ID uint32
+	// Members maps references of nodes to their globules, nodes which are not listed belong to globule 0.
+	Members map[string]uint32
+}
+
+// NewGlobule creates new default Globule configuration with single globule.
+func NewGlobule() Globule {
+	return Globule{
+		ID:      0,
+		Members: map[string]uint32{},
+	}
 }
 
 // NewLedger creates new default Ledger configuration.
@@ -96,6 +243,17 @@ func NewLedger() Ledger {
 		Storage: Storage{
 			DataDirectory:       "./data",
 			TxRetriesOnConflict: 3,
+			GCInterval:          10 * time.Minute,
+			Quota: StorageQuota{
+				CheckInterval:    10 * time.Second,
+				WarningHeadroom:  15,
+				CriticalHeadroom: 5,
+			},
+			Encryption: StorageEncryption{
+				KeyID:         1,
+				RotationBatch: 100,
+				RotationPause: time.Second,
+			},
 		},
 
 		PulseManager: PulseManager{
@@ -107,21 +265,59 @@ func NewLedger() Ledger {
 				Max:    2 * time.Second,
 				Factor: 2,
 			},
-			SplitThreshold: 10 * 100, // 10 megabytes.
+			SplitThreshold:          10 * 100, // 10 megabytes.
+			HeavyMaxCongestionDelay: 10 * time.Second,
 		},
 
 		RecentStorage: RecentStorage{
 			DefaultTTL: 10,
 		},
 
+		JetPlanner: JetPlanner{
+			Window:         5,
+			MergeThreshold: 10 * 10,
+			Execute:        false,
+		},
+
+		Scrubber: Scrubber{
+			Interval:  time.Minute,
+			BatchSize: 1000,
+		},
+
+		Upgrade: Upgrade{
+			MaxRestartsPerRole: 1,
+			SlotPulses:         30,
+		},
+
 		LightChainLimit: 5, // 5 pulses
 
 		JetSizesHistoryDepth: 10,
 
 		Exporter: Exporter{
 			ExportLag: 40, // 40 seconds
+			Analytics: Analytics{
+				BucketSize: 3600,
+				Dir:        "data/analytics",
+			},
 		},
 
 		PendingRequestsLimit: 1000,
+
+		PendingNotificationPulses: 2,
+
+		LostRequestsScanPulses: 5,
+
+		Globule: NewGlobule(),
+
+		MemorySnapshotInterval: 10,
+
+		HeavyReplicas: []string{},
+
+		HeavyCongestion: HeavyCongestion{
+			QueueDepth:   4,
+			StoreLatency: 500 * time.Millisecond,
+			DelayStep:    100 * time.Millisecond,
+			MaxDelay:     5 * time.Second,
+		},
 	}
 }
@@ -0,0 +1,46 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package configuration
+
+import (
+	"time"
+)
+
+// LoadShedding holds configuration of load shedder which rejects low priority work (read-only API calls,
+// explorer queries) of overloaded node to keep resources for consensus and executor duties.
+type LoadShedding struct {
+	// MaxCPU is a CPU usage of node process in percents of all cores from which low priority work is shed,
+	// zero disables the check.
+	MaxCPU uint8
+	// MaxQueueDepth is a number of requests queued for execution from which low priority work is shed,
+	// zero disables the check.
+	MaxQueueDepth int
+	// SampleInterval is an interval of load measurements.
+	SampleInterval time.Duration
+	// MaxDelay is a time low priority work waits for load to drop before it's rejected, zero rejects it at once.
+	MaxDelay time.Duration
+}
+
+// NewLoadShedding creates new default configuration of load shedder, shedding is disabled.
+func NewLoadShedding() LoadShedding {
+	return LoadShedding{
+		MaxCPU:         0,
+		MaxQueueDepth:  0,
+		SampleInterval: time.Second,
+		MaxDelay:       500 * time.Millisecond,
+	}
+}
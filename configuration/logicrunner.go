@@ -16,6 +16,10 @@
 
 package configuration
 
+import (
+	"time"
+)
+
 // LogicRunner configuration
 type LogicRunner struct {
 	// RPCListen - address logic runner binds RPC API to
@@ -27,6 +31,86 @@ type LogicRunner struct {
 	BuiltIn *BuiltIn
 	// GoPlugin - configuration of executor based on Go plugins
 	GoPlugin *GoPlugin
+	// MaxQueueLength - max length of execution queue of an object,
+	// requests above it are rejected with busy reply, zero means unlimited
+	MaxQueueLength int
+	// ExecutorWorkers - max number of contract executions running at once, executions waiting for results
+	// of nested calls don't occupy workers. Zero means four workers per CPU available to the process,
+	// negative value means unlimited
+	ExecutorWorkers int
+	// MaxCallerQueueLength - max number of requests of one caller in execution queue of an object,
+	// requests above it are rejected with busy reply, zero means unlimited
+	MaxCallerQueueLength int
+	// FairQueue - interleave requests of distinct callers in execution queue of an object
+	// instead of executing them in order of arrival
+	FairQueue bool
+	// BusyRetryAfter - number of pulses sender should wait before retrying rejected request
+	BusyRetryAfter int
+	// ExecutionDeadline - configuration of deferring executions which won't finish before pulse end,
+	// nil disables the check
+	ExecutionDeadline *ExecutionDeadline
+	// ExecutorResultsDelta - on pulse handover send to the next executor only queue elements
+	// it can't fetch from ledger, elements fetched from ledger as pending requests are left for fetching again
+	ExecutorResultsDelta bool
+	// DrainTimeout - max time to wait for in-flight executions on stop, new requests are rejected
+	// with busy reply while draining
+	DrainTimeout time.Duration
+	// MaxLockTTL - max time contract can hold named lock for, locks are also released on pulse change,
+	// zero means no limit
+	MaxLockTTL time.Duration
+	// PulseSpool - configuration of retrying messages sent on pulse change which failed to be delivered,
+	// nil disables retries
+	PulseSpool *PulseSpool
+	// SessionTTL - time descriptors of objects called within client session are cached for after the last call,
+	// zero disables the cache
+	SessionTTL time.Duration
+	// ValidationSampleRate - share of executions offered to validators, from 0 to 1,
+	// executions marked by contract as high-value are validated regardless of it
+	ValidationSampleRate float64
+	// Preload - base58 references of objects or prototypes fetched into caches with their code on the first pulse
+	// after start and after node rejoins network, keyed by node role, e.g. references of root domain,
+	// node domain and frequently used prototypes for "virtual" role
+	Preload map[string][]string
+	// CaseBindExportPulses - number of recent pulses case binds of executed requests are kept for,
+	// external validators fetch them via API, zero disables export
+	CaseBindExportPulses int
+	// MaxMethodLatencies - max number of distinct prototype and method pairs execution duration histograms
+	// are collected for, executions of other methods are counted under "other" label, zero disables histograms
+	MaxMethodLatencies int
+	// MaxObjectStates - max number of objects execution state is kept in memory for, states of idle objects
+	// not accessed for the longest time are evicted above it and rebuilt from ledger on demand, zero means unlimited
+	MaxObjectStates int
+	// CrossJetProofs - request proofs of object states read from ledger during execution, proofs are recorded
+	// with fetched states, so validators check reads instead of fetching objects again
+	CrossJetProofs bool
+	// SpeculativeExecution - execute request deferred at the end of pulse speculatively against the latest known
+	// state of object, result is committed without execution if object is in the same state after handover,
+	// works with ExecutionDeadline only
+	SpeculativeExecution bool
+	// MaxTimerPulses - max number of pulses ahead contract can schedule call on itself for, zero means no limit
+	MaxTimerPulses int
+	// ResultCompressionThreshold - min size of method result in bytes which is compressed when it's returned
+	// to caller accepting compressed results, zero disables compression
+	ResultCompressionThreshold int
+}
+
+// PulseSpool configuration
+type PulseSpool struct {
+	// MaxPulses - number of pulses failed message is retried for before it's dropped
+	MaxPulses int
+	// RetryDelay - delay before the first retry, it doubles after every failed retry
+	RetryDelay time.Duration
+	// MaxRetryDelay - max delay between retries
+	MaxRetryDelay time.Duration
+}
+
+// ExecutionDeadline configuration
+type ExecutionDeadline struct {
+	// Margin - time left before pulse end execution is expected to finish by,
+	// otherwise it's deferred to the next executor
+	Margin time.Duration
+	// PulseFraction - margin as a fraction of current pulse duration, the larger of two margins is used
+	PulseFraction float64
 }
 
 // BuiltIn configuration, no options at the moment
@@ -51,5 +135,28 @@ func NewLogicRunner() LogicRunner {
 			RunnerListen:   "127.0.0.1:7777",
 			RunnerProtocol: "tcp",
 		},
+		MaxQueueLength: 1000,
+		FairQueue:      true,
+		BusyRetryAfter: 1,
+		ExecutionDeadline: &ExecutionDeadline{
+			Margin:        500 * time.Millisecond,
+			PulseFraction: 0.05,
+		},
+		DrainTimeout: 10 * time.Second,
+		MaxLockTTL:   10 * time.Second,
+		PulseSpool: &PulseSpool{
+			MaxPulses:     2,
+			RetryDelay:    100 * time.Millisecond,
+			MaxRetryDelay: 2 * time.Second,
+		},
+		SessionTTL:           time.Minute,
+		ValidationSampleRate: 1,
+		Preload:              map[string][]string{},
+		CaseBindExportPulses: 3,
+		MaxMethodLatencies:   1000,
+		MaxObjectStates:      100000,
+		MaxTimerPulses:       100000,
+
+		ResultCompressionThreshold: 64 << 10,
 	}
 }
@@ -0,0 +1,49 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package configuration
+
+import (
+	"time"
+)
+
+// Membership holds configuration of publishing network membership changes to external service discovery.
+type Membership struct {
+	// Backend is a service discovery system changes are published to: "webhook" or "consul".
+	// Empty backend disables publishing.
+	Backend string
+	// URL is an address of webhook or HTTP API of Consul agent.
+	URL string
+	// Timeout limits one publishing request.
+	Timeout time.Duration
+	// Roles are roles of nodes which are published, empty list publishes nodes of all roles.
+	Roles []string
+	// ServiceName is a name of service nodes are registered with in Consul, role of node is added as a tag.
+	ServiceName string
+	// ServicePort is a port nodes are registered with, zero keeps port of node network address.
+	ServicePort int
+}
+
+// NewMembership creates new default configuration of membership publishing.
+func NewMembership() Membership {
+	return Membership{
+		Backend:     "",
+		URL:         "",
+		Timeout:     5 * time.Second,
+		Roles:       []string{"api_gateway"},
+		ServiceName: "insolar",
+		ServicePort: 0,
+	}
+}
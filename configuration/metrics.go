@@ -28,6 +28,16 @@ type Metrics struct {
 	// ReportingPeriod defines exporter reporting period
 	// if zero, exporter uses default value (1s)
 	ReportingPeriod time.Duration
+	// DisabledMetrics lists prefixes of names of metrics which aren't collected, names are the ones exported
+	// to Prometheus, e.g. "insolar_network_peer" disables per peer traffic metrics
+	DisabledMetrics []string
+	// AllowedLabels lists labels metrics may have, other labels are dropped and metrics are aggregated over them,
+	// e.g. without "jet" label per jet metrics become per node ones. All labels are allowed if it's empty.
+	AllowedLabels []string
+	// FlushFile is a file metrics are periodically written to in Prometheus text format, empty disables flush.
+	FlushFile string
+	// FlushInterval is an interval between flushes of metrics to FlushFile.
+	FlushInterval time.Duration
 }
 
 // NewMetrics creates new default configuration for metrics publishing.
@@ -36,5 +46,6 @@ func NewMetrics() Metrics {
 		ListenAddress: "0.0.0.0:9090",
 		Namespace:     "insolar",
 		ZpagesEnabled: true,
+		FlushInterval: time.Minute,
 	}
 }
@@ -0,0 +1,60 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package configuration
+
+import (
+	"time"
+)
+
+// Notifier holds configuration of webhook notifications sent to node operators about critical events.
+type Notifier struct {
+	// URLs receive POST requests with signed JSON notifications, empty list disables notifications.
+	URLs []string
+	// Timeout limits one delivery attempt.
+	Timeout time.Duration
+	// Retries is a number of delivery attempts after failed one, undelivered notifications are logged as dead letters.
+	Retries int
+	// RetryDelay is a delay before first retry, it doubles with every next retry.
+	RetryDelay time.Duration
+	// QueueSize limits number of notifications waiting for delivery.
+	QueueSize int
+	// CheckInterval is an interval of storage and certificate checks.
+	CheckInterval time.Duration
+	// ConsensusFailures is a number of failed consensus rounds within ConsensusWindow pulses which triggers notification.
+	ConsensusFailures int
+	// ConsensusWindow is a number of last pulses in which failed consensus rounds are counted.
+	ConsensusWindow int
+	// MinStorageHeadroom is a free space of storage in percents below which notification is sent.
+	MinStorageHeadroom uint8
+	// CertificateExpiration is a period before certificate expiration when notification is sent.
+	CertificateExpiration time.Duration
+}
+
+// NewNotifier creates new default configuration of operator notifications.
+func NewNotifier() Notifier {
+	return Notifier{
+		URLs:                  nil,
+		Timeout:               5 * time.Second,
+		Retries:               3,
+		RetryDelay:            time.Second,
+		QueueSize:             100,
+		CheckInterval:         time.Minute,
+		ConsensusFailures:     1,
+		ConsensusWindow:       10,
+		MinStorageHeadroom:    10,
+		CertificateExpiration: 7 * 24 * time.Hour,
+	}
+}
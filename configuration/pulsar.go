@@ -41,6 +41,14 @@ type Pulsar struct {
 	Neighbours []PulsarNodeAddress
 
 	NumberDelta uint32
+	// MinNumberDelta and MaxNumberDelta bound delta between pulse numbers adapted to duration of consensus rounds,
+	// delta is fixed to NumberDelta unless both are set
+	MinNumberDelta uint32
+	MaxNumberDelta uint32
+
+	// TestSchedule switches pulsar to test mode if set. Pulses are emitted on schedule without consensus with
+	// neighbours, see testpulsar.ParseSchedule for format. Never set it in production network.
+	TestSchedule string
 
 	DistributionTransport Transport
 	PulseDistributor      PulseDistributor
@@ -80,6 +88,7 @@ func NewPulsar() Pulsar {
 			Protocol:  "TCP",
 			Address:   "0.0.0.0:18091",
 			BehindNAT: false,
+			Bandwidth: map[string]int64{},
 		},
 		PulseDistributor: PulseDistributor{
 			BootstrapHosts:            []string{"localhost:53837"},
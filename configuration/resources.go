@@ -0,0 +1,33 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package configuration
+
+// Resources configures sizing of the process to CPUs available to it. Containers often limit CPU by quota while
+// Go runtime sees all CPUs of host, so goroutines are scheduled on more threads than quota allows and throttled.
+type Resources struct {
+	// MaxProcs is a value of GOMAXPROCS, zero means number of CPUs available to the process: CPU quota of
+	// container rounded up or number of CPUs of host if there's no quota. Worker pools left zero in configuration
+	// are sized from this number.
+	MaxProcs int
+}
+
+// NewResources creates new default configuration of resources, GOMAXPROCS is detected.
+func NewResources() Resources {
+	return Resources{
+		MaxProcs: 0,
+	}
+}
@@ -0,0 +1,38 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package configuration
+
+import (
+	"time"
+)
+
+// Scheduler holds configuration for scheduler of periodic node tasks.
+type Scheduler struct {
+	// Tick is an interval of checking which tasks are due, zero tick disables scheduling.
+	// Tasks still can be triggered via API.
+	Tick time.Duration
+	// Disabled is a list of names of tasks which are not run by schedule.
+	Disabled []string
+}
+
+// NewScheduler creates new default configuration for scheduler.
+func NewScheduler() Scheduler {
+	return Scheduler{
+		Tick:     time.Second,
+		Disabled: []string{},
+	}
+}
@@ -0,0 +1,296 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package configuration
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Secret reference prefixes. Configuration value which starts with one of them
+// is treated as a reference and replaced with the referenced secret on startup.
+const (
+	// SecretFilePrefix references a file, e.g. "file:///run/secrets/keys.json".
+	SecretFilePrefix = "file://"
+	// SecretEnvPrefix references an environment variable, e.g. "env://INSOLAR_KEYS".
+	SecretEnvPrefix = "env://"
+	// SecretVaultPrefix references a key of a Vault-compatible KV secret, e.g. "vault://secret/insolar#keys".
+	SecretVaultPrefix = "vault://"
+)
+
+// Secrets holds configuration of secret references resolution.
+type Secrets struct {
+	// VaultAddress is an address of Vault-compatible HTTP endpoint, e.g. "http://127.0.0.1:8200".
+	VaultAddress string
+	// VaultTokenEnv is a name of environment variable holding Vault access token.
+	// Token itself never lives in configuration.
+	VaultTokenEnv string
+	// Timeout for requests to Vault.
+	Timeout time.Duration
+}
+
+// NewSecrets creates new default configuration of secret references resolution.
+func NewSecrets() Secrets {
+	return Secrets{
+		VaultAddress:  "",
+		VaultTokenEnv: "VAULT_TOKEN",
+		Timeout:       5 * time.Second,
+	}
+}
+
+// IsSecretRef checks if value is a reference to secret.
+func IsSecretRef(value string) bool {
+	return strings.HasPrefix(value, SecretFilePrefix) ||
+		strings.HasPrefix(value, SecretEnvPrefix) ||
+		strings.HasPrefix(value, SecretVaultPrefix)
+}
+
+// SecretResolver resolves secret references to secret values.
+type SecretResolver struct {
+	cfg    Secrets
+	client *http.Client
+	// resolved are paths of configuration values replaced by ResolveAll
+	resolved []string
+}
+
+// NewSecretResolver creates new SecretResolver.
+func NewSecretResolver(cfg Secrets) *SecretResolver {
+	return &SecretResolver{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+// Resolve returns secret referenced by value. Values which are not references are returned as is.
+func (r *SecretResolver) Resolve(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, SecretFilePrefix):
+		return r.resolveFile(strings.TrimPrefix(value, SecretFilePrefix))
+	case strings.HasPrefix(value, SecretEnvPrefix):
+		return r.resolveEnv(strings.TrimPrefix(value, SecretEnvPrefix))
+	case strings.HasPrefix(value, SecretVaultPrefix):
+		return r.resolveVault(strings.TrimPrefix(value, SecretVaultPrefix))
+	}
+	return value, nil
+}
+
+func (r *SecretResolver) resolveFile(path string) (string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "[ resolveFile ] can't read secret file %s", path)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+func (r *SecretResolver) resolveEnv(name string) (string, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", errors.Errorf("[ resolveEnv ] environment variable %s is not set", name)
+	}
+	return value, nil
+}
+
+// resolveVault reads secret from Vault KV engine. Reference format is "<path>#<key>",
+// both KV v1 ({"data": {...}}) and KV v2 ({"data": {"data": {...}}}) responses are supported.
+func (r *SecretResolver) resolveVault(ref string) (string, error) {
+	if r.cfg.VaultAddress == "" {
+		return "", errors.New("[ resolveVault ] VaultAddress is not configured")
+	}
+	parts := strings.SplitN(ref, "#", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", errors.Errorf("[ resolveVault ] bad reference %s, expected <path>#<key>", ref)
+	}
+	path, key := parts[0], parts[1]
+
+	req, err := http.NewRequest(
+		http.MethodGet,
+		strings.TrimRight(r.cfg.VaultAddress, "/")+"/v1/"+strings.TrimLeft(path, "/"),
+		nil,
+	)
+	if err != nil {
+		return "", errors.Wrap(err, "[ resolveVault ] can't create request")
+	}
+	if token := os.Getenv(r.cfg.VaultTokenEnv); token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return "", errors.Wrapf(err, "[ resolveVault ] can't read secret %s", path)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("[ resolveVault ] can't read secret %s: %s", path, resp.Status)
+	}
+
+	var body struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", errors.Wrap(err, "[ resolveVault ] can't decode response")
+	}
+
+	data := body.Data
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		data = nested
+	}
+	value, ok := data[key]
+	if !ok {
+		return "", errors.Errorf("[ resolveVault ] secret %s has no key %s", path, key)
+	}
+	return fmt.Sprint(value), nil
+}
+
+// ResolveAll replaces all secret references in string fields of cfg (a pointer to struct) with secret values.
+// Paths of replaced values are recorded, see Resolved.
+func (r *SecretResolver) ResolveAll(cfg interface{}) error {
+	return r.resolveValue(reflect.ValueOf(cfg), "", "")
+}
+
+// Resolved returns paths of configuration values replaced with secrets by ResolveAll. Path is a dot separated
+// list of keys of the value in configuration dump, e.g. "apirunner.admintoken", and "[i]" for slice elements.
+func (r *SecretResolver) Resolved() []string {
+	return r.resolved
+}
+
+// resolveValue resolves secret references in v, path is used in errors and key is recorded for redaction.
+func (r *SecretResolver) resolveValue(v reflect.Value, path, key string) error {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return r.resolveValue(v.Elem(), path, key)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			err := r.resolveValue(v.Field(i), path+"."+field.Name, dumpKey(key, strings.ToLower(field.Name)))
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			err := r.resolveValue(v.Index(i), fmt.Sprintf("%s[%d]", path, i), fmt.Sprintf("%s[%d]", key, i))
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if v.Type().Elem().Kind() != reflect.String {
+			return nil
+		}
+		for _, k := range v.MapKeys() {
+			value := v.MapIndex(k).String()
+			resolved, err := r.resolveString(value, fmt.Sprintf("%s[%v]", path, k))
+			if err != nil {
+				return err
+			}
+			if resolved != value {
+				r.resolved = append(r.resolved, dumpKey(key, fmt.Sprint(k)))
+			}
+			v.SetMapIndex(k, reflect.ValueOf(resolved).Convert(v.Type().Elem()))
+		}
+	case reflect.String:
+		if !v.CanSet() {
+			return nil
+		}
+		value := v.String()
+		resolved, err := r.resolveString(value, path)
+		if err != nil {
+			return err
+		}
+		if resolved != value {
+			r.resolved = append(r.resolved, key)
+		}
+		v.SetString(resolved)
+	}
+	return nil
+}
+
+func (r *SecretResolver) resolveString(value string, path string) (string, error) {
+	if !IsSecretRef(value) {
+		return value, nil
+	}
+	resolved, err := r.Resolve(value)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to resolve secret for %s", strings.TrimPrefix(path, "."))
+	}
+	return resolved, nil
+}
+
+func dumpKey(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
+
+// RedactedValue replaces values of secret fields in configuration dumps.
+const RedactedValue = "<redacted>"
+
+// ToRedactedString converts configuration struct to yaml string with values of secrets paths (see
+// SecretResolver.Resolved) replaced with RedactedValue. Configuration is marshaled first, so in isn't modified
+// and doesn't share memory with the result.
+func ToRedactedString(in interface{}, secrets []string) string {
+	d, err := yaml.Marshal(in)
+	if err != nil {
+		return fmt.Sprintf("error: %v", err)
+	}
+	var tree yaml.MapSlice
+	if err := yaml.Unmarshal(d, &tree); err != nil {
+		return fmt.Sprintf("error: %v", err)
+	}
+	redacted := make(map[string]bool, len(secrets))
+	for _, path := range secrets {
+		redacted[path] = true
+	}
+	d, err = yaml.Marshal(redactTree(tree, "", redacted))
+	if err != nil {
+		return fmt.Sprintf("error: %v", err)
+	}
+	return string(d)
+}
+
+func redactTree(value interface{}, path string, redacted map[string]bool) interface{} {
+	if redacted[path] {
+		return RedactedValue
+	}
+	switch v := value.(type) {
+	case yaml.MapSlice:
+		for i, item := range v {
+			v[i].Value = redactTree(item.Value, dumpKey(path, fmt.Sprint(item.Key)), redacted)
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = redactTree(v[i], fmt.Sprintf("%s[%d]", path, i), redacted)
+		}
+	}
+	return value
+}
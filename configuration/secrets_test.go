@@ -0,0 +1,175 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package configuration
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretResolver_Resolve_Plain(t *testing.T) {
+	r := NewSecretResolver(NewSecrets())
+	res, err := r.Resolve("plain value")
+	require.NoError(t, err)
+	require.Equal(t, "plain value", res)
+}
+
+func TestSecretResolver_Resolve_File(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "secret")
+	err = ioutil.WriteFile(path, []byte("top secret\n"), 0600)
+	require.NoError(t, err)
+
+	r := NewSecretResolver(NewSecrets())
+	res, err := r.Resolve(SecretFilePrefix + path)
+	require.NoError(t, err)
+	require.Equal(t, "top secret", res)
+
+	_, err = r.Resolve(SecretFilePrefix + filepath.Join(dir, "absent"))
+	require.Error(t, err)
+}
+
+func TestSecretResolver_Resolve_Env(t *testing.T) {
+	os.Setenv("INSOLAR_TEST_SECRET", "from env")
+	defer os.Unsetenv("INSOLAR_TEST_SECRET")
+
+	r := NewSecretResolver(NewSecrets())
+	res, err := r.Resolve(SecretEnvPrefix + "INSOLAR_TEST_SECRET")
+	require.NoError(t, err)
+	require.Equal(t, "from env", res)
+
+	_, err = r.Resolve(SecretEnvPrefix + "INSOLAR_TEST_SECRET_ABSENT")
+	require.Error(t, err)
+}
+
+func TestSecretResolver_Resolve_Vault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/v1":
+			w.Write([]byte(`{"data": {"password": "v1 secret"}}`))
+		case "/v1/secret/data/v2":
+			w.Write([]byte(`{"data": {"data": {"password": "v2 secret"}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	os.Setenv("INSOLAR_TEST_VAULT_TOKEN", "token")
+	defer os.Unsetenv("INSOLAR_TEST_VAULT_TOKEN")
+
+	cfg := NewSecrets()
+	cfg.VaultAddress = server.URL
+	cfg.VaultTokenEnv = "INSOLAR_TEST_VAULT_TOKEN"
+	r := NewSecretResolver(cfg)
+
+	res, err := r.Resolve(SecretVaultPrefix + "secret/v1#password")
+	require.NoError(t, err)
+	require.Equal(t, "v1 secret", res)
+
+	res, err = r.Resolve(SecretVaultPrefix + "secret/data/v2#password")
+	require.NoError(t, err)
+	require.Equal(t, "v2 secret", res)
+
+	_, err = r.Resolve(SecretVaultPrefix + "secret/v1#absent")
+	require.Error(t, err)
+
+	_, err = r.Resolve(SecretVaultPrefix + "secret/absent#password")
+	require.Error(t, err)
+
+	_, err = r.Resolve(SecretVaultPrefix + "secret/v1")
+	require.Error(t, err)
+}
+
+func TestHolder_ResolveSecrets(t *testing.T) {
+	os.Setenv("INSOLAR_TEST_SECRET", "127.0.0.3:5555")
+	defer os.Unsetenv("INSOLAR_TEST_SECRET")
+
+	holder := NewHolder()
+	holder.Configuration.Host.Transport.Address = SecretEnvPrefix + "INSOLAR_TEST_SECRET"
+	holder.Configuration.LogicRunner.GoPlugin.RunnerListen = SecretEnvPrefix + "INSOLAR_TEST_SECRET"
+
+	err := holder.ResolveSecrets()
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.3:5555", holder.Configuration.Host.Transport.Address)
+	require.Equal(t, "127.0.0.3:5555", holder.Configuration.LogicRunner.GoPlugin.RunnerListen)
+
+	holder.Configuration.KeysPath = SecretEnvPrefix + "INSOLAR_TEST_SECRET_ABSENT"
+	err = holder.ResolveSecrets()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "KeysPath")
+}
+
+func TestToRedactedString(t *testing.T) {
+	os.Setenv("INSOLAR_TEST_SECRET", "admin secret")
+	defer os.Unsetenv("INSOLAR_TEST_SECRET")
+
+	holder := NewHolder()
+	cfg := &holder.Configuration
+	cfg.APIRunner.AdminToken = SecretEnvPrefix + "INSOLAR_TEST_SECRET"
+	cfg.Host.Transport.Address = SecretEnvPrefix + "INSOLAR_TEST_SECRET"
+	cfg.Ledger.PulseManager.ChaosScenario = "secret-free value"
+	cfg.Ledger.HeavyReplicas = []string{"plain", SecretEnvPrefix + "INSOLAR_TEST_SECRET"}
+	require.NoError(t, holder.ResolveSecrets())
+	require.Equal(t, "admin secret", cfg.APIRunner.AdminToken)
+
+	dump := holder.RedactedString()
+	require.NotContains(t, dump, "admin secret")
+	require.Contains(t, dump, "admintoken: "+RedactedValue)
+	require.Contains(t, dump, "address: "+RedactedValue)
+	require.Contains(t, dump, "- "+RedactedValue)
+	require.Contains(t, dump, "- plain")
+	require.Contains(t, dump, "chaosscenario: secret-free value")
+	require.Equal(t, "admin secret", cfg.APIRunner.AdminToken)
+
+	// values which are not resolved from references are not redacted, whatever their names are
+	cfg = &NewHolder().Configuration
+	cfg.Ledger.Exporter.Analytics.Salt = "inline salt"
+	require.Contains(t, ToRedactedString(cfg, nil), "salt: inline salt")
+}
+
+func TestSecretResolver_Resolved(t *testing.T) {
+	os.Setenv("INSOLAR_TEST_SECRET", "secret")
+	defer os.Unsetenv("INSOLAR_TEST_SECRET")
+
+	cfg := NewConfiguration()
+	cfg.APIRunner.AdminToken = SecretEnvPrefix + "INSOLAR_TEST_SECRET"
+	cfg.Ledger.Globule.Members = map[string]uint32{}
+	cfg.APIRunner.ShedServices = []string{"plain", SecretEnvPrefix + "INSOLAR_TEST_SECRET"}
+	cfg.APIRunner.MethodRoles = map[string]string{"Transfer": SecretEnvPrefix + "INSOLAR_TEST_SECRET"}
+
+	r := NewSecretResolver(cfg.Secrets)
+	require.NoError(t, r.ResolveAll(&cfg))
+	require.ElementsMatch(t, []string{
+		"apirunner.admintoken",
+		"apirunner.shedservices[1]",
+		"apirunner.methodroles.Transfer",
+	}, r.Resolved())
+}
@@ -0,0 +1,46 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package configuration
+
+import (
+	"time"
+)
+
+// SelfCheck holds thresholds of node self-check reported by status API. Zero threshold disables a check.
+type SelfCheck struct {
+	// MaxPulseAge is a max time since the current pulse was generated.
+	MaxPulseAge time.Duration
+	// MinConsensusStreak is a min number of consecutive consensus rounds passed by node.
+	MinConsensusStreak int
+	// MinStorageHeadroom is a min free space of node storage in percents.
+	MinStorageHeadroom uint8
+	// MaxReplicationLag is a max number of pulses waiting for replication to heavy material node.
+	MaxReplicationLag int
+	// MaxSendErrorRate is a max portion of parcels message bus failed to send during previous pulse.
+	MaxSendErrorRate float64
+}
+
+// NewSelfCheck creates new default thresholds of node self-check.
+func NewSelfCheck() SelfCheck {
+	return SelfCheck{
+		MaxPulseAge:        30 * time.Second,
+		MinConsensusStreak: 1,
+		MinStorageHeadroom: 10,
+		MaxReplicationLag:  10,
+		MaxSendErrorRate:   0.05,
+	}
+}
@@ -0,0 +1,42 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package configuration
+
+import (
+	"time"
+)
+
+// SelfTest holds configuration of node self-test executed on start before components are created. Self-test
+// checks keys and certificate, storage schema, clock, ports and connectivity to discovery nodes.
+type SelfTest struct {
+	// Enabled turns self-test on.
+	Enabled bool
+	// FailFast stops node with consolidated report when any check fails, otherwise failures are only logged.
+	FailFast bool
+	// DiscoveryTimeout limits time of waiting for connection to any discovery node, nodes of network which
+	// is being bootstrapped may start a bit later.
+	DiscoveryTimeout time.Duration
+}
+
+// NewSelfTest creates new default configuration of node self-test.
+func NewSelfTest() SelfTest {
+	return SelfTest{
+		Enabled:          true,
+		FailFast:         true,
+		DiscoveryTimeout: 30 * time.Second,
+	}
+}
@@ -19,11 +19,21 @@ package configuration
 // ServiceNetwork is configuration for ServiceNetwork.
 type ServiceNetwork struct {
 	Skip int // magic number that indicates what delta after last ignored pulse we should wait
+	// ReportLoad enables announcing load of the node (CPU, execution queue, storage headroom) to the network every pulse
+	ReportLoad bool
+	// Consensus is a configuration of sending packets in consensus phases.
+	Consensus Consensus
+	// RevokedNodes are references of nodes whose certificates are revoked by operator of discovery node at start.
+	// Discovery nodes distribute their revocation lists every pulse, node revoked by majority of them is expelled.
+	RevokedNodes []string
+	// RevocationsFile is a file discovery node keeps its revocation list in between restarts, empty disables it.
+	RevocationsFile string
 }
 
 // NewServiceNetwork creates a new ServiceNetwork configuration.
 func NewServiceNetwork() ServiceNetwork {
 	return ServiceNetwork{
-		Skip: 10,
+		Skip:      10,
+		Consensus: NewConsensus(),
 	}
 }
@@ -0,0 +1,30 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package configuration
+
+// Timeline holds configuration for journal of request processing events.
+type Timeline struct {
+	// Capacity is a maximum number of events kept by node, oldest events are dropped first. Zero disables journal.
+	Capacity int
+}
+
+// NewTimeline creates new default configuration for timeline journal.
+func NewTimeline() Timeline {
+	return Timeline{
+		Capacity: 10000,
+	}
+}
@@ -0,0 +1,50 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package configuration
+
+import (
+	"time"
+)
+
+// Watchdog holds configuration for resource leak watchdog.
+// Zero ceiling disables the check of a resource, the value is still exposed as metric.
+type Watchdog struct {
+	// Interval between samples, zero interval disables watchdog.
+	Interval time.Duration
+	// MaxGoroutines is a ceiling for number of goroutines.
+	MaxGoroutines int
+	// MaxOpenFiles is a ceiling for number of open file descriptors.
+	MaxOpenFiles int
+	// MaxConnections is a ceiling for number of connections in network transport pool.
+	MaxConnections int
+	// MaxPendingResults is a ceiling for number of requests waiting for results in ContractRequester.
+	MaxPendingResults int
+	// MaxLogicRunnerObjects is a ceiling for number of objects in LogicRunner state.
+	MaxLogicRunnerObjects int
+}
+
+// NewWatchdog creates new default configuration for resource leak watchdog.
+func NewWatchdog() Watchdog {
+	return Watchdog{
+		Interval:              10 * time.Second,
+		MaxGoroutines:         10000,
+		MaxOpenFiles:          4096,
+		MaxConnections:        1000,
+		MaxPendingResults:     10000,
+		MaxLogicRunnerObjects: 100000,
+	}
+}
@@ -32,6 +32,8 @@ var (
 var (
 	// PacketsSent urrent consensus transport packets sent counter.
 	PacketsSent = stats.Int64("consensus/packets/sent", "Current consensus transport packets sent counter", stats.UnitDimensionless)
+	// PacketsRetried consensus packets resent after failed send counter.
+	PacketsRetried = stats.Int64("consensus/packets/retried", "Consensus packets resent after failed send counter", stats.UnitDimensionless)
 	// PacketsRecv current consensus transport packets recv counter.
 	PacketsRecv = stats.Int64("consensus/packets/recv", "Current consensus transport packets recv counter", stats.UnitDimensionless)
 	// DeclinedClaims consensus claims declined counter.
@@ -44,17 +46,29 @@ var (
 	FailedCheckProof = stats.Int64("consensus/proof/failed", "Consensus validate proof fails", stats.UnitDimensionless)
 	// Phase2TimedOuts timed out nodes on phase 2.
 	Phase2TimedOuts = stats.Int64("consensus/phase2/timedout", "Timed out nodes on phase 2", stats.UnitDimensionless)
+	// EvictedNodes nodes evicted after missing consecutive consensus rounds.
+	EvictedNodes = stats.Int64("consensus/evicted", "Nodes evicted after missing consecutive rounds", stats.UnitDimensionless)
 	// Phase21Exec phase 21 execution counter.
 	Phase21Exec = stats.Int64("consensus/phase21/exec", "Phase 21 execution counter", stats.UnitDimensionless)
 	// Phase3Exec phase 3 execution counter
 	Phase3Exec = stats.Int64("consensus/phase3/exec", "Phase 3 execution counter", stats.UnitDimensionless)
+	// PhaseSendDuration duration of sending phase packets to participants.
+	PhaseSendDuration = stats.Float64("consensus/phase/send/duration", "Duration of sending phase packets to participants", stats.UnitMilliseconds)
+	// PhaseWaitDuration duration from start of exchange until the last packet from participants is received.
+	PhaseWaitDuration = stats.Float64("consensus/phase/wait/duration", "Duration of waiting phase packets from participants", stats.UnitMilliseconds)
+	// PhaseProcessDuration duration of phase without packets exchange.
+	PhaseProcessDuration = stats.Float64("consensus/phase/process/duration", "Duration of phase processing", stats.UnitMilliseconds)
+	// PeerRTT medians of round trip times to consensus participants measured during pulse.
+	PeerRTT = stats.Float64("consensus/peer/rtt", "Medians of round trip times to consensus participants", stats.UnitMilliseconds)
 	// ActiveNodes active nodes count after consensus.
 	ActiveNodes = stats.Int64("consensus/activenodes/count", "Active nodes count after consensus", stats.UnitDimensionless)
 )
 
+var phaseDurationDistribution = view.Distribution(1, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000)
+
 func init() {
 	commontags := []tag.Key{TagPhase}
-	err := view.Register(
+	err := insmetrics.Register(
 		&view.View{
 			Name:        PacketsSent.Name(),
 			Description: PacketsSent.Description(),
@@ -62,6 +76,13 @@ func init() {
 			Aggregation: view.Count(),
 			TagKeys:     commontags,
 		},
+		&view.View{
+			Name:        PacketsRetried.Name(),
+			Description: PacketsRetried.Description(),
+			Measure:     PacketsRetried,
+			Aggregation: view.Count(),
+			TagKeys:     commontags,
+		},
 		&view.View{
 			Name:        PacketsRecv.Name(),
 			Description: PacketsRecv.Description(),
@@ -99,6 +120,12 @@ func init() {
 			Measure:     Phase2TimedOuts,
 			Aggregation: view.Count(),
 		},
+		&view.View{
+			Name:        EvictedNodes.Name(),
+			Description: EvictedNodes.Description(),
+			Measure:     EvictedNodes,
+			Aggregation: view.Sum(),
+		},
 		&view.View{
 			Name:        Phase3Exec.Name(),
 			Description: Phase3Exec.Description(),
@@ -111,6 +138,33 @@ func init() {
 			Measure:     Phase21Exec,
 			Aggregation: view.Count(),
 		},
+		&view.View{
+			Name:        PhaseSendDuration.Name(),
+			Description: PhaseSendDuration.Description(),
+			Measure:     PhaseSendDuration,
+			Aggregation: phaseDurationDistribution,
+			TagKeys:     commontags,
+		},
+		&view.View{
+			Name:        PhaseWaitDuration.Name(),
+			Description: PhaseWaitDuration.Description(),
+			Measure:     PhaseWaitDuration,
+			Aggregation: phaseDurationDistribution,
+			TagKeys:     commontags,
+		},
+		&view.View{
+			Name:        PhaseProcessDuration.Name(),
+			Description: PhaseProcessDuration.Description(),
+			Measure:     PhaseProcessDuration,
+			Aggregation: phaseDurationDistribution,
+			TagKeys:     commontags,
+		},
+		&view.View{
+			Name:        PeerRTT.Name(),
+			Description: PeerRTT.Description(),
+			Measure:     PeerRTT,
+			Aggregation: phaseDurationDistribution,
+		},
 		&view.View{
 			Name:        ActiveNodes.Name(),
 			Description: ActiveNodes.Description(),
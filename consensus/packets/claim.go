@@ -18,7 +18,9 @@
 package packets
 
 import (
+	"bytes"
 	"crypto"
+	"time"
 
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/platformpolicy"
@@ -36,6 +38,12 @@ const (
 	TypeNodeBroadcast
 	TypeNodeLeaveClaim
 	TypeChangeNetworkClaim
+	TypeNodeLoadClaim
+	TypeMaintenanceClaim
+	TypeNodeStorageClaim
+	TypeNodeExpelClaim
+	TypeNodeCordonClaim
+	TypeNodeTimeClaim
 )
 
 const claimHeaderSize = 2
@@ -197,12 +205,30 @@ func (nac *NodeAnnounceClaim) SetCloudHash(cloudHash []byte) {
 	copy(nac.CloudHash[:], cloudHash[:HashLength])
 }
 
+// LeaveNoteLength is a max length of operator note in NodeLeaveClaim.
+const LeaveNoteLength = 64
+
 // NodeLeaveClaim can be the only be issued by the node itself and must be the only claim record.
 // Should be executed with the next pulse. Type 1, len == 0.
 type NodeLeaveClaim struct {
 	// additional field that is not serialized and is set from transport layer on packet receive
 	NodeID core.RecordRef
 	ETA    core.PulseNumber
+	Reason core.LeaveReason
+	// Note is an operator note padded with zero bytes
+	Note [LeaveNoteLength]byte
+}
+
+// NewNodeLeaveClaim creates NodeLeaveClaim with reason and operator note, note longer than LeaveNoteLength is truncated.
+func NewNodeLeaveClaim(reason core.LeaveReason, note string) *NodeLeaveClaim {
+	claim := &NodeLeaveClaim{Reason: reason}
+	copy(claim.Note[:], note)
+	return claim
+}
+
+// GetNote returns operator note.
+func (nlc *NodeLeaveClaim) GetNote() string {
+	return string(bytes.TrimRight(nlc.Note[:], "\x00"))
 }
 
 func (nlc *NodeLeaveClaim) Clone() ReferendumClaim {
@@ -218,6 +244,221 @@ func (nlc *NodeLeaveClaim) Type() ClaimType {
 	return TypeNodeLeaveClaim
 }
 
+// NodeLoadClaim is an optional self-reported load of the node, is issued by the node itself every pulse.
+// Type 8, len == 4.
+type NodeLoadClaim struct {
+	// additional field that is not serialized and is set from transport layer on packet receive
+	NodeID          core.RecordRef
+	CPU             uint8
+	StorageHeadroom uint8
+	QueueDepth      uint16
+}
+
+// NewNodeLoadClaim creates NodeLoadClaim from load of the node.
+func NewNodeLoadClaim(load core.NodeLoad) *NodeLoadClaim {
+	return &NodeLoadClaim{
+		CPU:             load.CPU,
+		StorageHeadroom: load.StorageHeadroom,
+		QueueDepth:      load.QueueDepth,
+	}
+}
+
+// GetLoad returns load reported by the node.
+func (nlc *NodeLoadClaim) GetLoad() core.NodeLoad {
+	return core.NodeLoad{
+		NodeID:          nlc.NodeID,
+		CPU:             nlc.CPU,
+		StorageHeadroom: nlc.StorageHeadroom,
+		QueueDepth:      nlc.QueueDepth,
+	}
+}
+
+func (nlc *NodeLoadClaim) Clone() ReferendumClaim {
+	result := *nlc
+	return &result
+}
+
+func (nlc *NodeLoadClaim) AddSupplementaryInfo(nodeID core.RecordRef) {
+	nlc.NodeID = nodeID
+}
+
+func (nlc *NodeLoadClaim) Type() ClaimType {
+	return TypeNodeLoadClaim
+}
+
+// NodeStorageClaim announces that storage of the node is exhausted, so the node doesn't take executor and validator
+// roles in the next pulse. It's issued by the node itself every pulse until space is freed. Type 10, len == 1.
+type NodeStorageClaim struct {
+	// additional field that is not serialized and is set from transport layer on packet receive
+	NodeID core.RecordRef
+	// Headroom is a free space of node storage in percents
+	Headroom uint8
+}
+
+// NewNodeStorageClaim creates NodeStorageClaim with free space of node storage.
+func NewNodeStorageClaim(headroom uint8) *NodeStorageClaim {
+	return &NodeStorageClaim{Headroom: headroom}
+}
+
+func (nsc *NodeStorageClaim) Clone() ReferendumClaim {
+	result := *nsc
+	return &result
+}
+
+func (nsc *NodeStorageClaim) AddSupplementaryInfo(nodeID core.RecordRef) {
+	nsc.NodeID = nodeID
+}
+
+func (nsc *NodeStorageClaim) Type() ClaimType {
+	return TypeNodeStorageClaim
+}
+
+// NodeExpelClaim is a vote of discovery node to expel node whose certificate it revoked. It's issued by discovery
+// node every pulse while node is in its revocation list, node is expelled when majority of discovery nodes vote
+// for it in the same pulse. Type 11, len == 64.
+type NodeExpelClaim struct {
+	// additional field that is not serialized and is set from transport layer on packet receive
+	NodeID core.RecordRef
+	Target core.RecordRef
+}
+
+// NewNodeExpelClaim creates NodeExpelClaim of node with revoked certificate.
+func NewNodeExpelClaim(target core.RecordRef) *NodeExpelClaim {
+	return &NodeExpelClaim{Target: target}
+}
+
+func (nec *NodeExpelClaim) Clone() ReferendumClaim {
+	result := *nec
+	return &result
+}
+
+func (nec *NodeExpelClaim) AddSupplementaryInfo(nodeID core.RecordRef) {
+	nec.NodeID = nodeID
+}
+
+func (nec *NodeExpelClaim) Type() ClaimType {
+	return TypeNodeExpelClaim
+}
+
+// NodeCordonClaim announces that node is cordoned by operator, so it stays in the network, but doesn't take
+// executor and validator roles in the next pulse. It's issued by the node itself every pulse while it's cordoned.
+// Type 12, len == 4.
+type NodeCordonClaim struct {
+	// additional field that is not serialized and is set from transport layer on packet receive
+	NodeID core.RecordRef
+	// Since is a pulse when node was cordoned
+	Since core.PulseNumber
+}
+
+// NewNodeCordonClaim creates NodeCordonClaim of node cordoned since pulse.
+func NewNodeCordonClaim(since core.PulseNumber) *NodeCordonClaim {
+	return &NodeCordonClaim{Since: since}
+}
+
+func (ncc *NodeCordonClaim) Clone() ReferendumClaim {
+	result := *ncc
+	return &result
+}
+
+func (ncc *NodeCordonClaim) AddSupplementaryInfo(nodeID core.RecordRef) {
+	ncc.NodeID = nodeID
+}
+
+func (ncc *NodeCordonClaim) Type() ClaimType {
+	return TypeNodeCordonClaim
+}
+
+// NodeTimeClaim announces local time of the node when it sends phase1 packet, receivers use it to estimate
+// skew of their clocks. It's issued by the node itself every pulse. Type 13, len == 8.
+type NodeTimeClaim struct {
+	// additional field that is not serialized and is set from transport layer on packet receive
+	NodeID core.RecordRef
+	// Time is a local time of the node in nanoseconds since Unix epoch
+	Time int64
+}
+
+// NewNodeTimeClaim creates NodeTimeClaim with local time of the node.
+func NewNodeTimeClaim(t time.Time) *NodeTimeClaim {
+	return &NodeTimeClaim{Time: t.UnixNano()}
+}
+
+// GetTime returns local time of the node announced in the claim.
+func (ntc *NodeTimeClaim) GetTime() time.Time {
+	return time.Unix(0, ntc.Time)
+}
+
+func (ntc *NodeTimeClaim) Clone() ReferendumClaim {
+	result := *ntc
+	return &result
+}
+
+func (ntc *NodeTimeClaim) AddSupplementaryInfo(nodeID core.RecordRef) {
+	ntc.NodeID = nodeID
+}
+
+func (ntc *NodeTimeClaim) Type() ClaimType {
+	return TypeNodeTimeClaim
+}
+
+// MaintenanceNoteLength is a max length of operator note in MaintenanceClaim.
+const MaintenanceNoteLength = 64
+
+// MaintenanceClaim announces network-wide maintenance window, is issued by discovery node and signed with its key.
+// Type 9, len == 268.
+type MaintenanceClaim struct {
+	Issuer   core.RecordRef
+	IssuerPK [PublicKeyLength]byte
+	Start    core.PulseNumber
+	End      core.PulseNumber
+	// Note is an operator note padded with zero bytes
+	Note      [MaintenanceNoteLength]byte
+	Signature [SignatureLength]byte
+}
+
+// NewMaintenanceClaim creates unsigned MaintenanceClaim of the window, note longer than MaintenanceNoteLength is truncated.
+func NewMaintenanceClaim(window core.MaintenanceWindow, issuerPK []byte) *MaintenanceClaim {
+	claim := &MaintenanceClaim{
+		Issuer: window.Issuer,
+		Start:  window.Start,
+		End:    window.End,
+	}
+	copy(claim.IssuerPK[:], issuerPK)
+	copy(claim.Note[:], window.Note)
+	return claim
+}
+
+// GetWindow returns announced maintenance window.
+func (mc *MaintenanceClaim) GetWindow() core.MaintenanceWindow {
+	return core.MaintenanceWindow{
+		Issuer: mc.Issuer,
+		Start:  mc.Start,
+		End:    mc.End,
+		Note:   string(bytes.TrimRight(mc.Note[:], "\x00")),
+	}
+}
+
+func (mc *MaintenanceClaim) Clone() ReferendumClaim {
+	result := *mc
+	return &result
+}
+
+func (mc *MaintenanceClaim) GetNodeID() core.RecordRef {
+	return mc.Issuer
+}
+
+func (mc *MaintenanceClaim) GetPublicKey() (crypto.PublicKey, error) {
+	keyProc := platformpolicy.NewKeyProcessor()
+	return keyProc.ImportPublicKeyBinary(mc.IssuerPK[:])
+}
+
+func (mc *MaintenanceClaim) GetSignature() []byte {
+	return mc.Signature[:]
+}
+
+func (mc *MaintenanceClaim) Type() ClaimType {
+	return TypeMaintenanceClaim
+}
+
 func getClaimSize(claim ReferendumClaim) uint16 {
 	return claimSizeMap[claim.Type()]
 }
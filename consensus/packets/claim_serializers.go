@@ -350,6 +350,14 @@ func (nlc *NodeLeaveClaim) Serialize() ([]byte, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "[ NodeLeaveClaim.Serialize ] failed to write ETA to buffer")
 	}
+	err = binary.Write(&result, defaultByteOrder, nlc.Reason)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NodeLeaveClaim.Serialize ] failed to write Reason to buffer")
+	}
+	err = binary.Write(&result, defaultByteOrder, nlc.Note)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NodeLeaveClaim.Serialize ] failed to write Note to buffer")
+	}
 	return result.Bytes(), nil
 }
 
@@ -359,6 +367,210 @@ func (nlc *NodeLeaveClaim) Deserialize(data io.Reader) error {
 	if err != nil {
 		return errors.Wrap(err, "[ NodeLeaveClaim.Deserialize ] failed to read a ETA")
 	}
+	err = binary.Read(data, defaultByteOrder, &nlc.Reason)
+	if err != nil {
+		return errors.Wrap(err, "[ NodeLeaveClaim.Deserialize ] failed to read a Reason")
+	}
+	err = binary.Read(data, defaultByteOrder, &nlc.Note)
+	if err != nil {
+		return errors.Wrap(err, "[ NodeLeaveClaim.Deserialize ] failed to read a Note")
+	}
+	return nil
+}
+
+// Serialize implements interface method
+func (nlc *NodeLoadClaim) Serialize() ([]byte, error) {
+	if err := nlc.GetLoad().Validate(); err != nil {
+		return nil, errors.Wrap(err, "[ NodeLoadClaim.Serialize ] invalid load")
+	}
+	var result bytes.Buffer
+	err := binary.Write(&result, defaultByteOrder, nlc.CPU)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NodeLoadClaim.Serialize ] failed to write CPU to buffer")
+	}
+	err = binary.Write(&result, defaultByteOrder, nlc.StorageHeadroom)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NodeLoadClaim.Serialize ] failed to write StorageHeadroom to buffer")
+	}
+	err = binary.Write(&result, defaultByteOrder, nlc.QueueDepth)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NodeLoadClaim.Serialize ] failed to write QueueDepth to buffer")
+	}
+	return result.Bytes(), nil
+}
+
+// Deserialize implements interface method
+func (nlc *NodeLoadClaim) Deserialize(data io.Reader) error {
+	err := binary.Read(data, defaultByteOrder, &nlc.CPU)
+	if err != nil {
+		return errors.Wrap(err, "[ NodeLoadClaim.Deserialize ] failed to read a CPU")
+	}
+	err = binary.Read(data, defaultByteOrder, &nlc.StorageHeadroom)
+	if err != nil {
+		return errors.Wrap(err, "[ NodeLoadClaim.Deserialize ] failed to read a StorageHeadroom")
+	}
+	err = binary.Read(data, defaultByteOrder, &nlc.QueueDepth)
+	if err != nil {
+		return errors.Wrap(err, "[ NodeLoadClaim.Deserialize ] failed to read a QueueDepth")
+	}
+	if err := nlc.GetLoad().Validate(); err != nil {
+		return errors.Wrap(err, "[ NodeLoadClaim.Deserialize ] invalid load")
+	}
+	return nil
+}
+
+// Serialize implements interface method
+func (nsc *NodeStorageClaim) Serialize() ([]byte, error) {
+	if nsc.Headroom > 100 {
+		return nil, errors.Errorf("[ NodeStorageClaim.Serialize ] storage headroom %d%% is out of range", nsc.Headroom)
+	}
+	var result bytes.Buffer
+	err := binary.Write(&result, defaultByteOrder, nsc.Headroom)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NodeStorageClaim.Serialize ] failed to write Headroom to buffer")
+	}
+	return result.Bytes(), nil
+}
+
+// Deserialize implements interface method
+func (nsc *NodeStorageClaim) Deserialize(data io.Reader) error {
+	err := binary.Read(data, defaultByteOrder, &nsc.Headroom)
+	if err != nil {
+		return errors.Wrap(err, "[ NodeStorageClaim.Deserialize ] failed to read a Headroom")
+	}
+	if nsc.Headroom > 100 {
+		return errors.Errorf("[ NodeStorageClaim.Deserialize ] storage headroom %d%% is out of range", nsc.Headroom)
+	}
+	return nil
+}
+
+// Serialize implements interface method
+func (nec *NodeExpelClaim) Serialize() ([]byte, error) {
+	var result bytes.Buffer
+	err := binary.Write(&result, defaultByteOrder, nec.Target)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NodeExpelClaim.Serialize ] failed to write Target to buffer")
+	}
+	return result.Bytes(), nil
+}
+
+// Deserialize implements interface method
+func (nec *NodeExpelClaim) Deserialize(data io.Reader) error {
+	err := binary.Read(data, defaultByteOrder, &nec.Target)
+	if err != nil {
+		return errors.Wrap(err, "[ NodeExpelClaim.Deserialize ] failed to read a Target")
+	}
+	return nil
+}
+
+// Serialize implements interface method
+func (ncc *NodeCordonClaim) Serialize() ([]byte, error) {
+	var result bytes.Buffer
+	err := binary.Write(&result, defaultByteOrder, ncc.Since)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NodeCordonClaim.Serialize ] failed to write Since to buffer")
+	}
+	return result.Bytes(), nil
+}
+
+// Deserialize implements interface method
+func (ncc *NodeCordonClaim) Deserialize(data io.Reader) error {
+	err := binary.Read(data, defaultByteOrder, &ncc.Since)
+	if err != nil {
+		return errors.Wrap(err, "[ NodeCordonClaim.Deserialize ] failed to read a Since")
+	}
+	return nil
+}
+
+// Serialize implements interface method
+func (ntc *NodeTimeClaim) Serialize() ([]byte, error) {
+	var result bytes.Buffer
+	err := binary.Write(&result, defaultByteOrder, ntc.Time)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NodeTimeClaim.Serialize ] failed to write Time to buffer")
+	}
+	return result.Bytes(), nil
+}
+
+// Deserialize implements interface method
+func (ntc *NodeTimeClaim) Deserialize(data io.Reader) error {
+	err := binary.Read(data, defaultByteOrder, &ntc.Time)
+	if err != nil {
+		return errors.Wrap(err, "[ NodeTimeClaim.Deserialize ] failed to read a Time")
+	}
+	return nil
+}
+
+// Serialize implements interface method
+func (mc *MaintenanceClaim) Serialize() ([]byte, error) {
+	var result bytes.Buffer
+	rawData, err := mc.SerializeRaw()
+	if err != nil {
+		return nil, errors.Wrap(err, "[ MaintenanceClaim.Serialize ] failed to serialize a claim without signature")
+	}
+	err = binary.Write(&result, defaultByteOrder, rawData)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ MaintenanceClaim.Serialize ] failed to write a claim without signature")
+	}
+	err = binary.Write(&result, defaultByteOrder, mc.Signature)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ MaintenanceClaim.Serialize ] failed to write Signature to buffer")
+	}
+	return result.Bytes(), nil
+}
+
+// SerializeRaw serializes the claim without signature.
+func (mc *MaintenanceClaim) SerializeRaw() ([]byte, error) {
+	var result bytes.Buffer
+	err := binary.Write(&result, defaultByteOrder, mc.Issuer)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ MaintenanceClaim.SerializeRaw ] failed to write Issuer to buffer")
+	}
+	err = binary.Write(&result, defaultByteOrder, mc.IssuerPK)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ MaintenanceClaim.SerializeRaw ] failed to write IssuerPK to buffer")
+	}
+	err = binary.Write(&result, defaultByteOrder, mc.Start)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ MaintenanceClaim.SerializeRaw ] failed to write Start to buffer")
+	}
+	err = binary.Write(&result, defaultByteOrder, mc.End)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ MaintenanceClaim.SerializeRaw ] failed to write End to buffer")
+	}
+	err = binary.Write(&result, defaultByteOrder, mc.Note)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ MaintenanceClaim.SerializeRaw ] failed to write Note to buffer")
+	}
+	return result.Bytes(), nil
+}
+
+// Deserialize implements interface method
+func (mc *MaintenanceClaim) Deserialize(data io.Reader) error {
+	err := binary.Read(data, defaultByteOrder, &mc.Issuer)
+	if err != nil {
+		return errors.Wrap(err, "[ MaintenanceClaim.Deserialize ] failed to read an Issuer")
+	}
+	err = binary.Read(data, defaultByteOrder, &mc.IssuerPK)
+	if err != nil {
+		return errors.Wrap(err, "[ MaintenanceClaim.Deserialize ] failed to read an IssuerPK")
+	}
+	err = binary.Read(data, defaultByteOrder, &mc.Start)
+	if err != nil {
+		return errors.Wrap(err, "[ MaintenanceClaim.Deserialize ] failed to read a Start")
+	}
+	err = binary.Read(data, defaultByteOrder, &mc.End)
+	if err != nil {
+		return errors.Wrap(err, "[ MaintenanceClaim.Deserialize ] failed to read an End")
+	}
+	err = binary.Read(data, defaultByteOrder, &mc.Note)
+	if err != nil {
+		return errors.Wrap(err, "[ MaintenanceClaim.Deserialize ] failed to read a Note")
+	}
+	err = binary.Read(data, defaultByteOrder, &mc.Signature)
+	if err != nil {
+		return errors.Wrap(err, "[ MaintenanceClaim.Deserialize ] failed to read a Signature")
+	}
 	return nil
 }
 
@@ -419,6 +631,18 @@ func parseReferendumClaim(data []byte) ([]ReferendumClaim, error) {
 			refClaim = &NodeLeaveClaim{}
 		case TypeNodeAnnounceClaim:
 			refClaim = &NodeAnnounceClaim{}
+		case TypeNodeLoadClaim:
+			refClaim = &NodeLoadClaim{}
+		case TypeMaintenanceClaim:
+			refClaim = &MaintenanceClaim{}
+		case TypeNodeStorageClaim:
+			refClaim = &NodeStorageClaim{}
+		case TypeNodeExpelClaim:
+			refClaim = &NodeExpelClaim{}
+		case TypeNodeCordonClaim:
+			refClaim = &NodeCordonClaim{}
+		case TypeNodeTimeClaim:
+			refClaim = &NodeTimeClaim{}
 		default:
 			return nil, errors.Wrap(err, "[ PacketHeader.parseReferendumClaim ] Unsupported claim type.")
 		}
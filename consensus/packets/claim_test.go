@@ -18,7 +18,12 @@
 package packets
 
 import (
+	"bytes"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
 
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/testutils"
@@ -85,10 +90,75 @@ func TestNodeJoinClaim_BadData(t *testing.T) {
 }
 
 func TestNodeLeaveClaim(t *testing.T) {
-	nodeLeaveClaim := &NodeLeaveClaim{}
+	nodeLeaveClaim := NewNodeLeaveClaim(core.LeaveReasonUpgrade, "upgrade to v2")
+	nodeLeaveClaim.ETA = core.PulseNumber(42)
 	checkSerializationDeserialization(t, nodeLeaveClaim)
 }
 
+func TestNodeLeaveClaim_Note(t *testing.T) {
+	require.Equal(t, "upgrade to v2", NewNodeLeaveClaim(core.LeaveReasonUpgrade, "upgrade to v2").GetNote())
+	require.Empty(t, (&NodeLeaveClaim{}).GetNote())
+
+	long := strings.Repeat("a", LeaveNoteLength+10)
+	require.Equal(t, long[:LeaveNoteLength], NewNodeLeaveClaim(core.LeaveReasonUpgrade, long).GetNote())
+}
+
+func TestNodeLoadClaim(t *testing.T) {
+	checkSerializationDeserialization(t, NewNodeLoadClaim(core.NodeLoad{CPU: 42, StorageHeadroom: 80, QueueDepth: 300}))
+	require.Equal(t, uint16(4), getClaimSize(&NodeLoadClaim{}))
+}
+
+func TestNodeLoadClaim_Validation(t *testing.T) {
+	_, err := (&NodeLoadClaim{CPU: 101}).Serialize()
+	require.Error(t, err)
+
+	err = (&NodeLoadClaim{}).Deserialize(bytes.NewReader([]byte{50, 101, 0, 0}))
+	require.Error(t, err)
+}
+
+func TestNodeStorageClaim(t *testing.T) {
+	checkSerializationDeserialization(t, NewNodeStorageClaim(3))
+	require.Equal(t, uint16(1), getClaimSize(&NodeStorageClaim{}))
+
+	_, err := NewNodeStorageClaim(101).Serialize()
+	require.Error(t, err)
+	err = (&NodeStorageClaim{}).Deserialize(bytes.NewReader([]byte{101}))
+	require.Error(t, err)
+}
+
+func TestNodeExpelClaim(t *testing.T) {
+	checkSerializationDeserialization(t, NewNodeExpelClaim(testutils.RandomRef()))
+	require.Equal(t, uint16(64), getClaimSize(&NodeExpelClaim{}))
+}
+
+func TestNodeCordonClaim(t *testing.T) {
+	checkSerializationDeserialization(t, NewNodeCordonClaim(core.FirstPulseNumber))
+	require.Equal(t, uint16(4), getClaimSize(&NodeCordonClaim{}))
+}
+
+func TestNodeTimeClaim(t *testing.T) {
+	now := time.Now()
+	claim := NewNodeTimeClaim(now)
+	checkSerializationDeserialization(t, claim)
+	require.Equal(t, uint16(8), getClaimSize(&NodeTimeClaim{}))
+	require.True(t, now.Equal(claim.GetTime()))
+}
+
+func TestMaintenanceClaim(t *testing.T) {
+	window := core.MaintenanceWindow{Issuer: testutils.RandomRef(), Start: 100, End: 150, Note: "storage compaction"}
+	claim := NewMaintenanceClaim(window, genRandomSlice(PublicKeyLength))
+	claim.Signature = randomArray66()
+	checkSerializationDeserialization(t, claim)
+	require.Equal(t, window, claim.GetWindow())
+	require.Equal(t, uint16(268), getClaimSize(&MaintenanceClaim{}))
+
+	raw, err := claim.SerializeRaw()
+	require.NoError(t, err)
+	full, err := claim.Serialize()
+	require.NoError(t, err)
+	require.Equal(t, raw, full[:len(full)-SignatureLength])
+}
+
 func TestMakeClaimHeader(t *testing.T) {
 
 }
@@ -4,9 +4,9 @@ package packets
 
 import "strconv"
 
-const _ClaimType_name = "TypeNodeJoinClaimTypeNodeAnnounceClaimTypeCapabilityPollingAndActivationTypeNodeViolationBlameTypeNodeBroadcastTypeNodeLeaveClaimTypeChangeNetworkClaim"
+const _ClaimType_name = "TypeNodeJoinClaimTypeNodeAnnounceClaimTypeCapabilityPollingAndActivationTypeNodeViolationBlameTypeNodeBroadcastTypeNodeLeaveClaimTypeChangeNetworkClaimTypeNodeLoadClaimTypeMaintenanceClaimTypeNodeStorageClaimTypeNodeExpelClaimTypeNodeCordonClaimTypeNodeTimeClaim"
 
-var _ClaimType_index = [...]uint8{0, 17, 38, 72, 94, 111, 129, 151}
+var _ClaimType_index = [...]uint16{0, 17, 38, 72, 94, 111, 129, 151, 168, 188, 208, 226, 245, 262}
 
 func (i ClaimType) String() string {
 	i -= 1
@@ -52,6 +52,12 @@ func init() {
 	claimSizeMap[TypeNodeBroadcast] = sizeOf(&NodeBroadcast{})
 	claimSizeMap[TypeNodeLeaveClaim] = sizeOf(&NodeLeaveClaim{})
 	claimSizeMap[TypeChangeNetworkClaim] = sizeOf(&NodeLeaveClaim{})
+	claimSizeMap[TypeNodeLoadClaim] = sizeOf(&NodeLoadClaim{})
+	claimSizeMap[TypeMaintenanceClaim] = sizeOf(&MaintenanceClaim{})
+	claimSizeMap[TypeNodeStorageClaim] = sizeOf(&NodeStorageClaim{})
+	claimSizeMap[TypeNodeExpelClaim] = sizeOf(&NodeExpelClaim{})
+	claimSizeMap[TypeNodeCordonClaim] = sizeOf(&NodeCordonClaim{})
+	claimSizeMap[TypeNodeTimeClaim] = sizeOf(&NodeTimeClaim{})
 
 	voteSizeMap = make(map[VoteType]uint16)
 	voteSizeMap[TypeStateFraudNodeSupplementaryVote] = sizeOf(&StateFraudNodeSupplementaryVote{})
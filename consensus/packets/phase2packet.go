@@ -95,6 +95,30 @@ func (p2p *Phase2Packet) Verify(crypto core.CryptographyService, key crypto.Publ
 	return nil
 }
 
+// SignatureChecks returns checks of signatures of packet sections made by owner of key, for batch verification.
+func (p2p *Phase2Packet) SignatureChecks(key crypto.PublicKey) ([]core.SignatureCheck, error) {
+	raw, err := p2p.rawFirstPart()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to get raw first part of phase 2 packet")
+	}
+	checks := []core.SignatureCheck{
+		{PublicKey: key, Signature: core.SignatureFromBytes(p2p.SignatureHeaderSection1[:]), Data: raw},
+	}
+
+	if !p2p.hasSection2() {
+		return checks, nil
+	}
+
+	raw, err = p2p.rawSecondPart()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to get raw second part of phase 2 packet")
+	}
+	checks = append(checks, core.SignatureCheck{
+		PublicKey: key, Signature: core.SignatureFromBytes(p2p.SignatureHeaderSection2[:]), Data: raw,
+	})
+	return checks, nil
+}
+
 func (p2p *Phase2Packet) Sign(cryptographyService core.CryptographyService) error {
 	raw, err := p2p.rawFirstPart()
 	if err != nil {
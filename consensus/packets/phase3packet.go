@@ -69,6 +69,17 @@ func (p3p *Phase3Packet) Verify(crypto core.CryptographyService, key crypto.Publ
 	return nil
 }
 
+// SignatureChecks returns check of packet signature made by owner of key, for batch verification.
+func (p3p *Phase3Packet) SignatureChecks(key crypto.PublicKey) ([]core.SignatureCheck, error) {
+	raw, err := p3p.rawBytes()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to get raw part of phase 3 packet")
+	}
+	return []core.SignatureCheck{
+		{PublicKey: key, Signature: core.SignatureFromBytes(p3p.SignatureHeaderSection1[:]), Data: raw},
+	}, nil
+}
+
 func (p3p *Phase3Packet) Sign(cryptographyService core.CryptographyService) error {
 	raw, err := p3p.rawBytes()
 	if err != nil {
@@ -19,12 +19,16 @@ package phases
 
 import (
 	"context"
+	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/insolar/insolar/component"
+	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/consensus"
 	"github.com/insolar/insolar/consensus/packets"
 	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/crashreport"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/instrumentation/instracer"
 	"github.com/insolar/insolar/log"
@@ -76,17 +80,21 @@ type ConsensusCommunicator struct {
 	PulseHandler     network.PulseHandler     `inject:""`
 	Cryptography     core.CryptographyService `inject:""`
 	NodeKeeper       network.NodeKeeper       `inject:""`
+	Profiler         Profiler                 `inject:""`
+	ClockSkewMonitor core.ClockSkewMonitor    `inject:""`
 
 	phase1result chan phase1Result
 	phase2result chan phase2Result
 	phase3result chan phase3Result
 
 	currentPulseNumber uint32
+
+	cfg configuration.Consensus
 }
 
 // NewCommunicator constructor creates new ConsensusCommunicator
-func NewCommunicator() *ConsensusCommunicator {
-	return &ConsensusCommunicator{}
+func NewCommunicator(cfg configuration.Consensus) *ConsensusCommunicator {
+	return &ConsensusCommunicator{cfg: cfg}
 }
 
 // Start method implements Starter interface
@@ -110,32 +118,70 @@ func (nc *ConsensusCommunicator) setPulseNumber(new core.PulseNumber) bool {
 	return old < new && atomic.CompareAndSwapUint32(&nc.currentPulseNumber, uint32(old), uint32(new))
 }
 
-func (nc *ConsensusCommunicator) sendRequestToNodes(ctx context.Context, participants []core.Node, packet packets.ConsensusPacket) {
+// profileSend records duration of sending phase packets when all sends are done.
+func (nc *ConsensusCommunicator) profileSend(phase string, start time.Time, wg *sync.WaitGroup) {
+	wg.Wait()
+	nc.Profiler.RecordSend(phase, time.Since(start))
+}
+
+// fanout returns fanout of phase packets configured for phase.
+func (nc *ConsensusCommunicator) fanout(phase string) *fanout {
+	var cfg configuration.ConsensusFanout
+	switch phase {
+	case ProfilePhase1:
+		cfg = nc.cfg.Phase1
+	case ProfilePhase2:
+		cfg = nc.cfg.Phase2
+	case ProfilePhase3:
+		cfg = nc.cfg.Phase3
+	}
+	return &fanout{cfg: cfg, send: nc.sendPacket}
+}
+
+// sendPacket signs and sends packet to receiver, attempt is a number of retry of failed send.
+func (nc *ConsensusCommunicator) sendPacket(ctx context.Context, packet packets.ConsensusPacket, receiver core.RecordRef, attempt int) error {
+	logger := inslogger.FromContext(ctx)
+	logger.Debugf("Send %s request to %s", packet.GetType(), receiver)
+	phaseTag := []tag.Mutator{tag.Upsert(consensus.TagPhase, packet.GetType().String())}
+	if attempt > 0 {
+		err := stats.RecordWithTags(context.Background(), phaseTag, consensus.PacketsRetried.M(1))
+		if err != nil {
+			logger.Warn("Failed to record metric of retried requests")
+		}
+	}
+	err := nc.ConsensusNetwork.SignAndSendPacket(packet, receiver, nc.Cryptography)
+	if err != nil {
+		logger.Errorf("Failed to send %s request to %s (attempt %d): %s", packet.GetType(), receiver, attempt+1, err.Error())
+		return err
+	}
+	err = stats.RecordWithTags(context.Background(), phaseTag, consensus.PacketsSent.M(1))
+	if err != nil {
+		logger.Warnf("Failed to record metric of sent %s requests", packet.GetType())
+	}
+	return nil
+}
+
+func (nc *ConsensusCommunicator) sendRequestToNodes(ctx context.Context, phase string, participants []core.Node, packet packets.ConsensusPacket) {
+	requests := make([]fanoutRequest, 0, len(participants))
 	for _, node := range participants {
 		if node.ID().Equal(nc.NodeKeeper.GetOrigin().ID()) {
 			continue
 		}
-
-		go func(ctx context.Context, n core.Node, packet packets.ConsensusPacket) {
-			logger := inslogger.FromContext(ctx)
-			logger.Debugf("Send %s request to %s", packet.GetType(), n.ID())
-			err := nc.ConsensusNetwork.SignAndSendPacket(packet, n.ID(), nc.Cryptography)
-			if err != nil {
-				logger.Errorf("Failed to send %s request: %s", packet.GetType(), err.Error())
-				return
-			}
-			err = stats.RecordWithTags(context.Background(), []tag.Mutator{tag.Upsert(consensus.TagPhase, packet.GetType().String())}, consensus.PacketsSent.M(1))
-			if err != nil {
-				logger.Warn("Failed to record metric of sent phase1 requests")
-			}
-		}(ctx, node, packet.Clone())
+		requests = append(requests, fanoutRequest{receiver: node.ID(), packet: packet.Clone()})
 	}
+
+	start := time.Now()
+	wg := &sync.WaitGroup{}
+	nc.fanout(phase).run(ctx, requests, wg)
+	crashreport.Go(ctx, "ConsensusCommunicator.profileSend", func() {
+		nc.profileSend(phase, start, wg)
+	})
 }
 
 func (nc *ConsensusCommunicator) sendRequestToNodesWithOrigin(ctx context.Context, originClaim *packets.NodeAnnounceClaim,
 	participants []core.Node, packet *packets.Phase1Packet) error {
 
-	requests := make(map[core.RecordRef]packets.ConsensusPacket)
+	requests := make([]fanoutRequest, 0, len(participants))
 	for _, participant := range participants {
 		if participant.ID().Equal(nc.NodeKeeper.GetOrigin().ID()) {
 			continue
@@ -145,24 +191,15 @@ func (nc *ConsensusCommunicator) sendRequestToNodesWithOrigin(ctx context.Contex
 		if err != nil {
 			return errors.Wrap(err, "Failed to update claims before sending in phase1")
 		}
-		requests[participant.ID()] = packet.Clone()
+		requests = append(requests, fanoutRequest{receiver: participant.ID(), packet: packet.Clone()})
 	}
 
-	for ref, req := range requests {
-		go func(ctx context.Context, node core.RecordRef, consensusPacket packets.ConsensusPacket) {
-			logger := inslogger.FromContext(ctx)
-			logger.Debug("Send phase1 request with origin to %s", node)
-			err := nc.ConsensusNetwork.SignAndSendPacket(consensusPacket, node, nc.Cryptography)
-			if err != nil {
-				logger.Error("Failed to send phase1 request with origin: " + err.Error())
-				return
-			}
-			err = stats.RecordWithTags(context.Background(), []tag.Mutator{tag.Upsert(consensus.TagPhase, consensusPacket.GetType().String())}, consensus.PacketsSent.M(1))
-			if err != nil {
-				logger.Warn("Failed to record metric of sent phase1 requests")
-			}
-		}(ctx, ref, req)
-	}
+	start := time.Now()
+	wg := &sync.WaitGroup{}
+	nc.fanout(ProfilePhase1).run(ctx, requests, wg)
+	crashreport.Go(ctx, "ConsensusCommunicator.profileSend", func() {
+		nc.profileSend(ProfilePhase1, start, wg)
+	})
 	return nil
 }
 
@@ -238,6 +275,8 @@ func (nc *ConsensusCommunicator) ExchangePhase1(
 	defer span.End()
 	logger := inslogger.FromContext(ctx)
 
+	start := time.Now()
+	var wait time.Duration
 	result := make(map[core.RecordRef]*packets.Phase1Packet, len(participants))
 	result[nc.ConsensusNetwork.GetNodeID()] = packet
 	nc.setPulseNumber(packet.GetPulse().PulseNumber)
@@ -256,7 +295,7 @@ func (nc *ConsensusCommunicator) ExchangePhase1(
 	}
 
 	if originClaim == nil {
-		nc.sendRequestToNodes(ctx, participants, request)
+		nc.sendRequestToNodes(ctx, ProfilePhase1, participants, request)
 	} else {
 		err := nc.sendRequestToNodesWithOrigin(ctx, originClaim, participants, packet)
 		if err != nil {
@@ -310,6 +349,7 @@ func (nc *ConsensusCommunicator) ExchangePhase1(
 			if !res.id.IsEmpty() {
 				sentRequests[res.id] = none{}
 				result[res.id] = res.packet
+				wait = time.Since(start)
 			}
 
 			// FIXME: early return is commented to have synchronized length of phases on all nodes
@@ -317,6 +357,7 @@ func (nc *ConsensusCommunicator) ExchangePhase1(
 			// 	return result, nil
 			// }
 		case <-ctx.Done():
+			nc.Profiler.RecordExchange(ProfilePhase1, time.Since(start), wait)
 			return result, nil
 		}
 	}
@@ -330,11 +371,13 @@ func (nc *ConsensusCommunicator) ExchangePhase2(ctx context.Context, list networ
 	defer span.End()
 	logger := inslogger.FromContext(ctx)
 
+	start := time.Now()
+	var wait time.Duration
 	result := make(map[core.RecordRef]*packets.Phase2Packet, len(participants))
 
 	result[nc.ConsensusNetwork.GetNodeID()] = packet
 
-	nc.sendRequestToNodes(ctx, participants, packet)
+	nc.sendRequestToNodes(ctx, ProfilePhase2, participants, packet)
 
 	type none struct{}
 	sentRequests := make(map[core.RecordRef]none)
@@ -374,12 +417,14 @@ func (nc *ConsensusCommunicator) ExchangePhase2(ctx context.Context, list networ
 			}
 			result[res.id] = res.packet
 			sentRequests[res.id] = none{}
+			wait = time.Since(start)
 
 			// FIXME: early return is commented to have synchronized length of phases on all nodes
 			// if len(result) == len(participants) {
 			// 	return result, nil
 			// }
 		case <-ctx.Done():
+			nc.Profiler.RecordExchange(ProfilePhase2, time.Since(start), wait)
 			return result, nil
 		}
 	}
@@ -397,6 +442,7 @@ func (nc *ConsensusCommunicator) sendAdditionalRequests(ctx context.Context, ori
 	additionalRequests []*AdditionalRequest) error {
 
 	logger := inslogger.FromContext(ctx)
+	start := time.Now()
 	for _, req := range additionalRequests {
 		newReq := *origReq
 		newReq.AddVote(&packets.MissingNode{NodeIndex: uint16(req.RequestIndex)})
@@ -410,6 +456,7 @@ func (nc *ConsensusCommunicator) sendAdditionalRequests(ctx context.Context, ori
 			logger.Warn("Failed to record metric of sent phase2.1 additional requests")
 		}
 	}
+	nc.Profiler.RecordSend(ProfilePhase21, time.Since(start))
 
 	return nil
 }
@@ -444,6 +491,8 @@ func (nc *ConsensusCommunicator) ExchangePhase21(ctx context.Context, list netwo
 		result = append(result, vote)
 	}
 
+	start := time.Now()
+	var wait time.Duration
 	err := nc.sendAdditionalRequests(ctx, packet, additionalRequests)
 	if err != nil {
 		return nil, errors.Wrap(err, "[ ExchangePhase2.1 ] Failed to send additional phase 2.1 requests")
@@ -490,12 +539,14 @@ func (nc *ConsensusCommunicator) ExchangePhase21(ctx context.Context, list netwo
 			}
 
 			incoming[res.id] = none{}
+			wait = time.Since(start)
 
 			// FIXME: early return is commented to have synchronized length of phases on all nodes
 			// if len(result) == len(participants) {
 			// 	return result, nil
 			// }
 		case <-ctx.Done():
+			nc.Profiler.RecordExchange(ProfilePhase21, time.Since(start), wait)
 			return result, nil
 		}
 	}
@@ -509,9 +560,11 @@ func (nc *ConsensusCommunicator) ExchangePhase3(ctx context.Context, participant
 	defer span.End()
 	logger := inslogger.FromContext(ctx)
 
+	start := time.Now()
+	var wait time.Duration
 	result[nc.ConsensusNetwork.GetNodeID()] = packet
 
-	nc.sendRequestToNodes(ctx, participants, packet)
+	nc.sendRequestToNodes(ctx, ProfilePhase3, participants, packet)
 
 	type none struct{}
 	sentRequests := make(map[core.RecordRef]none)
@@ -542,23 +595,27 @@ func (nc *ConsensusCommunicator) ExchangePhase3(ctx context.Context, participant
 			}
 			result[res.id] = res.packet
 			sentRequests[res.id] = none{}
+			wait = time.Since(start)
 
 			// FIXME: early return is commented to have synchronized length of phases on all nodes
 			// if len(result) == len(participants) {
 			// 	return result, nil
 			// }
 		case <-ctx.Done():
+			nc.Profiler.RecordExchange(ProfilePhase3, time.Since(start), wait)
 			return result, nil
 		}
 	}
 }
 
 func (nc *ConsensusCommunicator) phase1DataHandler(packet packets.ConsensusPacket, sender core.RecordRef) {
+	receivedAt := time.Now()
 	p, ok := packet.(*packets.Phase1Packet)
 	if !ok {
 		log.Errorln("invalid Phase1Packet")
 		return
 	}
+	nc.observeNeighborTime(sender, p, receivedAt)
 
 	newPulse := p.GetPulse()
 
@@ -568,12 +625,28 @@ func (nc *ConsensusCommunicator) phase1DataHandler(packet packets.ConsensusPacke
 	}
 
 	if nc.setPulseNumber(newPulse.PulseNumber) {
-		go nc.PulseHandler.HandlePulse(context.Background(), newPulse)
+		ctx := context.Background()
+		crashreport.Go(ctx, "ConsensusCommunicator.HandlePulse", func() {
+			nc.PulseHandler.HandlePulse(ctx, newPulse)
+		})
 	}
 
 	nc.phase1result <- phase1Result{id: sender, packet: p}
 }
 
+// observeNeighborTime passes local time announced by sender of phase1 packet to clock skew monitor.
+func (nc *ConsensusCommunicator) observeNeighborTime(sender core.RecordRef, p *packets.Phase1Packet, receivedAt time.Time) {
+	if sender.IsEmpty() {
+		return
+	}
+	for _, claim := range p.GetClaims() {
+		if timeClaim, ok := claim.(*packets.NodeTimeClaim); ok {
+			nc.ClockSkewMonitor.ObserveNeighborTime(context.Background(), sender, timeClaim.GetTime(), receivedAt)
+			return
+		}
+	}
+}
+
 func (nc *ConsensusCommunicator) phase2DataHandler(packet packets.ConsensusPacket, sender core.RecordRef) {
 	p, ok := packet.(*packets.Phase2Packet)
 	if !ok {
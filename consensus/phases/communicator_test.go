@@ -24,12 +24,15 @@ import (
 	"time"
 
 	"github.com/insolar/insolar/component"
+	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/consensus/packets"
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/clockskew"
 	"github.com/insolar/insolar/network/nodenetwork"
 	"github.com/insolar/insolar/testutils"
 	networkUtils "github.com/insolar/insolar/testutils/network"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -48,7 +51,7 @@ type communicatorSuite struct {
 func NewSuite() *communicatorSuite {
 	return &communicatorSuite{
 		Suite:        suite.Suite{},
-		communicator: NewCommunicator(),
+		communicator: NewCommunicator(configuration.NewConsensus()),
 		participants: nil,
 	}
 }
@@ -82,7 +85,8 @@ func (s *communicatorSuite) SetupTest() {
 
 	})
 
-	s.componentManager.Inject(nodeN, cryptoServ, s.communicator, s.consensusNetworkMock, s.pulseHandlerMock)
+	s.componentManager.Inject(nodeN, cryptoServ, s.communicator, s.consensusNetworkMock, s.pulseHandlerMock,
+		NewProfiler(DefaultProfilerWindow), clockskew.NewMonitor(configuration.NewClockSkew()))
 	err := s.componentManager.Start(context.TODO())
 	s.NoError(err)
 }
@@ -104,3 +108,19 @@ func (s *communicatorSuite) TestExchangeData() {
 func TestNaiveCommunicator(t *testing.T) {
 	suite.Run(t, NewSuite())
 }
+
+func TestCommunicator_ObserveNeighborTime(t *testing.T) {
+	monitor := clockskew.NewMonitor(configuration.NewClockSkew())
+	nc := &ConsensusCommunicator{ClockSkewMonitor: monitor}
+	now := time.Now()
+
+	p := packets.NewPhase1Packet(core.Pulse{PulseNumber: core.FirstPulseNumber + 1})
+	require.True(t, p.AddClaim(packets.NewNodeTimeClaim(now.Add(-time.Minute))))
+
+	nc.observeNeighborTime(core.RecordRef{}, p, now)
+	require.False(t, monitor.IsSkewed())
+
+	nc.observeNeighborTime(testutils.RandomRef(), p, now)
+	require.True(t, monitor.IsSkewed())
+	require.Equal(t, time.Minute, monitor.Skew())
+}
@@ -0,0 +1,96 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package phases
+
+import (
+	"sync"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+)
+
+// Evictor tracks nodes which miss consensus rounds and evicts nodes which miss too many rounds in a row,
+// so flapping nodes don't slow down every round with additional requests of phase 2.1.
+type Evictor interface {
+	// RecordRound records nodes which were active at the start of round and ones of them which participated
+	// in phase 1, returns nodes which missed configured number of consecutive rounds and must be evicted.
+	RecordRound(active []core.Node, participants map[core.RecordRef]bool) []core.RecordRef
+	// IsEvicted checks if node was evicted and can't rejoin yet.
+	IsEvicted(ref core.RecordRef) bool
+}
+
+type evictor struct {
+	cfg configuration.ConsensusEviction
+
+	lock    sync.Mutex
+	round   int
+	misses  map[core.RecordRef]int
+	evicted map[core.RecordRef]int
+}
+
+// NewEvictor creates evictor configured by cfg.
+func NewEvictor(cfg configuration.ConsensusEviction) Evictor {
+	return &evictor{
+		cfg:     cfg,
+		misses:  make(map[core.RecordRef]int),
+		evicted: make(map[core.RecordRef]int),
+	}
+}
+
+func (e *evictor) RecordRound(active []core.Node, participants map[core.RecordRef]bool) []core.RecordRef {
+	if e.cfg.MissedRounds <= 0 {
+		return nil
+	}
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.round++
+	for ref, evictedAt := range e.evicted {
+		if e.round-evictedAt >= e.cfg.RejoinPulses {
+			delete(e.evicted, ref)
+		}
+	}
+
+	result := make([]core.RecordRef, 0)
+	misses := make(map[core.RecordRef]int, len(active))
+	for _, node := range active {
+		ref := node.ID()
+		if participants[ref] {
+			continue
+		}
+		count := e.misses[ref] + 1
+		if count < e.cfg.MissedRounds {
+			misses[ref] = count
+			continue
+		}
+		e.evicted[ref] = e.round
+		result = append(result, ref)
+	}
+	// counters of nodes which participated or aren't active anymore start from scratch
+	e.misses = misses
+	return result
+}
+
+func (e *evictor) IsEvicted(ref core.RecordRef) bool {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	_, ok := e.evicted[ref]
+	return ok
+}
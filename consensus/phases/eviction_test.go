@@ -0,0 +1,61 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package phases
+
+import (
+	"testing"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/network/nodenetwork"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvictor_RecordRound(t *testing.T) {
+	e := NewEvictor(configuration.ConsensusEviction{MissedRounds: 2, RejoinPulses: 2})
+	stable := nodenetwork.NewNode(testutils.RandomRef(), core.StaticRoleVirtual, nil, "127.0.0.1:5432", "")
+	flapping := nodenetwork.NewNode(testutils.RandomRef(), core.StaticRoleVirtual, nil, "127.0.0.1:5433", "")
+	active := []core.Node{stable, flapping}
+	onlyStable := map[core.RecordRef]bool{stable.ID(): true}
+	all := map[core.RecordRef]bool{stable.ID(): true, flapping.ID(): true}
+
+	// participation resets counter of misses
+	require.Empty(t, e.RecordRound(active, onlyStable))
+	require.Empty(t, e.RecordRound(active, all))
+	require.Empty(t, e.RecordRound(active, onlyStable))
+	require.False(t, e.IsEvicted(flapping.ID()))
+
+	require.Equal(t, []core.RecordRef{flapping.ID()}, e.RecordRound(active, onlyStable))
+	require.True(t, e.IsEvicted(flapping.ID()))
+	require.False(t, e.IsEvicted(stable.ID()))
+
+	require.Empty(t, e.RecordRound([]core.Node{stable}, onlyStable))
+	require.True(t, e.IsEvicted(flapping.ID()))
+	require.Empty(t, e.RecordRound([]core.Node{stable}, onlyStable))
+	require.False(t, e.IsEvicted(flapping.ID()))
+}
+
+func TestEvictor_Disabled(t *testing.T) {
+	e := NewEvictor(configuration.ConsensusEviction{})
+	node := nodenetwork.NewNode(testutils.RandomRef(), core.StaticRoleVirtual, nil, "127.0.0.1:5432", "")
+	for i := 0; i < 10; i++ {
+		require.Empty(t, e.RecordRound([]core.Node{node}, nil))
+	}
+	require.False(t, e.IsEvicted(node.ID()))
+}
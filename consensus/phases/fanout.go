@@ -0,0 +1,120 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package phases
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/consensus/packets"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/crashreport"
+)
+
+// fanoutRequest is a packet to be sent to participant.
+type fanoutRequest struct {
+	receiver core.RecordRef
+	packet   packets.ConsensusPacket
+}
+
+// fanout sends packets of consensus phase in batches. Batches are spread evenly over a portion of the rest
+// of phase window to avoid network microbursts, failed sends are retried while phase deadline allows.
+type fanout struct {
+	cfg configuration.ConsensusFanout
+	// send sends packet, attempt is zero for first send and number of retry otherwise.
+	send func(ctx context.Context, packet packets.ConsensusPacket, receiver core.RecordRef, attempt int) error
+}
+
+// run sends requests in background, wg is done when all sends are finished.
+// Requests which aren't sent until phase is over are dropped.
+func (f *fanout) run(ctx context.Context, requests []fanoutRequest, wg *sync.WaitGroup) {
+	wg.Add(len(requests))
+	batches := f.batches(requests)
+	interval := f.interval(ctx, len(batches))
+	crashreport.Go(ctx, "fanout.run", func() {
+		for i, batch := range batches {
+			if i > 0 && interval > 0 {
+				select {
+				case <-ctx.Done():
+					for _, rest := range batches[i:] {
+						wg.Add(-len(rest))
+					}
+					return
+				case <-time.After(interval):
+				}
+			}
+			for _, req := range batch {
+				go func(req fanoutRequest) {
+					defer wg.Done()
+					defer crashreport.Recover(ctx, "fanout.sendWithRetries")
+					f.sendWithRetries(ctx, req)
+				}(req)
+			}
+		}
+	})
+}
+
+func (f *fanout) batches(requests []fanoutRequest) [][]fanoutRequest {
+	size := f.cfg.BatchSize
+	if size <= 0 || size > len(requests) {
+		size = len(requests)
+	}
+	var batches [][]fanoutRequest
+	for len(requests) > 0 {
+		batches = append(batches, requests[:size])
+		requests = requests[size:]
+		if size > len(requests) {
+			size = len(requests)
+		}
+	}
+	return batches
+}
+
+// interval returns pause between batches, so batches are spread over configured portion of the rest of phase window.
+func (f *fanout) interval(ctx context.Context, batches int) time.Duration {
+	if batches <= 1 || f.cfg.PacingRatio <= 0 {
+		return 0
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0
+	}
+	window := time.Until(deadline)
+	if window <= 0 {
+		return 0
+	}
+	return time.Duration(float64(window) * f.cfg.PacingRatio / float64(batches))
+}
+
+func (f *fanout) sendWithRetries(ctx context.Context, req fanoutRequest) {
+	for attempt := 0; ; attempt++ {
+		err := f.send(ctx, req.packet, req.receiver, attempt)
+		if err == nil || attempt >= f.cfg.Retries {
+			return
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= f.cfg.RetryDelay {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(f.cfg.RetryDelay):
+		}
+	}
+}
@@ -0,0 +1,114 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package phases
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/consensus/packets"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+)
+
+func fanoutRequests(n int) []fanoutRequest {
+	requests := make([]fanoutRequest, n)
+	for i := range requests {
+		requests[i] = fanoutRequest{receiver: testutils.RandomRef(), packet: &packets.Phase3Packet{}}
+	}
+	return requests
+}
+
+func TestFanout_Batches(t *testing.T) {
+	f := &fanout{cfg: configuration.ConsensusFanout{BatchSize: 2}}
+	batches := f.batches(fanoutRequests(5))
+	require.Len(t, batches, 3)
+	require.Len(t, batches[0], 2)
+	require.Len(t, batches[2], 1)
+
+	f.cfg.BatchSize = 0
+	require.Len(t, f.batches(fanoutRequests(5)), 1)
+	require.Empty(t, f.batches(nil))
+}
+
+func TestFanout_Interval(t *testing.T) {
+	f := &fanout{cfg: configuration.ConsensusFanout{PacingRatio: 0.5}}
+	require.Zero(t, f.interval(context.Background(), 4), "no deadline")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 400*time.Millisecond)
+	defer cancel()
+	require.Zero(t, f.interval(ctx, 1))
+	require.InDelta(t, 50*time.Millisecond, f.interval(ctx, 4), float64(10*time.Millisecond))
+
+	f.cfg.PacingRatio = 0
+	require.Zero(t, f.interval(ctx, 4))
+}
+
+func TestFanout_RunRetriesFailedSends(t *testing.T) {
+	var lock sync.Mutex
+	attempts := map[core.RecordRef]int{}
+	requests := fanoutRequests(3)
+	failing := requests[1].receiver
+	f := &fanout{
+		cfg: configuration.ConsensusFanout{BatchSize: 1, PacingRatio: 0.1, Retries: 2, RetryDelay: time.Millisecond},
+		send: func(ctx context.Context, packet packets.ConsensusPacket, receiver core.RecordRef, attempt int) error {
+			lock.Lock()
+			defer lock.Unlock()
+			attempts[receiver]++
+			if receiver == failing {
+				return errors.New("send failed")
+			}
+			return nil
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	wg := &sync.WaitGroup{}
+	start := time.Now()
+	f.run(ctx, requests, wg)
+	wg.Wait()
+
+	require.True(t, time.Since(start) >= 50*time.Millisecond, "batches must be spread over phase window")
+	require.Equal(t, 1, attempts[requests[0].receiver])
+	require.Equal(t, 3, attempts[failing])
+	require.Equal(t, 1, attempts[requests[2].receiver])
+}
+
+func TestFanout_RunDropsSendsAfterDeadline(t *testing.T) {
+	sent := 0
+	f := &fanout{
+		cfg: configuration.ConsensusFanout{BatchSize: 1, PacingRatio: 1},
+		send: func(ctx context.Context, packet packets.ConsensusPacket, receiver core.RecordRef, attempt int) error {
+			sent++
+			return nil
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	wg := &sync.WaitGroup{}
+	f.run(ctx, fanoutRequests(3), wg)
+	cancel()
+	wg.Wait()
+	require.Equal(t, 1, sent)
+}
@@ -20,6 +20,7 @@ package phases
 import (
 	"context"
 	"math"
+	"time"
 
 	"github.com/insolar/insolar/consensus"
 	"github.com/insolar/insolar/consensus/packets"
@@ -62,10 +63,12 @@ func NewFirstPhase() FirstPhase {
 }
 
 type FirstPhaseImpl struct {
-	Calculator   merkle.Calculator        `inject:""`
-	Communicator Communicator             `inject:""`
-	Cryptography core.CryptographyService `inject:""`
-	NodeKeeper   network.NodeKeeper       `inject:""`
+	Calculator   merkle.Calculator           `inject:""`
+	Communicator Communicator                `inject:""`
+	Cryptography core.CryptographyService    `inject:""`
+	NodeKeeper   network.NodeKeeper          `inject:""`
+	Evictor      Evictor                     `inject:""`
+	Revocations  core.CertificateRevocations `inject:""`
 }
 
 // Execute do first phase
@@ -109,6 +112,9 @@ func (fp *FirstPhaseImpl) Execute(ctx context.Context, pulse *core.Pulse) (*Firs
 		}
 		log.Debug("[ NET Consensus phase-1 ] Added origin claim in Phase1Packet")
 	}
+	if !packet.AddClaim(packets.NewNodeTimeClaim(time.Now())) {
+		log.Warn("[ NET Consensus phase-1 ] Failed to add time claim in Phase1Packet")
+	}
 	for {
 		claim := fp.NodeKeeper.GetClaimQueue().Front()
 		if claim == nil {
@@ -161,7 +167,7 @@ func (fp *FirstPhaseImpl) Execute(ctx context.Context, pulse *core.Pulse) (*Firs
 			},
 			StateHash: rawProof.StateHash(),
 		}
-		claimMap[ref] = fp.filterClaims(ref, packet.GetClaims())
+		claimMap[ref] = fp.filterClaims(ref, pulse, packet.GetClaims())
 	}
 
 	if fp.NodeKeeper.GetState() == core.WaitingNodeNetworkState {
@@ -185,6 +191,9 @@ func (fp *FirstPhaseImpl) Execute(ctx context.Context, pulse *core.Pulse) (*Firs
 		logger.Warnf("[ NET Consensus phase-1 ] Failed to validate proof from %s", nodeID)
 		unsyncList.RemoveNode(nodeID)
 	}
+	if fp.NodeKeeper.GetState() == core.ReadyNodeNetworkState {
+		fp.evictStaleNodes(ctx, unsyncList, activeNodes, valid)
+	}
 	logger.Infof("[ NET Consensus phase-1 ] Valid proofs after phase: %d/%d", len(valid), unsyncList.Length())
 
 	return &FirstPhaseState{
@@ -197,6 +206,24 @@ func (fp *FirstPhaseImpl) Execute(ctx context.Context, pulse *core.Pulse) (*Firs
 	}, nil
 }
 
+func (fp *FirstPhaseImpl) evictStaleNodes(
+	ctx context.Context, unsyncList network.UnsyncList, activeNodes []core.Node, valid map[core.Node]*merkle.PulseProof,
+) {
+	participants := make(map[core.RecordRef]bool, len(valid)+1)
+	participants[fp.NodeKeeper.GetOrigin().ID()] = true
+	for node := range valid {
+		participants[node.ID()] = true
+	}
+	evicted := fp.Evictor.RecordRound(activeNodes, participants)
+	for _, nodeID := range evicted {
+		inslogger.FromContext(ctx).Warnf("[ NET Consensus phase-1 ] Evicting node %s that keeps missing consensus", nodeID)
+		unsyncList.RemoveNode(nodeID)
+	}
+	if len(evicted) > 0 {
+		stats.Record(ctx, consensus.EvictedNodes.M(int64(len(evicted))))
+	}
+}
+
 func (fp *FirstPhaseImpl) checkPacketSignature(packet *packets.Phase1Packet, recordRef core.RecordRef) error {
 	if fp.NodeKeeper.GetState() == core.WaitingNodeNetworkState {
 		return fp.checkPacketSignatureFromClaim(packet, recordRef)
@@ -240,9 +267,29 @@ func detectSparseBitsetLength(claims map[core.RecordRef][]packets.ReferendumClai
 	return 0, errors.New("no announce claims were received")
 }
 
-func (fp *FirstPhaseImpl) filterClaims(nodeID core.RecordRef, claims []packets.ReferendumClaim) []packets.ReferendumClaim {
+func (fp *FirstPhaseImpl) filterClaims(
+	nodeID core.RecordRef, pulse *core.Pulse, claims []packets.ReferendumClaim,
+) []packets.ReferendumClaim {
 	result := make([]packets.ReferendumClaim, 0)
 	for _, claim := range claims {
+		if joinClaim := joinClaimOf(claim); joinClaim != nil {
+			if fp.Evictor.IsEvicted(joinClaim.NodeRef) {
+				log.Warnf("ignoring join claim of evicted node %s", joinClaim.NodeRef)
+				continue
+			}
+			if fp.Revocations.IsRevoked(joinClaim.NodeRef) {
+				stats.Record(context.Background(), consensus.DeclinedClaims.M(1))
+				log.Warnf("ignoring join claim of node %s with revoked certificate", joinClaim.NodeRef)
+				continue
+			}
+		}
+		if maintenanceClaim, ok := claim.(*packets.MaintenanceClaim); ok {
+			if err := fp.checkMaintenanceClaim(maintenanceClaim, pulse); err != nil {
+				stats.Record(context.Background(), consensus.DeclinedClaims.M(1))
+				log.Warnf("ignoring maintenance claim of node %s: %s", maintenanceClaim.Issuer, err)
+				continue
+			}
+		}
 		signedClaim, ok := claim.(packets.SignedClaim)
 		if ok && !nodeID.Equal(fp.NodeKeeper.GetOrigin().ID()) {
 			err := fp.checkClaimSignature(signedClaim)
@@ -261,6 +308,37 @@ func (fp *FirstPhaseImpl) filterClaims(nodeID core.RecordRef, claims []packets.R
 	return result
 }
 
+func joinClaimOf(claim packets.ReferendumClaim) *packets.NodeJoinClaim {
+	switch c := claim.(type) {
+	case *packets.NodeJoinClaim:
+		return c
+	case *packets.NodeAnnounceClaim:
+		return &c.NodeJoinClaim
+	}
+	return nil
+}
+
+// checkMaintenanceClaim checks that window is announced in advance by active node with its own key.
+func (fp *FirstPhaseImpl) checkMaintenanceClaim(claim *packets.MaintenanceClaim, pulse *core.Pulse) error {
+	if err := claim.GetWindow().Validate(*pulse); err != nil {
+		return err
+	}
+	issuer := fp.NodeKeeper.GetActiveNode(claim.Issuer)
+	if issuer == nil {
+		return errors.New("issuer is not an active node")
+	}
+	exported, err := platformpolicy.NewKeyProcessor().ExportPublicKeyBinary(issuer.PublicKey())
+	if err != nil {
+		return errors.Wrap(err, "failed to export a key of issuer")
+	}
+	var key [packets.PublicKeyLength]byte
+	copy(key[:], exported)
+	if key != claim.IssuerPK {
+		return errors.New("claim key doesn't match key of issuer")
+	}
+	return nil
+}
+
 func (fp *FirstPhaseImpl) checkClaimSignature(claim packets.SignedClaim) error {
 	key, err := claim.GetPublicKey()
 	if err != nil {
@@ -18,10 +18,12 @@
 package phases
 
 import (
+	"context"
 	"crypto"
 	"testing"
 
 	"github.com/insolar/insolar/component"
+	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/network/nodenetwork"
 	"github.com/insolar/insolar/testutils"
@@ -31,6 +33,13 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+type noRevocations struct{}
+
+func (noRevocations) IsRevoked(core.RecordRef) bool                 { return false }
+func (noRevocations) Revoke(context.Context, core.RecordRef) error  { return nil }
+func (noRevocations) Restore(context.Context, core.RecordRef) error { return nil }
+func (noRevocations) RevocationLists() []core.RevocationList        { return nil }
+
 func TestFirstPhase_HandlePulse(t *testing.T) {
 	firstPhase := &FirstPhaseImpl{}
 	nodeKeeperMock := network.NewNodeKeeperMock(t)
@@ -53,7 +62,9 @@ func TestFirstPhase_HandlePulse(t *testing.T) {
 	})
 
 	cm := component.Manager{}
-	cm.Inject(cryptoServ, nodeKeeperMock, firstPhase, pulseCalculatorMock, communicatorMock, consensusNetworkMock)
+	evictor := NewEvictor(configuration.ConsensusEviction{})
+	cm.Inject(cryptoServ, nodeKeeperMock, firstPhase, pulseCalculatorMock, communicatorMock, consensusNetworkMock, evictor,
+		&noRevocations{})
 
 	require.NotNil(t, firstPhase.Calculator)
 	require.NotNil(t, firstPhase.NodeKeeper)
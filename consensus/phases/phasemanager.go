@@ -22,6 +22,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/network"
@@ -41,13 +42,15 @@ type Phases struct {
 	PulseManager core.PulseManager  `inject:""`
 	NodeKeeper   network.NodeKeeper `inject:""`
 	Calculator   merkle.Calculator  `inject:""`
+	Profiler     Profiler           `inject:""`
 
-	lock sync.Mutex
+	deadlines configuration.ConsensusDeadlines
+	lock      sync.Mutex
 }
 
 // NewPhaseManager creates and returns a new phase manager.
-func NewPhaseManager() PhaseManager {
-	return &Phases{}
+func NewPhaseManager(deadlines configuration.ConsensusDeadlines) PhaseManager {
+	return &Phases{deadlines: deadlines}
 }
 
 // OnPulse starts calculate args on phases.
@@ -71,31 +74,39 @@ func (pm *Phases) OnPulse(ctx context.Context, pulse *core.Pulse, pulseStartTime
 	tctx, cancel = contextTimeoutWithDelay(ctx, *pulseDuration, consensusDelay, 0.3)
 	defer cancel()
 
+	start := time.Now()
 	firstPhaseState, err := pm.FirstPhase.Execute(tctx, pulse)
+	pm.Profiler.RecordPhase(ProfilePhase1, time.Since(start))
 	if err != nil {
 		return errors.Wrap(err, "[ NET Consensus ] Error executing phase 1")
 	}
 
-	tctx, cancel = contextTimeout(ctx, *pulseDuration, 0.05)
+	tctx, cancel = contextTimeout(ctx, *pulseDuration, pm.phaseDeadline(ctx, *pulseDuration))
 	defer cancel()
 
+	start = time.Now()
 	secondPhaseState, err := pm.SecondPhase.Execute(tctx, pulse, firstPhaseState)
+	pm.Profiler.RecordPhase(ProfilePhase2, time.Since(start))
 	if err != nil {
 		return errors.Wrap(err, "[ NET Consensus ] Error executing phase 2.0")
 	}
 
-	tctx, cancel = contextTimeout(ctx, *pulseDuration, 0.05)
+	tctx, cancel = contextTimeout(ctx, *pulseDuration, pm.phaseDeadline(ctx, *pulseDuration))
 	defer cancel()
 
+	start = time.Now()
 	secondPhaseState, err = pm.SecondPhase.Execute21(tctx, pulse, secondPhaseState)
+	pm.Profiler.RecordPhase(ProfilePhase21, time.Since(start))
 	if err != nil {
 		return errors.Wrap(err, "[ NET Consensus ] Error executing phase 2.1")
 	}
 
-	tctx, cancel = contextTimeout(ctx, *pulseDuration, 0.05)
+	tctx, cancel = contextTimeout(ctx, *pulseDuration, pm.phaseDeadline(ctx, *pulseDuration))
 	defer cancel()
 
+	start = time.Now()
 	thirdPhaseState, err := pm.ThirdPhase.Execute(tctx, pulse, secondPhaseState)
+	pm.Profiler.RecordPhase(ProfilePhase3, time.Since(start))
 	if err != nil {
 		return errors.Wrap(err, "[ NET Consensus ] Error executing phase 3")
 	}
@@ -115,8 +126,36 @@ func (pm *Phases) OnPulse(ctx context.Context, pulse *core.Pulse, pulseStartTime
 	return nil
 }
 
+// phaseDeadline returns portion of pulse duration given to phases 2, 2.1 and 3.
+func (pm *Phases) phaseDeadline(ctx context.Context, pulseDuration time.Duration) float64 {
+	max := pm.deadlines.MaxFraction
+	if max <= 0 {
+		max = 0.05
+	}
+	if !pm.deadlines.Adaptive || pm.Profiler == nil {
+		return max
+	}
+	rtt, ok := pm.Profiler.LastRTT()
+	if !ok || rtt.Max == 0 {
+		return max
+	}
+
+	k := pm.deadlines.RTTFactor * float64(rtt.Max) / float64(pulseDuration)
+	if k < pm.deadlines.MinFraction {
+		k = pm.deadlines.MinFraction
+	}
+	if k > max {
+		k = max
+	}
+	inslogger.FromContext(ctx).Debugf("[ NET Consensus ] Phase deadline %v for max RTT %v", time.Duration(k*float64(pulseDuration)), rtt.Max)
+	return k
+}
+
 func getPulseDuration(pulse *core.Pulse) (*time.Duration, error) {
-	duration := time.Duration(pulse.NextPulseNumber-pulse.PulseNumber) * time.Second
+	duration := pulse.Duration()
+	if duration == 0 {
+		return nil, errors.Errorf("pulse %d doesn't announce next pulse", pulse.PulseNumber)
+	}
 	return &duration, nil
 }
 
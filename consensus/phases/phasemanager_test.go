@@ -0,0 +1,49 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package phases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+)
+
+func TestPhases_PhaseDeadline(t *testing.T) {
+	ctx := context.Background()
+	deadlines := configuration.NewConsensus().Deadlines
+	profiler := NewProfiler(DefaultProfilerWindow)
+	pm := &Phases{Profiler: profiler, deadlines: deadlines}
+
+	require.Equal(t, 0.05, pm.phaseDeadline(ctx, 10*time.Second))
+
+	pm.deadlines.Adaptive = true
+	require.Equal(t, 0.05, pm.phaseDeadline(ctx, 10*time.Second), "no measurements yet")
+
+	profiler.RecordRTT(1, []core.PeerRTT{{P50: 75 * time.Millisecond}})
+	require.InDelta(t, 0.03, pm.phaseDeadline(ctx, 10*time.Second), 1e-9)
+
+	profiler.RecordRTT(2, []core.PeerRTT{{P50: time.Millisecond}})
+	require.Equal(t, 0.02, pm.phaseDeadline(ctx, 10*time.Second))
+
+	profiler.RecordRTT(3, []core.PeerRTT{{P50: time.Second}})
+	require.Equal(t, 0.05, pm.phaseDeadline(ctx, 10*time.Second))
+}
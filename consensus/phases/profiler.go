@@ -0,0 +1,220 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package phases
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+
+	"github.com/insolar/insolar/consensus"
+	"github.com/insolar/insolar/core"
+)
+
+// Names of consensus phases in time profile.
+const (
+	ProfilePhase1  = "phase1"
+	ProfilePhase2  = "phase2"
+	ProfilePhase21 = "phase21"
+	ProfilePhase3  = "phase3"
+)
+
+// DefaultProfilerWindow is a number of recent pulses percentiles of phase durations are calculated over.
+const DefaultProfilerWindow = 100
+
+var profilePhases = []string{ProfilePhase1, ProfilePhase2, ProfilePhase21, ProfilePhase3}
+
+type timing int
+
+const (
+	timingSend timing = iota
+	timingWait
+	timingProcess
+)
+
+var timingMeasures = map[timing]*stats.Float64Measure{
+	timingSend:    consensus.PhaseSendDuration,
+	timingWait:    consensus.PhaseWaitDuration,
+	timingProcess: consensus.PhaseProcessDuration,
+}
+
+// Profiler records durations of consensus phases each pulse, so it's possible to tell which phase consumes pulse time.
+type Profiler interface {
+	core.ConsensusProfiler
+	core.RTTProvider
+	// RecordSend records duration of sending phase packets to all participants.
+	RecordSend(phase string, d time.Duration)
+	// RecordExchange records duration of packets exchange and duration until the last packet from participants was received.
+	RecordExchange(phase string, exchange, wait time.Duration)
+	// RecordPhase records total duration of phase, processing duration is calculated without exchange duration.
+	RecordPhase(phase string, total time.Duration)
+	// RecordRTT records round trip times to consensus participants measured during pulse.
+	RecordRTT(pulse core.PulseNumber, peers []core.PeerRTT)
+	// LastRTT returns summary of round trip times of the latest recorded pulse.
+	LastRTT() (core.RTTSummary, bool)
+}
+
+type profiler struct {
+	window int
+
+	lock     sync.Mutex
+	samples  map[string]map[timing][]time.Duration
+	exchange map[string]time.Duration
+	rtt      []core.RTTSummary
+}
+
+// NewProfiler creates profiler that keeps samples of last window pulses.
+func NewProfiler(window int) Profiler {
+	return &profiler{
+		window:   window,
+		samples:  make(map[string]map[timing][]time.Duration),
+		exchange: make(map[string]time.Duration),
+	}
+}
+
+func (p *profiler) RecordSend(phase string, d time.Duration) {
+	p.record(phase, timingSend, d)
+}
+
+func (p *profiler) RecordExchange(phase string, exchange, wait time.Duration) {
+	p.lock.Lock()
+	p.exchange[phase] = exchange
+	p.lock.Unlock()
+
+	p.record(phase, timingWait, wait)
+}
+
+func (p *profiler) RecordPhase(phase string, total time.Duration) {
+	p.lock.Lock()
+	process := total - p.exchange[phase]
+	delete(p.exchange, phase)
+	p.lock.Unlock()
+
+	if process < 0 {
+		process = 0
+	}
+	p.record(phase, timingProcess, process)
+}
+
+func (p *profiler) record(phase string, t timing, d time.Duration) {
+	p.lock.Lock()
+	timings, ok := p.samples[phase]
+	if !ok {
+		timings = make(map[timing][]time.Duration)
+		p.samples[phase] = timings
+	}
+	samples := append(timings[t], d)
+	if len(samples) > p.window {
+		samples = append(samples[:0], samples[len(samples)-p.window:]...)
+	}
+	timings[t] = samples
+	p.lock.Unlock()
+
+	_ = stats.RecordWithTags(
+		context.Background(),
+		[]tag.Mutator{tag.Upsert(consensus.TagPhase, phase)},
+		timingMeasures[t].M(float64(d)/float64(time.Millisecond)),
+	)
+}
+
+func (p *profiler) RecordRTT(pulse core.PulseNumber, peers []core.PeerRTT) {
+	medians := make([]time.Duration, 0, len(peers))
+	for _, peer := range peers {
+		medians = append(medians, peer.P50)
+		stats.Record(context.Background(), consensus.PeerRTT.M(float64(peer.P50)/float64(time.Millisecond)))
+	}
+	summary := percentiles(medians)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.rtt = append(p.rtt, core.RTTSummary{
+		Pulse: pulse,
+		Peers: peers,
+		P50:   summary.P50,
+		P90:   summary.P90,
+		Max:   summary.Max,
+	})
+	if len(p.rtt) > p.window {
+		p.rtt = append(p.rtt[:0], p.rtt[len(p.rtt)-p.window:]...)
+	}
+}
+
+func (p *profiler) LastRTT() (core.RTTSummary, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if len(p.rtt) == 0 {
+		return core.RTTSummary{}, false
+	}
+	return p.rtt[len(p.rtt)-1], true
+}
+
+func (p *profiler) GetRTT() []core.RTTSummary {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	result := make([]core.RTTSummary, len(p.rtt))
+	copy(result, p.rtt)
+	return result
+}
+
+func (p *profiler) GetPhaseTimings() []core.PhaseTimings {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	result := make([]core.PhaseTimings, 0, len(profilePhases))
+	for _, phase := range profilePhases {
+		timings, ok := p.samples[phase]
+		if !ok {
+			continue
+		}
+		result = append(result, core.PhaseTimings{
+			Phase:   phase,
+			Send:    percentiles(timings[timingSend]),
+			Wait:    percentiles(timings[timingWait]),
+			Process: percentiles(timings[timingProcess]),
+		})
+	}
+	return result
+}
+
+// percentiles calculates percentiles of samples by nearest-rank method.
+func percentiles(samples []time.Duration) core.DurationPercentiles {
+	if len(samples) == 0 {
+		return core.DurationPercentiles{}
+	}
+	sorted := make([]time.Duration, len(samples))
+	copy(sorted, samples)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := func(p float64) time.Duration {
+		return sorted[int(math.Ceil(p*float64(len(sorted))))-1]
+	}
+	return core.DurationPercentiles{
+		Samples: len(sorted),
+		P50:     rank(0.5),
+		P90:     rank(0.9),
+		P99:     rank(0.99),
+		Max:     sorted[len(sorted)-1],
+	}
+}
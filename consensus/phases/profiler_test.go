@@ -0,0 +1,88 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package phases
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+)
+
+func TestProfiler_GetPhaseTimings(t *testing.T) {
+	p := NewProfiler(DefaultProfilerWindow)
+	for i := 1; i <= 100; i++ {
+		p.RecordSend(ProfilePhase2, time.Duration(i)*time.Millisecond)
+		p.RecordExchange(ProfilePhase2, 100*time.Millisecond, time.Duration(i)*time.Millisecond)
+		p.RecordPhase(ProfilePhase2, 100*time.Millisecond+time.Duration(i)*time.Millisecond)
+	}
+	p.RecordPhase(ProfilePhase1, 10*time.Millisecond)
+
+	timings := p.GetPhaseTimings()
+	require.Len(t, timings, 2)
+
+	require.Equal(t, ProfilePhase1, timings[0].Phase)
+	require.Equal(t, core.DurationPercentiles{}, timings[0].Send)
+	require.Equal(t, 10*time.Millisecond, timings[0].Process.Max)
+
+	require.Equal(t, ProfilePhase2, timings[1].Phase)
+	expected := core.DurationPercentiles{
+		Samples: 100,
+		P50:     50 * time.Millisecond,
+		P90:     90 * time.Millisecond,
+		P99:     99 * time.Millisecond,
+		Max:     100 * time.Millisecond,
+	}
+	require.Equal(t, expected, timings[1].Send)
+	require.Equal(t, expected, timings[1].Wait)
+	require.Equal(t, expected, timings[1].Process)
+}
+
+func TestProfiler_Window(t *testing.T) {
+	p := NewProfiler(3)
+	for i := 1; i <= 5; i++ {
+		p.RecordSend(ProfilePhase3, time.Duration(i))
+	}
+
+	timings := p.GetPhaseTimings()
+	require.Len(t, timings, 1)
+	require.Equal(t, core.DurationPercentiles{Samples: 3, P50: 4, P90: 5, P99: 5, Max: 5}, timings[0].Send)
+}
+
+func TestProfiler_RecordRTT(t *testing.T) {
+	p := NewProfiler(2)
+	_, ok := p.LastRTT()
+	require.False(t, ok)
+
+	peers := []core.PeerRTT{
+		{Address: "127.0.0.1:1", Samples: 3, Min: 1, P50: 2, Max: 10},
+		{Address: "127.0.0.1:2", Samples: 1, Min: 5, P50: 5, Max: 5},
+	}
+	for pulse := core.PulseNumber(1); pulse <= 3; pulse++ {
+		p.RecordRTT(pulse, peers)
+	}
+
+	summaries := p.GetRTT()
+	require.Len(t, summaries, 2)
+	require.Equal(t, core.PulseNumber(2), summaries[0].Pulse)
+
+	last, ok := p.LastRTT()
+	require.True(t, ok)
+	require.Equal(t, core.RTTSummary{Pulse: 3, Peers: peers, P50: 2, P90: 5, Max: 5}, last)
+}
@@ -48,6 +48,7 @@ type SecondPhaseImpl struct {
 	Calculator   merkle.Calculator        `inject:""`
 	Communicator Communicator             `inject:""`
 	Cryptography core.CryptographyService `inject:""`
+	Verifier     core.BatchVerifier       `inject:""`
 }
 
 func (sp *SecondPhaseImpl) Execute(ctx context.Context, pulse *core.Pulse, state *FirstPhaseState) (*SecondPhaseState, error) {
@@ -96,12 +97,14 @@ func (sp *SecondPhaseImpl) Execute(ctx context.Context, pulse *core.Pulse, state
 	origin := sp.NodeKeeper.GetOrigin().ID()
 	stateMatrix := NewStateMatrix(state.UnsyncList)
 
+	signed := make(map[core.RecordRef]signedPacket, len(packets))
 	for ref, packet := range packets {
-		err = nil
-		if !ref.Equal(origin) {
-			err = sp.checkPacketSignature(packet, ref, state.UnsyncList)
-		}
-		if err != nil {
+		signed[ref] = packet
+	}
+	failed := verifyPacketSignatures(sp.Verifier, state.UnsyncList, origin, signed)
+
+	for ref, packet := range packets {
+		if err, ok := failed[ref]; ok {
 			logger.Warnf("[ NET Consensus phase-2.0 ] Failed to check phase2 packet signature from %s: %s", ref, err.Error())
 			continue
 		}
@@ -317,12 +320,3 @@ func (sp *SecondPhaseImpl) generatePhase2Bitset(list network.UnsyncList, proofs
 	}
 	return bitset, nil
 }
-
-func (sp *SecondPhaseImpl) checkPacketSignature(packet *packets.Phase2Packet, recordRef core.RecordRef, unsyncList network.UnsyncList) error {
-	activeNode := unsyncList.GetActiveNode(recordRef)
-	if activeNode == nil {
-		return errors.New("failed to get active node")
-	}
-	key := activeNode.PublicKey()
-	return packet.Verify(sp.Cryptography, key)
-}
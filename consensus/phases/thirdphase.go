@@ -47,6 +47,7 @@ type ThirdPhaseImpl struct {
 	Communicator Communicator             `inject:""`
 	NodeKeeper   network.NodeKeeper       `inject:""`
 	Calculator   merkle.Calculator        `inject:""`
+	Verifier     core.BatchVerifier       `inject:""`
 }
 
 func (tp *ThirdPhaseImpl) Execute(ctx context.Context, pulse *core.Pulse, state *SecondPhaseState) (*ThirdPhaseState, error) {
@@ -56,7 +57,7 @@ func (tp *ThirdPhaseImpl) Execute(ctx context.Context, pulse *core.Pulse, state
 	stats.Record(ctx, consensus.Phase3Exec.M(1))
 
 	logger := inslogger.FromContext(ctx)
-	totalCount := state.UnsyncList.Length()
+	totalCount := state.UnsyncList.Length() - observersCount(state.UnsyncList.GetActiveNodes())
 
 	var gSign [packets.SignatureLength]byte
 	copy(gSign[:], state.GlobuleProof.Signature.Bytes()[:packets.SignatureLength])
@@ -76,12 +77,14 @@ func (tp *ThirdPhaseImpl) Execute(ctx context.Context, pulse *core.Pulse, state
 		logger.Warn("[ NET Consensus phase-3 ] Failed to record received responses metric: " + err.Error())
 	}
 
+	signed := make(map[core.RecordRef]signedPacket, len(responses))
 	for ref, packet := range responses {
-		err = nil
-		if !ref.Equal(tp.NodeKeeper.GetOrigin().ID()) {
-			err = tp.checkPacketSignature(packet, ref, state.UnsyncList)
-		}
-		if err != nil {
+		signed[ref] = packet
+	}
+	failed := verifyPacketSignatures(tp.Verifier, state.UnsyncList, tp.NodeKeeper.GetOrigin().ID(), signed)
+
+	for ref, packet := range responses {
+		if err, ok := failed[ref]; ok {
 			logger.Warnf("[ NET Consensus phase-3 ] Failed to check phase3 packet signature from %s: %s", ref, err.Error())
 			continue
 		}
@@ -94,6 +97,9 @@ func (tp *ThirdPhaseImpl) Execute(ctx context.Context, pulse *core.Pulse, state
 	prevCloudHash := tp.NodeKeeper.GetCloudHash()
 	validNodes := 0
 	for _, node := range nodes {
+		if node.Role() == core.StaticRoleObserver {
+			continue
+		}
 		ghs, ok := state.UnsyncList.GetGlobuleHashSignature(node.ID())
 		if !ok {
 			log.Warnf("[ NET Consensus phase-3 ] No globule hash signature for node %s", node.ID())
@@ -129,11 +135,13 @@ func (tp *ThirdPhaseImpl) Execute(ctx context.Context, pulse *core.Pulse, state
 	}, nil
 }
 
-func (tp *ThirdPhaseImpl) checkPacketSignature(packet *packets.Phase3Packet, recordRef core.RecordRef, unsyncList network.UnsyncList) error {
-	activeNode := unsyncList.GetActiveNode(recordRef)
-	if activeNode == nil {
-		return errors.New("failed to get active node")
+// observersCount returns number of nodes which don't vote in consensus.
+func observersCount(nodes []core.Node) int {
+	count := 0
+	for _, node := range nodes {
+		if node.Role() == core.StaticRoleObserver {
+			count++
+		}
 	}
-	key := activeNode.PublicKey()
-	return packet.Verify(tp.Cryptography, key)
+	return count
 }
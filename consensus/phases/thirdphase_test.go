@@ -0,0 +1,38 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package phases
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/network/nodenetwork"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestObserversCount(t *testing.T) {
+	nodes := []core.Node{
+		nodenetwork.NewNode(testutils.RandomRef(), core.StaticRoleVirtual, nil, "127.0.0.1:5432", ""),
+		nodenetwork.NewNode(testutils.RandomRef(), core.StaticRoleObserver, nil, "127.0.0.1:5433", ""),
+		nodenetwork.NewNode(testutils.RandomRef(), core.StaticRoleLightMaterial, nil, "127.0.0.1:5434", ""),
+		nodenetwork.NewNode(testutils.RandomRef(), core.StaticRoleObserver, nil, "127.0.0.1:5435", ""),
+	}
+	assert.Equal(t, 2, observersCount(nodes))
+	assert.Equal(t, 0, observersCount(nil))
+}
@@ -0,0 +1,70 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package phases
+
+import (
+	"crypto"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/network"
+)
+
+// signedPacket is a phase packet which signatures can be verified in a batch.
+type signedPacket interface {
+	SignatureChecks(key crypto.PublicKey) ([]core.SignatureCheck, error)
+}
+
+// verifyPacketSignatures checks signatures of packets received from participants in a single batch and returns
+// errors of packets which failed the check. Packet of origin is not checked.
+func verifyPacketSignatures(
+	verifier core.BatchVerifier,
+	list network.UnsyncList,
+	origin core.RecordRef,
+	packets map[core.RecordRef]signedPacket,
+) map[core.RecordRef]error {
+	failed := make(map[core.RecordRef]error)
+	checks := make([]core.SignatureCheck, 0, len(packets))
+	owners := make([]core.RecordRef, 0, len(packets))
+	for ref, packet := range packets {
+		if ref.Equal(origin) {
+			continue
+		}
+		activeNode := list.GetActiveNode(ref)
+		if activeNode == nil {
+			failed[ref] = errors.New("failed to get active node")
+			continue
+		}
+		packetChecks, err := packet.SignatureChecks(activeNode.PublicKey())
+		if err != nil {
+			failed[ref] = err
+			continue
+		}
+		for _, check := range packetChecks {
+			checks = append(checks, check)
+			owners = append(owners, ref)
+		}
+	}
+
+	for i, valid := range verifier.VerifyBatch(checks) {
+		if !valid {
+			failed[owners[i]] = errors.New("bad signature")
+		}
+	}
+	return failed
+}
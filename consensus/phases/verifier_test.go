@@ -0,0 +1,76 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package phases
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/consensus/packets"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/cryptography"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+	"github.com/insolar/insolar/testutils/network"
+)
+
+func TestVerifyPacketSignatures(t *testing.T) {
+	kp := platformpolicy.NewKeyProcessor()
+	newService := func() core.CryptographyService {
+		key, err := kp.GeneratePrivateKey()
+		require.NoError(t, err)
+		return cryptography.NewKeyBoundCryptographyService(key)
+	}
+	newPacket := func(signer core.CryptographyService) *packets.Phase3Packet {
+		bitset, err := packets.NewBitSet(4)
+		require.NoError(t, err)
+		packet := packets.NewPhase3Packet(core.FirstPulseNumber, packets.GlobuleHashSignature{}, bitset)
+		require.NoError(t, packet.Sign(signer))
+		return packet
+	}
+
+	origin, good := testutils.RandomRef(), testutils.RandomRef()
+	forged, unknown := testutils.RandomRef(), testutils.RandomRef()
+	goodService, forgedService, otherService := newService(), newService(), newService()
+	keys := map[core.RecordRef]core.CryptographyService{good: goodService, forged: forgedService}
+
+	list := network.NewUnsyncListMock(t)
+	list.GetActiveNodeFunc = func(ref core.RecordRef) core.Node {
+		service, ok := keys[ref]
+		if !ok {
+			return nil
+		}
+		key, err := service.GetPublicKey()
+		require.NoError(t, err)
+		node := network.NewNodeMock(t)
+		node.PublicKeyMock.Return(key)
+		return node
+	}
+
+	signed := map[core.RecordRef]signedPacket{
+		origin:  packets.NewPhase3Packet(core.FirstPulseNumber, packets.GlobuleHashSignature{}, nil),
+		good:    newPacket(goodService),
+		forged:  newPacket(otherService),
+		unknown: newPacket(otherService),
+	}
+	failed := verifyPacketSignatures(platformpolicy.NewPlatformCryptographyScheme().BatchVerifier(2), list, origin, signed)
+
+	require.Len(t, failed, 2)
+	require.Contains(t, failed, forged)
+	require.Contains(t, failed, unknown)
+}
@@ -0,0 +1,247 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+package consensus
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/configuration"
+)
+
+// phase1Fraction is a portion of pulse duration given to phase 1, as in phase manager.
+const phase1Fraction = 0.3
+
+// RTTDistribution is a log-normal distribution of round trip times between nodes.
+type RTTDistribution struct {
+	// Median is median round trip time.
+	Median time.Duration
+	// Sigma is standard deviation of logarithm of round trip time, zero makes all round trip times equal to median.
+	Sigma float64
+}
+
+func (d RTTDistribution) sample(r *rand.Rand) time.Duration {
+	return time.Duration(float64(d.Median) * math.Exp(d.Sigma*r.NormFloat64()))
+}
+
+// SimulationParams describes network which consensus is simulated for.
+type SimulationParams struct {
+	// Nodes is a number of consensus participants.
+	Nodes int
+	// RTT is a distribution of round trip times between pair of nodes, they are sampled for every pair every round.
+	RTT RTTDistribution
+	// Loss is a probability of packet to be lost, lost packets are not resent.
+	Loss float64
+	// PulseDuration is a duration of pulse.
+	PulseDuration time.Duration
+	// Rounds is a number of simulated consensus rounds.
+	Rounds int
+	// Seed makes simulation reproducible.
+	Seed int64
+	// Consensus is a configuration of phases fanout and deadlines.
+	Consensus configuration.Consensus
+}
+
+// PhaseReport is a simulated outcome of consensus phase.
+type PhaseReport struct {
+	Name string
+	// Executed is a number of rounds phase was executed in, phase 2.1 is executed only if phase 2 timed out.
+	Executed int
+	// Deadline is a mean deadline of phase.
+	Deadline time.Duration
+	// TimeoutRate is a portion of phase executions on nodes which didn't get packets from all participants in time.
+	TimeoutRate float64
+	// RoundTimeoutRate is a portion of executed rounds in which phase timed out on at least one node.
+	RoundTimeoutRate float64
+	// Mean and P99 are durations of phase on nodes, timed out phases last until deadline.
+	Mean time.Duration
+	P99  time.Duration
+}
+
+// SimulationReport is a simulated outcome of consensus rounds.
+type SimulationReport struct {
+	Rounds int
+	Phases []PhaseReport
+}
+
+// phaseStats accumulates outcomes of phase executions.
+type phaseStats struct {
+	executed  int
+	deadlines time.Duration
+	timeouts  int
+	roundFail int
+	durations []time.Duration
+}
+
+func (s *phaseStats) report(name string) PhaseReport {
+	rep := PhaseReport{Name: name, Executed: s.executed}
+	if s.executed == 0 {
+		return rep
+	}
+	rep.Deadline = s.deadlines / time.Duration(s.executed)
+	rep.TimeoutRate = float64(s.timeouts) / float64(len(s.durations))
+	rep.RoundTimeoutRate = float64(s.roundFail) / float64(s.executed)
+
+	sort.Slice(s.durations, func(i, j int) bool { return s.durations[i] < s.durations[j] })
+	var sum time.Duration
+	for _, d := range s.durations {
+		sum += d
+	}
+	rep.Mean = sum / time.Duration(len(s.durations))
+	rep.P99 = s.durations[(len(s.durations)*99)/100]
+	return rep
+}
+
+// Simulate runs consensus rounds offline and reports expected phase timeouts, helps to plan network size and phase
+// timings. Every node sends phase packets to all other nodes in batches as configured, phase is over on node when
+// packets of all participants are received or deadline is reached.
+func Simulate(params SimulationParams) (*SimulationReport, error) {
+	if params.Nodes < 2 {
+		return nil, errors.New("at least 2 nodes are required")
+	}
+	if params.PulseDuration <= 0 || params.Rounds <= 0 || params.RTT.Median <= 0 {
+		return nil, errors.New("pulse duration, rounds and median RTT must be positive")
+	}
+	if params.Loss < 0 || params.Loss >= 1 {
+		return nil, errors.New("loss must be in [0, 1)")
+	}
+
+	s := &simulator{params: params, r: rand.New(rand.NewSource(params.Seed))}
+	var phase1, phase2, phase21, phase3 phaseStats
+	for round := 0; round < params.Rounds; round++ {
+		rtt := s.sampleRTT()
+		s.run(&phase1, time.Duration(phase1Fraction*float64(params.PulseDuration)), params.Consensus.Phase1, rtt)
+
+		deadline := s.deadline(rtt)
+		if s.run(&phase2, deadline, params.Consensus.Phase2, rtt) {
+			s.run(&phase21, deadline, params.Consensus.Phase2, rtt)
+		}
+		s.run(&phase3, deadline, params.Consensus.Phase3, rtt)
+	}
+
+	return &SimulationReport{
+		Rounds: params.Rounds,
+		Phases: []PhaseReport{
+			phase1.report("1"),
+			phase2.report("2"),
+			phase21.report("2.1"),
+			phase3.report("3"),
+		},
+	}, nil
+}
+
+type simulator struct {
+	params SimulationParams
+	r      *rand.Rand
+}
+
+// sampleRTT returns symmetric matrix of round trip times between nodes.
+func (s *simulator) sampleRTT() [][]time.Duration {
+	n := s.params.Nodes
+	rtt := make([][]time.Duration, n)
+	for i := range rtt {
+		rtt[i] = make([]time.Duration, n)
+	}
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			rtt[i][j] = s.params.RTT.sample(s.r)
+			rtt[j][i] = rtt[i][j]
+		}
+	}
+	return rtt
+}
+
+// deadline returns deadline of phases 2, 2.1 and 3 the same way phase manager picks it. Adaptive deadline is
+// calculated from the largest round trip time of the round.
+func (s *simulator) deadline(rtt [][]time.Duration) time.Duration {
+	cfg := s.params.Consensus.Deadlines
+	pulse := float64(s.params.PulseDuration)
+	max := cfg.MaxFraction
+	if max <= 0 {
+		max = 0.05
+	}
+	k := max
+	if cfg.Adaptive {
+		var largest time.Duration
+		for i := range rtt {
+			for _, d := range rtt[i] {
+				if d > largest {
+					largest = d
+				}
+			}
+		}
+		k = math.Min(math.Max(cfg.RTTFactor*float64(largest)/pulse, cfg.MinFraction), max)
+	}
+	return time.Duration(k * pulse)
+}
+
+// run simulates phase on all nodes, returns true if phase timed out on any node.
+func (s *simulator) run(stats *phaseStats, window time.Duration, cfg configuration.ConsensusFanout, rtt [][]time.Duration) bool {
+	n := s.params.Nodes
+	// last is time the last packet was received by node, received is number of packets received by node.
+	last := make([]time.Duration, n)
+	received := make([]int, n)
+	for sender := 0; sender < n; sender++ {
+		peers := s.r.Perm(n)
+		batch := cfg.BatchSize
+		if batch <= 0 || batch > n-1 {
+			batch = n - 1
+		}
+		batches := (n - 1 + batch - 1) / batch
+		var interval time.Duration
+		if batches > 1 && cfg.PacingRatio > 0 {
+			interval = time.Duration(float64(window) * cfg.PacingRatio / float64(batches))
+		}
+
+		sent := 0
+		for _, receiver := range peers {
+			if receiver == sender {
+				continue
+			}
+			at := time.Duration(sent/batch)*interval + rtt[sender][receiver]/2
+			sent++
+			if s.r.Float64() < s.params.Loss || at > window {
+				continue
+			}
+			received[receiver]++
+			if at > last[receiver] {
+				last[receiver] = at
+			}
+		}
+	}
+
+	stats.executed++
+	stats.deadlines += window
+	timedOut := false
+	for node := 0; node < n; node++ {
+		if received[node] < n-1 {
+			timedOut = true
+			stats.timeouts++
+			stats.durations = append(stats.durations, window)
+			continue
+		}
+		stats.durations = append(stats.durations, last[node])
+	}
+	if timedOut {
+		stats.roundFail++
+	}
+	return timedOut
+}
@@ -0,0 +1,104 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+)
+
+func simulationParams() SimulationParams {
+	return SimulationParams{
+		Nodes:         20,
+		RTT:           RTTDistribution{Median: 10 * time.Millisecond},
+		PulseDuration: 10 * time.Second,
+		Rounds:        10,
+		Consensus:     configuration.NewConsensus(),
+	}
+}
+
+func TestSimulate_Validation(t *testing.T) {
+	params := simulationParams()
+	params.Nodes = 1
+	_, err := Simulate(params)
+	require.Error(t, err)
+
+	params = simulationParams()
+	params.Loss = 1
+	_, err = Simulate(params)
+	require.Error(t, err)
+
+	params = simulationParams()
+	params.PulseDuration = 0
+	_, err = Simulate(params)
+	require.Error(t, err)
+}
+
+func TestSimulate_FastNetwork(t *testing.T) {
+	rep, err := Simulate(simulationParams())
+	require.NoError(t, err)
+	require.Equal(t, 10, rep.Rounds)
+	require.Len(t, rep.Phases, 4)
+	for _, phase := range rep.Phases {
+		require.Zero(t, phase.TimeoutRate, phase.Name)
+	}
+	require.Equal(t, 10, rep.Phases[0].Executed)
+	require.Equal(t, 0, rep.Phases[2].Executed, "phase 2.1 is executed only after timeouts")
+	require.Equal(t, 500*time.Millisecond, rep.Phases[1].Deadline)
+	require.True(t, rep.Phases[1].P99 >= 5*time.Millisecond)
+	require.True(t, rep.Phases[1].P99 < rep.Phases[1].Deadline)
+}
+
+func TestSimulate_SlowNetwork(t *testing.T) {
+	params := simulationParams()
+	params.RTT.Median = 2 * time.Second
+	rep, err := Simulate(params)
+	require.NoError(t, err)
+
+	require.Zero(t, rep.Phases[0].TimeoutRate, "phase 1 has 30% of pulse")
+	require.Equal(t, 1.0, rep.Phases[1].TimeoutRate)
+	require.Equal(t, 10, rep.Phases[2].Executed)
+	require.Equal(t, 1.0, rep.Phases[3].RoundTimeoutRate)
+	require.Equal(t, rep.Phases[3].Deadline, rep.Phases[3].Mean)
+
+	// Adaptive deadline stretches up to MaxFraction only.
+	params.Consensus.Deadlines.Adaptive = true
+	params.Consensus.Deadlines.MaxFraction = 0.2
+	rep, err = Simulate(params)
+	require.NoError(t, err)
+	require.Equal(t, 2*time.Second, rep.Phases[1].Deadline)
+}
+
+func TestSimulate_Loss(t *testing.T) {
+	params := simulationParams()
+	params.Loss = 0.01
+	params.RTT.Sigma = 0.5
+	params.Seed = 42
+	rep, err := Simulate(params)
+	require.NoError(t, err)
+	require.True(t, rep.Phases[1].TimeoutRate > 0)
+	require.True(t, rep.Phases[1].TimeoutRate < 1)
+	require.True(t, rep.Phases[2].Executed > 0)
+
+	again, err := Simulate(params)
+	require.NoError(t, err)
+	require.Equal(t, rep, again)
+}
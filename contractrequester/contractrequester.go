@@ -31,21 +31,35 @@ import (
 	"github.com/insolar/insolar/core/reply"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/instrumentation/instracer"
+	"github.com/insolar/insolar/instrumentation/retry"
+)
+
+const (
+	// maxBusyRetries is a number of retries of request rejected by busy executor.
+	maxBusyRetries = 3
+	// defaultPulseDuration is used to wait for pulses when current pulse doesn't know the next one.
+	defaultPulseDuration = 10 * time.Second
 )
 
 // ContractRequester helps to call contracts
 type ContractRequester struct {
-	MessageBus   core.MessageBus   `inject:""`
-	PulseStorage core.PulseStorage `inject:""`
-	ResultMutex  sync.Mutex
-	ResultMap    map[uint64]chan *message.ReturnResults
-	Sequence     uint64
+	MessageBus      core.MessageBus      `inject:""`
+	PulseStorage    core.PulseStorage    `inject:""`
+	ArtifactManager core.ArtifactManager `inject:""`
+	ResultMutex     sync.Mutex
+	ResultMap       map[uint64]chan *message.ReturnResults
+	Sequence        uint64
+
+	cfg configuration.ContractRequester
+	now func() time.Time
 }
 
 // New creates new ContractRequester
-func New() (*ContractRequester, error) {
+func New(cfg configuration.ContractRequester) (*ContractRequester, error) {
 	return &ContractRequester{
 		ResultMap: make(map[uint64]chan *message.ReturnResults),
+		cfg:       cfg,
+		now:       time.Now,
 	}, nil
 }
 
@@ -64,18 +78,140 @@ func randomUint64() uint64 {
 	return binary.LittleEndian.Uint64(buf)
 }
 
+// send sends message and retries it while executor replies it's busy.
+func (cr *ContractRequester) send(ctx context.Context, mb core.MessageBus, msg core.Message) (core.Reply, error) {
+	var res core.Reply
+	policy := retry.Policy{Attempts: maxBusyRetries + 1}
+	err := retry.Do(ctx, "contractrequester.send", policy, func(ctx context.Context, attempt int) error {
+		var err error
+		ops, object := cr.hintedOptions(ctx, msg)
+		res, err = mb.Send(ctx, msg, ops)
+		if err != nil {
+			if object != nil {
+				core.RoutingHintsFromContext(ctx).Forget(*object)
+			}
+			// Node rejects message it isn't executor of before registering it, so it's safe to resend.
+			if core.ErrorCodeOf(err) == core.ErrCodeNotAuthorized {
+				inslogger.FromContext(ctx).Debug("Message is rejected by node which isn't executor, retrying: ", err)
+				return retry.Retryable(err)
+			}
+			return err
+		}
+		busy, ok := res.(*reply.Busy)
+		if !ok {
+			return nil
+		}
+
+		wait, err := cr.pulsesDuration(ctx, busy.RetryAfter)
+		if err != nil {
+			return err
+		}
+		inslogger.FromContext(ctx).Debugf("Executor is busy, retrying in %s", wait)
+		return retry.RetryAfter(errors.New("executor is busy"), wait)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// hintedOptions returns send options with executor node from context's routing hints as receiver. Object is returned
+// to forget its hint if sending fails. Nil options mean the message is routed by role.
+func (cr *ContractRequester) hintedOptions(
+	ctx context.Context, msg core.Message,
+) (*core.MessageSendOptions, *core.RecordRef) {
+	hints := core.RoutingHintsFromContext(ctx)
+	object := msg.DefaultTarget()
+	if hints == nil || object == nil || msg.DefaultRole() != core.DynamicRoleVirtualExecutor {
+		return nil, nil
+	}
+	executor := hints.Executor(ctx, *object)
+	if executor == nil {
+		return nil, nil
+	}
+	return &core.MessageSendOptions{Receiver: executor}, object
+}
+
+// pulsesDuration returns duration of n pulses, estimated by current pulse.
+func (cr *ContractRequester) pulsesDuration(ctx context.Context, n int) (time.Duration, error) {
+	if n < 1 {
+		n = 1
+	}
+	pulse, err := cr.PulseStorage.Current(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "can't get current pulse")
+	}
+	duration := pulse.Duration()
+	if duration == 0 {
+		duration = defaultPulseDuration
+	}
+	return time.Duration(n) * duration, nil
+}
+
+// alignToPulse delays call issued in the tail of pulse until the next pulse, so the call isn't registered
+// by executor which is about to hand its objects over. Latency-sensitive calls aren't delayed.
+func (cr *ContractRequester) alignToPulse(ctx context.Context) error {
+	if cr.cfg.PulseTail == 0 {
+		return nil
+	}
+	if apiRequest := core.APIRequestFromContext(ctx); apiRequest != nil && apiRequest.Priority {
+		return nil
+	}
+	pulse, err := cr.PulseStorage.Current(ctx)
+	if err != nil {
+		return errors.Wrap(err, "can't get current pulse")
+	}
+	wait := pulseTailWait(pulse, cr.cfg.PulseTail, cr.now())
+	if wait == 0 {
+		return nil
+	}
+
+	inslogger.FromContext(ctx).Debugf("Call is issued in the tail of pulse %d, delaying it for %s",
+		pulse.PulseNumber, wait)
+	select {
+	case <-time.After(wait):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// pulseTailWait returns time left until the next pulse if now is in the last tail percents of pulse, zero otherwise.
+// Overdue pulses aren't waited for.
+func pulseTailWait(pulse *core.Pulse, tail uint8, now time.Time) time.Duration {
+	duration := pulse.Duration()
+	if duration == 0 || pulse.PulseTimestamp == 0 {
+		return 0
+	}
+	left := time.Unix(pulse.PulseTimestamp, 0).Add(duration).Sub(now)
+	if left <= 0 || left > duration*time.Duration(tail)/100 {
+		return 0
+	}
+	return left
+}
+
 // SendRequest makes synchronously call to method of contract by its ref without additional information
 func (cr *ContractRequester) SendRequest(ctx context.Context, ref *core.RecordRef, method string, argsIn []interface{}) (core.Reply, error) {
 	ctx, span := instracer.StartSpan(ctx, "SendRequest "+method)
 	defer span.End()
 
+	if err := cr.alignToPulse(ctx); err != nil {
+		return nil, errors.Wrap(err, "[ ContractRequester::SendRequest ] Can't align call to pulse")
+	}
+
 	args, err := core.MarshalArgs(argsIn...)
 	if err != nil {
 		return nil, errors.Wrap(err, "[ ContractRequester::SendRequest ] Can't marshal")
 	}
 
+	apiRequest := core.APIRequestFromContext(ctx)
 	bm := &message.BaseLogicMessage{
-		Nonce: randomUint64(),
+		Nonce:      randomUint64(),
+		APIRequest: apiRequest,
+	}
+	if apiRequest != nil {
+		// nested calls share API request, only the call made by API node is registered with its nonce
+		bm.SignedNonce = apiRequest.Nonce
 	}
 	routResult, err := cr.CallMethod(ctx, bm, false, ref, method, args, nil)
 	if err != nil {
@@ -85,6 +221,15 @@ func (cr *ContractRequester) SendRequest(ctx context.Context, ref *core.RecordRe
 	return routResult, nil
 }
 
+// CallByDelegate resolves delegate of parent object with provided prototype and makes synchronous call to its method
+func (cr *ContractRequester) CallByDelegate(ctx context.Context, parent *core.RecordRef, prototype *core.RecordRef, method string, argsIn []interface{}) (core.Reply, error) {
+	delegate, err := cr.ArtifactManager.GetDelegate(ctx, *parent, *prototype)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ ContractRequester::CallByDelegate ] Can't get delegate")
+	}
+	return cr.SendRequest(ctx, delegate, method, argsIn)
+}
+
 func (cr *ContractRequester) CallMethod(ctx context.Context, base core.Message, async bool, ref *core.RecordRef, method string, argsIn core.Arguments, mustPrototype *core.RecordRef) (core.Reply, error) {
 	ctx, span := instracer.StartSpan(ctx, "ContractRequester.CallMethod "+method)
 	defer span.End()
@@ -109,11 +254,12 @@ func (cr *ContractRequester) CallMethod(ctx context.Context, base core.Message,
 	}
 
 	msg := &message.CallMethod{
-		BaseLogicMessage: *baseMessage,
-		ReturnMode:       mode,
-		ObjectRef:        *ref,
-		Method:           method,
-		Arguments:        argsIn,
+		BaseLogicMessage:  *baseMessage,
+		ReturnMode:        mode,
+		ObjectRef:         *ref,
+		Method:            method,
+		Arguments:         argsIn,
+		AcceptsCompressed: true,
 	}
 	if mustPrototype != nil {
 		msg.ProxyPrototype = *mustPrototype
@@ -133,7 +279,7 @@ func (cr *ContractRequester) CallMethod(ctx context.Context, base core.Message,
 		cr.ResultMutex.Unlock()
 	}
 
-	res, err := mb.Send(ctx, msg, nil)
+	res, err := cr.send(ctx, mb, msg)
 
 	if err != nil {
 		return nil, errors.Wrap(err, "couldn't dispatch event")
@@ -148,7 +294,7 @@ func (cr *ContractRequester) CallMethod(ctx context.Context, base core.Message,
 		return res, nil
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, time.Duration(configuration.NewAPIRunner().Timeout)*time.Second)
+	waitCtx, cancel := context.WithTimeout(ctx, time.Duration(configuration.NewAPIRunner().Timeout)*time.Second)
 	defer cancel()
 	inslogger.FromContext(ctx).Debug("Waiting for Method results ref=", r.Request)
 
@@ -157,6 +303,9 @@ func (cr *ContractRequester) CallMethod(ctx context.Context, base core.Message,
 	select {
 	case ret := <-ch:
 		inslogger.FromContext(ctx).Debug("Got Method results")
+		if errReply, ok := ret.Reply.(*reply.Error); ok {
+			return nil, errReply.Error()
+		}
 		if ret.Error != "" {
 			return nil, errors.New(ret.Error)
 		}
@@ -164,20 +313,51 @@ func (cr *ContractRequester) CallMethod(ctx context.Context, base core.Message,
 		if !ok {
 			return nil, errors.New("Reply is not CallMethod")
 		}
+		if err := retReply.DecompressResult(); err != nil {
+			return nil, errors.Wrap(err, "couldn't read results")
+		}
 		result = &reply.CallMethod{
-			Request: r.Request,
-			Result:  retReply.Result,
+			Request:  r.Request,
+			Result:   retReply.Result,
+			Pulse:    retReply.Pulse,
+			Finality: retReply.Finality,
 		}
-	case <-ctx.Done():
+	case <-waitCtx.Done():
 		cr.ResultMutex.Lock()
 		delete(cr.ResultMap, seq)
 		cr.ResultMutex.Unlock()
-		return nil, errors.New("canceled")
+
+		result, err = cr.resultFromLedger(ctx, r.Request)
+		if err == core.ErrDeactivated {
+			return nil, err
+		}
+		if err != nil {
+			inslogger.FromContext(ctx).Debug("Result is not registered on ledger: ", err)
+			return nil, errors.New("canceled")
+		}
 	}
 
 	return result, nil
 }
 
+// resultFromLedger fetches result of request registered on ledger. Results could be executed and registered while
+// ReturnResults message was lost, so ledger is asked before giving up waiting.
+func (cr *ContractRequester) resultFromLedger(ctx context.Context, request core.RecordRef) (*reply.CallMethod, error) {
+	payload, err := cr.ArtifactManager.GetResult(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if reply.IsDeactivatedResult(payload) {
+		return nil, core.ErrDeactivated
+	}
+	return &reply.CallMethod{
+		Request:  request,
+		Result:   payload,
+		Pulse:    request.Record().Pulse(),
+		Finality: core.FinalityExecuted,
+	}, nil
+}
+
 func (cr *ContractRequester) CallConstructor(ctx context.Context, base core.Message, async bool,
 	prototype *core.RecordRef, to *core.RecordRef, method string,
 	argsIn core.Arguments, saveAs int) (*core.RecordRef, error) {
@@ -214,7 +394,7 @@ func (cr *ContractRequester) CallConstructor(ctx context.Context, base core.Mess
 		cr.ResultMutex.Unlock()
 	}
 
-	res, err := mb.Send(ctx, msg, nil)
+	res, err := cr.send(ctx, mb, msg)
 	if err != nil {
 		return nil, errors.Wrap(err, "couldn't save new object as delegate")
 	}
@@ -235,6 +415,9 @@ func (cr *ContractRequester) CallConstructor(ctx context.Context, base core.Mess
 	select {
 	case ret := <-ch:
 		inslogger.FromContext(ctx).Debug("Got Constructor results")
+		if errReply, ok := ret.Reply.(*reply.Error); ok {
+			return nil, errReply.Error()
+		}
 		if ret.Error != "" {
 			return nil, errors.New(ret.Error)
 		}
@@ -274,3 +457,10 @@ func (cr *ContractRequester) ReceiveResult(ctx context.Context, parcel core.Parc
 
 	return &reply.OK{}, nil
 }
+
+// ResultMapSize returns number of requests waiting for results.
+func (cr *ContractRequester) ResultMapSize() int {
+	cr.ResultMutex.Lock()
+	defer cr.ResultMutex.Unlock()
+	return len(cr.ResultMap)
+}
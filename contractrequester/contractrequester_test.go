@@ -23,9 +23,11 @@ import (
 	"time"
 
 	"github.com/gojuno/minimock"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/insolar/insolar/component"
+	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/core/message"
 	"github.com/insolar/insolar/core/reply"
@@ -45,15 +47,17 @@ func mockMessageBus(t *testing.T, result core.Reply) *testutils.MessageBusMock {
 func TestNew(t *testing.T) {
 	ps := testutils.NewPulseStorageMock(t)
 	messageBus := mockMessageBus(t, nil)
+	am := testutils.NewArtifactManagerMock(t)
 
-	contractRequester, err := New()
+	contractRequester, err := New(configuration.NewContractRequester())
 
 	cm := &component.Manager{}
-	cm.Inject(ps, messageBus, contractRequester)
+	cm.Inject(ps, messageBus, am, contractRequester)
 
 	require.NoError(t, err)
 	require.Equal(t, messageBus, contractRequester.MessageBus)
 	require.Equal(t, ps, contractRequester.PulseStorage)
+	require.Equal(t, am, contractRequester.ArtifactManager)
 }
 
 func TestContractRequester_SendRequest(t *testing.T) {
@@ -64,7 +68,7 @@ func TestContractRequester_SendRequest(t *testing.T) {
 	pm.CurrentMock.Return(core.GenesisPulse, nil)
 
 	mbm := mockMessageBus(t, &reply.RegisterRequest{})
-	cReq, err := New()
+	cReq, err := New(configuration.NewContractRequester())
 	assert.NoError(t, err)
 	cReq.MessageBus = mbm
 	cReq.PulseStorage = pm
@@ -96,6 +100,34 @@ func TestContractRequester_SendRequest(t *testing.T) {
 	require.Equal(t, &reply.CallMethod{}, result)
 }
 
+func TestContractRequester_CallByDelegate(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	parent, prototype, delegate := testutils.RandomRef(), testutils.RandomRef(), testutils.RandomRef()
+
+	am := testutils.NewArtifactManagerMock(t)
+	am.GetDelegateMock.Expect(ctx, parent, prototype).Return(&delegate, nil)
+	mbm := testutils.NewMessageBusMock(t)
+	mbm.SendFunc = func(c context.Context, m core.Message, o *core.MessageSendOptions) (core.Reply, error) {
+		require.Equal(t, delegate, m.(*message.CallMethod).ObjectRef)
+		require.Equal(t, "TestMethod", m.(*message.CallMethod).Method)
+		return nil, errors.New("test error")
+	}
+
+	cReq, err := New(configuration.NewContractRequester())
+	require.NoError(t, err)
+	cReq.MessageBus = mbm
+	cReq.ArtifactManager = am
+
+	_, err = cReq.CallByDelegate(ctx, &parent, &prototype, "TestMethod", []interface{}{})
+	require.Error(t, err)
+	require.Equal(t, uint64(1), mbm.SendCounter)
+
+	am.GetDelegateMock.Expect(ctx, parent, prototype).Return(nil, errors.New("no delegate"))
+	_, err = cReq.CallByDelegate(ctx, &parent, &prototype, "TestMethod", []interface{}{})
+	require.Contains(t, err.Error(), "Can't get delegate")
+	require.Equal(t, uint64(1), mbm.SendCounter)
+}
+
 func TestContractRequester_SendRequest_RouteError(t *testing.T) {
 	ctx := inslogger.TestContext(t)
 	ref := testutils.RandomRef()
@@ -104,7 +136,7 @@ func TestContractRequester_SendRequest_RouteError(t *testing.T) {
 	pm.CurrentMock.Return(core.GenesisPulse, nil)
 
 	mbm := mockMessageBus(t, &reply.CallMethod{})
-	cReq, err := New()
+	cReq, err := New(configuration.NewContractRequester())
 	assert.NoError(t, err)
 	cReq.MessageBus = mbm
 	cReq.PulseStorage = pm
@@ -137,12 +169,96 @@ func TestContractRequester_SendRequest_RouteError(t *testing.T) {
 	require.Nil(t, result)
 }
 
+func TestContractRequester_CallMethod_Busy(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	ref := testutils.RandomRef()
+
+	pm := testutils.NewPulseStorageMock(t)
+	pm.CurrentMock.Return(&core.Pulse{PulseNumber: core.FirstPulseNumber, NextPulseNumber: core.FirstPulseNumber + 1}, nil)
+
+	sent := 0
+	mbm := testutils.NewMessageBusMock(t)
+	mbm.SendFunc = func(c context.Context, m core.Message, o *core.MessageSendOptions) (core.Reply, error) {
+		sent++
+		if sent == 1 {
+			return &reply.Busy{RetryAfter: 1}, nil
+		}
+		return &reply.RegisterRequest{}, nil
+	}
+
+	cReq, err := New(configuration.NewContractRequester())
+	require.NoError(t, err)
+	cReq.MessageBus = mbm
+	cReq.PulseStorage = pm
+
+	start := time.Now()
+	result, err := cReq.CallMethod(ctx, &message.BaseLogicMessage{}, true, &ref, "TestMethod", nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, &reply.RegisterRequest{}, result)
+	require.Equal(t, 2, sent)
+	require.True(t, time.Since(start) >= time.Second)
+
+	mbm.SendFunc = func(c context.Context, m core.Message, o *core.MessageSendOptions) (core.Reply, error) {
+		return &reply.Busy{RetryAfter: 1}, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	_, err = cReq.CallMethod(ctx, &message.BaseLogicMessage{}, true, &ref, "TestMethod", nil, nil)
+	require.Error(t, err)
+}
+
+type staticHints struct {
+	executor  *core.RecordRef
+	forgotten []core.RecordRef
+}
+
+func (h *staticHints) Executor(ctx context.Context, object core.RecordRef) *core.RecordRef {
+	return h.executor
+}
+
+func (h *staticHints) Forget(object core.RecordRef) {
+	h.forgotten = append(h.forgotten, object)
+	h.executor = nil
+}
+
+func TestContractRequester_CallMethod_RoutingHints(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	ref, executor := testutils.RandomRef(), testutils.RandomRef()
+
+	hints := &staticHints{executor: &executor}
+	ctx = core.ContextWithRoutingHints(ctx, hints)
+
+	var receivers []*core.RecordRef
+	mbm := testutils.NewMessageBusMock(t)
+	mbm.SendFunc = func(c context.Context, m core.Message, o *core.MessageSendOptions) (core.Reply, error) {
+		receivers = append(receivers, o.Safe().Receiver)
+		if len(receivers) == 2 {
+			return nil, errors.New("node is not executor")
+		}
+		return &reply.RegisterRequest{}, nil
+	}
+
+	cReq, err := New(configuration.NewContractRequester())
+	require.NoError(t, err)
+	cReq.MessageBus = mbm
+
+	_, err = cReq.CallMethod(ctx, &message.BaseLogicMessage{}, true, &ref, "TestMethod", nil, nil)
+	require.NoError(t, err)
+	_, err = cReq.CallMethod(ctx, &message.BaseLogicMessage{}, true, &ref, "TestMethod", nil, nil)
+	require.Error(t, err)
+	_, err = cReq.CallMethod(ctx, &message.BaseLogicMessage{}, true, &ref, "TestMethod", nil, nil)
+	require.NoError(t, err)
+
+	require.Equal(t, []*core.RecordRef{&executor, &executor, nil}, receivers)
+	require.Equal(t, []core.RecordRef{ref}, hints.forgotten)
+}
+
 func TestCallMethodCanceled(t *testing.T) {
 	ctx := context.Background()
 	ctx, cancelFunc := context.WithTimeout(ctx, time.Second)
 	defer cancelFunc()
 
-	cr, err := New()
+	cr, err := New(configuration.NewContractRequester())
 	require.NoError(t, err)
 
 	mc := minimock.NewController(t)
@@ -150,6 +266,9 @@ func TestCallMethodCanceled(t *testing.T) {
 
 	mb := testutils.NewMessageBusMock(mc)
 	cr.MessageBus = mb
+	am := testutils.NewArtifactManagerMock(mc)
+	am.GetResultMock.Return(nil, core.ErrNotFound)
+	cr.ArtifactManager = am
 
 	msg := &message.BaseLogicMessage{
 		Nonce: randomUint64(),
@@ -170,12 +289,44 @@ func TestCallMethodCanceled(t *testing.T) {
 	assert.Equal(t, false, ok)
 }
 
+func TestCallMethodCanceled_ResultFromLedger(t *testing.T) {
+	ctx := context.Background()
+	ctx, cancelFunc := context.WithTimeout(ctx, time.Second)
+	defer cancelFunc()
+
+	cr, err := New(configuration.NewContractRequester())
+	require.NoError(t, err)
+
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	request := testutils.RandomRef()
+	payload := []byte{1, 2, 3}
+
+	mb := testutils.NewMessageBusMock(mc)
+	mb.SendMock.Return(&reply.RegisterRequest{Request: request}, nil)
+	cr.MessageBus = mb
+	am := testutils.NewArtifactManagerMock(mc)
+	am.GetResultFunc = func(p context.Context, p1 core.RecordRef) ([]byte, error) {
+		require.Equal(t, request, p1)
+		return payload, nil
+	}
+	cr.ArtifactManager = am
+
+	ref := testutils.RandomRef()
+	res, err := cr.CallMethod(ctx, &message.BaseLogicMessage{}, false, &ref, "TestMethod", core.Arguments{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, request, res.(*reply.CallMethod).Request)
+	assert.Equal(t, payload, res.(*reply.CallMethod).Result)
+	assert.Empty(t, cr.ResultMap)
+}
+
 func TestCallMethodWaitResults(t *testing.T) {
 	ctx := context.Background()
 	ctx, cancelFunc := context.WithTimeout(ctx, time.Second*10)
 	defer cancelFunc()
 
-	cr, err := New()
+	cr, err := New(configuration.NewContractRequester())
 	require.NoError(t, err)
 
 	mc := minimock.NewController(t)
@@ -208,3 +359,50 @@ func TestCallMethodWaitResults(t *testing.T) {
 	_, err = cr.CallMethod(ctx, msg, false, &ref, method, core.Arguments{}, &prototypeRef)
 	require.NoError(t, err)
 }
+
+func TestPulseTailWait(t *testing.T) {
+	start := time.Unix(core.FirstPulseNumber, 0)
+	pulse := &core.Pulse{
+		PulseNumber:     core.FirstPulseNumber,
+		NextPulseNumber: core.FirstPulseNumber + 10,
+		PulseTimestamp:  start.Unix(),
+	}
+
+	assert.Zero(t, pulseTailWait(pulse, 20, start.Add(5*time.Second)))
+	assert.Equal(t, time.Second, pulseTailWait(pulse, 20, start.Add(9*time.Second)))
+	assert.Zero(t, pulseTailWait(pulse, 20, start.Add(11*time.Second)), "overdue pulse isn't waited for")
+	assert.Zero(t, pulseTailWait(&core.Pulse{PulseNumber: core.FirstPulseNumber}, 20, start))
+}
+
+func TestSendRequest_PulseTail(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	cr, err := New(configuration.ContractRequester{PulseTail: 20})
+	require.NoError(t, err)
+	start := time.Unix(core.FirstPulseNumber, 0)
+	ps := testutils.NewPulseStorageMock(mc)
+	ps.CurrentMock.Return(&core.Pulse{
+		PulseNumber:     core.FirstPulseNumber,
+		NextPulseNumber: core.FirstPulseNumber + 10,
+		PulseTimestamp:  start.Unix(),
+	}, nil)
+	cr.PulseStorage = ps
+	cr.now = func() time.Time { return start.Add(10*time.Second - 50*time.Millisecond) }
+
+	began := time.Now()
+	require.NoError(t, cr.alignToPulse(core.ContextWithAPIRequest(ctx, &core.APIRequest{})))
+	require.True(t, time.Since(began) >= 50*time.Millisecond, "call in the tail of pulse is delayed")
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	ref := testutils.RandomRef()
+	_, err = cr.SendRequest(cancelled, &ref, "GetBalance", nil)
+	require.Contains(t, err.Error(), "Can't align call to pulse")
+
+	cr.cfg.PulseTail = 0
+	require.NoError(t, cr.alignToPulse(cancelled))
+	cr.cfg.PulseTail = 20
+	require.NoError(t, cr.alignToPulse(core.ContextWithAPIRequest(cancelled, &core.APIRequest{Priority: true})))
+}
@@ -0,0 +1,76 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package core
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Roles of callers which can be allowed by access control lists of prototypes.
+const (
+	// ACLRoleAPI allows calls made directly via API.
+	ACLRoleAPI = "api"
+	// ACLRoleParent allows calls made by parent of called object.
+	ACLRoleParent = "parent"
+)
+
+// PrototypeACL is an access control list of prototype methods, it is kept in memory of prototype record.
+// Methods which are missing in the list can be called by anyone.
+type PrototypeACL map[string]MethodACL
+
+// MethodACL lists roles and prototypes of callers which may invoke method.
+type MethodACL struct {
+	Roles      []string
+	Prototypes []RecordRef
+}
+
+// Allows checks that caller with provided roles and prototype may invoke method.
+func (acl PrototypeACL) Allows(method string, roles []string, callerPrototype RecordRef) bool {
+	methodACL, ok := acl[method]
+	if !ok {
+		return true
+	}
+	for _, allowed := range methodACL.Roles {
+		for _, role := range roles {
+			if role == allowed {
+				return true
+			}
+		}
+	}
+	if callerPrototype.IsEmpty() {
+		return false
+	}
+	for _, allowed := range methodACL.Prototypes {
+		if allowed == callerPrototype {
+			return true
+		}
+	}
+	return false
+}
+
+// DecodePrototypeACL decodes ACL from memory of prototype. Prototype with empty memory has no ACL.
+func DecodePrototypeACL(memory []byte) (PrototypeACL, error) {
+	if len(memory) == 0 {
+		return nil, nil
+	}
+	acl := PrototypeACL{}
+	err := Deserialize(memory, &acl)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ DecodePrototypeACL ] failed to decode ACL")
+	}
+	return acl, nil
+}
@@ -0,0 +1,60 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrototypeACL_Allows(t *testing.T) {
+	wallet := RecordRef{1}
+	member := RecordRef{2}
+	acl := PrototypeACL{
+		"Transfer":   {Roles: []string{ACLRoleParent}, Prototypes: []RecordRef{member}},
+		"GetBalance": {Roles: []string{ACLRoleAPI}},
+	}
+
+	require.True(t, acl.Allows("Accept", nil, RecordRef{}))
+	require.True(t, acl.Allows("Transfer", []string{ACLRoleParent}, wallet))
+	require.True(t, acl.Allows("Transfer", nil, member))
+	require.False(t, acl.Allows("Transfer", []string{ACLRoleAPI}, wallet))
+	require.True(t, acl.Allows("GetBalance", []string{ACLRoleAPI}, RecordRef{}))
+	require.False(t, acl.Allows("GetBalance", nil, RecordRef{}))
+
+	var empty PrototypeACL
+	require.True(t, empty.Allows("Transfer", nil, RecordRef{}))
+}
+
+func TestDecodePrototypeACL(t *testing.T) {
+	acl, err := DecodePrototypeACL(nil)
+	require.NoError(t, err)
+	require.Nil(t, acl)
+
+	expected := PrototypeACL{
+		"Transfer": {Roles: []string{ACLRoleParent}, Prototypes: []RecordRef{{1}}},
+	}
+	memory, err := Serialize(expected)
+	require.NoError(t, err)
+	acl, err = DecodePrototypeACL(memory)
+	require.NoError(t, err)
+	require.Equal(t, expected, acl)
+
+	_, err = DecodePrototypeACL([]byte{0xff, 0x01})
+	require.Error(t, err)
+}
@@ -0,0 +1,118 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package core
+
+import (
+	"bytes"
+	"context"
+	"time"
+)
+
+// APIRequest is a metadata of API request which initiated contract call chain.
+// It is passed along with logic messages so contracts can authorize calls and
+// logs can be correlated with original request.
+type APIRequest struct {
+	Member  RecordRef // Member who signed the request
+	QID     string    // Query id of the request
+	APINode RecordRef // Node which accepted the request
+	TraceID string    // Trace id of the request
+	Session string    // Token of client session related calls belong to, empty if there is no session
+	// Priority marks latency-sensitive call, it's executed and delivered ahead of regular calls
+	Priority bool
+	// Nonce is a seed or transaction nonce request is signed with, it can be registered on member only once
+	Nonce []byte
+}
+
+// APISeedSize is a size of seed API node issues to clients. Client signs request together with seed,
+// so signed request is bound to pulse and node seed was issued on.
+const APISeedSize = 32
+
+// APISeedMaxAge is a number of pulses after which seed can't be used for signed request.
+const APISeedMaxAge = 10
+
+// apiSeedNodeTagSize is a number of node reference bytes embedded in seed after pulse number.
+const apiSeedNodeTagSize = 12
+
+func apiSeedNodeTag(node RecordRef) []byte {
+	return node[PulseNumberSize : PulseNumberSize+apiSeedNodeTagSize]
+}
+
+// BindAPISeed writes pulse number and tag of API node to the head of seed. The rest of seed is left random.
+func BindAPISeed(seed []byte, pulse PulseNumber, node RecordRef) {
+	copy(seed[:PulseNumberSize], pulse.Bytes())
+	copy(seed[PulseNumberSize:PulseNumberSize+apiSeedNodeTagSize], apiSeedNodeTag(node))
+}
+
+// APISeedPulse returns pulse number seed was issued on.
+func APISeedPulse(seed []byte) PulseNumber {
+	if len(seed) != APISeedSize {
+		return 0
+	}
+	return NewPulseNumber(seed[:PulseNumberSize])
+}
+
+// APISeedIssuedBy checks that seed was issued by provided API node.
+func APISeedIssuedBy(seed []byte, node RecordRef) bool {
+	if len(seed) != APISeedSize {
+		return false
+	}
+	return bytes.Equal(seed[PulseNumberSize:PulseNumberSize+apiSeedNodeTagSize], apiSeedNodeTag(node))
+}
+
+// SignedNonceExpiry returns the last pulse request signed with nonce may be executed in. Nonce is either seed
+// issued by API node or seed of offline transaction. Delta is a number of pulse numbers between pulses.
+func SignedNonceExpiry(nonce []byte, delta PulseNumber) PulseNumber {
+	if expiry, ok := TransactionSeedExpiry(nonce); ok {
+		return expiry
+	}
+	return APISeedPulse(nonce) + APISeedMaxAge*delta
+}
+
+type apiRequestKey struct{}
+
+// APIRequestFromContext returns APIRequest from context or nil if context doesn't have it.
+func APIRequestFromContext(ctx context.Context) *APIRequest {
+	req, ok := ctx.Value(apiRequestKey{}).(*APIRequest)
+	if !ok {
+		return nil
+	}
+	return req
+}
+
+// ContextWithAPIRequest returns new context with provided APIRequest.
+func ContextWithAPIRequest(ctx context.Context, req *APIRequest) context.Context {
+	return context.WithValue(ctx, apiRequestKey{}, req)
+}
+
+// APIRequestOutcome is an archived outcome of API request. Clients which lost the reply can query it by QID.
+type APIRequestOutcome struct {
+	QID     string
+	Member  RecordRef
+	Method  string
+	Request *RecordRef // Reference of registered request, nil if request wasn't registered
+	Result  []byte     // JSON-encoded result of the call
+	Error   string
+	Time    time.Time
+}
+
+// APIRequestArchive keeps outcomes of API requests for a retention period.
+type APIRequestArchive interface {
+	// SetAPIRequestOutcome archives outcome of API request by its QID.
+	SetAPIRequestOutcome(ctx context.Context, outcome *APIRequestOutcome) error
+	// GetAPIRequestOutcome returns archived outcome of API request with provided QID or nil if there is no such outcome.
+	GetAPIRequestOutcome(ctx context.Context, qid string) (*APIRequestOutcome, error)
+}
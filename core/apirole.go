@@ -0,0 +1,56 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+// APIRole is a role of member in API authorization. Roles are ordered by privileges, a member with a role may call
+// methods which require this role or any less privileged one.
+type APIRole int
+
+const (
+	// APIRolePublic is required by methods any signed request may call.
+	APIRolePublic APIRole = iota
+	// APIRoleMember is a role of every registered member.
+	APIRoleMember
+	// APIRoleOperator is a role granted to members by root in root domain, e.g. for reading dumps of users.
+	APIRoleOperator
+	// APIRoleRoot is a role of root member.
+	APIRoleRoot
+)
+
+var apiRoleNames = []string{"public", "member", "operator", "root"}
+
+func (r APIRole) String() string {
+	if r < 0 || int(r) >= len(apiRoleNames) {
+		return fmt.Sprintf("APIRole(%d)", int(r))
+	}
+	return apiRoleNames[r]
+}
+
+// ParseAPIRole parses case-insensitive name of role.
+func ParseAPIRole(name string) (APIRole, error) {
+	for i, n := range apiRoleNames {
+		if strings.EqualFold(n, name) {
+			return APIRole(i), nil
+		}
+	}
+	return APIRolePublic, fmt.Errorf("unknown API role %q", name)
+}
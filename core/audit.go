@@ -0,0 +1,49 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package core
+
+import (
+	"context"
+	"time"
+)
+
+// AuditRecord is a signed record of admin operation made by node operator.
+type AuditRecord struct {
+	// Operation is a name of API method, e.g. "objects.Reset".
+	Operation string
+	// Target is an object, jet or contract operation is applied to.
+	Target string
+	Reason string
+	// Remote is an address request came from.
+	Remote string
+	// Error is empty if operation succeeded.
+	Error string
+	Node  RecordRef
+	Time  time.Time
+	// Signature is made by node over all other fields.
+	Signature []byte
+}
+
+// AuditLog is an append-only log of admin operations, records are never changed or removed.
+type AuditLog interface {
+	// AppendAuditRecord signs and appends record to the log.
+	AppendAuditRecord(ctx context.Context, record *AuditRecord) error
+	// GetAuditRecords returns up to limit most recent records, newest first.
+	GetAuditRecords(ctx context.Context, limit int) ([]AuditRecord, error)
+	// VerifyAuditRecord checks that record is signed by the node.
+	VerifyAuditRecord(record *AuditRecord) bool
+}
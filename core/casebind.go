@@ -0,0 +1,71 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package core
+
+import (
+	"context"
+)
+
+// CaseBindFormatVersion is version of portable case bind format, it's increased on incompatible changes.
+const CaseBindFormatVersion = 1
+
+// PortableCaseBind is execution registry (case bind) of object for one pulse in portable format.
+// External validators re-execute requests offline, answer outgoing calls from recorded tape
+// and compare results with ones of executor.
+//
+// Binary fields are base64 strings in JSON.
+type PortableCaseBind struct {
+	// Version is CaseBindFormatVersion of format.
+	Version int
+	// Object is reference of executed object.
+	Object RecordRef
+	// Pulse is pulse requests were executed in.
+	Pulse PulseNumber
+	// Executor is reference of node which executed requests.
+	Executor RecordRef
+	// Requests are finished requests in order of execution.
+	Requests []PortableCaseRequest
+}
+
+// PortableCaseRequest is request executed on object with its result and outgoing calls.
+type PortableCaseRequest struct {
+	// Request is reference of request registered on ledger.
+	Request RecordRef
+	// MessageType is type of request message, e.g. "TypeCallMethod".
+	MessageType string
+	// Message is request message in message bus envelope format.
+	Message []byte
+	// Reply is result of execution in reply envelope format (version, type, gob payload), empty on error.
+	Reply []byte
+	// Error is error of execution, empty on success.
+	Error string
+	// Validate is set if request was chosen for validation by sampling or by contract.
+	Validate bool
+	// PulseNumber and Entropy are of pulse request was registered in, contract gets them instead of current pulse.
+	PulseNumber PulseNumber
+	Entropy     []byte
+	// Tape is outgoing calls of execution with their replies in message bus tape format (CBOR pulse number
+	// followed by array of message hash, reply and error), empty if calls were not recorded.
+	Tape []byte
+}
+
+// CaseBindExporter exports case binds of objects executed by node in recent pulses.
+type CaseBindExporter interface {
+	// ExportCaseBind returns case bind of object for pulse, nil if node didn't execute object in pulse
+	// or case bind is already forgotten.
+	ExportCaseBind(ctx context.Context, object RecordRef, pulse PulseNumber) (*PortableCaseBind, error)
+}
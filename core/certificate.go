@@ -17,7 +17,9 @@
 package core
 
 import (
+	"context"
 	"crypto"
+	"time"
 )
 
 type NodeMeta interface {
@@ -34,6 +36,33 @@ type Certificate interface {
 	GetDiscoveryNodes() []DiscoveryNode
 }
 
+// FailureDomainsProvider provides failure domains (zones) of nodes, nodes of one domain may fail together
+type FailureDomainsProvider interface {
+	GetFailureDomains() map[RecordRef]string
+}
+
+// PulseDurationBoundsProvider provides limits of pulse duration set by network operators,
+// pulses of other duration aren't accepted. Zero bound isn't checked.
+type PulseDurationBoundsProvider interface {
+	GetPulseDurationBounds() (min, max time.Duration)
+}
+
+// CertificateExpirationProvider provides time after which certificate should be renewed.
+// Zero time means certificate never expires.
+type CertificateExpirationProvider interface {
+	GetExpiration() time.Time
+}
+
+// PulseDurationAllowed checks that duration of pulse is within bounds, pulse without duration is never allowed
+// if any bound is set.
+func PulseDurationAllowed(pulse *Pulse, min, max time.Duration) bool {
+	if min == 0 && max == 0 {
+		return true
+	}
+	d := pulse.Duration()
+	return d > 0 && d >= min && (max == 0 || d <= max)
+}
+
 //go:generate minimock -i github.com/insolar/insolar/core.DiscoveryNode -o ../testutils -s _mock.go
 type DiscoveryNode interface {
 	NodeMeta
@@ -57,3 +86,9 @@ type CertificateManager interface {
 	VerifyAuthorizationCertificate(authCert AuthorizationCertificate) (bool, error)
 	NewUnsignedCertificate(pKey string, role string, nodeRef string) (Certificate, error)
 }
+
+// IdentityReloader reloads node certificate and keys from disk after renewal, so node doesn't need restart.
+type IdentityReloader interface {
+	// ReloadIdentity validates new certificate and keys and switches node to them. Current identity is kept on error.
+	ReloadIdentity(ctx context.Context) (Certificate, error)
+}
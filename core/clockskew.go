@@ -0,0 +1,32 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package core
+
+import (
+	"context"
+	"time"
+)
+
+// ClockSkewMonitor watches difference between local clock and network time.
+type ClockSkewMonitor interface {
+	// ObservePulse takes into account pulse received at local time receivedAt.
+	ObservePulse(ctx context.Context, pulse Pulse, receivedAt time.Time)
+	// ObserveNeighborTime takes into account time reported by neighbor node and received at local time receivedAt.
+	ObserveNeighborTime(ctx context.Context, node RecordRef, reported time.Time, receivedAt time.Time)
+	// RefuseExecutorRole returns true if node must not take executor roles because of clock skew.
+	RefuseExecutorRole() bool
+}
@@ -0,0 +1,61 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package core
+
+import (
+	"github.com/pkg/errors"
+)
+
+// CodecType is a type of serialization codec of contract arguments and results.
+type CodecType uint8
+
+// Codecs of contract arguments and results.
+const (
+	// CodecCBOR is native codec of contracts.
+	CodecCBOR CodecType = iota
+	// CodecJSON is a list of values encoded as JSON array.
+	CodecJSON
+	// CodecProtobuf is a list of values encoded as google.protobuf.ListValue.
+	CodecProtobuf
+)
+
+var codecNames = map[CodecType]string{
+	CodecCBOR:     "cbor",
+	CodecJSON:     "json",
+	CodecProtobuf: "protobuf",
+}
+
+// String returns name of codec.
+func (t CodecType) String() string {
+	if name, ok := codecNames[t]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// ParseCodecType returns codec by its name. Empty name means CBOR.
+func ParseCodecType(name string) (CodecType, error) {
+	if name == "" {
+		return CodecCBOR, nil
+	}
+	for t, n := range codecNames {
+		if n == name {
+			return t, nil
+		}
+	}
+	return 0, errors.Errorf("unknown codec %s", name)
+}
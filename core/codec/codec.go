@@ -0,0 +1,119 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+// Package codec is a registry of codecs of contract arguments and results.
+//
+// Contracts always work with CBOR. Arguments of calls made with other codecs are transcoded to CBOR
+// before execution and results are transcoded back, so clients can use codecs native to their platform.
+package codec
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/pkg/errors"
+	ucodec "github.com/ugorji/go/codec"
+
+	"github.com/insolar/insolar/core"
+)
+
+// Codec encodes and decodes list of values without schema.
+type Codec interface {
+	Encode(values []interface{}) ([]byte, error)
+	Decode(data []byte) ([]interface{}, error)
+}
+
+var (
+	lock     sync.RWMutex
+	registry = map[core.CodecType]Codec{
+		core.CodecCBOR:     handleCodec{handle: cborHandle()},
+		core.CodecJSON:     handleCodec{handle: jsonHandle()},
+		core.CodecProtobuf: protobufCodec{},
+	}
+)
+
+// Register adds codec to registry, codec of the same type is replaced.
+func Register(t core.CodecType, c Codec) {
+	lock.Lock()
+	defer lock.Unlock()
+	registry[t] = c
+}
+
+// Get returns codec of provided type.
+func Get(t core.CodecType) (Codec, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+	c, ok := registry[t]
+	if !ok {
+		return nil, errors.Errorf("codec %s isn't registered", t)
+	}
+	return c, nil
+}
+
+// Transcode converts list of values encoded with one codec to another.
+func Transcode(data []byte, from, to core.CodecType) ([]byte, error) {
+	if from == to {
+		return data, nil
+	}
+	decoder, err := Get(from)
+	if err != nil {
+		return nil, err
+	}
+	encoder, err := Get(to)
+	if err != nil {
+		return nil, err
+	}
+	values, err := decoder.Decode(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode %s", from)
+	}
+	res, err := encoder.Encode(values)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to encode %s", to)
+	}
+	return res, nil
+}
+
+var mapType = reflect.TypeOf(map[string]interface{}(nil))
+
+func cborHandle() *ucodec.CborHandle {
+	h := &ucodec.CborHandle{}
+	h.MapType = mapType
+	return h
+}
+
+func jsonHandle() *ucodec.JsonHandle {
+	h := &ucodec.JsonHandle{}
+	h.MapType = mapType
+	return h
+}
+
+// handleCodec is a codec backed by ugorji handle.
+type handleCodec struct {
+	handle ucodec.Handle
+}
+
+func (c handleCodec) Encode(values []interface{}) ([]byte, error) {
+	var data []byte
+	err := ucodec.NewEncoderBytes(&data, c.handle).Encode(values)
+	return data, err
+}
+
+func (c handleCodec) Decode(data []byte) ([]interface{}, error) {
+	var values []interface{}
+	err := ucodec.NewDecoderBytes(data, c.handle).Decode(&values)
+	return values, err
+}
@@ -0,0 +1,85 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package codec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+)
+
+func TestTranscode_JSONToCBOR(t *testing.T) {
+	data, err := Transcode([]byte(`["test",[1,2],{"a":true}]`), core.CodecJSON, core.CodecCBOR)
+	require.NoError(t, err)
+
+	var (
+		s string
+		l []int
+		m map[string]bool
+	)
+	err = core.Deserialize(data, []interface{}{&s, &l, &m})
+	require.NoError(t, err)
+	require.Equal(t, "test", s)
+	require.Equal(t, []int{1, 2}, l)
+	require.Equal(t, map[string]bool{"a": true}, m)
+}
+
+func TestTranscode_CBORToJSON(t *testing.T) {
+	data, err := core.MarshalArgs("test", 42)
+	require.NoError(t, err)
+
+	res, err := Transcode(data, core.CodecCBOR, core.CodecJSON)
+	require.NoError(t, err)
+	require.Equal(t, `["test",42]`, string(res))
+}
+
+func TestTranscode_Protobuf(t *testing.T) {
+	data, err := core.MarshalArgs("test", 42, -1, 0.5, nil, []interface{}{true}, map[string]interface{}{"a": "b"})
+	require.NoError(t, err)
+
+	pb, err := Transcode(data, core.CodecCBOR, core.CodecProtobuf)
+	require.NoError(t, err)
+
+	c, err := Get(core.CodecProtobuf)
+	require.NoError(t, err)
+	values, err := c.Decode(pb)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{
+		"test", uint64(42), int64(-1), 0.5, nil, []interface{}{true}, map[string]interface{}{"a": "b"},
+	}, values)
+
+	back, err := Transcode(pb, core.CodecProtobuf, core.CodecCBOR)
+	require.NoError(t, err)
+	require.Equal(t, []byte(data), back)
+}
+
+func TestTranscode_SameCodec(t *testing.T) {
+	data := []byte("not encoded")
+	res, err := Transcode(data, core.CodecJSON, core.CodecJSON)
+	require.NoError(t, err)
+	require.Equal(t, data, res)
+}
+
+func TestGet_UnknownCodec(t *testing.T) {
+	_, err := Get(core.CodecType(100))
+	require.Error(t, err)
+
+	_, err = Transcode(nil, core.CodecType(100), core.CodecCBOR)
+	require.Error(t, err)
+}
@@ -0,0 +1,143 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package codec
+
+import (
+	"encoding/base64"
+	"math"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/pkg/errors"
+)
+
+// protobufCodec encodes list of values as google.protobuf.ListValue. Bytes are encoded as base64 strings,
+// integral numbers are decoded as integers.
+type protobufCodec struct{}
+
+func (protobufCodec) Encode(values []interface{}) ([]byte, error) {
+	list, err := toListValue(values)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(list)
+}
+
+func (protobufCodec) Decode(data []byte) ([]interface{}, error) {
+	list := &structpb.ListValue{}
+	if err := proto.Unmarshal(data, list); err != nil {
+		return nil, err
+	}
+	return fromListValue(list), nil
+}
+
+func toListValue(values []interface{}) (*structpb.ListValue, error) {
+	list := &structpb.ListValue{Values: make([]*structpb.Value, 0, len(values))}
+	for _, v := range values {
+		pv, err := toValue(v)
+		if err != nil {
+			return nil, err
+		}
+		list.Values = append(list.Values, pv)
+	}
+	return list, nil
+}
+
+func toValue(v interface{}) (*structpb.Value, error) {
+	switch v := v.(type) {
+	case nil:
+		return &structpb.Value{Kind: &structpb.Value_NullValue{}}, nil
+	case bool:
+		return &structpb.Value{Kind: &structpb.Value_BoolValue{BoolValue: v}}, nil
+	case string:
+		return &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: v}}, nil
+	case []byte:
+		return &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: base64.StdEncoding.EncodeToString(v)}}, nil
+	case int:
+		return &structpb.Value{Kind: &structpb.Value_NumberValue{NumberValue: float64(v)}}, nil
+	case int64:
+		return &structpb.Value{Kind: &structpb.Value_NumberValue{NumberValue: float64(v)}}, nil
+	case uint:
+		return &structpb.Value{Kind: &structpb.Value_NumberValue{NumberValue: float64(v)}}, nil
+	case uint64:
+		return &structpb.Value{Kind: &structpb.Value_NumberValue{NumberValue: float64(v)}}, nil
+	case float32:
+		return &structpb.Value{Kind: &structpb.Value_NumberValue{NumberValue: float64(v)}}, nil
+	case float64:
+		return &structpb.Value{Kind: &structpb.Value_NumberValue{NumberValue: v}}, nil
+	case []interface{}:
+		list, err := toListValue(v)
+		if err != nil {
+			return nil, err
+		}
+		return &structpb.Value{Kind: &structpb.Value_ListValue{ListValue: list}}, nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		fields := make(map[string]*structpb.Value, len(v))
+		for _, k := range keys {
+			pv, err := toValue(v[k])
+			if err != nil {
+				return nil, err
+			}
+			fields[k] = pv
+		}
+		return &structpb.Value{Kind: &structpb.Value_StructValue{StructValue: &structpb.Struct{Fields: fields}}}, nil
+	default:
+		return nil, errors.Errorf("value of type %T can't be encoded to protobuf", v)
+	}
+}
+
+func fromListValue(list *structpb.ListValue) []interface{} {
+	values := make([]interface{}, 0, len(list.Values))
+	for _, v := range list.Values {
+		values = append(values, fromValue(v))
+	}
+	return values
+}
+
+func fromValue(v *structpb.Value) interface{} {
+	switch k := v.GetKind().(type) {
+	case *structpb.Value_BoolValue:
+		return k.BoolValue
+	case *structpb.Value_StringValue:
+		return k.StringValue
+	case *structpb.Value_NumberValue:
+		n := k.NumberValue
+		if n != math.Trunc(n) || math.IsInf(n, 0) {
+			return n
+		}
+		if n < 0 {
+			return int64(n)
+		}
+		return uint64(n)
+	case *structpb.Value_ListValue:
+		return fromListValue(k.ListValue)
+	case *structpb.Value_StructValue:
+		fields := make(map[string]interface{}, len(k.StructValue.Fields))
+		for name, f := range k.StructValue.Fields {
+			fields[name] = fromValue(f)
+		}
+		return fields
+	default:
+		return nil
+	}
+}
@@ -24,6 +24,8 @@ import (
 // ContractRequester is the global contract requester handler. Other system parts communicate with contract requester through it.
 type ContractRequester interface {
 	SendRequest(ctx context.Context, ref *RecordRef, method string, argsIn []interface{}) (Reply, error)
+	// CallByDelegate calls method of parent's delegate with provided prototype
+	CallByDelegate(ctx context.Context, parent *RecordRef, prototype *RecordRef, method string, argsIn []interface{}) (Reply, error)
 	// CallMethod - low level calls contract
 	CallMethod(ctx context.Context, base Message, async bool,
 		ref *RecordRef, method string, argsIn Arguments,
@@ -31,3 +33,24 @@ type ContractRequester interface {
 	CallConstructor(ctx context.Context, base Message, async bool,
 		prototype *RecordRef, to *RecordRef, method string, argsIn Arguments, saveType int) (*RecordRef, error)
 }
+
+// RoutingHints provides nodes to send object's messages to directly, without routing them by role on each send.
+type RoutingHints interface {
+	// Executor returns virtual executor node of object. Nil means the message should be routed by role.
+	Executor(ctx context.Context, object RecordRef) *RecordRef
+	// Forget drops remembered executor of object, e.g. when hinted node failed to accept a message.
+	Forget(object RecordRef)
+}
+
+type routingHintsKey struct{}
+
+// RoutingHintsFromContext returns RoutingHints from context or nil if context doesn't provide them.
+func RoutingHintsFromContext(ctx context.Context) RoutingHints {
+	hints, _ := ctx.Value(routingHintsKey{}).(RoutingHints)
+	return hints
+}
+
+// ContextWithRoutingHints returns new context with provided routing hints.
+func ContextWithRoutingHints(ctx context.Context, hints RoutingHints) context.Context {
+	return context.WithValue(ctx, routingHintsKey{}, hints)
+}
@@ -0,0 +1,49 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package core
+
+import (
+	"context"
+	"time"
+)
+
+// ValidatorVerdict is a result of validation reported by one validator node.
+type ValidatorVerdict struct {
+	Node  RecordRef
+	Steps int // Count of requests validator passed with the same results as executor
+	Error string
+}
+
+// ValidationDispute is an archived disagreement between executor and validators of an object.
+type ValidationDispute struct {
+	Object             RecordRef
+	Pulse              PulseNumber
+	Executor           RecordRef
+	ExecutorResultHash []byte
+	Requests           int // Count of requests executor reported
+	Verdicts           []ValidatorVerdict
+	Approved           bool // Whether validation consensus approved executor results
+	Time               time.Time
+}
+
+// DisputeArchive keeps validation disputes for later investigation.
+type DisputeArchive interface {
+	// SetValidationDispute archives validation dispute.
+	SetValidationDispute(ctx context.Context, dispute *ValidationDispute) error
+	// GetValidationDisputes returns up to limit most recent disputes, newest first.
+	GetValidationDisputes(ctx context.Context, limit int) ([]ValidationDispute, error)
+}
@@ -33,6 +33,145 @@ var (
 	ErrNotFound = errors.New("Not found")
 	// ErrTooManyPendingRequests is returned when a limit of pending requests has been reached on a current LME
 	ErrTooManyPendingRequests = errors.New("the limit of pending requests count has been reached")
+	// ErrWriteQuotaExceeded is returned when caller has written more bytes than allowed within current pulse
+	ErrWriteQuotaExceeded = errors.New("write quota of the caller has been exceeded in current pulse")
 	// ErrNoNodes is returned if no matching nodes found
 	ErrNoNodes = errors.New("no matching nodes")
+	// ErrRequestOutOfOrder is returned when request arrives to executor after requests numbered next to it were executed
+	ErrRequestOutOfOrder = errors.New("request is out of order")
+	// ErrRequestsMissing is reported when requests registered for object never reached its executor
+	ErrRequestsMissing = errors.New("requests registered for object are missing")
+	// ErrNonceUsed is returned when signed request with the same nonce is already registered for object
+	ErrNonceUsed = errors.New("signed request with this nonce is already registered")
 )
+
+// ErrInvalidStateRecord is returned when object state record can't be used to activate or amend the object.
+var ErrInvalidStateRecord = errors.New("invalid state record")
+
+// ErrorCode identifies class of error independently of its text, so it may be checked on the other side of network.
+type ErrorCode uint32
+
+// Error codes of errors propagated between nodes.
+const (
+	// ErrCodeUnknown is a code of errors which can't be classified.
+	ErrCodeUnknown ErrorCode = iota
+	ErrCodeDeactivated
+	ErrCodeStateNotAvailable
+	ErrCodeHotDataTimeout
+	ErrCodeNoPendingRequest
+	ErrCodeNotFound
+	ErrCodeTooManyPendingRequests
+	ErrCodeWriteQuotaExceeded
+	ErrCodeNoNodes
+	ErrCodeRequestOutOfOrder
+	ErrCodeRequestsMissing
+	ErrCodeInvalidStateRecord
+	// ErrCodeNotAuthorized is a code of errors returned by node which isn't responsible for request in current pulse.
+	ErrCodeNotAuthorized
+	ErrCodeNonceUsed
+)
+
+var errorCodes = map[error]ErrorCode{
+	ErrDeactivated:            ErrCodeDeactivated,
+	ErrStateNotAvailable:      ErrCodeStateNotAvailable,
+	ErrHotDataTimeout:         ErrCodeHotDataTimeout,
+	ErrNoPendingRequest:       ErrCodeNoPendingRequest,
+	ErrNotFound:               ErrCodeNotFound,
+	ErrTooManyPendingRequests: ErrCodeTooManyPendingRequests,
+	ErrWriteQuotaExceeded:     ErrCodeWriteQuotaExceeded,
+	ErrNoNodes:                ErrCodeNoNodes,
+	ErrRequestOutOfOrder:      ErrCodeRequestOutOfOrder,
+	ErrRequestsMissing:        ErrCodeRequestsMissing,
+	ErrInvalidStateRecord:     ErrCodeInvalidStateRecord,
+	ErrNonceUsed:              ErrCodeNonceUsed,
+}
+
+// errors with these codes are transient, the same request may succeed later.
+var retryableCodes = map[ErrorCode]bool{
+	ErrCodeHotDataTimeout:         true,
+	ErrCodeTooManyPendingRequests: true,
+	ErrCodeWriteQuotaExceeded:     true,
+	ErrCodeNotAuthorized:          true,
+}
+
+// CodedError is an error envelope which keeps code and origin of error when it crosses node boundaries.
+type CodedError struct {
+	Code ErrorCode
+	// Component is a name of component (e.g. handled message type) which returned the error.
+	Component string
+	Retryable bool
+	Message   string
+}
+
+// NewCodedError creates error with provided code, retryable flag is derived from the code.
+func NewCodedError(code ErrorCode, component string, message string) *CodedError {
+	return &CodedError{
+		Code:      code,
+		Component: component,
+		Retryable: retryableCodes[code],
+		Message:   message,
+	}
+}
+
+// Error implements error interface, it returns original message of error.
+func (e *CodedError) Error() string {
+	return e.Message
+}
+
+// codedCause walks causes of err and returns first coded error or known sentinel error.
+func codedCause(err error) (*CodedError, ErrorCode) {
+	type causer interface {
+		Cause() error
+	}
+	for err != nil {
+		if coded, ok := err.(*CodedError); ok {
+			return coded, coded.Code
+		}
+		if code, ok := errorCodes[err]; ok {
+			return nil, code
+		}
+		cause, ok := err.(causer)
+		if !ok {
+			break
+		}
+		err = cause.Cause()
+	}
+	return nil, ErrCodeUnknown
+}
+
+// ErrorCodeOf returns code of error, wrapped errors are unwrapped. ErrCodeUnknown is returned for unclassified errors.
+func ErrorCodeOf(err error) ErrorCode {
+	_, code := codedCause(err)
+	return code
+}
+
+// IsRetryable reports whether failed request may succeed if it is retried later.
+func IsRetryable(err error) bool {
+	coded, code := codedCause(err)
+	if coded != nil {
+		return coded.Retryable
+	}
+	return retryableCodes[code]
+}
+
+// ToCodedError converts err into envelope which can be sent to other node. Message of err is preserved as is.
+func ToCodedError(err error, component string) *CodedError {
+	if err == nil {
+		return nil
+	}
+	coded, code := codedCause(err)
+	if coded != nil {
+		return &CodedError{
+			Code:      coded.Code,
+			Component: coded.Component,
+			Retryable: coded.Retryable,
+			Message:   err.Error(),
+		}
+	}
+	return &CodedError{
+		Code:      code,
+		Component: component,
+		Retryable: retryableCodes[code],
+		Message:   err.Error(),
+	}
+}
@@ -0,0 +1,62 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package core
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorCodeOf(t *testing.T) {
+	require.Equal(t, ErrCodeUnknown, ErrorCodeOf(nil))
+	require.Equal(t, ErrCodeUnknown, ErrorCodeOf(errors.New("some error")))
+	require.Equal(t, ErrCodeNotFound, ErrorCodeOf(ErrNotFound))
+	require.Equal(t, ErrCodeInvalidStateRecord, ErrorCodeOf(errors.Wrap(ErrInvalidStateRecord, "can't update")))
+
+	coded := NewCodedError(ErrCodeNotAuthorized, "LogicRunner", "can't execute this object")
+	require.Equal(t, ErrCodeNotAuthorized, ErrorCodeOf(errors.Wrap(coded, "can't play role")))
+}
+
+func TestIsRetryable(t *testing.T) {
+	require.False(t, IsRetryable(errors.New("some error")))
+	require.False(t, IsRetryable(ErrDeactivated))
+	require.True(t, IsRetryable(errors.Wrap(ErrTooManyPendingRequests, "can't register")))
+	require.True(t, IsRetryable(NewCodedError(ErrCodeNotAuthorized, "LogicRunner", "not executor")))
+	require.False(t, IsRetryable(&CodedError{Code: ErrCodeNotAuthorized, Retryable: false}))
+}
+
+func TestToCodedError(t *testing.T) {
+	require.Nil(t, ToCodedError(nil, "test"))
+
+	err := ToCodedError(errors.Wrap(ErrHotDataTimeout, "[ handler ]"), "GetObject")
+	require.Equal(t, &CodedError{
+		Code:      ErrCodeHotDataTimeout,
+		Component: "GetObject",
+		Retryable: true,
+		Message:   "[ handler ]: requests were abandoned due to hot-data timeout",
+	}, err)
+
+	// component which has coded the error originally is kept
+	inner := NewCodedError(ErrCodeNotAuthorized, "LogicRunner", "can't execute this object")
+	err = ToCodedError(errors.Wrap(inner, "[ Execute ] can't play role"), "CallMethod")
+	require.Equal(t, ErrCodeNotAuthorized, err.Code)
+	require.Equal(t, "LogicRunner", err.Component)
+	require.True(t, err.Retryable)
+	require.Equal(t, "[ Execute ] can't play role: can't execute this object", err.Error())
+}
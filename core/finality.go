@@ -0,0 +1,55 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package core
+
+import (
+	"context"
+)
+
+// Finality shows how far result of request has progressed towards being irreversible.
+type Finality uint8
+
+const (
+	// FinalityUnknown is a finality of request which result is unknown to the node.
+	FinalityUnknown Finality = iota
+	// FinalityExecuted means result is computed by executor, but may still be rejected by validators.
+	FinalityExecuted
+	// FinalityValidated means result is approved by validators.
+	FinalityValidated
+	// FinalityReplicated means request is stored on heavy material node and is irreversible.
+	FinalityReplicated
+)
+
+// String returns name of finality used in API.
+func (f Finality) String() string {
+	switch f {
+	case FinalityExecuted:
+		return "executed"
+	case FinalityValidated:
+		return "validated"
+	case FinalityReplicated:
+		return "replicated"
+	default:
+		return "unknown"
+	}
+}
+
+// FinalityChecker checks current finality of executed requests.
+type FinalityChecker interface {
+	// GetFinality returns finality of request to object.
+	GetFinality(ctx context.Context, object RecordRef, request RecordRef) (Finality, error)
+}
@@ -0,0 +1,55 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package core
+
+import (
+	"context"
+)
+
+// ReplayedObject is a state of object reconstructed from its ledger records and compared with live state.
+type ReplayedObject struct {
+	Object RecordRef
+	Parent RecordRef
+	// States is a number of state records from activation to the latest state.
+	States      int
+	LatestState RecordID
+	Deactivated bool
+	Children    int
+	// Mismatches describe differences of reconstructed state from ledger records and live state, empty if they match.
+	Mismatches []string
+}
+
+// GenesisReplayReport is a result of reconstruction of system objects starting from root domain.
+type GenesisReplayReport struct {
+	Objects []ReplayedObject
+}
+
+// Consistent returns true if reconstructed states of all objects match live states.
+func (r *GenesisReplayReport) Consistent() bool {
+	for _, obj := range r.Objects {
+		if len(obj.Mismatches) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// GenesisReplayer reconstructs states of system objects (root domain, members, node domain and nodes) purely from
+// ledger records starting from pulse zero, so ledger can be checked after migrations and caches can be recovered.
+type GenesisReplayer interface {
+	// ReplayGenesis reconstructs root domain and its descendants up to depth levels below it.
+	ReplayGenesis(ctx context.Context, depth int) (*GenesisReplayReport, error)
+}
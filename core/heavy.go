@@ -18,13 +18,41 @@ package core
 
 import (
 	"context"
+	"time"
 )
 
 // HeavySync provides methods for sync on heavy node.
 //go:generate minimock -i github.com/insolar/insolar/core.HeavySync -o ../testutils -s _mock.go
 type HeavySync interface {
 	Start(ctx context.Context, jet RecordID, pn PulseNumber) error
+	// Resume continues interrupted sync of pulse or starts it, returns number of payloads already stored for pulse.
+	Resume(ctx context.Context, jet RecordID, pn PulseNumber) (int, error)
 	Store(ctx context.Context, jet RecordID, pn PulseNumber, kvs []KV) error
-	Stop(ctx context.Context, jet RecordID, pn PulseNumber) error
+	Stop(ctx context.Context, jet RecordID, pn PulseNumber, node RecordRef) error
 	Reset(ctx context.Context, jet RecordID, pn PulseNumber) error
+	// Progress returns replication progress of jets synced to heavy node.
+	Progress(ctx context.Context) []HeavySyncProgress
+	// Congestion returns load of heavy node light nodes should adapt replication to.
+	Congestion(ctx context.Context) HeavyCongestion
+}
+
+// HeavyCongestion is load of heavy node reported to light material nodes in replication acks.
+type HeavyCongestion struct {
+	// QueueDepth is number of payloads being stored by heavy node at the moment.
+	QueueDepth int
+	// Delay is pause light node should make before sending the next payload, zero if heavy isn't congested.
+	Delay time.Duration
+}
+
+// HeavySyncProgress is replication progress of jet from light material nodes to heavy node.
+type HeavySyncProgress struct {
+	Jet RecordID
+	// Synced is last pulse of jet confirmed by heavy node.
+	Synced PulseNumber
+	// InSync is pulse being synced, zero if there is none.
+	InSync PulseNumber
+	// Stored is number of payloads of pulse being synced already stored.
+	Stored int
+	// Nodes are last pulses of jet confirmed per light material node.
+	Nodes map[RecordRef]PulseNumber
 }
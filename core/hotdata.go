@@ -0,0 +1,40 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package core
+
+import (
+	"context"
+)
+
+// HotDataMigration describes hot data of jet copied to other light material node.
+type HotDataMigration struct {
+	Jet    RecordID
+	Target RecordRef
+	// Objects is a number of migrated object indexes.
+	Objects int
+	// Requests is a number of migrated pending requests.
+	Requests int
+	// Checksum is a checksum of migrated data confirmed by target node.
+	Checksum []byte
+}
+
+// HotDataMigrator moves hot data of jets between light material nodes ahead of planned topology change,
+// so new executor of jet doesn't wait for hot data on pulse change.
+type HotDataMigrator interface {
+	// MigrateHotData copies indexes and pending requests of jet to target node and verifies by checksums
+	// target stored them completely.
+	MigrateHotData(ctx context.Context, jet RecordID, target RecordRef) (*HotDataMigration, error)
+}
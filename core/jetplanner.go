@@ -0,0 +1,59 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package core
+
+// JetLoad is an average size of jet drops over recent pulses.
+type JetLoad struct {
+	Jet         RecordID
+	AvgDropSize uint64
+	Drops       int
+}
+
+// JetMerge is a pair of sibling jets recommended to merge into parent jet.
+type JetMerge struct {
+	Parent RecordID
+	Left   JetLoad
+	Right  JetLoad
+}
+
+// JetRebalancePlan is a recommended change of jet tree based on load of jets.
+type JetRebalancePlan struct {
+	// Pulse is a pulse plan was made on.
+	Pulse PulseNumber
+	// Splits are jets recommended to split.
+	Splits []JetLoad
+	// Merges are sibling jets recommended to merge. Merges aren't executed, jet tree doesn't support merging yet.
+	Merges []JetMerge
+	// Executed is set if splits are performed by pulse manager.
+	Executed bool
+}
+
+// IsSplit checks if jet is recommended to split by plan.
+func (p *JetRebalancePlan) IsSplit(jet RecordID) bool {
+	for _, load := range p.Splits {
+		if load.Jet == jet {
+			return true
+		}
+	}
+	return false
+}
+
+// JetPlanner plans rebalancing of jet tree by load of jets.
+type JetPlanner interface {
+	// LastPlan returns plan made on last pulse, nil if node didn't plan yet.
+	LastPlan() *JetRebalancePlan
+}
@@ -21,3 +21,12 @@ import "crypto"
 type KeyStore interface {
 	GetPrivateKey(string) (crypto.PrivateKey, error)
 }
+
+// ReloadableKeyStore is implemented by key stores which can re-read keys from their storage while node is running.
+type ReloadableKeyStore interface {
+	KeyStore
+	// ReadPrivateKey reads private key from storage without replacing the one in use.
+	ReadPrivateKey(string) (crypto.PrivateKey, error)
+	// SetPrivateKey replaces private key in use.
+	SetPrivateKey(string, crypto.PrivateKey)
+}
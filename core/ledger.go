@@ -115,6 +115,25 @@ type JetCoordinator interface {
 	NodeForObject(ctx context.Context, objectID RecordID, rootPN, targetPN PulseNumber) (*RecordRef, error)
 }
 
+// GlobuleRoleQuerier calculates dynamic roles of nodes in specific globule.
+type GlobuleRoleQuerier interface {
+	// QueryRoleInGlobule returns node refs of provided globule responsible for role bound operations for given object and pulse.
+	QueryRoleInGlobule(ctx context.Context, globule GlobuleID, role DynamicRole, obj RecordID, pulse PulseNumber) ([]RecordRef, error)
+}
+
+type objectProofsKey struct{}
+
+// ContextWithObjectProofs returns new context which makes ArtifactManager request proofs of fetched object states.
+func ContextWithObjectProofs(ctx context.Context) context.Context {
+	return context.WithValue(ctx, objectProofsKey{}, true)
+}
+
+// ObjectProofsRequested checks if proofs of fetched object states are requested for context.
+func ObjectProofsRequested(ctx context.Context) bool {
+	requested, _ := ctx.Value(objectProofsKey{}).(bool)
+	return requested
+}
+
 // ArtifactManager is a high level storage interface.
 //go:generate minimock -i github.com/insolar/insolar/core.ArtifactManager -o ../testutils -s _mock.go
 type ArtifactManager interface {
@@ -124,7 +143,15 @@ type ArtifactManager interface {
 	GenesisRef() *RecordRef
 
 	// RegisterRequest creates request record in storage.
-	RegisterRequest(ctx context.Context, object RecordRef, parcel Parcel) (*RecordID, error)
+	//
+	// Returns sequence number of the request within object assigned on registration.
+	RegisterRequest(ctx context.Context, object RecordRef, parcel Parcel) (*RecordID, uint64, error)
+
+	// RegisterTimer creates timer record for call object scheduled on itself.
+	//
+	// Executor of provided pulse is notified about the timer and makes the call. Timer is closed by result
+	// registered for the timer.
+	RegisterTimer(ctx context.Context, object RecordRef, pulse PulseNumber, msg Message) (*RecordID, error)
 
 	// RegisterValidation marks provided object state as approved or disapproved.
 	//
@@ -134,6 +161,12 @@ type ArtifactManager interface {
 	// RegisterResult saves VM method call result.
 	RegisterResult(ctx context.Context, object, request RecordRef, payload []byte) (*RecordID, error)
 
+	// GetResult returns payload of result registered for provided request.
+	//
+	// Results are indexed by request, so lookup doesn't scan records. If result is not registered, ErrNotFound will
+	// be returned.
+	GetResult(ctx context.Context, request RecordRef) ([]byte, error)
+
 	// GetCode returns code from code record by provided reference according to provided machine preference.
 	//
 	// This method is used by VM to fetch code for execution.
@@ -145,8 +178,14 @@ type ArtifactManager interface {
 	// provide methods for fetching all related data.
 	GetObject(ctx context.Context, head RecordRef, state *RecordID, approved bool) (ObjectDescriptor, error)
 
-	// GetPendingRequest returns a pending request for object.
-	GetPendingRequest(ctx context.Context, objectID RecordID) (Parcel, error)
+	// GetObjects returns descriptors of the latest states of objects and codes fetched in one round trip.
+	//
+	// Batch is served by executor of the first object, so objects and codes stored elsewhere are missing in
+	// returned maps and should be fetched one by one.
+	GetObjects(ctx context.Context, heads []RecordRef, codes []RecordRef) (map[RecordRef]ObjectDescriptor, map[RecordRef]CodeDescriptor, error)
+
+	// GetPendingRequest returns a pending request for object and its sequence number within object.
+	GetPendingRequest(ctx context.Context, objectID RecordID) (Parcel, uint64, error)
 
 	// HasPendingRequests returns true if object has unclosed requests.
 	HasPendingRequests(ctx context.Context, object RecordRef) (bool, error)
@@ -169,8 +208,11 @@ type ArtifactManager interface {
 
 	// DeployCode creates new code record in storage.
 	//
-	// Code records are used to activate prototype.
-	DeployCode(ctx context.Context, domain, request RecordRef, code []byte, machineType MachineType) (*RecordID, error)
+	// Code records are used to activate prototype. Schema is a JSON ContractSchema of contract methods, it's stored
+	// alongside the code and may be nil.
+	DeployCode(
+		ctx context.Context, domain, request RecordRef, code []byte, machineType MachineType, schema []byte,
+	) (*RecordID, error)
 
 	// ActivatePrototype creates activate object record in storage. Provided prototype reference will be used as objects prototype
 	// memory as memory of created object. If memory is not provided, the prototype default memory will be used.
@@ -237,6 +279,9 @@ type CodeDescriptor interface {
 
 	// Code returns code data.
 	Code() ([]byte, error)
+
+	// Schema returns JSON ContractSchema of contract methods, nil if code was deployed without schema.
+	Schema() []byte
 }
 
 // ObjectDescriptor represents meta info required to fetch all object data.
@@ -316,6 +361,53 @@ type StorageExporter interface {
 	Export(ctx context.Context, fromPulse PulseNumber, size int) (*StorageExportResult, error)
 }
 
+// QueriedRecord is a stored record matched by ledger query.
+type QueriedRecord struct {
+	ID  RecordID
+	Jet RecordID
+	// Type is a name of record type, e.g. "RequestRecord".
+	Type string
+	// Object is an object record belongs to, nil if record itself doesn't tell it.
+	Object *RecordID
+	Data   interface{}
+}
+
+// LedgerQuerier answers operational questions about records stored on heavy node with simple filter queries.
+type LedgerQuerier interface {
+	// QueryRecords calls fn for every record matching query in order of pulses, error of fn stops iteration.
+	QueryRecords(ctx context.Context, query string, fn func(QueriedRecord) error) error
+}
+
+// LedgerArchive fetches requests and their results regardless of age. Records within retention of light material
+// nodes are fetched from them, older ones are fetched from heavy material node.
+type LedgerArchive interface {
+	// GetRequest returns parcel of registered request and its sequence number within object. ErrNotFound is
+	// returned if request isn't registered.
+	GetRequest(ctx context.Context, request RecordRef) (Parcel, uint64, error)
+	// GetResult returns payload of result registered for request. ErrNotFound is returned if there's no result.
+	GetResult(ctx context.Context, request RecordRef) ([]byte, error)
+}
+
+// StoredPulse is a pulse from storage with links to neighbour stored pulses.
+type StoredPulse struct {
+	Pulse Pulse
+	Prev  *PulseNumber
+	Next  *PulseNumber
+}
+
+// PulseHistoryResult is a page of stored pulses.
+type PulseHistoryResult struct {
+	Pulses []StoredPulse
+	// NextFrom is a first pulse of the next page, nil if there are no more pulses in requested range
+	NextFrom *PulseNumber
+}
+
+// PulseHistory provides methods for fetching stored pulses.
+type PulseHistory interface {
+	// GetPulses returns at most size stored pulses in range [fromPulse, toPulse], zero toPulse means no upper bound.
+	GetPulses(ctx context.Context, fromPulse, toPulse PulseNumber, size int) (*PulseHistoryResult, error)
+}
+
 var (
 	// TODOJetID temporary stub for passing jet ID in ledger functions
 	// on period Jet ID full implementation
@@ -0,0 +1,38 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package core
+
+import (
+	"context"
+)
+
+// WorkPriority is a priority of work done by node, low priority work is shed first when node is overloaded.
+type WorkPriority uint8
+
+const (
+	// PriorityCritical is a priority of consensus and executor duties, such work is never shed.
+	PriorityCritical WorkPriority = iota
+	// PriorityLow is a priority of read-only API calls and explorer queries.
+	PriorityLow
+)
+
+// LoadShedder protects duties of node under CPU and queue pressure by rejecting low priority work.
+type LoadShedder interface {
+	// Admit reports whether work of kind (e.g. API method) may be done now. Low priority work may be delayed
+	// waiting for pressure to drop, false means it must be rejected.
+	Admit(ctx context.Context, priority WorkPriority, kind string) bool
+}
@@ -73,4 +73,6 @@ type Logger interface {
 	WithFields(map[string]interface{}) Logger
 	// WithField return copy of Logger with predefined single field.
 	WithField(string, interface{}) Logger
+	// WithLevel return copy of Logger with its own log level, other loggers are not affected.
+	WithLevel(string) (Logger, error)
 }
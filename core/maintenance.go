@@ -0,0 +1,88 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package core
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// MaintenanceNoticePulses is a minimal number of pulses between announcement of maintenance window and its start.
+	MaintenanceNoticePulses = 3
+	// MaintenanceMaxPulses is a max length of maintenance window in pulses.
+	MaintenanceMaxPulses = 10
+)
+
+// MaintenanceWindow is a range of pulses announced by discovery node during which executors are not handed over
+// and jets are not split, so operators can safely do infrastructure work.
+type MaintenanceWindow struct {
+	Issuer RecordRef
+	// Start is a first pulse of the window
+	Start PulseNumber
+	// End is a last pulse of the window
+	End  PulseNumber
+	Note string
+}
+
+// Contains checks if pulse is inside the window.
+func (w MaintenanceWindow) Contains(pulse PulseNumber) bool {
+	return pulse >= w.Start && pulse <= w.End
+}
+
+// Overlaps checks if windows have common pulses.
+func (w MaintenanceWindow) Overlaps(other MaintenanceWindow) bool {
+	return w.Start <= other.End && other.Start <= w.End
+}
+
+// Validate checks that window is announced in advance in provided pulse and isn't too long.
+func (w MaintenanceWindow) Validate(announced Pulse) error {
+	if announced.NextPulseNumber <= announced.PulseNumber {
+		return errors.Errorf("can't announce maintenance in pulse %d without next pulse", announced.PulseNumber)
+	}
+	delta := announced.NextPulseNumber - announced.PulseNumber
+	if w.Start < announced.PulseNumber+MaintenanceNoticePulses*delta {
+		return errors.Errorf(
+			"maintenance window must start at least %d pulses after %d, starts at %d",
+			MaintenanceNoticePulses, announced.PulseNumber, w.Start,
+		)
+	}
+	if w.End < w.Start {
+		return errors.Errorf("maintenance window ends at %d before start %d", w.End, w.Start)
+	}
+	if w.End-w.Start >= MaintenanceMaxPulses*delta {
+		return errors.Errorf("maintenance window is longer than %d pulses", MaintenanceMaxPulses)
+	}
+	return nil
+}
+
+// MaintenanceSchedule provides maintenance windows agreed by the network.
+type MaintenanceSchedule interface {
+	// MaintenanceAt returns window containing provided pulse or nil.
+	MaintenanceAt(pulse PulseNumber) *MaintenanceWindow
+	// MaintenanceWindows returns known windows sorted by start.
+	MaintenanceWindows() []MaintenanceWindow
+}
+
+// MaintenancePlanner announces maintenance windows to the network.
+type MaintenancePlanner interface {
+	MaintenanceSchedule
+
+	// ScheduleMaintenance announces window of provided pulses with signed claim, only discovery nodes can do it.
+	ScheduleMaintenance(ctx context.Context, start, end PulseNumber, note string) error
+}
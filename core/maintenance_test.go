@@ -0,0 +1,49 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenanceWindow_Validate(t *testing.T) {
+	announced := Pulse{PulseNumber: 100, NextPulseNumber: 110}
+
+	require.NoError(t, MaintenanceWindow{Start: 130, End: 130}.Validate(announced))
+	require.NoError(t, MaintenanceWindow{Start: 130, End: 220}.Validate(announced))
+
+	// too early
+	require.Error(t, MaintenanceWindow{Start: 120, End: 150}.Validate(announced))
+	// ends before start
+	require.Error(t, MaintenanceWindow{Start: 150, End: 140}.Validate(announced))
+	// too long
+	require.Error(t, MaintenanceWindow{Start: 130, End: 230}.Validate(announced))
+	// unknown pulse duration
+	require.Error(t, MaintenanceWindow{Start: 130, End: 130}.Validate(Pulse{PulseNumber: 100}))
+}
+
+func TestMaintenanceWindow_Contains(t *testing.T) {
+	w := MaintenanceWindow{Start: 130, End: 150}
+	require.False(t, w.Contains(129))
+	require.True(t, w.Contains(130))
+	require.True(t, w.Contains(150))
+	require.False(t, w.Contains(151))
+	require.True(t, w.Overlaps(MaintenanceWindow{Start: 150, End: 160}))
+	require.False(t, w.Overlaps(MaintenanceWindow{Start: 151, End: 160}))
+}
@@ -58,6 +58,8 @@ type HeavyStartStop struct {
 	JetID    core.RecordID
 	PulseNum core.PulseNumber
 	Finished bool
+	// Resume asks heavy to continue interrupted sync of pulse instead of failing to start it again.
+	Resume bool
 }
 
 // AllowedSenderObjectAndRole implements interface method
@@ -115,3 +117,33 @@ func (HeavyReset) GetCaller() *core.RecordRef {
 func (e *HeavyReset) Type() core.MessageType {
 	return core.TypeHeavyReset
 }
+
+// GetKeyValues requests intact Key/Value records from other heavy node to repair corrupted ones.
+type GetKeyValues struct {
+	Keys [][]byte
+}
+
+// AllowedSenderObjectAndRole implements interface method
+func (*GetKeyValues) AllowedSenderObjectAndRole() (*core.RecordRef, core.DynamicRole) {
+	return nil, 0
+}
+
+// DefaultTarget returns of target of this event.
+func (*GetKeyValues) DefaultTarget() *core.RecordRef {
+	return &core.RecordRef{}
+}
+
+// DefaultRole returns role for this event
+func (*GetKeyValues) DefaultRole() core.DynamicRole {
+	return core.DynamicRoleHeavyExecutor
+}
+
+// GetCaller implementation of Message interface.
+func (GetKeyValues) GetCaller() *core.RecordRef {
+	return nil
+}
+
+// Type implementation of Message interface.
+func (*GetKeyValues) Type() core.MessageType {
+	return core.TypeGetKeyValues
+}
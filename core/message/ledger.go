@@ -91,6 +91,8 @@ type GetObject struct {
 	Head     core.RecordRef
 	State    *core.RecordID // If nil, will fetch the latest state.
 	Approved bool
+	// WithProof makes ledger attach proof of state to reply.
+	WithProof bool
 }
 
 // AllowedSenderObjectAndRole implements interface method
@@ -113,6 +115,37 @@ func (*GetObject) Type() core.MessageType {
 	return core.TypeGetObject
 }
 
+// GetObjects retrieves latest states of several objects and codes in one round trip. It's routed by
+// the first object, objects and codes stored elsewhere aren't returned.
+type GetObjects struct {
+	ledgerMessage
+	Heads []core.RecordRef
+	Codes []core.RecordRef
+}
+
+// AllowedSenderObjectAndRole implements interface method
+func (m *GetObjects) AllowedSenderObjectAndRole() (*core.RecordRef, core.DynamicRole) {
+	return m.DefaultTarget(), core.DynamicRoleVirtualExecutor
+}
+
+// DefaultRole returns role for this event
+func (*GetObjects) DefaultRole() core.DynamicRole {
+	return core.DynamicRoleLightExecutor
+}
+
+// DefaultTarget returns of target of this event.
+func (m *GetObjects) DefaultTarget() *core.RecordRef {
+	if len(m.Heads) == 0 {
+		return nil
+	}
+	return &m.Heads[0]
+}
+
+// Type implementation of Message interface.
+func (*GetObjects) Type() core.MessageType {
+	return core.TypeGetObjects
+}
+
 // GetDelegate retrieves object represented as provided type.
 type GetDelegate struct {
 	ledgerMessage
@@ -494,6 +527,70 @@ func (m *AbandonedRequestsNotification) DefaultTarget() *core.RecordRef {
 	return core.NewRecordRef(core.DomainID, m.Object)
 }
 
+// DueTimers informs virtual node about timers of object which are due in current pulse.
+type DueTimers struct {
+	ledgerMessage
+
+	Object core.RecordID
+	// Timers are serialized messages of scheduled calls by ids of timer records.
+	Timers map[core.RecordID][]byte
+}
+
+// Type implementation of Message interface.
+func (*DueTimers) Type() core.MessageType {
+	return core.TypeDueTimers
+}
+
+// AllowedSenderObjectAndRole implements interface method
+func (m *DueTimers) AllowedSenderObjectAndRole() (*core.RecordRef, core.DynamicRole) {
+	return nil, core.DynamicRoleUndefined
+}
+
+// DefaultRole returns role for this event
+func (*DueTimers) DefaultRole() core.DynamicRole {
+	return core.DynamicRoleVirtualExecutor
+}
+
+// DefaultTarget returns of target of this event.
+func (m *DueTimers) DefaultTarget() *core.RecordRef {
+	return core.NewRecordRef(core.DomainID, m.Object)
+}
+
+// PendingRequestStatus notifies API node which accepted request that request is still pending,
+// so node can push status update to subscribed client.
+type PendingRequestStatus struct {
+	QID     string
+	Member  core.RecordRef
+	Request core.RecordRef
+	// Pulses is a number of pulses request is pending for.
+	Pulses int
+}
+
+// AllowedSenderObjectAndRole implements interface method
+func (*PendingRequestStatus) AllowedSenderObjectAndRole() (*core.RecordRef, core.DynamicRole) {
+	return nil, core.DynamicRoleUndefined
+}
+
+// DefaultRole returns role for this event
+func (*PendingRequestStatus) DefaultRole() core.DynamicRole {
+	return core.DynamicRoleUndefined
+}
+
+// DefaultTarget returns of target of this event.
+func (*PendingRequestStatus) DefaultTarget() *core.RecordRef {
+	return nil
+}
+
+// GetCaller implementation of Message interface.
+func (*PendingRequestStatus) GetCaller() *core.RecordRef {
+	return nil
+}
+
+// Type implementation of Message interface.
+func (*PendingRequestStatus) Type() core.MessageType {
+	return core.TypePendingRequestStatus
+}
+
 // GetRequest fetches request from ledger.
 type GetRequest struct {
 	ledgerMessage
@@ -521,6 +618,33 @@ func (m *GetRequest) DefaultTarget() *core.RecordRef {
 	return core.NewRecordRef(core.DomainID, m.Request)
 }
 
+// GetResult fetches result registered for request from ledger.
+type GetResult struct {
+	ledgerMessage
+
+	Request core.RecordID
+}
+
+// Type implementation of Message interface.
+func (*GetResult) Type() core.MessageType {
+	return core.TypeGetResult
+}
+
+// AllowedSenderObjectAndRole implements interface method
+func (m *GetResult) AllowedSenderObjectAndRole() (*core.RecordRef, core.DynamicRole) {
+	return nil, core.DynamicRoleUndefined
+}
+
+// DefaultRole returns role for this event
+func (*GetResult) DefaultRole() core.DynamicRole {
+	return core.DynamicRoleLightExecutor
+}
+
+// DefaultTarget returns of target of this event.
+func (m *GetResult) DefaultTarget() *core.RecordRef {
+	return core.NewRecordRef(core.DomainID, m.Request)
+}
+
 // GetPendingRequestID fetches a pending request id for an object from current LME
 type GetPendingRequestID struct {
 	ledgerMessage
@@ -0,0 +1,69 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package message
+
+import (
+	"time"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/platformpolicy"
+)
+
+// Lock acquires, extends or releases named lock for owner object. Lock is held by virtual executor of
+// lock's reference and expires after TTL or when pulse changes.
+type Lock struct {
+	Name    string
+	Owner   core.RecordRef
+	TTL     time.Duration
+	Release bool
+}
+
+// LockReference returns reference lock with provided name is bound to.
+func LockReference(name string) core.RecordRef {
+	hash := platformpolicy.NewPlatformCryptographyScheme().IntegrityHasher()
+	_, err := hash.Write([]byte("lock:" + name))
+	if err != nil {
+		panic(err)
+	}
+	return *core.NewRecordRef(core.RecordID{}, *core.NewRecordID(core.FirstPulseNumber, hash.Sum(nil)))
+}
+
+// AllowedSenderObjectAndRole implements interface method
+func (*Lock) AllowedSenderObjectAndRole() (*core.RecordRef, core.DynamicRole) {
+	return nil, core.DynamicRoleUndefined
+}
+
+// DefaultRole returns role for this event
+func (*Lock) DefaultRole() core.DynamicRole {
+	return core.DynamicRoleVirtualExecutor
+}
+
+// DefaultTarget returns of target of this event.
+func (m *Lock) DefaultTarget() *core.RecordRef {
+	ref := LockReference(m.Name)
+	return &ref
+}
+
+// GetCaller implementation of Message interface.
+func (m *Lock) GetCaller() *core.RecordRef {
+	return &m.Owner
+}
+
+// Type implementation of Message interface.
+func (*Lock) Type() core.MessageType {
+	return core.TypeLock
+}
@@ -47,6 +47,8 @@ type IBaseLogicMessage interface {
 	GetReference() core.RecordRef
 	GetRequest() core.RecordRef
 	GetCallerPrototype() *core.RecordRef
+	GetAPIRequest() *core.APIRequest
+	GetCodec() core.CodecType
 }
 
 // BaseLogicMessage base of event class family, do not use it standalone
@@ -56,6 +58,12 @@ type BaseLogicMessage struct {
 	CallerPrototype core.RecordRef
 	Nonce           uint64
 	Sequence        uint64
+	APIRequest      *core.APIRequest
+	// SignedNonce is a nonce of signed request the call is made for, only the call made by API node has it.
+	// Ledger registers request with the same signed nonce for object once, so signed request is executed once.
+	SignedNonce []byte
+	// Codec is a codec of arguments and result of the call, CBOR is used by default.
+	Codec core.CodecType
 }
 
 func (m *BaseLogicMessage) GetBaseLogicMessage() *BaseLogicMessage {
@@ -95,6 +103,16 @@ func (m *BaseLogicMessage) GetRequest() core.RecordRef {
 	return m.Request
 }
 
+// GetAPIRequest returns metadata of API request which initiated the call, nil for internal calls.
+func (m *BaseLogicMessage) GetAPIRequest() *core.APIRequest {
+	return m.APIRequest
+}
+
+// GetCodec returns codec of arguments and result of the call.
+func (m *BaseLogicMessage) GetCodec() core.CodecType {
+	return m.Codec
+}
+
 // ReturnResults - push results of methods
 type ReturnResults struct {
 	Target   core.RecordRef
@@ -132,6 +150,8 @@ type CallMethod struct {
 	Method         string
 	Arguments      core.Arguments
 	ProxyPrototype core.RecordRef
+	// AcceptsCompressed is set if caller decompresses results, executor compresses large results then
+	AcceptsCompressed bool
 }
 
 // ToMap returns map representation of CallMethod.
@@ -274,11 +294,31 @@ type ExecutorResults struct {
 	Queue                 []ExecutionQueueElement
 	LedgerHasMoreRequests bool
 	Pending               PendingState
+	// Sequence is number of request all requests up to which are executed or reported missing, zero if unknown
+	Sequence uint64
+	// SequenceFinished are requests after Sequence which are already executed
+	SequenceFinished []uint64
+	// SequenceGaps are requests reported missing, they're still accepted if they arrive late
+	SequenceGaps []uint64
+	// Speculation is a result of the first request of queue executed speculatively, nil if there is none
+	Speculation *SpeculativeResult
+}
+
+// SpeculativeResult is a result of request executed speculatively against State of object by previous executor.
+type SpeculativeResult struct {
+	Request           core.RecordRef
+	State             core.RecordID
+	Memory            []byte
+	Result            []byte
+	RequireValidation bool
 }
 
 type ExecutionQueueElement struct {
-	Parcel  core.Parcel
-	Request *core.RecordRef
+	Parcel   core.Parcel
+	Request  *core.RecordRef
+	Sequence uint64
+	// Pulse is pulse request was registered in, zero if it's unknown.
+	Pulse core.Pulse
 }
 
 // AllowedSenderObjectAndRole implements interface method
@@ -323,6 +363,10 @@ type CaseBindRequest struct {
 	MessageBusTape []byte
 	Reply          core.Reply
 	Error          string
+	// Validate is set for requests validators should check, others are only recorded
+	Validate bool
+	// Pulse is pulse request was registered in, validators give it to contract instead of current pulse
+	Pulse core.Pulse
 }
 
 // AllowedSenderObjectAndRole implements interface method
@@ -57,6 +57,8 @@ func getEmptyMessage(mt core.MessageType) (core.Message, error) {
 		return &GetCode{}, nil
 	case core.TypeGetObject:
 		return &GetObject{}, nil
+	case core.TypeGetObjects:
+		return &GetObjects{}, nil
 	case core.TypeGetDelegate:
 		return &GetDelegate{}, nil
 	case core.TypeGetChildren:
@@ -79,10 +81,18 @@ func getEmptyMessage(mt core.MessageType) (core.Message, error) {
 		return &GetJet{}, nil
 	case core.TypeAbandonedRequestsNotification:
 		return &AbandonedRequestsNotification{}, nil
+	case core.TypeDueTimers:
+		return &DueTimers{}, nil
+	case core.TypePendingRequestStatus:
+		return &PendingRequestStatus{}, nil
 	case core.TypeGetPendingRequestID:
 		return &GetPendingRequestID{}, nil
 	case core.TypeGetRequest:
 		return &GetRequest{}, nil
+	case core.TypeGetResult:
+		return &GetResult{}, nil
+	case core.TypeMigrateHotData:
+		return &MigrateHotData{}, nil
 
 	// heavy sync
 	case core.TypeHeavyStartStop:
@@ -91,6 +101,8 @@ func getEmptyMessage(mt core.MessageType) (core.Message, error) {
 		return &HeavyPayload{}, nil
 	case core.TypeHeavyReset:
 		return &HeavyReset{}, nil
+	case core.TypeGetKeyValues:
+		return &GetKeyValues{}, nil
 	// Bootstrap
 	case core.TypeBootstrapRequest:
 		return &GenesisRequest{}, nil
@@ -98,6 +110,14 @@ func getEmptyMessage(mt core.MessageType) (core.Message, error) {
 	// NodeCert
 	case core.TypeNodeSignRequest:
 		return &NodeSignPayload{}, nil
+	case core.TypeGetNodeVersion:
+		return &GetNodeVersion{}, nil
+	case core.TypeGetTimeline:
+		return &GetTimeline{}, nil
+	case core.TypeLock:
+		return &Lock{}, nil
+	case core.TypeRestartSlot:
+		return &RestartSlot{}, nil
 	default:
 		return nil, errors.Errorf("unimplemented message type %d", mt)
 	}
@@ -207,6 +227,7 @@ func init() {
 	// Ledger
 	gob.Register(&GetCode{})
 	gob.Register(&GetObject{})
+	gob.Register(&GetObjects{})
 	gob.Register(&GetDelegate{})
 	gob.Register(&UpdateObject{})
 	gob.Register(&RegisterChild{})
@@ -219,14 +240,19 @@ func init() {
 	gob.Register(&GetPendingRequests{})
 	gob.Register(&GetJet{})
 	gob.Register(&AbandonedRequestsNotification{})
+	gob.Register(&DueTimers{})
+	gob.Register(&PendingRequestStatus{})
 	gob.Register(&HotData{})
 	gob.Register(&GetPendingRequestID{})
 	gob.Register(&GetRequest{})
+	gob.Register(&GetResult{})
+	gob.Register(&MigrateHotData{})
 
 	// heavy
 	gob.Register(&HeavyStartStop{})
 	gob.Register(&HeavyPayload{})
 	gob.Register(&HeavyReset{})
+	gob.Register(&GetKeyValues{})
 
 	// Bootstrap
 	gob.Register(&GenesisRequest{})
@@ -236,4 +262,8 @@ func init() {
 
 	// NodeCert
 	gob.Register(&NodeSignPayload{})
+	gob.Register(&GetNodeVersion{})
+	gob.Register(&GetTimeline{})
+	gob.Register(&Lock{})
+	gob.Register(&RestartSlot{})
 }
@@ -0,0 +1,96 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package message
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/ledger/recentstorage"
+)
+
+// MigrateHotData carries hot data of jet to light material node which is going to execute the jet.
+type MigrateHotData struct {
+	ledgerMessage
+	Jet             core.RecordID
+	RecentObjects   map[core.RecordID]HotIndex
+	PendingRequests map[core.RecordID]recentstorage.PendingObjectContext
+}
+
+// AllowedSenderObjectAndRole implements interface method
+func (*MigrateHotData) AllowedSenderObjectAndRole() (*core.RecordRef, core.DynamicRole) {
+	return nil, core.DynamicRoleUndefined
+}
+
+// DefaultRole returns role for this event
+func (*MigrateHotData) DefaultRole() core.DynamicRole {
+	return core.DynamicRoleUndefined
+}
+
+// DefaultTarget returns of target of this event.
+func (*MigrateHotData) DefaultTarget() *core.RecordRef {
+	return nil
+}
+
+// Type implementation of Message interface.
+func (*MigrateHotData) Type() core.MessageType {
+	return core.TypeMigrateHotData
+}
+
+// HotDataChecksum calculates checksum of hot data independent of map ordering. TTLs of indexes and activity
+// of pending requests aren't included, since they change on transfer.
+func HotDataChecksum(
+	hasher core.Hasher,
+	objects map[core.RecordID]HotIndex,
+	pending map[core.RecordID]recentstorage.PendingObjectContext,
+) []byte {
+	objIDs := make([]core.RecordID, 0, len(objects))
+	for id := range objects {
+		objIDs = append(objIDs, id)
+	}
+	for _, id := range sortIDs(objIDs) {
+		_, _ = hasher.Write(id[:])
+		_, _ = hasher.Write(objects[id].Index)
+	}
+
+	pendingIDs := make([]core.RecordID, 0, len(pending))
+	for id := range pending {
+		pendingIDs = append(pendingIDs, id)
+	}
+	for _, obj := range sortIDs(pendingIDs) {
+		_, _ = hasher.Write(obj[:])
+		requests := append([]core.RecordID(nil), pending[obj].Requests...)
+		for _, req := range sortIDs(requests) {
+			_, _ = hasher.Write(req[:])
+		}
+		timers := make([]core.RecordID, 0, len(pending[obj].Timers))
+		for _, timer := range pending[obj].Timers {
+			timers = append(timers, timer.ID)
+		}
+		for _, timer := range sortIDs(timers) {
+			_, _ = hasher.Write(timer[:])
+		}
+	}
+	return hasher.Sum(nil)
+}
+
+func sortIDs(ids []core.RecordID) []core.RecordID {
+	sort.Slice(ids, func(i, j int) bool {
+		return bytes.Compare(ids[i][:], ids[j][:]) < 0
+	})
+	return ids
+}
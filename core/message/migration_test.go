@@ -0,0 +1,49 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package message
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/ledger/recentstorage"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestHotDataChecksum(t *testing.T) {
+	scheme := platformpolicy.NewPlatformCryptographyScheme()
+	obj, req1, req2 := testutils.RandomID(), testutils.RandomID(), testutils.RandomID()
+	objects := map[core.RecordID]HotIndex{obj: {TTL: 1, Index: []byte{1, 2, 3}}}
+
+	checksum := HotDataChecksum(scheme.IntegrityHasher(), objects, map[core.RecordID]recentstorage.PendingObjectContext{
+		obj: {Active: true, Requests: []core.RecordID{req1, req2}},
+	})
+
+	objects[obj] = HotIndex{TTL: 5, Index: []byte{1, 2, 3}}
+	same := HotDataChecksum(scheme.IntegrityHasher(), objects, map[core.RecordID]recentstorage.PendingObjectContext{
+		obj: {Active: false, Requests: []core.RecordID{req2, req1}},
+	})
+	require.Equal(t, checksum, same, "order of requests, TTLs and activity don't affect checksum")
+
+	missing := HotDataChecksum(scheme.IntegrityHasher(), objects, map[core.RecordID]recentstorage.PendingObjectContext{
+		obj: {Requests: []core.RecordID{req1}},
+	})
+	require.NotEqual(t, checksum, missing)
+}
@@ -36,10 +36,12 @@ type Parcel struct {
 	Sender        core.RecordRef
 	Msg           core.Message
 	Signature     []byte
+	SessionMAC    []byte // MAC made with session key of sender and receiver, used instead of Signature if set
 	LogTraceID    string
 	TraceSpanData []byte
 	Token         core.DelegationToken
 	PulseNumber   core.PulseNumber
+	TTL           uint32 // number of pulses after PulseNumber parcel is valid for, zero means parcel doesn't expire
 }
 
 // AllowedSenderObjectAndRole implements interface method
@@ -71,7 +73,11 @@ func (sm *Parcel) Message() core.Message {
 func (sm *Parcel) Context(ctx context.Context) context.Context {
 	ctx = inslogger.ContextWithTrace(ctx, sm.LogTraceID)
 	parentspan := instracer.MustDeserialize(sm.TraceSpanData)
-	return instracer.WithParentSpan(ctx, parentspan)
+	ctx = instracer.WithParentSpan(ctx, parentspan)
+	if target := instracer.TraceTarget(ctx); target != "" {
+		ctx = instracer.WithTraceTarget(ctx, target)
+	}
+	return ctx
 }
 
 func (sm *Parcel) DelegationToken() core.DelegationToken {
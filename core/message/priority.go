@@ -0,0 +1,48 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package message
+
+import (
+	"github.com/insolar/insolar/core"
+)
+
+// priorityMessages are messages which hand over state to executors of new pulse.
+// They must be delivered before phase deadlines, so they aren't delayed by regular traffic.
+var priorityMessages = map[core.MessageType]bool{
+	core.TypeExecutorResults: true,
+	core.TypeStillExecuting:  true,
+	core.TypePendingFinished: true,
+	core.TypeHotRecords:      true,
+}
+
+// IsPriority returns true if message of provided type is pulse-critical.
+func IsPriority(mt core.MessageType) bool {
+	return priorityMessages[mt]
+}
+
+// IsPriorityParcel returns true if parcel is pulse-critical or it's a call marked as latency-sensitive by API node.
+func IsPriorityParcel(parcel core.Parcel) bool {
+	if IsPriority(parcel.Type()) {
+		return true
+	}
+	if msg, ok := parcel.Message().(IBaseLogicMessage); ok {
+		if apiRequest := msg.GetAPIRequest(); apiRequest != nil {
+			return apiRequest.Priority
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package message
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+)
+
+func TestIsPriorityParcel(t *testing.T) {
+	require.True(t, IsPriorityParcel(&Parcel{Msg: &StillExecuting{}}))
+	require.False(t, IsPriorityParcel(&Parcel{Msg: &CallMethod{}}))
+	require.False(t, IsPriorityParcel(&Parcel{Msg: &CallMethod{
+		BaseLogicMessage: BaseLogicMessage{APIRequest: &core.APIRequest{}},
+	}}))
+	require.True(t, IsPriorityParcel(&Parcel{Msg: &CallMethod{
+		BaseLogicMessage: BaseLogicMessage{APIRequest: &core.APIRequest{Priority: true}},
+	}}))
+}
@@ -0,0 +1,52 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package message
+
+import (
+	"github.com/insolar/insolar/core"
+)
+
+// GetTimeline requests events of request processing with provided query id or request reference.
+type GetTimeline struct {
+	QID     string
+	Request *core.RecordRef
+}
+
+// AllowedSenderObjectAndRole implements interface method
+func (*GetTimeline) AllowedSenderObjectAndRole() (*core.RecordRef, core.DynamicRole) {
+	return nil, core.DynamicRoleUndefined
+}
+
+// DefaultRole returns role for this event
+func (*GetTimeline) DefaultRole() core.DynamicRole {
+	return core.DynamicRoleUndefined
+}
+
+// DefaultTarget returns of target of this event.
+func (*GetTimeline) DefaultTarget() *core.RecordRef {
+	return nil
+}
+
+// GetCaller implementation of Message interface.
+func (*GetTimeline) GetCaller() *core.RecordRef {
+	return nil
+}
+
+// Type implementation of Message interface.
+func (*GetTimeline) Type() core.MessageType {
+	return core.TypeGetTimeline
+}
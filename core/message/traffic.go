@@ -0,0 +1,50 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package message
+
+import (
+	"github.com/insolar/insolar/core"
+)
+
+// Traffic classes of packets sent by transport, bandwidth of each class may be capped separately.
+const (
+	// TrafficConsensus is traffic of consensus phases.
+	TrafficConsensus = "consensus"
+	// TrafficReplication is traffic of jet drops and records moved between ledger nodes.
+	TrafficReplication = "replication"
+	// TrafficContract is traffic of contract calls, their results and validation.
+	TrafficContract = "contract"
+)
+
+var trafficClasses = map[core.MessageType]string{
+	core.TypeJetDrop:           TrafficReplication,
+	core.TypeHeavyStartStop:    TrafficReplication,
+	core.TypeHeavyPayload:      TrafficReplication,
+	core.TypeHeavyReset:        TrafficReplication,
+	core.TypeMigrateHotData:    TrafficReplication,
+	core.TypeCallMethod:        TrafficContract,
+	core.TypeCallConstructor:   TrafficContract,
+	core.TypeReturnResults:     TrafficContract,
+	core.TypeValidateCaseBind:  TrafficContract,
+	core.TypeValidationResults: TrafficContract,
+}
+
+// TrafficClass returns traffic class of message of provided type, empty for messages of no class.
+// Priority messages have no class, so they are never delayed by bandwidth caps.
+func TrafficClass(mt core.MessageType) string {
+	return trafficClasses[mt]
+}
@@ -0,0 +1,63 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package message
+
+import (
+	"github.com/insolar/insolar/core"
+)
+
+// RestartSlotAction is an action of RestartSlot message.
+type RestartSlotAction uint8
+
+const (
+	// RestartSlotAcquire asks for slot to restart sender.
+	RestartSlotAcquire RestartSlotAction = iota + 1
+	// RestartSlotRelease returns slot of sender.
+	RestartSlotRelease
+	// RestartSlotList lists slots held by nodes.
+	RestartSlotList
+)
+
+// RestartSlot is sent by node to discovery node which grants restart slots.
+type RestartSlot struct {
+	Action RestartSlotAction
+}
+
+// AllowedSenderObjectAndRole implements interface method
+func (*RestartSlot) AllowedSenderObjectAndRole() (*core.RecordRef, core.DynamicRole) {
+	return nil, core.DynamicRoleUndefined
+}
+
+// DefaultRole returns role for this event
+func (*RestartSlot) DefaultRole() core.DynamicRole {
+	return core.DynamicRoleUndefined
+}
+
+// DefaultTarget returns of target of this event.
+func (*RestartSlot) DefaultTarget() *core.RecordRef {
+	return nil
+}
+
+// GetCaller implementation of Message interface.
+func (*RestartSlot) GetCaller() *core.RecordRef {
+	return nil
+}
+
+// Type implementation of Message interface.
+func (*RestartSlot) Type() core.MessageType {
+	return core.TypeRestartSlot
+}
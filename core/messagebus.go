@@ -138,6 +138,8 @@ type RedirectReply interface {
 type MessageSendOptions struct {
 	Receiver *RecordRef
 	Token    DelegationToken
+	// Globule is a globule of receiver calculated by role, nil means globule of sender
+	Globule *GlobuleID
 }
 
 // Safe returns original options, falling back on defaults if nil.
@@ -281,6 +283,26 @@ const (
 
 	// TypeNodeSignRequest used to request sign for new node
 	TypeNodeSignRequest
+	// TypeGetNodeVersion requests version of node software.
+	TypeGetNodeVersion
+	// TypeGetTimeline requests events of request processing observed by node.
+	TypeGetTimeline
+	// TypeLock acquires or releases named lock held by virtual executor.
+	TypeLock
+	// TypeGetResult fetches result registered for request.
+	TypeGetResult
+	// TypeMigrateHotData carries hot data of jet to its future executor.
+	TypeMigrateHotData
+	// TypeGetKeyValues requests intact records from heavy to repair corrupted ones.
+	TypeGetKeyValues
+	// TypePendingRequestStatus notifies API node which accepted request that request is still pending.
+	TypePendingRequestStatus
+	// TypeGetObjects retrieves several objects and codes from storage in one round trip.
+	TypeGetObjects
+	// TypeRestartSlot acquires, releases or lists slots nodes restart in.
+	TypeRestartSlot
+	// TypeDueTimers informs virtual node about contract timers which are due.
+	TypeDueTimers
 )
 
 // DelegationTokenType is an enum type of delegation token
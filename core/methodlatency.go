@@ -0,0 +1,52 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package core
+
+import (
+	"time"
+)
+
+// MethodLatencyOther is prototype and method label of executions collected beyond cardinality limit.
+const MethodLatencyOther = "other"
+
+// LatencyBucket is bucket of latency histogram.
+type LatencyBucket struct {
+	// UpperBound is max duration of executions counted in bucket, zero for the last unbounded bucket.
+	UpperBound time.Duration
+	// Count is number of executions in bucket.
+	Count uint64
+}
+
+// MethodLatency is histogram of execution durations of contract method.
+type MethodLatency struct {
+	// Prototype is reference of object prototype, MethodLatencyOther for executions beyond cardinality limit.
+	Prototype string
+	// Method is method name, constructors are prefixed with "constructor:".
+	Method string
+	// Count is number of executions.
+	Count uint64
+	// Sum is total duration of executions.
+	Sum time.Duration
+	// Buckets are histogram buckets in ascending order of upper bound.
+	Buckets []LatencyBucket
+}
+
+// MethodStatsProvider provides histograms of contract method execution durations collected by node.
+type MethodStatsProvider interface {
+	// MethodLatencies returns histograms of executed methods sorted by prototype and method.
+	MethodLatencies() []MethodLatency
+}
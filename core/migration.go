@@ -0,0 +1,60 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package core
+
+import (
+	"context"
+)
+
+// ObjectTreeFormatVersion is version of exported object tree format, it's increased on incompatible changes.
+const ObjectTreeFormatVersion = 1
+
+// ObjectTree is subtree of objects exported from one network to be imported into another.
+type ObjectTree struct {
+	// Version is ObjectTreeFormatVersion of format.
+	Version int
+	// Root is reference of subtree root in source network.
+	Root RecordRef
+	// Objects are latest states of objects of subtree, parents go before their children.
+	Objects []ExportedObject
+}
+
+// ExportedObject is latest state of object in source network.
+type ExportedObject struct {
+	// Head is reference of object in source network.
+	Head RecordRef
+	// Parent is reference of parent in source network.
+	Parent RecordRef
+	// Prototype is reference of object's prototype in source network.
+	Prototype RecordRef
+	// IsDelegate is set if object is delegate of its parent.
+	IsDelegate bool
+	// Memory is object memory.
+	Memory []byte
+}
+
+// ObjectMigrator moves subtrees of objects between networks, e.g. from testnet to staging.
+type ObjectMigrator interface {
+	// ExportObjects exports latest states of object and all its descendants.
+	ExportObjects(ctx context.Context, root RecordRef) (*ObjectTree, error)
+	// ImportObjects activates objects of tree under parent in current pulse. Prototypes of source network are
+	// replaced with ones from prototypes map, references of imported objects in memory are replaced with new ones.
+	// Returns new references of objects by their references in source network.
+	ImportObjects(
+		ctx context.Context, tree *ObjectTree, parent RecordRef, prototypes map[RecordRef]RecordRef,
+	) (map[RecordRef]RecordRef, error)
+}
@@ -0,0 +1,39 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package core
+
+// Names of network-wide parameters governed by root domain.
+const (
+	// NetworkParameterMaxQueueLength overrides max length of execution queue of an object, non-negative integer.
+	NetworkParameterMaxQueueLength = "MaxQueueLength"
+	// NetworkParameterCallTimeout overrides default timeout of API calls, duration like "15s".
+	NetworkParameterCallTimeout = "CallTimeout"
+	// NetworkParameterFeeSchedule is a JSON-encoded fee schedule of the network.
+	NetworkParameterFeeSchedule = "FeeSchedule"
+	// NetworkParameterWriteQuota overrides bytes of records and blobs one caller can write per pulse, zero disables quota.
+	NetworkParameterWriteQuota = "WriteQuota"
+	// NetworkParameterEmergencyPause pauses the network, value is a reason of pause. While it's set nodes reject
+	// mutating API calls and contract constructors, reads are still allowed. Removing parameter lifts the pause.
+	NetworkParameterEmergencyPause = "EmergencyPause"
+)
+
+// NetworkParameters gives access to network-wide parameters stored in root domain. Nodes refresh them on pulse
+// boundaries, so parameters can be changed without restarting nodes.
+type NetworkParameters interface {
+	// GetNetworkParameter returns value of parameter and false if parameter isn't set in root domain.
+	GetNetworkParameter(name string) (string, bool)
+}
@@ -43,6 +43,21 @@ type Network interface {
 	RemoteProcedureRegister(name string, method RemoteProcedure)
 }
 
+// NodeMessageHandler handles application message sent directly to this node. Sender is authenticated by network,
+// returned bytes are sent back as reply.
+type NodeMessageHandler func(ctx context.Context, sender RecordRef, payload []byte) ([]byte, error)
+
+// NodeMessenger sends application messages of platform components directly to other nodes, so features exchanging
+// data between nodes don't need their own plumbing. It's not available to contracts.
+//go:generate minimock -i github.com/insolar/insolar/core.NodeMessenger -o ../testutils -s _mock.go
+type NodeMessenger interface {
+	// SendToNode sends payload to handler of topic on active node and returns its reply. Payload is signed by this
+	// node and its size is limited by configuration.
+	SendToNode(ctx context.Context, node RecordRef, topic string, payload []byte) ([]byte, error)
+	// RegisterNodeHandler registers handler of messages of topic sent by other nodes.
+	RegisterNodeHandler(topic string, handler NodeMessageHandler)
+}
+
 // PulseDistributor is interface for pulse distribution.
 //go:generate minimock -i github.com/insolar/insolar/core.PulseDistributor -o ../testutils -s _mock.go
 type PulseDistributor interface {
@@ -0,0 +1,70 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package core
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// LeaveReason is a reason of graceful leave of node from the network.
+type LeaveReason uint8
+
+const (
+	// LeaveReasonUnknown is used when operator didn't provide a reason.
+	LeaveReasonUnknown LeaveReason = iota
+	// LeaveReasonMaintenance is a temporary leave, node is expected to return.
+	LeaveReasonMaintenance
+	// LeaveReasonUpgrade is a leave for node software upgrade.
+	LeaveReasonUpgrade
+	// LeaveReasonDecommission is a permanent leave.
+	LeaveReasonDecommission
+)
+
+var leaveReasonNames = map[LeaveReason]string{
+	LeaveReasonUnknown:      "unknown",
+	LeaveReasonMaintenance:  "maintenance",
+	LeaveReasonUpgrade:      "upgrade",
+	LeaveReasonDecommission: "decommission",
+}
+
+func (r LeaveReason) String() string {
+	if name, ok := leaveReasonNames[r]; ok {
+		return name
+	}
+	return "LeaveReason(" + strconv.Itoa(int(r)) + ")"
+}
+
+// ParseLeaveReason returns LeaveReason by its name.
+func ParseLeaveReason(name string) (LeaveReason, error) {
+	for r, n := range leaveReasonNames {
+		if n == name {
+			return r, nil
+		}
+	}
+	return LeaveReasonUnknown, errors.Errorf("unknown leave reason %s", name)
+}
+
+// NodeLeave is a record of graceful leave of node from the network.
+type NodeLeave struct {
+	NodeID RecordRef
+	Reason LeaveReason
+	Note   string    // optional operator note
+	Time   time.Time // time when leave was applied to active list
+}
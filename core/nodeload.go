@@ -0,0 +1,54 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package core
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// NodeLoad is a load self-reported by node to the network every pulse.
+// It isn't used by consensus and coordination, it's a diagnostic information for operators.
+type NodeLoad struct {
+	NodeID RecordRef
+	// CPU is a CPU usage of node process in percents of all cores
+	CPU uint8
+	// QueueDepth is a number of requests queued for execution
+	QueueDepth uint16
+	// StorageHeadroom is a free space of node storage in percents
+	StorageHeadroom uint8
+	// Time is a time when load was applied to node history
+	Time time.Time
+}
+
+// Validate checks that load values are in allowed ranges.
+func (l NodeLoad) Validate() error {
+	if l.CPU > 100 {
+		return errors.Errorf("CPU usage %d%% is out of range", l.CPU)
+	}
+	if l.StorageHeadroom > 100 {
+		return errors.Errorf("storage headroom %d%% is out of range", l.StorageHeadroom)
+	}
+	return nil
+}
+
+// NodeLoadReporter measures load of the current node.
+type NodeLoadReporter interface {
+	NodeLoad(ctx context.Context) NodeLoad
+}
@@ -0,0 +1,92 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package core
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// NodeMode is an operational mode of the node.
+type NodeMode uint8
+
+const (
+	// NodeModeBootstrapping is a mode of node joining the network.
+	NodeModeBootstrapping NodeMode = iota
+	// NodeModeSyncing is a mode of node which joined the network, but network is not complete yet.
+	NodeModeSyncing
+	// NodeModeParticipant is a mode of node which takes part in the network with all its roles.
+	NodeModeParticipant
+	// NodeModeCordoned is a mode of node which stays in the network, but doesn't take executor and validator roles.
+	NodeModeCordoned
+	// NodeModeDraining is a mode of node which announced leave and finishes its work.
+	NodeModeDraining
+	// NodeModeStopped is a final mode of node, it is never left.
+	NodeModeStopped
+)
+
+var nodeModeNames = map[NodeMode]string{
+	NodeModeBootstrapping: "bootstrapping",
+	NodeModeSyncing:       "syncing",
+	NodeModeParticipant:   "participant",
+	NodeModeCordoned:      "cordoned",
+	NodeModeDraining:      "draining",
+	NodeModeStopped:       "stopped",
+}
+
+func (m NodeMode) String() string {
+	if name, ok := nodeModeNames[m]; ok {
+		return name
+	}
+	return "NodeMode(" + strconv.Itoa(int(m)) + ")"
+}
+
+// ParseNodeMode returns NodeMode by its name.
+func ParseNodeMode(name string) (NodeMode, error) {
+	for m, n := range nodeModeNames {
+		if n == name {
+			return m, nil
+		}
+	}
+	return NodeModeBootstrapping, errors.Errorf("unknown node mode %s", name)
+}
+
+// NodeModeTransition is a record of change of node mode.
+type NodeModeTransition struct {
+	From      NodeMode
+	To        NodeMode
+	Pulse     PulseNumber // pulse in which mode was changed, zero if pulse is unknown
+	Reason    string      // operator reason or description of the event which changed mode
+	Requested bool        // true if transition was requested by operator
+	Time      time.Time
+}
+
+// NodeModes tracks operational mode of the node. Bootstrapping, syncing, participant and stopped modes are
+// switched by the node itself, operator can cordon, uncordon and drain the node.
+type NodeModes interface {
+	// NodeMode returns current mode of the node.
+	NodeMode() NodeMode
+	// RequestNodeMode switches node to provided mode, it fails if operator can't switch to it from current mode.
+	RequestNodeMode(ctx context.Context, mode NodeMode, reason string) error
+	// DrainNode switches node to draining mode and announces its leave with reason and optional operator note.
+	DrainNode(ctx context.Context, leave LeaveReason, note string) error
+	// NodeModeHistory returns recent transitions, oldest first.
+	NodeModeHistory() []NodeModeTransition
+}
@@ -18,6 +18,7 @@ package core
 
 import (
 	"crypto"
+	"time"
 )
 
 // ShortNodeID is the shortened ID of node that is unique inside the globe
@@ -36,6 +37,120 @@ const (
 	NodeReady
 )
 
+// ActiveNodeInfo describes node from active list of the network.
+type ActiveNodeInfo struct {
+	ID      RecordRef
+	Role    StaticRole
+	Address string
+	// JoinPulse is a first pulse when node was seen in active list by this node
+	JoinPulse PulseNumber
+	State     NodeState
+}
+
+// ActiveNodesProvider provides active list of the network.
+type ActiveNodesProvider interface {
+	// GetActiveNodesInfo returns active nodes with one of roles and one of states sorted by ID, empty filter matches any node.
+	GetActiveNodesInfo(roles []StaticRole, states []NodeState) []ActiveNodeInfo
+}
+
+// PeerTraffic is a traffic exchanged with remote peer by packets of one type.
+type PeerTraffic struct {
+	// Node is a reference of remote node, it is empty if node isn't known.
+	Node    RecordRef
+	Role    StaticRole
+	Address string
+	// Type is a type of packet, for consensus packets it is a consensus phase.
+	Type string
+
+	SentBytes   uint64
+	SentPackets uint64
+	RecvBytes   uint64
+	RecvPackets uint64
+}
+
+// TrafficProvider provides accounting of network traffic of the node.
+type TrafficProvider interface {
+	// GetTraffic returns traffic counters since node start, aggregated by remote peer and packet type.
+	GetTraffic() []PeerTraffic
+}
+
+// CapturedPacket is metadata of packet sent or received by network transports of the node.
+type CapturedPacket struct {
+	Time time.Time
+	Sent bool
+	// Type is a type of packet, for consensus packets it is a consensus phase.
+	Type string
+	Size int
+	// Node is a reference of remote node, it is empty if node isn't known.
+	Node    RecordRef
+	Address string
+	// Pulse is a pulse node was in when packet was captured.
+	Pulse PulseNumber
+	// Hash identifies packet, the same packet has the same hash on sender and receiver.
+	Hash []byte
+}
+
+// PacketCapture provides metadata of packets recently sent and received by the node, it's used in postmortems.
+type PacketCapture interface {
+	// GetCapturedPackets returns packets of capture window in order of capture.
+	GetCapturedPackets() []CapturedPacket
+}
+
+// DurationPercentiles are percentiles of duration samples.
+type DurationPercentiles struct {
+	Samples int
+	P50     time.Duration
+	P90     time.Duration
+	P99     time.Duration
+	Max     time.Duration
+}
+
+// PhaseTimings is a time profile of consensus phase over recent pulses.
+type PhaseTimings struct {
+	// Phase is a name of consensus phase.
+	Phase string
+	// Send is a duration of sending phase packets to all participants.
+	Send DurationPercentiles
+	// Wait is a duration from start of packets exchange until the last packet from participants is received.
+	Wait DurationPercentiles
+	// Process is a duration of phase without packets exchange.
+	Process DurationPercentiles
+}
+
+// ConsensusProfiler provides accounting of time consumed by consensus phases.
+type ConsensusProfiler interface {
+	// GetPhaseTimings returns durations of consensus phases over recent pulses.
+	GetPhaseTimings() []PhaseTimings
+}
+
+// PeerRTT is round trip time to remote peer measured on request/response exchanges of the node.
+type PeerRTT struct {
+	// Node is a reference of remote node, it is empty if node isn't known.
+	Node    RecordRef
+	Address string
+
+	Samples int
+	Min     time.Duration
+	P50     time.Duration
+	Max     time.Duration
+}
+
+// RTTSummary is a summary of round trip times to consensus participants measured during pulse.
+type RTTSummary struct {
+	Pulse PulseNumber
+	Peers []PeerRTT
+	// P50, P90 and Max are percentiles of medians of round trip times to peers.
+	P50 time.Duration
+	P90 time.Duration
+	Max time.Duration
+}
+
+// RTTProvider provides round trip times to consensus participants.
+type RTTProvider interface {
+	// GetRTT returns summaries of round trip times over recent pulses, the latest pulse is the last.
+	GetRTT() []RTTSummary
+}
+
 //go:generate minimock -i github.com/insolar/insolar/core.Node -o ../testutils/network -s _mock.go
 type Node interface {
 	// ID is the unique identifier of the node
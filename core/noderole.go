@@ -24,6 +24,12 @@ const (
 	StaticRoleVirtual
 	StaticRoleHeavyMaterial
 	StaticRoleLightMaterial
+	// StaticRoleAPIGateway is a role of node which serves public API and routes calls to virtual nodes.
+	// Such nodes never execute or validate contracts.
+	StaticRoleAPIGateway
+	// StaticRoleObserver is a role of read-only node which follows pulses and ledger data for explorers and analytics.
+	// Such nodes don't vote in consensus, never execute contracts and can send only read requests.
+	StaticRoleObserver
 )
 
 // AllStaticRoles is an array of all possible StaticRoles.
@@ -31,6 +37,8 @@ var AllStaticRoles = []StaticRole{
 	StaticRoleVirtual,
 	StaticRoleLightMaterial,
 	StaticRoleHeavyMaterial,
+	StaticRoleAPIGateway,
+	StaticRoleObserver,
 }
 
 // GetStaticRoleFromString converts role from string to StaticRole.
@@ -42,6 +50,10 @@ func GetStaticRoleFromString(role string) StaticRole {
 		return StaticRoleHeavyMaterial
 	case "light_material":
 		return StaticRoleLightMaterial
+	case "api_gateway":
+		return StaticRoleAPIGateway
+	case "observer":
+		return StaticRoleObserver
 	}
 
 	return StaticRoleUnknown
@@ -55,6 +67,10 @@ func (nr StaticRole) String() string {
 		return "heavy_material"
 	case StaticRoleLightMaterial:
 		return "light_material"
+	case StaticRoleAPIGateway:
+		return "api_gateway"
+	case StaticRoleObserver:
+		return "observer"
 	}
 
 	return "unknown"
@@ -0,0 +1,44 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package core
+
+import (
+	"context"
+)
+
+// NotificationEvent is a kind of critical node event which node operators are notified about.
+type NotificationEvent string
+
+const (
+	// NotificationNodeLeft is sent when node is excluded from working nodes by consensus.
+	NotificationNodeLeft NotificationEvent = "node_left"
+	// NotificationConsensusFailures is sent when number of failed consensus rounds exceeds threshold.
+	NotificationConsensusFailures NotificationEvent = "consensus_failures"
+	// NotificationStorageCapacity is sent when free space of node storage is below threshold.
+	NotificationStorageCapacity NotificationEvent = "storage_capacity"
+	// NotificationCertificateExpiring is sent when node certificate expires soon.
+	NotificationCertificateExpiring NotificationEvent = "certificate_expiring"
+	// NotificationNodeModeChanged is sent when operational mode of the node is changed.
+	NotificationNodeModeChanged NotificationEvent = "node_mode_changed"
+)
+
+// Notifier delivers notifications about critical node events to node operators.
+type Notifier interface {
+	// Notify queues notification for delivery, details are sent as is.
+	Notify(ctx context.Context, event NotificationEvent, details map[string]interface{})
+	// ObserveConsensus takes into account result of consensus round of pulse, err is nil for successful round.
+	ObserveConsensus(ctx context.Context, pulse PulseNumber, err error)
+}
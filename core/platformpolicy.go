@@ -35,6 +35,19 @@ type Verifier interface {
 	Verify(Signature, []byte) bool
 }
 
+// SignatureCheck is a signature of data made by owner of public key, to be verified in a batch.
+type SignatureCheck struct {
+	PublicKey crypto.PublicKey
+	Signature Signature
+	Data      []byte
+}
+
+// BatchVerifier verifies many signatures at once.
+type BatchVerifier interface {
+	// VerifyBatch returns validity of every check, in order of checks.
+	VerifyBatch(checks []SignatureCheck) []bool
+}
+
 type PlatformCryptographyScheme interface {
 	PublicKeySize() int
 	SignatureSIze() int
@@ -44,6 +57,8 @@ type PlatformCryptographyScheme interface {
 
 	Signer(crypto.PrivateKey) Signer
 	Verifier(crypto.PublicKey) Verifier
+	// BatchVerifier returns verifier of signature batches which uses up to workers goroutines.
+	BatchVerifier(workers int) BatchVerifier
 }
 
 //go:generate minimock -i github.com/insolar/insolar/core.KeyProcessor -o ../testutils -s _mock.go
@@ -19,6 +19,7 @@ package core
 import (
 	"encoding/binary"
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/insolar/insolar/core/utils"
@@ -76,6 +77,30 @@ type Pulse struct {
 	Signs   map[string]PulseSenderConfirmation
 }
 
+// Duration returns duration of the pulse announced by pulsar via next pulse number,
+// pulse numbers are seconds so pulsar adjusts pulse duration by changing the delta.
+// Zero is returned if next pulse is unknown.
+func (p *Pulse) Duration() time.Duration {
+	if p.NextPulseNumber <= p.PulseNumber {
+		return 0
+	}
+	return time.Duration(p.NextPulseNumber-p.PulseNumber) * time.Second
+}
+
+// NumberAfter estimates number of the pulse coming count pulses after this one, assuming pulsar keeps the delta.
+// Delta of one is assumed if next pulse is unknown. Result is capped by the max pulse number.
+func (p *Pulse) NumberAfter(count uint) PulseNumber {
+	delta := uint64(1)
+	if p.NextPulseNumber > p.PulseNumber {
+		delta = uint64(p.NextPulseNumber - p.PulseNumber)
+	}
+	number := uint64(p.PulseNumber) + uint64(count)*delta
+	if number > math.MaxUint32 {
+		return math.MaxUint32
+	}
+	return PulseNumber(number)
+}
+
 // PulseSenderConfirmation contains confirmations of the pulse from other pulsars
 // Because the system is using BFT for consensus between pulsars, because of it
 // All pulsar send to the chosen pulsar their confirmations
@@ -0,0 +1,47 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package core
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPulse_Duration(t *testing.T) {
+	require.Equal(t, 10*time.Second, (&Pulse{PulseNumber: 100, NextPulseNumber: 110}).Duration())
+	require.Zero(t, (&Pulse{PulseNumber: 100}).Duration())
+}
+
+func TestPulseDurationAllowed(t *testing.T) {
+	pulse := &Pulse{PulseNumber: 100, NextPulseNumber: 110}
+	require.True(t, PulseDurationAllowed(pulse, 0, 0))
+	require.True(t, PulseDurationAllowed(pulse, 5*time.Second, 0))
+	require.True(t, PulseDurationAllowed(pulse, 10*time.Second, 10*time.Second))
+	require.False(t, PulseDurationAllowed(pulse, 11*time.Second, 0))
+	require.False(t, PulseDurationAllowed(pulse, 0, 9*time.Second))
+	require.True(t, PulseDurationAllowed(&Pulse{PulseNumber: 100}, 0, 0))
+	require.False(t, PulseDurationAllowed(&Pulse{PulseNumber: 100}, 0, 20*time.Second))
+}
+
+func TestPulse_NumberAfter(t *testing.T) {
+	require.Equal(t, PulseNumber(130), (&Pulse{PulseNumber: 100, NextPulseNumber: 110}).NumberAfter(3))
+	require.Equal(t, PulseNumber(103), (&Pulse{PulseNumber: 100}).NumberAfter(3))
+	require.Equal(t, PulseNumber(math.MaxUint32), (&Pulse{PulseNumber: 100, NextPulseNumber: 110}).NumberAfter(1<<30))
+}
@@ -0,0 +1,120 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jbenet/go-base58"
+	"github.com/pkg/errors"
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// Reasons of reference parsing errors, they are returned by errors.Cause of ReferenceError.
+var (
+	// ErrRefFormat means reference isn't two ids separated by RecordRefIDSeparator.
+	ErrRefFormat = errors.New("reference must be record and domain ids separated by " + RecordRefIDSeparator)
+	// ErrIDEncoding means id isn't canonical base58 string.
+	ErrIDEncoding = errors.New("id isn't canonical base58 string")
+	// ErrIDSize means decoded id has wrong size.
+	ErrIDSize = errors.Errorf("id must be %d bytes long", RecordIDSize)
+	// ErrEmptyRecord means record part of reference is empty.
+	ErrEmptyRecord = errors.New("record id is empty")
+	// ErrImplausiblePulse means id has pulse from range reserved by system which can't be in ids.
+	ErrImplausiblePulse = errors.New("id has implausible pulse")
+)
+
+// ReferenceError is an error of strict parsing of reference or id.
+type ReferenceError struct {
+	// Input is a string being parsed.
+	Input string
+	// Part is a part of reference which is wrong: "record", "domain" or empty if whole reference is wrong.
+	Part string
+	// Err is a reason of error, one of ErrRef* or ErrID* errors.
+	Err error
+}
+
+func (e *ReferenceError) Error() string {
+	if e.Part == "" {
+		return fmt.Sprintf("bad reference %q: %s", e.Input, e.Err)
+	}
+	return fmt.Sprintf("bad %s part of reference %q: %s", e.Part, e.Input, e.Err)
+}
+
+// Cause returns reason of error.
+func (e *ReferenceError) Cause() error {
+	return e.Err
+}
+
+// IsPlausibleIDPulse returns true if pulse can be in record id. Ids of code and prototypes have no pulse,
+// ids of jets have PulseNumberJet, other pulses below FirstPulseNumber are reserved by system.
+func IsPlausibleIDPulse(pn PulseNumber) bool {
+	return pn == 0 || pn == PulseNumberJet || pn >= FirstPulseNumber
+}
+
+// ParseID strictly deserializes RecordID from base58 encoded string. Unlike NewIDFromBase58 it rejects
+// non-canonical strings and ids with implausible pulses.
+func ParseID(str string) (*RecordID, error) {
+	id, err := parseID(str)
+	if err != nil {
+		return nil, &ReferenceError{Input: str, Err: err}
+	}
+	return id, nil
+}
+
+// ParseRef strictly deserializes reference from base58 encoded string. Unlike NewRefFromBase58 it rejects
+// non-canonical strings, empty records and ids with implausible pulses, and returns ReferenceError
+// which tells what is wrong.
+func ParseRef(str string) (*RecordRef, error) {
+	parts := strings.Split(str, RecordRefIDSeparator)
+	if len(parts) != 2 {
+		return nil, &ReferenceError{Input: str, Err: ErrRefFormat}
+	}
+	record, err := parseID(parts[0])
+	if err != nil {
+		return nil, &ReferenceError{Input: str, Part: "record", Err: err}
+	}
+	if *record == (RecordID{}) {
+		return nil, &ReferenceError{Input: str, Part: "record", Err: ErrEmptyRecord}
+	}
+	domain, err := parseID(parts[1])
+	if err != nil {
+		return nil, &ReferenceError{Input: str, Part: "domain", Err: err}
+	}
+	return NewRecordRef(*domain, *record), nil
+}
+
+func parseID(str string) (*RecordID, error) {
+	if str == "" || strings.Trim(str, base58Alphabet) != "" {
+		return nil, ErrIDEncoding
+	}
+	decoded := base58.Decode(str)
+	if len(decoded) != RecordIDSize {
+		return nil, ErrIDSize
+	}
+	var id RecordID
+	copy(id[:], decoded)
+	if id.String() != str {
+		return nil, ErrIDEncoding
+	}
+	if !IsPlausibleIDPulse(id.Pulse()) {
+		return nil, ErrImplausiblePulse
+	}
+	return &id, nil
+}
@@ -0,0 +1,65 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package core_test
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestParseRef(t *testing.T) {
+	domain := core.NewRecordID(core.FirstPulseNumber, randomHash())
+	record := core.NewRecordID(core.FirstPulseNumber+1, randomHash())
+	ref := core.NewRecordRef(*domain, *record)
+
+	parsed, err := core.ParseRef(ref.String())
+	require.NoError(t, err)
+	require.Equal(t, ref, parsed)
+
+	prototype := core.NewRecordRef(core.RecordID{}, *core.NewRecordID(0, randomHash()))
+	_, err = core.ParseRef(prototype.String())
+	require.NoError(t, err, "prototypes have no pulse and domain")
+
+	cases := map[string]struct {
+		input  string
+		reason error
+	}{
+		"no separator":    {record.String(), core.ErrRefFormat},
+		"many separators": {ref.String() + "." + domain.String(), core.ErrRefFormat},
+		"bad alphabet":    {"0" + record.String()[1:] + "." + domain.String(), core.ErrIDEncoding},
+		"leading zero":    {"1" + ref.String(), core.ErrIDSize},
+		"short":           {record.String()[2:] + "." + domain.String(), core.ErrIDSize},
+		"empty record":    {(&core.RecordID{}).String() + "." + domain.String(), core.ErrEmptyRecord},
+		"reserved pulse":  {core.NewRecordID(core.PulseNumberCurrent, record.Hash()).String() + "." + domain.String(), core.ErrImplausiblePulse},
+	}
+	for name, c := range cases {
+		_, err := core.ParseRef(c.input)
+		require.Error(t, err, name)
+		require.IsType(t, &core.ReferenceError{}, err, name)
+		require.Equal(t, c.reason, errors.Cause(err), name)
+	}
+}
+
+func randomHash() []byte {
+	id := testutils.RandomID()
+	return id.Hash()
+}
@@ -78,6 +78,30 @@ const (
 	TypeHeavyError
 
 	TypeNodeSign
+	// TypeNodeVersion contains version of node software.
+	TypeNodeVersion
+	// TypeBusy is returned by executor which can't accept requests at the moment.
+	TypeBusy
+	// TypeTimeline contains events of request processing observed by node.
+	TypeTimeline
+	// TypeLock is a result of named lock request.
+	TypeLock
+	// TypeResult contains result registered for request.
+	TypeResult
+	// TypeHotDataMigrated confirms migrated hot data is stored.
+	TypeHotDataMigrated
+	// TypeKeyValues contains records requested from heavy.
+	TypeKeyValues
+	// TypeObjects contains objects and codes fetched in one round trip.
+	TypeObjects
+	// TypeHeavySyncResumed contains number of payloads heavy already stored for pulse being synced.
+	TypeHeavySyncResumed
+	// TypeHeavyAck confirms stored payload and carries congestion signal of heavy.
+	TypeHeavyAck
+	// TypeRestartSlots contains restart slot granted to node or slots held by nodes.
+	TypeRestartSlots
+	// TypeRemoteError carries coded error returned by handler of message.
+	TypeRemoteError
 )
 
 // ErrType is used to determine and compare reply errors.
@@ -94,6 +118,14 @@ const (
 	ErrNoPendingRequests
 	// ErrTooManyPendingRequests is returned when a limit of pending requests has been reached
 	ErrTooManyPendingRequests
+	// ErrNotFound is returned when requested record is not found
+	ErrNotFound
+	// ErrWriteQuotaExceeded is returned when caller has exceeded its write quota in current pulse
+	ErrWriteQuotaExceeded
+	// ErrRequestOutOfOrder is registered as result of request which arrived after later requests were executed
+	ErrRequestOutOfOrder
+	// ErrNonceUsed is returned when signed request with the same nonce is already registered for object
+	ErrNonceUsed
 )
 
 func getEmptyReply(t core.ReplyType) (core.Reply, error) {
@@ -136,41 +168,70 @@ func getEmptyReply(t core.ReplyType) (core.Reply, error) {
 		return &Jet{}, nil
 	case TypeRequest:
 		return &Request{}, nil
+	case TypeResult:
+		return &Result{}, nil
 
 	case TypeNodeSign:
 		return &NodeSign{}, nil
+	case TypeNodeVersion:
+		return &NodeVersion{}, nil
+	case TypeBusy:
+		return &Busy{}, nil
+	case TypeTimeline:
+		return &Timeline{}, nil
+	case TypeLock:
+		return &Lock{}, nil
+	case TypeHotDataMigrated:
+		return &HotDataMigrated{}, nil
+	case TypeKeyValues:
+		return &KeyValues{}, nil
+	case TypeObjects:
+		return &Objects{}, nil
+	case TypeHeavySyncResumed:
+		return &HeavySyncResumed{}, nil
+	case TypeHeavyAck:
+		return &HeavyAck{}, nil
+	case TypeRestartSlots:
+		return &RestartSlots{}, nil
+	case TypeRemoteError:
+		return &RemoteError{}, nil
 
 	default:
 		return nil, errors.Errorf("unimplemented reply type: '%d'", t)
 	}
 }
 
-// Serialize returns encoded reply.
+// Serialize returns encoded reply wrapped into envelope. Unknown replies are written back as they were received.
 func Serialize(reply core.Reply) (io.Reader, error) {
 	buff := &bytes.Buffer{}
-	_, err := buff.Write([]byte{byte(reply.Type())})
+	if unknown, ok := reply.(*Unknown); ok {
+		err := writeEnvelope(buff, unknown.Version, unknown.ReplyType, unknown.Payload)
+		return buff, err
+	}
+
+	payload := &bytes.Buffer{}
+	enc := gob.NewEncoder(payload)
+	err := enc.Encode(reply)
 	if err != nil {
 		return nil, err
 	}
 
-	enc := gob.NewEncoder(buff)
-	err = enc.Encode(reply)
+	err = writeEnvelope(buff, EnvelopeVersion, reply.Type(), payload.Bytes())
 	return buff, err
 }
 
-// Deserialize returns decoded reply.
+// Deserialize returns decoded reply. Replies of unknown type or envelope version are returned as Unknown.
 func Deserialize(buff io.Reader) (core.Reply, error) {
-	b := make([]byte, 1)
-	_, err := buff.Read(b)
+	version, t, payload, err := readEnvelope(buff)
 	if err != nil {
-		return nil, errors.New("too short input to deserialize a message reply")
+		return nil, err
 	}
 
-	reply, err := getEmptyReply(core.ReplyType(b[0]))
-	if err != nil {
-		return nil, err
+	reply, err := getEmptyReply(t)
+	if err != nil || version > EnvelopeVersion {
+		return &Unknown{Version: version, ReplyType: t, Payload: payload}, nil
 	}
-	enc := gob.NewDecoder(buff)
+	enc := gob.NewDecoder(bytes.NewReader(payload))
 	err = enc.Decode(reply)
 	return reply, err
 }
@@ -202,6 +263,19 @@ func init() {
 	gob.Register(&HeavyError{})
 	gob.Register(&JetMiss{})
 	gob.Register(&NodeSign{})
+	gob.Register(&NodeVersion{})
+	gob.Register(&Busy{})
+	gob.Register(&Timeline{})
+	gob.Register(&Lock{})
 	gob.Register(&HasPendingRequests{})
 	gob.Register(&Request{})
+	gob.Register(&Result{})
+	gob.Register(&HotDataMigrated{})
+	gob.Register(&KeyValues{})
+	gob.Register(&Objects{})
+	gob.Register(&HeavySyncResumed{})
+	gob.Register(&HeavyAck{})
+	gob.Register(&RestartSlots{})
+	gob.Register(&RemoteError{})
+	gob.Register(&Unknown{})
 }
@@ -0,0 +1,67 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package reply
+
+import (
+	"bytes"
+	"compress/flate"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+)
+
+// CompressResult returns copy of reply with result compressed by deflate if result is at least threshold bytes
+// and compression makes it smaller, otherwise reply itself is returned. Zero threshold disables compression.
+// Result should be compressed only for callers which accept it, see message.CallMethod.AcceptsCompressed.
+func (r *CallMethod) CompressResult(threshold int) (*CallMethod, error) {
+	if threshold <= 0 || len(r.Result) < threshold || r.Compressed {
+		return r, nil
+	}
+
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.BestSpeed)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create compressor")
+	}
+	if _, err := w.Write(r.Result); err != nil {
+		return nil, errors.Wrap(err, "failed to compress result")
+	}
+	if err := w.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to compress result")
+	}
+	if buf.Len() >= len(r.Result) {
+		return r, nil
+	}
+
+	compressed := *r
+	compressed.Result = buf.Bytes()
+	compressed.Compressed = true
+	return &compressed, nil
+}
+
+// DecompressResult restores compressed result of reply in place.
+func (r *CallMethod) DecompressResult() error {
+	if !r.Compressed {
+		return nil
+	}
+	result, err := ioutil.ReadAll(flate.NewReader(bytes.NewReader(r.Result)))
+	if err != nil {
+		return errors.Wrap(err, "failed to decompress result")
+	}
+	r.Result = result
+	r.Compressed = false
+	return nil
+}
@@ -0,0 +1,50 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package reply
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallMethod_CompressResult(t *testing.T) {
+	result := bytes.Repeat([]byte("dump of users "), 1000)
+	r := &CallMethod{Result: result, Pulse: 42}
+
+	compressed, err := r.CompressResult(1024)
+	require.NoError(t, err)
+	require.True(t, compressed.Compressed)
+	require.True(t, len(compressed.Result) < len(result))
+	require.Equal(t, r.Pulse, compressed.Pulse)
+	require.Equal(t, result, r.Result, "original reply is left intact")
+
+	require.NoError(t, compressed.DecompressResult())
+	require.False(t, compressed.Compressed)
+	require.Equal(t, result, compressed.Result)
+
+	same, err := r.CompressResult(len(result) + 1)
+	require.NoError(t, err)
+	require.Equal(t, r, same)
+
+	same, err = r.CompressResult(0)
+	require.NoError(t, err)
+	require.Equal(t, r, same)
+
+	broken := &CallMethod{Result: []byte("not deflate"), Compressed: true}
+	require.Error(t, broken.DecompressResult())
+}
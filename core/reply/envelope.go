@@ -0,0 +1,127 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package reply
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/insolar/insolar/core"
+	"github.com/pkg/errors"
+)
+
+// EnvelopeVersion is a version of reply envelope written by this node.
+//
+// Envelope layout is stable across versions: magic byte, version byte, reply type byte, payload length
+// (uint32, big endian) and payload itself. Newer versions may change payload encoding only, so replies of unknown
+// versions and types can still be read and passed through.
+//
+// Replies of nodes which don't know envelopes start with reply type byte followed by gob payload. Magic byte is
+// never used as reply type, so such replies are read as legacy ones and decoded as envelope version 1. Legacy nodes
+// can't read enveloped replies, they fail on unknown reply type, so all nodes must be upgraded before mixed networks
+// exchange replies.
+const EnvelopeVersion byte = 1
+
+// envelopeMagic marks enveloped replies, it is out of range of reply types.
+const envelopeMagic byte = 0xFE
+
+const envelopeHeaderSize = 7
+
+// maxPayloadSize limits payload length to protect from corrupted and malicious envelopes. Replies travel in
+// network packets, which are limited to 128 Mb.
+const maxPayloadSize = 128 * 1024 * 1024
+
+// Unknown is a reply of type or envelope version unknown to this node. Its payload is preserved as is,
+// so the reply can be forwarded to nodes which know how to decode it.
+type Unknown struct {
+	Version   byte
+	ReplyType core.ReplyType
+	Payload   []byte
+}
+
+// Type implementation of Reply interface.
+func (e *Unknown) Type() core.ReplyType {
+	return e.ReplyType
+}
+
+func writeEnvelope(w io.Writer, version byte, t core.ReplyType, payload []byte) error {
+	header := make([]byte, envelopeHeaderSize)
+	header[0] = envelopeMagic
+	header[1] = version
+	header[2] = byte(t)
+	binary.BigEndian.PutUint32(header[3:], uint32(len(payload)))
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	_, err := w.Write(payload)
+	return err
+}
+
+func readEnvelope(r io.Reader) (version byte, t core.ReplyType, payload []byte, err error) {
+	first := make([]byte, 1)
+	if _, err = io.ReadFull(r, first); err != nil {
+		return 0, 0, nil, errors.New("too short input to deserialize a message reply")
+	}
+	if first[0] != envelopeMagic {
+		payload, err = readPayload(r, maxPayloadSize, false)
+		if err != nil {
+			return 0, 0, nil, err
+		}
+		return EnvelopeVersion, core.ReplyType(first[0]), payload, nil
+	}
+
+	header := make([]byte, envelopeHeaderSize-1)
+	if _, err = io.ReadFull(r, header); err != nil {
+		return 0, 0, nil, errors.New("too short input to deserialize a message reply")
+	}
+	version = header[0]
+	if version == 0 {
+		return 0, 0, nil, errors.New("bad reply envelope version")
+	}
+	size := binary.BigEndian.Uint32(header[2:])
+	if size > maxPayloadSize {
+		return 0, 0, nil, errors.Errorf("reply payload is too big: %d bytes", size)
+	}
+	payload, err = readPayload(r, int64(size), true)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	return version, core.ReplyType(header[1]), payload, nil
+}
+
+// readPayload reads at most size bytes, buffer grows with received data, so declared size doesn't make
+// allocation by itself. If exact is false, payload is read till the end of input, which must fit into size.
+func readPayload(r io.Reader, size int64, exact bool) ([]byte, error) {
+	limit := size
+	if !exact {
+		// one more byte to detect oversized payload
+		limit++
+	}
+	buf := &bytes.Buffer{}
+	n, err := buf.ReadFrom(io.LimitReader(r, limit))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read reply payload")
+	}
+	if n > size {
+		return nil, errors.Errorf("reply payload is bigger than %d bytes", size)
+	}
+	if exact && n < size {
+		return nil, errors.Wrap(io.ErrUnexpectedEOF, "failed to read reply payload")
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,109 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package reply
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+)
+
+func TestSerialize_RoundTrip(t *testing.T) {
+	rep := &NodeVersion{Version: "v1.0.0"}
+
+	b := ToBytes(rep)
+	require.Equal(t, envelopeMagic, b[0])
+	require.Equal(t, EnvelopeVersion, b[1])
+	require.Equal(t, byte(TypeNodeVersion), b[2])
+
+	out, err := Deserialize(bytes.NewReader(b))
+	require.NoError(t, err)
+	require.Equal(t, rep, out)
+}
+
+func TestSerialize_RemoteError(t *testing.T) {
+	coded := core.NewCodedError(core.ErrCodeNotAuthorized, "LogicRunner", "can't execute this object")
+
+	out, err := Deserialize(bytes.NewReader(ToBytes(NewRemoteError(coded))))
+	require.NoError(t, err)
+	remote, ok := out.(*RemoteError)
+	require.True(t, ok)
+	require.Equal(t, coded, remote.Error())
+	require.Equal(t, core.ErrCodeNotAuthorized, core.ErrorCodeOf(remote.Error()))
+}
+
+func TestDeserialize_UnknownType(t *testing.T) {
+	b := ToBytes(&NodeVersion{Version: "v1.0.0"})
+	b[2] = 0xFF
+
+	out, err := Deserialize(bytes.NewReader(b))
+	require.NoError(t, err)
+	unknown, ok := out.(*Unknown)
+	require.True(t, ok)
+	require.Equal(t, core.ReplyType(0xFF), unknown.Type())
+
+	// unknown reply is forwarded unchanged
+	require.Equal(t, b, ToBytes(unknown))
+}
+
+func TestDeserialize_NewerEnvelopeVersion(t *testing.T) {
+	b := ToBytes(&OK{})
+	b[1] = EnvelopeVersion + 1
+
+	out, err := Deserialize(bytes.NewReader(b))
+	require.NoError(t, err)
+	require.IsType(t, &Unknown{}, out)
+	require.Equal(t, TypeOK, out.Type())
+	require.Equal(t, b, ToBytes(out))
+}
+
+func TestDeserialize_Truncated(t *testing.T) {
+	b := ToBytes(&NodeVersion{Version: "v1.0.0"})
+
+	_, err := Deserialize(bytes.NewReader(b[:3]))
+	require.Error(t, err)
+	_, err = Deserialize(bytes.NewReader(b[:len(b)-1]))
+	require.Error(t, err)
+}
+
+func TestDeserialize_Legacy(t *testing.T) {
+	rep := &NodeVersion{Version: "v1.0.0"}
+	legacy := &bytes.Buffer{}
+	legacy.WriteByte(byte(TypeNodeVersion))
+	require.NoError(t, gob.NewEncoder(legacy).Encode(rep))
+
+	out, err := Deserialize(legacy)
+	require.NoError(t, err)
+	require.Equal(t, rep, out)
+}
+
+func TestDeserialize_TooBig(t *testing.T) {
+	b := ToBytes(&OK{})
+	binary.BigEndian.PutUint32(b[3:], maxPayloadSize+1)
+	_, err := Deserialize(bytes.NewReader(b))
+	require.Contains(t, err.Error(), "reply payload is too big")
+
+	// declared size isn't allocated before payload is received
+	binary.BigEndian.PutUint32(b[3:], maxPayloadSize)
+	_, err = Deserialize(bytes.NewReader(b))
+	require.Contains(t, err.Error(), "failed to read reply payload")
+}
@@ -16,7 +16,11 @@
 
 package reply
 
-import "github.com/insolar/insolar/core"
+import (
+	"bytes"
+
+	"github.com/insolar/insolar/core"
+)
 
 // OK is a generic reply for signaling a positive result.
 type OK struct {
@@ -59,7 +63,66 @@ func (e *Error) Error() error {
 		return core.ErrNoPendingRequest
 	case ErrTooManyPendingRequests:
 		return core.ErrTooManyPendingRequests
+	case ErrNotFound:
+		return core.ErrNotFound
+	case ErrWriteQuotaExceeded:
+		return core.ErrWriteQuotaExceeded
+	case ErrRequestOutOfOrder:
+		return core.ErrRequestOutOfOrder
+	case ErrNonceUsed:
+		return core.ErrNonceUsed
 	}
 
 	return core.ErrUnknown
 }
+
+// RemoteError is returned when handler of message failed on receiver node. It carries code of error so sender
+// can handle it without matching error text.
+type RemoteError struct {
+	Code      core.ErrorCode
+	Component string
+	Retryable bool
+	Message   string
+}
+
+// NewRemoteError wraps handler error into reply.
+func NewRemoteError(err *core.CodedError) *RemoteError {
+	return &RemoteError{
+		Code:      err.Code,
+		Component: err.Component,
+		Retryable: err.Retryable,
+		Message:   err.Message,
+	}
+}
+
+// Type implementation of Reply interface.
+func (e *RemoteError) Type() core.ReplyType {
+	return TypeRemoteError
+}
+
+// Error reconstructs error returned by remote handler.
+func (e *RemoteError) Error() error {
+	return &core.CodedError{
+		Code:      e.Code,
+		Component: e.Component,
+		Retryable: e.Retryable,
+		Message:   e.Message,
+	}
+}
+
+// DeactivatedResult returns payload of result registered for request which wasn't executed because preceding
+// request deactivated the object.
+func DeactivatedResult() []byte {
+	return ToBytes(&Error{ErrType: ErrDeactivated})
+}
+
+// IsDeactivatedResult checks if result payload was registered for request to deactivated object.
+func IsDeactivatedResult(payload []byte) bool {
+	return bytes.Equal(payload, DeactivatedResult())
+}
+
+// OutOfOrderResult returns payload of result registered for request which wasn't executed because it arrived
+// after requests numbered next to it were executed.
+func OutOfOrderResult() []byte {
+	return ToBytes(&Error{ErrType: ErrRequestOutOfOrder})
+}
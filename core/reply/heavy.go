@@ -18,6 +18,7 @@ package reply
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/insolar/insolar/core"
 )
@@ -54,3 +55,37 @@ func (e *HeavyError) Error() string {
 func (e *HeavyError) IsRetryable() bool {
 	return e.SubType == ErrHeavySyncInProgress
 }
+
+// HeavySyncResumed is reply for start of pulse sync which could be resumed after interruption.
+type HeavySyncResumed struct {
+	// Stored is number of payloads heavy already stored for pulse, sender skips them.
+	Stored int
+}
+
+// Type implementation of Reply interface.
+func (e *HeavySyncResumed) Type() core.ReplyType {
+	return TypeHeavySyncResumed
+}
+
+// HeavyAck is reply for stored payload of heavy sync.
+type HeavyAck struct {
+	// QueueDepth is number of payloads heavy is storing at the moment.
+	QueueDepth int
+	// Delay is suggested pause before the next payload, zero if heavy isn't congested.
+	Delay time.Duration
+}
+
+// Type implementation of Reply interface.
+func (e *HeavyAck) Type() core.ReplyType {
+	return TypeHeavyAck
+}
+
+// KeyValues carries intact Key/Value records requested for repair.
+type KeyValues struct {
+	Records []core.KV
+}
+
+// Type implementation of Reply interface.
+func (e *KeyValues) Type() core.ReplyType {
+	return TypeKeyValues
+}
@@ -24,6 +24,8 @@ import (
 type Code struct {
 	Code        []byte
 	MachineType core.MachineType
+	// Schema is a JSON schema of contract methods, nil if code was deployed without schema.
+	Schema []byte
 }
 
 // Type implementation of Reply interface.
@@ -40,6 +42,19 @@ type Object struct {
 	ChildPointer *core.RecordID
 	Memory       []byte
 	Parent       core.RecordRef
+	// Proof links state to ledger, it's attached when requested.
+	Proof *ObjectProof
+}
+
+// ObjectProof is a lightweight proof of object state fetched from ledger. Validators check it against state
+// recorded by executor instead of fetching the object again.
+type ObjectProof struct {
+	// Record is a serialized state record, its hash is a part of state id.
+	Record []byte
+	// Jet is a jet the state is stored in.
+	Jet core.RecordID
+	// DropHash is a hash of jet drop of state pulse, empty if the drop isn't created yet.
+	DropHash []byte
 }
 
 // Type implementation of Reply interface.
@@ -47,6 +62,17 @@ func (e *Object) Type() core.ReplyType {
 	return TypeObject
 }
 
+// Objects contains objects and codes fetched by GetObjects, ones which weren't found aren't included.
+type Objects struct {
+	Objects map[core.RecordRef]Object
+	Codes   map[core.RecordRef]Code
+}
+
+// Type implementation of Reply interface.
+func (e *Objects) Type() core.ReplyType {
+	return TypeObjects
+}
+
 // Delegate is delegate reference from storage.
 type Delegate struct {
 	Head core.RecordRef
@@ -60,6 +86,8 @@ func (e *Delegate) Type() core.ReplyType {
 // ID is common reaction for methods returning id to lifeline states.
 type ID struct {
 	ID core.RecordID
+	// Sequence is a number of registered request within its object, it's zero for other records.
+	Sequence uint64
 }
 
 // Type implementation of Reply interface.
@@ -129,3 +157,14 @@ type Request struct {
 func (r *Request) Type() core.ReplyType {
 	return TypeRequest
 }
+
+// Result contains result record registered for request.
+type Result struct {
+	ID     core.RecordID
+	Record []byte
+}
+
+// Type implementation of Reply interface.
+func (r *Result) Type() core.ReplyType {
+	return TypeResult
+}
@@ -0,0 +1,35 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package reply
+
+import (
+	"time"
+
+	"github.com/insolar/insolar/core"
+)
+
+// Lock is a result of named lock request.
+type Lock struct {
+	Acquired bool
+	// Expires is a time lock expires at if it's acquired, lock expires earlier if pulse changes.
+	Expires time.Time
+}
+
+// Type implementation of Reply interface.
+func (e *Lock) Type() core.ReplyType {
+	return TypeLock
+}
@@ -24,6 +24,12 @@ import (
 type CallMethod struct {
 	Request core.RecordRef
 	Result  []byte
+	// Pulse is a pulse number of execution.
+	Pulse core.PulseNumber
+	// Finality is a finality of result at the moment of reply.
+	Finality core.Finality
+	// Compressed is set if Result is compressed, see CompressResult.
+	Compressed bool
 }
 
 // Type returns type of the reply
@@ -48,3 +54,13 @@ type RegisterRequest struct {
 func (r *RegisterRequest) Type() core.ReplyType {
 	return TypeRegisterRequest
 }
+
+// Busy is returned by executor when its queues are saturated. Sender should retry request after RetryAfter pulses.
+type Busy struct {
+	RetryAfter int
+}
+
+// Type returns type of the reply
+func (r *Busy) Type() core.ReplyType {
+	return TypeBusy
+}
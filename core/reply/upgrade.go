@@ -0,0 +1,34 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package reply
+
+import (
+	"github.com/insolar/insolar/core"
+)
+
+// RestartSlots is a result of restart slot request.
+type RestartSlots struct {
+	// Decision is an answer to acquire request.
+	Decision *core.RestartDecision
+	// Slots are slots held by nodes, they are listed on list request.
+	Slots []core.RestartSlot
+}
+
+// Type implementation of Reply interface.
+func (e *RestartSlots) Type() core.ReplyType {
+	return TypeRestartSlots
+}
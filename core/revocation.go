@@ -0,0 +1,49 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package core
+
+import (
+	"context"
+)
+
+// RevocationList is a list of nodes whose certificates are revoked by operator of discovery node. Discovery nodes
+// sign their lists and distribute them to all nodes every pulse.
+type RevocationList struct {
+	// Pulse is a pulse list was signed in, lists which aren't refreshed for several pulses are dropped.
+	Pulse     PulseNumber
+	Signer    RecordRef
+	Revoked   []RecordRef
+	Signature []byte
+}
+
+// SignedData returns data signature of list is made for.
+func (l *RevocationList) SignedData() ([]byte, error) {
+	return MarshalArgs(l.Pulse, l.Signer, l.Revoked)
+}
+
+// CertificateRevocations checks certificates of nodes against revocation lists of discovery nodes, so operators
+// can expel compromised node without waiting for its certificate to expire.
+type CertificateRevocations interface {
+	// IsRevoked checks if certificate of node is revoked by majority of discovery nodes.
+	IsRevoked(node RecordRef) bool
+	// Revoke adds node to revocation list of this discovery node.
+	Revoke(ctx context.Context, node RecordRef) error
+	// Restore removes node from revocation list of this discovery node.
+	Restore(ctx context.Context, node RecordRef) error
+	// RevocationLists returns lists of discovery nodes known to this node.
+	RevocationLists() []RevocationList
+}
@@ -0,0 +1,64 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package core
+
+import (
+	"context"
+	"time"
+)
+
+// Task is a job periodically run by Scheduler.
+type Task func(ctx context.Context) error
+
+// TaskSchedule defines how often task is run. If both intervals are set, task is run when any of them passes.
+type TaskSchedule struct {
+	Every  time.Duration // interval of wall time, zero disables it
+	Pulses int           // interval in pulses, zero disables it
+}
+
+// PeriodicTask is a task with its name and schedule.
+type PeriodicTask struct {
+	Name     string
+	Schedule TaskSchedule
+	Run      Task
+}
+
+// PeriodicTasksProvider is implemented by components which need Scheduler to run their periodic tasks.
+type PeriodicTasksProvider interface {
+	PeriodicTasks() []PeriodicTask
+}
+
+// TaskInfo is a state of scheduled task.
+type TaskInfo struct {
+	Name      string
+	Schedule  TaskSchedule
+	Running   bool
+	Runs      int
+	LastRun   time.Time
+	LastPulse PulseNumber
+	LastError string
+}
+
+// Scheduler runs periodic tasks of the node.
+type Scheduler interface {
+	// Schedule registers task. Task names are unique.
+	Schedule(task PeriodicTask) error
+	// Tasks returns state of all registered tasks sorted by name.
+	Tasks() []TaskInfo
+	// Trigger runs task out of schedule and waits for it to finish.
+	Trigger(ctx context.Context, name string) error
+}
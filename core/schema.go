@@ -0,0 +1,103 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/ugorji/go/codec"
+)
+
+// FieldSchema describes an argument or a result of contract method.
+type FieldSchema struct {
+	// Name is a name of field in contract source, it's empty for unnamed results.
+	Name string `json:"name,omitempty"`
+	// Type is a Go type of field, e.g. "uint" or "core.RecordRef".
+	Type string `json:"type"`
+}
+
+// MethodSchema describes arguments and results of contract method or constructor.
+type MethodSchema struct {
+	Arguments []FieldSchema `json:"arguments"`
+	Results   []FieldSchema `json:"results"`
+}
+
+// ContractSchema describes arguments and results of contract methods and constructors. It's uploaded alongside
+// contract code, so explorers can decode calls into human-readable JSON without contract sources.
+type ContractSchema struct {
+	Methods map[string]MethodSchema `json:"methods"`
+}
+
+// SchemaRegistry resolves schemas of contracts by prototype references.
+type SchemaRegistry interface {
+	// Schema returns schema of contract code of prototype, ErrNotFound is returned if code was deployed without schema.
+	Schema(ctx context.Context, prototype RecordRef) (*ContractSchema, error)
+}
+
+// ParseContractSchema parses JSON schema stored alongside contract code.
+func ParseContractSchema(data []byte) (*ContractSchema, error) {
+	schema := &ContractSchema{}
+	if err := json.Unmarshal(data, schema); err != nil {
+		return nil, errors.Wrap(err, "failed to parse contract schema")
+	}
+	if len(schema.Methods) == 0 {
+		return nil, errors.New("contract schema has no methods")
+	}
+	return schema, nil
+}
+
+// DecodeArguments decodes CBOR serialized arguments of method call into map of argument names to values.
+func (s *ContractSchema) DecodeArguments(method string, args Arguments) (map[string]interface{}, error) {
+	m, ok := s.Methods[method]
+	if !ok {
+		return nil, errors.Errorf("method %s isn't described by schema", method)
+	}
+	return decodeFields(m.Arguments, args, "arg")
+}
+
+// DecodeResults decodes CBOR serialized results of method call into map of result names to values.
+// Unnamed results are named by position, e.g. "result0".
+func (s *ContractSchema) DecodeResults(method string, results []byte) (map[string]interface{}, error) {
+	m, ok := s.Methods[method]
+	if !ok {
+		return nil, errors.Errorf("method %s isn't described by schema", method)
+	}
+	return decodeFields(m.Results, results, "result")
+}
+
+func decodeFields(fields []FieldSchema, data []byte, prefix string) (map[string]interface{}, error) {
+	var values []interface{}
+	if err := codec.NewDecoderBytes(data, &codec.CborHandle{}).Decode(&values); err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize values")
+	}
+	if len(values) != len(fields) {
+		return nil, errors.Errorf("schema describes %d values, got %d", len(fields), len(values))
+	}
+
+	decoded := make(map[string]interface{}, len(fields))
+	for i, f := range fields {
+		name := f.Name
+		if name == "" {
+			name = fmt.Sprintf("%s%d", prefix, i)
+		}
+		decoded[name] = values[i]
+	}
+	return decoded, nil
+}
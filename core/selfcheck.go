@@ -0,0 +1,45 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package core
+
+import (
+	"context"
+)
+
+// ConsensusRounds provides participation of the node in consensus rounds.
+type ConsensusRounds interface {
+	// ConsensusStreak returns number of consecutive consensus rounds passed by the node, it is zero after failed round.
+	ConsensusStreak() int
+}
+
+// ReplicationMonitor provides lag of replication of node data.
+type ReplicationMonitor interface {
+	// ReplicationLag returns the largest number of pulses waiting for replication to heavy material node among jets.
+	ReplicationLag(ctx context.Context) int
+}
+
+// SendStats is a number of parcels sent by message bus and number of failed sends.
+type SendStats struct {
+	Sent   int
+	Failed int
+}
+
+// SendStatsProvider provides statistics of parcels sent by message bus.
+type SendStatsProvider interface {
+	// GetSendStats returns statistics of parcels sent during previous pulse.
+	GetSendStats() SendStats
+}
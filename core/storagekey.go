@@ -0,0 +1,45 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package core
+
+import (
+	"context"
+	"time"
+)
+
+// StorageKeyRotation is a progress of rotation of storage encryption key.
+type StorageKeyRotation struct {
+	// KeyID is an id of key values are re-encrypted with.
+	KeyID uint32
+	// Started is a time rotation was requested.
+	Started time.Time
+	// Finished is a time all values were re-encrypted, it's zero while rotation is in progress.
+	Finished time.Time
+	// Rewritten is a number of values re-encrypted so far.
+	Rewritten uint64
+	// Skipped is a number of values which can't be decoded and are left as is.
+	Skipped uint64
+}
+
+// StorageKeyRotator rotates key of at-rest encryption of ledger storage.
+type StorageKeyRotator interface {
+	// RotateKey makes new values be encrypted with key keyID right away and starts background re-encryption of
+	// existing values. Rotation survives restarts of node.
+	RotateKey(ctx context.Context, keyID uint32) (*StorageKeyRotation, error)
+	// KeyRotation returns progress of the last rotation, nil if key was never rotated.
+	KeyRotation(ctx context.Context) (*StorageKeyRotation, error)
+}
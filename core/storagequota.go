@@ -0,0 +1,45 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package core
+
+// StorageLevel is a level of exhaustion of free space of node storage.
+type StorageLevel uint8
+
+//go:generate stringer -type=StorageLevel
+const (
+	// StorageLevelNormal means storage has enough free space.
+	StorageLevelNormal StorageLevel = iota
+	// StorageLevelWarning means free space is below warning threshold, operator should free space.
+	StorageLevelWarning
+	// StorageLevelCritical means free space is below critical threshold, node stops taking executor and validator
+	// roles until space is freed.
+	StorageLevelCritical
+)
+
+// StorageMonitor watches free space of ledger storage.
+type StorageMonitor interface {
+	// StorageLevel returns level of storage exhaustion measured by the last check.
+	StorageLevel() StorageLevel
+	// Headroom returns free space of storage in percents measured by the last check.
+	Headroom() uint8
+}
+
+// RoleSuspensions provides nodes which suspended taking executor and validator roles because their storage is
+// exhausted. Suspensions are announced with claims in every consensus, so all nodes see the same suspended nodes.
+type RoleSuspensions interface {
+	// IsSuspended returns true if node announced suspension in the last consensus.
+	IsSuspended(node RecordRef) bool
+}
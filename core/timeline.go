@@ -0,0 +1,54 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package core
+
+import (
+	"context"
+	"time"
+)
+
+// TimelineStage is a stage of request processing.
+type TimelineStage string
+
+// Stages of request processing in order of occurrence.
+const (
+	TimelineAPIReceived       TimelineStage = "api_received"
+	TimelineRequestRegistered TimelineStage = "request_registered"
+	TimelineExecutionStarted  TimelineStage = "execution_started"
+	TimelineExecutionFinished TimelineStage = "execution_finished"
+	TimelineResultRegistered  TimelineStage = "result_registered"
+	TimelineValidationDone    TimelineStage = "validation_done"
+	TimelineAPIReplied        TimelineStage = "api_replied"
+)
+
+// TimelineEvent is an event of request processing observed by node.
+type TimelineEvent struct {
+	Time    time.Time
+	Node    RecordRef
+	Stage   TimelineStage
+	QID     string     // Query id of API request, empty for internal calls
+	Request *RecordRef // Reference of request, nil if it isn't known yet
+	Error   string
+}
+
+// Timeline is a journal of request processing events of node.
+type Timeline interface {
+	// Record adds event to journal. Node and time are set if not provided.
+	Record(ctx context.Context, event TimelineEvent)
+	// Events returns events with provided query id or request reference ordered by time.
+	Events(qid string, request *RecordRef) []TimelineEvent
+}
@@ -0,0 +1,28 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package core
+
+// TraceTargets is dynamic list of objects and members which flows are traced in full. Flow of parcel sent to or
+// by listed object is logged at debug level and all its spans are sampled, on every node the flow reaches.
+type TraceTargets interface {
+	// AddTraceTarget adds object or member to list.
+	AddTraceTarget(target RecordRef)
+	// RemoveTraceTarget removes object or member from list, returns false if it wasn't listed.
+	RemoveTraceTarget(target RecordRef) bool
+	// GetTraceTargets returns listed objects and members.
+	GetTraceTargets() []RecordRef
+}
@@ -0,0 +1,77 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package core
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// Transaction is a call of member method signed offline. Instead of seed issued by API node it carries nonce
+// chosen by signer and the last pulse it may be executed in, so it can be created on air-gapped machine and
+// submitted later by any third party. Ledger registers transaction with the same nonce on member once.
+//
+// Binary fields are base64 strings in JSON.
+type Transaction struct {
+	// Reference is reference of member the call is made on behalf of.
+	Reference string `json:"reference"`
+	Method    string `json:"method"`
+	// Params are serialized arguments of method.
+	Params []byte `json:"params"`
+	Nonce  uint64 `json:"nonce"`
+	// Expiry is the last pulse transaction may be executed in.
+	Expiry PulseNumber `json:"expiry"`
+	// Signature is signature of member key over SignedPayload.
+	Signature []byte `json:"signature"`
+}
+
+// TransactionMaxLifetime is a maximum number of pulses offline transaction may stay valid for.
+const TransactionMaxLifetime = 8640
+
+// transactionSeedTag marks seeds of offline transactions, seeds issued by API nodes have different size.
+var transactionSeedTag = []byte("tx")
+
+// TransactionSeedSize is a size of seed of offline transaction: tag, expiry pulse and nonce.
+const TransactionSeedSize = 2 + PulseNumberSize + 8
+
+// Seed returns seed transaction is signed with in place of seed issued by API node.
+func (t *Transaction) Seed() []byte {
+	seed := make([]byte, 0, TransactionSeedSize)
+	seed = append(seed, transactionSeedTag...)
+	seed = append(seed, t.Expiry.Bytes()...)
+	var nonce [8]byte
+	binary.BigEndian.PutUint64(nonce[:], t.Nonce)
+	return append(seed, nonce[:]...)
+}
+
+// SignedPayload returns bytes signed by member, they are the same as of call with seed issued by API node.
+func (t *Transaction) SignedPayload() ([]byte, error) {
+	ref, err := ParseRef(t.Reference)
+	if err != nil {
+		return nil, err
+	}
+	return MarshalArgs(*ref, t.Method, t.Params, t.Seed())
+}
+
+// TransactionSeedExpiry returns expiry pulse of seed of offline transaction, false if seed was issued by API node.
+func TransactionSeedExpiry(seed []byte) (PulseNumber, bool) {
+	if len(seed) != TransactionSeedSize || !bytes.HasPrefix(seed, transactionSeedTag) {
+		return 0, false
+	}
+	tagSize := len(transactionSeedTag)
+	return NewPulseNumber(seed[tagSize : tagSize+PulseNumberSize]), true
+}
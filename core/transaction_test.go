@@ -0,0 +1,51 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionSeed(t *testing.T) {
+	tx := Transaction{Nonce: 42, Expiry: FirstPulseNumber + 10}
+	seed := tx.Seed()
+	require.Len(t, seed, TransactionSeedSize)
+
+	expiry, ok := TransactionSeedExpiry(seed)
+	require.True(t, ok)
+	require.Equal(t, tx.Expiry, expiry)
+
+	other := tx
+	other.Nonce++
+	require.NotEqual(t, seed, other.Seed())
+
+	apiSeed := make([]byte, APISeedSize)
+	copy(apiSeed, seed)
+	_, ok = TransactionSeedExpiry(apiSeed)
+	require.False(t, ok)
+}
+
+func TestSignedNonceExpiry(t *testing.T) {
+	tx := Transaction{Nonce: 42, Expiry: FirstPulseNumber + 100}
+	require.Equal(t, tx.Expiry, SignedNonceExpiry(tx.Seed(), 10))
+
+	seed := make([]byte, APISeedSize)
+	BindAPISeed(seed, FirstPulseNumber, RecordRef{})
+	require.Equal(t, PulseNumber(FirstPulseNumber+APISeedMaxAge*10), SignedNonceExpiry(seed, 10))
+}
@@ -0,0 +1,51 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package core
+
+import (
+	"context"
+)
+
+// RestartSlot is a permission granted by the network to node to restart, e.g. for upgrade. Number of nodes of one
+// role holding slots at once is limited and light validators of the same jet never hold slots together.
+type RestartSlot struct {
+	Node RecordRef
+	Role StaticRole
+	// Granted is a pulse slot was granted in.
+	Granted PulseNumber
+	// Expires is a pulse slot is released in if node doesn't release it earlier.
+	Expires PulseNumber
+}
+
+// RestartDecision is an answer to node's intent to restart.
+type RestartDecision struct {
+	// Slot is a granted slot, nil if node should wait and ask again later.
+	Slot *RestartSlot
+	// Reason explains why slot isn't granted.
+	Reason string
+}
+
+// UpgradeCoordinator grants restart slots to nodes, so fleet upgrades can be automated without losing availability.
+// Slots are granted by the discovery node with the lowest reference among working ones.
+type UpgradeCoordinator interface {
+	// RequestRestart registers intent of this node to restart. Slot already granted to the node is returned again.
+	RequestRestart(ctx context.Context) (*RestartDecision, error)
+	// ReleaseRestart returns slot of this node, node should call it once it's back after restart.
+	ReleaseRestart(ctx context.Context) error
+	// RestartSlots returns slots held by nodes at the moment.
+	RestartSlots(ctx context.Context) ([]RestartSlot, error)
+}
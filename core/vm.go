@@ -62,6 +62,20 @@ type LogicRunner interface {
 	OnPulse(context.Context, Pulse) error
 }
 
+// ExecutionStateSummary describes execution state of object.
+type ExecutionStateSummary struct {
+	InPending             bool // execution of object is in progress on previous executor
+	PendingConfirmed      bool
+	QueueLength           int
+	LedgerHasMoreRequests bool
+}
+
+// ObjectStateResetter allows operators to unstick objects without restarting the node.
+type ObjectStateResetter interface {
+	// ResetExecutionState drops execution state of object and rebuilds it from ledger. Returns summary of dropped state.
+	ResetExecutionState(ctx context.Context, object RecordRef) (*ExecutionStateSummary, error)
+}
+
 // LogicCallContext is a context of contract execution
 type LogicCallContext struct {
 	Mode            string     // either "execution" or "validation"
@@ -73,6 +87,7 @@ type LogicCallContext struct {
 	Parent          *RecordRef // Parent of the callee
 	Caller          *RecordRef // Contract that made the call
 	Time            time.Time  // Time when call was made
-	Pulse           Pulse      // Number of the pulse
+	Pulse           Pulse      // Pulse request was registered in, validators get the same pulse from case bind
 	TraceID         string
+	APIRequest      *APIRequest // API request which initiated the call chain, nil for internal calls
 }
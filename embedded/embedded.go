@@ -0,0 +1,179 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+// Package embedded runs minimal insolar node inside another Go process, so backend services can call contracts
+// over MessageBus natively instead of going through HTTP API.
+//
+// Embedded node joins the network as a client: its certificate must have observer or API gateway role, so node never
+// takes executor or ledger roles and doesn't execute contracts.
+//
+// Usage:
+//
+//	node, err := embedded.New(ctx, cfg)
+//	...
+//	err = node.Start(ctx)
+//	...
+//	defer node.Stop(ctx)
+//	reply, err := node.Call(ctx, member, "GetBalance")
+package embedded
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/certificate"
+	"github.com/insolar/insolar/component"
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/cryptography"
+	"github.com/insolar/insolar/keystore"
+	"github.com/insolar/insolar/nodecomponents"
+	"github.com/insolar/insolar/platformpolicy"
+)
+
+// readyCheckInterval is an interval of network state checks while waiting for node to be ready.
+const readyCheckInterval = 100 * time.Millisecond
+
+// Node is an insolar node embedded into Go process.
+type Node struct {
+	cm   *component.Manager
+	deps *dependencies
+}
+
+// dependencies holds node components used by Node methods.
+type dependencies struct {
+	CertificateManager core.CertificateManager `inject:""`
+	ContractRequester  core.ContractRequester  `inject:""`
+	MessageBus         core.MessageBus         `inject:""`
+	NetworkSwitcher    core.NetworkSwitcher    `inject:""`
+	PulseStorage       core.PulseStorage       `inject:""`
+}
+
+// New creates embedded node from configuration. Keys and certificate are read from cfg.KeysPath and
+// cfg.CertificatePath, certificate role must be observer or API gateway.
+func New(ctx context.Context, cfg configuration.Configuration) (*Node, error) {
+	keyStore, err := keystore.NewKeyStore(cfg.KeysPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ embedded.New ] failed to load KeyStore")
+	}
+	platformCryptographyScheme := platformpolicy.NewPlatformCryptographyScheme()
+	keyProcessor := platformpolicy.NewKeyProcessor()
+	cryptographyService := cryptography.NewCryptographyService()
+
+	earlyComponents := component.Manager{}
+	earlyComponents.Register(platformCryptographyScheme, keyStore)
+	earlyComponents.Inject(cryptographyService, keyProcessor)
+
+	publicKey, err := cryptographyService.GetPublicKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "[ embedded.New ] failed to retrieve node public key")
+	}
+	certManager, err := certificate.NewManagerReadCertificate(publicKey, keyProcessor, cfg.CertificatePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ embedded.New ] failed to read certificate")
+	}
+	cert := certManager.GetCertificate()
+	if role := cert.GetRole(); role != core.StaticRoleObserver && role != core.StaticRoleAPIGateway {
+		return nil, errors.Errorf("[ embedded.New ] embedded node must have observer or API gateway role, got %s", role)
+	}
+
+	cm := &component.Manager{}
+	components, err := nodecomponents.Register(
+		ctx,
+		cfg,
+		cm,
+		cryptographyService,
+		platformCryptographyScheme,
+		keyStore,
+		keyProcessor,
+		certManager,
+		false,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ embedded.New ] failed to create node components")
+	}
+
+	deps := &dependencies{}
+	components = append(components, deps)
+	cm.Inject(components...)
+
+	return &Node{cm: cm, deps: deps}, nil
+}
+
+// Start initializes and starts node components, node connects to the network in background.
+// Use WaitReady to wait until node is able to make calls.
+func (n *Node) Start(ctx context.Context) error {
+	if err := n.cm.Init(ctx); err != nil {
+		return errors.Wrap(err, "[ Node.Start ] failed to init components")
+	}
+	if err := n.cm.Start(ctx); err != nil {
+		return errors.Wrap(err, "[ Node.Start ] failed to start components")
+	}
+	return nil
+}
+
+// Stop gracefully stops node.
+func (n *Node) Stop(ctx context.Context) error {
+	return n.cm.Stop(ctx)
+}
+
+// Ready returns true if node is connected to complete network and can make calls.
+func (n *Node) Ready() bool {
+	return n.deps.NetworkSwitcher.GetState() == core.CompleteNetworkState
+}
+
+// WaitReady blocks until node is ready or context is done.
+func (n *Node) WaitReady(ctx context.Context) error {
+	ticker := time.NewTicker(readyCheckInterval)
+	defer ticker.Stop()
+	for !n.Ready() {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// NodeRef returns reference of embedded node.
+func (n *Node) NodeRef() core.RecordRef {
+	return *n.deps.CertificateManager.GetCertificate().GetNodeRef()
+}
+
+// Call synchronously calls method of contract object and returns its reply.
+func (n *Node) Call(ctx context.Context, object core.RecordRef, method string, args ...interface{}) (core.Reply, error) {
+	if !n.Ready() {
+		return nil, errors.New("[ Node.Call ] node is not connected to the network")
+	}
+	return n.deps.ContractRequester.SendRequest(ctx, &object, method, args)
+}
+
+// CurrentPulse returns current pulse of the network.
+func (n *Node) CurrentPulse(ctx context.Context) (*core.Pulse, error) {
+	return n.deps.PulseStorage.Current(ctx)
+}
+
+// ContractRequester returns requester which sends calls of contracts to the network.
+func (n *Node) ContractRequester() core.ContractRequester {
+	return n.deps.ContractRequester
+}
+
+// MessageBus returns bus of the node for sending messages to the network directly.
+func (n *Node) MessageBus() core.MessageBus {
+	return n.deps.MessageBus
+}
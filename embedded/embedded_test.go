@@ -0,0 +1,47 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package embedded
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+)
+
+func TestNew_Observer(t *testing.T) {
+	ctx := context.Background()
+	cfg := configuration.NewConfiguration()
+	cfg.KeysPath = "testdata/keys.json"
+	cfg.CertificatePath = "testdata/certificate.json"
+	tmpDir, err := ioutil.TempDir("", "embedded-")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+	cfg.Ledger.Storage.DataDirectory = tmpDir
+
+	var node *Node
+	require.NotPanics(t, func() {
+		node, err = New(ctx, cfg)
+	})
+	require.NoError(t, err)
+	require.NotNil(t, node.ContractRequester())
+	require.NotNil(t, node.MessageBus())
+}
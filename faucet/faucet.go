@@ -0,0 +1,187 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+// Package faucet gives tokens of designated member to other members, so test environments don't need manual
+// transfers from root member. Faucet must be enabled only on test networks.
+package faucet
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/application/extractor"
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/platformpolicy"
+)
+
+// Faucet transfers tokens from faucet member with signed member calls.
+type Faucet struct {
+	ContractRequester   core.ContractRequester   `inject:""`
+	GenesisDataProvider core.GenesisDataProvider `inject:""`
+	PulseStorage        core.PulseStorage        `inject:""`
+	NodeNetwork         core.NodeNetwork         `inject:""`
+
+	cfg    configuration.Faucet
+	member core.RecordRef
+	signer core.Signer
+
+	lock  sync.Mutex
+	given map[core.RecordRef]time.Time
+}
+
+// New creates new faucet. Faucet member is loaded on start if faucet is enabled.
+func New(cfg configuration.Faucet) *Faucet {
+	return &Faucet{
+		cfg:   cfg,
+		given: map[core.RecordRef]time.Time{},
+	}
+}
+
+type memberFile struct {
+	Caller     string `json:"caller"`
+	PrivateKey string `json:"private_key"`
+}
+
+// Start loads reference and key of faucet member.
+func (f *Faucet) Start(ctx context.Context) error {
+	if !f.cfg.Enabled {
+		return nil
+	}
+
+	data, err := ioutil.ReadFile(filepath.Clean(f.cfg.MemberFile))
+	if err != nil {
+		return errors.Wrap(err, "[ Faucet.Start ] Can't read member file")
+	}
+	var mf memberFile
+	err = json.Unmarshal(data, &mf)
+	if err != nil {
+		return errors.Wrap(err, "[ Faucet.Start ] Can't parse member file")
+	}
+	member, err := core.NewRefFromBase58(mf.Caller)
+	if err != nil {
+		return errors.Wrap(err, "[ Faucet.Start ] Can't parse member reference")
+	}
+	key, err := platformpolicy.NewKeyProcessor().ImportPrivateKeyPEM([]byte(mf.PrivateKey))
+	if err != nil {
+		return errors.Wrap(err, "[ Faucet.Start ] Can't import member key")
+	}
+
+	f.member = *member
+	f.signer = platformpolicy.NewPlatformCryptographyScheme().Signer(key)
+	return nil
+}
+
+// Give transfers tokens to recipient. Recipient can get tokens once per configured period.
+func (f *Faucet) Give(ctx context.Context, to core.RecordRef, amount uint) (uint, error) {
+	if !f.cfg.Enabled {
+		return 0, errors.New("[ Give ] faucet is disabled")
+	}
+	if amount == 0 || amount > f.cfg.MaxAmount {
+		amount = f.cfg.MaxAmount
+	}
+
+	now := time.Now()
+	if !f.take(to, now) {
+		return 0, errors.Errorf("[ Give ] %s already got tokens, try again later", to)
+	}
+	err := f.transfer(ctx, to, amount)
+	if err != nil {
+		f.forget(to, now)
+		return 0, errors.Wrap(err, "[ Give ] Can't transfer tokens")
+	}
+	return amount, nil
+}
+
+// take reserves recipient's turn, turns of recipients which can get tokens again are forgotten.
+func (f *Faucet) take(to core.RecordRef, now time.Time) bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for ref, at := range f.given {
+		if now.Sub(at) >= f.cfg.Period {
+			delete(f.given, ref)
+		}
+	}
+	if _, ok := f.given[to]; ok {
+		return false
+	}
+	f.given[to] = now
+	return true
+}
+
+func (f *Faucet) forget(to core.RecordRef, at time.Time) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.given[to] == at {
+		delete(f.given, to)
+	}
+}
+
+// transfer calls Transfer of faucet member signed with member key.
+func (f *Faucet) transfer(ctx context.Context, to core.RecordRef, amount uint) error {
+	pulse, err := f.PulseStorage.Current(ctx)
+	if err != nil {
+		return errors.Wrap(err, "[ transfer ] Can't get current pulse")
+	}
+	seed := make([]byte, core.APISeedSize)
+	_, err = rand.Read(seed)
+	if err != nil {
+		return errors.Wrap(err, "[ transfer ] Can't generate seed")
+	}
+	core.BindAPISeed(seed, pulse.PulseNumber, f.NodeNetwork.GetOrigin().ID())
+
+	args, err := core.MarshalArgs(amount, to.String())
+	if err != nil {
+		return errors.Wrap(err, "[ transfer ] Can't marshal params")
+	}
+	params := []byte(args)
+	signed, err := core.MarshalArgs(f.member, "Transfer", params, seed)
+	if err != nil {
+		return errors.Wrap(err, "[ transfer ] Can't marshal signed request")
+	}
+	signature, err := f.signer.Sign(signed)
+	if err != nil {
+		return errors.Wrap(err, "[ transfer ] Can't sign request")
+	}
+
+	res, err := f.ContractRequester.SendRequest(
+		ctx,
+		&f.member,
+		"Call",
+		[]interface{}{*f.GenesisDataProvider.GetRootDomain(ctx), "Transfer", params, seed, signature.Bytes()},
+	)
+	if err != nil {
+		return errors.Wrap(err, "[ transfer ] Can't send request")
+	}
+	_, contractErr, err := extractor.CallResponse(res.(*reply.CallMethod).Result)
+	if err != nil {
+		return errors.Wrap(err, "[ transfer ] Can't extract response")
+	}
+	if contractErr != nil {
+		return errors.Wrap(errors.New(contractErr.S), "[ transfer ] Error in called method")
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package faucet
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/network/nodenetwork"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+	"github.com/insolar/insolar/testutils/network"
+)
+
+type genesisDataProvider struct {
+	rootDomain core.RecordRef
+}
+
+func (p genesisDataProvider) GetRootDomain(ctx context.Context) *core.RecordRef {
+	return &p.rootDomain
+}
+
+func (p genesisDataProvider) GetNodeDomain(ctx context.Context) (*core.RecordRef, error) {
+	return nil, nil
+}
+
+func (p genesisDataProvider) GetRootMember(ctx context.Context) (*core.RecordRef, error) {
+	return nil, nil
+}
+
+func TestFaucet_Give(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	member, rootDomain, recipient := testutils.RandomRef(), testutils.RandomRef(), testutils.RandomRef()
+
+	kp := platformpolicy.NewKeyProcessor()
+	key, err := kp.GeneratePrivateKey()
+	require.NoError(t, err)
+	pem, err := kp.ExportPrivateKeyPEM(key)
+	require.NoError(t, err)
+	data, err := json.Marshal(map[string]string{"caller": member.String(), "private_key": string(pem)})
+	require.NoError(t, err)
+	dir, err := ioutil.TempDir("", "faucet")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir) // nolint: errcheck
+	memberFile := filepath.Join(dir, "member.json")
+	require.NoError(t, ioutil.WriteFile(memberFile, data, 0600))
+
+	verifier := platformpolicy.NewPlatformCryptographyScheme().Verifier(kp.ExtractPublicKey(key))
+	var transferred []uint
+	fail := false
+	cr := testutils.NewContractRequesterMock(t)
+	cr.SendRequestFunc = func(ctx context.Context, ref *core.RecordRef, method string, args []interface{}) (core.Reply, error) {
+		if fail {
+			return nil, errors.New("test error")
+		}
+		require.Equal(t, member, *ref)
+		require.Equal(t, "Call", method)
+		require.Equal(t, rootDomain, args[0])
+		require.Equal(t, "Transfer", args[1])
+		params, seed, sign := args[2].([]byte), args[3].([]byte), args[4].([]byte)
+
+		signed, err := core.MarshalArgs(member, "Transfer", params, seed)
+		require.NoError(t, err)
+		require.True(t, verifier.Verify(core.SignatureFromBytes(sign), signed))
+		require.Equal(t, core.PulseNumber(core.FirstPulseNumber), core.APISeedPulse(seed))
+
+		var amount uint
+		var to string
+		require.NoError(t, core.Deserialize(params, &[]interface{}{&amount, &to}))
+		require.Equal(t, recipient.String(), to)
+		transferred = append(transferred, amount)
+
+		result, err := core.Serialize([]interface{}{nil, nil})
+		require.NoError(t, err)
+		return &reply.CallMethod{Result: result}, nil
+	}
+
+	ps := testutils.NewPulseStorageMock(t)
+	ps.CurrentMock.Return(core.GenesisPulse, nil)
+	nn := network.NewNodeNetworkMock(t)
+	nn.GetOriginMock.Return(nodenetwork.NewNode(testutils.RandomRef(), core.StaticRoleVirtual, nil, "127.0.0.1:1", ""))
+
+	f := New(configuration.Faucet{Enabled: true, MemberFile: memberFile, MaxAmount: 100, Period: time.Hour})
+	f.ContractRequester = cr
+	f.GenesisDataProvider = genesisDataProvider{rootDomain: rootDomain}
+	f.PulseStorage = ps
+	f.NodeNetwork = nn
+	require.NoError(t, f.Start(ctx))
+
+	amount, err := f.Give(ctx, recipient, 500)
+	require.NoError(t, err)
+	require.Equal(t, uint(100), amount, "amount is capped")
+
+	_, err = f.Give(ctx, recipient, 10)
+	require.Error(t, err, "recipient is rate limited")
+
+	// recipient can get tokens again after period
+	f.given[recipient] = time.Now().Add(-time.Hour)
+	fail = true
+	_, err = f.Give(ctx, recipient, 10)
+	require.Error(t, err)
+
+	// failed transfer doesn't count
+	fail = false
+	amount, err = f.Give(ctx, recipient, 10)
+	require.NoError(t, err)
+	require.Equal(t, uint(10), amount)
+	require.Equal(t, []uint{100, 10}, transferred)
+}
+
+func TestFaucet_Disabled(t *testing.T) {
+	f := New(configuration.NewFaucet())
+	require.NoError(t, f.Start(context.Background()))
+	_, err := f.Give(context.Background(), testutils.RandomRef(), 1)
+	require.EqualError(t, err, "[ Give ] faucet is disabled")
+}
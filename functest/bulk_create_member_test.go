@@ -0,0 +1,67 @@
+// +build functest
+
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package functest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkCreateMembers(t *testing.T) {
+	first, err := newUserWithKeys()
+	require.NoError(t, err)
+	second, err := newUserWithKeys()
+	require.NoError(t, err)
+
+	resp, err := signedRequest(&root, "BulkCreateMembers",
+		[]string{"Member1", "Member2"}, []string{first.pubKey, second.pubKey})
+	require.NoError(t, err)
+
+	data, err := base64.StdEncoding.DecodeString(resp.(string))
+	require.NoError(t, err)
+	var result []struct {
+		Name      string
+		Reference string
+		Error     string
+	}
+	require.NoError(t, json.Unmarshal(data, &result))
+	require.Len(t, result, 2)
+	for i, name := range []string{"Member1", "Member2"} {
+		require.Equal(t, name, result[i].Name)
+		require.Empty(t, result[i].Error)
+		require.NotEmpty(t, result[i].Reference)
+	}
+
+	first.ref = result[0].Reference
+	require.Equal(t, 1000*1000*1000, getBalanceNoErr(t, first, first.ref))
+}
+
+func TestBulkCreateMembersWrongKeysCount(t *testing.T) {
+	_, err := signedRequest(&root, "BulkCreateMembers", []string{"Member1", "Member2"}, []string{"000"})
+	require.Contains(t, err.Error(), "[ BulkCreateMembers ] Got 2 names and 1 keys")
+}
+
+func TestBulkCreateMembersByNoRoot(t *testing.T) {
+	member := createMember(t, "Member")
+	_, err := signedRequest(member, "BulkCreateMembers", []string{"Member1"}, []string{"000"})
+	require.Contains(t, err.Error(), "[ BulkCreateMembers ] Only Root member can create members")
+}
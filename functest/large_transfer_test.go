@@ -0,0 +1,107 @@
+// +build functest
+
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package functest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const largeAmount = 2000000
+
+func startLargeTransfer(t *testing.T, from *user, to *user) string {
+	res, err := signedRequest(from, "Transfer", largeAmount, to.ref)
+	require.NoError(t, err)
+	transferRef, ok := res.(string)
+	require.True(t, ok)
+	return transferRef
+}
+
+func getTransferStatus(t *testing.T, caller *user, transferRef string) string {
+	res, err := signedRequest(caller, "GetTransferStatus", transferRef)
+	require.NoError(t, err)
+	return res.(string)
+}
+
+func TestLargeTransfer(t *testing.T) {
+	firstMember := createMember(t, "Member1")
+	secondMember := createMember(t, "Member2")
+	oldFirstBalance := getBalanceNoErr(t, firstMember, firstMember.ref)
+
+	transferRef := startLargeTransfer(t, firstMember, secondMember)
+	require.Equal(t, "pending", getTransferStatus(t, firstMember, transferRef))
+	require.Equal(t, oldFirstBalance-largeAmount, getBalanceNoErr(t, firstMember, firstMember.ref))
+
+	_, err := signedRequest(firstMember, "ConfirmTransfer", transferRef)
+	require.NoError(t, err)
+	require.Equal(t, "confirmed", getTransferStatus(t, firstMember, transferRef))
+
+	_, err = signedRequest(firstMember, "ConfirmTransfer", transferRef)
+	require.Contains(t, err.Error(), "[ Confirm ] Transfer is already confirmed")
+}
+
+func TestLargeTransferConfirmByOtherMember(t *testing.T) {
+	firstMember := createMember(t, "Member1")
+	secondMember := createMember(t, "Member2")
+
+	transferRef := startLargeTransfer(t, firstMember, secondMember)
+
+	_, err := signedRequest(secondMember, "ConfirmTransfer", transferRef)
+	require.Contains(t, err.Error(), "[ Confirm ] Only owner wallet can manage pending transfer")
+	require.Equal(t, "pending", getTransferStatus(t, firstMember, transferRef))
+}
+
+func TestLargeTransferConfirmKey(t *testing.T) {
+	firstMember := createMember(t, "Member1")
+	secondMember := createMember(t, "Member2")
+	confirmer, err := newUserWithKeys()
+	require.NoError(t, err)
+	confirmer.ref = firstMember.ref
+
+	_, err = signedRequest(firstMember, "SetConfirmKey", confirmer.pubKey)
+	require.NoError(t, err)
+
+	transferRef := startLargeTransfer(t, firstMember, secondMember)
+
+	_, err = signedRequest(firstMember, "ConfirmTransfer", transferRef)
+	require.Contains(t, err.Error(), "Incorrect signature")
+
+	_, err = signedRequest(confirmer, "ConfirmTransfer", transferRef)
+	require.NoError(t, err)
+	require.Equal(t, "confirmed", getTransferStatus(t, firstMember, transferRef))
+}
+
+func TestLargeTransferConfirmKeyRotation(t *testing.T) {
+	member := createMember(t, "Member1")
+	confirmer, err := newUserWithKeys()
+	require.NoError(t, err)
+	confirmer.ref = member.ref
+	other, err := newUserWithKeys()
+	require.NoError(t, err)
+
+	_, err = signedRequest(member, "SetConfirmKey", confirmer.pubKey)
+	require.NoError(t, err)
+
+	_, err = signedRequest(member, "SetConfirmKey", other.pubKey)
+	require.Contains(t, err.Error(), "Incorrect signature")
+
+	_, err = signedRequest(confirmer, "SetConfirmKey", other.pubKey)
+	require.NoError(t, err)
+}
@@ -0,0 +1,57 @@
+// +build functest
+
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package functest
+
+import (
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetNetworkParameter(t *testing.T) {
+	_, err := signedRequest(&root, "SetNetworkParameter", core.NetworkParameterCallTimeout, "20s")
+	require.NoError(t, err)
+
+	params, err := signedRequest(&root, "GetNetworkParameters")
+	require.NoError(t, err)
+	require.Equal(t, "20s", params.(map[string]interface{})[core.NetworkParameterCallTimeout])
+
+	_, err = signedRequest(&root, "SetNetworkParameter", core.NetworkParameterCallTimeout, "")
+	require.NoError(t, err)
+
+	params, err = signedRequest(&root, "GetNetworkParameters")
+	require.NoError(t, err)
+	require.NotContains(t, params, core.NetworkParameterCallTimeout)
+}
+
+func TestSetNetworkParameterInvalid(t *testing.T) {
+	_, err := signedRequest(&root, "SetNetworkParameter", core.NetworkParameterMaxQueueLength, "many")
+	require.Contains(t, err.Error(), "MaxQueueLength must be non-negative integer")
+
+	_, err = signedRequest(&root, "SetNetworkParameter", "unknown", "1")
+	require.Contains(t, err.Error(), "Unknown network parameter unknown")
+}
+
+func TestSetNetworkParameterNoRoot(t *testing.T) {
+	member := createMember(t, "Member")
+
+	_, err := signedRequest(member, "SetNetworkParameter", core.NetworkParameterCallTimeout, "20s")
+	require.Contains(t, err.Error(), "[ SetNetworkParameter ] Only Root member can set network parameters")
+}
@@ -0,0 +1,58 @@
+// +build functest
+
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package functest
+
+import (
+	"testing"
+
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPrototypeByName(t *testing.T) {
+	ref, err := signedRequest(&root, "GetPrototypeByName", "member")
+	require.NoError(t, err)
+	require.NotEmpty(t, ref)
+
+	list, err := signedRequest(&root, "ListPrototypes")
+	require.NoError(t, err)
+	require.Equal(t, ref, list.(map[string]interface{})["member"])
+}
+
+func TestGetPrototypeUnknownName(t *testing.T) {
+	_, err := signedRequest(&root, "GetPrototypeByName", "unknown")
+	require.Contains(t, err.Error(), "[ GetPrototypeByName ] Prototype unknown is not registered")
+}
+
+func TestRegisterPrototype(t *testing.T) {
+	proto := testutils.RandomRef().String()
+	_, err := signedRequest(&root, "RegisterPrototype", "custom", proto)
+	require.NoError(t, err)
+
+	ref, err := signedRequest(&root, "GetPrototypeByName", "custom")
+	require.NoError(t, err)
+	require.Equal(t, proto, ref)
+}
+
+func TestRegisterPrototypeNoRoot(t *testing.T) {
+	member := createMember(t, "Member")
+
+	_, err := signedRequest(member, "RegisterPrototype", "custom", testutils.RandomRef().String())
+	require.Contains(t, err.Error(), "[ RegisterPrototype ] Only Root member can register prototypes")
+}
@@ -131,7 +131,7 @@ func TestTransferMoreThanAvailableAmount(t *testing.T) {
 	amount := oldFirstBalance + 100
 
 	_, err := signedRequest(firstMember, "Transfer", amount, secondMember.ref)
-	require.Contains(t, err.Error(), "[ Transfer ] Not enough balance for transfer: subtrahend must be smaller than minuend")
+	require.Contains(t, err.Error(), "Not enough balance for transfer: subtrahend must be smaller than minuend")
 
 	newFirstBalance := getBalanceNoErr(t, firstMember, firstMember.ref)
 	newSecondBalance := getBalanceNoErr(t, secondMember, secondMember.ref)
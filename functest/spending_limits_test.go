@@ -0,0 +1,54 @@
+// +build functest
+
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+
+package functest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpendingLimits(t *testing.T) {
+	firstMember := createMember(t, "Member1")
+	secondMember := createMember(t, "Member2")
+
+	_, err := signedRequest(firstMember, "SetSpendingLimits", 100, 150, 1000)
+	require.NoError(t, err)
+
+	_, err = signedRequest(firstMember, "Transfer", 101, secondMember.ref)
+	require.Contains(t, err.Error(), "Transfer amount 101 exceeds limit 100")
+
+	_, err = signedRequest(firstMember, "Transfer", 100, secondMember.ref)
+	require.NoError(t, err)
+	_, err = signedRequest(firstMember, "Transfer", 60, secondMember.ref)
+	require.Contains(t, err.Error(), "Transfer amount 60 exceeds limit 150 per 1000 pulses, 100 is already spent")
+	_, err = signedRequest(firstMember, "Transfer", 50, secondMember.ref)
+	require.NoError(t, err)
+
+	res, err := signedRequest(firstMember, "GetSpendingLimits")
+	require.NoError(t, err)
+	limits := res.(map[string]interface{})
+	require.Equal(t, float64(150), limits["windowSpent"])
+
+	_, err = signedRequest(firstMember, "SetSpendingLimits", 0, 0, 0)
+	require.NoError(t, err)
+	_, err = signedRequest(firstMember, "Transfer", 200, secondMember.ref)
+	require.NoError(t, err)
+}
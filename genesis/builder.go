@@ -18,11 +18,13 @@ package genesis
 
 import (
 	"context"
+	"encoding/json"
 	"go/build"
 	"io/ioutil"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"time"
 
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/core/message"
@@ -31,6 +33,8 @@ import (
 	"github.com/pkg/errors"
 )
 
+const insolarPackage = "github.com/insolar/insolar"
+
 // PrependGoPath prepends `path` to GOPATH environment variable
 // accounting for possibly for default value. Returns new value.
 // NOTE: that environment is not changed
@@ -68,6 +72,12 @@ type ContractsBuilder struct {
 	ArtifactManager core.ArtifactManager
 	Prototypes      map[string]*core.RecordRef
 	Codes           map[string]*core.RecordRef
+
+	// Sandbox makes builder run compiler with minimal environment without access to network and user's
+	// go caches, it should be set when contracts come from untrusted source.
+	Sandbox bool
+	// BuildTimeout limits time of compilation of single plugin, zero means no limit.
+	BuildTimeout time.Duration
 }
 
 // NewContractBuilder returns a new `ContractsBuilder`, takes in: path to tmp directory,
@@ -98,19 +108,19 @@ func (cb *ContractsBuilder) Clean() {
 // Build ...
 func (cb *ContractsBuilder) Build(ctx context.Context, contracts map[string]*preprocessor.ParsedFile, domain *core.RecordID) error {
 
-	domainRef := core.NewRecordRef(*domain, *domain)
+	if cb.Sandbox {
+		for name, code := range contracts {
+			if err := code.CheckUntrusted(); err != nil {
+				return errors.Wrapf(err, "[ Build ] Contract %q is rejected", name)
+			}
+		}
+	}
 
 	for name := range contracts {
-		protoID, err := cb.ArtifactManager.RegisterRequest(
-			ctx, *domainRef, &message.Parcel{Msg: &message.GenesisRequest{Name: name + "_proto"}},
-		)
+		err := cb.registerPrototype(ctx, name, domain)
 		if err != nil {
-			return errors.Wrap(err, "[ Build ] Can't RegisterRequest")
+			return err
 		}
-
-		protoRef := core.NewRecordRef(*domain, *protoID)
-		log.Debugf("Registered prototype %q for contract %q in %q", protoRef.String(), name, cb.root)
-		cb.Prototypes[name] = protoRef
 	}
 
 	for name, code := range contracts {
@@ -149,7 +159,7 @@ func (cb *ContractsBuilder) Build(ctx context.Context, contracts map[string]*pre
 
 	for name := range contracts {
 		log.Debugf("Building plugin for contract %q in %q", name, cb.root)
-		err := cb.plugin(name)
+		err := cb.plugin(ctx, name)
 		if err != nil {
 			return errors.Wrap(err, "[ Build ] Can't call plugin")
 		}
@@ -159,53 +169,129 @@ func (cb *ContractsBuilder) Build(ctx context.Context, contracts map[string]*pre
 		if err != nil {
 			return errors.Wrap(err, "[ Build ] Can't ReadFile")
 		}
-		codeReq, err := cb.ArtifactManager.RegisterRequest(
-			ctx, *domainRef, &message.Parcel{Msg: &message.GenesisRequest{Name: name + "_code"}},
-		)
+		acl, err := cb.prototypeACL(contracts[name])
 		if err != nil {
-			return errors.Wrap(err, "[ Build ] Can't RegisterRequest")
+			return errors.Wrapf(err, "[ Build ] Can't make ACL of contract %q", name)
 		}
-
-		log.Debugf("Deploying code for contract %q", name)
-		codeID, err := cb.ArtifactManager.DeployCode(
-			ctx,
-			*domainRef, *core.NewRecordRef(*domain, *codeReq),
-			pluginBinary, core.MachineTypeGoPlugin,
-		)
-		codeRef := core.NewRecordRef(*domain, *codeID)
+		schema, err := json.Marshal(contracts[name].Schema())
 		if err != nil {
-			return errors.Wrap(err, "[ Build ] Can't SetRecord")
+			return errors.Wrapf(err, "[ Build ] Can't marshal schema of contract %q", name)
 		}
-		_, err = cb.ArtifactManager.RegisterResult(ctx, *domainRef, *codeRef, nil)
+		err = cb.deployCode(ctx, name, pluginBinary, schema, domain, acl)
 		if err != nil {
-			return errors.Wrap(err, "[ Build ] Can't SetRecord")
+			return err
 		}
-		log.Debugf("Deployed code %q for contract %q in %q", codeRef.String(), name, cb.root)
-		cb.Codes[name] = codeRef
+	}
 
-		// FIXME: It's a temporary fix and should not be here. Ii will NOT work properly on production. Remove it ASAP!
-		_, err = cb.ArtifactManager.ActivatePrototype(
-			ctx,
-			*domainRef,
-			*cb.Prototypes[name],
-			*cb.ArtifactManager.GenesisRef(), // FIXME: Only bootstrap can do this!
-			*codeRef,
-			nil,
-		)
+	return nil
+}
+
+// DeployPlugin registers prebuilt plugin of contract on ledger and activates its prototype. Schema of contract
+// methods may be nil.
+func (cb *ContractsBuilder) DeployPlugin(
+	ctx context.Context, name string, pluginBinary []byte, schema []byte, domain *core.RecordID,
+) error {
+	if _, ok := cb.Prototypes[name]; !ok {
+		err := cb.registerPrototype(ctx, name, domain)
 		if err != nil {
-			return errors.Wrap(err, "[ Build ] Can't ActivatePrototype")
+			return err
 		}
-		_, err = cb.ArtifactManager.RegisterResult(ctx, *domainRef, *cb.Prototypes[name], nil)
-		if err != nil {
-			return errors.Wrap(err, "[ Build ] Can't RegisterResult of prototype")
+	}
+	return cb.deployCode(ctx, name, pluginBinary, schema, domain, nil)
+}
+
+// prototypeACL resolves names of contracts in ACL declared by contract to prototype references
+// and serializes ACL to be stored in memory of prototype.
+func (cb *ContractsBuilder) prototypeACL(code *preprocessor.ParsedFile) ([]byte, error) {
+	declared := code.ACL()
+	if len(declared) == 0 {
+		return nil, nil
+	}
+
+	acl := core.PrototypeACL{}
+	for method, callers := range declared {
+		var methodACL core.MethodACL
+		for _, caller := range callers {
+			switch caller {
+			case core.ACLRoleAPI, core.ACLRoleParent:
+				methodACL.Roles = append(methodACL.Roles, caller)
+			default:
+				proto, ok := cb.Prototypes[caller]
+				if !ok {
+					return nil, errors.Errorf("unknown contract %q in ACL of method %s", caller, method)
+				}
+				methodACL.Prototypes = append(methodACL.Prototypes, *proto)
+			}
 		}
+		acl[method] = methodACL
+	}
+	return core.Serialize(acl)
+}
+
+func (cb *ContractsBuilder) registerPrototype(ctx context.Context, name string, domain *core.RecordID) error {
+	domainRef := core.NewRecordRef(*domain, *domain)
+	protoID, _, err := cb.ArtifactManager.RegisterRequest(
+		ctx, *domainRef, &message.Parcel{Msg: &message.GenesisRequest{Name: name + "_proto"}},
+	)
+	if err != nil {
+		return errors.Wrap(err, "[ Build ] Can't RegisterRequest")
+	}
+
+	protoRef := core.NewRecordRef(*domain, *protoID)
+	log.Debugf("Registered prototype %q for contract %q in %q", protoRef.String(), name, cb.root)
+	cb.Prototypes[name] = protoRef
+	return nil
+}
+
+func (cb *ContractsBuilder) deployCode(
+	ctx context.Context, name string, pluginBinary []byte, schema []byte, domain *core.RecordID, acl []byte,
+) error {
+	domainRef := core.NewRecordRef(*domain, *domain)
+	codeReq, _, err := cb.ArtifactManager.RegisterRequest(
+		ctx, *domainRef, &message.Parcel{Msg: &message.GenesisRequest{Name: name + "_code"}},
+	)
+	if err != nil {
+		return errors.Wrap(err, "[ Build ] Can't RegisterRequest")
+	}
+
+	log.Debugf("Deploying code for contract %q", name)
+	codeID, err := cb.ArtifactManager.DeployCode(
+		ctx,
+		*domainRef, *core.NewRecordRef(*domain, *codeReq),
+		pluginBinary, core.MachineTypeGoPlugin, schema,
+	)
+	if err != nil {
+		return errors.Wrap(err, "[ Build ] Can't SetRecord")
+	}
+	codeRef := core.NewRecordRef(*domain, *codeID)
+	_, err = cb.ArtifactManager.RegisterResult(ctx, *domainRef, *codeRef, nil)
+	if err != nil {
+		return errors.Wrap(err, "[ Build ] Can't SetRecord")
 	}
+	log.Debugf("Deployed code %q for contract %q in %q", codeRef.String(), name, cb.root)
+	cb.Codes[name] = codeRef
 
+	// FIXME: It's a temporary fix and should not be here. Ii will NOT work properly on production. Remove it ASAP!
+	_, err = cb.ArtifactManager.ActivatePrototype(
+		ctx,
+		*domainRef,
+		*cb.Prototypes[name],
+		*cb.ArtifactManager.GenesisRef(), // FIXME: Only bootstrap can do this!
+		*codeRef,
+		acl,
+	)
+	if err != nil {
+		return errors.Wrap(err, "[ Build ] Can't ActivatePrototype")
+	}
+	_, err = cb.ArtifactManager.RegisterResult(ctx, *domainRef, *cb.Prototypes[name], nil)
+	if err != nil {
+		return errors.Wrap(err, "[ Build ] Can't RegisterResult of prototype")
+	}
 	return nil
 }
 
 // Plugin ...
-func (cb *ContractsBuilder) plugin(name string) error {
+func (cb *ContractsBuilder) plugin(ctx context.Context, name string) error {
 	dstDir := filepath.Join(cb.root, "plugins")
 
 	err := os.MkdirAll(dstDir, 0777)
@@ -213,16 +299,74 @@ func (cb *ContractsBuilder) plugin(name string) error {
 		return errors.Wrap(err, "[ plugin ]")
 	}
 
-	cmd := exec.Command(
+	if cb.BuildTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cb.BuildTimeout)
+		defer cancel()
+	}
+	cmd := exec.CommandContext(
+		ctx,
 		"go", "build",
 		"-buildmode=plugin",
 		"-o", filepath.Join(dstDir, name+".so"),
 		filepath.Join(cb.root, "src/contract", name),
 	)
 	cmd.Env = append(os.Environ(), "GOPATH="+PrependGoPath(cb.root))
+	if cb.Sandbox {
+		cmd.Env, err = cb.sandboxEnv()
+		if err != nil {
+			return errors.Wrap(err, "[ plugin ]")
+		}
+	}
 	out, err := cmd.CombinedOutput()
 	if err != nil {
 		return errors.Wrap(err, "can't build contract: "+string(out))
 	}
 	return nil
 }
+
+// sandboxEnv returns environment of compiler which keeps all its files inside of builder directory and
+// forbids downloading of dependencies. Only insolar sources are visible in GOPATH, other packages of user's
+// GOPATH can't be imported. Plugin build mode requires cgo linker, so cgo can't be switched off here, instead
+// contract sources with cgo are rejected by Build and cgo flags from user's environment are never passed.
+func (cb *ContractsBuilder) sandboxEnv() ([]string, error) {
+	gopath, err := cb.sandboxGoPath()
+	if err != nil {
+		return nil, err
+	}
+	return []string{
+		"PATH=" + os.Getenv("PATH"),
+		"HOME=" + cb.root,
+		"TMPDIR=" + cb.root,
+		"GOPATH=" + gopath,
+		"GOCACHE=" + filepath.Join(cb.root, "cache"),
+		"GO111MODULE=off",
+		"GOPROXY=off",
+		"GOFLAGS=",
+		"CGO_CFLAGS=",
+		"CGO_CPPFLAGS=",
+		"CGO_CXXFLAGS=",
+		"CGO_LDFLAGS=",
+	}, nil
+}
+
+// sandboxGoPath makes GOPATH of builder directory and directory with link to insolar sources only.
+func (cb *ContractsBuilder) sandboxGoPath() (string, error) {
+	insolar, err := build.Import(insolarPackage, "", build.FindOnly)
+	if err != nil {
+		return "", errors.Wrap(err, "can't find insolar sources")
+	}
+	deps := filepath.Join(cb.root, "deps")
+	link := filepath.Join(deps, "src", insolarPackage)
+	if _, err := os.Lstat(link); os.IsNotExist(err) {
+		err = os.MkdirAll(filepath.Dir(link), 0775)
+		if err != nil {
+			return "", err
+		}
+		err = os.Symlink(insolar.Dir, link)
+		if err != nil {
+			return "", errors.Wrap(err, "can't link insolar sources")
+		}
+	}
+	return cb.root + string(os.PathListSeparator) + deps, nil
+}
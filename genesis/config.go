@@ -43,6 +43,10 @@ type Config struct {
 		HeavyMaterial uint `mapstructure:"heavy_material"`
 		LightMaterial uint `mapstructure:"light_material"`
 	} `mapstructure:"min_roles"`
+	LargeTransfer struct {
+		Threshold     uint `mapstructure:"threshold"`
+		ConfirmPulses uint `mapstructure:"confirm_pulses"`
+	} `mapstructure:"large_transfer"`
 	PulsarPublicKeys []string `mapstructure:"pulsar_public_keys"`
 	DiscoveryNodes   []Node   `mapstructure:"discovery_nodes"`
 	Nodes            []Node   `mapstructure:"nodes"`
@@ -48,10 +48,12 @@ const (
 	walletContract    = "wallet"
 	memberContract    = "member"
 	allowanceContract = "allowance"
+	pendingTransfer   = "pendingtransfer"
+	collectionEntry   = "entry"
 	nodeAmount        = 32
 )
 
-var contractNames = []string{walletContract, memberContract, allowanceContract, rootDomain, nodeDomain, nodeRecord}
+var contractNames = []string{walletContract, memberContract, allowanceContract, pendingTransfer, collectionEntry, rootDomain, nodeDomain, nodeRecord}
 
 type messageBusLocker interface {
 	Lock(ctx context.Context)
@@ -157,7 +159,7 @@ func (g *Genesis) activateNodeDomain(
 		return nil, errors.Wrap(err, "[ ActivateNodeDomain ]")
 	}
 
-	contractID, err := g.ArtifactManager.RegisterRequest(ctx, *g.rootDomainRef, &message.Parcel{Msg: &message.GenesisRequest{Name: "NodeDomain"}})
+	contractID, _, err := g.ArtifactManager.RegisterRequest(ctx, *g.rootDomainRef, &message.Parcel{Msg: &message.GenesisRequest{Name: "NodeDomain"}})
 
 	if err != nil {
 		return nil, errors.Wrap(err, "[ ActivateNodeDomain ] couldn't create nodedomain instance")
@@ -199,7 +201,7 @@ func (g *Genesis) activateRootMember(
 		return errors.Wrap(err, "[ ActivateRootMember ]")
 	}
 
-	contractID, err := g.ArtifactManager.RegisterRequest(ctx, *g.rootDomainRef, &message.Parcel{Msg: &message.GenesisRequest{Name: "RootMember"}})
+	contractID, _, err := g.ArtifactManager.RegisterRequest(ctx, *g.rootDomainRef, &message.Parcel{Msg: &message.GenesisRequest{Name: "RootMember"}})
 
 	if err != nil {
 		return errors.Wrap(err, "[ ActivateRootMember ] couldn't create root member instance")
@@ -229,7 +231,18 @@ func (g *Genesis) activateRootMember(
 func (g *Genesis) updateRootDomain(
 	ctx context.Context, domainDesc core.ObjectDescriptor,
 ) error {
-	updateData, err := serializeInstance(&rootdomain.RootDomain{RootMember: *g.rootMemberRef, NodeDomainRef: *g.nodeDomainRef})
+	prototypes := make(map[string]core.RecordRef, len(g.prototypeRefs))
+	for name, ref := range g.prototypeRefs {
+		prototypes[name] = *ref
+	}
+	updateData, err := serializeInstance(&rootdomain.RootDomain{
+		RootMember:    *g.rootMemberRef,
+		NodeDomainRef: *g.nodeDomainRef,
+		Prototypes:    prototypes,
+
+		LargeTransferThreshold: g.config.LargeTransfer.Threshold,
+		LargeTransferPulses:    g.config.LargeTransfer.ConfirmPulses,
+	})
 	if err != nil {
 		return errors.Wrap(err, "[ updateRootDomain ]")
 	}
@@ -261,7 +274,7 @@ func (g *Genesis) activateRootMemberWallet(
 		return errors.Wrap(err, "[ ActivateRootWallet ]")
 	}
 
-	contractID, err := g.ArtifactManager.RegisterRequest(ctx, *g.rootDomainRef, &message.Parcel{Msg: &message.GenesisRequest{Name: "RootWallet"}})
+	contractID, _, err := g.ArtifactManager.RegisterRequest(ctx, *g.rootDomainRef, &message.Parcel{Msg: &message.GenesisRequest{Name: "RootWallet"}})
 
 	if err != nil {
 		return errors.Wrap(err, "[ ActivateRootWallet ] couldn't create root wallet")
@@ -407,7 +420,7 @@ func (g *Genesis) activateNodeRecord(ctx context.Context, cb *ContractsBuilder,
 		return nil, errors.Wrap(err, "[ activateNodeRecord ] Couldn't serialize node instance")
 	}
 
-	nodeID, err := g.ArtifactManager.RegisterRequest(ctx, *g.rootDomainRef, &message.Parcel{Msg: &message.GenesisRequest{Name: name}})
+	nodeID, _, err := g.ArtifactManager.RegisterRequest(ctx, *g.rootDomainRef, &message.Parcel{Msg: &message.GenesisRequest{Name: name}})
 	if err != nil {
 		return nil, errors.Wrap(err, "[ activateNodeRecord ] Couldn't register request to artifact manager")
 	}
@@ -548,7 +561,8 @@ func (g *Genesis) uploadKeys(ctx context.Context, path string, amount int) ([]no
 }
 
 func (g *Genesis) registerGenesisRequest(ctx context.Context, name string) (*core.RecordID, error) {
-	return g.ArtifactManager.RegisterRequest(ctx, *g.ArtifactManager.GenesisRef(), &message.Parcel{Msg: &message.GenesisRequest{Name: name}})
+	id, _, err := g.ArtifactManager.RegisterRequest(ctx, *g.ArtifactManager.GenesisRef(), &message.Parcel{Msg: &message.GenesisRequest{Name: name}})
+	return id, err
 }
 
 // Start creates types and RootDomain instance
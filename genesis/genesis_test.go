@@ -35,9 +35,9 @@ const testDataPath = "gentestdata"
 
 func mockArtifactManager(t *testing.T) *testutils.ArtifactManagerMock {
 	amMock := testutils.NewArtifactManagerMock(t)
-	amMock.RegisterRequestFunc = func(p context.Context, p1 core.RecordRef, p2 core.Parcel) (r *core.RecordID, r1 error) {
+	amMock.RegisterRequestFunc = func(p context.Context, p1 core.RecordRef, p2 core.Parcel) (r *core.RecordID, r1 uint64, r2 error) {
 		id := testutils.RandomID()
-		return &id, nil
+		return &id, 0, nil
 	}
 	amMock.ActivateObjectFunc = func(p context.Context, p1 core.RecordRef, p2 core.RecordRef, p3 core.RecordRef, p4 core.RecordRef, p5 bool, p6 []byte) (r core.ObjectDescriptor, r1 error) {
 		return testutils.NewObjectDescriptorMock(t), nil
@@ -51,8 +51,8 @@ func mockArtifactManager(t *testing.T) *testutils.ArtifactManagerMock {
 
 func mockArtifactManagerWithRegisterRequestError(t *testing.T) *testutils.ArtifactManagerMock {
 	amMock := testutils.NewArtifactManagerMock(t)
-	amMock.RegisterRequestFunc = func(p context.Context, p1 core.RecordRef, p2 core.Parcel) (r *core.RecordID, r1 error) {
-		return nil, errors.New("test reasons")
+	amMock.RegisterRequestFunc = func(p context.Context, p1 core.RecordRef, p2 core.Parcel) (r *core.RecordID, r1 uint64, r2 error) {
+		return nil, 0, errors.New("test reasons")
 	}
 	return amMock
 }
@@ -210,9 +210,9 @@ func TestActivateNodeRecord_RegisterRequest_Err(t *testing.T) {
 
 func TestActivateNodeRecord_Activate_Err(t *testing.T) {
 	am := testutils.NewArtifactManagerMock(t)
-	am.RegisterRequestFunc = func(p context.Context, p1 core.RecordRef, p2 core.Parcel) (r *core.RecordID, r1 error) {
+	am.RegisterRequestFunc = func(p context.Context, p1 core.RecordRef, p2 core.Parcel) (r *core.RecordID, r1 uint64, r2 error) {
 		id := testutils.RandomID()
-		return &id, nil
+		return &id, 0, nil
 	}
 	am.ActivateObjectFunc = func(p context.Context, p1 core.RecordRef, p2 core.RecordRef, p3 core.RecordRef, p4 core.RecordRef, p5 bool, p6 []byte) (r core.ObjectDescriptor, r1 error) {
 		return nil, errors.New("test reasons")
@@ -237,9 +237,9 @@ func TestActivateNodeRecord_Activate_Err(t *testing.T) {
 
 func TestActivateNodeRecord_RegisterResult_Err(t *testing.T) {
 	am := testutils.NewArtifactManagerMock(t)
-	am.RegisterRequestFunc = func(p context.Context, p1 core.RecordRef, p2 core.Parcel) (r *core.RecordID, r1 error) {
+	am.RegisterRequestFunc = func(p context.Context, p1 core.RecordRef, p2 core.Parcel) (r *core.RecordID, r1 uint64, r2 error) {
 		id := testutils.RandomID()
-		return &id, nil
+		return &id, 0, nil
 	}
 	am.ActivateObjectFunc = func(p context.Context, p1 core.RecordRef, p2 core.RecordRef, p3 core.RecordRef, p4 core.RecordRef, p5 bool, p6 []byte) (r core.ObjectDescriptor, r1 error) {
 		return testutils.NewObjectDescriptorMock(t), nil
@@ -0,0 +1,179 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package crashreport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opencensus.io/stats"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/instrumentation/insmetrics"
+)
+
+// Report is a content of crash report file.
+type Report struct {
+	Time      time.Time
+	Component string
+	Pulse     core.PulseNumber
+	TraceID   string
+	Panic     string
+	Stack     string
+	LogTail   []string
+}
+
+// Reporter is a component which writes crash reports.
+type Reporter struct {
+	PulseStorage core.PulseStorage `inject:""`
+
+	cfg     configuration.CrashReport
+	logTail *LogTail
+	lock    sync.Mutex
+}
+
+// NewReporter creates new Reporter.
+func NewReporter(cfg configuration.CrashReport) *Reporter {
+	return &Reporter{
+		cfg:     cfg,
+		logTail: NewLogTail(cfg.LogTailSize),
+	}
+}
+
+// LogTail returns writer which should receive log output to include it in crash reports.
+func (r *Reporter) LogTail() *LogTail {
+	return r.logTail
+}
+
+// Init makes reporter the global one.
+func (r *Reporter) Init(ctx context.Context) error {
+	if r.cfg.Directory != "" {
+		if err := os.MkdirAll(r.cfg.Directory, 0755); err != nil {
+			return errors.Wrap(err, "[ Reporter.Init ] can't create crash reports directory")
+		}
+	}
+	SetReporter(r)
+	return nil
+}
+
+// Report saves crash report to file and returns its path.
+func (r *Reporter) Report(ctx context.Context, component string, recovered interface{}, stack []byte) (string, error) {
+	report := Report{
+		Time:      time.Now(),
+		Component: component,
+		TraceID:   inslogger.TraceID(ctx),
+		Panic:     fmt.Sprint(recovered),
+		Stack:     string(stack),
+		LogTail:   r.logTail.Lines(),
+	}
+	if r.PulseStorage != nil {
+		if pulse, err := r.PulseStorage.Current(ctx); err == nil {
+			report.Pulse = pulse.PulseNumber
+		}
+	}
+
+	if r.cfg.Directory == "" {
+		return "", nil
+	}
+
+	data, err := json.MarshalIndent(report, "", "    ")
+	if err != nil {
+		return "", errors.Wrap(err, "[ Reporter.Report ] can't marshal report")
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	name := fmt.Sprintf("crash-%s-%s.json", report.Time.Format("20060102-150405.000000000"), component)
+	path := filepath.Join(r.cfg.Directory, name)
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		return "", errors.Wrap(err, "[ Reporter.Report ] can't write report")
+	}
+	return path, nil
+}
+
+var (
+	globalReporter     *Reporter
+	globalReporterLock sync.RWMutex
+)
+
+// SetReporter sets the global reporter used by Recover functions.
+func SetReporter(r *Reporter) {
+	globalReporterLock.Lock()
+	globalReporter = r
+	globalReporterLock.Unlock()
+}
+
+func getReporter() *Reporter {
+	globalReporterLock.RLock()
+	defer globalReporterLock.RUnlock()
+	return globalReporter
+}
+
+func handle(ctx context.Context, component string, recovered interface{}) {
+	stack := debug.Stack()
+	stats.Record(insmetrics.InsertTag(ctx, tagComponent, component), statPanicsTotal.M(1))
+
+	logger := inslogger.FromContext(ctx)
+	logger.Errorf("[ %s ] recovered from panic: %v\n%s", component, recovered, stack)
+
+	r := getReporter()
+	if r == nil {
+		return
+	}
+	path, err := r.Report(ctx, component, recovered, stack)
+	if err != nil {
+		logger.Error("failed to write crash report: ", err)
+		return
+	}
+	if path != "" {
+		logger.Errorf("[ %s ] crash report is saved to %s", component, path)
+	}
+}
+
+// Recover recovers panic and reports it. Must be called with defer.
+func Recover(ctx context.Context, component string) {
+	if recovered := recover(); recovered != nil {
+		handle(ctx, component, recovered)
+	}
+}
+
+// RecoverError recovers panic, reports it and sets err. Must be called with defer.
+func RecoverError(ctx context.Context, component string, err *error) {
+	if recovered := recover(); recovered != nil {
+		handle(ctx, component, recovered)
+		*err = errors.Errorf("[ %s ] recovered from panic: %v", component, recovered)
+	}
+}
+
+// Go runs fn in new goroutine protected with Recover.
+func Go(ctx context.Context, component string, fn func()) {
+	go func() {
+		defer Recover(ctx, component)
+		fn()
+	}()
+}
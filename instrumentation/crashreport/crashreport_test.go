@@ -0,0 +1,108 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package crashreport
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestLogTail(t *testing.T) {
+	tail := NewLogTail(3)
+	require.Empty(t, tail.Lines())
+
+	_, err := tail.Write([]byte("one\ntwo\nthr"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"one", "two"}, tail.Lines())
+
+	_, err = tail.Write([]byte("ee\nfour\n"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"two", "three", "four"}, tail.Lines())
+}
+
+func TestRecoverError(t *testing.T) {
+	SetReporter(nil)
+	fn := func() (err error) {
+		defer RecoverError(context.Background(), "test", &err)
+		panic("boom")
+	}
+	err := fn()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "boom")
+
+	fn = func() (err error) {
+		defer RecoverError(context.Background(), "test", &err)
+		return errors.New("regular")
+	}
+	require.EqualError(t, fn(), "regular")
+}
+
+func TestReporter_Report(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cfg := configuration.NewCrashReport()
+	cfg.Directory = dir
+	r := NewReporter(cfg)
+
+	ps := testutils.NewPulseStorageMock(t)
+	ps.CurrentMock.Return(&core.Pulse{PulseNumber: 42}, nil)
+	r.PulseStorage = ps
+
+	err = r.Init(ctx)
+	require.NoError(t, err)
+	defer SetReporter(nil)
+
+	_, err = r.LogTail().Write([]byte("last words\n"))
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer Recover(ctx, "testcomponent")
+		panic("boom")
+	}()
+	<-done
+
+	files, err := filepath.Glob(filepath.Join(dir, "crash-*-testcomponent.json"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	data, err := ioutil.ReadFile(files[0])
+	require.NoError(t, err)
+	report := Report{}
+	err = json.Unmarshal(data, &report)
+	require.NoError(t, err)
+	require.Equal(t, "testcomponent", report.Component)
+	require.Equal(t, "boom", report.Panic)
+	require.Equal(t, core.PulseNumber(42), report.Pulse)
+	require.Equal(t, []string{"last words"}, report.LogTail)
+	require.NotEmpty(t, report.Stack)
+}
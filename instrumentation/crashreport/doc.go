@@ -0,0 +1,40 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+/*
+Package crashreport provides panic recovery for long-running goroutines.
+
+Recovered panic is logged, counted in metrics and saved to crash report file
+with stack, component name, current pulse and recent log lines.
+
+Examples:
+
+	// protect goroutine, panic is recovered and reported
+	go func() {
+		defer crashreport.Recover(ctx, "LogicRunner.ProcessExecutionQueue")
+		...
+	}()
+
+	// same, but shorter
+	crashreport.Go(ctx, "LogicRunner.ProcessExecutionQueue", func() { ... })
+
+	// convert panic to error of handler
+	func (mb *MessageBus) doDeliver(ctx context.Context, msg core.Parcel) (rep core.Reply, err error) {
+		defer crashreport.RecoverError(ctx, "MessageBus.doDeliver", &err)
+		...
+	}
+*/
+package crashreport
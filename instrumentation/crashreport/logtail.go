@@ -0,0 +1,74 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package crashreport
+
+import (
+	"bytes"
+	"sync"
+)
+
+// LogTail is an io.Writer which keeps last written log lines in memory.
+type LogTail struct {
+	lock    sync.Mutex
+	lines   []string
+	next    int
+	full    bool
+	partial bytes.Buffer
+}
+
+// NewLogTail creates LogTail which keeps size last lines.
+func NewLogTail(size int) *LogTail {
+	if size < 1 {
+		size = 1
+	}
+	return &LogTail{lines: make([]string, size)}
+}
+
+// Write implements io.Writer.
+func (t *LogTail) Write(p []byte) (int, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for _, b := range p {
+		if b != '\n' {
+			t.partial.WriteByte(b)
+			continue
+		}
+		t.push(t.partial.String())
+		t.partial.Reset()
+	}
+	return len(p), nil
+}
+
+func (t *LogTail) push(line string) {
+	t.lines[t.next] = line
+	t.next = (t.next + 1) % len(t.lines)
+	if t.next == 0 {
+		t.full = true
+	}
+}
+
+// Lines returns kept lines, oldest first.
+func (t *LogTail) Lines() []string {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if !t.full {
+		return append([]string{}, t.lines[:t.next]...)
+	}
+	return append(append([]string{}, t.lines[t.next:]...), t.lines[:t.next]...)
+}
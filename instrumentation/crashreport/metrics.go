@@ -0,0 +1,50 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package crashreport
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"github.com/insolar/insolar/instrumentation/insmetrics"
+)
+
+var (
+	tagComponent = insmetrics.MustTagKey("component")
+)
+
+var (
+	statPanicsTotal = stats.Int64(
+		"crashreport/panics/count",
+		"number of recovered panics",
+		stats.UnitDimensionless,
+	)
+)
+
+func init() {
+	err := insmetrics.Register(
+		&view.View{
+			Measure:     statPanicsTotal,
+			Aggregation: view.Sum(),
+			TagKeys:     []tag.Key{tagComponent},
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+}
@@ -47,6 +47,17 @@ func WithField(ctx context.Context, key string, value string) (context.Context,
 	return SetLogger(ctx, l), l
 }
 
+// WithLevel returns context with copy of logger which logs at provided level regardless of level of other loggers,
+// and logger itself. Logger from context is kept on invalid level.
+func WithLevel(ctx context.Context, level string) (context.Context, core.Logger) {
+	l, err := getLogger(ctx).WithLevel(level)
+	if err != nil {
+		l = getLogger(ctx)
+		l.Error(err)
+	}
+	return SetLogger(ctx, l), l
+}
+
 // WithTraceField returns context with logger initialized with provided traceid value and logger itself.
 func WithTraceField(ctx context.Context, traceid string) (context.Context, core.Logger) {
 	ctx, err := utils.SetTraceID(ctx, traceid)
@@ -0,0 +1,141 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package insmetrics
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+// views keeps all views registered via Register, so they can be reconfigured later.
+var views = struct {
+	sync.Mutex
+	// registered maps view as it's declared to the view actually registered in opencensus, nil if it's disabled
+	registered map[*view.View]*view.View
+	order      []*view.View
+	policy     viewPolicy
+}{
+	registered: map[*view.View]*view.View{},
+}
+
+type viewPolicy struct {
+	namespace   string
+	disabled    []string
+	allowedTags map[string]bool
+}
+
+// Register registers views in opencensus according to policy set by Configure. All views should be registered with it
+// instead of view.Register, otherwise they can't be disabled.
+func Register(vs ...*view.View) error {
+	views.Lock()
+	defer views.Unlock()
+
+	for _, v := range vs {
+		applied := views.policy.apply(v)
+		if applied != nil {
+			if err := view.Register(applied); err != nil {
+				return err
+			}
+		}
+		views.registered[v] = applied
+		views.order = append(views.order, v)
+	}
+	return nil
+}
+
+// Configure disables views with names starting with one of disabled prefixes and drops tags missing in allowedTags
+// from the rest, so metrics are aggregated over them. Empty allowedTags allows all tags. Names are matched as they're
+// exported to Prometheus in namespace, e.g. "insolar_vm_execution" matches "vm/execution/..." views.
+// Views registered before are reconfigured, data collected by reconfigured views is reset.
+func Configure(namespace string, disabled []string, allowedTags []string) error {
+	views.Lock()
+	defer views.Unlock()
+
+	views.policy = viewPolicy{namespace: namespace, disabled: disabled}
+	if len(allowedTags) > 0 {
+		views.policy.allowedTags = map[string]bool{}
+		for _, t := range allowedTags {
+			views.policy.allowedTags[t] = true
+		}
+	}
+
+	for _, v := range views.order {
+		applied := views.policy.apply(v)
+		current := views.registered[v]
+		if current == applied {
+			continue
+		}
+		if current != nil {
+			view.Unregister(current)
+		}
+		if applied != nil {
+			if err := view.Register(applied); err != nil {
+				return err
+			}
+		}
+		views.registered[v] = applied
+	}
+	return nil
+}
+
+// apply returns view to register in opencensus or nil if view is disabled.
+func (p viewPolicy) apply(v *view.View) *view.View {
+	name := v.Name
+	if name == "" {
+		name = v.Measure.Name()
+	}
+	name = ExportedName(p.namespace, name)
+	for _, prefix := range p.disabled {
+		if strings.HasPrefix(name, prefix) {
+			return nil
+		}
+	}
+
+	if p.allowedTags == nil {
+		return v
+	}
+	keys := make([]tag.Key, 0, len(v.TagKeys))
+	for _, k := range v.TagKeys {
+		if p.allowedTags[k.Name()] {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == len(v.TagKeys) {
+		return v
+	}
+	restricted := *v
+	restricted.TagKeys = keys
+	return &restricted
+}
+
+// ExportedName returns name of metric as it's exported to Prometheus.
+func ExportedName(namespace string, name string) string {
+	sanitized := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return '_'
+	}, name)
+	if namespace == "" {
+		return sanitized
+	}
+	return namespace + "_" + sanitized
+}
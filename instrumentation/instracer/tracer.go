@@ -77,8 +77,40 @@ func GetBaggage(ctx context.Context) []Entry {
 	return val.([]Entry)
 }
 
+// traceTargetKey is baggage key of object or member flow is traced for.
+const traceTargetKey = "insTraceTarget"
+
+// WithTraceTarget marks flow of context as traced for target object or member. Target is stored in baggage to be
+// propagated with parcels to other nodes, all spans of flow are sampled and flow is logged at debug level.
+func WithTraceTarget(ctx context.Context, target string) context.Context {
+	entries := []Entry{{Key: traceTargetKey, Value: target}}
+	for _, e := range GetBaggage(ctx) {
+		if e.Key != traceTargetKey {
+			entries = append(entries, e)
+		}
+	}
+	ctx = SetBaggage(ctx, entries...)
+	ctx, _ = inslogger.WithLevel(ctx, "debug")
+	ctx, _ = inslogger.WithField(ctx, "trace_target", target)
+	return ctx
+}
+
+// TraceTarget returns object or member flow of context is traced for, empty string if flow isn't traced.
+func TraceTarget(ctx context.Context) string {
+	for _, e := range GetBaggage(ctx) {
+		if e.Key == traceTargetKey {
+			return e.Value
+		}
+	}
+	return ""
+}
+
 // StartSpan starts span with stored baggage and with parent span if find in context.
+// Spans of traced flows are always sampled.
 func StartSpan(ctx context.Context, name string, o ...trace.StartOption) (context.Context, *trace.Span) {
+	if TraceTarget(ctx) != "" {
+		o = append(o, trace.WithSampler(trace.AlwaysSample()))
+	}
 	parentSpan, haveParent := getParentSpan(ctx)
 	var (
 		spanctx context.Context
@@ -0,0 +1,69 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package nodeload
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"github.com/insolar/insolar/instrumentation/insmetrics"
+)
+
+var (
+	tagKind = insmetrics.MustTagKey("kind")
+)
+
+var (
+	statShed = stats.Int64(
+		"loadshedder/shed/count",
+		"number of low priority work items rejected because node is overloaded",
+		stats.UnitDimensionless,
+	)
+	statDelayed = stats.Int64(
+		"loadshedder/delayed/count",
+		"number of low priority work items admitted after waiting for overload to end",
+		stats.UnitDimensionless,
+	)
+	statOverloaded = stats.Int64(
+		"loadshedder/overloaded",
+		"1 if node is overloaded and sheds low priority work, 0 otherwise",
+		stats.UnitDimensionless,
+	)
+)
+
+func init() {
+	err := insmetrics.Register(
+		&view.View{
+			Measure:     statShed,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{tagKind},
+		},
+		&view.View{
+			Measure:     statDelayed,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{tagKind},
+		},
+		&view.View{
+			Measure:     statOverloaded,
+			Aggregation: view.LastValue(),
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,118 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+// Package nodeload measures load of the node process which is reported to the network every pulse.
+package nodeload
+
+import (
+	"context"
+	"math"
+	"runtime"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// QueueSizer is implemented by components which queue requests for execution.
+type QueueSizer interface {
+	QueueDepth() int
+}
+
+// Reporter implements core.NodeLoadReporter.
+type Reporter struct {
+	LogicRunner QueueSizer `inject:""`
+
+	storageDir string
+
+	lock     sync.Mutex
+	lastTime time.Time
+	lastCPU  time.Duration
+}
+
+// NewReporter creates Reporter, storage headroom is measured on file system of storageDir.
+func NewReporter(storageDir string) *Reporter {
+	return &Reporter{storageDir: storageDir}
+}
+
+// NodeLoad implements core.NodeLoadReporter. CPU usage is averaged since the previous call.
+func (r *Reporter) NodeLoad(ctx context.Context) core.NodeLoad {
+	logger := inslogger.FromContext(ctx)
+	load := core.NodeLoad{}
+
+	cpu, err := r.cpuUsage(time.Now())
+	if err != nil {
+		logger.Warn("[ NodeLoad ] failed to measure CPU usage: ", err)
+	}
+	load.CPU = cpu
+
+	headroom, err := StorageHeadroom(r.storageDir)
+	if err != nil {
+		logger.Warn("[ NodeLoad ] failed to measure storage headroom: ", err)
+	}
+	load.StorageHeadroom = headroom
+
+	if r.LogicRunner != nil {
+		load.QueueDepth = clampUint16(r.LogicRunner.QueueDepth())
+	}
+	return load
+}
+
+func (r *Reporter) cpuUsage(now time.Time) (uint8, error) {
+	var usage syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &usage); err != nil {
+		return 0, err
+	}
+	cpu := time.Duration(usage.Utime.Nano() + usage.Stime.Nano())
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	lastTime, lastCPU := r.lastTime, r.lastCPU
+	r.lastTime, r.lastCPU = now, cpu
+	if lastTime.IsZero() || !now.After(lastTime) {
+		return 0, nil
+	}
+	return percent(float64(cpu-lastCPU), float64(now.Sub(lastTime))*float64(runtime.NumCPU())), nil
+}
+
+// StorageHeadroom returns free space of file system of dir in percents.
+func StorageHeadroom(dir string) (uint8, error) {
+	var fs syscall.Statfs_t
+	if err := syscall.Statfs(dir, &fs); err != nil {
+		return 0, err
+	}
+	return percent(float64(fs.Bavail), float64(fs.Blocks)), nil
+}
+
+// percent returns part of total in percents in range [0, 100].
+func percent(part, total float64) uint8 {
+	if total <= 0 || part <= 0 {
+		return 0
+	}
+	return uint8(math.Min(100, math.Round(part/total*100)))
+}
+
+func clampUint16(v int) uint16 {
+	if v < 0 {
+		return 0
+	}
+	if v > math.MaxUint16 {
+		return math.MaxUint16
+	}
+	return uint16(v)
+}
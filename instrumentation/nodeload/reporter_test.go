@@ -0,0 +1,76 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package nodeload
+
+import (
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+type queueSizer int
+
+func (q queueSizer) QueueDepth() int {
+	return int(q)
+}
+
+func TestReporter_NodeLoad(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	r := NewReporter(os.TempDir())
+	r.LogicRunner = queueSizer(math.MaxUint16 + 1)
+
+	load := r.NodeLoad(ctx)
+	require.NoError(t, load.Validate())
+	require.Equal(t, uint16(math.MaxUint16), load.QueueDepth)
+	require.Equal(t, uint8(0), load.CPU, "first sample has nothing to compare to")
+
+	r.LogicRunner = queueSizer(3)
+	load = r.NodeLoad(ctx)
+	require.NoError(t, load.Validate())
+	require.Equal(t, uint16(3), load.QueueDepth)
+}
+
+func TestReporter_cpuUsage(t *testing.T) {
+	r := NewReporter(os.TempDir())
+	now := time.Now()
+	_, err := r.cpuUsage(now)
+	require.NoError(t, err)
+
+	cpu, err := r.cpuUsage(now)
+	require.NoError(t, err)
+	require.Equal(t, uint8(0), cpu, "no time passed since previous sample")
+}
+
+func TestPercent(t *testing.T) {
+	require.Equal(t, uint8(0), percent(1, 0))
+	require.Equal(t, uint8(0), percent(-1, 10))
+	require.Equal(t, uint8(25), percent(1, 4))
+	require.Equal(t, uint8(100), percent(5, 4))
+}
+
+func TestStorageHeadroom(t *testing.T) {
+	_, err := StorageHeadroom(os.TempDir())
+	require.NoError(t, err)
+
+	_, err = StorageHeadroom("/not/existing/dir")
+	require.Error(t, err)
+}
@@ -0,0 +1,132 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package nodeload
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/instrumentation/insmetrics"
+)
+
+// Shedder implements core.LoadShedder. Load of node is sampled periodically, while CPU usage or execution queue
+// depth exceed thresholds low priority work is rejected, consensus and executor duties are never shed.
+type Shedder struct {
+	LogicRunner QueueSizer `inject:""`
+
+	cfg configuration.LoadShedding
+	// probe has own CPU samples, Reporter averages CPU usage since the previous call.
+	probe *Reporter
+
+	lock       sync.RWMutex
+	overloaded bool
+	// relief is closed when overload is over, delayed work waits for it.
+	relief chan struct{}
+}
+
+// NewShedder creates new load Shedder.
+func NewShedder(cfg configuration.LoadShedding) *Shedder {
+	return &Shedder{
+		cfg:   cfg,
+		probe: NewReporter(""),
+	}
+}
+
+func (s *Shedder) enabled() bool {
+	return s.cfg.MaxCPU > 0 || s.cfg.MaxQueueDepth > 0
+}
+
+// PeriodicTasks returns sampling of node load to be run by scheduler.
+func (s *Shedder) PeriodicTasks() []core.PeriodicTask {
+	if !s.enabled() || s.cfg.SampleInterval <= 0 {
+		return nil
+	}
+	return []core.PeriodicTask{
+		{
+			Name:     "loadshedder.sample",
+			Schedule: core.TaskSchedule{Every: s.cfg.SampleInterval},
+			Run:      s.sample,
+		},
+	}
+}
+
+func (s *Shedder) sample(ctx context.Context) error {
+	cpu, err := s.probe.cpuUsage(time.Now())
+	if err != nil {
+		inslogger.FromContext(ctx).Warn("[ Shedder ] failed to measure CPU usage: ", err)
+	}
+	queue := 0
+	if s.LogicRunner != nil {
+		queue = s.LogicRunner.QueueDepth()
+	}
+	s.observe(ctx, cpu, queue)
+	return nil
+}
+
+// observe takes into account measured load of node.
+func (s *Shedder) observe(ctx context.Context, cpu uint8, queue int) {
+	overloaded := (s.cfg.MaxCPU > 0 && cpu >= s.cfg.MaxCPU) ||
+		(s.cfg.MaxQueueDepth > 0 && queue >= s.cfg.MaxQueueDepth)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	logger := inslogger.FromContext(ctx)
+	switch {
+	case overloaded && !s.overloaded:
+		logger.Warnf("[ Shedder ] node is overloaded (CPU %d%%, queue depth %d), low priority work is shed", cpu, queue)
+		s.relief = make(chan struct{})
+		stats.Record(ctx, statOverloaded.M(1))
+	case !overloaded && s.overloaded:
+		logger.Infof("[ Shedder ] node isn't overloaded anymore (CPU %d%%, queue depth %d)", cpu, queue)
+		close(s.relief)
+		stats.Record(ctx, statOverloaded.M(0))
+	}
+	s.overloaded = overloaded
+}
+
+// Admit implements core.LoadShedder. Low priority work waits for overload to end at most MaxDelay.
+func (s *Shedder) Admit(ctx context.Context, priority core.WorkPriority, kind string) bool {
+	if priority == core.PriorityCritical {
+		return true
+	}
+
+	s.lock.RLock()
+	overloaded, relief := s.overloaded, s.relief
+	s.lock.RUnlock()
+	if !overloaded {
+		return true
+	}
+
+	if s.cfg.MaxDelay > 0 {
+		select {
+		case <-relief:
+			stats.Record(insmetrics.InsertTag(ctx, tagKind, kind), statDelayed.M(1))
+			return true
+		case <-time.After(s.cfg.MaxDelay):
+		case <-ctx.Done():
+		}
+	}
+	stats.Record(insmetrics.InsertTag(ctx, tagKind, kind), statShed.M(1))
+	return false
+}
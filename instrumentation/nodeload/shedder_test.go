@@ -0,0 +1,78 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package nodeload
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+func TestShedder_PeriodicTasks(t *testing.T) {
+	require.Empty(t, NewShedder(configuration.NewLoadShedding()).PeriodicTasks(), "shedding is disabled by default")
+
+	cfg := configuration.NewLoadShedding()
+	cfg.MaxQueueDepth = 10
+	tasks := NewShedder(cfg).PeriodicTasks()
+	require.Len(t, tasks, 1)
+	require.Equal(t, cfg.SampleInterval, tasks[0].Schedule.Every)
+}
+
+func TestShedder_Admit(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	cfg := configuration.LoadShedding{MaxCPU: 80, MaxQueueDepth: 10}
+	s := NewShedder(cfg)
+
+	s.observe(ctx, 50, 5)
+	require.True(t, s.Admit(ctx, core.PriorityLow, "GetBalance"))
+
+	s.observe(ctx, 80, 5)
+	require.False(t, s.Admit(ctx, core.PriorityLow, "GetBalance"))
+	require.True(t, s.Admit(ctx, core.PriorityCritical, "consensus"))
+
+	s.observe(ctx, 50, 10)
+	require.False(t, s.Admit(ctx, core.PriorityLow, "pulses.List"))
+
+	s.observe(ctx, 50, 5)
+	require.True(t, s.Admit(ctx, core.PriorityLow, "pulses.List"))
+}
+
+func TestShedder_Admit_Delay(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	s := NewShedder(configuration.LoadShedding{MaxQueueDepth: 10, MaxDelay: time.Minute})
+	s.LogicRunner = queueSizer(10)
+	require.NoError(t, s.sample(ctx))
+
+	admitted := make(chan bool)
+	go func() {
+		admitted <- s.Admit(ctx, core.PriorityLow, "GetBalance")
+	}()
+	s.LogicRunner = queueSizer(1)
+	require.NoError(t, s.sample(ctx))
+	require.True(t, <-admitted, "delayed work is admitted when overload is over")
+
+	s.observe(ctx, 0, 10)
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	require.False(t, s.Admit(cancelled, core.PriorityLow, "GetBalance"))
+}
@@ -0,0 +1,313 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/crashreport"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// SignatureHeader is a HTTP header with base64 encoded signature of notification body made by node key.
+const SignatureHeader = "X-Insolar-Signature"
+
+// Notification is a JSON body of webhook request.
+type Notification struct {
+	Event   core.NotificationEvent `json:"event"`
+	Node    string                 `json:"node"`
+	Time    int64                  `json:"time"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+type delivery struct {
+	event     core.NotificationEvent
+	body      []byte
+	signature string
+}
+
+// Notifier sends signed JSON notifications about critical node events to webhooks configured by node operators.
+// Failed deliveries are retried, notifications which can't be delivered are logged as dead letters.
+type Notifier struct {
+	NodeNetwork         core.NodeNetwork         `inject:""`
+	CertificateManager  core.CertificateManager  `inject:""`
+	CryptographyService core.CryptographyService `inject:""`
+	NodeLoadReporter    core.NodeLoadReporter    `inject:""`
+
+	cfg    configuration.Notifier
+	client *http.Client
+	queue  chan delivery
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+
+	lock sync.Mutex
+	// workingNodes holds working nodes of previous pulse, nil before first check.
+	workingNodes map[core.RecordRef]struct{}
+	// rounds holds results of last consensus rounds, true is for failed round.
+	rounds []bool
+	// raised holds events whose conditions are still active, so notification is sent once per occurrence.
+	raised map[core.NotificationEvent]bool
+}
+
+// New creates new Notifier.
+func New(cfg configuration.Notifier) *Notifier {
+	return &Notifier{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+		queue:  make(chan delivery, cfg.QueueSize),
+		stop:   make(chan struct{}),
+		raised: make(map[core.NotificationEvent]bool),
+	}
+}
+
+func (n *Notifier) enabled() bool {
+	return len(n.cfg.URLs) > 0
+}
+
+// Start starts delivery of notifications.
+func (n *Notifier) Start(ctx context.Context) error {
+	if !n.enabled() {
+		inslogger.FromContext(ctx).Info("[ Notifier.Start ] notifications are disabled")
+		return nil
+	}
+
+	n.wg.Add(1)
+	go func() {
+		defer n.wg.Done()
+		defer crashreport.Recover(ctx, "Notifier")
+
+		for {
+			select {
+			case d := <-n.queue:
+				n.send(ctx, d)
+			case <-n.stop:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop stops delivery of notifications, queued notifications are dropped.
+func (n *Notifier) Stop(ctx context.Context) error {
+	close(n.stop)
+	n.wg.Wait()
+	return nil
+}
+
+// PeriodicTasks returns checks of node state to be run by scheduler.
+func (n *Notifier) PeriodicTasks() []core.PeriodicTask {
+	if !n.enabled() {
+		return nil
+	}
+	return []core.PeriodicTask{
+		{
+			Name:     "notifier.nodes",
+			Schedule: core.TaskSchedule{Pulses: 1},
+			Run:      n.checkNodes,
+		},
+		{
+			Name:     "notifier.resources",
+			Schedule: core.TaskSchedule{Every: n.cfg.CheckInterval},
+			Run:      n.checkResources,
+		},
+	}
+}
+
+// Notify queues notification for delivery, details are sent as is.
+func (n *Notifier) Notify(ctx context.Context, event core.NotificationEvent, details map[string]interface{}) {
+	if !n.enabled() {
+		return
+	}
+	logger := inslogger.FromContext(ctx)
+
+	notification := Notification{
+		Event:   event,
+		Time:    time.Now().Unix(),
+		Details: details,
+	}
+	if ref := n.CertificateManager.GetCertificate().GetNodeRef(); ref != nil {
+		notification.Node = ref.String()
+	}
+	body, err := json.Marshal(notification)
+	if err != nil {
+		logger.Error(errors.Wrapf(err, "[ Notifier ] can't marshal %s notification", event))
+		return
+	}
+	signature, err := n.CryptographyService.Sign(body)
+	if err != nil {
+		logger.Error(errors.Wrapf(err, "[ Notifier ] can't sign %s notification", event))
+		return
+	}
+
+	d := delivery{
+		event:     event,
+		body:      body,
+		signature: base64.StdEncoding.EncodeToString(signature.Bytes()),
+	}
+	select {
+	case n.queue <- d:
+	default:
+		logger.Errorf("[ Notifier ] dead letter: queue is full, %s notification is dropped: %s", event, body)
+	}
+}
+
+// ObserveConsensus takes into account result of consensus round and notifies when number of failed rounds
+// within configured window reaches threshold.
+func (n *Notifier) ObserveConsensus(ctx context.Context, pulse core.PulseNumber, err error) {
+	if n.cfg.ConsensusFailures <= 0 || n.cfg.ConsensusWindow <= 0 {
+		return
+	}
+
+	n.lock.Lock()
+	n.rounds = append(n.rounds, err != nil)
+	if len(n.rounds) > n.cfg.ConsensusWindow {
+		n.rounds = n.rounds[len(n.rounds)-n.cfg.ConsensusWindow:]
+	}
+	failures := 0
+	for _, failed := range n.rounds {
+		if failed {
+			failures++
+		}
+	}
+	rounds := len(n.rounds)
+	n.lock.Unlock()
+
+	details := map[string]interface{}{
+		"pulse":    pulse,
+		"failures": failures,
+		"rounds":   rounds,
+	}
+	if err != nil {
+		details["error"] = err.Error()
+	}
+	n.alert(ctx, core.NotificationConsensusFailures, failures >= n.cfg.ConsensusFailures, details)
+}
+
+// checkNodes notifies about nodes which were working in previous pulse and aren't working now.
+func (n *Notifier) checkNodes(ctx context.Context) error {
+	working := make(map[core.RecordRef]struct{})
+	for _, node := range n.NodeNetwork.GetWorkingNodes() {
+		working[node.ID()] = struct{}{}
+	}
+
+	n.lock.Lock()
+	previous := n.workingNodes
+	n.workingNodes = working
+	n.lock.Unlock()
+
+	for ref := range previous {
+		if _, ok := working[ref]; !ok {
+			n.Notify(ctx, core.NotificationNodeLeft, map[string]interface{}{"node": ref.String()})
+		}
+	}
+	return nil
+}
+
+// checkResources notifies when storage is nearly full or certificate expires soon.
+func (n *Notifier) checkResources(ctx context.Context) error {
+	if n.cfg.MinStorageHeadroom > 0 {
+		headroom := n.NodeLoadReporter.NodeLoad(ctx).StorageHeadroom
+		n.alert(ctx, core.NotificationStorageCapacity, headroom < n.cfg.MinStorageHeadroom, map[string]interface{}{
+			"headroom": headroom,
+		})
+	}
+
+	provider, ok := n.CertificateManager.GetCertificate().(core.CertificateExpirationProvider)
+	if ok && n.cfg.CertificateExpiration > 0 {
+		expiration := provider.GetExpiration()
+		expiring := !expiration.IsZero() && time.Until(expiration) < n.cfg.CertificateExpiration
+		n.alert(ctx, core.NotificationCertificateExpiring, expiring, map[string]interface{}{
+			"expires_at": expiration.Unix(),
+		})
+	}
+	return nil
+}
+
+// alert sends notification when condition becomes active. It's sent again only after condition was back to normal.
+func (n *Notifier) alert(ctx context.Context, event core.NotificationEvent, active bool, details map[string]interface{}) {
+	n.lock.Lock()
+	raised := n.raised[event]
+	n.raised[event] = active
+	n.lock.Unlock()
+
+	if active && !raised {
+		n.Notify(ctx, event, details)
+	}
+}
+
+// send delivers notification to all webhooks.
+func (n *Notifier) send(ctx context.Context, d delivery) {
+	logger := inslogger.FromContext(ctx)
+	for _, url := range n.cfg.URLs {
+		if err := n.deliver(ctx, url, d); err != nil {
+			logger.Errorf("[ Notifier ] dead letter: %s notification is not delivered to %s: %s, body: %s",
+				d.event, url, err, d.body)
+		}
+	}
+}
+
+// deliver posts notification to webhook retrying failed attempts with exponential backoff.
+func (n *Notifier) deliver(ctx context.Context, url string, d delivery) error {
+	delay := n.cfg.RetryDelay
+	var err error
+	for attempt := 0; attempt <= n.cfg.Retries; attempt++ {
+		if attempt > 0 {
+			inslogger.FromContext(ctx).Warnf("[ Notifier ] failed to deliver %s notification to %s, retrying in %s: %s",
+				d.event, url, delay, err)
+			select {
+			case <-time.After(delay):
+			case <-n.stop:
+				return errors.Wrap(err, "notifier is stopped")
+			}
+			delay *= 2
+		}
+		if err = n.post(url, d); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+func (n *Notifier) post(url string, d delivery) error {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(d.body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, d.signature)
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package notifier
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+	"github.com/insolar/insolar/testutils/network"
+)
+
+type loadReporter uint8
+
+func (r loadReporter) NodeLoad(ctx context.Context) core.NodeLoad {
+	return core.NodeLoad{StorageHeadroom: uint8(r)}
+}
+
+type expiringCertificate struct {
+	core.Certificate
+	expiration time.Time
+}
+
+func (c expiringCertificate) GetExpiration() time.Time {
+	return c.expiration
+}
+
+func newTestNotifier(t *testing.T, cfg configuration.Notifier, cert core.Certificate) *Notifier {
+	n := New(cfg)
+	certManager := testutils.NewCertificateManagerMock(t)
+	certManager.GetCertificateMock.Return(cert)
+	n.CertificateManager = certManager
+	cs := testutils.NewCryptographyServiceMock(t)
+	cs.SignFunc = func(data []byte) (*core.Signature, error) {
+		signature := core.SignatureFromBytes(append([]byte("signed:"), data...))
+		return &signature, nil
+	}
+	n.CryptographyService = cs
+	return n
+}
+
+func TestNotifier_Deliver(t *testing.T) {
+	ctx := context.Background()
+	node := testutils.RandomRef()
+	cert := testutils.NewCertificateMock(t)
+	cert.GetNodeRefMock.Return(&node)
+
+	var attempts int32
+	received := make(chan Notification, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		signature, err := base64.StdEncoding.DecodeString(r.Header.Get(SignatureHeader))
+		require.NoError(t, err)
+		require.Equal(t, append([]byte("signed:"), body...), signature)
+
+		var notification Notification
+		require.NoError(t, json.Unmarshal(body, &notification))
+		received <- notification
+	}))
+	defer server.Close()
+
+	cfg := configuration.NewNotifier()
+	cfg.URLs = []string{server.URL}
+	cfg.RetryDelay = time.Millisecond
+	n := newTestNotifier(t, cfg, cert)
+	require.NoError(t, n.Start(ctx))
+	defer n.Stop(ctx)
+
+	n.Notify(ctx, core.NotificationStorageCapacity, map[string]interface{}{"headroom": 5})
+	select {
+	case notification := <-received:
+		require.Equal(t, core.NotificationStorageCapacity, notification.Event)
+		require.Equal(t, node.String(), notification.Node)
+		require.Equal(t, map[string]interface{}{"headroom": float64(5)}, notification.Details)
+	case <-time.After(5 * time.Second):
+		t.Fatal("notification is not delivered")
+	}
+	require.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+}
+
+func TestNotifier_Checks(t *testing.T) {
+	ctx := context.Background()
+	node := testutils.RandomRef()
+	certMock := testutils.NewCertificateMock(t)
+	certMock.GetNodeRefMock.Return(&node)
+	cert := expiringCertificate{Certificate: certMock, expiration: time.Now().Add(time.Hour)}
+	cfg := configuration.NewNotifier()
+	cfg.URLs = []string{"http://localhost"}
+	cfg.ConsensusFailures = 2
+	cfg.ConsensusWindow = 3
+	cfg.MinStorageHeadroom = 10
+	n := newTestNotifier(t, cfg, cert)
+	n.NodeLoadReporter = loadReporter(5)
+
+	left := network.NewNodeMock(t)
+	left.IDMock.Return(testutils.RandomRef())
+	stayed := network.NewNodeMock(t)
+	stayed.IDMock.Return(testutils.RandomRef())
+	nodeNetwork := network.NewNodeNetworkMock(t)
+	nodeNetwork.GetWorkingNodesMock.Return([]core.Node{left, stayed})
+	n.NodeNetwork = nodeNetwork
+
+	events := func() []core.NotificationEvent {
+		var result []core.NotificationEvent
+		for len(n.queue) > 0 {
+			d := <-n.queue
+			result = append(result, d.event)
+		}
+		return result
+	}
+
+	require.NoError(t, n.checkResources(ctx))
+	require.NoError(t, n.checkResources(ctx))
+	require.Equal(t, []core.NotificationEvent{
+		core.NotificationStorageCapacity, core.NotificationCertificateExpiring,
+	}, events())
+
+	require.NoError(t, n.checkNodes(ctx))
+	nodeNetwork.GetWorkingNodesMock.Return([]core.Node{stayed})
+	require.NoError(t, n.checkNodes(ctx))
+	require.Equal(t, []core.NotificationEvent{core.NotificationNodeLeft}, events())
+
+	n.ObserveConsensus(ctx, 1, errors.New("failed"))
+	n.ObserveConsensus(ctx, 2, nil)
+	require.Empty(t, events())
+	n.ObserveConsensus(ctx, 3, errors.New("failed"))
+	n.ObserveConsensus(ctx, 4, errors.New("failed"))
+	require.Equal(t, []core.NotificationEvent{core.NotificationConsensusFailures}, events())
+}
+
+func TestNotifier_Disabled(t *testing.T) {
+	n := New(configuration.NewNotifier())
+	require.Empty(t, n.PeriodicTasks())
+	n.Notify(context.Background(), core.NotificationNodeLeft, nil)
+	require.Empty(t, n.queue)
+}
@@ -0,0 +1,153 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+// Package resources sizes the process to CPUs available to it, which are limited by CPU quota in containers.
+package resources
+
+import (
+	"context"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// executorWorkersPerCPU is a number of contract executions per CPU, executions spend much time waiting for ledger.
+const executorWorkersPerCPU = 4
+
+// cgroupRoot is a mount point of cgroup file system.
+const cgroupRoot = "/sys/fs/cgroup"
+
+// Limits is a sizing of the process derived from available CPUs and configuration.
+type Limits struct {
+	// CPUs is a number of CPUs available to the process, CPU quota is rounded up.
+	CPUs int
+	// MaxProcs is a value of GOMAXPROCS set.
+	MaxProcs int
+	// ExecutorWorkers is a max number of contract executions running at once.
+	ExecutorWorkers int
+	// VerifyWorkers is a number of workers verifying signatures of consensus packets.
+	VerifyWorkers int
+	// ReplicationWorkers is a max number of jets replicated to heavy at once.
+	ReplicationWorkers int
+}
+
+// Apply detects CPUs available to the process, sets GOMAXPROCS and fills worker pool sizes left zero in cfg.
+// Values set in configuration are kept.
+func Apply(ctx context.Context, cfg *configuration.Configuration) Limits {
+	limits := Derive(cfg, AvailableCPUs(cgroupRoot))
+	previous := runtime.GOMAXPROCS(limits.MaxProcs)
+
+	cfg.LogicRunner.ExecutorWorkers = limits.ExecutorWorkers
+	cfg.Service.Consensus.VerifyWorkers = limits.VerifyWorkers
+	cfg.Ledger.PulseManager.HeavySyncConcurrency = limits.ReplicationWorkers
+
+	inslogger.FromContext(ctx).Infof(
+		"[ Resources ] CPUs: %d, GOMAXPROCS: %d (was %d), executor workers: %d, verify workers: %d, "+
+			"replication workers: %d",
+		limits.CPUs, limits.MaxProcs, previous, limits.ExecutorWorkers, limits.VerifyWorkers,
+		limits.ReplicationWorkers,
+	)
+	return limits
+}
+
+// Derive calculates sizing of the process with cpus available to it, non-zero values of cfg override derived ones.
+func Derive(cfg *configuration.Configuration, cpus int) Limits {
+	limits := Limits{
+		CPUs:               cpus,
+		MaxProcs:           cfg.Resources.MaxProcs,
+		ExecutorWorkers:    cfg.LogicRunner.ExecutorWorkers,
+		VerifyWorkers:      cfg.Service.Consensus.VerifyWorkers,
+		ReplicationWorkers: cfg.Ledger.PulseManager.HeavySyncConcurrency,
+	}
+	if limits.MaxProcs <= 0 {
+		limits.MaxProcs = cpus
+	}
+	if limits.ExecutorWorkers == 0 {
+		limits.ExecutorWorkers = executorWorkersPerCPU * limits.MaxProcs
+	}
+	if limits.VerifyWorkers <= 0 {
+		limits.VerifyWorkers = limits.MaxProcs
+	}
+	if limits.ReplicationWorkers == 0 {
+		limits.ReplicationWorkers = limits.MaxProcs
+	}
+	return limits
+}
+
+// AvailableCPUs returns number of CPUs available to the process: CPU quota of cgroup under root rounded up,
+// or number of CPUs of host if there's no quota or it's larger.
+func AvailableCPUs(root string) int {
+	cpus := runtime.NumCPU()
+	quota, ok := cgroupV2Quota(root)
+	if !ok {
+		quota, ok = cgroupV1Quota(root)
+	}
+	if !ok {
+		return cpus
+	}
+	limited := int(math.Ceil(quota))
+	if limited < 1 {
+		limited = 1
+	}
+	if limited < cpus {
+		return limited
+	}
+	return cpus
+}
+
+// cgroupV2Quota reads CPU quota from "cpu.max" file of unified hierarchy, e.g. "150000 100000" is 1.5 CPU.
+func cgroupV2Quota(root string) (float64, bool) {
+	data, err := ioutil.ReadFile(filepath.Join(root, "cpu.max"))
+	if err != nil {
+		return 0, false
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) != 2 || fields[0] == "max" {
+		return 0, false
+	}
+	return ratio(fields[0], fields[1])
+}
+
+// cgroupV1Quota reads CPU quota from CFS files of cpu controller, negative quota means no limit.
+func cgroupV1Quota(root string) (float64, bool) {
+	quota, err := ioutil.ReadFile(filepath.Join(root, "cpu", "cpu.cfs_quota_us"))
+	if err != nil {
+		return 0, false
+	}
+	period, err := ioutil.ReadFile(filepath.Join(root, "cpu", "cpu.cfs_period_us"))
+	if err != nil {
+		return 0, false
+	}
+	return ratio(strings.TrimSpace(string(quota)), strings.TrimSpace(string(period)))
+}
+
+func ratio(quota, period string) (float64, bool) {
+	q, err := strconv.ParseInt(quota, 10, 64)
+	if err != nil || q <= 0 {
+		return 0, false
+	}
+	p, err := strconv.ParseInt(period, 10, 64)
+	if err != nil || p <= 0 {
+		return 0, false
+	}
+	return float64(q) / float64(p), true
+}
@@ -0,0 +1,84 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package resources
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+)
+
+func writeCgroupFile(t *testing.T, root, name, content string) {
+	path := filepath.Join(root, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+}
+
+func TestAvailableCPUs(t *testing.T) {
+	if runtime.NumCPU() < 2 {
+		t.Skip("quota can't be lower than number of CPUs of host")
+	}
+	root, err := ioutil.TempDir("", "cgroup")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	require.Equal(t, runtime.NumCPU(), AvailableCPUs(root))
+
+	writeCgroupFile(t, root, "cpu/cpu.cfs_quota_us", "-1\n")
+	writeCgroupFile(t, root, "cpu/cpu.cfs_period_us", "100000\n")
+	require.Equal(t, runtime.NumCPU(), AvailableCPUs(root))
+
+	writeCgroupFile(t, root, "cpu/cpu.cfs_quota_us", "50000\n")
+	require.Equal(t, 1, AvailableCPUs(root))
+
+	writeCgroupFile(t, root, "cpu.max", "max 100000\n")
+	require.Equal(t, 1, AvailableCPUs(root), "v1 quota is used if v2 one is unlimited")
+
+	writeCgroupFile(t, root, "cpu.max", "110000 100000\n")
+	require.Equal(t, 2, AvailableCPUs(root))
+
+	writeCgroupFile(t, root, "cpu.max", "100000000 100000\n")
+	require.Equal(t, runtime.NumCPU(), AvailableCPUs(root))
+}
+
+func TestDerive(t *testing.T) {
+	cfg := configuration.NewConfiguration()
+	require.Equal(t, Limits{
+		CPUs:               2,
+		MaxProcs:           2,
+		ExecutorWorkers:    8,
+		VerifyWorkers:      2,
+		ReplicationWorkers: 2,
+	}, Derive(&cfg, 2))
+
+	cfg.Resources.MaxProcs = 3
+	cfg.LogicRunner.ExecutorWorkers = -1
+	cfg.Ledger.PulseManager.HeavySyncConcurrency = 5
+	require.Equal(t, Limits{
+		CPUs:               2,
+		MaxProcs:           3,
+		ExecutorWorkers:    -1,
+		VerifyWorkers:      3,
+		ReplicationWorkers: 5,
+	}, Derive(&cfg, 2))
+}
@@ -0,0 +1,75 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package retry
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"github.com/insolar/insolar/instrumentation/insmetrics"
+)
+
+var (
+	tagOperation = insmetrics.MustTagKey("operation")
+)
+
+var (
+	statRetries = stats.Int64(
+		"retry/retries",
+		"number of retries of failed attempts",
+		stats.UnitDimensionless,
+	)
+	statExhausted = stats.Int64(
+		"retry/exhausted",
+		"number of operations which failed after all retries",
+		stats.UnitDimensionless,
+	)
+	statDelay = stats.Float64(
+		"retry/delay",
+		"delay before retry",
+		stats.UnitMilliseconds,
+	)
+)
+
+func init() {
+	err := insmetrics.Register(
+		&view.View{
+			Name:        statRetries.Name(),
+			Description: statRetries.Description(),
+			Measure:     statRetries,
+			Aggregation: view.Sum(),
+			TagKeys:     []tag.Key{tagOperation},
+		},
+		&view.View{
+			Name:        statExhausted.Name(),
+			Description: statExhausted.Description(),
+			Measure:     statExhausted,
+			Aggregation: view.Sum(),
+			TagKeys:     []tag.Key{tagOperation},
+		},
+		&view.View{
+			Name:        statDelay.Name(),
+			Description: statDelay.Description(),
+			Measure:     statDelay,
+			Aggregation: view.Distribution(10, 100, 1000, 10000, 60000),
+			TagKeys:     []tag.Key{tagOperation},
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,116 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+// Package retry provides shared retry loop with exponential backoff and jitter. Loops are bounded by number of
+// attempts, by time budget and by context, and report retry metrics uniformly tagged by operation name.
+package retry
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opencensus.io/stats"
+
+	"github.com/insolar/insolar/instrumentation/insmetrics"
+	"github.com/insolar/insolar/utils/backoff"
+)
+
+// Policy describes how operation is retried.
+type Policy struct {
+	// Backoff calculates delay before each retry.
+	Backoff backoff.Backoff
+	// Attempts limits number of attempts including the first one, zero means unlimited.
+	Attempts int
+	// Budget limits total time of all attempts and delays, retry which would exceed budget isn't made.
+	// Zero means unlimited.
+	Budget time.Duration
+	// DelayFirst makes the first attempt after backoff delay too.
+	DelayFirst bool
+}
+
+type retryableError struct {
+	err   error
+	delay time.Duration
+}
+
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+// Cause returns original error of attempt.
+func (e *retryableError) Cause() error {
+	return e.err
+}
+
+// Retryable marks error of attempt as temporary, operation is retried after backoff delay.
+func Retryable(err error) error {
+	return &retryableError{err: err}
+}
+
+// RetryAfter marks error of attempt as temporary, operation is retried after provided delay
+// instead of backoff delay.
+func RetryAfter(err error, delay time.Duration) error {
+	return &retryableError{err: err, delay: delay}
+}
+
+// Do calls op until it succeeds or returns error which isn't marked as retryable. It gives up when attempts
+// or budget of policy are exhausted, returning the last error of op, or when ctx is done, returning error of ctx.
+// Attempts are counted from zero.
+func Do(ctx context.Context, name string, p Policy, op func(ctx context.Context, attempt int) error) error {
+	ctx = insmetrics.InsertTag(ctx, tagOperation, name)
+	deadline := time.Now().Add(p.Budget)
+
+	var delay time.Duration
+	if p.DelayFirst {
+		delay = p.Backoff.ForAttempt(0)
+	}
+	for attempt := 0; ; attempt++ {
+		if delay > 0 {
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		err := op(ctx, attempt)
+		if err == nil {
+			return nil
+		}
+		r, ok := err.(*retryableError)
+		if !ok {
+			return err
+		}
+
+		if p.Attempts > 0 && attempt+1 >= p.Attempts {
+			stats.Record(ctx, statExhausted.M(1))
+			return errors.Wrapf(r.err, "[ retry ] %s failed after %d attempts", name, attempt+1)
+		}
+		delay = r.delay
+		if delay <= 0 {
+			next := attempt + 1
+			if !p.DelayFirst {
+				next = attempt
+			}
+			delay = p.Backoff.ForAttempt(next)
+		}
+		if p.Budget > 0 && time.Now().Add(delay).After(deadline) {
+			stats.Record(ctx, statExhausted.M(1))
+			return errors.Wrapf(r.err, "[ retry ] %s is out of time budget %s", name, p.Budget)
+		}
+		stats.Record(ctx, statRetries.M(1), statDelay.M(float64(delay)/float64(time.Millisecond)))
+	}
+}
@@ -0,0 +1,85 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package retry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/utils/backoff"
+)
+
+var fastBackoff = backoff.Backoff{Min: time.Millisecond, Max: 2 * time.Millisecond}
+
+func TestDo_RetriesUntilSuccess(t *testing.T) {
+	calls := 0
+	err := Do(context.Background(), "test", Policy{Backoff: fastBackoff}, func(ctx context.Context, attempt int) error {
+		require.Equal(t, calls, attempt)
+		calls++
+		if calls < 3 {
+			return Retryable(errors.New("temporary"))
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, calls)
+}
+
+func TestDo_PermanentError(t *testing.T) {
+	permanent := errors.New("permanent")
+	calls := 0
+	err := Do(context.Background(), "test", Policy{Backoff: fastBackoff}, func(ctx context.Context, attempt int) error {
+		calls++
+		return permanent
+	})
+	require.Equal(t, permanent, err)
+	require.Equal(t, 1, calls)
+}
+
+func TestDo_AttemptsExhausted(t *testing.T) {
+	temporary := errors.New("temporary")
+	calls := 0
+	err := Do(context.Background(), "test", Policy{Backoff: fastBackoff, Attempts: 3}, func(ctx context.Context, attempt int) error {
+		calls++
+		return Retryable(temporary)
+	})
+	require.Error(t, err)
+	require.Equal(t, temporary, errors.Cause(err))
+	require.Equal(t, 3, calls)
+}
+
+func TestDo_BudgetExhausted(t *testing.T) {
+	calls := 0
+	err := Do(context.Background(), "test", Policy{Budget: 50 * time.Millisecond}, func(ctx context.Context, attempt int) error {
+		calls++
+		return RetryAfter(errors.New("temporary"), time.Second)
+	})
+	require.Error(t, err)
+	require.Equal(t, 1, calls, "retry which exceeds budget isn't made")
+}
+
+func TestDo_ContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	err := Do(ctx, "test", Policy{}, func(ctx context.Context, attempt int) error {
+		cancel()
+		return RetryAfter(errors.New("temporary"), time.Minute)
+	})
+	require.Equal(t, context.Canceled, err)
+}
@@ -0,0 +1,159 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package selftest
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+)
+
+// keysProbe is signed with node key to check that it matches certificate.
+var keysProbe = []byte("insolar self-test probe")
+
+// Keys checks that key in keystore signs data verifiable with public key of certificate, and that certificate
+// isn't expired.
+func Keys(cs core.CryptographyService, cert core.Certificate, now func() time.Time) Check {
+	return Check{Name: "keys", Run: func(ctx context.Context) error {
+		signature, err := cs.Sign(keysProbe)
+		if err != nil {
+			return errors.Wrap(err, "can't sign with node key")
+		}
+		if !cs.Verify(cert.GetPublicKey(), *signature, keysProbe) {
+			return errors.New("node key doesn't match public key of certificate")
+		}
+		if exp, ok := cert.(core.CertificateExpirationProvider); ok {
+			if expires := exp.GetExpiration(); !expires.IsZero() && now().After(expires) {
+				return errors.Errorf("certificate expired at %s", expires.Format(time.RFC3339))
+			}
+		}
+		return nil
+	}}
+}
+
+// Clock checks that local clock isn't behind the first pulse or build date of node, such clock would produce
+// pulse numbers network rejects. Empty or malformed build date isn't checked.
+func Clock(now func() time.Time, buildDate string) Check {
+	return Check{Name: "clock", Run: func(ctx context.Context) error {
+		t := now()
+		first := time.Unix(int64(core.GenesisPulse.PulseTimestamp), 0)
+		if t.Before(first) {
+			return errors.Errorf("clock %s is before the first pulse %s", t.Format(time.RFC3339), first.Format(time.RFC3339))
+		}
+		if built, err := time.Parse("2006-01-02", buildDate); err == nil && t.Before(built) {
+			return errors.Errorf("clock %s is before build date %s", t.Format(time.RFC3339), buildDate)
+		}
+		return nil
+	}}
+}
+
+// Endpoint is an address node listens on.
+type Endpoint struct {
+	Name string
+	// Network is "tcp" or "udp".
+	Network string
+	Address string
+}
+
+// Ports checks that addresses of endpoints may be listened on. Endpoints with empty address or zero port are skipped.
+func Ports(endpoints ...Endpoint) Check {
+	return Check{Name: "ports", Run: func(ctx context.Context) error {
+		var busy []string
+		for _, e := range endpoints {
+			if e.Address == "" {
+				continue
+			}
+			if _, port, err := net.SplitHostPort(e.Address); err == nil && port == "0" {
+				continue
+			}
+			if err := listen(e.Network, e.Address); err != nil {
+				busy = append(busy, fmt.Sprintf("%s %s/%s (%s)", e.Name, e.Network, e.Address, err))
+			}
+		}
+		if len(busy) > 0 {
+			return errors.Errorf("can't listen on %s", strings.Join(busy, ", "))
+		}
+		return nil
+	}}
+}
+
+func listen(network, address string) error {
+	if network == "udp" {
+		conn, err := net.ListenPacket(network, address)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+	listener, err := net.Listen(network, address)
+	if err != nil {
+		return err
+	}
+	return listener.Close()
+}
+
+// Discovery checks that at least one discovery node of certificate accepts connections on its host address.
+// Discovery nodes are bootstrapped together, so the check passes on discovery node itself. Hosts are only
+// resolved if network transport isn't TCP, since UDP doesn't tell whether anyone listens.
+func Discovery(cert core.Certificate, protocol string, timeout time.Duration) Check {
+	return Check{Name: "discovery", Run: func(ctx context.Context) error {
+		nodes := cert.GetDiscoveryNodes()
+		if len(nodes) == 0 {
+			return errors.New("certificate has no discovery nodes")
+		}
+		for _, node := range nodes {
+			if *node.GetNodeRef() == *cert.GetNodeRef() {
+				return nil
+			}
+		}
+
+		if !strings.EqualFold(protocol, "TCP") {
+			for _, node := range nodes {
+				if _, err := net.ResolveUDPAddr("udp", node.GetHost()); err == nil {
+					return nil
+				}
+			}
+			return errors.New("can't resolve any discovery node host")
+		}
+
+		deadline := time.Now().Add(timeout)
+		var lastErr error
+		for {
+			for _, node := range nodes {
+				conn, err := net.DialTimeout("tcp", node.GetHost(), time.Second)
+				if err == nil {
+					return conn.Close()
+				}
+				lastErr = err
+			}
+			if !time.Now().Before(deadline) {
+				return errors.Wrap(lastErr, "can't connect to any discovery node")
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Second):
+			}
+		}
+	}}
+}
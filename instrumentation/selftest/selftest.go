@@ -0,0 +1,92 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+// Package selftest runs checks of node environment on start, so misconfigured node fails fast with consolidated
+// report instead of joining consensus in broken state.
+package selftest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Check is a named check of node self-test.
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// Result is an outcome of a check, Err is nil if check passed.
+type Result struct {
+	Name     string
+	Err      error
+	Duration time.Duration
+}
+
+// Report is a consolidated outcome of self-test.
+type Report struct {
+	Results []Result
+}
+
+// Failed returns results of failed checks.
+func (r *Report) Failed() []Result {
+	var failed []Result
+	for _, res := range r.Results {
+		if res.Err != nil {
+			failed = append(failed, res)
+		}
+	}
+	return failed
+}
+
+// String returns report with a line per check.
+func (r *Report) String() string {
+	var b strings.Builder
+	for _, res := range r.Results {
+		if res.Err != nil {
+			fmt.Fprintf(&b, "FAIL %s: %s\n", res.Name, res.Err)
+		} else {
+			fmt.Fprintf(&b, "ok   %s (%s)\n", res.Name, res.Duration.Round(time.Millisecond))
+		}
+	}
+	return b.String()
+}
+
+// Summary returns one line summary of failed checks, it's empty if all checks passed.
+func (r *Report) Summary() string {
+	failed := r.Failed()
+	if len(failed) == 0 {
+		return ""
+	}
+	msgs := make([]string, 0, len(failed))
+	for _, res := range failed {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", res.Name, res.Err))
+	}
+	return fmt.Sprintf("%d of %d self-test checks failed: %s", len(failed), len(r.Results), strings.Join(msgs, "; "))
+}
+
+// Run executes all checks, failed check doesn't stop the rest, so report lists all problems at once.
+func Run(ctx context.Context, checks ...Check) *Report {
+	report := &Report{Results: make([]Result, 0, len(checks))}
+	for _, check := range checks {
+		start := time.Now()
+		err := check.Run(ctx)
+		report.Results = append(report.Results, Result{Name: check.Name, Err: err, Duration: time.Since(start)})
+	}
+	return report
+}
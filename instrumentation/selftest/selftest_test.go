@@ -0,0 +1,131 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package selftest
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/cryptography"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestRun(t *testing.T) {
+	report := Run(context.Background(),
+		Check{Name: "first", Run: func(ctx context.Context) error { return errors.New("broken") }},
+		Check{Name: "second", Run: func(ctx context.Context) error { return nil }},
+		Check{Name: "third", Run: func(ctx context.Context) error { return errors.New("missing") }},
+	)
+
+	require.Len(t, report.Results, 3, "failed check doesn't stop the rest")
+	require.Len(t, report.Failed(), 2)
+	require.Equal(t, "2 of 3 self-test checks failed: first: broken; third: missing", report.Summary())
+	require.Contains(t, report.String(), "FAIL first: broken")
+	require.Contains(t, report.String(), "ok   second")
+
+	require.Empty(t, Run(context.Background()).Summary())
+}
+
+func TestKeys(t *testing.T) {
+	kp := platformpolicy.NewKeyProcessor()
+	nodeKey, err := kp.GeneratePrivateKey()
+	require.NoError(t, err)
+	otherKey, err := kp.GeneratePrivateKey()
+	require.NoError(t, err)
+	cs := cryptography.NewKeyBoundCryptographyService(nodeKey)
+	now := time.Now
+
+	cert := testutils.NewCertificateMock(t)
+	cert.GetPublicKeyMock.Return(kp.ExtractPublicKey(nodeKey))
+	require.NoError(t, Keys(cs, cert, now).Run(context.Background()))
+
+	cert = testutils.NewCertificateMock(t)
+	cert.GetPublicKeyMock.Return(kp.ExtractPublicKey(otherKey))
+	require.Error(t, Keys(cs, cert, now).Run(context.Background()))
+}
+
+func TestClock(t *testing.T) {
+	at := func(t time.Time) func() time.Time {
+		return func() time.Time { return t }
+	}
+	first := time.Unix(int64(core.GenesisPulse.PulseTimestamp), 0)
+
+	require.NoError(t, Clock(time.Now, "unset").Run(context.Background()))
+	require.Error(t, Clock(at(first.Add(-time.Hour)), "unset").Run(context.Background()))
+	require.NoError(t, Clock(at(first.Add(48*time.Hour)), "2018-09-02").Run(context.Background()))
+	require.Error(t, Clock(at(first.Add(time.Hour)), "2018-09-02").Run(context.Background()))
+}
+
+func TestPorts(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	free, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	freeAddr := free.Addr().String()
+	require.NoError(t, free.Close())
+
+	require.NoError(t, Ports(
+		Endpoint{Name: "api", Network: "tcp", Address: freeAddr},
+		Endpoint{Name: "transport", Network: "udp", Address: "127.0.0.1:0"},
+		Endpoint{Name: "metrics", Network: "tcp", Address: ""},
+	).Run(context.Background()))
+
+	err = Ports(Endpoint{Name: "api", Network: "tcp", Address: listener.Addr().String()}).Run(context.Background())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "api tcp/"+listener.Addr().String())
+}
+
+func TestDiscovery(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	self, discoveryRef := testutils.RandomRef(), testutils.RandomRef()
+	discovery := func(host string) core.DiscoveryNode {
+		node := testutils.NewDiscoveryNodeMock(t)
+		node.GetNodeRefMock.Return(&discoveryRef)
+		node.GetHostMock.Return(host)
+		return node
+	}
+	certificate := func(ref core.RecordRef, nodes ...core.DiscoveryNode) core.Certificate {
+		cert := testutils.NewCertificateMock(t)
+		cert.GetNodeRefMock.Return(&ref)
+		cert.GetDiscoveryNodesMock.Return(nodes)
+		return cert
+	}
+
+	cert := certificate(self, discovery(listener.Addr().String()))
+	require.NoError(t, Discovery(cert, "TCP", time.Second).Run(context.Background()))
+
+	cert = certificate(discoveryRef, discovery("127.0.0.1:1"))
+	require.NoError(t, Discovery(cert, "TCP", time.Second).Run(context.Background()), "node is discovery itself")
+
+	cert = certificate(self, discovery("127.0.0.1:1"))
+	require.Error(t, Discovery(cert, "TCP", 0).Run(context.Background()))
+
+	cert = certificate(self)
+	require.Error(t, Discovery(cert, "TCP", 0).Run(context.Background()))
+}
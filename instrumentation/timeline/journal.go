@@ -0,0 +1,115 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+// Package timeline keeps journal of request processing events, so path of request through the network
+// can be restored by query id or request reference.
+package timeline
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+)
+
+// Journal implements core.Timeline. It keeps limited number of recent events in memory.
+type Journal struct {
+	NodeNetwork core.NodeNetwork `inject:""`
+
+	lock   sync.Mutex
+	events []core.TimelineEvent
+	next   int
+}
+
+// NewJournal creates new Journal.
+func NewJournal(cfg configuration.Timeline) *Journal {
+	return &Journal{
+		events: make([]core.TimelineEvent, 0, cfg.Capacity),
+	}
+}
+
+// Record adds event to journal. Node and time are set if not provided. Oldest event is dropped if journal is full.
+func (j *Journal) Record(ctx context.Context, event core.TimelineEvent) {
+	if cap(j.events) == 0 {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	if event.Node.IsEmpty() && j.NodeNetwork != nil {
+		event.Node = j.NodeNetwork.GetOrigin().ID()
+	}
+
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	if len(j.events) < cap(j.events) {
+		j.events = append(j.events, event)
+		return
+	}
+	j.events[j.next] = event
+	j.next = (j.next + 1) % len(j.events)
+}
+
+// Events returns events with provided query id or request reference ordered by time. Events of requests
+// which are known to belong to query are returned too.
+func (j *Journal) Events(qid string, request *core.RecordRef) []core.TimelineEvent {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	requests := map[core.RecordRef]bool{}
+	if request != nil {
+		requests[*request] = true
+	}
+	if qid != "" {
+		for _, e := range j.events {
+			if e.QID == qid && e.Request != nil {
+				requests[*e.Request] = true
+			}
+		}
+	}
+
+	var found []core.TimelineEvent
+	for _, e := range j.events {
+		if qid != "" && e.QID == qid || e.Request != nil && requests[*e.Request] {
+			found = append(found, e)
+		}
+	}
+	Sort(found)
+	return found
+}
+
+// Sort orders events by time. Events of the same time are ordered by stage.
+func Sort(events []core.TimelineEvent) {
+	sort.SliceStable(events, func(i, k int) bool {
+		if events[i].Time.Equal(events[k].Time) {
+			return stageOrder[events[i].Stage] < stageOrder[events[k].Stage]
+		}
+		return events[i].Time.Before(events[k].Time)
+	})
+}
+
+var stageOrder = map[core.TimelineStage]int{
+	core.TimelineAPIReceived:       1,
+	core.TimelineRequestRegistered: 2,
+	core.TimelineExecutionStarted:  3,
+	core.TimelineExecutionFinished: 4,
+	core.TimelineResultRegistered:  5,
+	core.TimelineValidationDone:    6,
+	core.TimelineAPIReplied:        7,
+}
@@ -0,0 +1,69 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package timeline
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestJournal_Events(t *testing.T) {
+	ctx := context.Background()
+	j := NewJournal(configuration.NewTimeline())
+	request, other := testutils.RandomRef(), testutils.RandomRef()
+	start := time.Now()
+
+	j.Record(ctx, core.TimelineEvent{Stage: core.TimelineAPIReplied, QID: "q", Request: &request, Time: start.Add(time.Second)})
+	j.Record(ctx, core.TimelineEvent{Stage: core.TimelineExecutionStarted, Request: &request, Time: start})
+	j.Record(ctx, core.TimelineEvent{Stage: core.TimelineAPIReceived, QID: "q", Time: start})
+	j.Record(ctx, core.TimelineEvent{Stage: core.TimelineAPIReceived, QID: "other", Request: &other})
+
+	var stages []core.TimelineStage
+	for _, e := range j.Events("q", nil) {
+		stages = append(stages, e.Stage)
+	}
+	require.Equal(t, []core.TimelineStage{
+		core.TimelineAPIReceived, core.TimelineExecutionStarted, core.TimelineAPIReplied,
+	}, stages, "events of request of query must be found and ordered by time and stage")
+
+	require.Len(t, j.Events("", &request), 2)
+	require.Len(t, j.Events("", &other), 1)
+	require.Empty(t, j.Events("unknown", nil))
+	require.False(t, j.Events("other", nil)[0].Time.IsZero())
+}
+
+func TestJournal_Capacity(t *testing.T) {
+	ctx := context.Background()
+	j := NewJournal(configuration.Timeline{Capacity: 2})
+	for _, qid := range []string{"1", "2", "3"} {
+		j.Record(ctx, core.TimelineEvent{Stage: core.TimelineAPIReceived, QID: qid})
+	}
+	require.Empty(t, j.Events("1", nil), "oldest event must be dropped")
+	require.Len(t, j.Events("2", nil), 1)
+	require.Len(t, j.Events("3", nil), 1)
+
+	disabled := NewJournal(configuration.Timeline{})
+	disabled.Record(ctx, core.TimelineEvent{Stage: core.TimelineAPIReceived, QID: "1"})
+	require.Empty(t, disabled.Events("1", nil))
+}
@@ -0,0 +1,60 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package watchdog
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"github.com/insolar/insolar/instrumentation/insmetrics"
+)
+
+var (
+	tagResource = insmetrics.MustTagKey("resource")
+)
+
+var (
+	statResourceUsage = stats.Int64(
+		"watchdog/resource/usage",
+		"sampled usage of resource",
+		stats.UnitDimensionless,
+	)
+	statCeilingExceededTotal = stats.Int64(
+		"watchdog/ceiling/exceeded/count",
+		"number of samples where resource usage exceeded its ceiling",
+		stats.UnitDimensionless,
+	)
+)
+
+func init() {
+	err := insmetrics.Register(
+		&view.View{
+			Measure:     statResourceUsage,
+			Aggregation: view.LastValue(),
+			TagKeys:     []tag.Key{tagResource},
+		},
+		&view.View{
+			Measure:     statCeilingExceededTotal,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{tagResource},
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+}
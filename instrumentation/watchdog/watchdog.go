@@ -0,0 +1,216 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package watchdog
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"runtime"
+	"runtime/pprof"
+	"sync"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"go.opencensus.io/stats"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/instrumentation/crashreport"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/instrumentation/insmetrics"
+	"github.com/insolar/insolar/metrics"
+)
+
+// Names of watched resources, used in logs and as metric tag values.
+const (
+	ResourceGoroutines         = "goroutines"
+	ResourceOpenFiles          = "open_files"
+	ResourceConnections        = "connections"
+	ResourcePendingResults     = "pending_results"
+	ResourceLogicRunnerObjects = "logicrunner_objects"
+)
+
+// ResultMapSizer is implemented by components which wait for call results.
+type ResultMapSizer interface {
+	ResultMapSize() int
+}
+
+// StateSizer is implemented by components which hold per-object state.
+type StateSizer interface {
+	StateSize() int
+}
+
+// Usage is a single sample of watched resources. Negative value means resource can't be sampled.
+type Usage struct {
+	Goroutines         int
+	OpenFiles          int
+	Connections        int
+	PendingResults     int
+	LogicRunnerObjects int
+}
+
+// Watchdog periodically samples resources usage, exposes it as metrics
+// and logs detailed dumps when usage exceeds configured ceilings.
+type Watchdog struct {
+	ContractRequester ResultMapSizer `inject:""`
+	LogicRunner       StateSizer     `inject:""`
+
+	cfg configuration.Watchdog
+
+	// exceeded holds resources which are above ceiling, so dump is logged once per excess.
+	exceeded map[string]bool
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewWatchdog creates new Watchdog.
+func NewWatchdog(cfg configuration.Watchdog) *Watchdog {
+	return &Watchdog{
+		cfg:      cfg,
+		exceeded: make(map[string]bool),
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start starts periodic sampling.
+func (w *Watchdog) Start(ctx context.Context) error {
+	if w.cfg.Interval <= 0 {
+		inslogger.FromContext(ctx).Info("[ Watchdog.Start ] watchdog is disabled")
+		return nil
+	}
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		defer crashreport.Recover(ctx, "Watchdog")
+
+		ticker := time.NewTicker(w.cfg.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				w.check(ctx, w.Sample())
+			case <-w.stop:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop stops periodic sampling.
+func (w *Watchdog) Stop(ctx context.Context) error {
+	close(w.stop)
+	w.wg.Wait()
+	return nil
+}
+
+// Sample returns current usage of watched resources.
+func (w *Watchdog) Sample() Usage {
+	u := Usage{
+		Goroutines:         runtime.NumGoroutine(),
+		OpenFiles:          openFiles(),
+		Connections:        connections(),
+		PendingResults:     -1,
+		LogicRunnerObjects: -1,
+	}
+	if w.ContractRequester != nil {
+		u.PendingResults = w.ContractRequester.ResultMapSize()
+	}
+	if w.LogicRunner != nil {
+		u.LogicRunnerObjects = w.LogicRunner.StateSize()
+	}
+	return u
+}
+
+// check records usage metrics and logs dumps for resources which exceeded ceilings.
+// It returns names of resources above ceiling.
+func (w *Watchdog) check(ctx context.Context, u Usage) []string {
+	resources := []struct {
+		name    string
+		value   int
+		ceiling int
+	}{
+		{ResourceGoroutines, u.Goroutines, w.cfg.MaxGoroutines},
+		{ResourceOpenFiles, u.OpenFiles, w.cfg.MaxOpenFiles},
+		{ResourceConnections, u.Connections, w.cfg.MaxConnections},
+		{ResourcePendingResults, u.PendingResults, w.cfg.MaxPendingResults},
+		{ResourceLogicRunnerObjects, u.LogicRunnerObjects, w.cfg.MaxLogicRunnerObjects},
+	}
+
+	logger := inslogger.FromContext(ctx)
+	var exceeded []string
+	for _, r := range resources {
+		if r.value < 0 {
+			continue
+		}
+		rctx := insmetrics.InsertTag(ctx, tagResource, r.name)
+		stats.Record(rctx, statResourceUsage.M(int64(r.value)))
+
+		if r.ceiling <= 0 || r.value <= r.ceiling {
+			if w.exceeded[r.name] {
+				logger.Infof("[ Watchdog ] %s usage is back to normal: %d <= %d", r.name, r.value, r.ceiling)
+			}
+			delete(w.exceeded, r.name)
+			continue
+		}
+
+		exceeded = append(exceeded, r.name)
+		stats.Record(rctx, statCeilingExceededTotal.M(1))
+		if w.exceeded[r.name] {
+			logger.Warnf("[ Watchdog ] %s usage is still above ceiling: %d > %d", r.name, r.value, r.ceiling)
+			continue
+		}
+		w.exceeded[r.name] = true
+		logger.Errorf(
+			"[ Watchdog ] %s usage exceeded ceiling: %d > %d, usage: %+v\n%s",
+			r.name, r.value, r.ceiling, u, dump(r.name),
+		)
+	}
+	return exceeded
+}
+
+// dump returns detailed state of resource, if one is available.
+func dump(resource string) string {
+	if resource != ResourceGoroutines {
+		return ""
+	}
+	var buf bytes.Buffer
+	if err := pprof.Lookup("goroutine").WriteTo(&buf, 1); err != nil {
+		return "can't dump goroutines: " + err.Error()
+	}
+	return buf.String()
+}
+
+// openFiles returns number of open file descriptors of the process, or -1 on platforms without procfs.
+func openFiles() int {
+	fds, err := ioutil.ReadDir("/proc/self/fd")
+	if err != nil {
+		return -1
+	}
+	return len(fds)
+}
+
+// connections returns number of connections in network transport pool.
+func connections() int {
+	var m dto.Metric
+	if err := metrics.NetworkConnections.Write(&m); err != nil {
+		return -1
+	}
+	return int(m.GetGauge().GetValue())
+}
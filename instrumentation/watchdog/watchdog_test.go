@@ -0,0 +1,83 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package watchdog
+
+import (
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+)
+
+type sizer int
+
+func (s sizer) ResultMapSize() int { return int(s) }
+func (s sizer) StateSize() int     { return int(s) }
+
+func TestWatchdog_Sample(t *testing.T) {
+	w := NewWatchdog(configuration.NewWatchdog())
+	u := w.Sample()
+	require.True(t, u.Goroutines > 0)
+	require.Equal(t, -1, u.PendingResults)
+	require.Equal(t, -1, u.LogicRunnerObjects)
+
+	w.ContractRequester = sizer(3)
+	w.LogicRunner = sizer(5)
+	u = w.Sample()
+	require.Equal(t, 3, u.PendingResults)
+	require.Equal(t, 5, u.LogicRunnerObjects)
+	if runtime.GOOS == "linux" {
+		require.True(t, u.OpenFiles > 0)
+	}
+}
+
+func TestWatchdog_Check(t *testing.T) {
+	ctx := context.Background()
+	cfg := configuration.NewWatchdog()
+	cfg.MaxGoroutines = 10
+	cfg.MaxOpenFiles = 0
+	cfg.MaxPendingResults = 100
+	w := NewWatchdog(cfg)
+
+	usage := Usage{Goroutines: 11, OpenFiles: 1000000, PendingResults: 100, LogicRunnerObjects: -1}
+	require.Equal(t, []string{ResourceGoroutines}, w.check(ctx, usage))
+	require.True(t, w.exceeded[ResourceGoroutines])
+
+	usage.PendingResults = 101
+	require.Equal(t, []string{ResourceGoroutines, ResourcePendingResults}, w.check(ctx, usage))
+
+	usage.Goroutines = 10
+	usage.PendingResults = 1
+	require.Empty(t, w.check(ctx, usage))
+	require.Empty(t, w.exceeded)
+}
+
+func TestWatchdog_StartStop(t *testing.T) {
+	ctx := context.Background()
+	cfg := configuration.NewWatchdog()
+	w := NewWatchdog(cfg)
+	require.NoError(t, w.Start(ctx))
+	require.NoError(t, w.Stop(ctx))
+
+	cfg.Interval = 0
+	w = NewWatchdog(cfg)
+	require.NoError(t, w.Start(ctx))
+	require.NoError(t, w.Stop(ctx))
+}
@@ -19,6 +19,7 @@ package keystore
 import (
 	"context"
 	"crypto"
+	"sync"
 
 	"github.com/insolar/insolar/component"
 	"github.com/insolar/insolar/core"
@@ -47,10 +48,14 @@ func (ks *keyStore) Start(ctx context.Context) error {
 type cachedKeyStore struct {
 	keyStore core.KeyStore
 
+	lock       sync.RWMutex
 	privateKey crypto.PrivateKey
 }
 
 func (ks *cachedKeyStore) getCachedPrivateKey(identifier string) crypto.PublicKey {
+	ks.lock.RLock()
+	defer ks.lock.RUnlock()
+
 	if ks.privateKey != nil {
 		return ks.privateKey
 	}
@@ -58,13 +63,30 @@ func (ks *cachedKeyStore) getCachedPrivateKey(identifier string) crypto.PublicKe
 }
 
 func (ks *cachedKeyStore) loadPrivateKey(identifier string) (crypto.PrivateKey, error) {
+	privateKey, err := ks.ReadPrivateKey(identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	ks.SetPrivateKey(identifier, privateKey)
+	return privateKey, nil
+}
+
+// ReadPrivateKey reads private key from file, cached key is not changed.
+func (ks *cachedKeyStore) ReadPrivateKey(identifier string) (crypto.PrivateKey, error) {
 	privateKey, err := ks.keyStore.GetPrivateKey(identifier)
 	if err != nil {
-		return nil, errors.Wrap(err, "[ loadPrivateKey ] Can't GetPrivateKey")
+		return nil, errors.Wrap(err, "[ ReadPrivateKey ] Can't GetPrivateKey")
 	}
+	return privateKey, nil
+}
+
+// SetPrivateKey replaces cached private key.
+func (ks *cachedKeyStore) SetPrivateKey(identifier string, privateKey crypto.PrivateKey) {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
 
 	ks.privateKey = privateKey
-	return privateKey, nil
 }
 
 func (ks *cachedKeyStore) GetPrivateKey(identifier string) (crypto.PrivateKey, error) {
@@ -104,3 +126,24 @@ func NewKeyStore(path string) (core.KeyStore, error) {
 
 	return cachedKeyStore, nil
 }
+
+type inMemoryKeyStore struct {
+	privateKey crypto.PrivateKey
+}
+
+func (ks *inMemoryKeyStore) GetPrivateKey(identifier string) (crypto.PrivateKey, error) {
+	return ks.privateKey, nil
+}
+
+// NewEphemeralKeyStore creates key store with newly generated private key which is never persisted.
+// Key is lost on restart, use it for test nodes only.
+func NewEphemeralKeyStore(keyProcessor core.KeyProcessor) (core.KeyStore, error) {
+	privateKey, err := keyProcessor.GeneratePrivateKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NewEphemeralKeyStore ] Failed to generate private key")
+	}
+	return &cachedKeyStore{
+		keyStore:   &inMemoryKeyStore{privateKey: privateKey},
+		privateKey: privateKey,
+	}, nil
+}
@@ -21,6 +21,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/platformpolicy"
 )
 
 const (
@@ -61,3 +64,42 @@ func TestKeyStore_GetPrivateKeyReturnsECDSA(t *testing.T) {
 	require.NotNil(t, ecdsaPK)
 	require.True(t, ok)
 }
+
+func TestKeyStore_Reload(t *testing.T) {
+	ks, err := NewKeyStore(testKeys)
+	require.NoError(t, err)
+	reloadable, ok := ks.(core.ReloadableKeyStore)
+	require.True(t, ok)
+
+	pk, err := ks.GetPrivateKey("")
+	require.NoError(t, err)
+
+	newPK, err := reloadable.ReadPrivateKey("")
+	require.NoError(t, err)
+	require.Equal(t, pk, newPK)
+
+	reloadable.SetPrivateKey("", nil)
+	cached, err := ks.GetPrivateKey("")
+	require.NoError(t, err)
+	require.Nil(t, cached)
+}
+
+func TestNewEphemeralKeyStore(t *testing.T) {
+	kp := platformpolicy.NewKeyProcessor()
+	ks, err := NewEphemeralKeyStore(kp)
+	require.NoError(t, err)
+
+	pk, err := ks.GetPrivateKey("")
+	require.NoError(t, err)
+	require.NotNil(t, pk)
+
+	reread, err := ks.(core.ReloadableKeyStore).ReadPrivateKey("")
+	require.NoError(t, err)
+	require.Equal(t, pk, reread)
+
+	other, err := NewEphemeralKeyStore(kp)
+	require.NoError(t, err)
+	otherPK, err := other.GetPrivateKey("")
+	require.NoError(t, err)
+	require.NotEqual(t, kp.ExtractPublicKey(pk), kp.ExtractPublicKey(otherPK))
+}
@@ -0,0 +1,129 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package artifactmanager
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/ledger/storage"
+	"github.com/insolar/insolar/ledger/storage/record"
+)
+
+// LedgerArchive implements core.LedgerArchive. Query is routed by pulse of request: light material nodes keep
+// records for light chain limit, older records are read from heavy or its read replica.
+type LedgerArchive struct {
+	DefaultBus     core.MessageBus     `inject:""`
+	PulseStorage   core.PulseStorage   `inject:""`
+	JetCoordinator core.JetCoordinator `inject:""`
+	JetStorage     storage.JetStorage  `inject:""`
+}
+
+// NewLedgerArchive creates new ledger archive.
+func NewLedgerArchive() *LedgerArchive {
+	return &LedgerArchive{}
+}
+
+// GetRequest implements core.LedgerArchive.
+func (a *LedgerArchive) GetRequest(ctx context.Context, request core.RecordRef) (core.Parcel, uint64, error) {
+	genericReply, err := a.send(ctx, &message.GetRequest{Request: *request.Record()})
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "[ GetRequest ] failed to fetch request")
+	}
+
+	switch r := genericReply.(type) {
+	case *reply.Request:
+		rec, ok := record.DeserializeRecord(r.Record).(*record.RequestRecord)
+		if !ok {
+			return nil, 0, fmt.Errorf("[ GetRequest ] unexpected record: %#v", r)
+		}
+		parcel, err := message.DeserializeParcel(bytes.NewBuffer(rec.Parcel))
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "[ GetRequest ] failed to deserialize parcel")
+		}
+		return parcel, rec.Sequence, nil
+	case *reply.Error:
+		if r.ErrType == reply.ErrNotFound {
+			return nil, 0, core.ErrNotFound
+		}
+		return nil, 0, r.Error()
+	default:
+		return nil, 0, fmt.Errorf("[ GetRequest ] unexpected reply: %#v", genericReply)
+	}
+}
+
+// GetResult implements core.LedgerArchive.
+func (a *LedgerArchive) GetResult(ctx context.Context, request core.RecordRef) ([]byte, error) {
+	genericReply, err := a.send(ctx, &message.GetResult{Request: *request.Record()})
+	if err != nil {
+		return nil, errors.Wrap(err, "[ GetResult ] failed to fetch result")
+	}
+
+	switch r := genericReply.(type) {
+	case *reply.Result:
+		rec, ok := record.DeserializeRecord(r.Record).(*record.ResultRecord)
+		if !ok {
+			return nil, fmt.Errorf("[ GetResult ] unexpected record: %#v", r)
+		}
+		return rec.Payload, nil
+	case *reply.Error:
+		if r.ErrType == reply.ErrNotFound {
+			return nil, core.ErrNotFound
+		}
+		return nil, r.Error()
+	default:
+		return nil, fmt.Errorf("[ GetResult ] unexpected reply: %#v", genericReply)
+	}
+}
+
+// send sends query about request to light executor if request is within light chain limit, otherwise to heavy.
+// Heavy is also asked if light doesn't have records, since they could be cleaned right after replication.
+func (a *LedgerArchive) send(ctx context.Context, msg core.Message) (core.Reply, error) {
+	currentPulse, err := a.PulseStorage.Current(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bus := core.MessageBusFromContext(ctx, a.DefaultBus)
+
+	target := *msg.DefaultTarget().Record()
+	beyond, err := a.JetCoordinator.IsBeyondLimit(ctx, currentPulse.PulseNumber, target.Pulse())
+	if err != nil {
+		return nil, err
+	}
+	if !beyond {
+		sender := BuildSender(bus.Send, retryJetSender(currentPulse.PulseNumber, a.JetStorage))
+		genericReply, err := sender(ctx, msg, nil)
+		if err != nil {
+			return nil, err
+		}
+		if r, ok := genericReply.(*reply.Error); !ok || r.ErrType != reply.ErrNotFound {
+			return genericReply, nil
+		}
+	}
+
+	heavy, err := readHeavy(ctx, a.JetCoordinator, currentPulse.PulseNumber)
+	if err != nil {
+		return nil, err
+	}
+	return bus.Send(ctx, msg, &core.MessageSendOptions{Receiver: heavy})
+}
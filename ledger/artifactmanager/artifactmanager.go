@@ -77,7 +77,7 @@ func (m *LedgerArtifactManager) GenesisRef() *core.RecordRef {
 // returns request record Ref if request successfully created or already exists.
 func (m *LedgerArtifactManager) RegisterRequest(
 	ctx context.Context, obj core.RecordRef, parcel core.Parcel,
-) (*core.RecordID, error) {
+) (*core.RecordID, uint64, error) {
 	var err error
 	ctx, span := instracer.StartSpan(ctx, "artifactmanager.RegisterRequest")
 	instrumenter := instrument(ctx, "RegisterRequest").err(&err)
@@ -91,7 +91,7 @@ func (m *LedgerArtifactManager) RegisterRequest(
 
 	currentPulse, err := m.PulseStorage.Current(ctx)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	rec := &record.RequestRecord{
@@ -99,18 +99,75 @@ func (m *LedgerArtifactManager) RegisterRequest(
 		MessageHash: m.PlatformCryptographyScheme.IntegrityHasher().Hash(message.MustSerializeBytes(parcel.Message())),
 		Object:      *obj.Record(),
 	}
+	if msg, ok := parcel.Message().(message.IBaseLogicMessage); ok {
+		rec.SignedNonce = msg.GetBaseLogicMessage().SignedNonce
+	}
 	recID := record.NewRecordIDFromRecord(
 		m.PlatformCryptographyScheme,
 		currentPulse.PulseNumber,
 		rec)
 	recRef := core.NewRecordRef(*parcel.DefaultTarget().Domain(), *recID)
-	id, err := m.setRecord(
-		ctx,
-		rec,
-		*recRef,
-		*currentPulse,
-	)
-	return id, errors.Wrap(err, "[ RegisterRequest ] ")
+
+	bus := core.MessageBusFromContext(ctx, m.DefaultBus)
+	sender := BuildSender(bus.Send, retryJetSender(currentPulse.PulseNumber, m.JetStorage))
+	genericReply, err := sender(ctx, &message.SetRecord{
+		Record:    record.SerializeRecord(rec),
+		TargetRef: *recRef,
+	}, nil)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "[ RegisterRequest ] ")
+	}
+
+	switch rep := genericReply.(type) {
+	case *reply.ID:
+		return &rep.ID, rep.Sequence, nil
+	case *reply.Error:
+		err = rep.Error()
+		return nil, 0, errors.Wrap(err, "[ RegisterRequest ] ")
+	default:
+		err = fmt.Errorf("[ RegisterRequest ] unexpected reply: %#v", rep)
+		return nil, 0, err
+	}
+}
+
+// RegisterTimer creates timer record for call object scheduled on itself in future pulse.
+//
+// Light material keeps the timer with pending requests of object and notifies executor of due pulse.
+func (m *LedgerArtifactManager) RegisterTimer(
+	ctx context.Context, obj core.RecordRef, pulse core.PulseNumber, msg core.Message,
+) (*core.RecordID, error) {
+	var err error
+	ctx, span := instracer.StartSpan(ctx, "artifactmanager.RegisterTimer")
+	instrumenter := instrument(ctx, "RegisterTimer").err(&err)
+	defer func() {
+		if err != nil {
+			span.AddAttributes(trace.StringAttribute("error", err.Error()))
+		}
+		span.End()
+		instrumenter.end()
+	}()
+
+	currentPulse, err := m.PulseStorage.Current(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if pulse <= currentPulse.PulseNumber {
+		err = errors.Errorf("[ RegisterTimer ] timer pulse %d isn't in future", pulse)
+		return nil, err
+	}
+
+	rec := &record.TimerRecord{
+		Message: message.MustSerializeBytes(msg),
+		Object:  *obj.Record(),
+		Pulse:   pulse,
+	}
+	recID := record.NewRecordIDFromRecord(m.PlatformCryptographyScheme, currentPulse.PulseNumber, rec)
+
+	id, err := m.setRecord(ctx, rec, *core.NewRecordRef(*obj.Domain(), *recID), *currentPulse)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ RegisterTimer ] ")
+	}
+	return id, nil
 }
 
 // GetCode returns code from code record by provided reference according to provided machine preference.
@@ -156,6 +213,7 @@ func (m *LedgerArtifactManager) GetCode(
 			ref:         code,
 			machineType: rep.MachineType,
 			code:        rep.Code,
+			schema:      rep.Schema,
 		}
 		return &desc, nil
 	case *reply.Error:
@@ -193,9 +251,10 @@ func (m *LedgerArtifactManager) GetObject(
 	}()
 
 	getObjectMsg := &message.GetObject{
-		Head:     head,
-		State:    state,
-		Approved: approved,
+		Head:      head,
+		State:     state,
+		Approved:  approved,
+		WithProof: core.ObjectProofsRequested(ctx),
 	}
 
 	currentPulse, err := m.PulseStorage.Current(ctx)
@@ -217,6 +276,12 @@ func (m *LedgerArtifactManager) GetObject(
 
 	switch r := genericReact.(type) {
 	case *reply.Object:
+		if r.Proof != nil {
+			err = VerifyObjectProof(m.PlatformCryptographyScheme, r)
+			if err != nil {
+				return nil, errors.Wrap(err, "GetObject: invalid proof of object state")
+			}
+		}
 		desc = &ObjectDescriptor{
 			ctx:          ctx,
 			am:           m,
@@ -236,10 +301,85 @@ func (m *LedgerArtifactManager) GetObject(
 	}
 }
 
+// GetObjects returns descriptors of the latest states of objects and codes fetched in one round trip.
+//
+// Batch is served by executor of the first object, objects and codes stored elsewhere are missing in returned maps.
+func (m *LedgerArtifactManager) GetObjects(
+	ctx context.Context, heads []core.RecordRef, codes []core.RecordRef,
+) (map[core.RecordRef]core.ObjectDescriptor, map[core.RecordRef]core.CodeDescriptor, error) {
+	var err error
+	instrumenter := instrument(ctx, "GetObjects").err(&err)
+	ctx, span := instracer.StartSpan(ctx, "artifactmanager.GetObjects")
+	defer func() {
+		if err != nil {
+			span.AddAttributes(trace.StringAttribute("error", err.Error()))
+		}
+		span.End()
+		instrumenter.end()
+	}()
+
+	objects := map[core.RecordRef]core.ObjectDescriptor{}
+	codeDescs := map[core.RecordRef]core.CodeDescriptor{}
+	if len(heads) == 0 {
+		return objects, codeDescs, nil
+	}
+
+	currentPulse, err := m.PulseStorage.Current(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bus := core.MessageBusFromContext(ctx, m.DefaultBus)
+	sender := BuildSender(
+		bus.Send,
+		retryJetSender(currentPulse.PulseNumber, m.JetStorage),
+	)
+
+	genericReact, err := sender(ctx, &message.GetObjects{Heads: heads, Codes: codes}, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch r := genericReact.(type) {
+	case *reply.Objects:
+		for head, obj := range r.Objects {
+			objects[head] = &ObjectDescriptor{
+				ctx:          ctx,
+				am:           m,
+				head:         obj.Head,
+				state:        obj.State,
+				prototype:    obj.Prototype,
+				isPrototype:  obj.IsPrototype,
+				childPointer: obj.ChildPointer,
+				memory:       obj.Memory,
+				parent:       obj.Parent,
+			}
+		}
+		for ref, code := range r.Codes {
+			codeDescs[ref] = &CodeDescriptor{
+				ctx:         ctx,
+				ref:         ref,
+				machineType: code.MachineType,
+				code:        code.Code,
+				schema:      code.Schema,
+			}
+		}
+		return objects, codeDescs, nil
+	case *reply.Error:
+		err = r.Error()
+		return nil, nil, err
+	default:
+		err = fmt.Errorf("GetObjects: unexpected reply: %#v", genericReact)
+		return nil, nil, err
+	}
+}
+
 // GetPendingRequest returns an unclosed pending request
 // It takes an id from current LME
 // Then goes either to a light node or heavy node
-func (m *LedgerArtifactManager) GetPendingRequest(ctx context.Context, objectID core.RecordID) (core.Parcel, error) {
+func (m *LedgerArtifactManager) GetPendingRequest(
+	ctx context.Context, objectID core.RecordID,
+) (core.Parcel, uint64, error) {
 	var err error
 	instrumenter := instrument(ctx, "GetRegisterRequest").err(&err)
 	ctx, span := instracer.StartSpan(ctx, "artifactmanager.GetRegisterRequest")
@@ -263,7 +403,7 @@ func (m *LedgerArtifactManager) GetPendingRequest(ctx context.Context, objectID
 		ObjectID: objectID,
 	}, nil)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	var requestIDReply *reply.ID
@@ -271,15 +411,15 @@ func (m *LedgerArtifactManager) GetPendingRequest(ctx context.Context, objectID
 	case *reply.ID:
 		requestIDReply = r
 	case *reply.Error:
-		return nil, r.Error()
+		return nil, 0, r.Error()
 	default:
-		return nil, fmt.Errorf("GetPendingRequest: unexpected reply: %#v", requestIDReply)
+		return nil, 0, fmt.Errorf("GetPendingRequest: unexpected reply: %#v", requestIDReply)
 	}
 
 	node, err := m.JetCoordinator.NodeForObject(ctx, objectID, currentPulse.PulseNumber, requestIDReply.ID.Pulse())
 
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	sender = BuildSender(
@@ -295,7 +435,7 @@ func (m *LedgerArtifactManager) GetPendingRequest(ctx context.Context, objectID
 		},
 	)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	switch r := genericReply.(type) {
@@ -303,14 +443,18 @@ func (m *LedgerArtifactManager) GetPendingRequest(ctx context.Context, objectID
 		rec := record.DeserializeRecord(r.Record)
 		castedRecord, ok := rec.(*record.RequestRecord)
 		if !ok {
-			return nil, fmt.Errorf("GetPendingRequest: unexpected message: %#v", r)
+			return nil, 0, fmt.Errorf("GetPendingRequest: unexpected message: %#v", r)
 		}
 
-		return message.DeserializeParcel(bytes.NewBuffer(castedRecord.Parcel))
+		parcel, err := message.DeserializeParcel(bytes.NewBuffer(castedRecord.Parcel))
+		if err != nil {
+			return nil, 0, err
+		}
+		return parcel, castedRecord.Sequence, nil
 	case *reply.Error:
-		return nil, r.Error()
+		return nil, 0, r.Error()
 	default:
-		return nil, fmt.Errorf("GetPendingRequest: unexpected reply: %#v", requestIDReply)
+		return nil, 0, fmt.Errorf("GetPendingRequest: unexpected reply: %#v", requestIDReply)
 	}
 }
 
@@ -458,13 +602,15 @@ func (m *LedgerArtifactManager) DeclareType(
 
 // DeployCode creates new code record in storage.
 //
-// CodeRef records are used to activate prototype or as migration code for an object.
+// CodeRef records are used to activate prototype or as migration code for an object. Schema of contract methods
+// is stored as separate blob, its id is saved in code record.
 func (m *LedgerArtifactManager) DeployCode(
 	ctx context.Context,
 	domain core.RecordRef,
 	request core.RecordRef,
 	code []byte,
 	machineType core.MachineType,
+	schema []byte,
 ) (*core.RecordID, error) {
 	var err error
 	ctx, span := instracer.StartSpan(ctx, "artifactmanager.DeployCode")
@@ -490,6 +636,9 @@ func (m *LedgerArtifactManager) DeployCode(
 		Code:        record.CalculateIDForBlob(m.PlatformCryptographyScheme, currentPulse.PulseNumber, code),
 		MachineType: machineType,
 	}
+	if schema != nil {
+		codeRec.Schema = record.CalculateIDForBlob(m.PlatformCryptographyScheme, currentPulse.PulseNumber, schema)
+	}
 	codeID := record.NewRecordIDFromRecord(m.PlatformCryptographyScheme, currentPulse.PulseNumber, codeRec)
 	codeRef := core.NewRecordRef(*domain.Record(), *codeID)
 
@@ -497,6 +646,12 @@ func (m *LedgerArtifactManager) DeployCode(
 	if err != nil {
 		return nil, err
 	}
+	if schema != nil {
+		_, err = m.setBlob(ctx, schema, *codeRef, *currentPulse)
+		if err != nil {
+			return nil, err
+		}
+	}
 	id, err := m.setRecord(
 		ctx,
 		codeRec,
@@ -728,6 +883,66 @@ func (m *LedgerArtifactManager) RegisterResult(
 	return recid, err
 }
 
+// GetResult returns payload of result registered for provided request.
+//
+// Result is looked up on current light executor first. If it's not there, heavy node is asked, because result
+// could be registered in one of previous pulses and replicated already.
+func (m *LedgerArtifactManager) GetResult(
+	ctx context.Context, request core.RecordRef,
+) ([]byte, error) {
+	var err error
+	ctx, span := instracer.StartSpan(ctx, "artifactmanager.GetResult")
+	instrumenter := instrument(ctx, "GetResult").err(&err)
+	defer func() {
+		if err != nil {
+			span.AddAttributes(trace.StringAttribute("error", err.Error()))
+		}
+		span.End()
+		instrumenter.end()
+	}()
+
+	currentPulse, err := m.PulseStorage.Current(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &message.GetResult{Request: *request.Record()}
+	bus := core.MessageBusFromContext(ctx, m.DefaultBus)
+	sender := BuildSender(bus.Send, retryJetSender(currentPulse.PulseNumber, m.JetStorage))
+	genericReply, err := sender(ctx, msg, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if r, ok := genericReply.(*reply.Error); ok && r.ErrType == reply.ErrNotFound {
+		var heavy *core.RecordRef
+		heavy, err = m.JetCoordinator.Heavy(ctx, currentPulse.PulseNumber)
+		if err != nil {
+			return nil, err
+		}
+		genericReply, err = bus.Send(ctx, msg, &core.MessageSendOptions{Receiver: heavy})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch r := genericReply.(type) {
+	case *reply.Result:
+		rec, ok := record.DeserializeRecord(r.Record).(*record.ResultRecord)
+		if !ok {
+			err = fmt.Errorf("GetResult: unexpected record: %#v", r)
+			return nil, err
+		}
+		return rec.Payload, nil
+	case *reply.Error:
+		err = r.Error()
+		return nil, err
+	default:
+		err = fmt.Errorf("GetResult: unexpected reply: %#v", genericReply)
+		return nil, err
+	}
+}
+
 func (m *LedgerArtifactManager) activateObject(
 	ctx context.Context,
 	domain core.RecordRef,
@@ -41,6 +41,7 @@ import (
 	"github.com/insolar/insolar/ledger/storage/storagetest"
 	"github.com/insolar/insolar/platformpolicy"
 	"github.com/insolar/insolar/testutils"
+	"github.com/insolar/insolar/testutils/conformance"
 	"github.com/insolar/insolar/testutils/testmessagebus"
 )
 
@@ -163,6 +164,7 @@ func getTestData(s *amSuite) (
 		PlatformCryptographyScheme: s.scheme,
 		conf:                       &configuration.Ledger{LightChainLimit: 3, PendingRequestsLimit: 10},
 		certificate:                certificate,
+		quota:                      newWriteQuota(),
 	}
 
 	handler.NodeStorage = s.nodeStorage
@@ -178,6 +180,7 @@ func getTestData(s *amSuite) (
 	pendingMock.GetRequestsForObjectMock.Return(nil)
 	pendingMock.AddPendingRequestMock.Return()
 	pendingMock.RemovePendingRequestMock.Return()
+	pendingMock.RemoveTimerMock.Return()
 
 	provideMock := recentstorage.NewProviderMock(s.T())
 	provideMock.GetIndexStorageMock.Return(indexMock)
@@ -216,7 +219,7 @@ func (s *amSuite) TestLedgerArtifactManager_RegisterRequest() {
 	ctx, os, am := getTestData(s)
 
 	parcel := message.Parcel{Msg: &message.GenesisRequest{Name: "4K3NiGuqYGqKPnYp6XeGd2kdN4P9veL6rYcWkLKWXZCu.4FFB8zfQoGznSmzDxwv4njX1aR9ioL8GHSH17QXH2AFa"}}
-	id, err := am.RegisterRequest(ctx, *am.GenesisRef(), &parcel)
+	id, _, err := am.RegisterRequest(ctx, *am.GenesisRef(), &parcel)
 	assert.NoError(s.T(), err)
 	rec, err := os.GetRecord(ctx, *jet.NewID(0, nil), id)
 	assert.NoError(s.T(), err)
@@ -302,6 +305,7 @@ func (s *amSuite) TestLedgerArtifactManager_DeployCode_CreatesCorrectRecord() {
 		requestRef,
 		[]byte{1, 2, 3},
 		core.MachineTypeBuiltin,
+		nil,
 	)
 	assert.NoError(s.T(), err)
 	codeRec, err := os.GetRecord(ctx, *jet.NewID(0, nil), id)
@@ -316,6 +320,29 @@ func (s *amSuite) TestLedgerArtifactManager_DeployCode_CreatesCorrectRecord() {
 	})
 }
 
+func (s *amSuite) TestLedgerArtifactManager_DeployCode_StoresSchema() {
+	ctx, os, am := getTestData(s)
+	jetID := *jet.NewID(0, nil)
+	schema := []byte(`{"methods":{"Get":{"arguments":[],"results":[{"type":"int"}]}}}`)
+
+	id, err := am.DeployCode(ctx, domainRef, requestRef, []byte{1, 2, 3}, core.MachineTypeBuiltin, schema)
+	require.NoError(s.T(), err)
+
+	rec, err := os.GetRecord(ctx, jetID, id)
+	require.NoError(s.T(), err)
+	codeRec, ok := rec.(*record.CodeRecord)
+	require.True(s.T(), ok)
+	require.NotNil(s.T(), codeRec.Schema)
+	assert.Equal(
+		s.T(),
+		record.CalculateIDForBlob(am.PlatformCryptographyScheme, core.GenesisPulse.PulseNumber, schema),
+		codeRec.Schema,
+	)
+	blob, err := os.GetBlob(ctx, jetID, codeRec.Schema)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), schema, blob)
+}
+
 func (s *amSuite) TestLedgerArtifactManager_ActivateObject_CreatesCorrectRecord() {
 	ctx, os, am := getTestData(s)
 	jetID := *jet.NewID(0, nil)
@@ -790,6 +817,7 @@ func (s *amSuite) TestLedgerArtifactManager_RegisterValidation() {
 	pendingMock.GetRequestsForObjectMock.Return(nil)
 	pendingMock.AddPendingRequestMock.Return()
 	pendingMock.RemovePendingRequestMock.Return()
+	pendingMock.RemoveTimerMock.Return()
 
 	provideMock := recentstorage.NewProviderMock(s.T())
 	provideMock.GetIndexStorageMock.Return(indexMock)
@@ -804,6 +832,7 @@ func (s *amSuite) TestLedgerArtifactManager_RegisterValidation() {
 		PlatformCryptographyScheme: s.scheme,
 		conf:                       &configuration.Ledger{LightChainLimit: 3, PendingRequestsLimit: 10},
 		certificate:                certificate,
+		quota:                      newWriteQuota(),
 	}
 
 	handler.Bus = mb
@@ -835,7 +864,7 @@ func (s *amSuite) TestLedgerArtifactManager_RegisterValidation() {
 		GenesisState:               s.genesisState,
 	}
 
-	objID, err := am.RegisterRequest(
+	objID, _, err := am.RegisterRequest(
 		s.ctx,
 		*am.GenesisRef(),
 		&message.Parcel{
@@ -925,7 +954,7 @@ func (s *amSuite) TestLedgerArtifactManager_RegisterRequest_JetMiss() {
 		mb := testutils.NewMessageBusMock(mc)
 		am.DefaultBus = mb
 		mb.SendMock.Return(&reply.JetMiss{JetID: *jet.NewID(5, []byte{1, 2, 3})}, nil)
-		_, err := am.RegisterRequest(s.ctx, *am.GenesisRef(), &message.Parcel{Msg: &message.CallMethod{}})
+		_, _, err := am.RegisterRequest(s.ctx, *am.GenesisRef(), &message.Parcel{Msg: &message.CallMethod{}})
 		require.Error(t, err)
 	})
 
@@ -940,7 +969,7 @@ func (s *amSuite) TestLedgerArtifactManager_RegisterRequest_JetMiss() {
 			retries--
 			return &reply.JetMiss{JetID: *jet.NewID(4, []byte{0xD5})}, nil
 		}
-		_, err := am.RegisterRequest(s.ctx, *am.GenesisRef(), &message.Parcel{Msg: &message.CallMethod{}})
+		_, _, err := am.RegisterRequest(s.ctx, *am.GenesisRef(), &message.Parcel{Msg: &message.CallMethod{}})
 		require.NoError(t, err)
 
 		jetID, actual := s.jetStorage.FindJet(
@@ -997,10 +1026,19 @@ func (s *amSuite) TestLedgerArtifactManager_GetRequest_Success() {
 	am.PulseStorage = pulseStorageMock
 
 	// Act
-	res, err := am.GetPendingRequest(inslogger.TestContext(s.T()), objectID)
+	res, _, err := am.GetPendingRequest(inslogger.TestContext(s.T()), objectID)
 
 	// Assert
 	require.NoError(s.T(), err)
 	require.Equal(s.T(), parcel, res)
 
 }
+
+func (s *amSuite) TestLedgerArtifactManager_Conformance() {
+	conformance.ArtifactManager(s.T(), func(t *testing.T) (context.Context, core.ArtifactManager) {
+		ctx, _, am := getTestData(s)
+		am.JetStorage = s.jetStorage
+		am.senders = newLedgerArtifactSenders()
+		return ctx, am
+	})
+}
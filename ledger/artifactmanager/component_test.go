@@ -28,6 +28,7 @@ import (
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/ledger/recentstorage"
 	"github.com/insolar/insolar/ledger/storage"
+	"github.com/insolar/insolar/ledger/storage/index"
 	"github.com/insolar/insolar/ledger/storage/jet"
 	"github.com/insolar/insolar/ledger/storage/storagetest"
 	"github.com/insolar/insolar/platformpolicy"
@@ -164,9 +165,12 @@ func (s *componentSuite) TestLedgerArtifactManager_PendingRequest() {
 
 	s.jetStorage.UpdateJetTree(s.ctx, core.FirstPulseNumber, true, jetID)
 	s.jetStorage.UpdateJetTree(s.ctx, core.FirstPulseNumber+1, true, jetID)
+	// object exists, so its index isn't fetched from heavy
+	err = s.objectStorage.SetObjectIndex(s.ctx, jetID, objRef.Record(), &index.ObjectLifeline{})
+	require.NoError(s.T(), err)
 
 	// Register request
-	reqID, err := am.RegisterRequest(s.ctx, objRef, &message.Parcel{Msg: &message.CallMethod{}, PulseNumber: core.FirstPulseNumber})
+	reqID, _, err := am.RegisterRequest(s.ctx, objRef, &message.Parcel{Msg: &message.CallMethod{}, PulseNumber: core.FirstPulseNumber})
 	require.NoError(s.T(), err)
 
 	// Change pulse.
@@ -32,6 +32,7 @@ type CodeDescriptor struct {
 	code        []byte
 	machineType core.MachineType
 	ref         core.RecordRef
+	schema      []byte
 
 	ctx context.Context
 	am  core.ArtifactManager
@@ -52,6 +53,11 @@ func (d *CodeDescriptor) Code() ([]byte, error) {
 	return d.code, nil
 }
 
+// Schema returns JSON schema of contract methods, nil if code was deployed without schema.
+func (d *CodeDescriptor) Schema() []byte {
+	return d.schema
+}
+
 // ObjectDescriptor represents meta info required to fetch all object data.
 type ObjectDescriptor struct {
 	ctx context.Context
@@ -0,0 +1,99 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package artifactmanager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/core/reply"
+)
+
+// FinalityChecker checks finality of requests by asking ledger whether request is replicated to heavy node
+// and whether validators approved object state produced by request.
+type FinalityChecker struct {
+	DefaultBus      core.MessageBus      `inject:""`
+	PulseStorage    core.PulseStorage    `inject:""`
+	JetCoordinator  core.JetCoordinator  `inject:""`
+	ArtifactManager core.ArtifactManager `inject:""`
+}
+
+// NewFinalityChecker creates new finality checker.
+func NewFinalityChecker() *FinalityChecker {
+	return &FinalityChecker{}
+}
+
+// GetFinality returns finality of request to object.
+func (c *FinalityChecker) GetFinality(ctx context.Context, object core.RecordRef, request core.RecordRef) (core.Finality, error) {
+	replicated, err := c.isReplicated(ctx, request)
+	if err != nil {
+		return core.FinalityUnknown, errors.Wrap(err, "[ GetFinality ] failed to check replication")
+	}
+	if replicated {
+		return core.FinalityReplicated, nil
+	}
+
+	desc, err := c.ArtifactManager.GetObject(ctx, object, nil, true)
+	if err == core.ErrStateNotAvailable {
+		return core.FinalityExecuted, nil
+	}
+	if err != nil {
+		return core.FinalityUnknown, errors.Wrap(err, "[ GetFinality ] failed to fetch approved state")
+	}
+	if desc.StateID().Pulse() >= request.Record().Pulse() {
+		return core.FinalityValidated, nil
+	}
+	return core.FinalityExecuted, nil
+}
+
+// isReplicated checks if request record is stored on heavy node.
+func (c *FinalityChecker) isReplicated(ctx context.Context, request core.RecordRef) (bool, error) {
+	pulse, err := c.PulseStorage.Current(ctx)
+	if err != nil {
+		return false, err
+	}
+	heavy, err := c.JetCoordinator.Heavy(ctx, pulse.PulseNumber)
+	if err != nil {
+		return false, err
+	}
+
+	bus := core.MessageBusFromContext(ctx, c.DefaultBus)
+	genericReply, err := bus.Send(ctx, &message.GetRequest{
+		Request: *request.Record(),
+	}, &core.MessageSendOptions{
+		Receiver: heavy,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	switch r := genericReply.(type) {
+	case *reply.Request:
+		return true, nil
+	case *reply.Error:
+		if r.ErrType == reply.ErrNotFound {
+			return false, nil
+		}
+		return false, r.Error()
+	default:
+		return false, fmt.Errorf("isReplicated: unexpected reply: %#v", genericReply)
+	}
+}
@@ -0,0 +1,95 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package artifactmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gojuno/minimock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestFinalityChecker_GetFinality(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	heavy := testutils.RandomRef()
+	object := testutils.RandomRef()
+	request := *core.NewRecordRef(core.RecordID{}, *core.NewRecordID(core.FirstPulseNumber+1, nil))
+
+	ps := testutils.NewPulseStorageMock(mc)
+	ps.CurrentMock.Return(&core.Pulse{PulseNumber: core.FirstPulseNumber + 2}, nil)
+	jc := testutils.NewJetCoordinatorMock(mc)
+	jc.HeavyMock.Return(&heavy, nil)
+
+	var onHeavy bool
+	mb := testutils.NewMessageBusMock(mc)
+	mb.SendFunc = func(ctx context.Context, msg core.Message, options *core.MessageSendOptions) (core.Reply, error) {
+		require.Equal(t, &message.GetRequest{Request: *request.Record()}, msg)
+		require.Equal(t, heavy, *options.Receiver)
+		if onHeavy {
+			return &reply.Request{}, nil
+		}
+		return &reply.Error{ErrType: reply.ErrNotFound}, nil
+	}
+
+	var approved core.ObjectDescriptor
+	am := testutils.NewArtifactManagerMock(mc)
+	am.GetObjectFunc = func(ctx context.Context, head core.RecordRef, state *core.RecordID, isApproved bool) (core.ObjectDescriptor, error) {
+		require.Equal(t, object, head)
+		require.True(t, isApproved)
+		if approved == nil {
+			return nil, core.ErrStateNotAvailable
+		}
+		return approved, nil
+	}
+
+	checker := NewFinalityChecker()
+	checker.DefaultBus = mb
+	checker.PulseStorage = ps
+	checker.JetCoordinator = jc
+	checker.ArtifactManager = am
+
+	finality, err := checker.GetFinality(ctx, object, request)
+	require.NoError(t, err)
+	require.Equal(t, core.FinalityExecuted, finality)
+
+	desc := testutils.NewObjectDescriptorMock(mc)
+	desc.StateIDMock.Return(core.NewRecordID(core.FirstPulseNumber, nil))
+	approved = desc
+	finality, err = checker.GetFinality(ctx, object, request)
+	require.NoError(t, err)
+	require.Equal(t, core.FinalityExecuted, finality, "approved state is older than request")
+
+	desc.StateIDMock.Return(core.NewRecordID(core.FirstPulseNumber+1, nil))
+	finality, err = checker.GetFinality(ctx, object, request)
+	require.NoError(t, err)
+	require.Equal(t, core.FinalityValidated, finality)
+
+	onHeavy = true
+	finality, err = checker.GetFinality(ctx, object, request)
+	require.NoError(t, err)
+	require.Equal(t, core.FinalityReplicated, finality)
+}
@@ -0,0 +1,297 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package artifactmanager
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/ledger/storage"
+	"github.com/insolar/insolar/ledger/storage/index"
+	"github.com/insolar/insolar/ledger/storage/record"
+)
+
+// defaultReplayDepth is a depth of replay below root domain, which covers members and node domain with its nodes.
+const defaultReplayDepth = 2
+
+// GenesisReplayer reconstructs system objects from their ledger records and verifies them against live state
+// fetched through artifact manager.
+type GenesisReplayer struct {
+	ObjectStorage   storage.ObjectStorage `inject:""`
+	JetStorage      storage.JetStorage    `inject:""`
+	PulseStorage    core.PulseStorage     `inject:""`
+	ArtifactManager core.ArtifactManager  `inject:""`
+
+	certificate core.Certificate
+}
+
+// NewGenesisReplayer creates new genesis replayer.
+func NewGenesisReplayer(certificate core.Certificate) *GenesisReplayer {
+	return &GenesisReplayer{certificate: certificate}
+}
+
+// ReplayGenesis reconstructs root domain and its descendants up to depth levels below it. Default depth is used
+// if provided one isn't positive.
+func (r *GenesisReplayer) ReplayGenesis(ctx context.Context, depth int) (*core.GenesisReplayReport, error) {
+	if depth <= 0 {
+		depth = defaultReplayDepth
+	}
+	rootDomain := r.certificate.GetRootDomainReference()
+	if rootDomain == nil {
+		return nil, errors.New("[ ReplayGenesis ] root domain is unknown")
+	}
+	pulse, err := r.PulseStorage.Current(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ ReplayGenesis ] failed to fetch current pulse")
+	}
+
+	report := &core.GenesisReplayReport{}
+	visited := map[core.RecordRef]struct{}{}
+	queue := []replayItem{{object: *rootDomain}}
+	for len(queue) > 0 {
+		item := queue[0]
+		queue = queue[1:]
+		if _, ok := visited[item.object]; ok {
+			continue
+		}
+		visited[item.object] = struct{}{}
+
+		replayed, children, err := r.replayObject(ctx, pulse.PulseNumber, item.object)
+		if err != nil {
+			return nil, errors.Wrapf(err, "[ ReplayGenesis ] failed to replay object %s", item.object)
+		}
+		if item.parent != nil && replayed.Parent != *item.parent {
+			replayed.Mismatches = append(replayed.Mismatches, fmt.Sprintf(
+				"parent %s differs from object %s which has it as a child", replayed.Parent, item.parent,
+			))
+		}
+		report.Objects = append(report.Objects, *replayed)
+
+		if item.level >= depth {
+			continue
+		}
+		for _, child := range children {
+			parent := item.object
+			queue = append(queue, replayItem{object: child, parent: &parent, level: item.level + 1})
+		}
+	}
+	return report, nil
+}
+
+type replayItem struct {
+	object core.RecordRef
+	parent *core.RecordRef
+	level  int
+}
+
+// replayedState is an object state reconstructed from records with its materialized memory.
+type replayedState struct {
+	id     core.RecordID
+	state  record.ObjectState
+	memory []byte
+}
+
+func (r *GenesisReplayer) replayObject(
+	ctx context.Context, pulse core.PulseNumber, object core.RecordRef,
+) (*core.ReplayedObject, []core.RecordRef, error) {
+	jetID, _ := r.JetStorage.FindJet(ctx, pulse, *object.Record())
+	idx, err := r.ObjectStorage.GetObjectIndex(ctx, *jetID, object.Record(), false)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to fetch object index")
+	}
+
+	replayed := &core.ReplayedObject{Object: object, Parent: idx.Parent}
+	states, mismatches, err := r.replayStates(ctx, *jetID, idx)
+	if err != nil {
+		return nil, nil, err
+	}
+	replayed.Mismatches = append(replayed.Mismatches, mismatches...)
+	replayed.States = len(states)
+	if len(states) > 0 {
+		latest := states[len(states)-1]
+		replayed.LatestState = latest.id
+		replayed.Deactivated = latest.state.State() == record.StateDeactivation
+	}
+
+	children, err := r.replayChildren(ctx, *jetID, idx.ChildPointer)
+	if err != nil {
+		return nil, nil, err
+	}
+	replayed.Children = len(children)
+
+	if len(states) > 0 {
+		replayed.Mismatches = append(replayed.Mismatches, r.compareLive(ctx, object, idx, states)...)
+	}
+	return replayed, children, nil
+}
+
+// replayStates walks object states from the latest one back to activation and materializes memory of each state
+// forward from activation, checking that the lifeline is well-formed.
+func (r *GenesisReplayer) replayStates(
+	ctx context.Context, jetID core.RecordID, idx *index.ObjectLifeline,
+) ([]replayedState, []string, error) {
+	var (
+		states     []replayedState
+		mismatches []string
+	)
+	seen := map[core.RecordID]struct{}{}
+	for id := idx.LatestState; id != nil; {
+		if _, ok := seen[*id]; ok {
+			return nil, append(mismatches, fmt.Sprintf("state %s is referenced twice in lifeline", id)), nil
+		}
+		seen[*id] = struct{}{}
+
+		rec, err := r.ObjectStorage.GetRecord(ctx, jetID, id)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to fetch state %s", id)
+		}
+		state, ok := rec.(record.ObjectState)
+		if !ok {
+			return nil, append(mismatches, fmt.Sprintf("record %s isn't an object state", id)), nil
+		}
+		states = append(states, replayedState{id: *id, state: state})
+		id = state.PrevStateID()
+	}
+	if len(states) == 0 {
+		return nil, append(mismatches, "object has no states"), nil
+	}
+	for i, j := 0, len(states)-1; i < j; i, j = i+1, j-1 {
+		states[i], states[j] = states[j], states[i]
+	}
+
+	activation, ok := states[0].state.(*record.ObjectActivateRecord)
+	if !ok {
+		mismatches = append(mismatches, fmt.Sprintf("first state %s isn't an activation", states[0].id))
+	} else if activation.Parent != idx.Parent {
+		mismatches = append(mismatches, fmt.Sprintf(
+			"parent %s of activation differs from parent %s of index", activation.Parent, idx.Parent,
+		))
+	}
+
+	var memory []byte
+	for i := range states {
+		state := &states[i]
+		if i > 0 && state.state.State() == record.StateActivation {
+			mismatches = append(mismatches, fmt.Sprintf("state %s is a repeated activation", state.id))
+		}
+		if i < len(states)-1 && state.state.State() == record.StateDeactivation {
+			mismatches = append(mismatches, fmt.Sprintf("state %s follows deactivation", states[i+1].id))
+		}
+		if i > 0 && state.id.Pulse() < states[i-1].id.Pulse() {
+			mismatches = append(mismatches, fmt.Sprintf("state %s precedes its previous state", state.id))
+		}
+
+		if state.state.GetMemory() == nil {
+			memory = nil
+			state.memory = nil
+			continue
+		}
+		blob, err := r.ObjectStorage.GetBlob(ctx, jetID, state.state.GetMemory())
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to fetch memory of state %s", state.id)
+		}
+		if amend, ok := state.state.(*record.ObjectAmendRecord); ok && amend.MemoryDiffDepth > 0 {
+			blob, err = storage.PatchMemory(memory, blob)
+			if err != nil {
+				mismatches = append(mismatches, fmt.Sprintf("memory diff of state %s can't be applied", state.id))
+			}
+		}
+		memory = blob
+		state.memory = blob
+	}
+
+	if latest := states[len(states)-1].state.State(); latest != idx.State {
+		mismatches = append(mismatches, fmt.Sprintf("state of index %d differs from latest record %d", idx.State, latest))
+	}
+	return states, mismatches, nil
+}
+
+func (r *GenesisReplayer) replayChildren(
+	ctx context.Context, jetID core.RecordID, pointer *core.RecordID,
+) ([]core.RecordRef, error) {
+	var children []core.RecordRef
+	seen := map[core.RecordID]struct{}{}
+	for pointer != nil {
+		if _, ok := seen[*pointer]; ok {
+			break
+		}
+		seen[*pointer] = struct{}{}
+
+		rec, err := r.ObjectStorage.GetRecord(ctx, jetID, pointer)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch child record %s", pointer)
+		}
+		child, ok := rec.(*record.ChildRecord)
+		if !ok {
+			return nil, fmt.Errorf("record %s isn't a child record", pointer)
+		}
+		children = append(children, child.Ref)
+		pointer = child.PrevChild
+	}
+	return children, nil
+}
+
+// compareLive compares the latest reconstructed state with live state returned by artifact manager.
+func (r *GenesisReplayer) compareLive(
+	ctx context.Context, object core.RecordRef, idx *index.ObjectLifeline, states []replayedState,
+) []string {
+	latest := states[len(states)-1]
+	deactivated := latest.state.State() == record.StateDeactivation
+
+	desc, err := r.ArtifactManager.GetObject(ctx, object, nil, false)
+	if errors.Cause(err) == core.ErrDeactivated || (err == nil && desc == nil) {
+		if deactivated {
+			return nil
+		}
+		return []string{"live object is deactivated"}
+	}
+	if err != nil {
+		return []string{fmt.Sprintf("live state is unavailable: %s", err)}
+	}
+	if deactivated {
+		return []string{"live object isn't deactivated"}
+	}
+
+	var mismatches []string
+	if desc.StateID() == nil || *desc.StateID() != latest.id {
+		mismatches = append(mismatches, fmt.Sprintf("live state %s differs from replayed %s", desc.StateID(), latest.id))
+	}
+	if !bytes.Equal(desc.Memory(), latest.memory) {
+		mismatches = append(mismatches, "live memory differs from replayed")
+	}
+	if desc.IsPrototype() != latest.state.GetIsPrototype() {
+		mismatches = append(mismatches, "live prototype flag differs from replayed")
+	}
+	if parent := desc.Parent(); parent == nil || *parent != idx.Parent {
+		mismatches = append(mismatches, fmt.Sprintf("live parent %s differs from replayed %s", parent, idx.Parent))
+	}
+	if !equalRecordIDs(desc.ChildPointer(), idx.ChildPointer) {
+		mismatches = append(mismatches, "live child pointer differs from replayed")
+	}
+	return mismatches
+}
+
+func equalRecordIDs(a, b *core.RecordID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,232 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package artifactmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gojuno/minimock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage"
+	"github.com/insolar/insolar/ledger/storage/index"
+	"github.com/insolar/insolar/ledger/storage/record"
+	"github.com/insolar/insolar/testutils"
+)
+
+// replayLedger is a ledger of single jet with root domain, which was amended with memory diff and has a deactivated
+// child.
+type replayLedger struct {
+	records map[core.RecordID]record.Record
+	blobs   map[core.RecordID][]byte
+	indexes map[core.RecordID]*index.ObjectLifeline
+
+	rootDomain, child core.RecordRef
+	rootState         core.RecordID
+	rootMemory        []byte
+	rootChildPointer  core.RecordID
+}
+
+func newReplayLedger() *replayLedger {
+	l := &replayLedger{
+		records: map[core.RecordID]record.Record{},
+		blobs:   map[core.RecordID][]byte{},
+		indexes: map[core.RecordID]*index.ObjectLifeline{},
+	}
+	id := func(pulse core.PulseNumber) core.RecordID {
+		random := testutils.RandomID()
+		return *core.NewRecordID(pulse, random.Hash())
+	}
+	l.rootDomain = *core.NewRecordRef(core.RecordID{}, id(core.FirstPulseNumber))
+	l.child = *core.NewRecordRef(core.RecordID{}, id(core.FirstPulseNumber+1))
+
+	baseMemory, rootMemory := []byte("root domain v1"), []byte("root domain v2")
+	rootActivation, rootBase, rootDiff := id(core.FirstPulseNumber), id(core.FirstPulseNumber), id(core.FirstPulseNumber+2)
+	l.blobs[rootBase] = baseMemory
+	l.blobs[rootDiff] = storage.DiffMemory(baseMemory, rootMemory)
+	l.records[rootActivation] = &record.ObjectActivateRecord{
+		ObjectStateRecord: record.ObjectStateRecord{Memory: &rootBase},
+	}
+	l.rootState = id(core.FirstPulseNumber + 2)
+	l.records[l.rootState] = &record.ObjectAmendRecord{
+		ObjectStateRecord: record.ObjectStateRecord{Memory: &rootDiff},
+		PrevState:         rootActivation,
+		MemoryDiffDepth:   1,
+	}
+	l.rootMemory = rootMemory
+
+	l.rootChildPointer = id(core.FirstPulseNumber + 1)
+	l.records[l.rootChildPointer] = &record.ChildRecord{Ref: l.child}
+	l.indexes[*l.rootDomain.Record()] = &index.ObjectLifeline{
+		LatestState:  &l.rootState,
+		ChildPointer: &l.rootChildPointer,
+		State:        record.StateAmend,
+	}
+
+	childActivation, childDeactivation := id(core.FirstPulseNumber+1), id(core.FirstPulseNumber+3)
+	l.records[childActivation] = &record.ObjectActivateRecord{Parent: l.rootDomain}
+	l.records[childDeactivation] = &record.DeactivationRecord{PrevState: childActivation}
+	l.indexes[*l.child.Record()] = &index.ObjectLifeline{
+		LatestState: &childDeactivation,
+		Parent:      l.rootDomain,
+		State:       record.StateDeactivation,
+	}
+	return l
+}
+
+func (l *replayLedger) replayer(t *testing.T, mc *minimock.Controller) *GenesisReplayer {
+	jetID := core.TODOJetID
+
+	os := storage.NewObjectStorageMock(mc)
+	os.GetObjectIndexFunc = func(
+		ctx context.Context, jet core.RecordID, id *core.RecordID, forupdate bool,
+	) (*index.ObjectLifeline, error) {
+		require.Equal(t, jetID, jet)
+		idx, ok := l.indexes[*id]
+		if !ok {
+			return nil, core.ErrNotFound
+		}
+		return idx, nil
+	}
+	os.GetRecordFunc = func(ctx context.Context, jet core.RecordID, id *core.RecordID) (record.Record, error) {
+		rec, ok := l.records[*id]
+		if !ok {
+			return nil, core.ErrNotFound
+		}
+		return rec, nil
+	}
+	os.GetBlobFunc = func(ctx context.Context, jet core.RecordID, id *core.RecordID) ([]byte, error) {
+		blob, ok := l.blobs[*id]
+		if !ok {
+			return nil, core.ErrNotFound
+		}
+		return blob, nil
+	}
+	js := storage.NewJetStorageMock(mc)
+	js.FindJetMock.Return(&jetID, true)
+	ps := testutils.NewPulseStorageMock(mc)
+	ps.CurrentMock.Return(core.GenesisPulse, nil)
+	cert := testutils.NewCertificateMock(mc)
+	cert.GetRootDomainReferenceMock.Return(&l.rootDomain)
+
+	replayer := NewGenesisReplayer(cert)
+	replayer.ObjectStorage = os
+	replayer.JetStorage = js
+	replayer.PulseStorage = ps
+	return replayer
+}
+
+func (l *replayLedger) liveRootDomain(mc *minimock.Controller, memory []byte) core.ObjectDescriptor {
+	desc := testutils.NewObjectDescriptorMock(mc)
+	desc.StateIDMock.Return(&l.rootState)
+	desc.MemoryMock.Return(memory)
+	desc.IsPrototypeMock.Return(false)
+	desc.ParentMock.Return(&core.RecordRef{})
+	desc.ChildPointerMock.Return(&l.rootChildPointer)
+	return desc
+}
+
+func TestGenesisReplayer_ReplayGenesis(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	l := newReplayLedger()
+	replayer := l.replayer(t, mc)
+	am := testutils.NewArtifactManagerMock(mc)
+	am.GetObjectFunc = func(
+		ctx context.Context, head core.RecordRef, state *core.RecordID, approved bool,
+	) (core.ObjectDescriptor, error) {
+		if head == l.child {
+			return nil, core.ErrDeactivated
+		}
+		return l.liveRootDomain(mc, l.rootMemory), nil
+	}
+	replayer.ArtifactManager = am
+
+	report, err := replayer.ReplayGenesis(ctx, 0)
+	require.NoError(t, err)
+	require.Len(t, report.Objects, 2)
+	assert.True(t, report.Consistent(), "%v", report.Objects)
+
+	root := report.Objects[0]
+	assert.Equal(t, l.rootDomain, root.Object)
+	assert.Equal(t, 2, root.States)
+	assert.Equal(t, l.rootState, root.LatestState)
+	assert.Equal(t, 1, root.Children)
+
+	child := report.Objects[1]
+	assert.Equal(t, l.child, child.Object)
+	assert.Equal(t, l.rootDomain, child.Parent)
+	assert.True(t, child.Deactivated)
+}
+
+func TestGenesisReplayer_ReplayGenesis_Mismatch(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	l := newReplayLedger()
+	replayer := l.replayer(t, mc)
+	am := testutils.NewArtifactManagerMock(mc)
+	am.GetObjectFunc = func(
+		ctx context.Context, head core.RecordRef, state *core.RecordID, approved bool,
+	) (core.ObjectDescriptor, error) {
+		if head == l.child {
+			// live child isn't compared further once it's found active
+			return testutils.NewObjectDescriptorMock(mc), nil
+		}
+		return l.liveRootDomain(mc, []byte("diverged")), nil
+	}
+	replayer.ArtifactManager = am
+
+	report, err := replayer.ReplayGenesis(ctx, 1)
+	require.NoError(t, err)
+	require.False(t, report.Consistent())
+	assert.Equal(t, []string{"live memory differs from replayed"}, report.Objects[0].Mismatches)
+	assert.Equal(t, []string{"live object isn't deactivated"}, report.Objects[1].Mismatches)
+}
+
+func TestGenesisReplayer_ReplayGenesis_BrokenLifeline(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	l := newReplayLedger()
+	l.indexes[*l.child.Record()].State = record.StateAmend
+	replayer := l.replayer(t, mc)
+	am := testutils.NewArtifactManagerMock(mc)
+	am.GetObjectFunc = func(
+		ctx context.Context, head core.RecordRef, state *core.RecordID, approved bool,
+	) (core.ObjectDescriptor, error) {
+		if head == l.child {
+			return nil, core.ErrDeactivated
+		}
+		return l.liveRootDomain(mc, l.rootMemory), nil
+	}
+	replayer.ArtifactManager = am
+
+	report, err := replayer.ReplayGenesis(ctx, 0)
+	require.NoError(t, err)
+	require.False(t, report.Consistent())
+	assert.Empty(t, report.Objects[0].Mismatches)
+	assert.Len(t, report.Objects[1].Mismatches, 1)
+}
@@ -39,6 +39,7 @@ import (
 	"github.com/insolar/insolar/ledger/storage"
 	"github.com/insolar/insolar/ledger/storage/index"
 	"github.com/insolar/insolar/ledger/storage/record"
+	"github.com/insolar/insolar/netparams"
 )
 
 // MessageHandler processes messages for local storage interaction.
@@ -58,13 +59,17 @@ type MessageHandler struct {
 	PulseTracker               storage.PulseTracker            `inject:""`
 	DBContext                  storage.DBContext               `inject:""`
 	HotDataWaiter              HotDataWaiter                   `inject:""`
+	NetworkParameters          core.NetworkParameters          `inject:""`
 
 	certificate    core.Certificate
 	replayHandlers map[core.MessageType]core.MessageHandler
 	conf           *configuration.Ledger
 	middleware     *middleware
 	jetTreeUpdater *jetTreeUpdater
+	quota          *writeQuota
+	scannedJets    *scannedJets
 	isHeavy        bool
+	isReplica      bool
 }
 
 // NewMessageHandler creates new handler.
@@ -73,6 +78,8 @@ func NewMessageHandler(conf *configuration.Ledger, certificate core.Certificate)
 		certificate:    certificate,
 		replayHandlers: map[core.MessageType]core.MessageHandler{},
 		conf:           conf,
+		quota:          newWriteQuota(),
+		scannedJets:    newScannedJets(),
 	}
 }
 
@@ -114,6 +121,7 @@ func (h *MessageHandler) Init(ctx context.Context) error {
 	h.jetTreeUpdater = newJetTreeUpdater(h.NodeStorage, h.JetStorage, h.Bus, h.JetCoordinator)
 
 	h.isHeavy = h.certificate.GetRole() == core.StaticRoleHeavyMaterial
+	h.isReplica = h.isHeavy && h.isHeavyReplica()
 
 	// core.StaticRoleUnknown - genesis
 	if h.certificate.GetRole() == core.StaticRoleLightMaterial || h.certificate.GetRole() == core.StaticRoleUnknown {
@@ -132,12 +140,16 @@ func (h *MessageHandler) setHandlersForLight(m *middleware) {
 	// Generic.
 	h.Bus.MustRegister(core.TypeGetCode, BuildMiddleware(h.handleGetCode))
 
-	h.Bus.MustRegister(core.TypeGetObject,
-		BuildMiddleware(h.handleGetObject,
-			instrumentHandler("handleGetObject"),
-			m.addFieldsToLogger,
-			m.checkJet,
-			m.waitForHotData))
+	getObject := BuildMiddleware(h.handleGetObject,
+		instrumentHandler("handleGetObject"),
+		m.addFieldsToLogger,
+		m.checkJet,
+		m.waitForHotData)
+	h.Bus.MustRegister(core.TypeGetObject, getObject)
+
+	h.Bus.MustRegister(core.TypeGetObjects,
+		BuildMiddleware(h.handleGetObjects(getObject, BuildMiddleware(h.handleGetCode)),
+			instrumentHandler("handleGetObjects")))
 
 	h.Bus.MustRegister(core.TypeGetDelegate,
 		BuildMiddleware(h.handleGetDelegate,
@@ -204,6 +216,10 @@ func (h *MessageHandler) setHandlersForLight(m *middleware) {
 			instrumentHandler("handleHotRecords"),
 			m.releaseHotDataWaiters))
 
+	h.Bus.MustRegister(core.TypeMigrateHotData,
+		BuildMiddleware(h.handleMigrateHotData,
+			instrumentHandler("handleMigrateHotData")))
+
 	h.Bus.MustRegister(
 		core.TypeGetRequest,
 		BuildMiddleware(
@@ -213,6 +229,15 @@ func (h *MessageHandler) setHandlersForLight(m *middleware) {
 		),
 	)
 
+	h.Bus.MustRegister(
+		core.TypeGetResult,
+		BuildMiddleware(
+			h.handleGetResult,
+			instrumentHandler("handleGetResult"),
+			m.checkJet,
+		),
+	)
+
 	h.Bus.MustRegister(
 		core.TypeGetPendingRequestID,
 		BuildMiddleware(
@@ -277,21 +302,37 @@ func (h *MessageHandler) setHandlersForHeavy(m *middleware) {
 	h.Bus.MustRegister(core.TypeGetObject,
 		BuildMiddleware(h.handleGetObject,
 			instrumentHandler("handleGetObject"),
+			m.failoverToPrimary,
 			m.zeroJetForHeavy))
 
 	h.Bus.MustRegister(core.TypeGetDelegate,
 		BuildMiddleware(h.handleGetDelegate,
 			instrumentHandler("handleGetDelegate"),
+			m.failoverToPrimary,
 			m.zeroJetForHeavy))
 
 	h.Bus.MustRegister(core.TypeGetChildren,
 		BuildMiddleware(h.handleGetChildren,
 			instrumentHandler("handleGetChildren"),
+			m.failoverToPrimary,
+			m.zeroJetForHeavy))
+
+	h.Bus.MustRegister(core.TypeGetRequest,
+		BuildMiddleware(h.handleGetRequest,
+			instrumentHandler("handleGetRequest"),
+			m.failoverToPrimary,
+			m.zeroJetForHeavy))
+
+	h.Bus.MustRegister(core.TypeGetResult,
+		BuildMiddleware(h.handleGetResult,
+			instrumentHandler("handleGetResult"),
+			m.failoverToPrimary,
 			m.zeroJetForHeavy))
 
 	h.Bus.MustRegister(core.TypeGetObjectIndex,
 		BuildMiddleware(h.handleGetObjectIndex,
 			instrumentHandler("handleGetObjectIndex"),
+			m.failoverToPrimary,
 			m.zeroJetForHeavy))
 }
 
@@ -305,27 +346,157 @@ func (h *MessageHandler) handleSetRecord(ctx context.Context, parcel core.Parcel
 	jetID := jetFromContext(ctx)
 
 	id := record.NewRecordIDFromRecord(h.PlatformCryptographyScheme, parcel.Pulse(), rec)
+	caller := writeCaller(parcel, rec)
 
+	var sequence uint64
 	switch r := rec.(type) {
 	case record.Request:
 		if h.RecentStorageProvider.Count() > h.conf.PendingRequestsLimit {
 			return &reply.Error{ErrType: reply.ErrTooManyPendingRequests}, nil
 		}
+		if h.quota.exceeded(parcel.Pulse(), caller, h.writeQuotaLimit()) {
+			inslogger.FromContext(ctx).Warnf("write quota of %s is exceeded, request is declined", caller)
+			return &reply.Error{ErrType: reply.ErrWriteQuotaExceeded}, nil
+		}
+		if req, ok := r.(*record.RequestRecord); ok {
+			err := h.assignRequestSequence(ctx, jetID, parcel.Pulse(), *id, req)
+			if errors.Cause(err) == core.ErrNonceUsed {
+				return &reply.Error{ErrType: reply.ErrNonceUsed}, nil
+			}
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to assign request sequence")
+			}
+			sequence = req.Sequence
+		}
 		recentStorage := h.RecentStorageProvider.GetPendingStorage(ctx, jetID)
 		recentStorage.AddPendingRequest(ctx, r.GetObject(), *id)
+	case *record.TimerRecord:
+		if h.quota.exceeded(parcel.Pulse(), caller, h.writeQuotaLimit()) {
+			inslogger.FromContext(ctx).Warnf("write quota of %s is exceeded, timer is declined", caller)
+			return &reply.Error{ErrType: reply.ErrWriteQuotaExceeded}, nil
+		}
+		recentStorage := h.RecentStorageProvider.GetPendingStorage(ctx, jetID)
+		recentStorage.AddTimer(ctx, r.Object, recentstorage.PendingTimer{ID: *id, Pulse: r.Pulse, Message: r.Message})
 	case *record.ResultRecord:
 		recentStorage := h.RecentStorageProvider.GetPendingStorage(ctx, jetID)
 		recentStorage.RemovePendingRequest(ctx, r.Object, *r.Request.Record())
+		// result of timer is a request registered for scheduled call
+		recentStorage.RemoveTimer(ctx, r.Object, *r.Request.Record())
 	}
 
-	id, err := h.ObjectStorage.SetRecord(ctx, jetID, parcel.Pulse(), rec)
+	// id of registered record is the same, it isn't returned on override
+	_, err := h.ObjectStorage.SetRecord(ctx, jetID, parcel.Pulse(), rec)
 	if err == storage.ErrOverride {
 		inslogger.FromContext(ctx).WithField("type", fmt.Sprintf("%T", rec)).Warnln("set record override")
 	} else if err != nil {
 		return nil, err
 	}
+	h.quota.add(parcel.Pulse(), caller, len(msg.Record))
 
-	return &reply.ID{ID: *id}, nil
+	return &reply.ID{ID: *id, Sequence: sequence}, nil
+}
+
+// assignRequestSequence numbers request within its object. Sequence of the latest request is kept in object index,
+// so numbering continues across pulses and light materials. Request registered again keeps its number.
+//
+// Nonce of signed request is kept in object index too until request expires, so signed request can't be
+// registered twice via any node. core.ErrNonceUsed is returned for replayed request.
+func (h *MessageHandler) assignRequestSequence(
+	ctx context.Context, jetID core.RecordID, pulse core.PulseNumber, id core.RecordID, req *record.RequestRecord,
+) error {
+	registered, err := h.ObjectStorage.GetRecord(ctx, jetID, &id)
+	if err == nil {
+		if registeredReq, ok := registered.(*record.RequestRecord); ok {
+			req.Sequence = registeredReq.Sequence
+			return nil
+		}
+	} else if err != storage.ErrNotFound {
+		return err
+	}
+
+	h.RecentStorageProvider.GetIndexStorage(ctx, jetID).AddObject(ctx, req.Object)
+
+	_, err = h.ObjectStorage.GetObjectIndex(ctx, jetID, &req.Object, false)
+	if err == storage.ErrNotFound && !isConstructorRequest(req) {
+		_, err = h.saveIndexFromHeavy(ctx, jetID, *core.NewRecordRef(core.DomainID, req.Object), pulse)
+		if err != nil {
+			return errors.Wrap(err, "failed to fetch index from heavy")
+		}
+	} else if err != nil && err != storage.ErrNotFound {
+		return err
+	}
+
+	nonce := req.SignedNonce
+	delta := core.PulseNumber(1)
+	if nonce != nil {
+		current, err := h.PulseStorage.Current(ctx)
+		if err != nil {
+			return err
+		}
+		if current.NextPulseNumber > current.PulseNumber {
+			delta = current.NextPulseNumber - current.PulseNumber
+		}
+	}
+
+	return h.DBContext.Update(ctx, func(tx *storage.TransactionManager) error {
+		idx, err := tx.GetObjectIndex(ctx, jetID, &req.Object, true)
+		if err == storage.ErrNotFound {
+			// Object is being created. There is no index for it anywhere.
+			idx = &index.ObjectLifeline{State: record.StateUndefined}
+		} else if err != nil {
+			return err
+		}
+
+		if nonce != nil {
+			err = useSignedNonce(idx, nonce, pulse, delta)
+			if err != nil {
+				return err
+			}
+		}
+		idx.RequestSequence++
+		req.Sequence = idx.RequestSequence
+		return tx.SetObjectIndex(ctx, jetID, &req.Object, idx)
+	})
+}
+
+// useSignedNonce remembers nonce in object index until request signed with it expires. Expired nonces are
+// forgotten, expiry is limited by the longest lifetime of offline transaction.
+func useSignedNonce(idx *index.ObjectLifeline, nonce []byte, pulse core.PulseNumber, delta core.PulseNumber) error {
+	key := string(nonce)
+	if expiry, ok := idx.SignedNonces[key]; ok && expiry >= pulse {
+		return core.ErrNonceUsed
+	}
+	for n, expiry := range idx.SignedNonces {
+		if expiry < pulse {
+			delete(idx.SignedNonces, n)
+		}
+	}
+
+	expiry := core.SignedNonceExpiry(nonce, delta)
+	if expiry < pulse {
+		// expired request is declined by member, there is no need to remember it
+		return nil
+	}
+	if limit := pulse + core.TransactionMaxLifetime*delta; expiry > limit {
+		expiry = limit
+	}
+	if idx.SignedNonces == nil {
+		idx.SignedNonces = map[string]core.PulseNumber{}
+	}
+	idx.SignedNonces[key] = expiry
+	return nil
+}
+
+// isConstructorRequest checks if request creates new object. Serialized parcel starts with type of its message.
+func isConstructorRequest(req *record.RequestRecord) bool {
+	if len(req.Parcel) == 0 {
+		return false
+	}
+	switch core.MessageType(req.Parcel[0]) {
+	case core.TypeCallConstructor, core.TypeBootstrapRequest:
+		return true
+	}
+	return false
 }
 
 func (h *MessageHandler) handleSetBlob(ctx context.Context, parcel core.Parcel) (core.Reply, error) {
@@ -347,6 +518,7 @@ func (h *MessageHandler) handleSetBlob(ctx context.Context, parcel core.Parcel)
 
 	id, err := h.ObjectStorage.SetBlob(ctx, jetID, parcel.Pulse(), msg.Memory)
 	if err == nil {
+		h.quota.add(parcel.Pulse(), parcel.GetSender(), len(msg.Memory))
 		return &reply.ID{ID: *id}, nil
 	}
 	if err == storage.ErrOverride {
@@ -355,6 +527,15 @@ func (h *MessageHandler) handleSetBlob(ctx context.Context, parcel core.Parcel)
 	return nil, err
 }
 
+// writeQuotaLimit returns write quota set in root domain or local one. Network parameters aren't set
+// when handler is created without component manager.
+func (h *MessageHandler) writeQuotaLimit() int {
+	if h.NetworkParameters == nil {
+		return h.conf.WriteQuota
+	}
+	return netparams.Int(h.NetworkParameters, core.NetworkParameterWriteQuota, h.conf.WriteQuota)
+}
+
 func (h *MessageHandler) handleGetCode(ctx context.Context, parcel core.Parcel) (core.Reply, error) {
 	msg := parcel.Message().(*message.GetCode)
 	jetID := *jet.NewID(0, nil)
@@ -375,10 +556,18 @@ func (h *MessageHandler) handleGetCode(ctx context.Context, parcel core.Parcel)
 	if err != nil {
 		return nil, err
 	}
+	var schema []byte
+	if codeRec.Schema != nil {
+		schema, err = h.ObjectStorage.GetBlob(ctx, jetID, codeRec.Schema)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to fetch schema of code")
+		}
+	}
 
 	rep := reply.Code{
 		Code:        code,
 		MachineType: codeRec.MachineType,
+		Schema:      schema,
 	}
 
 	return &rep, nil
@@ -406,11 +595,7 @@ func (h *MessageHandler) handleGetObject(
 		}
 
 		logger.Debug("failed to fetch index (fetching from heavy)")
-		node, err := h.JetCoordinator.Heavy(ctx, parcel.Pulse())
-		if err != nil {
-			return nil, err
-		}
-		idx, err = h.saveIndexFromHeavy(ctx, jetID, msg.Head, node)
+		idx, err = h.saveIndexFromHeavy(ctx, jetID, msg.Head, parcel.Pulse())
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to fetch index from heavy")
 		}
@@ -443,7 +628,7 @@ func (h *MessageHandler) handleGetObject(
 			return nil, err
 		}
 		if onHeavy {
-			node, err := h.JetCoordinator.Heavy(ctx, parcel.Pulse())
+			node, err := h.heavyForRead(ctx, parcel.Pulse())
 			if err != nil {
 				return nil, err
 			}
@@ -506,16 +691,65 @@ func (h *MessageHandler) handleGetObject(
 		Parent:       idx.Parent,
 	}
 
-	if state.GetMemory() != nil {
-		rep.Memory, err = h.ObjectStorage.GetBlob(ctx, *stateJet, state.GetMemory())
+	rep.Memory, err = storage.GetObjectMemory(ctx, h.ObjectStorage, *stateJet, state)
+	if err == storage.ErrNotFound && !h.isHeavy {
+		// Previous states of memory diff are already removed from the node.
+		node, err := h.heavyForRead(ctx, parcel.Pulse())
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to fetch blob")
+			return nil, err
 		}
+		logger.WithFields(map[string]interface{}{
+			"state":       stateID.DebugString(),
+			"redirect_to": node.String(),
+		}).Debug("redirect (memory diff base not found)")
+		return reply.NewGetObjectRedirectReply(h.DelegationTokenFactory, parcel, node, stateID)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch blob")
+	}
+	if msg.WithProof {
+		rep.Proof = h.objectProof(ctx, *stateJet, *stateID, rec)
 	}
 
 	return &rep, nil
 }
 
+// diffMemory returns memory of amend as diff against memory of previous state and depth of the diff. Full memory and
+// zero depth are returned if snapshot is due, previous state isn't available in the jet or diff isn't smaller.
+func (h *MessageHandler) diffMemory(
+	ctx context.Context, jetID core.RecordID, amend *record.ObjectAmendRecord, memory []byte,
+) ([]byte, int) {
+	if h.conf.MemorySnapshotInterval <= 0 {
+		return memory, 0
+	}
+	rec, err := h.ObjectStorage.GetRecord(ctx, jetID, &amend.PrevState)
+	if err != nil {
+		return memory, 0
+	}
+	prev, ok := rec.(record.ObjectState)
+	if !ok {
+		return memory, 0
+	}
+	depth := 1
+	if prevAmend, ok := prev.(*record.ObjectAmendRecord); ok {
+		depth = prevAmend.MemoryDiffDepth + 1
+	}
+	if depth >= h.conf.MemorySnapshotInterval {
+		return memory, 0
+	}
+	base, err := storage.GetObjectMemory(ctx, h.ObjectStorage, jetID, prev)
+	if err != nil {
+		inslogger.FromContext(ctx).Debugf("failed to fetch memory of previous state, full memory is saved: %s", err)
+		return memory, 0
+	}
+	diff := storage.DiffMemory(base, memory)
+	if len(diff) >= len(memory) {
+		return memory, 0
+	}
+	stats.Record(ctx, statMemoryDiffSavedBytes.M(int64(len(memory)-len(diff))))
+	return diff, depth
+}
+
 func (h *MessageHandler) handleHasPendingRequests(ctx context.Context, parcel core.Parcel) (core.Reply, error) {
 	msg := parcel.Message().(*message.GetPendingRequests)
 	jetID := jetFromContext(ctx)
@@ -537,6 +771,54 @@ func (h *MessageHandler) handleGetJet(ctx context.Context, parcel core.Parcel) (
 	return &reply.Jet{ID: *jetID, Actual: actual}, nil
 }
 
+// handleGetObjects returns handler of batches which passes every object and code of batch to handlers of
+// single GetObject and GetCode messages. Objects and codes which these handlers don't return (e.g. redirect
+// to other node) are omitted from reply.
+func (h *MessageHandler) handleGetObjects(getObject, getCode core.MessageHandler) core.MessageHandler {
+	return func(ctx context.Context, parcel core.Parcel) (core.Reply, error) {
+		msg := parcel.Message().(*message.GetObjects)
+		batch, ok := parcel.(*message.Parcel)
+		if !ok {
+			return nil, errors.New("unexpected parcel")
+		}
+		logger := inslogger.FromContext(ctx)
+
+		rep := &reply.Objects{
+			Objects: map[core.RecordRef]reply.Object{},
+			Codes:   map[core.RecordRef]reply.Code{},
+		}
+		for _, head := range msg.Heads {
+			res, err := getObject(ctx, batchElement(batch, &message.GetObject{Head: head}))
+			if err != nil {
+				logger.Debugf("failed to get object %s of batch: %s", head, err)
+				continue
+			}
+			if obj, ok := res.(*reply.Object); ok {
+				rep.Objects[head] = *obj
+			}
+		}
+		for _, code := range msg.Codes {
+			res, err := getCode(ctx, batchElement(batch, &message.GetCode{Code: code}))
+			if err != nil {
+				logger.Debugf("failed to get code %s of batch: %s", code, err)
+				continue
+			}
+			if c, ok := res.(*reply.Code); ok {
+				rep.Codes[code] = *c
+			}
+		}
+		return rep, nil
+	}
+}
+
+// batchElement returns parcel of batched message. Batches are never redirected, so element has no token.
+func batchElement(batch *message.Parcel, msg core.Message) core.Parcel {
+	element := *batch
+	element.Msg = msg
+	element.Token = nil
+	return &element
+}
+
 func (h *MessageHandler) handleGetDelegate(ctx context.Context, parcel core.Parcel) (core.Reply, error) {
 	msg := parcel.Message().(*message.GetDelegate)
 	jetID := jetFromContext(ctx)
@@ -551,11 +833,7 @@ func (h *MessageHandler) handleGetDelegate(ctx context.Context, parcel core.Parc
 			return nil, fmt.Errorf("failed to fetch index for %v", msg.Head.Record())
 		}
 
-		heavy, err := h.JetCoordinator.Heavy(ctx, parcel.Pulse())
-		if err != nil {
-			return nil, err
-		}
-		idx, err = h.saveIndexFromHeavy(ctx, jetID, msg.Head, heavy)
+		idx, err = h.saveIndexFromHeavy(ctx, jetID, msg.Head, parcel.Pulse())
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to fetch index from heavy")
 		}
@@ -591,11 +869,7 @@ func (h *MessageHandler) handleGetChildren(
 			return nil, fmt.Errorf("failed to fetch index for %v", msg.Parent.Record())
 		}
 
-		heavy, err := h.JetCoordinator.Heavy(ctx, parcel.Pulse())
-		if err != nil {
-			return nil, err
-		}
-		idx, err = h.saveIndexFromHeavy(ctx, jetID, msg.Parent, heavy)
+		idx, err = h.saveIndexFromHeavy(ctx, jetID, msg.Parent, parcel.Pulse())
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to fetch index from heavy")
 		}
@@ -633,7 +907,7 @@ func (h *MessageHandler) handleGetChildren(
 			return nil, err
 		}
 		if onHeavy {
-			node, err := h.JetCoordinator.Heavy(ctx, parcel.Pulse())
+			node, err := h.heavyForRead(ctx, parcel.Pulse())
 			if err != nil {
 				return nil, err
 			}
@@ -706,6 +980,9 @@ func (h *MessageHandler) handleGetRequest(ctx context.Context, parcel core.Parce
 	msg := parcel.Message().(*message.GetRequest)
 
 	rec, err := h.ObjectStorage.GetRecord(ctx, jetID, &msg.Request)
+	if err == storage.ErrNotFound {
+		return &reply.Error{ErrType: reply.ErrNotFound}, nil
+	}
 	if err != nil {
 		return nil, errors.New("failed to fetch request")
 	}
@@ -723,6 +1000,36 @@ func (h *MessageHandler) handleGetRequest(ctx context.Context, parcel core.Parce
 	return &rep, nil
 }
 
+func (h *MessageHandler) handleGetResult(ctx context.Context, parcel core.Parcel) (core.Reply, error) {
+	jetID := jetFromContext(ctx)
+	msg := parcel.Message().(*message.GetResult)
+
+	id, err := h.ObjectStorage.GetResultID(ctx, jetID, &msg.Request)
+	if err == storage.ErrNotFound {
+		return &reply.Error{ErrType: reply.ErrNotFound}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch result id")
+	}
+
+	rec, err := h.ObjectStorage.GetRecord(ctx, jetID, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch result")
+	}
+
+	res, ok := rec.(*record.ResultRecord)
+	if !ok {
+		return nil, errors.New("failed to decode result")
+	}
+
+	rep := reply.Result{
+		ID:     *id,
+		Record: record.SerializeRecord(res),
+	}
+
+	return &rep, nil
+}
+
 func (h *MessageHandler) handleGetPendingRequestID(ctx context.Context, parcel core.Parcel) (core.Reply, error) {
 	jetID := jetFromContext(ctx)
 	msg := parcel.Message().(*message.GetPendingRequestID)
@@ -761,7 +1068,11 @@ func (h *MessageHandler) handleUpdateObject(ctx context.Context, parcel core.Par
 
 	// FIXME: temporary fix. If we calculate blob id on the client, pulse can change before message sending and this
 	//  id will not match the one calculated on the server.
-	blobID, err := h.ObjectStorage.SetBlob(ctx, jetID, parcel.Pulse(), msg.Memory)
+	memory := msg.Memory
+	if amend, ok := state.(*record.ObjectAmendRecord); ok {
+		memory, amend.MemoryDiffDepth = h.diffMemory(ctx, jetID, amend, msg.Memory)
+	}
+	blobID, err := h.ObjectStorage.SetBlob(ctx, jetID, parcel.Pulse(), memory)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to set blob")
 	}
@@ -785,11 +1096,7 @@ func (h *MessageHandler) handleUpdateObject(ctx context.Context, parcel core.Par
 			} else {
 				logger.Debug("failed to fetch index (fetching from heavy)")
 				// We are updating object. Index should be on the heavy executor.
-				heavy, err := h.JetCoordinator.Heavy(ctx, parcel.Pulse())
-				if err != nil {
-					return err
-				}
-				idx, err = h.saveIndexFromHeavy(ctx, jetID, msg.Object, heavy)
+				idx, err = h.saveIndexFromHeavy(ctx, jetID, msg.Object, parcel.Pulse())
 				if err != nil {
 					return errors.Wrap(err, "failed to fetch index from heavy")
 				}
@@ -807,7 +1114,7 @@ func (h *MessageHandler) handleUpdateObject(ctx context.Context, parcel core.Par
 		// Index exists and latest record id does not match (preserving chain consistency).
 		// For the case when vm can't save or send result to another vm and it tries to update the same record again
 		if idx.LatestState != nil && !state.PrevStateID().Equal(idx.LatestState) && idx.LatestState != recID {
-			return errors.New("invalid state record")
+			return core.ErrInvalidStateRecord
 		}
 
 		id, err := tx.SetRecord(ctx, jetID, parcel.Pulse(), rec)
@@ -862,11 +1169,7 @@ func (h *MessageHandler) handleRegisterChild(ctx context.Context, parcel core.Pa
 	err := h.DBContext.Update(ctx, func(tx *storage.TransactionManager) error {
 		idx, err := h.ObjectStorage.GetObjectIndex(ctx, jetID, msg.Parent.Record(), false)
 		if err == storage.ErrNotFound {
-			heavy, err := h.JetCoordinator.Heavy(ctx, parcel.Pulse())
-			if err != nil {
-				return err
-			}
-			idx, err = h.saveIndexFromHeavy(ctx, jetID, msg.Parent, heavy)
+			idx, err = h.saveIndexFromHeavy(ctx, jetID, msg.Parent, parcel.Pulse())
 			if err != nil {
 				return errors.Wrap(err, "failed to fetch index from heavy")
 			}
@@ -952,11 +1255,7 @@ func (h *MessageHandler) handleValidateRecord(ctx context.Context, parcel core.P
 	err := h.DBContext.Update(ctx, func(tx *storage.TransactionManager) error {
 		idx, err := tx.GetObjectIndex(ctx, jetID, msg.Object.Record(), true)
 		if err == storage.ErrNotFound {
-			heavy, err := h.JetCoordinator.Heavy(ctx, parcel.Pulse())
-			if err != nil {
-				return err
-			}
-			idx, err = h.saveIndexFromHeavy(ctx, jetID, msg.Object, heavy)
+			idx, err = h.saveIndexFromHeavy(ctx, jetID, msg.Object, parcel.Pulse())
 			if err != nil {
 				return errors.Wrap(err, "failed to fetch index from heavy")
 			}
@@ -1074,8 +1373,38 @@ func validateState(old record.State, new record.State) error {
 	return nil
 }
 
+// saveIndexFromHeavy fetches object index from heavy and saves it locally. Index is read from heavy replica
+// if there is one, primary heavy is asked if replica fails.
 func (h *MessageHandler) saveIndexFromHeavy(
-	ctx context.Context, jetID core.RecordID, obj core.RecordRef, heavy *core.RecordRef,
+	ctx context.Context, jetID core.RecordID, obj core.RecordRef, pulse core.PulseNumber,
+) (*index.ObjectLifeline, error) {
+	heavy, err := h.heavyForRead(ctx, pulse)
+	if err != nil {
+		return nil, err
+	}
+	idx, err := h.fetchIndexFromHeavy(ctx, obj, heavy)
+	if err != nil {
+		primary, primaryErr := h.JetCoordinator.Heavy(ctx, pulse)
+		if primaryErr != nil || *primary == *heavy {
+			return nil, err
+		}
+		inslogger.FromContext(ctx).Warnf("failed to fetch index from heavy replica %s, failover to primary: %s", heavy, err)
+		stats.Record(ctx, statHeavyReplicaFailovers.M(1))
+		idx, err = h.fetchIndexFromHeavy(ctx, obj, primary)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = h.ObjectStorage.SetObjectIndex(ctx, jetID, obj.Record(), idx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to save")
+	}
+	return idx, nil
+}
+
+func (h *MessageHandler) fetchIndexFromHeavy(
+	ctx context.Context, obj core.RecordRef, heavy *core.RecordRef,
 ) (*index.ObjectLifeline, error) {
 	genericReply, err := h.Bus.Send(ctx, &message.GetObjectIndex{
 		Object: obj,
@@ -1093,11 +1422,6 @@ func (h *MessageHandler) saveIndexFromHeavy(
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to decode")
 	}
-
-	err = h.ObjectStorage.SetObjectIndex(ctx, jetID, obj.Record(), idx)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to save")
-	}
 	return idx, nil
 }
 
@@ -1133,14 +1457,22 @@ func (h *MessageHandler) handleHotRecords(ctx context.Context, parcel core.Parce
 	logger.Debugf("received %d pending requests", len(msg.PendingRequests))
 
 	var notificationList []core.RecordID
+	var abandonedRequests []core.RecordID
 	for objID, objContext := range msg.PendingRequests {
-		if !objContext.Active {
+		if !objContext.Active && len(objContext.Requests) > 0 {
 			notificationList = append(notificationList, objID)
+			abandonedRequests = append(abandonedRequests, objContext.Requests...)
 		}
 
 		objContext.Active = false
 		pendingStorage.SetContextToObject(ctx, objID, objContext)
 	}
+	for objID, requests := range h.recoverLostRequests(ctx, jetID, msg.PulseNumber, pendingStorage) {
+		if objContext, ok := msg.PendingRequests[objID]; !ok || objContext.Active {
+			notificationList = append(notificationList, objID)
+		}
+		abandonedRequests = append(abandonedRequests, requests...)
+	}
 
 	go func() {
 		for _, objID := range notificationList {
@@ -1159,6 +1491,7 @@ func (h *MessageHandler) handleHotRecords(ctx context.Context, parcel core.Parce
 			}(objID)
 		}
 	}()
+	go h.notifyPendingCallers(ctx, jetID, msg.PulseNumber, abandonedRequests)
 
 	indexStorage := h.RecentStorageProvider.GetIndexStorage(ctx, jetID)
 	for id, meta := range msg.RecentObjects {
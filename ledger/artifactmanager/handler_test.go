@@ -38,6 +38,7 @@ import (
 	"github.com/insolar/insolar/platformpolicy"
 	"github.com/insolar/insolar/testutils"
 	"github.com/insolar/insolar/testutils/network"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -144,6 +145,7 @@ func (s *handlerSuite) TestMessageHandler_HandleGetObject_Redirects() {
 	pendingMock.GetRequestsForObjectMock.Return(nil)
 	pendingMock.AddPendingRequestMock.Return()
 	pendingMock.RemovePendingRequestMock.Return()
+	pendingMock.RemoveTimerMock.Return()
 
 	provideMock := recentstorage.NewProviderMock(s.T())
 	provideMock.GetIndexStorageMock.Return(indexMock)
@@ -270,6 +272,7 @@ func (s *handlerSuite) TestMessageHandler_HandleGetChildren_Redirects() {
 	pendingMock.GetRequestsForObjectMock.Return(nil)
 	pendingMock.AddPendingRequestMock.Return()
 	pendingMock.RemovePendingRequestMock.Return()
+	pendingMock.RemoveTimerMock.Return()
 
 	provideMock := recentstorage.NewProviderMock(s.T())
 	provideMock.GetIndexStorageMock.Return(indexMock)
@@ -391,6 +394,7 @@ func (s *handlerSuite) TestMessageHandler_HandleGetDelegate_FetchesIndexFromHeav
 	pendingMock.GetRequestsForObjectMock.Return(nil)
 	pendingMock.AddPendingRequestMock.Return()
 	pendingMock.RemovePendingRequestMock.Return()
+	pendingMock.RemoveTimerMock.Return()
 
 	provideMock := recentstorage.NewProviderMock(s.T())
 	provideMock.GetIndexStorageMock.Return(indexMock)
@@ -453,6 +457,63 @@ func (s *handlerSuite) TestMessageHandler_HandleGetDelegate_FetchesIndexFromHeav
 	assert.Equal(s.T(), objIndex.Delegates, idx.Delegates)
 }
 
+func (s *handlerSuite) TestMessageHandler_HandleGetDelegate_FailoverToPrimaryHeavy() {
+	mc := minimock.NewController(s.T())
+	defer mc.Finish()
+	jetID := *jet.NewID(0, nil)
+
+	indexMock := recentstorage.NewRecentIndexStorageMock(s.T())
+	indexMock.AddObjectMock.Return()
+	provideMock := recentstorage.NewProviderMock(s.T())
+	provideMock.GetIndexStorageMock.Return(indexMock)
+
+	certificate := testutils.NewCertificateMock(s.T())
+	certificate.GetRoleMock.Return(core.StaticRoleLightMaterial)
+
+	mb := testutils.NewMessageBusMock(mc)
+	mb.MustRegisterMock.Return()
+	primary := testutils.RandomRef()
+	jc := &replicaJetCoordinator{JetCoordinatorMock: testutils.NewJetCoordinatorMock(mc), replica: testutils.RandomRef()}
+	jc.HeavyMock.Return(&primary, nil)
+
+	h := NewMessageHandler(&configuration.Ledger{LightChainLimit: 3}, certificate)
+	h.JetStorage = s.jetStorage
+	h.NodeStorage = s.nodeStorage
+	h.DBContext = s.db
+	h.PulseTracker = s.pulseTracker
+	h.ObjectStorage = s.objectStorage
+	h.RecentStorageProvider = provideMock
+	h.JetCoordinator = jc
+	h.Bus = mb
+	require.NoError(s.T(), h.Init(s.ctx))
+
+	delegateType := *genRandomRef(0)
+	delegate := *genRandomRef(0)
+	objIndex := index.ObjectLifeline{Delegates: map[core.RecordRef]core.RecordRef{delegateType: delegate}}
+	msg := message.GetDelegate{
+		Head:   *genRandomRef(0),
+		AsType: delegateType,
+	}
+
+	var receivers []core.RecordRef
+	mb.SendFunc = func(c context.Context, gm core.Message, o *core.MessageSendOptions) (core.Reply, error) {
+		receivers = append(receivers, *o.Receiver)
+		if *o.Receiver == jc.replica {
+			return nil, errors.New("replica is down")
+		}
+		buf, err := index.EncodeObjectLifeline(&objIndex)
+		require.NoError(s.T(), err)
+		return &reply.ObjectIndex{Index: buf}, nil
+	}
+
+	rep, err := h.handleGetDelegate(contextWithJet(s.ctx, jetID), &message.Parcel{
+		Msg: &msg,
+	})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), &reply.Delegate{Head: delegate}, rep)
+	assert.Equal(s.T(), []core.RecordRef{jc.replica, primary}, receivers)
+}
+
 func (s *handlerSuite) TestMessageHandler_HandleUpdateObject_FetchesIndexFromHeavy() {
 	mc := minimock.NewController(s.T())
 	defer mc.Finish()
@@ -465,6 +526,7 @@ func (s *handlerSuite) TestMessageHandler_HandleUpdateObject_FetchesIndexFromHea
 	pendingMock.GetRequestsForObjectMock.Return(nil)
 	pendingMock.AddPendingRequestMock.Return()
 	pendingMock.RemovePendingRequestMock.Return()
+	pendingMock.RemoveTimerMock.Return()
 
 	provideMock := recentstorage.NewProviderMock(s.T())
 	provideMock.GetIndexStorageMock.Return(indexMock)
@@ -544,6 +606,7 @@ func (s *handlerSuite) TestMessageHandler_HandleUpdateObject_UpdateIndexState()
 	pendingMock.GetRequestsForObjectMock.Return(nil)
 	pendingMock.AddPendingRequestMock.Return()
 	pendingMock.RemovePendingRequestMock.Return()
+	pendingMock.RemoveTimerMock.Return()
 
 	provideMock := recentstorage.NewProviderMock(s.T())
 	provideMock.GetIndexStorageMock.Return(indexMock)
@@ -597,6 +660,80 @@ func (s *handlerSuite) TestMessageHandler_HandleUpdateObject_UpdateIndexState()
 	require.Equal(s.T(), core.FirstPulseNumber, int(idx.LatestUpdate))
 }
 
+func (s *handlerSuite) TestMessageHandler_HandleUpdateObject_StoresMemoryDiff() {
+	jetID := *jet.NewID(0, nil)
+
+	indexMock := recentstorage.NewRecentIndexStorageMock(s.T())
+	indexMock.AddObjectMock.Return()
+	provideMock := recentstorage.NewProviderMock(s.T())
+	provideMock.GetIndexStorageMock.Return(indexMock)
+
+	certificate := testutils.NewCertificateMock(s.T())
+	certificate.GetRoleMock.Return(core.StaticRoleLightMaterial)
+
+	h := NewMessageHandler(&configuration.Ledger{
+		LightChainLimit:        3,
+		MemorySnapshotInterval: 2,
+	}, certificate)
+	h.DBContext = s.db
+	h.ObjectStorage = s.objectStorage
+	h.RecentStorageProvider = provideMock
+	h.PlatformCryptographyScheme = s.scheme
+
+	memory := make([]byte, 100)
+	blobID, err := s.objectStorage.SetBlob(s.ctx, jetID, core.FirstPulseNumber, memory)
+	require.NoError(s.T(), err)
+	stateID, err := s.objectStorage.SetRecord(s.ctx, jetID, core.FirstPulseNumber, &record.ObjectActivateRecord{
+		ObjectStateRecord: record.ObjectStateRecord{Memory: blobID},
+	})
+	require.NoError(s.T(), err)
+	object := *genRandomRef(0)
+	err = s.objectStorage.SetObjectIndex(s.ctx, jetID, object.Record(), &index.ObjectLifeline{
+		LatestState: stateID,
+		State:       record.StateActivation,
+	})
+	require.NoError(s.T(), err)
+
+	update := func(prev *core.RecordID, memory []byte) *record.ObjectAmendRecord {
+		rep, err := h.handleUpdateObject(contextWithJet(s.ctx, jetID), &message.Parcel{
+			Msg: &message.UpdateObject{
+				Record: record.SerializeRecord(&record.ObjectAmendRecord{PrevState: *prev}),
+				Object: object,
+				Memory: memory,
+			},
+			PulseNumber: core.FirstPulseNumber,
+		})
+		require.NoError(s.T(), err)
+		objRep, ok := rep.(*reply.Object)
+		require.True(s.T(), ok)
+
+		rec, err := s.objectStorage.GetRecord(s.ctx, jetID, &objRep.State)
+		require.NoError(s.T(), err)
+		amend := rec.(*record.ObjectAmendRecord)
+		stored, err := storage.GetObjectMemory(s.ctx, s.objectStorage, jetID, amend)
+		require.NoError(s.T(), err)
+		require.Equal(s.T(), memory, stored)
+		*prev = objRep.State
+		return amend
+	}
+
+	memory[50] = 1
+	amend := update(stateID, memory)
+	require.Equal(s.T(), 1, amend.MemoryDiffDepth)
+	blob, err := s.objectStorage.GetBlob(s.ctx, jetID, amend.Memory)
+	require.NoError(s.T(), err)
+	require.True(s.T(), len(blob) < len(memory))
+
+	// Snapshot is stored after interval.
+	memory[60] = 1
+	amend = update(stateID, memory)
+	require.Equal(s.T(), 0, amend.MemoryDiffDepth)
+
+	memory[70] = 1
+	amend = update(stateID, memory)
+	require.Equal(s.T(), 1, amend.MemoryDiffDepth)
+}
+
 func (s *handlerSuite) TestMessageHandler_HandleGetObjectIndex() {
 	mc := minimock.NewController(s.T())
 	defer mc.Finish()
@@ -611,6 +748,7 @@ func (s *handlerSuite) TestMessageHandler_HandleGetObjectIndex() {
 	pendingMock.GetRequestsForObjectMock.Return(nil)
 	pendingMock.AddPendingRequestMock.Return()
 	pendingMock.RemovePendingRequestMock.Return()
+	pendingMock.RemoveTimerMock.Return()
 
 	provideMock := recentstorage.NewProviderMock(s.T())
 	provideMock.GetIndexStorageMock.Return(indexMock)
@@ -720,6 +858,7 @@ func (s *handlerSuite) TestMessageHandler_HandleGetCode_Redirects() {
 	pendingMock.GetRequestsForObjectMock.Return(nil)
 	pendingMock.AddPendingRequestMock.Return()
 	pendingMock.RemovePendingRequestMock.Return()
+	pendingMock.RemoveTimerMock.Return()
 
 	provideMock := recentstorage.NewProviderMock(s.T())
 	provideMock.GetIndexStorageMock.Return(indexMock)
@@ -798,6 +937,7 @@ func (s *handlerSuite) TestMessageHandler_HandleRegisterChild_FetchesIndexFromHe
 	pendingMock.GetRequestsForObjectMock.Return(nil)
 	pendingMock.AddPendingRequestMock.Return()
 	pendingMock.RemovePendingRequestMock.Return()
+	pendingMock.RemoveTimerMock.Return()
 
 	provideMock := recentstorage.NewProviderMock(s.T())
 	provideMock.GetIndexStorageMock.Return(indexMock)
@@ -878,6 +1018,7 @@ func (s *handlerSuite) TestMessageHandler_HandleRegisterChild_IndexStateUpdated(
 	pendingMock.GetRequestsForObjectMock.Return(nil)
 	pendingMock.AddPendingRequestMock.Return()
 	pendingMock.RemovePendingRequestMock.Return()
+	pendingMock.RemoveTimerMock.Return()
 
 	provideMock := recentstorage.NewProviderMock(s.T())
 	provideMock.GetIndexStorageMock.Return(indexMock)
@@ -1081,6 +1222,7 @@ func (s *handlerSuite) TestMessageHandler_HandleValidationCheck() {
 	indexMock.AddObjectMock.Return()
 	pendingMock.AddPendingRequestMock.Return()
 	pendingMock.RemovePendingRequestMock.Return()
+	pendingMock.RemoveTimerMock.Return()
 
 	provideMock := recentstorage.NewProviderMock(s.T())
 	provideMock.GetIndexStorageMock.Return(indexMock)
@@ -1274,3 +1416,220 @@ func (s *handlerSuite) TestMessageHandler_HandleGetRequest() {
 	require.True(s.T(), ok)
 	assert.Equal(s.T(), req, *record.DeserializeRecord(reqReply.Record).(*record.RequestRecord))
 }
+
+func (s *handlerSuite) TestMessageHandler_HandleGetResult() {
+	mc := minimock.NewController(s.T())
+	defer mc.Finish()
+
+	jetID := *jet.NewID(0, nil)
+	reqID := *genRandomID(core.FirstPulseNumber)
+
+	certificate := testutils.NewCertificateMock(s.T())
+	certificate.GetRoleMock.Return(core.StaticRoleLightMaterial)
+
+	h := NewMessageHandler(&configuration.Ledger{}, certificate)
+	h.ObjectStorage = s.objectStorage
+
+	msg := message.GetResult{
+		Request: reqID,
+	}
+	parcel := &message.Parcel{
+		Msg:         &msg,
+		PulseNumber: core.FirstPulseNumber + 1,
+	}
+
+	rep, err := h.handleGetResult(contextWithJet(s.ctx, jetID), parcel)
+	require.NoError(s.T(), err)
+	errReply, ok := rep.(*reply.Error)
+	require.True(s.T(), ok)
+	assert.Equal(s.T(), reply.ErrType(reply.ErrNotFound), errReply.ErrType)
+
+	res := record.ResultRecord{
+		Object:  *genRandomID(0),
+		Request: *core.NewRecordRef(core.DomainID, reqID),
+		Payload: []byte{1, 2, 3},
+	}
+	resID, err := s.objectStorage.SetRecord(s.ctx, jetID, core.FirstPulseNumber, &res)
+	require.NoError(s.T(), err)
+
+	rep, err = h.handleGetResult(contextWithJet(s.ctx, jetID), parcel)
+	require.NoError(s.T(), err)
+	resReply, ok := rep.(*reply.Result)
+	require.True(s.T(), ok)
+	assert.Equal(s.T(), *resID, resReply.ID)
+	assert.Equal(s.T(), res, *record.DeserializeRecord(resReply.Record).(*record.ResultRecord))
+}
+
+func (s *handlerSuite) TestMessageHandler_HandleSetRecord_WriteQuota() {
+	mc := minimock.NewController(s.T())
+	defer mc.Finish()
+
+	jetID := *jet.NewID(0, nil)
+	caller := testutils.RandomRef()
+
+	pendingMock := recentstorage.NewPendingStorageMock(mc)
+	pendingMock.AddPendingRequestMock.Return()
+	indexMock := recentstorage.NewRecentIndexStorageMock(mc)
+	indexMock.AddObjectMock.Return()
+	provideMock := recentstorage.NewProviderMock(mc)
+	provideMock.CountMock.Return(0)
+	provideMock.GetPendingStorageMock.Return(pendingMock)
+	provideMock.GetIndexStorageMock.Return(indexMock)
+
+	certificate := testutils.NewCertificateMock(s.T())
+	certificate.GetRoleMock.Return(core.StaticRoleLightMaterial)
+
+	h := NewMessageHandler(&configuration.Ledger{WriteQuota: 1}, certificate)
+	h.ObjectStorage = s.objectStorage
+	h.DBContext = s.db
+	h.PlatformCryptographyScheme = s.scheme
+	h.RecentStorageProvider = provideMock
+
+	setRequest := func(pulse core.PulseNumber, hash byte) core.Reply {
+		obj := *genRandomID(0)
+		err := s.objectStorage.SetObjectIndex(s.ctx, jetID, &obj, &index.ObjectLifeline{})
+		require.NoError(s.T(), err)
+
+		req := record.RequestRecord{
+			Parcel: message.MustSerializeBytes(&message.Parcel{
+				Msg: &message.CallMethod{BaseLogicMessage: message.BaseLogicMessage{Caller: caller}},
+			}),
+			MessageHash: []byte{hash},
+			Object:      obj,
+		}
+		rep, err := h.handleSetRecord(contextWithJet(s.ctx, jetID), &message.Parcel{
+			Msg:         &message.SetRecord{Record: record.SerializeRecord(&req)},
+			PulseNumber: pulse,
+		})
+		require.NoError(s.T(), err)
+		return rep
+	}
+
+	_, ok := setRequest(core.FirstPulseNumber, 1).(*reply.ID)
+	require.True(s.T(), ok)
+
+	rep := setRequest(core.FirstPulseNumber, 2)
+	require.Equal(s.T(), &reply.Error{ErrType: reply.ErrWriteQuotaExceeded}, rep)
+
+	_, ok = setRequest(core.FirstPulseNumber+1, 3).(*reply.ID)
+	require.True(s.T(), ok, "quota is reset on new pulse")
+}
+
+func (s *handlerSuite) TestMessageHandler_HandleSetRecord_RequestSequence() {
+	mc := minimock.NewController(s.T())
+	defer mc.Finish()
+
+	jetID := *jet.NewID(0, nil)
+
+	pendingMock := recentstorage.NewPendingStorageMock(mc)
+	pendingMock.AddPendingRequestMock.Return()
+	indexMock := recentstorage.NewRecentIndexStorageMock(mc)
+	indexMock.AddObjectMock.Return()
+	provideMock := recentstorage.NewProviderMock(mc)
+	provideMock.CountMock.Return(0)
+	provideMock.GetPendingStorageMock.Return(pendingMock)
+	provideMock.GetIndexStorageMock.Return(indexMock)
+
+	certificate := testutils.NewCertificateMock(s.T())
+	certificate.GetRoleMock.Return(core.StaticRoleLightMaterial)
+
+	h := NewMessageHandler(&configuration.Ledger{}, certificate)
+	h.ObjectStorage = s.objectStorage
+	h.DBContext = s.db
+	h.PlatformCryptographyScheme = s.scheme
+	h.RecentStorageProvider = provideMock
+
+	obj := *genRandomID(0)
+	setRequest := func(pulse core.PulseNumber, msg core.Message, hash byte) uint64 {
+		req := record.RequestRecord{
+			Parcel:      message.MustSerializeBytes(&message.Parcel{Msg: msg}),
+			MessageHash: []byte{hash},
+			Object:      obj,
+		}
+		rep, err := h.handleSetRecord(contextWithJet(s.ctx, jetID), &message.Parcel{
+			Msg:         &message.SetRecord{Record: record.SerializeRecord(&req)},
+			PulseNumber: pulse,
+		})
+		require.NoError(s.T(), err)
+		id, ok := rep.(*reply.ID)
+		require.True(s.T(), ok)
+		return id.Sequence
+	}
+
+	assert.Equal(s.T(), uint64(1), setRequest(core.FirstPulseNumber, &message.CallConstructor{}, 1))
+	assert.Equal(s.T(), uint64(2), setRequest(core.FirstPulseNumber, &message.CallMethod{}, 2))
+	assert.Equal(s.T(), uint64(3), setRequest(core.FirstPulseNumber+1, &message.CallMethod{}, 3), "numbering continues in next pulse")
+	assert.Equal(s.T(), uint64(2), setRequest(core.FirstPulseNumber, &message.CallMethod{}, 2), "registered request keeps its number")
+}
+
+func (s *handlerSuite) TestMessageHandler_HandleSetRecord_SignedNonce() {
+	mc := minimock.NewController(s.T())
+	defer mc.Finish()
+
+	jetID := *jet.NewID(0, nil)
+
+	pendingMock := recentstorage.NewPendingStorageMock(mc)
+	pendingMock.AddPendingRequestMock.Return()
+	indexMock := recentstorage.NewRecentIndexStorageMock(mc)
+	indexMock.AddObjectMock.Return()
+	provideMock := recentstorage.NewProviderMock(mc)
+	provideMock.CountMock.Return(0)
+	provideMock.GetPendingStorageMock.Return(pendingMock)
+	provideMock.GetIndexStorageMock.Return(indexMock)
+
+	pulseStorageMock := testutils.NewPulseStorageMock(mc)
+	pulseStorageMock.CurrentMock.Return(&core.Pulse{
+		PulseNumber:     core.FirstPulseNumber,
+		NextPulseNumber: core.FirstPulseNumber + 10,
+	}, nil)
+
+	certificate := testutils.NewCertificateMock(s.T())
+	certificate.GetRoleMock.Return(core.StaticRoleLightMaterial)
+
+	h := NewMessageHandler(&configuration.Ledger{}, certificate)
+	h.ObjectStorage = s.objectStorage
+	h.DBContext = s.db
+	h.PlatformCryptographyScheme = s.scheme
+	h.RecentStorageProvider = provideMock
+	h.PulseStorage = pulseStorageMock
+
+	obj := *genRandomID(0)
+	err := s.objectStorage.SetObjectIndex(s.ctx, jetID, &obj, &index.ObjectLifeline{})
+	require.NoError(s.T(), err)
+
+	setRequest := func(pulse core.PulseNumber, nonce []byte, hash byte) core.Reply {
+		req := record.RequestRecord{
+			Parcel:      message.MustSerializeBytes(&message.Parcel{Msg: &message.CallMethod{}}),
+			MessageHash: []byte{hash},
+			Object:      obj,
+			SignedNonce: nonce,
+		}
+		rep, err := h.handleSetRecord(contextWithJet(s.ctx, jetID), &message.Parcel{
+			Msg:         &message.SetRecord{Record: record.SerializeRecord(&req)},
+			PulseNumber: pulse,
+		})
+		require.NoError(s.T(), err)
+		return rep
+	}
+
+	tx := core.Transaction{Nonce: 1, Expiry: core.FirstPulseNumber + 20}
+	_, ok := setRequest(core.FirstPulseNumber, tx.Seed(), 1).(*reply.ID)
+	require.True(s.T(), ok)
+	_, ok = setRequest(core.FirstPulseNumber, tx.Seed(), 1).(*reply.ID)
+	require.True(s.T(), ok, "the same request may be registered again")
+
+	rep := setRequest(core.FirstPulseNumber+10, tx.Seed(), 2)
+	require.Equal(s.T(), &reply.Error{ErrType: reply.ErrNonceUsed}, rep, "replayed request is declined")
+
+	other := core.Transaction{Nonce: 2, Expiry: core.FirstPulseNumber + 20}
+	_, ok = setRequest(core.FirstPulseNumber+10, other.Seed(), 3).(*reply.ID)
+	require.True(s.T(), ok)
+	_, ok = setRequest(core.FirstPulseNumber+10, nil, 4).(*reply.ID)
+	require.True(s.T(), ok, "requests without nonce aren't checked")
+
+	_, ok = setRequest(core.FirstPulseNumber+30, tx.Seed(), 5).(*reply.ID)
+	require.True(s.T(), ok, "expired nonce is forgotten")
+	idx, err := s.objectStorage.GetObjectIndex(s.ctx, jetID, &obj, false)
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), idx.SignedNonces)
+}
@@ -33,7 +33,10 @@ func (h *MessageHandler) handleHeavyPayload(ctx context.Context, genericMsg core
 	if err := h.HeavySync.Store(ctx, msg.JetID, msg.PulseNum, msg.Records); err != nil {
 		return heavyerrreply(err)
 	}
-	return &reply.OK{}, nil
+	h.forwardToReplicas(ctx, msg)
+
+	congestion := h.HeavySync.Congestion(ctx)
+	return &reply.HeavyAck{QueueDepth: congestion.QueueDepth, Delay: congestion.Delay}, nil
 }
 
 func (h *MessageHandler) handleHeavyStartStop(ctx context.Context, genericMsg core.Parcel) (core.Reply, error) {
@@ -41,15 +44,26 @@ func (h *MessageHandler) handleHeavyStartStop(ctx context.Context, genericMsg co
 
 	// stop
 	if msg.Finished {
-		if err := h.HeavySync.Stop(ctx, msg.JetID, msg.PulseNum); err != nil {
+		if err := h.HeavySync.Stop(ctx, msg.JetID, msg.PulseNum, genericMsg.GetSender()); err != nil {
 			return nil, err
 		}
+		h.forwardToReplicas(ctx, msg)
 		return &reply.OK{}, nil
 	}
+	// resume
+	if msg.Resume {
+		stored, err := h.HeavySync.Resume(ctx, msg.JetID, msg.PulseNum)
+		if err != nil {
+			return heavyerrreply(err)
+		}
+		h.forwardToReplicas(ctx, msg)
+		return &reply.HeavySyncResumed{Stored: stored}, nil
+	}
 	// start
 	if err := h.HeavySync.Start(ctx, msg.JetID, msg.PulseNum); err != nil {
 		return heavyerrreply(err)
 	}
+	h.forwardToReplicas(ctx, msg)
 	return &reply.OK{}, nil
 }
 
@@ -59,6 +73,7 @@ func (h *MessageHandler) handleHeavyReset(ctx context.Context, genericMsg core.P
 	if err := h.HeavySync.Reset(ctx, msg.JetID, msg.PulseNum); err != nil {
 		return heavyerrreply(err)
 	}
+	h.forwardToReplicas(ctx, msg)
 	return &reply.OK{}, nil
 }
 
@@ -32,6 +32,7 @@ import (
 
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/core/reply"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -115,11 +116,13 @@ func (s *heavySuite) TestLedgerArtifactManager_handleHeavy() {
 		return s.db.StoreKeyValues(ctx, kvs)
 	})
 	heavysync.StopMock.Return(nil)
+	heavysync.CongestionMock.Return(core.HeavyCongestion{QueueDepth: 1})
 
 	recentIndexMock := recentstorage.NewRecentIndexStorageMock(s.T())
 	recentIndexMock.AddObjectMock.Return()
 	pendingMock := recentstorage.NewPendingStorageMock(s.T())
 	pendingMock.RemovePendingRequestMock.Return()
+	pendingMock.RemoveTimerMock.Return()
 	provideMock := recentstorage.NewProviderMock(s.T())
 	provideMock.GetIndexStorageMock.Return(recentIndexMock)
 	provideMock.GetPendingStorageMock.Return(pendingMock)
@@ -151,9 +154,9 @@ func (s *heavySuite) TestLedgerArtifactManager_handleHeavy() {
 		},
 	}
 
-	var err error
-	_, err = mh.handleHeavyPayload(s.ctx, parcel)
+	rep, err := mh.handleHeavyPayload(s.ctx, parcel)
 	require.NoError(s.T(), err)
+	require.Equal(s.T(), &reply.HeavyAck{QueueDepth: 1}, rep)
 
 	badgerdb := s.db.GetBadgerDB()
 	err = badgerdb.View(func(tx *badger.Txn) error {
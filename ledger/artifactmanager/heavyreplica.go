@@ -0,0 +1,76 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package artifactmanager
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// heavyReplicaRouter is implemented by jet coordinators aware of heavy read replicas.
+type heavyReplicaRouter interface {
+	HeavyReplica(ctx context.Context, pulse core.PulseNumber) (*core.RecordRef, error)
+	HeavyReplicas() []core.RecordRef
+	IsHeavyReplica(node core.RecordRef) bool
+}
+
+// heavyForRead returns heavy which should serve reads of old pulses.
+func (h *MessageHandler) heavyForRead(ctx context.Context, pulse core.PulseNumber) (*core.RecordRef, error) {
+	return readHeavy(ctx, h.JetCoordinator, pulse)
+}
+
+// readHeavy returns read replica of heavy if coordinator is aware of them, primary heavy otherwise.
+func readHeavy(ctx context.Context, jc core.JetCoordinator, pulse core.PulseNumber) (*core.RecordRef, error) {
+	if router, ok := jc.(heavyReplicaRouter); ok {
+		return router.HeavyReplica(ctx, pulse)
+	}
+	return jc.Heavy(ctx, pulse)
+}
+
+// isHeavyReplica checks if current node is a read replica of heavy.
+func (h *MessageHandler) isHeavyReplica() bool {
+	router, ok := h.JetCoordinator.(heavyReplicaRouter)
+	return ok && h.certificate.GetNodeRef() != nil && router.IsHeavyReplica(*h.certificate.GetNodeRef())
+}
+
+// forwardToReplicas sends heavy sync message stored by primary heavy to heavy replicas. Replicas which fail
+// to store records are skipped, their reads are failed over to primary.
+func (h *MessageHandler) forwardToReplicas(ctx context.Context, msg core.Message) {
+	router, ok := h.JetCoordinator.(heavyReplicaRouter)
+	if !ok || h.isReplica {
+		return
+	}
+	logger := inslogger.FromContext(ctx)
+	for _, replica := range router.HeavyReplicas() {
+		replica := replica
+		rep, err := h.Bus.Send(ctx, msg, &core.MessageSendOptions{Receiver: &replica})
+		if err == nil {
+			if herr, ok := rep.(*reply.HeavyError); ok {
+				err = herr
+			}
+		}
+		if err != nil {
+			stats.Record(ctx, statHeavyReplicaForwardErrors.M(1))
+			logger.Errorf("failed to forward %s to heavy replica %s: %s", msg.Type(), replica, err)
+		}
+	}
+}
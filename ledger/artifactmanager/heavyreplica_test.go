@@ -0,0 +1,129 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package artifactmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gojuno/minimock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage/index"
+	"github.com/insolar/insolar/testutils"
+)
+
+type replicaJetCoordinator struct {
+	*testutils.JetCoordinatorMock
+	replica core.RecordRef
+}
+
+func (jc *replicaJetCoordinator) HeavyReplica(ctx context.Context, pulse core.PulseNumber) (*core.RecordRef, error) {
+	return &jc.replica, nil
+}
+
+func (jc *replicaJetCoordinator) HeavyReplicas() []core.RecordRef {
+	return []core.RecordRef{jc.replica}
+}
+
+func (jc *replicaJetCoordinator) IsHeavyReplica(node core.RecordRef) bool {
+	return node == jc.replica
+}
+
+func newReplicaTestHandler(
+	t *testing.T, mc *minimock.Controller, me core.RecordRef,
+) (*MessageHandler, *replicaJetCoordinator, *testutils.MessageBusMock) {
+	certificate := testutils.NewCertificateMock(t)
+	certificate.GetRoleMock.Return(core.StaticRoleHeavyMaterial)
+	certificate.GetNodeRefMock.Return(&me)
+
+	jc := &replicaJetCoordinator{JetCoordinatorMock: testutils.NewJetCoordinatorMock(mc), replica: testutils.RandomRef()}
+	mb := testutils.NewMessageBusMock(mc)
+	mb.MustRegisterMock.Return()
+
+	h := NewMessageHandler(&configuration.Ledger{}, certificate)
+	h.JetCoordinator = jc
+	h.Bus = mb
+	return h, jc, mb
+}
+
+func TestMessageHandler_ForwardToReplicas(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	h, jc, mb := newReplicaTestHandler(t, mc, testutils.RandomRef())
+	require.NoError(t, h.Init(ctx))
+	require.False(t, h.isReplica)
+
+	heavySync := testutils.NewHeavySyncMock(mc)
+	heavySync.StartMock.Return(nil)
+	h.HeavySync = heavySync
+
+	msg := &message.HeavyStartStop{JetID: testutils.RandomJet(), PulseNum: core.FirstPulseNumber + 1}
+	mb.SendFunc = func(ctx context.Context, m core.Message, o *core.MessageSendOptions) (core.Reply, error) {
+		require.Equal(t, msg, m)
+		require.Equal(t, jc.replica, *o.Receiver)
+		return &reply.HeavyError{Message: "test error"}, nil
+	}
+
+	rep, err := h.handleHeavyStartStop(ctx, &message.Parcel{Msg: msg})
+	require.NoError(t, err)
+	require.Equal(t, &reply.OK{}, rep)
+	require.Equal(t, uint64(1), mb.SendCounter)
+}
+
+func TestMessageHandler_ReplicaFailoverToPrimary(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	primary := testutils.RandomRef()
+	replica := testutils.RandomRef()
+	h, jc, mb := newReplicaTestHandler(t, mc, replica)
+	jc.replica = replica
+	require.NoError(t, h.Init(ctx))
+	require.True(t, h.isReplica)
+
+	jc.HeavyMock.Return(&primary, nil)
+	msg := &message.GetObjectIndex{Object: testutils.RandomRef()}
+	buf, err := index.EncodeObjectLifeline(&index.ObjectLifeline{})
+	require.NoError(t, err)
+	mb.SendFunc = func(ctx context.Context, m core.Message, o *core.MessageSendOptions) (core.Reply, error) {
+		require.Equal(t, msg, m)
+		require.Equal(t, primary, *o.Receiver)
+		return &reply.ObjectIndex{Index: buf}, nil
+	}
+
+	failing := func(ctx context.Context, parcel core.Parcel) (core.Reply, error) {
+		return nil, errors.New("index not found")
+	}
+	rep, err := h.middleware.failoverToPrimary(failing)(ctx, &message.Parcel{Msg: msg})
+	require.NoError(t, err)
+	require.Equal(t, &reply.ObjectIndex{Index: buf}, rep)
+
+	// primary heavy doesn't fail over
+	h.isReplica = false
+	_, err = h.middleware.failoverToPrimary(failing)(ctx, &message.Parcel{Msg: msg})
+	require.Error(t, err)
+}
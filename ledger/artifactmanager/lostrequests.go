@@ -0,0 +1,133 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package artifactmanager
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.opencensus.io/stats"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/recentstorage"
+	"github.com/insolar/insolar/ledger/storage/record"
+)
+
+// scannedJets remembers jets already scanned for lost requests since node start.
+type scannedJets struct {
+	lock sync.Mutex
+	jets map[core.RecordID]struct{}
+}
+
+func newScannedJets() *scannedJets {
+	return &scannedJets{jets: map[core.RecordID]struct{}{}}
+}
+
+// add returns true if jet wasn't scanned before.
+func (s *scannedJets) add(jetID core.RecordID) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.jets[jetID]; ok {
+		return false
+	}
+	s.jets[jetID] = struct{}{}
+	return true
+}
+
+// recoverLostRequests scans records of jet stored in recent pulses when node starts serving the jet for the first
+// time since start. Pending requests live in memory, so they are lost if executor or light material node crashes
+// and hot data doesn't list them. Requests registered without results which pending storage doesn't know are
+// added to it, returned requests are grouped by object, so executors of objects can be notified.
+func (h *MessageHandler) recoverLostRequests(
+	ctx context.Context, jetID core.RecordID, current core.PulseNumber, pendingStorage recentstorage.PendingStorage,
+) map[core.RecordID][]core.RecordID {
+	if h.conf.LostRequestsScanPulses <= 0 || !h.scannedJets.add(jetID) {
+		return nil
+	}
+	logger := inslogger.FromContext(ctx)
+
+	requests := map[core.RecordID]core.RecordID{}
+	finished := map[core.RecordID]struct{}{}
+	pulse := current
+	for i := 0; i < h.conf.LostRequestsScanPulses; i++ {
+		prev, err := h.PulseTracker.GetPreviousPulse(ctx, pulse)
+		if err != nil {
+			break
+		}
+		pulse = prev.Pulse.PulseNumber
+
+		err = h.DBContext.IterateRecordsOnPulse(ctx, jetID, pulse, func(id core.RecordID, rec record.Record) error {
+			switch r := rec.(type) {
+			case *record.RequestRecord:
+				requests[id] = r.Object
+			case *record.ResultRecord:
+				finished[*r.Request.Record()] = struct{}{}
+			}
+			return nil
+		})
+		if err != nil {
+			logger.Warn(errors.Wrapf(err, "[ recoverLostRequests ] failed to scan pulse %v", pulse))
+			break
+		}
+	}
+
+	lost := map[core.RecordID][]core.RecordID{}
+	for reqID, objID := range requests {
+		if _, ok := finished[reqID]; ok {
+			continue
+		}
+		if containsID(pendingStorage.GetRequestsForObject(objID), reqID) {
+			continue
+		}
+		lost[objID] = append(lost[objID], reqID)
+	}
+
+	var recovered int64
+	var contexts map[core.RecordID]recentstorage.PendingObjectContext
+	if len(lost) > 0 {
+		contexts = pendingStorage.GetRequests()
+	}
+	for objID, reqs := range lost {
+		sort.Slice(reqs, func(i, j int) bool { return bytes.Compare(reqs[i].Bytes(), reqs[j].Bytes()) < 0 })
+		pendingStorage.SetContextToObject(ctx, objID, recentstorage.PendingObjectContext{
+			Active:   false,
+			Requests: append(pendingStorage.GetRequestsForObject(objID), reqs...),
+			Timers:   contexts[objID].Timers,
+		})
+		recovered += int64(len(reqs))
+	}
+	if recovered > 0 {
+		logger.Infof("[ recoverLostRequests ] recovered %d lost requests of %d objects in jet %s",
+			recovered, len(lost), jetID.DebugString())
+		stats.Record(ctx, statLostRequestsRecovered.M(recovered))
+	}
+	return lost
+}
+
+func containsID(ids []core.RecordID, id core.RecordID) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
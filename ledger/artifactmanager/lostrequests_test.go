@@ -0,0 +1,68 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package artifactmanager
+
+import (
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/ledger/recentstorage"
+	"github.com/insolar/insolar/ledger/storage/record"
+	"github.com/insolar/insolar/testutils"
+)
+
+func (s *handlerSuite) TestMessageHandler_RecoverLostRequests() {
+	jetID := testutils.RandomJet()
+	// the first pulse is added on suite setup
+	for pn := core.FirstPulseNumber + 1; pn <= core.FirstPulseNumber+3; pn++ {
+		err := s.pulseTracker.AddPulse(s.ctx, core.Pulse{PulseNumber: core.PulseNumber(pn)})
+		require.NoError(s.T(), err)
+	}
+	current := core.PulseNumber(core.FirstPulseNumber + 3)
+
+	lostObj := testutils.RandomID()
+	knownObj := testutils.RandomID()
+	setRecord := func(pulse core.PulseNumber, rec record.Record) core.RecordID {
+		id, err := s.objectStorage.SetRecord(s.ctx, jetID, pulse, rec)
+		require.NoError(s.T(), err)
+		return *id
+	}
+	// too old to be scanned
+	setRecord(current-3, &record.RequestRecord{Object: lostObj, MessageHash: []byte{0}})
+	lost := setRecord(current-2, &record.RequestRecord{Object: lostObj, MessageHash: []byte{1}})
+	finished := setRecord(current-2, &record.RequestRecord{Object: lostObj, MessageHash: []byte{2}})
+	setRecord(current-1, &record.ResultRecord{Object: lostObj, Request: *core.NewRecordRef(core.DomainID, finished)})
+	known := setRecord(current-1, &record.RequestRecord{Object: knownObj, MessageHash: []byte{3}})
+
+	pendingStorage := recentstorage.NewPendingStorage(jetID)
+	pendingStorage.AddPendingRequest(s.ctx, knownObj, known)
+
+	certificate := testutils.NewCertificateMock(s.T())
+	h := NewMessageHandler(&configuration.Ledger{LostRequestsScanPulses: 2}, certificate)
+	h.PulseTracker = s.pulseTracker
+	h.DBContext = s.db
+
+	recovered := h.recoverLostRequests(s.ctx, jetID, current, pendingStorage)
+	require.Equal(s.T(), map[core.RecordID][]core.RecordID{lostObj: {lost}}, recovered)
+	require.Equal(s.T(), []core.RecordID{lost}, pendingStorage.GetRequestsForObject(lostObj))
+	require.False(s.T(), pendingStorage.GetRequests()[lostObj].Active)
+	require.Equal(s.T(), []core.RecordID{known}, pendingStorage.GetRequestsForObject(knownObj))
+
+	// jet is scanned only once since start
+	require.Nil(s.T(), h.recoverLostRequests(s.ctx, jetID, current, recentstorage.NewPendingStorage(jetID)))
+}
@@ -34,11 +34,18 @@ var (
 	statLatency = stats.Int64("artifactmanager/latency", "The latency in milliseconds per AM call", stats.UnitMilliseconds)
 
 	statRedirects = stats.Int64("artifactmanager/redirects", "The number redirects happens on AM", stats.UnitDimensionless)
+
+	statHeavyReplicaFailovers     = stats.Int64("artifactmanager/heavy/replica/failovers", "The number of reads from heavy replicas failed over to primary heavy", stats.UnitDimensionless)
+	statHeavyReplicaForwardErrors = stats.Int64("artifactmanager/heavy/replica/forward/errors", "The number of heavy sync messages not forwarded to heavy replicas", stats.UnitDimensionless)
+
+	statMemoryDiffSavedBytes = stats.Int64("artifactmanager/memory/diff/saved", "The number of bytes saved by storing object memory as diffs", stats.UnitBytes)
+
+	statLostRequestsRecovered = stats.Int64("artifactmanager/requests/lost/recovered", "The number of requests without results found by scan of recent pulses and put pending", stats.UnitDimensionless)
 )
 
 func init() {
 	commontags := []tag.Key{tagMethod, tagResult}
-	err := view.Register(
+	err := insmetrics.Register(
 		&view.View{
 			Name:        statCalls.Name(),
 			Description: statCalls.Description(),
@@ -60,6 +67,30 @@ func init() {
 			Measure:     statRedirects,
 			Aggregation: view.Count(),
 		},
+		&view.View{
+			Name:        statHeavyReplicaFailovers.Name(),
+			Description: statHeavyReplicaFailovers.Description(),
+			Measure:     statHeavyReplicaFailovers,
+			Aggregation: view.Count(),
+		},
+		&view.View{
+			Name:        statHeavyReplicaForwardErrors.Name(),
+			Description: statHeavyReplicaForwardErrors.Description(),
+			Measure:     statHeavyReplicaForwardErrors,
+			Aggregation: view.Count(),
+		},
+		&view.View{
+			Name:        statMemoryDiffSavedBytes.Name(),
+			Description: statMemoryDiffSavedBytes.Description(),
+			Measure:     statMemoryDiffSavedBytes,
+			Aggregation: view.Sum(),
+		},
+		&view.View{
+			Name:        statLostRequestsRecovered.Name(),
+			Description: statLostRequestsRecovered.Description(),
+			Measure:     statLostRequestsRecovered,
+			Aggregation: view.Sum(),
+		},
 	)
 	if err != nil {
 		panic(err)
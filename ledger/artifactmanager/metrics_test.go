@@ -136,7 +136,7 @@ func (s *metricSuite) TestLedgerArtifactManager_Metrics() {
 	defer tmetrics.Stop()
 
 	msg := message.GenesisRequest{Name: "4K3NiGuqYGqKPnYp6XeGd2kdN4P9veL6rYcWkLKWXZCu.4FFB8zfQoGznSmzDxwv4njX1aR9ioL8GHSH17QXH2AFa"}
-	_, err := am.RegisterRequest(s.ctx, *am.GenesisRef(), &message.Parcel{Msg: &msg})
+	_, _, err := am.RegisterRequest(s.ctx, *am.GenesisRef(), &message.Parcel{Msg: &msg})
 	require.NoError(s.T(), err)
 
 	time.Sleep(1500 * time.Millisecond)
@@ -20,6 +20,7 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	"go.opencensus.io/stats"
 
 	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/core"
@@ -86,6 +87,24 @@ func (m *middleware) zeroJetForHeavy(handler core.MessageHandler) core.MessageHa
 	}
 }
 
+// failoverToPrimary sends message to primary heavy if heavy replica failed to handle it, e.g. when replica
+// lags behind primary.
+func (m *middleware) failoverToPrimary(handler core.MessageHandler) core.MessageHandler {
+	return func(ctx context.Context, parcel core.Parcel) (core.Reply, error) {
+		rep, err := handler(ctx, parcel)
+		if err == nil || !m.handler.isReplica {
+			return rep, err
+		}
+		primary, primaryErr := m.jetCoordinator.Heavy(ctx, parcel.Pulse())
+		if primaryErr != nil {
+			return nil, err
+		}
+		inslogger.FromContext(ctx).Warnf("failed to handle %s on heavy replica, failover to primary: %s", parcel.Type(), err)
+		stats.Record(ctx, statHeavyReplicaFailovers.M(1))
+		return m.messageBus.Send(ctx, parcel.Message(), &core.MessageSendOptions{Receiver: primary})
+	}
+}
+
 func addJetIDToLogger(ctx context.Context, jetID core.RecordID) context.Context {
 	ctx, _ = inslogger.WithField(ctx, "jetid", jetID.DebugString())
 
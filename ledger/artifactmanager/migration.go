@@ -0,0 +1,188 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package artifactmanager
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/recentstorage"
+	"github.com/insolar/insolar/ledger/storage"
+	"github.com/insolar/insolar/ledger/storage/index"
+)
+
+// HotDataMigrator copies hot data of jets to other light material nodes ahead of planned topology change.
+type HotDataMigrator struct {
+	Bus                        core.MessageBus                 `inject:""`
+	NodeNet                    core.NodeNetwork                `inject:""`
+	PlatformCryptographyScheme core.PlatformCryptographyScheme `inject:""`
+	RecentStorageProvider      recentstorage.Provider          `inject:""`
+	ObjectStorage              storage.ObjectStorage           `inject:""`
+}
+
+// NewHotDataMigrator creates new hot data migrator.
+func NewHotDataMigrator() *HotDataMigrator {
+	return &HotDataMigrator{}
+}
+
+// MigrateHotData sends indexes and pending requests of jet to target node. Migration succeeds if checksum
+// of data stored by target matches checksum of sent data.
+func (m *HotDataMigrator) MigrateHotData(
+	ctx context.Context, jet core.RecordID, target core.RecordRef,
+) (*core.HotDataMigration, error) {
+	if m.NodeNet.GetOrigin().Role() != core.StaticRoleLightMaterial {
+		return nil, errors.New("[ MigrateHotData ] only light material node can migrate hot data")
+	}
+	node := m.NodeNet.GetWorkingNode(target)
+	if node == nil || node.Role() != core.StaticRoleLightMaterial {
+		return nil, errors.Errorf("[ MigrateHotData ] %s is not a working light material node", target)
+	}
+
+	msg, err := collectHotData(ctx, m.RecentStorageProvider, m.ObjectStorage, jet, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ MigrateHotData ] failed to collect hot data")
+	}
+	checksum := message.HotDataChecksum(m.PlatformCryptographyScheme.IntegrityHasher(), msg.RecentObjects, msg.PendingRequests)
+
+	genericReply, err := m.Bus.Send(ctx, msg, &core.MessageSendOptions{Receiver: &target})
+	if err != nil {
+		return nil, errors.Wrap(err, "[ MigrateHotData ] failed to send hot data")
+	}
+	rep, ok := genericReply.(*reply.HotDataMigrated)
+	if !ok {
+		return nil, errors.Wrap(fmt.Errorf("unexpected reply: %#v", genericReply), "[ MigrateHotData ]")
+	}
+	if !bytes.Equal(rep.Checksum, checksum) {
+		return nil, errors.Errorf(
+			"[ MigrateHotData ] checksum mismatch: sent %d objects and %d requests, target stored %d objects and %d requests",
+			len(msg.RecentObjects), countRequests(msg.PendingRequests), rep.Objects, rep.Requests,
+		)
+	}
+
+	inslogger.FromContext(ctx).Infof(
+		"migrated hot data of jet %s to %s: %d objects, %d requests",
+		jet.DebugString(), target, rep.Objects, rep.Requests,
+	)
+	return &core.HotDataMigration{
+		Jet:      jet,
+		Target:   target,
+		Objects:  rep.Objects,
+		Requests: rep.Requests,
+		Checksum: checksum,
+	}, nil
+}
+
+// collectHotData reads hot data of jet from local storages. If only is not nil, data is read for listed objects only.
+func collectHotData(
+	ctx context.Context,
+	provider recentstorage.Provider,
+	objectStorage storage.ObjectStorage,
+	jet core.RecordID,
+	only *message.MigrateHotData,
+) (*message.MigrateHotData, error) {
+	msg := &message.MigrateHotData{
+		Jet:             jet,
+		RecentObjects:   map[core.RecordID]message.HotIndex{},
+		PendingRequests: map[core.RecordID]recentstorage.PendingObjectContext{},
+	}
+
+	for id, ttl := range provider.GetIndexStorage(ctx, jet).GetObjects() {
+		if only != nil {
+			if _, ok := only.RecentObjects[id]; !ok {
+				continue
+			}
+		}
+		id := id
+		lifeline, err := objectStorage.GetObjectIndex(ctx, jet, &id, false)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch index of %s", id.DebugString())
+		}
+		encoded, err := index.EncodeObjectLifeline(lifeline)
+		if err != nil {
+			return nil, err
+		}
+		msg.RecentObjects[id] = message.HotIndex{TTL: ttl, Index: encoded}
+	}
+
+	for obj, objContext := range provider.GetPendingStorage(ctx, jet).GetRequests() {
+		if only != nil {
+			if _, ok := only.PendingRequests[obj]; !ok {
+				continue
+			}
+		}
+		if len(objContext.Requests) > 0 || len(objContext.Timers) > 0 {
+			msg.PendingRequests[obj] = objContext
+		}
+	}
+	return msg, nil
+}
+
+func countRequests(pending map[core.RecordID]recentstorage.PendingObjectContext) int {
+	count := 0
+	for _, objContext := range pending {
+		count += len(objContext.Requests)
+	}
+	return count
+}
+
+// handleMigrateHotData stores migrated hot data and replies with checksum of data read back from storages.
+// Data isn't activated, node receives regular hot data when it becomes executor of the jet.
+func (h *MessageHandler) handleMigrateHotData(ctx context.Context, parcel core.Parcel) (core.Reply, error) {
+	msg := parcel.Message().(*message.MigrateHotData)
+	logger := inslogger.FromContext(ctx)
+	logger.Infof(
+		"received migrated hot data of jet %s: %d objects, %d pending objects",
+		msg.Jet.DebugString(), len(msg.RecentObjects), len(msg.PendingRequests),
+	)
+
+	indexStorage := h.RecentStorageProvider.GetIndexStorage(ctx, msg.Jet)
+	for id, meta := range msg.RecentObjects {
+		id := id
+		decodedIndex, err := index.DecodeObjectLifeline(meta.Index)
+		if err != nil {
+			return nil, errors.Wrap(err, "[ handleMigrateHotData ] failed to decode index")
+		}
+		err = h.ObjectStorage.SetObjectIndex(ctx, msg.Jet, &id, decodedIndex)
+		if err != nil {
+			return nil, errors.Wrap(err, "[ handleMigrateHotData ] failed to store index")
+		}
+		indexStorage.AddObjectWithTLL(ctx, id, meta.TTL)
+	}
+
+	pendingStorage := h.RecentStorageProvider.GetPendingStorage(ctx, msg.Jet)
+	for obj, objContext := range msg.PendingRequests {
+		objContext.Active = false
+		pendingStorage.SetContextToObject(ctx, obj, objContext)
+	}
+
+	stored, err := collectHotData(ctx, h.RecentStorageProvider, h.ObjectStorage, msg.Jet, msg)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ handleMigrateHotData ] failed to read back hot data")
+	}
+	return &reply.HotDataMigrated{
+		Objects:  len(stored.RecentObjects),
+		Requests: countRequests(stored.PendingRequests),
+		Checksum: message.HotDataChecksum(h.PlatformCryptographyScheme.IntegrityHasher(), stored.RecentObjects, stored.PendingRequests),
+	}, nil
+}
@@ -0,0 +1,101 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package artifactmanager
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage/record"
+)
+
+// notifyPendingCallers notifies API nodes which accepted abandoned requests that requests are still pending,
+// so clients learn about the delay. Requests pending for less than configured number of pulses are skipped.
+func (h *MessageHandler) notifyPendingCallers(
+	ctx context.Context, jetID core.RecordID, current core.PulseNumber, requests []core.RecordID,
+) {
+	if h.conf.PendingNotificationPulses <= 0 {
+		return
+	}
+	logger := inslogger.FromContext(ctx)
+
+	for _, reqID := range requests {
+		pulses := h.pendingPulses(ctx, reqID.Pulse(), current)
+		if pulses < h.conf.PendingNotificationPulses {
+			continue
+		}
+
+		apiRequest, err := h.requestOrigin(ctx, jetID, reqID)
+		if err != nil {
+			logger.Warn(errors.Wrapf(err, "[ notifyPendingCallers ] can't get origin of request %s", reqID.DebugString()))
+			continue
+		}
+		if apiRequest == nil || apiRequest.APINode.IsEmpty() {
+			continue
+		}
+
+		status := &message.PendingRequestStatus{
+			QID:     apiRequest.QID,
+			Member:  apiRequest.Member,
+			Request: *core.NewRecordRef(core.DomainID, reqID),
+			Pulses:  pulses,
+		}
+		_, err = h.Bus.Send(ctx, status, &core.MessageSendOptions{Receiver: &apiRequest.APINode})
+		if err != nil {
+			logger.Warn(errors.Wrapf(err, "[ notifyPendingCallers ] failed to notify API node %s", apiRequest.APINode))
+		}
+	}
+}
+
+// pendingPulses returns number of pulses passed since request pulse. Request is considered pending
+// for a long time if its pulse is already forgotten by node.
+func (h *MessageHandler) pendingPulses(ctx context.Context, requestPulse, current core.PulseNumber) int {
+	from, err := h.PulseTracker.GetPulse(ctx, requestPulse)
+	if err != nil {
+		return h.conf.PendingNotificationPulses
+	}
+	to, err := h.PulseTracker.GetPulse(ctx, current)
+	if err != nil {
+		return 0
+	}
+	return to.SerialNumber - from.SerialNumber
+}
+
+// requestOrigin returns metadata of API request which initiated call chain of registered request.
+func (h *MessageHandler) requestOrigin(ctx context.Context, jetID core.RecordID, reqID core.RecordID) (*core.APIRequest, error) {
+	rec, err := h.ObjectStorage.GetRecord(ctx, jetID, &reqID)
+	if err != nil {
+		return nil, err
+	}
+	req, ok := rec.(*record.RequestRecord)
+	if !ok {
+		return nil, errors.New("record is not a request")
+	}
+	parcel, err := message.DeserializeParcel(bytes.NewBuffer(req.Parcel))
+	if err != nil {
+		return nil, err
+	}
+	msg, ok := parcel.Message().(message.IBaseLogicMessage)
+	if !ok {
+		return nil, nil
+	}
+	return msg.GetAPIRequest(), nil
+}
@@ -0,0 +1,94 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package artifactmanager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage"
+	"github.com/insolar/insolar/ledger/storage/record"
+)
+
+// objectProof builds proof of state record stored in jet. Drop hash is left empty if drop of state pulse isn't
+// created yet.
+func (h *MessageHandler) objectProof(
+	ctx context.Context, jetID core.RecordID, stateID core.RecordID, rec record.Record,
+) *reply.ObjectProof {
+	proof := &reply.ObjectProof{
+		Record: record.SerializeRecord(rec),
+		Jet:    jetID,
+	}
+	drop, err := h.DropStorage.GetDrop(ctx, jetID, stateID.Pulse())
+	if err == nil {
+		proof.DropHash = drop.Hash
+	} else if err != storage.ErrNotFound {
+		inslogger.FromContext(ctx).Warnf("failed to fetch drop for proof of state %s: %s", stateID.DebugString(), err)
+	}
+	return proof
+}
+
+// VerifyObjectProof checks that proof attached to fetched object matches its state id, prototype and memory.
+func VerifyObjectProof(scheme core.PlatformCryptographyScheme, obj *reply.Object) error {
+	if obj.Proof == nil {
+		return errors.New("proof is missing")
+	}
+	rec, err := deserializeProofRecord(obj.Proof.Record)
+	if err != nil {
+		return err
+	}
+	state, ok := rec.(record.ObjectState)
+	if !ok {
+		return errors.New("proof record isn't an object state")
+	}
+
+	id := record.NewRecordIDFromRecord(scheme, obj.State.Pulse(), rec)
+	if !id.Equal(&obj.State) {
+		return errors.Errorf("proof record doesn't match state %s", obj.State.DebugString())
+	}
+	image := state.GetImage()
+	if (image == nil) != (obj.Prototype == nil) || (image != nil && !image.Equal(*obj.Prototype)) {
+		return errors.Errorf("prototype doesn't match state %s", obj.State.DebugString())
+	}
+
+	// memory of amend stored as diff can't be checked against blob id without previous states
+	memory := state.GetMemory()
+	if amend, ok := state.(*record.ObjectAmendRecord); memory == nil || (ok && amend.MemoryDiffDepth > 0) {
+		return nil
+	}
+	if !record.CalculateIDForBlob(scheme, memory.Pulse(), obj.Memory).Equal(memory) {
+		return errors.Errorf("memory doesn't match state %s", obj.State.DebugString())
+	}
+	return nil
+}
+
+func deserializeProofRecord(buf []byte) (rec record.Record, err error) {
+	if len(buf) < record.TypeIDSize {
+		return nil, errors.New("proof record is too short")
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to decode proof record: %v", r)
+		}
+	}()
+	return record.DeserializeRecord(buf), nil
+}
@@ -0,0 +1,86 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package artifactmanager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage"
+	"github.com/insolar/insolar/ledger/storage/jet"
+	"github.com/insolar/insolar/ledger/storage/record"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestObjectProof(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	scheme := platformpolicy.NewPlatformCryptographyScheme()
+	pulse := core.PulseNumber(core.FirstPulseNumber + 10)
+	jetID := testutils.RandomJet()
+	memory := []byte("memory")
+	rec := &record.ObjectActivateRecord{
+		ObjectStateRecord: record.ObjectStateRecord{
+			Memory: record.CalculateIDForBlob(scheme, pulse, memory),
+			Image:  testutils.RandomRef(),
+		},
+	}
+	stateID := *record.NewRecordIDFromRecord(scheme, pulse, rec)
+
+	dropStorage := storage.NewDropStorageMock(t)
+	dropStorage.GetDropMock.Expect(ctx, jetID, pulse).Return(&jet.JetDrop{Pulse: pulse, Hash: []byte("drop")}, nil)
+	h := &MessageHandler{DropStorage: dropStorage}
+
+	object := func() *reply.Object {
+		return &reply.Object{
+			Head:      testutils.RandomRef(),
+			State:     stateID,
+			Prototype: &rec.Image,
+			Memory:    memory,
+			Proof:     h.objectProof(ctx, jetID, stateID, rec),
+		}
+	}
+	obj := object()
+	assert.Equal(t, jetID, obj.Proof.Jet)
+	assert.Equal(t, []byte("drop"), obj.Proof.DropHash)
+	require.NoError(t, VerifyObjectProof(scheme, obj))
+
+	obj = object()
+	obj.Memory = []byte("forged")
+	assert.EqualError(t, VerifyObjectProof(scheme, obj), "memory doesn't match state "+stateID.DebugString())
+
+	obj = object()
+	obj.State = testutils.RandomID()
+	assert.EqualError(t, VerifyObjectProof(scheme, obj), "proof record doesn't match state "+obj.State.DebugString())
+
+	obj = object()
+	prototype := testutils.RandomRef()
+	obj.Prototype = &prototype
+	assert.EqualError(t, VerifyObjectProof(scheme, obj), "prototype doesn't match state "+stateID.DebugString())
+
+	obj = object()
+	obj.Proof.Record = []byte{1}
+	assert.EqualError(t, VerifyObjectProof(scheme, obj), "proof record is too short")
+
+	obj.Proof = nil
+	assert.EqualError(t, VerifyObjectProof(scheme, obj), "proof is missing")
+}
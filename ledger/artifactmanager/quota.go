@@ -0,0 +1,80 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package artifactmanager
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/ledger/storage/record"
+)
+
+// writeQuota counts bytes of records and blobs written by callers within current pulse.
+type writeQuota struct {
+	lock    sync.Mutex
+	pulse   core.PulseNumber
+	written map[core.RecordRef]int
+}
+
+func newWriteQuota() *writeQuota {
+	return &writeQuota{written: map[core.RecordRef]int{}}
+}
+
+// add counts size bytes written by caller in pulse, counters are reset when new pulse comes.
+func (q *writeQuota) add(pulse core.PulseNumber, caller core.RecordRef, size int) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	q.resetOnPulse(pulse)
+	q.written[caller] += size
+}
+
+// exceeded checks if caller has written more than limit bytes in pulse, zero limit is never exceeded.
+func (q *writeQuota) exceeded(pulse core.PulseNumber, caller core.RecordRef, limit int) bool {
+	if limit <= 0 {
+		return false
+	}
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	q.resetOnPulse(pulse)
+	return q.written[caller] >= limit
+}
+
+func (q *writeQuota) resetOnPulse(pulse core.PulseNumber) {
+	if pulse > q.pulse {
+		q.pulse = pulse
+		q.written = map[core.RecordRef]int{}
+	}
+}
+
+// writeCaller returns member or object which initiated request, or node which sent the record otherwise.
+func writeCaller(parcel core.Parcel, rec record.Record) core.RecordRef {
+	if req, ok := rec.(*record.RequestRecord); ok {
+		reqParcel, err := message.DeserializeParcel(bytes.NewBuffer(req.Parcel))
+		if err == nil {
+			caller := reqParcel.GetCaller()
+			if caller != nil && !caller.IsEmpty() {
+				return *caller
+			}
+		}
+	}
+	return parcel.GetSender()
+}
@@ -0,0 +1,76 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package artifactmanager
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+)
+
+// SchemaRegistry resolves schemas of contract methods stored alongside contract code.
+type SchemaRegistry struct {
+	ArtifactManager core.ArtifactManager `inject:""`
+
+	lock sync.RWMutex
+	// schemas is a cache of parsed schemas by code reference, since code records are immutable.
+	schemas map[core.RecordRef]*core.ContractSchema
+}
+
+// NewSchemaRegistry creates new schema registry.
+func NewSchemaRegistry() *SchemaRegistry {
+	return &SchemaRegistry{schemas: map[core.RecordRef]*core.ContractSchema{}}
+}
+
+// Schema returns schema of code of provided prototype. core.ErrNotFound is returned if code was deployed without
+// schema.
+func (r *SchemaRegistry) Schema(ctx context.Context, prototype core.RecordRef) (*core.ContractSchema, error) {
+	desc, err := r.ArtifactManager.GetObject(ctx, prototype, nil, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ SchemaRegistry ] failed to fetch prototype")
+	}
+	codeRef, err := desc.Code()
+	if err != nil {
+		return nil, errors.Wrap(err, "[ SchemaRegistry ] failed to fetch code reference of prototype")
+	}
+
+	r.lock.RLock()
+	schema, ok := r.schemas[*codeRef]
+	r.lock.RUnlock()
+	if ok {
+		return schema, nil
+	}
+
+	code, err := r.ArtifactManager.GetCode(ctx, *codeRef)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ SchemaRegistry ] failed to fetch code")
+	}
+	if code.Schema() == nil {
+		return nil, core.ErrNotFound
+	}
+	schema, err = core.ParseContractSchema(code.Schema())
+	if err != nil {
+		return nil, errors.Wrap(err, "[ SchemaRegistry ]")
+	}
+
+	r.lock.Lock()
+	r.schemas[*codeRef] = schema
+	r.lock.Unlock()
+	return schema, nil
+}
@@ -0,0 +1,85 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package artifactmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gojuno/minimock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestSchemaRegistry_Schema(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	prototype := testutils.RandomRef()
+	codeRef := testutils.RandomRef()
+
+	proto := testutils.NewObjectDescriptorMock(mc)
+	proto.CodeMock.Return(&codeRef, nil)
+	code := testutils.NewCodeDescriptorMock(mc)
+	code.SchemaMock.Return([]byte(`{"methods":{"Get":{"arguments":[],"results":[{"type":"int"}]}}}`))
+
+	fetched := 0
+	am := testutils.NewArtifactManagerMock(mc)
+	am.GetObjectMock.Expect(ctx, prototype, nil, false).Return(proto, nil)
+	am.GetCodeFunc = func(ctx context.Context, ref core.RecordRef) (core.CodeDescriptor, error) {
+		require.Equal(t, codeRef, ref)
+		fetched++
+		return code, nil
+	}
+
+	registry := NewSchemaRegistry()
+	registry.ArtifactManager = am
+
+	schema, err := registry.Schema(ctx, prototype)
+	require.NoError(t, err)
+	require.Equal(t, []core.FieldSchema{{Type: "int"}}, schema.Methods["Get"].Results)
+
+	cached, err := registry.Schema(ctx, prototype)
+	require.NoError(t, err)
+	require.Equal(t, schema, cached)
+	require.Equal(t, 1, fetched)
+}
+
+func TestSchemaRegistry_Schema_NotDeployed(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	codeRef := testutils.RandomRef()
+	proto := testutils.NewObjectDescriptorMock(mc)
+	proto.CodeMock.Return(&codeRef, nil)
+	code := testutils.NewCodeDescriptorMock(mc)
+	code.SchemaMock.Return(nil)
+
+	am := testutils.NewArtifactManagerMock(mc)
+	am.GetObjectMock.Return(proto, nil)
+	am.GetCodeMock.Return(code, nil)
+
+	registry := NewSchemaRegistry()
+	registry.ArtifactManager = am
+
+	_, err := registry.Schema(ctx, testutils.RandomRef())
+	require.Equal(t, core.ErrNotFound, err)
+}
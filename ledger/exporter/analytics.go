@@ -0,0 +1,285 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage/record"
+)
+
+// AnalyticsBucket is anonymized statistics aggregated over bucket of pulses. Members are identified by salted
+// hashes of their references only, so statistics can be handed over without raw ledger contents.
+type AnalyticsBucket struct {
+	// From is the first pulse number of bucket.
+	From core.PulseNumber
+	// To is the first pulse number after bucket.
+	To core.PulseNumber
+	// Transactions is a number of calls made by members.
+	Transactions int
+	// Transfers is a number of transfers between members.
+	Transfers   int
+	Volume      uint64
+	MinTransfer uint64
+	MaxTransfer uint64
+	// Values counts transfers by decimal order of amount, e.g. "1e2" counts amounts from 100 to 999.
+	Values map[string]int
+	// ActiveMembers is a number of members who made calls or took part in transfers.
+	ActiveMembers int
+	// Members counts transactions and transfers of every active member by hash of its reference.
+	Members map[string]int
+}
+
+func newAnalyticsBucket(from, to core.PulseNumber) *AnalyticsBucket {
+	return &AnalyticsBucket{
+		From:    from,
+		To:      to,
+		Values:  map[string]int{},
+		Members: map[string]int{},
+	}
+}
+
+// addRequest accounts request in statistics of bucket. Requests which are neither member calls nor transfers are
+// skipped.
+func (b *AnalyticsBucket) addRequest(req *record.RequestRecord, hash func(core.RecordRef) string) {
+	parcel, err := message.DeserializeParcel(bytes.NewBuffer(req.Parcel))
+	if err != nil {
+		return
+	}
+	call, ok := parcel.Message().(*message.CallMethod)
+	if !ok {
+		return
+	}
+
+	switch call.Method {
+	case "Call":
+		b.Transactions++
+		b.addMember(hash(call.ObjectRef))
+	case "Transfer", "TransferWithConfirmation":
+		var amount uint
+		var to core.RecordRef
+		if err := core.Deserialize(call.Arguments, []interface{}{&amount, &to}); err != nil {
+			return
+		}
+		b.addTransfer(uint64(amount))
+		b.addMember(hash(call.Caller))
+		b.addMember(hash(to))
+	}
+}
+
+func (b *AnalyticsBucket) addTransfer(amount uint64) {
+	if b.Transfers == 0 || amount < b.MinTransfer {
+		b.MinTransfer = amount
+	}
+	if amount > b.MaxTransfer {
+		b.MaxTransfer = amount
+	}
+	b.Transfers++
+	b.Volume += amount
+	b.Values[amountOrder(amount)]++
+}
+
+func (b *AnalyticsBucket) addMember(member string) {
+	if b.Members[member] == 0 {
+		b.ActiveMembers++
+	}
+	b.Members[member]++
+}
+
+// amountOrder returns decimal order of amount, e.g. "1e2" for 100..999.
+func amountOrder(amount uint64) string {
+	if amount == 0 {
+		return "0"
+	}
+	return "1e" + strconv.Itoa(len(strconv.FormatUint(amount, 10))-1)
+}
+
+// Analytics exports anonymized statistics of ledger for analytics on schedule. It works on heavy material nodes only.
+type Analytics struct {
+	LedgerQuerier core.LedgerQuerier `inject:""`
+	PulseStorage  core.PulseStorage  `inject:""`
+
+	conf    configuration.Analytics
+	lag     uint32
+	isHeavy bool
+
+	lock sync.Mutex
+	// next is the first pulse number of bucket to be exported next
+	next     core.PulseNumber
+	restored bool
+}
+
+// NewAnalytics creates new analytics exporter.
+func NewAnalytics(conf configuration.Ledger, certificate core.Certificate) *Analytics {
+	return &Analytics{
+		conf:    conf.Exporter.Analytics,
+		lag:     conf.Exporter.ExportLag,
+		isHeavy: certificate.GetRole() == core.StaticRoleHeavyMaterial,
+	}
+}
+
+func (a *Analytics) enabled() bool {
+	return a.isHeavy && a.conf.Interval > 0
+}
+
+// Init checks configuration of enabled export and creates directory for export files.
+func (a *Analytics) Init(ctx context.Context) error {
+	if !a.enabled() {
+		return nil
+	}
+	if a.conf.Salt == "" {
+		return errors.New("[ Analytics ] salt for member hashes must be set")
+	}
+	if a.conf.BucketSize == 0 {
+		return errors.New("[ Analytics ] bucket size must be positive")
+	}
+	return errors.Wrap(os.MkdirAll(a.conf.Dir, 0755), "[ Analytics ] failed to create export directory")
+}
+
+// PeriodicTasks returns export task to be run by scheduler.
+func (a *Analytics) PeriodicTasks() []core.PeriodicTask {
+	if !a.enabled() {
+		return nil
+	}
+	return []core.PeriodicTask{{
+		Name:     "ledger.analytics",
+		Schedule: core.TaskSchedule{Every: a.conf.Interval},
+		Run:      a.Export,
+	}}
+}
+
+// Export aggregates statistics of buckets which are completely synced to heavy since previous export and writes
+// every bucket with activity to its own file. Like with export, recent pulses are left for next run.
+func (a *Analytics) Export(ctx context.Context) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if !a.restored {
+		next, err := a.lastExported()
+		if err != nil {
+			return err
+		}
+		a.next, a.restored = next, true
+	}
+
+	current, err := a.PulseStorage.Current(ctx)
+	if err != nil {
+		return errors.Wrap(err, "[ Analytics ] failed to get current pulse data")
+	}
+	if current.PrevPulseNumber < core.PulseNumber(a.lag) {
+		return nil
+	}
+	end := a.bucketStart(current.PrevPulseNumber - core.PulseNumber(a.lag))
+	if end <= a.next {
+		return nil
+	}
+
+	buckets := map[core.PulseNumber]*AnalyticsBucket{}
+	query := fmt.Sprintf("type=request pulse=%d..%d", a.next, end-1)
+	err = a.LedgerQuerier.QueryRecords(ctx, query, func(rec core.QueriedRecord) error {
+		req, ok := rec.Data.(*record.RequestRecord)
+		if !ok {
+			return nil
+		}
+		from := a.bucketStart(rec.ID.Pulse())
+		bucket, ok := buckets[from]
+		if !ok {
+			bucket = newAnalyticsBucket(from, from+core.PulseNumber(a.conf.BucketSize))
+			buckets[from] = bucket
+		}
+		bucket.addRequest(req, a.hashMember)
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "[ Analytics ] failed to query requests")
+	}
+
+	for _, bucket := range buckets {
+		if bucket.Transactions == 0 && bucket.Transfers == 0 {
+			continue
+		}
+		if err := a.write(bucket); err != nil {
+			return err
+		}
+	}
+	inslogger.FromContext(ctx).Infof("[ Analytics ] statistics of pulses %d..%d are exported", a.next, end-1)
+	a.next = end
+	return nil
+}
+
+// bucketStart returns the first pulse number of bucket pulse belongs to.
+func (a *Analytics) bucketStart(pn core.PulseNumber) core.PulseNumber {
+	return pn - pn%core.PulseNumber(a.conf.BucketSize)
+}
+
+// hashMember returns salted hash of member reference.
+func (a *Analytics) hashMember(ref core.RecordRef) string {
+	mac := hmac.New(sha256.New, []byte(a.conf.Salt))
+	mac.Write(ref[:]) // nolint: errcheck
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+const analyticsFilePattern = "analytics-%d-%d.json"
+
+// write writes bucket to file atomically, so partially written files are never picked up.
+func (a *Analytics) write(bucket *AnalyticsBucket) error {
+	data, err := json.MarshalIndent(bucket, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "[ Analytics ] failed to marshal bucket")
+	}
+	path := filepath.Join(a.conf.Dir, fmt.Sprintf(analyticsFilePattern, bucket.From, bucket.To))
+	if err := ioutil.WriteFile(path+".tmp", data, 0644); err != nil {
+		return errors.Wrap(err, "[ Analytics ] failed to write bucket")
+	}
+	return errors.Wrap(os.Rename(path+".tmp", path), "[ Analytics ] failed to write bucket")
+}
+
+// lastExported returns the end of the latest exported bucket, so export continues after restart where it stopped.
+func (a *Analytics) lastExported() (core.PulseNumber, error) {
+	files, err := ioutil.ReadDir(a.conf.Dir)
+	if err != nil {
+		return 0, errors.Wrap(err, "[ Analytics ] failed to list export directory")
+	}
+	var last core.PulseNumber
+	for _, f := range files {
+		var from, to core.PulseNumber
+		if _, err := fmt.Sscanf(f.Name(), analyticsFilePattern, &from, &to); err != nil {
+			continue
+		}
+		if filepath.Ext(f.Name()) == ".json" && to > last {
+			last = to
+		}
+	}
+	return last, nil
+}
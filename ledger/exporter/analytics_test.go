@@ -0,0 +1,172 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package exporter
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage/record"
+	"github.com/insolar/insolar/testutils"
+)
+
+type ledgerQuerier struct {
+	queries []string
+	records []core.QueriedRecord
+}
+
+func (q *ledgerQuerier) QueryRecords(ctx context.Context, query string, fn func(core.QueriedRecord) error) error {
+	q.queries = append(q.queries, query)
+	for _, rec := range q.records {
+		if err := fn(rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func requestRecord(msg core.Message) *record.RequestRecord {
+	return &record.RequestRecord{Parcel: message.ParcelToBytes(&message.Parcel{Msg: msg})}
+}
+
+func callRequest(member core.RecordRef) *record.RequestRecord {
+	return requestRecord(&message.CallMethod{ObjectRef: member, Method: "Call"})
+}
+
+func transferRequest(t *testing.T, from, to core.RecordRef, amount uint) *record.RequestRecord {
+	args, err := core.MarshalArgs(amount, &to)
+	require.NoError(t, err)
+	return requestRecord(&message.CallMethod{
+		BaseLogicMessage: message.BaseLogicMessage{Caller: from},
+		ObjectRef:        testutils.RandomRef(),
+		Method:           "Transfer",
+		Arguments:        args,
+	})
+}
+
+func TestAmountOrder(t *testing.T) {
+	assert.Equal(t, "0", amountOrder(0))
+	assert.Equal(t, "1e0", amountOrder(9))
+	assert.Equal(t, "1e1", amountOrder(10))
+	assert.Equal(t, "1e3", amountOrder(1500))
+}
+
+func TestAnalyticsBucket_addRequest(t *testing.T) {
+	alice, bob := testutils.RandomRef(), testutils.RandomRef()
+	hash := func(ref core.RecordRef) string { return ref.String() }
+
+	bucket := newAnalyticsBucket(1000, 2000)
+	bucket.addRequest(callRequest(alice), hash)
+	bucket.addRequest(callRequest(alice), hash)
+	bucket.addRequest(transferRequest(t, alice, bob, 150), hash)
+	bucket.addRequest(transferRequest(t, bob, alice, 5), hash)
+	bucket.addRequest(requestRecord(&message.CallConstructor{Method: "New"}), hash)
+	bucket.addRequest(&record.RequestRecord{Parcel: []byte("garbage")}, hash)
+
+	assert.Equal(t, 2, bucket.Transactions)
+	assert.Equal(t, 2, bucket.Transfers)
+	assert.Equal(t, uint64(155), bucket.Volume)
+	assert.Equal(t, uint64(5), bucket.MinTransfer)
+	assert.Equal(t, uint64(150), bucket.MaxTransfer)
+	assert.Equal(t, map[string]int{"1e0": 1, "1e2": 1}, bucket.Values)
+	assert.Equal(t, 2, bucket.ActiveMembers)
+	assert.Equal(t, map[string]int{alice.String(): 4, bob.String(): 2}, bucket.Members)
+}
+
+func TestAnalytics_Export(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	dir, err := ioutil.TempDir("", "analytics")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cert := testutils.NewCertificateMock(t)
+	cert.GetRoleMock.Return(core.StaticRoleHeavyMaterial)
+	conf := configuration.NewLedger()
+	conf.Exporter.ExportLag = 10
+	conf.Exporter.Analytics = configuration.Analytics{Interval: time.Minute, BucketSize: 100, Dir: dir, Salt: "salt"}
+
+	alice, bob := testutils.RandomRef(), testutils.RandomRef()
+	querier := &ledgerQuerier{records: []core.QueriedRecord{
+		{ID: *core.NewRecordID(65610, nil), Data: callRequest(alice)},
+		{ID: *core.NewRecordID(65620, nil), Data: transferRequest(t, alice, bob, 42)},
+		{ID: *core.NewRecordID(65620, nil), Data: &record.ResultRecord{}},
+		{ID: *core.NewRecordID(65710, nil), Data: callRequest(bob)},
+	}}
+	pulses := testutils.NewPulseStorageMock(t)
+	pulses.CurrentMock.Return(&core.Pulse{PulseNumber: 65830, PrevPulseNumber: 65820}, nil)
+
+	newAnalytics := func() *Analytics {
+		a := NewAnalytics(conf, cert)
+		a.LedgerQuerier, a.PulseStorage = querier, pulses
+		require.NoError(t, a.Init(ctx))
+		require.Len(t, a.PeriodicTasks(), 1)
+		return a
+	}
+	a := newAnalytics()
+	require.NoError(t, a.Export(ctx))
+	assert.Equal(t, []string{"type=request pulse=0..65799"}, querier.queries)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*"))
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	data, err := ioutil.ReadFile(filepath.Join(dir, "analytics-65600-65700.json"))
+	require.NoError(t, err)
+	var bucket AnalyticsBucket
+	require.NoError(t, json.Unmarshal(data, &bucket))
+	assert.Equal(t, 1, bucket.Transactions)
+	assert.Equal(t, uint64(42), bucket.Volume)
+	assert.Equal(t, 2, bucket.ActiveMembers)
+	assert.Equal(t, map[string]int{a.hashMember(alice): 2, a.hashMember(bob): 1}, bucket.Members)
+	assert.NotContains(t, string(data), alice.String())
+
+	// nothing new is synced yet
+	require.NoError(t, a.Export(ctx))
+	assert.Len(t, querier.queries, 1)
+
+	// export continues after restart where it stopped
+	pulses.CurrentMock.Return(&core.Pulse{PulseNumber: 65930, PrevPulseNumber: 65920}, nil)
+	require.NoError(t, newAnalytics().Export(ctx))
+	assert.Equal(t, "type=request pulse=65800..65899", querier.queries[1])
+}
+
+func TestAnalytics_Disabled(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	conf := configuration.NewLedger()
+	conf.Exporter.Analytics.Interval = time.Minute
+
+	light := testutils.NewCertificateMock(t)
+	light.GetRoleMock.Return(core.StaticRoleLightMaterial)
+	a := NewAnalytics(conf, light)
+	require.NoError(t, a.Init(ctx))
+	assert.Empty(t, a.PeriodicTasks())
+
+	heavy := testutils.NewCertificateMock(t)
+	heavy.GetRoleMock.Return(core.StaticRoleHeavyMaterial)
+	require.EqualError(t, NewAnalytics(conf, heavy).Init(ctx), "[ Analytics ] salt for member hashes must be set")
+}
@@ -40,6 +40,7 @@ type Exporter struct {
 	ObjectStorage storage.ObjectStorage `inject:""`
 	PulseTracker  storage.PulseTracker  `inject:""`
 	PulseStorage  core.PulseStorage     `inject:""`
+	Schemas       core.SchemaRegistry   `inject:""`
 
 	cfg configuration.Exporter
 }
@@ -96,20 +97,9 @@ func (e *Exporter) Export(ctx context.Context, fromPulse core.PulseNumber, size
 			fromPulsePN, currentPulse.PulseNumber)
 	}
 
-	_, err = e.PulseTracker.GetPulse(ctx, fromPulsePN)
+	fromPulsePN, err = e.firstStoredPulse(ctx, fromPulsePN)
 	if err != nil {
-		tryPulse, err := e.PulseTracker.GetPulse(ctx, core.GenesisPulse.PulseNumber)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to fetch genesis pulse data")
-		}
-
-		for fromPulsePN > *tryPulse.Next {
-			tryPulse, err = e.PulseTracker.GetPulse(ctx, *tryPulse.Next)
-			if err != nil {
-				return nil, errors.Wrap(err, "failed to iterate through first pulses")
-			}
-		}
-		fromPulsePN = *tryPulse.Next
+		return nil, err
 	}
 
 	iterPulse := &fromPulsePN
@@ -149,6 +139,30 @@ func (e *Exporter) Export(ctx context.Context, fromPulse core.PulseNumber, size
 	return &result, nil
 }
 
+// firstStoredPulse returns first stored pulse which is not less than provided one.
+func (e *Exporter) firstStoredPulse(ctx context.Context, from core.PulseNumber) (core.PulseNumber, error) {
+	_, err := e.PulseTracker.GetPulse(ctx, from)
+	if err == nil {
+		return from, nil
+	}
+
+	tryPulse, err := e.PulseTracker.GetPulse(ctx, core.GenesisPulse.PulseNumber)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to fetch genesis pulse data")
+	}
+
+	for tryPulse.Next != nil && from > *tryPulse.Next {
+		tryPulse, err = e.PulseTracker.GetPulse(ctx, *tryPulse.Next)
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to iterate through first pulses")
+		}
+	}
+	if tryPulse.Next == nil {
+		return 0, errors.Errorf("there are no stored pulses after %v", from)
+	}
+	return *tryPulse.Next, nil
+}
+
 func (e *Exporter) exportPulse(ctx context.Context, jetID core.RecordID, pulse *core.Pulse) (*pulseData, error) {
 	records := recordsData{}
 	err := e.DB.IterateRecordsOnPulse(ctx, jetID, pulse.PulseNumber, func(id core.RecordID, rec record.Record) error {
@@ -182,9 +196,9 @@ func (e *Exporter) getPayload(ctx context.Context, jetID core.RecordID, rec reco
 		if r.GetMemory() == nil {
 			break
 		}
-		blob, err := e.ObjectStorage.GetBlob(ctx, jetID, r.GetMemory())
+		blob, err := storage.GetObjectMemory(ctx, e.ObjectStorage, jetID, r)
 		if err != nil {
-			return nil, errors.Wrapf(err, "getPayload failed to GetBlob (jet: %s)", jetID.DebugString())
+			return nil, errors.Wrapf(err, "getPayload failed to GetObjectMemory (jet: %s)", jetID.DebugString())
 		}
 		memory := payload{}
 		err = codec.NewDecoderBytes(blob, &codec.CborHandle{}).Decode(&memory)
@@ -208,19 +222,95 @@ func (e *Exporter) getPayload(ctx context.Context, jetID core.RecordID, rec reco
 			if err != nil {
 				return payload{"Payload": m, "Type": msg.Type().String()}, nil
 			}
-			return payload{"Payload": res, "Type": msg.Type().String()}, nil
+			p := payload{"Payload": res, "Type": msg.Type().String()}
+			if schema := e.methodSchema(ctx, jetID, m); schema != nil {
+				if args, err := schema.DecodeArguments(m.Method, m.Arguments); err == nil {
+					p["DecodedArguments"] = args
+				}
+			}
+			return p, nil
 		case *message.CallConstructor:
 			res, err := m.ToMap()
 			if err != nil {
 				return payload{"Payload": m, "Type": msg.Type().String()}, nil
 			}
-			return payload{"Payload": res, "Type": msg.Type().String()}, nil
+			p := payload{"Payload": res, "Type": msg.Type().String()}
+			if schema, err := e.Schemas.Schema(ctx, m.PrototypeRef); err == nil {
+				if args, err := schema.DecodeArguments(m.Method, m.Arguments); err == nil {
+					p["DecodedArguments"] = args
+				}
+			}
+			return p, nil
 		case *message.GenesisRequest:
 			return payload{"Payload": m, "Type": msg.Type().String()}, nil
 		}
 
 		return payload{"Payload": msg, "Type": msg.Type().String()}, nil
+	case *record.ResultRecord:
+		if r.Payload == nil {
+			break
+		}
+		call := e.requestCall(ctx, jetID, r.Request)
+		if call == nil {
+			break
+		}
+		schema := e.methodSchema(ctx, jetID, call)
+		if schema == nil {
+			break
+		}
+		results, err := schema.DecodeResults(call.Method, r.Payload)
+		if err != nil {
+			break
+		}
+		return payload{"DecodedResults": results}, nil
 	}
 
 	return nil, nil
 }
+
+// methodSchema returns schema of contract called by method call. Schemas are used to decode calls for explorers, so
+// nil is returned if schema isn't available for any reason.
+func (e *Exporter) methodSchema(
+	ctx context.Context, jetID core.RecordID, call *message.CallMethod,
+) *core.ContractSchema {
+	prototype := call.ProxyPrototype
+	if prototype.IsEmpty() {
+		idx, err := e.ObjectStorage.GetObjectIndex(ctx, jetID, call.ObjectRef.Record(), false)
+		if err != nil || idx.LatestState == nil {
+			return nil
+		}
+		rec, err := e.ObjectStorage.GetRecord(ctx, jetID, idx.LatestState)
+		if err != nil {
+			return nil
+		}
+		state, ok := rec.(record.ObjectState)
+		if !ok || state.GetImage() == nil {
+			return nil
+		}
+		prototype = *state.GetImage()
+	}
+
+	schema, err := e.Schemas.Schema(ctx, prototype)
+	if err != nil {
+		return nil
+	}
+	return schema
+}
+
+// requestCall returns method call registered as request record, nil is returned for other requests.
+func (e *Exporter) requestCall(ctx context.Context, jetID core.RecordID, request core.RecordRef) *message.CallMethod {
+	rec, err := e.ObjectStorage.GetRecord(ctx, jetID, request.Record())
+	if err != nil {
+		return nil
+	}
+	req, ok := rec.(record.Request)
+	if !ok || req.GetPayload() == nil {
+		return nil
+	}
+	parcel, err := message.DeserializeParcel(bytes.NewBuffer(req.GetPayload()))
+	if err != nil {
+		return nil
+	}
+	call, _ := parcel.Message().(*message.CallMethod)
+	return call
+}
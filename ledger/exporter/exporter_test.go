@@ -31,6 +31,7 @@ import (
 	"github.com/insolar/insolar/ledger/storage/record"
 	"github.com/insolar/insolar/ledger/storage/storagetest"
 	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
 	base58 "github.com/jbenet/go-base58"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -38,6 +39,19 @@ import (
 	"github.com/ugorji/go/codec"
 )
 
+// schemaRegistry is a pointer to struct, component manager injects pointers only.
+type schemaRegistry struct {
+	schemas map[core.RecordRef]*core.ContractSchema
+}
+
+func (r *schemaRegistry) Schema(ctx context.Context, prototype core.RecordRef) (*core.ContractSchema, error) {
+	schema, ok := r.schemas[prototype]
+	if !ok {
+		return nil, core.ErrNotFound
+	}
+	return schema, nil
+}
+
 type exporterSuite struct {
 	suite.Suite
 
@@ -51,6 +65,7 @@ type exporterSuite struct {
 	pulseStorage  *storage.PulseStorage
 
 	exporter *Exporter
+	schemas  *schemaRegistry
 	jetID    core.RecordID
 }
 
@@ -77,6 +92,7 @@ func (s *exporterSuite) BeforeTest(suiteName, testName string) {
 	s.jetStorage = storage.NewJetStorage()
 	s.pulseStorage = storage.NewPulseStorage()
 	s.exporter = NewExporter(configuration.Exporter{ExportLag: 0})
+	s.schemas = &schemaRegistry{schemas: map[core.RecordRef]*core.ContractSchema{}}
 
 	s.cm.Inject(
 		platformpolicy.NewPlatformCryptographyScheme(),
@@ -86,6 +102,7 @@ func (s *exporterSuite) BeforeTest(suiteName, testName string) {
 		s.jetStorage,
 		s.pulseStorage,
 		s.exporter,
+		s.schemas,
 	)
 
 	err := s.cm.Init(s.ctx)
@@ -139,7 +156,13 @@ func (s *exporterSuite) TestExporter_Export() {
 		},
 		IsDelegate: true,
 	})
-	msg := &message.CallConstructor{}
+	prototype := testutils.RandomRef()
+	s.schemas.schemas[prototype] = &core.ContractSchema{Methods: map[string]core.MethodSchema{
+		"New": {Arguments: []core.FieldSchema{{Name: "name", Type: "string"}, {Name: "amount", Type: "uint"}}},
+	}}
+	args, err := core.MarshalArgs("alice", uint(10))
+	require.NoError(s.T(), err)
+	msg := &message.CallConstructor{PrototypeRef: prototype, Method: "New", Arguments: args}
 	var parcel core.Parcel = &message.Parcel{Msg: msg}
 
 	msgHash := platformpolicy.NewPlatformCryptographyScheme().IntegrityHasher().Hash(message.ToBytes(msg))
@@ -183,6 +206,9 @@ func (s *exporterSuite) TestExporter_Export() {
 		assert.Equal(s.T(), "RequestRecord", request.Type)
 		assert.Equal(s.T(), msgHash, request.Data.(*record.RequestRecord).MessageHash)
 		assert.Equal(s.T(), core.TypeCallConstructor.String(), request.Payload["Type"])
+		decoded := request.Payload["DecodedArguments"].(map[string]interface{})
+		assert.Equal(s.T(), "alice", decoded["name"])
+		assert.EqualValues(s.T(), 10, decoded["amount"])
 	}
 
 	_, err = s.exporter.Export(s.ctx, 100000, 2)
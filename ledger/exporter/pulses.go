@@ -0,0 +1,76 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package exporter
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+)
+
+// GetPulses implements core.PulseHistory.
+func (e *Exporter) GetPulses(ctx context.Context, fromPulse, toPulse core.PulseNumber, size int) (*core.PulseHistoryResult, error) {
+	if size <= 0 {
+		return nil, errors.New("size must be positive")
+	}
+	if toPulse != 0 && toPulse < fromPulse {
+		return nil, errors.Errorf("invalid range: from-pulse[%v] > to-pulse[%v]", fromPulse, toPulse)
+	}
+
+	result := core.PulseHistoryResult{Pulses: []core.StoredPulse{}}
+	if fromPulse < core.GenesisPulse.PulseNumber {
+		fromPulse = core.GenesisPulse.PulseNumber
+	}
+	latest, err := e.PulseTracker.GetLatestPulse(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch latest pulse")
+	}
+	if fromPulse > latest.Pulse.PulseNumber {
+		return &result, nil
+	}
+	from, err := e.firstStoredPulse(ctx, fromPulse)
+	if err != nil {
+		return nil, err
+	}
+
+	iterPulse := &from
+	for iterPulse != nil {
+		if toPulse != 0 && *iterPulse > toPulse {
+			iterPulse = nil
+			break
+		}
+		if len(result.Pulses) == size {
+			break
+		}
+
+		pulse, err := e.PulseTracker.GetPulse(ctx, *iterPulse)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to fetch pulse data")
+		}
+		result.Pulses = append(result.Pulses, core.StoredPulse{
+			Pulse: pulse.Pulse,
+			Prev:  pulse.Prev,
+			Next:  pulse.Next,
+		})
+		iterPulse = pulse.Next
+	}
+	result.NextFrom = iterPulse
+
+	return &result, nil
+}
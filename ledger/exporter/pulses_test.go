@@ -0,0 +1,73 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package exporter
+
+import (
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+)
+
+func (s *exporterSuite) TestExporter_GetPulses() {
+	for i := 1; i <= 5; i++ {
+		err := s.pulseTracker.AddPulse(s.ctx, core.Pulse{
+			PulseNumber:    core.FirstPulseNumber + 10*core.PulseNumber(i),
+			PulseTimestamp: int64(i),
+		})
+		require.NoError(s.T(), err)
+	}
+	pn := func(i int) core.PulseNumber {
+		return core.FirstPulseNumber + 10*core.PulseNumber(i)
+	}
+
+	// first page starts from first stored pulse after requested one
+	result, err := s.exporter.GetPulses(s.ctx, pn(1)+1, 0, 2)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), result.Pulses, 2)
+	require.Equal(s.T(), pn(2), result.Pulses[0].Pulse.PulseNumber)
+	require.Equal(s.T(), pn(1), *result.Pulses[0].Prev)
+	require.Equal(s.T(), pn(3), *result.Pulses[0].Next)
+	require.Equal(s.T(), pn(3), result.Pulses[1].Pulse.PulseNumber)
+	require.Equal(s.T(), pn(4), *result.NextFrom)
+
+	// last page
+	result, err = s.exporter.GetPulses(s.ctx, *result.NextFrom, 0, 10)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), result.Pulses, 2)
+	require.Nil(s.T(), result.Pulses[1].Next)
+	require.Nil(s.T(), result.NextFrom)
+
+	// range is limited by to-pulse
+	result, err = s.exporter.GetPulses(s.ctx, pn(1), pn(2), 10)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), result.Pulses, 2)
+	require.Nil(s.T(), result.NextFrom)
+
+	// genesis pulse is first stored pulse
+	result, err = s.exporter.GetPulses(s.ctx, 0, 0, 1)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), core.GenesisPulse.PulseNumber, result.Pulses[0].Pulse.PulseNumber)
+
+	result, err = s.exporter.GetPulses(s.ctx, pn(6), 0, 10)
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), result.Pulses)
+
+	_, err = s.exporter.GetPulses(s.ctx, pn(2), pn(1), 10)
+	require.Error(s.T(), err)
+	_, err = s.exporter.GetPulses(s.ctx, pn(1), 0, 0)
+	require.Error(s.T(), err)
+}
@@ -0,0 +1,253 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package exporter
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/ledger/storage/jet"
+	"github.com/insolar/insolar/ledger/storage/record"
+)
+
+// Query is a parsed ledger query. Query is a space separated list of filters, records must match all of them:
+//
+//	type=request,result  record types, case and "Record" suffix are optional
+//	pulse=65537..65600   pulse or inclusive range of pulses, either bound of range may be omitted
+//	object=4K3NiGuqYGqK  prefix of object reference, only requests, results and activations tell their object
+//	jet=0110             bits of jet prefix, jet and its descendants match
+//	limit=100            max number of records
+//
+// Empty query matches all stored records.
+type Query struct {
+	Types  map[string]bool
+	From   core.PulseNumber
+	To     core.PulseNumber
+	Object string
+	Jet    string
+	Limit  int
+}
+
+// ParseQuery parses ledger query.
+func ParseQuery(query string) (*Query, error) {
+	q := &Query{}
+	for _, filter := range strings.Fields(query) {
+		parts := strings.SplitN(filter, "=", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			return nil, errors.Errorf("filter %q must be in key=value form", filter)
+		}
+		key, value := strings.ToLower(parts[0]), parts[1]
+		var err error
+		switch key {
+		case "type":
+			if q.Types == nil {
+				q.Types = map[string]bool{}
+			}
+			for _, t := range strings.Split(value, ",") {
+				q.Types[normalizeRecordType(t)] = true
+			}
+		case "pulse":
+			q.From, q.To, err = parsePulseRange(value)
+		case "object":
+			// record part of reference goes first, so full reference is a prefix of itself
+			q.Object = strings.SplitN(value, core.RecordRefIDSeparator, 2)[0]
+		case "jet":
+			if strings.Trim(value, "01") != "" {
+				err = errors.Errorf("jet prefix %q must consist of bits", value)
+			}
+			q.Jet = value
+		case "limit":
+			q.Limit, err = strconv.Atoi(value)
+			if err == nil && q.Limit <= 0 {
+				err = errors.New("limit must be positive")
+			}
+		default:
+			err = errors.Errorf("unknown filter %q", key)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid filter %q", filter)
+		}
+	}
+	return q, nil
+}
+
+func normalizeRecordType(t string) string {
+	return strings.TrimSuffix(strings.ToLower(t), "record")
+}
+
+// parsePulseRange parses pulse number or range of pulses, omitted bounds are returned as zero.
+func parsePulseRange(value string) (core.PulseNumber, core.PulseNumber, error) {
+	parse := func(s string) (core.PulseNumber, error) {
+		if s == "" {
+			return 0, nil
+		}
+		pn, err := strconv.ParseUint(s, 10, 32)
+		return core.PulseNumber(pn), err
+	}
+
+	bounds := strings.SplitN(value, "..", 2)
+	from, err := parse(bounds[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(bounds) == 1 {
+		return from, from, nil
+	}
+	to, err := parse(bounds[1])
+	if err != nil {
+		return 0, 0, err
+	}
+	if to != 0 && to < from {
+		return 0, 0, errors.Errorf("range ends at %d before start %d", to, from)
+	}
+	return from, to, nil
+}
+
+// matchJet checks if jet is under jet prefix of query.
+func (q *Query) matchJet(jetID core.RecordID) bool {
+	if q.Jet == "" {
+		return true
+	}
+	depth, prefix := jet.Jet(jetID)
+	if int(depth) < len(q.Jet) {
+		return false
+	}
+	for i, bit := range q.Jet {
+		set := prefix[i/8]&(0x80>>uint(i%8)) != 0
+		if set != (bit == '1') {
+			return false
+		}
+	}
+	return true
+}
+
+// matchRecord checks if record matches type and object filters of query.
+func (q *Query) matchRecord(rec record.Record) (bool, *core.RecordID) {
+	if q.Types != nil && !q.Types[normalizeRecordType(record.TypeFromRecord(rec).String())] {
+		return false, nil
+	}
+	object := recordObject(rec)
+	if q.Object == "" {
+		return true, object
+	}
+	return object != nil && strings.HasPrefix(object.String(), q.Object), object
+}
+
+// recordObject returns object record belongs to if record tells it.
+func recordObject(rec record.Record) *core.RecordID {
+	switch r := rec.(type) {
+	case *record.RequestRecord:
+		return &r.Object
+	case *record.ResultRecord:
+		return &r.Object
+	case *record.ObjectActivateRecord:
+		return r.Request.Record()
+	}
+	return nil
+}
+
+// errQueryLimit stops iteration over records when query limit is reached.
+var errQueryLimit = errors.New("query limit is reached")
+
+// QueryRecords calls fn for every stored record matching query in order of pulses. Like with export, records of
+// recent pulses aren't queried, because they may still be synced to heavy.
+func (e *Exporter) QueryRecords(ctx context.Context, query string, fn func(core.QueriedRecord) error) error {
+	q, err := ParseQuery(query)
+	if err != nil {
+		return err
+	}
+
+	jetIDs, err := e.JetStorage.GetJets(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch jets")
+	}
+	var jets []core.RecordID
+	for jetID := range jetIDs {
+		if q.matchJet(jetID) {
+			jets = append(jets, jetID)
+		}
+	}
+
+	currentPulse, err := e.PulseStorage.Current(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get current pulse data")
+	}
+	synced := currentPulse.PrevPulseNumber - core.PulseNumber(e.cfg.ExportLag)
+	from := q.From
+	if from < core.GenesisPulse.PulseNumber {
+		from = core.GenesisPulse.PulseNumber
+	}
+	if from >= synced {
+		return nil
+	}
+	from, err = e.firstStoredPulse(ctx, from)
+	if err != nil {
+		return err
+	}
+
+	matched := 0
+	for iterPulse := &from; iterPulse != nil; {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if *iterPulse >= synced || (q.To != 0 && *iterPulse > q.To) {
+			break
+		}
+		pulse, err := e.PulseTracker.GetPulse(ctx, *iterPulse)
+		if err != nil {
+			return errors.Wrap(err, "failed to fetch pulse data")
+		}
+
+		for _, jetID := range jets {
+			jetID := jetID
+			handler := func(id core.RecordID, rec record.Record) error {
+				ok, object := q.matchRecord(rec)
+				if !ok {
+					return nil
+				}
+				err := fn(core.QueriedRecord{
+					ID:     id,
+					Jet:    jetID,
+					Type:   record.TypeFromRecord(rec).String(),
+					Object: object,
+					Data:   rec,
+				})
+				if err != nil {
+					return err
+				}
+				matched++
+				if q.Limit > 0 && matched >= q.Limit {
+					return errQueryLimit
+				}
+				return nil
+			}
+			err := e.DB.IterateRecordsOnPulse(ctx, jetID, pulse.Pulse.PulseNumber, handler)
+			if err == errQueryLimit {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+		}
+		iterPulse = pulse.Next
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package exporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/ledger/storage/jet"
+	"github.com/insolar/insolar/ledger/storage/record"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestParseQuery(t *testing.T) {
+	q, err := ParseQuery("type=Request,resultRecord pulse=65537..65600 object=4K3N.11111 jet=01 limit=10")
+	require.NoError(t, err)
+	require.Equal(t, &Query{
+		Types:  map[string]bool{"request": true, "result": true},
+		From:   65537,
+		To:     65600,
+		Object: "4K3N",
+		Jet:    "01",
+		Limit:  10,
+	}, q)
+
+	q, err = ParseQuery("pulse=65537..")
+	require.NoError(t, err)
+	require.Equal(t, &Query{From: 65537}, q)
+	q, err = ParseQuery("pulse=65600")
+	require.NoError(t, err)
+	require.Equal(t, &Query{From: 65600, To: 65600}, q)
+	q, err = ParseQuery("  ")
+	require.NoError(t, err)
+	require.Equal(t, &Query{}, q)
+
+	for _, query := range []string{"type", "type=", "owner=me", "pulse=2..1", "pulse=x", "jet=012", "limit=0"} {
+		_, err := ParseQuery(query)
+		require.Error(t, err, query)
+	}
+}
+
+func TestQuery_matchJet(t *testing.T) {
+	q := &Query{Jet: "01"}
+	require.False(t, q.matchJet(*jet.NewID(0, nil)))
+	require.False(t, q.matchJet(*jet.NewID(1, []byte{0x00})))
+	require.True(t, q.matchJet(*jet.NewID(2, []byte{0x40})))
+	require.True(t, q.matchJet(*jet.NewID(3, []byte{0x60})))
+	require.False(t, q.matchJet(*jet.NewID(2, []byte{0xC0})))
+	require.True(t, (&Query{}).matchJet(*jet.NewID(0, nil)))
+}
+
+func (s *exporterSuite) TestExporter_QueryRecords() {
+	for i := 1; i <= 3; i++ {
+		err := s.pulseTracker.AddPulse(s.ctx, core.Pulse{
+			PulseNumber:     core.FirstPulseNumber + 10*core.PulseNumber(i),
+			PrevPulseNumber: core.FirstPulseNumber + 10*core.PulseNumber(i-1),
+		})
+		require.NoError(s.T(), err)
+	}
+
+	object := testutils.RandomID()
+	pulse := core.PulseNumber(core.FirstPulseNumber + 10)
+	_, err := s.objectStorage.SetRecord(s.ctx, s.jetID, pulse, &record.GenesisRecord{})
+	require.NoError(s.T(), err)
+	requestID, err := s.objectStorage.SetRecord(s.ctx, s.jetID, pulse, &record.RequestRecord{Object: object})
+	require.NoError(s.T(), err)
+	resultID, err := s.objectStorage.SetRecord(s.ctx, s.jetID, pulse, &record.ResultRecord{Object: object})
+	require.NoError(s.T(), err)
+	_, err = s.objectStorage.SetRecord(s.ctx, s.jetID, pulse, &record.ResultRecord{Object: testutils.RandomID()})
+	require.NoError(s.T(), err)
+
+	query := func(q string) []core.QueriedRecord {
+		var records []core.QueriedRecord
+		err := s.exporter.QueryRecords(s.ctx, q, func(rec core.QueriedRecord) error {
+			records = append(records, rec)
+			return nil
+		})
+		require.NoError(s.T(), err)
+		return records
+	}
+
+	// genesis record of first pulse is stored on storage init
+	require.Len(s.T(), query(""), 5)
+	require.Len(s.T(), query("type=result"), 2)
+	require.Len(s.T(), query("type=result limit=1"), 1)
+	require.Len(s.T(), query("type=genesis pulse=65547.."), 1)
+	require.Len(s.T(), query("pulse=65537"), 1)
+
+	records := query("object=" + object.String()[:20])
+	require.Len(s.T(), records, 2)
+	ids := []core.RecordID{records[0].ID, records[1].ID}
+	require.ElementsMatch(s.T(), []core.RecordID{*requestID, *resultID}, ids)
+	require.Equal(s.T(), &object, records[0].Object)
+	require.Equal(s.T(), s.jetID, records[0].Jet)
+
+	err = s.exporter.QueryRecords(s.ctx, "pulse=x", func(core.QueriedRecord) error { return nil })
+	require.Error(s.T(), err)
+}
+
+func TestQuery_matchRecord(t *testing.T) {
+	object := testutils.RandomID()
+	q := &Query{Types: map[string]bool{"objectactivate": true}, Object: object.String()}
+
+	ok, found := q.matchRecord(&record.ObjectActivateRecord{
+		SideEffectRecord: record.SideEffectRecord{Request: *core.NewRecordRef(core.RecordID{}, object)},
+	})
+	require.True(t, ok)
+	require.Equal(t, &object, found)
+
+	ok, _ = q.matchRecord(&record.ObjectAmendRecord{})
+	require.False(t, ok)
+	ok, _ = q.matchRecord(&record.ObjectActivateRecord{})
+	require.False(t, ok)
+}
@@ -26,17 +26,25 @@ import (
 	"github.com/insolar/insolar/core/reply"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/instrumentation/insmetrics"
+	"github.com/insolar/insolar/instrumentation/retry"
 	"github.com/insolar/insolar/ledger/storage"
 	"github.com/insolar/insolar/utils/backoff"
 	"github.com/pkg/errors"
 	"go.opencensus.io/stats"
 )
 
+// errPulseOutdated stops retries of synchronization of pulse which became outdated.
+var errPulseOutdated = errors.New("pulse is outdated")
+
 // Options contains heavy client configuration params.
 type Options struct {
 	SyncMessageLimit int
 	PulsesDeltaLimit int
 	BackoffConf      configuration.Backoff
+	// MaxCongestionDelay caps delay between payloads suggested by congested heavy.
+	MaxCongestionDelay time.Duration
+	// Concurrency is a max number of jets synchronized at once, non-positive value means unlimited.
+	Concurrency int
 }
 
 // JetClient heavy replication client. Replicates records for one jet.
@@ -49,6 +57,8 @@ type JetClient struct {
 	db             storage.DBContext
 
 	opts Options
+	// slots is shared by clients of pool to limit number of jets synchronized at once, nil if unlimited
+	slots chan struct{}
 
 	// life cycle control
 	//
@@ -59,10 +69,10 @@ type JetClient struct {
 	syncdone chan struct{}
 
 	// state:
-	jetID       core.RecordID
-	muPulses    sync.Mutex
-	leftPulses  []core.PulseNumber
-	syncbackoff *backoff.Backoff
+	jetID      core.RecordID
+	muPulses   sync.Mutex
+	leftPulses []core.PulseNumber
+	syncpolicy retry.Policy
 }
 
 // NewJetClient heavy replication client constructor.
@@ -86,7 +96,7 @@ func NewJetClient(
 		cleaner:        cleaner,
 		db:             db,
 		jetID:          jetID,
-		syncbackoff:    backoffFromConfig(opts.BackoffConf),
+		syncpolicy:     retry.Policy{Backoff: backoffFromConfig(opts.BackoffConf)},
 		signal:         make(chan struct{}, 1),
 		syncdone:       make(chan struct{}),
 		opts:           opts,
@@ -163,7 +173,6 @@ func (c *JetClient) nextPulseNumber() (core.PulseNumber, bool) {
 }
 
 func (c *JetClient) runOnce(ctx context.Context) {
-	// retrydelay = m.syncbackoff.ForAttempt(attempt)
 	c.startOnce.Do(func() {
 		// TODO: reset TraceID from context, or just don't use context?
 		// (TraceID not meaningful in async sync loop)
@@ -178,28 +187,20 @@ func (c *JetClient) syncloop(ctx context.Context) {
 	defer close(c.syncdone)
 
 	var (
-		syncPN     core.PulseNumber
-		hasNext    bool
-		retrydelay time.Duration
+		syncPN  core.PulseNumber
+		hasNext bool
 	)
 
 	finishpulse := func() {
 		_ = c.unshiftPulse(ctx)
-		c.syncbackoff.Reset()
-		retrydelay = 0
 	}
 
 	for {
-		select {
-		case <-time.After(retrydelay):
-			// for first try delay should be zero
-		case <-ctx.Done():
-			if c.pulsesLeft() == 0 {
-				// got cancel signal and have nothing to do
-				return
-			}
-			// client in canceled state signal but has smth to do
+		if ctx.Err() != nil && c.pulsesLeft() == 0 {
+			// got cancel signal and have nothing to do
+			return
 		}
+		// client in canceled state signal but has smth to do
 
 		for {
 			// if we have pulses to sync, process it
@@ -223,31 +224,45 @@ func (c *JetClient) syncloop(ctx context.Context) {
 			continue
 		}
 
+		if !c.acquireSlot(ctx) {
+			// stop is called while waiting, unsynced pulse is left to the next start
+			return
+		}
 		inslog.Infof("start synchronization to heavy for pulse %v", syncPN)
 
-		shouldretry := false
-		isretry := c.syncbackoff.Attempt() > 0
+		// retries aren't interrupted by stop, it waits until pulses left are synchronized;
+		// ctx of loop carries nothing but cancel, so background one is used instead
+		syncerr := retry.Do(context.Background(), "heavy.sync", c.syncpolicy, func(_ context.Context, attempt int) error {
+			if attempt > 0 && isPulseNumberOutdated(ctx, c.pulseTracker, c.pulseStorage, syncPN, c.opts.PulsesDeltaLimit) {
+				return errPulseOutdated
+			}
 
-		syncerr := c.HeavySync(ctx, syncPN, isretry)
-		if syncerr != nil {
-			if heavyerr, ok := syncerr.(*reply.HeavyError); ok {
+			err := c.HeavySync(ctx, syncPN, attempt > 0)
+			if err == nil {
+				return nil
+			}
+			shouldretry := false
+			if heavyerr, ok := err.(*reply.HeavyError); ok {
 				shouldretry = heavyerr.IsRetryable()
 			}
-
-			syncerr = errors.Wrap(syncerr, "HeavySync failed")
 			inslog.Errorf("%v (on attempt=%v, shouldretry=%v)",
-				syncerr.Error(), c.syncbackoff.Attempt(), shouldretry)
-
+				errors.Wrap(err, "HeavySync failed").Error(), attempt, shouldretry)
 			if shouldretry {
-				retrydelay = c.syncbackoff.Duration()
-				continue
+				return retry.Retryable(err)
 			}
-			// TODO: write some info to dust - 14.Dec.2018 @nordicdyno
-		} else {
+			return err
+		})
+		c.releaseSlot()
+		switch {
+		case syncerr == nil:
 			ctx = insmetrics.InsertTag(ctx, tagJet, c.jetID.DebugString())
 			stats.Record(ctx,
 				statSyncedPulsesCount.M(1),
 			)
+		case syncerr == errPulseOutdated:
+			inslog.Infof("pulse %v on jet %v is outdated, skip it", syncPN, c.jetID)
+		default:
+			// TODO: write some info to dust - 14.Dec.2018 @nordicdyno
 		}
 
 		finishpulse()
@@ -255,6 +270,25 @@ func (c *JetClient) syncloop(ctx context.Context) {
 
 }
 
+// acquireSlot waits for turn of client to synchronize, false is returned if ctx is canceled while waiting.
+func (c *JetClient) acquireSlot(ctx context.Context) bool {
+	if c.slots == nil {
+		return true
+	}
+	select {
+	case c.slots <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (c *JetClient) releaseSlot() {
+	if c.slots != nil {
+		<-c.slots
+	}
+}
+
 // Stop stops heavy client replication
 func (c *JetClient) Stop(ctx context.Context) {
 	// cancel should be set if client has started
@@ -267,8 +301,8 @@ func (c *JetClient) Stop(ctx context.Context) {
 	}
 }
 
-func backoffFromConfig(bconf configuration.Backoff) *backoff.Backoff {
-	return &backoff.Backoff{
+func backoffFromConfig(bconf configuration.Backoff) backoff.Backoff {
+	return backoff.Backoff{
 		Jitter: bconf.Jitter,
 		Min:    bconf.Min,
 		Max:    bconf.Max,
@@ -40,6 +40,8 @@ type Pool struct {
 	db             storage.DBContext
 
 	clientDefaults Options
+	// slots limits number of jets synchronized at once, nil if unlimited
+	slots chan struct{}
 
 	sync.Mutex
 	clients map[core.RecordID]*JetClient
@@ -57,7 +59,7 @@ func NewPool(
 	db storage.DBContext,
 	clientDefaults Options,
 ) *Pool {
-	return &Pool{
+	pool := &Pool{
 		bus:            bus,
 		pulseStorage:   pulseStorage,
 		pulseTracker:   tracker,
@@ -67,6 +69,10 @@ func NewPool(
 		db:             db,
 		clients:        map[core.RecordID]*JetClient{},
 	}
+	if clientDefaults.Concurrency > 0 {
+		pool.slots = make(chan struct{}, clientDefaults.Concurrency)
+	}
+	return pool
 }
 
 // Stop send stop signals to all managed heavy clients and waits when until all of them will stop.
@@ -108,6 +114,7 @@ func (scp *Pool) AddPulsesToSyncClient(
 			jetID,
 			scp.clientDefaults,
 		)
+		client.slots = scp.slots
 
 		scp.clients[jetID] = client
 	}
@@ -136,6 +143,19 @@ func (scp *Pool) AllClients(ctx context.Context) []*JetClient {
 	return clients
 }
 
+// ReplicationLag returns the largest number of pulses waiting for sync among jets.
+func (scp *Pool) ReplicationLag() int {
+	scp.Lock()
+	defer scp.Unlock()
+	lag := 0
+	for _, c := range scp.clients {
+		if left := c.pulsesLeft(); left > lag {
+			lag = left
+		}
+	}
+	return lag
+}
+
 // LightCleanup starts async cleanup on all heavy synchronization clients (per jet cleanup).
 //
 // Waits until all cleanup will done and mesaures time.
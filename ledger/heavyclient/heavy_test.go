@@ -34,6 +34,7 @@ import (
 	"github.com/insolar/insolar/core/reply"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/ledger/artifactmanager"
+	"github.com/insolar/insolar/ledger/jetplanner"
 	"github.com/insolar/insolar/ledger/pulsemanager"
 	"github.com/insolar/insolar/ledger/recentstorage"
 	"github.com/insolar/insolar/ledger/storage"
@@ -64,6 +65,7 @@ type heavySuite struct {
 	objectStorage  storage.ObjectStorage
 	dropStorage    storage.DropStorage
 	storageCleaner storage.Cleaner
+	jetPlanner     jetplanner.Planner
 }
 
 func NewHeavySuite() *heavySuite {
@@ -91,6 +93,7 @@ func (s *heavySuite) BeforeTest(suiteName, testName string) {
 	s.objectStorage = storage.NewObjectStorage()
 	s.dropStorage = storage.NewDropStorage(10)
 	s.storageCleaner = storage.NewCleaner()
+	s.jetPlanner = jetplanner.NewPlanner(configuration.NewLedger())
 
 	s.cm.Inject(
 		platformpolicy.NewPlatformCryptographyScheme(),
@@ -102,6 +105,7 @@ func (s *heavySuite) BeforeTest(suiteName, testName string) {
 		s.objectStorage,
 		s.dropStorage,
 		s.storageCleaner,
+		s.jetPlanner,
 	)
 
 	err := s.cm.Init(s.ctx)
@@ -265,6 +269,7 @@ func sendToHeavy(s *heavySuite, withretry bool) {
 	pm.StorageCleaner = s.storageCleaner
 	pm.ObjectStorage = s.objectStorage
 	pm.DropStorage = s.dropStorage
+	pm.JetPlanner = s.jetPlanner
 
 	ps := storage.NewPulseStorage()
 	ps.PulseTracker = s.pulseTracker
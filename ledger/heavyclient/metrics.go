@@ -34,11 +34,13 @@ var (
 
 	statSyncedPulsesCount = stats.Int64("heavyclient/synced/count", "How many pulses unsynced", stats.UnitDimensionless)
 
+	statCongestionWait = stats.Int64("heavyclient/congestion/wait", "Pause between payloads asked by congested heavy", stats.UnitMilliseconds)
+
 	statCleanLatencyDB = stats.Int64("lightcleanup/latency/db", "Light storage db cleanup time in milliseconds", stats.UnitMilliseconds)
 )
 
 func init() {
-	err := view.Register(
+	err := insmetrics.Register(
 		&view.View{
 			Name:        statUnsyncedPulsesCount.Name(),
 			Description: statUnsyncedPulsesCount.Description(),
@@ -60,6 +62,12 @@ func init() {
 			Aggregation: view.Count(),
 			TagKeys:     []tag.Key{tagJet},
 		},
+		&view.View{
+			Name:        statCongestionWait.Name(),
+			Description: statCongestionWait.Description(),
+			Measure:     statCongestionWait,
+			Aggregation: view.Distribution(100, 500, 1000, 2000, 5000),
+		},
 
 		&view.View{
 			Name:        statCleanLatencyDB.Name(),
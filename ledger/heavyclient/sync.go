@@ -18,31 +18,39 @@ package heavyclient
 
 import (
 	"context"
+	"time"
 
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/core/message"
 	"github.com/insolar/insolar/core/reply"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/ledger/storage"
+	"go.opencensus.io/stats"
 )
 
 func messageToHeavy(ctx context.Context, bus core.MessageBus, msg core.Message) error {
+	_, err := replyFromHeavy(ctx, bus, msg)
+	return err
+}
+
+func replyFromHeavy(ctx context.Context, bus core.MessageBus, msg core.Message) (core.Reply, error) {
 	busreply, buserr := bus.Send(ctx, msg, nil)
 	if buserr != nil {
-		return buserr
+		return nil, buserr
 	}
 	if busreply != nil {
 		herr, ok := busreply.(*reply.HeavyError)
 		if ok {
-			return herr
+			return nil, herr
 		}
 	}
-	return nil
+	return busreply, nil
 }
 
 // HeavySync syncs records from light to heavy node, returns last synced pulse and error.
 //
-// It syncs records from start to end of provided pulse numbers.
+// It syncs records from start to end of provided pulse numbers. Interrupted sync of pulse is resumed
+// from payloads heavy hasn't stored yet.
 func (c *JetClient) HeavySync(
 	ctx context.Context,
 	pn core.PulseNumber,
@@ -68,15 +76,24 @@ func (c *JetClient) HeavySync(
 	signalMsg := &message.HeavyStartStop{
 		JetID:    jetID,
 		PulseNum: pn,
+		Resume:   true,
 	}
-	if err := messageToHeavy(ctx, c.bus, signalMsg); err != nil {
+	startReply, err := replyFromHeavy(ctx, c.bus, signalMsg)
+	if err != nil {
 		inslog.Error("synchronize: start failed")
 		return err
 	}
+	var stored int
+	if resumed, ok := startReply.(*reply.HeavySyncResumed); ok {
+		stored = resumed.Stored
+	}
+	if stored > 0 {
+		inslog.Infof("synchronize: resume after %v stored payloads", stored)
+	}
 
 	replicator := storage.NewReplicaIter(
 		ctx, c.db, jetID, pn, pn+1, c.opts.SyncMessageLimit)
-	for {
+	for payload := 0; ; payload++ {
 		recs, err := replicator.NextRecords()
 		if err == storage.ErrReplicatorDone {
 			break
@@ -84,15 +101,24 @@ func (c *JetClient) HeavySync(
 		if err != nil {
 			panic(err)
 		}
+		if payload < stored {
+			continue
+		}
 		msg := &message.HeavyPayload{
 			JetID:    jetID,
 			PulseNum: pn,
 			Records:  recs,
 		}
-		if err := messageToHeavy(ctx, c.bus, msg); err != nil {
+		payloadReply, err := replyFromHeavy(ctx, c.bus, msg)
+		if err != nil {
 			inslog.Error("synchronize: payload failed")
 			return err
 		}
+		if ack, ok := payloadReply.(*reply.HeavyAck); ok {
+			if err := c.waitCongestion(ctx, ack.Delay); err != nil {
+				return err
+			}
+		}
 	}
 
 	signalMsg.Finished = true
@@ -103,3 +129,22 @@ func (c *JetClient) HeavySync(
 
 	return nil
 }
+
+// waitCongestion pauses sync for delay suggested by congested heavy, but no longer than configured maximum.
+func (c *JetClient) waitCongestion(ctx context.Context, delay time.Duration) error {
+	if delay > c.opts.MaxCongestionDelay {
+		delay = c.opts.MaxCongestionDelay
+	}
+	if delay <= 0 {
+		return nil
+	}
+	inslogger.FromContext(ctx).Debugf("synchronize: heavy is congested, wait %v", delay)
+	stats.Record(ctx, statCongestionWait.M(delay.Nanoseconds()/int64(time.Millisecond)))
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(delay):
+		return nil
+	}
+}
@@ -0,0 +1,64 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package heavyclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJetClient_WaitCongestion(t *testing.T) {
+	ctx := context.Background()
+	c := &JetClient{opts: Options{MaxCongestionDelay: 10 * time.Millisecond}}
+
+	require.NoError(t, c.waitCongestion(ctx, 0))
+
+	start := time.Now()
+	require.NoError(t, c.waitCongestion(ctx, time.Hour), "delay is capped by configured maximum")
+	require.True(t, time.Since(start) >= 10*time.Millisecond)
+
+	c.opts.MaxCongestionDelay = time.Hour
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	require.Equal(t, context.Canceled, c.waitCongestion(cancelled, time.Minute))
+
+	c.opts.MaxCongestionDelay = 0
+	require.NoError(t, c.waitCongestion(cancelled, time.Minute), "suggestions are ignored when disabled")
+}
+
+func TestJetClient_AcquireSlot(t *testing.T) {
+	ctx := context.Background()
+	pool := NewPool(nil, nil, nil, nil, nil, nil, Options{Concurrency: 1})
+	first := &JetClient{slots: pool.slots}
+	second := &JetClient{slots: pool.slots}
+
+	require.True(t, first.acquireSlot(ctx))
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	require.False(t, second.acquireSlot(cancelled), "jets over concurrency limit wait for their turn")
+
+	first.releaseSlot()
+	require.True(t, second.acquireSlot(ctx))
+	second.releaseSlot()
+
+	unlimited := &JetClient{}
+	require.True(t, unlimited.acquireSlot(cancelled))
+	unlimited.releaseSlot()
+}
@@ -17,13 +17,18 @@
 package heavyserver
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"sort"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.opencensus.io/stats"
 
+	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/core/reply"
 	"github.com/insolar/insolar/instrumentation/inslogger"
@@ -44,27 +49,43 @@ func errSyncInProgress(jetID core.RecordID, pn core.PulseNumber) *reply.HeavyErr
 // in testnet we start with only one jet
 type syncstate struct {
 	sync.Mutex
+	jetID  core.RecordID
 	lastok core.PulseNumber
 	// insyncend core.PulseNumber
 	syncpulse *core.PulseNumber
 	insync    bool
+	// stored is number of payloads stored for syncpulse, interrupted sync resumes after them
+	stored int
+	// nodes are last pulses confirmed per light material node
+	nodes map[core.RecordRef]core.PulseNumber
 }
 
 type jetprefix [core.JetPrefixSize]byte
 
+// storeLatencySmoothing is a weight of the last store duration in moving average of store latency.
+const storeLatencySmoothing = 0.2
+
 // Sync provides methods for syncing records to heavy storage.
 type Sync struct {
+	// storing is number of payloads being stored at the moment, accessed atomically
+	storing int64
+	// storeLatency is moving average of store duration in nanoseconds, accessed atomically
+	storeLatency int64
+
 	ReplicaStorage storage.ReplicaStorage `inject:""`
 	DBContext      storage.DBContext
 
+	congestion configuration.HeavyCongestion
+
 	sync.Mutex
 	jetSyncStates map[jetprefix]*syncstate
 }
 
 // NewSync creates new Sync instance.
-func NewSync(db storage.DBContext) *Sync {
+func NewSync(db storage.DBContext, congestion configuration.HeavyCongestion) *Sync {
 	return &Sync{
 		DBContext:     db,
+		congestion:    congestion,
 		jetSyncStates: map[jetprefix]*syncstate{},
 	}
 }
@@ -103,7 +124,7 @@ func (s *Sync) getJetSyncState(ctx context.Context, jetID core.RecordID) *syncst
 	s.Lock()
 	jetState, ok := s.jetSyncStates[jp]
 	if !ok {
-		jetState = &syncstate{}
+		jetState = &syncstate{jetID: jetID, nodes: map[core.RecordRef]core.PulseNumber{}}
 		s.jetSyncStates[jp] = jetState
 	}
 	s.Unlock()
@@ -116,6 +137,33 @@ func (s *Sync) Start(ctx context.Context, jetID core.RecordID, pn core.PulseNumb
 	jetState.Lock()
 	defer jetState.Unlock()
 
+	return s.start(ctx, jetID, jetState, pn)
+}
+
+// Resume continues interrupted sync of provided pulse or starts it if pulse isn't in sync.
+// Returns number of payloads already stored for pulse, sender should skip them.
+func (s *Sync) Resume(ctx context.Context, jetID core.RecordID, pn core.PulseNumber) (int, error) {
+	jetState := s.getJetSyncState(ctx, jetID)
+	jetState.Lock()
+	defer jetState.Unlock()
+
+	if jetState.syncpulse != nil && *jetState.syncpulse == pn {
+		if jetState.insync {
+			return 0, errSyncInProgress(jetID, pn)
+		}
+		inslogger.FromContext(ctx).Infof(
+			"heavyserver: resume sync: jetID=%v, pulse=%v, stored=%v", jetID, pn, jetState.stored)
+		return jetState.stored, nil
+	}
+
+	if err := s.start(ctx, jetID, jetState, pn); err != nil {
+		return 0, err
+	}
+	return 0, nil
+}
+
+// start starts sync of pulse, jetState should be locked.
+func (s *Sync) start(ctx context.Context, jetID core.RecordID, jetState *syncstate, pn core.PulseNumber) error {
 	if jetState.syncpulse != nil {
 		if *jetState.syncpulse >= pn {
 			return fmt.Errorf("heavyserver: pulse %v is not greater than current in-sync pulse %v (jet=%v)",
@@ -133,6 +181,7 @@ func (s *Sync) Start(ctx context.Context, jetID core.RecordID, pn core.PulseNumb
 	}
 
 	jetState.syncpulse = &pn
+	jetState.stored = 0
 	return nil
 }
 
@@ -168,10 +217,17 @@ func (s *Sync) Store(ctx context.Context, jetID core.RecordID, pn core.PulseNumb
 		jetState.Unlock()
 	}()
 	// TODO: check jet in keys?
+	atomic.AddInt64(&s.storing, 1)
+	start := time.Now()
 	err = s.DBContext.StoreKeyValues(ctx, kvs)
+	s.observeStore(time.Since(start))
+	atomic.AddInt64(&s.storing, -1)
 	if err != nil {
 		return errors.Wrapf(err, "heavyserver: store failed")
 	}
+	jetState.Lock()
+	jetState.stored++
+	jetState.Unlock()
 
 	// heavy stats
 	recordsCount := int64(len(kvs))
@@ -188,10 +244,10 @@ func (s *Sync) Store(ctx context.Context, jetID core.RecordID, pn core.PulseNumb
 	return nil
 }
 
-// Stop successfully stops replication for specified pulse.
+// Stop successfully stops replication for specified pulse synced by light material node.
 //
 // TODO: call Stop if range sync too long
-func (s *Sync) Stop(ctx context.Context, jetID core.RecordID, pn core.PulseNumber) error {
+func (s *Sync) Stop(ctx context.Context, jetID core.RecordID, pn core.PulseNumber, node core.RecordRef) error {
 	jetState := s.getJetSyncState(ctx, jetID)
 	jetState.Lock()
 	defer jetState.Unlock()
@@ -208,6 +264,7 @@ func (s *Sync) Stop(ctx context.Context, jetID core.RecordID, pn core.PulseNumbe
 		return errSyncInProgress(jetID, pn)
 	}
 	jetState.syncpulse = nil
+	jetState.stored = 0
 
 	err := s.ReplicaStorage.SetHeavySyncedPulse(ctx, jetID, pn)
 	if err != nil {
@@ -215,6 +272,7 @@ func (s *Sync) Stop(ctx context.Context, jetID core.RecordID, pn core.PulseNumbe
 	}
 	inslogger.FromContext(ctx).Debugf("heavyserver: Fin sync: jetID=%v, pulse=%v", jetID, pn)
 	jetState.lastok = pn
+	jetState.nodes[node] = pn
 	return nil
 }
 
@@ -228,7 +286,94 @@ func (s *Sync) Reset(ctx context.Context, jetID core.RecordID, pn core.PulseNumb
 		return errSyncInProgress(jetID, pn)
 	}
 
+	// payloads of the same pulse are already stored, keep them to resume sync
+	if jetState.syncpulse != nil && *jetState.syncpulse == pn {
+		inslogger.FromContext(ctx).Debugf("heavyserver: Keep sync for resume: jetID=%v, pulse=%v", jetID, pn)
+		return nil
+	}
+
 	inslogger.FromContext(ctx).Debugf("heavyserver: Reset sync: jetID=%v, pulse=%v", jetID, pn)
 	jetState.syncpulse = nil
+	jetState.stored = 0
 	return nil
 }
+
+// observeStore takes into account duration of storing payload.
+func (s *Sync) observeStore(d time.Duration) {
+	for {
+		avg := atomic.LoadInt64(&s.storeLatency)
+		next := int64(d)
+		if avg != 0 {
+			next = avg + int64(storeLatencySmoothing*float64(int64(d)-avg))
+		}
+		if atomic.CompareAndSwapInt64(&s.storeLatency, avg, next) {
+			return
+		}
+	}
+}
+
+// Congestion returns number of payloads being stored and delay light nodes should make between payloads.
+//
+// Delay grows by configured step for every payload in excess of configured queue depth. Stores slower than
+// configured latency (e.g. during database compaction) add average store duration to delay, so light
+// nodes give heavy time to catch up instead of timing out and retrying.
+func (s *Sync) Congestion(ctx context.Context) core.HeavyCongestion {
+	depth := int(atomic.LoadInt64(&s.storing))
+	latency := time.Duration(atomic.LoadInt64(&s.storeLatency))
+
+	var delay time.Duration
+	if s.congestion.QueueDepth > 0 && depth > s.congestion.QueueDepth {
+		delay += time.Duration(depth-s.congestion.QueueDepth) * s.congestion.DelayStep
+	}
+	if s.congestion.StoreLatency > 0 && latency > s.congestion.StoreLatency {
+		delay += latency
+	}
+	if s.congestion.MaxDelay > 0 && delay > s.congestion.MaxDelay {
+		delay = s.congestion.MaxDelay
+	}
+	if delay > 0 {
+		stats.Record(ctx, statCongestionDelay.M(delay.Nanoseconds()/int64(time.Millisecond)))
+	}
+	return core.HeavyCongestion{QueueDepth: depth, Delay: delay}
+}
+
+// Progress returns replication progress of jets synced since node start.
+func (s *Sync) Progress(ctx context.Context) []core.HeavySyncProgress {
+	s.Lock()
+	states := make([]*syncstate, 0, len(s.jetSyncStates))
+	for _, jetState := range s.jetSyncStates {
+		states = append(states, jetState)
+	}
+	s.Unlock()
+
+	progress := make([]core.HeavySyncProgress, 0, len(states))
+	for _, jetState := range states {
+		jetState.Lock()
+		p := core.HeavySyncProgress{
+			Jet:    jetState.jetID,
+			Synced: jetState.lastok,
+			Stored: jetState.stored,
+			Nodes:  make(map[core.RecordRef]core.PulseNumber, len(jetState.nodes)),
+		}
+		if jetState.syncpulse != nil {
+			p.InSync = *jetState.syncpulse
+		}
+		for node, pn := range jetState.nodes {
+			p.Nodes[node] = pn
+		}
+		jetState.Unlock()
+
+		if p.Synced == 0 {
+			synced, err := s.ReplicaStorage.GetHeavySyncedPulse(ctx, p.Jet)
+			if err != nil {
+				inslogger.FromContext(ctx).Error(errors.Wrap(err, "heavyserver: GetHeavySyncedPulse failed"))
+			}
+			p.Synced = synced
+		}
+		progress = append(progress, p)
+	}
+	sort.Slice(progress, func(i, j int) bool {
+		return bytes.Compare(progress[i].Jet[:], progress[j].Jet[:]) < 0
+	})
+	return progress
+}
@@ -19,8 +19,10 @@ package heavyserver
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/insolar/insolar/component"
+	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/ledger/storage"
@@ -102,8 +104,9 @@ func (s *heavysyncSuite) TestHeavy_SyncBasic() {
 
 	// TODO: call every case in subtest
 	jetID := testutils.RandomJet()
+	node := testutils.RandomRef()
 
-	sync := NewSync(s.db)
+	sync := NewSync(s.db, configuration.NewLedger().HeavyCongestion)
 	sync.ReplicaStorage = s.replicaStorage
 	err = sync.Start(s.ctx, jetID, pnum)
 	require.Error(s.T(), err, "start with zero pulse")
@@ -111,7 +114,7 @@ func (s *heavysyncSuite) TestHeavy_SyncBasic() {
 	err = sync.Store(s.ctx, jetID, pnum, kvalues)
 	require.Error(s.T(), err, "store values on non started sync")
 
-	err = sync.Stop(s.ctx, jetID, pnum)
+	err = sync.Stop(s.ctx, jetID, pnum, node)
 	require.Error(s.T(), err, "stop on non started sync")
 
 	pnum = 5
@@ -134,14 +137,14 @@ func (s *heavysyncSuite) TestHeavy_SyncBasic() {
 	require.Error(s.T(), err, "start next pulse sync when previous not end")
 
 	// stop previous
-	err = sync.Stop(s.ctx, jetID, pnum)
+	err = sync.Stop(s.ctx, jetID, pnum, node)
 	require.NoError(s.T(), err)
 
 	// start sparse next
 	pnumNextPlus := pnumNext + 1
 	err = sync.Start(s.ctx, jetID, pnumNextPlus)
 	require.NoError(s.T(), err, "sparse sync is ok")
-	err = sync.Stop(s.ctx, jetID, pnumNextPlus)
+	err = sync.Stop(s.ctx, jetID, pnumNextPlus, node)
 	require.NoError(s.T(), err)
 
 	// prepare pulse helper
@@ -161,24 +164,24 @@ func (s *heavysyncSuite) TestHeavy_SyncBasic() {
 	err = sync.Store(s.ctx, jetID, pnumNextPlus, kvalues)
 	require.Error(s.T(), err, "store from other pulse at the same jet")
 
-	err = sync.Stop(s.ctx, jetID, pnumNextPlus)
+	err = sync.Stop(s.ctx, jetID, pnumNextPlus, node)
 	require.Error(s.T(), err, "stop from other pulse at the same jet")
 
 	err = sync.Store(s.ctx, jetID, pnumNext, kvalues)
 	require.NoError(s.T(), err, "store on current range")
 	err = sync.Store(s.ctx, jetID, pnumNext, kvalues)
 	require.NoError(s.T(), err, "store the same on current range")
-	err = sync.Stop(s.ctx, jetID, pnumNext)
+	err = sync.Stop(s.ctx, jetID, pnumNext, node)
 	require.NoError(s.T(), err, "stop current range")
 
 	preparepulse(pnumNextPlus) // should set corret next for previous pulse
-	sync = NewSync(s.db)
+	sync = NewSync(s.db, configuration.NewLedger().HeavyCongestion)
 	sync.ReplicaStorage = s.replicaStorage
 	err = sync.Start(s.ctx, jetID, pnumNextPlus)
 	require.NoError(s.T(), err, "start next+1 range on new sync instance (checkpoint check)")
 	err = sync.Store(s.ctx, jetID, pnumNextPlus, kvalues)
 	require.NoError(s.T(), err, "store next+1 pulse")
-	err = sync.Stop(s.ctx, jetID, pnumNextPlus)
+	err = sync.Stop(s.ctx, jetID, pnumNextPlus, node)
 	require.NoError(s.T(), err, "stop next+1 range on new sync instance")
 }
 
@@ -198,8 +201,9 @@ func (s *heavysyncSuite) TestHeavy_SyncByJet() {
 	// flip first bit of last byte jetID2 for different prefixes
 	lastidx := len(jetID1) - 1
 	jetID2[lastidx] ^= 0xFF
+	node := testutils.RandomRef()
 
-	sync := NewSync(s.db)
+	sync := NewSync(s.db, configuration.NewLedger().HeavyCongestion)
 	sync.ReplicaStorage = s.replicaStorage
 
 	pnum = core.FirstPulseNumber + 1
@@ -223,8 +227,8 @@ func (s *heavysyncSuite) TestHeavy_SyncByJet() {
 	require.NoError(s.T(), err, "store jet1 pulse")
 
 	// stop previous
-	err = sync.Stop(s.ctx, jetID1, pnum)
-	err = sync.Stop(s.ctx, jetID2, pnum)
+	err = sync.Stop(s.ctx, jetID1, pnum, node)
+	err = sync.Stop(s.ctx, jetID2, pnum, node)
 	require.NoError(s.T(), err)
 }
 
@@ -235,8 +239,9 @@ func (s *heavysyncSuite) TestHeavy_SyncLockOnPrefix() {
 	// different jets with same prefix
 	jetID1 := *jet.NewID(1, []byte{})
 	jetID2 := *jet.NewID(2, []byte{})
+	node := testutils.RandomRef()
 
-	sync := NewSync(s.db)
+	sync := NewSync(s.db, configuration.NewLedger().HeavyCongestion)
 	sync.ReplicaStorage = s.replicaStorage
 
 	pnum = core.FirstPulseNumber + 2
@@ -251,15 +256,92 @@ func (s *heavysyncSuite) TestHeavy_SyncLockOnPrefix() {
 	require.Error(s.T(), err, "should not start on same prefix")
 
 	// stop previous sync (only prefix matters)
-	err = sync.Stop(s.ctx, jetID2, pnum)
+	err = sync.Stop(s.ctx, jetID2, pnum, node)
 	require.NoError(s.T(), err)
 
 	err = sync.Start(s.ctx, jetID2, pnum+1)
 	require.NoError(s.T(), err, "should start after released lock")
 }
 
+func (s *heavysyncSuite) TestHeavy_SyncResume() {
+	kvalues := []core.KV{
+		{K: []byte("3_31"), V: []byte("3_32")},
+	}
+	jetID := testutils.RandomJet()
+	node := testutils.RandomRef()
+
+	sync := NewSync(s.db, configuration.NewLedger().HeavyCongestion)
+	sync.ReplicaStorage = s.replicaStorage
+
+	pnum := core.PulseNumber(core.FirstPulseNumber + 1)
+	preparepulse(s, pnum)
+	preparepulse(s, pnum+1)
+
+	stored, err := sync.Resume(s.ctx, jetID, pnum)
+	require.NoError(s.T(), err, "resume starts sync if pulse isn't in sync")
+	require.Equal(s.T(), 0, stored)
+
+	err = sync.Store(s.ctx, jetID, pnum, kvalues)
+	require.NoError(s.T(), err)
+	err = sync.Store(s.ctx, jetID, pnum, kvalues)
+	require.NoError(s.T(), err)
+
+	// interrupted sender retries
+	err = sync.Reset(s.ctx, jetID, pnum)
+	require.NoError(s.T(), err)
+	stored, err = sync.Resume(s.ctx, jetID, pnum)
+	require.NoError(s.T(), err, "resume interrupted sync")
+	require.Equal(s.T(), 2, stored)
+
+	progress := sync.Progress(s.ctx)
+	require.Len(s.T(), progress, 1)
+	require.Equal(s.T(), jetID, progress[0].Jet)
+	require.Equal(s.T(), pnum, progress[0].InSync)
+	require.Equal(s.T(), 2, progress[0].Stored)
+
+	err = sync.Stop(s.ctx, jetID, pnum, node)
+	require.NoError(s.T(), err)
+
+	progress = sync.Progress(s.ctx)
+	require.Equal(s.T(), []core.HeavySyncProgress{{
+		Jet:    jetID,
+		Synced: pnum,
+		Nodes:  map[core.RecordRef]core.PulseNumber{node: pnum},
+	}}, progress)
+
+	stored, err = sync.Resume(s.ctx, jetID, pnum+1)
+	require.NoError(s.T(), err, "resume starts sync of next pulse")
+	require.Equal(s.T(), 0, stored)
+}
+
 func preparepulse(s *heavysyncSuite, pn core.PulseNumber) {
 	pulse := core.Pulse{PulseNumber: pn}
 	err := s.pulseTracker.AddPulse(s.ctx, pulse)
 	require.NoError(s.T(), err)
 }
+
+func TestSync_Congestion(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	sync := NewSync(nil, configuration.HeavyCongestion{
+		QueueDepth:   2,
+		StoreLatency: 100 * time.Millisecond,
+		DelayStep:    10 * time.Millisecond,
+		MaxDelay:     time.Second,
+	})
+
+	sync.storing = 2
+	sync.observeStore(50 * time.Millisecond)
+	require.Equal(t, core.HeavyCongestion{QueueDepth: 2}, sync.Congestion(ctx))
+
+	sync.storing = 5
+	require.Equal(t, core.HeavyCongestion{QueueDepth: 5, Delay: 30 * time.Millisecond}, sync.Congestion(ctx))
+
+	// slow stores during compaction add average store duration to delay
+	sync.storing = 0
+	sync.storeLatency = 0
+	sync.observeStore(300 * time.Millisecond)
+	require.Equal(t, core.HeavyCongestion{Delay: 300 * time.Millisecond}, sync.Congestion(ctx))
+
+	sync.observeStore(100 * time.Second)
+	require.Equal(t, time.Second, sync.Congestion(ctx).Delay)
+}
@@ -33,11 +33,13 @@ var (
 	statSyncedRecords = stats.Int64("heavyserver/synced/records", "The number synced records", stats.UnitDimensionless)
 	statSyncedPulse   = stats.Int64("heavyserver/synced/pulse", "Last synced pulse", stats.UnitDimensionless)
 	statSyncedBytes   = stats.Int64("heavyserver/synced/bytes", "Amount of synced records in bytes", stats.UnitBytes)
+
+	statCongestionDelay = stats.Int64("heavyserver/congestion/delay", "Delay between payloads suggested to light nodes", stats.UnitMilliseconds)
 )
 
 func init() {
 	commontags := []tag.Key{tagJet}
-	err := view.Register(
+	err := insmetrics.Register(
 		&view.View{
 			Name:        statSyncedCount.Name(),
 			Description: statSyncedCount.Description(),
@@ -66,6 +68,12 @@ func init() {
 			Aggregation: view.Sum(),
 			TagKeys:     commontags,
 		},
+		&view.View{
+			Name:        statCongestionDelay.Name(),
+			Description: statCongestionDelay.Description(),
+			Measure:     statCongestionDelay,
+			Aggregation: view.Distribution(100, 500, 1000, 2000, 5000),
+		},
 	)
 	if err != nil {
 		panic(err)
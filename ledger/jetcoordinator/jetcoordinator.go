@@ -21,7 +21,9 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"sync/atomic"
 
+	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/ledger/storage"
 	"github.com/insolar/insolar/ledger/storage/jet"
@@ -39,11 +41,77 @@ type JetCoordinator struct {
 	NodeStorage                storage.NodeStorage             `inject:""`
 
 	lightChainLimit int
+
+	globuleCfg configuration.Globule
+	globule    core.GlobuleID
+	members    map[core.RecordRef]core.GlobuleID
+
+	domains map[core.RecordRef]string
+
+	replicas      map[core.RecordRef]bool
+	replicaCursor uint32
 }
 
 // NewJetCoordinator creates new coordinator instance.
-func NewJetCoordinator(lightChainLimit int) *JetCoordinator {
-	return &JetCoordinator{lightChainLimit: lightChainLimit}
+func NewJetCoordinator(lightChainLimit int, globule configuration.Globule) *JetCoordinator {
+	return &JetCoordinator{lightChainLimit: lightChainLimit, globuleCfg: globule}
+}
+
+// Init parses globule membership of nodes.
+func (jc *JetCoordinator) Init(ctx context.Context) error {
+	members := make(map[core.RecordRef]core.GlobuleID, len(jc.globuleCfg.Members))
+	for node, globule := range jc.globuleCfg.Members {
+		ref, err := core.NewRefFromBase58(node)
+		if err != nil {
+			return errors.Wrapf(err, "invalid reference of globule member %s", node)
+		}
+		members[*ref] = core.GlobuleID(globule)
+	}
+	jc.globule = core.GlobuleID(jc.globuleCfg.ID)
+	jc.members = members
+	return nil
+}
+
+// Globule returns globule of current node.
+func (jc *JetCoordinator) Globule() core.GlobuleID {
+	return jc.globule
+}
+
+// SetFailureDomains sets failure domains of nodes. Executors and validators of an object are selected
+// from distinct domains when possible.
+func (jc *JetCoordinator) SetFailureDomains(domains map[core.RecordRef]string) {
+	jc.domains = domains
+}
+
+// SetHeavyReplicas sets read replicas of heavy. Replicas are never selected as primary heavy,
+// they serve reads of old pulses only.
+func (jc *JetCoordinator) SetHeavyReplicas(replicas []core.RecordRef) {
+	jc.replicas = make(map[core.RecordRef]bool, len(replicas))
+	for _, ref := range replicas {
+		jc.replicas[ref] = true
+	}
+}
+
+// HeavyReplicas returns read replicas of heavy sorted by reference.
+func (jc *JetCoordinator) HeavyReplicas() []core.RecordRef {
+	replicas := make([]core.RecordRef, 0, len(jc.replicas))
+	for ref := range jc.replicas {
+		replicas = append(replicas, ref)
+	}
+	sort.Slice(replicas, func(i, j int) bool {
+		return bytes.Compare(replicas[i][:], replicas[j][:]) < 0
+	})
+	return replicas
+}
+
+// IsHeavyReplica checks if node is a read replica of heavy.
+func (jc *JetCoordinator) IsHeavyReplica(node core.RecordRef) bool {
+	return jc.replicas[node]
+}
+
+// GlobuleOf returns globule of provided node.
+func (jc *JetCoordinator) GlobuleOf(node core.RecordRef) core.GlobuleID {
+	return jc.members[node]
 }
 
 // Hardcoded roles count for validation and execution
@@ -86,37 +154,38 @@ func (jc *JetCoordinator) QueryRole(
 	role core.DynamicRole,
 	objID core.RecordID,
 	pulse core.PulseNumber,
+) ([]core.RecordRef, error) {
+	return jc.QueryRoleInGlobule(ctx, jc.globule, role, objID, pulse)
+}
+
+// QueryRoleInGlobule returns node refs of provided globule responsible for role bound operations
+// for given object and pulse.
+func (jc *JetCoordinator) QueryRoleInGlobule(
+	ctx context.Context,
+	globule core.GlobuleID,
+	role core.DynamicRole,
+	objID core.RecordID,
+	pulse core.PulseNumber,
 ) ([]core.RecordRef, error) {
 	switch role {
 	case core.DynamicRoleVirtualExecutor:
-		node, err := jc.VirtualExecutorForObject(ctx, objID, pulse)
-		if err != nil {
-			return nil, err
-		}
-		return []core.RecordRef{*node}, nil
+		return jc.virtualsForObject(ctx, globule, objID, pulse, VirtualExecutorCount)
 
 	case core.DynamicRoleVirtualValidator:
-		return jc.VirtualValidatorsForObject(ctx, objID, pulse)
+		return jc.virtualValidatorsForObject(ctx, globule, objID, pulse)
 
 	case core.DynamicRoleLightExecutor:
-		if objID.Pulse() == core.PulseNumberJet {
-			node, err := jc.LightExecutorForJet(ctx, objID, pulse)
-			if err != nil {
-				return nil, err
-			}
-			return []core.RecordRef{*node}, nil
+		jetID := objID
+		if objID.Pulse() != core.PulseNumberJet {
+			jetID = *jc.findJet(ctx, objID, pulse)
 		}
-		node, err := jc.LightExecutorForObject(ctx, objID, pulse)
-		if err != nil {
-			return nil, err
-		}
-		return []core.RecordRef{*node}, nil
+		return jc.lightMaterialsForJet(ctx, globule, jetID, pulse, MaterialExecutorCount)
 
 	case core.DynamicRoleLightValidator:
-		return jc.LightValidatorsForObject(ctx, objID, pulse)
+		return jc.lightValidatorsForJet(ctx, globule, *jc.findJet(ctx, objID, pulse), pulse)
 
 	case core.DynamicRoleHeavyExecutor:
-		node, err := jc.Heavy(ctx, pulse)
+		node, err := jc.heavy(ctx, globule, pulse)
 		if err != nil {
 			return nil, err
 		}
@@ -130,7 +199,7 @@ func (jc *JetCoordinator) QueryRole(
 func (jc *JetCoordinator) VirtualExecutorForObject(
 	ctx context.Context, objID core.RecordID, pulse core.PulseNumber,
 ) (*core.RecordRef, error) {
-	nodes, err := jc.virtualsForObject(ctx, objID, pulse, VirtualExecutorCount)
+	nodes, err := jc.virtualsForObject(ctx, jc.globule, objID, pulse, VirtualExecutorCount)
 	if err != nil {
 		return nil, err
 	}
@@ -141,7 +210,13 @@ func (jc *JetCoordinator) VirtualExecutorForObject(
 func (jc *JetCoordinator) VirtualValidatorsForObject(
 	ctx context.Context, objID core.RecordID, pulse core.PulseNumber,
 ) ([]core.RecordRef, error) {
-	nodes, err := jc.virtualsForObject(ctx, objID, pulse, VirtualValidatorCount+VirtualExecutorCount)
+	return jc.virtualValidatorsForObject(ctx, jc.globule, objID, pulse)
+}
+
+func (jc *JetCoordinator) virtualValidatorsForObject(
+	ctx context.Context, globule core.GlobuleID, objID core.RecordID, pulse core.PulseNumber,
+) ([]core.RecordRef, error) {
+	nodes, err := jc.virtualsForObject(ctx, globule, objID, pulse, VirtualValidatorCount+VirtualExecutorCount)
 	if err != nil {
 		return nil, err
 	}
@@ -154,7 +229,7 @@ func (jc *JetCoordinator) VirtualValidatorsForObject(
 func (jc *JetCoordinator) LightExecutorForJet(
 	ctx context.Context, jetID core.RecordID, pulse core.PulseNumber,
 ) (*core.RecordRef, error) {
-	nodes, err := jc.lightMaterialsForJet(ctx, jetID, pulse, MaterialExecutorCount)
+	nodes, err := jc.lightMaterialsForJet(ctx, jc.globule, jetID, pulse, MaterialExecutorCount)
 	if err != nil {
 		return nil, err
 	}
@@ -165,7 +240,13 @@ func (jc *JetCoordinator) LightExecutorForJet(
 func (jc *JetCoordinator) LightValidatorsForJet(
 	ctx context.Context, jetID core.RecordID, pulse core.PulseNumber,
 ) ([]core.RecordRef, error) {
-	nodes, err := jc.lightMaterialsForJet(ctx, jetID, pulse, MaterialValidatorCount+MaterialExecutorCount)
+	return jc.lightValidatorsForJet(ctx, jc.globule, jetID, pulse)
+}
+
+func (jc *JetCoordinator) lightValidatorsForJet(
+	ctx context.Context, globule core.GlobuleID, jetID core.RecordID, pulse core.PulseNumber,
+) ([]core.RecordRef, error) {
+	nodes, err := jc.lightMaterialsForJet(ctx, globule, jetID, pulse, MaterialValidatorCount+MaterialExecutorCount)
 	if err != nil {
 		return nil, err
 	}
@@ -178,27 +259,68 @@ func (jc *JetCoordinator) LightValidatorsForJet(
 func (jc *JetCoordinator) LightExecutorForObject(
 	ctx context.Context, objID core.RecordID, pulse core.PulseNumber,
 ) (*core.RecordRef, error) {
-	jetID, _ := jc.JetStorage.FindJet(ctx, pulse, objID)
-	return jc.LightExecutorForJet(ctx, *jetID, pulse)
+	return jc.LightExecutorForJet(ctx, *jc.findJet(ctx, objID, pulse), pulse)
 }
 
 // LightValidatorsForObject returns list of LVs for a provided pulse and objID
 func (jc *JetCoordinator) LightValidatorsForObject(
 	ctx context.Context, objID core.RecordID, pulse core.PulseNumber,
 ) ([]core.RecordRef, error) {
+	return jc.LightValidatorsForJet(ctx, *jc.findJet(ctx, objID, pulse), pulse)
+}
+
+func (jc *JetCoordinator) findJet(ctx context.Context, objID core.RecordID, pulse core.PulseNumber) *core.RecordID {
 	jetID, _ := jc.JetStorage.FindJet(ctx, pulse, objID)
-	return jc.LightValidatorsForJet(ctx, *jetID, pulse)
+	return jetID
 }
 
 // Heavy returns *core.RecorRef to a heavy of specific pulse
 func (jc *JetCoordinator) Heavy(ctx context.Context, pulse core.PulseNumber) (*core.RecordRef, error) {
-	candidates, err := jc.NodeStorage.GetActiveNodesByRole(pulse, core.StaticRoleHeavyMaterial)
+	return jc.heavy(ctx, jc.globule, pulse)
+}
+
+// HeavyReplica returns *core.RecordRef to a heavy which should serve reads of specific pulse.
+// Active read replicas are used in turn, primary heavy is returned if there are no active replicas.
+func (jc *JetCoordinator) HeavyReplica(ctx context.Context, pulse core.PulseNumber) (*core.RecordRef, error) {
+	if len(jc.replicas) > 0 {
+		candidates, err := jc.candidates(pulse, core.StaticRoleHeavyMaterial, jc.globule)
+		if err != nil && err != core.ErrNoNodes {
+			return nil, errors.Wrapf(err, "failed to fetch active heavy nodes for pulse %v", pulse)
+		}
+		var replicas []core.RecordRef
+		for _, n := range candidates {
+			if jc.replicas[n.ID()] {
+				replicas = append(replicas, n.ID())
+			}
+		}
+		if len(replicas) > 0 {
+			sort.Slice(replicas, func(i, j int) bool {
+				return bytes.Compare(replicas[i][:], replicas[j][:]) < 0
+			})
+			next := atomic.AddUint32(&jc.replicaCursor, 1)
+			return &replicas[int(next)%len(replicas)], nil
+		}
+	}
+	return jc.Heavy(ctx, pulse)
+}
+
+func (jc *JetCoordinator) heavy(ctx context.Context, globule core.GlobuleID, pulse core.PulseNumber) (*core.RecordRef, error) {
+	candidates, err := jc.candidates(pulse, core.StaticRoleHeavyMaterial, globule)
 	if err == core.ErrNoNodes {
 		return nil, err
 	}
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to fetch active heavy nodes for pulse %v", pulse)
 	}
+	if len(jc.replicas) > 0 {
+		var primaries []core.Node
+		for _, n := range candidates {
+			if !jc.replicas[n.ID()] {
+				primaries = append(primaries, n)
+			}
+		}
+		candidates = primaries
+	}
 	if len(candidates) == 0 {
 		return nil, errors.New(fmt.Sprintf("no active heavy nodes for pulse %d", pulse))
 	}
@@ -271,9 +393,10 @@ func (jc *JetCoordinator) NodeForObject(ctx context.Context, objectID core.Recor
 }
 
 func (jc *JetCoordinator) virtualsForObject(
-	ctx context.Context, objID core.RecordID, pulse core.PulseNumber, count int,
+	ctx context.Context, globule core.GlobuleID, objID core.RecordID, pulse core.PulseNumber, count int,
 ) ([]core.RecordRef, error) {
-	candidates, err := jc.NodeStorage.GetActiveNodesByRole(pulse, core.StaticRoleVirtual)
+	pulse = jc.selectionPulse(pulse)
+	candidates, err := jc.candidates(pulse, core.StaticRoleVirtual, globule)
 	if err == core.ErrNoNodes {
 		return nil, err
 	}
@@ -289,20 +412,16 @@ func (jc *JetCoordinator) virtualsForObject(
 		return nil, errors.Wrapf(err, "failed to fetch entropy for pulse %v", pulse)
 	}
 
-	return getRefs(
-		jc.PlatformCryptographyScheme,
-		circleXOR(ent[:], objID.Hash()),
-		candidates,
-		count,
-	)
+	return jc.selectRefs(circleXOR(ent[:], objID.Hash()), candidates, count)
 }
 
 func (jc *JetCoordinator) lightMaterialsForJet(
-	ctx context.Context, jetID core.RecordID, pulse core.PulseNumber, count int,
+	ctx context.Context, globule core.GlobuleID, jetID core.RecordID, pulse core.PulseNumber, count int,
 ) ([]core.RecordRef, error) {
 	_, prefix := jet.Jet(jetID)
 
-	candidates, err := jc.NodeStorage.GetActiveNodesByRole(pulse, core.StaticRoleLightMaterial)
+	pulse = jc.selectionPulse(pulse)
+	candidates, err := jc.candidates(pulse, core.StaticRoleLightMaterial, globule)
 	if err == core.ErrNoNodes {
 		return nil, err
 	}
@@ -318,12 +437,37 @@ func (jc *JetCoordinator) lightMaterialsForJet(
 		return nil, errors.Wrapf(err, "failed to fetch entropy for pulse %v", pulse)
 	}
 
-	return getRefs(
-		jc.PlatformCryptographyScheme,
-		circleXOR(ent[:], prefix),
-		candidates,
-		count,
-	)
+	return jc.selectRefs(circleXOR(ent[:], prefix), candidates, count)
+}
+
+// selectionPulse returns pulse which nodes and entropy are used to select executors and validators for provided pulse.
+// Inside maintenance window it's the first pulse of the window, so executors are not handed over until the window ends.
+func (jc *JetCoordinator) selectionPulse(pulse core.PulseNumber) core.PulseNumber {
+	schedule, ok := jc.NodeNet.(core.MaintenanceSchedule)
+	if !ok {
+		return pulse
+	}
+	if window := schedule.MaintenanceAt(pulse); window != nil {
+		return window.Start
+	}
+	return pulse
+}
+
+// candidates returns active nodes of provided role and globule.
+func (jc *JetCoordinator) candidates(
+	pulse core.PulseNumber, role core.StaticRole, globule core.GlobuleID,
+) ([]core.Node, error) {
+	nodes, err := jc.NodeStorage.GetActiveNodesByRole(pulse, role)
+	if err != nil || len(jc.members) == 0 && globule == 0 {
+		return nodes, err
+	}
+	var inGlobule []core.Node
+	for _, n := range nodes {
+		if jc.GlobuleOf(n.ID()) == globule {
+			inGlobule = append(inGlobule, n)
+		}
+	}
+	return inGlobule, nil
 }
 
 func (jc *JetCoordinator) entropy(ctx context.Context, pulse core.PulseNumber) (core.Entropy, error) {
@@ -344,6 +488,52 @@ func (jc *JetCoordinator) entropy(ctx context.Context, pulse core.PulseNumber) (
 	return older.Pulse.Entropy, nil
 }
 
+// selectRefs selects count nodes by entropy spreading them over failure domains.
+func (jc *JetCoordinator) selectRefs(e []byte, candidates []core.Node, count int) ([]core.RecordRef, error) {
+	if len(jc.domains) == 0 || count == 1 {
+		return getRefs(jc.PlatformCryptographyScheme, e, candidates, count)
+	}
+	if count > len(candidates) {
+		return nil, errors.New("count value should be less than values size")
+	}
+
+	// nodes are selected in the same order as without domains, so the first (executor) node doesn't change
+	ordered, err := getRefs(jc.PlatformCryptographyScheme, e, candidates, len(candidates))
+	if err != nil {
+		return nil, err
+	}
+	return spreadByDomains(ordered, jc.domains, count), nil
+}
+
+// spreadByDomains picks count nodes keeping their order and skipping nodes of already used domains.
+// Skipped nodes are picked in the end if there are not enough domains. Nodes without domain are always distinct.
+func spreadByDomains(ordered []core.RecordRef, domains map[core.RecordRef]string, count int) []core.RecordRef {
+	selected := make([]core.RecordRef, 0, count)
+	used := make(map[string]bool)
+	var skipped []core.RecordRef
+	for _, ref := range ordered {
+		if len(selected) == count {
+			return selected
+		}
+		domain, ok := domains[ref]
+		if ok && used[domain] {
+			skipped = append(skipped, ref)
+			continue
+		}
+		if ok {
+			used[domain] = true
+		}
+		selected = append(selected, ref)
+	}
+	for _, ref := range skipped {
+		if len(selected) == count {
+			break
+		}
+		selected = append(selected, ref)
+	}
+	return selected
+}
+
 func getRefs(
 	scheme core.PlatformCryptographyScheme,
 	e []byte,
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/insolar/insolar/component"
+	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/ledger/storage"
@@ -29,6 +30,7 @@ import (
 	"github.com/insolar/insolar/platformpolicy"
 	"github.com/insolar/insolar/pulsar/entropygenerator"
 	"github.com/insolar/insolar/testutils"
+	"github.com/insolar/insolar/testutils/conformance"
 	"github.com/insolar/insolar/testutils/network"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -71,7 +73,7 @@ func (s *jetCoordinatorSuite) BeforeTest(suiteName, testName string) {
 	s.pulseStorage = storage.NewPulseStorage()
 	s.jetStorage = storage.NewJetStorage()
 	s.nodeStorages = storage.NewNodeStorage()
-	s.coordinator = NewJetCoordinator(5)
+	s.coordinator = NewJetCoordinator(5, configuration.NewGlobule())
 	s.coordinator.NodeNet = network.NewNodeNetworkMock(s.T())
 
 	s.cm.Inject(
@@ -126,6 +128,33 @@ func (s *jetCoordinatorSuite) TestJetCoordinator_QueryRole() {
 	assert.Equal(s.T(), []core.RecordRef{nodeRefs[16], nodeRefs[21], nodeRefs[78]}, selected)
 }
 
+func (s *jetCoordinatorSuite) TestJetCoordinator_QueryRole_SkipsAPIGateways() {
+	err := s.pulseTracker.AddPulse(s.ctx, core.Pulse{PulseNumber: 0, Entropy: core.Entropy{1, 2, 3}})
+	require.NoError(s.T(), err)
+	var nodes []core.Node
+	virtuals := map[core.RecordRef]struct{}{}
+	for i := 0; i < 20; i++ {
+		ref := *core.NewRecordRef(core.DomainID, *core.NewRecordID(0, []byte{byte(i)}))
+		role := core.StaticRoleAPIGateway
+		if i%4 == 0 {
+			role = core.StaticRoleVirtual
+			virtuals[ref] = struct{}{}
+		}
+		nodes = append(nodes, storage.Node{FID: ref, FRole: role})
+	}
+	err = s.nodeStorages.SetActiveNodes(0, nodes)
+	require.NoError(s.T(), err)
+
+	for i := 0; i < 10; i++ {
+		selected, err := s.coordinator.QueryRole(s.ctx, core.DynamicRoleVirtualValidator, testutils.RandomID(), 0)
+		require.NoError(s.T(), err)
+		require.Len(s.T(), selected, VirtualValidatorCount)
+		for _, ref := range selected {
+			require.Contains(s.T(), virtuals, ref)
+		}
+	}
+}
+
 func TestJetCoordinator_Me(t *testing.T) {
 	t.Parallel()
 	// Arrange
@@ -134,7 +163,7 @@ func TestJetCoordinator_Me(t *testing.T) {
 	node := network.NewNodeMock(t)
 	nodeNet.GetOriginMock.Return(node)
 	node.IDMock.Return(expectedID)
-	jc := NewJetCoordinator(1)
+	jc := NewJetCoordinator(1, configuration.NewGlobule())
 	jc.NodeNet = nodeNet
 
 	// Act
@@ -147,7 +176,7 @@ func TestJetCoordinator_Me(t *testing.T) {
 func TestNewJetCoordinator(t *testing.T) {
 	t.Parallel()
 	// Act
-	calc := NewJetCoordinator(12)
+	calc := NewJetCoordinator(12, configuration.NewGlobule())
 
 	// Assert
 	require.NotNil(t, calc)
@@ -160,7 +189,7 @@ func TestJetCoordinator_IsBeyondLimit_ProblemsWithTracker(t *testing.T) {
 	ctx := inslogger.TestContext(t)
 	pulseTrackerMock := storage.NewPulseTrackerMock(t)
 	pulseTrackerMock.GetPulseMock.Return(nil, errors.New("it's expected"))
-	calc := NewJetCoordinator(12)
+	calc := NewJetCoordinator(12, configuration.NewGlobule())
 	calc.PulseTracker = pulseTrackerMock
 
 	// Act
@@ -183,7 +212,7 @@ func TestJetCoordinator_IsBeyondLimit_ProblemsWithTracker_SecondCall(t *testing.
 
 		return nil, errors.New("it's expected")
 	}
-	calc := NewJetCoordinator(12)
+	calc := NewJetCoordinator(12, configuration.NewGlobule())
 	calc.PulseTracker = pulseTrackerMock
 
 	// Act
@@ -206,7 +235,7 @@ func TestJetCoordinator_IsBeyondLimit_OutsideOfLightChainLimit(t *testing.T) {
 
 		return &storage.Pulse{SerialNumber: 24}, nil
 	}
-	calc := NewJetCoordinator(25)
+	calc := NewJetCoordinator(25, configuration.NewGlobule())
 	calc.PulseTracker = pulseTrackerMock
 
 	// Act
@@ -229,7 +258,7 @@ func TestJetCoordinator_IsBeyondLimit_InsideOfLightChainLimit(t *testing.T) {
 
 		return &storage.Pulse{SerialNumber: 34}, nil
 	}
-	calc := NewJetCoordinator(25)
+	calc := NewJetCoordinator(25, configuration.NewGlobule())
 	calc.PulseTracker = pulseTrackerMock
 
 	// Act
@@ -246,7 +275,7 @@ func TestJetCoordinator_NodeForJet_CheckLimitFailed(t *testing.T) {
 	ctx := inslogger.TestContext(t)
 	pulseTrackerMock := storage.NewPulseTrackerMock(t)
 	pulseTrackerMock.GetPulseMock.Return(nil, errors.New("it's expected"))
-	calc := NewJetCoordinator(12)
+	calc := NewJetCoordinator(12, configuration.NewGlobule())
 	calc.PulseTracker = pulseTrackerMock
 
 	// Act
@@ -286,7 +315,7 @@ func TestJetCoordinator_NodeForJet_GoToHeavy(t *testing.T) {
 		return &core.Pulse{PulseNumber: core.FirstPulseNumber, Entropy: generator.GenerateEntropy()}, nil
 	}
 
-	calc := NewJetCoordinator(25)
+	calc := NewJetCoordinator(25, configuration.NewGlobule())
 	calc.PulseTracker = pulseTrackerMock
 	calc.NodeStorage = activeNodesStorageMock
 	calc.PulseStorage = pulseStorageMock
@@ -329,7 +358,7 @@ func TestJetCoordinator_NodeForJet_GoToLight(t *testing.T) {
 		return &core.Pulse{PulseNumber: core.FirstPulseNumber, Entropy: generator.GenerateEntropy()}, nil
 	}
 
-	calc := NewJetCoordinator(25)
+	calc := NewJetCoordinator(25, configuration.NewGlobule())
 	calc.PulseTracker = pulseTrackerMock
 	calc.NodeStorage = activeNodesStorageMock
 	calc.PulseStorage = pulseStorageMock
@@ -342,3 +371,233 @@ func TestJetCoordinator_NodeForJet_GoToLight(t *testing.T) {
 	require.Nil(t, err)
 	require.Equal(t, expectedID, resNode)
 }
+
+func TestJetCoordinator_QueryRoleInGlobule(t *testing.T) {
+	t.Parallel()
+	ctx := inslogger.TestContext(t)
+
+	byRole := map[core.StaticRole][]core.Node{}
+	globuleCfg := configuration.NewGlobule()
+	globuleOf := map[core.RecordRef]core.GlobuleID{}
+	for _, role := range []core.StaticRole{core.StaticRoleVirtual, core.StaticRoleHeavyMaterial} {
+		for i := 0; i < 2*(VirtualValidatorCount+VirtualExecutorCount); i++ {
+			ref := testutils.RandomRef()
+			globule := core.GlobuleID(i % 2)
+			byRole[role] = append(byRole[role], storage.Node{FID: ref, FRole: role})
+			globuleOf[ref] = globule
+			if globule != 0 {
+				globuleCfg.Members[ref.String()] = uint32(globule)
+			}
+		}
+	}
+	nodeStorage := storage.NewNodeStorageMock(t)
+	nodeStorage.GetActiveNodesByRoleFunc = func(p core.PulseNumber, role core.StaticRole) ([]core.Node, error) {
+		return byRole[role], nil
+	}
+	entropy := (&entropygenerator.StandardEntropyGenerator{}).GenerateEntropy()
+	pulseStorage := testutils.NewPulseStorageMock(t)
+	pulseStorage.CurrentFunc = func(p context.Context) (*core.Pulse, error) {
+		return &core.Pulse{PulseNumber: core.FirstPulseNumber, Entropy: entropy}, nil
+	}
+
+	jc := NewJetCoordinator(25, globuleCfg)
+	jc.NodeStorage = nodeStorage
+	jc.PulseStorage = pulseStorage
+	jc.PlatformCryptographyScheme = platformpolicy.NewPlatformCryptographyScheme()
+	require.NoError(t, jc.Init(ctx))
+	require.Equal(t, core.GlobuleID(0), jc.Globule())
+
+	for _, globule := range []core.GlobuleID{0, 1} {
+		for _, role := range []core.DynamicRole{
+			core.DynamicRoleVirtualExecutor, core.DynamicRoleVirtualValidator, core.DynamicRoleHeavyExecutor,
+		} {
+			nodes, err := jc.QueryRoleInGlobule(ctx, globule, role, testutils.RandomID(), core.FirstPulseNumber)
+			require.NoError(t, err)
+			require.NotEmpty(t, nodes)
+			for _, n := range nodes {
+				require.Equal(t, globule, globuleOf[n], "role %v in globule %v", role, globule)
+				require.Equal(t, globule, jc.GlobuleOf(n))
+			}
+		}
+	}
+
+	// without globule QueryRole routes to own globule
+	obj := testutils.RandomID()
+	own, err := jc.QueryRole(ctx, core.DynamicRoleVirtualExecutor, obj, core.FirstPulseNumber)
+	require.NoError(t, err)
+	inGlobule, err := jc.QueryRoleInGlobule(ctx, 0, core.DynamicRoleVirtualExecutor, obj, core.FirstPulseNumber)
+	require.NoError(t, err)
+	require.Equal(t, inGlobule, own)
+
+	_, err = jc.QueryRoleInGlobule(ctx, 2, core.DynamicRoleVirtualExecutor, obj, core.FirstPulseNumber)
+	require.Error(t, err)
+
+	globuleCfg.Members["not a ref"] = 1
+	require.Error(t, NewJetCoordinator(25, globuleCfg).Init(ctx))
+}
+
+func TestSpreadByDomains(t *testing.T) {
+	t.Parallel()
+	refs := []core.RecordRef{testutils.RandomRef(), testutils.RandomRef(), testutils.RandomRef(), testutils.RandomRef()}
+	domains := map[core.RecordRef]string{refs[0]: "a", refs[1]: "a", refs[2]: "b"}
+
+	require.Equal(t, []core.RecordRef{refs[0], refs[2], refs[3]}, spreadByDomains(refs, domains, 3))
+	require.Equal(t, []core.RecordRef{refs[0], refs[2], refs[3], refs[1]}, spreadByDomains(refs, domains, 4))
+	require.Equal(t, refs[:2], spreadByDomains(refs, nil, 2))
+}
+
+func TestJetCoordinator_QueryRole_FailureDomains(t *testing.T) {
+	t.Parallel()
+	ctx := inslogger.TestContext(t)
+
+	var nodes []core.Node
+	domains := map[core.RecordRef]string{}
+	zones := []string{"zone-1", "zone-2", "zone-3", "zone-4"}
+	for i := 0; i < 4*len(zones); i++ {
+		ref := testutils.RandomRef()
+		nodes = append(nodes, storage.Node{FID: ref, FRole: core.StaticRoleVirtual})
+		domains[ref] = zones[i/4]
+	}
+	nodeStorage := storage.NewNodeStorageMock(t)
+	nodeStorage.GetActiveNodesByRoleMock.Return(nodes, nil)
+	entropy := (&entropygenerator.StandardEntropyGenerator{}).GenerateEntropy()
+	pulseStorage := testutils.NewPulseStorageMock(t)
+	pulseStorage.CurrentMock.Return(&core.Pulse{PulseNumber: core.FirstPulseNumber, Entropy: entropy}, nil)
+
+	jc := NewJetCoordinator(25, configuration.NewGlobule())
+	jc.NodeStorage = nodeStorage
+	jc.PulseStorage = pulseStorage
+	jc.PlatformCryptographyScheme = platformpolicy.NewPlatformCryptographyScheme()
+	require.NoError(t, jc.Init(ctx))
+
+	for i := 0; i < 20; i++ {
+		obj := testutils.RandomID()
+		executors, err := jc.QueryRole(ctx, core.DynamicRoleVirtualExecutor, obj, core.FirstPulseNumber)
+		require.NoError(t, err)
+
+		withoutDomains, err := jc.QueryRole(ctx, core.DynamicRoleVirtualValidator, obj, core.FirstPulseNumber)
+		require.NoError(t, err)
+
+		jc.SetFailureDomains(domains)
+		validators, err := jc.QueryRole(ctx, core.DynamicRoleVirtualValidator, obj, core.FirstPulseNumber)
+		require.NoError(t, err)
+		executorsWithDomains, err := jc.QueryRole(ctx, core.DynamicRoleVirtualExecutor, obj, core.FirstPulseNumber)
+		require.NoError(t, err)
+		jc.SetFailureDomains(nil)
+
+		require.Equal(t, executors, executorsWithDomains, "executor must not depend on domains")
+		require.Len(t, validators, len(withoutDomains))
+		used := map[string]bool{domains[executors[0]]: true}
+		for _, v := range validators {
+			require.False(t, used[domains[v]], "validators must be in distinct domains")
+			used[domains[v]] = true
+		}
+	}
+}
+
+func TestJetCoordinator_HeavyReplica(t *testing.T) {
+	t.Parallel()
+	ctx := inslogger.TestContext(t)
+
+	primary := storage.Node{FID: testutils.RandomRef(), FRole: core.StaticRoleHeavyMaterial}
+	replicas := []core.RecordRef{testutils.RandomRef(), testutils.RandomRef()}
+	nodes := []core.Node{primary}
+	for _, ref := range replicas {
+		nodes = append(nodes, storage.Node{FID: ref, FRole: core.StaticRoleHeavyMaterial})
+	}
+	nodeStorage := storage.NewNodeStorageMock(t)
+	nodeStorage.GetActiveNodesByRoleFunc = func(core.PulseNumber, core.StaticRole) ([]core.Node, error) {
+		return nodes, nil
+	}
+	entropy := (&entropygenerator.StandardEntropyGenerator{}).GenerateEntropy()
+	pulseStorage := testutils.NewPulseStorageMock(t)
+	pulseStorage.CurrentMock.Return(&core.Pulse{PulseNumber: core.FirstPulseNumber, Entropy: entropy}, nil)
+
+	jc := NewJetCoordinator(25, configuration.NewGlobule())
+	jc.NodeStorage = nodeStorage
+	jc.PulseStorage = pulseStorage
+	jc.PlatformCryptographyScheme = platformpolicy.NewPlatformCryptographyScheme()
+	require.NoError(t, jc.Init(ctx))
+	jc.SetHeavyReplicas(replicas)
+
+	require.True(t, jc.IsHeavyReplica(replicas[0]))
+	require.False(t, jc.IsHeavyReplica(primary.ID()))
+	require.Len(t, jc.HeavyReplicas(), 2)
+
+	for i := 0; i < 10; i++ {
+		heavy, err := jc.Heavy(ctx, core.FirstPulseNumber)
+		require.NoError(t, err)
+		require.Equal(t, primary.ID(), *heavy, "replica must not be selected as primary heavy")
+	}
+
+	used := map[core.RecordRef]int{}
+	for i := 0; i < 10; i++ {
+		heavy, err := jc.HeavyReplica(ctx, core.FirstPulseNumber)
+		require.NoError(t, err)
+		used[*heavy]++
+	}
+	require.Equal(t, map[core.RecordRef]int{replicas[0]: 5, replicas[1]: 5}, used)
+
+	// replicas are down
+	nodes = nodes[:1]
+	heavy, err := jc.HeavyReplica(ctx, core.FirstPulseNumber)
+	require.NoError(t, err)
+	require.Equal(t, primary.ID(), *heavy)
+}
+
+func (s *jetCoordinatorSuite) TestJetCoordinator_Conformance() {
+	err := s.pulseTracker.AddPulse(s.ctx, core.Pulse{PulseNumber: 0, Entropy: core.Entropy{1, 2, 3}})
+	require.NoError(s.T(), err)
+	var nodes []core.Node
+	for _, role := range []core.StaticRole{core.StaticRoleVirtual, core.StaticRoleLightMaterial, core.StaticRoleHeavyMaterial} {
+		for i := 0; i < 10; i++ {
+			nodes = append(nodes, storage.Node{FID: testutils.RandomRef(), FRole: role})
+		}
+	}
+	err = s.nodeStorages.SetActiveNodes(0, nodes)
+	require.NoError(s.T(), err)
+
+	conformance.JetCoordinator(s.T(), func(t *testing.T) (context.Context, core.JetCoordinator, core.PulseNumber) {
+		return s.ctx, s.coordinator, 0
+	})
+}
+
+type maintenanceNodeNet struct {
+	core.NodeNetwork
+	window core.MaintenanceWindow
+}
+
+func (n *maintenanceNodeNet) MaintenanceAt(pulse core.PulseNumber) *core.MaintenanceWindow {
+	if n.window.Contains(pulse) {
+		return &n.window
+	}
+	return nil
+}
+
+func (n *maintenanceNodeNet) MaintenanceWindows() []core.MaintenanceWindow {
+	return []core.MaintenanceWindow{n.window}
+}
+
+func (s *jetCoordinatorSuite) TestJetCoordinator_MaintenancePinsExecutors() {
+	s.coordinator.NodeNet = &maintenanceNodeNet{
+		NodeNetwork: s.coordinator.NodeNet,
+		window:      core.MaintenanceWindow{Start: 10, End: 20},
+	}
+	for _, pn := range []core.PulseNumber{10, 20} {
+		err := s.pulseTracker.AddPulse(s.ctx, core.Pulse{PulseNumber: pn, Entropy: core.Entropy{byte(pn)}})
+		require.NoError(s.T(), err)
+		var nodes []core.Node
+		for i := 0; i < 10; i++ {
+			nodes = append(nodes, storage.Node{FID: testutils.RandomRef(), FRole: core.StaticRoleVirtual})
+		}
+		err = s.nodeStorages.SetActiveNodes(pn, nodes)
+		require.NoError(s.T(), err)
+	}
+
+	objID := *core.NewRecordID(20, []byte{1, 2, 3})
+	inWindow, err := s.coordinator.VirtualExecutorForObject(s.ctx, objID, 20)
+	require.NoError(s.T(), err)
+	atStart, err := s.coordinator.VirtualExecutorForObject(s.ctx, objID, 10)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), atStart, inWindow)
+}
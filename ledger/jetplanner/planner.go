@@ -0,0 +1,139 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+// Package jetplanner recommends splits and merges of jets by their load over recent pulses.
+package jetplanner
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage"
+	"github.com/insolar/insolar/ledger/storage/jet"
+)
+
+// Planner makes plans of jet tree rebalancing.
+type Planner interface {
+	core.JetPlanner
+	// Plan makes plan of rebalancing for provided jets. Jets without enough drops history are skipped.
+	Plan(ctx context.Context, pulse core.PulseNumber, jets []core.RecordID) (*core.JetRebalancePlan, error)
+	// Approved checks if planned splits should be performed.
+	Approved() bool
+}
+
+type planner struct {
+	DropStorage storage.DropStorage `inject:""`
+
+	window         int
+	splitThreshold uint64
+	mergeThreshold uint64
+	approved       bool
+
+	lock sync.RWMutex
+	last *core.JetRebalancePlan
+}
+
+// NewPlanner creates new planner. Split threshold is shared with pulse manager configuration.
+func NewPlanner(conf configuration.Ledger) Planner {
+	return &planner{
+		window:         conf.JetPlanner.Window,
+		splitThreshold: conf.PulseManager.SplitThreshold,
+		mergeThreshold: conf.JetPlanner.MergeThreshold,
+		approved:       conf.JetPlanner.Execute,
+	}
+}
+
+func (p *planner) Plan(ctx context.Context, pulse core.PulseNumber, jets []core.RecordID) (*core.JetRebalancePlan, error) {
+	plan := &core.JetRebalancePlan{Pulse: pulse, Executed: p.approved}
+
+	loads := make(map[core.RecordID]core.JetLoad, len(jets))
+	for _, jetID := range jets {
+		history, err := p.DropStorage.GetDropSizeHistory(ctx, jetID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "[ Plan ] Can't get drop sizes of jet %s", jetID.DebugString())
+		}
+		load, ok := p.load(jetID, history)
+		if !ok {
+			continue
+		}
+		loads[jetID] = load
+
+		if load.AvgDropSize > p.splitThreshold {
+			plan.Splits = append(plan.Splits, load)
+		}
+	}
+
+	for _, jetID := range jets {
+		if depth, _ := jet.Jet(jetID); depth == 0 {
+			continue
+		}
+		parent := jet.Parent(jetID)
+		leftID, rightID := jet.Children(parent)
+		if jetID != leftID {
+			// Pairs are checked from left sibling, so every pair is considered once.
+			continue
+		}
+		left, ok := loads[leftID]
+		if !ok {
+			continue
+		}
+		right, ok := loads[rightID]
+		if !ok {
+			continue
+		}
+		if left.AvgDropSize < p.mergeThreshold && right.AvgDropSize < p.mergeThreshold {
+			plan.Merges = append(plan.Merges, core.JetMerge{Parent: parent, Left: left, Right: right})
+		}
+	}
+
+	if len(plan.Splits) > 0 || len(plan.Merges) > 0 {
+		inslogger.FromContext(ctx).Infof(
+			"[ Plan ] Jets rebalancing planned on pulse %d: %d splits, %d merges", pulse, len(plan.Splits), len(plan.Merges),
+		)
+	}
+
+	p.lock.Lock()
+	p.last = plan
+	p.lock.Unlock()
+	return plan, nil
+}
+
+// load calculates average size of last drops of jet. Jets with history shorter than window aren't planned.
+func (p *planner) load(jetID core.RecordID, history jet.DropSizeHistory) (core.JetLoad, bool) {
+	if p.window <= 0 || len(history) < p.window {
+		return core.JetLoad{}, false
+	}
+	var total uint64
+	for _, ds := range history[len(history)-p.window:] {
+		total += ds.DropSize
+	}
+	return core.JetLoad{Jet: jetID, AvgDropSize: total / uint64(p.window), Drops: p.window}, true
+}
+
+func (p *planner) Approved() bool {
+	return p.approved
+}
+
+func (p *planner) LastPlan() *core.JetRebalancePlan {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.last
+}
@@ -0,0 +1,85 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package jetplanner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gojuno/minimock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage"
+	"github.com/insolar/insolar/ledger/storage/jet"
+)
+
+func history(sizes ...uint64) jet.DropSizeHistory {
+	h := jet.DropSizeHistory{}
+	for _, size := range sizes {
+		h = append(h, jet.DropSize{DropSize: size})
+	}
+	return h
+}
+
+func TestPlanner_Plan(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	conf := configuration.NewLedger()
+	conf.PulseManager.SplitThreshold = 1000
+	conf.JetPlanner.Window = 3
+	conf.JetPlanner.MergeThreshold = 100
+
+	// Depth 2 jets: 00 and 01 are lightly loaded siblings, 10 is overloaded, 11 has too short history.
+	left, right := jet.Children(*jet.NewID(1, nil))
+	hotLeft, hotRight := jet.Children(*jet.NewID(1, []byte{0x80}))
+
+	sizes := map[core.RecordID]jet.DropSizeHistory{
+		left:     history(5000, 10, 20, 30),
+		right:    history(50, 50, 50),
+		hotLeft:  history(900, 1200, 1500),
+		hotRight: history(10),
+	}
+	ds := storage.NewDropStorageMock(mc)
+	ds.GetDropSizeHistoryFunc = func(_ context.Context, jetID core.RecordID) (jet.DropSizeHistory, error) {
+		return sizes[jetID], nil
+	}
+
+	p := NewPlanner(conf).(*planner)
+	p.DropStorage = ds
+	require.Nil(t, p.LastPlan())
+
+	plan, err := p.Plan(ctx, core.FirstPulseNumber, []core.RecordID{left, right, hotLeft, hotRight})
+	require.NoError(t, err)
+	require.Equal(t, &core.JetRebalancePlan{
+		Pulse:  core.FirstPulseNumber,
+		Splits: []core.JetLoad{{Jet: hotLeft, AvgDropSize: 1200, Drops: 3}},
+		Merges: []core.JetMerge{{
+			Parent: *jet.NewID(1, nil),
+			Left:   core.JetLoad{Jet: left, AvgDropSize: 20, Drops: 3},
+			Right:  core.JetLoad{Jet: right, AvgDropSize: 50, Drops: 3},
+		}},
+	}, plan)
+	require.True(t, plan.IsSplit(hotLeft))
+	require.False(t, plan.IsSplit(hotRight))
+	require.Equal(t, plan, p.LastPlan())
+	require.False(t, p.Approved())
+}
@@ -28,9 +28,12 @@ import (
 	"github.com/insolar/insolar/ledger/exporter"
 	"github.com/insolar/insolar/ledger/heavyserver"
 	"github.com/insolar/insolar/ledger/jetcoordinator"
+	"github.com/insolar/insolar/ledger/jetplanner"
 	"github.com/insolar/insolar/ledger/localstorage"
 	"github.com/insolar/insolar/ledger/pulsemanager"
+	"github.com/insolar/insolar/ledger/scrubber"
 	"github.com/insolar/insolar/ledger/storage"
+	"github.com/insolar/insolar/ledger/upgrade"
 	"github.com/insolar/insolar/log"
 )
 
@@ -105,6 +108,20 @@ func GetLedgerComponents(conf configuration.Ledger, certificate core.Certificate
 		pulseTracker = storage.NewPulseTrackerMemory()
 	}
 
+	jc := jetcoordinator.NewJetCoordinator(conf.LightChainLimit, conf.Globule)
+	if domains, ok := certificate.(core.FailureDomainsProvider); ok {
+		jc.SetFailureDomains(domains.GetFailureDomains())
+	}
+	replicas := make([]core.RecordRef, 0, len(conf.HeavyReplicas))
+	for _, node := range conf.HeavyReplicas {
+		ref, err := core.NewRefFromBase58(node)
+		if err != nil {
+			panic(errors.Wrapf(err, "invalid reference of heavy replica %s", node))
+		}
+		replicas = append(replicas, *ref)
+	}
+	jc.SetHeavyReplicas(replicas)
+
 	return []interface{}{
 		db,
 		storage.NewCleaner(),
@@ -115,16 +132,26 @@ func GetLedgerComponents(conf configuration.Ledger, certificate core.Certificate
 		storage.NewNodeStorage(),
 		storage.NewObjectStorage(),
 		storage.NewReplicaStorage(),
+		storage.NewIntegrityStorage(),
 		storage.NewGenesisInitializer(),
 		recentstorage.NewRecentStorageProvider(conf.RecentStorage.DefaultTTL),
 		artifactmanager.NewHotDataWaiterConcrete(),
 		artifactmanager.NewArtifactManger(),
-		jetcoordinator.NewJetCoordinator(conf.LightChainLimit),
+		artifactmanager.NewFinalityChecker(),
+		artifactmanager.NewLedgerArchive(),
+		artifactmanager.NewSchemaRegistry(),
+		artifactmanager.NewGenesisReplayer(certificate),
+		artifactmanager.NewHotDataMigrator(),
+		jc,
+		jetplanner.NewPlanner(conf),
 		pulsemanager.NewPulseManager(conf),
 		artifactmanager.NewMessageHandler(&conf, certificate),
 		localstorage.NewLocalStorage(db),
-		heavyserver.NewSync(db),
+		heavyserver.NewSync(db, conf.HeavyCongestion),
+		scrubber.NewScrubber(conf, certificate),
+		upgrade.NewCoordinator(conf, certificate),
 		exporter.NewExporter(conf.Exporter),
+		exporter.NewAnalytics(conf, certificate),
 	}
 }
 
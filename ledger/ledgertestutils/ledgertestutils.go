@@ -27,6 +27,7 @@ import (
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/ledger"
 	"github.com/insolar/insolar/ledger/artifactmanager"
+	"github.com/insolar/insolar/ledger/jetplanner"
 	"github.com/insolar/insolar/ledger/localstorage"
 	"github.com/insolar/insolar/ledger/pulsemanager"
 	"github.com/insolar/insolar/ledger/recentstorage"
@@ -72,6 +73,7 @@ func TmpLedger(t *testing.T, dir string, handlersRole core.StaticRole, c core.Co
 
 	conf.PulseManager.HeavySyncEnabled = false
 	pm := pulsemanager.NewPulseManager(conf)
+	jp := jetplanner.NewPlanner(conf)
 	ls := localstorage.NewLocalStorage(db)
 	jc := testutils.NewJetCoordinatorMock(mc)
 	jc.IsAuthorizedMock.Return(true, nil)
@@ -129,6 +131,7 @@ func TmpLedger(t *testing.T, dir string, handlersRole core.StaticRole, c core.Co
 		am,
 		rs,
 		cl,
+		jp,
 	)
 
 	err := cm.Init(ctx)
@@ -166,6 +169,7 @@ func TmpLedger(t *testing.T, dir string, handlersRole core.StaticRole, c core.Co
 	pm.PulseTracker = pt
 	pm.ReplicaStorage = rs
 	pm.StorageCleaner = cl
+	pm.JetPlanner = jp
 
 	hdw := artifactmanager.NewHotDataWaiterConcrete()
 
@@ -179,6 +183,7 @@ func TmpLedger(t *testing.T, dir string, handlersRole core.StaticRole, c core.Co
 	pendingMock.GetRequestsForObjectMock.Return(nil)
 	pendingMock.AddPendingRequestMock.Return()
 	pendingMock.RemovePendingRequestMock.Return()
+	pendingMock.RemoveTimerMock.Return()
 
 	provideMock := recentstorage.NewProviderMock(t)
 	provideMock.GetIndexStorageMock.Return(indexMock)
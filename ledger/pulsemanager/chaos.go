@@ -0,0 +1,39 @@
+// +build !chaos
+
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package pulsemanager
+
+import (
+	"context"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+)
+
+type setFunc func(ctx context.Context, pulse core.Pulse, persist bool) error
+
+// chaosMonkey passes pulses as is. Build with "chaos" tag to inject pulse faults from scenario file.
+type chaosMonkey struct{}
+
+func newChaosMonkey(configuration.PulseManager) *chaosMonkey {
+	return &chaosMonkey{}
+}
+
+func (c *chaosMonkey) set(ctx context.Context, pulse core.Pulse, persist bool, set setFunc) error {
+	return set(ctx, pulse, persist)
+}
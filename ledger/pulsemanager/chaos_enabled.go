@@ -0,0 +1,153 @@
+// +build chaos
+
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package pulsemanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+type setFunc func(ctx context.Context, pulse core.Pulse, persist bool) error
+
+// Chaos actions applied to pulse.
+const (
+	// chaosSkip drops pulse, pulse manager never sees it.
+	chaosSkip = "skip"
+	// chaosDelay applies pulse after delay.
+	chaosDelay = "delay"
+	// chaosDuplicate applies pulse twice.
+	chaosDuplicate = "duplicate"
+)
+
+// chaosStep is a fault injected into n-th pulse received by pulse manager, pulses are counted from 1.
+type chaosStep struct {
+	Pulse  int    `json:"pulse"`
+	Action string `json:"action"`
+	// Delay is a duration string like "1.5s", it's used by "delay" action.
+	Delay string `json:"delay,omitempty"`
+
+	delay time.Duration
+}
+
+// chaosScenario is a content of scenario file, e.g.
+//
+// 	{"steps": [
+// 		{"pulse": 3, "action": "skip"},
+// 		{"pulse": 5, "action": "delay", "delay": "2s"},
+// 		{"pulse": 7, "action": "duplicate"}
+// 	]}
+type chaosScenario struct {
+	Steps []chaosStep `json:"steps"`
+}
+
+// chaosMonkey injects skipped, late and duplicate pulses according to scenario, so pulse edge handling can be
+// tested deterministically.
+type chaosMonkey struct {
+	steps map[int]chaosStep
+
+	lock     sync.Mutex
+	received int
+}
+
+func newChaosMonkey(conf configuration.PulseManager) *chaosMonkey {
+	if conf.ChaosScenario == "" {
+		return &chaosMonkey{}
+	}
+	c, err := loadChaosScenario(conf.ChaosScenario)
+	if err != nil {
+		panic(fmt.Sprintf("[ chaos ] failed to load scenario: %v", err))
+	}
+	return c
+}
+
+func loadChaosScenario(path string) (*chaosMonkey, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var scenario chaosScenario
+	if err := json.Unmarshal(data, &scenario); err != nil {
+		return nil, errors.Wrap(err, "failed to parse scenario")
+	}
+	return newChaosMonkeyWithSteps(scenario.Steps)
+}
+
+func newChaosMonkeyWithSteps(steps []chaosStep) (*chaosMonkey, error) {
+	c := &chaosMonkey{steps: map[int]chaosStep{}}
+	for _, step := range steps {
+		switch step.Action {
+		case chaosSkip, chaosDuplicate:
+		case chaosDelay:
+			delay, err := time.ParseDuration(step.Delay)
+			if err != nil {
+				return nil, errors.Wrapf(err, "bad delay of pulse %d", step.Pulse)
+			}
+			step.delay = delay
+		default:
+			return nil, errors.Errorf("unknown action %q of pulse %d", step.Action, step.Pulse)
+		}
+		if _, ok := c.steps[step.Pulse]; ok {
+			return nil, errors.Errorf("pulse %d has several steps", step.Pulse)
+		}
+		c.steps[step.Pulse] = step
+	}
+	return c, nil
+}
+
+func (c *chaosMonkey) set(ctx context.Context, pulse core.Pulse, persist bool, set setFunc) error {
+	if c == nil || len(c.steps) == 0 {
+		return set(ctx, pulse, persist)
+	}
+
+	c.lock.Lock()
+	c.received++
+	step, ok := c.steps[c.received]
+	c.lock.Unlock()
+	if !ok {
+		return set(ctx, pulse, persist)
+	}
+
+	logger := inslogger.FromContext(ctx)
+	switch step.Action {
+	case chaosSkip:
+		logger.Warnf("[ chaos ] skipping pulse %v", pulse.PulseNumber)
+		return nil
+	case chaosDelay:
+		logger.Warnf("[ chaos ] delaying pulse %v for %v", pulse.PulseNumber, step.delay)
+		time.Sleep(step.delay)
+		return set(ctx, pulse, persist)
+	case chaosDuplicate:
+		logger.Warnf("[ chaos ] duplicating pulse %v", pulse.PulseNumber)
+		if err := set(ctx, pulse, persist); err != nil {
+			return err
+		}
+		return set(ctx, pulse, persist)
+	}
+	return set(ctx, pulse, persist)
+}
@@ -0,0 +1,63 @@
+// +build chaos
+
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package pulsemanager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+func TestChaosMonkey(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	c, err := newChaosMonkeyWithSteps([]chaosStep{
+		{Pulse: 2, Action: chaosSkip},
+		{Pulse: 3, Action: chaosDelay, Delay: "10ms"},
+		{Pulse: 4, Action: chaosDuplicate},
+	})
+	require.NoError(t, err)
+
+	var applied []core.PulseNumber
+	set := func(ctx context.Context, pulse core.Pulse, persist bool) error {
+		applied = append(applied, pulse.PulseNumber)
+		return nil
+	}
+
+	for pn := core.PulseNumber(1); pn <= 5; pn++ {
+		start := time.Now()
+		require.NoError(t, c.set(ctx, core.Pulse{PulseNumber: pn}, true, set))
+		if pn == 3 {
+			require.True(t, time.Since(start) >= 10*time.Millisecond, "pulse is delayed")
+		}
+	}
+	require.Equal(t, []core.PulseNumber{1, 3, 4, 4, 5}, applied)
+}
+
+func TestChaosMonkey_BadScenario(t *testing.T) {
+	_, err := newChaosMonkeyWithSteps([]chaosStep{{Pulse: 1, Action: "explode"}})
+	require.Error(t, err)
+
+	_, err = newChaosMonkeyWithSteps([]chaosStep{{Pulse: 1, Action: chaosDelay, Delay: "soon"}})
+	require.Error(t, err)
+}
@@ -19,6 +19,8 @@ package pulsemanager
 import (
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
+
+	"github.com/insolar/insolar/instrumentation/insmetrics"
 )
 
 var (
@@ -28,7 +30,7 @@ var (
 )
 
 func init() {
-	err := view.Register(
+	err := insmetrics.Register(
 
 		&view.View{
 			Name:        statCleanLatencyTotal.Name(),
@@ -32,10 +32,12 @@ import (
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/core/message"
 	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/instrumentation/crashreport"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/instrumentation/instracer"
 	"github.com/insolar/insolar/ledger/artifactmanager"
 	"github.com/insolar/insolar/ledger/heavyclient"
+	"github.com/insolar/insolar/ledger/jetplanner"
 	"github.com/insolar/insolar/ledger/recentstorage"
 	"github.com/insolar/insolar/ledger/storage"
 	"github.com/insolar/insolar/ledger/storage/index"
@@ -68,6 +70,7 @@ type PulseManager struct {
 	ReplicaStorage             storage.ReplicaStorage          `inject:""`
 	DBContext                  storage.DBContext               `inject:""`
 	StorageCleaner             storage.Cleaner                 `inject:""`
+	JetPlanner                 jetplanner.Planner              `inject:""`
 
 	// TODO: move clients pool to component - @nordicdyno - 18.Dec.2018
 	syncClientsPool *heavyclient.Pool
@@ -81,6 +84,9 @@ type PulseManager struct {
 
 	// stores pulse manager options
 	options pmOptions
+
+	// chaos injects pulse faults in builds with "chaos" tag
+	chaos *chaosMonkey
 }
 
 type jetInfo struct {
@@ -98,6 +104,9 @@ type pmOptions struct {
 	storeLightPulses      int
 	heavySyncMessageLimit int
 	lightChainLimit       int
+
+	heavyMaxCongestionDelay time.Duration
+	heavySyncConcurrency    int
 }
 
 // NewPulseManager creates PulseManager instance.
@@ -113,7 +122,11 @@ func NewPulseManager(conf configuration.Ledger) *PulseManager {
 			storeLightPulses:      conf.LightChainLimit,
 			heavySyncMessageLimit: pmconf.HeavySyncMessageLimit,
 			lightChainLimit:       conf.LightChainLimit,
+
+			heavyMaxCongestionDelay: pmconf.HeavyMaxCongestionDelay,
+			heavySyncConcurrency:    pmconf.HeavySyncConcurrency,
 		},
+		chaos: newChaosMonkey(pmconf),
 	}
 	return pm
 }
@@ -137,6 +150,8 @@ func (m *PulseManager) processEndPulse(
 				return errors.Wrapf(err, "create drop on pulse %v failed", currentPulse.PulseNumber)
 			}
 
+			go m.notifyDueTimers(ctx, m.dueTimers(ctx, info.id, newPulse.PulseNumber))
+
 			sender := func(msg message.HotData, jetID core.RecordID) {
 				ctx, span := instracer.StartSpan(ctx, "pulse.send_hot")
 				defer span.End()
@@ -323,7 +338,7 @@ func (m *PulseManager) getExecutorHotData(
 
 	requestCount := 0
 	for objID, objContext := range pendingStorage.GetRequests() {
-		if len(objContext.Requests) > 0 {
+		if len(objContext.Requests) > 0 || len(objContext.Timers) > 0 {
 			pendingRequests[objID] = objContext
 			requestCount += len(objContext.Requests)
 		}
@@ -351,6 +366,41 @@ func (m *PulseManager) getExecutorHotData(
 	return msg, nil
 }
 
+// dueTimers collects timers of jet which are due in pulse by their objects.
+func (m *PulseManager) dueTimers(
+	ctx context.Context, jetID core.RecordID, pulse core.PulseNumber,
+) map[core.RecordID]map[core.RecordID][]byte {
+	due := map[core.RecordID]map[core.RecordID][]byte{}
+	for objID, objContext := range m.RecentStorageProvider.GetPendingStorage(ctx, jetID).GetRequests() {
+		for _, timer := range objContext.Timers {
+			if timer.Pulse > pulse {
+				continue
+			}
+			if due[objID] == nil {
+				due[objID] = map[core.RecordID][]byte{}
+			}
+			due[objID][timer.ID] = timer.Message
+		}
+	}
+	return due
+}
+
+// notifyDueTimers sends due timers to executors of their objects. Timers stay pending until executor closes them,
+// so they are sent again on next pulse if notification is lost.
+func (m *PulseManager) notifyDueTimers(ctx context.Context, due map[core.RecordID]map[core.RecordID][]byte) {
+	logger := inslogger.FromContext(ctx)
+	for objID, timers := range due {
+		rep, err := m.Bus.Send(ctx, &message.DueTimers{Object: objID, Timers: timers}, nil)
+		if err != nil {
+			logger.Error(errors.Wrapf(err, "failed to notify about due timers of %s", objID.DebugString()))
+			continue
+		}
+		if _, ok := rep.(*reply.OK); !ok {
+			logger.Errorf("received unexpected reply on due timers notification: %#v", rep)
+		}
+	}
+}
+
 // TODO: @andreyromancev. 12.01.19. Remove when dynamic split is working.
 var splitCount = 5
 
@@ -371,7 +421,12 @@ func (m *PulseManager) processJets(ctx context.Context, currentPulse, newPulse c
 		"current_pulse": currentPulse,
 		"new_pulse":     newPulse,
 	})
+	plan, err := m.JetPlanner.Plan(ctx, currentPulse, jetIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to plan jets rebalancing")
+	}
 	indexToSplit := rand.Intn(len(jetIDs))
+	maintenance := m.maintenanceAt(newPulse)
 	for i, jetID := range jetIDs {
 		wasExecutor := false
 		executor, err := m.JetCoordinator.LightExecutorForJet(ctx, jetID, currentPulse)
@@ -390,9 +445,18 @@ func (m *PulseManager) processJets(ctx context.Context, currentPulse, newPulse c
 		}
 
 		info := jetInfo{id: jetID}
-		if indexToSplit == i && splitCount > 0 {
+		split := false
+		if m.JetPlanner.Approved() {
+			split = plan.IsSplit(jetID)
+		} else if indexToSplit == i && splitCount > 0 {
 			splitCount--
-
+			split = true
+		}
+		if split && maintenance != nil {
+			logger.Infof("jet split is postponed by maintenance until pulse %d", maintenance.End)
+			split = false
+		}
+		if split {
 			leftJetID, rightJetID, err := m.JetStorage.SplitJetTree(
 				ctx,
 				newPulse,
@@ -484,6 +548,10 @@ func (m *PulseManager) rewriteHotData(ctx context.Context, fromJetID, toJetID co
 
 // Set set's new pulse and closes current jet drop.
 func (m *PulseManager) Set(ctx context.Context, newPulse core.Pulse, persist bool) error {
+	return m.chaos.set(ctx, newPulse, persist, m.set)
+}
+
+func (m *PulseManager) set(ctx context.Context, newPulse core.Pulse, persist bool) error {
 	m.setLock.Lock()
 	defer m.setLock.Unlock()
 	if m.stopped {
@@ -517,7 +585,9 @@ func (m *PulseManager) Set(ctx context.Context, newPulse core.Pulse, persist boo
 		}
 		m.postProcessJets(ctx, newPulse, jets)
 		m.addSync(ctx, jets, oldPulse.PulseNumber)
-		go m.cleanLightData(ctx, newPulse, jetIndexesRemoved)
+		crashreport.Go(ctx, "PulseManager.cleanLightData", func() {
+			m.cleanLightData(ctx, newPulse, jetIndexesRemoved)
+		})
 	}
 
 	err = m.Bus.OnPulse(ctx, newPulse)
@@ -583,7 +653,7 @@ func (m *PulseManager) setUnderGilSection(
 			m.PulseStorage.Unlock()
 			return nil, nil, nil, nil, errors.Wrap(err, "call of AddPulse failed")
 		}
-		err = m.NodeStorage.SetActiveNodes(newPulse.PulseNumber, m.NodeNet.GetWorkingNodes())
+		err = m.NodeStorage.SetActiveNodes(newPulse.PulseNumber, m.assignableNodes(m.NodeNet.GetWorkingNodes()))
 		if err != nil {
 			m.PulseStorage.Unlock()
 			return nil, nil, nil, nil, errors.Wrap(err, "call of SetActiveNodes failed")
@@ -681,6 +751,10 @@ func (m *PulseManager) cleanLightData(ctx context.Context, newPulse core.Pulse,
 	}
 
 	pn := p.Pulse.PulseNumber
+	// Executors are selected by nodes and entropy of the first pulse of maintenance window until it ends.
+	if window := m.maintenanceAt(newPulse.PulseNumber); window != nil && window.Start < pn {
+		pn = window.Start
+	}
 
 	m.NodeStorage.RemoveActiveNodesUntil(pn)
 
@@ -702,6 +776,41 @@ func (m *PulseManager) cleanLightData(ctx context.Context, newPulse core.Pulse,
 	}
 }
 
+// maintenanceAt returns maintenance window agreed by the network which contains provided pulse.
+func (m *PulseManager) maintenanceAt(pulse core.PulseNumber) *core.MaintenanceWindow {
+	if schedule, ok := m.NodeNet.(core.MaintenanceSchedule); ok {
+		return schedule.MaintenanceAt(pulse)
+	}
+	return nil
+}
+
+// assignableNodes excludes virtual and light material nodes which were suspended by the network because of exhausted
+// storage. Suspensions are ignored for a role if every node of this role is suspended.
+func (m *PulseManager) assignableNodes(nodes []core.Node) []core.Node {
+	suspensions, ok := m.NodeNet.(core.RoleSuspensions)
+	if !ok {
+		return nodes
+	}
+
+	available := map[core.StaticRole]bool{}
+	for _, node := range nodes {
+		if !suspensions.IsSuspended(node.ID()) {
+			available[node.Role()] = true
+		}
+	}
+
+	result := make([]core.Node, 0, len(nodes))
+	for _, node := range nodes {
+		role := node.Role()
+		suspendable := role == core.StaticRoleVirtual || role == core.StaticRoleLightMaterial
+		if suspendable && available[role] && suspensions.IsSuspended(node.ID()) {
+			continue
+		}
+		result = append(result, node)
+	}
+	return result
+}
+
 func (m *PulseManager) prepareArtifactManagerMessageHandlerForNextPulse(ctx context.Context, newPulse core.Pulse, jets []jetInfo) {
 	ctx, span := instracer.StartSpan(ctx, "early.close")
 	defer span.End()
@@ -757,8 +866,10 @@ func (m *PulseManager) Start(ctx context.Context) error {
 			m.StorageCleaner,
 			m.DBContext,
 			heavyclient.Options{
-				SyncMessageLimit: m.options.heavySyncMessageLimit,
-				PulsesDeltaLimit: m.options.lightChainLimit,
+				SyncMessageLimit:   m.options.heavySyncMessageLimit,
+				PulsesDeltaLimit:   m.options.lightChainLimit,
+				MaxCongestionDelay: m.options.heavyMaxCongestionDelay,
+				Concurrency:        m.options.heavySyncConcurrency,
 			},
 		)
 		m.syncClientsPool = heavySyncPool
@@ -772,6 +883,14 @@ func (m *PulseManager) Start(ctx context.Context) error {
 	return m.restoreGenesisRecentObjects(ctx)
 }
 
+// ReplicationLag implements core.ReplicationMonitor, it is always zero on nodes which don't replicate to heavy.
+func (m *PulseManager) ReplicationLag(ctx context.Context) int {
+	if m.syncClientsPool == nil {
+		return 0
+	}
+	return m.syncClientsPool.ReplicationLag()
+}
+
 func (m *PulseManager) restoreLatestPulse(ctx context.Context) error {
 	if m.NodeNet.GetOrigin().Role() != core.StaticRoleHeavyMaterial {
 		return nil
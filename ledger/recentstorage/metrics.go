@@ -38,7 +38,7 @@ var (
 
 func init() {
 	commontags := []tag.Key{tagJet}
-	err := view.Register(
+	err := insmetrics.Register(
 		&view.View{
 			Name:        statRecentStorageObjectsAdded.Name(),
 			Description: statRecentStorageObjectsAdded.Description(),
@@ -25,6 +25,11 @@ type PendingStorageMock struct {
 	AddPendingRequestPreCounter uint64
 	AddPendingRequestMock       mPendingStorageMockAddPendingRequest
 
+	AddTimerFunc       func(p context.Context, p1 core.RecordID, p2 PendingTimer)
+	AddTimerCounter    uint64
+	AddTimerPreCounter uint64
+	AddTimerMock       mPendingStorageMockAddTimer
+
 	GetRequestsFunc       func() (r map[core.RecordID]PendingObjectContext)
 	GetRequestsCounter    uint64
 	GetRequestsPreCounter uint64
@@ -40,6 +45,11 @@ type PendingStorageMock struct {
 	RemovePendingRequestPreCounter uint64
 	RemovePendingRequestMock       mPendingStorageMockRemovePendingRequest
 
+	RemoveTimerFunc       func(p context.Context, p1 core.RecordID, p2 core.RecordID)
+	RemoveTimerCounter    uint64
+	RemoveTimerPreCounter uint64
+	RemoveTimerMock       mPendingStorageMockRemoveTimer
+
 	SetContextToObjectFunc       func(p context.Context, p1 core.RecordID, p2 PendingObjectContext)
 	SetContextToObjectCounter    uint64
 	SetContextToObjectPreCounter uint64
@@ -55,9 +65,11 @@ func NewPendingStorageMock(t minimock.Tester) *PendingStorageMock {
 	}
 
 	m.AddPendingRequestMock = mPendingStorageMockAddPendingRequest{mock: m}
+	m.AddTimerMock = mPendingStorageMockAddTimer{mock: m}
 	m.GetRequestsMock = mPendingStorageMockGetRequests{mock: m}
 	m.GetRequestsForObjectMock = mPendingStorageMockGetRequestsForObject{mock: m}
 	m.RemovePendingRequestMock = mPendingStorageMockRemovePendingRequest{mock: m}
+	m.RemoveTimerMock = mPendingStorageMockRemoveTimer{mock: m}
 	m.SetContextToObjectMock = mPendingStorageMockSetContextToObject{mock: m}
 
 	return m
@@ -188,6 +200,131 @@ func (m *PendingStorageMock) AddPendingRequestFinished() bool {
 	return true
 }
 
+type mPendingStorageMockAddTimer struct {
+	mock              *PendingStorageMock
+	mainExpectation   *PendingStorageMockAddTimerExpectation
+	expectationSeries []*PendingStorageMockAddTimerExpectation
+}
+
+type PendingStorageMockAddTimerExpectation struct {
+	input *PendingStorageMockAddTimerInput
+}
+
+type PendingStorageMockAddTimerInput struct {
+	p  context.Context
+	p1 core.RecordID
+	p2 PendingTimer
+}
+
+//Expect specifies that invocation of PendingStorage.AddTimer is expected from 1 to Infinity times
+func (m *mPendingStorageMockAddTimer) Expect(p context.Context, p1 core.RecordID, p2 PendingTimer) *mPendingStorageMockAddTimer {
+	m.mock.AddTimerFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &PendingStorageMockAddTimerExpectation{}
+	}
+	m.mainExpectation.input = &PendingStorageMockAddTimerInput{p, p1, p2}
+	return m
+}
+
+//Return specifies results of invocation of PendingStorage.AddTimer
+func (m *mPendingStorageMockAddTimer) Return() *PendingStorageMock {
+	m.mock.AddTimerFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &PendingStorageMockAddTimerExpectation{}
+	}
+
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of PendingStorage.AddTimer is expected once
+func (m *mPendingStorageMockAddTimer) ExpectOnce(p context.Context, p1 core.RecordID, p2 PendingTimer) *PendingStorageMockAddTimerExpectation {
+	m.mock.AddTimerFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &PendingStorageMockAddTimerExpectation{}
+	expectation.input = &PendingStorageMockAddTimerInput{p, p1, p2}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+//Set uses given function f as a mock of PendingStorage.AddTimer method
+func (m *mPendingStorageMockAddTimer) Set(f func(p context.Context, p1 core.RecordID, p2 PendingTimer)) *PendingStorageMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.AddTimerFunc = f
+	return m.mock
+}
+
+//AddTimer implements github.com/insolar/insolar/ledger/recentstorage.PendingStorage interface
+func (m *PendingStorageMock) AddTimer(p context.Context, p1 core.RecordID, p2 PendingTimer) {
+	counter := atomic.AddUint64(&m.AddTimerPreCounter, 1)
+	defer atomic.AddUint64(&m.AddTimerCounter, 1)
+
+	if len(m.AddTimerMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.AddTimerMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to PendingStorageMock.AddTimer. %v %v %v", p, p1, p2)
+			return
+		}
+
+		input := m.AddTimerMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, PendingStorageMockAddTimerInput{p, p1, p2}, "PendingStorage.AddTimer got unexpected parameters")
+
+		return
+	}
+
+	if m.AddTimerMock.mainExpectation != nil {
+
+		input := m.AddTimerMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, PendingStorageMockAddTimerInput{p, p1, p2}, "PendingStorage.AddTimer got unexpected parameters")
+		}
+
+		return
+	}
+
+	if m.AddTimerFunc == nil {
+		m.t.Fatalf("Unexpected call to PendingStorageMock.AddTimer. %v %v %v", p, p1, p2)
+		return
+	}
+
+	m.AddTimerFunc(p, p1, p2)
+}
+
+//AddTimerMinimockCounter returns a count of PendingStorageMock.AddTimerFunc invocations
+func (m *PendingStorageMock) AddTimerMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.AddTimerCounter)
+}
+
+//AddTimerMinimockPreCounter returns the value of PendingStorageMock.AddTimer invocations
+func (m *PendingStorageMock) AddTimerMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.AddTimerPreCounter)
+}
+
+//AddTimerFinished returns true if mock invocations count is ok
+func (m *PendingStorageMock) AddTimerFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.AddTimerMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.AddTimerCounter) == uint64(len(m.AddTimerMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.AddTimerMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.AddTimerCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.AddTimerFunc != nil {
+		return atomic.LoadUint64(&m.AddTimerCounter) > 0
+	}
+
+	return true
+}
+
 type mPendingStorageMockGetRequests struct {
 	mock              *PendingStorageMock
 	mainExpectation   *PendingStorageMockGetRequestsExpectation
@@ -594,6 +731,131 @@ func (m *PendingStorageMock) RemovePendingRequestFinished() bool {
 	return true
 }
 
+type mPendingStorageMockRemoveTimer struct {
+	mock              *PendingStorageMock
+	mainExpectation   *PendingStorageMockRemoveTimerExpectation
+	expectationSeries []*PendingStorageMockRemoveTimerExpectation
+}
+
+type PendingStorageMockRemoveTimerExpectation struct {
+	input *PendingStorageMockRemoveTimerInput
+}
+
+type PendingStorageMockRemoveTimerInput struct {
+	p  context.Context
+	p1 core.RecordID
+	p2 core.RecordID
+}
+
+//Expect specifies that invocation of PendingStorage.RemoveTimer is expected from 1 to Infinity times
+func (m *mPendingStorageMockRemoveTimer) Expect(p context.Context, p1 core.RecordID, p2 core.RecordID) *mPendingStorageMockRemoveTimer {
+	m.mock.RemoveTimerFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &PendingStorageMockRemoveTimerExpectation{}
+	}
+	m.mainExpectation.input = &PendingStorageMockRemoveTimerInput{p, p1, p2}
+	return m
+}
+
+//Return specifies results of invocation of PendingStorage.RemoveTimer
+func (m *mPendingStorageMockRemoveTimer) Return() *PendingStorageMock {
+	m.mock.RemoveTimerFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &PendingStorageMockRemoveTimerExpectation{}
+	}
+
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of PendingStorage.RemoveTimer is expected once
+func (m *mPendingStorageMockRemoveTimer) ExpectOnce(p context.Context, p1 core.RecordID, p2 core.RecordID) *PendingStorageMockRemoveTimerExpectation {
+	m.mock.RemoveTimerFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &PendingStorageMockRemoveTimerExpectation{}
+	expectation.input = &PendingStorageMockRemoveTimerInput{p, p1, p2}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+//Set uses given function f as a mock of PendingStorage.RemoveTimer method
+func (m *mPendingStorageMockRemoveTimer) Set(f func(p context.Context, p1 core.RecordID, p2 core.RecordID)) *PendingStorageMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.RemoveTimerFunc = f
+	return m.mock
+}
+
+//RemoveTimer implements github.com/insolar/insolar/ledger/recentstorage.PendingStorage interface
+func (m *PendingStorageMock) RemoveTimer(p context.Context, p1 core.RecordID, p2 core.RecordID) {
+	counter := atomic.AddUint64(&m.RemoveTimerPreCounter, 1)
+	defer atomic.AddUint64(&m.RemoveTimerCounter, 1)
+
+	if len(m.RemoveTimerMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.RemoveTimerMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to PendingStorageMock.RemoveTimer. %v %v %v", p, p1, p2)
+			return
+		}
+
+		input := m.RemoveTimerMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, PendingStorageMockRemoveTimerInput{p, p1, p2}, "PendingStorage.RemoveTimer got unexpected parameters")
+
+		return
+	}
+
+	if m.RemoveTimerMock.mainExpectation != nil {
+
+		input := m.RemoveTimerMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, PendingStorageMockRemoveTimerInput{p, p1, p2}, "PendingStorage.RemoveTimer got unexpected parameters")
+		}
+
+		return
+	}
+
+	if m.RemoveTimerFunc == nil {
+		m.t.Fatalf("Unexpected call to PendingStorageMock.RemoveTimer. %v %v %v", p, p1, p2)
+		return
+	}
+
+	m.RemoveTimerFunc(p, p1, p2)
+}
+
+//RemoveTimerMinimockCounter returns a count of PendingStorageMock.RemoveTimerFunc invocations
+func (m *PendingStorageMock) RemoveTimerMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.RemoveTimerCounter)
+}
+
+//RemoveTimerMinimockPreCounter returns the value of PendingStorageMock.RemoveTimer invocations
+func (m *PendingStorageMock) RemoveTimerMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.RemoveTimerPreCounter)
+}
+
+//RemoveTimerFinished returns true if mock invocations count is ok
+func (m *PendingStorageMock) RemoveTimerFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.RemoveTimerMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.RemoveTimerCounter) == uint64(len(m.RemoveTimerMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.RemoveTimerMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.RemoveTimerCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.RemoveTimerFunc != nil {
+		return atomic.LoadUint64(&m.RemoveTimerCounter) > 0
+	}
+
+	return true
+}
+
 type mPendingStorageMockSetContextToObject struct {
 	mock              *PendingStorageMock
 	mainExpectation   *PendingStorageMockSetContextToObjectExpectation
@@ -727,6 +989,10 @@ func (m *PendingStorageMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to PendingStorageMock.AddPendingRequest")
 	}
 
+	if !m.AddTimerFinished() {
+		m.t.Fatal("Expected call to PendingStorageMock.AddTimer")
+	}
+
 	if !m.GetRequestsFinished() {
 		m.t.Fatal("Expected call to PendingStorageMock.GetRequests")
 	}
@@ -739,6 +1005,10 @@ func (m *PendingStorageMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to PendingStorageMock.RemovePendingRequest")
 	}
 
+	if !m.RemoveTimerFinished() {
+		m.t.Fatal("Expected call to PendingStorageMock.RemoveTimer")
+	}
+
 	if !m.SetContextToObjectFinished() {
 		m.t.Fatal("Expected call to PendingStorageMock.SetContextToObject")
 	}
@@ -764,6 +1034,10 @@ func (m *PendingStorageMock) MinimockFinish() {
 		m.t.Fatal("Expected call to PendingStorageMock.AddPendingRequest")
 	}
 
+	if !m.AddTimerFinished() {
+		m.t.Fatal("Expected call to PendingStorageMock.AddTimer")
+	}
+
 	if !m.GetRequestsFinished() {
 		m.t.Fatal("Expected call to PendingStorageMock.GetRequests")
 	}
@@ -776,6 +1050,10 @@ func (m *PendingStorageMock) MinimockFinish() {
 		m.t.Fatal("Expected call to PendingStorageMock.RemovePendingRequest")
 	}
 
+	if !m.RemoveTimerFinished() {
+		m.t.Fatal("Expected call to PendingStorageMock.RemoveTimer")
+	}
+
 	if !m.SetContextToObjectFinished() {
 		m.t.Fatal("Expected call to PendingStorageMock.SetContextToObject")
 	}
@@ -795,9 +1073,11 @@ func (m *PendingStorageMock) MinimockWait(timeout time.Duration) {
 	for {
 		ok := true
 		ok = ok && m.AddPendingRequestFinished()
+		ok = ok && m.AddTimerFinished()
 		ok = ok && m.GetRequestsFinished()
 		ok = ok && m.GetRequestsForObjectFinished()
 		ok = ok && m.RemovePendingRequestFinished()
+		ok = ok && m.RemoveTimerFinished()
 		ok = ok && m.SetContextToObjectFinished()
 
 		if ok {
@@ -811,6 +1091,10 @@ func (m *PendingStorageMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to PendingStorageMock.AddPendingRequest")
 			}
 
+			if !m.AddTimerFinished() {
+				m.t.Error("Expected call to PendingStorageMock.AddTimer")
+			}
+
 			if !m.GetRequestsFinished() {
 				m.t.Error("Expected call to PendingStorageMock.GetRequests")
 			}
@@ -823,6 +1107,10 @@ func (m *PendingStorageMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to PendingStorageMock.RemovePendingRequest")
 			}
 
+			if !m.RemoveTimerFinished() {
+				m.t.Error("Expected call to PendingStorageMock.RemoveTimer")
+			}
+
 			if !m.SetContextToObjectFinished() {
 				m.t.Error("Expected call to PendingStorageMock.SetContextToObject")
 			}
@@ -843,6 +1131,10 @@ func (m *PendingStorageMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.AddTimerFinished() {
+		return false
+	}
+
 	if !m.GetRequestsFinished() {
 		return false
 	}
@@ -855,6 +1147,10 @@ func (m *PendingStorageMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.RemoveTimerFinished() {
+		return false
+	}
+
 	if !m.SetContextToObjectFinished() {
 		return false
 	}
@@ -62,4 +62,7 @@ type PendingStorage interface {
 	GetRequestsForObject(obj core.RecordID) []core.RecordID
 
 	RemovePendingRequest(ctx context.Context, obj, req core.RecordID)
+
+	AddTimer(ctx context.Context, obj core.RecordID, timer PendingTimer)
+	RemoveTimer(ctx context.Context, obj, timer core.RecordID)
 }
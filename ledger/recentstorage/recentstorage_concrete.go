@@ -122,7 +122,7 @@ func (p *RecentStorageProvider) ClonePendingStorage(ctx context.Context, fromJet
 		requests: map[core.RecordID]*lockedPendingObjectContext{},
 	}
 	for objID, pendingContext := range fromStorage.requests {
-		if len(pendingContext.Context.Requests) == 0 {
+		if len(pendingContext.Context.Requests) == 0 && len(pendingContext.Context.Timers) == 0 {
 			continue
 		}
 
@@ -134,6 +134,7 @@ func (p *RecentStorageProvider) ClonePendingStorage(ctx context.Context, fromJet
 		}
 
 		clone.Requests = append(clone.Requests, pendingContext.Context.Requests...)
+		clone.Timers = append(clone.Timers, pendingContext.Context.Timers...)
 		toStorage.requests[objID] = &lockedPendingObjectContext{Context: &clone}
 
 		pendingContext.lock.Unlock()
@@ -312,6 +313,16 @@ type PendingStorageConcrete struct {
 type PendingObjectContext struct {
 	Active   bool
 	Requests []core.RecordID
+	// Timers are calls object scheduled on itself, they aren't requests until executor registers them.
+	Timers []PendingTimer
+}
+
+// PendingTimer is a call scheduled by object for future pulse. It's kept until executor of due pulse
+// registers the call and closes the timer.
+type PendingTimer struct {
+	ID      core.RecordID
+	Pulse   core.PulseNumber
+	Message []byte
 }
 
 type lockedPendingObjectContext struct {
@@ -381,6 +392,7 @@ func (r *PendingStorageConcrete) GetRequests() map[core.RecordID]PendingObjectCo
 			Requests: []core.RecordID{},
 		}
 		objectClone.Requests = append(objectClone.Requests, objContext.Context.Requests...)
+		objectClone.Timers = append(objectClone.Timers, objContext.Context.Timers...)
 		requestsClone[objID] = objectClone
 
 		objContext.lock.RUnlock()
@@ -452,3 +464,51 @@ func (r *PendingStorageConcrete) RemovePendingRequest(ctx context.Context, obj,
 	ctx = insmetrics.InsertTag(ctx, tagJet, r.jetID.DebugString())
 	stats.Record(ctx, statRecentStoragePendingsRemoved.M(1))
 }
+
+// AddTimer adds a call scheduled by object for future pulse
+func (r *PendingStorageConcrete) AddTimer(ctx context.Context, obj core.RecordID, timer PendingTimer) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	objectContext, ok := r.requests[obj]
+	if !ok {
+		objectContext = &lockedPendingObjectContext{
+			Context: &PendingObjectContext{
+				Active:   true,
+				Requests: []core.RecordID{},
+			},
+		}
+		r.requests[obj] = objectContext
+	}
+
+	objectContext.lock.Lock()
+	defer objectContext.lock.Unlock()
+
+	for _, t := range objectContext.Context.Timers {
+		if t.ID == timer.ID {
+			return
+		}
+	}
+	objectContext.Context.Timers = append(objectContext.Context.Timers, timer)
+}
+
+// RemoveTimer removes timer of object when executor closes it
+func (r *PendingStorageConcrete) RemoveTimer(ctx context.Context, obj, timer core.RecordID) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	objContext, ok := r.requests[obj]
+	if !ok {
+		return
+	}
+
+	objContext.lock.Lock()
+	defer objContext.lock.Unlock()
+
+	for i, t := range objContext.Context.Timers {
+		if t.ID == timer {
+			objContext.Context.Timers = append(objContext.Context.Timers[:i], objContext.Context.Timers[i+1:]...)
+			return
+		}
+	}
+}
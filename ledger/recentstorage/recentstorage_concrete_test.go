@@ -509,3 +509,29 @@ func TestPendingStorageConcrete_RemovePendingRequest_RemoveNotExisting(t *testin
 	require.Equal(t, first, pendingStorage.requests[objID].Context.Requests[0])
 	require.Equal(t, second, pendingStorage.requests[objID].Context.Requests[1])
 }
+
+func TestPendingStorageConcrete_Timers(t *testing.T) {
+	t.Parallel()
+
+	ctx := inslogger.TestContext(t)
+	objID := testutils.RandomID()
+	first := PendingTimer{ID: testutils.RandomID(), Pulse: core.FirstPulseNumber + 10, Message: []byte{1}}
+	second := PendingTimer{ID: testutils.RandomID(), Pulse: core.FirstPulseNumber + 20, Message: []byte{2}}
+
+	pendingStorage := NewPendingStorage(testutils.RandomID())
+	pendingStorage.AddTimer(ctx, objID, first)
+	pendingStorage.AddTimer(ctx, objID, second)
+	pendingStorage.AddTimer(ctx, objID, first)
+	require.Equal(t, []PendingTimer{first, second}, pendingStorage.GetRequests()[objID].Timers)
+	require.Empty(t, pendingStorage.GetRequestsForObject(objID))
+
+	provider := NewRecentStorageProvider(0)
+	provider.pendingStorages[pendingStorage.jetID] = pendingStorage
+	toJetID := testutils.RandomID()
+	provider.ClonePendingStorage(ctx, pendingStorage.jetID, toJetID)
+	require.Equal(t, []PendingTimer{first, second}, provider.GetPendingStorage(ctx, toJetID).GetRequests()[objID].Timers)
+
+	pendingStorage.RemoveTimer(ctx, objID, first.ID)
+	pendingStorage.RemoveTimer(ctx, objID, testutils.RandomID())
+	require.Equal(t, []PendingTimer{second}, pendingStorage.GetRequests()[objID].Timers)
+}
@@ -0,0 +1,78 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package scrubber
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+
+	"github.com/insolar/insolar/instrumentation/insmetrics"
+)
+
+var (
+	statChecked = stats.Int64(
+		"heavy/scrubber/checked",
+		"number of stored entries verified against integrity hashes",
+		stats.UnitDimensionless,
+	)
+	statCorrupted = stats.Int64(
+		"heavy/scrubber/corrupted",
+		"number of stored entries found corrupted",
+		stats.UnitDimensionless,
+	)
+	statRepaired = stats.Int64(
+		"heavy/scrubber/repaired",
+		"number of corrupted entries repaired from other heavy nodes",
+		stats.UnitDimensionless,
+	)
+	statQuarantined = stats.Int64(
+		"heavy/scrubber/quarantined",
+		"number of corrupted entries waiting for repair",
+		stats.UnitDimensionless,
+	)
+)
+
+func init() {
+	err := insmetrics.Register(
+		&view.View{
+			Name:        statChecked.Name(),
+			Description: statChecked.Description(),
+			Measure:     statChecked,
+			Aggregation: view.Sum(),
+		},
+		&view.View{
+			Name:        statCorrupted.Name(),
+			Description: statCorrupted.Description(),
+			Measure:     statCorrupted,
+			Aggregation: view.Sum(),
+		},
+		&view.View{
+			Name:        statRepaired.Name(),
+			Description: statRepaired.Description(),
+			Measure:     statRepaired,
+			Aggregation: view.Sum(),
+		},
+		&view.View{
+			Name:        statQuarantined.Name(),
+			Description: statQuarantined.Description(),
+			Measure:     statQuarantined,
+			Aggregation: view.LastValue(),
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+}
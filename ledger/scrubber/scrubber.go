@@ -0,0 +1,185 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+// Package scrubber verifies data stored on heavy material node in background, quarantines corrupted records
+// and repairs them from other heavy nodes.
+package scrubber
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.opencensus.io/stats"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage"
+)
+
+// heavyReplicaProvider is implemented by jet coordinators aware of heavy read replicas.
+type heavyReplicaProvider interface {
+	HeavyReplicas() []core.RecordRef
+}
+
+// Scrubber re-reads records and blobs stored on heavy over time and verifies them against integrity hashes.
+// Corrupted entries are quarantined until intact copy is received from other heavy node.
+type Scrubber struct {
+	Bus              core.MessageBus          `inject:""`
+	JetCoordinator   core.JetCoordinator      `inject:""`
+	PulseStorage     core.PulseStorage        `inject:""`
+	IntegrityStorage storage.IntegrityStorage `inject:""`
+
+	conf    configuration.Scrubber
+	isHeavy bool
+
+	lock sync.Mutex
+	// next is a key verification continues from, nil starts new round
+	next []byte
+	// corrupted holds keys of quarantined entries waiting for repair
+	corrupted map[string][]byte
+}
+
+// NewScrubber creates new scrubber. It works on heavy material nodes only.
+func NewScrubber(conf configuration.Ledger, certificate core.Certificate) *Scrubber {
+	return &Scrubber{
+		conf:      conf.Scrubber,
+		isHeavy:   certificate.GetRole() == core.StaticRoleHeavyMaterial,
+		corrupted: map[string][]byte{},
+	}
+}
+
+// Init registers handler serving intact records to other heavy nodes.
+func (s *Scrubber) Init(ctx context.Context) error {
+	if s.isHeavy {
+		s.Bus.MustRegister(core.TypeGetKeyValues, s.handleGetKeyValues)
+	}
+	return nil
+}
+
+// PeriodicTasks returns tasks of scrubber to be run by scheduler.
+func (s *Scrubber) PeriodicTasks() []core.PeriodicTask {
+	if !s.isHeavy || s.conf.Interval <= 0 || s.conf.BatchSize <= 0 {
+		return nil
+	}
+	return []core.PeriodicTask{{
+		Name:     "ledger.scrub",
+		Schedule: core.TaskSchedule{Every: s.conf.Interval},
+		Run:      s.Scrub,
+	}}
+}
+
+// Scrub verifies next batch of stored entries and tries to repair corrupted ones.
+func (s *Scrubber) Scrub(ctx context.Context) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	logger := inslogger.FromContext(ctx)
+	report, err := s.IntegrityStorage.VerifyIntegrity(ctx, s.next, s.conf.BatchSize)
+	if err != nil {
+		return errors.Wrap(err, "[ Scrub ] verification failed")
+	}
+	s.next = report.Next
+	stats.Record(ctx, statChecked.M(int64(report.Checked)))
+
+	for _, key := range report.Corrupted {
+		if _, ok := s.corrupted[string(key)]; !ok {
+			logger.Errorf("[ Scrub ] entry %x doesn't match its integrity hash, it's quarantined", key)
+			stats.Record(ctx, statCorrupted.M(1))
+		}
+		s.corrupted[string(key)] = key
+	}
+	stats.Record(ctx, statQuarantined.M(int64(len(s.corrupted))))
+
+	if len(s.corrupted) > 0 {
+		s.repair(ctx)
+	}
+	return nil
+}
+
+// repair requests quarantined entries from other heavy nodes until all of them are restored.
+func (s *Scrubber) repair(ctx context.Context) {
+	logger := inslogger.FromContext(ctx)
+	for _, peer := range s.peers(ctx) {
+		if len(s.corrupted) == 0 {
+			break
+		}
+		keys := make([][]byte, 0, len(s.corrupted))
+		for _, key := range s.corrupted {
+			keys = append(keys, key)
+		}
+
+		peer := peer
+		genericReply, err := s.Bus.Send(ctx, &message.GetKeyValues{Keys: keys}, &core.MessageSendOptions{Receiver: &peer})
+		if err != nil {
+			logger.Warnf("[ Scrub ] failed to request records from %s: %s", peer, err)
+			continue
+		}
+		rep, ok := genericReply.(*reply.KeyValues)
+		if !ok {
+			logger.Warnf("[ Scrub ] unexpected reply from %s: %#v", peer, genericReply)
+			continue
+		}
+		repaired, err := s.IntegrityStorage.Repair(ctx, rep.Records)
+		if err != nil {
+			logger.Error(errors.Wrap(err, "[ Scrub ] failed to repair records"))
+			return
+		}
+		for _, key := range repaired {
+			delete(s.corrupted, string(key))
+			logger.Infof("[ Scrub ] entry %x is repaired from %s", key, peer)
+		}
+		stats.Record(ctx, statRepaired.M(int64(len(repaired))))
+	}
+	stats.Record(ctx, statQuarantined.M(int64(len(s.corrupted))))
+}
+
+// peers returns other heavy nodes which may have intact copies of records.
+func (s *Scrubber) peers(ctx context.Context) []core.RecordRef {
+	me := s.JetCoordinator.Me()
+	var candidates []core.RecordRef
+	pulse, err := s.PulseStorage.Current(ctx)
+	if err == nil {
+		heavy, err := s.JetCoordinator.Heavy(ctx, pulse.PulseNumber)
+		if err == nil {
+			candidates = append(candidates, *heavy)
+		}
+	}
+	if provider, ok := s.JetCoordinator.(heavyReplicaProvider); ok {
+		candidates = append(candidates, provider.HeavyReplicas()...)
+	}
+
+	var peers []core.RecordRef
+	seen := map[core.RecordRef]bool{me: true}
+	for _, ref := range candidates {
+		if !seen[ref] {
+			seen[ref] = true
+			peers = append(peers, ref)
+		}
+	}
+	return peers
+}
+
+func (s *Scrubber) handleGetKeyValues(ctx context.Context, parcel core.Parcel) (core.Reply, error) {
+	msg := parcel.Message().(*message.GetKeyValues)
+	kvs, err := s.IntegrityStorage.GetKeyValues(ctx, msg.Keys)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ handleGetKeyValues ] failed to read records")
+	}
+	return &reply.KeyValues{Records: kvs}, nil
+}
@@ -0,0 +1,81 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/insolar/insolar/core"
+	"github.com/pkg/errors"
+)
+
+// APIRequestStorage keeps outcomes of API requests by QID. Outcomes expire after retention period.
+type APIRequestStorage struct {
+	DB DBContext `inject:""`
+
+	retention time.Duration
+}
+
+// NewAPIRequestStorage creates new API request storage. Zero retention keeps outcomes forever.
+func NewAPIRequestStorage(retention time.Duration) *APIRequestStorage {
+	return &APIRequestStorage{retention: retention}
+}
+
+func apiRequestOutcomeKey(qid string) []byte {
+	return prefixkey(scopeIDSystem, []byte{sysAPIRequestOutcome}, []byte(qid))
+}
+
+// SetAPIRequestOutcome archives outcome of API request by its QID.
+func (s *APIRequestStorage) SetAPIRequestOutcome(ctx context.Context, outcome *core.APIRequestOutcome) error {
+	if outcome.QID == "" {
+		return errors.New("[ SetAPIRequestOutcome ] empty QID")
+	}
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(outcome)
+	if err != nil {
+		return errors.Wrap(err, "[ SetAPIRequestOutcome ] failed to encode outcome")
+	}
+
+	entry := &badger.Entry{Key: apiRequestOutcomeKey(outcome.QID), Value: buf.Bytes()}
+	if s.retention > 0 {
+		entry.ExpiresAt = uint64(time.Now().Add(s.retention).Unix())
+	}
+	return s.DB.GetBadgerDB().Update(func(txn *badger.Txn) error {
+		return s.DB.codec().setEntry(txn, entry)
+	})
+}
+
+// GetAPIRequestOutcome returns archived outcome of API request with provided QID or nil if it's missing or expired.
+func (s *APIRequestStorage) GetAPIRequestOutcome(ctx context.Context, qid string) (*core.APIRequestOutcome, error) {
+	buf, err := s.DB.get(ctx, apiRequestOutcomeKey(qid))
+	if err == ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var outcome core.APIRequestOutcome
+	err = gob.NewDecoder(bytes.NewReader(buf)).Decode(&outcome)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ GetAPIRequestOutcome ] failed to decode outcome")
+	}
+	return &outcome, nil
+}
@@ -0,0 +1,75 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package storage_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage"
+	"github.com/insolar/insolar/ledger/storage/storagetest"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestAPIRequestStorage(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	db, cleaner := storagetest.TmpDB(ctx, t)
+	defer cleaner()
+
+	s := storage.NewAPIRequestStorage(time.Hour)
+	s.DB = db
+
+	outcome, err := s.GetAPIRequestOutcome(ctx, "qid")
+	require.NoError(t, err)
+	require.Nil(t, outcome)
+
+	request := testutils.RandomRef()
+	expected := &core.APIRequestOutcome{
+		QID:     "qid",
+		Member:  testutils.RandomRef(),
+		Method:  "Transfer",
+		Request: &request,
+		Result:  []byte(`"OK"`),
+		Time:    time.Unix(1546300800, 0),
+	}
+	require.NoError(t, s.SetAPIRequestOutcome(ctx, expected))
+	require.Error(t, s.SetAPIRequestOutcome(ctx, &core.APIRequestOutcome{}))
+
+	outcome, err = s.GetAPIRequestOutcome(ctx, "qid")
+	require.NoError(t, err)
+	require.Equal(t, expected, outcome)
+
+	// Outcome must expire after retention period.
+	var expiresAt uint64
+	err = db.GetBadgerDB().View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+		for it.Rewind(); it.Valid(); it.Next() {
+			if it.Item().ExpiresAt() > expiresAt {
+				expiresAt = it.Item().ExpiresAt()
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.InDelta(t, time.Now().Add(time.Hour).Unix(), int64(expiresAt), 5)
+}
@@ -0,0 +1,134 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/gob"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/insolar/insolar/core"
+	"github.com/pkg/errors"
+)
+
+// AuditStorage keeps signed records of admin operations ordered by time they were made.
+// Records are written once, attempt to override existing record fails with ErrOverride.
+type AuditStorage struct {
+	DB                  DBContext                `inject:""`
+	CryptographyService core.CryptographyService `inject:""`
+}
+
+// NewAuditStorage creates new audit log storage.
+func NewAuditStorage() *AuditStorage {
+	return &AuditStorage{}
+}
+
+func auditRecordKey(record *core.AuditRecord) []byte {
+	ts := make([]byte, 8)
+	binary.BigEndian.PutUint64(ts, uint64(record.Time.UnixNano()))
+	return prefixkey(scopeIDSystem, []byte{sysAuditRecord}, ts)
+}
+
+// auditRecordData returns serialized record without signature, it's what node signs.
+func auditRecordData(record *core.AuditRecord) ([]byte, error) {
+	unsigned := *record
+	unsigned.Signature = nil
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(unsigned)
+	return buf.Bytes(), err
+}
+
+// AppendAuditRecord signs and appends record to the log, zero time of record is set to the current time.
+func (s *AuditStorage) AppendAuditRecord(ctx context.Context, record *core.AuditRecord) error {
+	if record.Time.IsZero() {
+		record.Time = time.Now()
+	}
+	record.Time = record.Time.UTC()
+
+	data, err := auditRecordData(record)
+	if err != nil {
+		return errors.Wrap(err, "[ AppendAuditRecord ] failed to encode record")
+	}
+	signature, err := s.CryptographyService.Sign(data)
+	if err != nil {
+		return errors.Wrap(err, "[ AppendAuditRecord ] failed to sign record")
+	}
+	record.Signature = signature.Bytes()
+
+	var buf bytes.Buffer
+	err = gob.NewEncoder(&buf).Encode(record)
+	if err != nil {
+		return errors.Wrap(err, "[ AppendAuditRecord ] failed to encode record")
+	}
+	key := auditRecordKey(record)
+	return s.DB.Update(ctx, func(tx *TransactionManager) error {
+		_, err := tx.get(ctx, key)
+		if err == nil {
+			return ErrOverride
+		}
+		if err != ErrNotFound {
+			return err
+		}
+		return tx.set(ctx, key, buf.Bytes())
+	})
+}
+
+// GetAuditRecords returns up to limit most recent records, newest first.
+func (s *AuditStorage) GetAuditRecords(ctx context.Context, limit int) ([]core.AuditRecord, error) {
+	prefix := prefixkey(scopeIDSystem, []byte{sysAuditRecord})
+	records := []core.AuditRecord{}
+	err := s.DB.GetBadgerDB().View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Reverse = true
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Seek(append(prefix, 0xFF)); it.ValidForPrefix(prefix) && len(records) < limit; it.Next() {
+			buf, err := s.DB.codec().decode(it.Item())
+			if err != nil {
+				return err
+			}
+			var record core.AuditRecord
+			err = gob.NewDecoder(bytes.NewReader(buf)).Decode(&record)
+			if err != nil {
+				return errors.Wrap(err, "[ GetAuditRecords ] failed to decode record")
+			}
+			records = append(records, record)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// VerifyAuditRecord checks that record is signed by the node.
+func (s *AuditStorage) VerifyAuditRecord(record *core.AuditRecord) bool {
+	publicKey, err := s.CryptographyService.GetPublicKey()
+	if err != nil {
+		return false
+	}
+	data, err := auditRecordData(record)
+	if err != nil {
+		return false
+	}
+	return s.CryptographyService.Verify(publicKey, core.SignatureFromBytes(record.Signature), data)
+}
@@ -0,0 +1,81 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package storage_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/cryptography"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage"
+	"github.com/insolar/insolar/ledger/storage/storagetest"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestAuditStorage(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	db, cleaner := storagetest.TmpDB(ctx, t)
+	defer cleaner()
+
+	privateKey, err := platformpolicy.NewKeyProcessor().GeneratePrivateKey()
+	require.NoError(t, err)
+
+	s := storage.NewAuditStorage()
+	s.DB = db
+	s.CryptographyService = cryptography.NewKeyBoundCryptographyService(privateKey)
+
+	records, err := s.GetAuditRecords(ctx, 10)
+	require.NoError(t, err)
+	require.Empty(t, records)
+
+	node := testutils.RandomRef()
+	var expected []core.AuditRecord
+	for i := 0; i < 3; i++ {
+		record := core.AuditRecord{
+			Operation: "objects.Reset",
+			Target:    testutils.RandomRef().String(),
+			Reason:    "stuck queue",
+			Remote:    "127.0.0.1:1234",
+			Node:      node,
+			Time:      time.Unix(1546300800+int64(i), 0),
+		}
+		require.NoError(t, s.AppendAuditRecord(ctx, &record))
+		require.NotEmpty(t, record.Signature)
+		expected = append([]core.AuditRecord{record}, expected...)
+	}
+
+	records, err = s.GetAuditRecords(ctx, 2)
+	require.NoError(t, err)
+	require.Equal(t, expected[:2], records, "records must be returned newest first")
+	for _, record := range records {
+		require.True(t, s.VerifyAuditRecord(&record))
+	}
+
+	forged := records[0]
+	forged.Reason = "forged"
+	require.False(t, s.VerifyAuditRecord(&forged))
+
+	duplicate := core.AuditRecord{Operation: "jets.Migrate", Time: expected[0].Time}
+	err = s.AppendAuditRecord(ctx, &duplicate)
+	require.Equal(t, storage.ErrOverride, errors.Cause(err), "records must be written once")
+}
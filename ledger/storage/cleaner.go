@@ -101,6 +101,13 @@ func (c *cleaner) CleanJetRecordsUntilPulse(
 	}
 	allstat["records"] = stat
 
+	if stat, err = c.RemoveJetResultsUntil(ctx, jetID, pn); err != nil {
+		result = multierror.Append(result, errors.Wrap(err, "RemoveJetResultsUntil"))
+		stat.Errors = stat.Scanned
+		stat.Removed = 0
+	}
+	allstat["results"] = stat
+
 	if stat, err = c.RemoveJetDropsUntil(ctx, jetID, pn); err != nil {
 		result = multierror.Append(result, errors.Wrap(err, "RemoveJetDropsUntil"))
 		stat.Errors = stat.Scanned
@@ -124,6 +131,11 @@ func (c *cleaner) RemoveJetRecordsUntil(ctx context.Context, jetID core.RecordID
 	return c.removeJetRecordsUntil(ctx, scopeIDRecord, jetID, pn)
 }
 
+// RemoveJetResultsUntil removes for provided JetID index of results for requests older than provided pulse number.
+func (c *cleaner) RemoveJetResultsUntil(ctx context.Context, jetID core.RecordID, pn core.PulseNumber) (RmStat, error) {
+	return c.removeJetRecordsUntil(ctx, scopeIDResult, jetID, pn)
+}
+
 // RemoveJetDropsUntil removes for provided JetID all jet drops older than provided pulse number.
 func (c *cleaner) RemoveJetDropsUntil(ctx context.Context, jetID core.RecordID, pn core.PulseNumber) (RmStat, error) {
 	return c.removeJetRecordsUntil(ctx, scopeIDJetDrop, jetID, pn)
@@ -21,10 +21,12 @@ import (
 	"context"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/dgraph-io/badger"
 	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/ledger/storage/jet"
 	"github.com/insolar/insolar/ledger/storage/record"
 	"github.com/pkg/errors"
@@ -39,6 +41,11 @@ const (
 	scopeIDMessage  byte = 6
 	scopeIDBlob     byte = 7
 	scopeIDLocal    byte = 8
+	scopeIDResult   byte = 9
+	// scopeIDIntegrity holds integrity hashes of replicated records and blobs.
+	scopeIDIntegrity byte = 10
+	// scopeIDQuarantine holds entries which failed integrity verification.
+	scopeIDQuarantine byte = 11
 
 	sysGenesis                byte = 1
 	sysLatestPulse            byte = 2
@@ -47,6 +54,11 @@ const (
 	sysJetTree                byte = 5
 	sysJetList                byte = 6
 	sysDropSizeHistory        byte = 7
+	sysAPIRequestOutcome      byte = 8
+	sysValidationDispute      byte = 9
+	sysAuditRecord            byte = 10
+	sysKeyRotation            byte = 11
+	sysSchemaVersion          byte = 12
 )
 
 // DBContext provides base db methods
@@ -83,6 +95,8 @@ type DBContext interface {
 
 	GetPlatformCryptographyScheme() core.PlatformCryptographyScheme
 
+	// codec returns codec of values, storages which access badger directly read and write values with it.
+	codec() *valueCodec
 	set(ctx context.Context, key, value []byte) error
 	get(ctx context.Context, key []byte) ([]byte, error)
 
@@ -111,11 +125,19 @@ type DB struct {
 	// so txretiries is our knob to tune up retry logic.
 	txretiries int
 
+	gcInterval time.Duration
+
 	idlocker             *IDLocker
 	jetHeavyClientLocker *IDLocker
 
 	closeLock sync.RWMutex
 	isClosed  bool
+
+	// encryption is nil when storage values aren't encrypted
+	encryption    *valueCodec
+	encryptionCfg configuration.StorageEncryption
+	// rotationLock serializes steps of key rotation
+	rotationLock sync.Mutex
 }
 
 // SetTxRetiries sets number of retries on conflict in Update
@@ -145,6 +167,11 @@ func NewDB(conf configuration.Ledger, opts *badger.Options) (DBContext, error) {
 	opts.Dir = dir
 	opts.ValueDir = dir
 
+	encryption, err := loadValueCodec(conf.Storage.Encryption)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load storage encryption keys")
+	}
+
 	bdb, err := badger.Open(*opts)
 	if err != nil {
 		return nil, errors.Wrap(err, "local database open failed")
@@ -153,8 +180,15 @@ func NewDB(conf configuration.Ledger, opts *badger.Options) (DBContext, error) {
 	db := &DB{
 		db:                   bdb,
 		txretiries:           conf.Storage.TxRetriesOnConflict,
+		gcInterval:           conf.Storage.GCInterval,
 		idlocker:             NewIDLocker(),
 		jetHeavyClientLocker: NewIDLocker(),
+		encryption:           encryption,
+		encryptionCfg:        conf.Storage.Encryption,
+	}
+	if err := db.restoreKeyRotation(context.Background()); err != nil {
+		bdb.Close() // nolint: errcheck
+		return nil, err
 	}
 	return db, nil
 }
@@ -180,6 +214,52 @@ func (db *DB) Stop(ctx context.Context) error {
 	return db.Close()
 }
 
+// gcDiscardRatio is a ratio of discardable data in value log file which makes file to be rewritten by GC.
+const gcDiscardRatio = 0.5
+
+// PeriodicTasks returns tasks of DB component to be run by scheduler.
+func (db *DB) PeriodicTasks() []core.PeriodicTask {
+	var tasks []core.PeriodicTask
+	if db.gcInterval > 0 {
+		tasks = append(tasks, core.PeriodicTask{
+			Name:     "storage.gc",
+			Schedule: core.TaskSchedule{Every: db.gcInterval},
+			Run:      db.CollectGarbage,
+		})
+	}
+	if db.encryption != nil && db.encryptionCfg.RotationPause > 0 {
+		tasks = append(tasks, core.PeriodicTask{
+			Name:     "storage.key_rotation",
+			Schedule: core.TaskSchedule{Every: db.encryptionCfg.RotationPause},
+			Run:      db.ReencryptStep,
+		})
+	}
+	return tasks
+}
+
+// CollectGarbage removes stale data from value log files.
+func (db *DB) CollectGarbage(ctx context.Context) error {
+	db.closeLock.RLock()
+	defer db.closeLock.RUnlock()
+	if db.isClosed {
+		return ErrClosed
+	}
+
+	rewritten := 0
+	for {
+		err := db.db.RunValueLogGC(gcDiscardRatio)
+		if err == badger.ErrNoRewrite {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "[ CollectGarbage ] value log GC failed")
+		}
+		rewritten++
+	}
+	inslogger.FromContext(ctx).Debugf("[ CollectGarbage ] %d value log files rewritten", rewritten)
+	return nil
+}
+
 // BeginTransaction opens a new transaction.
 // All methods called on returned transaction manager will persist changes
 // only after success on "Commit" call.
@@ -306,7 +386,8 @@ func (db *DB) IterateRecordsOnPulse(
 	})
 }
 
-// StoreKeyValues stores provided key/value pairs.
+// StoreKeyValues stores provided key/value pairs. Integrity hashes of records and blobs are stored along with them,
+// so replicated data can be verified later.
 func (db *DB) StoreKeyValues(ctx context.Context, kvs []core.KV) error {
 	return db.Update(ctx, func(tx *TransactionManager) error {
 		for _, rec := range kvs {
@@ -314,6 +395,12 @@ func (db *DB) StoreKeyValues(ctx context.Context, kvs []core.KV) error {
 			if err != nil {
 				return err
 			}
+			if isIntegrityProtected(rec.K) {
+				err = tx.set(ctx, integrityKey(rec.K), db.PlatformCryptographyScheme.IntegrityHasher().Hash(rec.V))
+				if err != nil {
+					return err
+				}
+			}
 		}
 		return nil
 	})
@@ -323,6 +410,10 @@ func (db *DB) GetPlatformCryptographyScheme() core.PlatformCryptographyScheme {
 	return db.PlatformCryptographyScheme
 }
 
+func (db *DB) codec() *valueCodec {
+	return db.encryption
+}
+
 // get wraps matching transaction manager method.
 func (db *DB) get(ctx context.Context, key []byte) ([]byte, error) {
 	tx, err := db.BeginTransaction(false)
@@ -363,7 +454,7 @@ func (db *DB) iterate(
 
 		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
 			key := it.Item().KeyCopy(nil)[len(prefix):]
-			value, err := it.Item().ValueCopy(nil)
+			value, err := db.encryption.decode(it.Item())
 			if err != nil {
 				return err
 			}
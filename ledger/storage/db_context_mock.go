@@ -82,6 +82,11 @@ type DBContextMock struct {
 	ViewPreCounter uint64
 	ViewMock       mDBContextMockView
 
+	codecFunc       func() (r *valueCodec)
+	codecCounter    uint64
+	codecPreCounter uint64
+	codecMock       mDBContextMockcodec
+
 	getFunc       func(p context.Context, p1 []byte) (r []byte, r1 error)
 	getCounter    uint64
 	getPreCounter uint64
@@ -123,6 +128,7 @@ func NewDBContextMock(t minimock.Tester) *DBContextMock {
 	m.StoreKeyValuesMock = mDBContextMockStoreKeyValues{mock: m}
 	m.UpdateMock = mDBContextMockUpdate{mock: m}
 	m.ViewMock = mDBContextMockView{mock: m}
+	m.codecMock = mDBContextMockcodec{mock: m}
 	m.getMock = mDBContextMockget{mock: m}
 	m.iterateMock = mDBContextMockiterate{mock: m}
 	m.setMock = mDBContextMockset{mock: m}
@@ -1852,6 +1858,140 @@ func (m *DBContextMock) ViewFinished() bool {
 	return true
 }
 
+type mDBContextMockcodec struct {
+	mock              *DBContextMock
+	mainExpectation   *DBContextMockcodecExpectation
+	expectationSeries []*DBContextMockcodecExpectation
+}
+
+type DBContextMockcodecExpectation struct {
+	result *DBContextMockcodecResult
+}
+
+type DBContextMockcodecResult struct {
+	r *valueCodec
+}
+
+//Expect specifies that invocation of DBContext.codec is expected from 1 to Infinity times
+func (m *mDBContextMockcodec) Expect() *mDBContextMockcodec {
+	m.mock.codecFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &DBContextMockcodecExpectation{}
+	}
+
+	return m
+}
+
+//Return specifies results of invocation of DBContext.codec
+func (m *mDBContextMockcodec) Return(r *valueCodec) *DBContextMock {
+	m.mock.codecFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &DBContextMockcodecExpectation{}
+	}
+	m.mainExpectation.result = &DBContextMockcodecResult{r}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of DBContext.codec is expected once
+func (m *mDBContextMockcodec) ExpectOnce() *DBContextMockcodecExpectation {
+	m.mock.codecFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &DBContextMockcodecExpectation{}
+
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *DBContextMockcodecExpectation) Return(r *valueCodec) {
+	e.result = &DBContextMockcodecResult{r}
+}
+
+//Set uses given function f as a mock of DBContext.codec method
+func (m *mDBContextMockcodec) Set(f func() (r *valueCodec)) *DBContextMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.codecFunc = f
+	return m.mock
+}
+
+//codec implements github.com/insolar/insolar/ledger/storage.DBContext interface
+func (m *DBContextMock) codec() (r *valueCodec) {
+	counter := atomic.AddUint64(&m.codecPreCounter, 1)
+	defer atomic.AddUint64(&m.codecCounter, 1)
+
+	if len(m.codecMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.codecMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to DBContextMock.codec.")
+			return
+		}
+
+		result := m.codecMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the DBContextMock.codec")
+			return
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.codecMock.mainExpectation != nil {
+
+		result := m.codecMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the DBContextMock.codec")
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.codecFunc == nil {
+		m.t.Fatalf("Unexpected call to DBContextMock.codec.")
+		return
+	}
+
+	return m.codecFunc()
+}
+
+//codecMinimockCounter returns a count of DBContextMock.codecFunc invocations
+func (m *DBContextMock) codecMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.codecCounter)
+}
+
+//codecMinimockPreCounter returns the value of DBContextMock.codec invocations
+func (m *DBContextMock) codecMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.codecPreCounter)
+}
+
+//codecFinished returns true if mock invocations count is ok
+func (m *DBContextMock) codecFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.codecMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.codecCounter) == uint64(len(m.codecMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.codecMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.codecCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.codecFunc != nil {
+		return atomic.LoadUint64(&m.codecCounter) > 0
+	}
+
+	return true
+}
+
 type mDBContextMockget struct {
 	mock              *DBContextMock
 	mainExpectation   *DBContextMockgetExpectation
@@ -2463,6 +2603,10 @@ func (m *DBContextMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to DBContextMock.View")
 	}
 
+	if !m.codecFinished() {
+		m.t.Fatal("Expected call to DBContextMock.codec")
+	}
+
 	if !m.getFinished() {
 		m.t.Fatal("Expected call to DBContextMock.get")
 	}
@@ -2544,6 +2688,10 @@ func (m *DBContextMock) MinimockFinish() {
 		m.t.Fatal("Expected call to DBContextMock.View")
 	}
 
+	if !m.codecFinished() {
+		m.t.Fatal("Expected call to DBContextMock.codec")
+	}
+
 	if !m.getFinished() {
 		m.t.Fatal("Expected call to DBContextMock.get")
 	}
@@ -2586,6 +2734,7 @@ func (m *DBContextMock) MinimockWait(timeout time.Duration) {
 		ok = ok && m.StoreKeyValuesFinished()
 		ok = ok && m.UpdateFinished()
 		ok = ok && m.ViewFinished()
+		ok = ok && m.codecFinished()
 		ok = ok && m.getFinished()
 		ok = ok && m.iterateFinished()
 		ok = ok && m.setFinished()
@@ -2646,6 +2795,10 @@ func (m *DBContextMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to DBContextMock.View")
 			}
 
+			if !m.codecFinished() {
+				m.t.Error("Expected call to DBContextMock.codec")
+			}
+
 			if !m.getFinished() {
 				m.t.Error("Expected call to DBContextMock.get")
 			}
@@ -2722,6 +2875,10 @@ func (m *DBContextMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.codecFinished() {
+		return false
+	}
+
 	if !m.getFinished() {
 		return false
 	}
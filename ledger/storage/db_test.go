@@ -0,0 +1,41 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage"
+	"github.com/insolar/insolar/ledger/storage/storagetest"
+)
+
+func TestDB_CollectGarbage(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	dbCtx, cleaner := storagetest.TmpDB(ctx, t)
+	defer cleaner()
+	db := dbCtx.(*storage.DB)
+
+	// GC is disabled in test config.
+	require.Empty(t, db.PeriodicTasks())
+	require.NoError(t, db.CollectGarbage(ctx))
+
+	require.NoError(t, db.Close())
+	require.Equal(t, storage.ErrClosed, db.CollectGarbage(ctx))
+}
@@ -0,0 +1,84 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/gob"
+
+	"github.com/dgraph-io/badger"
+	"github.com/insolar/insolar/core"
+	"github.com/pkg/errors"
+)
+
+// DisputeStorage keeps validation disputes ordered by time they were recorded.
+type DisputeStorage struct {
+	DB DBContext `inject:""`
+}
+
+// NewDisputeStorage creates new validation dispute storage.
+func NewDisputeStorage() *DisputeStorage {
+	return &DisputeStorage{}
+}
+
+func validationDisputeKey(dispute *core.ValidationDispute) []byte {
+	ts := make([]byte, 8)
+	binary.BigEndian.PutUint64(ts, uint64(dispute.Time.UnixNano()))
+	return prefixkey(scopeIDSystem, []byte{sysValidationDispute}, ts, dispute.Object[:])
+}
+
+// SetValidationDispute archives validation dispute.
+func (s *DisputeStorage) SetValidationDispute(ctx context.Context, dispute *core.ValidationDispute) error {
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(dispute)
+	if err != nil {
+		return errors.Wrap(err, "[ SetValidationDispute ] failed to encode dispute")
+	}
+	return s.DB.set(ctx, validationDisputeKey(dispute), buf.Bytes())
+}
+
+// GetValidationDisputes returns up to limit most recent disputes, newest first.
+func (s *DisputeStorage) GetValidationDisputes(ctx context.Context, limit int) ([]core.ValidationDispute, error) {
+	prefix := prefixkey(scopeIDSystem, []byte{sysValidationDispute})
+	disputes := []core.ValidationDispute{}
+	err := s.DB.GetBadgerDB().View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Reverse = true
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Seek(append(prefix, 0xFF)); it.ValidForPrefix(prefix) && len(disputes) < limit; it.Next() {
+			buf, err := s.DB.codec().decode(it.Item())
+			if err != nil {
+				return err
+			}
+			var dispute core.ValidationDispute
+			err = gob.NewDecoder(bytes.NewReader(buf)).Decode(&dispute)
+			if err != nil {
+				return errors.Wrap(err, "[ GetValidationDisputes ] failed to decode dispute")
+			}
+			disputes = append(disputes, dispute)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return disputes, nil
+}
@@ -0,0 +1,66 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package storage_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage"
+	"github.com/insolar/insolar/ledger/storage/storagetest"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestDisputeStorage(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	db, cleaner := storagetest.TmpDB(ctx, t)
+	defer cleaner()
+
+	s := storage.NewDisputeStorage()
+	s.DB = db
+
+	disputes, err := s.GetValidationDisputes(ctx, 10)
+	require.NoError(t, err)
+	require.Empty(t, disputes)
+
+	var expected []core.ValidationDispute
+	for i := 0; i < 3; i++ {
+		dispute := core.ValidationDispute{
+			Object:             testutils.RandomRef(),
+			Pulse:              core.FirstPulseNumber,
+			Executor:           testutils.RandomRef(),
+			ExecutorResultHash: []byte{byte(i)},
+			Requests:           2,
+			Verdicts:           []core.ValidatorVerdict{{Node: testutils.RandomRef(), Steps: 1}},
+			Time:               time.Unix(1546300800+int64(i), 0),
+		}
+		require.NoError(t, s.SetValidationDispute(ctx, &dispute))
+		expected = append([]core.ValidationDispute{dispute}, expected...)
+	}
+
+	disputes, err = s.GetValidationDisputes(ctx, 10)
+	require.NoError(t, err)
+	require.Equal(t, expected, disputes, "disputes must be returned newest first")
+
+	disputes, err = s.GetValidationDisputes(ctx, 2)
+	require.NoError(t, err)
+	require.Equal(t, expected[:2], disputes)
+}
@@ -104,7 +104,7 @@ func (ds *dropStorage) CreateDrop(ctx context.Context, jetID core.RecordID, puls
 		defer it.Close()
 
 		for it.Seek(recordPrefix); it.ValidForPrefix(recordPrefix); it.Next() {
-			val, err := it.Item().ValueCopy(nil)
+			val, err := ds.DB.codec().decode(it.Item())
 			if err != nil {
 				return err
 			}
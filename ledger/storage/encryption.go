@@ -0,0 +1,200 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package storage
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"strconv"
+	"sync"
+
+	"github.com/dgraph-io/badger"
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/configuration"
+)
+
+// userMetaEncrypted marks badger entries with encrypted values, so encrypted and plain values are told apart
+// without guessing by content.
+const userMetaEncrypted byte = 1
+
+// keyIDSize is a size of key id encrypted values are prefixed with.
+const keyIDSize = 4
+
+// valueCodec encrypts storage values with AES-GCM. Encrypted value is prefixed with id of its key and nonce, so values
+// encrypted with previous keys stay readable while they are re-encrypted with the current one. Key of entry is
+// authenticated along with value, so value can't be moved to other key unnoticed.
+//
+// Nil codec means encryption is disabled: values are written as is and encrypted values can't be read.
+type valueCodec struct {
+	lock    sync.RWMutex
+	keys    map[uint32]cipher.AEAD
+	current uint32
+}
+
+// loadValueCodec reads encryption keys from keys file of cfg. It returns nil codec if encryption isn't configured.
+func loadValueCodec(cfg configuration.StorageEncryption) (*valueCodec, error) {
+	if cfg.KeysFile == "" {
+		return nil, nil
+	}
+	data, err := ioutil.ReadFile(cfg.KeysFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read encryption keys")
+	}
+	hexKeys := map[string]string{}
+	if err := json.Unmarshal(data, &hexKeys); err != nil {
+		return nil, errors.Wrap(err, "failed to parse encryption keys")
+	}
+
+	c := &valueCodec{keys: make(map[uint32]cipher.AEAD, len(hexKeys))}
+	for id, hexKey := range hexKeys {
+		keyID, err := strconv.ParseUint(id, 10, 32)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid id of encryption key %q", id)
+		}
+		key, err := hex.DecodeString(hexKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid encryption key %d", keyID)
+		}
+		if len(key) != 32 {
+			return nil, errors.Errorf("encryption key %d must be 32 bytes long", keyID)
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid encryption key %d", keyID)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid encryption key %d", keyID)
+		}
+		c.keys[uint32(keyID)] = aead
+	}
+	if err := c.setCurrent(cfg.KeyID); err != nil {
+		return nil, err
+	}
+	if cfg.RotationBatch <= 0 {
+		return nil, errors.New("key rotation batch must be positive")
+	}
+	return c, nil
+}
+
+// setCurrent makes codec encrypt new values with key keyID.
+func (c *valueCodec) setCurrent(keyID uint32) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if _, ok := c.keys[keyID]; !ok {
+		return errors.Errorf("encryption key %d is not found in keys file", keyID)
+	}
+	c.current = keyID
+	return nil
+}
+
+// replace takes keys and current key of other codec, it's used to pick up keys added to keys file.
+func (c *valueCodec) replace(other *valueCodec) {
+	other.lock.RLock()
+	defer other.lock.RUnlock()
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.keys = other.keys
+	c.current = other.current
+}
+
+// encode encrypts value of entry with the current key.
+func (c *valueCodec) encode(entry *badger.Entry) error {
+	if c == nil {
+		return nil
+	}
+	c.lock.RLock()
+	keyID, aead := c.current, c.keys[c.current]
+	c.lock.RUnlock()
+
+	buf := make([]byte, keyIDSize+aead.NonceSize(), keyIDSize+aead.NonceSize()+len(entry.Value)+aead.Overhead())
+	binary.BigEndian.PutUint32(buf, keyID)
+	nonce := buf[keyIDSize:]
+	if _, err := rand.Read(nonce); err != nil {
+		return errors.Wrap(err, "failed to generate nonce")
+	}
+	entry.Value = aead.Seal(buf, nonce, entry.Value, entry.Key)
+	entry.UserMeta |= userMetaEncrypted
+	return nil
+}
+
+// decode returns plain value of item.
+func (c *valueCodec) decode(item *badger.Item) ([]byte, error) {
+	value, err := item.ValueCopy(nil)
+	if err != nil || item.UserMeta()&userMetaEncrypted == 0 {
+		return value, err
+	}
+	if c == nil {
+		return nil, errors.Wrapf(ErrUndecodable, "value of %x is encrypted, but storage encryption is not configured", item.Key())
+	}
+
+	if len(value) < keyIDSize {
+		return nil, errors.Wrapf(ErrUndecodable, "malformed encrypted value of %x", item.Key())
+	}
+	keyID := binary.BigEndian.Uint32(value)
+	c.lock.RLock()
+	aead, ok := c.keys[keyID]
+	c.lock.RUnlock()
+	if !ok {
+		return nil, errors.Wrapf(ErrUndecodable, "value of %x is encrypted with unknown key %d", item.Key(), keyID)
+	}
+	if len(value) < keyIDSize+aead.NonceSize() {
+		return nil, errors.Wrapf(ErrUndecodable, "malformed encrypted value of %x", item.Key())
+	}
+	nonce := value[keyIDSize : keyIDSize+aead.NonceSize()]
+	plain, err := aead.Open(nil, nonce, value[keyIDSize+aead.NonceSize():], item.Key())
+	if err != nil {
+		return nil, errors.Wrapf(ErrUndecodable, "failed to decrypt value of %x: %s", item.Key(), err)
+	}
+	return plain, nil
+}
+
+// stale checks if value of item isn't encrypted with the current key.
+func (c *valueCodec) stale(item *badger.Item) (bool, error) {
+	if item.UserMeta()&userMetaEncrypted == 0 {
+		return true, nil
+	}
+	value, err := item.Value()
+	if err != nil {
+		return false, err
+	}
+	if len(value) < keyIDSize {
+		return false, errors.Wrapf(ErrUndecodable, "malformed encrypted value of %x", item.Key())
+	}
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return binary.BigEndian.Uint32(value) != c.current, nil
+}
+
+// setEntry writes entry to badger transaction encrypting its value if encryption is enabled.
+func (c *valueCodec) setEntry(txn *badger.Txn, entry *badger.Entry) error {
+	if err := c.encode(entry); err != nil {
+		return err
+	}
+	return txn.SetEntry(entry)
+}
+
+// set writes value by key to badger transaction encrypting it if encryption is enabled.
+func (c *valueCodec) set(txn *badger.Txn, key, value []byte) error {
+	return c.setEntry(txn, &badger.Entry{Key: key, Value: value})
+}
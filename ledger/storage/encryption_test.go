@@ -0,0 +1,277 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package storage
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/dgraph-io/badger"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// writeEncryptionKeys writes keys file with keys of ids, key of id is filled with byte id.
+func writeEncryptionKeys(t *testing.T, path string, ids ...uint32) {
+	keys := map[string]string{}
+	for _, id := range ids {
+		keys[strconv.Itoa(int(id))] = hex.EncodeToString(bytes.Repeat([]byte{byte(id)}, 32))
+	}
+	data, err := json.Marshal(keys)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(path, data, 0600))
+}
+
+func encryptedConf(dir string, keyID uint32) configuration.Ledger {
+	return configuration.Ledger{Storage: configuration.Storage{
+		DataDirectory: filepath.Join(dir, "data"),
+		Encryption: configuration.StorageEncryption{
+			KeysFile:      filepath.Join(dir, "keys.json"),
+			KeyID:         keyID,
+			RotationBatch: 1,
+		},
+	}}
+}
+
+func rawValue(t *testing.T, db *DB, key []byte) ([]byte, byte) {
+	var value []byte
+	var meta byte
+	err := db.GetBadgerDB().View(func(txn *badger.Txn) error {
+		item, err := txn.Get(key)
+		if err != nil {
+			return err
+		}
+		meta = item.UserMeta()
+		value, err = item.ValueCopy(nil)
+		return err
+	})
+	require.NoError(t, err)
+	return value, meta
+}
+
+func TestDB_Encryption(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	dir, err := ioutil.TempDir("", "bdb-encryption-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	writeEncryptionKeys(t, filepath.Join(dir, "keys.json"), 1)
+	conf := encryptedConf(dir, 1)
+
+	dbContext, err := NewDB(conf, nil)
+	require.NoError(t, err)
+	db := dbContext.(*DB)
+	key, value := []byte("key"), []byte("secret value")
+	require.NoError(t, db.set(ctx, key, value))
+
+	raw, meta := rawValue(t, db, key)
+	require.Equal(t, userMetaEncrypted, meta)
+	require.False(t, bytes.Contains(raw, value))
+	got, err := db.get(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, value, got)
+
+	// value moved to other key doesn't decrypt
+	err = db.GetBadgerDB().Update(func(txn *badger.Txn) error {
+		return txn.SetWithMeta([]byte("other"), raw, meta)
+	})
+	require.NoError(t, err)
+	_, err = db.get(ctx, []byte("other"))
+	require.Error(t, err)
+	require.NoError(t, db.Close())
+
+	conf.Storage.Encryption.KeysFile = ""
+	dbContext, err = NewDB(conf, nil)
+	require.NoError(t, err)
+	_, err = dbContext.(*DB).get(ctx, key)
+	require.Error(t, err)
+	require.NoError(t, dbContext.Close())
+
+	writeEncryptionKeys(t, filepath.Join(dir, "keys.json"), 2)
+	_, err = NewDB(encryptedConf(dir, 1), nil)
+	require.Error(t, err)
+}
+
+func TestDB_RotateKey(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	dir, err := ioutil.TempDir("", "bdb-rotation-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	keysFile := filepath.Join(dir, "keys.json")
+	conf := encryptedConf(dir, 1)
+
+	// value written before encryption was enabled
+	plain := conf
+	plain.Storage.Encryption.KeysFile = ""
+	dbContext, err := NewDB(plain, nil)
+	require.NoError(t, err)
+	require.NoError(t, dbContext.(*DB).set(ctx, []byte("a"), []byte("plain")))
+	require.NoError(t, dbContext.Close())
+
+	writeEncryptionKeys(t, keysFile, 1)
+	dbContext, err = NewDB(conf, nil)
+	require.NoError(t, err)
+	db := dbContext.(*DB)
+	require.NoError(t, db.set(ctx, []byte("b"), []byte("old key")))
+	rotation, err := db.KeyRotation(ctx)
+	require.NoError(t, err)
+	require.Nil(t, rotation)
+
+	_, err = db.RotateKey(ctx, 2)
+	require.Error(t, err, "key isn't in keys file yet")
+
+	writeEncryptionKeys(t, keysFile, 1, 2)
+	rotation, err = db.RotateKey(ctx, 2)
+	require.NoError(t, err)
+	require.Equal(t, uint32(2), rotation.KeyID)
+	require.True(t, rotation.Finished.IsZero())
+
+	// new values are encrypted with new key right away
+	require.NoError(t, db.set(ctx, []byte("c"), []byte("new key")))
+	raw, _ := rawValue(t, db, []byte("c"))
+	require.Equal(t, []byte{0, 0, 0, 2}, raw[:keyIDSize])
+
+	// rotation survives restart
+	require.NoError(t, db.ReencryptStep(ctx))
+	require.NoError(t, db.Close())
+	dbContext, err = NewDB(conf, nil)
+	require.NoError(t, err)
+	db = dbContext.(*DB)
+	defer db.Close()
+	rotation, err = db.KeyRotation(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint32(2), rotation.KeyID)
+
+	for i := 0; i < 100 && rotation.Finished.IsZero(); i++ {
+		require.NoError(t, db.ReencryptStep(ctx))
+		rotation, err = db.KeyRotation(ctx)
+		require.NoError(t, err)
+	}
+	require.False(t, rotation.Finished.IsZero())
+	require.True(t, rotation.Rewritten >= 2, "values a and b are rewritten")
+
+	// old key isn't needed anymore
+	writeEncryptionKeys(t, keysFile, 2)
+	_, err = db.RotateKey(ctx, 2)
+	require.NoError(t, err)
+	for key, expected := range map[string]string{"a": "plain", "b": "old key", "c": "new key"} {
+		raw, meta := rawValue(t, db, []byte(key))
+		require.Equal(t, userMetaEncrypted, meta)
+		require.Equal(t, []byte{0, 0, 0, 2}, raw[:keyIDSize])
+		value, err := db.get(ctx, []byte(key))
+		require.NoError(t, err)
+		require.Equal(t, expected, string(value))
+	}
+}
+
+func TestDB_ReencryptStep_TxnTooBig(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	dir, err := ioutil.TempDir("", "bdb-rotation-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	keysFile := filepath.Join(dir, "keys.json")
+	writeEncryptionKeys(t, keysFile, 1, 2)
+	conf := encryptedConf(dir, 1)
+	conf.Storage.Encryption.RotationBatch = 1000
+
+	// small tables make badger transactions hold only a few dozens of values
+	opts := badger.DefaultOptions
+	opts.MaxTableSize = 1 << 16
+	dbContext, err := NewDB(conf, &opts)
+	require.NoError(t, err)
+	db := dbContext.(*DB)
+	defer db.Close()
+
+	value := bytes.Repeat([]byte{1}, 100)
+	for i := 0; i < 300; i++ {
+		require.NoError(t, db.set(ctx, []byte(strconv.Itoa(i)), value))
+	}
+
+	_, err = db.RotateKey(ctx, 2)
+	require.NoError(t, err)
+	require.NoError(t, db.ReencryptStep(ctx))
+
+	rotation, err := db.KeyRotation(ctx)
+	require.NoError(t, err)
+	require.False(t, rotation.Finished.IsZero())
+	require.Equal(t, uint64(300), rotation.Rewritten)
+	for i := 0; i < 300; i++ {
+		raw, _ := rawValue(t, db, []byte(strconv.Itoa(i)))
+		require.Equal(t, []byte{0, 0, 0, 2}, raw[:keyIDSize])
+	}
+}
+
+func TestDB_ReencryptStep_Undecodable(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	dir, err := ioutil.TempDir("", "bdb-rotation-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	keysFile := filepath.Join(dir, "keys.json")
+	writeEncryptionKeys(t, keysFile, 1, 2)
+	conf := encryptedConf(dir, 1)
+
+	dbContext, err := NewDB(conf, nil)
+	require.NoError(t, err)
+	db := dbContext.(*DB)
+	defer db.Close()
+
+	require.NoError(t, db.set(ctx, []byte("a"), []byte("good")))
+	err = db.GetBadgerDB().Update(func(txn *badger.Txn) error {
+		if err := txn.SetWithMeta([]byte("b"), []byte{1}, userMetaEncrypted); err != nil {
+			return err
+		}
+		return txn.SetWithMeta([]byte("c"), []byte{0, 0, 0, 1, 2, 3}, userMetaEncrypted)
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.set(ctx, []byte("d"), []byte("good")))
+
+	_, err = db.RotateKey(ctx, 2)
+	require.NoError(t, err)
+	var rotation *core.StorageKeyRotation
+	for i := 0; i < 100; i++ {
+		require.NoError(t, db.ReencryptStep(ctx))
+		rotation, err = db.KeyRotation(ctx)
+		require.NoError(t, err)
+		if !rotation.Finished.IsZero() {
+			break
+		}
+	}
+	require.False(t, rotation.Finished.IsZero())
+	require.Equal(t, uint64(2), rotation.Rewritten)
+	require.Equal(t, uint64(2), rotation.Skipped)
+
+	state, err := db.keyRotation(ctx)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{[]byte("b"), []byte("c")}, state.SkippedKeys)
+
+	// undecodable values are left as is
+	raw, _ := rawValue(t, db, []byte("b"))
+	require.Equal(t, []byte{1}, raw)
+	for _, key := range []string{"a", "d"} {
+		value, err := db.get(ctx, []byte(key))
+		require.NoError(t, err)
+		require.Equal(t, "good", string(value))
+	}
+}
@@ -43,4 +43,7 @@ var (
 
 	// ErrBadPulse is returned when pulse less than latest
 	ErrBadPulse = errors.New("pulse should be bigger than latest")
+
+	// ErrUndecodable is returned when stored value is malformed or can't be decrypted with known keys.
+	ErrUndecodable = errors.New("storage value can't be decoded")
 )
@@ -33,6 +33,9 @@ type ObjectLifeline struct {
 	Delegates           map[core.RecordRef]core.RecordRef
 	State               record.State
 	LatestUpdate        core.PulseNumber
+	RequestSequence     uint64 // Sequence number of the latest request registered for object.
+	// SignedNonces holds nonces of signed requests registered for object with the last pulses they are valid in.
+	SignedNonces map[string]core.PulseNumber
 }
 
 // EncodeObjectLifeline converts lifeline index into binary format.
@@ -0,0 +1,229 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package storage
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/dgraph-io/badger"
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+)
+
+// IntegrityReport is a result of verification of stored data.
+type IntegrityReport struct {
+	// Next is a key to continue verification from, nil if all entries are checked.
+	Next []byte
+	// Checked is a number of checked entries.
+	Checked int
+	// Corrupted are keys of entries which don't match their integrity hashes or can't be decoded,
+	// entries are moved to quarantine.
+	Corrupted [][]byte
+}
+
+// IntegrityStorage verifies records and blobs replicated to heavy against integrity hashes stored along with them.
+type IntegrityStorage interface {
+	// VerifyIntegrity checks at most limit entries starting from key next. Corrupted entries are quarantined.
+	VerifyIntegrity(ctx context.Context, next []byte, limit int) (*IntegrityReport, error)
+	// GetKeyValues returns entries of provided keys which match their integrity hashes, others are skipped.
+	GetKeyValues(ctx context.Context, keys [][]byte) ([]core.KV, error)
+	// Repair restores entries which match their integrity hashes and returns keys of restored entries.
+	Repair(ctx context.Context, kvs []core.KV) ([][]byte, error)
+}
+
+type integrityStorage struct {
+	DB DBContext `inject:""`
+}
+
+// NewIntegrityStorage creates new integrity storage.
+func NewIntegrityStorage() IntegrityStorage {
+	return new(integrityStorage)
+}
+
+func isIntegrityProtected(key []byte) bool {
+	return len(key) > 0 && (key[0] == scopeIDRecord || key[0] == scopeIDBlob)
+}
+
+func integrityKey(key []byte) []byte {
+	return prefixkey(scopeIDIntegrity, key)
+}
+
+func quarantineKey(key []byte) []byte {
+	return prefixkey(scopeIDQuarantine, key)
+}
+
+func (s *integrityStorage) isIntact(value, hash []byte) bool {
+	return bytes.Equal(s.DB.GetPlatformCryptographyScheme().IntegrityHasher().Hash(value), hash)
+}
+
+// VerifyIntegrity re-reads entries with integrity hashes and compares their content to hashes.
+func (s *integrityStorage) VerifyIntegrity(ctx context.Context, next []byte, limit int) (*IntegrityReport, error) {
+	type entry struct {
+		key  []byte
+		hash []byte
+		// undecodable is set if hash can't be decoded, so entry can't be verified
+		undecodable bool
+	}
+	var entries []entry
+	report := &IntegrityReport{}
+
+	prefix := []byte{scopeIDIntegrity}
+	start := prefix
+	if next != nil {
+		start = integrityKey(next)
+	}
+	err := s.DB.GetBadgerDB().View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+
+		for it.Seek(start); it.ValidForPrefix(prefix); it.Next() {
+			key := it.Item().KeyCopy(nil)[len(prefix):]
+			if len(entries) == limit {
+				report.Next = key
+				return nil
+			}
+			hash, err := s.DB.codec().decode(it.Item())
+			if errors.Cause(err) == ErrUndecodable {
+				entries = append(entries, entry{key: key, undecodable: true})
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			entries = append(entries, entry{key: key, hash: hash})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read integrity hashes")
+	}
+
+	for _, e := range entries {
+		report.Checked++
+		value, err := s.DB.get(ctx, e.key)
+		if err == ErrNotFound {
+			// entry is already quarantined and waits for repair
+			report.Corrupted = append(report.Corrupted, e.key)
+			continue
+		}
+		switch {
+		case errors.Cause(err) == ErrUndecodable:
+			err = s.quarantineRaw(e.key)
+		case err != nil:
+			return nil, err
+		case !e.undecodable && s.isIntact(value, e.hash):
+			continue
+		default:
+			err = s.quarantine(e.key, value)
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to quarantine corrupted entry")
+		}
+		report.Corrupted = append(report.Corrupted, e.key)
+	}
+	return report, nil
+}
+
+// quarantine moves entry with decoded value to quarantine.
+func (s *integrityStorage) quarantine(key, value []byte) error {
+	return s.DB.GetBadgerDB().Update(func(txn *badger.Txn) error {
+		if err := s.DB.codec().set(txn, quarantineKey(key), value); err != nil {
+			return err
+		}
+		return txn.Delete(key)
+	})
+}
+
+// quarantineRaw moves entry which value can't be decoded to quarantine as is.
+func (s *integrityStorage) quarantineRaw(key []byte) error {
+	return s.DB.GetBadgerDB().Update(func(txn *badger.Txn) error {
+		item, err := txn.Get(key)
+		if err != nil {
+			return err
+		}
+		raw, err := item.ValueCopy(nil)
+		if err != nil {
+			return err
+		}
+		if err := txn.SetWithMeta(quarantineKey(key), raw, item.UserMeta()); err != nil {
+			return err
+		}
+		return txn.Delete(key)
+	})
+}
+
+// GetKeyValues returns intact entries of provided keys.
+func (s *integrityStorage) GetKeyValues(ctx context.Context, keys [][]byte) ([]core.KV, error) {
+	var kvs []core.KV
+	for _, key := range keys {
+		if !isIntegrityProtected(key) {
+			continue
+		}
+		hash, err := s.DB.get(ctx, integrityKey(key))
+		if err == ErrNotFound || errors.Cause(err) == ErrUndecodable {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		value, err := s.DB.get(ctx, key)
+		if err == ErrNotFound || errors.Cause(err) == ErrUndecodable {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if s.isIntact(value, hash) {
+			kvs = append(kvs, core.KV{K: key, V: value})
+		}
+	}
+	return kvs, nil
+}
+
+// Repair stores entries received from peers if they match integrity hashes of quarantined entries.
+func (s *integrityStorage) Repair(ctx context.Context, kvs []core.KV) ([][]byte, error) {
+	var repaired [][]byte
+	for _, kv := range kvs {
+		if !isIntegrityProtected(kv.K) {
+			continue
+		}
+		hash, err := s.DB.get(ctx, integrityKey(kv.K))
+		if err == ErrNotFound || errors.Cause(err) == ErrUndecodable {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !s.isIntact(kv.V, hash) {
+			continue
+		}
+
+		err = s.DB.GetBadgerDB().Update(func(txn *badger.Txn) error {
+			if err := s.DB.codec().set(txn, kv.K, kv.V); err != nil {
+				return err
+			}
+			return txn.Delete(quarantineKey(kv.K))
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to restore entry")
+		}
+		repaired = append(repaired, kv.K)
+	}
+	return repaired, nil
+}
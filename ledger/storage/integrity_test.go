@@ -0,0 +1,130 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/component"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage"
+	"github.com/insolar/insolar/ledger/storage/storagetest"
+	"github.com/insolar/insolar/platformpolicy"
+)
+
+func TestIntegrityStorage_QuarantineAndRepair(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	db, cleaner := storagetest.TmpDB(ctx, t)
+	defer cleaner()
+
+	integrity := storage.NewIntegrityStorage()
+	cm := &component.Manager{}
+	cm.Inject(platformpolicy.NewPlatformCryptographyScheme(), db, integrity)
+	require.NoError(t, cm.Init(ctx))
+
+	// 2 is a scope of records
+	key := []byte{2, 1, 2, 3}
+	intact := []byte("intact record")
+	require.NoError(t, db.StoreKeyValues(ctx, []core.KV{{K: key, V: intact}}))
+
+	report, err := integrity.VerifyIntegrity(ctx, nil, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Checked)
+	assert.Empty(t, report.Corrupted)
+	assert.Nil(t, report.Next)
+
+	err = db.GetBadgerDB().Update(func(txn *badger.Txn) error {
+		return txn.Set(key, []byte("corrupted record"))
+	})
+	require.NoError(t, err)
+
+	report, err = integrity.VerifyIntegrity(ctx, nil, 10)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{key}, report.Corrupted)
+
+	kvs, err := integrity.GetKeyValues(ctx, [][]byte{key})
+	require.NoError(t, err)
+	assert.Empty(t, kvs)
+
+	repaired, err := integrity.Repair(ctx, []core.KV{{K: key, V: []byte("other record")}})
+	require.NoError(t, err)
+	assert.Empty(t, repaired)
+
+	repaired, err = integrity.Repair(ctx, []core.KV{{K: key, V: intact}})
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{key}, repaired)
+
+	kvs, err = integrity.GetKeyValues(ctx, [][]byte{key})
+	require.NoError(t, err)
+	assert.Equal(t, []core.KV{{K: key, V: intact}}, kvs)
+
+	report, err = integrity.VerifyIntegrity(ctx, nil, 10)
+	require.NoError(t, err)
+	assert.Empty(t, report.Corrupted)
+}
+
+func TestIntegrityStorage_QuarantineUndecodable(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	db, cleaner := storagetest.TmpDB(ctx, t)
+	defer cleaner()
+
+	integrity := storage.NewIntegrityStorage()
+	cm := &component.Manager{}
+	cm.Inject(platformpolicy.NewPlatformCryptographyScheme(), db, integrity)
+	require.NoError(t, cm.Init(ctx))
+
+	// 2 is a scope of records
+	badValue, badHash, intact := []byte{2, 1}, []byte{2, 2}, []byte{2, 3}
+	require.NoError(t, db.StoreKeyValues(ctx, []core.KV{
+		{K: badValue, V: []byte("record")},
+		{K: badHash, V: []byte("record")},
+		{K: intact, V: []byte("record")},
+	}))
+
+	// values marked as encrypted can't be decoded by storage without encryption
+	const userMetaEncrypted = 1
+	err := db.GetBadgerDB().Update(func(txn *badger.Txn) error {
+		if err := txn.SetWithMeta(badValue, []byte("record"), userMetaEncrypted); err != nil {
+			return err
+		}
+		// 10 is a scope of integrity hashes
+		return txn.SetWithMeta(append([]byte{10}, badHash...), []byte("hash"), userMetaEncrypted)
+	})
+	require.NoError(t, err)
+
+	report, err := integrity.VerifyIntegrity(ctx, nil, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 3, report.Checked)
+	assert.Equal(t, [][]byte{badValue, badHash}, report.Corrupted)
+
+	kvs, err := integrity.GetKeyValues(ctx, [][]byte{badValue, badHash, intact})
+	require.NoError(t, err)
+	assert.Equal(t, []core.KV{{K: intact, V: []byte("record")}}, kvs)
+
+	repaired, err := integrity.Repair(ctx, []core.KV{{K: badValue, V: []byte("record")}})
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{badValue}, repaired)
+
+	report, err = integrity.VerifyIntegrity(ctx, nil, 10)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{badHash}, report.Corrupted)
+}
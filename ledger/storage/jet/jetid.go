@@ -61,6 +61,15 @@ func Jet(id core.RecordID) (uint8, []byte) {
 	return id[core.PulseNumberSize], id[core.PulseNumberSize+1:]
 }
 
+// Children returns left and right child jets of provided jet.
+func Children(id core.RecordID) (core.RecordID, core.RecordID) {
+	depth, prefix := Jet(id)
+	leftPrefix := ResetBits(prefix, depth)
+	rightPrefix := ResetBits(prefix, depth)
+	setBit(rightPrefix, depth)
+	return *NewID(depth+1, leftPrefix), *NewID(depth+1, rightPrefix)
+}
+
 func Parent(id core.RecordID) core.RecordID {
 	depth, prefix := Jet(id)
 	if depth == 0 {
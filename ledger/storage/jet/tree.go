@@ -276,10 +276,8 @@ func (t *Tree) Split(jetID core.RecordID) (*core.RecordID, *core.RecordID, error
 	}
 	j.Right = &jet{}
 	j.Left = &jet{}
-	leftPrefix := ResetBits(prefix, depth)
-	rightPrefix := ResetBits(prefix, depth)
-	setBit(rightPrefix, depth)
-	return NewID(depth+1, leftPrefix), NewID(depth+1, rightPrefix), nil
+	left, right := Children(jetID)
+	return &left, &right, nil
 }
 
 func (t *Tree) LeafIDs() []core.RecordID {
@@ -0,0 +1,238 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// maxSkippedKeys limits number of keys of undecodable values kept in key rotation state.
+const maxSkippedKeys = 100
+
+// keyRotationState is a persistent state of key rotation.
+type keyRotationState struct {
+	core.StorageKeyRotation
+	// Next is a key re-encryption continues from.
+	Next []byte
+	// SkippedKeys are first maxSkippedKeys keys of values which can't be decoded and are left as is.
+	SkippedKeys [][]byte
+}
+
+// skip counts value of key which can't be re-encrypted.
+func (s *keyRotationState) skip(key []byte) {
+	s.Skipped++
+	if len(s.SkippedKeys) < maxSkippedKeys {
+		s.SkippedKeys = append(s.SkippedKeys, key)
+	}
+}
+
+func keyRotationKey() []byte {
+	return prefixkey(scopeIDSystem, []byte{sysKeyRotation})
+}
+
+// RotateKey implements core.StorageKeyRotator. Keys file is read again, so key added to it after start can be used.
+// Rotation started before is restarted with new key.
+func (db *DB) RotateKey(ctx context.Context, keyID uint32) (*core.StorageKeyRotation, error) {
+	if db.encryption == nil {
+		return nil, errors.New("[ RotateKey ] storage encryption is not configured")
+	}
+	cfg := db.encryptionCfg
+	cfg.KeyID = keyID
+	keys, err := loadValueCodec(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ RotateKey ] failed to load encryption keys")
+	}
+
+	db.rotationLock.Lock()
+	defer db.rotationLock.Unlock()
+
+	db.encryption.replace(keys)
+	state := &keyRotationState{StorageKeyRotation: core.StorageKeyRotation{KeyID: keyID, Started: time.Now()}}
+	if err := db.saveKeyRotation(ctx, state); err != nil {
+		return nil, errors.Wrap(err, "[ RotateKey ] failed to save rotation")
+	}
+	inslogger.FromContext(ctx).Infof("[ RotateKey ] storage key is rotated to %d, re-encryption started", keyID)
+	return &state.StorageKeyRotation, nil
+}
+
+// KeyRotation implements core.StorageKeyRotator.
+func (db *DB) KeyRotation(ctx context.Context) (*core.StorageKeyRotation, error) {
+	state, err := db.keyRotation(ctx)
+	if err != nil || state == nil {
+		return nil, err
+	}
+	return &state.StorageKeyRotation, nil
+}
+
+// ReencryptStep re-encrypts values of the next RotationBatch keys with the current key. Position and progress of
+// rotation are saved in the same transaction, so rotation continues where it stopped after restart.
+// Values which can't be decoded are left as is and counted in rotation state. If batch doesn't fit in one
+// transaction, values re-encrypted so far are committed and the batch is continued in the next one.
+func (db *DB) ReencryptStep(ctx context.Context) error {
+	db.closeLock.RLock()
+	defer db.closeLock.RUnlock()
+	if db.isClosed {
+		return ErrClosed
+	}
+
+	db.rotationLock.Lock()
+	defer db.rotationLock.Unlock()
+
+	state, err := db.keyRotation(ctx)
+	if err != nil || state == nil || !state.Finished.IsZero() {
+		return err
+	}
+
+	stateKey := keyRotationKey()
+	checked := 0
+	for {
+		full := false
+		err = db.db.Update(func(txn *badger.Txn) error {
+			it := txn.NewIterator(badger.DefaultIteratorOptions)
+			defer it.Close()
+
+			written := 0
+			for it.Seek(state.Next); it.Valid(); it.Next() {
+				item := it.Item()
+				if checked == db.encryptionCfg.RotationBatch {
+					state.Next = item.KeyCopy(nil)
+					break
+				}
+				checked++
+				if bytes.Equal(item.Key(), stateKey) {
+					continue
+				}
+				stale, err := db.encryption.stale(item)
+				var value []byte
+				if err == nil && stale {
+					value, err = db.encryption.decode(item)
+				}
+				if errors.Cause(err) == ErrUndecodable {
+					inslogger.FromContext(ctx).Warn("[ ReencryptStep ] value is left as is: ", err)
+					state.skip(item.KeyCopy(nil))
+					continue
+				}
+				if err != nil {
+					return err
+				}
+				if !stale {
+					continue
+				}
+				err = db.encryption.setEntry(txn, &badger.Entry{
+					Key:       item.KeyCopy(nil),
+					Value:     value,
+					UserMeta:  item.UserMeta() &^ userMetaEncrypted,
+					ExpiresAt: item.ExpiresAt(),
+				})
+				if err == badger.ErrTxnTooBig && written > 0 {
+					// values written so far are committed, this one is re-encrypted in the next transaction
+					checked--
+					state.Next = item.KeyCopy(nil)
+					full = true
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+				written++
+				state.Rewritten++
+			}
+			if !it.Valid() {
+				state.Next = nil
+				state.Finished = time.Now()
+			}
+			err := db.setKeyRotation(txn, state)
+			if err == badger.ErrTxnTooBig && written > 0 {
+				full = true
+				return nil
+			}
+			return err
+		})
+		if err != nil || !full {
+			break
+		}
+		// state didn't fit in transaction with values, it's saved separately
+		err = db.saveKeyRotation(ctx, state)
+		if err != nil || !state.Finished.IsZero() || checked == db.encryptionCfg.RotationBatch {
+			break
+		}
+	}
+	if err == badger.ErrConflict {
+		// values were changed concurrently, the step is repeated next time
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "[ ReencryptStep ] failed to re-encrypt values")
+	}
+	if !state.Finished.IsZero() {
+		inslogger.FromContext(ctx).Infof(
+			"[ ReencryptStep ] %d values are re-encrypted with key %d, %d values can't be decoded",
+			state.Rewritten, state.KeyID, state.Skipped,
+		)
+	}
+	return nil
+}
+
+// restoreKeyRotation makes storage encrypt new values with key of the last rotation instead of configured one.
+func (db *DB) restoreKeyRotation(ctx context.Context) error {
+	if db.encryption == nil {
+		return nil
+	}
+	state, err := db.keyRotation(ctx)
+	if err != nil || state == nil {
+		return err
+	}
+	return errors.Wrap(db.encryption.setCurrent(state.KeyID), "failed to restore storage key rotation")
+}
+
+func (db *DB) keyRotation(ctx context.Context) (*keyRotationState, error) {
+	buf, err := db.get(ctx, keyRotationKey())
+	if err == ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	state := &keyRotationState{}
+	if err := gob.NewDecoder(bytes.NewReader(buf)).Decode(state); err != nil {
+		return nil, errors.Wrap(err, "failed to decode key rotation")
+	}
+	return state, nil
+}
+
+func (db *DB) saveKeyRotation(ctx context.Context, state *keyRotationState) error {
+	return db.db.Update(func(txn *badger.Txn) error {
+		return db.setKeyRotation(txn, state)
+	})
+}
+
+func (db *DB) setKeyRotation(txn *badger.Txn, state *keyRotationState) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(state); err != nil {
+		return errors.Wrap(err, "failed to encode key rotation")
+	}
+	return db.encryption.set(txn, keyRotationKey(), buf.Bytes())
+}
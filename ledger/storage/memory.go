@@ -0,0 +1,99 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/ledger/storage/record"
+)
+
+// memoryDiffHeaderSize is a size of lengths of kept prefix and suffix of base memory.
+const memoryDiffHeaderSize = 8
+
+// DiffMemory returns binary delta of memory against base. Delta keeps common prefix and suffix of base and replaces
+// the rest, it's compact for objects which change part of their memory.
+func DiffMemory(base, memory []byte) []byte {
+	prefix := 0
+	for prefix < len(base) && prefix < len(memory) && base[prefix] == memory[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(base)-prefix && suffix < len(memory)-prefix &&
+		base[len(base)-1-suffix] == memory[len(memory)-1-suffix] {
+		suffix++
+	}
+
+	diff := make([]byte, memoryDiffHeaderSize, memoryDiffHeaderSize+len(memory)-prefix-suffix)
+	binary.BigEndian.PutUint32(diff[0:4], uint32(prefix))
+	binary.BigEndian.PutUint32(diff[4:8], uint32(suffix))
+	return append(diff, memory[prefix:len(memory)-suffix]...)
+}
+
+// PatchMemory applies binary delta made by DiffMemory to base.
+func PatchMemory(base, diff []byte) ([]byte, error) {
+	if len(diff) < memoryDiffHeaderSize {
+		return nil, errors.New("[ PatchMemory ] diff is too short")
+	}
+	prefix := int(binary.BigEndian.Uint32(diff[0:4]))
+	suffix := int(binary.BigEndian.Uint32(diff[4:8]))
+	if prefix+suffix > len(base) {
+		return nil, errors.New("[ PatchMemory ] diff doesn't match base")
+	}
+
+	data := diff[memoryDiffHeaderSize:]
+	memory := make([]byte, 0, prefix+len(data)+suffix)
+	memory = append(memory, base[:prefix]...)
+	memory = append(memory, data...)
+	return append(memory, base[len(base)-suffix:]...), nil
+}
+
+// GetObjectMemory returns memory of object state. Memory diffs of amends are materialized from previous states,
+// which are looked up in the same jet. ErrNotFound is returned if any of them is missing.
+func GetObjectMemory(
+	ctx context.Context, objectStorage ObjectStorage, jetID core.RecordID, state record.ObjectState,
+) ([]byte, error) {
+	if state.GetMemory() == nil {
+		return nil, nil
+	}
+	blob, err := objectStorage.GetBlob(ctx, jetID, state.GetMemory())
+	if err != nil {
+		return nil, err
+	}
+	amend, ok := state.(*record.ObjectAmendRecord)
+	if !ok || amend.MemoryDiffDepth == 0 {
+		return blob, nil
+	}
+
+	rec, err := objectStorage.GetRecord(ctx, jetID, &amend.PrevState)
+	if err != nil {
+		return nil, err
+	}
+	prev, ok := rec.(record.ObjectState)
+	if !ok {
+		return nil, errors.New("[ GetObjectMemory ] previous record isn't an object state")
+	}
+	base, err := GetObjectMemory(ctx, objectStorage, jetID, prev)
+	if err != nil {
+		return nil, err
+	}
+	return PatchMemory(base, blob)
+}
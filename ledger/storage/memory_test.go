@@ -0,0 +1,113 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/component"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage"
+	"github.com/insolar/insolar/ledger/storage/jet"
+	"github.com/insolar/insolar/ledger/storage/record"
+	"github.com/insolar/insolar/ledger/storage/storagetest"
+	"github.com/insolar/insolar/platformpolicy"
+)
+
+func TestDiffMemory(t *testing.T) {
+	cases := []struct {
+		base, memory string
+		size         int
+	}{
+		{"", "", 8},
+		{"", "new", 11},
+		{"old", "", 8},
+		{"hello world", "hello world", 8},
+		{"hello world", "hello there world", 14},
+		{"hello world", "hello", 8},
+		{"aaaa", "aa", 8},
+		{"abcdef", "xbcdex", 14},
+	}
+	for _, c := range cases {
+		diff := storage.DiffMemory([]byte(c.base), []byte(c.memory))
+		require.Len(t, diff, c.size, "diff of %q and %q", c.base, c.memory)
+		memory, err := storage.PatchMemory([]byte(c.base), diff)
+		require.NoError(t, err)
+		require.Equal(t, c.memory, string(memory))
+	}
+
+	_, err := storage.PatchMemory([]byte("a"), []byte{1})
+	require.Error(t, err)
+	_, err = storage.PatchMemory([]byte("a"), storage.DiffMemory([]byte("abc"), []byte("abd")))
+	require.Error(t, err)
+}
+
+func TestGetObjectMemory(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	db, cleaner := storagetest.TmpDB(ctx, t)
+	defer cleaner()
+	os := storage.NewObjectStorage()
+	cm := &component.Manager{}
+	cm.Inject(platformpolicy.NewPlatformCryptographyScheme(), db, os)
+
+	jetID := jet.ZeroJetID
+	pulse := core.PulseNumber(core.FirstPulseNumber)
+
+	memory := []byte("memory of first state")
+	blob, err := os.SetBlob(ctx, jetID, pulse, memory)
+	require.NoError(t, err)
+	activate := &record.ObjectActivateRecord{ObjectStateRecord: record.ObjectStateRecord{Memory: blob}}
+	activateID, err := os.SetRecord(ctx, jetID, pulse, activate)
+	require.NoError(t, err)
+
+	blob, err = os.SetBlob(ctx, jetID, pulse, storage.DiffMemory(memory, []byte("memory of second state")))
+	require.NoError(t, err)
+	first := &record.ObjectAmendRecord{
+		ObjectStateRecord: record.ObjectStateRecord{Memory: blob},
+		PrevState:         *activateID,
+		MemoryDiffDepth:   1,
+	}
+	firstID, err := os.SetRecord(ctx, jetID, pulse, first)
+	require.NoError(t, err)
+
+	blob, err = os.SetBlob(ctx, jetID, pulse, storage.DiffMemory([]byte("memory of second state"), []byte("memory of third")))
+	require.NoError(t, err)
+	second := &record.ObjectAmendRecord{
+		ObjectStateRecord: record.ObjectStateRecord{Memory: blob},
+		PrevState:         *firstID,
+		MemoryDiffDepth:   2,
+	}
+
+	res, err := storage.GetObjectMemory(ctx, os, jetID, activate)
+	require.NoError(t, err)
+	require.Equal(t, memory, res)
+	res, err = storage.GetObjectMemory(ctx, os, jetID, second)
+	require.NoError(t, err)
+	require.Equal(t, "memory of third", string(res))
+
+	res, err = storage.GetObjectMemory(ctx, os, jetID, &record.ObjectAmendRecord{})
+	require.NoError(t, err)
+	require.Nil(t, res)
+
+	missing := *second
+	missing.PrevState = *core.NewRecordID(pulse, []byte{1})
+	_, err = storage.GetObjectMemory(ctx, os, jetID, &missing)
+	require.Equal(t, storage.ErrNotFound, err)
+}
@@ -35,10 +35,13 @@ var (
 
 	statPulseDeleted = stats.Int64("lightcleanup/pulses/removed/total", "How many pulses deleted from pulseTracker on LM cleanup", stats.UnitDimensionless)
 	statPulseAdded   = stats.Int64("lightcleanup/pulses/added/total", "How many pulses added to pulseTracker", stats.UnitDimensionless)
+
+	statStorageHeadroom = stats.Int64("storage/headroom", "Free space of storage in percents", stats.UnitDimensionless)
+	statStorageLevel    = stats.Int64("storage/level", "Level of storage exhaustion: 0 - normal, 1 - warning, 2 - critical", stats.UnitDimensionless)
 )
 
 func init() {
-	err := view.Register(
+	err := insmetrics.Register(
 		&view.View{
 			Name:        statCleanScanned.Name(),
 			Description: statCleanScanned.Description(),
@@ -72,6 +75,18 @@ func init() {
 			Measure:     statPulseAdded,
 			Aggregation: view.Count(),
 		},
+		&view.View{
+			Name:        statStorageHeadroom.Name(),
+			Description: statStorageHeadroom.Description(),
+			Measure:     statStorageHeadroom,
+			Aggregation: view.LastValue(),
+		},
+		&view.View{
+			Name:        statStorageLevel.Name(),
+			Description: statStorageLevel.Description(),
+			Measure:     statStorageLevel,
+			Aggregation: view.LastValue(),
+		},
 	)
 	if err != nil {
 		panic(err)
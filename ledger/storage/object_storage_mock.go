@@ -37,6 +37,11 @@ type ObjectStorageMock struct {
 	GetRecordPreCounter uint64
 	GetRecordMock       mObjectStorageMockGetRecord
 
+	GetResultIDFunc       func(p context.Context, p1 core.RecordID, p2 *core.RecordID) (r *core.RecordID, r1 error)
+	GetResultIDCounter    uint64
+	GetResultIDPreCounter uint64
+	GetResultIDMock       mObjectStorageMockGetResultID
+
 	IterateIndexIDsFunc       func(p context.Context, p1 core.RecordID, p2 func(p core.RecordID) (r error)) (r error)
 	IterateIndexIDsCounter    uint64
 	IterateIndexIDsPreCounter uint64
@@ -79,6 +84,7 @@ func NewObjectStorageMock(t minimock.Tester) *ObjectStorageMock {
 	m.GetBlobMock = mObjectStorageMockGetBlob{mock: m}
 	m.GetObjectIndexMock = mObjectStorageMockGetObjectIndex{mock: m}
 	m.GetRecordMock = mObjectStorageMockGetRecord{mock: m}
+	m.GetResultIDMock = mObjectStorageMockGetResultID{mock: m}
 	m.IterateIndexIDsMock = mObjectStorageMockIterateIndexIDs{mock: m}
 	m.RemoveObjectIndexMock = mObjectStorageMockRemoveObjectIndex{mock: m}
 	m.SetBlobMock = mObjectStorageMockSetBlob{mock: m}
@@ -546,6 +552,158 @@ func (m *ObjectStorageMock) GetRecordFinished() bool {
 	return true
 }
 
+type mObjectStorageMockGetResultID struct {
+	mock              *ObjectStorageMock
+	mainExpectation   *ObjectStorageMockGetResultIDExpectation
+	expectationSeries []*ObjectStorageMockGetResultIDExpectation
+}
+
+type ObjectStorageMockGetResultIDExpectation struct {
+	input  *ObjectStorageMockGetResultIDInput
+	result *ObjectStorageMockGetResultIDResult
+}
+
+type ObjectStorageMockGetResultIDInput struct {
+	p  context.Context
+	p1 core.RecordID
+	p2 *core.RecordID
+}
+
+type ObjectStorageMockGetResultIDResult struct {
+	r  *core.RecordID
+	r1 error
+}
+
+//Expect specifies that invocation of ObjectStorage.GetResultID is expected from 1 to Infinity times
+func (m *mObjectStorageMockGetResultID) Expect(p context.Context, p1 core.RecordID, p2 *core.RecordID) *mObjectStorageMockGetResultID {
+	m.mock.GetResultIDFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ObjectStorageMockGetResultIDExpectation{}
+	}
+	m.mainExpectation.input = &ObjectStorageMockGetResultIDInput{p, p1, p2}
+	return m
+}
+
+//Return specifies results of invocation of ObjectStorage.GetResultID
+func (m *mObjectStorageMockGetResultID) Return(r *core.RecordID, r1 error) *ObjectStorageMock {
+	m.mock.GetResultIDFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ObjectStorageMockGetResultIDExpectation{}
+	}
+	m.mainExpectation.result = &ObjectStorageMockGetResultIDResult{r, r1}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of ObjectStorage.GetResultID is expected once
+func (m *mObjectStorageMockGetResultID) ExpectOnce(p context.Context, p1 core.RecordID, p2 *core.RecordID) *ObjectStorageMockGetResultIDExpectation {
+	m.mock.GetResultIDFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &ObjectStorageMockGetResultIDExpectation{}
+	expectation.input = &ObjectStorageMockGetResultIDInput{p, p1, p2}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *ObjectStorageMockGetResultIDExpectation) Return(r *core.RecordID, r1 error) {
+	e.result = &ObjectStorageMockGetResultIDResult{r, r1}
+}
+
+//Set uses given function f as a mock of ObjectStorage.GetResultID method
+func (m *mObjectStorageMockGetResultID) Set(f func(p context.Context, p1 core.RecordID, p2 *core.RecordID) (r *core.RecordID, r1 error)) *ObjectStorageMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.GetResultIDFunc = f
+	return m.mock
+}
+
+//GetResultID implements github.com/insolar/insolar/ledger/storage.ObjectStorage interface
+func (m *ObjectStorageMock) GetResultID(p context.Context, p1 core.RecordID, p2 *core.RecordID) (r *core.RecordID, r1 error) {
+	counter := atomic.AddUint64(&m.GetResultIDPreCounter, 1)
+	defer atomic.AddUint64(&m.GetResultIDCounter, 1)
+
+	if len(m.GetResultIDMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.GetResultIDMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to ObjectStorageMock.GetResultID. %v %v %v", p, p1, p2)
+			return
+		}
+
+		input := m.GetResultIDMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, ObjectStorageMockGetResultIDInput{p, p1, p2}, "ObjectStorage.GetResultID got unexpected parameters")
+
+		result := m.GetResultIDMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the ObjectStorageMock.GetResultID")
+			return
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.GetResultIDMock.mainExpectation != nil {
+
+		input := m.GetResultIDMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, ObjectStorageMockGetResultIDInput{p, p1, p2}, "ObjectStorage.GetResultID got unexpected parameters")
+		}
+
+		result := m.GetResultIDMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the ObjectStorageMock.GetResultID")
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.GetResultIDFunc == nil {
+		m.t.Fatalf("Unexpected call to ObjectStorageMock.GetResultID. %v %v %v", p, p1, p2)
+		return
+	}
+
+	return m.GetResultIDFunc(p, p1, p2)
+}
+
+//GetResultIDMinimockCounter returns a count of ObjectStorageMock.GetResultIDFunc invocations
+func (m *ObjectStorageMock) GetResultIDMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.GetResultIDCounter)
+}
+
+//GetResultIDMinimockPreCounter returns the value of ObjectStorageMock.GetResultID invocations
+func (m *ObjectStorageMock) GetResultIDMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.GetResultIDPreCounter)
+}
+
+//GetResultIDFinished returns true if mock invocations count is ok
+func (m *ObjectStorageMock) GetResultIDFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.GetResultIDMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.GetResultIDCounter) == uint64(len(m.GetResultIDMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.GetResultIDMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.GetResultIDCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.GetResultIDFunc != nil {
+		return atomic.LoadUint64(&m.GetResultIDCounter) > 0
+	}
+
+	return true
+}
+
 type mObjectStorageMockIterateIndexIDs struct {
 	mock              *ObjectStorageMock
 	mainExpectation   *ObjectStorageMockIterateIndexIDsExpectation
@@ -1465,6 +1623,9 @@ func (m *ObjectStorageMock) ValidateCallCounters() {
 	if !m.GetRecordFinished() {
 		m.t.Fatal("Expected call to ObjectStorageMock.GetRecord")
 	}
+	if !m.GetResultIDFinished() {
+		m.t.Fatal("Expected call to ObjectStorageMock.GetResultID")
+	}
 
 	if !m.IterateIndexIDsFinished() {
 		m.t.Fatal("Expected call to ObjectStorageMock.IterateIndexIDs")
@@ -1518,6 +1679,9 @@ func (m *ObjectStorageMock) MinimockFinish() {
 	if !m.GetRecordFinished() {
 		m.t.Fatal("Expected call to ObjectStorageMock.GetRecord")
 	}
+	if !m.GetResultIDFinished() {
+		m.t.Fatal("Expected call to ObjectStorageMock.GetResultID")
+	}
 
 	if !m.IterateIndexIDsFinished() {
 		m.t.Fatal("Expected call to ObjectStorageMock.IterateIndexIDs")
@@ -1560,6 +1724,7 @@ func (m *ObjectStorageMock) MinimockWait(timeout time.Duration) {
 		ok = ok && m.GetBlobFinished()
 		ok = ok && m.GetObjectIndexFinished()
 		ok = ok && m.GetRecordFinished()
+		ok = ok && m.GetResultIDFinished()
 		ok = ok && m.IterateIndexIDsFinished()
 		ok = ok && m.RemoveObjectIndexFinished()
 		ok = ok && m.SetBlobFinished()
@@ -1585,6 +1750,9 @@ func (m *ObjectStorageMock) MinimockWait(timeout time.Duration) {
 			if !m.GetRecordFinished() {
 				m.t.Error("Expected call to ObjectStorageMock.GetRecord")
 			}
+			if !m.GetResultIDFinished() {
+				m.t.Error("Expected call to ObjectStorageMock.GetResultID")
+			}
 
 			if !m.IterateIndexIDsFinished() {
 				m.t.Error("Expected call to ObjectStorageMock.IterateIndexIDs")
@@ -1633,6 +1801,9 @@ func (m *ObjectStorageMock) AllMocksCalled() bool {
 	if !m.GetRecordFinished() {
 		return false
 	}
+	if !m.GetResultIDFinished() {
+		return false
+	}
 
 	if !m.IterateIndexIDsFinished() {
 		return false
@@ -34,6 +34,7 @@ type ObjectStorage interface {
 
 	GetRecord(ctx context.Context, jetID core.RecordID, id *core.RecordID) (record.Record, error)
 	SetRecord(ctx context.Context, jetID core.RecordID, pulseNumber core.PulseNumber, rec record.Record) (*core.RecordID, error)
+	GetResultID(ctx context.Context, jetID core.RecordID, request *core.RecordID) (*core.RecordID, error)
 
 	SetMessage(ctx context.Context, jetID core.RecordID, pulseNumber core.PulseNumber, genericMessage core.Message) error
 
@@ -140,6 +141,22 @@ func (os *objectStorage) SetRecord(ctx context.Context, jetID core.RecordID, pul
 	return id, nil
 }
 
+// GetResultID wraps matching transaction manager method.
+func (os *objectStorage) GetResultID(ctx context.Context, jetID core.RecordID, request *core.RecordID) (*core.RecordID, error) {
+	var (
+		id  *core.RecordID
+		err error
+	)
+	err = os.DB.View(ctx, func(tx *TransactionManager) error {
+		id, err = tx.GetResultID(ctx, jetID, request)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return id, nil
+}
+
 // SetMessage persists message to the database
 func (os *objectStorage) SetMessage(ctx context.Context, jetID core.RecordID, pulseNumber core.PulseNumber, genericMessage core.Message) error {
 	_, prefix := jet.Jet(jetID)
@@ -0,0 +1,114 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package storage
+
+import (
+	"context"
+	"sync"
+
+	"go.opencensus.io/stats"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/instrumentation/nodeload"
+)
+
+// QuotaMonitor watches free space of storage. Below warning threshold it emits warnings, below critical threshold
+// node stops taking executor and validator roles, so it doesn't fail writes of data it's responsible for.
+type QuotaMonitor struct {
+	cfg configuration.StorageQuota
+	dir string
+	// headroom measures free space of storage in percents, it's replaced in tests.
+	headroom func(dir string) (uint8, error)
+
+	lock     sync.RWMutex
+	level    core.StorageLevel
+	measured uint8
+}
+
+// NewQuotaMonitor creates monitor of free space of storage data directory.
+func NewQuotaMonitor(cfg configuration.Storage) *QuotaMonitor {
+	return &QuotaMonitor{
+		cfg:      cfg.Quota,
+		dir:      cfg.DataDirectory,
+		headroom: nodeload.StorageHeadroom,
+		measured: 100,
+	}
+}
+
+// PeriodicTasks returns tasks of QuotaMonitor to be run by scheduler.
+func (m *QuotaMonitor) PeriodicTasks() []core.PeriodicTask {
+	if m.cfg.CheckInterval <= 0 {
+		return nil
+	}
+	return []core.PeriodicTask{{
+		Name:     "storage.quota",
+		Schedule: core.TaskSchedule{Every: m.cfg.CheckInterval},
+		Run:      m.Check,
+	}}
+}
+
+// Check measures free space of storage and updates storage level.
+func (m *QuotaMonitor) Check(ctx context.Context) error {
+	headroom, err := m.headroom(m.dir)
+	if err != nil {
+		return err
+	}
+	m.observe(ctx, headroom)
+	return nil
+}
+
+// StorageLevel implements core.StorageMonitor.
+func (m *QuotaMonitor) StorageLevel() core.StorageLevel {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.level
+}
+
+// Headroom implements core.StorageMonitor.
+func (m *QuotaMonitor) Headroom() uint8 {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.measured
+}
+
+func (m *QuotaMonitor) observe(ctx context.Context, headroom uint8) {
+	level := core.StorageLevelNormal
+	switch {
+	case headroom < m.cfg.CriticalHeadroom:
+		level = core.StorageLevelCritical
+	case headroom < m.cfg.WarningHeadroom:
+		level = core.StorageLevelWarning
+	}
+
+	m.lock.Lock()
+	prev := m.level
+	m.level, m.measured = level, headroom
+	m.lock.Unlock()
+
+	stats.Record(ctx, statStorageHeadroom.M(int64(headroom)), statStorageLevel.M(int64(level)))
+
+	logger := inslogger.FromContext(ctx)
+	switch {
+	case level == core.StorageLevelCritical && prev != level:
+		logger.Errorf("[ QuotaMonitor ] storage is exhausted, %d%% is free: node stops taking roles", headroom)
+	case level == core.StorageLevelWarning && prev != level:
+		logger.Warnf("[ QuotaMonitor ] storage is running out of space, %d%% is free", headroom)
+	case level == core.StorageLevelNormal && prev != level:
+		logger.Infof("[ QuotaMonitor ] storage space is freed, %d%% is free", headroom)
+	}
+}
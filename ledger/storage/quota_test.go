@@ -0,0 +1,68 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package storage
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+func TestQuotaMonitor_Check(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	cfg := configuration.NewLedger().Storage
+	cfg.Quota.WarningHeadroom = 15
+	cfg.Quota.CriticalHeadroom = 5
+	monitor := NewQuotaMonitor(cfg)
+	require.Equal(t, core.StorageLevelNormal, monitor.StorageLevel())
+
+	var free uint8
+	monitor.headroom = func(dir string) (uint8, error) {
+		require.Equal(t, cfg.DataDirectory, dir)
+		return free, nil
+	}
+	for _, tc := range []struct {
+		free  uint8
+		level core.StorageLevel
+	}{
+		{free: 40, level: core.StorageLevelNormal},
+		{free: 10, level: core.StorageLevelWarning},
+		{free: 4, level: core.StorageLevelCritical},
+		{free: 16, level: core.StorageLevelNormal},
+	} {
+		free = tc.free
+		require.NoError(t, monitor.Check(ctx))
+		require.Equal(t, tc.level, monitor.StorageLevel(), "free %d%%", tc.free)
+		require.Equal(t, tc.free, monitor.Headroom())
+	}
+
+	monitor.headroom = func(string) (uint8, error) { return 0, errors.New("statfs failed") }
+	require.Error(t, monitor.Check(ctx))
+	require.Equal(t, core.StorageLevelNormal, monitor.StorageLevel())
+}
+
+func TestQuotaMonitor_PeriodicTasks(t *testing.T) {
+	cfg := configuration.NewLedger().Storage
+	require.Len(t, NewQuotaMonitor(cfg).PeriodicTasks(), 1)
+
+	cfg.Quota.CheckInterval = 0
+	require.Empty(t, NewQuotaMonitor(cfg).PeriodicTasks())
+}
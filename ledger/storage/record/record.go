@@ -41,6 +41,7 @@ func init() {
 	register(102, new(JetRecord))
 
 	register(200, new(RequestRecord))
+	register(201, new(TimerRecord))
 
 	register(300, new(ResultRecord))
 	register(301, new(TypeRecord))
@@ -34,6 +34,12 @@ type RequestRecord struct {
 	Parcel      []byte
 	MessageHash []byte
 	Object      core.RecordID
+	// Sequence is a number of request within its object assigned by ledger on registration.
+	// It's not a part of record hash, so request keeps its id.
+	Sequence uint64
+	// SignedNonce is a nonce of signed request made by API node, request with the same nonce is registered
+	// for object once. It's covered by message hash.
+	SignedNonce []byte
 }
 
 // WriteHashData writes record data to provided writer. This data is used to calculate record's hash.
@@ -50,3 +56,22 @@ func (r *RequestRecord) GetPayload() []byte {
 func (r *RequestRecord) GetObject() core.RecordID {
 	return r.Object
 }
+
+// TimerRecord is a call scheduled by contract on itself to be made in future pulse.
+// It's closed by result record which refers to request registered for the call.
+type TimerRecord struct {
+	Message []byte
+	Object  core.RecordID
+	// Pulse is a pulse call is due in.
+	Pulse core.PulseNumber
+}
+
+// WriteHashData writes record data to provided writer. This data is used to calculate record's hash.
+func (r *TimerRecord) WriteHashData(w io.Writer) (int, error) {
+	n, err := w.Write(r.Message)
+	if err != nil {
+		return n, err
+	}
+	m, err := w.Write(r.Pulse.Bytes())
+	return n + m, err
+}
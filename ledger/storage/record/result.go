@@ -86,6 +86,9 @@ type CodeRecord struct {
 
 	Code        *core.RecordID
 	MachineType core.MachineType
+	// Schema is an id of blob with JSON schema of contract methods, it's derived from hash of the schema.
+	// Nil if code was deployed without schema.
+	Schema *core.RecordID
 }
 
 // WriteHashData writes record data to provided writer. This data is used to calculate record's hash.
@@ -145,6 +148,9 @@ type ObjectAmendRecord struct {
 	ObjectStateRecord
 
 	PrevState core.RecordID
+	// MemoryDiffDepth is a number of memory diffs since the last full snapshot of memory. If it isn't zero,
+	// Memory is a binary delta against memory of PrevState.
+	MemoryDiffDepth int
 }
 
 // PrevStateID returns previous state id.
@@ -13,6 +13,8 @@ func TypeFromRecord(generic Record) TypeID {
 		return 102
 	case *RequestRecord:
 		return 200
+	case *TimerRecord:
+		return 201
 	case *ResultRecord:
 		return 300
 	case *TypeRecord:
@@ -40,6 +42,8 @@ func RecordFromType(i TypeID) Record {
 		return new(JetRecord)
 	case 200:
 		return new(RequestRecord)
+	case 201:
+		return new(TimerRecord)
 	case 300:
 		return new(ResultRecord)
 	case 301:
@@ -67,6 +71,8 @@ func (i TypeID) String() string {
 		return "JetRecord"
 	case 200:
 		return "RequestRecord"
+	case 201:
+		return "TimerRecord"
 	case 300:
 		return "ResultRecord"
 	case 301:
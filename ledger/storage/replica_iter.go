@@ -87,6 +87,7 @@ func NewReplicaIter(
 			newit(scopeIDRecord, jetID, start, end),
 			newit(scopeIDBlob, jetID, start, end),
 			newit(scopeIDLifeline, jetID, core.FirstPulseNumber, end),
+			newit(scopeIDResult, jetID, core.FirstPulseNumber, end),
 			newit(scopeIDJetDrop, jetID, start, end),
 		},
 	}
@@ -99,6 +100,7 @@ func (r *ReplicaIter) NextRecords() ([]core.KV, error) {
 	}
 	fc := &fetchchunk{
 		db:    r.dbContext.GetBadgerDB(),
+		codec: r.dbContext.codec(),
 		limit: r.limitBytes,
 	}
 	for _, is := range r.istates {
@@ -137,6 +139,7 @@ func (r *ReplicaIter) isDone() bool {
 
 type fetchchunk struct {
 	db      *badger.DB
+	codec   *valueCodec
 	records []core.KV
 	size    int
 	limit   int
@@ -179,7 +182,7 @@ func (fc *fetchchunk) fetch(
 			lastpulse = pulseFromKey(key)
 			// fmt.Printf("Replica> key: %v (pulse=%v)\n", hex.EncodeToString(key), lastpulse)
 
-			value, err := it.Item().ValueCopy(nil)
+			value, err := fc.codec.decode(it.Item())
 			if err != nil {
 				return err
 			}
@@ -114,7 +114,7 @@ func (rs *replicaStorage) GetAllSyncClientJets(ctx context.Context) (map[core.Re
 				break
 			}
 			key := item.Key()
-			value, err := it.Item().Value()
+			value, err := rs.DB.codec().decode(it.Item())
 			if err != nil {
 				return err
 			}
@@ -0,0 +1,61 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/configuration"
+)
+
+// SchemaVersion is a version of layout of keys and values in storage. It's increased on incompatible changes,
+// node refuses to start on storage of other version.
+const SchemaVersion uint32 = 1
+
+// CheckSchemaVersion opens storage and checks its schema version. Storage without version is stamped with
+// SchemaVersion, since it was created before versions were recorded.
+func CheckSchemaVersion(ctx context.Context, conf configuration.Ledger) (uint32, error) {
+	dbContext, err := NewDB(conf, nil)
+	if err != nil {
+		return 0, err
+	}
+	db := dbContext.(*DB)
+	defer db.Close() // nolint: errcheck
+
+	key := prefixkey(scopeIDSystem, []byte{sysSchemaVersion})
+	buf, err := db.get(ctx, key)
+	if err == ErrNotFound {
+		buf = make([]byte, 4)
+		binary.BigEndian.PutUint32(buf, SchemaVersion)
+		return SchemaVersion, db.set(ctx, key, buf)
+	}
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to read schema version")
+	}
+	if len(buf) != 4 {
+		return 0, errors.Errorf("malformed schema version %x", buf)
+	}
+
+	version := binary.BigEndian.Uint32(buf)
+	if version != SchemaVersion {
+		return version, errors.Errorf("storage schema version %d doesn't match supported version %d", version, SchemaVersion)
+	}
+	return version, nil
+}
@@ -0,0 +1,56 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package storage
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+func TestCheckSchemaVersion(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	tmpdir, err := ioutil.TempDir("", "bdb-schema-")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpdir)
+	conf := configuration.Ledger{Storage: configuration.Storage{DataDirectory: tmpdir}}
+
+	version, err := CheckSchemaVersion(ctx, conf)
+	require.NoError(t, err)
+	require.Equal(t, SchemaVersion, version)
+
+	version, err = CheckSchemaVersion(ctx, conf)
+	require.NoError(t, err)
+	require.Equal(t, SchemaVersion, version)
+
+	dbContext, err := NewDB(conf, nil)
+	require.NoError(t, err)
+	buf := make([]byte, 4)
+	binary.BigEndian.PutUint32(buf, SchemaVersion+1)
+	require.NoError(t, dbContext.(*DB).set(ctx, prefixkey(scopeIDSystem, []byte{sysSchemaVersion}), buf))
+	require.NoError(t, dbContext.(*DB).Close())
+
+	version, err = CheckSchemaVersion(ctx, conf)
+	require.Error(t, err)
+	require.Equal(t, SchemaVersion+1, version)
+}
@@ -123,6 +123,23 @@ func (s *storageSuite) TestDB_SetRecord() {
 	assert.Equalf(s.T(), err, storage.ErrOverride, "records override should be forbidden")
 }
 
+func (s *storageSuite) TestDB_GetResultID() {
+	requestID := testutils.RandomID()
+	_, err := s.objectStorage.GetResultID(s.ctx, s.jetID, &requestID)
+	assert.Equal(s.T(), storage.ErrNotFound, err)
+
+	rec := &record.ResultRecord{
+		Object:  testutils.RandomID(),
+		Request: *core.NewRecordRef(core.DomainID, requestID),
+	}
+	resultID, err := s.objectStorage.SetRecord(s.ctx, s.jetID, core.GenesisPulse.PulseNumber, rec)
+	require.NoError(s.T(), err)
+
+	gotID, err := s.objectStorage.GetResultID(s.ctx, s.jetID, &requestID)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), resultID, gotID)
+}
+
 func (s *storageSuite) TestDB_SetObjectIndex_ReturnsNotFoundIfNoIndex() {
 	idx, err := s.objectStorage.GetObjectIndex(s.ctx, s.jetID, core.NewRecordID(0, hexhash("5000")), false)
 	assert.Equal(s.T(), storage.ErrNotFound, err)
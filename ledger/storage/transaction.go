@@ -59,7 +59,7 @@ func (m *TransactionManager) Commit() error {
 	tx := m.db.db.NewTransaction(m.update)
 	defer tx.Discard()
 	for _, rec := range m.txupdates {
-		err = tx.Set(rec.k, rec.v)
+		err = m.db.encryption.set(tx, rec.k, rec.v)
 		if err != nil {
 			break
 		}
@@ -160,9 +160,29 @@ func (m *TransactionManager) SetRecord(ctx context.Context, jetID core.RecordID,
 	if err != nil {
 		return nil, err
 	}
+	if res, ok := rec.(*record.ResultRecord); ok {
+		err = m.set(ctx, prefixkey(scopeIDResult, prefix, res.Request.Record()[:]), id[:])
+		if err != nil {
+			return nil, err
+		}
+	}
 	return id, nil
 }
 
+// GetResultID returns id of result record registered for provided request.
+//
+// If result is not registered returns ErrNotFound error.
+func (m *TransactionManager) GetResultID(ctx context.Context, jetID core.RecordID, request *core.RecordID) (*core.RecordID, error) {
+	_, prefix := jet.Jet(jetID)
+	buf, err := m.get(ctx, prefixkey(scopeIDResult, prefix, request[:]))
+	if err != nil {
+		return nil, err
+	}
+	var id core.RecordID
+	copy(id[:], buf)
+	return &id, nil
+}
+
 // GetObjectIndex fetches object lifeline index.
 func (m *TransactionManager) GetObjectIndex(
 	ctx context.Context,
@@ -234,7 +254,7 @@ func (m *TransactionManager) get(ctx context.Context, key []byte) ([]byte, error
 		}
 		return nil, err
 	}
-	return item.ValueCopy(nil)
+	return m.db.encryption.decode(item)
 }
 
 // removes value by key
@@ -0,0 +1,277 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+// Package upgrade coordinates restarts of nodes during rolling upgrades.
+package upgrade
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage"
+)
+
+// Coordinator grants restart slots. Every node runs it, but only the discovery node with the lowest reference
+// among working ones (arbiter) grants slots, other nodes forward their requests to it. Slots are kept in memory
+// of arbiter, so nodes ask for slots again if arbiter changes.
+type Coordinator struct {
+	Bus            core.MessageBus     `inject:""`
+	NodeNetwork    core.NodeNetwork    `inject:""`
+	PulseStorage   core.PulseStorage   `inject:""`
+	JetCoordinator core.JetCoordinator `inject:""`
+	JetStorage     storage.JetStorage  `inject:""`
+
+	conf      configuration.Upgrade
+	discovery []core.RecordRef
+
+	lock  sync.Mutex
+	slots map[core.RecordRef]core.RestartSlot
+}
+
+// NewCoordinator creates new restart slots coordinator.
+func NewCoordinator(conf configuration.Ledger, certificate core.Certificate) *Coordinator {
+	c := &Coordinator{
+		conf:  conf.Upgrade,
+		slots: map[core.RecordRef]core.RestartSlot{},
+	}
+	for _, node := range certificate.GetDiscoveryNodes() {
+		c.discovery = append(c.discovery, *node.GetNodeRef())
+	}
+	sort.Slice(c.discovery, func(i, j int) bool {
+		return bytes.Compare(c.discovery[i][:], c.discovery[j][:]) < 0
+	})
+	return c
+}
+
+// Init registers handler of restart slot requests.
+func (c *Coordinator) Init(ctx context.Context) error {
+	c.Bus.MustRegister(core.TypeRestartSlot, c.handleRestartSlot)
+	return nil
+}
+
+// RequestRestart implements core.UpgradeCoordinator.
+func (c *Coordinator) RequestRestart(ctx context.Context) (*core.RestartDecision, error) {
+	rep, err := c.send(ctx, message.RestartSlotAcquire)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ RequestRestart ] failed to acquire restart slot")
+	}
+	return rep.Decision, nil
+}
+
+// ReleaseRestart implements core.UpgradeCoordinator.
+func (c *Coordinator) ReleaseRestart(ctx context.Context) error {
+	_, err := c.send(ctx, message.RestartSlotRelease)
+	return errors.Wrap(err, "[ ReleaseRestart ] failed to release restart slot")
+}
+
+// RestartSlots implements core.UpgradeCoordinator.
+func (c *Coordinator) RestartSlots(ctx context.Context) ([]core.RestartSlot, error) {
+	rep, err := c.send(ctx, message.RestartSlotList)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ RestartSlots ] failed to list restart slots")
+	}
+	return rep.Slots, nil
+}
+
+// send performs action on behalf of this node, locally if node is arbiter.
+func (c *Coordinator) send(ctx context.Context, action message.RestartSlotAction) (*reply.RestartSlots, error) {
+	origin := c.NodeNetwork.GetOrigin()
+	arbiter, err := c.arbiter()
+	if err != nil {
+		return nil, err
+	}
+	if arbiter == origin.ID() {
+		return c.perform(ctx, origin.ID(), origin.Role(), action)
+	}
+
+	genericReply, err := c.Bus.Send(ctx, &message.RestartSlot{Action: action}, &core.MessageSendOptions{Receiver: &arbiter})
+	if err != nil {
+		return nil, err
+	}
+	rep, ok := genericReply.(*reply.RestartSlots)
+	if !ok {
+		return nil, errors.Errorf("unexpected reply from arbiter %s: %#v", arbiter, genericReply)
+	}
+	return rep, nil
+}
+
+// arbiter returns the working discovery node with the lowest reference.
+func (c *Coordinator) arbiter() (core.RecordRef, error) {
+	for _, ref := range c.discovery {
+		if c.NodeNetwork.GetWorkingNode(ref) != nil {
+			return ref, nil
+		}
+	}
+	return core.RecordRef{}, errors.New("no working discovery nodes to grant restart slots")
+}
+
+func (c *Coordinator) handleRestartSlot(ctx context.Context, parcel core.Parcel) (core.Reply, error) {
+	msg := parcel.Message().(*message.RestartSlot)
+
+	arbiter, err := c.arbiter()
+	if err != nil {
+		return nil, err
+	}
+	if arbiter != c.NodeNetwork.GetOrigin().ID() {
+		return nil, errors.Errorf("restart slots are granted by %s", arbiter)
+	}
+	sender := c.NodeNetwork.GetWorkingNode(parcel.GetSender())
+	if sender == nil {
+		return nil, errors.Errorf("sender %s isn't a working node", parcel.GetSender())
+	}
+	return c.perform(ctx, sender.ID(), sender.Role(), msg.Action)
+}
+
+// perform does action requested by node, node must be arbiter.
+func (c *Coordinator) perform(
+	ctx context.Context, node core.RecordRef, role core.StaticRole, action message.RestartSlotAction,
+) (*reply.RestartSlots, error) {
+	pulse, err := c.PulseStorage.Current(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.expire(pulse.PulseNumber)
+	switch action {
+	case message.RestartSlotAcquire:
+		decision, err := c.grant(ctx, node, role, pulse)
+		if err != nil {
+			return nil, err
+		}
+		return &reply.RestartSlots{Decision: decision}, nil
+	case message.RestartSlotRelease:
+		if _, ok := c.slots[node]; ok {
+			inslogger.FromContext(ctx).Infof("[ RestartSlot ] node %s released restart slot", node)
+		}
+		delete(c.slots, node)
+		return &reply.RestartSlots{}, nil
+	case message.RestartSlotList:
+		return &reply.RestartSlots{Slots: c.list()}, nil
+	}
+	return nil, errors.Errorf("unknown restart slot action %d", action)
+}
+
+// grant grants slot to node if no more than configured number of nodes of its role restart and none of them
+// validates the same jet. c.lock should be locked.
+func (c *Coordinator) grant(
+	ctx context.Context, node core.RecordRef, role core.StaticRole, pulse *core.Pulse,
+) (*core.RestartDecision, error) {
+	if slot, ok := c.slots[node]; ok {
+		return &core.RestartDecision{Slot: &slot}, nil
+	}
+
+	restarting := 0
+	for _, slot := range c.slots {
+		if slot.Role == role {
+			restarting++
+		}
+	}
+	if restarting >= c.conf.MaxRestartsPerRole {
+		return &core.RestartDecision{
+			Reason: fmt.Sprintf("%d of %d allowed %s nodes are restarting", restarting, c.conf.MaxRestartsPerRole, role),
+		}, nil
+	}
+
+	if role == core.StaticRoleLightMaterial {
+		reason, err := c.jetConflict(ctx, node, pulse.PulseNumber)
+		if err != nil {
+			return nil, err
+		}
+		if reason != "" {
+			return &core.RestartDecision{Reason: reason}, nil
+		}
+	}
+
+	delta := core.PulseNumber(1)
+	if pulse.NextPulseNumber > pulse.PulseNumber {
+		delta = pulse.NextPulseNumber - pulse.PulseNumber
+	}
+	slot := core.RestartSlot{
+		Node:    node,
+		Role:    role,
+		Granted: pulse.PulseNumber,
+		Expires: pulse.PulseNumber + core.PulseNumber(c.conf.SlotPulses)*delta,
+	}
+	c.slots[node] = slot
+	inslogger.FromContext(ctx).Infof("[ RestartSlot ] node %s granted restart slot till pulse %d", node, slot.Expires)
+	return &core.RestartDecision{Slot: &slot}, nil
+}
+
+// jetConflict returns reason why node can't restart if it validates some jet together with restarting node.
+func (c *Coordinator) jetConflict(ctx context.Context, node core.RecordRef, pulse core.PulseNumber) (string, error) {
+	jets, err := c.JetStorage.GetJets(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get jets")
+	}
+	for jetID := range jets {
+		validators, err := c.JetCoordinator.LightValidatorsForJet(ctx, jetID, pulse)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to calculate validators of jet %s", jetID.DebugString())
+		}
+		if !contains(validators, node) {
+			continue
+		}
+		for _, validator := range validators {
+			if _, ok := c.slots[validator]; ok && validator != node {
+				return fmt.Sprintf("node validates jet %s together with restarting node %s", jetID.DebugString(), validator), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// expire releases slots of nodes which didn't release them in time. c.lock should be locked.
+func (c *Coordinator) expire(pulse core.PulseNumber) {
+	for node, slot := range c.slots {
+		if pulse >= slot.Expires {
+			delete(c.slots, node)
+		}
+	}
+}
+
+// list returns slots sorted by node. c.lock should be locked.
+func (c *Coordinator) list() []core.RestartSlot {
+	slots := make([]core.RestartSlot, 0, len(c.slots))
+	for _, slot := range c.slots {
+		slots = append(slots, slot)
+	}
+	sort.Slice(slots, func(i, j int) bool {
+		return bytes.Compare(slots[i].Node[:], slots[j].Node[:]) < 0
+	})
+	return slots
+}
+
+func contains(refs []core.RecordRef, ref core.RecordRef) bool {
+	for _, r := range refs {
+		if r == ref {
+			return true
+		}
+	}
+	return false
+}
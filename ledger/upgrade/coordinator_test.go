@@ -0,0 +1,148 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package upgrade
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage"
+	"github.com/insolar/insolar/ledger/storage/jet"
+	"github.com/insolar/insolar/testutils"
+	"github.com/insolar/insolar/testutils/network"
+)
+
+type testNodes map[core.RecordRef]core.Node
+
+func (n testNodes) add(t *testing.T, role core.StaticRole) core.RecordRef {
+	ref := testutils.RandomRef()
+	node := network.NewNodeMock(t)
+	node.IDMock.Return(ref)
+	node.RoleMock.Return(role)
+	n[ref] = node
+	return ref
+}
+
+func newTestCoordinator(t *testing.T, conf configuration.Ledger, nodes testNodes, origin core.RecordRef, pulse *core.Pulse) *Coordinator {
+	discovery := testutils.NewDiscoveryNodeMock(t)
+	discovery.GetNodeRefMock.Return(&origin)
+	cert := testutils.NewCertificateMock(t)
+	cert.GetDiscoveryNodesMock.Return([]core.DiscoveryNode{discovery})
+
+	nn := network.NewNodeNetworkMock(t)
+	nn.GetOriginMock.Return(nodes[origin])
+	nn.GetWorkingNodeMock.Set(func(ref core.RecordRef) core.Node {
+		return nodes[ref]
+	})
+	ps := testutils.NewPulseStorageMock(t)
+	ps.CurrentMock.Set(func(context.Context) (*core.Pulse, error) {
+		return pulse, nil
+	})
+
+	c := NewCoordinator(conf, cert)
+	c.NodeNetwork = nn
+	c.PulseStorage = ps
+	return c
+}
+
+func perform(t *testing.T, c *Coordinator, node core.RecordRef, action message.RestartSlotAction) *reply.RestartSlots {
+	rep, err := c.handleRestartSlot(
+		inslogger.TestContext(t),
+		&message.Parcel{Msg: &message.RestartSlot{Action: action}, Sender: node},
+	)
+	require.NoError(t, err)
+	return rep.(*reply.RestartSlots)
+}
+
+func TestCoordinator_GrantsSlots(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	nodes := testNodes{}
+	arbiter := nodes.add(t, core.StaticRoleVirtual)
+	validator1 := nodes.add(t, core.StaticRoleLightMaterial)
+	validator2 := nodes.add(t, core.StaticRoleLightMaterial)
+	light1 := nodes.add(t, core.StaticRoleLightMaterial)
+	light2 := nodes.add(t, core.StaticRoleLightMaterial)
+
+	conf := configuration.NewLedger()
+	conf.Upgrade.MaxRestartsPerRole = 2
+	conf.Upgrade.SlotPulses = 3
+	pulse := &core.Pulse{PulseNumber: 100, NextPulseNumber: 110}
+	c := newTestCoordinator(t, conf, nodes, arbiter, pulse)
+
+	js := storage.NewJetStorageMock(t)
+	js.GetJetsMock.Return(jet.IDSet{core.TODOJetID: struct{}{}}, nil)
+	c.JetStorage = js
+	jc := testutils.NewJetCoordinatorMock(t)
+	jc.LightValidatorsForJetMock.Return([]core.RecordRef{validator1, validator2}, nil)
+	c.JetCoordinator = jc
+
+	rep := perform(t, c, validator1, message.RestartSlotAcquire)
+	slot := core.RestartSlot{Node: validator1, Role: core.StaticRoleLightMaterial, Granted: 100, Expires: 130}
+	require.Equal(t, &core.RestartDecision{Slot: &slot}, rep.Decision)
+	// slot is granted once
+	require.Equal(t, &slot, perform(t, c, validator1, message.RestartSlotAcquire).Decision.Slot)
+
+	// validators of the same jet don't restart together
+	rep = perform(t, c, validator2, message.RestartSlotAcquire)
+	require.Nil(t, rep.Decision.Slot)
+	require.Contains(t, rep.Decision.Reason, validator1.String())
+
+	require.NotNil(t, perform(t, c, light1, message.RestartSlotAcquire).Decision.Slot)
+	rep = perform(t, c, light2, message.RestartSlotAcquire)
+	require.Nil(t, rep.Decision.Slot)
+	require.Contains(t, rep.Decision.Reason, "2 of 2 allowed light_material nodes")
+
+	// arbiter grants slot to itself without messages
+	decision, err := c.RequestRestart(ctx)
+	require.NoError(t, err)
+	require.Equal(t, core.StaticRoleVirtual, decision.Slot.Role)
+	slots, err := c.RestartSlots(ctx)
+	require.NoError(t, err)
+	require.Len(t, slots, 3)
+
+	perform(t, c, validator1, message.RestartSlotRelease)
+	require.NotNil(t, perform(t, c, validator2, message.RestartSlotAcquire).Decision.Slot)
+
+	// slots expire if nodes don't release them
+	pulse.PulseNumber, pulse.NextPulseNumber = 130, 140
+	require.Empty(t, perform(t, c, arbiter, message.RestartSlotList).Slots)
+}
+
+func TestCoordinator_OnlyArbiterGrantsSlots(t *testing.T) {
+	nodes := testNodes{}
+	arbiter := nodes.add(t, core.StaticRoleHeavyMaterial)
+	other := nodes.add(t, core.StaticRoleVirtual)
+	c := newTestCoordinator(t, configuration.NewLedger(), nodes, arbiter, &core.Pulse{PulseNumber: 100})
+	c.NodeNetwork.(*network.NodeNetworkMock).GetOriginMock.Return(nodes[other])
+
+	_, err := c.handleRestartSlot(
+		inslogger.TestContext(t),
+		&message.Parcel{Msg: &message.RestartSlot{Action: message.RestartSlotAcquire}, Sender: other},
+	)
+	require.Contains(t, err.Error(), arbiter.String())
+
+	delete(nodes, arbiter)
+	_, err = c.arbiter()
+	require.Error(t, err)
+}
@@ -163,3 +163,25 @@ func TestLog_AddFields(t *testing.T) {
 		})
 	}
 }
+
+func TestLog_WithLevel(t *testing.T) {
+	logger, err := NewLog(configuration.Log{Level: "info", Adapter: "logrus", Formatter: "text"})
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	logger.SetOutput(&buf)
+
+	_, err = logger.WithLevel("invalid")
+	require.Error(t, err)
+
+	debugLogger, err := logger.WithLevel("debug")
+	require.NoError(t, err)
+	assert.Equal(t, "debug", debugLogger.GetLevel())
+	assert.Equal(t, "info", logger.GetLevel())
+
+	logger.WithField("flow", "plain").Debug("HelloWorld")
+	assert.Empty(t, buf.String())
+
+	debugLogger.WithField("flow", "traced").Debug("HelloWorld")
+	assertHelloWorld(t, buf.String())
+	assert.Contains(t, buf.String(), "flow=traced")
+}
@@ -76,6 +76,24 @@ func (l logrusAdapter) WithField(key string, value interface{}) core.Logger {
 	return lcopy
 }
 
+// WithLevel return copy of adapter with its own log level. Output, formatter and hooks are shared with adapter.
+func (l logrusAdapter) WithLevel(level string) (core.Logger, error) {
+	lvl, err := logrus.ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+
+	lcopy := l
+	lcopy.entry = l.entry.WithFields(logrus.Fields{})
+	lcopy.entry.Logger = &logrus.Logger{
+		Out:       l.entry.Logger.Out,
+		Hooks:     l.entry.Logger.Hooks,
+		Formatter: l.entry.Logger.Formatter,
+		Level:     lvl,
+	}
+	return lcopy, nil
+}
+
 // Debug logs a message at level Debug on the stdout.
 func (l logrusAdapter) Debug(args ...interface{}) {
 	if l.entry.Logger.IsLevelEnabled(logrus.DebugLevel) {
@@ -0,0 +1,70 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logicrunner
+
+import (
+	"context"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// PeriodicTasks returns scan of abandoned requests to be run by scheduler.
+func (lr *LogicRunner) PeriodicTasks() []core.PeriodicTask {
+	return []core.PeriodicTask{{
+		Name:     "logicrunner.abandoned",
+		Schedule: core.TaskSchedule{Pulses: 1},
+		Run:      lr.scanAbandonedRequests,
+	}}
+}
+
+// scanAbandonedRequests starts processing of objects which have requests pending on ledger, but nothing processes
+// them, e.g. when ledger notified about abandoned requests of object which has no incoming calls.
+func (lr *LogicRunner) scanAbandonedRequests(ctx context.Context) error {
+	if lr.isStopping() {
+		return nil
+	}
+
+	stalled := lr.stalledExecutionStates()
+	if len(stalled) == 0 {
+		return nil
+	}
+	inslogger.FromContext(ctx).Infof("[ scanAbandonedRequests ] fetching pending requests of %d objects", len(stalled))
+	for _, es := range stalled {
+		go lr.getLedgerPendingRequest(ctx, es)
+	}
+	return nil
+}
+
+// stalledExecutionStates returns execution states with requests on ledger and no active processing.
+func (lr *LogicRunner) stalledExecutionStates() []*ExecutionState {
+	var stalled []*ExecutionState
+	lr.stateMutex.RLock()
+	defer lr.stateMutex.RUnlock()
+	for _, state := range lr.state {
+		state.Lock()
+		if es := state.ExecutionState; es != nil {
+			es.Lock()
+			if es.LedgerHasMoreRequests && es.LedgerQueueElement == nil && !es.QueueProcessorActive && es.Current == nil {
+				stalled = append(stalled, es)
+			}
+			es.Unlock()
+		}
+		state.Unlock()
+	}
+	return stalled
+}
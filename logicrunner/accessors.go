@@ -46,6 +46,7 @@ func (lr *LogicRunner) GetObjectState(ref Ref) *ObjectState {
 	if !ok {
 		return nil
 	}
+	lr.touchObjectState(ref)
 	return res
 }
 
@@ -53,15 +54,19 @@ func (lr *LogicRunner) UpsertObjectState(ref Ref) *ObjectState {
 	lr.stateMutex.RLock()
 	if res, ok := lr.state[ref]; ok {
 		lr.stateMutex.RUnlock()
+		lr.touchObjectState(ref)
 		return res
 	}
 	lr.stateMutex.RUnlock()
 
 	lr.stateMutex.Lock()
 	defer lr.stateMutex.Unlock()
+	lr.stateMutex.assertLocked("LogicRunner.state")
 	if _, ok := lr.state[ref]; !ok {
+		lr.evictIdleStates()
 		lr.state[ref] = &ObjectState{}
 	}
+	lr.touchObjectState(ref)
 	return lr.state[ref]
 }
 
@@ -81,6 +86,15 @@ func (lr *LogicRunner) pulse(ctx context.Context) *core.Pulse {
 	return pulse
 }
 
+// requestPulse returns pulse given to contract executing queue element: pulse request was registered in,
+// current pulse if it's unknown, e.g. for pending requests fetched from ledger.
+func (lr *LogicRunner) requestPulse(ctx context.Context, qe ExecutionQueueElement) core.Pulse {
+	if qe.pulse.PulseNumber != 0 {
+		return qe.pulse
+	}
+	return *lr.pulse(ctx)
+}
+
 func (lr *LogicRunner) GetConsensus(ctx context.Context, ref Ref) *Consensus {
 	state := lr.UpsertObjectState(ref)
 
@@ -104,6 +118,7 @@ func (lr *LogicRunner) GetConsensus(ctx context.Context, ref Ref) *Consensus {
 }
 
 func (st *ObjectState) RefreshConsensus() {
+	st.assertLocked("ObjectState.Consensus")
 	if st.Consensus == nil {
 		return
 	}
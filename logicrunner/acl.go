@@ -0,0 +1,98 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logicrunner
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+)
+
+// aclCache keeps ACLs of prototypes read from ledger, it's reset on pulse change.
+type aclCache struct {
+	lock sync.Mutex
+	acls map[core.RecordRef]core.PrototypeACL
+}
+
+func newACLCache() *aclCache {
+	return &aclCache{acls: map[core.RecordRef]core.PrototypeACL{}}
+}
+
+func (c *aclCache) get(prototype core.RecordRef) (core.PrototypeACL, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	acl, ok := c.acls[prototype]
+	return acl, ok
+}
+
+func (c *aclCache) set(prototype core.RecordRef, acl core.PrototypeACL) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.acls[prototype] = acl
+}
+
+func (c *aclCache) reset() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.acls = map[core.RecordRef]core.PrototypeACL{}
+}
+
+// prototypeACL returns ACL kept in memory of prototype record.
+func (lr *LogicRunner) prototypeACL(ctx context.Context, prototype core.RecordRef) (core.PrototypeACL, error) {
+	if acl, ok := lr.acls.get(prototype); ok {
+		return acl, nil
+	}
+
+	protoDesc, err := lr.ArtifactManager.GetObject(ctx, prototype, nil, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't get prototype descriptor")
+	}
+	acl, err := core.DecodePrototypeACL(protoDesc.Memory())
+	if err != nil {
+		return nil, err
+	}
+	lr.acls.set(prototype, acl)
+	return acl, nil
+}
+
+// checkACL checks that caller carried in message may invoke method according to ACL of callee prototype.
+func (lr *LogicRunner) checkACL(ctx context.Context, m *message.CallMethod, body *ObjectBody) error {
+	acl, err := lr.prototypeACL(ctx, *body.Prototype)
+	if err != nil {
+		return err
+	}
+
+	var roles []string
+	if m.Caller.IsEmpty() {
+		roles = append(roles, core.ACLRoleAPI)
+	} else if body.Parent != nil && m.Caller == *body.Parent {
+		roles = append(roles, core.ACLRoleParent)
+	}
+	if !acl.Allows(m.Method, roles, m.CallerPrototype) {
+		return errors.Errorf(
+			"caller %s with prototype %s isn't allowed to call method %s", m.Caller, m.CallerPrototype, m.Method,
+		)
+	}
+	return nil
+}
@@ -23,9 +23,13 @@ import (
 
 	"github.com/insolar/insolar/component"
 	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/instrumentation/timeline"
 	"github.com/insolar/insolar/ledger/pulsemanager"
 	"github.com/insolar/insolar/ledger/recentstorage"
+	"github.com/insolar/insolar/ledger/storage"
 	"github.com/insolar/insolar/messagebus"
+	"github.com/insolar/insolar/netparams"
+	"github.com/insolar/insolar/network/clockskew"
 	"github.com/insolar/insolar/platformpolicy"
 	"github.com/insolar/insolar/testutils/network"
 	"github.com/insolar/insolar/testutils/nodekeeper"
@@ -58,6 +62,7 @@ func TestBareHelloworld(t *testing.T) {
 	lr, err := NewLogicRunner(&configuration.LogicRunner{
 		BuiltIn: &configuration.BuiltIn{},
 	})
+	lr.NetworkParameters = netparams.New()
 
 	mock := testutils.NewCryptographyServiceMock(t)
 	mock.SignFunc = func(p []byte) (r *core.Signature, r1 error) {
@@ -108,7 +113,7 @@ func TestBareHelloworld(t *testing.T) {
 	cm := &component.Manager{}
 	cm.Register(scheme)
 	cm.Register(l.GetPulseManager(), l.GetArtifactManager(), l.GetJetCoordinator())
-	cm.Inject(db, nk, recent, l, lr, nw, mb, delegationTokenFactory, parcelFactory, mock)
+	cm.Inject(db, nk, recent, l, lr, nw, mb, delegationTokenFactory, parcelFactory, clockskew.NewMonitor(configuration.NewClockSkew()), timeline.NewJournal(configuration.NewTimeline()), storage.NewDisputeStorage(), mock)
 	err = cm.Init(ctx)
 	assert.NoError(t, err)
 	err = cm.Start(ctx)
@@ -125,7 +130,7 @@ func TestBareHelloworld(t *testing.T) {
 	_, _, protoRef, err := goplugintestutils.AMPublishCode(t, am, domain, request, core.MachineTypeBuiltin, []byte("helloworld"))
 	assert.NoError(t, err)
 
-	contract, err := am.RegisterRequest(ctx, *am.GenesisRef(), &message.Parcel{Msg: &message.CallConstructor{PrototypeRef: byteRecorRef(4)}})
+	contract, _, err := am.RegisterRequest(ctx, *am.GenesisRef(), &message.Parcel{Msg: &message.CallConstructor{PrototypeRef: byteRecorRef(4)}})
 	assert.NoError(t, err)
 
 	// TODO: use proper conversion
@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/gob"
+	"math/rand"
 	"reflect"
 
 	"github.com/insolar/insolar/instrumentation/inslogger"
@@ -28,6 +29,7 @@ import (
 	"github.com/insolar/insolar/core/message"
 	"github.com/insolar/insolar/core/reply"
 	"github.com/pkg/errors"
+	"go.opencensus.io/stats"
 )
 
 type CaseRequest struct {
@@ -36,6 +38,9 @@ type CaseRequest struct {
 	MessageBus core.MessageBus
 	Reply      core.Reply
 	Error      string
+	Validate   bool
+	// Pulse is pulse request was registered in as contract has seen it.
+	Pulse core.Pulse
 }
 
 // CaseBinder is a whole result of executor efforts on every object it seen on this pulse
@@ -62,6 +67,8 @@ func NewCaseBindFromValidateMessage(ctx context.Context, mb core.MessageBus, msg
 			MessageBus: mb,
 			Reply:      req.Reply,
 			Error:      req.Error,
+			Validate:   req.Validate,
+			Pulse:      req.Pulse,
 		}
 	}
 	return res
@@ -82,18 +89,23 @@ func (cb *CaseBind) getCaseBindForMessage(ctx context.Context) []message.CaseBin
 	//requests := make([]message.CaseBindRequest, len(cb.Requests))
 	//
 	//for i, req := range cb.Requests {
+	//	requests[i] = message.CaseBindRequest{
+	//		Parcel:   req.Parcel,
+	//		Request:  req.Request,
+	//		Reply:    req.Reply,
+	//		Error:    req.Error,
+	//		Validate: req.Validate,
+	//		Pulse:    req.Pulse,
+	//	}
+	//	if !req.Validate {
+	//		continue
+	//	}
 	//	var buf bytes.Buffer
 	//	err := req.MessageBus.(core.TapeWriter).WriteTape(ctx, &buf)
 	//	if err != nil {
 	//		panic("couldn't write tape: " + err.Error())
 	//	}
-	//	requests[i] = message.CaseBindRequest{
-	//		Parcel:         req.Parcel,
-	//		Request:        req.Request,
-	//		MessageBusTape: buf.Bytes(),
-	//		Reply:          req.Reply,
-	//		Error:          req.Error,
-	//	}
+	//	requests[i].MessageBusTape = buf.Bytes()
 	//}
 	//
 	//return requests
@@ -108,11 +120,12 @@ func (cb *CaseBind) ToValidateMessage(ctx context.Context, ref Ref, pulse core.P
 	return res
 }
 
-func (cb *CaseBind) NewRequest(p core.Parcel, request Ref, mb core.MessageBus) *CaseRequest {
+func (cb *CaseBind) NewRequest(p core.Parcel, request Ref, pulse core.Pulse, mb core.MessageBus) *CaseRequest {
 	res := CaseRequest{
 		Parcel:     p,
 		Request:    request,
 		MessageBus: mb,
+		Pulse:      pulse,
 	}
 	cb.Requests = append(cb.Requests, res)
 	return &cb.Requests[len(cb.Requests)-1]
@@ -135,12 +148,15 @@ func NewCaseBindReplay(cb CaseBind) *CaseBindReplay {
 	}
 }
 
+// NextRequest returns next request executor asked to validate, requests left out by sampling are skipped.
 func (r *CaseBindReplay) NextRequest() *CaseRequest {
-	if r.Request+1 >= len(r.CaseBind.Requests) {
-		return nil
+	for r.Request+1 < len(r.CaseBind.Requests) {
+		r.Request++
+		if r.CaseBind.Requests[r.Request].Validate {
+			return &r.CaseBind.Requests[r.Request]
+		}
 	}
-	r.Request++
-	return &r.CaseBind.Requests[r.Request]
+	return nil
 }
 
 func (lr *LogicRunner) Validate(ctx context.Context, ref Ref, p core.Pulse, cb CaseBind) (int, error) {
@@ -167,11 +183,17 @@ func (lr *LogicRunner) Validate(ctx context.Context, ref Ref, p core.Pulse, cb C
 		ctx = inslogger.ContextWithTrace(ctx, traceID)
 		ctx = core.ContextWithMessageBus(ctx, request.MessageBus)
 
+		// contract gets pulse executor has given it, case binds without recorded pulse get pulse of execution
+		pulse := request.Pulse
+		if pulse.PulseNumber == 0 {
+			pulse = p
+		}
 		sender := request.Parcel.GetSender()
 		vs.Current = &CurrentExecution{
 			Context:       ctx,
 			Request:       &request.Request,
 			RequesterNode: &sender,
+			Pulse:         pulse,
 		}
 
 		rep, err := func() (core.Reply, error) {
@@ -181,6 +203,11 @@ func (lr *LogicRunner) Validate(ctx context.Context, ref Ref, p core.Pulse, cb C
 		}()
 
 		err = vs.Behaviour.Result(rep, err)
+		var apiRequest *core.APIRequest
+		if msg, ok := request.Parcel.Message().(message.IBaseLogicMessage); ok {
+			apiRequest = msg.GetAPIRequest()
+		}
+		lr.recordTimeline(ctx, core.TimelineValidationDone, apiRequest, &request.Request, err)
 		if err != nil {
 			return 0, errors.Wrap(err, "validation step failed")
 		}
@@ -275,8 +302,20 @@ func (vb *ValidationSaver) Mode() string {
 	return "execution"
 }
 
-func (vb *ValidationSaver) NewRequest(p core.Parcel, request Ref, mb core.MessageBus) {
-	vb.current = vb.caseBind.NewRequest(p, request, mb)
+func (vb *ValidationSaver) NewRequest(p core.Parcel, request Ref, pulse core.Pulse, mb core.MessageBus) {
+	vb.current = vb.caseBind.NewRequest(p, request, pulse, mb)
+	vb.current.Validate = vb.lr.sampleValidation()
+}
+
+// RequireValidation marks current request as high-value, it's validated regardless of sampling.
+func (vb *ValidationSaver) RequireValidation() {
+	if vb.current == nil {
+		return
+	}
+	if !vb.current.Validate {
+		vb.current.Validate = true
+		stats.Record(context.Background(), statValidationRequired.M(1))
+	}
 }
 
 func (vb *ValidationSaver) Result(reply core.Reply, err error) error {
@@ -287,9 +326,30 @@ func (vb *ValidationSaver) Result(reply core.Reply, err error) error {
 	if err != nil {
 		vb.current.Error = err.Error()
 	}
+	vb.lr.exportCaseRequest(vb.current)
+	if vb.current.Validate {
+		stats.Record(context.Background(), statValidationSampled.M(1))
+	} else {
+		stats.Record(context.Background(), statValidationSkipped.M(1))
+	}
 	return nil
 }
 
+// sampleValidation decides if execution should be offered to validators according to configured sample rate.
+func (lr *LogicRunner) sampleValidation() bool {
+	if lr == nil || lr.Cfg == nil {
+		return true
+	}
+	rate := lr.Cfg.ValidationSampleRate
+	switch {
+	case rate >= 1:
+		return true
+	case rate <= 0:
+		return false
+	}
+	return rand.Float64() < rate
+}
+
 type ValidationChecker struct {
 	lr      *LogicRunner
 	cb      *CaseBindReplay
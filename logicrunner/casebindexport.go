@@ -0,0 +1,171 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logicrunner
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/core/reply"
+)
+
+// caseBindRegistry keeps finished requests of executed objects per pulse for export to external validators.
+// Requests of the configured number of the most recent pulses with executions are kept.
+type caseBindRegistry struct {
+	pulses int
+
+	lock  sync.Mutex
+	binds map[core.PulseNumber]map[Ref][]CaseRequest
+}
+
+func newCaseBindRegistry(pulses int) *caseBindRegistry {
+	return &caseBindRegistry{
+		pulses: pulses,
+		binds:  map[core.PulseNumber]map[Ref][]CaseRequest{},
+	}
+}
+
+func (r *caseBindRegistry) add(pulse core.PulseNumber, object Ref, req CaseRequest) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	objects, ok := r.binds[pulse]
+	if !ok {
+		objects = map[Ref][]CaseRequest{}
+		r.binds[pulse] = objects
+	}
+	objects[object] = append(objects[object], req)
+}
+
+func (r *caseBindRegistry) get(pulse core.PulseNumber, object Ref) []CaseRequest {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	requests := r.binds[pulse][object]
+	if len(requests) == 0 {
+		return nil
+	}
+	return append([]CaseRequest(nil), requests...)
+}
+
+// forget drops requests of pulses older than configured number of recent pulses.
+func (r *caseBindRegistry) forget() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if len(r.binds) <= r.pulses {
+		return
+	}
+	pulses := make([]core.PulseNumber, 0, len(r.binds))
+	for pn := range r.binds {
+		pulses = append(pulses, pn)
+	}
+	sort.Slice(pulses, func(i, j int) bool { return pulses[i] > pulses[j] })
+	for _, pn := range pulses[r.pulses:] {
+		delete(r.binds, pn)
+	}
+}
+
+// exportCaseRequest keeps finished request for export if export is enabled.
+func (lr *LogicRunner) exportCaseRequest(req *CaseRequest) {
+	if lr == nil || lr.caseBinds == nil || req.Parcel == nil {
+		return
+	}
+	msg, ok := req.Parcel.Message().(message.IBaseLogicMessage)
+	if !ok {
+		return
+	}
+	pulse := core.PulseNumber(atomic.LoadUint32((*uint32)(&lr.lastPulse)))
+	lr.caseBinds.add(pulse, msg.GetReference(), *req)
+}
+
+// ExportCaseBind returns case bind of object executed by node in pulse in portable format.
+func (lr *LogicRunner) ExportCaseBind(
+	ctx context.Context, object core.RecordRef, pulse core.PulseNumber,
+) (*core.PortableCaseBind, error) {
+	if lr.caseBinds == nil {
+		return nil, errors.New("[ ExportCaseBind ] export of case binds is disabled")
+	}
+	requests := lr.caseBinds.get(pulse, object)
+	if requests == nil {
+		return nil, nil
+	}
+
+	res := &core.PortableCaseBind{
+		Version:  core.CaseBindFormatVersion,
+		Object:   object,
+		Pulse:    pulse,
+		Executor: lr.JetCoordinator.Me(),
+		Requests: make([]core.PortableCaseRequest, 0, len(requests)),
+	}
+	for _, req := range requests {
+		portable, err := portableCaseRequest(ctx, req)
+		if err != nil {
+			return nil, errors.Wrapf(err, "[ ExportCaseBind ] can't export request %s", req.Request)
+		}
+		res.Requests = append(res.Requests, portable)
+	}
+	return res, nil
+}
+
+func portableCaseRequest(ctx context.Context, req CaseRequest) (core.PortableCaseRequest, error) {
+	msg := req.Parcel.Message()
+	msgBuf, err := message.Serialize(msg)
+	if err != nil {
+		return core.PortableCaseRequest{}, errors.Wrap(err, "can't serialize message")
+	}
+	msgBytes, err := ioutil.ReadAll(msgBuf)
+	if err != nil {
+		return core.PortableCaseRequest{}, errors.Wrap(err, "can't serialize message")
+	}
+
+	res := core.PortableCaseRequest{
+		Request:     req.Request,
+		MessageType: msg.Type().String(),
+		Message:     msgBytes,
+		Error:       req.Error,
+		Validate:    req.Validate,
+		PulseNumber: req.Pulse.PulseNumber,
+		Entropy:     req.Pulse.Entropy[:],
+	}
+	if req.Reply != nil {
+		repBuf, err := reply.Serialize(req.Reply)
+		if err != nil {
+			return core.PortableCaseRequest{}, errors.Wrap(err, "can't serialize reply")
+		}
+		res.Reply, err = ioutil.ReadAll(repBuf)
+		if err != nil {
+			return core.PortableCaseRequest{}, errors.Wrap(err, "can't serialize reply")
+		}
+	}
+	if tw, ok := req.MessageBus.(core.TapeWriter); ok {
+		var tape bytes.Buffer
+		if err := tw.WriteTape(ctx, &tape); err != nil {
+			return core.PortableCaseRequest{}, errors.Wrap(err, "can't write tape")
+		}
+		res.Tape = tape.Bytes()
+	}
+	return res, nil
+}
@@ -0,0 +1,108 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logicrunner
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/testutils"
+)
+
+type tapedBus struct {
+	core.MessageBus
+	tape []byte
+}
+
+func (b *tapedBus) WriteTape(ctx context.Context, w io.Writer) error {
+	_, err := w.Write(b.tape)
+	return err
+}
+
+func TestCaseBindRegistry_Forget(t *testing.T) {
+	r := newCaseBindRegistry(2)
+	object := testutils.RandomRef()
+	for pn := core.FirstPulseNumber; pn < core.FirstPulseNumber+4; pn++ {
+		r.add(core.PulseNumber(pn), object, CaseRequest{Error: "test"})
+	}
+
+	r.forget()
+	require.Nil(t, r.get(core.FirstPulseNumber, object))
+	require.Nil(t, r.get(core.FirstPulseNumber+1, object))
+	require.Len(t, r.get(core.FirstPulseNumber+2, object), 1)
+	require.Len(t, r.get(core.FirstPulseNumber+3, object), 1)
+}
+
+func TestLogicRunner_ExportCaseBind(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	cfg := configuration.NewLogicRunner()
+	lr, err := NewLogicRunner(&cfg)
+	require.NoError(t, err)
+	me := testutils.RandomRef()
+	jc := testutils.NewJetCoordinatorMock(t)
+	jc.MeMock.Return(me)
+	lr.JetCoordinator = jc
+	lr.lastPulse = core.FirstPulseNumber
+
+	object, request := testutils.RandomRef(), testutils.RandomRef()
+	msg := &message.CallMethod{ObjectRef: object, Method: "GetBalance"}
+	rep := &reply.CallMethod{Result: []byte{1, 2, 3}}
+	saver := &ValidationSaver{lr: lr, caseBind: NewCaseBind()}
+	pulse := core.Pulse{PulseNumber: core.FirstPulseNumber, Entropy: core.Entropy{1, 2, 3}}
+	saver.NewRequest(&message.Parcel{Msg: msg}, request, pulse, &tapedBus{tape: []byte("tape")})
+	require.NoError(t, saver.Result(rep, nil))
+
+	cb, err := lr.ExportCaseBind(ctx, object, core.FirstPulseNumber)
+	require.NoError(t, err)
+	require.Equal(t, core.CaseBindFormatVersion, cb.Version)
+	require.Equal(t, object, cb.Object)
+	require.Equal(t, core.PulseNumber(core.FirstPulseNumber), cb.Pulse)
+	require.Equal(t, me, cb.Executor)
+	require.Len(t, cb.Requests, 1)
+
+	exported := cb.Requests[0]
+	require.Equal(t, request, exported.Request)
+	require.Equal(t, "TypeCallMethod", exported.MessageType)
+	require.Equal(t, []byte("tape"), exported.Tape)
+	require.True(t, exported.Validate)
+	require.Equal(t, pulse.PulseNumber, exported.PulseNumber)
+	require.Equal(t, pulse.Entropy[:], exported.Entropy)
+
+	gotMsg, err := message.Deserialize(bytes.NewReader(exported.Message))
+	require.NoError(t, err)
+	require.Equal(t, msg.Method, gotMsg.(*message.CallMethod).Method)
+	gotRep, err := reply.Deserialize(bytes.NewReader(exported.Reply))
+	require.NoError(t, err)
+	require.Equal(t, rep, gotRep)
+
+	cb, err = lr.ExportCaseBind(ctx, object, core.FirstPulseNumber+1)
+	require.NoError(t, err)
+	require.Nil(t, cb)
+
+	lr.caseBinds = nil
+	_, err = lr.ExportCaseBind(ctx, object, core.FirstPulseNumber)
+	require.Error(t, err)
+}
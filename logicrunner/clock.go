@@ -0,0 +1,68 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logicrunner
+
+import (
+	"context"
+	"time"
+)
+
+// Clock is a source of time and timers for LogicRunner. Tests substitute virtual clock
+// to control timeouts and delays without sleeping.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// racePoint names a place where handling of request races with pulse change.
+type racePoint string
+
+const (
+	// raceExecuteRegistered is reached by Execute after request is registered on ledger,
+	// execution state is unlocked there and pulse may change before request is queued.
+	raceExecuteRegistered racePoint = "execute.registered"
+	// raceStillExecuting is reached by HandleStillExecutingMessage before object state is locked.
+	raceStillExecuting racePoint = "still_executing.received"
+	// raceOnPulseStates is reached by OnPulse before states of objects are processed.
+	raceOnPulseStates racePoint = "on_pulse.states"
+)
+
+// setClock replaces clock of logic runner and its parts.
+func (lr *LogicRunner) setClock(clock Clock) {
+	lr.clock = clock
+	lr.sessions.now = clock.Now
+	if lr.spool != nil {
+		lr.spool.clock = clock
+	}
+}
+
+// reach passes race point, tests hold goroutines there to interleave them with pulse change.
+func (lr *LogicRunner) reach(ctx context.Context, point racePoint) {
+	if lr.raceHook != nil {
+		lr.raceHook(ctx, point)
+	}
+}
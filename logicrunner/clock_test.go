@@ -0,0 +1,186 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logicrunner
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// virtualClock is a Clock which time moves only when test advances it.
+type virtualClock struct {
+	lock   sync.Mutex
+	cond   *sync.Cond
+	now    time.Time
+	timers []virtualTimer
+}
+
+type virtualTimer struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func newVirtualClock(now time.Time) *virtualClock {
+	c := &virtualClock{now: now}
+	c.cond = sync.NewCond(&c.lock)
+	return c
+}
+
+func (c *virtualClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.now
+}
+
+func (c *virtualClock) After(d time.Duration) <-chan time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.timers = append(c.timers, virtualTimer{at: c.now.Add(d), ch: ch})
+	c.cond.Broadcast()
+	return ch
+}
+
+// Advance moves time forward and fires timers which are due.
+func (c *virtualClock) Advance(d time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.now = c.now.Add(d)
+	pending := c.timers[:0]
+	for _, t := range c.timers {
+		if t.at.After(c.now) {
+			pending = append(pending, t)
+			continue
+		}
+		t.ch <- c.now
+	}
+	c.timers = pending
+}
+
+// BlockUntil waits until n timers are waiting for time to advance.
+func (c *virtualClock) BlockUntil(n int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for len(c.timers) < n {
+		c.cond.Wait()
+	}
+}
+
+// raceScheduler holds goroutines on race points of LogicRunner until test releases them.
+type raceScheduler struct {
+	lock sync.Mutex
+	held map[racePoint]*heldPoint
+}
+
+type heldPoint struct {
+	reached chan struct{}
+	release chan struct{}
+}
+
+func newRaceScheduler(lr *LogicRunner) *raceScheduler {
+	s := &raceScheduler{held: map[racePoint]*heldPoint{}}
+	lr.raceHook = s.reach
+	return s
+}
+
+// Hold makes the next goroutine reaching point wait until Release.
+func (s *raceScheduler) Hold(point racePoint) *heldPoint {
+	h := &heldPoint{reached: make(chan struct{}), release: make(chan struct{})}
+	s.lock.Lock()
+	s.held[point] = h
+	s.lock.Unlock()
+	return h
+}
+
+func (s *raceScheduler) reach(ctx context.Context, point racePoint) {
+	s.lock.Lock()
+	h, ok := s.held[point]
+	delete(s.held, point)
+	s.lock.Unlock()
+	if !ok {
+		return
+	}
+	close(h.reached)
+	<-h.release
+}
+
+// Wait blocks until goroutine reaches held point.
+func (h *heldPoint) Wait() {
+	<-h.reached
+}
+
+// Release lets goroutine held on point continue.
+func (h *heldPoint) Release() {
+	close(h.release)
+}
+
+func TestVirtualClock(t *testing.T) {
+	start := time.Unix(100, 0)
+	clock := newVirtualClock(start)
+	require.Equal(t, start, clock.Now())
+
+	first := clock.After(time.Second)
+	second := clock.After(2 * time.Second)
+	clock.BlockUntil(2)
+
+	clock.Advance(time.Second)
+	require.Equal(t, start.Add(time.Second), <-first)
+	select {
+	case <-second:
+		t.Fatal("timer fired before its time")
+	default:
+	}
+
+	clock.Advance(time.Second)
+	require.Equal(t, start.Add(2*time.Second), <-second)
+	require.Equal(t, start.Add(2*time.Second), <-clock.After(0))
+}
+
+func TestRaceScheduler(t *testing.T) {
+	lr := &LogicRunner{}
+	races := newRaceScheduler(lr)
+	ctx := context.Background()
+
+	lr.reach(ctx, raceOnPulseStates)
+
+	held := races.Hold(raceOnPulseStates)
+	done := make(chan struct{})
+	go func() {
+		lr.reach(ctx, raceOnPulseStates)
+		close(done)
+	}()
+
+	held.Wait()
+	select {
+	case <-done:
+		t.Fatal("goroutine passed held point")
+	default:
+	}
+	held.Release()
+	<-done
+}
@@ -0,0 +1,41 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package logicrunner
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// compressResult compresses large method result returned to requester, result is sent as is if compression fails.
+func (lr *LogicRunner) compressResult(ctx context.Context, result *reply.CallMethod) *reply.CallMethod {
+	compressed, err := result.CompressResult(lr.Cfg.ResultCompressionThreshold)
+	if err != nil {
+		inslogger.FromContext(ctx).Warn("couldn't compress results: ", err)
+		return result
+	}
+	if compressed.Compressed {
+		stats.Record(ctx,
+			statResultsCompressed.M(1),
+			statResultsCompressionRatio.M(float64(len(compressed.Result))/float64(len(result.Result))),
+		)
+	}
+	return compressed
+}
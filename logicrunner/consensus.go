@@ -19,9 +19,11 @@ package logicrunner
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/pkg/errors"
 )
 
@@ -100,9 +102,11 @@ func (c *Consensus) CheckReady(ctx context.Context) {
 	var err error
 	if maxSame < c.Need && c.Total == c.Have {
 		c.ready = true
+		c.recordDispute(ctx, false)
 		err = c.lr.ArtifactManager.RegisterValidation(ctx, c.GetReference(), *c.FindRequestBefore(stepsSame), false, c.GetValidatorSignatures())
 	} else if maxSame >= c.Need && stepsSame == len(c.CaseBind.Requests) {
 		c.ready = true
+		c.recordDispute(ctx, true)
 		err = c.lr.ArtifactManager.RegisterValidation(ctx, c.GetReference(), *c.FindRequestBefore(stepsSame), true, c.GetValidatorSignatures())
 	}
 	if err != nil {
@@ -110,6 +114,45 @@ func (c *Consensus) CheckReady(ctx context.Context) {
 	}
 }
 
+// dispute returns dispute if any validator disagrees with executor results or nil otherwise.
+func (c *Consensus) dispute(approved bool) *core.ValidationDispute {
+	requests := len(c.CaseBind.Requests)
+	disagree := false
+	verdicts := make([]core.ValidatorVerdict, 0, len(c.Results))
+	for node, r := range c.Results {
+		if r.Steps != requests || r.Error != "" {
+			disagree = true
+		}
+		verdicts = append(verdicts, core.ValidatorVerdict{Node: node, Steps: r.Steps, Error: r.Error})
+	}
+	if !disagree {
+		return nil
+	}
+	return &core.ValidationDispute{
+		Object:             c.GetReference(),
+		Pulse:              c.Message.Pulse(),
+		Executor:           c.Message.GetSender(),
+		ExecutorResultHash: c.lr.PlatformCryptographyScheme.IntegrityHasher().Hash(message.ToBytes(c.Message.Message())),
+		Requests:           requests,
+		Verdicts:           verdicts,
+		Approved:           approved,
+		Time:               time.Now(),
+	}
+}
+
+// recordDispute archives dispute for operators if validators didn't agree with executor.
+func (c *Consensus) recordDispute(ctx context.Context, approved bool) {
+	dispute := c.dispute(approved)
+	if dispute == nil {
+		return
+	}
+	inslogger.FromContext(ctx).Warnf("[ Consensus ] validation dispute on object %s, approved: %t", dispute.Object, approved)
+	err := c.lr.DisputeArchive.SetValidationDispute(ctx, dispute)
+	if err != nil {
+		inslogger.FromContext(ctx).Error("[ Consensus ] failed to archive validation dispute: ", err)
+	}
+}
+
 func (c *Consensus) GetReference() Ref {
 	return c.Message.Message().(*message.ExecutorResults).RecordRef
 }
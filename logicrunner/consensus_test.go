@@ -0,0 +1,84 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logicrunner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+)
+
+type disputeArchive struct {
+	disputes []*core.ValidationDispute
+}
+
+func (a *disputeArchive) SetValidationDispute(ctx context.Context, dispute *core.ValidationDispute) error {
+	a.disputes = append(a.disputes, dispute)
+	return nil
+}
+
+func (a *disputeArchive) GetValidationDisputes(ctx context.Context, limit int) ([]core.ValidationDispute, error) {
+	return nil, nil
+}
+
+func TestConsensus_RecordDispute(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	archive := &disputeArchive{}
+	lr := &LogicRunner{
+		PlatformCryptographyScheme: platformpolicy.NewPlatformCryptographyScheme(),
+		DisputeArchive:             archive,
+	}
+
+	object, executor := testutils.RandomRef(), testutils.RandomRef()
+	first, second := testutils.RandomRef(), testutils.RandomRef()
+	parcel := testutils.NewParcelMock(t)
+	parcel.MessageMock.Return(&message.ExecutorResults{RecordRef: object})
+	parcel.PulseMock.Return(core.FirstPulseNumber)
+	parcel.GetSenderMock.Return(executor)
+
+	c := newConsensus(lr, []Ref{first, second})
+	c.Message = parcel
+	c.CaseBind.Requests = make([]CaseRequest, 2)
+	c.Results[first] = ConsensusRecord{Steps: 2}
+	c.Results[second] = ConsensusRecord{Steps: 2}
+
+	c.recordDispute(ctx, true)
+	require.Empty(t, archive.disputes, "validators agree with executor")
+
+	c.Results[second] = ConsensusRecord{Steps: 1, Error: "mismatch"}
+	c.recordDispute(ctx, true)
+	require.Len(t, archive.disputes, 1)
+
+	dispute := archive.disputes[0]
+	require.Equal(t, object, dispute.Object)
+	require.Equal(t, core.PulseNumber(core.FirstPulseNumber), dispute.Pulse)
+	require.Equal(t, executor, dispute.Executor)
+	require.NotEmpty(t, dispute.ExecutorResultHash)
+	require.Equal(t, 2, dispute.Requests)
+	require.True(t, dispute.Approved)
+	require.ElementsMatch(t, []core.ValidatorVerdict{
+		{Node: first, Steps: 2},
+		{Node: second, Steps: 1, Error: "mismatch"},
+	}, dispute.Verdicts)
+}
@@ -0,0 +1,96 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logicrunner
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// drainPollInterval is how often running executions are checked while draining.
+const drainPollInterval = 10 * time.Millisecond
+
+func (lr *LogicRunner) isStopping() bool {
+	return atomic.LoadInt32(&lr.stopping) == 1
+}
+
+// drain stops accepting requests and processing of queues, then waits for current executions
+// no longer than configured drain timeout.
+//
+// Queued requests are registered on ledger before they are queued, so they are released like
+// on pulse change and left on ledger, the next executor gets them as pending requests.
+func (lr *LogicRunner) drain(ctx context.Context) {
+	if !atomic.CompareAndSwapInt32(&lr.stopping, 0, 1) {
+		return
+	}
+	logger := inslogger.FromContext(ctx)
+
+	deadline := lr.clock.Now().Add(lr.Cfg.DrainTimeout)
+	for {
+		running := lr.runningExecutions()
+		if running == 0 {
+			break
+		}
+		if !lr.clock.Now().Before(deadline) {
+			logger.Warnf("[ LogicRunner.drain ] %d executions are still running, stopping anyway", running)
+			break
+		}
+		<-lr.clock.After(drainPollInterval)
+	}
+
+	released := 0
+	lr.stateMutex.Lock()
+	for _, state := range lr.state {
+		state.Lock()
+		if es := state.ExecutionState; es != nil {
+			es.Lock()
+			released += len(es.Queue)
+			es.releaseQueue()
+			es.LedgerQueueElement = nil
+			es.Unlock()
+		}
+		state.Unlock()
+	}
+	lr.stateMutex.Unlock()
+
+	if released > 0 {
+		logger.Infof("[ LogicRunner.drain ] %d queued requests are left on ledger for the next executor", released)
+	}
+}
+
+// runningExecutions returns number of objects which are executed at the moment.
+func (lr *LogicRunner) runningExecutions() int {
+	lr.stateMutex.RLock()
+	defer lr.stateMutex.RUnlock()
+
+	running := 0
+	for _, state := range lr.state {
+		state.Lock()
+		if es := state.ExecutionState; es != nil {
+			es.Lock()
+			if es.Current != nil {
+				running++
+			}
+			es.Unlock()
+		}
+		state.Unlock()
+	}
+	return running
+}
@@ -5,17 +5,20 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/core/message"
 )
 
 type ExecutionState struct {
-	sync.Mutex
+	stateMutex
 
 	Ref Ref
 
 	objectbody *ObjectBody
 	deactivate bool
-	nonce      uint64
+	// deactivated is set when execution deactivated the object, remaining requests are finished without execution
+	deactivated bool
+	nonce       uint64
 
 	Behaviour ValidationBehaviour
 
@@ -25,6 +28,16 @@ type ExecutionState struct {
 	LedgerHasMoreRequests bool
 	LedgerQueueElement    *ExecutionQueueElement
 	getLedgerPendingMutex sync.Mutex
+	// deferred is set when queue processing is stopped till next pulse because of execution deadline
+	deferred bool
+	// sequences tracks ledger assigned numbers of requests to the object
+	sequences *requestSequence
+	// registering is number of requests being registered on ledger, their numbers aren't known yet
+	registering int
+	// prefetched are descriptors of object and prototypes fetched in one batch when queue processing starts
+	prefetched map[Ref]core.ObjectDescriptor
+	// speculation is a result of the first queued request executed speculatively
+	speculation *speculation
 
 	// TODO not using in validation, need separate ObjectState.ExecutionState and ObjectState.Validation from ExecutionState struct
 	pending              message.PendingState
@@ -32,6 +45,27 @@ type ExecutionState struct {
 	HasPendingCheckMutex sync.Mutex
 }
 
+// sequence returns tracker of request numbers, es should be locked
+func (es *ExecutionState) sequence() *requestSequence {
+	if es.sequences == nil {
+		es.sequences = newRequestSequence()
+	}
+	return es.sequences
+}
+
+// takePrefetched returns descriptor prefetched for queue processing. Descriptor of the object itself is
+// returned once, because its state changes with execution, descriptors of prototypes are kept.
+func (es *ExecutionState) takePrefetched(ref Ref) (core.ObjectDescriptor, bool) {
+	es.Lock()
+	defer es.Unlock()
+
+	desc, ok := es.prefetched[ref]
+	if ok && ref == es.Ref {
+		delete(es.prefetched, ref)
+	}
+	return desc, ok
+}
+
 func (es *ExecutionState) WrapError(err error, message string) error {
 	if err == nil {
 		err = errors.New(message)
@@ -50,6 +84,7 @@ func (es *ExecutionState) WrapError(err error, message string) error {
 
 // releaseQueue must be calling only with es.Lock
 func (es *ExecutionState) releaseQueue() ([]ExecutionQueueElement, bool) {
+	es.assertLocked("ExecutionState.Queue")
 	ledgerHasMoreRequest := false
 	q := es.Queue
 
@@ -63,6 +98,76 @@ func (es *ExecutionState) releaseQueue() ([]ExecutionQueueElement, bool) {
 	return q, ledgerHasMoreRequest
 }
 
+// enqueue adds element to the queue, must be calling only with es.Lock
+//
+// With fair queuing element is put after all elements of other callers which have the same or smaller
+// number of requests ahead in the queue, so requests of distinct callers interleave and one caller
+// can't starve others by filling the queue.
+func (es *ExecutionState) enqueue(qe ExecutionQueueElement, fair bool) {
+	es.assertLocked("ExecutionState.Queue")
+	// latency-sensitive calls are queued after other latency-sensitive ones but ahead of regular calls
+	priorities := 0
+	for priorities < len(es.Queue) && es.Queue[priorities].priority {
+		priorities++
+	}
+	if qe.priority {
+		es.insert(priorities, qe)
+		return
+	}
+	if !fair {
+		es.Queue = append(es.Queue, qe)
+		return
+	}
+
+	round := es.callerQueueLength(qe.caller) + 1
+	rounds := make(map[core.RecordRef]int)
+	pos := priorities
+	for i, e := range es.Queue {
+		rounds[e.caller]++
+		if rounds[e.caller] <= round && i >= priorities {
+			pos = i + 1
+		}
+	}
+	es.insert(pos, qe)
+}
+
+func (es *ExecutionState) insert(pos int, qe ExecutionQueueElement) {
+	es.Queue = append(es.Queue, ExecutionQueueElement{})
+	copy(es.Queue[pos+1:], es.Queue[pos:])
+	es.Queue[pos] = qe
+}
+
+// callerQueueLength returns number of queued requests of caller, must be calling only with es.Lock
+func (es *ExecutionState) callerQueueLength(caller core.RecordRef) int {
+	es.assertLocked("ExecutionState.Queue")
+	n := 0
+	for _, e := range es.Queue {
+		if e.caller == caller {
+			n++
+		}
+	}
+	return n
+}
+
+// queueCaller returns caller which share of execution queue is accounted for the message.
+// Requests from API are accounted for the member who signed them.
+func queueCaller(msg message.IBaseLogicMessage) core.RecordRef {
+	caller := *msg.GetCaller()
+	if caller.IsEmpty() {
+		if apiRequest := msg.GetAPIRequest(); apiRequest != nil {
+			caller = apiRequest.Member
+		}
+	}
+	return caller
+}
+
+// queuePriority returns true if the message is a call marked as latency-sensitive by API node.
+func queuePriority(msg message.IBaseLogicMessage) bool {
+	apiRequest := msg.GetAPIRequest()
+	return apiRequest != nil && apiRequest.Priority
+}
+
 func (es *ExecutionState) haveSomeToProcess() bool {
+	es.assertLocked("ExecutionState.Queue")
 	return len(es.Queue) > 0 || es.LedgerHasMoreRequests || es.LedgerQueueElement != nil
 }
@@ -0,0 +1,63 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logicrunner
+
+// executorPool bounds number of contract executions running at once. Execution frees its worker while it waits
+// for results of nested calls, otherwise chains of calls longer than pool would deadlock.
+type executorPool struct {
+	workers chan struct{}
+}
+
+// newExecutorPool creates pool of size workers, nil pool is returned for non-positive size and doesn't limit
+// executions.
+func newExecutorPool(size int) *executorPool {
+	if size <= 0 {
+		return nil
+	}
+	return &executorPool{workers: make(chan struct{}, size)}
+}
+
+// acquire waits for free worker.
+func (p *executorPool) acquire() {
+	if p == nil {
+		return
+	}
+	p.workers <- struct{}{}
+}
+
+// release frees worker taken by acquire.
+func (p *executorPool) release() {
+	if p == nil {
+		return
+	}
+	<-p.workers
+}
+
+// suspendWorker frees worker of current execution of es while it waits for nested call, returned function takes
+// worker back. Executions which don't hold workers, e.g. validations, aren't affected.
+func (lr *LogicRunner) suspendWorker(es *ExecutionState) func() {
+	if lr.executors == nil || es.Current == nil || !es.Current.holdsWorker {
+		return func() {}
+	}
+	current := es.Current
+	current.holdsWorker = false
+	lr.executors.release()
+	return func() {
+		lr.executors.acquire()
+		current.holdsWorker = true
+	}
+}
@@ -0,0 +1,69 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logicrunner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutorPool_SuspendWorker(t *testing.T) {
+	lr := &LogicRunner{executors: newExecutorPool(1)}
+	es := &ExecutionState{Current: &CurrentExecution{}}
+
+	lr.executors.acquire()
+	es.Current.holdsWorker = true
+
+	nested := make(chan struct{})
+	go func() {
+		lr.executors.acquire()
+		close(nested)
+	}()
+	select {
+	case <-nested:
+		t.Fatal("nested execution took worker of running one")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	resume := lr.suspendWorker(es)
+	select {
+	case <-nested:
+	case <-time.After(time.Second):
+		t.Fatal("nested execution didn't get worker of suspended one")
+	}
+	require.False(t, es.Current.holdsWorker)
+
+	resumed := make(chan struct{})
+	go func() {
+		resume()
+		close(resumed)
+	}()
+	lr.executors.release()
+	<-resumed
+	require.True(t, es.Current.holdsWorker)
+}
+
+func TestExecutorPool_Unlimited(t *testing.T) {
+	lr := &LogicRunner{executors: newExecutorPool(0)}
+	require.Nil(t, lr.executors)
+
+	lr.executors.acquire()
+	lr.executors.release()
+	lr.suspendWorker(&ExecutionState{Current: &CurrentExecution{holdsWorker: true}})()
+}
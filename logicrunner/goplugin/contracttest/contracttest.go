@@ -0,0 +1,560 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+// Package contracttest provides harness for unit testing of contracts. Contract methods are executed
+// in the test process against in-memory artifact manager, calls of other contracts made via proxies
+// are recorded and answered by handlers registered in the harness.
+package contracttest
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
+	"github.com/insolar/insolar/logicrunner/goplugin/goplugintestutils"
+	"github.com/insolar/insolar/logicrunner/goplugin/proxyctx"
+	"github.com/insolar/insolar/testutils"
+	"github.com/pkg/errors"
+	"github.com/tylerb/gls"
+	"github.com/ugorji/go/codec"
+)
+
+// Call is a call of other contract made by tested contract.
+type Call struct {
+	Object      core.RecordRef // called object, parent for constructor calls
+	Prototype   core.RecordRef // prototype of proxy used for the call
+	Method      string
+	Args        []interface{}
+	Wait        bool
+	Constructor bool
+}
+
+// Timer is a call scheduled by tested contract on itself.
+type Timer struct {
+	Object core.RecordRef
+	Pulse  core.PulseNumber // pulse call is due in
+	Method string
+	Args   []interface{}
+}
+
+// Handler answers calls made by tested contract. It returns results of called method without trailing error.
+// Handlers of constructors return state of created object as the first result.
+type Handler func(call Call) ([]interface{}, error)
+
+type handlerKey struct {
+	prototype core.RecordRef
+	method    string
+}
+
+type lock struct {
+	owner   core.RecordRef
+	expires time.Time
+}
+
+// Harness executes contract methods in-process. It replaces proxyctx.Current until Close is called,
+// so only one harness can be used at a time.
+type Harness struct {
+	ArtifactManager *goplugintestutils.TestArtifactManager
+
+	// Pulse, Caller, CallerPrototype and APIRequest are put into call context of executed methods.
+	Pulse           core.Pulse
+	Caller          core.RecordRef
+	CallerPrototype core.RecordRef
+	APIRequest      *core.APIRequest
+
+	mu       sync.Mutex
+	calls    []Call
+	timers   []Timer
+	handlers map[handlerKey]Handler
+	children map[core.RecordRef][]core.RecordRef
+	locks    map[string]lock
+	validate map[core.RecordRef]bool
+	prev     proxyctx.ProxyHelper
+}
+
+// New creates harness with empty artifact manager and installs it as proxy helper.
+func New() *Harness {
+	h := &Harness{
+		ArtifactManager: goplugintestutils.NewTestArtifactManager(),
+		Pulse:           *core.GenesisPulse,
+		handlers:        make(map[handlerKey]Handler),
+		children:        make(map[core.RecordRef][]core.RecordRef),
+		locks:           make(map[string]lock),
+		validate:        make(map[core.RecordRef]bool),
+		prev:            proxyctx.Current,
+	}
+	proxyctx.Current = h
+	return h
+}
+
+// Close restores proxy helper replaced by the harness.
+func (h *Harness) Close() {
+	proxyctx.Current = h.prev
+}
+
+// Deploy activates object of prototype as child of parent. Contract is a pointer to initial state of the object.
+func (h *Harness) Deploy(contract interface{}, prototype, parent core.RecordRef) (core.RecordRef, error) {
+	return h.deploy(contract, prototype, parent, false)
+}
+
+// DeployAsDelegate activates object of prototype as delegate of parent.
+func (h *Harness) DeployAsDelegate(contract interface{}, prototype, parent core.RecordRef) (core.RecordRef, error) {
+	return h.deploy(contract, prototype, parent, true)
+}
+
+func (h *Harness) deploy(contract interface{}, prototype, parent core.RecordRef, asDelegate bool) (core.RecordRef, error) {
+	var memory []byte
+	err := h.Serialize(contract, &memory)
+	if err != nil {
+		return core.RecordRef{}, errors.Wrap(err, "[ Deploy ] Can't serialize contract")
+	}
+	return h.activate(parent, prototype, asDelegate, memory)
+}
+
+func (h *Harness) activate(parent, prototype core.RecordRef, asDelegate bool, memory []byte) (core.RecordRef, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ref := testutils.RandomRef()
+	_, err := h.ArtifactManager.ActivateObject(
+		context.Background(), core.RecordRef{}, ref, parent, prototype, asDelegate, memory,
+	)
+	if err != nil {
+		return core.RecordRef{}, err
+	}
+	if !asDelegate {
+		h.children[parent] = append(h.children[parent], ref)
+	}
+	return ref, nil
+}
+
+// State loads current state of object into contract, which should be a pointer to contract struct.
+func (h *Harness) State(object core.RecordRef, contract interface{}) error {
+	h.mu.Lock()
+	desc, ok := h.ArtifactManager.Objects[object]
+	h.mu.Unlock()
+	if !ok {
+		return errors.New("[ State ] No object")
+	}
+
+	v := reflect.ValueOf(contract)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return errors.New("[ State ] contract should be a non-nil pointer")
+	}
+	v.Elem().Set(reflect.Zero(v.Elem().Type()))
+	return h.Deserialize(desc.Data, contract)
+}
+
+// Call executes method of object the same way as logic runner does: loads state of the object into contract,
+// passes serialized arguments to the method and saves state of the object after the call. Contract should be
+// a pointer to contract struct of object's prototype, it holds new state of the object after the call.
+// Returns results of the method without trailing error and the error returned by the method.
+func (h *Harness) Call(object core.RecordRef, contract interface{}, method string, args ...interface{}) ([]interface{}, error) {
+	err := h.State(object, contract)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ Call ] Can't load state")
+	}
+
+	m := reflect.ValueOf(contract).MethodByName(method)
+	if !m.IsValid() {
+		return nil, errors.Errorf("[ Call ] contract has no method %s", method)
+	}
+	in, err := h.arguments(m.Type(), args)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[ Call ] Can't pass arguments of %s", method)
+	}
+	errType := reflect.TypeOf((*error)(nil)).Elem()
+	if m.Type().NumOut() == 0 || m.Type().Out(m.Type().NumOut()-1) != errType {
+		return nil, errors.Errorf("[ Call ] method %s should return error as the last result", method)
+	}
+
+	h.mu.Lock()
+	desc := h.ArtifactManager.Objects[object]
+	h.mu.Unlock()
+
+	callCtx := h.callContext(object, desc)
+	prev := gls.Get("callCtx")
+	gls.Set("callCtx", callCtx)
+	out := m.Call(in)
+	if prev != nil {
+		gls.Set("callCtx", prev)
+	} else {
+		gls.Cleanup()
+	}
+
+	h.mu.Lock()
+	_, active := h.ArtifactManager.Objects[object]
+	h.mu.Unlock()
+	if active {
+		var memory []byte
+		err = h.Serialize(contract, &memory)
+		if err != nil {
+			return nil, errors.Wrap(err, "[ Call ] Can't serialize state")
+		}
+		h.mu.Lock()
+		desc.Data = memory
+		h.mu.Unlock()
+	}
+
+	res := make([]interface{}, 0, len(out)-1)
+	for _, v := range out[:len(out)-1] {
+		res = append(res, v.Interface())
+	}
+	if e := out[len(out)-1]; !e.IsNil() {
+		return res, e.Interface().(error)
+	}
+	return res, nil
+}
+
+func (h *Harness) arguments(t reflect.Type, args []interface{}) ([]reflect.Value, error) {
+	if t.NumIn() != len(args) {
+		return nil, errors.Errorf("expected %d arguments, got %d", t.NumIn(), len(args))
+	}
+	in := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		var data []byte
+		err := h.Serialize(arg, &data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "can't serialize argument %d", i)
+		}
+		v := reflect.New(t.In(i))
+		err = h.Deserialize(data, v.Interface())
+		if err != nil {
+			return nil, errors.Wrapf(err, "can't deserialize argument %d", i)
+		}
+		in[i] = v.Elem()
+	}
+	return in, nil
+}
+
+func (h *Harness) callContext(object core.RecordRef, desc *goplugintestutils.TestObjectDescriptor) *core.LogicCallContext {
+	request := testutils.RandomRef()
+	caller := h.Caller
+	callerPrototype := h.CallerPrototype
+	// method called from handler of other call is called by the object that made that call
+	if outer, ok := gls.Get("callCtx").(*core.LogicCallContext); ok {
+		caller = *outer.Callee
+		callerPrototype = *outer.Prototype
+	}
+	var parent core.RecordRef
+	h.mu.Lock()
+	for p, children := range h.children {
+		for _, c := range children {
+			if c == object {
+				parent = p
+			}
+		}
+	}
+	h.mu.Unlock()
+
+	return &core.LogicCallContext{
+		Mode:            "execution",
+		Callee:          &object,
+		Request:         &request,
+		Prototype:       desc.PrototypeRef,
+		Code:            desc.PrototypeRef,
+		CallerPrototype: &callerPrototype,
+		Parent:          &parent,
+		Caller:          &caller,
+		Time:            time.Now(),
+		Pulse:           h.Pulse,
+		APIRequest:      h.APIRequest,
+	}
+}
+
+// Handle registers handler of calls of method made via proxy of prototype. Handler registered with empty
+// prototype handles calls of method made via any proxy.
+func (h *Harness) Handle(prototype core.RecordRef, method string, handler Handler) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handlers[handlerKey{prototype: prototype, method: method}] = handler
+}
+
+func (h *Harness) handler(prototype core.RecordRef, method string) Handler {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if handler, ok := h.handlers[handlerKey{prototype: prototype, method: method}]; ok {
+		return handler
+	}
+	return h.handlers[handlerKey{method: method}]
+}
+
+// Calls returns calls of other contracts made by tested contracts in order they were made.
+func (h *Harness) Calls() []Call {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]Call(nil), h.calls...)
+}
+
+// CallsOf returns calls of method made by tested contracts.
+func (h *Harness) CallsOf(method string) []Call {
+	var res []Call
+	for _, c := range h.Calls() {
+		if c.Method == method {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+// Timers returns calls scheduled by tested contracts in order they were scheduled.
+func (h *Harness) Timers() []Timer {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]Timer(nil), h.timers...)
+}
+
+// Reset forgets recorded calls and timers.
+func (h *Harness) Reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.calls = nil
+	h.timers = nil
+}
+
+func (h *Harness) record(call Call, argsSerialized []byte) (Call, error) {
+	err := h.Deserialize(argsSerialized, &call.Args)
+	if err != nil {
+		return call, errors.Wrap(err, "Can't deserialize arguments")
+	}
+	h.mu.Lock()
+	h.calls = append(h.calls, call)
+	h.mu.Unlock()
+	return call, nil
+}
+
+func (h *Harness) current() (*core.LogicCallContext, error) {
+	callCtx, ok := gls.Get("callCtx").(*core.LogicCallContext)
+	if !ok {
+		return nil, errors.New("proxy is used outside of contract call")
+	}
+	return callCtx, nil
+}
+
+// RouteCall records call and answers it with registered handler. Calls without wait don't require handler.
+// Calls with done ctx fail and are not recorded.
+func (h *Harness) RouteCall(
+	ctx context.Context, ref core.RecordRef, wait bool, method string, args []byte, proxyPrototype core.RecordRef,
+) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, errors.Wrap(err, "[ RouteCall ] call is aborted")
+	}
+	call, err := h.record(Call{Object: ref, Prototype: proxyPrototype, Method: method, Wait: wait}, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ RouteCall ]")
+	}
+
+	handler := h.handler(proxyPrototype, method)
+	if handler == nil {
+		if !wait {
+			return nil, nil
+		}
+		return nil, errors.Errorf("[ RouteCall ] no handler for method %s", method)
+	}
+	res, err := handler(call)
+	if !wait {
+		return nil, nil
+	}
+
+	var result []byte
+	err = h.Serialize(append(res, h.MakeErrorSerializable(err)), &result)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ RouteCall ] Can't serialize results")
+	}
+	return result, nil
+}
+
+// SaveAsChild records constructor call and activates object with state returned by handler of constructor.
+func (h *Harness) SaveAsChild(parentRef, classRef core.RecordRef, constructorName string, argsSerialized []byte) (core.RecordRef, error) {
+	return h.construct(parentRef, classRef, constructorName, argsSerialized, false)
+}
+
+// SaveAsDelegate records constructor call and activates delegate with state returned by handler of constructor.
+func (h *Harness) SaveAsDelegate(parentRef, classRef core.RecordRef, constructorName string, argsSerialized []byte) (core.RecordRef, error) {
+	return h.construct(parentRef, classRef, constructorName, argsSerialized, true)
+}
+
+func (h *Harness) construct(
+	parent, prototype core.RecordRef, constructor string, args []byte, asDelegate bool,
+) (core.RecordRef, error) {
+	call, err := h.record(Call{
+		Object: parent, Prototype: prototype, Method: constructor, Wait: true, Constructor: true,
+	}, args)
+	if err != nil {
+		return core.RecordRef{}, errors.Wrap(err, "[ SaveAsChild ]")
+	}
+
+	var state interface{}
+	if handler := h.handler(prototype, constructor); handler != nil {
+		res, err := handler(call)
+		if err != nil {
+			return core.RecordRef{}, err
+		}
+		if len(res) > 0 {
+			state = res[0]
+		}
+	}
+	return h.deploy(state, prototype, parent, asDelegate)
+}
+
+// GetObjChildrenIterator returns all children of object with prototype at once.
+func (h *Harness) GetObjChildrenIterator(head core.RecordRef, prototype core.RecordRef, iteratorID string) (*proxyctx.ChildrenTypedIterator, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	iter := &proxyctx.ChildrenTypedIterator{Parent: head, ChildPrototype: prototype}
+	for _, c := range h.children[head] {
+		desc, ok := h.ArtifactManager.Objects[c]
+		if !ok {
+			continue
+		}
+		if prototype.IsEmpty() || *desc.PrototypeRef == prototype {
+			iter.Buff = append(iter.Buff, c)
+		}
+	}
+	return iter, nil
+}
+
+// GetDelegate returns delegate of object with prototype.
+func (h *Harness) GetDelegate(object, ofType core.RecordRef) (core.RecordRef, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ref, err := h.ArtifactManager.GetDelegate(context.Background(), object, ofType)
+	if err != nil {
+		return core.RecordRef{}, errors.Wrap(err, "[ GetDelegate ]")
+	}
+	return *ref, nil
+}
+
+// DeactivateObject removes object from artifact manager.
+func (h *Harness) DeactivateObject(object core.RecordRef) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.ArtifactManager.Objects[object]; !ok {
+		return errors.New("[ DeactivateObject ] No object")
+	}
+	delete(h.ArtifactManager.Objects, object)
+	return nil
+}
+
+// Deactivated returns true if object was deactivated or never existed.
+func (h *Harness) Deactivated(object core.RecordRef) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, ok := h.ArtifactManager.Objects[object]
+	return !ok
+}
+
+// AcquireLock acquires named lock for the called object.
+func (h *Harness) AcquireLock(name string, ttl time.Duration) (bool, error) {
+	callCtx, err := h.current()
+	if err != nil {
+		return false, errors.Wrap(err, "[ AcquireLock ]")
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	l, ok := h.locks[name]
+	if ok && l.owner != *callCtx.Callee && time.Now().Before(l.expires) {
+		return false, nil
+	}
+	h.locks[name] = lock{owner: *callCtx.Callee, expires: time.Now().Add(ttl)}
+	return true, nil
+}
+
+// ReleaseLock releases named lock held by the called object.
+func (h *Harness) ReleaseLock(name string) error {
+	callCtx, err := h.current()
+	if err != nil {
+		return errors.Wrap(err, "[ ReleaseLock ]")
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if l, ok := h.locks[name]; ok && l.owner == *callCtx.Callee {
+		delete(h.locks, name)
+	}
+	return nil
+}
+
+// RequireValidation remembers that the called object marked its call as high-value.
+func (h *Harness) RequireValidation() error {
+	callCtx, err := h.current()
+	if err != nil {
+		return errors.Wrap(err, "[ RequireValidation ]")
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.validate[*callCtx.Callee] = true
+	return nil
+}
+
+// ValidationRequired returns true if object marked any of its calls as high-value.
+func (h *Harness) ValidationRequired(object core.RecordRef) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.validate[object]
+}
+
+// ScheduleCall records timer of the called object. Scheduled calls aren't made, test makes them with Call.
+func (h *Harness) ScheduleCall(pulses uint, method string, args []byte) error {
+	callCtx, err := h.current()
+	if err != nil {
+		return errors.Wrap(err, "[ ScheduleCall ]")
+	}
+	if pulses == 0 {
+		return errors.New("[ ScheduleCall ] call should be scheduled at least one pulse ahead")
+	}
+
+	timer := Timer{
+		Object: *callCtx.Callee,
+		Pulse:  h.Pulse.NumberAfter(pulses),
+		Method: method,
+	}
+	err = h.Deserialize(args, &timer.Args)
+	if err != nil {
+		return errors.Wrap(err, "[ ScheduleCall ] Can't deserialize arguments")
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.timers = append(h.timers, timer)
+	return nil
+}
+
+// Serialize serializes data the same way as contract runtime does.
+func (h *Harness) Serialize(what interface{}, to *[]byte) error {
+	return codec.NewEncoderBytes(to, new(codec.CborHandle)).Encode(what)
+}
+
+// Deserialize deserializes data the same way as contract runtime does.
+func (h *Harness) Deserialize(from []byte, into interface{}) error {
+	return codec.NewDecoderBytes(from, new(codec.CborHandle)).Decode(into)
+}
+
+// MakeErrorSerializable converts error to foundation.Error.
+func (h *Harness) MakeErrorSerializable(e error) error {
+	if e == nil || e == (*foundation.Error)(nil) || reflect.ValueOf(e).IsNil() {
+		return nil
+	}
+	return &foundation.Error{S: e.Error()}
+}
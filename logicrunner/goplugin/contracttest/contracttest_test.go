@@ -0,0 +1,129 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package contracttest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/insolar/insolar/application/contract/wallet"
+	"github.com/insolar/insolar/application/proxy/allowance"
+	"github.com/insolar/insolar/application/proxy/member"
+	walletproxy "github.com/insolar/insolar/application/proxy/wallet"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func deployWallet(t *testing.T, h *Harness, balance uint) (core.RecordRef, core.RecordRef) {
+	m, err := h.Deploy(nil, member.GetPrototype(), core.RecordRef{})
+	require.NoError(t, err)
+	w, err := h.DeployAsDelegate(&wallet.Wallet{Balance: balance}, walletproxy.GetPrototype(), m)
+	require.NoError(t, err)
+	return m, w
+}
+
+func TestHarness_Call(t *testing.T) {
+	h := New()
+	defer h.Close()
+
+	_, from := deployWallet(t, h, 100)
+	to, toWallet := deployWallet(t, h, 0)
+
+	var w wallet.Wallet
+	_, err := h.Call(from, &w, "Transfer", uint(10), &to)
+	require.NoError(t, err)
+	require.Equal(t, uint(90), w.Balance)
+
+	var saved wallet.Wallet
+	require.NoError(t, h.State(from, &saved))
+	require.Equal(t, uint(90), saved.Balance)
+
+	calls := h.Calls()
+	require.Len(t, calls, 2)
+	require.True(t, calls[0].Constructor)
+	require.Equal(t, from, calls[0].Object)
+	require.Equal(t, allowance.GetPrototype(), calls[0].Prototype)
+	require.Equal(t, uint64(10), calls[0].Args[1])
+	require.Equal(t, "Accept", calls[1].Method)
+	require.Equal(t, toWallet, calls[1].Object)
+	require.False(t, calls[1].Wait)
+
+	_, err = h.Call(from, &w, "Transfer", uint(1000), &to)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Not enough balance")
+	require.Equal(t, uint(90), w.Balance)
+	require.Len(t, h.Calls(), 2)
+}
+
+func TestHarness_Handle(t *testing.T) {
+	h := New()
+	defer h.Close()
+
+	_, w := deployWallet(t, h, 100)
+	_, err := h.Deploy(nil, allowance.GetPrototype(), w)
+	require.NoError(t, err)
+
+	h.Handle(allowance.GetPrototype(), "GetExpiredBalance", func(call Call) ([]interface{}, error) {
+		return []interface{}{uint(5)}, nil
+	})
+	res, err := h.Call(w, &wallet.Wallet{}, "GetBalance")
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{uint(105)}, res)
+	require.Len(t, h.CallsOf("GetExpiredBalance"), 1)
+
+	h.Reset()
+	_, err = h.Call(w, &wallet.Wallet{}, "Accept", &w)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no handler for method TakeAmount")
+	require.Len(t, h.Calls(), 1)
+}
+
+func TestHarness_CallErrors(t *testing.T) {
+	h := New()
+	defer h.Close()
+
+	_, w := deployWallet(t, h, 100)
+	_, err := h.Call(w, &wallet.Wallet{}, "NoSuchMethod")
+	require.Error(t, err)
+	_, err = h.Call(w, &wallet.Wallet{}, "GetBalance", 1)
+	require.Error(t, err)
+	_, err = h.Call(core.RecordRef{}, &wallet.Wallet{}, "GetBalance")
+	require.Error(t, err)
+}
+
+func TestHarness_CallCanceled(t *testing.T) {
+	h := New()
+	defer h.Close()
+
+	_, w := deployWallet(t, h, 100)
+	a, err := h.Deploy(nil, allowance.GetPrototype(), w)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = allowance.GetObject(a).TakeAmountWithContext(ctx)
+	require.Error(t, err)
+
+	callErr, ok := err.(*foundation.CallError)
+	require.True(t, ok)
+	require.Equal(t, a, callErr.Object)
+	require.Equal(t, "TakeAmount", callErr.Method)
+	require.Equal(t, context.Canceled, errors.Cause(err))
+	require.Empty(t, h.CallsOf("TakeAmount"))
+}
@@ -18,6 +18,7 @@ package foundation
 
 import (
 	"crypto"
+	"sync/atomic"
 
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/platformpolicy"
@@ -28,6 +29,18 @@ import (
 var platformCryptographyScheme = platformpolicy.NewPlatformCryptographyScheme()
 var keyProcessor = platformpolicy.NewKeyProcessor()
 
+// secp256k1Keys is non-zero if members may have secp256k1 keys of Ethereum-style wallets.
+var secp256k1Keys int32
+
+// AllowSecp256k1Keys switches import of secp256k1 keys of members, it must be set the same way on all nodes.
+func AllowSecp256k1Keys(allow bool) {
+	var value int32
+	if allow {
+		value = 1
+	}
+	atomic.StoreInt32(&secp256k1Keys, value)
+}
+
 // Sign signs given seed.
 func Sign(data []byte, key crypto.PrivateKey) ([]byte, error) {
 	signature, err := platformCryptographyScheme.Signer(key).Sign(data)
@@ -37,8 +50,11 @@ func Sign(data []byte, key crypto.PrivateKey) ([]byte, error) {
 	return signature.Bytes(), nil
 }
 
-// Verify verifies signature.
+// Verify verifies signature. Signatures of secp256k1 keys are expected in form Ethereum wallets make them.
 func Verify(data []byte, signatureRaw []byte, publicKey crypto.PublicKey) bool {
+	if platformpolicy.IsSecp256k1Key(publicKey) {
+		return platformpolicy.NewEthereumVerifier(publicKey).Verify(core.SignatureFromBytes(signatureRaw), data)
+	}
 	return platformCryptographyScheme.Verifier(publicKey).Verify(core.SignatureFromBytes(signatureRaw), data)
 }
 
@@ -46,7 +62,11 @@ func GeneratePrivateKey() (crypto.PrivateKey, error) {
 	return keyProcessor.GeneratePrivateKey()
 }
 
+// ImportPublicKey parses PEM key of platform or, if it's allowed, secp256k1 key of Ethereum-style wallet.
 func ImportPublicKey(publicKey string) (crypto.PublicKey, error) {
+	if atomic.LoadInt32(&secp256k1Keys) != 0 {
+		return platformpolicy.ImportMemberPublicKey([]byte(publicKey))
+	}
 	return keyProcessor.ImportPublicKeyPEM([]byte(publicKey))
 }
 
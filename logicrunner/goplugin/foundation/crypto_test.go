@@ -0,0 +1,38 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package foundation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/platformpolicy"
+)
+
+func TestImportPublicKey_Secp256k1(t *testing.T) {
+	const walletKey = "0x0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"
+	defer AllowSecp256k1Keys(false)
+
+	_, err := ImportPublicKey(walletKey)
+	require.Error(t, err)
+
+	AllowSecp256k1Keys(true)
+	key, err := ImportPublicKey(walletKey)
+	require.NoError(t, err)
+	require.True(t, platformpolicy.IsSecp256k1Key(key))
+}
@@ -18,6 +18,8 @@
 package foundation
 
 import (
+	"time"
+
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/logicrunner/goplugin/proxyctx"
 	"github.com/tylerb/gls"
@@ -98,6 +100,36 @@ func (bc *BaseContract) SelfDestruct() error {
 	return proxyctx.Current.DeactivateObject(bc.GetReference())
 }
 
+// AcquireLock acquires named lock for the contract or extends it if the contract already holds the lock.
+// Returns false if the lock is held by other object. Lock is released after ttl or when pulse changes,
+// whatever happens first, so it can guard multi-step workflows spanning several calls within a pulse.
+func (bc *BaseContract) AcquireLock(name string, ttl time.Duration) (bool, error) {
+	return proxyctx.Current.AcquireLock(name, ttl)
+}
+
+// ReleaseLock releases named lock held by the contract.
+func (bc *BaseContract) ReleaseLock(name string) error {
+	return proxyctx.Current.ReleaseLock(name)
+}
+
+// RequireValidation marks current call as high-value, such calls are always checked by validators
+// even if execution sampling would skip them.
+func (bc *BaseContract) RequireValidation() error {
+	return proxyctx.Current.RequireValidation()
+}
+
+// ScheduleCall schedules call of the contract's method with args to be made after pulses pulses. Scheduled call
+// is made by executor of due pulse like a call of other contract made without waiting for result, it can be used
+// for auctions, vesting or expiration without external triggers.
+func (bc *BaseContract) ScheduleCall(pulses uint, method string, args ...interface{}) error {
+	var argsSerialized []byte
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return err
+	}
+	return proxyctx.Current.ScheduleCall(pulses, method, argsSerialized)
+}
+
 // Error elementary string based error struct satisfying builtin error interface
 //    foundation.Error{"some err"}
 type Error struct {
@@ -108,3 +140,27 @@ type Error struct {
 func (e *Error) Error() string {
 	return e.S
 }
+
+// CallError is returned by proxies when a call to other contract couldn't be made or its result couldn't be read,
+// as opposed to errors returned by the called method itself which are *Error. Message is the message of Err,
+// so checking the type instead of parsing the message is the way to tell failed calls from failed methods.
+type CallError struct {
+	Object core.RecordRef
+	Method string
+	Err    error
+}
+
+// NewCallError wraps error of calling method of object
+func NewCallError(object core.RecordRef, method string, err error) error {
+	return &CallError{Object: object, Method: method, Err: err}
+}
+
+// Error returns message of underlying error
+func (e *CallError) Error() string {
+	return e.Err.Error()
+}
+
+// Cause returns underlying error, compatible with errors.Cause
+func (e *CallError) Cause() error {
+	return e.Err
+}
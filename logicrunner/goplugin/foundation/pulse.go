@@ -0,0 +1,50 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package foundation
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/rand"
+
+	"github.com/insolar/insolar/core"
+)
+
+// GetPulseNumber returns number of pulse the current request was registered in. Validators replay request
+// with the same number, so it can be used for time-window logic.
+func GetPulseNumber() core.PulseNumber {
+	return GetContext().Pulse.PulseNumber
+}
+
+// GetEntropy returns entropy of pulse the current request was registered in. Entropy is produced by pulsars
+// and isn't known before the pulse, validators replay request with the same entropy.
+func GetEntropy() core.Entropy {
+	return GetContext().Pulse.Entropy
+}
+
+// NewRand returns pseudo-random generator seeded by pulse entropy and reference of the current request.
+// Every request gets its own sequence which is reproduced by validators, so results drawn from it
+// can be verified by anyone who knows the entropy.
+func NewRand() *rand.Rand {
+	ctx := GetContext()
+	data := append([]byte(nil), ctx.Pulse.Entropy[:]...)
+	if ctx.Request != nil {
+		data = append(data, ctx.Request[:]...)
+	}
+	sum := sha256.Sum256(data)
+	return rand.New(rand.NewSource(int64(binary.BigEndian.Uint64(sum[:])))) // nolint: gosec
+}
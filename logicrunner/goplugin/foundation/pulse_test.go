@@ -0,0 +1,55 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package foundation
+
+import (
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/require"
+	"github.com/tylerb/gls"
+)
+
+func draw(ctx *core.LogicCallContext) int64 {
+	gls.Set("callCtx", ctx)
+	defer gls.Cleanup()
+	return NewRand().Int63()
+}
+
+func TestNewRand(t *testing.T) {
+	request := testutils.RandomRef()
+	ctx := &core.LogicCallContext{
+		Request: &request,
+		Pulse:   core.Pulse{PulseNumber: core.FirstPulseNumber, Entropy: core.Entropy{1, 2, 3}},
+	}
+
+	gls.Set("callCtx", ctx)
+	require.Equal(t, core.PulseNumber(core.FirstPulseNumber), GetPulseNumber())
+	require.Equal(t, ctx.Pulse.Entropy, GetEntropy())
+	gls.Cleanup()
+
+	first := draw(ctx)
+	require.Equal(t, first, draw(ctx), "validators must draw the same numbers")
+
+	otherRequest := testutils.RandomRef()
+	require.NotEqual(t, first, draw(&core.LogicCallContext{Request: &otherRequest, Pulse: ctx.Pulse}))
+
+	otherPulse := ctx.Pulse
+	otherPulse.Entropy[0] = 42
+	require.NotEqual(t, first, draw(&core.LogicCallContext{Request: &request, Pulse: otherPulse}))
+}
@@ -0,0 +1,171 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package foundation
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/platformpolicy"
+)
+
+// sealedDataKeySize is size of random AES-256 key payload of sealed field is encrypted with.
+const sealedDataKeySize = 32
+
+// SealedField is payload member stores in its object encrypted client-side. Payload is encrypted with random
+// data key, the key is sealed to public keys of members allowed to decrypt field. Contracts never see
+// plain payload, they check integrity of ciphertext with owner's signature and decide who gets sealed keys.
+type SealedField struct {
+	// Ciphertext is payload encrypted with data key by AES-256-GCM, nonce is prepended.
+	Ciphertext []byte
+	// Digest is hash of ciphertext.
+	Digest []byte
+	// Signature is owner's signature of digest.
+	Signature []byte
+	// Keys are data key sealed to public keys of members, keyed by member reference.
+	Keys map[string][]byte
+}
+
+// SealedDigest returns digest of sealed field ciphertext owner signs.
+func SealedDigest(ciphertext []byte) []byte {
+	return platformCryptographyScheme.IntegrityHasher().Hash(ciphertext)
+}
+
+// VerifySealedField checks that ciphertext of field is intact and signed by owner of public key
+// without decrypting it.
+func VerifySealedField(field *SealedField, publicKey crypto.PublicKey) error {
+	if len(field.Ciphertext) == 0 {
+		return fmt.Errorf("[ VerifySealedField ] Empty ciphertext")
+	}
+	if !bytes.Equal(field.Digest, SealedDigest(field.Ciphertext)) {
+		return fmt.Errorf("[ VerifySealedField ] Digest doesn't match ciphertext")
+	}
+	if !Verify(field.Digest, field.Signature, publicKey) {
+		return fmt.Errorf("[ VerifySealedField ] Incorrect signature")
+	}
+	return nil
+}
+
+// SealFor returns copy of field with the only sealed key of member, error if member isn't allowed to decrypt it.
+func (f *SealedField) SealFor(member core.RecordRef) (*SealedField, error) {
+	key, ok := f.Keys[member.String()]
+	if !ok {
+		return nil, fmt.Errorf("[ SealFor ] Access to field is not granted to %s", member)
+	}
+	return &SealedField{
+		Ciphertext: f.Ciphertext,
+		Digest:     f.Digest,
+		Signature:  f.Signature,
+		Keys:       map[string][]byte{member.String(): key},
+	}, nil
+}
+
+// SealField encrypts payload client-side, signs it with owner's key and seals data key to owner's public key.
+func SealField(payload []byte, owner core.RecordRef, ownerKey crypto.PrivateKey) (*SealedField, error) {
+	dataKey := make([]byte, sealedDataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, fmt.Errorf("[ SealField ] Can't generate data key: %s", err.Error())
+	}
+	aead, err := sealedCipher(dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("[ SealField ] %s", err.Error())
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("[ SealField ] Can't generate nonce: %s", err.Error())
+	}
+	ciphertext := aead.Seal(nonce, nonce, payload, nil)
+
+	digest := SealedDigest(ciphertext)
+	signature, err := Sign(digest, ownerKey)
+	if err != nil {
+		return nil, fmt.Errorf("[ SealField ] Can't sign digest: %s", err.Error())
+	}
+	sealedKey, err := platformpolicy.SealToKey(ExtractPublicKey(ownerKey), dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("[ SealField ] Can't seal data key: %s", err.Error())
+	}
+
+	return &SealedField{
+		Ciphertext: ciphertext,
+		Digest:     digest,
+		Signature:  signature,
+		Keys:       map[string][]byte{owner.String(): sealedKey},
+	}, nil
+}
+
+// SealKeyFor opens data key of field with key of member and seals it to public key of grantee client-side,
+// the result is passed to contract to grant access to field.
+func SealKeyFor(
+	field *SealedField, member core.RecordRef, key crypto.PrivateKey, grantee crypto.PublicKey,
+) ([]byte, error) {
+	dataKey, err := openDataKey(field, member, key)
+	if err != nil {
+		return nil, fmt.Errorf("[ SealKeyFor ] %s", err.Error())
+	}
+	sealedKey, err := platformpolicy.SealToKey(grantee, dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("[ SealKeyFor ] Can't seal data key: %s", err.Error())
+	}
+	return sealedKey, nil
+}
+
+// OpenSealedField decrypts payload of field client-side with key of member it was granted to.
+func OpenSealedField(field *SealedField, member core.RecordRef, key crypto.PrivateKey) ([]byte, error) {
+	dataKey, err := openDataKey(field, member, key)
+	if err != nil {
+		return nil, fmt.Errorf("[ OpenSealedField ] %s", err.Error())
+	}
+	aead, err := sealedCipher(dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("[ OpenSealedField ] %s", err.Error())
+	}
+	if len(field.Ciphertext) < aead.NonceSize() {
+		return nil, fmt.Errorf("[ OpenSealedField ] Ciphertext is too short")
+	}
+	nonce := field.Ciphertext[:aead.NonceSize()]
+	payload, err := aead.Open(nil, nonce, field.Ciphertext[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("[ OpenSealedField ] Can't decrypt payload: %s", err.Error())
+	}
+	return payload, nil
+}
+
+func openDataKey(field *SealedField, member core.RecordRef, key crypto.PrivateKey) ([]byte, error) {
+	sealedKey, ok := field.Keys[member.String()]
+	if !ok {
+		return nil, fmt.Errorf("No data key sealed to %s", member)
+	}
+	dataKey, err := platformpolicy.OpenWithKey(key, sealedKey)
+	if err != nil {
+		return nil, fmt.Errorf("Can't open data key: %s", err.Error())
+	}
+	return dataKey, nil
+}
+
+func sealedCipher(dataKey []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("Can't create cipher: %s", err.Error())
+	}
+	return cipher.NewGCM(block)
+}
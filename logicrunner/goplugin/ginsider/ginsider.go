@@ -74,6 +74,8 @@ func NewGoInsider(path, network, address string) *GoInsider {
 // RPC struct with methods representing RPC interface of this code runner
 type RPC struct {
 	GI *GoInsider
+	// Guard rejects calls while runner is draining to restart, it's optional
+	Guard *MemoryGuard
 }
 
 func recoverRPC(ctx context.Context, err *error) {
@@ -92,6 +94,11 @@ func recoverRPC(ctx context.Context, err *error) {
 // CallMethod is an RPC that runs a method on an object and
 // returns a new state of the object and result of the method
 func (t *RPC) CallMethod(args rpctypes.DownCallMethodReq, reply *rpctypes.DownCallMethodResp) (err error) {
+	if err := t.Guard.enter(); err != nil {
+		return err
+	}
+	defer t.Guard.leave()
+
 	start := time.Now()
 	metrics.InsgorundCallsTotal.Inc()
 	ctx := inslogger.ContextWithTrace(context.Background(), args.Context.TraceID)
@@ -152,6 +159,11 @@ func (t *RPC) CallMethod(args rpctypes.DownCallMethodReq, reply *rpctypes.DownCa
 // CallConstructor is an RPC that runs a method on an object and
 // returns a new state of the object and result of the method
 func (t *RPC) CallConstructor(args rpctypes.DownCallConstructorReq, reply *rpctypes.DownCallConstructorResp) (err error) {
+	if err := t.Guard.enter(); err != nil {
+		return err
+	}
+	defer t.Guard.leave()
+
 	metrics.InsgorundCallsTotal.Inc()
 	ctx := inslogger.ContextWithTrace(context.Background(), args.Context.TraceID)
 	inslogger.FromContext(ctx).Debugf("Calling constructor %q in code %q", args.Name, args.Code)
@@ -185,6 +197,20 @@ func (t *RPC) CallConstructor(args rpctypes.DownCallConstructorReq, reply *rpcty
 	return nil
 }
 
+// PreloadCode loads plugin before the first call of its code, so the first call doesn't wait for it
+func (t *RPC) PreloadCode(args rpctypes.DownPreloadCodeReq, reply *rpctypes.DownPreloadCodeResp) (err error) {
+	if err := t.Guard.enter(); err != nil {
+		return err
+	}
+	defer t.Guard.leave()
+
+	ctx := inslogger.ContextWithTrace(context.Background(), args.TraceID)
+	inslogger.FromContext(ctx).Debugf("Preloading code %q", args.Code)
+	defer recoverRPC(ctx, &err)
+
+	return t.GI.preloadPlugin(ctx, args.Code, args.Data)
+}
+
 // Upstream returns RPC client connected to upstream server (goplugin)
 func (gi *GoInsider) Upstream() (*rpc.Client, error) {
 	gi.upstreamMutex.Lock()
@@ -271,6 +297,34 @@ func (gi *GoInsider) Plugin(ctx context.Context, ref core.RecordRef) (*plugin.Pl
 	return p, nil
 }
 
+// preloadPlugin stores provided code and loads plugin from it unless plugin is loaded already
+func (gi *GoInsider) preloadPlugin(ctx context.Context, ref core.RecordRef, code []byte) error {
+	rec := gi.getPluginRec(ref)
+
+	rec.Lock()
+	defer rec.Unlock()
+
+	if rec.plugin != nil {
+		return nil
+	}
+
+	path := filepath.Join(gi.dir, ref.String())
+	_, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		err = ioutil.WriteFile(path, code, 0666)
+	}
+	if err != nil {
+		return errors.Wrap(err, "[ preloadPlugin ] couldn't store code")
+	}
+
+	p, err := plugin.Open(path)
+	if err != nil {
+		return errors.Wrap(err, "[ preloadPlugin ] couldn't open plugin")
+	}
+	rec.plugin = p
+	return nil
+}
+
 // getPluginRec return existed gi.plugins[ref] or create a new one
 // also set gi.plugins[ref].Lock()
 func (gi *GoInsider) getPluginRec(ref core.RecordRef) *pluginRec {
@@ -300,7 +354,7 @@ func MakeUpBaseReq() rpctypes.UpBaseReq {
 }
 
 // RouteCall ...
-func (gi *GoInsider) RouteCall(ref core.RecordRef, wait bool, method string, args []byte, proxyPrototype core.RecordRef) ([]byte, error) {
+func (gi *GoInsider) RouteCall(ctx context.Context, ref core.RecordRef, wait bool, method string, args []byte, proxyPrototype core.RecordRef) ([]byte, error) {
 	client, err := gi.Upstream()
 	if err != nil {
 		return nil, err
@@ -315,7 +369,13 @@ func (gi *GoInsider) RouteCall(ref core.RecordRef, wait bool, method string, arg
 	}
 
 	res := rpctypes.UpRouteResp{}
-	err = client.Call("RPC.RouteCall", req, &res)
+	var call *rpc.Call
+	select {
+	case call = <-client.Go("RPC.RouteCall", req, &res, nil).Done:
+	case <-ctx.Done():
+		return nil, errors.Wrap(ctx.Err(), "[ RouteCall ] call is aborted")
+	}
+	err = call.Error
 	if err != nil {
 		if err == rpc.ErrShutdown {
 			log.Error("Insgorund can't connect to Insolard")
@@ -468,6 +528,98 @@ func (gi *GoInsider) DeactivateObject(object core.RecordRef) error {
 	return nil
 }
 
+// AcquireLock acquires named lock for current object or extends it if object already holds the lock.
+func (gi *GoInsider) AcquireLock(name string, ttl time.Duration) (bool, error) {
+	res, err := gi.lock(name, ttl, false)
+	if err != nil {
+		return false, errors.Wrap(err, "[ AcquireLock ] on calling main API")
+	}
+	return res.Acquired, nil
+}
+
+// ReleaseLock releases named lock held by current object.
+func (gi *GoInsider) ReleaseLock(name string) error {
+	_, err := gi.lock(name, 0, true)
+	if err != nil {
+		return errors.Wrap(err, "[ ReleaseLock ] on calling main API")
+	}
+	return nil
+}
+
+func (gi *GoInsider) lock(name string, ttl time.Duration, release bool) (*rpctypes.UpLockResp, error) {
+	client, err := gi.Upstream()
+	if err != nil {
+		return nil, err
+	}
+
+	req := rpctypes.UpLockReq{
+		UpBaseReq: MakeUpBaseReq(),
+		Name:      name,
+		TTL:       ttl,
+		Release:   release,
+	}
+
+	res := rpctypes.UpLockResp{}
+	err = client.Call("RPC.Lock", req, &res)
+	if err != nil {
+		if err == rpc.ErrShutdown {
+			log.Error("Insgorund can't connect to Insolard")
+			os.Exit(0)
+		}
+		return nil, err
+	}
+	return &res, nil
+}
+
+// RequireValidation marks current call to be always checked by validators.
+func (gi *GoInsider) RequireValidation() error {
+	client, err := gi.Upstream()
+	if err != nil {
+		return err
+	}
+
+	req := rpctypes.UpRequireValidationReq{
+		UpBaseReq: MakeUpBaseReq(),
+	}
+
+	res := rpctypes.UpRequireValidationResp{}
+	err = client.Call("RPC.RequireValidation", req, &res)
+	if err != nil {
+		if err == rpc.ErrShutdown {
+			log.Error("Insgorund can't connect to Insolard")
+			os.Exit(0)
+		}
+		return errors.Wrap(err, "[ RequireValidation ] on calling main API")
+	}
+	return nil
+}
+
+// ScheduleCall schedules call of current object's method to be made after pulses pulses.
+func (gi *GoInsider) ScheduleCall(pulses uint, method string, args []byte) error {
+	client, err := gi.Upstream()
+	if err != nil {
+		return err
+	}
+
+	req := rpctypes.UpScheduleCallReq{
+		UpBaseReq: MakeUpBaseReq(),
+		Pulses:    pulses,
+		Method:    method,
+		Arguments: args,
+	}
+
+	res := rpctypes.UpScheduleCallResp{}
+	err = client.Call("RPC.ScheduleCall", req, &res)
+	if err != nil {
+		if err == rpc.ErrShutdown {
+			log.Error("Insgorund can't connect to Insolard")
+			os.Exit(0)
+		}
+		return errors.Wrap(err, "[ ScheduleCall ] on calling main API")
+	}
+	return nil
+}
+
 // Serialize - CBOR serializer wrapper: `what` -> `to`
 func (gi *GoInsider) Serialize(what interface{}, to *[]byte) error {
 	ch := new(codec.CborHandle)
@@ -0,0 +1,127 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package ginsider
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/log"
+	"github.com/insolar/insolar/logicrunner/goplugin/rpctypes"
+	"github.com/insolar/insolar/metrics"
+)
+
+// MemoryGuard watches memory of the runner. Contracts may leak memory and runner lives long, so when
+// resident memory exceeds limit runner stops accepting calls, waits for calls in progress and should be restarted.
+type MemoryGuard struct {
+	limit uint64
+	rss   func() (uint64, error)
+
+	lock     sync.Mutex
+	draining bool
+	inflight sync.WaitGroup
+}
+
+// NewMemoryGuard creates guard with limit of resident memory in bytes.
+func NewMemoryGuard(limit uint64) *MemoryGuard {
+	return &MemoryGuard{limit: limit, rss: residentMemory}
+}
+
+// enter registers call in progress, it fails if runner is draining. Nil guard accepts all calls.
+func (g *MemoryGuard) enter() error {
+	if g == nil {
+		return nil
+	}
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if g.draining {
+		return errors.New(rpctypes.ErrRecycling)
+	}
+	g.inflight.Add(1)
+	return nil
+}
+
+// leave marks call as finished.
+func (g *MemoryGuard) leave() {
+	if g == nil {
+		return
+	}
+	g.inflight.Done()
+}
+
+// Watch checks memory with interval and returns when limit is exceeded and calls in progress are finished,
+// so runner can be restarted. It returns false if ctx is done before.
+func (g *MemoryGuard) Watch(ctx context.Context, interval time.Duration) bool {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return false
+		}
+
+		rss, err := g.rss()
+		if err != nil {
+			log.Warn("can't get resident memory of runner: ", err)
+			continue
+		}
+		metrics.InsgorundMemoryResident.Set(float64(rss))
+		if rss <= g.limit {
+			continue
+		}
+
+		log.Warnf("runner uses %d bytes of memory over limit of %d bytes, draining calls to restart", rss, g.limit)
+		g.lock.Lock()
+		g.draining = true
+		g.lock.Unlock()
+		g.inflight.Wait()
+		return true
+	}
+}
+
+// residentMemory returns resident memory of the process. It's read from procfs if available,
+// memory obtained by go runtime is returned otherwise.
+func residentMemory() (uint64, error) {
+	statm, err := ioutil.ReadFile("/proc/self/statm")
+	if os.IsNotExist(err) {
+		var ms runtime.MemStats
+		runtime.ReadMemStats(&ms)
+		return ms.Sys, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	fields := strings.Fields(string(statm))
+	if len(fields) < 2 {
+		return 0, errors.Errorf("unexpected format of statm: %q", statm)
+	}
+	pages, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, "unexpected format of statm")
+	}
+	return pages * uint64(os.Getpagesize()), nil
+}
@@ -0,0 +1,73 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package ginsider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/logicrunner/goplugin/rpctypes"
+)
+
+func TestMemoryGuard(t *testing.T) {
+	g := NewMemoryGuard(100)
+	rss := make(chan uint64, 1)
+	rss <- 50
+	g.rss = func() (uint64, error) {
+		select {
+		case r := <-rss:
+			return r, nil
+		default:
+			return 50, nil
+		}
+	}
+
+	require.NoError(t, g.enter())
+
+	recycle := make(chan bool)
+	go func() {
+		recycle <- g.Watch(context.Background(), time.Millisecond)
+	}()
+	rss <- 200
+
+	// calls are rejected while draining
+	deadline := time.Now().Add(time.Second)
+	for g.enter() == nil {
+		g.leave()
+		require.True(t, time.Now().Before(deadline), "guard doesn't start draining")
+		time.Sleep(time.Millisecond)
+	}
+	require.EqualError(t, g.enter(), rpctypes.ErrRecycling)
+
+	select {
+	case <-recycle:
+		t.Fatal("guard shouldn't wait for call in progress")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	g.leave()
+	require.True(t, <-recycle)
+}
+
+func TestMemoryGuard_Nil(t *testing.T) {
+	var g *MemoryGuard
+	require.NoError(t, g.enter())
+	g.leave()
+}
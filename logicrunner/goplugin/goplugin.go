@@ -77,6 +77,9 @@ func (gp *GoPlugin) Stop() error {
 
 const timeout = time.Minute * 10
 
+// recyclingRetryDelay is a pause before repeating call rejected by restarting runner
+const recyclingRetryDelay = 100 * time.Millisecond
+
 // Downstream returns a connection to `ginsider`
 func (gp *GoPlugin) Downstream(ctx context.Context) (*rpc.Client, error) {
 	gp.clientMutex.Lock()
@@ -102,6 +105,10 @@ func (gp *GoPlugin) CloseDownstream() {
 	gp.clientMutex.Lock()
 	defer gp.clientMutex.Unlock()
 
+	// concurrent calls may find connection broken at the same time
+	if gp.client == nil {
+		return
+	}
 	gp.client.Close()
 	gp.client = nil
 }
@@ -118,6 +125,15 @@ func (gp *GoPlugin) callClientWithReconnect(ctx context.Context, method string,
 			call := <-client.Go(method, req, res, nil).Done
 			err = call.Error
 
+			if serr, ok := err.(rpc.ServerError); ok && string(serr) == rpctypes.ErrRecycling {
+				// call isn't executed, runner restarts soon
+				inslogger.FromContext(ctx).Debug("insgorund is recycling, need to reconnect")
+				stats.Record(ctx, statGopluginRunnerRecycling.M(1))
+				gp.CloseDownstream()
+				time.Sleep(recyclingRetryDelay)
+				continue
+			}
+
 			if err != rpc.ErrShutdown {
 				break
 			} else {
@@ -185,6 +201,20 @@ func (gp *GoPlugin) CallMethod(
 	}
 }
 
+// PreloadCode passes code to the runner, so it's loaded before the first call
+func (gp *GoPlugin) PreloadCode(ctx context.Context, code core.RecordRef, data []byte) error {
+	client, err := gp.Downstream(ctx)
+	if err != nil {
+		return err
+	}
+	req := rpctypes.DownPreloadCodeReq{
+		TraceID: inslogger.TraceID(ctx),
+		Code:    code,
+		Data:    data,
+	}
+	return client.Call("RPC.PreloadCode", req, &rpctypes.DownPreloadCodeResp{})
+}
+
 type CallConstructorResult struct {
 	Response rpctypes.DownCallConstructorResp
 	Error    error
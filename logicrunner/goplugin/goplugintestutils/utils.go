@@ -61,6 +61,7 @@ type TestCodeDescriptor struct {
 	ARef         core.RecordRef
 	ACode        []byte
 	AMachineType core.MachineType
+	ASchema      []byte
 }
 
 // Ref implementation for tests
@@ -78,6 +79,11 @@ func (t *TestCodeDescriptor) Code() ([]byte, error) {
 	return t.ACode, nil
 }
 
+// Schema implementation for tests
+func (t *TestCodeDescriptor) Schema() []byte {
+	return t.ASchema
+}
+
 // TestObjectDescriptor implementation for tests
 type TestObjectDescriptor struct {
 	AM                *TestArtifactManager
@@ -152,7 +158,7 @@ type TestArtifactManager struct {
 	Prototypes map[core.RecordRef]*TestObjectDescriptor
 }
 
-func (t *TestArtifactManager) GetPendingRequest(ctx context.Context, objectID core.RecordID) (core.Parcel, error) {
+func (t *TestArtifactManager) GetPendingRequest(ctx context.Context, objectID core.RecordID) (core.Parcel, uint64, error) {
 	panic("implement me")
 }
 
@@ -183,9 +189,16 @@ func NewTestArtifactManager() *TestArtifactManager {
 func (t *TestArtifactManager) GenesisRef() *core.RecordRef { return &core.RecordRef{} }
 
 // RegisterRequest implementation for tests
-func (t *TestArtifactManager) RegisterRequest(ctx context.Context, obj core.RecordRef, parcel core.Parcel) (*core.RecordID, error) {
+func (t *TestArtifactManager) RegisterRequest(ctx context.Context, obj core.RecordRef, parcel core.Parcel) (*core.RecordID, uint64, error) {
 	nonce := testutils.RandomID()
-	return &nonce, nil
+	return &nonce, 0, nil
+}
+
+// RegisterTimer implementation for tests
+func (t *TestArtifactManager) RegisterTimer(
+	ctx context.Context, obj core.RecordRef, pulse core.PulseNumber, msg core.Message,
+) (*core.RecordID, error) {
+	panic("implement me")
 }
 
 // RegisterResult saves VM method call result.
@@ -195,6 +208,11 @@ func (t *TestArtifactManager) RegisterResult(
 	panic("implement me")
 }
 
+// GetResult implementation for tests
+func (t *TestArtifactManager) GetResult(ctx context.Context, request core.RecordRef) ([]byte, error) {
+	panic("implement me")
+}
+
 // GetObject implementation for tests
 func (t *TestArtifactManager) GetObject(ctx context.Context, object core.RecordRef, state *core.RecordID, approved bool) (core.ObjectDescriptor, error) {
 	res, ok := t.Objects[object]
@@ -204,6 +222,25 @@ func (t *TestArtifactManager) GetObject(ctx context.Context, object core.RecordR
 	return res, nil
 }
 
+// GetObjects implementation for tests
+func (t *TestArtifactManager) GetObjects(
+	ctx context.Context, heads []core.RecordRef, codes []core.RecordRef,
+) (map[core.RecordRef]core.ObjectDescriptor, map[core.RecordRef]core.CodeDescriptor, error) {
+	objects := map[core.RecordRef]core.ObjectDescriptor{}
+	for _, head := range heads {
+		if obj, ok := t.Objects[head]; ok {
+			objects[head] = obj
+		}
+	}
+	codeDescs := map[core.RecordRef]core.CodeDescriptor{}
+	for _, ref := range codes {
+		if code, ok := t.Codes[ref]; ok {
+			codeDescs[ref] = code
+		}
+	}
+	return objects, codeDescs, nil
+}
+
 // GetDelegate implementation for tests
 func (t *TestArtifactManager) GetDelegate(ctx context.Context, head, asClass core.RecordRef) (*core.RecordRef, error) {
 	obj, ok := t.Objects[head]
@@ -225,13 +262,14 @@ func (t *TestArtifactManager) DeclareType(ctx context.Context, domain core.Recor
 }
 
 // DeployCode implementation for tests
-func (t *TestArtifactManager) DeployCode(ctx context.Context, domain core.RecordRef, request core.RecordRef, code []byte, mt core.MachineType) (*core.RecordID, error) {
+func (t *TestArtifactManager) DeployCode(ctx context.Context, domain core.RecordRef, request core.RecordRef, code []byte, mt core.MachineType, schema []byte) (*core.RecordID, error) {
 	ref := testutils.RandomRef()
 
 	t.Codes[ref] = &TestCodeDescriptor{
 		ARef:         ref,
 		ACode:        code,
 		AMachineType: core.MachineTypeGoPlugin,
+		ASchema:      schema,
 	}
 	id := ref.Record()
 	return id, nil
@@ -391,14 +429,14 @@ func AMPublishCode(
 ) {
 	ctx := context.TODO()
 	codeID, err := am.DeployCode(
-		ctx, domain, request, code, mtype,
+		ctx, domain, request, code, mtype, nil,
 	)
 	assert.NoError(t, err, "create code on ledger")
 	codeRef = &core.RecordRef{}
 	codeRef.SetRecord(*codeID)
 
 	nonce := testutils.RandomRef()
-	protoID, err := am.RegisterRequest(ctx, *am.GenesisRef(), &message.Parcel{Msg: &message.CallConstructor{PrototypeRef: nonce}})
+	protoID, _, err := am.RegisterRequest(ctx, *am.GenesisRef(), &message.Parcel{Msg: &message.CallConstructor{PrototypeRef: nonce}})
 	assert.NoError(t, err)
 	protoRef = &core.RecordRef{}
 	protoRef.SetRecord(*protoID)
@@ -450,7 +488,7 @@ func (cb *ContractsBuilder) Build(contracts map[string]string) error {
 
 	for name := range contracts {
 		nonce := testutils.RandomRef()
-		protoID, err := cb.ArtifactManager.RegisterRequest(
+		protoID, _, err := cb.ArtifactManager.RegisterRequest(
 			ctx, *cb.ArtifactManager.GenesisRef(), &message.Parcel{Msg: &message.CallConstructor{PrototypeRef: nonce}},
 		)
 		if err != nil {
@@ -493,7 +531,7 @@ func (cb *ContractsBuilder) Build(contracts map[string]string) error {
 			return errors.Wrap(err, "[ Build ] Can't ReadFile")
 		}
 		nonce := testutils.RandomRef()
-		codeReq, err := cb.ArtifactManager.RegisterRequest(
+		codeReq, _, err := cb.ArtifactManager.RegisterRequest(
 			ctx, *cb.ArtifactManager.GenesisRef(), &message.Parcel{Msg: &message.CallConstructor{PrototypeRef: nonce}},
 		)
 		if err != nil {
@@ -504,7 +542,7 @@ func (cb *ContractsBuilder) Build(contracts map[string]string) error {
 		codeID, err := cb.ArtifactManager.DeployCode(
 			ctx,
 			core.RecordRef{}, *core.NewRecordRef(core.RecordID{}, *codeReq),
-			pluginBinary, core.MachineTypeGoPlugin,
+			pluginBinary, core.MachineTypeGoPlugin, nil,
 		)
 		codeRef := &core.RecordRef{}
 		codeRef.SetRecord(*codeID)
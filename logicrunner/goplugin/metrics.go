@@ -34,15 +34,24 @@ var (
 		"time spent on execution contract, measured in goplugin",
 		stats.UnitMilliseconds,
 	)
+	statGopluginRunnerRecycling = stats.Int64(
+		"goplugin/runner/recycling/count",
+		"number of calls repeated because runner was restarting to release memory",
+		stats.UnitDimensionless,
+	)
 )
 
 func init() {
-	err := view.Register(
+	err := insmetrics.Register(
 		&view.View{
 			Measure:     statGopluginContractMethodTime,
 			Aggregation: view.Distribution(0.001, 0.01, 0.1, 1, 10, 100, 1000, 5000, 10000, 20000),
 			TagKeys:     []tag.Key{tagMethodName},
 		},
+		&view.View{
+			Measure:     statGopluginRunnerRecycling,
+			Aggregation: view.Count(),
+		},
 	)
 	if err != nil {
 		panic(err)
@@ -57,6 +57,7 @@ type ParsedFile struct {
 	types        map[string]*ast.TypeSpec
 	methods      map[string][]*ast.FuncDecl
 	constructors map[string][]*ast.FuncDecl
+	acl          map[string][]string
 	contract     string
 }
 
@@ -88,6 +89,11 @@ func ParseFile(fileName string) (*ParsedFile, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "")
 	}
+
+	err = res.parseACL()
+	if err != nil {
+		return nil, errors.Wrap(err, "")
+	}
 	if res.contract == "" {
 		return nil, errors.New("Only one smart contract must exist")
 	}
@@ -196,6 +202,63 @@ func (pf *ParsedFile) parseMethod(fd *ast.FuncDecl) error {
 	return nil
 }
 
+// parseACL parses access control list of contract methods declared as
+// `var INSACL_<Method> = []string{"api", "parent", "<contract name>"}`
+func (pf *ParsedFile) parseACL() error {
+	pf.acl = make(map[string][]string)
+	for _, decl := range pf.node.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.VAR {
+			continue
+		}
+
+		for _, spec := range gd.Specs {
+			vs := spec.(*ast.ValueSpec)
+			for i, name := range vs.Names {
+				if !strings.HasPrefix(name.Name, "INSACL_") {
+					continue
+				}
+				method := strings.TrimPrefix(name.Name, "INSACL_")
+				if i >= len(vs.Values) {
+					return errors.Errorf("ACL of method %s has no value", method)
+				}
+				callers, err := parseStringSlice(vs.Values[i])
+				if err != nil {
+					return errors.Wrapf(err, "ACL of method %s", method)
+				}
+				pf.acl[method] = callers
+			}
+		}
+	}
+	return nil
+}
+
+func parseStringSlice(expr ast.Expr) ([]string, error) {
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return nil, errors.New("value must be []string literal")
+	}
+	res := make([]string, 0, len(lit.Elts))
+	for _, elt := range lit.Elts {
+		str, ok := elt.(*ast.BasicLit)
+		if !ok || str.Kind != token.STRING {
+			return nil, errors.New("value must be []string literal")
+		}
+		val, err := strconv.Unquote(str.Value)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, val)
+	}
+	return res, nil
+}
+
+// ACL returns callers allowed to invoke methods of the contract by method names.
+// Callers are ACL roles or names of contracts.
+func (pf *ParsedFile) ACL() map[string][]string {
+	return pf.acl
+}
+
 // ProxyPackageName guesses user friendly contract "name" from file name
 // and/or package in the file
 func (pf *ParsedFile) ProxyPackageName() (string, error) {
@@ -323,27 +386,122 @@ func (pf *ParsedFile) functionInfoForProxy(list []*ast.FuncDecl) []map[string]st
 	var res []map[string]string
 
 	for _, fun := range list {
+		callErr := fmt.Sprintf("foundation.NewCallError(r.Reference, %q, err)", fun.Name.Name)
 		info := map[string]string{
-			"Name":            fun.Name.Name,
-			"Arguments":       genFieldList(pf, fun.Type.Params, true),
-			"InitArgs":        generateInitArguments(fun.Type.Params),
-			"ResultZeroList":  generateZeroListOfTypes(pf, "ret", fun.Type.Results),
-			"Results":         numberedVars(fun.Type.Results, "ret"),
-			"ErrorVar":        fmt.Sprintf("ret%d", fun.Type.Results.NumFields()-1),
-			"ResultsWithErr":  commaAppend(numberedVarsI(fun.Type.Results.NumFields()-1, "ret"), "err"),
-			"ResultsNilError": commaAppend(numberedVarsI(fun.Type.Results.NumFields()-1, "ret"), "nil"),
-			"ResultsTypes":    genFieldList(pf, fun.Type.Results, false),
+			"Name":               fun.Name.Name,
+			"Arguments":          genFieldList(pf, fun.Type.Params, true),
+			"ArgumentsNames":     genArgumentsNames(fun.Type.Params),
+			"InitArgs":           generateInitArguments(fun.Type.Params),
+			"ResultZeroList":     generateZeroListOfTypes(pf, "ret", fun.Type.Results),
+			"Results":            numberedVars(fun.Type.Results, "ret"),
+			"ErrorVar":           fmt.Sprintf("ret%d", fun.Type.Results.NumFields()-1),
+			"ResultsWithCallErr": commaAppend(numberedVarsI(fun.Type.Results.NumFields()-1, "ret"), callErr),
+			"ResultsNilError":    commaAppend(numberedVarsI(fun.Type.Results.NumFields()-1, "ret"), "nil"),
+			"ResultsTypes":       genFieldList(pf, fun.Type.Results, false),
 		}
 		res = append(res, info)
 	}
 	return res
 }
 
+// Schema returns schema of arguments and results of contract methods and constructors, it's uploaded
+// alongside contract code to decode calls of the contract.
+func (pf *ParsedFile) Schema() *core.ContractSchema {
+	schema := &core.ContractSchema{Methods: map[string]core.MethodSchema{}}
+	for _, list := range [][]*ast.FuncDecl{pf.methods[pf.contract], pf.constructors[pf.contract]} {
+		for _, fun := range list {
+			schema.Methods[fun.Name.Name] = core.MethodSchema{
+				Arguments: pf.fieldsSchema(fun.Type.Params),
+				Results:   pf.fieldsSchema(fun.Type.Results),
+			}
+		}
+	}
+	return schema
+}
+
+func (pf *ParsedFile) fieldsSchema(list *ast.FieldList) []core.FieldSchema {
+	fields := []core.FieldSchema{}
+	if list == nil {
+		return fields
+	}
+	for _, f := range list.List {
+		typ := pf.codeOfNode(f.Type)
+		if len(f.Names) == 0 {
+			fields = append(fields, core.FieldSchema{Type: typ})
+			continue
+		}
+		for _, name := range f.Names {
+			fields = append(fields, core.FieldSchema{Name: name.Name, Type: typ})
+		}
+	}
+	return fields
+}
+
 // ChangePackageToMain changes package of the parsed code to "main"
 func (pf *ParsedFile) ChangePackageToMain() {
 	pf.node.Name.Name = "main"
 }
 
+// untrustedImports is a list of packages contract code from untrusted source may import: foundation, core types,
+// proxies of other contracts and vetted stdlib packages without access to the file system, network, processes
+// or memory outside Go type system.
+var untrustedImports = map[string]bool{
+	foundationPath: true,
+	corePath:       true,
+
+	"bytes":           true,
+	"encoding/base64": true,
+	"encoding/hex":    true,
+	"encoding/json":   true,
+	"errors":          true,
+	"fmt":             true,
+	"math":            true,
+	"math/big":        true,
+	"sort":            true,
+	"strconv":         true,
+	"strings":         true,
+	"time":            true,
+	"unicode":         true,
+	"unicode/utf8":    true,
+}
+
+// proxyImportPrefix is a prefix of contract proxies untrusted code may import.
+var proxyImportPrefix = "github.com/insolar/insolar/application/proxy/"
+
+// CheckUntrusted returns error if contract code imports packages outside of allowed list or uses
+// compiler directives, such code can't be built from untrusted source as it escapes from checks of Go compiler.
+func (pf *ParsedFile) CheckUntrusted() error {
+	for _, imp := range pf.node.Imports {
+		importPath, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			return errors.Wrapf(err, "invalid import %s", imp.Path.Value)
+		}
+		if !untrustedImports[importPath] && !isProxyImport(importPath) {
+			return errors.Errorf("import %q is not allowed in contract code", importPath)
+		}
+	}
+	for _, group := range pf.node.Comments {
+		for _, c := range group.List {
+			if strings.HasPrefix(c.Text, "//go:") || strings.HasPrefix(c.Text, "//line ") {
+				return errors.Errorf("compiler directive %q is not allowed in contract code", c.Text)
+			}
+			if strings.Contains(c.Text, "#cgo") {
+				return errors.New("cgo directives are not allowed in contract code")
+			}
+		}
+	}
+	return nil
+}
+
+// isProxyImport checks if import path is a proxy package of some contract, proxies of nested packages are not allowed.
+func isProxyImport(importPath string) bool {
+	if !strings.HasPrefix(importPath, proxyImportPrefix) {
+		return false
+	}
+	name := strings.TrimPrefix(importPath, proxyImportPrefix)
+	return name != "" && !strings.Contains(name, "/") && !strings.Contains(name, ".")
+}
+
 // Write prints `out` contract's code, it could be changed with a few methods
 func (pf *ParsedFile) Write(out io.Writer) error {
 	return printer.Fprint(out, pf.fileSet, pf.node)
@@ -365,7 +523,9 @@ func (pf *ParsedFile) generateImports(wrapper bool) map[string]bool {
 	imports := make(map[string]bool)
 	imports[fmt.Sprintf(`"%s"`, proxyctxPath)] = true
 	if !wrapper {
+		imports[`"context"`] = true
 		imports[fmt.Sprintf(`"%s"`, corePath)] = true
+		imports[fmt.Sprintf(`"%s"`, foundationPath)] = true
 	}
 	for _, method := range pf.methods[pf.contract] {
 		extendImportsMap(pf, method.Type.Params, imports)
@@ -550,6 +710,20 @@ func genFieldList(parsed *ParsedFile, params *ast.FieldList, withNames bool) str
 	return res
 }
 
+func genArgumentsNames(params *ast.FieldList) string {
+	res := ""
+	if params == nil {
+		return res
+	}
+	for i, e := range params.List {
+		if i > 0 {
+			res += ", "
+		}
+		res += e.Names[0].Name
+	}
+	return res
+}
+
 func generateInitArguments(list *ast.FieldList) string {
 	initArgs := ""
 	initArgs += fmt.Sprintf("var args [%d]interface{}\n", list.NumFields())
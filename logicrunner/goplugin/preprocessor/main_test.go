@@ -18,13 +18,16 @@ package preprocessor
 
 import (
 	"bytes"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"os/exec"
 	"path"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/testutils"
 	"github.com/stretchr/testify/require"
 
@@ -140,6 +143,22 @@ func TestBasicGeneration(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotEmpty(t, code)
 	})
+
+	t.Run("schema", func(t *testing.T) {
+		t.Parallel()
+
+		schema := parsed.Schema()
+		assert.Len(t, schema.Methods, 7)
+		assert.Equal(t, core.MethodSchema{
+			Arguments: []core.FieldSchema{
+				{Name: "Name", Type: "FullName"},
+				{Name: "s", Type: "string"},
+				{Name: "i", Type: "int"},
+			},
+			Results: []core.FieldSchema{{Type: "*PersonalGreeting"}, {Type: "error"}},
+		}, schema.Methods["MultiArgs"])
+		assert.Empty(t, schema.Methods["Hello"].Arguments)
+	})
 }
 
 func TestConstructorsParsing(t *testing.T) {
@@ -419,6 +438,46 @@ type B struct{
 	assert.EqualError(t, err, ": more than one contract in a file")
 }
 
+func TestACLParsing(t *testing.T) {
+	t.Parallel()
+	tmpDir, err := ioutil.TempDir("", "test-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmpDir) //nolint: errcheck
+
+	testContract := "/test.go"
+
+	err = goplugintestutils.WriteFile(tmpDir, testContract, `
+package main
+
+type A struct{
+	foundation.BaseContract
+}
+
+var INSACL_Transfer = []string{"parent", "member"}
+
+var INSATTR_Get_API = true
+`)
+	assert.NoError(t, err)
+
+	parsed, err := ParseFile(tmpDir + testContract)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{"Transfer": {"parent", "member"}}, parsed.ACL())
+
+	err = goplugintestutils.WriteFile(tmpDir, testContract, `
+package main
+
+type A struct{
+	foundation.BaseContract
+}
+
+var INSACL_Transfer = "parent"
+`)
+	assert.NoError(t, err)
+
+	_, err = ParseFile(tmpDir + testContract)
+	assert.EqualError(t, err, ": ACL of method Transfer: value must be []string literal")
+}
+
 func TestImportsFromContract(t *testing.T) {
 	t.Parallel()
 	tmpDir, err := ioutil.TempDir("", "test-")
@@ -654,3 +713,67 @@ func TestProxyGeneration(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckUntrusted(t *testing.T) {
+	t.Parallel()
+	tmpDir, err := ioutil.TempDir("", "test-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmpDir) //nolint: errcheck
+
+	contract := `
+package main
+
+%s
+
+type A struct{
+	foundation.BaseContract
+}
+
+%s
+func (a *A) Get() (int, error) {
+	return 1, nil
+}
+`
+	type testCase struct {
+		imports string
+		comment string
+		ok      bool
+		reason  string
+	}
+	cases := map[string]testCase{
+		"plain":    {imports: `import "github.com/insolar/insolar/logicrunner/goplugin/foundation"`, ok: true},
+		"cgo":      {imports: "// #cgo LDFLAGS: -lm\nimport \"C\""},
+		"linkname": {comment: "//go:linkname get runtime.nanotime"},
+		"noescape": {comment: "//go:noescape"},
+		"allowed": {imports: `import (
+	"fmt"
+	"strings"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/application/proxy/wallet"
+)`, ok: true},
+		"proxy subpackage": {imports: `import "github.com/insolar/insolar/application/proxy/wallet/internal"`},
+		"other insolar":    {imports: `import "github.com/insolar/insolar/ledger/storage"`},
+	}
+	for _, forbidden := range []string{"unsafe", "syscall", "os", "os/exec", "plugin", "net", "reflect"} {
+		cases[strings.Replace(forbidden, "/", "_", -1)] = testCase{
+			imports: fmt.Sprintf("import (\n\t\"fmt\"\n\t_ %q\n)", forbidden),
+			reason:  fmt.Sprintf("import %q is not allowed", forbidden),
+		}
+	}
+	for name, c := range cases {
+		testContract := "/" + name + ".go"
+		err = goplugintestutils.WriteFile(tmpDir, testContract, fmt.Sprintf(contract, c.imports, c.comment))
+		require.NoError(t, err)
+
+		parsed, err := ParseFile(tmpDir + testContract)
+		require.NoError(t, err, name)
+		err = parsed.CheckUntrusted()
+		if c.ok {
+			assert.NoError(t, err, name)
+		} else {
+			require.Error(t, err, name)
+			assert.Contains(t, err.Error(), c.reason, name)
+		}
+	}
+}
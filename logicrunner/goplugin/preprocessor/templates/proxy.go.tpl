@@ -110,14 +110,14 @@ func (r *{{ $.ContractType }}) GetPrototype() (core.RecordRef, error) {
 		var ret1 *foundation.Error
 		ret[1] = &ret1
 
-		res, err := proxyctx.Current.RouteCall(r.Reference, true, "GetPrototype", make([]byte, 0), *PrototypeReference)
+		res, err := proxyctx.Current.RouteCall(context.Background(), r.Reference, true, "GetPrototype", make([]byte, 0), *PrototypeReference)
 		if err != nil {
-			return ret0, err
+			return ret0, foundation.NewCallError(r.Reference, "GetPrototype", err)
 		}
 
 		err = proxyctx.Current.Deserialize(res, &ret)
 		if err != nil {
-			return ret0, err
+			return ret0, foundation.NewCallError(r.Reference, "GetPrototype", err)
 		}
 
 		if ret1 != nil {
@@ -140,14 +140,14 @@ func (r *{{ $.ContractType }}) GetCode() (core.RecordRef, error) {
 		var ret1 *foundation.Error
 		ret[1] = &ret1
 
-		res, err := proxyctx.Current.RouteCall(r.Reference, true, "GetCode", make([]byte, 0), *PrototypeReference)
+		res, err := proxyctx.Current.RouteCall(context.Background(), r.Reference, true, "GetCode", make([]byte, 0), *PrototypeReference)
 		if err != nil {
-			return ret0, err
+			return ret0, foundation.NewCallError(r.Reference, "GetCode", err)
 		}
 
 		err = proxyctx.Current.Deserialize(res, &ret)
 		if err != nil {
-			return ret0, err
+			return ret0, foundation.NewCallError(r.Reference, "GetCode", err)
 		}
 
 		if ret1 != nil {
@@ -163,6 +163,11 @@ func (r *{{ $.ContractType }}) GetCode() (core.RecordRef, error) {
 {{ range $method := .MethodsProxies }}
 // {{ $method.Name }} is proxy generated method
 func (r *{{ $.ContractType }}) {{ $method.Name }}( {{ $method.Arguments }} ) ( {{ $method.ResultsTypes }} ) {
+	return r.{{ $method.Name }}WithContext(context.Background(), {{ $method.ArgumentsNames }})
+}
+
+// {{ $method.Name }}WithContext is proxy generated method, call is aborted when ctx is done
+func (r *{{ $.ContractType }}) {{ $method.Name }}WithContext(ctx context.Context, {{ $method.Arguments }} ) ( {{ $method.ResultsTypes }} ) {
 	{{ $method.InitArgs }}
 	var argsSerialized []byte
 
@@ -170,17 +175,17 @@ func (r *{{ $.ContractType }}) {{ $method.Name }}( {{ $method.Arguments }} ) ( {
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return {{ $method.ResultsWithErr }}
+		return {{ $method.ResultsWithCallErr }}
 	}
 
-	res, err := proxyctx.Current.RouteCall(r.Reference, true, "{{ $method.Name }}", argsSerialized, *PrototypeReference)
+	res, err := proxyctx.Current.RouteCall(ctx, r.Reference, true, "{{ $method.Name }}", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return {{ $method.ResultsWithErr }}
+		return {{ $method.ResultsWithCallErr }}
 	}
 
 	err = proxyctx.Current.Deserialize(res, &ret)
 	if err != nil {
-		return {{ $method.ResultsWithErr }}
+		return {{ $method.ResultsWithCallErr }}
 	}
 
 	if {{ $method.ErrorVar }} != nil {
@@ -196,12 +201,12 @@ func (r *{{ $.ContractType }}) {{ $method.Name }}NoWait( {{ $method.Arguments }}
 
 	err := proxyctx.Current.Serialize(args, &argsSerialized)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "{{ $method.Name }}", err)
 	}
 
-	_, err = proxyctx.Current.RouteCall(r.Reference, false, "{{ $method.Name }}", argsSerialized, *PrototypeReference)
+	_, err = proxyctx.Current.RouteCall(context.Background(), r.Reference, false, "{{ $method.Name }}", argsSerialized, *PrototypeReference)
 	if err != nil {
-		return err
+		return foundation.NewCallError(r.Reference, "{{ $method.Name }}", err)
 	}
 
 	return nil
@@ -17,17 +17,24 @@
 package proxyctx
 
 import (
+	"context"
+	"time"
+
 	"github.com/insolar/insolar/core"
 )
 
 // ProxyHelper interface with methods that are needed by contract proxies
 type ProxyHelper interface {
-	RouteCall(ref core.RecordRef, wait bool, method string, args []byte, proxyPrototype core.RecordRef) ([]byte, error)
+	RouteCall(ctx context.Context, ref core.RecordRef, wait bool, method string, args []byte, proxyPrototype core.RecordRef) ([]byte, error)
 	SaveAsChild(parentRef, classRef core.RecordRef, constructorName string, argsSerialized []byte) (core.RecordRef, error)
 	GetObjChildrenIterator(head core.RecordRef, prototype core.RecordRef, iteratorID string) (*ChildrenTypedIterator, error)
 	SaveAsDelegate(parentRef, classRef core.RecordRef, constructorName string, argsSerialized []byte) (core.RecordRef, error)
 	GetDelegate(object, ofType core.RecordRef) (core.RecordRef, error)
 	DeactivateObject(object core.RecordRef) error
+	AcquireLock(name string, ttl time.Duration) (bool, error)
+	ReleaseLock(name string) error
+	RequireValidation() error
+	ScheduleCall(pulses uint, method string, args []byte) error
 	Serialize(what interface{}, to *[]byte) error
 	Deserialize(from []byte, into interface{}) error
 	MakeErrorSerializable(error) error
@@ -17,9 +17,15 @@
 package rpctypes
 
 import (
+	"time"
+
 	"github.com/insolar/insolar/core"
 )
 
+// ErrRecycling is text of error returned by runner to calls arrived while it's going to restart,
+// such calls aren't executed and should be repeated after reconnect.
+const ErrRecycling = "runner is recycling"
+
 // Types for RPC requests and responses between goplugin and goinsider.
 // Calls from goplugin to goinsider go "downwards" and names are
 // prefixed with "Down". Reverse calls go "upwards", so "Up" prefix
@@ -53,6 +59,16 @@ type DownCallConstructorResp struct {
 	Ret core.Arguments
 }
 
+// DownPreloadCodeReq is a set of arguments for PreloadCode RPC in the runner
+type DownPreloadCodeReq struct {
+	TraceID string
+	Code    core.RecordRef
+	Data    []byte
+}
+
+// DownPreloadCodeResp is response from PreloadCode RPC in the runner
+type DownPreloadCodeResp struct{}
+
 // UpBaseReq  is a base type for all insgorund -> logicrunner requests
 type UpBaseReq struct {
 	Mode      string
@@ -159,3 +175,37 @@ type UpDeactivateObjectReq struct {
 // UpDeactivateObjectResp is response from DeactivateObject RPC in goplugin
 type UpDeactivateObjectResp struct {
 }
+
+// UpLockReq is a set of arguments for Lock RPC in goplugin
+type UpLockReq struct {
+	UpBaseReq
+	Name    string
+	TTL     time.Duration
+	Release bool
+}
+
+// UpLockResp is response from Lock RPC in goplugin
+type UpLockResp struct {
+	Acquired bool
+}
+
+// UpRequireValidationReq is a set of arguments for RequireValidation RPC in goplugin
+type UpRequireValidationReq struct {
+	UpBaseReq
+}
+
+// UpRequireValidationResp is response from RequireValidation RPC in goplugin
+type UpRequireValidationResp struct {
+}
+
+// UpScheduleCallReq is a set of arguments for ScheduleCall RPC in goplugin
+type UpScheduleCallReq struct {
+	UpBaseReq
+	Pulses    uint
+	Method    string
+	Arguments core.Arguments
+}
+
+// UpScheduleCallResp is response from ScheduleCall RPC in goplugin
+type UpScheduleCallResp struct {
+}
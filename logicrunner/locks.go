@@ -0,0 +1,108 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logicrunner
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+type heldLock struct {
+	owner   core.RecordRef
+	expires time.Time
+}
+
+// lockTable keeps named locks held by objects. Node keeps locks it's executor for, so all locks are released
+// on pulse change, when executors change.
+type lockTable struct {
+	lock  sync.Mutex
+	locks map[string]heldLock
+}
+
+func newLockTable() *lockTable {
+	return &lockTable{locks: map[string]heldLock{}}
+}
+
+// acquire takes lock for owner or extends it if owner already holds the lock.
+func (t *lockTable) acquire(name string, owner core.RecordRef, ttl time.Duration, now time.Time) (bool, time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	held, ok := t.locks[name]
+	if ok && held.owner != owner && now.Before(held.expires) {
+		return false, held.expires
+	}
+	expires := now.Add(ttl)
+	t.locks[name] = heldLock{owner: owner, expires: expires}
+	return true, expires
+}
+
+// release releases lock if it's held by owner.
+func (t *lockTable) release(name string, owner core.RecordRef) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if held, ok := t.locks[name]; ok && held.owner == owner {
+		delete(t.locks, name)
+	}
+}
+
+func (t *lockTable) reset() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.locks = map[string]heldLock{}
+}
+
+// HandleLockMessage acquires or releases named lock for owner object. Locks are valid within pulse only,
+// so requests from previous pulses are rejected.
+func (lr *LogicRunner) HandleLockMessage(ctx context.Context, parcel core.Parcel) (core.Reply, error) {
+	msg, ok := parcel.Message().(*message.Lock)
+	if !ok {
+		return nil, errors.New("HandleLockMessage( ! message.Lock )")
+	}
+	if parcel.Pulse() != lr.pulse(ctx).PulseNumber {
+		return nil, errors.New("[ HandleLockMessage ] lock request is from other pulse")
+	}
+	if err := lr.CheckOurRole(ctx, msg, core.DynamicRoleVirtualExecutor); err != nil {
+		return nil, errors.Wrap(err, "[ HandleLockMessage ] can't play role")
+	}
+
+	if msg.Release {
+		lr.locks.release(msg.Name, msg.Owner)
+		return &reply.Lock{}, nil
+	}
+
+	ttl := msg.TTL
+	if lr.Cfg.MaxLockTTL > 0 && (ttl <= 0 || ttl > lr.Cfg.MaxLockTTL) {
+		ttl = lr.Cfg.MaxLockTTL
+	}
+	if ttl <= 0 {
+		return nil, errors.New("[ HandleLockMessage ] lock TTL must be positive")
+	}
+	acquired, expires := lr.locks.acquire(msg.Name, msg.Owner, ttl, lr.clock.Now())
+	inslogger.FromContext(ctx).Debugf("[ HandleLockMessage ] lock %q for %s acquired: %t", msg.Name, msg.Owner, acquired)
+	return &reply.Lock{Acquired: acquired, Expires: expires}, nil
+}
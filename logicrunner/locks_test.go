@@ -0,0 +1,62 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logicrunner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestLockTable(t *testing.T) {
+	table := newLockTable()
+	first, second := testutils.RandomRef(), testutils.RandomRef()
+	now := time.Now()
+
+	ok, expires := table.acquire("name", first, time.Second, now)
+	require.True(t, ok)
+	require.Equal(t, now.Add(time.Second), expires)
+
+	ok, expires = table.acquire("name", second, time.Second, now)
+	require.False(t, ok, "lock is held by other owner")
+	require.Equal(t, now.Add(time.Second), expires)
+
+	ok, _ = table.acquire("other", second, time.Second, now)
+	require.True(t, ok, "locks with different names are independent")
+
+	ok, expires = table.acquire("name", first, 2*time.Second, now)
+	require.True(t, ok, "owner extends its lock")
+	require.Equal(t, now.Add(2*time.Second), expires)
+
+	table.release("name", second)
+	ok, _ = table.acquire("name", second, time.Second, now)
+	require.False(t, ok, "lock can be released by owner only")
+
+	ok, _ = table.acquire("name", second, time.Second, now.Add(2*time.Second))
+	require.True(t, ok, "expired lock is taken")
+
+	table.release("name", second)
+	ok, _ = table.acquire("name", first, time.Second, now)
+	require.True(t, ok)
+
+	table.reset()
+	ok, _ = table.acquire("name", second, time.Second, now)
+	require.True(t, ok, "reset releases all locks")
+}
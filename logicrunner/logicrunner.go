@@ -23,10 +23,10 @@ import (
 	"encoding/gob"
 	"net"
 	"strconv"
-	"strings"
 	"sync"
 	"time"
 
+	"go.opencensus.io/stats"
 	"go.opencensus.io/trace"
 
 	"github.com/insolar/insolar/instrumentation/instracer"
@@ -35,11 +35,14 @@ import (
 
 	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/codec"
 	"github.com/insolar/insolar/core/message"
 	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/instrumentation/crashreport"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/logicrunner/builtin"
 	"github.com/insolar/insolar/logicrunner/goplugin"
+	"github.com/insolar/insolar/netparams"
 )
 
 const maxQueueLength = 10
@@ -48,11 +51,13 @@ type Ref = core.RecordRef
 
 // Context of one contract execution
 type ObjectState struct {
-	sync.Mutex
+	stateMutex
 
 	ExecutionState *ExecutionState
 	Validation     *ExecutionState
-	Consensus      *Consensus
+	// Speculation is a state of speculative execution of request deferred till next pulse
+	Speculation *ExecutionState
+	Consensus   *Consensus
 }
 
 type CurrentExecution struct {
@@ -63,6 +68,14 @@ type CurrentExecution struct {
 	RequesterNode *Ref
 	ReturnMode    message.MethodReturnMode
 	SentResult    bool
+	// AcceptsCompressed is set if requester decompresses results
+	AcceptsCompressed bool
+	// RequestSequence is a number of executed request within object
+	RequestSequence uint64
+	// Pulse is pulse request was registered in, it's given to contract and recorded in case bind for validators
+	Pulse core.Pulse
+	// holdsWorker is set while execution occupies worker of executor pool
+	holdsWorker bool
 }
 
 type ExecutionQueueResult struct {
@@ -76,6 +89,11 @@ type ExecutionQueueElement struct {
 	parcel     core.Parcel
 	request    *Ref
 	fromLedger bool
+	caller     Ref
+	sequence   uint64
+	priority   bool
+	// pulse is pulse request was registered in, zero if it's unknown and current pulse is used
+	pulse core.Pulse
 }
 
 type Error struct {
@@ -103,11 +121,14 @@ func (lre Error) Error() string {
 }
 
 func (st *ObjectState) MustModeState(mode string) (res *ExecutionState) {
+	st.assertLocked("ObjectState." + mode)
 	switch mode {
 	case "execution":
 		res = st.ExecutionState
 	case "validation":
 		res = st.Validation
+	case speculationMode:
+		res = st.Speculation
 	default:
 		panic("'" + mode + "' is unknown object processing mode")
 	}
@@ -140,13 +161,45 @@ type LogicRunner struct {
 	PulseStorage               core.PulseStorage               `inject:""`
 	ArtifactManager            core.ArtifactManager            `inject:""`
 	JetCoordinator             core.JetCoordinator             `inject:""`
+	ClockSkewMonitor           core.ClockSkewMonitor           `inject:""`
+	Timeline                   core.Timeline                   `inject:""`
+	DisputeArchive             core.DisputeArchive             `inject:""`
+	NetworkParameters          core.NetworkParameters          `inject:""`
 
 	Executors    [core.MachineTypesLastID]core.MachineLogicExecutor
 	machinePrefs []core.MachineType
 	Cfg          *configuration.LogicRunner
 
 	state      map[Ref]*ObjectState // if object exists, we are validating or executing it right now
-	stateMutex sync.RWMutex
+	stateMutex stateRWMutex
+
+	timings *methodTimings
+	locks   *lockTable
+	acls    *aclCache
+	// sessions caches descriptors of objects called within client sessions
+	sessions *sessionCache
+	// validations caches descriptors of prototypes and code shared by validations within a pulse
+	validations *validationCache
+	// spool retries pulse change messages which failed to be delivered, nil if disabled
+	spool *pulseSpool
+	// caseBinds keeps executed requests of recent pulses for export, nil if disabled
+	caseBinds *caseBindRegistry
+	// latencies collects execution duration histograms per prototype and method, nil if disabled
+	latencies *methodLatencies
+	// recentStates orders objects in state by the last access for eviction of idle ones, nil if state isn't capped
+	recentStates *objectStateLRU
+	// stopping is set when logic runner drains executions before stop
+	stopping int32
+	// lastPulse is number of the last pulse seen, gap in pulses means node rejoined network
+	lastPulse core.PulseNumber
+	// preloading is set while configured objects are preloaded
+	preloading int32
+	// executors bounds number of executions running at once, nil if unlimited
+	executors *executorPool
+
+	clock Clock
+	// raceHook is called on race points, tests use it to interleave requests with pulse change
+	raceHook func(ctx context.Context, point racePoint)
 
 	sock net.Listener
 }
@@ -157,8 +210,31 @@ func NewLogicRunner(cfg *configuration.LogicRunner) (*LogicRunner, error) {
 		return nil, errors.New("LogicRunner have nil configuration")
 	}
 	res := LogicRunner{
-		Cfg:   cfg,
-		state: make(map[Ref]*ObjectState),
+		Cfg:     cfg,
+		clock:   realClock{},
+		state:   make(map[Ref]*ObjectState),
+		timings: newMethodTimings(),
+		locks:   newLockTable(),
+		acls:    newACLCache(),
+
+		sessions:    newSessionCache(cfg.SessionTTL),
+		validations: newValidationCache(),
+		executors:   newExecutorPool(cfg.ExecutorWorkers),
+	}
+	if cfg.CaseBindExportPulses > 0 {
+		res.caseBinds = newCaseBindRegistry(cfg.CaseBindExportPulses)
+	}
+	if cfg.MaxMethodLatencies > 0 {
+		res.latencies = newMethodLatencies(cfg.MaxMethodLatencies)
+	}
+	if cfg.MaxObjectStates > 0 {
+		res.recentStates = newObjectStateLRU()
+	}
+	if cfg.PulseSpool != nil {
+		res.spool = newPulseSpool(*cfg.PulseSpool, res.clock, func(ctx context.Context, msg core.Message) error {
+			_, err := res.MessageBus.Send(ctx, msg, nil)
+			return err
+		})
 	}
 	return &res, nil
 }
@@ -193,6 +269,31 @@ func (lr *LogicRunner) Start(ctx context.Context) error {
 	return nil
 }
 
+// StateSize returns number of objects LogicRunner holds state for.
+func (lr *LogicRunner) StateSize() int {
+	lr.stateMutex.RLock()
+	defer lr.stateMutex.RUnlock()
+	return len(lr.state)
+}
+
+// QueueDepth returns number of requests queued for execution on all objects.
+func (lr *LogicRunner) QueueDepth() int {
+	lr.stateMutex.RLock()
+	defer lr.stateMutex.RUnlock()
+
+	depth := 0
+	for _, state := range lr.state {
+		state.Lock()
+		if es := state.ExecutionState; es != nil {
+			es.Lock()
+			depth += len(es.Queue)
+			es.Unlock()
+		}
+		state.Unlock()
+	}
+	return depth
+}
+
 func (lr *LogicRunner) RegisterHandlers() {
 	lr.MessageBus.MustRegister(core.TypeCallMethod, lr.Execute)
 	lr.MessageBus.MustRegister(core.TypeCallConstructor, lr.Execute)
@@ -202,10 +303,14 @@ func (lr *LogicRunner) RegisterHandlers() {
 	lr.MessageBus.MustRegister(core.TypePendingFinished, lr.HandlePendingFinishedMessage)
 	lr.MessageBus.MustRegister(core.TypeStillExecuting, lr.HandleStillExecutingMessage)
 	lr.MessageBus.MustRegister(core.TypeAbandonedRequestsNotification, lr.HandleAbandonedRequestsNotificationMessage)
+	lr.MessageBus.MustRegister(core.TypeLock, lr.HandleLockMessage)
+	lr.MessageBus.MustRegister(core.TypeDueTimers, lr.HandleDueTimersMessage)
 }
 
-// Stop stops logic runner component and its executors
+// Stop drains in-flight executions and stops logic runner component and its executors
 func (lr *LogicRunner) Stop(ctx context.Context) error {
+	lr.drain(ctx)
+
 	reterr := error(nil)
 	for _, e := range lr.Executors {
 		if e == nil {
@@ -227,6 +332,9 @@ func (lr *LogicRunner) Stop(ctx context.Context) error {
 }
 
 func (lr *LogicRunner) CheckOurRole(ctx context.Context, msg core.Message, role core.DynamicRole) error {
+	if role == core.DynamicRoleVirtualExecutor && lr.ClockSkewMonitor.RefuseExecutorRole() {
+		return errors.New("can't execute this object: local clock is skewed")
+	}
 	// TODO do map of supported objects for pulse, go to jetCoordinator only if map is empty for ref
 	target := msg.DefaultTarget()
 	isAuthorized, err := lr.JetCoordinator.IsAuthorized(
@@ -236,24 +344,25 @@ func (lr *LogicRunner) CheckOurRole(ctx context.Context, msg core.Message, role
 		return errors.Wrap(err, "authorization failed with error")
 	}
 	if !isAuthorized {
-		return errors.New("can't execute this object")
+		return core.NewCodedError(core.ErrCodeNotAuthorized, "LogicRunner", "can't execute this object")
 	}
 	return nil
 }
 
-func (lr *LogicRunner) RegisterRequest(ctx context.Context, parcel core.Parcel) (*Ref, error) {
+// RegisterRequest registers request on ledger, returns its reference and sequence number within object.
+func (lr *LogicRunner) RegisterRequest(ctx context.Context, parcel core.Parcel) (*Ref, uint64, error) {
 	ctx, span := instracer.StartSpan(ctx, "LogicRunner.RegisterRequest")
 	defer span.End()
 
 	obj := parcel.Message().(message.IBaseLogicMessage).GetReference()
-	id, err := lr.ArtifactManager.RegisterRequest(ctx, obj, parcel)
+	id, seq, err := lr.ArtifactManager.RegisterRequest(ctx, obj, parcel)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	res := obj
 	res.SetRecord(*id)
-	return &res, nil
+	return &res, seq, nil
 }
 
 func loggerWithTargetID(ctx context.Context, msg core.Parcel) context.Context {
@@ -277,6 +386,17 @@ func (lr *LogicRunner) Execute(ctx context.Context, parcel core.Parcel) (core.Re
 	)
 	defer span.End()
 
+	if lr.isStopping() {
+		inslogger.FromContext(ctx).Debug("[ Execute ] logic runner is stopping, rejecting request")
+		return &reply.Busy{RetryAfter: lr.Cfg.BusyRetryAfter}, nil
+	}
+
+	if _, ok := msg.(*message.CallConstructor); ok {
+		if reason, paused := netparams.Paused(lr.NetworkParameters); paused {
+			return nil, errors.Errorf("[ Execute ] network is paused, constructors are rejected: %s", reason)
+		}
+	}
+
 	rep, err := lr.executeActual(ctx, parcel, msg)
 	return rep, err
 }
@@ -314,14 +434,35 @@ func (lr *LogicRunner) executeActual(ctx context.Context, parcel core.Parcel, ms
 		es.Unlock()
 		return nil, os.WrapError(nil, "loop detected")
 	}
+
+	queueLimit := netparams.Int(lr.NetworkParameters, core.NetworkParameterMaxQueueLength, lr.Cfg.MaxQueueLength)
+	if queueLimit > 0 && len(es.Queue) >= queueLimit {
+		es.Unlock()
+		inslogger.FromContext(ctx).Warnf("[ Execute ] execution queue of %s is full, rejecting request", ref)
+		return &reply.Busy{RetryAfter: lr.Cfg.BusyRetryAfter}, nil
+	}
+	caller := queueCaller(msg)
+	if lr.Cfg.MaxCallerQueueLength > 0 && es.callerQueueLength(caller) >= lr.Cfg.MaxCallerQueueLength {
+		es.Unlock()
+		stats.Record(ctx, statQueueCallerRejected.M(1))
+		inslogger.FromContext(ctx).Warnf("[ Execute ] caller %s exceeded its share of execution queue of %s, rejecting request", caller, ref)
+		return &reply.Busy{RetryAfter: lr.Cfg.BusyRetryAfter}, nil
+	}
+	es.registering++
 	es.Unlock()
 
-	request, err := lr.RegisterRequest(ctx, parcel)
+	request, seq, err := lr.RegisterRequest(ctx, parcel)
 	if err != nil {
+		es.Lock()
+		es.registering--
+		es.Unlock()
 		return nil, os.WrapError(err, "[ Execute ] can't create request")
 	}
+	lr.recordTimeline(ctx, core.TimelineRequestRegistered, msg.GetAPIRequest(), request, nil)
+	lr.reach(ctx, raceExecuteRegistered)
 
 	es.Lock()
+	es.registering--
 	pulse := lr.pulse(ctx)
 	if pulse.PulseNumber != parcel.Pulse() {
 		meCurrent, _ := lr.JetCoordinator.IsAuthorized(
@@ -335,13 +476,28 @@ func (lr *LogicRunner) executeActual(ctx context.Context, parcel core.Parcel, ms
 		}
 	}
 
+	if err := es.sequence().observe(seq); err != nil {
+		es.Unlock()
+		stats.Record(ctx, statRequestsOutOfOrder.M(1))
+		lr.finishOutOfOrder(ctx, ref, *request)
+		return nil, os.WrapError(err, "[ Execute ] can't enqueue request")
+	}
+
 	qElement := ExecutionQueueElement{
-		ctx:     ctx,
-		parcel:  parcel,
-		request: request,
+		ctx:      ctx,
+		parcel:   parcel,
+		request:  request,
+		caller:   caller,
+		sequence: seq,
+		priority: queuePriority(msg),
+		pulse:    *pulse,
 	}
 
-	es.Queue = append(es.Queue, qElement)
+	es.enqueue(qElement, lr.Cfg.FairQueue)
+	if len(es.Queue) > 0 {
+		share := float64(es.callerQueueLength(caller)) / float64(len(es.Queue))
+		stats.Record(ctx, statQueueCallerShare.M(share))
+	}
 	es.Unlock()
 
 	err = lr.ClarifyPendingState(ctx, es, parcel)
@@ -463,10 +619,26 @@ func (lr *LogicRunner) StartQueueProcessorIfNeeded(
 }
 
 func (lr *LogicRunner) ProcessExecutionQueue(ctx context.Context, es *ExecutionState) {
+	var panicErr error
+	defer func() {
+		if panicErr != nil {
+			lr.recoverQueueProcessing(ctx, es, panicErr)
+		}
+	}()
+	defer crashreport.RecoverError(ctx, "LogicRunner.ProcessExecutionQueue", &panicErr)
+
+	lr.prefetchQueue(ctx, es)
+	defer func() {
+		es.Lock()
+		es.prefetched = nil
+		es.Unlock()
+	}()
+
 	for {
 		es.Lock()
 		if len(es.Queue) == 0 && es.LedgerQueueElement == nil {
 			inslogger.FromContext(ctx).Debug("Quiting queue processing, empty")
+			lr.reportMissingRequests(ctx, es)
 			es.QueueProcessorActive = false
 			es.Current = nil
 			es.Unlock()
@@ -476,21 +648,69 @@ func (lr *LogicRunner) ProcessExecutionQueue(ctx context.Context, es *ExecutionS
 		var qe ExecutionQueueElement
 		if es.LedgerQueueElement != nil {
 			qe = *es.LedgerQueueElement
+		} else {
+			qe = es.Queue[0]
+		}
+
+		if lr.isStopping() {
+			inslogger.FromContext(qe.ctx).Info("Logic runner is stopping, quiting queue processing")
+			es.QueueProcessorActive = false
+			es.Current = nil
+			es.Unlock()
+			return
+		}
+
+		key := timingKey(es, qe.parcel)
+		if !lr.fitsPulse(key) {
+			inslogger.FromContext(qe.ctx).Infof(
+				"Execution of %s won't finish before pulse end, deferring queue till next pulse", key,
+			)
+			if qe.fromLedger {
+				es.LedgerHasMoreRequests = true
+			}
+			lr.startSpeculation(ctx, es, qe)
+			es.deferred = true
+			es.QueueProcessorActive = false
+			es.Current = nil
+			es.Unlock()
+			return
+		}
+
+		if es.LedgerQueueElement != nil {
 			es.LedgerQueueElement = nil
 		} else {
-			qe, es.Queue = es.Queue[0], es.Queue[1:]
+			es.Queue = es.Queue[1:]
+		}
+
+		if es.deactivated {
+			es.sequence().finish(qe.sequence)
+			es.Unlock()
+
+			lr.finishDeactivated(ctx, es, qe)
+			if qe.fromLedger {
+				go lr.getLedgerPendingRequest(ctx, es)
+			}
+			lr.finishPendingIfNeeded(ctx, es)
+			continue
 		}
 
 		sender := qe.parcel.GetSender()
 		current := CurrentExecution{
-			Request:       qe.request,
-			RequesterNode: &sender,
-			Context:       qe.ctx,
+			Request:         qe.request,
+			RequesterNode:   &sender,
+			Context:         qe.ctx,
+			RequestSequence: qe.sequence,
+			Pulse:           lr.requestPulse(ctx, qe),
+		}
+		if lr.Cfg.CrossJetProofs {
+			// proofs are recorded with fetched states, validators check them instead of fetching objects again
+			current.Context = core.ContextWithObjectProofs(qe.ctx)
 		}
 		es.Current = &current
 
 		if msg, ok := qe.parcel.Message().(*message.CallMethod); ok {
 			current.ReturnMode = msg.ReturnMode
+			current.AcceptsCompressed = msg.AcceptsCompressed
 		}
 		if msg, ok := qe.parcel.Message().(message.IBaseLogicMessage); ok {
 			current.Sequence = msg.GetBaseLogicMessage().Sequence
@@ -502,9 +722,25 @@ func (lr *LogicRunner) ProcessExecutionQueue(ctx context.Context, es *ExecutionS
 
 		inslogger.FromContext(qe.ctx).Debug("Registering request within execution behaviour")
 
-		es.Behaviour.(*ValidationSaver).NewRequest(qe.parcel, *qe.request, lr.MessageBus)
+		es.Behaviour.(*ValidationSaver).NewRequest(qe.parcel, *qe.request, current.Pulse, lr.MessageBus)
 
+		lr.executors.acquire()
+		current.holdsWorker = lr.executors != nil
+		start := lr.clock.Now()
 		res.reply, res.err = lr.executeOrValidate(current.Context, es, qe.parcel)
+		if current.holdsWorker {
+			current.holdsWorker = false
+			lr.executors.release()
+		}
+		if key != "" {
+			duration := lr.clock.Now().Sub(start)
+			// prototype of the object is known after execution even if it wasn't before
+			key = timingKey(es, qe.parcel)
+			lr.timings.Add(key, duration)
+			if lr.latencies != nil {
+				lr.latencies.Add(qe.ctx, latencyPrototype(es, qe.parcel), timingMethod(qe.parcel), duration)
+			}
+		}
 
 		if qe.fromLedger {
 			go lr.getLedgerPendingRequest(ctx, es)
@@ -516,10 +752,151 @@ func (lr *LogicRunner) ProcessExecutionQueue(ctx context.Context, es *ExecutionS
 			res.err = err
 		}
 
+		es.Lock()
+		es.sequence().finish(qe.sequence)
+		es.Unlock()
+
 		lr.finishPendingIfNeeded(ctx, es)
 	}
 }
 
+// recoverQueueProcessing resets execution state after panic in queue processing, so object isn't left
+// with active processor forever, returns error to caller of current request and restarts processing of the rest.
+func (lr *LogicRunner) recoverQueueProcessing(ctx context.Context, es *ExecutionState, panicErr error) {
+	es.Lock()
+	current := es.Current
+	es.Current = nil
+	es.QueueProcessorActive = false
+	if current != nil {
+		es.sequence().finish(current.RequestSequence)
+	}
+	es.Unlock()
+
+	if current != nil {
+		if current.holdsWorker {
+			current.holdsWorker = false
+			lr.executors.release()
+		}
+		if !current.SentResult && current.ReturnMode == message.ReturnResult && current.RequesterNode != nil {
+			lr.sendPanicResult(ctx, *current.RequesterNode, current.Sequence, panicErr)
+		}
+	}
+
+	lr.finishPendingIfNeeded(ctx, es)
+	if err := lr.StartQueueProcessorIfNeeded(ctx, es); err != nil {
+		inslogger.FromContext(ctx).Error("couldn't restart queue processing after panic: ", err)
+	}
+}
+
+func (lr *LogicRunner) sendPanicResult(ctx context.Context, target core.RecordRef, seq uint64, panicErr error) {
+	go func() {
+		_, err := core.MessageBusFromContext(ctx, lr.MessageBus).Send(
+			ctx,
+			&message.ReturnResults{
+				Caller:   lr.NodeNetwork.GetOrigin().ID(),
+				Target:   target,
+				Sequence: seq,
+				Error:    panicErr.Error(),
+			},
+			&core.MessageSendOptions{
+				Receiver: &target,
+			},
+		)
+		if err != nil {
+			inslogger.FromContext(ctx).Error("couldn't deliver results: ", err)
+		}
+	}()
+}
+
+// finishOutOfOrder registers error result for request which is rejected since requests numbered after it were
+// executed already, so request doesn't stay pending on ledger forever.
+func (lr *LogicRunner) finishOutOfOrder(ctx context.Context, object Ref, request Ref) {
+	_, err := lr.ArtifactManager.RegisterResult(ctx, object, request, reply.OutOfOrderResult())
+	if err != nil {
+		inslogger.FromContext(ctx).Error("couldn't register result of out of order request: ", err)
+	}
+}
+
+// finishDeactivated registers typed result for request left in queue of deactivated object and returns it to
+// caller, so request fails with core.ErrDeactivated instead of failing to fetch object state.
+func (lr *LogicRunner) finishDeactivated(ctx context.Context, es *ExecutionState, qe ExecutionQueueElement) {
+	logger := inslogger.FromContext(qe.ctx)
+	logger.Info("Object is deactivated, finishing request without execution")
+	stats.Record(ctx, statRequestsDeactivated.M(1))
+
+	if qe.request != nil {
+		_, err := lr.ArtifactManager.RegisterResult(ctx, es.Ref, *qe.request, reply.DeactivatedResult())
+		if err != nil {
+			logger.Error("couldn't register result of request to deactivated object: ", err)
+		}
+	}
+
+	var seq uint64
+	switch msg := qe.parcel.Message().(type) {
+	case *message.CallMethod:
+		if msg.ReturnMode != message.ReturnResult {
+			return
+		}
+		seq = msg.Sequence
+	case *message.CallConstructor:
+		seq = msg.Sequence
+	default:
+		return
+	}
+
+	target := qe.parcel.GetSender()
+	go func() {
+		_, err := core.MessageBusFromContext(ctx, lr.MessageBus).Send(
+			ctx,
+			&message.ReturnResults{
+				Caller:   lr.NodeNetwork.GetOrigin().ID(),
+				Target:   target,
+				Sequence: seq,
+				Reply:    &reply.Error{ErrType: reply.ErrDeactivated},
+				Error:    core.ErrDeactivated.Error(),
+			},
+			&core.MessageSendOptions{
+				Receiver: &target,
+			},
+		)
+		if err != nil {
+			logger.Error("couldn't deliver results: ", err)
+		}
+	}()
+}
+
+// fitsPulse checks if execution with timing key is expected to finish before pulse end.
+func (lr *LogicRunner) fitsPulse(key string) bool {
+	if lr.Cfg.ExecutionDeadline == nil || key == "" {
+		return true
+	}
+	deadline := lr.Cfg.ExecutionDeadline
+	return lr.timings.Fits(key, lr.timings.Margin(deadline.Margin, deadline.PulseFraction), lr.clock.Now())
+}
+
+// reportMissingRequests logs requests registered on ledger which never reached executor, es should be locked
+func (lr *LogicRunner) reportMissingRequests(ctx context.Context, es *ExecutionState) {
+	if es.LedgerHasMoreRequests || es.registering > 0 {
+		return
+	}
+	missing := es.sequence().gaps()
+	if len(missing) == 0 {
+		return
+	}
+	stats.Record(ctx, statRequestsMissing.M(int64(len(missing))))
+	inslogger.FromContext(ctx).Error(
+		es.WrapError(errors.Wrapf(core.ErrRequestsMissing, "numbers %v", missing), "[ ProcessExecutionQueue ]"),
+	)
+}
+
+// executingSequence returns number of request being executed or zero, es should be locked
+func executingSequence(es *ExecutionState) uint64 {
+	if es.Current == nil {
+		return 0
+	}
+	return es.Current.RequestSequence
+}
+
 // finishPendingIfNeeded checks whether last execution was a pending one.
 // If this is true as a side effect the function sends a PendingFinished
 // message to the current executor
@@ -567,9 +944,15 @@ func (lr *LogicRunner) executeOrValidate(
 		Callee:          &ref,
 		Request:         es.Current.Request,
 		Time:            time.Now(), // TODO: probably we should take it earlier
-		Pulse:           *lr.pulse(ctx),
+		Pulse:           es.Current.Pulse,
 		TraceID:         inslogger.TraceID(ctx),
 		CallerPrototype: msg.GetCallerPrototype(),
+		APIRequest:      msg.GetAPIRequest(),
+	}
+
+	executing := es.Behaviour.Mode() == "execution"
+	if executing {
+		lr.recordTimeline(ctx, core.TimelineExecutionStarted, msg.GetAPIRequest(), es.Current.Request, nil)
 	}
 
 	var re core.Reply
@@ -584,6 +967,9 @@ func (lr *LogicRunner) executeOrValidate(
 	default:
 		panic("Unknown e type")
 	}
+	if executing {
+		lr.recordTimeline(ctx, core.TimelineExecutionFinished, msg.GetAPIRequest(), es.Current.Request, err)
+	}
 	errstr := ""
 	if err != nil {
 		errstr = err.Error()
@@ -600,6 +986,10 @@ func (lr *LogicRunner) executeOrValidate(
 	target := *es.Current.RequesterNode
 	request := *es.Current.Request
 	seq := es.Current.Sequence
+	sent := re
+	if result, ok := re.(*reply.CallMethod); ok && es.Current.AcceptsCompressed {
+		sent = lr.compressResult(ctx, result)
+	}
 
 	go func() {
 		inslogger.FromContext(ctx).Debugf("Sending Method Results for ", request)
@@ -610,7 +1000,7 @@ func (lr *LogicRunner) executeOrValidate(
 				Caller:   lr.NodeNetwork.GetOrigin().ID(),
 				Target:   target,
 				Sequence: seq,
-				Reply:    re,
+				Reply:    sent,
 				Error:    errstr,
 			},
 			&core.MessageSendOptions{
@@ -625,6 +1015,45 @@ func (lr *LogicRunner) executeOrValidate(
 	return re, err
 }
 
+// prefetchQueue fetches descriptors of the object and prototypes of constructors of queued requests in one
+// batch, so burst of requests doesn't cost round trip to ledger per element.
+func (lr *LogicRunner) prefetchQueue(ctx context.Context, es *ExecutionState) {
+	es.Lock()
+	if es.objectbody != nil {
+		es.Unlock()
+		return
+	}
+	heads := []Ref{es.Ref}
+	seen := map[Ref]bool{es.Ref: true}
+	queued := 0
+	for _, qe := range es.Queue {
+		if qe.parcel == nil {
+			continue
+		}
+		queued++
+		msg, ok := qe.parcel.Message().(*message.CallConstructor)
+		if !ok || seen[msg.PrototypeRef] {
+			continue
+		}
+		seen[msg.PrototypeRef] = true
+		heads = append(heads, msg.PrototypeRef)
+	}
+	es.Unlock()
+	if queued < 2 {
+		return
+	}
+
+	objects, _, err := lr.ArtifactManager.GetObjects(ctx, heads, nil)
+	if err != nil {
+		inslogger.FromContext(ctx).Debug("failed to prefetch descriptors of queue: ", err)
+		return
+	}
+
+	es.Lock()
+	es.prefetched = objects
+	es.Unlock()
+}
+
 // never call this under es.Lock(), this leads to deadlock
 func (lr *LogicRunner) getLedgerPendingRequest(ctx context.Context, es *ExecutionState) {
 	ctx, span := instracer.StartSpan(ctx, "LogicRunner.getLedgerPendingRequest")
@@ -656,7 +1085,7 @@ func (lr *LogicRunner) unsafeGetLedgerPendingRequest(ctx context.Context, es *Ex
 
 	id := *es.Ref.Record()
 
-	parcel, err := lr.ArtifactManager.GetPendingRequest(ctx, id)
+	parcel, seq, err := lr.ArtifactManager.GetPendingRequest(ctx, id)
 	if err != nil {
 		if err != core.ErrNoPendingRequest {
 			inslogger.FromContext(ctx).Debug("GetPendingRequest failed with error")
@@ -692,12 +1121,24 @@ func (lr *LogicRunner) unsafeGetLedgerPendingRequest(ctx context.Context, es *Ex
 	request := msg.GetReference()
 	request.SetRecord(id)
 
+	if err := es.sequence().observe(seq); err != nil {
+		inslogger.FromContext(ctx).Error(es.WrapError(err, "[ getLedgerPendingRequest ] can't execute pending request"))
+		stats.Record(ctx, statRequestsOutOfOrder.M(1))
+		// result closes request on ledger, so fetching continues with the next one
+		go func() {
+			lr.finishOutOfOrder(ctx, es.Ref, request)
+			lr.getLedgerPendingRequest(ctx, es)
+		}()
+		return nil
+	}
+
 	es.LedgerHasMoreRequests = ledgerHasMore
 	es.LedgerQueueElement = &ExecutionQueueElement{
 		ctx:        ctx,
 		parcel:     parcel,
 		request:    &request,
 		fromLedger: true,
+		sequence:   seq,
 	}
 
 	return msg.DefaultTarget()
@@ -757,16 +1198,36 @@ func (lr *LogicRunner) prepareObjectState(ctx context.Context, msg *message.Exec
 		es.LedgerHasMoreRequests = msg.LedgerHasMoreRequests
 	}
 
+	es.sequence().handover(msg.Sequence, msg.SequenceFinished, msg.SequenceGaps)
+	if msg.Speculation != nil {
+		es.speculation = speculationFromMessage(msg.Speculation)
+	}
+
 	//prepare Queue
 	if msg.Queue != nil {
 		queueFromMessage := make([]ExecutionQueueElement, 0)
 		for _, qe := range msg.Queue {
+			if err := es.sequence().observe(qe.Sequence); err != nil {
+				inslogger.FromContext(ctx).Error(es.WrapError(err, "[ prepareObjectState ] dropping handed over request"))
+				stats.Record(ctx, statRequestsOutOfOrder.M(1))
+				continue
+			}
+			var caller Ref
+			var priority bool
+			if logicMsg, ok := qe.Parcel.Message().(message.IBaseLogicMessage); ok {
+				caller = queueCaller(logicMsg)
+				priority = queuePriority(logicMsg)
+			}
 			queueFromMessage = append(
 				queueFromMessage,
 				ExecutionQueueElement{
-					ctx:     qe.Parcel.Context(context.Background()),
-					parcel:  qe.Parcel,
-					request: qe.Request,
+					ctx:      qe.Parcel.Context(context.Background()),
+					parcel:   qe.Parcel,
+					request:  qe.Request,
+					caller:   caller,
+					sequence: qe.Sequence,
+					priority: priority,
+					pulse:    qe.Pulse,
 				})
 		}
 		es.Queue = append(queueFromMessage, es.Queue...)
@@ -784,7 +1245,11 @@ func (lr *LogicRunner) prepareObjectState(ctx context.Context, msg *message.Exec
 
 func (lr *LogicRunner) executeMethodCall(ctx context.Context, es *ExecutionState, m *message.CallMethod) (core.Reply, error) {
 	if es.objectbody == nil {
-		objDesc, protoDesc, codeDesc, err := lr.getDescriptorsByObjectRef(ctx, m.ObjectRef)
+		var session string
+		if m.APIRequest != nil {
+			session = m.APIRequest.Session
+		}
+		objDesc, protoDesc, codeDesc, err := lr.getDescriptorsByObjectRef(ctx, es, m.ObjectRef, session)
 		if err != nil {
 			return nil, errors.Wrap(err, "couldn't get descriptors by object reference")
 		}
@@ -807,17 +1272,38 @@ func (lr *LogicRunner) executeMethodCall(ctx context.Context, es *ExecutionState
 	if !m.ProxyPrototype.IsEmpty() && !m.ProxyPrototype.Equal(*es.objectbody.Prototype) {
 		return nil, errors.New("proxy call error: try to call method of prototype as method of another prototype")
 	}
+	if err := lr.checkACL(ctx, m, es.objectbody); err != nil {
+		return nil, es.WrapError(err, "access denied")
+	}
 
 	executor, err := lr.GetExecutor(es.objectbody.CodeMachineType)
 	if err != nil {
 		return nil, es.WrapError(err, "no executor registered")
 	}
 
-	newData, result, err := executor.CallMethod(
-		ctx, current.LogicContext, *es.objectbody.CodeRef, es.objectbody.Object, m.Method, m.Arguments,
-	)
-	if err != nil {
-		return nil, es.WrapError(err, "executor error")
+	var newData, result []byte
+	if spec := lr.takeSpeculation(ctx, es, *current.Request); spec != nil {
+		newData, result = spec.memory, spec.result
+		if saver, ok := es.Behaviour.(*ValidationSaver); ok && spec.requireValidation {
+			saver.RequireValidation()
+		}
+	} else {
+		args, err := codec.Transcode(m.Arguments, m.GetCodec(), core.CodecCBOR)
+		if err != nil {
+			return nil, es.WrapError(err, "couldn't decode arguments")
+		}
+
+		newData, result, err = executor.CallMethod(
+			ctx, current.LogicContext, *es.objectbody.CodeRef, es.objectbody.Object, m.Method, args,
+		)
+		if err != nil {
+			return nil, es.WrapError(err, "executor error")
+		}
+
+		result, err = codec.Transcode(result, core.CodecCBOR, m.GetCodec())
+		if err != nil {
+			return nil, es.WrapError(err, "couldn't encode result")
+		}
 	}
 
 	am := lr.ArtifactManager
@@ -828,10 +1314,11 @@ func (lr *LogicRunner) executeMethodCall(ctx context.Context, es *ExecutionState
 		if err != nil {
 			return nil, es.WrapError(err, "couldn't deactivate object")
 		}
+		es.deactivated = true
 	} else if !bytes.Equal(es.objectbody.Object, newData) {
 		od, err := am.UpdateObject(ctx, Ref{}, *current.Request, es.objectbody.objDescriptor, newData)
 		if err != nil {
-			if strings.Contains(err.Error(), "invalid state record") {
+			if core.ErrorCodeOf(err) == core.ErrCodeInvalidStateRecord {
 				es.objectbody = nil
 			}
 			return nil, es.WrapError(err, "couldn't update object")
@@ -842,10 +1329,32 @@ func (lr *LogicRunner) executeMethodCall(ctx context.Context, es *ExecutionState
 	if err != nil {
 		return nil, es.WrapError(err, "couldn't save results")
 	}
+	if current.LogicContext.Mode == "execution" {
+		lr.recordTimeline(ctx, core.TimelineResultRegistered, m.APIRequest, current.Request, nil)
+	}
 
 	es.objectbody.Object = newData
 
-	return &reply.CallMethod{Result: result, Request: *current.Request}, nil
+	return &reply.CallMethod{
+		Result:   result,
+		Request:  *current.Request,
+		Pulse:    current.LogicContext.Pulse.PulseNumber,
+		Finality: core.FinalityExecuted,
+	}, nil
+}
+
+// recordTimeline adds event of request processing to timeline of node.
+func (lr *LogicRunner) recordTimeline(
+	ctx context.Context, stage core.TimelineStage, apiRequest *core.APIRequest, request *Ref, err error,
+) {
+	event := core.TimelineEvent{Stage: stage, Request: request}
+	if apiRequest != nil {
+		event.QID = apiRequest.QID
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	lr.Timeline.Record(ctx, event)
 }
 
 func (lr *LogicRunner) getDescriptorsByPrototypeRef(
@@ -857,31 +1366,50 @@ func (lr *LogicRunner) getDescriptorsByPrototypeRef(
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "couldn't get prototype descriptor")
 	}
+	codeDesc, err := lr.getCodeByPrototype(ctx, protoDesc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return protoDesc, codeDesc, nil
+}
+
+func (lr *LogicRunner) getCodeByPrototype(
+	ctx context.Context, protoDesc core.ObjectDescriptor,
+) (
+	core.CodeDescriptor, error,
+) {
 	codeRef, err := protoDesc.Code()
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "couldn't get code reference")
+		return nil, errors.Wrap(err, "couldn't get code reference")
 	}
 	// we don't want to record GetCode messages because of cache
 	ctx = core.ContextWithMessageBus(ctx, lr.MessageBus)
 	codeDesc, err := lr.ArtifactManager.GetCode(ctx, *codeRef)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "couldn't get code descriptor")
+		return nil, errors.Wrap(err, "couldn't get code descriptor")
 	}
-
-	return protoDesc, codeDesc, nil
+	return codeDesc, nil
 }
 
+// getDescriptorsByObjectRef returns descriptors of object, its prototype and code. Object is taken from
+// descriptors prefetched for queue if any. Descriptors of prototype and code are reused from cache of client
+// session if session isn't empty or from cache of validations within pulse if validating.
 func (lr *LogicRunner) getDescriptorsByObjectRef(
-	ctx context.Context, objRef Ref,
+	ctx context.Context, es *ExecutionState, objRef Ref, session string,
 ) (
 	core.ObjectDescriptor, core.ObjectDescriptor, core.CodeDescriptor, error,
 ) {
 	ctx, span := instracer.StartSpan(ctx, "LogicRunner.getDescriptorsByObjectRef")
 	defer span.End()
 
-	objDesc, err := lr.ArtifactManager.GetObject(ctx, objRef, nil, false)
-	if err != nil {
-		return nil, nil, nil, errors.Wrap(err, "couldn't get object")
+	objDesc, ok := es.takePrefetched(objRef)
+	if !ok {
+		var err error
+		objDesc, err = lr.ArtifactManager.GetObject(ctx, objRef, nil, false)
+		if err != nil {
+			return nil, nil, nil, errors.Wrap(err, "couldn't get object")
+		}
 	}
 
 	protoRef, err := objDesc.Prototype()
@@ -889,10 +1417,23 @@ func (lr *LogicRunner) getDescriptorsByObjectRef(
 		return nil, nil, nil, errors.Wrap(err, "couldn't get prototype reference")
 	}
 
+	if es.Current.LogicContext.Mode == "validation" {
+		protoDesc, codeDesc, err := lr.getValidationDescriptorsByPrototypeRef(ctx, *protoRef)
+		if err != nil {
+			return nil, nil, nil, errors.Wrap(err, "couldn't resolve prototype reference to descriptors")
+		}
+		return objDesc, protoDesc, codeDesc, nil
+	}
+
+	if cached, ok := lr.sessions.get(session, objRef); ok && cached.prototype.HeadRef().Equal(*protoRef) {
+		return objDesc, cached.prototype, cached.code, nil
+	}
+
 	protoDesc, codeDesc, err := lr.getDescriptorsByPrototypeRef(ctx, *protoRef)
 	if err != nil {
 		return nil, nil, nil, errors.Wrap(err, "couldn't resolve prototype reference to descriptors")
 	}
+	lr.sessions.set(session, objRef, sessionDescriptors{prototype: protoDesc, code: codeDesc})
 
 	return objDesc, protoDesc, codeDesc, nil
 }
@@ -907,7 +1448,17 @@ func (lr *LogicRunner) executeConstructorCall(
 		return nil, es.WrapError(nil, "Call constructor from nowhere")
 	}
 
-	protoDesc, codeDesc, err := lr.getDescriptorsByPrototypeRef(ctx, m.PrototypeRef)
+	var protoDesc core.ObjectDescriptor
+	var codeDesc core.CodeDescriptor
+	var err error
+	if current.LogicContext.Mode == "validation" {
+		protoDesc, codeDesc, err = lr.getValidationDescriptorsByPrototypeRef(ctx, m.PrototypeRef)
+	} else if prefetched, ok := es.takePrefetched(m.PrototypeRef); ok {
+		protoDesc = prefetched
+		codeDesc, err = lr.getCodeByPrototype(ctx, protoDesc)
+	} else {
+		protoDesc, codeDesc, err = lr.getDescriptorsByPrototypeRef(ctx, m.PrototypeRef)
+	}
 	if err != nil {
 		return nil, es.WrapError(err, "couldn't descriptors")
 	}
@@ -919,7 +1470,12 @@ func (lr *LogicRunner) executeConstructorCall(
 		return nil, es.WrapError(err, "no executer registered")
 	}
 
-	newData, err := executor.CallConstructor(ctx, current.LogicContext, *codeDesc.Ref(), m.Method, m.Arguments)
+	args, err := codec.Transcode(m.Arguments, m.GetCodec(), core.CodecCBOR)
+	if err != nil {
+		return nil, es.WrapError(err, "couldn't decode arguments")
+	}
+
+	newData, err := executor.CallConstructor(ctx, current.LogicContext, *codeDesc.Ref(), m.Method, args)
 	if err != nil {
 		return nil, es.WrapError(err, "executer error")
 	}
@@ -934,6 +1490,9 @@ func (lr *LogicRunner) executeConstructorCall(
 		if err != nil {
 			return nil, es.WrapError(err, "couldn't save results")
 		}
+		if current.LogicContext.Mode == "execution" {
+			lr.recordTimeline(ctx, core.TimelineResultRegistered, m.APIRequest, current.Request, nil)
+		}
 		return &reply.CallConstructor{Object: current.Request}, err
 
 	default:
@@ -942,6 +1501,19 @@ func (lr *LogicRunner) executeConstructorCall(
 }
 
 func (lr *LogicRunner) OnPulse(ctx context.Context, pulse core.Pulse) error {
+	lr.timings.SetPulse(pulse, lr.clock.Now())
+	lr.preloadIfRejoined(ctx, pulse)
+	lr.locks.reset()
+	lr.acls.reset()
+	lr.validations.reset()
+	if lr.caseBinds != nil {
+		lr.caseBinds.forget()
+	}
+	if lr.spool != nil {
+		lr.spool.onPulse(ctx)
+	}
+
+	lr.reach(ctx, raceOnPulseStates)
 	lr.stateMutex.Lock()
 
 	ctx, span := instracer.StartSpan(ctx, "pulse.logicrunner")
@@ -964,6 +1536,9 @@ func (lr *LogicRunner) OnPulse(ctx context.Context, pulse core.Pulse) error {
 
 			// if we are executor again we just continue working
 			// without sending data on next executor (because we are next executor)
+			deferred := es.deferred
+			es.deferred = false
+
 			if !meNext {
 				sendExecResults := false
 
@@ -993,11 +1568,18 @@ func (lr *LogicRunner) OnPulse(ctx context.Context, pulse core.Pulse) error {
 
 				queue, ledgerHasMoreRequest := es.releaseQueue()
 				if len(queue) > 0 || sendExecResults {
+					if lr.Cfg.ExecutorResultsDelta {
+						var fetched bool
+						queue, fetched = unfetchedQueue(queue)
+						ledgerHasMoreRequest = ledgerHasMoreRequest || fetched
+					}
+
 					// TODO: we also should send when executed something for validation
 					// TODO: now validation is disabled
 					caseBind := es.Behaviour.(*ValidationSaver).caseBind
 					requests := caseBind.getCaseBindForMessage(ctx)
 					messagesQueue := convertQueueToMessageQueue(queue)
+					finished, gaps := es.sequence().handoverState(executingSequence(es))
 
 					messages = append(
 						messages,
@@ -1012,6 +1594,10 @@ func (lr *LogicRunner) OnPulse(ctx context.Context, pulse core.Pulse) error {
 							Requests:              requests,
 							Queue:                 messagesQueue,
 							LedgerHasMoreRequests: es.LedgerHasMoreRequests || ledgerHasMoreRequest,
+							Sequence:              es.sequence().handoverPoint(executingSequence(es)),
+							SequenceFinished:      finished,
+							SequenceGaps:          gaps,
+							Speculation:           es.handoverSpeculation(queue),
 						},
 					)
 				}
@@ -1034,13 +1620,19 @@ func (lr *LogicRunner) OnPulse(ctx context.Context, pulse core.Pulse) error {
 					go lr.getLedgerPendingRequest(ctx, es)
 				}
 				es.PendingConfirmed = false
+
+				// queue was deferred till this pulse
+				if deferred && es.Current == nil && !es.QueueProcessorActive {
+					es.QueueProcessorActive = true
+					go lr.ProcessExecutionQueue(ctx, es)
+				}
 			}
 
 			es.Unlock()
 		}
 
-		if state.ExecutionState == nil && state.Validation == nil && state.Consensus == nil {
-			delete(lr.state, ref)
+		if state.ExecutionState == nil && state.Validation == nil && state.Speculation == nil && state.Consensus == nil {
+			lr.forgetObjectState(ref)
 		}
 
 		state.Unlock()
@@ -1067,6 +1659,7 @@ func (lr *LogicRunner) HandleStillExecutingMessage(
 	msg := parcel.Message().(*message.StillExecuting)
 	ref := msg.DefaultTarget()
 	os := lr.UpsertObjectState(*ref)
+	lr.reach(ctx, raceStillExecuting)
 
 	inslogger.FromContext(ctx).Debug("Got information that ", ref, " is still executing")
 
@@ -1152,15 +1745,33 @@ func (lr *LogicRunner) sendOnPulseMessage(ctx context.Context, msg core.Message,
 	_, err := lr.MessageBus.Send(ctx, msg, nil)
 	if err != nil {
 		inslogger.FromContext(ctx).Error(errors.Wrap(err, "error while sending validation data on pulse"))
+		if lr.spool != nil {
+			lr.spool.add(ctx, msg)
+		}
+	}
+}
+
+// unfetchedQueue returns queue elements which weren't fetched from ledger as pending requests, the rest
+// is fetched from ledger by the next executor again. Elements of incoming calls are kept, since callers wait
+// for results of them. Second return value is true if some elements were left out.
+func unfetchedQueue(queue []ExecutionQueueElement) ([]ExecutionQueueElement, bool) {
+	res := make([]ExecutionQueueElement, 0, len(queue))
+	for _, qe := range queue {
+		if !qe.fromLedger {
+			res = append(res, qe)
+		}
 	}
+	return res, len(res) < len(queue)
 }
 
 func convertQueueToMessageQueue(queue []ExecutionQueueElement) []message.ExecutionQueueElement {
 	mq := make([]message.ExecutionQueueElement, 0)
 	for _, elem := range queue {
 		mq = append(mq, message.ExecutionQueueElement{
-			Parcel:  elem.parcel,
-			Request: elem.request,
+			Parcel:   elem.parcel,
+			Request:  elem.request,
+			Sequence: elem.sequence,
+			Pulse:    elem.pulse,
 		})
 	}
 
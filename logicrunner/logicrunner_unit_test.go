@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -35,6 +36,10 @@ import (
 	"github.com/insolar/insolar/core/message"
 	"github.com/insolar/insolar/core/reply"
 	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/instrumentation/timeline"
+	"github.com/insolar/insolar/logicrunner/goplugin/rpctypes"
+	"github.com/insolar/insolar/netparams"
+	"github.com/insolar/insolar/network/clockskew"
 	"github.com/insolar/insolar/testutils"
 	"github.com/insolar/insolar/testutils/network"
 )
@@ -76,6 +81,9 @@ func (suite *LogicRunnerCommonTestSuite) SetupLogicRunner() {
 	suite.lr.JetCoordinator = suite.jc
 	suite.lr.PulseStorage = suite.ps
 	suite.lr.NodeNetwork = suite.nn
+	suite.lr.ClockSkewMonitor = clockskew.NewMonitor(configuration.NewClockSkew())
+	suite.lr.Timeline = timeline.NewJournal(configuration.NewTimeline())
+	suite.lr.NetworkParameters = netparams.New()
 }
 
 func (suite *LogicRunnerCommonTestSuite) AfterTest(suiteName, testName string) {
@@ -282,6 +290,7 @@ func (suite *LogicRunnerTestSuite) TestPrepareState() {
 
 	parcel := testutils.NewParcelMock(suite.mc)
 	parcel.ContextMock.Expect(context.Background()).Return(context.Background())
+	parcel.MessageMock.Return(&message.CallMethod{})
 	// brand new queue from message
 	msg.Queue = []message.ExecutionQueueElement{{Parcel: parcel}}
 	_ = suite.lr.prepareObjectState(suite.ctx, msg)
@@ -454,6 +463,64 @@ func TestReleaseQueue(t *testing.T) {
 	}
 }
 
+func TestEnqueue(t *testing.T) {
+	t.Parallel()
+
+	a, b, c := testutils.RandomRef(), testutils.RandomRef(), testutils.RandomRef()
+	callers := func(es *ExecutionState) []Ref {
+		res := make([]Ref, 0, len(es.Queue))
+		for _, qe := range es.Queue {
+			res = append(res, qe.caller)
+		}
+		return res
+	}
+
+	fair := &ExecutionState{}
+	unfair := &ExecutionState{}
+	for _, caller := range []Ref{a, a, a, b, b, c} {
+		fair.enqueue(ExecutionQueueElement{caller: caller}, true)
+		unfair.enqueue(ExecutionQueueElement{caller: caller}, false)
+	}
+
+	require.Equal(t, []Ref{a, b, c, a, b, a}, callers(fair))
+	require.Equal(t, []Ref{a, a, a, b, b, c}, callers(unfair))
+	require.Equal(t, 3, fair.callerQueueLength(a))
+	require.Equal(t, 0, fair.callerQueueLength(testutils.RandomRef()))
+}
+
+func TestEnqueue_Priority(t *testing.T) {
+	t.Parallel()
+
+	a, b, p := testutils.RandomRef(), testutils.RandomRef(), testutils.RandomRef()
+	for _, fair := range []bool{true, false} {
+		es := &ExecutionState{}
+		es.enqueue(ExecutionQueueElement{caller: a}, fair)
+		es.enqueue(ExecutionQueueElement{caller: b}, fair)
+		es.enqueue(ExecutionQueueElement{caller: p, priority: true}, fair)
+		es.enqueue(ExecutionQueueElement{caller: b}, fair)
+		es.enqueue(ExecutionQueueElement{caller: a, priority: true}, fair)
+
+		callers := make([]Ref, 0, len(es.Queue))
+		for _, qe := range es.Queue {
+			callers = append(callers, qe.caller)
+		}
+		require.Equal(t, []Ref{p, a, a, b, b}, callers, "fair: %v", fair)
+	}
+}
+
+func TestQueueCaller(t *testing.T) {
+	t.Parallel()
+
+	caller, member := testutils.RandomRef(), testutils.RandomRef()
+	require.Equal(t, caller, queueCaller(&message.CallMethod{
+		BaseLogicMessage: message.BaseLogicMessage{Caller: caller, APIRequest: &core.APIRequest{Member: member}},
+	}))
+	require.Equal(t, member, queueCaller(&message.CallMethod{
+		BaseLogicMessage: message.BaseLogicMessage{APIRequest: &core.APIRequest{Member: member}},
+	}))
+	require.True(t, queueCaller(&message.CallMethod{}).IsEmpty())
+}
+
 func (suite *LogicRunnerTestSuite) TestNoExcessiveAmends() {
 	suite.am.UpdateObjectMock.Return(nil, nil)
 
@@ -467,6 +534,8 @@ func (suite *LogicRunnerTestSuite) TestNoExcessiveAmends() {
 	es.Current.LogicContext = &core.LogicCallContext{}
 	es.Current.Request = &randRef
 	es.objectbody.CodeRef = &randRef
+	es.objectbody.Prototype = &randRef
+	suite.lr.acls.set(randRef, nil)
 
 	data := []byte(testutils.RandomString())
 	es.objectbody.Object = data
@@ -604,6 +673,212 @@ func (suite *LogicRunnerTestSuite) TestStartStop() {
 	suite.Require().NoError(err)
 }
 
+func (suite *LogicRunnerTestSuite) TestExecuteBusy() {
+	objectRef := testutils.RandomRef()
+	pulse := core.Pulse{}
+
+	suite.lr.Cfg.MaxQueueLength = 1
+	suite.lr.Cfg.BusyRetryAfter = 2
+	suite.lr.state[objectRef] = &ObjectState{
+		ExecutionState: &ExecutionState{
+			Queue: []ExecutionQueueElement{{}},
+		},
+	}
+
+	suite.jc.MeMock.Return(testutils.RandomRef())
+	suite.jc.IsAuthorizedMock.Return(true, nil)
+	suite.ps.CurrentMock.Return(&pulse, nil)
+
+	msg := &message.CallMethod{ObjectRef: objectRef, Method: "some"}
+	parcel := testutils.NewParcelMock(suite.T())
+	parcel.DefaultTargetMock.Return(&objectRef)
+	parcel.MessageMock.Return(msg)
+	parcel.PulseMock.Return(pulse.PulseNumber)
+
+	rep, err := suite.lr.Execute(suite.ctx, parcel)
+	suite.Require().NoError(err)
+	suite.Require().Equal(&reply.Busy{RetryAfter: 2}, rep)
+}
+
+func (suite *LogicRunnerTestSuite) TestExecuteBusyCaller() {
+	objectRef := testutils.RandomRef()
+	caller := testutils.RandomRef()
+	pulse := core.Pulse{}
+
+	suite.lr.Cfg.MaxCallerQueueLength = 1
+	suite.lr.Cfg.BusyRetryAfter = 2
+	suite.lr.state[objectRef] = &ObjectState{
+		ExecutionState: &ExecutionState{
+			Queue: []ExecutionQueueElement{{caller: caller}},
+		},
+	}
+
+	suite.jc.MeMock.Return(testutils.RandomRef())
+	suite.jc.IsAuthorizedMock.Return(true, nil)
+	suite.ps.CurrentMock.Return(&pulse, nil)
+
+	msg := &message.CallMethod{
+		BaseLogicMessage: message.BaseLogicMessage{Caller: caller},
+		ObjectRef:        objectRef,
+		Method:           "some",
+	}
+	parcel := testutils.NewParcelMock(suite.T())
+	parcel.DefaultTargetMock.Return(&objectRef)
+	parcel.MessageMock.Return(msg)
+	parcel.PulseMock.Return(pulse.PulseNumber)
+
+	rep, err := suite.lr.Execute(suite.ctx, parcel)
+	suite.Require().NoError(err)
+	suite.Require().Equal(&reply.Busy{RetryAfter: 2}, rep)
+}
+
+func (suite *LogicRunnerTestSuite) TestProcessExecutionQueueDeferred() {
+	suite.lr.Cfg.ExecutionDeadline = &configuration.ExecutionDeadline{Margin: time.Second}
+
+	parcel := testutils.NewParcelMock(suite.T())
+	parcel.MessageMock.Return(&message.CallMethod{Method: "some"})
+
+	es := &ExecutionState{
+		Queue:                []ExecutionQueueElement{{ctx: suite.ctx, parcel: parcel}},
+		QueueProcessorActive: true,
+	}
+	suite.lr.timings.Add(timingKey(es, parcel), 5*time.Second)
+	suite.lr.timings.SetPulse(core.Pulse{PulseNumber: 100, NextPulseNumber: 110}, time.Now().Add(-5*time.Second))
+	suite.lr.ProcessExecutionQueue(suite.ctx, es)
+
+	suite.Require().True(es.deferred)
+	suite.Require().False(es.QueueProcessorActive)
+	suite.Require().Nil(es.Current)
+	suite.Require().Len(es.Queue, 1)
+}
+
+func (suite *LogicRunnerTestSuite) TestProcessExecutionQueueDeactivated() {
+	objectRef := testutils.RandomRef()
+	request := testutils.RandomRef()
+	sender := testutils.RandomRef()
+	meRef := testutils.RandomRef()
+
+	parcel := testutils.NewParcelMock(suite.mc)
+	parcel.MessageMock.Return(&message.CallMethod{
+		BaseLogicMessage: message.BaseLogicMessage{Sequence: 7},
+		ObjectRef:        objectRef,
+		Method:           "some",
+	})
+	parcel.GetSenderMock.Return(sender)
+
+	nodeMock := network.NewNodeMock(suite.mc)
+	nodeMock.IDMock.Return(meRef)
+	suite.nn.GetOriginMock.Return(nodeMock)
+
+	suite.am.RegisterResultMock.Expect(suite.ctx, objectRef, request, reply.DeactivatedResult()).Return(nil, nil)
+	suite.mb.SendMock.Set(func(_ context.Context, msg core.Message, _ *core.MessageSendOptions) (core.Reply, error) {
+		suite.Equal(&message.ReturnResults{
+			Caller:   meRef,
+			Target:   sender,
+			Sequence: 7,
+			Reply:    &reply.Error{ErrType: reply.ErrDeactivated},
+			Error:    core.ErrDeactivated.Error(),
+		}, msg)
+		return &reply.OK{}, nil
+	})
+
+	es := &ExecutionState{
+		Ref:                  objectRef,
+		Behaviour:            &ValidationSaver{},
+		Queue:                []ExecutionQueueElement{{ctx: suite.ctx, parcel: parcel, request: &request}},
+		QueueProcessorActive: true,
+		deactivated:          true,
+	}
+	suite.lr.ProcessExecutionQueue(suite.ctx, es)
+
+	suite.Require().Empty(es.Queue)
+	suite.Require().False(es.QueueProcessorActive)
+	suite.mc.Wait(time.Second)
+}
+
+func (suite *LogicRunnerTestSuite) TestProcessExecutionQueuePanic() {
+	sender := testutils.RandomRef()
+	meRef := testutils.RandomRef()
+
+	parcel := testutils.NewParcelMock(suite.mc)
+	parcel.MessageMock.Return(&message.CallMethod{
+		BaseLogicMessage: message.BaseLogicMessage{Sequence: 7},
+		Method:           "some",
+	})
+	parcel.GetSenderMock.Return(sender)
+
+	nodeMock := network.NewNodeMock(suite.mc)
+	nodeMock.IDMock.Return(meRef)
+	suite.nn.GetOriginMock.Return(nodeMock)
+
+	suite.mb.SendMock.Set(func(_ context.Context, msg core.Message, _ *core.MessageSendOptions) (core.Reply, error) {
+		result, ok := msg.(*message.ReturnResults)
+		suite.Require().True(ok)
+		suite.Equal(sender, result.Target)
+		suite.Equal(uint64(7), result.Sequence)
+		suite.Contains(result.Error, "recovered from panic")
+		return &reply.OK{}, nil
+	})
+
+	request := testutils.RandomRef()
+	// execution panics since behaviour isn't set
+	es := &ExecutionState{
+		Ref: testutils.RandomRef(),
+		Queue: []ExecutionQueueElement{{
+			ctx:     suite.ctx,
+			parcel:  parcel,
+			request: &request,
+			pulse:   core.Pulse{PulseNumber: 100},
+		}},
+		QueueProcessorActive: true,
+		pending:              message.NotPending,
+	}
+	suite.lr.ProcessExecutionQueue(suite.ctx, es)
+
+	suite.Require().Empty(es.Queue)
+	suite.Require().False(es.QueueProcessorActive)
+	suite.Require().Nil(es.Current)
+	suite.mc.Wait(time.Second)
+}
+
+func (suite *LogicRunnerTestSuite) TestPrefetchQueue() {
+	objectRef := testutils.RandomRef()
+	protoRef := testutils.RandomRef()
+
+	call := testutils.NewParcelMock(suite.mc)
+	call.MessageMock.Return(&message.CallMethod{ObjectRef: objectRef})
+	constructor := testutils.NewParcelMock(suite.mc)
+	constructor.MessageMock.Return(&message.CallConstructor{PrototypeRef: protoRef})
+
+	objDesc := testutils.NewObjectDescriptorMock(suite.mc)
+	protoDesc := testutils.NewObjectDescriptorMock(suite.mc)
+	suite.am.GetObjectsMock.Set(func(
+		_ context.Context, heads []core.RecordRef, codes []core.RecordRef,
+	) (map[core.RecordRef]core.ObjectDescriptor, map[core.RecordRef]core.CodeDescriptor, error) {
+		suite.Require().Equal([]core.RecordRef{objectRef, protoRef}, heads)
+		suite.Require().Empty(codes)
+		return map[core.RecordRef]core.ObjectDescriptor{objectRef: objDesc, protoRef: protoDesc}, nil, nil
+	})
+
+	es := &ExecutionState{
+		Ref:   objectRef,
+		Queue: []ExecutionQueueElement{{parcel: call}, {parcel: constructor}, {parcel: constructor}},
+	}
+	suite.lr.prefetchQueue(suite.ctx, es)
+
+	desc, ok := es.takePrefetched(objectRef)
+	suite.Require().True(ok)
+	suite.Require().Equal(objDesc, desc)
+	_, ok = es.takePrefetched(objectRef)
+	suite.Require().False(ok, "object descriptor must be taken once")
+
+	for i := 0; i < 2; i++ {
+		desc, ok = es.takePrefetched(protoRef)
+		suite.Require().True(ok)
+		suite.Require().Equal(protoDesc, desc)
+	}
+}
+
 func (suite *LogicRunnerTestSuite) TestConcurrency() {
 	objectRef := testutils.RandomRef()
 	parentRef := testutils.RandomRef()
@@ -641,14 +916,20 @@ func (suite *LogicRunnerTestSuite) TestConcurrency() {
 
 	pd := testutils.NewObjectDescriptorMock(suite.T())
 	pd.CodeMock.Return(&codeRef, nil)
+	pd.MemoryMock.Return(nil)
 	pd.HeadRefMock.Return(&protoRef)
 
 	cd := testutils.NewCodeDescriptorMock(suite.T())
 	cd.MachineTypeMock.Return(core.MachineTypeBuiltin)
 	cd.RefMock.Return(&codeRef)
-	suite.am.GetCodeMock.Return(cd, nil)
-
-	suite.am.GetObjectFunc = func(
+	// descriptors are prefetched only if requests pile up in queue before processing starts, so artifact manager
+	// isn't bound to controller and GetObjects may be not called at all
+	am := testutils.NewArtifactManagerMock(suite.T())
+	suite.lr.ArtifactManager = am
+	am.GetObjectsMock.Return(nil, nil, errors.New("not prefetched"))
+	am.GetCodeMock.Return(cd, nil)
+
+	am.GetObjectFunc = func(
 		ctx context.Context, obj core.RecordRef, st *core.RecordID, approved bool,
 	) (core.ObjectDescriptor, error) {
 		switch obj {
@@ -660,14 +941,14 @@ func (suite *LogicRunnerTestSuite) TestConcurrency() {
 		return nil, errors.New("unexpected call")
 	}
 
-	suite.am.GetCodeMock.Return(cd, nil)
+	am.GetCodeMock.Return(cd, nil)
 
-	suite.am.HasPendingRequestsMock.Return(false, nil)
+	am.HasPendingRequestsMock.Return(false, nil)
 
 	reqId := testutils.RandomID()
-	suite.am.RegisterRequestMock.Return(&reqId, nil)
+	am.RegisterRequestMock.Return(&reqId, 0, nil)
 	resId := testutils.RandomID()
-	suite.am.RegisterResultMock.Return(&resId, nil)
+	am.RegisterResultMock.Return(&resId, nil)
 
 	num := 100
 	wg := sync.WaitGroup{}
@@ -712,6 +993,270 @@ func (suite *LogicRunnerTestSuite) TestConcurrency() {
 	wg.Wait()
 }
 
+func (suite *LogicRunnerTestSuite) TestDrain() {
+	suite.lr.Cfg.DrainTimeout = time.Second
+	suite.lr.Cfg.BusyRetryAfter = 2
+	objectRef := testutils.RandomRef()
+
+	es := &ExecutionState{
+		Ref:     objectRef,
+		Current: &CurrentExecution{},
+		Queue:   []ExecutionQueueElement{{}, {}},
+	}
+	suite.lr.state[objectRef] = &ObjectState{ExecutionState: es}
+	clock := newVirtualClock(time.Now())
+	suite.lr.setClock(clock)
+
+	done := make(chan struct{})
+	go func() {
+		suite.lr.drain(suite.ctx)
+		close(done)
+	}()
+
+	// drain polls running executions after it stops accepting requests
+	clock.BlockUntil(1)
+
+	parcel := testutils.NewParcelMock(suite.mc)
+	parcel.DefaultTargetMock.Return(&objectRef)
+	parcel.MessageMock.Return(&message.CallMethod{ObjectRef: objectRef})
+	rep, err := suite.lr.Execute(suite.ctx, parcel)
+	suite.Require().NoError(err)
+	suite.Require().Equal(&reply.Busy{RetryAfter: 2}, rep)
+
+	clock.Advance(drainPollInterval)
+	clock.BlockUntil(1)
+	select {
+	case <-done:
+		suite.Fail("drain must wait for current execution")
+	default:
+	}
+
+	es.Lock()
+	es.Current = nil
+	es.Unlock()
+	clock.Advance(drainPollInterval)
+	<-done
+	suite.Require().Empty(es.Queue)
+}
+
+func (suite *LogicRunnerTestSuite) TestDrain_Timeout() {
+	suite.lr.Cfg.DrainTimeout = 10 * time.Millisecond
+	objectRef := testutils.RandomRef()
+	es := &ExecutionState{Ref: objectRef, Current: &CurrentExecution{}}
+	suite.lr.state[objectRef] = &ObjectState{ExecutionState: es}
+	clock := newVirtualClock(time.Now())
+	suite.lr.setClock(clock)
+
+	done := make(chan struct{})
+	go func() {
+		suite.lr.drain(suite.ctx)
+		close(done)
+	}()
+
+	clock.BlockUntil(1)
+	clock.Advance(suite.lr.Cfg.DrainTimeout)
+	<-done
+	suite.Require().True(suite.lr.isStopping())
+	suite.Require().NotNil(es.Current)
+}
+
+func (suite *LogicRunnerTestSuite) TestExecute_PulseChangeAfterRegistration() {
+	objectRef := testutils.RandomRef()
+	requestID := testutils.RandomID()
+	first := core.Pulse{PulseNumber: core.FirstPulseNumber}
+	next := core.Pulse{PulseNumber: core.FirstPulseNumber + 10, PrevPulseNumber: core.FirstPulseNumber}
+
+	var lock sync.Mutex
+	current := first
+	suite.ps.CurrentFunc = func(ctx context.Context) (*core.Pulse, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		pulse := current
+		return &pulse, nil
+	}
+	suite.jc.MeMock.Return(testutils.RandomRef())
+	suite.jc.IsAuthorizedFunc = func(ctx context.Context, role core.DynamicRole, obj core.RecordID, pulse core.PulseNumber, node core.RecordRef) (bool, error) {
+		return pulse == first.PulseNumber, nil
+	}
+	suite.am.RegisterRequestMock.Return(&requestID, 1, nil)
+
+	parcel := testutils.NewParcelMock(suite.mc)
+	parcel.DefaultTargetMock.Return(&objectRef)
+	parcel.MessageMock.Return(&message.CallMethod{ObjectRef: objectRef, Method: "some"})
+	parcel.PulseMock.Return(first.PulseNumber)
+
+	races := newRaceScheduler(suite.lr)
+	registered := races.Hold(raceExecuteRegistered)
+
+	type result struct {
+		rep core.Reply
+		err error
+	}
+	done := make(chan result)
+	go func() {
+		rep, err := suite.lr.Execute(suite.ctx, parcel)
+		done <- result{rep: rep, err: err}
+	}()
+
+	// pulse changes while request is registered on ledger, the next executor is another node
+	registered.Wait()
+	es := suite.lr.MustObjectState(objectRef).ExecutionState
+	lock.Lock()
+	current = next
+	lock.Unlock()
+	suite.Require().NoError(suite.lr.OnPulse(suite.ctx, next))
+	registered.Release()
+
+	res := <-done
+	suite.Require().NoError(res.err)
+	expected := objectRef
+	expected.SetRecord(requestID)
+	suite.Require().Equal(&reply.RegisterRequest{Request: expected}, res.rep)
+
+	suite.Require().Empty(es.Queue, "request is left on ledger for the next executor")
+	suite.Require().False(es.QueueProcessorActive)
+	_, ok := suite.lr.state[objectRef]
+	suite.Require().False(ok)
+}
+
+func (suite *LogicRunnerTestSuite) TestHandleStillExecutingMessage_AfterPulse() {
+	objectRef := testutils.RandomRef()
+	suite.jc.MeMock.Return(testutils.RandomRef())
+	suite.jc.IsAuthorizedMock.Return(true, nil)
+
+	es := &ExecutionState{
+		Ref:              objectRef,
+		Behaviour:        &ValidationSaver{},
+		Queue:            make([]ExecutionQueueElement, 0),
+		pending:          message.InPending,
+		PendingConfirmed: true,
+	}
+	suite.lr.state[objectRef] = &ObjectState{ExecutionState: es}
+
+	parcel := testutils.NewParcelMock(suite.mc)
+	parcel.DefaultTargetMock.Return(&objectRef)
+	parcel.MessageMock.Return(&message.StillExecuting{Reference: objectRef})
+
+	races := newRaceScheduler(suite.lr)
+	received := races.Hold(raceStillExecuting)
+
+	done := make(chan error)
+	go func() {
+		_, err := suite.lr.HandleStillExecutingMessage(suite.ctx, parcel)
+		done <- err
+	}()
+
+	// confirmation of the previous pulse is reset, StillExecuting sent on pulse change comes after
+	received.Wait()
+	suite.Require().NoError(suite.lr.OnPulse(suite.ctx, core.Pulse{PulseNumber: core.FirstPulseNumber}))
+	suite.Require().False(es.PendingConfirmed)
+	received.Release()
+
+	suite.Require().NoError(<-done)
+	suite.Require().Equal(message.InPending, es.pending)
+	suite.Require().True(es.PendingConfirmed)
+}
+
+func (suite *LogicRunnerTestSuite) TestResetExecutionState() {
+	objectRef := testutils.RandomRef()
+	_, err := suite.lr.ResetExecutionState(suite.ctx, objectRef)
+	suite.Require().Error(err)
+
+	es := &ExecutionState{Ref: objectRef, Current: &CurrentExecution{}}
+	suite.lr.state[objectRef] = &ObjectState{ExecutionState: es}
+	_, err = suite.lr.ResetExecutionState(suite.ctx, objectRef)
+	suite.Require().Error(err, "state of executed object mustn't be reset")
+
+	es.Current = nil
+	es.pending = message.InPending
+	es.Queue = []ExecutionQueueElement{{}, {}}
+	suite.am.GetPendingRequestMock.Return(nil, 0, core.ErrNoPendingRequest)
+
+	summary, err := suite.lr.ResetExecutionState(suite.ctx, objectRef)
+	suite.Require().NoError(err)
+	suite.Require().Equal(&core.ExecutionStateSummary{InPending: true, QueueLength: 2}, summary)
+
+	suite.mc.Wait(time.Second)
+	es.Lock()
+	defer es.Unlock()
+	suite.Require().Equal(message.NotPending, es.pending)
+	suite.Require().Empty(es.Queue)
+	suite.Require().False(es.LedgerHasMoreRequests)
+}
+
+func (suite *LogicRunnerTestSuite) TestHandleLockMessage() {
+	owner, other := testutils.RandomRef(), testutils.RandomRef()
+	pulse := core.Pulse{PulseNumber: core.FirstPulseNumber + 1}
+	suite.lr.Cfg.MaxLockTTL = time.Minute
+
+	suite.jc.MeMock.Return(testutils.RandomRef())
+	suite.jc.IsAuthorizedMock.Return(true, nil)
+	suite.ps.CurrentMock.Return(&pulse, nil)
+
+	lock := func(msg *message.Lock, pn core.PulseNumber) (core.Reply, error) {
+		parcel := testutils.NewParcelMock(suite.T())
+		parcel.MessageMock.Return(msg)
+		parcel.PulseMock.Return(pn)
+		return suite.lr.HandleLockMessage(suite.ctx, parcel)
+	}
+
+	_, err := lock(&message.Lock{Name: "lock", Owner: owner, TTL: time.Second}, pulse.PulseNumber-1)
+	suite.Require().Error(err, "lock from other pulse")
+
+	rep, err := lock(&message.Lock{Name: "lock", Owner: owner, TTL: time.Hour}, pulse.PulseNumber)
+	suite.Require().NoError(err)
+	suite.Require().True(rep.(*reply.Lock).Acquired)
+	suite.Require().WithinDuration(time.Now().Add(time.Minute), rep.(*reply.Lock).Expires, time.Second,
+		"TTL is limited by config")
+
+	rep, err = lock(&message.Lock{Name: "lock", Owner: other, TTL: time.Second}, pulse.PulseNumber)
+	suite.Require().NoError(err)
+	suite.Require().False(rep.(*reply.Lock).Acquired)
+
+	_, err = lock(&message.Lock{Name: "lock", Owner: owner, Release: true}, pulse.PulseNumber)
+	suite.Require().NoError(err)
+
+	rep, err = lock(&message.Lock{Name: "lock", Owner: other, TTL: time.Second}, pulse.PulseNumber)
+	suite.Require().NoError(err)
+	suite.Require().True(rep.(*reply.Lock).Acquired)
+}
+
+func (suite *LogicRunnerTestSuite) TestCheckACL() {
+	protoRef := testutils.RandomRef()
+	parentRef := testutils.RandomRef()
+	memberProto := testutils.RandomRef()
+	memory, err := core.Serialize(core.PrototypeACL{
+		"Transfer": {Roles: []string{core.ACLRoleParent}},
+		"Accept":   {Prototypes: []core.RecordRef{memberProto}},
+	})
+	suite.Require().NoError(err)
+
+	pd := testutils.NewObjectDescriptorMock(suite.T())
+	pd.MemoryMock.Return(memory)
+	suite.am.GetObjectMock.Expect(suite.ctx, protoRef, nil, false).Return(pd, nil)
+
+	body := &ObjectBody{Prototype: &protoRef, Parent: &parentRef}
+	call := func(method string, caller, callerProto core.RecordRef) error {
+		msg := &message.CallMethod{Method: method}
+		msg.Caller = caller
+		msg.CallerPrototype = callerProto
+		return suite.lr.checkACL(suite.ctx, msg, body)
+	}
+
+	suite.NoError(call("Transfer", parentRef, testutils.RandomRef()))
+	suite.Error(call("Transfer", testutils.RandomRef(), testutils.RandomRef()))
+	suite.Error(call("Transfer", core.RecordRef{}, core.RecordRef{}), "API call")
+	suite.NoError(call("Accept", testutils.RandomRef(), memberProto))
+	suite.Error(call("Accept", parentRef, testutils.RandomRef()))
+	suite.NoError(call("GetBalance", core.RecordRef{}, core.RecordRef{}))
+
+	// ACL is read from ledger once per pulse
+	suite.Equal(uint64(1), suite.am.GetObjectCounter)
+	suite.lr.acls.reset()
+	suite.NoError(call("GetBalance", core.RecordRef{}, core.RecordRef{}))
+	suite.Equal(uint64(2), suite.am.GetObjectCounter)
+}
+
 func TestLogicRunner(t *testing.T) {
 	t.Parallel()
 	suite.Run(t, new(LogicRunnerTestSuite))
@@ -883,7 +1428,7 @@ func (s *LogicRunnerOnPulseTestSuite) TestStateTransfer2() {
 	s.jc.MeMock.Return(core.RecordRef{})
 	s.jc.IsAuthorizedMock.Return(true, nil)
 
-	s.am.GetPendingRequestMock.Return(nil, core.ErrNoPendingRequest)
+	s.am.GetPendingRequestMock.Return(nil, 0, core.ErrNoPendingRequest)
 
 	s.lr.state[s.objectRef] = &ObjectState{
 		ExecutionState: &ExecutionState{
@@ -1004,6 +1549,72 @@ func (s *LogicRunnerOnPulseTestSuite) TestLedgerHasMoreRequests() {
 	}
 }
 
+func (s *LogicRunnerOnPulseTestSuite) TestExecutorResultsDelta() {
+	s.jc.IsAuthorizedMock.Return(false, nil)
+	s.jc.MeMock.Return(core.RecordRef{})
+
+	request, fetchedRequest := testutils.RandomRef(), testutils.RandomRef()
+	incoming := ExecutionQueueElement{request: &request}
+	fetched := ExecutionQueueElement{request: &fetchedRequest, fromLedger: true}
+
+	expectedMessage := &message.ExecutorResults{
+		RecordRef:             s.objectRef,
+		Requests:              make([]message.CaseBindRequest, 0),
+		Queue:                 convertQueueToMessageQueue([]ExecutionQueueElement{incoming}),
+		LedgerHasMoreRequests: true,
+	}
+
+	mb := testutils.NewMessageBusMock(s.mc)
+	mb.SendMock.Set(func(p context.Context, p1 core.Message, p2 *core.MessageSendOptions) (r core.Reply, r1 error) {
+		s.Equal(expectedMessage, p1)
+		return nil, nil
+	})
+
+	s.lr.Cfg.ExecutorResultsDelta = true
+	s.lr.MessageBus = mb
+	s.lr.state[s.objectRef] = &ObjectState{
+		ExecutionState: &ExecutionState{
+			Behaviour: &ValidationSaver{},
+			Queue:     []ExecutionQueueElement{incoming, fetched},
+		},
+	}
+
+	err := s.lr.OnPulse(s.ctx, s.pulse)
+	s.Require().NoError(err)
+}
+
+func (s *LogicRunnerOnPulseTestSuite) TestExecutorResultsDelta_PreservesQueue() {
+	s.jc.IsAuthorizedMock.Return(false, nil)
+	s.jc.MeMock.Return(core.RecordRef{})
+
+	first, second := testutils.RandomRef(), testutils.RandomRef()
+	queue := []ExecutionQueueElement{{request: &first, sequence: 1}, {request: &second, sequence: 2}}
+
+	expectedMessage := &message.ExecutorResults{
+		RecordRef: s.objectRef,
+		Requests:  make([]message.CaseBindRequest, 0),
+		Queue:     convertQueueToMessageQueue(queue),
+	}
+
+	mb := testutils.NewMessageBusMock(s.mc)
+	mb.SendMock.Set(func(p context.Context, p1 core.Message, p2 *core.MessageSendOptions) (r core.Reply, r1 error) {
+		s.Equal(expectedMessage, p1)
+		return nil, nil
+	})
+
+	s.lr.Cfg.ExecutorResultsDelta = true
+	s.lr.MessageBus = mb
+	s.lr.state[s.objectRef] = &ObjectState{
+		ExecutionState: &ExecutionState{
+			Behaviour: &ValidationSaver{},
+			Queue:     queue,
+		},
+	}
+
+	err := s.lr.OnPulse(s.ctx, s.pulse)
+	s.Require().NoError(err)
+}
+
 func TestLogicRunnerOnPulse(t *testing.T) {
 	suite.Run(t, new(LogicRunnerOnPulseTestSuite))
 }
@@ -1063,7 +1674,7 @@ func (s *LRUnsafeGetLedgerPendingRequestTestSuite) TestNoMoreRequestsInLedger()
 	es := &ExecutionState{Ref: s.ref, Behaviour: &ValidationSaver{}, LedgerHasMoreRequests: true}
 
 	am := testutils.NewArtifactManagerMock(s.mc)
-	am.GetPendingRequestMock.Return(nil, core.ErrNoPendingRequest)
+	am.GetPendingRequestMock.Return(nil, 0, core.ErrNoPendingRequest)
 	s.lr.ArtifactManager = am
 	s.lr.unsafeGetLedgerPendingRequest(s.ctx, es)
 	s.Equal(false, es.LedgerHasMoreRequests)
@@ -1076,7 +1687,7 @@ func (s *LRUnsafeGetLedgerPendingRequestTestSuite) TestDoesNotAuthorized() {
 		PulseNumber: s.oldRequestPulseNumber,
 		Msg:         &message.CallMethod{},
 	}
-	s.am.GetPendingRequestMock.Return(parcel, nil)
+	s.am.GetPendingRequestMock.Return(parcel, 0, nil)
 
 	// we doesn't authorized (pulse change in time we process function)
 	s.ps.CurrentMock.Return(&core.Pulse{PulseNumber: s.currentPulseNumber}, nil)
@@ -1094,7 +1705,7 @@ func (s LRUnsafeGetLedgerPendingRequestTestSuite) TestUnsafeGetLedgerPendingRequ
 		PulseNumber: s.oldRequestPulseNumber,
 		Msg:         &message.CallMethod{}, // todo add ref
 	}
-	s.am.GetPendingRequestMock.Return(parcel, nil)
+	s.am.GetPendingRequestMock.Return(parcel, 0, nil)
 
 	s.ps.CurrentMock.Return(&core.Pulse{PulseNumber: s.currentPulseNumber}, nil)
 	s.jc.IsAuthorizedMock.Return(true, nil)
@@ -1104,3 +1715,179 @@ func (s LRUnsafeGetLedgerPendingRequestTestSuite) TestUnsafeGetLedgerPendingRequ
 	s.Require().Equal(true, es.LedgerHasMoreRequests)
 	s.Require().Equal(parcel, es.LedgerQueueElement.parcel)
 }
+
+func (s *LRUnsafeGetLedgerPendingRequestTestSuite) TestOutOfOrder() {
+	es := &ExecutionState{Ref: s.ref, Behaviour: &ValidationSaver{}, LedgerHasMoreRequests: true}
+	es.sequence().handover(5, nil, nil)
+
+	parcel := &message.Parcel{
+		PulseNumber: s.oldRequestPulseNumber,
+		Msg:         &message.CallMethod{},
+	}
+	fetched := 0
+	s.am.GetPendingRequestFunc = func(ctx context.Context, id core.RecordID) (core.Parcel, uint64, error) {
+		fetched++
+		if fetched == 1 {
+			return parcel, 3, nil
+		}
+		return nil, 0, core.ErrNoPendingRequest
+	}
+	registered := make(chan []byte, 1)
+	s.am.RegisterResultFunc = func(_ context.Context, obj, req core.RecordRef, payload []byte) (*core.RecordID, error) {
+		registered <- payload
+		return nil, nil
+	}
+
+	s.ps.CurrentMock.Return(&core.Pulse{PulseNumber: s.currentPulseNumber}, nil)
+	s.jc.IsAuthorizedMock.Return(true, nil)
+	s.jc.MeMock.Return(core.RecordRef{})
+	s.lr.unsafeGetLedgerPendingRequest(s.ctx, es)
+
+	s.Require().Nil(es.LedgerQueueElement)
+	select {
+	case payload := <-registered:
+		s.Equal(reply.OutOfOrderResult(), payload, "out of order request is closed on ledger")
+	case <-time.After(time.Second):
+		s.Fail("result of out of order request isn't registered")
+	}
+}
+
+func TestMakeBaseMessagePassesAPIRequest(t *testing.T) {
+	apiRequest := &core.APIRequest{
+		Member:  testutils.RandomRef(),
+		QID:     "qid",
+		APINode: testutils.RandomRef(),
+		TraceID: "traceid",
+	}
+	es := &ExecutionState{
+		Current: &CurrentExecution{
+			LogicContext: &core.LogicCallContext{APIRequest: apiRequest},
+		},
+	}
+
+	req := rpctypes.UpBaseReq{Callee: testutils.RandomRef()}
+	bm := MakeBaseMessage(req, es)
+	require.Equal(t, apiRequest, bm.APIRequest)
+	require.Equal(t, req.Callee, bm.Caller)
+	require.Equal(t, uint64(1), bm.Nonce)
+
+	es.Current.LogicContext = &core.LogicCallContext{}
+	bm = MakeBaseMessage(req, es)
+	require.Nil(t, bm.APIRequest)
+}
+
+func TestValidationSampling(t *testing.T) {
+	cfg := configuration.NewLogicRunner()
+	lr := &LogicRunner{Cfg: &cfg}
+	saver := &ValidationSaver{lr: lr, caseBind: NewCaseBind()}
+
+	saver.NewRequest(&message.Parcel{}, testutils.RandomRef(), core.Pulse{}, nil)
+	require.NoError(t, saver.Result(&reply.OK{}, nil))
+
+	cfg.ValidationSampleRate = 0
+	saver.NewRequest(&message.Parcel{}, testutils.RandomRef(), core.Pulse{}, nil)
+	require.NoError(t, saver.Result(&reply.OK{}, nil))
+
+	saver.NewRequest(&message.Parcel{}, testutils.RandomRef(), core.Pulse{}, nil)
+	saver.RequireValidation()
+	require.NoError(t, saver.Result(&reply.OK{}, nil))
+
+	requests := saver.caseBind.Requests
+	require.True(t, requests[0].Validate)
+	require.False(t, requests[1].Validate)
+	require.True(t, requests[2].Validate)
+
+	replay := NewCaseBindReplay(*saver.caseBind)
+	require.Equal(t, requests[0].Request, replay.NextRequest().Request)
+	require.Equal(t, requests[2].Request, replay.NextRequest().Request)
+	require.Nil(t, replay.NextRequest())
+}
+
+type preloadingExecutor struct {
+	*testutils.MachineLogicExecutorMock
+	preloaded chan core.RecordRef
+}
+
+func (e *preloadingExecutor) PreloadCode(ctx context.Context, code core.RecordRef, data []byte) error {
+	e.preloaded <- code
+	return nil
+}
+
+func TestPreloadIfRejoined(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	obj, proto, code := testutils.RandomRef(), testutils.RandomRef(), testutils.RandomRef()
+
+	node := network.NewNodeMock(mc)
+	node.RoleMock.Return(core.StaticRoleVirtual)
+	nn := network.NewNodeNetworkMock(mc)
+	nn.GetOriginMock.Return(node)
+
+	objDesc := testutils.NewObjectDescriptorMock(mc)
+	objDesc.IsPrototypeMock.Return(false)
+	objDesc.PrototypeMock.Return(&proto, nil)
+	protoDesc := testutils.NewObjectDescriptorMock(mc)
+	protoDesc.CodeMock.Return(&code, nil)
+	codeDesc := testutils.NewCodeDescriptorMock(mc)
+	codeDesc.MachineTypeMock.Return(core.MachineTypeGoPlugin)
+	codeDesc.CodeMock.Return([]byte{1, 2, 3}, nil)
+
+	am := testutils.NewArtifactManagerMock(mc)
+	am.GetObjectFunc = func(ctx context.Context, ref core.RecordRef, state *core.RecordID, approved bool) (core.ObjectDescriptor, error) {
+		if ref.Equal(obj) {
+			return objDesc, nil
+		}
+		return protoDesc, nil
+	}
+	am.GetCodeMock.Return(codeDesc, nil)
+
+	cfg := configuration.NewLogicRunner()
+	cfg.Preload = map[string][]string{"virtual": {obj.String()}}
+	lr, err := NewLogicRunner(&cfg)
+	require.NoError(t, err)
+	lr.NodeNetwork = nn
+	lr.ArtifactManager = am
+	executor := &preloadingExecutor{
+		MachineLogicExecutorMock: testutils.NewMachineLogicExecutorMock(mc),
+		preloaded:                make(chan core.RecordRef, 1),
+	}
+	require.NoError(t, lr.RegisterExecutor(core.MachineTypeGoPlugin, executor))
+
+	waitPreloaded := func() {
+		require.Equal(t, code, <-executor.preloaded)
+		for atomic.LoadInt32(&lr.preloading) != 0 {
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	lr.preloadIfRejoined(ctx, core.Pulse{PulseNumber: 100, PrevPulseNumber: 90})
+	waitPreloaded()
+
+	lr.preloadIfRejoined(ctx, core.Pulse{PulseNumber: 110, PrevPulseNumber: 100})
+	require.Equal(t, int32(0), atomic.LoadInt32(&lr.preloading), "caches are warm on regular pulse")
+
+	lr.preloadIfRejoined(ctx, core.Pulse{PulseNumber: 150, PrevPulseNumber: 140})
+	waitPreloaded()
+}
+
+func TestStalledExecutionStates(t *testing.T) {
+	lr, err := NewLogicRunner(&configuration.LogicRunner{})
+	require.NoError(t, err)
+
+	stalled := &ExecutionState{Ref: testutils.RandomRef(), LedgerHasMoreRequests: true}
+	lr.state[stalled.Ref] = &ObjectState{ExecutionState: stalled}
+	lr.state[testutils.RandomRef()] = &ObjectState{ExecutionState: &ExecutionState{
+		LedgerHasMoreRequests: true,
+		QueueProcessorActive:  true,
+	}}
+	lr.state[testutils.RandomRef()] = &ObjectState{ExecutionState: &ExecutionState{
+		LedgerHasMoreRequests: true,
+		Current:               &CurrentExecution{},
+	}}
+	lr.state[testutils.RandomRef()] = &ObjectState{ExecutionState: &ExecutionState{}}
+	lr.state[testutils.RandomRef()] = &ObjectState{}
+
+	require.Equal(t, []*ExecutionState{stalled}, lr.stalledExecutionStates())
+}
@@ -0,0 +1,167 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logicrunner
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// methodLatencyBounds are upper bounds of execution duration histogram buckets,
+// they match buckets of statMethodDuration view.
+var methodLatencyBounds = []time.Duration{
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+type methodLatencyKey struct {
+	prototype string
+	method    string
+}
+
+type methodLatencyHistogram struct {
+	count   uint64
+	sum     time.Duration
+	buckets []uint64
+}
+
+// methodLatencies collects histograms of execution duration per prototype and method.
+// Number of distinct histograms is limited, executions beyond the limit are collected under "other" label.
+type methodLatencies struct {
+	lock       sync.Mutex
+	limit      int
+	histograms map[methodLatencyKey]*methodLatencyHistogram
+}
+
+func newMethodLatencies(limit int) *methodLatencies {
+	return &methodLatencies{
+		limit:      limit,
+		histograms: make(map[methodLatencyKey]*methodLatencyHistogram),
+	}
+}
+
+// Add takes into account duration of method execution and records it to metrics.
+func (l *methodLatencies) Add(ctx context.Context, prototype, method string, d time.Duration) {
+	key := l.add(methodLatencyKey{prototype: prototype, method: method}, d)
+
+	err := stats.RecordWithTags(
+		ctx,
+		[]tag.Mutator{tag.Upsert(tagPrototype, key.prototype), tag.Upsert(tagMethod, key.method)},
+		statMethodDuration.M(float64(d)/float64(time.Millisecond)),
+	)
+	if err != nil {
+		inslogger.FromContext(ctx).Warn("failed to record method duration: ", err)
+	}
+}
+
+// add counts duration in histogram of key and returns key it was counted under.
+func (l *methodLatencies) add(key methodLatencyKey, d time.Duration) methodLatencyKey {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	h, ok := l.histograms[key]
+	if !ok {
+		// one slot is reserved for "other"
+		if len(l.histograms) >= l.limit-1 {
+			key = methodLatencyKey{prototype: core.MethodLatencyOther, method: core.MethodLatencyOther}
+			h, ok = l.histograms[key]
+		}
+		if !ok {
+			h = &methodLatencyHistogram{buckets: make([]uint64, len(methodLatencyBounds)+1)}
+			l.histograms[key] = h
+		}
+	}
+
+	h.count++
+	h.sum += d
+	h.buckets[sort.Search(len(methodLatencyBounds), func(i int) bool { return d <= methodLatencyBounds[i] })]++
+	return key
+}
+
+// Snapshot returns copies of collected histograms sorted by prototype and method.
+func (l *methodLatencies) Snapshot() []core.MethodLatency {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	res := make([]core.MethodLatency, 0, len(l.histograms))
+	for key, h := range l.histograms {
+		buckets := make([]core.LatencyBucket, len(h.buckets))
+		for i, count := range h.buckets {
+			buckets[i].Count = count
+			if i < len(methodLatencyBounds) {
+				buckets[i].UpperBound = methodLatencyBounds[i]
+			}
+		}
+		res = append(res, core.MethodLatency{
+			Prototype: key.prototype,
+			Method:    key.method,
+			Count:     h.count,
+			Sum:       h.sum,
+			Buckets:   buckets,
+		})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Prototype != res[j].Prototype {
+			return res[i].Prototype < res[j].Prototype
+		}
+		return res[i].Method < res[j].Method
+	})
+	return res
+}
+
+// MethodLatencies returns histograms of contract method execution durations, nil if collection is disabled.
+func (lr *LogicRunner) MethodLatencies() []core.MethodLatency {
+	if lr.latencies == nil {
+		return nil
+	}
+	return lr.latencies.Snapshot()
+}
+
+// latencyPrototype returns prototype executed parcel is labeled with in latency histograms.
+func latencyPrototype(es *ExecutionState, parcel core.Parcel) string {
+	switch msg := parcel.Message().(type) {
+	case *message.CallConstructor:
+		return msg.PrototypeRef.String()
+	case *message.CallMethod:
+		if es.objectbody != nil && es.objectbody.Prototype != nil {
+			return es.objectbody.Prototype.String()
+		}
+		if !msg.ProxyPrototype.IsEmpty() {
+			return msg.ProxyPrototype.String()
+		}
+	}
+	return core.MethodLatencyOther
+}
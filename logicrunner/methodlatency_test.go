@@ -0,0 +1,83 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logicrunner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestMethodLatencies(t *testing.T) {
+	ctx := context.Background()
+	latencies := newMethodLatencies(3)
+
+	latencies.Add(ctx, "proto", "Transfer", 3*time.Millisecond)
+	latencies.Add(ctx, "proto", "Transfer", 5*time.Millisecond)
+	latencies.Add(ctx, "proto", "Transfer", time.Minute)
+	latencies.Add(ctx, "proto", "GetBalance", time.Millisecond)
+	// beyond limit
+	latencies.Add(ctx, "proto", "Burn", 20*time.Millisecond)
+	latencies.Add(ctx, "other proto", "Mint", 30*time.Millisecond)
+	// known methods are still collected separately
+	latencies.Add(ctx, "proto", "GetBalance", time.Millisecond)
+
+	snapshot := latencies.Snapshot()
+	require.Len(t, snapshot, 3)
+
+	require.Equal(t, core.MethodLatencyOther, snapshot[0].Prototype)
+	require.Equal(t, core.MethodLatencyOther, snapshot[0].Method)
+	require.Equal(t, uint64(2), snapshot[0].Count)
+	require.Equal(t, 50*time.Millisecond, snapshot[0].Sum)
+	require.Equal(t, uint64(1), snapshot[0].Buckets[3].Count)
+	require.Equal(t, uint64(1), snapshot[0].Buckets[4].Count)
+
+	require.Equal(t, "GetBalance", snapshot[1].Method)
+	require.Equal(t, uint64(2), snapshot[1].Count)
+	require.Equal(t, uint64(2), snapshot[1].Buckets[0].Count)
+
+	transfer := snapshot[2]
+	require.Equal(t, "proto", transfer.Prototype)
+	require.Equal(t, "Transfer", transfer.Method)
+	require.Equal(t, uint64(3), transfer.Count)
+	require.Len(t, transfer.Buckets, len(methodLatencyBounds)+1)
+	require.Equal(t, 5*time.Millisecond, transfer.Buckets[1].UpperBound)
+	require.Equal(t, uint64(2), transfer.Buckets[1].Count)
+	// unbounded bucket
+	last := transfer.Buckets[len(transfer.Buckets)-1]
+	require.Equal(t, time.Duration(0), last.UpperBound)
+	require.Equal(t, uint64(1), last.Count)
+}
+
+func TestLatencyPrototype(t *testing.T) {
+	proto := testutils.RandomRef()
+	proxy := testutils.RandomRef()
+	es := &ExecutionState{}
+
+	require.Equal(t, proto.String(), latencyPrototype(es, &message.Parcel{Msg: &message.CallConstructor{PrototypeRef: proto}}))
+	require.Equal(t, core.MethodLatencyOther, latencyPrototype(es, &message.Parcel{Msg: &message.CallMethod{}}))
+	require.Equal(t, proxy.String(), latencyPrototype(es, &message.Parcel{Msg: &message.CallMethod{ProxyPrototype: proxy}}))
+
+	es.objectbody = &ObjectBody{Prototype: &proto}
+	require.Equal(t, proto.String(), latencyPrototype(es, &message.Parcel{Msg: &message.CallMethod{ProxyPrototype: proxy}}))
+}
@@ -0,0 +1,204 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logicrunner
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"github.com/insolar/insolar/instrumentation/insmetrics"
+)
+
+var (
+	tagPrototype = insmetrics.MustTagKey("prototype")
+	tagMethod    = insmetrics.MustTagKey("method")
+)
+
+var (
+	statQueueCallerShare = stats.Float64(
+		"vm/execution/queue/caller/share",
+		"share of object execution queue taken by caller of enqueued request",
+		stats.UnitDimensionless,
+	)
+	statQueueCallerRejected = stats.Int64(
+		"vm/execution/queue/caller/rejected/count",
+		"number of requests rejected because caller exceeded its share of execution queue",
+		stats.UnitDimensionless,
+	)
+	statPulseSpoolDepth = stats.Int64(
+		"vm/pulse/spool/depth",
+		"number of messages sent on pulse change waiting for retry",
+		stats.UnitDimensionless,
+	)
+	statPulseSpoolFailed = stats.Int64(
+		"vm/pulse/spool/failed/count",
+		"number of messages sent on pulse change dropped after running out of retries",
+		stats.UnitDimensionless,
+	)
+	statValidationSampled = stats.Int64(
+		"vm/validation/sampled/count",
+		"number of executions offered to validators",
+		stats.UnitDimensionless,
+	)
+	statValidationSkipped = stats.Int64(
+		"vm/validation/skipped/count",
+		"number of executions left without validation by sampling",
+		stats.UnitDimensionless,
+	)
+	statValidationRequired = stats.Int64(
+		"vm/validation/required/count",
+		"number of executions marked by contract as high-value which sampling would skip",
+		stats.UnitDimensionless,
+	)
+	statRequestsOutOfOrder = stats.Int64(
+		"vm/request/sequence/outoforder/count",
+		"number of requests rejected because object already executed requests with greater sequence",
+		stats.UnitDimensionless,
+	)
+	statRequestsMissing = stats.Int64(
+		"vm/request/sequence/missing/count",
+		"number of registered requests never reached executor after handover",
+		stats.UnitDimensionless,
+	)
+	statRequestsDeactivated = stats.Int64(
+		"vm/request/deactivated/count",
+		"number of queued requests finished without execution because object was deactivated",
+		stats.UnitDimensionless,
+	)
+	statObjectStatesEvicted = stats.Int64(
+		"vm/state/evicted/count",
+		"number of idle objects states evicted because number of states exceeded configured cap",
+		stats.UnitDimensionless,
+	)
+	statSpeculationCommitted = stats.Int64(
+		"vm/execution/speculation/committed/count",
+		"number of speculative results committed without execution",
+		stats.UnitDimensionless,
+	)
+	statSpeculationDiscarded = stats.Int64(
+		"vm/execution/speculation/discarded/count",
+		"number of speculative results discarded because object state changed before commit",
+		stats.UnitDimensionless,
+	)
+	statTimersScheduled = stats.Int64(
+		"vm/timer/scheduled/count",
+		"number of calls scheduled by contracts on themselves for future pulses",
+		stats.UnitDimensionless,
+	)
+	statTimersFired = stats.Int64(
+		"vm/timer/fired/count",
+		"number of scheduled calls registered by executor of due pulse",
+		stats.UnitDimensionless,
+	)
+	statResultsCompressed = stats.Int64(
+		"vm/result/compressed/count",
+		"number of method results compressed before returning them to requester",
+		stats.UnitDimensionless,
+	)
+	statResultsCompressionRatio = stats.Float64(
+		"vm/result/compression/ratio",
+		"ratio of compressed method result size to original size",
+		stats.UnitDimensionless,
+	)
+	statMethodDuration = stats.Float64(
+		"vm/execution/method/duration",
+		"duration of contract method execution by prototype and method",
+		stats.UnitMilliseconds,
+	)
+)
+
+func init() {
+	err := insmetrics.Register(
+		&view.View{
+			Measure:     statQueueCallerShare,
+			Aggregation: view.Distribution(0.1, 0.25, 0.5, 0.75, 0.9, 1),
+		},
+		&view.View{
+			Measure:     statQueueCallerRejected,
+			Aggregation: view.Count(),
+		},
+		&view.View{
+			Measure:     statPulseSpoolDepth,
+			Aggregation: view.LastValue(),
+		},
+		&view.View{
+			Measure:     statPulseSpoolFailed,
+			Aggregation: view.Sum(),
+		},
+		&view.View{
+			Measure:     statValidationSampled,
+			Aggregation: view.Count(),
+		},
+		&view.View{
+			Measure:     statValidationSkipped,
+			Aggregation: view.Count(),
+		},
+		&view.View{
+			Measure:     statValidationRequired,
+			Aggregation: view.Count(),
+		},
+		&view.View{
+			Measure:     statRequestsOutOfOrder,
+			Aggregation: view.Count(),
+		},
+		&view.View{
+			Measure:     statRequestsMissing,
+			Aggregation: view.Sum(),
+		},
+		&view.View{
+			Measure:     statRequestsDeactivated,
+			Aggregation: view.Count(),
+		},
+		&view.View{
+			Measure:     statObjectStatesEvicted,
+			Aggregation: view.Sum(),
+		},
+		&view.View{
+			Measure:     statSpeculationCommitted,
+			Aggregation: view.Count(),
+		},
+		&view.View{
+			Measure:     statSpeculationDiscarded,
+			Aggregation: view.Count(),
+		},
+		&view.View{
+			Measure:     statTimersScheduled,
+			Aggregation: view.Count(),
+		},
+		&view.View{
+			Measure:     statTimersFired,
+			Aggregation: view.Count(),
+		},
+		&view.View{
+			Measure:     statResultsCompressed,
+			Aggregation: view.Count(),
+		},
+		&view.View{
+			Measure:     statResultsCompressionRatio,
+			Aggregation: view.Distribution(0.05, 0.1, 0.25, 0.5, 0.75, 1),
+		},
+		&view.View{
+			Measure:     statMethodDuration,
+			Aggregation: view.Distribution(1, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000),
+			TagKeys:     []tag.Key{tagPrototype, tagMethod},
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+}
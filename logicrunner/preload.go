@@ -0,0 +1,118 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logicrunner
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// codePreloader is implemented by executors which can load code before the first call of it.
+type codePreloader interface {
+	PreloadCode(ctx context.Context, code core.RecordRef, data []byte) error
+}
+
+// preloadIfRejoined starts preloading of objects configured for node role on the first pulse after start
+// and after node missed pulses, i.e. it was out of network. Caches are cold in both cases.
+func (lr *LogicRunner) preloadIfRejoined(ctx context.Context, pulse core.Pulse) {
+	last := core.PulseNumber(atomic.SwapUint32((*uint32)(&lr.lastPulse), uint32(pulse.PulseNumber)))
+	if last != 0 && pulse.PrevPulseNumber == last {
+		return
+	}
+	if lr.Cfg == nil || len(lr.Cfg.Preload) == 0 || lr.NodeNetwork == nil {
+		return
+	}
+	origin := lr.NodeNetwork.GetOrigin()
+	if origin == nil {
+		return
+	}
+	refs := lr.Cfg.Preload[origin.Role().String()]
+	if len(refs) == 0 {
+		return
+	}
+	if !atomic.CompareAndSwapInt32(&lr.preloading, 0, 1) {
+		return
+	}
+
+	go func() {
+		defer atomic.StoreInt32(&lr.preloading, 0)
+		lr.preload(ctx, refs)
+	}()
+}
+
+// preload fetches objects or prototypes with their code into caches.
+func (lr *LogicRunner) preload(ctx context.Context, refs []string) {
+	logger := inslogger.FromContext(ctx)
+	failed := 0
+	for _, s := range refs {
+		ref, err := core.NewRefFromBase58(s)
+		if err == nil {
+			err = lr.preloadObject(ctx, *ref)
+		}
+		if err != nil {
+			failed++
+			logger.Warnf("[ preload ] failed to preload %s: %s", s, err)
+		}
+	}
+	logger.Infof("[ preload ] preloaded %d objects, %d failed", len(refs)-failed, failed)
+}
+
+func (lr *LogicRunner) preloadObject(ctx context.Context, ref core.RecordRef) error {
+	desc, err := lr.ArtifactManager.GetObject(ctx, ref, nil, false)
+	if err != nil {
+		return errors.Wrap(err, "couldn't get object")
+	}
+	if !desc.IsPrototype() {
+		protoRef, err := desc.Prototype()
+		if err != nil {
+			return errors.Wrap(err, "couldn't get prototype reference")
+		}
+		desc, err = lr.ArtifactManager.GetObject(ctx, *protoRef, nil, false)
+		if err != nil {
+			return errors.Wrap(err, "couldn't get prototype")
+		}
+	}
+
+	codeRef, err := desc.Code()
+	if err != nil {
+		return errors.Wrap(err, "couldn't get code reference")
+	}
+	// code descriptors are cached by artifact manager
+	codeDesc, err := lr.ArtifactManager.GetCode(ctx, *codeRef)
+	if err != nil {
+		return errors.Wrap(err, "couldn't get code")
+	}
+
+	executor, err := lr.GetExecutor(codeDesc.MachineType())
+	if err != nil {
+		return err
+	}
+	preloader, ok := executor.(codePreloader)
+	if !ok {
+		return nil
+	}
+	data, err := codeDesc.Code()
+	if err != nil {
+		return errors.Wrap(err, "couldn't get code")
+	}
+	return preloader.PreloadCode(ctx, *codeRef, data)
+}
@@ -0,0 +1,71 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logicrunner
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// ResetExecutionState drops execution state of object which got stuck, e.g. InPending with no confirmations
+// from previous executor. Object is considered not pending and its queue is rebuilt from pending requests
+// on ledger, queued requests are registered on ledger, so nothing is lost. Object mustn't be executed at the moment.
+func (lr *LogicRunner) ResetExecutionState(ctx context.Context, object Ref) (*core.ExecutionStateSummary, error) {
+	os := lr.GetObjectState(object)
+	if os == nil {
+		return nil, errors.New("[ ResetExecutionState ] node has no state for object")
+	}
+	os.Lock()
+	es := os.ExecutionState
+	os.Unlock()
+	if es == nil {
+		return nil, errors.New("[ ResetExecutionState ] node doesn't execute object")
+	}
+
+	es.Lock()
+	if es.Current != nil {
+		es.Unlock()
+		return nil, errors.New("[ ResetExecutionState ] object is being executed")
+	}
+	summary := &core.ExecutionStateSummary{
+		InPending:             es.pending == message.InPending,
+		PendingConfirmed:      es.PendingConfirmed,
+		QueueLength:           len(es.Queue),
+		LedgerHasMoreRequests: es.LedgerHasMoreRequests,
+	}
+	es.releaseQueue()
+	es.LedgerQueueElement = nil
+	es.LedgerHasMoreRequests = true
+	es.pending = message.NotPending
+	es.PendingConfirmed = false
+	es.deferred = false
+	es.objectbody = nil
+	es.Unlock()
+
+	inslogger.FromContext(ctx).Warnf(
+		"[ ResetExecutionState ] execution state of %s is reset (in pending: %t, confirmed: %t, queue: %d)",
+		object, summary.InPending, summary.PendingConfirmed, summary.QueueLength,
+	)
+
+	go lr.getLedgerPendingRequest(ctx, es)
+	return summary, nil
+}
@@ -0,0 +1,181 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logicrunner
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+)
+
+// requestSequence tracks sequence numbers ledger assigns to requests of an object on registration.
+// All requests numbered up to done are executed or reported missing. Numbering is trusted to be continuous only since
+// the first request of the object or since handover from the previous executor, so gaps are reported only then.
+// Zero sequence means request isn't numbered and it's ignored.
+type requestSequence struct {
+	done     uint64
+	anchored bool
+	trusted  bool
+	arrived  map[uint64]bool
+	finished map[uint64]bool
+	// reported are gaps which were already reported, they don't hold numbering back,
+	// but they're still accepted if requests arrive late
+	reported map[uint64]bool
+}
+
+func newRequestSequence() *requestSequence {
+	return &requestSequence{
+		arrived:  map[uint64]bool{},
+		finished: map[uint64]bool{},
+		reported: map[uint64]bool{},
+	}
+}
+
+// observe registers arrival of request to executor. Request already executed or numbered below executed ones
+// is out of order, unless it's a reported gap arriving late.
+func (s *requestSequence) observe(seq uint64) error {
+	if seq == 0 {
+		return nil
+	}
+	if !s.anchored {
+		s.anchored = true
+		s.trusted = seq == 1
+		s.done = seq - 1
+	}
+	if (seq <= s.done && !s.reported[seq]) || s.finished[seq] {
+		return errors.Wrapf(core.ErrRequestOutOfOrder, "request #%d arrived after requests up to #%d were executed", seq, s.done)
+	}
+	s.arrived[seq] = true
+	return nil
+}
+
+// finish marks request as executed.
+func (s *requestSequence) finish(seq uint64) {
+	if seq == 0 {
+		return
+	}
+	delete(s.arrived, seq)
+	delete(s.reported, seq)
+	if seq <= s.done {
+		return
+	}
+	s.finished[seq] = true
+	s.advance()
+}
+
+// handover continues numbering from the previous executor. All requests up to done and finished ones were executed
+// by it, reported gaps are still accepted if requests arrive late. Zero done means previous executor doesn't know
+// where numbering is.
+func (s *requestSequence) handover(done uint64, finished, reported []uint64) {
+	if done == 0 {
+		return
+	}
+	s.anchored = true
+	s.trusted = true
+	s.done = done
+	for seq := range s.arrived {
+		if seq <= done {
+			delete(s.arrived, seq)
+		}
+	}
+	for seq := range s.finished {
+		if seq <= done {
+			delete(s.finished, seq)
+		}
+	}
+	for _, seq := range finished {
+		if seq > done {
+			delete(s.arrived, seq)
+			s.finished[seq] = true
+		}
+	}
+	for _, seq := range reported {
+		if !s.finished[seq] {
+			s.reported[seq] = true
+		}
+	}
+	s.advance()
+}
+
+// handoverPoint returns number the next executor should continue from, request being executed is considered
+// finished because it's completed by current executor anyway.
+func (s *requestSequence) handoverPoint(executing uint64) uint64 {
+	if !s.trusted {
+		return 0
+	}
+	done := s.done
+	for done+1 == executing || s.finished[done+1] || s.reported[done+1] {
+		done++
+	}
+	return done
+}
+
+// handoverState returns requests after handover point which are executed, including the one being executed,
+// and reported gaps, so the next executor neither executes requests again nor rejects gaps arriving late.
+func (s *requestSequence) handoverState(executing uint64) (finished, reported []uint64) {
+	if !s.trusted {
+		return nil, nil
+	}
+	point := s.handoverPoint(executing)
+	for seq := range s.finished {
+		if seq > point {
+			finished = append(finished, seq)
+		}
+	}
+	if executing > point {
+		finished = append(finished, executing)
+	}
+	for seq := range s.reported {
+		reported = append(reported, seq)
+	}
+	sort.Slice(finished, func(i, j int) bool { return finished[i] < finished[j] })
+	sort.Slice(reported, func(i, j int) bool { return reported[i] < reported[j] })
+	return finished, reported
+}
+
+// gaps returns numbers which never arrived though later requests were executed. Every gap is reported once
+// and numbering moves past it, but request arriving late is still accepted. It's expected to be called
+// when executor has nothing more to execute.
+func (s *requestSequence) gaps() []uint64 {
+	if !s.trusted {
+		return nil
+	}
+	var last uint64
+	for seq := range s.finished {
+		if seq > last {
+			last = seq
+		}
+	}
+	var res []uint64
+	for seq := s.done + 1; seq < last; seq++ {
+		if !s.finished[seq] && !s.arrived[seq] && !s.reported[seq] {
+			res = append(res, seq)
+			s.reported[seq] = true
+		}
+	}
+	s.advance()
+	return res
+}
+
+func (s *requestSequence) advance() {
+	for s.finished[s.done+1] || s.reported[s.done+1] {
+		delete(s.finished, s.done+1)
+		s.done++
+	}
+}
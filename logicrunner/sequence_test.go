@@ -0,0 +1,131 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logicrunner
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+)
+
+func TestRequestSequence_Gaps(t *testing.T) {
+	s := newRequestSequence()
+	require.NoError(t, s.observe(1))
+	require.NoError(t, s.observe(3))
+	s.finish(1)
+	s.finish(3)
+
+	require.Equal(t, []uint64{2}, s.gaps())
+	require.Empty(t, s.gaps(), "gap is reported once")
+	require.Equal(t, uint64(3), s.handoverPoint(0), "reported gap doesn't hold numbering back")
+
+	require.NoError(t, s.observe(2), "late request is accepted")
+	s.finish(2)
+	require.Equal(t, uint64(3), s.handoverPoint(0))
+
+	err := s.observe(2)
+	require.Equal(t, core.ErrRequestOutOfOrder, errors.Cause(err))
+}
+
+func TestRequestSequence_Untrusted(t *testing.T) {
+	s := newRequestSequence()
+	require.NoError(t, s.observe(5))
+	require.NoError(t, s.observe(7))
+	s.finish(5)
+	s.finish(7)
+
+	require.Empty(t, s.gaps(), "numbering before first seen request is unknown")
+	require.Equal(t, uint64(0), s.handoverPoint(0))
+}
+
+func TestRequestSequence_Handover(t *testing.T) {
+	s := newRequestSequence()
+	s.handover(4, nil, nil)
+	require.Equal(t, core.ErrRequestOutOfOrder, errors.Cause(s.observe(4)))
+
+	require.NoError(t, s.observe(5))
+	require.Equal(t, uint64(5), s.handoverPoint(5), "executing request is completed by current executor")
+
+	require.NoError(t, s.observe(7))
+	s.finish(7)
+	require.Equal(t, []uint64{6}, s.gaps(), "request 5 is still executing")
+	s.finish(5)
+	require.Equal(t, uint64(7), s.handoverPoint(0))
+}
+
+func TestRequestSequence_HandoverState(t *testing.T) {
+	prev := newRequestSequence()
+	prev.handover(4, nil, nil)
+	for _, seq := range []uint64{5, 7, 8, 10} {
+		require.NoError(t, prev.observe(seq))
+	}
+	prev.finish(7)
+	prev.finish(10)
+	require.Equal(t, []uint64{6, 9}, prev.gaps())
+
+	// 5 is being executed, 8 is queued
+	point := prev.handoverPoint(5)
+	require.Equal(t, uint64(7), point)
+	finished, reported := prev.handoverState(5)
+	require.Equal(t, []uint64{10}, finished)
+	require.Equal(t, []uint64{6, 9}, reported)
+
+	next := newRequestSequence()
+	next.handover(point, finished, reported)
+
+	// executed requests are rejected, queued and late ones are accepted
+	for _, seq := range []uint64{5, 7, 10} {
+		require.Equal(t, core.ErrRequestOutOfOrder, errors.Cause(next.observe(seq)), "request %d", seq)
+	}
+	require.NoError(t, next.observe(8))
+	require.NoError(t, next.observe(6))
+	require.Empty(t, next.gaps(), "gaps are already reported by previous executor")
+
+	next.finish(8)
+	require.Equal(t, uint64(10), next.handoverPoint(0))
+	require.NoError(t, next.observe(9))
+	next.finish(9)
+	next.finish(6)
+	require.Equal(t, core.ErrRequestOutOfOrder, errors.Cause(next.observe(6)), "late request is executed once")
+	require.Equal(t, core.ErrRequestOutOfOrder, errors.Cause(next.observe(9)), "late request is executed once")
+
+	finished, reported = next.handoverState(0)
+	require.Empty(t, finished)
+	require.Empty(t, reported)
+}
+
+func TestRequestSequence_HandoverStateExecuting(t *testing.T) {
+	s := newRequestSequence()
+	s.handover(4, nil, nil)
+	require.NoError(t, s.observe(5))
+	require.NoError(t, s.observe(6))
+
+	// 6 is executed while 5 is still queued
+	finished, reported := s.handoverState(6)
+	require.Equal(t, uint64(4), s.handoverPoint(6))
+	require.Equal(t, []uint64{6}, finished)
+	require.Empty(t, reported)
+
+	untrusted := newRequestSequence()
+	require.NoError(t, untrusted.observe(5))
+	finished, reported = untrusted.handoverState(5)
+	require.Nil(t, finished)
+	require.Nil(t, reported)
+}
@@ -108,23 +108,32 @@ func (gpr *RPC) GetCode(req rpctypes.UpGetCodeReq, reply *rpctypes.UpGetCodeResp
 // MakeBaseMessage makes base of logicrunner event from base of up request
 func MakeBaseMessage(req rpctypes.UpBaseReq, es *ExecutionState) message.BaseLogicMessage {
 	es.nonce++
-	return message.BaseLogicMessage{
+	bm := message.BaseLogicMessage{
 		Caller:          req.Callee,
 		CallerPrototype: req.Prototype,
 		Request:         req.Request,
 		Nonce:           es.nonce,
 	}
+	// calls made by contract belong to the same API request
+	if es.Current.LogicContext != nil {
+		bm.APIRequest = es.Current.LogicContext.APIRequest
+	}
+	return bm
 }
 
 // RouteCall routes call from a contract to a contract through event bus.
 func (gpr *RPC) RouteCall(req rpctypes.UpRouteReq, rep *rpctypes.UpRouteResp) (err error) {
 	defer recoverRPC(&err)
+	if req.Mode == speculationMode {
+		return errSpeculativeSideEffect
+	}
 
 	os := gpr.lr.MustObjectState(req.Callee)
 	es := os.MustModeState(req.Mode)
 	ctx := es.Current.Context
 
 	bm := MakeBaseMessage(req.UpBaseReq, es)
+	resume := gpr.lr.suspendWorker(es)
 	res, err := gpr.lr.ContractRequester.CallMethod(ctx,
 		&bm,
 		!req.Wait,
@@ -133,6 +142,7 @@ func (gpr *RPC) RouteCall(req rpctypes.UpRouteReq, rep *rpctypes.UpRouteResp) (e
 		req.Arguments,
 		&req.ProxyPrototype,
 	)
+	resume()
 	if err != nil {
 		return err
 	}
@@ -147,13 +157,18 @@ func (gpr *RPC) RouteCall(req rpctypes.UpRouteReq, rep *rpctypes.UpRouteResp) (e
 // SaveAsChild is an RPC saving data as memory of a contract as child a parent
 func (gpr *RPC) SaveAsChild(req rpctypes.UpSaveAsChildReq, rep *rpctypes.UpSaveAsChildResp) (err error) {
 	defer recoverRPC(&err)
+	if req.Mode == speculationMode {
+		return errSpeculativeSideEffect
+	}
 
 	os := gpr.lr.MustObjectState(req.Callee)
 	es := os.MustModeState(req.Mode)
 	ctx := es.Current.Context
 
 	bm := MakeBaseMessage(req.UpBaseReq, es)
+	resume := gpr.lr.suspendWorker(es)
 	ref, err := gpr.lr.ContractRequester.CallConstructor(ctx, &bm, false, &req.Prototype, &req.Parent, req.ConstructorName, req.ArgsSerialized, int(message.Child))
+	resume()
 
 	rep.Reference = ref
 
@@ -163,13 +178,18 @@ func (gpr *RPC) SaveAsChild(req rpctypes.UpSaveAsChildReq, rep *rpctypes.UpSaveA
 // SaveAsDelegate is an RPC saving data as memory of a contract as child a parent
 func (gpr *RPC) SaveAsDelegate(req rpctypes.UpSaveAsDelegateReq, rep *rpctypes.UpSaveAsDelegateResp) (err error) {
 	defer recoverRPC(&err)
+	if req.Mode == speculationMode {
+		return errSpeculativeSideEffect
+	}
 
 	os := gpr.lr.MustObjectState(req.Callee)
 	es := os.MustModeState(req.Mode)
 	ctx := es.Current.Context
 
 	bm := MakeBaseMessage(req.UpBaseReq, es)
+	resume := gpr.lr.suspendWorker(es)
 	ref, err := gpr.lr.ContractRequester.CallConstructor(ctx, &bm, false, &req.Prototype, &req.Into, req.ConstructorName, req.ArgsSerialized, int(message.Delegate))
+	resume()
 
 	rep.Reference = ref
 	return err
@@ -279,6 +299,9 @@ func (gpr *RPC) GetDelegate(req rpctypes.UpGetDelegateReq, rep *rpctypes.UpGetDe
 // DeactivateObject is an RPC saving data as memory of a contract as child a parent
 func (gpr *RPC) DeactivateObject(req rpctypes.UpDeactivateObjectReq, rep *rpctypes.UpDeactivateObjectResp) (err error) {
 	defer recoverRPC(&err)
+	if req.Mode == speculationMode {
+		return errSpeculativeSideEffect
+	}
 
 	os := gpr.lr.MustObjectState(req.Callee)
 	es := os.MustModeState(req.Mode)
@@ -286,6 +309,68 @@ func (gpr *RPC) DeactivateObject(req rpctypes.UpDeactivateObjectReq, rep *rpctyp
 	return nil
 }
 
+// Lock is an RPC acquiring or releasing named lock for a contract
+func (gpr *RPC) Lock(req rpctypes.UpLockReq, rep *rpctypes.UpLockResp) (err error) {
+	defer recoverRPC(&err)
+	if req.Mode == speculationMode {
+		return errSpeculativeSideEffect
+	}
+
+	os := gpr.lr.MustObjectState(req.Callee)
+	es := os.MustModeState(req.Mode)
+	ctx := es.Current.Context
+
+	res, err := gpr.lr.MessageBus.Send(ctx, &message.Lock{
+		Name:    req.Name,
+		Owner:   req.Callee,
+		TTL:     req.TTL,
+		Release: req.Release,
+	}, nil)
+	if err != nil {
+		return err
+	}
+	lock, ok := res.(*reply.Lock)
+	if !ok {
+		return errors.Errorf("[ Lock ] unexpected reply: %#v", res)
+	}
+	rep.Acquired = lock.Acquired
+	return nil
+}
+
+// ScheduleCall is an RPC scheduling call of a contract on itself in future pulse
+func (gpr *RPC) ScheduleCall(req rpctypes.UpScheduleCallReq, rep *rpctypes.UpScheduleCallResp) (err error) {
+	defer recoverRPC(&err)
+	if req.Mode == speculationMode {
+		return errSpeculativeSideEffect
+	}
+
+	os := gpr.lr.MustObjectState(req.Callee)
+	es := os.MustModeState(req.Mode)
+	ctx := es.Current.Context
+
+	bm := MakeBaseMessage(req.UpBaseReq, es)
+	// timer is registered by executor, validators only check the call is made
+	if req.Mode == "validation" {
+		return nil
+	}
+	return gpr.lr.scheduleCall(ctx, es, bm, req.Pulses, req.Method, req.Arguments)
+}
+
+// RequireValidation is an RPC marking current call of a contract to be always checked by validators
+func (gpr *RPC) RequireValidation(req rpctypes.UpRequireValidationReq, rep *rpctypes.UpRequireValidationResp) (err error) {
+	defer recoverRPC(&err)
+
+	os := gpr.lr.MustObjectState(req.Callee)
+	es := os.MustModeState(req.Mode)
+	switch behaviour := es.Behaviour.(type) {
+	case *ValidationSaver:
+		behaviour.RequireValidation()
+	case *speculationBehaviour:
+		behaviour.requireValidation = true
+	}
+	return nil
+}
+
 // atomicLoadAndIncrementUint64 performs CAS loop, increments counter and returns old value.
 func atomicLoadAndIncrementUint64(addr *uint64) uint64 {
 	for {
@@ -0,0 +1,94 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package logicrunner
+
+import (
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/core"
+)
+
+// sessionDescriptors are descriptors of prototype and code of an object called within client session.
+// They are immutable, so they are safe to reuse while object keeps its prototype.
+type sessionDescriptors struct {
+	prototype core.ObjectDescriptor
+	code      core.CodeDescriptor
+}
+
+type callSession struct {
+	expires time.Time
+	objects map[Ref]sessionDescriptors
+}
+
+// sessionCache keeps descriptors of objects called within short-lived client sessions, so interactive
+// flows of related calls don't read them from ledger every time.
+type sessionCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	lock     sync.Mutex
+	sessions map[string]*callSession
+}
+
+func newSessionCache(ttl time.Duration) *sessionCache {
+	return &sessionCache{
+		ttl:      ttl,
+		now:      time.Now,
+		sessions: map[string]*callSession{},
+	}
+}
+
+func (c *sessionCache) get(session string, object Ref) (sessionDescriptors, bool) {
+	if session == "" || c.ttl <= 0 {
+		return sessionDescriptors{}, false
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	s, ok := c.sessions[session]
+	if !ok || c.now().After(s.expires) {
+		return sessionDescriptors{}, false
+	}
+	s.expires = c.now().Add(c.ttl)
+	d, ok := s.objects[object]
+	return d, ok
+}
+
+func (c *sessionCache) set(session string, object Ref, d sessionDescriptors) {
+	if session == "" || c.ttl <= 0 {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := c.now()
+	for token, s := range c.sessions {
+		if now.After(s.expires) {
+			delete(c.sessions, token)
+		}
+	}
+
+	s, ok := c.sessions[session]
+	if !ok {
+		s = &callSession{objects: map[Ref]sessionDescriptors{}}
+		c.sessions[session] = s
+	}
+	s.expires = now.Add(c.ttl)
+	s.objects[object] = d
+}
@@ -0,0 +1,54 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package logicrunner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestSessionCache(t *testing.T) {
+	now := time.Now()
+	c := newSessionCache(time.Minute)
+	c.now = func() time.Time { return now }
+	object := testutils.RandomRef()
+	d := sessionDescriptors{
+		prototype: testutils.NewObjectDescriptorMock(t),
+		code:      testutils.NewCodeDescriptorMock(t),
+	}
+
+	_, ok := c.get("session", object)
+	require.False(t, ok)
+
+	c.set("session", object, d)
+	cached, ok := c.get("session", object)
+	require.True(t, ok)
+	require.Equal(t, d, cached)
+
+	_, ok = c.get("other", object)
+	require.False(t, ok, "descriptors aren't shared between sessions")
+	c.set("", object, d)
+	_, ok = c.get("", object)
+	require.False(t, ok, "calls without session aren't cached")
+
+	now = now.Add(2 * time.Minute)
+	_, ok = c.get("session", object)
+	require.False(t, ok, "session is expired")
+}
@@ -0,0 +1,221 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logicrunner
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opencensus.io/stats"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/codec"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/instrumentation/crashreport"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// speculationMode is a mode of executions made speculatively near the end of pulse.
+const speculationMode = "speculation"
+
+// errSpeculativeSideEffect aborts speculative execution when contract tries to change anything besides its memory.
+var errSpeculativeSideEffect = errors.New("side effects aren't allowed in speculative execution")
+
+// speculation is a result of request executed speculatively against known state of object. It's committed
+// without execution if object is still in the same state when request gets its turn.
+type speculation struct {
+	request Ref
+	state   core.RecordID
+	memory  []byte
+	result  []byte
+	// requireValidation is set if contract marked call as high-value during speculative execution
+	requireValidation bool
+}
+
+func (s *speculation) toMessage() *message.SpeculativeResult {
+	return &message.SpeculativeResult{
+		Request:           s.request,
+		State:             s.state,
+		Memory:            s.memory,
+		Result:            s.result,
+		RequireValidation: s.requireValidation,
+	}
+}
+
+func speculationFromMessage(msg *message.SpeculativeResult) *speculation {
+	return &speculation{
+		request:           msg.Request,
+		state:             msg.State,
+		memory:            msg.Memory,
+		result:            msg.Result,
+		requireValidation: msg.RequireValidation,
+	}
+}
+
+// speculationBehaviour is a behaviour of speculative executions, results are kept by speculation itself.
+type speculationBehaviour struct {
+	requireValidation bool
+}
+
+func (*speculationBehaviour) Mode() string {
+	return speculationMode
+}
+
+func (*speculationBehaviour) Result(reply core.Reply, err error) error {
+	return nil
+}
+
+// startSpeculation starts speculative execution of request deferred till next pulse, es should be locked.
+// Only method calls on object with known state are executed speculatively.
+func (lr *LogicRunner) startSpeculation(ctx context.Context, es *ExecutionState, qe ExecutionQueueElement) {
+	if !lr.Cfg.SpeculativeExecution || es.objectbody == nil || es.speculation != nil || qe.request == nil {
+		return
+	}
+	msg, ok := qe.parcel.Message().(*message.CallMethod)
+	if !ok {
+		return
+	}
+	body := *es.objectbody
+	go lr.speculate(ctx, es, body, msg, qe)
+}
+
+// speculate executes method against state of body without writing anything to ledger. Result is saved to es
+// and either handed over to the next executor or committed by this node if it stays executor of the object.
+func (lr *LogicRunner) speculate(
+	ctx context.Context, es *ExecutionState, body ObjectBody, msg *message.CallMethod, qe ExecutionQueueElement,
+) {
+	defer crashreport.Recover(ctx, "LogicRunner.speculate")
+	logger := inslogger.FromContext(qe.ctx)
+
+	os := lr.UpsertObjectState(es.Ref)
+	os.Lock()
+	if os.Speculation != nil {
+		os.Unlock()
+		return
+	}
+	behaviour := &speculationBehaviour{}
+	ss := &ExecutionState{Ref: es.Ref, Behaviour: behaviour, objectbody: &body}
+	os.Speculation = ss
+	os.Unlock()
+	defer func() {
+		os.Lock()
+		os.Speculation = nil
+		os.Unlock()
+	}()
+
+	sender := qe.parcel.GetSender()
+	pulse := lr.requestPulse(ctx, qe)
+	ss.Current = &CurrentExecution{
+		Context:       qe.ctx,
+		Request:       qe.request,
+		RequesterNode: &sender,
+		Pulse:         pulse,
+		LogicContext: &core.LogicCallContext{
+			Mode:            speculationMode,
+			Caller:          msg.GetCaller(),
+			Callee:          &es.Ref,
+			Request:         qe.request,
+			Time:            time.Now(),
+			Pulse:           pulse,
+			TraceID:         inslogger.TraceID(qe.ctx),
+			CallerPrototype: msg.GetCallerPrototype(),
+			APIRequest:      msg.GetAPIRequest(),
+			Prototype:       body.Prototype,
+			Code:            body.CodeRef,
+			Parent:          body.Parent,
+		},
+	}
+
+	memory, result, err := lr.executeSpeculatively(qe.ctx, ss, msg)
+	if err != nil {
+		logger.Debugf("speculative execution of %s is aborted: %s", qe.request, err)
+		return
+	}
+
+	es.Lock()
+	es.speculation = &speculation{
+		request:           *qe.request,
+		state:             *body.objDescriptor.StateID(),
+		memory:            memory,
+		result:            result,
+		requireValidation: behaviour.requireValidation,
+	}
+	es.Unlock()
+	logger.Debugf("request %s is executed speculatively", qe.request)
+}
+
+func (lr *LogicRunner) executeSpeculatively(
+	ctx context.Context, ss *ExecutionState, m *message.CallMethod,
+) ([]byte, []byte, error) {
+	body := ss.objectbody
+	if !m.ProxyPrototype.IsEmpty() && !m.ProxyPrototype.Equal(*body.Prototype) {
+		return nil, nil, errors.New("proxy prototype doesn't match prototype of object")
+	}
+	if err := lr.checkACL(ctx, m, body); err != nil {
+		return nil, nil, err
+	}
+	executor, err := lr.GetExecutor(body.CodeMachineType)
+	if err != nil {
+		return nil, nil, err
+	}
+	args, err := codec.Transcode(m.Arguments, m.GetCodec(), core.CodecCBOR)
+	if err != nil {
+		return nil, nil, err
+	}
+	memory, result, err := executor.CallMethod(ctx, ss.Current.LogicContext, *body.CodeRef, body.Object, m.Method, args)
+	if err != nil {
+		return nil, nil, err
+	}
+	result, err = codec.Transcode(result, core.CodecCBOR, m.GetCodec())
+	if err != nil {
+		return nil, nil, err
+	}
+	return memory, result, nil
+}
+
+// takeSpeculation returns speculative result of request if it was executed against the current state of object.
+// Speculation is dropped anyway, it can't be reused for other requests.
+func (lr *LogicRunner) takeSpeculation(ctx context.Context, es *ExecutionState, request Ref) *speculation {
+	es.Lock()
+	spec := es.speculation
+	es.speculation = nil
+	es.Unlock()
+
+	if spec == nil || spec.request != request {
+		return nil
+	}
+	state := es.objectbody.objDescriptor.StateID()
+	if state == nil || *state != spec.state {
+		inslogger.FromContext(ctx).Debugf("speculative result of %s is discarded, object state has changed", request)
+		stats.Record(ctx, statSpeculationDiscarded.M(1))
+		return nil
+	}
+	stats.Record(ctx, statSpeculationCommitted.M(1))
+	return spec
+}
+
+// handoverSpeculation returns speculative result to hand over with queue if it's made for the head of queue,
+// es should be locked.
+func (es *ExecutionState) handoverSpeculation(queue []ExecutionQueueElement) *message.SpeculativeResult {
+	spec := es.speculation
+	es.speculation = nil
+	if spec == nil || len(queue) == 0 || queue[0].request == nil || *queue[0].request != spec.request {
+		return nil
+	}
+	return spec.toMessage()
+}
@@ -0,0 +1,81 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logicrunner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+)
+
+func speculationState(t *testing.T, state core.RecordID) *ExecutionState {
+	desc := testutils.NewObjectDescriptorMock(t)
+	desc.StateIDMock.Return(&state)
+	return &ExecutionState{objectbody: &ObjectBody{objDescriptor: desc}}
+}
+
+func TestLogicRunner_TakeSpeculation(t *testing.T) {
+	ctx := context.Background()
+	lr := &LogicRunner{}
+	request, state := testutils.RandomRef(), testutils.RandomID()
+
+	es := speculationState(t, state)
+	es.speculation = &speculation{request: request, state: state, result: []byte{1}}
+	spec := lr.takeSpeculation(ctx, es, request)
+	require.NotNil(t, spec)
+	require.Equal(t, []byte{1}, spec.result)
+	require.Nil(t, es.speculation)
+
+	// object got new state since speculation was made
+	es = speculationState(t, testutils.RandomID())
+	es.speculation = &speculation{request: request, state: state}
+	require.Nil(t, lr.takeSpeculation(ctx, es, request))
+	require.Nil(t, es.speculation)
+
+	// speculation was made for other request
+	es = speculationState(t, state)
+	es.speculation = &speculation{request: testutils.RandomRef(), state: state}
+	require.Nil(t, lr.takeSpeculation(ctx, es, request))
+	require.Nil(t, es.speculation)
+}
+
+func TestExecutionState_HandoverSpeculation(t *testing.T) {
+	request, other := testutils.RandomRef(), testutils.RandomRef()
+	spec := &speculation{
+		request:           request,
+		state:             testutils.RandomID(),
+		memory:            []byte{1},
+		result:            []byte{2},
+		requireValidation: true,
+	}
+
+	es := &ExecutionState{speculation: spec}
+	msg := es.handoverSpeculation([]ExecutionQueueElement{{request: &request}, {request: &other}})
+	require.NotNil(t, msg)
+	require.Equal(t, spec, speculationFromMessage(msg))
+	require.Nil(t, es.speculation)
+
+	// head of queue changed, speculation is useless for the next executor
+	es = &ExecutionState{speculation: spec}
+	require.Nil(t, es.handoverSpeculation([]ExecutionQueueElement{{request: &other}}))
+	require.Nil(t, es.speculation)
+	require.Nil(t, es.handoverSpeculation(nil))
+}
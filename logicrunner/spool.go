@@ -0,0 +1,133 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logicrunner
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.opencensus.io/stats"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+type spooledMessage struct {
+	msg core.Message
+	// pulses is a number of pulse changes message survived in spool
+	pulses  int
+	dropped chan struct{}
+}
+
+// pulseSpool keeps messages sent on pulse change which failed to be delivered and retries them with backoff.
+// Messages still undelivered after configured number of pulses are dropped, the receiver is expected
+// to be out of date with them anyway.
+type pulseSpool struct {
+	cfg   configuration.PulseSpool
+	clock Clock
+	send  func(ctx context.Context, msg core.Message) error
+
+	lock     sync.Mutex
+	messages map[*spooledMessage]struct{}
+}
+
+func newPulseSpool(
+	cfg configuration.PulseSpool, clock Clock, send func(ctx context.Context, msg core.Message) error,
+) *pulseSpool {
+	return &pulseSpool{
+		cfg:      cfg,
+		clock:    clock,
+		send:     send,
+		messages: map[*spooledMessage]struct{}{},
+	}
+}
+
+// add puts failed message to spool and starts retrying it.
+func (s *pulseSpool) add(ctx context.Context, msg core.Message) {
+	sm := &spooledMessage{msg: msg, dropped: make(chan struct{})}
+
+	s.lock.Lock()
+	s.messages[sm] = struct{}{}
+	depth := len(s.messages)
+	s.lock.Unlock()
+
+	stats.Record(ctx, statPulseSpoolDepth.M(int64(depth)))
+	go s.retry(ctx, sm)
+}
+
+func (s *pulseSpool) retry(ctx context.Context, sm *spooledMessage) {
+	delay := s.cfg.RetryDelay
+	for {
+		select {
+		case <-sm.dropped:
+			return
+		case <-s.clock.After(delay):
+		}
+
+		err := s.send(ctx, sm.msg)
+		if err == nil {
+			s.remove(ctx, sm)
+			return
+		}
+		inslogger.FromContext(ctx).Warn(errors.Wrap(err, "failed to resend spooled pulse message"))
+
+		delay *= 2
+		if s.cfg.MaxRetryDelay > 0 && delay > s.cfg.MaxRetryDelay {
+			delay = s.cfg.MaxRetryDelay
+		}
+	}
+}
+
+func (s *pulseSpool) remove(ctx context.Context, sm *spooledMessage) {
+	s.lock.Lock()
+	delete(s.messages, sm)
+	depth := len(s.messages)
+	s.lock.Unlock()
+
+	stats.Record(ctx, statPulseSpoolDepth.M(int64(depth)))
+}
+
+// onPulse ages spooled messages and drops ones which ran out of pulses.
+func (s *pulseSpool) onPulse(ctx context.Context) {
+	s.lock.Lock()
+	var dropped int64
+	for sm := range s.messages {
+		sm.pulses++
+		if sm.pulses > s.cfg.MaxPulses {
+			delete(s.messages, sm)
+			close(sm.dropped)
+			dropped++
+		}
+	}
+	depth := len(s.messages)
+	s.lock.Unlock()
+
+	if dropped > 0 {
+		inslogger.FromContext(ctx).Errorf("dropped %d undelivered pulse messages after %d pulses", dropped, s.cfg.MaxPulses)
+		stats.Record(ctx, statPulseSpoolFailed.M(dropped))
+	}
+	stats.Record(ctx, statPulseSpoolDepth.M(int64(depth)))
+}
+
+// depth returns number of messages waiting for retry.
+func (s *pulseSpool) depth() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return len(s.messages)
+}
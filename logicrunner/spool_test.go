@@ -0,0 +1,80 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logicrunner
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+)
+
+func waitSpoolDepth(t *testing.T, s *pulseSpool, depth int) {
+	for i := 0; i < 100; i++ {
+		if s.depth() == depth {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.Equal(t, depth, s.depth())
+}
+
+func TestPulseSpool_RetriesUntilDelivered(t *testing.T) {
+	ctx := context.Background()
+	var attempts int32
+	s := newPulseSpool(
+		configuration.PulseSpool{MaxPulses: 2, RetryDelay: time.Millisecond, MaxRetryDelay: 4 * time.Millisecond},
+		realClock{},
+		func(ctx context.Context, msg core.Message) error {
+			if atomic.AddInt32(&attempts, 1) < 3 {
+				return errors.New("send failed")
+			}
+			return nil
+		},
+	)
+
+	s.add(ctx, &message.ExecutorResults{})
+	waitSpoolDepth(t, s, 0)
+	require.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+}
+
+func TestPulseSpool_DropsAfterMaxPulses(t *testing.T) {
+	ctx := context.Background()
+	s := newPulseSpool(
+		configuration.PulseSpool{MaxPulses: 1, RetryDelay: time.Millisecond, MaxRetryDelay: time.Millisecond},
+		newVirtualClock(time.Time{}),
+		func(ctx context.Context, msg core.Message) error {
+			return errors.New("send failed")
+		},
+	)
+
+	s.add(ctx, &message.ExecutorResults{})
+	require.Equal(t, 1, s.depth())
+
+	s.onPulse(ctx)
+	require.Equal(t, 1, s.depth(), "message is retried for configured number of pulses")
+
+	s.onPulse(ctx)
+	require.Equal(t, 0, s.depth(), "message is dropped after running out of pulses")
+}
@@ -0,0 +1,150 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logicrunner
+
+import (
+	"container/list"
+	"context"
+	"sync"
+
+	"go.opencensus.io/stats"
+
+	"github.com/insolar/insolar/core/message"
+)
+
+// objectStateLRU orders objects LogicRunner holds state for by the last access, so states of objects
+// which weren't accessed for the longest time are evicted first when number of states exceeds the cap.
+type objectStateLRU struct {
+	lock  sync.Mutex
+	order *list.List
+	elems map[Ref]*list.Element
+}
+
+func newObjectStateLRU() *objectStateLRU {
+	return &objectStateLRU{
+		order: list.New(),
+		elems: make(map[Ref]*list.Element),
+	}
+}
+
+// touch marks object as the most recently accessed one.
+func (l *objectStateLRU) touch(ref Ref) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if e, ok := l.elems[ref]; ok {
+		l.order.MoveToFront(e)
+		return
+	}
+	l.elems[ref] = l.order.PushFront(ref)
+}
+
+// remove forgets object.
+func (l *objectStateLRU) remove(ref Ref) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if e, ok := l.elems[ref]; ok {
+		l.order.Remove(e)
+		delete(l.elems, ref)
+	}
+}
+
+// oldest returns objects starting from the least recently accessed one.
+func (l *objectStateLRU) oldest() []Ref {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	refs := make([]Ref, 0, l.order.Len())
+	for e := l.order.Back(); e != nil; e = e.Prev() {
+		refs = append(refs, e.Value.(Ref))
+	}
+	return refs
+}
+
+// idle tells whether state can be dropped and rebuilt from ledger later without losing anything:
+// object isn't executed or validated, nothing is queued or fetched from ledger and object isn't pending.
+// st should be locked.
+func (st *ObjectState) idle() bool {
+	st.assertLocked("ObjectState.ExecutionState")
+	if st.Validation != nil || st.Speculation != nil || st.Consensus != nil {
+		return false
+	}
+	es := st.ExecutionState
+	if es == nil {
+		return true
+	}
+
+	es.Lock()
+	defer es.Unlock()
+	return es.Current == nil &&
+		len(es.Queue) == 0 &&
+		!es.QueueProcessorActive &&
+		!es.LedgerHasMoreRequests &&
+		es.LedgerQueueElement == nil &&
+		!es.deferred &&
+		es.registering == 0 &&
+		es.pending != message.InPending &&
+		(es.sequences == nil || len(es.sequences.arrived) == 0)
+}
+
+// touchObjectState marks object state as accessed if number of states is capped.
+func (lr *LogicRunner) touchObjectState(ref Ref) {
+	if lr.recentStates != nil {
+		lr.recentStates.touch(ref)
+	}
+}
+
+// forgetObjectState drops state of object, lr.stateMutex should be locked.
+func (lr *LogicRunner) forgetObjectState(ref Ref) {
+	lr.stateMutex.assertLocked("LogicRunner.state")
+	delete(lr.state, ref)
+	if lr.recentStates != nil {
+		lr.recentStates.remove(ref)
+	}
+}
+
+// evictIdleStates drops states of idle objects starting from the least recently accessed one until there is room
+// for one more state. States of busy objects are kept, so cap may be exceeded while all objects are busy.
+// lr.stateMutex should be locked.
+func (lr *LogicRunner) evictIdleStates() {
+	lr.stateMutex.assertLocked("LogicRunner.state")
+	if lr.recentStates == nil || len(lr.state) < lr.Cfg.MaxObjectStates {
+		return
+	}
+
+	evicted := 0
+	for _, ref := range lr.recentStates.oldest() {
+		if len(lr.state) < lr.Cfg.MaxObjectStates {
+			break
+		}
+		state, ok := lr.state[ref]
+		if !ok {
+			lr.recentStates.remove(ref)
+			continue
+		}
+		state.Lock()
+		if state.idle() {
+			lr.forgetObjectState(ref)
+			evicted++
+		}
+		state.Unlock()
+	}
+	if evicted > 0 {
+		stats.Record(context.Background(), statObjectStatesEvicted.M(int64(evicted)))
+	}
+}
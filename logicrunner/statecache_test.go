@@ -0,0 +1,67 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logicrunner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestLogicRunner_EvictsIdleStates(t *testing.T) {
+	lr, err := NewLogicRunner(&configuration.LogicRunner{MaxObjectStates: 3})
+	require.NoError(t, err)
+
+	busy, pending, idle, recent := testutils.RandomRef(), testutils.RandomRef(), testutils.RandomRef(), testutils.RandomRef()
+	lr.UpsertObjectState(busy).ExecutionState = &ExecutionState{Ref: busy, Current: &CurrentExecution{}}
+	lr.UpsertObjectState(pending).ExecutionState = &ExecutionState{Ref: pending, pending: message.InPending}
+	lr.UpsertObjectState(idle).ExecutionState = &ExecutionState{Ref: idle, pending: message.NotPending}
+	// access makes busy object the most recently used one, it is kept anyway
+	require.NotNil(t, lr.GetObjectState(busy))
+
+	state := lr.UpsertObjectState(recent)
+	require.Equal(t, 3, lr.StateSize())
+	require.Nil(t, lr.GetObjectState(idle))
+	require.NotNil(t, lr.GetObjectState(busy))
+	require.NotNil(t, lr.GetObjectState(pending))
+	require.Equal(t, state, lr.GetObjectState(recent))
+
+	// evicted state is rebuilt on demand
+	require.Nil(t, lr.UpsertObjectState(idle).ExecutionState)
+	require.Equal(t, 3, lr.StateSize())
+	require.Nil(t, lr.GetObjectState(recent))
+
+	// cap is exceeded while all objects are busy
+	lr.UpsertObjectState(idle).Validation = &ExecutionState{Ref: idle}
+	lr.UpsertObjectState(recent)
+	require.Equal(t, 4, lr.StateSize())
+}
+
+func TestLogicRunner_UncappedStates(t *testing.T) {
+	lr, err := NewLogicRunner(&configuration.LogicRunner{})
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		lr.UpsertObjectState(testutils.RandomRef())
+	}
+	require.Equal(t, 10, lr.StateSize())
+	require.Nil(t, lr.recentStates)
+}
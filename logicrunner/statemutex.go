@@ -0,0 +1,40 @@
+// +build !stateaudit
+
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logicrunner
+
+import (
+	"sync"
+)
+
+// stateMutex guards ObjectState and ExecutionState. Build with "stateaudit" tag to log access to guarded state
+// from goroutines not holding the lock.
+type stateMutex struct {
+	sync.Mutex
+}
+
+func (m *stateMutex) assertLocked(what string) {}
+
+// stateRWMutex guards LogicRunner state map.
+type stateRWMutex struct {
+	sync.RWMutex
+}
+
+func (m *stateRWMutex) assertLocked(what string) {}
+
+func (m *stateRWMutex) assertRLocked(what string) {}
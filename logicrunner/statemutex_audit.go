@@ -0,0 +1,144 @@
+// +build stateaudit
+
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logicrunner
+
+import (
+	"bytes"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/insolar/insolar/log"
+)
+
+// reportedViolations holds call sites violations were already reported for, every site is reported once.
+var reportedViolations sync.Map
+
+// goroutineID returns id of current goroutine parsed from its stack header "goroutine N [...]".
+func goroutineID() int64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+	buf = buf[:bytes.IndexByte(buf, ' ')]
+	id, err := strconv.ParseInt(string(buf), 10, 64)
+	if err != nil {
+		panic("failed to parse goroutine id: " + err.Error())
+	}
+	return id
+}
+
+func reportViolation(what string, owner int64) {
+	pc, _, _, _ := runtime.Caller(2)
+	if _, reported := reportedViolations.LoadOrStore(pc, struct{}{}); reported {
+		return
+	}
+	log.Errorf(
+		"[ stateaudit ] %s is accessed by goroutine %d without lock, lock is held by goroutine %d\n%s",
+		what, goroutineID(), owner, debug.Stack(),
+	)
+}
+
+// stateMutex guards ObjectState and ExecutionState. It tracks goroutine holding the lock, so access
+// to guarded state from other goroutines is logged.
+type stateMutex struct {
+	mu    sync.Mutex
+	owner int64
+}
+
+func (m *stateMutex) Lock() {
+	m.mu.Lock()
+	atomic.StoreInt64(&m.owner, goroutineID())
+}
+
+func (m *stateMutex) Unlock() {
+	atomic.StoreInt64(&m.owner, 0)
+	m.mu.Unlock()
+}
+
+// assertLocked logs violation if current goroutine doesn't hold the lock.
+func (m *stateMutex) assertLocked(what string) {
+	if owner := atomic.LoadInt64(&m.owner); owner != goroutineID() {
+		reportViolation(what, owner)
+	}
+}
+
+// stateRWMutex guards LogicRunner state map. It tracks writer and readers holding the lock.
+type stateRWMutex struct {
+	mu    sync.RWMutex
+	owner int64
+
+	readersLock sync.Mutex
+	readers     map[int64]int
+}
+
+func (m *stateRWMutex) Lock() {
+	m.mu.Lock()
+	atomic.StoreInt64(&m.owner, goroutineID())
+}
+
+func (m *stateRWMutex) Unlock() {
+	atomic.StoreInt64(&m.owner, 0)
+	m.mu.Unlock()
+}
+
+func (m *stateRWMutex) RLock() {
+	m.mu.RLock()
+	id := goroutineID()
+	m.readersLock.Lock()
+	if m.readers == nil {
+		m.readers = map[int64]int{}
+	}
+	m.readers[id]++
+	m.readersLock.Unlock()
+}
+
+func (m *stateRWMutex) RUnlock() {
+	id := goroutineID()
+	m.readersLock.Lock()
+	m.readers[id]--
+	if m.readers[id] <= 0 {
+		delete(m.readers, id)
+	}
+	m.readersLock.Unlock()
+	m.mu.RUnlock()
+}
+
+// assertLocked logs violation if current goroutine doesn't hold the write lock.
+func (m *stateRWMutex) assertLocked(what string) {
+	if owner := atomic.LoadInt64(&m.owner); owner != goroutineID() {
+		reportViolation(what, owner)
+	}
+}
+
+// assertRLocked logs violation if current goroutine holds neither read nor write lock.
+func (m *stateRWMutex) assertRLocked(what string) {
+	id := goroutineID()
+	owner := atomic.LoadInt64(&m.owner)
+	if owner == id {
+		return
+	}
+	m.readersLock.Lock()
+	reading := m.readers[id] > 0
+	m.readersLock.Unlock()
+	if !reading {
+		reportViolation(what, owner)
+	}
+}
@@ -0,0 +1,62 @@
+// +build stateaudit
+
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logicrunner
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoroutineID(t *testing.T) {
+	id := goroutineID()
+	require.NotZero(t, id)
+	require.Equal(t, id, goroutineID())
+
+	var other int64
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		other = goroutineID()
+		wg.Done()
+	}()
+	wg.Wait()
+	require.NotEqual(t, id, other)
+}
+
+func TestStateMutex_TracksOwner(t *testing.T) {
+	var m stateMutex
+	m.Lock()
+	require.Equal(t, goroutineID(), m.owner)
+	m.Unlock()
+	require.Zero(t, m.owner)
+
+	var rw stateRWMutex
+	rw.RLock()
+	rw.RLock()
+	require.Equal(t, 2, rw.readers[goroutineID()])
+	rw.RUnlock()
+	rw.RUnlock()
+	require.Empty(t, rw.readers)
+
+	rw.Lock()
+	require.Equal(t, goroutineID(), rw.owner)
+	rw.Unlock()
+}
@@ -0,0 +1,117 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logicrunner
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	"go.opencensus.io/stats"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// scheduleCall registers timer for call of method made by object on itself after pulses pulses. Ledger notifies
+// executor of due pulse about the timer, so the call is made even if nothing else happens to the object.
+func (lr *LogicRunner) scheduleCall(
+	ctx context.Context,
+	es *ExecutionState,
+	base message.BaseLogicMessage,
+	pulses uint,
+	method string,
+	args core.Arguments,
+) error {
+	if pulses == 0 {
+		return errors.New("[ ScheduleCall ] call should be scheduled at least one pulse ahead")
+	}
+	if lr.Cfg.MaxTimerPulses > 0 && pulses > uint(lr.Cfg.MaxTimerPulses) {
+		return errors.Errorf("[ ScheduleCall ] call can't be scheduled more than %d pulses ahead", lr.Cfg.MaxTimerPulses)
+	}
+
+	msg := &message.CallMethod{
+		BaseLogicMessage: base,
+		ReturnMode:       message.ReturnNoWait,
+		ObjectRef:        es.Ref,
+		Method:           method,
+		Arguments:        args,
+	}
+	due := es.Current.LogicContext.Pulse.NumberAfter(pulses)
+	_, err := lr.ArtifactManager.RegisterTimer(ctx, es.Ref, due, msg)
+	if err != nil {
+		return errors.Wrap(err, "[ ScheduleCall ] can't register timer")
+	}
+	stats.Record(ctx, statTimersScheduled.M(1))
+	return nil
+}
+
+// HandleDueTimersMessage makes calls object scheduled for current pulse. Every timer is closed with request
+// registered for its call, ledger sends timers again on the next pulse until they are closed.
+func (lr *LogicRunner) HandleDueTimersMessage(ctx context.Context, parcel core.Parcel) (core.Reply, error) {
+	ctx = loggerWithTargetID(ctx, parcel)
+	msg, ok := parcel.Message().(*message.DueTimers)
+	if !ok {
+		return nil, errors.New("HandleDueTimersMessage( ! message.DueTimers )")
+	}
+	if err := lr.CheckOurRole(ctx, msg, core.DynamicRoleVirtualExecutor); err != nil {
+		return nil, errors.Wrap(err, "[ HandleDueTimersMessage ] can't play role")
+	}
+
+	object := *msg.DefaultTarget()
+	for id, call := range msg.Timers {
+		go lr.fireTimer(ctx, object, id, call)
+	}
+	return &reply.OK{}, nil
+}
+
+// fireTimer sends scheduled call to object and closes timer with registered request.
+func (lr *LogicRunner) fireTimer(ctx context.Context, object Ref, id core.RecordID, serialized []byte) {
+	logger := inslogger.FromContext(ctx)
+
+	generic, err := message.Deserialize(bytes.NewBuffer(serialized))
+	if err != nil {
+		logger.Error(errors.Wrapf(err, "[ fireTimer ] can't deserialize call of timer %s", id.DebugString()))
+		return
+	}
+	msg, ok := generic.(*message.CallMethod)
+	if !ok || msg.ObjectRef != object {
+		logger.Errorf("[ fireTimer ] timer %s isn't a call of %s", id.DebugString(), object)
+		return
+	}
+
+	rep, err := lr.MessageBus.Send(ctx, msg, nil)
+	if err != nil {
+		logger.Error(errors.Wrapf(err, "[ fireTimer ] can't make call of timer %s", id.DebugString()))
+		return
+	}
+	registered, ok := rep.(*reply.RegisterRequest)
+	if !ok {
+		logger.Errorf("[ fireTimer ] unexpected reply on call of timer %s: %#v", id.DebugString(), rep)
+		return
+	}
+
+	timer := core.NewRecordRef(*object.Domain(), id)
+	_, err = lr.ArtifactManager.RegisterResult(ctx, object, *timer, registered.Request.Bytes())
+	if err != nil {
+		logger.Error(errors.Wrapf(err, "[ fireTimer ] can't close timer %s", id.DebugString()))
+		return
+	}
+	stats.Record(ctx, statTimersFired.M(1))
+}
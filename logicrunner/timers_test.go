@@ -0,0 +1,103 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logicrunner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gojuno/minimock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestLogicRunner_ScheduleCall(t *testing.T) {
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+	ctx := inslogger.TestContext(t)
+
+	lr, err := NewLogicRunner(&configuration.LogicRunner{MaxTimerPulses: 10})
+	require.NoError(t, err)
+	am := testutils.NewArtifactManagerMock(mc)
+	lr.ArtifactManager = am
+
+	object := testutils.RandomRef()
+	es := &ExecutionState{Ref: object, Current: &CurrentExecution{LogicContext: &core.LogicCallContext{
+		Pulse: core.Pulse{PulseNumber: 100, NextPulseNumber: 110},
+	}}}
+	am.RegisterTimerFunc = func(
+		_ context.Context, obj core.RecordRef, pulse core.PulseNumber, msg core.Message,
+	) (*core.RecordID, error) {
+		require.Equal(t, object, obj)
+		require.Equal(t, core.PulseNumber(130), pulse)
+		call := msg.(*message.CallMethod)
+		require.Equal(t, object, call.ObjectRef)
+		require.Equal(t, object, call.Caller)
+		require.Equal(t, "Expire", call.Method)
+		require.Equal(t, message.ReturnNoWait, call.ReturnMode)
+		id := testutils.RandomID()
+		return &id, nil
+	}
+
+	base := message.BaseLogicMessage{Caller: object}
+	require.NoError(t, lr.scheduleCall(ctx, es, base, 3, "Expire", nil))
+	require.Error(t, lr.scheduleCall(ctx, es, base, 0, "Expire", nil))
+	require.Error(t, lr.scheduleCall(ctx, es, base, 11, "Expire", nil))
+	require.Equal(t, uint64(1), am.RegisterTimerCounter)
+}
+
+func TestLogicRunner_FireTimer(t *testing.T) {
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+	ctx := inslogger.TestContext(t)
+
+	lr, err := NewLogicRunner(&configuration.LogicRunner{})
+	require.NoError(t, err)
+	am := testutils.NewArtifactManagerMock(mc)
+	mb := testutils.NewMessageBusMock(mc)
+	lr.ArtifactManager = am
+	lr.MessageBus = mb
+
+	object, request, timer := testutils.RandomRef(), testutils.RandomRef(), testutils.RandomID()
+	call := &message.CallMethod{ObjectRef: object, Method: "Expire", ReturnMode: message.ReturnNoWait}
+	mb.SendFunc = func(_ context.Context, msg core.Message, _ *core.MessageSendOptions) (core.Reply, error) {
+		sent := msg.(*message.CallMethod)
+		require.Equal(t, object, sent.ObjectRef)
+		require.Equal(t, "Expire", sent.Method)
+		return &reply.RegisterRequest{Request: request}, nil
+	}
+	am.RegisterResultFunc = func(_ context.Context, obj, req core.RecordRef, payload []byte) (*core.RecordID, error) {
+		require.Equal(t, object, obj)
+		require.Equal(t, *core.NewRecordRef(*object.Domain(), timer), req)
+		require.Equal(t, request.Bytes(), payload)
+		id := testutils.RandomID()
+		return &id, nil
+	}
+
+	lr.fireTimer(ctx, object, timer, message.MustSerializeBytes(call))
+	require.Equal(t, uint64(1), am.RegisterResultCounter)
+
+	// timer with call of other object is ignored
+	lr.fireTimer(ctx, testutils.RandomRef(), timer, message.MustSerializeBytes(call))
+	require.Equal(t, uint64(1), mb.SendCounter)
+}
@@ -0,0 +1,128 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logicrunner
+
+import (
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+)
+
+// timingsSmoothing is a weight of the last sample in moving average of method duration.
+const timingsSmoothing = 0.2
+
+// methodTimings collects moving average of execution duration per method
+// and knows when the current pulse ends.
+type methodTimings struct {
+	lock     sync.RWMutex
+	average  map[string]time.Duration
+	pulseEnd time.Time
+	duration time.Duration
+}
+
+func newMethodTimings() *methodTimings {
+	return &methodTimings{
+		average: make(map[string]time.Duration),
+	}
+}
+
+// Add takes into account duration of method execution.
+func (t *methodTimings) Add(method string, d time.Duration) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	avg, ok := t.average[method]
+	if !ok {
+		t.average[method] = d
+		return
+	}
+	t.average[method] = avg + time.Duration(timingsSmoothing*float64(d-avg))
+}
+
+// Average returns moving average of method duration, false if method was never executed.
+func (t *methodTimings) Average(method string) (time.Duration, bool) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	avg, ok := t.average[method]
+	return avg, ok
+}
+
+// SetPulse estimates end of pulse started at local time now.
+func (t *methodTimings) SetPulse(pulse core.Pulse, now time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.duration = pulse.Duration()
+	if t.duration == 0 {
+		t.pulseEnd = time.Time{}
+		return
+	}
+	t.pulseEnd = now.Add(t.duration)
+}
+
+// Margin returns fraction of current pulse duration, but not less than min.
+func (t *methodTimings) Margin(min time.Duration, fraction float64) time.Duration {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	margin := time.Duration(fraction * float64(t.duration))
+	if margin < min {
+		return min
+	}
+	return margin
+}
+
+// Fits checks if method is expected to finish at least margin before pulse end.
+// Methods without history or longer than the whole pulse and unknown pulse end always fit.
+func (t *methodTimings) Fits(method string, margin time.Duration, now time.Time) bool {
+	avg, ok := t.Average(method)
+	if !ok {
+		return true
+	}
+
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if t.pulseEnd.IsZero() || avg+margin >= t.duration {
+		return true
+	}
+	return now.Add(avg + margin).Before(t.pulseEnd)
+}
+
+// timingKey returns key timings of the parcel's execution are collected by, empty for not executable parcels.
+// Methods with the same name of different prototypes are timed separately.
+func timingKey(es *ExecutionState, parcel core.Parcel) string {
+	method := timingMethod(parcel)
+	if method == "" {
+		return ""
+	}
+	return latencyPrototype(es, parcel) + "." + method
+}
+
+// timingMethod returns name of executed method, empty for not executable parcels.
+func timingMethod(parcel core.Parcel) string {
+	switch msg := parcel.Message().(type) {
+	case *message.CallMethod:
+		return msg.Method
+	case *message.CallConstructor:
+		return "constructor:" + msg.Method
+	}
+	return ""
+}
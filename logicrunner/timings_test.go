@@ -0,0 +1,91 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logicrunner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestMethodTimings(t *testing.T) {
+	timings := newMethodTimings()
+
+	_, ok := timings.Average("some")
+	require.False(t, ok)
+
+	timings.Add("some", time.Second)
+	timings.Add("some", 2*time.Second)
+	avg, ok := timings.Average("some")
+	require.True(t, ok)
+	require.Equal(t, 1200*time.Millisecond, avg)
+
+	now := time.Now()
+	// unknown pulse end
+	require.True(t, timings.Fits("some", 0, now))
+
+	timings.SetPulse(core.Pulse{PulseNumber: 100, NextPulseNumber: 110}, now)
+	require.True(t, timings.Fits("some", 0, now))
+	require.True(t, timings.Fits("some", time.Second, now.Add(7700*time.Millisecond)))
+	require.False(t, timings.Fits("some", time.Second, now.Add(7900*time.Millisecond)))
+	require.True(t, timings.Fits("unknown", time.Second, now.Add(9*time.Second)))
+
+	require.Equal(t, time.Second, timings.Margin(time.Second, 0.05))
+	require.Equal(t, 2*time.Second, timings.Margin(time.Second, 0.2))
+
+	// method longer than pulse can't fit in any pulse
+	timings.Add("long", 20*time.Second)
+	require.True(t, timings.Fits("long", 0, now))
+}
+
+func TestTimingKey(t *testing.T) {
+	proto := testutils.RandomRef()
+	other := testutils.RandomRef()
+
+	es := &ExecutionState{}
+	require.Equal(t, core.MethodLatencyOther+".some", timingKey(es, &message.Parcel{
+		Msg: &message.CallMethod{Method: "some"},
+	}))
+	require.Equal(t, proto.String()+".some", timingKey(es, &message.Parcel{
+		Msg: &message.CallMethod{Method: "some", ProxyPrototype: proto},
+	}))
+	require.Equal(t, proto.String()+".constructor:New", timingKey(es, &message.Parcel{
+		Msg: &message.CallConstructor{Method: "New", PrototypeRef: proto},
+	}))
+	require.Equal(t, "", timingKey(es, &message.Parcel{Msg: &message.GetCode{}}))
+
+	// same method of different prototypes is timed separately
+	es.objectbody = &ObjectBody{Prototype: &other}
+	require.Equal(t, other.String()+".some", timingKey(es, &message.Parcel{
+		Msg: &message.CallMethod{Method: "some", ProxyPrototype: proto},
+	}))
+	require.NotEqual(t,
+		timingKey(es, &message.Parcel{Msg: &message.CallMethod{Method: "some"}}),
+		timingKey(&ExecutionState{}, &message.Parcel{Msg: &message.CallMethod{Method: "some", ProxyPrototype: proto}}),
+	)
+}
+
+func TestTimingMethod(t *testing.T) {
+	require.Equal(t, "some", timingMethod(&message.Parcel{Msg: &message.CallMethod{Method: "some"}}))
+	require.Equal(t, "constructor:New", timingMethod(&message.Parcel{Msg: &message.CallConstructor{Method: "New"}}))
+	require.Equal(t, "", timingMethod(&message.Parcel{Msg: &message.GetCode{}}))
+}
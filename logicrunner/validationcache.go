@@ -0,0 +1,152 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logicrunner
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+)
+
+// validationFetch is a descriptor fetched once for all validations waiting on done.
+type validationFetch struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+// validationCache keeps descriptors of prototypes and code fetched while validating, so concurrent
+// validations of several objects within a pulse don't refetch them. It's reset on pulse change.
+type validationCache struct {
+	lock       sync.Mutex
+	prototypes map[Ref]*validationFetch
+	codes      map[Ref]*validationFetch
+}
+
+func newValidationCache() *validationCache {
+	return &validationCache{
+		prototypes: map[Ref]*validationFetch{},
+		codes:      map[Ref]*validationFetch{},
+	}
+}
+
+// fetch returns value cached by ref or calls fn once while other callers wait for its result.
+// Failed fetches aren't cached.
+func (c *validationCache) fetch(
+	cache func() map[Ref]*validationFetch, ref Ref, fn func() (interface{}, error),
+) (interface{}, error) {
+	c.lock.Lock()
+	fetches := cache()
+	f, ok := fetches[ref]
+	if !ok {
+		f = &validationFetch{done: make(chan struct{})}
+		fetches[ref] = f
+	}
+	c.lock.Unlock()
+
+	if ok {
+		<-f.done
+		return f.value, f.err
+	}
+
+	f.value, f.err = fn()
+	if f.err != nil {
+		c.lock.Lock()
+		if fetches := cache(); fetches[ref] == f {
+			delete(fetches, ref)
+		}
+		c.lock.Unlock()
+	}
+	close(f.done)
+	return f.value, f.err
+}
+
+func (c *validationCache) prototype(
+	ref Ref, fn func() (core.ObjectDescriptor, error),
+) (core.ObjectDescriptor, error) {
+	desc, err := c.fetch(func() map[Ref]*validationFetch { return c.prototypes }, ref, func() (interface{}, error) {
+		desc, err := fn()
+		if err != nil {
+			return nil, err
+		}
+		if !desc.HeadRef().Equal(ref) {
+			return nil, errors.Errorf("descriptor of %s is returned for prototype %s", desc.HeadRef(), ref)
+		}
+		return desc, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return desc.(core.ObjectDescriptor), nil
+}
+
+func (c *validationCache) code(ref Ref, fn func() (core.CodeDescriptor, error)) (core.CodeDescriptor, error) {
+	desc, err := c.fetch(func() map[Ref]*validationFetch { return c.codes }, ref, func() (interface{}, error) {
+		desc, err := fn()
+		if err != nil {
+			return nil, err
+		}
+		if !desc.Ref().Equal(ref) {
+			return nil, errors.Errorf("descriptor of %s is returned for code %s", desc.Ref(), ref)
+		}
+		return desc, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return desc.(core.CodeDescriptor), nil
+}
+
+func (c *validationCache) reset() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.prototypes = map[Ref]*validationFetch{}
+	c.codes = map[Ref]*validationFetch{}
+}
+
+// getValidationDescriptorsByPrototypeRef is getDescriptorsByPrototypeRef for validations. Prototype is read
+// once per pulse, code is looked up by the reference stored in that state of prototype, so code never
+// outlives state of prototype which points to it.
+func (lr *LogicRunner) getValidationDescriptorsByPrototypeRef(
+	ctx context.Context, protoRef Ref,
+) (
+	core.ObjectDescriptor, core.CodeDescriptor, error,
+) {
+	protoDesc, err := lr.validations.prototype(protoRef, func() (core.ObjectDescriptor, error) {
+		return lr.ArtifactManager.GetObject(ctx, protoRef, nil, false)
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "couldn't get prototype descriptor")
+	}
+	codeRef, err := protoDesc.Code()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "couldn't get code reference")
+	}
+	codeDesc, err := lr.validations.code(*codeRef, func() (core.CodeDescriptor, error) {
+		// we don't want to record GetCode messages because of cache
+		return lr.ArtifactManager.GetCode(core.ContextWithMessageBus(ctx, lr.MessageBus), *codeRef)
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "couldn't get code descriptor")
+	}
+
+	return protoDesc, codeDesc, nil
+}
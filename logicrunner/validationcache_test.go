@@ -0,0 +1,75 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package logicrunner
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestValidationCache(t *testing.T) {
+	c := newValidationCache()
+	proto := testutils.RandomRef()
+	desc := testutils.NewObjectDescriptorMock(t)
+	desc.HeadRefMock.Return(&proto)
+
+	var fetches int32
+	fetch := func() (core.ObjectDescriptor, error) {
+		atomic.AddInt32(&fetches, 1)
+		return desc, nil
+	}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cached, err := c.prototype(proto, fetch)
+			require.NoError(t, err)
+			require.Equal(t, desc, cached)
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, int32(1), fetches, "concurrent validations share fetch")
+
+	c.reset()
+	_, err := c.prototype(proto, fetch)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), fetches, "cache is reset on pulse")
+
+	other := testutils.RandomRef()
+	_, err = c.prototype(other, fetch)
+	require.Error(t, err, "descriptor of other prototype is rejected")
+
+	_, err = c.prototype(other, func() (core.ObjectDescriptor, error) {
+		return nil, errors.New("failed")
+	})
+	require.Error(t, err)
+	otherDesc := testutils.NewObjectDescriptorMock(t)
+	otherDesc.HeadRefMock.Return(&other)
+	cached, err := c.prototype(other, func() (core.ObjectDescriptor, error) {
+		return otherDesc, nil
+	})
+	require.NoError(t, err, "failures aren't cached")
+	require.Equal(t, otherDesc, cached)
+}
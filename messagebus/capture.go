@@ -0,0 +1,197 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package messagebus
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/ugorji/go/codec"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// Capture is a record of messages processed by node during a single pulse. Together with a copy of node's ledger
+// storage it is enough to reproduce message processing offline, see Replayer.
+type Capture struct {
+	Pulse  core.Pulse
+	Origin core.RecordRef
+	Nodes  []CapturedNode
+
+	// Inbound are parcels delivered to the node handlers in order of delivery.
+	Inbound []CapturedMessage
+	// Outbound are parcels sent by the node in order of receiving replies.
+	Outbound []CapturedMessage
+}
+
+// CapturedNode is an active node of the captured pulse.
+type CapturedNode struct {
+	ID   core.RecordRef
+	Role core.StaticRole
+}
+
+// CapturedMessage is a parcel with its reply or error.
+type CapturedMessage struct {
+	Parcel []byte
+	Reply  []byte
+	Error  string
+}
+
+func newCapturedMessage(parcel core.Parcel, rep core.Reply, err error) CapturedMessage {
+	captured := CapturedMessage{
+		Parcel: message.ParcelToBytes(parcel),
+	}
+	if rep != nil {
+		captured.Reply = reply.ToBytes(rep)
+	}
+	if err != nil {
+		captured.Error = err.Error()
+	}
+	return captured
+}
+
+// GetParcel deserializes captured parcel.
+func (m *CapturedMessage) GetParcel() (core.Parcel, error) {
+	return message.DeserializeParcel(bytes.NewReader(m.Parcel))
+}
+
+// GetReply deserializes captured reply and error.
+func (m *CapturedMessage) GetReply() (core.Reply, error) {
+	var err error
+	if m.Error != "" {
+		err = errors.New(m.Error)
+	}
+	if m.Reply == nil {
+		return nil, err
+	}
+	rep, decodeErr := reply.Deserialize(bytes.NewReader(m.Reply))
+	if decodeErr != nil {
+		return nil, errors.Wrap(decodeErr, "[ CapturedMessage ] can't read reply")
+	}
+	return rep, err
+}
+
+// ReadCapture reads capture written by Write.
+func ReadCapture(r io.Reader) (*Capture, error) {
+	c := Capture{}
+	err := codec.NewDecoder(r, new(codec.CborHandle)).Decode(&c)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ Capture ] can't read capture")
+	}
+	return &c, nil
+}
+
+// Write writes capture to the provided writer.
+func (c *Capture) Write(w io.Writer) error {
+	err := codec.NewEncoder(w, new(codec.CborHandle)).Encode(c)
+	if err != nil {
+		return errors.Wrap(err, "[ Capture ] can't write capture")
+	}
+	return nil
+}
+
+// capturer records parcels passing through MessageBus and saves them to a file on every pulse.
+type capturer struct {
+	dir string
+
+	lock    sync.Mutex
+	current *Capture
+}
+
+func newCapturer(dir string) *capturer {
+	return &capturer{dir: dir}
+}
+
+// begin finishes capture of the previous pulse and starts capturing the new one.
+func (c *capturer) begin(ctx context.Context, pulse core.Pulse, origin core.Node, nodes []core.Node) {
+	next := &Capture{
+		Pulse:  pulse,
+		Origin: origin.ID(),
+	}
+	for _, node := range nodes {
+		next.Nodes = append(next.Nodes, CapturedNode{ID: node.ID(), Role: node.Role()})
+	}
+
+	c.lock.Lock()
+	prev := c.current
+	c.current = next
+	c.lock.Unlock()
+
+	if prev == nil {
+		return
+	}
+	go func() {
+		err := c.save(prev)
+		if err != nil {
+			inslogger.FromContext(ctx).Error(errors.Wrapf(err, "failed to save capture of pulse %d", prev.Pulse.PulseNumber))
+		}
+	}()
+}
+
+func (c *capturer) inbound(parcel core.Parcel, rep core.Reply, err error) {
+	captured := newCapturedMessage(parcel, rep, err)
+	c.lock.Lock()
+	if c.current != nil {
+		c.current.Inbound = append(c.current.Inbound, captured)
+	}
+	c.lock.Unlock()
+}
+
+func (c *capturer) outbound(parcel core.Parcel, rep core.Reply, err error) {
+	captured := newCapturedMessage(parcel, rep, err)
+	c.lock.Lock()
+	if c.current != nil {
+		c.current.Outbound = append(c.current.Outbound, captured)
+	}
+	c.lock.Unlock()
+}
+
+func (c *capturer) save(capture *Capture) error {
+	err := os.MkdirAll(c.dir, 0755)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(c.dir, "capture")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // nolint: errcheck
+
+	err = capture.Write(tmp)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(c.dir, CaptureFileName(capture.Pulse.PulseNumber)))
+}
+
+// CaptureFileName returns name of the capture file for pulse.
+func CaptureFileName(pn core.PulseNumber) string {
+	return fmt.Sprintf("%d.capture", pn)
+}
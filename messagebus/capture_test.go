@@ -0,0 +1,130 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package messagebus
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/instrumentation/instracer"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestCapture_WriteRead(t *testing.T) {
+	dir, err := ioutil.TempDir("", "capture")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	c := newCapturer(dir)
+	c.current = &Capture{
+		Pulse:  core.Pulse{PulseNumber: core.FirstPulseNumber + 1},
+		Origin: testutils.RandomRef(),
+		Nodes:  []CapturedNode{{ID: testutils.RandomRef(), Role: core.StaticRoleVirtual}},
+	}
+	c.inbound(&message.Parcel{Msg: &message.GenesisRequest{Name: "in"}}, &reply.OK{}, nil)
+	c.outbound(&message.Parcel{Msg: &message.GetCode{Code: testutils.RandomRef()}}, nil, errors.New("failed"))
+
+	err = c.save(c.current)
+	require.NoError(t, err)
+
+	f, err := os.Open(filepath.Join(dir, CaptureFileName(core.FirstPulseNumber+1)))
+	require.NoError(t, err)
+	defer f.Close()
+	read, err := ReadCapture(f)
+	require.NoError(t, err)
+	assert.Equal(t, c.current, read)
+
+	rep, err := read.Inbound[0].GetReply()
+	require.NoError(t, err)
+	assert.Equal(t, &reply.OK{}, rep)
+
+	rep, err = read.Outbound[0].GetReply()
+	assert.Nil(t, rep)
+	assert.EqualError(t, err, "failed")
+}
+
+// tracedParcel returns parcel with trace span data, like the ones bus captures.
+func tracedParcel(ctx context.Context, msg core.Message) *message.Parcel {
+	return &message.Parcel{Msg: msg, TraceSpanData: instracer.MustSerialize(ctx)}
+}
+
+func TestReplayer(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	code := testutils.RandomRef()
+	codeReply := &reply.Code{Code: []byte{1, 2, 3}}
+	capture := &Capture{
+		Inbound: []CapturedMessage{
+			newCapturedMessage(tracedParcel(ctx, &message.GenesisRequest{Name: "same"}), &reply.OK{}, nil),
+			newCapturedMessage(tracedParcel(ctx, &message.GenesisRequest{Name: "changed"}), &reply.OK{}, nil),
+			newCapturedMessage(tracedParcel(ctx, &message.GetCode{Code: code}), codeReply, nil),
+		},
+		Outbound: []CapturedMessage{
+			newCapturedMessage(&message.Parcel{Msg: &message.GetCode{Code: code}, Signature: []byte{1}}, codeReply, nil),
+		},
+	}
+	r, err := NewReplayer(capture, platformpolicy.NewPlatformCryptographyScheme())
+	require.NoError(t, err)
+
+	r.MustRegister(core.TypeBootstrapRequest, func(ctx context.Context, parcel core.Parcel) (core.Reply, error) {
+		if parcel.Message().(*message.GenesisRequest).Name == "changed" {
+			return nil, errors.New("diverged")
+		}
+		return &reply.OK{}, nil
+	})
+
+	assert.Equal(t, map[core.MessageType]int{core.TypeGetCode: 1}, r.Unsent())
+
+	rep, err := r.Send(ctx, &message.GetCode{Code: code}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, codeReply, rep)
+
+	_, err = r.Send(ctx, &message.GetCode{Code: code}, nil)
+	assert.Error(t, err)
+	assert.Equal(t, []core.MessageType{core.TypeGetCode}, r.Unexpected())
+	assert.Empty(t, r.Unsent())
+
+	result, err := r.Replay(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Delivered)
+	assert.Equal(t, 1, result.Skipped)
+	require.Len(t, result.Mismatches, 1)
+	assert.Equal(t, ReplayMismatch{
+		Index:    1,
+		Type:     core.TypeBootstrapRequest,
+		Expected: "*reply.OK",
+		Got:      "error: diverged",
+	}, result.Mismatches[0])
+
+	var buf bytes.Buffer
+	require.NoError(t, capture.Write(&buf))
+	read, err := ReadCapture(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, capture, read)
+}
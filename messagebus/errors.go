@@ -23,4 +23,6 @@ import (
 var (
 	// ErrNoReply is returned from player when there is no stored reply for provided message.
 	ErrNoReply = errors.New("no such reply")
+	// ErrParcelExpired is returned when parcel is delivered after its TTL is over.
+	ErrParcelExpired = errors.New("parcel is expired")
 )
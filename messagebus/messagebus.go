@@ -32,6 +32,7 @@ import (
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/core/message"
 	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/instrumentation/crashreport"
 	"github.com/insolar/insolar/instrumentation/hack"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/instrumentation/insmetrics"
@@ -52,9 +53,19 @@ type MessageBus struct {
 	DelegationTokenFactory     core.DelegationTokenFactory     `inject:""`
 	ParcelFactory              message.ParcelFactory           `inject:""`
 	PulseStorage               core.PulseStorage               `inject:""`
+	KeyStore                   core.KeyStore                   `inject:""`
 
 	handlers     map[core.MessageType]core.MessageHandler
 	signmessages bool
+	usesessions  bool
+	parcelTTL    uint32
+	sessionKeys  *sessionKeys
+	capture      *capturer
+	traceTargets *traceTargets
+
+	sendStatsLock sync.Mutex
+	sendStats     core.SendStats
+	lastSendStats core.SendStats
 
 	globalLock                  sync.RWMutex
 	NextPulseMessagePoolChan    chan interface{}
@@ -68,8 +79,14 @@ func NewMessageBus(config configuration.Configuration) (*MessageBus, error) {
 	mb := &MessageBus{
 		handlers:                 map[core.MessageType]core.MessageHandler{},
 		signmessages:             config.Host.SignMessages,
+		usesessions:              config.Host.SignMessages && config.Host.SessionKeys,
+		parcelTTL:                config.Host.ParcelTTL,
+		traceTargets:             newTraceTargets(),
 		NextPulseMessagePoolChan: make(chan interface{}),
 	}
+	if config.Capture.Dir != "" {
+		mb.capture = newCapturer(config.Capture.Dir)
+	}
 	mb.Lock(context.Background())
 	return mb, nil
 }
@@ -100,6 +117,17 @@ func (mb *MessageBus) NewRecorder(ctx context.Context, currentPulse core.Pulse)
 func (mb *MessageBus) Start(ctx context.Context) error {
 	mb.Network.RemoteProcedureRegister(deliverRPCMethodName, mb.deliver)
 
+	if mb.usesessions {
+		privateKey, err := mb.KeyStore.GetPrivateKey("")
+		if err != nil {
+			return errors.Wrap(err, "[ MessageBus.Start ] failed to get node key")
+		}
+		mb.sessionKeys, err = newSessionKeys(mb.NodeNetwork.GetOrigin().ID(), privateKey)
+		if err != nil {
+			inslogger.FromContext(ctx).Warn("Session keys are disabled: ", err)
+		}
+	}
+
 	return nil
 }
 
@@ -138,6 +166,7 @@ func (mb *MessageBus) MustRegister(p core.MessageType, handler core.MessageHandl
 
 // Send an `Message` and get a `Value` or error from remote host.
 func (mb *MessageBus) Send(ctx context.Context, msg core.Message, ops *core.MessageSendOptions) (core.Reply, error) {
+	ctx = mb.traceFlow(ctx, msg)
 	ctx, span := instracer.StartSpan(ctx, "MessageBus.Send "+msg.Type().String())
 	defer span.End()
 
@@ -157,7 +186,32 @@ func (mb *MessageBus) Send(ctx context.Context, msg core.Message, ops *core.Mess
 
 // CreateParcel creates signed message from provided message.
 func (mb *MessageBus) CreateParcel(ctx context.Context, msg core.Message, token core.DelegationToken, currentPulse core.Pulse) (core.Parcel, error) {
-	return mb.ParcelFactory.Create(ctx, msg, mb.NodeNetwork.GetOrigin().ID(), token, currentPulse)
+	parcel, err := mb.ParcelFactory.Create(ctx, msg, mb.NodeNetwork.GetOrigin().ID(), token, currentPulse)
+	if err != nil {
+		return nil, err
+	}
+	if p, ok := parcel.(*message.Parcel); ok {
+		p.TTL = mb.parcelTTL
+	}
+	return parcel, nil
+}
+
+// queryRole calculates receivers of role, in globule from options if it is set.
+func (mb *MessageBus) queryRole(
+	ctx context.Context,
+	role core.DynamicRole,
+	obj core.RecordID,
+	pulse core.PulseNumber,
+	options *core.MessageSendOptions,
+) ([]core.RecordRef, error) {
+	if options == nil || options.Globule == nil {
+		return mb.JetCoordinator.QueryRole(ctx, role, obj, pulse)
+	}
+	querier, ok := mb.JetCoordinator.(core.GlobuleRoleQuerier)
+	if !ok {
+		return nil, errors.New("[ MessageBus.queryRole ] jet coordinator doesn't support globule routing")
+	}
+	return querier.QueryRoleInGlobule(ctx, *options.Globule, role, obj, pulse)
 }
 
 // SendParcel sends provided message via network.
@@ -166,13 +220,30 @@ func (mb *MessageBus) SendParcel(
 	parcel core.Parcel,
 	currentPulse core.Pulse,
 	options *core.MessageSendOptions,
+) (core.Reply, error) {
+	rep, err := mb.sendParcel(ctx, parcel, currentPulse, options)
+	mb.countSend(err)
+	if mb.capture != nil {
+		mb.capture.outbound(parcel, rep, err)
+	}
+	return rep, err
+}
+
+func (mb *MessageBus) sendParcel(
+	ctx context.Context,
+	parcel core.Parcel,
+	currentPulse core.Pulse,
+	options *core.MessageSendOptions,
 ) (core.Reply, error) {
 	parcelType := parcel.Type().String()
 	ctx, span := instracer.StartSpan(ctx, "MessageBus.SendParcel "+parcelType)
 	ctx = insmetrics.InsertTag(ctx, tagMessageType, parcelType)
 	defer span.End()
 
-	readBarrier(ctx, &mb.globalLock)
+	// Pulse-critical messages are sent while pulse is switching, they must not wait for the switch to finish.
+	if !message.IsPriority(parcel.Type()) {
+		readBarrier(ctx, &mb.globalLock)
+	}
 
 	var (
 		nodes []core.RecordRef
@@ -187,7 +258,7 @@ func (mb *MessageBus) SendParcel(
 		if target == nil {
 			target = &core.RecordRef{}
 		}
-		nodes, err = mb.JetCoordinator.QueryRole(ctx, parcel.DefaultRole(), *target.Record(), currentPulse.PulseNumber)
+		nodes, err = mb.queryRole(ctx, parcel.DefaultRole(), *target.Record(), currentPulse.PulseNumber, options)
 		if err != nil {
 			return nil, err
 		}
@@ -217,23 +288,69 @@ func (mb *MessageBus) SendParcel(
 		return mb.doDeliver(parcel.Context(context.Background()), parcel)
 	}
 
-	res, err := mb.Network.SendMessage(nodes[0], deliverRPCMethodName, parcel)
+	res, err := mb.Network.SendMessage(nodes[0], deliverRPCMethodName, mb.seal(ctx, parcel, nodes[0]))
 	if err != nil {
 		return nil, err
 	}
 
-	return reply.Deserialize(bytes.NewBuffer(res))
+	rep, err := reply.Deserialize(bytes.NewBuffer(res))
+	if err != nil {
+		return nil, err
+	}
+	if remoteErr, ok := rep.(*reply.RemoteError); ok {
+		return nil, remoteErr.Error()
+	}
+	return rep, nil
+}
+
+// seal replaces signature of parcel with session key MAC if sender and receiver have already exchanged signed parcels
+// in parcel's pulse.
+func (mb *MessageBus) seal(ctx context.Context, parcel core.Parcel, receiver core.RecordRef) core.Parcel {
+	if mb.sessionKeys == nil {
+		return parcel
+	}
+	p, ok := parcel.(*message.Parcel)
+	if !ok || mb.sessionKeys.needSignature(receiver, p.Type(), p.PulseNumber) {
+		return parcel
+	}
+	node := mb.NodeNetwork.GetWorkingNode(receiver)
+	if node == nil {
+		return parcel
+	}
+	sealed, err := mb.sessionKeys.seal(receiver, node.PublicKey(), p)
+	if err != nil {
+		inslogger.FromContext(ctx).Warn("Failed to seal parcel with session key: ", err)
+		return parcel
+	}
+	return sealed
 }
 
-type serializableError struct {
-	S string
+// GetSendStats implements core.SendStatsProvider.
+func (mb *MessageBus) GetSendStats() core.SendStats {
+	mb.sendStatsLock.Lock()
+	defer mb.sendStatsLock.Unlock()
+	return mb.lastSendStats
 }
 
-func (e *serializableError) Error() string {
-	return e.S
+func (mb *MessageBus) countSend(err error) {
+	mb.sendStatsLock.Lock()
+	defer mb.sendStatsLock.Unlock()
+	mb.sendStats.Sent++
+	if err != nil {
+		mb.sendStats.Failed++
+	}
 }
 
-func (mb *MessageBus) OnPulse(context.Context, core.Pulse) error {
+func (mb *MessageBus) OnPulse(ctx context.Context, pulse core.Pulse) error {
+	if mb.capture != nil {
+		mb.capture.begin(ctx, pulse, mb.NodeNetwork.GetOrigin(), mb.NodeNetwork.GetWorkingNodes())
+	}
+
+	mb.sendStatsLock.Lock()
+	mb.lastSendStats = mb.sendStats
+	mb.sendStats = core.SendStats{}
+	mb.sendStatsLock.Unlock()
+
 	close(mb.NextPulseMessagePoolChan)
 
 	mb.NextPulseMessagePoolLock.Lock()
@@ -244,9 +361,15 @@ func (mb *MessageBus) OnPulse(context.Context, core.Pulse) error {
 	return nil
 }
 
-func (mb *MessageBus) doDeliver(ctx context.Context, msg core.Parcel) (core.Reply, error) {
+func (mb *MessageBus) doDeliver(ctx context.Context, msg core.Parcel) (rep core.Reply, err error) {
+	if mb.capture != nil {
+		defer func() {
+			mb.capture.inbound(msg, rep, err)
+		}()
+	}
+	defer crashreport.RecoverError(ctx, "MessageBus.doDeliver", &err)
 
-	var err error
+	ctx = mb.traceFlow(ctx, msg)
 	ctx, span := instracer.StartSpan(ctx, "MessageBus.doDeliver")
 	defer span.End()
 	if err = mb.checkPulse(ctx, msg, false); err != nil {
@@ -271,9 +394,7 @@ func (mb *MessageBus) doDeliver(ctx context.Context, msg core.Parcel) (core.Repl
 
 	resp, err := handler(ctx, msg)
 	if err != nil {
-		return nil, &serializableError{
-			S: err.Error(),
-		}
+		return nil, core.ToCodedError(err, msg.Type().String())
 	}
 
 	return resp, nil
@@ -292,6 +413,14 @@ func (mb *MessageBus) checkPulse(ctx context.Context, parcel core.Parcel, locked
 	if ppn > pulse.PulseNumber {
 		return mb.handleParcelFromTheFuture(ctx, parcel, locked)
 	} else if ppn < pulse.PulseNumber {
+		if parcelExpired(parcel, pulse) {
+			stats.Record(insmetrics.InsertTag(ctx, tagMessageType, parcel.Type().String()), statParcelsExpiredTotal.M(1))
+			inslogger.FromContext(ctx).Warnf(
+				"[ checkPulse ] Parcel %s is expired and dropped (parcel: %d, current: %d)",
+				parcel.Type(), ppn, pulse.PulseNumber,
+			)
+			return ErrParcelExpired
+		}
 		if ppn < pulse.PrevPulseNumber {
 			inslogger.FromContext(ctx).Errorf(
 				"[ checkPulse ] Pulse is TOO OLD: (parcel: %d, current: %d) Parcel is: %#v",
@@ -323,6 +452,18 @@ func (mb *MessageBus) checkPulse(ctx context.Context, parcel core.Parcel, locked
 	return nil
 }
 
+// parcelExpired checks if parcel was sent more than its TTL pulses ago. Age of parcel in pulses is estimated
+// with pulse number delta of current pulse.
+func parcelExpired(parcel core.Parcel, pulse *core.Pulse) bool {
+	p, ok := parcel.(*message.Parcel)
+	if !ok || p.TTL == 0 || pulse.PrevPulseNumber == 0 || pulse.PrevPulseNumber >= pulse.PulseNumber {
+		return false
+	}
+	delta := pulse.PulseNumber - pulse.PrevPulseNumber
+	age := (pulse.PulseNumber - p.PulseNumber) / delta
+	return uint32(age) > p.TTL
+}
+
 func (mb *MessageBus) handleParcelFromTheFuture(ctx context.Context, parcel core.Parcel, locked bool) error {
 	ctx, span := instracer.StartSpan(ctx, "MessageBus.handleParcelFromTheFuture")
 	defer span.End()
@@ -388,20 +529,29 @@ func (mb *MessageBus) deliver(ctx context.Context, args [][]byte) (result []byte
 
 	if err = mb.checkPulse(parcelCtx, parcel, true); err != nil {
 		mb.globalLock.RUnlock()
-		return nil, err
+		return remoteError(err, "MessageBus")
 	}
 
 	if err = mb.checkParcel(parcelCtx, parcel); err != nil {
 		mb.globalLock.RUnlock()
-		return nil, err
+		return remoteError(err, "MessageBus")
 	}
 	mb.globalLock.RUnlock()
 
 	resp, err := mb.doDeliver(parcelCtx, parcel)
 	if err != nil {
-		return nil, err
+		return remoteError(err, parcel.Type().String())
 	}
 
+	return serializeReply(resp)
+}
+
+// remoteError serializes error of message processing into reply, so sender gets it with error code.
+func remoteError(err error, component string) ([]byte, error) {
+	return serializeReply(reply.NewRemoteError(core.ToCodedError(err, component)))
+}
+
+func serializeReply(resp core.Reply) ([]byte, error) {
 	rd, err := reply.Serialize(resp)
 	if err != nil {
 		return nil, err
@@ -416,10 +566,22 @@ func (mb *MessageBus) deliver(ctx context.Context, args [][]byte) (result []byte
 
 func (mb *MessageBus) checkParcel(ctx context.Context, parcel core.Parcel) error {
 	sender := parcel.GetSender()
+	senderNode := mb.NodeNetwork.GetWorkingNode(sender)
+
+	if err := checkSenderRole(senderNode, parcel); err != nil {
+		return err
+	}
 
 	if mb.signmessages {
-		senderKey := mb.NodeNetwork.GetWorkingNode(sender).PublicKey()
-		if err := mb.ParcelFactory.Validate(senderKey, parcel); err != nil {
+		senderKey := senderNode.PublicKey()
+		if p, ok := parcel.(*message.Parcel); ok && len(p.SessionMAC) > 0 {
+			if mb.sessionKeys == nil {
+				return errors.New("failed to check a message MAC: session keys are disabled")
+			}
+			if err := mb.sessionKeys.verify(sender, senderKey, p); err != nil {
+				return errors.Wrap(err, "failed to check a message MAC")
+			}
+		} else if err := mb.ParcelFactory.Validate(senderKey, parcel); err != nil {
 			return errors.Wrap(err, "failed to check a message sign")
 		}
 	}
@@ -467,5 +629,5 @@ func readBarrier(ctx context.Context, mutex *sync.RWMutex) {
 }
 
 func init() {
-	gob.Register(&serializableError{})
+	gob.Register(&core.CodedError{})
 }
@@ -22,6 +22,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/insolar/insolar/component"
@@ -65,7 +66,9 @@ func prepare(t *testing.T, ctx context.Context, currentPulse int, msgPulse int)
 	pf := NewParcelFactory()
 	ps := testutils.NewPulseStorageMock(t)
 
-	(&component.Manager{}).Inject(net, jc, ls, nn, pcs, cs, dtf, pf, ps, mb)
+	ks := &testKeyStore{}
+
+	(&component.Manager{}).Inject(net, jc, ls, nn, pcs, cs, dtf, pf, ps, ks, mb)
 
 	ps.CurrentFunc = func(ctx context.Context) (*core.Pulse, error) {
 		return &core.Pulse{
@@ -113,6 +116,22 @@ func TestMessageBus_doDeliver_SamePulse(t *testing.T) {
 	require.Equal(t, testReply, result)
 }
 
+func TestMessageBus_doDeliver_HandlerError(t *testing.T) {
+	ctx := context.Background()
+	mb, _, parcel := prepare(t, ctx, 100, 100)
+	mb.handlers[testType] = func(ctx context.Context, msg core.Parcel) (core.Reply, error) {
+		return nil, errors.Wrap(core.ErrInvalidStateRecord, "[ handleUpdateObject ]")
+	}
+
+	result, err := mb.doDeliver(ctx, parcel)
+	require.Nil(t, result)
+	coded, ok := err.(*core.CodedError)
+	require.True(t, ok)
+	require.Equal(t, core.ErrCodeInvalidStateRecord, coded.Code)
+	require.Equal(t, testType.String(), coded.Component)
+	require.Equal(t, "[ handleUpdateObject ]: invalid state record", coded.Message)
+}
+
 func TestMessageBus_doDeliver_NextPulse(t *testing.T) {
 	ctx := context.Background()
 	mb, ps, parcel := prepare(t, ctx, 100, 101)
@@ -173,3 +192,43 @@ func TestMessageBus_doDeliver_TwoAheadPulses(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, core.PulseNumber(102), pulse.PulseNumber)
 }
+
+func TestParcelExpired(t *testing.T) {
+	pulse := &core.Pulse{PulseNumber: 100, PrevPulseNumber: 90}
+
+	require.False(t, parcelExpired(&message.Parcel{PulseNumber: 90, TTL: 2}, pulse))
+	require.False(t, parcelExpired(&message.Parcel{PulseNumber: 80, TTL: 2}, pulse))
+	require.True(t, parcelExpired(&message.Parcel{PulseNumber: 70, TTL: 2}, pulse))
+	require.False(t, parcelExpired(&message.Parcel{PulseNumber: 10}, pulse), "zero TTL disables expiration")
+	require.False(t, parcelExpired(&message.Parcel{PulseNumber: 70, TTL: 2}, &core.Pulse{PulseNumber: 100}))
+	require.False(t, parcelExpired(testutils.NewParcelMock(t), pulse))
+}
+
+func TestMessageBus_doDeliver_ExpiredParcel(t *testing.T) {
+	ctx := context.Background()
+	mb, ps, _ := prepare(t, ctx, 100, 100)
+	ps.CurrentFunc = func(ctx context.Context) (*core.Pulse, error) {
+		return &core.Pulse{PulseNumber: 100, PrevPulseNumber: 90, NextPulseNumber: 110}, nil
+	}
+
+	result, err := mb.doDeliver(ctx, &message.Parcel{Msg: &message.CallMethod{}, PulseNumber: 60, TTL: 2})
+	require.Error(t, err)
+	require.Equal(t, ErrParcelExpired, errors.Cause(err))
+	require.Nil(t, result)
+}
+
+func TestMessageBus_GetSendStats(t *testing.T) {
+	ctx := context.Background()
+	mb, _, _ := prepare(t, ctx, 100, 100)
+
+	mb.countSend(nil)
+	mb.countSend(errors.New("send failed"))
+	mb.countSend(nil)
+	require.Equal(t, core.SendStats{}, mb.GetSendStats(), "stats of current pulse aren't reported")
+
+	require.NoError(t, mb.OnPulse(ctx, core.Pulse{PulseNumber: 101}))
+	require.Equal(t, core.SendStats{Sent: 3, Failed: 1}, mb.GetSendStats())
+
+	require.NoError(t, mb.OnPulse(ctx, core.Pulse{PulseNumber: 102}))
+	require.Equal(t, core.SendStats{}, mb.GetSendStats())
+}
@@ -39,6 +39,11 @@ var (
 		"total number of parcels delivered to the same machine",
 		stats.UnitDimensionless,
 	)
+	statParcelsExpiredTotal = stats.Int64(
+		"messagebus/parcels/expired/count",
+		"number of received parcels dropped because their TTL is over",
+		stats.UnitDimensionless,
+	)
 	statParcelsTime = stats.Float64(
 		"messagebus/parcels/time",
 		"time spent on sending parcels",
@@ -47,7 +52,7 @@ var (
 )
 
 func init() {
-	err := view.Register(
+	err := insmetrics.Register(
 		&view.View{
 			Measure:     statParcelsSentTotal,
 			Aggregation: view.Sum(),
@@ -58,6 +63,11 @@ func init() {
 			Aggregation: view.Count(),
 			TagKeys:     []tag.Key{tagMessageType},
 		},
+		&view.View{
+			Measure:     statParcelsExpiredTotal,
+			Aggregation: view.Sum(),
+			TagKeys:     []tag.Key{tagMessageType},
+		},
 		&view.View{
 			Measure:     statParcelsTime,
 			Aggregation: view.Distribution(0.001, 0.01, 0.1, 1, 10, 100, 1000, 5000, 10000, 20000),
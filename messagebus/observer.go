@@ -0,0 +1,50 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package messagebus
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+)
+
+// observerMessages are messages which nodes with observer role are allowed to send. Observers only read data.
+var observerMessages = map[core.MessageType]bool{
+	core.TypeGetCode:            true,
+	core.TypeGetObject:          true,
+	core.TypeGetDelegate:        true,
+	core.TypeGetChildren:        true,
+	core.TypeGetObjectIndex:     true,
+	core.TypeGetPendingRequests: true,
+	core.TypeGetJet:             true,
+	core.TypeGetRequest:         true,
+	core.TypeGetResult:          true,
+	core.TypeGetNodeVersion:     true,
+	core.TypeGetTimeline:        true,
+	core.TypeRestartSlot:        true,
+}
+
+// checkSenderRole rejects parcels which sender's role is not permitted to send.
+func checkSenderRole(sender core.Node, parcel core.Parcel) error {
+	if sender == nil || sender.Role() != core.StaticRoleObserver {
+		return nil
+	}
+	if !observerMessages[parcel.Type()] {
+		return errors.Errorf("observer node %s isn't allowed to send message of type %s", sender.ID(), parcel.Type())
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package messagebus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/testutils"
+	"github.com/insolar/insolar/testutils/network"
+)
+
+func TestMessageBus_checkParcel_Observer(t *testing.T) {
+	ctx := context.Background()
+	senderRef := testutils.RandomRef()
+
+	senderNode := network.NewNodeMock(t)
+	senderNode.RoleMock.Return(core.StaticRoleObserver)
+	senderNode.IDMock.Return(senderRef)
+	nn := network.NewNodeNetworkMock(t)
+	nn.GetWorkingNodeMock.Expect(senderRef).Return(senderNode)
+
+	mb := &MessageBus{NodeNetwork: nn}
+
+	read := &message.Parcel{Msg: &message.GetObject{}, Sender: senderRef}
+	require.NoError(t, mb.checkParcel(ctx, read))
+
+	write := &message.Parcel{Msg: &message.UpdateObject{}, Sender: senderRef}
+	require.Error(t, mb.checkParcel(ctx, write))
+
+	call := &message.Parcel{Msg: &message.CallMethod{}, Sender: senderRef}
+	require.Error(t, mb.checkParcel(ctx, call))
+}
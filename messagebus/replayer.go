@@ -0,0 +1,205 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package messagebus
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/core/reply"
+)
+
+// Replayer is a MessageBus that reproduces captured pulse offline. Messages sent by components are answered with
+// captured replies instead of going to the network, captured inbound parcels are delivered to registered handlers
+// by Replay.
+type Replayer struct {
+	capture *Capture
+	scheme  core.PlatformCryptographyScheme
+
+	handlers map[core.MessageType]core.MessageHandler
+
+	lock       sync.Mutex
+	replies    map[string][]CapturedMessage
+	unexpected []core.MessageType
+}
+
+// ReplayMismatch describes inbound parcel which got a reply different from the captured one.
+type ReplayMismatch struct {
+	Index    int
+	Type     core.MessageType
+	Expected string
+	Got      string
+}
+
+// ReplayResult is an outcome of replaying captured inbound parcels.
+type ReplayResult struct {
+	Delivered  int
+	Skipped    int
+	Mismatches []ReplayMismatch
+}
+
+// NewReplayer creates replayer for the capture.
+func NewReplayer(capture *Capture, scheme core.PlatformCryptographyScheme) (*Replayer, error) {
+	r := &Replayer{
+		capture:  capture,
+		scheme:   scheme,
+		handlers: map[core.MessageType]core.MessageHandler{},
+		replies:  map[string][]CapturedMessage{},
+	}
+	for i, captured := range capture.Outbound {
+		parcel, err := captured.GetParcel()
+		if err != nil {
+			return nil, errors.Wrapf(err, "[ NewReplayer ] can't read outbound parcel %d", i)
+		}
+		hash := string(r.hash(parcel.Message()))
+		r.replies[hash] = append(r.replies[hash], captured)
+	}
+	return r, nil
+}
+
+// Send returns captured reply for the message. Messages are matched by content, so replay does not depend on
+// parcel signatures and order of concurrent sends.
+func (r *Replayer) Send(ctx context.Context, msg core.Message, ops *core.MessageSendOptions) (core.Reply, error) {
+	hash := string(r.hash(msg))
+
+	r.lock.Lock()
+	queue := r.replies[hash]
+	if len(queue) == 0 {
+		r.unexpected = append(r.unexpected, msg.Type())
+		r.lock.Unlock()
+		return nil, errors.Errorf("[ Replayer.Send ] message %s was not captured", msg.Type())
+	}
+	captured := queue[0]
+	r.replies[hash] = queue[1:]
+	r.lock.Unlock()
+
+	return captured.GetReply()
+}
+
+// Register saves message handler to deliver captured parcels to.
+func (r *Replayer) Register(p core.MessageType, handler core.MessageHandler) error {
+	if _, ok := r.handlers[p]; ok {
+		return errors.New("handler for this type already exists")
+	}
+	r.handlers[p] = handler
+	return nil
+}
+
+// MustRegister is a wrapper for Register method that panics if an error was returned.
+func (r *Replayer) MustRegister(p core.MessageType, handler core.MessageHandler) {
+	err := r.Register(p, handler)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// NewPlayer is not supported by replayer.
+func (r *Replayer) NewPlayer(ctx context.Context, reader io.Reader) (core.MessageBus, error) {
+	return nil, errors.New("[ Replayer ] player is not supported in replay")
+}
+
+// NewRecorder returns replayer itself, replies are already captured.
+func (r *Replayer) NewRecorder(ctx context.Context, currentPulse core.Pulse) (core.MessageBus, error) {
+	return r, nil
+}
+
+// OnPulse does nothing, replay is bound to the captured pulse.
+func (r *Replayer) OnPulse(context.Context, core.Pulse) error {
+	return nil
+}
+
+// Replay delivers captured inbound parcels to registered handlers in order of capture and compares replies. Parcels
+// without a handler are skipped.
+func (r *Replayer) Replay(ctx context.Context) (*ReplayResult, error) {
+	result := &ReplayResult{}
+	for i, captured := range r.capture.Inbound {
+		parcel, err := captured.GetParcel()
+		if err != nil {
+			return nil, errors.Wrapf(err, "[ Replay ] can't read inbound parcel %d", i)
+		}
+		handler, ok := r.handlers[parcel.Type()]
+		if !ok {
+			result.Skipped++
+			continue
+		}
+
+		rep, err := handler(parcel.Context(ctx), parcel)
+		result.Delivered++
+
+		got := newCapturedMessage(parcel, rep, err)
+		if captured.Error != got.Error || !bytes.Equal(captured.Reply, got.Reply) {
+			result.Mismatches = append(result.Mismatches, ReplayMismatch{
+				Index:    i,
+				Type:     parcel.Type(),
+				Expected: describeCaptured(captured),
+				Got:      describeCaptured(got),
+			})
+		}
+	}
+	return result, nil
+}
+
+// Unexpected returns types of sent messages which were not found in the capture.
+func (r *Replayer) Unexpected() []core.MessageType {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return append([]core.MessageType(nil), r.unexpected...)
+}
+
+// Unsent returns number of captured outbound messages of each type which were not sent during replay.
+func (r *Replayer) Unsent() map[core.MessageType]int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	unsent := map[core.MessageType]int{}
+	for _, queue := range r.replies {
+		for _, captured := range queue {
+			parcel, err := captured.GetParcel()
+			if err != nil {
+				continue
+			}
+			unsent[parcel.Type()]++
+		}
+	}
+	return unsent
+}
+
+func (r *Replayer) hash(msg core.Message) []byte {
+	return r.scheme.IntegrityHasher().Hash(message.ToBytes(msg))
+}
+
+func describeCaptured(captured CapturedMessage) string {
+	if captured.Error != "" {
+		return "error: " + captured.Error
+	}
+	if captured.Reply == nil {
+		return "no reply"
+	}
+	rep, err := reply.Deserialize(bytes.NewReader(captured.Reply))
+	if err != nil {
+		return "unreadable reply: " + err.Error()
+	}
+	return fmt.Sprintf("%T", rep)
+}
@@ -0,0 +1,167 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package messagebus
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha256"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+)
+
+// criticalMessages are always signed with node key, even if session key with receiver is established.
+var criticalMessages = map[core.MessageType]bool{
+	core.TypeExecutorResults:   true,
+	core.TypeValidationResults: true,
+	core.TypeHeavyStartStop:    true,
+	core.TypeHeavyPayload:      true,
+	core.TypeHeavyReset:        true,
+	core.TypeBootstrapRequest:  true,
+	core.TypeNodeSignRequest:   true,
+}
+
+// sessionKeyLabel separates session keys from any other use of ECDH shared secret.
+const sessionKeyLabel = "insolar parcel session key"
+
+// sessionKeys authenticates parcels with symmetric keys shared by pair of nodes.
+//
+// Shared secret is derived via ECDH from node keys, so it is never sent over network. Session key is derived
+// from shared secret, sender, receiver and pulse number, so it changes every pulse and differs for each direction,
+// parcel sealed for the receiver can't be reflected back to the sender. First parcel to the node in a pulse is
+// signed as usual, following parcels in the same pulse are authenticated with MAC which is much cheaper to check.
+type sessionKeys struct {
+	origin     core.RecordRef
+	privateKey *ecdsa.PrivateKey
+
+	lock    sync.Mutex
+	secrets map[core.RecordRef][]byte           // ECDH shared secrets by node
+	signed  map[core.RecordRef]core.PulseNumber // last pulse when signed parcel was sent to node
+}
+
+func newSessionKeys(origin core.RecordRef, privateKey crypto.PrivateKey) (*sessionKeys, error) {
+	key, ok := privateKey.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("session keys require ECDSA node key")
+	}
+	return &sessionKeys{
+		origin:     origin,
+		privateKey: key,
+		secrets:    map[core.RecordRef][]byte{},
+		signed:     map[core.RecordRef]core.PulseNumber{},
+	}, nil
+}
+
+// needSignature returns true if parcel for node should be signed. It marks node as signed in provided pulse.
+func (sk *sessionKeys) needSignature(node core.RecordRef, msgType core.MessageType, pulse core.PulseNumber) bool {
+	if criticalMessages[msgType] {
+		return true
+	}
+
+	sk.lock.Lock()
+	defer sk.lock.Unlock()
+	if sk.signed[node] == pulse {
+		return false
+	}
+	sk.signed[node] = pulse
+	return true
+}
+
+// seal returns copy of parcel authenticated with session key instead of signature.
+func (sk *sessionKeys) seal(receiver core.RecordRef, receiverKey crypto.PublicKey, parcel *message.Parcel) (*message.Parcel, error) {
+	if receiver.Equal(sk.origin) {
+		return nil, errors.New("parcel addressed to self can't be sealed")
+	}
+	key, err := sk.sessionKey(receiver, receiverKey, sk.origin, receiver, parcel.PulseNumber)
+	if err != nil {
+		return nil, err
+	}
+	sealed := *parcel
+	sealed.Signature = nil
+	sealed.SessionMAC = makeMAC(key, parcel.Msg)
+	return &sealed, nil
+}
+
+// verify checks MAC of parcel received from sender.
+func (sk *sessionKeys) verify(sender core.RecordRef, senderKey crypto.PublicKey, parcel *message.Parcel) error {
+	if criticalMessages[parcel.Type()] {
+		return errors.Errorf("message of type %s must be signed", parcel.Type())
+	}
+	if sender.Equal(sk.origin) {
+		return errors.New("parcel sealed by self is rejected")
+	}
+	key, err := sk.sessionKey(sender, senderKey, sender, sk.origin, parcel.PulseNumber)
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal(parcel.SessionMAC, makeMAC(key, parcel.Msg)) {
+		return errors.New("parcel MAC isn't valid")
+	}
+	return nil
+}
+
+// sessionKey returns key of parcels from sender to receiver in pulse, node is the other side of the pair.
+func (sk *sessionKeys) sessionKey(
+	node core.RecordRef, nodeKey crypto.PublicKey, sender, receiver core.RecordRef, pulse core.PulseNumber,
+) ([]byte, error) {
+	sk.lock.Lock()
+	secret, ok := sk.secrets[node]
+	sk.lock.Unlock()
+
+	if !ok {
+		var err error
+		secret, err = sharedSecret(sk.privateKey, nodeKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to derive shared secret with node %s", node)
+		}
+		sk.lock.Lock()
+		sk.secrets[node] = secret
+		sk.lock.Unlock()
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(sessionKeyLabel)) // nolint: errcheck
+	mac.Write(sender[:])               // nolint: errcheck
+	mac.Write(receiver[:])             // nolint: errcheck
+	mac.Write(pulse.Bytes())           // nolint: errcheck
+	return mac.Sum(nil), nil
+}
+
+// sharedSecret computes ECDH shared secret of own private key and public key of other node.
+func sharedSecret(privateKey *ecdsa.PrivateKey, publicKey crypto.PublicKey) ([]byte, error) {
+	pub, ok := publicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.New("node key isn't ECDSA key")
+	}
+	if pub.Curve != privateKey.Curve || !pub.Curve.IsOnCurve(pub.X, pub.Y) {
+		return nil, errors.New("node key is on wrong curve")
+	}
+	x, _ := privateKey.Curve.ScalarMult(pub.X, pub.Y, privateKey.D.Bytes())
+	secret := sha256.Sum256(x.Bytes())
+	return secret[:], nil
+}
+
+func makeMAC(key []byte, msg core.Message) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(message.ToBytes(msg)) // nolint: errcheck
+	return mac.Sum(nil)
+}
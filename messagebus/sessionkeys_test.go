@@ -0,0 +1,190 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package messagebus
+
+import (
+	"context"
+	"crypto"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+	"github.com/insolar/insolar/testutils/network"
+)
+
+type testKeyStore struct {
+	privateKey crypto.PrivateKey
+}
+
+func (ks *testKeyStore) GetPrivateKey(string) (crypto.PrivateKey, error) {
+	return ks.privateKey, nil
+}
+
+func newTestSessionKeys(t *testing.T) (*sessionKeys, core.RecordRef, crypto.PublicKey) {
+	kp := platformpolicy.NewKeyProcessor()
+	privateKey, err := kp.GeneratePrivateKey()
+	require.NoError(t, err)
+	origin := testutils.RandomRef()
+	sk, err := newSessionKeys(origin, privateKey)
+	require.NoError(t, err)
+	return sk, origin, kp.ExtractPublicKey(privateKey)
+}
+
+func TestSessionKeys_SealVerify(t *testing.T) {
+	alice, aliceRef, aliceKey := newTestSessionKeys(t)
+	bob, bobRef, bobKey := newTestSessionKeys(t)
+
+	parcel := &message.Parcel{
+		Msg:         &message.GetObject{Head: testutils.RandomRef()},
+		Signature:   []byte{1, 2, 3},
+		Sender:      aliceRef,
+		PulseNumber: core.FirstPulseNumber,
+	}
+
+	sealed, err := alice.seal(bobRef, bobKey, parcel)
+	require.NoError(t, err)
+	require.Nil(t, sealed.Signature)
+	require.NotEmpty(t, sealed.SessionMAC)
+	require.Equal(t, []byte{1, 2, 3}, parcel.Signature, "original parcel must not be changed")
+
+	require.NoError(t, bob.verify(aliceRef, aliceKey, sealed))
+
+	// key changes every pulse
+	replayed := *sealed
+	replayed.PulseNumber++
+	require.Error(t, bob.verify(aliceRef, aliceKey, &replayed))
+
+	// only receiver can verify MAC
+	eve, _, _ := newTestSessionKeys(t)
+	require.Error(t, eve.verify(aliceRef, aliceKey, sealed))
+
+	tampered := *sealed
+	tampered.Msg = &message.GetObject{Head: testutils.RandomRef()}
+	require.Error(t, bob.verify(aliceRef, aliceKey, &tampered))
+}
+
+func TestSessionKeys_Reflection(t *testing.T) {
+	alice, aliceRef, aliceKey := newTestSessionKeys(t)
+	bob, bobRef, bobKey := newTestSessionKeys(t)
+
+	sealed, err := alice.seal(bobRef, bobKey, &message.Parcel{
+		Msg:         &message.GetObject{Head: testutils.RandomRef()},
+		Sender:      aliceRef,
+		PulseNumber: core.FirstPulseNumber,
+	})
+	require.NoError(t, err)
+
+	// parcel reflected back to alice as if bob sent it
+	reflected := *sealed
+	reflected.Sender = bobRef
+	require.Error(t, alice.verify(bobRef, bobKey, &reflected))
+
+	// parcel reflected back to alice as is
+	require.Error(t, alice.verify(aliceRef, aliceKey, sealed))
+
+	// keys of both directions differ
+	toBob, err := alice.sessionKey(bobRef, bobKey, aliceRef, bobRef, core.FirstPulseNumber)
+	require.NoError(t, err)
+	toAlice, err := bob.sessionKey(aliceRef, aliceKey, bobRef, aliceRef, core.FirstPulseNumber)
+	require.NoError(t, err)
+	require.NotEqual(t, toBob, toAlice)
+}
+
+func TestSessionKeys_Self(t *testing.T) {
+	alice, aliceRef, aliceKey := newTestSessionKeys(t)
+	parcel := &message.Parcel{
+		Msg:         &message.GetObject{Head: testutils.RandomRef()},
+		Sender:      aliceRef,
+		PulseNumber: core.FirstPulseNumber,
+	}
+
+	_, err := alice.seal(aliceRef, aliceKey, parcel)
+	require.Error(t, err)
+
+	key, err := alice.sessionKey(aliceRef, aliceKey, aliceRef, aliceRef, core.FirstPulseNumber)
+	require.NoError(t, err)
+	forged := *parcel
+	forged.SessionMAC = makeMAC(key, parcel.Msg)
+	require.Error(t, alice.verify(aliceRef, aliceKey, &forged))
+}
+
+func TestSessionKeys_NeedSignature(t *testing.T) {
+	sk, _, _ := newTestSessionKeys(t)
+	node := testutils.RandomRef()
+
+	require.True(t, sk.needSignature(node, core.TypeGetObject, core.FirstPulseNumber))
+	require.False(t, sk.needSignature(node, core.TypeGetObject, core.FirstPulseNumber))
+	require.True(t, sk.needSignature(node, core.TypeHeavyPayload, core.FirstPulseNumber))
+	require.True(t, sk.needSignature(testutils.RandomRef(), core.TypeGetObject, core.FirstPulseNumber))
+
+	require.True(t, sk.needSignature(node, core.TypeGetObject, core.FirstPulseNumber+1))
+	require.False(t, sk.needSignature(node, core.TypeGetObject, core.FirstPulseNumber+1))
+}
+
+func TestSessionKeys_CriticalMessageMustBeSigned(t *testing.T) {
+	alice, aliceRef, aliceKey := newTestSessionKeys(t)
+	bob, bobRef, bobKey := newTestSessionKeys(t)
+
+	sealed, err := alice.seal(bobRef, bobKey, &message.Parcel{
+		Msg:         &message.HeavyReset{},
+		PulseNumber: core.FirstPulseNumber,
+	})
+	require.NoError(t, err)
+	require.Error(t, bob.verify(aliceRef, aliceKey, sealed))
+}
+
+func TestMessageBus_checkParcel_SessionMAC(t *testing.T) {
+	ctx := context.Background()
+	kp := platformpolicy.NewKeyProcessor()
+	senderPrivate, err := kp.GeneratePrivateKey()
+	require.NoError(t, err)
+	receiverPrivate, err := kp.GeneratePrivateKey()
+	require.NoError(t, err)
+	senderRef, receiverRef := testutils.RandomRef(), testutils.RandomRef()
+
+	sender, err := newSessionKeys(senderRef, senderPrivate)
+	require.NoError(t, err)
+	receiver, err := newSessionKeys(receiverRef, receiverPrivate)
+	require.NoError(t, err)
+
+	senderNode := network.NewNodeMock(t)
+	senderNode.PublicKeyMock.Return(kp.ExtractPublicKey(senderPrivate))
+	senderNode.RoleMock.Return(core.StaticRoleVirtual)
+	nn := network.NewNodeNetworkMock(t)
+	nn.GetWorkingNodeMock.Expect(senderRef).Return(senderNode)
+
+	mb := &MessageBus{NodeNetwork: nn, signmessages: true, sessionKeys: receiver}
+
+	parcel := &message.Parcel{
+		Msg:         &message.GetObject{},
+		Sender:      senderRef,
+		PulseNumber: core.FirstPulseNumber,
+	}
+	sealed, err := sender.seal(receiverRef, kp.ExtractPublicKey(receiverPrivate), parcel)
+	require.NoError(t, err)
+	require.NoError(t, mb.checkParcel(ctx, sealed))
+
+	sealed.SessionMAC[0]++
+	require.Error(t, mb.checkParcel(ctx, sealed))
+
+	mb.sessionKeys = nil
+	require.Error(t, mb.checkParcel(ctx, sealed))
+}
@@ -0,0 +1,104 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package messagebus
+
+import (
+	"context"
+	"sync"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/instracer"
+)
+
+// traceTargets is list of objects and members which flows are traced in full.
+type traceTargets struct {
+	lock    sync.RWMutex
+	targets map[core.RecordRef]struct{}
+}
+
+func newTraceTargets() *traceTargets {
+	return &traceTargets{targets: map[core.RecordRef]struct{}{}}
+}
+
+func (tt *traceTargets) add(target core.RecordRef) {
+	tt.lock.Lock()
+	defer tt.lock.Unlock()
+	tt.targets[target] = struct{}{}
+}
+
+func (tt *traceTargets) remove(target core.RecordRef) bool {
+	tt.lock.Lock()
+	defer tt.lock.Unlock()
+	_, ok := tt.targets[target]
+	delete(tt.targets, target)
+	return ok
+}
+
+func (tt *traceTargets) list() []core.RecordRef {
+	tt.lock.RLock()
+	defer tt.lock.RUnlock()
+	targets := make([]core.RecordRef, 0, len(tt.targets))
+	for target := range tt.targets {
+		targets = append(targets, target)
+	}
+	return targets
+}
+
+// match returns listed target of message, message target is checked before its caller.
+func (tt *traceTargets) match(msg core.Message) *core.RecordRef {
+	tt.lock.RLock()
+	defer tt.lock.RUnlock()
+	if len(tt.targets) == 0 {
+		return nil
+	}
+	for _, ref := range []*core.RecordRef{msg.DefaultTarget(), msg.GetCaller()} {
+		if ref == nil {
+			continue
+		}
+		if _, ok := tt.targets[*ref]; ok {
+			return ref
+		}
+	}
+	return nil
+}
+
+// AddTraceTarget implements core.TraceTargets.
+func (mb *MessageBus) AddTraceTarget(target core.RecordRef) {
+	mb.traceTargets.add(target)
+}
+
+// RemoveTraceTarget implements core.TraceTargets.
+func (mb *MessageBus) RemoveTraceTarget(target core.RecordRef) bool {
+	return mb.traceTargets.remove(target)
+}
+
+// GetTraceTargets implements core.TraceTargets.
+func (mb *MessageBus) GetTraceTargets() []core.RecordRef {
+	return mb.traceTargets.list()
+}
+
+// traceFlow marks flow of message as traced if message touches listed object or member and flow isn't traced yet.
+func (mb *MessageBus) traceFlow(ctx context.Context, msg core.Message) context.Context {
+	if instracer.TraceTarget(ctx) != "" {
+		return ctx
+	}
+	target := mb.traceTargets.match(msg)
+	if target == nil {
+		return ctx
+	}
+	return instracer.WithTraceTarget(ctx, target.String())
+}
@@ -0,0 +1,57 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package messagebus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/instrumentation/instracer"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestMessageBus_TraceTargets(t *testing.T) {
+	mb := &MessageBus{traceTargets: newTraceTargets()}
+	member, object := testutils.RandomRef(), testutils.RandomRef()
+
+	mb.AddTraceTarget(member)
+	mb.AddTraceTarget(object)
+	require.ElementsMatch(t, []core.RecordRef{member, object}, mb.GetTraceTargets())
+	require.True(t, mb.RemoveTraceTarget(object))
+	require.False(t, mb.RemoveTraceTarget(object))
+	require.Equal(t, []core.RecordRef{member}, mb.GetTraceTargets())
+
+	ctx := mb.traceFlow(context.Background(), &message.GetObject{Head: object})
+	require.Empty(t, instracer.TraceTarget(ctx))
+
+	msg := &message.CallMethod{BaseLogicMessage: message.BaseLogicMessage{Caller: member}, ObjectRef: object}
+	ctx = mb.traceFlow(context.Background(), msg)
+	require.Equal(t, member.String(), instracer.TraceTarget(ctx))
+
+	// Flow stays traced on other nodes.
+	parcel := &message.Parcel{Msg: msg, TraceSpanData: instracer.MustSerialize(ctx)}
+	require.Equal(t, member.String(), instracer.TraceTarget(parcel.Context(context.Background())))
+
+	// Flow is traced for target it was started for.
+	mb.AddTraceTarget(object)
+	ctx = mb.traceFlow(ctx, &message.GetObject{Head: object})
+	require.Equal(t, member.String(), instracer.TraceTarget(ctx))
+}
@@ -0,0 +1,124 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package metrics
+
+import (
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/insolar/insolar/configuration"
+)
+
+// filteredGatherer drops disabled metrics and labels missing in allow-list from gathered metrics. Metrics differing
+// only by dropped labels are aggregated, summary quantiles can't be aggregated and are dropped in this case.
+type filteredGatherer struct {
+	gatherer prometheus.Gatherer
+	disabled []string
+	allowed  map[string]bool
+}
+
+func newFilteredGatherer(gatherer prometheus.Gatherer, cfg configuration.Metrics) prometheus.Gatherer {
+	if len(cfg.DisabledMetrics) == 0 && len(cfg.AllowedLabels) == 0 {
+		return gatherer
+	}
+	g := &filteredGatherer{gatherer: gatherer, disabled: cfg.DisabledMetrics}
+	if len(cfg.AllowedLabels) > 0 {
+		g.allowed = map[string]bool{}
+		for _, l := range cfg.AllowedLabels {
+			g.allowed[l] = true
+		}
+	}
+	return g
+}
+
+// Gather implements prometheus.Gatherer.
+func (g *filteredGatherer) Gather() ([]*dto.MetricFamily, error) {
+	families, err := g.gatherer.Gather()
+	res := make([]*dto.MetricFamily, 0, len(families))
+	for _, mf := range families {
+		if g.isDisabled(mf.GetName()) {
+			continue
+		}
+		if g.allowed != nil {
+			mf.Metric = g.restrictLabels(mf.Metric)
+		}
+		res = append(res, mf)
+	}
+	return res, err
+}
+
+func (g *filteredGatherer) isDisabled(name string) bool {
+	for _, prefix := range g.disabled {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *filteredGatherer) restrictLabels(metrics []*dto.Metric) []*dto.Metric {
+	merged := map[string]*dto.Metric{}
+	res := make([]*dto.Metric, 0, len(metrics))
+	for _, m := range metrics {
+		var (
+			labels []*dto.LabelPair
+			key    strings.Builder
+		)
+		for _, l := range m.Label {
+			if g.allowed[l.GetName()] {
+				labels = append(labels, l)
+				key.WriteString(l.GetName() + "=" + l.GetValue() + "\xff")
+			}
+		}
+		m.Label = labels
+
+		if dst, ok := merged[key.String()]; ok {
+			mergeMetric(dst, m)
+			continue
+		}
+		merged[key.String()] = m
+		res = append(res, m)
+	}
+	return res
+}
+
+func mergeMetric(dst, src *dto.Metric) {
+	switch {
+	case dst.Counter != nil && src.Counter != nil:
+		dst.Counter.Value = proto.Float64(dst.Counter.GetValue() + src.Counter.GetValue())
+	case dst.Gauge != nil && src.Gauge != nil:
+		dst.Gauge.Value = proto.Float64(dst.Gauge.GetValue() + src.Gauge.GetValue())
+	case dst.Untyped != nil && src.Untyped != nil:
+		dst.Untyped.Value = proto.Float64(dst.Untyped.GetValue() + src.Untyped.GetValue())
+	case dst.Histogram != nil && src.Histogram != nil:
+		dst.Histogram.SampleCount = proto.Uint64(dst.Histogram.GetSampleCount() + src.Histogram.GetSampleCount())
+		dst.Histogram.SampleSum = proto.Float64(dst.Histogram.GetSampleSum() + src.Histogram.GetSampleSum())
+		// buckets of metrics within family have same bounds
+		for i, b := range dst.Histogram.Bucket {
+			if i < len(src.Histogram.Bucket) {
+				b.CumulativeCount = proto.Uint64(b.GetCumulativeCount() + src.Histogram.Bucket[i].GetCumulativeCount())
+			}
+		}
+	case dst.Summary != nil && src.Summary != nil:
+		dst.Summary.SampleCount = proto.Uint64(dst.Summary.GetSampleCount() + src.Summary.GetSampleCount())
+		dst.Summary.SampleSum = proto.Float64(dst.Summary.GetSampleSum() + src.Summary.GetSampleSum())
+		dst.Summary.Quantile = nil
+	}
+}
@@ -0,0 +1,61 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+)
+
+func TestFilteredGatherer(t *testing.T) {
+	peerBytes := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "peer_sent_bytes",
+		Help: "Bytes sent to peer",
+	}, []string{"peer", "packetType"})
+	connections := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "connections",
+		Help: "Open connections",
+	})
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(peerBytes, connections)
+
+	peerBytes.WithLabelValues("127.0.0.1:1", "ping").Add(1)
+	peerBytes.WithLabelValues("127.0.0.1:2", "ping").Add(2)
+	peerBytes.WithLabelValues("127.0.0.1:2", "pulse").Add(4)
+	connections.Set(1)
+
+	gatherer := newFilteredGatherer(registry, configuration.Metrics{
+		DisabledMetrics: []string{"conn"},
+		AllowedLabels:   []string{"packetType"},
+	})
+	families, err := gatherer.Gather()
+	require.NoError(t, err)
+	require.Len(t, families, 1)
+	require.Equal(t, "peer_sent_bytes", families[0].GetName())
+
+	got := map[string]float64{}
+	for _, m := range families[0].Metric {
+		require.Len(t, m.Label, 1)
+		got[m.Label[0].GetValue()] = m.Counter.GetValue()
+	}
+	assert.Equal(t, map[string]float64{"ping": 3, "pulse": 4}, got)
+}
@@ -0,0 +1,72 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package metrics
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+
+	"github.com/insolar/insolar/core"
+)
+
+// PeriodicTasks returns flush of metrics to file to be run by scheduler, if flush is configured.
+func (m *Metrics) PeriodicTasks() []core.PeriodicTask {
+	if m.flushFile == "" || m.flushInterval <= 0 {
+		return nil
+	}
+	return []core.PeriodicTask{{
+		Name:     "metrics.flush",
+		Schedule: core.TaskSchedule{Every: m.flushInterval},
+		Run:      m.Flush,
+	}}
+}
+
+// Flush writes gathered metrics to configured file in Prometheus text format, so they can be collected from
+// the file (e.g. by textfile collector of node exporter) when node can't be scraped. File is replaced atomically.
+func (m *Metrics) Flush(ctx context.Context) error {
+	return flushTo(m.gatherer, m.flushFile)
+}
+
+func flushTo(gatherer prometheus.Gatherer, path string) error {
+	families, err := gatherer.Gather()
+	if err != nil {
+		return errors.Wrap(err, "[ Flush ] failed to gather metrics")
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return errors.Wrap(err, "[ Flush ] failed to create temporary file")
+	}
+	defer os.Remove(tmp.Name()) // nolint: errcheck
+
+	for _, mf := range families {
+		if _, err := expfmt.MetricFamilyToText(tmp, mf); err != nil {
+			tmp.Close() // nolint: errcheck
+			return errors.Wrap(err, "[ Flush ] failed to write metrics")
+		}
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "[ Flush ] failed to write metrics")
+	}
+	return errors.Wrap(os.Rename(tmp.Name(), path), "[ Flush ] failed to replace metrics file")
+}
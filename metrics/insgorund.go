@@ -35,11 +35,25 @@ var InsgorundContractExecutionTime = prometheus.NewSummaryVec(prometheus.Summary
 	Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.95: 0.005, 0.99: 0.001},
 }, []string{"method"})
 
+var InsgorundMemoryResident = prometheus.NewGauge(prometheus.GaugeOpts{
+	Name:      "memory_resident_bytes",
+	Help:      "Resident memory of runner checked by memory guard",
+	Namespace: insgorundNamespace,
+})
+
+var InsgorundRecycledTotal = prometheus.NewCounter(prometheus.CounterOpts{
+	Name:      "recycled_total",
+	Help:      "Total number of runner restarts because of exceeded memory limit",
+	Namespace: insgorundNamespace,
+})
+
 func GetInsgorundRegistry() *prometheus.Registry {
 	registry := prometheus.NewRegistry()
 
 	registry.MustRegister(InsgorundCallsTotal)
 	registry.MustRegister(InsgorundContractExecutionTime)
+	registry.MustRegister(InsgorundMemoryResident)
+	registry.MustRegister(InsgorundRecycledTotal)
 	// default system collectors
 	registry.MustRegister(prometheus.NewProcessCollector(os.Getpid(), insgorundNamespace))
 	registry.MustRegister(prometheus.NewGoCollector())
@@ -39,8 +39,14 @@ func GetInsolarRegistry() *prometheus.Registry {
 	registry.MustRegister(NetworkComplete)
 	registry.MustRegister(NetworkSentSize)
 	registry.MustRegister(NetworkRecvSize)
+	registry.MustRegister(NetworkPeerSentBytes)
+	registry.MustRegister(NetworkPeerRecvBytes)
+	registry.MustRegister(NetworkPacketMalformedTotal)
+	registry.MustRegister(NetworkPacketQuarantinedTotal)
+	registry.MustRegister(NetworkPeersQuarantinedTotal)
 
 	registry.MustRegister(APIContractExecutionTime)
+	registry.MustRegister(APIRoutingHints)
 
 	return registry
 }
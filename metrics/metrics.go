@@ -42,12 +42,17 @@ const insgorundNamespace = "insgorund"
 type Metrics struct {
 	server   *http.Server
 	listener net.Listener
+
+	gatherer      prometheus.Gatherer
+	flushFile     string
+	flushInterval time.Duration
 }
 
 // NewMetrics creates new Metrics component.
 func NewMetrics(ctx context.Context, cfg configuration.Metrics, registry *prometheus.Registry) (*Metrics, error) {
 	errlogger := &errorLogger{inslogger.FromContext(ctx)}
-	promhandler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{ErrorLog: errlogger})
+	gatherer := newFilteredGatherer(registry, cfg)
+	promhandler := promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{ErrorLog: errlogger})
 
 	mux := http.NewServeMux()
 	mux.Handle("/metrics", promhandler)
@@ -63,9 +68,17 @@ func NewMetrics(ctx context.Context, cfg configuration.Metrics, registry *promet
 			Addr:    cfg.ListenAddress,
 			Handler: mux,
 		},
+		gatherer:      gatherer,
+		flushFile:     cfg.FlushFile,
+		flushInterval: cfg.FlushInterval,
+	}
+
+	err := insmetrics.Configure(cfg.Namespace, cfg.DisabledMetrics, cfg.AllowedLabels)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to apply metrics configuration")
 	}
 
-	_, err := insmetrics.RegisterPrometheus(ctx, cfg.Namespace, registry, cfg.ReportingPeriod)
+	_, err = insmetrics.RegisterPrometheus(ctx, cfg.Namespace, registry, cfg.ReportingPeriod)
 	if err != nil {
 		errlogger.Println(err.Error())
 	}
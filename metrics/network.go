@@ -52,6 +52,22 @@ var NetworkRecvSize = prometheus.NewCounter(prometheus.CounterOpts{
 	Subsystem: "network",
 })
 
+// NetworkPeerSentBytes is total bytes sent to remote peer by packet type
+var NetworkPeerSentBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name:      "peer_sent_bytes",
+	Help:      "Bytes sent to remote peer by packet type",
+	Namespace: insolarNamespace,
+	Subsystem: "network",
+}, []string{"peer", "packetType"})
+
+// NetworkPeerRecvBytes is total bytes received from remote peer by packet type
+var NetworkPeerRecvBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name:      "peer_recv_bytes",
+	Help:      "Bytes received from remote peer by packet type",
+	Namespace: insolarNamespace,
+	Subsystem: "network",
+}, []string{"peer", "packetType"})
+
 // NetworkPacketTimeoutTotal is is total number of timed out packets metric
 var NetworkPacketTimeoutTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
 	Name:      "packet_timeout_total",
@@ -60,6 +76,30 @@ var NetworkPacketTimeoutTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
 	Subsystem: "network",
 }, []string{"packetType"})
 
+// NetworkPacketMalformedTotal is total number of received packets which failed to parse or validate
+var NetworkPacketMalformedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+	Name:      "packet_malformed_total",
+	Help:      "Total number of received malformed packets",
+	Namespace: insolarNamespace,
+	Subsystem: "network",
+})
+
+// NetworkPacketQuarantinedTotal is total number of packets ignored because their sender is quarantined
+var NetworkPacketQuarantinedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+	Name:      "packet_quarantined_total",
+	Help:      "Total number of packets ignored from quarantined peers",
+	Namespace: insolarNamespace,
+	Subsystem: "network",
+})
+
+// NetworkPeersQuarantinedTotal is total number of peers quarantined for sending malformed packets
+var NetworkPeersQuarantinedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+	Name:      "peers_quarantined_total",
+	Help:      "Total number of peers quarantined for sending malformed packets",
+	Namespace: insolarNamespace,
+	Subsystem: "network",
+})
+
 // NetworkFutures is current network transport futures count metric
 var NetworkFutures = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 	Name:      "futures",
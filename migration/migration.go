@@ -0,0 +1,206 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+// Package migration exports subtrees of objects from one network and imports them into another, e.g. to fill
+// staging with realistic state from testnet.
+package migration
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// MaxObjects is max count of objects in exported subtree.
+const MaxObjects = 10000
+
+// Migrator exports and imports subtrees of objects with artifact manager.
+type Migrator struct {
+	ArtifactManager core.ArtifactManager `inject:""`
+}
+
+// New creates new Migrator.
+func New() *Migrator {
+	return &Migrator{}
+}
+
+// ExportObjects implements core.ObjectMigrator.
+func (m *Migrator) ExportObjects(ctx context.Context, root core.RecordRef) (*core.ObjectTree, error) {
+	tree := &core.ObjectTree{Version: core.ObjectTreeFormatVersion, Root: root}
+	queue := []core.RecordRef{root}
+	for len(queue) > 0 {
+		head := queue[0]
+		queue = queue[1:]
+
+		obj, err := m.exportObject(ctx, head)
+		if err != nil {
+			return nil, errors.Wrapf(err, "[ ExportObjects ] can't export object %s", head)
+		}
+		tree.Objects = append(tree.Objects, *obj)
+		if len(tree.Objects)+len(queue) > MaxObjects {
+			return nil, errors.Errorf("[ ExportObjects ] subtree of %s has more than %d objects", root, MaxObjects)
+		}
+
+		children, err := m.ArtifactManager.GetChildren(ctx, head, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "[ ExportObjects ] can't get children of %s", head)
+		}
+		for children.HasNext() {
+			child, err := children.Next()
+			if err != nil {
+				return nil, errors.Wrapf(err, "[ ExportObjects ] can't get children of %s", head)
+			}
+			queue = append(queue, *child)
+		}
+	}
+	return tree, nil
+}
+
+func (m *Migrator) exportObject(ctx context.Context, head core.RecordRef) (*core.ExportedObject, error) {
+	desc, err := m.ArtifactManager.GetObject(ctx, head, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	if desc.IsPrototype() {
+		return nil, errors.New("prototypes can't be exported")
+	}
+	prototype, err := desc.Prototype()
+	if err != nil {
+		return nil, err
+	}
+	obj := &core.ExportedObject{
+		Head:      head,
+		Prototype: *prototype,
+		Memory:    desc.Memory(),
+	}
+	if parent := desc.Parent(); parent != nil {
+		obj.Parent = *parent
+		delegate, err := m.ArtifactManager.GetDelegate(ctx, *parent, *prototype)
+		if err != nil && err != core.ErrNotFound {
+			return nil, err
+		}
+		obj.IsDelegate = delegate != nil && *delegate == head
+	}
+	return obj, nil
+}
+
+// ImportObjects implements core.ObjectMigrator.
+func (m *Migrator) ImportObjects(
+	ctx context.Context, tree *core.ObjectTree, parent core.RecordRef, prototypes map[core.RecordRef]core.RecordRef,
+) (map[core.RecordRef]core.RecordRef, error) {
+	prototypes, err := m.checkTree(ctx, tree, prototypes)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ ImportObjects ]")
+	}
+
+	// Objects can refer to each other in any order, so all new references are made before activation.
+	refs := map[core.RecordRef]core.RecordRef{}
+	for _, obj := range tree.Objects {
+		id, _, err := m.ArtifactManager.RegisterRequest(
+			ctx, parent, &message.Parcel{Msg: &message.GenesisRequest{Name: "import_" + obj.Head.String()}},
+		)
+		if err != nil {
+			return nil, errors.Wrapf(err, "[ ImportObjects ] can't register request of %s", obj.Head)
+		}
+		refs[obj.Head] = *core.NewRecordRef(*parent.Record(), *id)
+	}
+
+	remap := map[core.RecordRef]core.RecordRef{tree.Objects[0].Parent: parent}
+	for old, ref := range prototypes {
+		remap[old] = ref
+	}
+	for old, ref := range refs {
+		remap[old] = ref
+	}
+
+	for _, obj := range tree.Objects {
+		_, err := m.ArtifactManager.ActivateObject(
+			ctx,
+			core.RecordRef{},
+			refs[obj.Head],
+			remap[obj.Parent],
+			remap[obj.Prototype],
+			obj.IsDelegate,
+			remapMemory(obj.Memory, remap),
+		)
+		if err != nil {
+			return nil, errors.Wrapf(err, "[ ImportObjects ] can't activate %s", obj.Head)
+		}
+	}
+
+	inslogger.FromContext(ctx).Infof(
+		"[ ImportObjects ] imported %d objects of %s under %s", len(refs), tree.Root, parent,
+	)
+	return refs, nil
+}
+
+// checkTree checks that tree is consistent, so nothing is written if tree can't be imported. Returns prototypes
+// map completed with prototypes that are not mapped but exist in current network.
+func (m *Migrator) checkTree(
+	ctx context.Context, tree *core.ObjectTree, prototypes map[core.RecordRef]core.RecordRef,
+) (map[core.RecordRef]core.RecordRef, error) {
+	if tree.Version != core.ObjectTreeFormatVersion {
+		return nil, errors.Errorf("unsupported format version %d", tree.Version)
+	}
+	if len(tree.Objects) == 0 || tree.Objects[0].Head != tree.Root {
+		return nil, errors.New("tree doesn't start with its root")
+	}
+	if len(tree.Objects) > MaxObjects {
+		return nil, errors.Errorf("tree has more than %d objects", MaxObjects)
+	}
+
+	resolved := make(map[core.RecordRef]core.RecordRef, len(prototypes))
+	for old, ref := range prototypes {
+		resolved[old] = ref
+	}
+	seen := map[core.RecordRef]bool{}
+	for i, obj := range tree.Objects {
+		if seen[obj.Head] {
+			return nil, errors.Errorf("object %s is exported twice", obj.Head)
+		}
+		if i > 0 && !seen[obj.Parent] {
+			return nil, errors.Errorf("parent of %s goes after it or isn't exported", obj.Head)
+		}
+		seen[obj.Head] = true
+
+		if _, ok := resolved[obj.Prototype]; ok {
+			continue
+		}
+		desc, err := m.ArtifactManager.GetObject(ctx, obj.Prototype, nil, false)
+		if err != nil || !desc.IsPrototype() {
+			return nil, errors.Errorf("prototype %s of %s isn't mapped and doesn't exist", obj.Prototype, obj.Head)
+		}
+		resolved[obj.Prototype] = obj.Prototype
+	}
+	return resolved, nil
+}
+
+// remapMemory replaces references in serialized memory. References are random enough to be replaced as bytes.
+func remapMemory(memory []byte, remap map[core.RecordRef]core.RecordRef) []byte {
+	out := memory
+	for old, ref := range remap {
+		if old == ref || old == (core.RecordRef{}) {
+			continue
+		}
+		out = bytes.Replace(out, old[:], ref[:], -1)
+	}
+	return out
+}
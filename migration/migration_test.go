@@ -0,0 +1,175 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package migration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/testutils"
+)
+
+type object struct {
+	core.ObjectDescriptor
+	head        core.RecordRef
+	parent      *core.RecordRef
+	prototype   core.RecordRef
+	isPrototype bool
+	isDelegate  bool
+	memory      []byte
+	children    []core.RecordRef
+}
+
+func (o *object) IsPrototype() bool                   { return o.isPrototype }
+func (o *object) Prototype() (*core.RecordRef, error) { return &o.prototype, nil }
+func (o *object) Parent() *core.RecordRef             { return o.parent }
+func (o *object) Memory() []byte                      { return o.memory }
+
+type refIterator struct {
+	refs []core.RecordRef
+}
+
+func (i *refIterator) HasNext() bool { return len(i.refs) > 0 }
+
+func (i *refIterator) Next() (*core.RecordRef, error) {
+	ref := i.refs[0]
+	i.refs = i.refs[1:]
+	return &ref, nil
+}
+
+// artifactManager keeps objects in memory.
+type artifactManager struct {
+	core.ArtifactManager
+	objects map[core.RecordRef]*object
+}
+
+func newArtifactManager() *artifactManager {
+	return &artifactManager{objects: map[core.RecordRef]*object{}}
+}
+
+func (am *artifactManager) GetObject(
+	ctx context.Context, head core.RecordRef, state *core.RecordID, approved bool,
+) (core.ObjectDescriptor, error) {
+	obj, ok := am.objects[head]
+	if !ok {
+		return nil, core.ErrNotFound
+	}
+	return obj, nil
+}
+
+func (am *artifactManager) GetChildren(
+	ctx context.Context, parent core.RecordRef, pulse *core.PulseNumber,
+) (core.RefIterator, error) {
+	return &refIterator{refs: am.objects[parent].children}, nil
+}
+
+func (am *artifactManager) GetDelegate(ctx context.Context, head, asType core.RecordRef) (*core.RecordRef, error) {
+	for _, child := range am.objects[head].children {
+		if obj := am.objects[child]; obj.isDelegate && obj.prototype == asType {
+			return &obj.head, nil
+		}
+	}
+	return nil, core.ErrNotFound
+}
+
+func (am *artifactManager) RegisterRequest(
+	ctx context.Context, object core.RecordRef, parcel core.Parcel,
+) (*core.RecordID, uint64, error) {
+	id := testutils.RandomID()
+	return &id, 0, nil
+}
+
+func (am *artifactManager) ActivateObject(
+	ctx context.Context, domain, request, parent, prototype core.RecordRef, asDelegate bool, memory []byte,
+) (core.ObjectDescriptor, error) {
+	return am.add(request, &parent, prototype, false, asDelegate, memory), nil
+}
+
+func (am *artifactManager) add(
+	head core.RecordRef, parent *core.RecordRef, prototype core.RecordRef, isPrototype, isDelegate bool, memory []byte,
+) *object {
+	obj := &object{
+		head:        head,
+		parent:      parent,
+		prototype:   prototype,
+		isPrototype: isPrototype,
+		isDelegate:  isDelegate,
+		memory:      memory,
+	}
+	am.objects[head] = obj
+	if parent != nil {
+		am.objects[*parent].children = append(am.objects[*parent].children, head)
+	}
+	return obj
+}
+
+func TestMigrator(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	source, target := newArtifactManager(), newArtifactManager()
+
+	domain, memberProto, walletProto := testutils.RandomRef(), testutils.RandomRef(), testutils.RandomRef()
+	source.add(domain, nil, testutils.RandomRef(), false, false, nil)
+	source.add(memberProto, nil, core.RecordRef{}, true, false, nil)
+	source.add(walletProto, nil, core.RecordRef{}, true, false, nil)
+	member, wallet := testutils.RandomRef(), testutils.RandomRef()
+	source.add(member, &domain, memberProto, false, false, append([]byte("wallet:"), wallet[:]...))
+	source.add(wallet, &member, walletProto, false, true, []byte("balance:100"))
+
+	migrator := &Migrator{ArtifactManager: source}
+	_, err := migrator.ExportObjects(ctx, memberProto)
+	require.Error(t, err)
+	tree, err := migrator.ExportObjects(ctx, member)
+	require.NoError(t, err)
+	require.Equal(t, core.ObjectTreeFormatVersion, tree.Version)
+	require.Equal(t, []core.ExportedObject{
+		{Head: member, Parent: domain, Prototype: memberProto, Memory: append([]byte("wallet:"), wallet[:]...)},
+		{Head: wallet, Parent: member, Prototype: walletProto, IsDelegate: true, Memory: []byte("balance:100")},
+	}, tree.Objects)
+
+	newDomain, newMemberProto := testutils.RandomRef(), testutils.RandomRef()
+	target.add(newDomain, nil, testutils.RandomRef(), false, false, nil)
+	target.add(newMemberProto, nil, core.RecordRef{}, true, false, nil)
+	migrator = &Migrator{ArtifactManager: target}
+
+	// Wallet prototype is neither mapped nor exists in target network.
+	prototypes := map[core.RecordRef]core.RecordRef{memberProto: newMemberProto}
+	_, err = migrator.ImportObjects(ctx, tree, newDomain, prototypes)
+	require.Contains(t, err.Error(), "isn't mapped")
+	require.Len(t, target.objects, 2)
+
+	target.add(walletProto, nil, core.RecordRef{}, true, false, nil)
+	refs, err := migrator.ImportObjects(ctx, tree, newDomain, prototypes)
+	require.NoError(t, err)
+	require.Len(t, prototypes, 1)
+
+	newMember, newWallet := target.objects[refs[member]], target.objects[refs[wallet]]
+	require.Equal(t, newDomain, *newMember.parent)
+	require.Equal(t, newMemberProto, newMember.prototype)
+	newWalletRef := refs[wallet]
+	require.Equal(t, append([]byte("wallet:"), newWalletRef[:]...), newMember.memory)
+	require.Equal(t, refs[member], *newWallet.parent)
+	require.Equal(t, walletProto, newWallet.prototype)
+	require.True(t, newWallet.isDelegate)
+
+	tree.Objects = tree.Objects[1:]
+	_, err = migrator.ImportObjects(ctx, tree, newDomain, prototypes)
+	require.Contains(t, err.Error(), "doesn't start with its root")
+}
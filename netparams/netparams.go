@@ -0,0 +1,118 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+// Package netparams keeps network-wide parameters stored in root domain. Parameters are refreshed from ledger
+// on pulse boundaries, components apply them as overrides of their local configuration.
+package netparams
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/application/extractor"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// Parameters is a node-local cache of network-wide parameters.
+type Parameters struct {
+	ContractRequester   core.ContractRequester   `inject:""`
+	GenesisDataProvider core.GenesisDataProvider `inject:""`
+
+	lock   sync.RWMutex
+	params map[string]string
+}
+
+// New creates new network parameters cache, all parameters are unset until first refresh.
+func New() *Parameters {
+	return &Parameters{params: map[string]string{}}
+}
+
+// GetNetworkParameter returns value of parameter and false if parameter isn't set in root domain.
+func (p *Parameters) GetNetworkParameter(name string) (string, bool) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	value, ok := p.params[name]
+	return value, ok
+}
+
+// PeriodicTasks implements core.PeriodicTasksProvider, parameters are refreshed every pulse.
+func (p *Parameters) PeriodicTasks() []core.PeriodicTask {
+	return []core.PeriodicTask{{
+		Name:     "netparams.refresh",
+		Schedule: core.TaskSchedule{Pulses: 1},
+		Run:      p.Refresh,
+	}}
+}
+
+// Refresh reads parameters from root domain.
+func (p *Parameters) Refresh(ctx context.Context) error {
+	res, err := p.ContractRequester.SendRequest(ctx, p.GenesisDataProvider.GetRootDomain(ctx), "GetNetworkParameters", []interface{}{})
+	if err != nil {
+		return errors.Wrap(err, "[ Refresh ] Can't send request")
+	}
+	params, err := extractor.NetworkParametersResponse(res.(*reply.CallMethod).Result)
+	if err != nil {
+		return errors.Wrap(err, "[ Refresh ] Can't extract response")
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for name, value := range params {
+		if p.params[name] != value {
+			inslogger.FromContext(ctx).Infof("[ Refresh ] Network parameter %s changed to %q", name, value)
+		}
+	}
+	p.params = params
+	return nil
+}
+
+// Int returns integer parameter or def if parameter isn't set or is malformed.
+func Int(p core.NetworkParameters, name string, def int) int {
+	value, ok := p.GetNetworkParameter(name)
+	if !ok {
+		return def
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return def
+	}
+	return n
+}
+
+// Paused returns reason of emergency pause and true if network is paused by root authority.
+func Paused(p core.NetworkParameters) (string, bool) {
+	return p.GetNetworkParameter(core.NetworkParameterEmergencyPause)
+}
+
+// Duration returns duration parameter or def if parameter isn't set or is malformed.
+func Duration(p core.NetworkParameters, name string, def time.Duration) time.Duration {
+	value, ok := p.GetNetworkParameter(name)
+	if !ok {
+		return def
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return def
+	}
+	return d
+}
@@ -0,0 +1,90 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package netparams
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/testutils"
+)
+
+type genesisDataProvider struct {
+	rootDomain core.RecordRef
+}
+
+func (p genesisDataProvider) GetRootDomain(ctx context.Context) *core.RecordRef {
+	return &p.rootDomain
+}
+
+func (p genesisDataProvider) GetNodeDomain(ctx context.Context) (*core.RecordRef, error) {
+	return nil, nil
+}
+
+func (p genesisDataProvider) GetRootMember(ctx context.Context) (*core.RecordRef, error) {
+	return nil, nil
+}
+
+func TestParameters_Refresh(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	rootDomain := testutils.RandomRef()
+	params := map[string]string{
+		core.NetworkParameterMaxQueueLength: "5",
+		core.NetworkParameterCallTimeout:    "20s",
+	}
+
+	cr := testutils.NewContractRequesterMock(t)
+	cr.SendRequestFunc = func(ctx context.Context, ref *core.RecordRef, method string, args []interface{}) (core.Reply, error) {
+		require.Equal(t, rootDomain, *ref)
+		require.Equal(t, "GetNetworkParameters", method)
+		data, err := core.Serialize([]interface{}{params, nil})
+		require.NoError(t, err)
+		return &reply.CallMethod{Result: data}, nil
+	}
+
+	p := New()
+	p.ContractRequester = cr
+	p.GenesisDataProvider = genesisDataProvider{rootDomain: rootDomain}
+
+	require.Equal(t, 10, Int(p, core.NetworkParameterMaxQueueLength, 10), "local value is used before refresh")
+
+	require.NoError(t, p.Refresh(ctx))
+	require.Equal(t, 5, Int(p, core.NetworkParameterMaxQueueLength, 10))
+	require.Equal(t, 20*time.Second, Duration(p, core.NetworkParameterCallTimeout, time.Second))
+
+	params = map[string]string{core.NetworkParameterMaxQueueLength: "many"}
+	require.NoError(t, p.Refresh(ctx))
+	require.Equal(t, 10, Int(p, core.NetworkParameterMaxQueueLength, 10), "malformed value is ignored")
+	require.Equal(t, time.Second, Duration(p, core.NetworkParameterCallTimeout, time.Second), "removed value isn't used")
+}
+
+func TestPaused(t *testing.T) {
+	p := New()
+	_, paused := Paused(p)
+	require.False(t, paused)
+
+	p.params = map[string]string{core.NetworkParameterEmergencyPause: "critical bug in wallet contract"}
+	reason, paused := Paused(p)
+	require.True(t, paused)
+	require.Equal(t, "critical bug in wallet contract", reason)
+}
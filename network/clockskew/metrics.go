@@ -0,0 +1,53 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package clockskew
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+
+	"github.com/insolar/insolar/instrumentation/insmetrics"
+)
+
+var (
+	statSkew = stats.Float64(
+		"clockskew/skew",
+		"estimated difference between local clock and network time",
+		stats.UnitMilliseconds,
+	)
+	statSkewedTotal = stats.Int64(
+		"clockskew/skewed/count",
+		"number of samples where clock skew exceeded threshold",
+		stats.UnitDimensionless,
+	)
+)
+
+func init() {
+	err := insmetrics.Register(
+		&view.View{
+			Measure:     statSkew,
+			Aggregation: view.LastValue(),
+		},
+		&view.View{
+			Measure:     statSkewedTotal,
+			Aggregation: view.Count(),
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+}
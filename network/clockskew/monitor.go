@@ -0,0 +1,149 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+// Package clockskew provides monitor of difference between local clock and network time.
+//
+// Consensus deadlines are calculated from pulse timestamps, so node with skewed clock
+// silently misses them. Monitor estimates skew from pulse timestamps and times reported
+// by neighbor nodes, logs and exposes it as metric and optionally makes node refuse executor roles.
+package clockskew
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// Monitor estimates clock skew of the node. Positive skew means local clock is ahead of network time.
+type Monitor struct {
+	cfg configuration.ClockSkew
+
+	lock         sync.RWMutex
+	pulseSamples []time.Duration
+	neighbors    map[core.RecordRef]time.Duration
+	skew         time.Duration
+	skewed       bool
+}
+
+// NewMonitor creates new clock skew Monitor.
+func NewMonitor(cfg configuration.ClockSkew) *Monitor {
+	if cfg.Window <= 0 {
+		cfg.Window = 1
+	}
+	return &Monitor{
+		cfg:       cfg,
+		neighbors: make(map[core.RecordRef]time.Duration),
+	}
+}
+
+// ObservePulse takes into account pulse received at local time receivedAt.
+func (m *Monitor) ObservePulse(ctx context.Context, pulse core.Pulse, receivedAt time.Time) {
+	// genesis pulse has a fixed timestamp in the past
+	if pulse.PulseTimestamp == 0 || pulse.PulseNumber == core.FirstPulseNumber {
+		return
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.pulseSamples = append(m.pulseSamples, receivedAt.Sub(time.Unix(pulse.PulseTimestamp, 0)))
+	if len(m.pulseSamples) > m.cfg.Window {
+		m.pulseSamples = m.pulseSamples[len(m.pulseSamples)-m.cfg.Window:]
+	}
+	m.update(ctx)
+}
+
+// ObserveNeighborTime takes into account time reported by neighbor node and received at local time receivedAt.
+func (m *Monitor) ObserveNeighborTime(ctx context.Context, node core.RecordRef, reported time.Time, receivedAt time.Time) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.neighbors[node] = receivedAt.Sub(reported)
+	m.update(ctx)
+}
+
+// Skew returns current estimation of clock skew.
+func (m *Monitor) Skew() time.Duration {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.skew
+}
+
+// IsSkewed returns true if clock skew exceeds threshold.
+func (m *Monitor) IsSkewed() bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.skewed
+}
+
+// RefuseExecutorRole returns true if clock is skewed and node is configured to refuse executor roles in this case.
+func (m *Monitor) RefuseExecutorRole() bool {
+	return m.cfg.RefuseExecutorRoles && m.IsSkewed()
+}
+
+func (m *Monitor) update(ctx context.Context) {
+	neighbors := make([]time.Duration, 0, len(m.neighbors))
+	for _, d := range m.neighbors {
+		neighbors = append(neighbors, d)
+	}
+
+	m.skew = median(m.pulseSamples)
+	if neighborSkew := median(neighbors); abs(neighborSkew) > abs(m.skew) {
+		m.skew = neighborSkew
+	}
+	stats.Record(ctx, statSkew.M(float64(m.skew)/float64(time.Millisecond)))
+
+	logger := inslogger.FromContext(ctx)
+	skewed := m.cfg.Threshold > 0 && abs(m.skew) > m.cfg.Threshold
+	if skewed {
+		stats.Record(ctx, statSkewedTotal.M(1))
+	}
+	switch {
+	case skewed && !m.skewed:
+		logger.Errorf(
+			"[ ClockSkew ] !!! LOCAL CLOCK IS SKEWED BY %s (threshold %s), CONSENSUS DEADLINES WILL BE MISSED. "+
+				"Synchronize system clock (e.g. with NTP). Refuse executor roles: %t",
+			m.skew, m.cfg.Threshold, m.cfg.RefuseExecutorRoles,
+		)
+	case !skewed && m.skewed:
+		logger.Infof("[ ClockSkew ] local clock is synchronized, skew is %s", m.skew)
+	}
+	m.skewed = skewed
+}
+
+func median(samples []time.Duration) time.Duration {
+	if len(samples) == 0 {
+		return 0
+	}
+	sorted := make([]time.Duration, len(samples))
+	copy(sorted, samples)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted[len(sorted)/2]
+}
+
+func abs(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
@@ -0,0 +1,73 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package clockskew
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+)
+
+func pulseAt(pn core.PulseNumber, t time.Time) core.Pulse {
+	return core.Pulse{PulseNumber: pn, PulseTimestamp: t.Unix()}
+}
+
+func TestMonitor_ObservePulse(t *testing.T) {
+	ctx := context.Background()
+	cfg := configuration.NewClockSkew()
+	cfg.Window = 3
+	m := NewMonitor(cfg)
+
+	now := time.Unix(time.Now().Unix(), 0)
+	m.ObservePulse(ctx, *core.GenesisPulse, now)
+	require.Equal(t, time.Duration(0), m.Skew())
+
+	m.ObservePulse(ctx, pulseAt(core.FirstPulseNumber+1, now), now)
+	require.False(t, m.IsSkewed())
+
+	// single outlier doesn't affect median
+	m.ObservePulse(ctx, pulseAt(core.FirstPulseNumber+2, now), now.Add(time.Minute))
+	m.ObservePulse(ctx, pulseAt(core.FirstPulseNumber+3, now), now)
+	require.False(t, m.IsSkewed())
+
+	m.ObservePulse(ctx, pulseAt(core.FirstPulseNumber+4, now), now.Add(-time.Minute))
+	m.ObservePulse(ctx, pulseAt(core.FirstPulseNumber+5, now), now.Add(-time.Minute))
+	require.True(t, m.IsSkewed())
+	require.Equal(t, -time.Minute, m.Skew())
+	require.False(t, m.RefuseExecutorRole())
+}
+
+func TestMonitor_ObserveNeighborTime(t *testing.T) {
+	ctx := context.Background()
+	cfg := configuration.NewClockSkew()
+	cfg.RefuseExecutorRoles = true
+	m := NewMonitor(cfg)
+
+	now := time.Unix(time.Now().Unix(), 0)
+	m.ObserveNeighborTime(ctx, testutils.RandomRef(), now.Add(-time.Minute), now)
+	m.ObserveNeighborTime(ctx, testutils.RandomRef(), now.Add(-time.Minute), now)
+	m.ObserveNeighborTime(ctx, testutils.RandomRef(), now, now)
+	require.True(t, m.IsSkewed())
+	require.True(t, m.RefuseExecutorRole())
+	require.Equal(t, time.Minute, m.Skew())
+}
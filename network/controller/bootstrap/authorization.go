@@ -43,9 +43,13 @@ type AuthorizationController interface {
 }
 
 type authorizationController struct {
-	NodeKeeper         network.NodeKeeper      `inject:""`
-	NetworkCoordinator core.NetworkCoordinator `inject:""`
-	SessionManager     SessionManager          `inject:""`
+	NodeKeeper         network.NodeKeeper          `inject:""`
+	NetworkCoordinator core.NetworkCoordinator     `inject:""`
+	SessionManager     SessionManager              `inject:""`
+	PulseStorage       core.PulseStorage           `inject:""`
+	Certificate        core.Certificate            `inject:""`
+	Cryptography       core.CryptographyService    `inject:""`
+	Revocations        core.CertificateRevocations `inject:""`
 
 	options   *common.Options
 	transport network.InternalTransport
@@ -78,8 +82,9 @@ type RegistrationRequest struct {
 
 // RegistrationResponse
 type RegistrationResponse struct {
-	Code  OperationCode
-	Error string
+	Code    OperationCode
+	Error   string
+	Package *JoinerPackage
 }
 
 func init() {
@@ -122,7 +127,8 @@ func (ac *authorizationController) Authorize(ctx context.Context, discoveryNode
 	return data.SessionID, nil
 }
 
-// Register node on the discovery node (step 4 of the bootstrap process)
+// Register node on the discovery node (step 4 of the bootstrap process).
+// The joiner package returned by the discovery node is verified and applied to the node keeper.
 func (ac *authorizationController) Register(ctx context.Context, discoveryNode *DiscoveryNode, sessionID SessionID) error {
 	inslogger.FromContext(ctx).Infof("Registering on host: %s", discoveryNode.Host)
 
@@ -151,7 +157,15 @@ func (ac *authorizationController) Register(ctx context.Context, discoveryNode *
 	if data.Code == OpRejected {
 		return errors.New("Register rejected: " + data.Error)
 	}
-	return nil
+	if data.Package == nil {
+		inslogger.FromContext(ctx).Warnf("Discovery node %s did not send joiner package", discoveryNode.Host)
+		return nil
+	}
+	err = data.Package.Verify(FindDiscovery(ac.Certificate, data.Package.Signer), ac.Cryptography)
+	if err != nil {
+		return errors.Wrap(err, "Failed to verify joiner package")
+	}
+	return data.Package.apply(ac.NodeKeeper)
 }
 
 func (ac *authorizationController) checkClaim(sessionID SessionID, claim *packets.NodeJoinClaim) error {
@@ -175,7 +189,21 @@ func (ac *authorizationController) processRegisterRequest(ctx context.Context, r
 	}
 	inslogger.FromContext(ctx).Infof("Added join claim from node %s", request.GetSender())
 	ac.NodeKeeper.AddPendingClaim(data.JoinClaim)
-	return ac.transport.BuildResponse(ctx, request, &RegistrationResponse{Code: OpConfirmed}), nil
+	return ac.transport.BuildResponse(ctx, request, &RegistrationResponse{Code: OpConfirmed, Package: ac.joinerPackage(ctx)}), nil
+}
+
+func (ac *authorizationController) joinerPackage(ctx context.Context) *JoinerPackage {
+	pulse, err := ac.PulseStorage.Current(ctx)
+	if err != nil {
+		inslogger.FromContext(ctx).Warn("Failed to get current pulse for joiner package: " + err.Error())
+		return nil
+	}
+	pkg, err := newJoinerPackage(pulse, ac.NodeKeeper, ac.Cryptography)
+	if err != nil {
+		inslogger.FromContext(ctx).Warn("Failed to assemble joiner package: " + err.Error())
+		return nil
+	}
+	return pkg
 }
 
 func (ac *authorizationController) processAuthorizeRequest(ctx context.Context, request network.Request) (network.Response, error) {
@@ -184,7 +212,19 @@ func (ac *authorizationController) processAuthorizeRequest(ctx context.Context,
 	if err != nil {
 		return ac.transport.BuildResponse(ctx, request, &AuthorizationResponse{Code: OpRejected, Error: err.Error()}), nil
 	}
+	if cert.GetRole() == core.StaticRoleObserver && !ac.options.AllowObservers {
+		err = errors.New("Observer nodes are not allowed in the network")
+		return ac.transport.BuildResponse(ctx, request, &AuthorizationResponse{Code: OpRejected, Error: err.Error()}), nil
+	}
+	if ac.Revocations.IsRevoked(*cert.GetNodeRef()) {
+		err = errors.New("Certificate is revoked")
+		return ac.transport.BuildResponse(ctx, request, &AuthorizationResponse{Code: OpRejected, Error: err.Error()}), nil
+	}
 	valid, err := ac.NetworkCoordinator.ValidateCert(ctx, cert)
+	if !valid && err == nil && ac.options.AllowEphemeral && certificate.IsSelfSigned(cert) {
+		inslogger.FromContext(ctx).Warnf("Admitting node %s with ephemeral self-signed certificate", cert.GetNodeRef())
+		valid = true
+	}
 	if !valid {
 		if err == nil {
 			err = errors.New("Certificate validation failed")
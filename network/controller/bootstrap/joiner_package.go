@@ -0,0 +1,122 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package bootstrap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/network"
+	"github.com/pkg/errors"
+)
+
+// JoinerPackage is a snapshot of the network assembled by a single discovery node on joiner registration.
+// It is signed by the discovery node, so the joiner can trust it after checking the signature against
+// the discovery node key from its certificate instead of collecting the same data from every node.
+type JoinerPackage struct {
+	PulseNumber core.PulseNumber
+	Entropy     core.Entropy
+	CloudHash   []byte
+	Nodes       []*NodeStruct
+
+	Signer    core.RecordRef
+	Signature []byte
+}
+
+func init() {
+	gob.Register(&JoinerPackage{})
+}
+
+func newJoinerPackage(pulse *core.Pulse, keeper network.NodeKeeper, signer core.CryptographyService) (*JoinerPackage, error) {
+	pkg := &JoinerPackage{
+		PulseNumber: pulse.PulseNumber,
+		Entropy:     pulse.Entropy,
+		CloudHash:   keeper.GetCloudHash(),
+		Signer:      keeper.GetOrigin().ID(),
+	}
+	for _, node := range keeper.GetActiveNodes() {
+		ns, err := newNodeStruct(node)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to serialize node %s", node.ID())
+		}
+		pkg.Nodes = append(pkg.Nodes, ns)
+	}
+
+	signature, err := signer.Sign(pkg.signedData())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign joiner package")
+	}
+	pkg.Signature = signature.Bytes()
+	return pkg, nil
+}
+
+// signedData returns deterministic binary representation of all package fields except the signature.
+func (jp *JoinerPackage) signedData() []byte {
+	var buf bytes.Buffer
+	writeBytes := func(data []byte) {
+		_ = binary.Write(&buf, binary.BigEndian, uint32(len(data)))
+		buf.Write(data)
+	}
+
+	_ = binary.Write(&buf, binary.BigEndian, jp.PulseNumber)
+	buf.Write(jp.Entropy[:])
+	writeBytes(jp.CloudHash)
+	buf.Write(jp.Signer[:])
+	_ = binary.Write(&buf, binary.BigEndian, uint32(len(jp.Nodes)))
+	for _, node := range jp.Nodes {
+		buf.Write(node.ID[:])
+		_ = binary.Write(&buf, binary.BigEndian, node.SID)
+		_ = binary.Write(&buf, binary.BigEndian, node.Role)
+		writeBytes(node.PK)
+		writeBytes([]byte(node.Address))
+		writeBytes([]byte(node.Version))
+	}
+	return buf.Bytes()
+}
+
+// Verify checks that the package is signed by the given discovery node.
+func (jp *JoinerPackage) Verify(discovery core.DiscoveryNode, verifier core.CryptographyService) error {
+	if discovery == nil {
+		return errors.New("joiner package signer is not a discovery node")
+	}
+	if !discovery.GetNodeRef().Equal(jp.Signer) {
+		return errors.Errorf("joiner package is signed by %s, expected discovery node %s", jp.Signer, discovery.GetNodeRef())
+	}
+	if !verifier.Verify(discovery.GetPublicKey(), core.SignatureFromBytes(jp.Signature), jp.signedData()) {
+		return errors.New("joiner package signature is invalid")
+	}
+	return nil
+}
+
+// apply adds consensus routes to every node of the package, so the joiner does not have to wait for
+// announce claims of the active nodes to reach them in the first consensus round.
+func (jp *JoinerPackage) apply(keeper network.NodeKeeper) error {
+	origin := keeper.GetOrigin().ID()
+	for _, node := range jp.Nodes {
+		if node.ID.Equal(origin) {
+			continue
+		}
+		err := keeper.AddTemporaryMapping(node.ID, node.SID, node.Address)
+		if err != nil {
+			return errors.Wrapf(err, "failed to add consensus mapping for node %s", node.ID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package bootstrap
+
+import (
+	"crypto"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/cryptography"
+	"github.com/insolar/insolar/network/nodenetwork"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+	"github.com/insolar/insolar/testutils/network"
+)
+
+func newTestNodeWithKey(t *testing.T, address string) (core.Node, crypto.PrivateKey) {
+	kp := platformpolicy.NewKeyProcessor()
+	key, err := kp.GeneratePrivateKey()
+	require.NoError(t, err)
+	node := nodenetwork.NewNode(testutils.RandomRef(), core.StaticRoleVirtual, kp.ExtractPublicKey(key), address, "")
+	return node, key
+}
+
+func TestJoinerPackage(t *testing.T) {
+	discovery, key := newTestNodeWithKey(t, "127.0.0.1:10000")
+	other, _ := newTestNodeWithKey(t, "127.0.0.1:20000")
+	joiner, _ := newTestNodeWithKey(t, "127.0.0.1:30000")
+	service := cryptography.NewKeyBoundCryptographyService(key)
+
+	keeper := network.NewNodeKeeperMock(t)
+	keeper.GetOriginMock.Return(discovery)
+	keeper.GetCloudHashMock.Return([]byte("cloud hash"))
+	keeper.GetActiveNodesMock.Return([]core.Node{discovery, other})
+
+	pulse := &core.Pulse{PulseNumber: core.FirstPulseNumber + 10}
+	pkg, err := newJoinerPackage(pulse, keeper, service)
+	require.NoError(t, err)
+	require.Len(t, pkg.Nodes, 2)
+
+	discoveryMeta := testutils.NewDiscoveryNodeMock(t)
+	discoveryMeta.GetNodeRefFunc = func() *core.RecordRef {
+		ref := discovery.ID()
+		return &ref
+	}
+	discoveryMeta.GetPublicKeyMock.Return(discovery.PublicKey())
+
+	assert.NoError(t, pkg.Verify(discoveryMeta, service))
+	assert.Error(t, pkg.Verify(nil, service))
+
+	pkg.CloudHash = []byte("forged hash")
+	assert.Error(t, pkg.Verify(discoveryMeta, service))
+	pkg.CloudHash = []byte("cloud hash")
+
+	pkg.Nodes[1].Address = "127.0.0.1:40000"
+	assert.Error(t, pkg.Verify(discoveryMeta, service))
+	pkg.Nodes[1].Address = other.Address()
+
+	mapped := map[core.RecordRef]string{}
+	joinerKeeper := network.NewNodeKeeperMock(t)
+	joinerKeeper.GetOriginMock.Return(joiner)
+	joinerKeeper.AddTemporaryMappingFunc = func(ref core.RecordRef, _ core.ShortNodeID, address string) error {
+		mapped[ref] = address
+		return nil
+	}
+	require.NoError(t, pkg.apply(joinerKeeper))
+	assert.Equal(t, map[core.RecordRef]string{
+		discovery.ID(): discovery.Address(),
+		other.ID():     other.Address(),
+	}, mapped)
+}
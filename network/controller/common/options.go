@@ -51,4 +51,13 @@ type Options struct {
 
 	// FakePulseDuration is a timeout to new pulse in ms
 	FakePulseDuration time.Duration
+
+	// AllowObservers - true to admit nodes with observer role
+	AllowObservers bool
+
+	// AllowEphemeral - true to admit nodes with self-signed ephemeral certificates, test networks only
+	AllowEphemeral bool
+
+	// MaxNodeMessageSize - max size of payload of application messages sent directly to nodes
+	MaxNodeMessageSize int
 }
@@ -60,6 +60,18 @@ func (c *Controller) SendCascadeMessage(data core.Cascade, method string, msg co
 	return c.RPCController.SendCascadeMessage(data, method, msg)
 }
 
+// SendToNode sends signed application message of platform component to node.
+func (c *Controller) SendToNode(
+	ctx context.Context, node core.RecordRef, topic string, payload []byte,
+) ([]byte, error) {
+	return c.RPCController.SendToNode(ctx, node, topic, payload)
+}
+
+// RegisterNodeHandler registers handler of application messages of topic.
+func (c *Controller) RegisterNodeHandler(topic string, handler core.NodeMessageHandler) {
+	c.RPCController.RegisterNodeHandler(topic, handler)
+}
+
 // Bootstrap init bootstrap process: 1. Connect to discovery node; 2. Reconnect to new discovery node if redirected.
 func (c *Controller) Bootstrap(ctx context.Context) (*network.BootstrapResult, error) {
 	return c.Bootstrapper.Bootstrap(ctx)
@@ -86,6 +98,9 @@ func ConfigureOptions(conf configuration.Configuration) *common.Options {
 		BootstrapTimeout:    10 * time.Second,
 		HandshakeSessionTTL: time.Duration(config.HandshakeSessionTTL) * time.Millisecond,
 		FakePulseDuration:   time.Duration(conf.Pulsar.PulseTime) * time.Millisecond,
+		AllowObservers:      config.AllowObservers,
+		AllowEphemeral:      config.AllowEphemeral,
+		MaxNodeMessageSize:  config.MaxNodeMessageSize,
 	}
 }
 
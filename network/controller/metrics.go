@@ -28,6 +28,7 @@ import (
 var (
 	tagMessageType = insmetrics.MustTagKey("messageType")
 	tagPacketType  = insmetrics.MustTagKey("packetType")
+	tagTopic       = insmetrics.MustTagKey("topic")
 )
 
 var (
@@ -41,6 +42,11 @@ var (
 		"size of replies to parcels",
 		stats.UnitBytes,
 	)
+	statNodeMessagesSentSizeBytes = stats.Int64(
+		"network/nodemessages/sent/size",
+		"size of payloads of application messages sent to nodes",
+		stats.UnitBytes,
+	)
 	statPacketsReceived = stats.Int64(
 		"network/packets/received",
 		"number of received packets",
@@ -49,7 +55,7 @@ var (
 )
 
 func init() {
-	err := view.Register(
+	err := insmetrics.Register(
 		&view.View{
 			Measure:     statParcelsSentSizeBytes,
 			Aggregation: view.Distribution(16, 32, 64, 128, 256, 512, 1024, 16*1<<10, 512*1<<10, 1<<20),
@@ -60,6 +66,11 @@ func init() {
 			Aggregation: view.Distribution(16, 32, 64, 128, 256, 512, 1024, 16*1<<10, 512*1<<10, 1<<20),
 			TagKeys:     []tag.Key{tagMessageType},
 		},
+		&view.View{
+			Measure:     statNodeMessagesSentSizeBytes,
+			Aggregation: view.Distribution(16, 32, 64, 128, 256, 512, 1024, 16*1<<10, 512*1<<10, 1<<20),
+			TagKeys:     []tag.Key{tagTopic},
+		},
 		&view.View{
 			Measure:     statPacketsReceived,
 			Aggregation: view.Count(),
@@ -0,0 +1,160 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+
+	"github.com/pkg/errors"
+	"go.opencensus.io/stats"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/instrumentation/insmetrics"
+	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/transport/packet/types"
+)
+
+// RequestNodeMessage is application message sent directly to node.
+type RequestNodeMessage struct {
+	TraceID   string
+	Topic     string
+	Payload   []byte
+	Signature []byte
+}
+
+// ResponseNodeMessage is reply of node handler to application message.
+type ResponseNodeMessage struct {
+	Success bool
+	Result  []byte
+	Error   string
+}
+
+func init() {
+	gob.Register(&RequestNodeMessage{})
+	gob.Register(&ResponseNodeMessage{})
+}
+
+// nodeMessageSigningData returns data signed by sender of application message. Receiver is signed too, so message
+// can't be redirected to another node.
+func nodeMessageSigningData(receiver core.RecordRef, topic string, payload []byte) []byte {
+	var buf bytes.Buffer
+	buf.Write(receiver.Bytes())
+	buf.WriteString(topic)
+	buf.WriteByte(0)
+	buf.Write(payload)
+	return buf.Bytes()
+}
+
+// SendToNode sends signed application message to handler of topic on node.
+func (rpc *rpcController) SendToNode(
+	ctx context.Context, node core.RecordRef, topic string, payload []byte,
+) ([]byte, error) {
+	if topic == "" {
+		return nil, errors.New("topic of node message is empty")
+	}
+	if len(payload) > rpc.options.MaxNodeMessageSize {
+		return nil, errors.Errorf(
+			"node message size %d exceeds limit %d", len(payload), rpc.options.MaxNodeMessageSize,
+		)
+	}
+	if rpc.NodeKeeper.GetActiveNode(node) == nil {
+		return nil, errors.Errorf("node %s is not active", node)
+	}
+
+	signature, err := rpc.CryptographyService.Sign(nodeMessageSigningData(node, topic, payload))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign node message")
+	}
+	request := rpc.hostNetwork.NewRequestBuilder().Type(types.NodeMessage).Data(&RequestNodeMessage{
+		TraceID:   inslogger.TraceID(ctx),
+		Topic:     topic,
+		Payload:   payload,
+		Signature: signature.Bytes(),
+	}).Build()
+
+	ctx = insmetrics.InsertTag(ctx, tagTopic, topic)
+	stats.Record(ctx, statNodeMessagesSentSizeBytes.M(int64(len(payload))))
+	future, err := rpc.hostNetwork.SendRequest(ctx, request, node)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to send node message to node %s", node)
+	}
+	response, err := future.GetResponse(rpc.options.PacketTimeout)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get reply to node message from node %s", node)
+	}
+	data := response.GetData().(*ResponseNodeMessage)
+	if !data.Success {
+		return nil, errors.New("node message handler returned error: " + data.Error)
+	}
+	return data.Result, nil
+}
+
+// RegisterNodeHandler registers handler of application messages of topic.
+func (rpc *rpcController) RegisterNodeHandler(topic string, handler core.NodeMessageHandler) {
+	rpc.nodeHandlersLock.Lock()
+	defer rpc.nodeHandlersLock.Unlock()
+	rpc.nodeHandlers[topic] = handler
+}
+
+func (rpc *rpcController) processNodeMessage(ctx context.Context, request network.Request) (network.Response, error) {
+	ctx = insmetrics.InsertTag(ctx, tagPacketType, request.GetType().String())
+	stats.Record(ctx, statPacketsReceived.M(1))
+
+	payload := request.GetData().(*RequestNodeMessage)
+	ctx, logger := inslogger.WithTraceField(ctx, payload.TraceID)
+	result, err := rpc.handleNodeMessage(ctx, request.GetSender(), payload)
+	if err != nil {
+		logger.Warnf("failed to handle node message %s from node %s: %s", payload.Topic, request.GetSender(), err)
+		response := &ResponseNodeMessage{Success: false, Error: err.Error()}
+		return rpc.hostNetwork.BuildResponse(ctx, request, response), nil
+	}
+	return rpc.hostNetwork.BuildResponse(ctx, request, &ResponseNodeMessage{Success: true, Result: result}), nil
+}
+
+// handleNodeMessage authenticates sender of application message and passes message to handler of its topic.
+func (rpc *rpcController) handleNodeMessage(
+	ctx context.Context, sender core.RecordRef, msg *RequestNodeMessage,
+) ([]byte, error) {
+	if len(msg.Payload) > rpc.options.MaxNodeMessageSize {
+		return nil, errors.Errorf(
+			"node message size %d exceeds limit %d", len(msg.Payload), rpc.options.MaxNodeMessageSize,
+		)
+	}
+	node := rpc.NodeKeeper.GetActiveNode(sender)
+	if node == nil {
+		return nil, errors.Errorf("sender %s is not active", sender)
+	}
+	origin := rpc.NodeKeeper.GetOrigin().ID()
+	ok := rpc.CryptographyService.Verify(
+		node.PublicKey(),
+		core.SignatureFromBytes(msg.Signature),
+		nodeMessageSigningData(origin, msg.Topic, msg.Payload),
+	)
+	if !ok {
+		return nil, errors.Errorf("invalid signature of node message from %s", sender)
+	}
+
+	rpc.nodeHandlersLock.RLock()
+	handler, ok := rpc.nodeHandlers[msg.Topic]
+	rpc.nodeHandlersLock.RUnlock()
+	if !ok {
+		return nil, errors.Errorf("handler of topic %s is not registered", msg.Topic)
+	}
+	return handler(ctx, sender, msg.Payload)
+}
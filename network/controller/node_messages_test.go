@@ -0,0 +1,109 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/cryptography"
+	"github.com/insolar/insolar/network/controller/common"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+	"github.com/insolar/insolar/testutils/network"
+)
+
+func TestRPCController_handleNodeMessage(t *testing.T) {
+	kp := platformpolicy.NewKeyProcessor()
+	newService := func() core.CryptographyService {
+		key, err := kp.GeneratePrivateKey()
+		require.NoError(t, err)
+		return cryptography.NewKeyBoundCryptographyService(key)
+	}
+	ctx := context.Background()
+	receiver, sender, unknown := testutils.RandomRef(), testutils.RandomRef(), testutils.RandomRef()
+	receiverService, senderService := newService(), newService()
+
+	origin := network.NewNodeMock(t)
+	origin.IDMock.Return(receiver)
+	senderNode := network.NewNodeMock(t)
+	senderKey, err := senderService.GetPublicKey()
+	require.NoError(t, err)
+	senderNode.PublicKeyMock.Return(senderKey)
+	keeper := network.NewNodeKeeperMock(t)
+	keeper.GetOriginMock.Return(origin)
+	keeper.GetActiveNodeFunc = func(ref core.RecordRef) core.Node {
+		if ref == sender {
+			return senderNode
+		}
+		return nil
+	}
+
+	rpc := &rpcController{
+		CryptographyService: receiverService,
+		NodeKeeper:          keeper,
+		options:             &common.Options{MaxNodeMessageSize: 16},
+		nodeHandlers:        map[string]core.NodeMessageHandler{},
+	}
+	rpc.RegisterNodeHandler("echo", func(ctx context.Context, from core.RecordRef, payload []byte) ([]byte, error) {
+		require.Equal(t, sender, from)
+		return payload, nil
+	})
+	newMessage := func(to core.RecordRef, topic string, payload []byte) *RequestNodeMessage {
+		signature, err := senderService.Sign(nodeMessageSigningData(to, topic, payload))
+		require.NoError(t, err)
+		return &RequestNodeMessage{Topic: topic, Payload: payload, Signature: signature.Bytes()}
+	}
+
+	result, err := rpc.handleNodeMessage(ctx, sender, newMessage(receiver, "echo", []byte("hello")))
+	require.NoError(t, err)
+	require.Equal(t, []byte("hello"), result)
+
+	_, err = rpc.handleNodeMessage(ctx, unknown, newMessage(receiver, "echo", []byte("hello")))
+	require.Contains(t, err.Error(), "is not active")
+
+	_, err = rpc.handleNodeMessage(ctx, sender, newMessage(unknown, "echo", []byte("hello")))
+	require.Contains(t, err.Error(), "invalid signature")
+
+	forged := newMessage(receiver, "echo", []byte("hello"))
+	forged.Payload = []byte("bye")
+	_, err = rpc.handleNodeMessage(ctx, sender, forged)
+	require.Contains(t, err.Error(), "invalid signature")
+
+	_, err = rpc.handleNodeMessage(ctx, sender, newMessage(receiver, "echo", make([]byte, 17)))
+	require.Contains(t, err.Error(), "exceeds limit")
+
+	_, err = rpc.handleNodeMessage(ctx, sender, newMessage(receiver, "unknown", []byte("hello")))
+	require.Contains(t, err.Error(), "is not registered")
+}
+
+func TestRPCController_SendToNode_Validation(t *testing.T) {
+	ctx := context.Background()
+	node := testutils.RandomRef()
+	keeper := network.NewNodeKeeperMock(t)
+	keeper.GetActiveNodeMock.Return(nil)
+	rpc := &rpcController{NodeKeeper: keeper, options: &common.Options{MaxNodeMessageSize: 16}}
+
+	_, err := rpc.SendToNode(ctx, node, "", []byte("hello"))
+	require.Contains(t, err.Error(), "topic")
+	_, err = rpc.SendToNode(ctx, node, "echo", make([]byte, 17))
+	require.Contains(t, err.Error(), "exceeds limit")
+	_, err = rpc.SendToNode(ctx, node, "echo", []byte("hello"))
+	require.Contains(t, err.Error(), "is not active")
+}
@@ -22,6 +22,7 @@ import (
 	"encoding/gob"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -38,6 +39,7 @@ import (
 	"github.com/insolar/insolar/network/cascade"
 	"github.com/insolar/insolar/network/controller/common"
 	"github.com/insolar/insolar/network/transport/packet/types"
+	"github.com/insolar/insolar/network/utils"
 )
 
 type RPCController interface {
@@ -49,14 +51,21 @@ type RPCController interface {
 	SendMessage(nodeID core.RecordRef, name string, msg core.Parcel) ([]byte, error)
 	SendCascadeMessage(data core.Cascade, method string, msg core.Parcel) error
 	RemoteProcedureRegister(name string, method core.RemoteProcedure)
+	SendToNode(ctx context.Context, node core.RecordRef, topic string, payload []byte) ([]byte, error)
+	RegisterNodeHandler(topic string, handler core.NodeMessageHandler)
 }
 
 type rpcController struct {
-	Scheme core.PlatformCryptographyScheme `inject:""`
+	Scheme              core.PlatformCryptographyScheme `inject:""`
+	CryptographyService core.CryptographyService        `inject:""`
+	NodeKeeper          network.NodeKeeper              `inject:""`
 
 	options     *common.Options
 	hostNetwork network.HostNetwork
 	methodTable map[string]core.RemoteProcedure
+
+	nodeHandlersLock sync.RWMutex
+	nodeHandlers     map[string]core.NodeMessageHandler
 }
 
 type RequestRPC struct {
@@ -220,6 +229,10 @@ func (rpc *rpcController) SendMessage(nodeID core.RecordRef, name string, msg co
 
 	start := time.Now()
 	ctx = msg.Context(ctx)
+	if message.IsPriorityParcel(msg) {
+		ctx = utils.ContextWithPriority(ctx)
+	}
+	ctx = utils.ContextWithTrafficClass(ctx, message.TrafficClass(msg.Type()))
 	logger := inslogger.FromContext(ctx)
 	logger.Debugf("SendParcel with nodeID = %s method = %s, message reference = %s, RequestID = %d", nodeID.String(),
 		name, msg.DefaultTarget().String(), request.GetRequestID())
@@ -278,12 +291,14 @@ func (rpc *rpcController) processCascade(ctx context.Context, request network.Re
 func (rpc *rpcController) Init(ctx context.Context) error {
 	rpc.hostNetwork.RegisterRequestHandler(types.RPC, rpc.processMessage)
 	rpc.hostNetwork.RegisterRequestHandler(types.Cascade, rpc.processCascade)
+	rpc.hostNetwork.RegisterRequestHandler(types.NodeMessage, rpc.processNodeMessage)
 	return nil
 }
 
 func NewRPCController(options *common.Options, hostNetwork network.HostNetwork) RPCController {
 	return &rpcController{options: options,
-		hostNetwork: hostNetwork,
-		methodTable: make(map[string]core.RemoteProcedure),
+		hostNetwork:  hostNetwork,
+		methodTable:  make(map[string]core.RemoteProcedure),
+		nodeHandlers: make(map[string]core.NodeMessageHandler),
 	}
 }
@@ -24,6 +24,7 @@ import (
 	"github.com/insolar/insolar/consensus"
 	"github.com/insolar/insolar/consensus/packets"
 	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/crashreport"
 	"github.com/insolar/insolar/log"
 	"github.com/insolar/insolar/network"
 	"github.com/insolar/insolar/network/sequence"
@@ -93,6 +94,8 @@ func (tc *transportConsensus) buildPacket(p packets.ConsensusPacket, receiver *h
 }
 
 func (tc *transportConsensus) processMessage(msg *packet.Packet) {
+	defer crashreport.Recover(context.Background(), "transportConsensus.processMessage")
+
 	p, ok := msg.Data.(packets.ConsensusPacket)
 	if !ok {
 		log.Error("Error processing incoming message: failed to convert to ConsensusPacket")
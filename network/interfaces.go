@@ -43,6 +43,10 @@ type Controller interface {
 	RemoteProcedureRegister(name string, method core.RemoteProcedure)
 	// SendCascadeMessage sends a message from MessageBus to a cascade of nodes.
 	SendCascadeMessage(data core.Cascade, method string, msg core.Parcel) error
+	// SendToNode sends signed application message of platform component to node.
+	SendToNode(ctx context.Context, node core.RecordRef, topic string, payload []byte) ([]byte, error)
+	// RegisterNodeHandler registers handler of application messages of topic.
+	RegisterNodeHandler(topic string, handler core.NodeMessageHandler)
 	// Bootstrap init complex bootstrap process. Blocks until bootstrap is complete.
 	Bootstrap(ctx context.Context) (*BootstrapResult, error)
 
@@ -220,6 +224,28 @@ type UnsyncList interface {
 type MergedListCopy struct {
 	ActiveList map[core.RecordRef]core.Node
 	Flags      MergedListFlags
+	// Leaves are graceful leaves of nodes merged from NodeLeaveClaims
+	Leaves []core.NodeLeave
+	// Loads are self-reported loads of nodes merged from NodeLoadClaims
+	Loads []core.NodeLoad
+	// Maintenance are maintenance windows merged from MaintenanceClaims
+	Maintenance []core.MaintenanceWindow
+	// Suspended are nodes with exhausted storage merged from NodeStorageClaims
+	Suspended []core.RecordRef
+	// Cordoned are nodes cordoned by operator merged from NodeCordonClaims
+	Cordoned []core.RecordRef
+}
+
+// LeaveHistory provides recent graceful leaves of nodes from the network.
+type LeaveHistory interface {
+	// GetLeaves returns recent graceful leaves, oldest first.
+	GetLeaves() []core.NodeLeave
+}
+
+// LoadHistory provides last self-reported loads of active nodes.
+type LoadHistory interface {
+	// GetLoads returns last reported load of every active node which reports it, sorted by node reference.
+	GetLoads() []core.NodeLoad
 }
 
 type MergedListFlags struct {
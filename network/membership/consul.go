@@ -0,0 +1,119 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package membership
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+)
+
+type consulService struct {
+	ID      string
+	Service string
+	Tags    []string
+	Address string
+	Port    int
+}
+
+type consulRegistration struct {
+	Node    string
+	Address string
+	Service *consulService `json:",omitempty"`
+}
+
+// Consul registers working nodes in catalog of Consul agent and deregisters nodes which left.
+// Node reference is used as Consul node name and service ID, role of node is a service tag.
+type Consul struct {
+	url     string
+	service string
+	port    int
+	client  *http.Client
+}
+
+// NewConsul creates new Consul publisher.
+func NewConsul(cfg configuration.Membership) *Consul {
+	return &Consul{
+		url:     strings.TrimSuffix(cfg.URL, "/"),
+		service: cfg.ServiceName,
+		port:    cfg.ServicePort,
+		client:  &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+// Publish implements Publisher.
+func (p *Consul) Publish(ctx context.Context, pulse core.PulseNumber, changes []Change) error {
+	for _, change := range changes {
+		var (
+			endpoint     string
+			registration consulRegistration
+			err          error
+		)
+		if change.Event == EventLeft {
+			endpoint = "/v1/catalog/deregister"
+			registration = consulRegistration{Node: change.Member.Node.String()}
+		} else {
+			endpoint = "/v1/catalog/register"
+			registration, err = p.registration(change.Member)
+			if err != nil {
+				return errors.Wrapf(err, "failed to register node %s", change.Member.Node)
+			}
+		}
+
+		data, err := json.Marshal(registration)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal registration")
+		}
+		err = send(ctx, p.client, http.MethodPut, p.url+endpoint, data)
+		if err != nil {
+			return errors.Wrapf(err, "failed to publish %s node %s", change.Event, change.Member.Node)
+		}
+	}
+	return nil
+}
+
+func (p *Consul) registration(member Member) (consulRegistration, error) {
+	host, port, err := net.SplitHostPort(member.Address)
+	if err != nil {
+		return consulRegistration{}, err
+	}
+	servicePort := p.port
+	if servicePort == 0 {
+		servicePort, err = strconv.Atoi(port)
+		if err != nil {
+			return consulRegistration{}, err
+		}
+	}
+	return consulRegistration{
+		Node:    member.Node.String(),
+		Address: host,
+		Service: &consulService{
+			ID:      member.Node.String(),
+			Service: p.service,
+			Tags:    []string{member.Role.String()},
+			Address: host,
+			Port:    servicePort,
+		},
+	}, nil
+}
@@ -0,0 +1,57 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package membership
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestConsul_Publish(t *testing.T) {
+	server, requests := recorder(t, http.StatusOK)
+	defer server.Close()
+
+	joined, left := testutils.RandomRef(), testutils.RandomRef()
+	p := NewConsul(configuration.Membership{URL: server.URL + "/", Timeout: time.Second, ServiceName: "insolar", ServicePort: 19101})
+	err := p.Publish(context.Background(), core.FirstPulseNumber, []Change{
+		{Event: EventJoined, Member: Member{Node: joined, Role: core.StaticRoleAPIGateway, Address: "10.0.0.1:13831"}},
+		{Event: EventLeft, Member: Member{Node: left, Role: core.StaticRoleAPIGateway, Address: "10.0.0.2:13831"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, *requests, 2)
+
+	require.Equal(t, http.MethodPut, (*requests)[0].method)
+	require.Equal(t, "/v1/catalog/register", (*requests)[0].path)
+	var registration consulRegistration
+	require.NoError(t, json.Unmarshal([]byte((*requests)[0].body), &registration))
+	require.Equal(t, consulRegistration{
+		Node:    joined.String(),
+		Address: "10.0.0.1",
+		Service: &consulService{ID: joined.String(), Service: "insolar", Tags: []string{"api_gateway"}, Address: "10.0.0.1", Port: 19101},
+	}, registration)
+
+	require.Equal(t, "/v1/catalog/deregister", (*requests)[1].path)
+	require.JSONEq(t, `{"Node": "`+left.String()+`", "Address": ""}`, (*requests)[1].body)
+}
@@ -0,0 +1,179 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+// Package membership publishes changes of network membership to external service discovery systems,
+// so load balancers in front of nodes follow network topology.
+//
+// Watcher compares working nodes every pulse and passes joined, left and changed nodes to Publisher.
+// Webhook and Consul publishers are built in, other systems are plugged in with Watcher.SetPublisher.
+package membership
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// Event is a kind of membership change.
+type Event string
+
+const (
+	// EventJoined is published when node becomes working.
+	EventJoined Event = "joined"
+	// EventLeft is published when node isn't working anymore.
+	EventLeft Event = "left"
+	// EventChanged is published when role or address of working node changes.
+	EventChanged Event = "changed"
+)
+
+// Member is a working node as it is seen by service discovery.
+type Member struct {
+	Node    core.RecordRef
+	Role    core.StaticRole
+	Address string
+}
+
+// Change is a change of network membership.
+type Change struct {
+	Event  Event
+	Member Member
+}
+
+// Publisher delivers membership changes to external service discovery system.
+type Publisher interface {
+	Publish(ctx context.Context, pulse core.PulseNumber, changes []Change) error
+}
+
+// Watcher publishes changes of working nodes every pulse. Changes which failed to be published are published
+// again with the next pulse.
+type Watcher struct {
+	NodeNetwork  core.NodeNetwork  `inject:""`
+	PulseStorage core.PulseStorage `inject:""`
+
+	roles map[core.StaticRole]bool
+
+	lock      sync.Mutex
+	publisher Publisher
+	// members holds members published last time, nil before the first publishing.
+	members map[core.RecordRef]Member
+}
+
+// NewWatcher creates new Watcher with publisher of configured backend.
+func NewWatcher(cfg configuration.Membership) (*Watcher, error) {
+	w := &Watcher{roles: make(map[core.StaticRole]bool)}
+	for _, role := range cfg.Roles {
+		staticRole := core.GetStaticRoleFromString(role)
+		if staticRole == core.StaticRoleUnknown {
+			return nil, errors.Errorf("unknown role %q", role)
+		}
+		w.roles[staticRole] = true
+	}
+
+	switch cfg.Backend {
+	case "":
+	case "webhook":
+		w.publisher = NewWebhook(cfg)
+	case "consul":
+		w.publisher = NewConsul(cfg)
+	default:
+		return nil, errors.Errorf("unknown backend %q", cfg.Backend)
+	}
+	return w, nil
+}
+
+// SetPublisher replaces publisher of configured backend, nil disables publishing.
+func (w *Watcher) SetPublisher(publisher Publisher) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.publisher = publisher
+	w.members = nil
+}
+
+// PeriodicTasks returns check of membership to be run by scheduler every pulse.
+func (w *Watcher) PeriodicTasks() []core.PeriodicTask {
+	return []core.PeriodicTask{
+		{
+			Name:     "membership.publish",
+			Schedule: core.TaskSchedule{Pulses: 1},
+			Run:      w.publish,
+		},
+	}
+}
+
+// publish publishes difference between working nodes and members published last time.
+func (w *Watcher) publish(ctx context.Context) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.publisher == nil {
+		return nil
+	}
+
+	members := make(map[core.RecordRef]Member)
+	for _, node := range w.NodeNetwork.GetWorkingNodes() {
+		if len(w.roles) > 0 && !w.roles[node.Role()] {
+			continue
+		}
+		members[node.ID()] = Member{Node: node.ID(), Role: node.Role(), Address: node.Address()}
+	}
+
+	changes := diff(w.members, members)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	pulse, err := w.PulseStorage.Current(ctx)
+	if err != nil {
+		return errors.Wrap(err, "[ membership ] failed to get current pulse")
+	}
+	err = w.publisher.Publish(ctx, pulse.PulseNumber, changes)
+	if err != nil {
+		return errors.Wrapf(err, "[ membership ] failed to publish %d changes", len(changes))
+	}
+	inslogger.FromContext(ctx).Infof("[ membership ] published %d changes of pulse %d", len(changes), pulse.PulseNumber)
+
+	w.members = members
+	return nil
+}
+
+// diff returns changes between previous and current members sorted by node.
+func diff(previous, current map[core.RecordRef]Member) []Change {
+	var changes []Change
+	for ref, member := range current {
+		old, ok := previous[ref]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Event: EventJoined, Member: member})
+		case old != member:
+			changes = append(changes, Change{Event: EventChanged, Member: member})
+		}
+	}
+	for ref, member := range previous {
+		if _, ok := current[ref]; !ok {
+			changes = append(changes, Change{Event: EventLeft, Member: member})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Member.Node.Compare(changes[j].Member.Node) < 0
+	})
+	return changes
+}
@@ -0,0 +1,121 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package membership
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/network/nodenetwork"
+	"github.com/insolar/insolar/testutils"
+	"github.com/insolar/insolar/testutils/network"
+)
+
+type publisher struct {
+	changes [][]Change
+	err     error
+}
+
+func (p *publisher) Publish(ctx context.Context, pulse core.PulseNumber, changes []Change) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.changes = append(p.changes, changes)
+	return nil
+}
+
+func TestNewWatcher(t *testing.T) {
+	cfg := configuration.NewMembership()
+	w, err := NewWatcher(cfg)
+	require.NoError(t, err)
+	require.Nil(t, w.publisher)
+
+	cfg.Backend = "consul"
+	w, err = NewWatcher(cfg)
+	require.NoError(t, err)
+	require.IsType(t, &Consul{}, w.publisher)
+
+	cfg.Backend = "zookeeper"
+	_, err = NewWatcher(cfg)
+	require.Error(t, err)
+
+	cfg.Backend = "webhook"
+	cfg.Roles = []string{"gateway"}
+	_, err = NewWatcher(cfg)
+	require.Error(t, err)
+}
+
+func TestWatcher_Publish(t *testing.T) {
+	ctx := context.Background()
+	gateway := nodenetwork.NewNode(testutils.RandomRef(), core.StaticRoleAPIGateway, nil, "127.0.0.1:1", "")
+	virtual := nodenetwork.NewNode(testutils.RandomRef(), core.StaticRoleVirtual, nil, "127.0.0.1:2", "")
+	working := []core.Node{gateway, virtual}
+
+	nn := network.NewNodeNetworkMock(t)
+	nn.GetWorkingNodesFunc = func() []core.Node { return working }
+	ps := testutils.NewPulseStorageMock(t)
+	ps.CurrentMock.Return(core.GenesisPulse, nil)
+
+	w, err := NewWatcher(configuration.NewMembership())
+	require.NoError(t, err)
+	w.NodeNetwork = nn
+	w.PulseStorage = ps
+	require.NoError(t, w.publish(ctx), "publishing is disabled")
+
+	p := &publisher{}
+	w.SetPublisher(p)
+	require.NoError(t, w.publish(ctx))
+	require.Equal(t, [][]Change{{{
+		Event:  EventJoined,
+		Member: Member{Node: gateway.ID(), Role: core.StaticRoleAPIGateway, Address: "127.0.0.1:1"},
+	}}}, p.changes, "only api gateways are published by default")
+
+	require.NoError(t, w.publish(ctx))
+	require.Len(t, p.changes, 1, "nothing changed")
+
+	working = nil
+	p.err = errors.New("unavailable")
+	require.Error(t, w.publish(ctx))
+
+	p.err = nil
+	require.NoError(t, w.publish(ctx))
+	require.Len(t, p.changes, 2)
+	require.Equal(t, EventLeft, p.changes[1][0].Event, "failed changes are published again")
+}
+
+func TestDiff(t *testing.T) {
+	a := Member{Node: testutils.RandomRef(), Role: core.StaticRoleVirtual, Address: "127.0.0.1:1"}
+	b := Member{Node: testutils.RandomRef(), Role: core.StaticRoleVirtual, Address: "127.0.0.1:2"}
+	c := Member{Node: testutils.RandomRef(), Role: core.StaticRoleVirtual, Address: "127.0.0.1:3"}
+	moved := b
+	moved.Address = "127.0.0.2:2"
+
+	changes := diff(
+		map[core.RecordRef]Member{a.Node: a, b.Node: b},
+		map[core.RecordRef]Member{b.Node: moved, c.Node: c},
+	)
+	require.Len(t, changes, 3)
+	events := map[Event]Member{}
+	for _, change := range changes {
+		events[change.Event] = change.Member
+	}
+	require.Equal(t, map[Event]Member{EventLeft: a, EventChanged: moved, EventJoined: c}, events)
+}
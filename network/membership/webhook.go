@@ -0,0 +1,94 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package membership
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+)
+
+// WebhookChange is a JSON representation of membership change sent to webhook.
+type WebhookChange struct {
+	Event   Event  `json:"event"`
+	Node    string `json:"node"`
+	Role    string `json:"role"`
+	Address string `json:"address"`
+}
+
+// WebhookBody is a JSON body of webhook request.
+type WebhookBody struct {
+	Pulse   core.PulseNumber `json:"pulse"`
+	Changes []WebhookChange  `json:"changes"`
+}
+
+// Webhook posts membership changes of pulse to generic webhook in one JSON request.
+type Webhook struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhook creates new Webhook publisher.
+func NewWebhook(cfg configuration.Membership) *Webhook {
+	return &Webhook{
+		url:    cfg.URL,
+		client: &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+// Publish implements Publisher.
+func (p *Webhook) Publish(ctx context.Context, pulse core.PulseNumber, changes []Change) error {
+	body := WebhookBody{Pulse: pulse, Changes: make([]WebhookChange, 0, len(changes))}
+	for _, change := range changes {
+		body.Changes = append(body.Changes, WebhookChange{
+			Event:   change.Event,
+			Node:    change.Member.Node.String(),
+			Role:    change.Member.Role.String(),
+			Address: change.Member.Address,
+		})
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal changes")
+	}
+	return send(ctx, p.client, http.MethodPost, p.url, data)
+}
+
+// send makes HTTP request with JSON body and checks its status.
+func send(ctx context.Context, client *http.Client, method, url string, data []byte) error {
+	req, err := http.NewRequest(method, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("unexpected status %s of %s", resp.Status, url)
+	}
+	return nil
+}
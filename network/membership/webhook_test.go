@@ -0,0 +1,78 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package membership
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+)
+
+type request struct {
+	method string
+	path   string
+	body   string
+}
+
+func recorder(t *testing.T, status int) (*httptest.Server, *[]request) {
+	var requests []request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		requests = append(requests, request{method: r.Method, path: r.URL.Path, body: string(body)})
+		w.WriteHeader(status)
+	}))
+	return server, &requests
+}
+
+func TestWebhook_Publish(t *testing.T) {
+	server, requests := recorder(t, http.StatusOK)
+	defer server.Close()
+
+	node := testutils.RandomRef()
+	p := NewWebhook(configuration.Membership{URL: server.URL, Timeout: time.Second})
+	err := p.Publish(context.Background(), core.FirstPulseNumber, []Change{
+		{Event: EventJoined, Member: Member{Node: node, Role: core.StaticRoleAPIGateway, Address: "127.0.0.1:1"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, *requests, 1)
+	require.Equal(t, http.MethodPost, (*requests)[0].method)
+
+	var body WebhookBody
+	require.NoError(t, json.Unmarshal([]byte((*requests)[0].body), &body))
+	require.Equal(t, WebhookBody{
+		Pulse:   core.FirstPulseNumber,
+		Changes: []WebhookChange{{Event: EventJoined, Node: node.String(), Role: "api_gateway", Address: "127.0.0.1:1"}},
+	}, body)
+}
+
+func TestWebhook_PublishFailed(t *testing.T) {
+	server, _ := recorder(t, http.StatusServiceUnavailable)
+	defer server.Close()
+
+	p := NewWebhook(configuration.Membership{URL: server.URL, Timeout: time.Second})
+	require.Error(t, p.Publish(context.Background(), core.FirstPulseNumber, []Change{{Event: EventLeft}}))
+}
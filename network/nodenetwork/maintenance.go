@@ -0,0 +1,119 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package nodenetwork
+
+import (
+	"context"
+	"sort"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// maintenanceHistorySize is a max number of maintenance windows kept by NodeKeeper.
+const maintenanceHistorySize = 16
+
+// setMaintenanceIssuers sets discovery nodes which are allowed to announce maintenance windows, the same nodes
+// vote for expelling nodes with revoked certificates.
+func (nk *nodekeeper) setMaintenanceIssuers(discovery []core.DiscoveryNode) {
+	nk.maintenanceLock.Lock()
+	defer nk.maintenanceLock.Unlock()
+
+	nk.maintenanceIssuers = make(map[core.RecordRef]bool, len(discovery))
+	for _, node := range discovery {
+		nk.maintenanceIssuers[*node.GetNodeRef()] = true
+	}
+}
+
+func (nk *nodekeeper) getMaintenanceIssuers() map[core.RecordRef]bool {
+	nk.maintenanceLock.RLock()
+	defer nk.maintenanceLock.RUnlock()
+	return nk.maintenanceIssuers
+}
+
+// MaintenanceAt implements core.MaintenanceSchedule.
+func (nk *nodekeeper) MaintenanceAt(pulse core.PulseNumber) *core.MaintenanceWindow {
+	nk.maintenanceLock.RLock()
+	defer nk.maintenanceLock.RUnlock()
+
+	for _, w := range nk.maintenance {
+		if w.Contains(pulse) {
+			window := w
+			return &window
+		}
+	}
+	return nil
+}
+
+// MaintenanceWindows implements core.MaintenanceSchedule.
+func (nk *nodekeeper) MaintenanceWindows() []core.MaintenanceWindow {
+	nk.maintenanceLock.RLock()
+	defer nk.maintenanceLock.RUnlock()
+
+	result := make([]core.MaintenanceWindow, len(nk.maintenance))
+	copy(result, nk.maintenance)
+	return result
+}
+
+// addMaintenance applies windows announced by discovery nodes. Windows are applied in the same order on every node,
+// so a window overlapping already scheduled one is rejected everywhere.
+func (nk *nodekeeper) addMaintenance(ctx context.Context, windows []core.MaintenanceWindow) {
+	if len(windows) == 0 {
+		return
+	}
+	logger := inslogger.FromContext(ctx)
+	sort.Slice(windows, func(i, j int) bool {
+		if windows[i].Start != windows[j].Start {
+			return windows[i].Start < windows[j].Start
+		}
+		return windows[i].Issuer.Compare(windows[j].Issuer) < 0
+	})
+
+	nk.maintenanceLock.Lock()
+	defer nk.maintenanceLock.Unlock()
+
+	for _, w := range windows {
+		if !nk.maintenanceIssuers[w.Issuer] {
+			logger.Warnf("[ MoveSyncToActive ] Ignoring maintenance window from non-discovery node %s", w.Issuer)
+			continue
+		}
+		if nk.overlapsMaintenance(w) {
+			logger.Warnf("[ MoveSyncToActive ] Ignoring maintenance window %d-%d from %s: overlaps scheduled one",
+				w.Start, w.End, w.Issuer)
+			continue
+		}
+		logger.Infof("[ MoveSyncToActive ] Maintenance scheduled for pulses %d-%d by %s, note: %q",
+			w.Start, w.End, w.Issuer, w.Note)
+		nk.maintenance = append(nk.maintenance, w)
+	}
+	sort.Slice(nk.maintenance, func(i, j int) bool {
+		return nk.maintenance[i].Start < nk.maintenance[j].Start
+	})
+	if len(nk.maintenance) > maintenanceHistorySize {
+		nk.maintenance = nk.maintenance[len(nk.maintenance)-maintenanceHistorySize:]
+	}
+}
+
+func (nk *nodekeeper) overlapsMaintenance(window core.MaintenanceWindow) bool {
+	for _, w := range nk.maintenance {
+		if w.Overlaps(window) {
+			return true
+		}
+	}
+	return false
+}
@@ -22,6 +22,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/insolar/insolar/instrumentation/inslogger"
 
@@ -39,6 +40,9 @@ import (
 	"go.opencensus.io/stats"
 )
 
+// leaveHistorySize is a max number of graceful leaves kept by NodeKeeper.
+const leaveHistorySize = 100
+
 // NewNodeNetwork create active node component
 func NewNodeNetwork(configuration configuration.HostNetwork, certificate core.Certificate) (core.NodeNetwork, error) {
 	origin, err := createOrigin(configuration, certificate)
@@ -46,6 +50,7 @@ func NewNodeNetwork(configuration configuration.HostNetwork, certificate core.Ce
 		return nil, errors.Wrap(err, "Failed to create origin node")
 	}
 	nodeKeeper := NewNodeKeeper(origin)
+	nodeKeeper.(*nodekeeper).setMaintenanceIssuers(certificate.GetDiscoveryNodes())
 	nodeKeeper.SetState(core.WaitingNodeNetworkState)
 	if len(certificate.GetDiscoveryNodes()) == 0 || utils.OriginIsDiscovery(certificate) {
 		nodeKeeper.SetState(core.ReadyNodeNetworkState)
@@ -71,7 +76,7 @@ func createOrigin(configuration configuration.HostNetwork, certificate core.Cert
 		role,
 		certificate.GetPublicKey(),
 		publicAddress,
-		version.Version,
+		version.GetNodeVersion(),
 	), nil
 }
 
@@ -99,6 +104,7 @@ func NewNodeKeeper(origin core.Node) network.NodeKeeper {
 		tempMapR:     make(map[core.RecordRef]*host.Host),
 		tempMapS:     make(map[core.ShortNodeID]*host.Host),
 		sync:         newUnsyncList(origin, []core.Node{}, 0),
+		loads:        make(map[core.RecordRef]core.NodeLoad),
 	}
 }
 
@@ -127,6 +133,20 @@ type nodekeeper struct {
 	isBootstrap     bool
 	isBootstrapLock sync.RWMutex
 
+	leavesLock sync.RWMutex
+	leaves     []core.NodeLeave
+
+	loadsLock sync.RWMutex
+	loads     map[core.RecordRef]core.NodeLoad
+
+	maintenanceLock    sync.RWMutex
+	maintenance        []core.MaintenanceWindow
+	maintenanceIssuers map[core.RecordRef]bool
+
+	suspendedLock sync.RWMutex
+	suspended     map[core.RecordRef]bool
+	cordoned      map[core.RecordRef]bool
+
 	Cryptography core.CryptographyService `inject:""`
 	Handler      core.TerminationHandler  `inject:""`
 }
@@ -386,11 +406,15 @@ func (nk *nodekeeper) NodesJoinedDuringPreviousPulse() bool {
 
 func (nk *nodekeeper) GetUnsyncList() network.UnsyncList {
 	activeNodes := nk.GetActiveNodes()
-	return newUnsyncList(nk.origin, activeNodes, len(activeNodes))
+	result := newUnsyncList(nk.origin, activeNodes, len(activeNodes))
+	result.discovery = nk.getMaintenanceIssuers()
+	return result
 }
 
 func (nk *nodekeeper) GetSparseUnsyncList(length int) network.UnsyncList {
-	return newSparseUnsyncList(nk.origin, length)
+	result := newSparseUnsyncList(nk.origin, length)
+	result.discovery = nk.getMaintenanceIssuers()
+	return result
 }
 
 func (nk *nodekeeper) Sync(list network.UnsyncList) {
@@ -438,13 +462,133 @@ func (nk *nodekeeper) MoveSyncToActive(ctx context.Context) error {
 
 	inslogger.FromContext(ctx).Infof("[ MoveSyncToActive ] New active list confirmed. Active list size: %d -> %d",
 		len(nk.active), len(mergeResult.ActiveList))
+	nk.addLeaves(ctx, mergeResult.Leaves)
 	nk.active = mergeResult.ActiveList
+	nk.updateLoads(mergeResult.Loads)
+	nk.addMaintenance(ctx, mergeResult.Maintenance)
+	nk.updateSuspended(ctx, mergeResult.Suspended, mergeResult.Cordoned)
 	stats.Record(ctx, consensus.ActiveNodes.M(int64(len(nk.active))))
 	nk.reindex()
 	nk.nodesJoinedDuringPrevPulse = mergeResult.Flags.NodesJoinedDuringPrevPulse
 	return nil
 }
 
+// GetLeaves implements network.LeaveHistory.
+func (nk *nodekeeper) GetLeaves() []core.NodeLeave {
+	nk.leavesLock.RLock()
+	defer nk.leavesLock.RUnlock()
+
+	result := make([]core.NodeLeave, len(nk.leaves))
+	copy(result, nk.leaves)
+	return result
+}
+
+func (nk *nodekeeper) addLeaves(ctx context.Context, leaves []core.NodeLeave) {
+	if len(leaves) == 0 {
+		return
+	}
+	now := time.Now()
+
+	nk.leavesLock.Lock()
+	defer nk.leavesLock.Unlock()
+	for _, leave := range leaves {
+		leave.Time = now
+		inslogger.FromContext(ctx).Infof("[ MoveSyncToActive ] Node %s left the network, reason: %s, note: %q",
+			leave.NodeID, leave.Reason, leave.Note)
+		nk.leaves = append(nk.leaves, leave)
+	}
+	if len(nk.leaves) > leaveHistorySize {
+		nk.leaves = nk.leaves[len(nk.leaves)-leaveHistorySize:]
+	}
+}
+
+// GetLoads implements network.LoadHistory.
+func (nk *nodekeeper) GetLoads() []core.NodeLoad {
+	nk.loadsLock.RLock()
+	defer nk.loadsLock.RUnlock()
+
+	result := make([]core.NodeLoad, 0, len(nk.loads))
+	for _, load := range nk.loads {
+		result = append(result, load)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].NodeID.Compare(result[j].NodeID) < 0
+	})
+	return result
+}
+
+// updateLoads keeps the last reported load of active nodes, must be called with activeLock.
+func (nk *nodekeeper) updateLoads(loads []core.NodeLoad) {
+	now := time.Now()
+
+	nk.loadsLock.Lock()
+	defer nk.loadsLock.Unlock()
+	for _, load := range loads {
+		load.Time = now
+		nk.loads[load.NodeID] = load
+	}
+	for ref := range nk.loads {
+		if _, ok := nk.active[ref]; !ok {
+			delete(nk.loads, ref)
+		}
+	}
+}
+
+// IsSuspended implements core.RoleSuspensions.
+func (nk *nodekeeper) IsSuspended(node core.RecordRef) bool {
+	nk.suspendedLock.RLock()
+	defer nk.suspendedLock.RUnlock()
+	return nk.suspended[node] || nk.cordoned[node]
+}
+
+// IsCordoned returns true if node announced cordon in the last consensus.
+func (nk *nodekeeper) IsCordoned(node core.RecordRef) bool {
+	nk.suspendedLock.RLock()
+	defer nk.suspendedLock.RUnlock()
+	return nk.cordoned[node]
+}
+
+// updateSuspended replaces suspended nodes with nodes which announced exhausted storage and cordoned nodes
+// with nodes which announced cordon in the last consensus.
+func (nk *nodekeeper) updateSuspended(ctx context.Context, exhausted []core.RecordRef, cordoned []core.RecordRef) {
+	logger := inslogger.FromContext(ctx)
+	suspended := refSet(exhausted)
+	cordons := refSet(cordoned)
+
+	nk.suspendedLock.Lock()
+	defer nk.suspendedLock.Unlock()
+	for ref := range suspended {
+		if !nk.suspended[ref] {
+			logger.Warnf("Node %s suspended taking roles: storage is exhausted", ref)
+		}
+	}
+	for ref := range cordons {
+		if !nk.cordoned[ref] {
+			logger.Warnf("Node %s suspended taking roles: node is cordoned", ref)
+		}
+	}
+	for ref := range nk.suspended {
+		if !suspended[ref] && !cordons[ref] && !nk.cordoned[ref] {
+			logger.Infof("Node %s resumed taking roles", ref)
+		}
+	}
+	for ref := range nk.cordoned {
+		if !cordons[ref] && !suspended[ref] {
+			logger.Infof("Node %s resumed taking roles", ref)
+		}
+	}
+	nk.suspended = suspended
+	nk.cordoned = cordons
+}
+
+func refSet(refs []core.RecordRef) map[core.RecordRef]bool {
+	set := make(map[core.RecordRef]bool, len(refs))
+	for _, ref := range refs {
+		set[ref] = true
+	}
+	return set
+}
+
 func (nk *nodekeeper) gracefullyStop() {
 	// TODO: graceful stop
 	nk.Handler.Abort()
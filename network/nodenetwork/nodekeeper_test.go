@@ -16,3 +16,195 @@
  */
 
 package nodenetwork
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	consensus "github.com/insolar/insolar/consensus/packets"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestNodekeeper_MoveSyncToActive_RecordsLeaves(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	origin := newMutableNode(testutils.RandomRef(), core.StaticRoleVirtual, nil, "127.0.0.1:0", "")
+	leaving := newMutableNode(testutils.RandomRef(), core.StaticRoleVirtual, nil, "127.0.0.1:1", "")
+	nk := NewNodeKeeper(origin).(*nodekeeper)
+	nk.AddActiveNodes([]core.Node{origin, leaving})
+
+	claim := consensus.NewNodeLeaveClaim(core.LeaveReasonDecommission, "hardware retired")
+	claim.AddSupplementaryInfo(leaving.ID())
+	nk.sync = nk.GetUnsyncList()
+	require.NoError(t, nk.sync.AddClaims(map[core.RecordRef][]consensus.ReferendumClaim{
+		leaving.ID(): {claim},
+	}))
+
+	require.NoError(t, nk.MoveSyncToActive(ctx))
+	require.Nil(t, nk.GetActiveNode(leaving.ID()))
+
+	leaves := nk.GetLeaves()
+	require.Len(t, leaves, 1)
+	require.Equal(t, leaving.ID(), leaves[0].NodeID)
+	require.Equal(t, core.LeaveReasonDecommission, leaves[0].Reason)
+	require.Equal(t, "hardware retired", leaves[0].Note)
+	require.False(t, leaves[0].Time.IsZero())
+}
+
+func TestNodekeeper_MoveSyncToActive_RecordsLoads(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	origin := newMutableNode(testutils.RandomRef(), core.StaticRoleVirtual, nil, "127.0.0.1:0", "")
+	other := newMutableNode(testutils.RandomRef(), core.StaticRoleVirtual, nil, "127.0.0.1:1", "")
+	nk := NewNodeKeeper(origin).(*nodekeeper)
+	nk.AddActiveNodes([]core.Node{origin, other})
+
+	claim := consensus.NewNodeLoadClaim(core.NodeLoad{CPU: 30, QueueDepth: 7, StorageHeadroom: 90})
+	claim.AddSupplementaryInfo(other.ID())
+	nk.sync = nk.GetUnsyncList()
+	require.NoError(t, nk.sync.AddClaims(map[core.RecordRef][]consensus.ReferendumClaim{
+		other.ID(): {claim},
+	}))
+	require.NoError(t, nk.MoveSyncToActive(ctx))
+
+	loads := nk.GetLoads()
+	require.Len(t, loads, 1)
+	require.Equal(t, other.ID(), loads[0].NodeID)
+	require.Equal(t, uint8(30), loads[0].CPU)
+	require.Equal(t, uint16(7), loads[0].QueueDepth)
+	require.Equal(t, uint8(90), loads[0].StorageHeadroom)
+	require.False(t, loads[0].Time.IsZero())
+
+	// load of node which left the network is dropped
+	leave := consensus.NewNodeLeaveClaim(core.LeaveReasonMaintenance, "")
+	leave.AddSupplementaryInfo(other.ID())
+	nk.sync = nk.GetUnsyncList()
+	require.NoError(t, nk.sync.AddClaims(map[core.RecordRef][]consensus.ReferendumClaim{
+		other.ID(): {leave},
+	}))
+	require.NoError(t, nk.MoveSyncToActive(ctx))
+	require.Empty(t, nk.GetLoads())
+}
+
+func TestNodekeeper_MoveSyncToActive_RecordsMaintenance(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	origin := newMutableNode(testutils.RandomRef(), core.StaticRoleVirtual, nil, "127.0.0.1:0", "")
+	discovery := newMutableNode(testutils.RandomRef(), core.StaticRoleHeavyMaterial, nil, "127.0.0.1:1", "")
+	other := newMutableNode(testutils.RandomRef(), core.StaticRoleVirtual, nil, "127.0.0.1:2", "")
+	nk := NewNodeKeeper(origin).(*nodekeeper)
+	nk.AddActiveNodes([]core.Node{origin, discovery, other})
+
+	discoveryNode := testutils.NewDiscoveryNodeMock(t)
+	discoveryRef := discovery.ID()
+	discoveryNode.GetNodeRefMock.Return(&discoveryRef)
+	nk.setMaintenanceIssuers([]core.DiscoveryNode{discoveryNode})
+
+	scheduled := core.MaintenanceWindow{Issuer: discovery.ID(), Start: 100, End: 120, Note: "certificate rollover"}
+	overlapping := core.MaintenanceWindow{Issuer: discovery.ID(), Start: 110, End: 130}
+	foreign := core.MaintenanceWindow{Issuer: other.ID(), Start: 200, End: 210}
+	nk.sync = nk.GetUnsyncList()
+	require.NoError(t, nk.sync.AddClaims(map[core.RecordRef][]consensus.ReferendumClaim{
+		discovery.ID(): {consensus.NewMaintenanceClaim(overlapping, nil), consensus.NewMaintenanceClaim(scheduled, nil)},
+		other.ID():     {consensus.NewMaintenanceClaim(foreign, nil)},
+	}))
+	require.NoError(t, nk.MoveSyncToActive(ctx))
+
+	require.Equal(t, []core.MaintenanceWindow{scheduled}, nk.MaintenanceWindows())
+	require.Nil(t, nk.MaintenanceAt(99))
+	require.Equal(t, &scheduled, nk.MaintenanceAt(100))
+	require.Equal(t, &scheduled, nk.MaintenanceAt(120))
+	require.Nil(t, nk.MaintenanceAt(121))
+	require.Nil(t, nk.MaintenanceAt(200))
+}
+
+func TestNodekeeper_MoveSyncToActive_RecordsSuspensions(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	origin := newMutableNode(testutils.RandomRef(), core.StaticRoleVirtual, nil, "127.0.0.1:0", "")
+	exhausted := newMutableNode(testutils.RandomRef(), core.StaticRoleLightMaterial, nil, "127.0.0.1:1", "")
+	nk := NewNodeKeeper(origin).(*nodekeeper)
+	nk.AddActiveNodes([]core.Node{origin, exhausted})
+
+	claim := consensus.NewNodeStorageClaim(2)
+	claim.AddSupplementaryInfo(exhausted.ID())
+	nk.sync = nk.GetUnsyncList()
+	require.NoError(t, nk.sync.AddClaims(map[core.RecordRef][]consensus.ReferendumClaim{
+		exhausted.ID(): {claim},
+	}))
+	require.NoError(t, nk.MoveSyncToActive(ctx))
+	require.True(t, nk.IsSuspended(exhausted.ID()))
+	require.False(t, nk.IsSuspended(origin.ID()))
+
+	// node resumes taking roles when it stops announcing exhausted storage
+	nk.sync = nk.GetUnsyncList()
+	require.NoError(t, nk.MoveSyncToActive(ctx))
+	require.False(t, nk.IsSuspended(exhausted.ID()))
+}
+
+func TestNodekeeper_MoveSyncToActive_RecordsCordons(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	origin := newMutableNode(testutils.RandomRef(), core.StaticRoleVirtual, nil, "127.0.0.1:0", "")
+	cordoned := newMutableNode(testutils.RandomRef(), core.StaticRoleVirtual, nil, "127.0.0.1:1", "")
+	nk := NewNodeKeeper(origin).(*nodekeeper)
+	nk.AddActiveNodes([]core.Node{origin, cordoned})
+
+	claim := consensus.NewNodeCordonClaim(core.FirstPulseNumber)
+	claim.AddSupplementaryInfo(cordoned.ID())
+	nk.sync = nk.GetUnsyncList()
+	require.NoError(t, nk.sync.AddClaims(map[core.RecordRef][]consensus.ReferendumClaim{
+		cordoned.ID(): {claim},
+	}))
+	require.NoError(t, nk.MoveSyncToActive(ctx))
+	require.True(t, nk.IsSuspended(cordoned.ID()))
+	require.True(t, nk.IsCordoned(cordoned.ID()))
+	require.False(t, nk.IsCordoned(origin.ID()))
+
+	nk.sync = nk.GetUnsyncList()
+	require.NoError(t, nk.MoveSyncToActive(ctx))
+	require.False(t, nk.IsSuspended(cordoned.ID()))
+	require.False(t, nk.IsCordoned(cordoned.ID()))
+}
+
+func TestNodekeeper_MoveSyncToActive_ExpelsByMajority(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	origin := newMutableNode(testutils.RandomRef(), core.StaticRoleVirtual, nil, "127.0.0.1:0", "")
+	revoked := newMutableNode(testutils.RandomRef(), core.StaticRoleVirtual, nil, "127.0.0.1:1", "")
+	nodes := []core.Node{origin, revoked}
+	discovery := make([]core.DiscoveryNode, 3)
+	issuers := make([]core.Node, len(discovery))
+	for i := range discovery {
+		node := newMutableNode(testutils.RandomRef(), core.StaticRoleHeavyMaterial, nil, "127.0.0.1:2", "")
+		nodes = append(nodes, node)
+		issuers[i] = node
+		ref := node.ID()
+		discoveryNode := testutils.NewDiscoveryNodeMock(t)
+		discoveryNode.GetNodeRefMock.Return(&ref)
+		discovery[i] = discoveryNode
+	}
+	nk := NewNodeKeeper(origin).(*nodekeeper)
+	nk.AddActiveNodes(nodes)
+	nk.setMaintenanceIssuers(discovery)
+
+	vote := func(issuer core.Node) consensus.ReferendumClaim {
+		claim := consensus.NewNodeExpelClaim(revoked.ID())
+		claim.AddSupplementaryInfo(issuer.ID())
+		return claim
+	}
+
+	// single discovery node and ordinary node can't expel
+	nk.sync = nk.GetUnsyncList()
+	require.NoError(t, nk.sync.AddClaims(map[core.RecordRef][]consensus.ReferendumClaim{
+		issuers[0].ID(): {vote(issuers[0])},
+		origin.ID():     {vote(origin)},
+	}))
+	require.NoError(t, nk.MoveSyncToActive(ctx))
+	require.NotNil(t, nk.GetActiveNode(revoked.ID()))
+
+	nk.sync = nk.GetUnsyncList()
+	require.NoError(t, nk.sync.AddClaims(map[core.RecordRef][]consensus.ReferendumClaim{
+		issuers[0].ID(): {vote(issuers[0])},
+		issuers[1].ID(): {vote(issuers[1])},
+	}))
+	require.NoError(t, nk.MoveSyncToActive(ctx))
+	require.Nil(t, nk.GetActiveNode(revoked.ID()))
+}
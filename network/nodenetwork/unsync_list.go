@@ -46,6 +46,8 @@ type unsyncList struct {
 	ghs         map[core.RecordRef]consensus.GlobuleHashSignature
 	indexToRef  map[int]core.RecordRef
 	cache       []byte
+	// discovery are discovery nodes, node is expelled when majority of them issue NodeExpelClaims for it
+	discovery map[core.RecordRef]bool
 }
 
 func (ul *unsyncList) GetGlobuleHashSignature(ref core.RecordRef) (consensus.GlobuleHashSignature, bool) {
@@ -169,8 +171,35 @@ func (ul *unsyncList) GetMergedCopy() (*network.MergedListCopy, error) {
 	nodes := copyActiveNodes(ul.activeNodes)
 
 	resultFlags := network.MergedListFlags{}
+	var leaves []core.NodeLeave
+	var loads []core.NodeLoad
+	var maintenance []core.MaintenanceWindow
+	var suspended []core.RecordRef
+	var cordoned []core.RecordRef
+	expelVotes := make(map[core.RecordRef]map[core.RecordRef]bool)
 	for _, claimList := range ul.claims {
 		for _, claim := range claimList {
+			if leave, ok := claim.(*consensus.NodeLeaveClaim); ok {
+				leaves = append(leaves, core.NodeLeave{NodeID: leave.NodeID, Reason: leave.Reason, Note: leave.GetNote()})
+			}
+			if load, ok := claim.(*consensus.NodeLoadClaim); ok {
+				loads = append(loads, load.GetLoad())
+			}
+			if m, ok := claim.(*consensus.MaintenanceClaim); ok {
+				maintenance = append(maintenance, m.GetWindow())
+			}
+			if storage, ok := claim.(*consensus.NodeStorageClaim); ok {
+				suspended = append(suspended, storage.NodeID)
+			}
+			if cordon, ok := claim.(*consensus.NodeCordonClaim); ok {
+				cordoned = append(cordoned, cordon.NodeID)
+			}
+			if expel, ok := claim.(*consensus.NodeExpelClaim); ok && ul.discovery[expel.NodeID] {
+				if expelVotes[expel.Target] == nil {
+					expelVotes[expel.Target] = make(map[core.RecordRef]bool)
+				}
+				expelVotes[expel.Target][expel.NodeID] = true
+			}
 			flags, err := ul.mergeClaim(ul.origin, nodes, claim)
 			if err != nil {
 				return nil, errors.Wrap(err, "[ GetMergedCopy ] failed to merge a claim")
@@ -183,10 +212,25 @@ func (ul *unsyncList) GetMergedCopy() (*network.MergedListCopy, error) {
 			}
 		}
 	}
+	for target, votes := range expelVotes {
+		if len(votes) < len(ul.discovery)/2+1 {
+			continue
+		}
+		log.Warnf("[ GetMergedCopy ] Node %s is expelled by %d discovery nodes", target, len(votes))
+		delete(nodes, target)
+		if ul.origin.ID().Equal(target) {
+			resultFlags.ShouldExit = true
+		}
+	}
 
 	return &network.MergedListCopy{
-		ActiveList: nodes,
-		Flags:      resultFlags,
+		ActiveList:  nodes,
+		Flags:       resultFlags,
+		Leaves:      leaves,
+		Loads:       loads,
+		Maintenance: maintenance,
+		Suspended:   suspended,
+		Cordoned:    cordoned,
 	}, nil
 }
 
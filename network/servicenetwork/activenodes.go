@@ -0,0 +1,91 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package servicenetwork
+
+import (
+	"sort"
+
+	"github.com/insolar/insolar/core"
+)
+
+// GetActiveNodesInfo implements core.ActiveNodesProvider. Nodes are sorted here so the order doesn't depend on
+// NodeKeeper implementation.
+func (n *ServiceNetwork) GetActiveNodesInfo(roles []core.StaticRole, states []core.NodeState) []core.ActiveNodeInfo {
+	n.joinPulsesLock.RLock()
+	defer n.joinPulsesLock.RUnlock()
+
+	result := make([]core.ActiveNodeInfo, 0)
+	for _, node := range n.NodeKeeper.GetActiveNodes() {
+		if !hasRole(roles, node.Role()) || !hasState(states, node.GetState()) {
+			continue
+		}
+		result = append(result, core.ActiveNodeInfo{
+			ID:        node.ID(),
+			Role:      node.Role(),
+			Address:   node.Address(),
+			JoinPulse: n.joinPulses[node.ID()],
+			State:     node.GetState(),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID.Compare(result[j].ID) < 0
+	})
+	return result
+}
+
+// updateJoinPulses remembers pulse when node appeared in active list and forgets nodes which left it.
+func (n *ServiceNetwork) updateJoinPulses(pulse core.PulseNumber) {
+	n.joinPulsesLock.Lock()
+	defer n.joinPulsesLock.Unlock()
+
+	active := make(map[core.RecordRef]struct{})
+	for _, node := range n.NodeKeeper.GetActiveNodes() {
+		active[node.ID()] = struct{}{}
+		if _, ok := n.joinPulses[node.ID()]; !ok {
+			n.joinPulses[node.ID()] = pulse
+		}
+	}
+	for id := range n.joinPulses {
+		if _, ok := active[id]; !ok {
+			delete(n.joinPulses, id)
+		}
+	}
+}
+
+func hasRole(roles []core.StaticRole, role core.StaticRole) bool {
+	if len(roles) == 0 {
+		return true
+	}
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func hasState(states []core.NodeState, state core.NodeState) bool {
+	if len(states) == 0 {
+		return true
+	}
+	for _, s := range states {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
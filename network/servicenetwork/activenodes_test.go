@@ -0,0 +1,78 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package servicenetwork
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/network/nodenetwork"
+	"github.com/insolar/insolar/testutils"
+	"github.com/insolar/insolar/testutils/network"
+)
+
+func TestServiceNetwork_GetActiveNodesInfo(t *testing.T) {
+	virtual := nodenetwork.NewNode(testutils.RandomRef(), core.StaticRoleVirtual, nil, "127.0.0.1:1", "")
+	light := nodenetwork.NewNode(testutils.RandomRef(), core.StaticRoleLightMaterial, nil, "127.0.0.1:2", "")
+	joining := nodenetwork.NewNode(testutils.RandomRef(), core.StaticRoleVirtual, nil, "127.0.0.1:3", "")
+	joining.(nodenetwork.MutableNode).SetState(core.NodeJoining)
+
+	nk := network.NewNodeKeeperMock(t)
+	nk.GetActiveNodesMock.Return([]core.Node{virtual, light})
+	n := &ServiceNetwork{NodeKeeper: nk, joinPulses: make(map[core.RecordRef]core.PulseNumber)}
+
+	n.updateJoinPulses(core.FirstPulseNumber)
+	nk.GetActiveNodesMock.Return([]core.Node{virtual, joining})
+	n.updateJoinPulses(core.FirstPulseNumber + 1)
+	require.NotContains(t, n.joinPulses, light.ID())
+
+	// node keeper returns nodes in reverse order of IDs
+	if virtual.ID().Compare(joining.ID()) < 0 {
+		nk.GetActiveNodesMock.Return([]core.Node{joining, virtual})
+	}
+	expected := []core.ActiveNodeInfo{
+		{
+			ID:        virtual.ID(),
+			Role:      core.StaticRoleVirtual,
+			Address:   "127.0.0.1:1",
+			JoinPulse: core.FirstPulseNumber,
+			State:     virtual.GetState(),
+		},
+		{
+			ID:        joining.ID(),
+			Role:      core.StaticRoleVirtual,
+			Address:   "127.0.0.1:3",
+			JoinPulse: core.FirstPulseNumber + 1,
+			State:     core.NodeJoining,
+		},
+	}
+	sort.Slice(expected, func(i, j int) bool {
+		return expected[i].ID.Compare(expected[j].ID) < 0
+	})
+
+	all := n.GetActiveNodesInfo(nil, nil)
+	require.Equal(t, expected, all)
+
+	filtered := n.GetActiveNodesInfo([]core.StaticRole{core.StaticRoleVirtual}, []core.NodeState{core.NodeJoining})
+	require.Len(t, filtered, 1)
+	require.Equal(t, joining.ID(), filtered[0].ID)
+
+	require.Empty(t, n.GetActiveNodesInfo([]core.StaticRole{core.StaticRoleHeavyMaterial}, nil))
+}
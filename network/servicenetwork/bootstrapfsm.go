@@ -16,3 +16,214 @@
  */
 
 package servicenetwork
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/consensus/packets"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
+)
+
+// nodeModeHistoryLimit is a max number of transitions kept in node mode history.
+const nodeModeHistoryLimit = 100
+
+var (
+	// automaticTransitions are mode changes made by node itself. Cordoned and draining modes are left only by
+	// operator request or on stop, stopped mode is final.
+	automaticTransitions = map[core.NodeMode][]core.NodeMode{
+		core.NodeModeBootstrapping: {core.NodeModeSyncing, core.NodeModeParticipant, core.NodeModeStopped},
+		core.NodeModeSyncing:       {core.NodeModeParticipant, core.NodeModeStopped},
+		core.NodeModeParticipant:   {core.NodeModeSyncing, core.NodeModeStopped},
+		core.NodeModeCordoned:      {core.NodeModeStopped},
+		core.NodeModeDraining:      {core.NodeModeStopped},
+	}
+	// requestedTransitions are mode changes operator can request.
+	requestedTransitions = map[core.NodeMode][]core.NodeMode{
+		core.NodeModeSyncing:     {core.NodeModeCordoned, core.NodeModeDraining},
+		core.NodeModeParticipant: {core.NodeModeCordoned, core.NodeModeDraining},
+		core.NodeModeCordoned:    {core.NodeModeParticipant, core.NodeModeDraining},
+	}
+)
+
+func transitionAllowed(table map[core.NodeMode][]core.NodeMode, from, to core.NodeMode) bool {
+	for _, mode := range table[from] {
+		if mode == to {
+			return true
+		}
+	}
+	return false
+}
+
+// nodeModeMachine guards transitions between node modes and keeps recent transitions.
+type nodeModeMachine struct {
+	lock    sync.RWMutex
+	mode    core.NodeMode
+	history []core.NodeModeTransition
+	now     func() time.Time
+}
+
+func newNodeModeMachine() *nodeModeMachine {
+	return &nodeModeMachine{mode: core.NodeModeBootstrapping, now: time.Now}
+}
+
+func (m *nodeModeMachine) Mode() core.NodeMode {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.mode
+}
+
+func (m *nodeModeMachine) History() []core.NodeModeTransition {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return append([]core.NodeModeTransition(nil), m.history...)
+}
+
+// cordonedSince returns pulse of transition to cordoned mode, ok is false if node isn't cordoned.
+func (m *nodeModeMachine) cordonedSince() (core.PulseNumber, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	if m.mode != core.NodeModeCordoned {
+		return 0, false
+	}
+	var since core.PulseNumber
+	if len(m.history) > 0 {
+		since = m.history[len(m.history)-1].Pulse
+	}
+	return since, true
+}
+
+// advance switches mode if node itself is allowed to do it from current mode, ok is false if mode isn't changed.
+func (m *nodeModeMachine) advance(
+	to core.NodeMode, pulse core.PulseNumber, reason string,
+) (core.NodeModeTransition, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if !transitionAllowed(automaticTransitions, m.mode, to) {
+		return core.NodeModeTransition{}, false
+	}
+	return m.apply(to, pulse, reason, false), true
+}
+
+// request switches mode on behalf of operator, it fails if operator can't switch to mode from current one.
+// Request of current mode is accepted without transition, ok is false in this case.
+func (m *nodeModeMachine) request(
+	to core.NodeMode, pulse core.PulseNumber, reason string,
+) (core.NodeModeTransition, bool, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.mode == to {
+		return core.NodeModeTransition{}, false, nil
+	}
+	if !transitionAllowed(requestedTransitions, m.mode, to) {
+		return core.NodeModeTransition{}, false, errors.Errorf("node can't be switched from %s to %s mode", m.mode, to)
+	}
+	return m.apply(to, pulse, reason, true), true, nil
+}
+
+func (m *nodeModeMachine) apply(
+	to core.NodeMode, pulse core.PulseNumber, reason string, requested bool,
+) core.NodeModeTransition {
+	transition := core.NodeModeTransition{
+		From:      m.mode,
+		To:        to,
+		Pulse:     pulse,
+		Reason:    reason,
+		Requested: requested,
+		Time:      m.now(),
+	}
+	m.mode = to
+	m.history = append(m.history, transition)
+	if len(m.history) > nodeModeHistoryLimit {
+		m.history = m.history[len(m.history)-nodeModeHistoryLimit:]
+	}
+	return transition
+}
+
+// NodeMode implements core.NodeModes.
+func (n *ServiceNetwork) NodeMode() core.NodeMode {
+	return n.modes.Mode()
+}
+
+// NodeModeHistory implements core.NodeModes.
+func (n *ServiceNetwork) NodeModeHistory() []core.NodeModeTransition {
+	return n.modes.History()
+}
+
+// RequestNodeMode implements core.NodeModes. Cordoned node announces suspension from roles every pulse,
+// draining node announces leave for maintenance to the network.
+func (n *ServiceNetwork) RequestNodeMode(ctx context.Context, mode core.NodeMode, reason string) error {
+	return n.requestNodeMode(ctx, mode, core.LeaveReasonMaintenance, reason)
+}
+
+// DrainNode implements core.NodeModes.
+func (n *ServiceNetwork) DrainNode(ctx context.Context, leave core.LeaveReason, note string) error {
+	return n.requestNodeMode(ctx, core.NodeModeDraining, leave, note)
+}
+
+func (n *ServiceNetwork) requestNodeMode(
+	ctx context.Context, mode core.NodeMode, leave core.LeaveReason, reason string,
+) error {
+	transition, ok, err := n.modes.request(mode, n.currentPulseNumber(ctx), reason)
+	if err != nil {
+		return errors.Wrap(err, "[ RequestNodeMode ] transition is not allowed")
+	}
+	if !ok {
+		return nil
+	}
+	n.nodeModeChanged(ctx, transition)
+	if mode == core.NodeModeDraining {
+		n.GracefulStop(ctx, leave, reason)
+	}
+	return nil
+}
+
+// advanceNodeMode switches mode on event observed by node, transitions not allowed from current mode are ignored.
+func (n *ServiceNetwork) advanceNodeMode(
+	ctx context.Context, mode core.NodeMode, pulse core.PulseNumber, reason string,
+) {
+	if transition, ok := n.modes.advance(mode, pulse, reason); ok {
+		n.nodeModeChanged(ctx, transition)
+	}
+}
+
+func (n *ServiceNetwork) nodeModeChanged(ctx context.Context, transition core.NodeModeTransition) {
+	inslogger.FromContext(ctx).Infof("Node mode is changed from %s to %s in pulse %d: %s",
+		transition.From, transition.To, transition.Pulse, transition.Reason)
+	if n.notifier == nil {
+		return
+	}
+	n.notifier.Notify(ctx, core.NotificationNodeModeChanged, map[string]interface{}{
+		"from":      transition.From.String(),
+		"to":        transition.To.String(),
+		"pulse":     transition.Pulse,
+		"reason":    transition.Reason,
+		"requested": transition.Requested,
+	})
+}
+
+// reportCordon adds claim which suspends cordoned node from executor and validator roles to the next consensus.
+func (n *ServiceNetwork) reportCordon(ctx context.Context) {
+	since, ok := n.modes.cordonedSince()
+	if !ok {
+		return
+	}
+	n.NodeKeeper.AddPendingClaim(packets.NewNodeCordonClaim(since))
+}
+
+func (n *ServiceNetwork) currentPulseNumber(ctx context.Context) core.PulseNumber {
+	if n.PulseStorage == nil {
+		return 0
+	}
+	current, err := n.PulseStorage.Current(ctx)
+	if err != nil {
+		return 0
+	}
+	return current.PulseNumber
+}
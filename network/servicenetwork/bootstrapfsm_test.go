@@ -0,0 +1,162 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package servicenetwork
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/consensus/packets"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils/network"
+)
+
+type modeNotifier struct {
+	events []map[string]interface{}
+}
+
+func (n *modeNotifier) Notify(ctx context.Context, event core.NotificationEvent, details map[string]interface{}) {
+	if event == core.NotificationNodeModeChanged {
+		n.events = append(n.events, details)
+	}
+}
+
+func (n *modeNotifier) ObserveConsensus(ctx context.Context, pulse core.PulseNumber, err error) {}
+
+func TestNodeModeMachine_Transitions(t *testing.T) {
+	m := newNodeModeMachine()
+	m.now = func() time.Time { return time.Unix(1, 0) }
+	require.Equal(t, core.NodeModeBootstrapping, m.Mode())
+
+	_, ok := m.advance(core.NodeModeParticipant, core.FirstPulseNumber, "network is complete")
+	require.True(t, ok)
+
+	_, _, err := m.request(core.NodeModeBootstrapping, core.FirstPulseNumber, "")
+	require.Contains(t, err.Error(), "node can't be switched from participant to bootstrapping mode")
+	_, _, err = m.request(core.NodeModeStopped, core.FirstPulseNumber, "")
+	require.Error(t, err)
+
+	_, ok, err = m.request(core.NodeModeCordoned, core.FirstPulseNumber+1, "disk replacement")
+	require.NoError(t, err)
+	require.True(t, ok)
+	_, ok, err = m.request(core.NodeModeCordoned, core.FirstPulseNumber+1, "")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// cordoned node keeps its mode while network changes
+	_, ok = m.advance(core.NodeModeSyncing, core.FirstPulseNumber+2, "network is not complete")
+	require.False(t, ok)
+	require.Equal(t, core.NodeModeCordoned, m.Mode())
+
+	_, ok, err = m.request(core.NodeModeDraining, core.FirstPulseNumber+3, "")
+	require.NoError(t, err)
+	require.True(t, ok)
+	_, _, err = m.request(core.NodeModeParticipant, core.FirstPulseNumber+3, "")
+	require.Error(t, err)
+
+	_, ok = m.advance(core.NodeModeStopped, core.FirstPulseNumber+4, "node is stopped")
+	require.True(t, ok)
+	_, ok = m.advance(core.NodeModeSyncing, core.FirstPulseNumber+5, "")
+	require.False(t, ok)
+
+	require.Equal(t, []core.NodeModeTransition{
+		{
+			From:   core.NodeModeBootstrapping,
+			To:     core.NodeModeParticipant,
+			Pulse:  core.FirstPulseNumber,
+			Reason: "network is complete",
+			Time:   time.Unix(1, 0),
+		},
+		{
+			From:      core.NodeModeParticipant,
+			To:        core.NodeModeCordoned,
+			Pulse:     core.FirstPulseNumber + 1,
+			Reason:    "disk replacement",
+			Requested: true,
+			Time:      time.Unix(1, 0),
+		},
+		{
+			From:      core.NodeModeCordoned,
+			To:        core.NodeModeDraining,
+			Pulse:     core.FirstPulseNumber + 3,
+			Requested: true,
+			Time:      time.Unix(1, 0),
+		},
+		{
+			From:   core.NodeModeDraining,
+			To:     core.NodeModeStopped,
+			Pulse:  core.FirstPulseNumber + 4,
+			Reason: "node is stopped",
+			Time:   time.Unix(1, 0),
+		},
+	}, m.History())
+}
+
+func TestNodeModeMachine_HistoryLimit(t *testing.T) {
+	m := newNodeModeMachine()
+	m.advance(core.NodeModeSyncing, 0, "")
+	for i := 0; i < nodeModeHistoryLimit; i++ {
+		m.advance(core.NodeModeParticipant, core.PulseNumber(i), "")
+		m.advance(core.NodeModeSyncing, core.PulseNumber(i), "")
+	}
+	history := m.History()
+	require.Len(t, history, nodeModeHistoryLimit)
+	require.Equal(t, core.PulseNumber(nodeModeHistoryLimit-1), history[len(history)-1].Pulse)
+}
+
+func TestServiceNetwork_RequestNodeMode(t *testing.T) {
+	ctx := context.Background()
+	var claims []packets.ReferendumClaim
+	nk := network.NewNodeKeeperMock(t)
+	nk.AddPendingClaimMock.Set(func(claim packets.ReferendumClaim) bool {
+		claims = append(claims, claim)
+		return true
+	})
+	notifier := &modeNotifier{}
+	n := &ServiceNetwork{NodeKeeper: nk, modes: newNodeModeMachine(), notifier: notifier}
+
+	err := n.RequestNodeMode(ctx, core.NodeModeCordoned, "")
+	require.Contains(t, err.Error(), "node can't be switched from bootstrapping to cordoned mode")
+
+	n.advanceNodeMode(ctx, core.NodeModeParticipant, core.FirstPulseNumber, "network is complete")
+	n.reportCordon(ctx)
+	require.Empty(t, claims)
+
+	// cordoned node is suspended from roles with its own claim
+	require.NoError(t, n.RequestNodeMode(ctx, core.NodeModeCordoned, "disk replacement"))
+	require.Equal(t, core.NodeModeCordoned, n.NodeMode())
+	n.reportStorage(ctx)
+	n.reportCordon(ctx)
+	require.Equal(t, []packets.ReferendumClaim{packets.NewNodeCordonClaim(0)}, claims)
+
+	require.NoError(t, n.DrainNode(ctx, core.LeaveReasonUpgrade, "upgrade"))
+	require.Equal(t, packets.NewNodeLeaveClaim(core.LeaveReasonUpgrade, "upgrade"), claims[1])
+
+	require.Equal(t, []map[string]interface{}{
+		{"from": "bootstrapping", "to": "participant", "pulse": core.PulseNumber(core.FirstPulseNumber),
+			"reason": "network is complete", "requested": false},
+		{"from": "participant", "to": "cordoned", "pulse": core.PulseNumber(0),
+			"reason": "disk replacement", "requested": true},
+		{"from": "cordoned", "to": "draining", "pulse": core.PulseNumber(0),
+			"reason": "upgrade", "requested": true},
+	}, notifier.events)
+	require.Len(t, n.NodeModeHistory(), 3)
+}
@@ -45,6 +45,15 @@ type CommunicatorMock struct {
 	testOpt      CommunicatorTestOpt
 }
 
+// NewCommunicatorMock wraps communicator to drop packets from ignoreFrom node in phases selected by testOpt.
+func NewCommunicatorMock(
+	communicator phases.Communicator,
+	ignoreFrom core.RecordRef,
+	testOpt CommunicatorTestOpt,
+) *CommunicatorMock {
+	return &CommunicatorMock{communicator: communicator, ignoreFrom: ignoreFrom, testOpt: testOpt}
+}
+
 func (cm *CommunicatorMock) ExchangePhase1(
 	ctx context.Context,
 	originClaim *packets.NodeAnnounceClaim,
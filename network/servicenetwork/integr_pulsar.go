@@ -27,7 +27,7 @@ import (
 	"github.com/insolar/insolar/network/pulsenetwork"
 	"github.com/insolar/insolar/network/transport"
 	"github.com/insolar/insolar/network/transport/relay"
-	"github.com/insolar/insolar/pulsar/entropygenerator"
+	"github.com/insolar/insolar/pulsar/testpulsar"
 	"github.com/pkg/errors"
 )
 
@@ -47,26 +47,22 @@ func NewTestPulsar(pulseTimeMs, requestsTimeoutMs, pulseDelta int32) (TestPulsar
 		return nil, errors.Wrap(err, "Failed to create distributor transport")
 	}
 	return &testPulsar{
-		transport:         tp,
-		generator:         &entropygenerator.StandardEntropyGenerator{},
-		pulseTimeMs:       pulseTimeMs,
-		reqTimeoutMs:      requestsTimeoutMs,
-		pulseDelta:        pulseDelta,
-		cancellationToken: make(chan struct{}),
+		transport: tp,
+		pulsar: testpulsar.New(
+			testpulsar.Fixed(time.Duration(pulseTimeMs)*time.Millisecond),
+			uint32(pulseDelta),
+		),
+		reqTimeoutMs: requestsTimeoutMs,
 	}, nil
 }
 
 type testPulsar struct {
 	transport   transport.Transport
 	distributor core.PulseDistributor
-	generator   entropygenerator.EntropyGenerator
+	pulsar      *testpulsar.TestPulsar
 	cm          *component.Manager
 
-	pulseTimeMs  int32
 	reqTimeoutMs int32
-	pulseDelta   int32
-
-	cancellationToken chan struct{}
 }
 
 func (tp *testPulsar) Start(ctx context.Context, bootstrapHosts []string) error {
@@ -84,7 +80,7 @@ func (tp *testPulsar) Start(ctx context.Context, bootstrapHosts []string) error
 	}
 
 	tp.cm = &component.Manager{}
-	tp.cm.Inject(tp.transport, tp.distributor)
+	tp.cm.Inject(tp.transport, tp.distributor, tp.pulsar)
 
 	if err = tp.cm.Init(ctx); err != nil {
 		return errors.Wrap(err, "Failed to init test pulsar components")
@@ -92,52 +88,12 @@ func (tp *testPulsar) Start(ctx context.Context, bootstrapHosts []string) error
 	if err = tp.cm.Start(ctx); err != nil {
 		return errors.Wrap(err, "Failed to start test pulsar components")
 	}
-
-	go tp.distribute(ctx)
 	return nil
 }
 
-func (tp *testPulsar) distribute(ctx context.Context) {
-	timeNow := time.Now()
-	pulseNumber := core.CalculatePulseNumber(timeNow)
-	pulse := core.Pulse{
-		PulseNumber:      pulseNumber,
-		Entropy:          tp.generator.GenerateEntropy(),
-		NextPulseNumber:  pulseNumber + core.PulseNumber(tp.pulseDelta),
-		PrevPulseNumber:  pulseNumber - core.PulseNumber(tp.pulseDelta),
-		EpochPulseNumber: 1,
-		OriginID:         [16]byte{206, 41, 229, 190, 7, 240, 162, 155, 121, 245, 207, 56, 161, 67, 189, 0},
-		PulseTimestamp:   timeNow.Unix(),
-	}
-
-	for {
-		select {
-		case <-time.After(time.Duration(tp.pulseTimeMs) * time.Millisecond):
-			go tp.distributor.Distribute(ctx, pulse)
-			pulse = tp.incrementPulse(pulse)
-		case <-tp.cancellationToken:
-			return
-		}
-	}
-}
-
-func (tp *testPulsar) incrementPulse(pulse core.Pulse) core.Pulse {
-	newPulse := pulse.PulseNumber + core.PulseNumber(tp.pulseDelta)
-	return core.Pulse{
-		PulseNumber:      newPulse,
-		Entropy:          tp.generator.GenerateEntropy(),
-		NextPulseNumber:  newPulse + core.PulseNumber(tp.pulseDelta),
-		PrevPulseNumber:  pulse.PulseNumber,
-		EpochPulseNumber: pulse.EpochPulseNumber,
-		OriginID:         pulse.OriginID,
-		PulseTimestamp:   time.Now().Unix(),
-	}
-}
-
 func (tp *testPulsar) Stop(ctx context.Context) error {
 	if err := tp.cm.Stop(ctx); err != nil {
 		return errors.Wrap(err, "Failed to stop test pulsar components")
 	}
-	close(tp.cancellationToken)
 	return nil
 }
@@ -17,7 +17,7 @@
  *
  */
 
-package servicenetwork
+package servicenetwork_test
 
 import (
 	"context"
@@ -29,6 +29,8 @@ import (
 	"github.com/insolar/insolar/consensus/phases"
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/log"
+	"github.com/insolar/insolar/network/servicenetwork"
+	"github.com/insolar/insolar/testutils/devnet"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
@@ -43,7 +45,7 @@ func (s *testSuite) TestNetworkConsensus3Times() {
 }
 
 func (s *testSuite) TestNodeConnect() {
-	testNode := newNetworkNode()
+	testNode := devnet.NewNode()
 	s.preInitNode(testNode)
 
 	s.InitNode(testNode)
@@ -54,29 +56,29 @@ func (s *testSuite) TestNodeConnect() {
 
 	s.waitForConsensus(1)
 
-	activeNodes := s.fixture().bootstrapNodes[0].serviceNetwork.NodeKeeper.GetActiveNodes()
+	activeNodes := s.fixture().BootstrapNodes[0].ServiceNetwork.NodeKeeper.GetActiveNodes()
 	s.Equal(s.getNodesCount(), len(activeNodes))
 
 	s.waitForConsensus(1)
 
-	activeNodes = s.fixture().bootstrapNodes[0].serviceNetwork.NodeKeeper.GetWorkingNodes()
+	activeNodes = s.fixture().BootstrapNodes[0].ServiceNetwork.NodeKeeper.GetWorkingNodes()
 	s.Equal(s.getNodesCount(), len(activeNodes))
 
 	s.waitForConsensus(2)
 
-	activeNodes = s.fixture().bootstrapNodes[0].serviceNetwork.NodeKeeper.GetWorkingNodes()
+	activeNodes = s.fixture().BootstrapNodes[0].ServiceNetwork.NodeKeeper.GetWorkingNodes()
 	s.Equal(s.getNodesCount()+1, len(activeNodes))
-	activeNodes = testNode.serviceNetwork.NodeKeeper.GetWorkingNodes()
+	activeNodes = testNode.ServiceNetwork.NodeKeeper.GetWorkingNodes()
 	s.Equal(s.getNodesCount()+1, len(activeNodes))
 }
 
 func (s *testSuite) TestTwoNodesConnect() {
-	if len(s.fixture().bootstrapNodes) < consensusMin {
+	if len(s.fixture().BootstrapNodes) < consensusMin {
 		s.T().Skip(consensusMinMsg)
 	}
 
-	testNode := newNetworkNode()
-	testNode2 := newNetworkNode()
+	testNode := devnet.NewNode()
+	testNode2 := devnet.NewNode()
 
 	s.preInitNode(testNode)
 	s.preInitNode(testNode2)
@@ -106,26 +108,26 @@ func (s *testSuite) TestTwoNodesConnect() {
 
 	s.waitForConsensus(1)
 
-	activeNodes := s.fixture().bootstrapNodes[0].serviceNetwork.NodeKeeper.GetActiveNodes()
+	activeNodes := s.fixture().BootstrapNodes[0].ServiceNetwork.NodeKeeper.GetActiveNodes()
 	s.Equal(s.getNodesCount(), len(activeNodes))
 
 	s.waitForConsensus(1)
 
-	activeNodes = s.fixture().bootstrapNodes[0].serviceNetwork.NodeKeeper.GetWorkingNodes()
+	activeNodes = s.fixture().BootstrapNodes[0].ServiceNetwork.NodeKeeper.GetWorkingNodes()
 	s.Equal(s.getNodesCount(), len(activeNodes))
 
 	s.waitForConsensus(2)
 
-	activeNodes = s.fixture().bootstrapNodes[0].serviceNetwork.NodeKeeper.GetWorkingNodes()
+	activeNodes = s.fixture().BootstrapNodes[0].ServiceNetwork.NodeKeeper.GetWorkingNodes()
 	s.Equal(s.getNodesCount()+2, len(activeNodes))
-	activeNodes = testNode.serviceNetwork.NodeKeeper.GetWorkingNodes()
+	activeNodes = testNode.ServiceNetwork.NodeKeeper.GetWorkingNodes()
 	s.Equal(s.getNodesCount()+2, len(activeNodes))
-	activeNodes = testNode2.serviceNetwork.NodeKeeper.GetWorkingNodes()
+	activeNodes = testNode2.ServiceNetwork.NodeKeeper.GetWorkingNodes()
 	s.Equal(s.getNodesCount()+2, len(activeNodes))
 }
 
 func (s *testSuite) TestNodeLeave() {
-	testNode := newNetworkNode()
+	testNode := devnet.NewNode()
 	s.preInitNode(testNode)
 
 	s.InitNode(testNode)
@@ -136,19 +138,19 @@ func (s *testSuite) TestNodeLeave() {
 
 	s.waitForConsensus(2)
 
-	activeNodes := s.fixture().bootstrapNodes[0].serviceNetwork.NodeKeeper.GetWorkingNodes()
+	activeNodes := s.fixture().BootstrapNodes[0].ServiceNetwork.NodeKeeper.GetWorkingNodes()
 	s.Equal(s.getNodesCount(), len(activeNodes))
 
 	s.waitForConsensus(1)
 
-	activeNodes = s.fixture().bootstrapNodes[0].serviceNetwork.NodeKeeper.GetWorkingNodes()
+	activeNodes = s.fixture().BootstrapNodes[0].ServiceNetwork.NodeKeeper.GetWorkingNodes()
 	s.Equal(s.getNodesCount()+1, len(activeNodes))
 
-	testNode.serviceNetwork.GracefulStop(context.Background())
+	testNode.ServiceNetwork.GracefulStop(context.Background(), core.LeaveReasonMaintenance, "")
 
 	s.waitForConsensus(2)
 
-	activeNodes = s.fixture().bootstrapNodes[0].serviceNetwork.NodeKeeper.GetWorkingNodes()
+	activeNodes = s.fixture().BootstrapNodes[0].ServiceNetwork.NodeKeeper.GetWorkingNodes()
 	s.Equal(s.getNodesCount(), len(activeNodes))
 }
 
@@ -169,6 +171,11 @@ func TestServiceNetworkManyNodes(t *testing.T) {
 	suite.Run(t, s)
 }
 
+func TestServiceNetworkSlowLinks(t *testing.T) {
+	s := NewTopologyTestSuite("../../testutils/devnet/testdata/topology/slow_links.json")
+	suite.Run(t, s)
+}
+
 // Full timeout test
 type FullTimeoutPhaseManager struct {
 }
@@ -178,173 +185,173 @@ func (ftpm *FullTimeoutPhaseManager) OnPulse(ctx context.Context, pulse *core.Pu
 }
 
 func (s *testSuite) TestFullTimeOut() {
-	if len(s.fixture().bootstrapNodes) < consensusMin {
+	if len(s.fixture().BootstrapNodes) < consensusMin {
 		s.T().Skip(consensusMinMsg)
 	}
 
 	// TODO: make this set operation thread-safe somehow (race detector does not like this code)
-	wrapper := s.fixture().bootstrapNodes[1].serviceNetwork.PhaseManager.(*phaseManagerWrapper)
-	wrapper.original = &FullTimeoutPhaseManager{}
-	s.fixture().bootstrapNodes[1].serviceNetwork.PhaseManager = wrapper
+	s.fixture().BootstrapNodes[1].SetPhaseManager(&FullTimeoutPhaseManager{})
 
 	s.waitForConsensus(2)
 
-	activeNodes := s.fixture().bootstrapNodes[0].serviceNetwork.NodeKeeper.GetWorkingNodes()
+	activeNodes := s.fixture().BootstrapNodes[0].ServiceNetwork.NodeKeeper.GetWorkingNodes()
 	s.Equal(s.getNodesCount()-1, len(activeNodes))
 }
 
 // Partial timeout
 
 func (s *testSuite) TestPartialPositive1PhaseTimeOut() {
-	if len(s.fixture().bootstrapNodes) < consensusMin {
+	if len(s.fixture().BootstrapNodes) < consensusMin {
 		s.T().Skip(consensusMinMsg)
 	}
 
-	setCommunicatorMock(s.fixture().bootstrapNodes, PartialPositive1Phase)
+	setCommunicatorMock(s.fixture().BootstrapNodes, servicenetwork.PartialPositive1Phase)
 
-	s.waitForConsensusExcept(2, s.fixture().bootstrapNodes[0].id)
-	activeNodes := s.fixture().bootstrapNodes[1].serviceNetwork.NodeKeeper.GetWorkingNodes()
+	s.waitForConsensusExcept(2, s.fixture().BootstrapNodes[0].ID)
+	activeNodes := s.fixture().BootstrapNodes[1].ServiceNetwork.NodeKeeper.GetWorkingNodes()
 	s.Equal(s.getNodesCount(), len(activeNodes))
 }
 
 func (s *testSuite) TestPartialPositive2PhaseTimeOut() {
-	if len(s.fixture().bootstrapNodes) < consensusMin {
+	if len(s.fixture().BootstrapNodes) < consensusMin {
 		s.T().Skip(consensusMinMsg)
 	}
 
-	setCommunicatorMock(s.fixture().bootstrapNodes, PartialPositive2Phase)
+	setCommunicatorMock(s.fixture().BootstrapNodes, servicenetwork.PartialPositive2Phase)
 
-	s.waitForConsensusExcept(2, s.fixture().bootstrapNodes[0].id)
-	activeNodes := s.fixture().bootstrapNodes[1].serviceNetwork.NodeKeeper.GetWorkingNodes()
+	s.waitForConsensusExcept(2, s.fixture().BootstrapNodes[0].ID)
+	activeNodes := s.fixture().BootstrapNodes[1].ServiceNetwork.NodeKeeper.GetWorkingNodes()
 	s.Equal(s.getNodesCount(), len(activeNodes))
 }
 
 func (s *testSuite) TestPartialNegative1PhaseTimeOut() {
-	if len(s.fixture().bootstrapNodes) < consensusMin {
+	if len(s.fixture().BootstrapNodes) < consensusMin {
 		s.T().Skip(consensusMinMsg)
 	}
 
-	setCommunicatorMock(s.fixture().bootstrapNodes, PartialNegative1Phase)
+	setCommunicatorMock(s.fixture().BootstrapNodes, servicenetwork.PartialNegative1Phase)
 
-	s.waitForConsensusExcept(2, s.fixture().bootstrapNodes[0].id)
-	activeNodes := s.fixture().bootstrapNodes[1].serviceNetwork.NodeKeeper.GetWorkingNodes()
+	s.waitForConsensusExcept(2, s.fixture().BootstrapNodes[0].ID)
+	activeNodes := s.fixture().BootstrapNodes[1].ServiceNetwork.NodeKeeper.GetWorkingNodes()
 	s.Equal(s.getNodesCount()-1, len(activeNodes))
 }
 
 func (s *testSuite) TestPartialNegative2PhaseTimeOut() {
-	if len(s.fixture().bootstrapNodes) < consensusMin {
+	if len(s.fixture().BootstrapNodes) < consensusMin {
 		s.T().Skip(consensusMinMsg)
 	}
 
-	setCommunicatorMock(s.fixture().bootstrapNodes, PartialNegative2Phase)
+	setCommunicatorMock(s.fixture().BootstrapNodes, servicenetwork.PartialNegative2Phase)
 
-	s.waitForConsensusExcept(2, s.fixture().bootstrapNodes[0].id)
-	activeNodes := s.fixture().bootstrapNodes[1].serviceNetwork.NodeKeeper.GetWorkingNodes()
+	s.waitForConsensusExcept(2, s.fixture().BootstrapNodes[0].ID)
+	activeNodes := s.fixture().BootstrapNodes[1].ServiceNetwork.NodeKeeper.GetWorkingNodes()
 	s.Equal(s.getNodesCount(), len(activeNodes))
 }
 
 func (s *testSuite) TestPartialNegative3PhaseTimeOut() {
-	if len(s.fixture().bootstrapNodes) < consensusMin {
+	if len(s.fixture().BootstrapNodes) < consensusMin {
 		s.T().Skip(consensusMinMsg)
 	}
 
-	setCommunicatorMock(s.fixture().bootstrapNodes, PartialNegative3Phase)
+	setCommunicatorMock(s.fixture().BootstrapNodes, servicenetwork.PartialNegative3Phase)
 
-	s.waitForConsensusExcept(2, s.fixture().bootstrapNodes[0].id)
-	activeNodes := s.fixture().bootstrapNodes[1].serviceNetwork.NodeKeeper.GetWorkingNodes()
+	s.waitForConsensusExcept(2, s.fixture().BootstrapNodes[0].ID)
+	activeNodes := s.fixture().BootstrapNodes[1].ServiceNetwork.NodeKeeper.GetWorkingNodes()
 	s.Equal(s.getNodesCount(), len(activeNodes))
 }
 
 func (s *testSuite) TestPartialPositive3PhaseTimeOut() {
-	if len(s.fixture().bootstrapNodes) < consensusMin {
+	if len(s.fixture().BootstrapNodes) < consensusMin {
 		s.T().Skip(consensusMinMsg)
 	}
 
-	setCommunicatorMock(s.fixture().bootstrapNodes, PartialPositive3Phase)
+	setCommunicatorMock(s.fixture().BootstrapNodes, servicenetwork.PartialPositive3Phase)
 
-	s.waitForConsensusExcept(2, s.fixture().bootstrapNodes[0].id)
-	activeNodes := s.fixture().bootstrapNodes[1].serviceNetwork.NodeKeeper.GetWorkingNodes()
+	s.waitForConsensusExcept(2, s.fixture().BootstrapNodes[0].ID)
+	activeNodes := s.fixture().BootstrapNodes[1].ServiceNetwork.NodeKeeper.GetWorkingNodes()
 	s.Equal(s.getNodesCount(), len(activeNodes))
 }
 
 func (s *testSuite) TestPartialNegative23PhaseTimeOut() {
-	if len(s.fixture().bootstrapNodes) < consensusMin {
+	if len(s.fixture().BootstrapNodes) < consensusMin {
 		s.T().Skip(consensusMinMsg)
 	}
 
-	setCommunicatorMock(s.fixture().bootstrapNodes, PartialNegative23Phase)
+	setCommunicatorMock(s.fixture().BootstrapNodes, servicenetwork.PartialNegative23Phase)
 
-	s.waitForConsensusExcept(2, s.fixture().bootstrapNodes[0].id)
-	activeNodes := s.fixture().bootstrapNodes[1].serviceNetwork.NodeKeeper.GetWorkingNodes()
+	s.waitForConsensusExcept(2, s.fixture().BootstrapNodes[0].ID)
+	activeNodes := s.fixture().BootstrapNodes[1].ServiceNetwork.NodeKeeper.GetWorkingNodes()
 	s.Equal(s.getNodesCount(), len(activeNodes))
 }
 
 func (s *testSuite) TestPartialPositive23PhaseTimeOut() {
-	if len(s.fixture().bootstrapNodes) < consensusMin {
+	if len(s.fixture().BootstrapNodes) < consensusMin {
 		s.T().Skip(consensusMinMsg)
 	}
 
-	setCommunicatorMock(s.fixture().bootstrapNodes, PartialPositive23Phase)
+	setCommunicatorMock(s.fixture().BootstrapNodes, servicenetwork.PartialPositive23Phase)
 
-	s.waitForConsensusExcept(2, s.fixture().bootstrapNodes[0].id)
-	activeNodes := s.fixture().bootstrapNodes[1].serviceNetwork.NodeKeeper.GetWorkingNodes()
+	s.waitForConsensusExcept(2, s.fixture().BootstrapNodes[0].ID)
+	activeNodes := s.fixture().BootstrapNodes[1].ServiceNetwork.NodeKeeper.GetWorkingNodes()
 	s.Equal(s.getNodesCount(), len(activeNodes))
 }
 
 func (s *testSuite) TestDiscoveryDown() {
-	if len(s.fixture().bootstrapNodes) < consensusMin {
+	if len(s.fixture().BootstrapNodes) < consensusMin {
 		s.T().Skip(consensusMinMsg)
 	}
 
-	s.fixture().bootstrapNodes[0].serviceNetwork.Stop(context.Background())
-	s.waitForConsensusExcept(2, s.fixture().bootstrapNodes[0].id)
-	activeNodes := s.fixture().bootstrapNodes[1].serviceNetwork.NodeKeeper.GetWorkingNodes()
+	s.fixture().BootstrapNodes[0].ServiceNetwork.Stop(context.Background())
+	s.waitForConsensusExcept(2, s.fixture().BootstrapNodes[0].ID)
+	activeNodes := s.fixture().BootstrapNodes[1].ServiceNetwork.NodeKeeper.GetWorkingNodes()
 	s.Equal(s.getNodesCount()-1, len(activeNodes))
 }
 
 func (s *testSuite) TestDiscoveryRestart() {
-	if len(s.fixture().bootstrapNodes) < consensusMin {
+	if len(s.fixture().BootstrapNodes) < consensusMin {
 		s.T().Skip(consensusMinMsg)
 	}
 
 	s.waitForConsensus(1)
 
 	log.Info("Discovery node stopping...")
-	err := s.fixture().bootstrapNodes[0].serviceNetwork.Stop(context.Background())
-	s.fixture().bootstrapNodes[0].serviceNetwork.NodeKeeper.(*nodeKeeperWrapper).Wipe(true)
+	err := s.fixture().BootstrapNodes[0].ServiceNetwork.Stop(context.Background())
+	s.fixture().BootstrapNodes[0].WipeNodeKeeper(true)
 	log.Info("Discovery node stopped...")
 	require.NoError(s.T(), err)
 
-	s.waitForConsensusExcept(2, s.fixture().bootstrapNodes[0].id)
-	activeNodes := s.fixture().bootstrapNodes[1].serviceNetwork.NodeKeeper.GetWorkingNodes()
+	s.waitForConsensusExcept(2, s.fixture().BootstrapNodes[0].ID)
+	activeNodes := s.fixture().BootstrapNodes[1].ServiceNetwork.NodeKeeper.GetWorkingNodes()
 	s.Equal(s.getNodesCount()-1, len(activeNodes))
 
 	log.Info("Discovery node starting...")
-	err = s.fixture().bootstrapNodes[0].serviceNetwork.Start(context.Background())
+	err = s.fixture().BootstrapNodes[0].ServiceNetwork.Start(context.Background())
 	log.Info("Discovery node started")
 	require.NoError(s.T(), err)
 
-	s.waitForConsensusExcept(3, s.fixture().bootstrapNodes[0].id)
-	activeNodes = s.fixture().bootstrapNodes[1].serviceNetwork.NodeKeeper.GetWorkingNodes()
+	s.waitForConsensusExcept(3, s.fixture().BootstrapNodes[0].ID)
+	activeNodes = s.fixture().BootstrapNodes[1].ServiceNetwork.NodeKeeper.GetWorkingNodes()
 	s.Equal(s.getNodesCount(), len(activeNodes))
-	activeNodes = s.fixture().bootstrapNodes[0].serviceNetwork.NodeKeeper.GetWorkingNodes()
+	activeNodes = s.fixture().BootstrapNodes[0].ServiceNetwork.NodeKeeper.GetWorkingNodes()
 	s.Equal(s.getNodesCount(), len(activeNodes))
 }
 
-func setCommunicatorMock(nodes []*networkNode, opt CommunicatorTestOpt) {
-	ref := nodes[0].id
+func setCommunicatorMock(nodes []*devnet.Node, opt servicenetwork.CommunicatorTestOpt) {
+	ref := nodes[0].ID
 	timedOutNodesCount := 0
 	switch opt {
-	case PartialNegative1Phase, PartialNegative2Phase, PartialNegative3Phase, PartialNegative23Phase:
+	case servicenetwork.PartialNegative1Phase, servicenetwork.PartialNegative2Phase,
+		servicenetwork.PartialNegative3Phase, servicenetwork.PartialNegative23Phase:
 		timedOutNodesCount = int(float64(len(nodes)) * 0.6)
-	case PartialPositive1Phase, PartialPositive2Phase, PartialPositive3Phase, PartialPositive23Phase:
+	case servicenetwork.PartialPositive1Phase, servicenetwork.PartialPositive2Phase,
+		servicenetwork.PartialPositive3Phase, servicenetwork.PartialPositive23Phase:
 		timedOutNodesCount = int(float64(len(nodes)) * 0.2)
 	}
 	// TODO: make these set operations thread-safe somehow (race detector does not like this code)
 	for i := 1; i <= timedOutNodesCount; i++ {
-		comm := nodes[i].serviceNetwork.PhaseManager.(*phaseManagerWrapper).original.(*phases.Phases).FirstPhase.(*phases.FirstPhaseImpl).Communicator
-		wrapper := &CommunicatorMock{communicator: comm, ignoreFrom: ref, testOpt: opt}
-		phasemanager := nodes[i].serviceNetwork.PhaseManager.(*phaseManagerWrapper).original.(*phases.Phases)
+		phasemanager := nodes[i].Phases()
+		comm := phasemanager.FirstPhase.(*phases.FirstPhaseImpl).Communicator
+		wrapper := servicenetwork.NewCommunicatorMock(comm, ref, opt)
 		phasemanager.FirstPhase.(*phases.FirstPhaseImpl).Communicator = wrapper
 		phasemanager.SecondPhase.(*phases.SecondPhaseImpl).Communicator = wrapper
 		phasemanager.ThirdPhase.(*phases.ThirdPhaseImpl).Communicator = wrapper
@@ -0,0 +1,88 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package servicenetwork
+
+import (
+	"context"
+
+	"github.com/insolar/insolar/consensus/packets"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/pkg/errors"
+)
+
+// ScheduleMaintenance announces maintenance window to the network with claim signed by the node.
+// Claim is sent with the next consensus, so window is checked against the next pulse.
+func (n *ServiceNetwork) ScheduleMaintenance(ctx context.Context, start, end core.PulseNumber, note string) error {
+	if !n.isDiscovery {
+		return errors.New("[ ScheduleMaintenance ] only discovery node can schedule maintenance")
+	}
+	current, err := n.PulseStorage.Current(ctx)
+	if err != nil {
+		return errors.Wrap(err, "[ ScheduleMaintenance ] failed to get current pulse")
+	}
+	delta := current.NextPulseNumber - current.PulseNumber
+	announced := core.Pulse{PulseNumber: current.NextPulseNumber, NextPulseNumber: current.NextPulseNumber + delta}
+
+	origin := n.NodeKeeper.GetOrigin()
+	window := core.MaintenanceWindow{Issuer: origin.ID(), Start: start, End: end, Note: note}
+	if err := window.Validate(announced); err != nil {
+		return errors.Wrap(err, "[ ScheduleMaintenance ] invalid window")
+	}
+	for _, w := range n.MaintenanceWindows() {
+		if w.Overlaps(window) {
+			return errors.Errorf("[ ScheduleMaintenance ] window overlaps scheduled one %d-%d", w.Start, w.End)
+		}
+	}
+
+	key, err := platformpolicy.NewKeyProcessor().ExportPublicKeyBinary(origin.PublicKey())
+	if err != nil {
+		return errors.Wrap(err, "[ ScheduleMaintenance ] failed to export a public key")
+	}
+	claim := packets.NewMaintenanceClaim(window, key)
+	raw, err := claim.SerializeRaw()
+	if err != nil {
+		return errors.Wrap(err, "[ ScheduleMaintenance ] failed to serialize a claim")
+	}
+	signature, err := n.CryptographyService.Sign(raw)
+	if err != nil {
+		return errors.Wrap(err, "[ ScheduleMaintenance ] failed to sign a claim")
+	}
+	copy(claim.Signature[:], signature.Bytes())
+
+	n.NodeKeeper.AddPendingClaim(claim)
+	inslogger.FromContext(ctx).Infof("[ ScheduleMaintenance ] Maintenance for pulses %d-%d is announced", start, end)
+	return nil
+}
+
+// MaintenanceAt implements core.MaintenanceSchedule.
+func (n *ServiceNetwork) MaintenanceAt(pulse core.PulseNumber) *core.MaintenanceWindow {
+	if schedule, ok := n.NodeKeeper.(core.MaintenanceSchedule); ok {
+		return schedule.MaintenanceAt(pulse)
+	}
+	return nil
+}
+
+// MaintenanceWindows implements core.MaintenanceSchedule.
+func (n *ServiceNetwork) MaintenanceWindows() []core.MaintenanceWindow {
+	if schedule, ok := n.NodeKeeper.(core.MaintenanceSchedule); ok {
+		return schedule.MaintenanceWindows()
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package servicenetwork
+
+import (
+	"sync/atomic"
+
+	"github.com/insolar/insolar/core"
+)
+
+// GetPhaseTimings implements core.ConsensusProfiler.
+func (n *ServiceNetwork) GetPhaseTimings() []core.PhaseTimings {
+	return n.profiler.GetPhaseTimings()
+}
+
+// GetRTT implements core.RTTProvider.
+func (n *ServiceNetwork) GetRTT() []core.RTTSummary {
+	return n.profiler.GetRTT()
+}
+
+// participantsRTT keeps round trip times to peers which are found in active list by reference or address.
+func participantsRTT(peers []core.PeerRTT, active []core.Node) []core.PeerRTT {
+	byRef := make(map[core.RecordRef]core.Node, len(active))
+	byAddress := make(map[string]core.Node, 2*len(active))
+	for _, node := range active {
+		byRef[node.ID()] = node
+		byAddress[node.Address()] = node
+		byAddress[node.ConsensusAddress()] = node
+	}
+
+	result := make([]core.PeerRTT, 0, len(peers))
+	for _, peer := range peers {
+		node, ok := byRef[peer.Node]
+		if !ok {
+			node, ok = byAddress[peer.Address]
+		}
+		if !ok {
+			continue
+		}
+		peer.Node = node.ID()
+		result = append(result, peer)
+	}
+	return result
+}
+
+// ConsensusStreak implements core.ConsensusRounds.
+func (n *ServiceNetwork) ConsensusStreak() int {
+	return int(atomic.LoadInt64(&n.consensusStreak))
+}
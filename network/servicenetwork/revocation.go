@@ -0,0 +1,175 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package servicenetwork
+
+import (
+	"context"
+	"time"
+
+	"github.com/insolar/insolar/consensus/packets"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
+)
+
+const (
+	revocationTopic = "certificate.revocations"
+	// revocationRequestTopic is used by node which joined network to fetch current lists of discovery nodes.
+	revocationRequestTopic = "certificate.revocations.request"
+	// revocationTimeout limits delivery of revocation list to one node.
+	revocationTimeout = 3 * time.Second
+)
+
+// IsRevoked checks if certificate of node is revoked by majority of discovery nodes.
+func (n *ServiceNetwork) IsRevoked(node core.RecordRef) bool {
+	return n.revocations.IsRevoked(node)
+}
+
+// Revoke adds node to revocation list of this discovery node, the list is distributed to all nodes every pulse.
+func (n *ServiceNetwork) Revoke(ctx context.Context, node core.RecordRef) error {
+	if err := n.revocations.Revoke(node); err != nil {
+		return errors.Wrap(err, "[ Revoke ]")
+	}
+	inslogger.FromContext(ctx).Warnf("Certificate of node %s is revoked", node)
+	return nil
+}
+
+// Restore removes node from revocation list of this discovery node.
+func (n *ServiceNetwork) Restore(ctx context.Context, node core.RecordRef) error {
+	if err := n.revocations.Restore(node); err != nil {
+		return errors.Wrap(err, "[ Restore ]")
+	}
+	inslogger.FromContext(ctx).Warnf("Certificate of node %s is restored", node)
+	return nil
+}
+
+// RevocationLists returns revocation lists of discovery nodes known to this node.
+func (n *ServiceNetwork) RevocationLists() []core.RevocationList {
+	return n.revocations.Lists()
+}
+
+// refreshRevocations drops outdated revocation lists, discovery node votes for expelling active nodes it revoked,
+// signs its own list for the pulse and distributes it to active nodes.
+func (n *ServiceNetwork) refreshRevocations(ctx context.Context, pulse core.Pulse) {
+	n.revocations.Expire(pulse)
+	if !n.isDiscovery {
+		return
+	}
+	for _, node := range n.revocations.Revoked() {
+		if n.NodeKeeper.GetActiveNode(node) != nil {
+			n.NodeKeeper.AddPendingClaim(packets.NewNodeExpelClaim(node))
+		}
+	}
+
+	logger := inslogger.FromContext(ctx)
+	list, err := n.revocations.Sign(pulse.PulseNumber)
+	if err != nil {
+		logger.Error("[ refreshRevocations ] failed to sign revocation list: ", err)
+		return
+	}
+	if list == nil {
+		return
+	}
+	if err := n.revocations.Accept(*list); err != nil {
+		logger.Error("[ refreshRevocations ] failed to accept own revocation list: ", err)
+		return
+	}
+	payload, err := core.Serialize(list)
+	if err != nil {
+		logger.Error("[ refreshRevocations ] failed to serialize revocation list: ", err)
+		return
+	}
+
+	origin := n.NodeKeeper.GetOrigin().ID()
+	for _, node := range n.NodeKeeper.GetActiveNodes() {
+		if node.ID().Equal(origin) {
+			continue
+		}
+		go func(node core.RecordRef) {
+			ctx, cancel := context.WithTimeout(ctx, revocationTimeout)
+			defer cancel()
+			if _, err := n.SendToNode(ctx, node, revocationTopic, payload); err != nil {
+				logger.Debugf("[ refreshRevocations ] failed to send revocation list to %s: %s", node, err)
+			}
+		}(node.ID())
+	}
+}
+
+// fetchRevocations requests current revocation lists from discovery nodes, so node which joined network
+// doesn't wait for the next distribution.
+func (n *ServiceNetwork) fetchRevocations(ctx context.Context) {
+	logger := inslogger.FromContext(ctx)
+	cert := n.CertificateManager.GetCertificate()
+	origin := *cert.GetNodeRef()
+	for _, discovery := range cert.GetDiscoveryNodes() {
+		node := *discovery.GetNodeRef()
+		if node.Equal(origin) {
+			continue
+		}
+		go func(node core.RecordRef) {
+			ctx, cancel := context.WithTimeout(ctx, revocationTimeout)
+			defer cancel()
+			payload, err := n.SendToNode(ctx, node, revocationRequestTopic, nil)
+			if err != nil {
+				logger.Debugf("[ fetchRevocations ] failed to fetch revocation list of %s: %s", node, err)
+				return
+			}
+			if len(payload) == 0 {
+				return
+			}
+			if err := n.acceptRevocations(node, payload); err != nil {
+				logger.Warn("[ fetchRevocations ] ", err)
+			}
+		}(node)
+	}
+}
+
+// revocationHandler accepts revocation list sent by discovery node.
+func (n *ServiceNetwork) revocationHandler(ctx context.Context, sender core.RecordRef, payload []byte) ([]byte, error) {
+	if err := n.acceptRevocations(sender, payload); err != nil {
+		return nil, errors.Wrap(err, "[ revocationHandler ]")
+	}
+	return nil, nil
+}
+
+// revocationRequestHandler returns revocation list of this node signed in the current pulse, empty reply means
+// there are no revocations.
+func (n *ServiceNetwork) revocationRequestHandler(ctx context.Context, sender core.RecordRef, _ []byte) ([]byte, error) {
+	if !n.isDiscovery {
+		return nil, nil
+	}
+	list, err := n.revocations.Sign(n.currentPulseNumber(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "[ revocationRequestHandler ] failed to sign revocation list")
+	}
+	if list == nil {
+		return nil, nil
+	}
+	return core.Serialize(list)
+}
+
+func (n *ServiceNetwork) acceptRevocations(sender core.RecordRef, payload []byte) error {
+	var list core.RevocationList
+	if err := core.Deserialize(payload, &list); err != nil {
+		return errors.Wrap(err, "failed to deserialize revocation list")
+	}
+	if !list.Signer.Equal(sender) {
+		return errors.New("revocation list is sent by node other than signer")
+	}
+	return n.revocations.Accept(list)
+}
@@ -22,13 +22,16 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/insolar/insolar/certificate"
 	"github.com/insolar/insolar/component"
 	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/consensus/packets"
 	"github.com/insolar/insolar/consensus/phases"
 	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/crashreport"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/instrumentation/instracer"
 	"github.com/insolar/insolar/log"
@@ -38,6 +41,7 @@ import (
 	"github.com/insolar/insolar/network/hostnetwork"
 	"github.com/insolar/insolar/network/merkle"
 	"github.com/insolar/insolar/network/routing"
+	"github.com/insolar/insolar/network/transport"
 	"github.com/insolar/insolar/network/utils"
 	"github.com/pkg/errors"
 	"go.opencensus.io/trace"
@@ -45,6 +49,9 @@ import (
 
 // ServiceNetwork is facade for network.
 type ServiceNetwork struct {
+	// consensusStreak is a number of consecutive consensus rounds passed, it's first for alignment of atomic access.
+	consensusStreak int64
+
 	cfg configuration.Configuration
 	cm  *component.Manager
 
@@ -61,24 +68,58 @@ type ServiceNetwork struct {
 	NodeKeeper          network.NodeKeeper              `inject:""`
 	NetworkSwitcher     core.NetworkSwitcher            `inject:""`
 	TerminationHandler  core.TerminationHandler         `inject:""`
+	ClockSkewMonitor    core.ClockSkewMonitor           `inject:""`
 
 	// subcomponents
 	PhaseManager phases.PhaseManager `inject:"subcomponent"`
 	Controller   network.Controller  `inject:"subcomponent"`
 
-	isGenesis   bool
-	isDiscovery bool
-	skip        int
+	isGenesis    bool
+	isDiscovery  bool
+	skip         int
+	loadReporter core.NodeLoadReporter
+	storage      core.StorageMonitor
+	notifier     core.Notifier
+	profiler     phases.Profiler
+	revocations  *certificate.Revocations
+	modes        *nodeModeMachine
 
 	lock sync.Mutex
+
+	joinPulsesLock sync.RWMutex
+	joinPulses     map[core.RecordRef]core.PulseNumber
 }
 
 // NewServiceNetwork returns a new ServiceNetwork.
 func NewServiceNetwork(conf configuration.Configuration, rootCm *component.Manager, isGenesis bool) (*ServiceNetwork, error) {
-	serviceNetwork := &ServiceNetwork{cm: component.NewManager(rootCm), cfg: conf, isGenesis: isGenesis, skip: conf.Service.Skip}
+	serviceNetwork := &ServiceNetwork{
+		cm:         component.NewManager(rootCm),
+		cfg:        conf,
+		isGenesis:  isGenesis,
+		skip:       conf.Service.Skip,
+		joinPulses: make(map[core.RecordRef]core.PulseNumber),
+		modes:      newNodeModeMachine(),
+	}
 	return serviceNetwork, nil
 }
 
+// SetNodeLoadReporter sets reporter of the node load which is announced to the network every pulse
+// if load reporting is enabled in configuration.
+func (n *ServiceNetwork) SetNodeLoadReporter(reporter core.NodeLoadReporter) {
+	n.loadReporter = reporter
+}
+
+// SetStorageMonitor sets monitor of node storage, node announces exhausted storage to the network every pulse
+// while storage level is critical.
+func (n *ServiceNetwork) SetStorageMonitor(monitor core.StorageMonitor) {
+	n.storage = monitor
+}
+
+// SetNotifier sets notifier which is told about results of consensus rounds.
+func (n *ServiceNetwork) SetNotifier(notifier core.Notifier) {
+	n.notifier = notifier
+}
+
 // SendMessage sends a message from MessageBus.
 func (n *ServiceNetwork) SendMessage(nodeID core.RecordRef, method string, msg core.Parcel) ([]byte, error) {
 	return n.Controller.SendMessage(nodeID, method, msg)
@@ -94,6 +135,18 @@ func (n *ServiceNetwork) RemoteProcedureRegister(name string, method core.Remote
 	n.Controller.RemoteProcedureRegister(name, method)
 }
 
+// SendToNode sends application message of platform component to active node, sender is authenticated by signature.
+func (n *ServiceNetwork) SendToNode(
+	ctx context.Context, node core.RecordRef, topic string, payload []byte,
+) ([]byte, error) {
+	return n.Controller.SendToNode(ctx, node, topic, payload)
+}
+
+// RegisterNodeHandler registers handler of application messages of topic sent by other nodes.
+func (n *ServiceNetwork) RegisterNodeHandler(topic string, handler core.NodeMessageHandler) {
+	n.Controller.RegisterNodeHandler(topic, handler)
+}
+
 // incrementPort increments port number if it not equals 0
 func incrementPort(address string) (string, error) {
 	parts := strings.Split(address, ":")
@@ -116,6 +169,7 @@ func incrementPort(address string) (string, error) {
 // Start implements component.Initer
 func (n *ServiceNetwork) Init(ctx context.Context) error {
 	n.routingTable = &routing.Table{}
+	transport.ConfigureCapture(n.cfg.Host.PacketCapture)
 	internalTransport, err := hostnetwork.NewInternalTransport(n.cfg, n.CertificateManager.GetCertificate().GetNodeRef().String())
 	if err != nil {
 		return errors.Wrap(err, "Failed to create internal transport")
@@ -143,16 +197,36 @@ func (n *ServiceNetwork) Init(ctx context.Context) error {
 	cert := n.CertificateManager.GetCertificate()
 	n.isDiscovery = utils.OriginIsDiscovery(cert)
 
+	revoked := make([]core.RecordRef, 0, len(n.cfg.Service.RevokedNodes))
+	for _, node := range n.cfg.Service.RevokedNodes {
+		ref, err := core.NewRefFromBase58(node)
+		if err != nil {
+			return errors.Wrapf(err, "invalid reference of revoked node %s", node)
+		}
+		revoked = append(revoked, *ref)
+	}
+	n.revocations = certificate.NewRevocations(cert, n.CryptographyService, revoked)
+	if n.isDiscovery && n.cfg.Service.RevocationsFile != "" {
+		if err := n.revocations.Persist(n.cfg.Service.RevocationsFile); err != nil {
+			return errors.Wrap(err, "failed to load revocation list")
+		}
+	}
+
+	n.profiler = phases.NewProfiler(phases.DefaultProfilerWindow)
+
 	n.cm.Inject(n,
 		cert,
 		n.NodeKeeper,
 		merkle.NewCalculator(),
 		consensusNetwork,
-		phases.NewCommunicator(),
+		n.profiler,
+		phases.NewCommunicator(n.cfg.Service.Consensus),
+		n.CryptographyScheme.BatchVerifier(n.cfg.Service.Consensus.VerifyWorkers),
+		phases.NewEvictor(n.cfg.Service.Consensus.Eviction),
 		phases.NewFirstPhase(),
 		phases.NewSecondPhase(),
 		phases.NewThirdPhase(),
-		phases.NewPhaseManager(),
+		phases.NewPhaseManager(n.cfg.Service.Consensus.Deadlines),
 		bootstrap.NewSessionManager(),
 		controller.NewNetworkController(n.hostNetwork),
 		controller.NewRPCController(options, n.hostNetwork),
@@ -184,27 +258,34 @@ func (n *ServiceNetwork) Start(ctx context.Context) error {
 		return errors.Wrap(err, "Failed to bootstrap network")
 	}
 
+	n.RegisterNodeHandler(revocationTopic, n.revocationHandler)
+	n.RegisterNodeHandler(revocationRequestTopic, n.revocationRequestHandler)
+
 	log.Infoln("Bootstrapping network...")
 	_, err = n.Controller.Bootstrap(ctx)
 	if err != nil {
 		return errors.Wrap(err, "Failed to bootstrap network")
 	}
+	n.fetchRevocations(ctx)
 
 	logger.Info("Service network started")
 	return nil
 }
 
-func (n *ServiceNetwork) GracefulStop(ctx context.Context) {
+// GracefulStop announces leave of the node with reason and optional operator note, other nodes record them in leave history.
+func (n *ServiceNetwork) GracefulStop(ctx context.Context, reason core.LeaveReason, note string) {
 	logger := inslogger.FromContext(ctx)
-	logger.Info("Gracefully stopping service network")
+	logger.Infof("Gracefully stopping service network, reason: %s, note: %q", reason, note)
 
-	n.NodeKeeper.AddPendingClaim(&packets.NodeLeaveClaim{})
+	n.NodeKeeper.AddPendingClaim(packets.NewNodeLeaveClaim(reason, note))
 }
 
 // Stop implements core.Component
 func (n *ServiceNetwork) Stop(ctx context.Context) error {
 	logger := inslogger.FromContext(ctx)
 
+	n.advanceNodeMode(ctx, core.NodeModeStopped, n.currentPulseNumber(ctx), "node is stopped")
+
 	logger.Info("Stopping network components")
 	if err := n.cm.Stop(ctx); err != nil {
 		log.Errorf("Error while stopping network components: %s", err.Error())
@@ -232,6 +313,9 @@ func (n *ServiceNetwork) HandlePulse(ctx context.Context, newPulse core.Pulse) {
 	)
 	defer span.End()
 
+	n.ClockSkewMonitor.ObservePulse(ctx, newPulse, currentTime)
+	transport.SetCapturePulse(newPulse.PulseNumber)
+
 	if !n.NodeKeeper.IsBootstrapped() {
 		n.Controller.SetLastIgnoredPulse(newPulse.NextPulseNumber)
 		return
@@ -241,6 +325,12 @@ func (n *ServiceNetwork) HandlePulse(ctx context.Context, newPulse core.Pulse) {
 		return
 	}
 
+	if !pulseDurationAllowed(n.CertificateManager.GetCertificate(), &newPulse) {
+		logger.Warnf("Ignore pulse %d: pulse duration %s is out of bounds declared in certificate",
+			newPulse.PulseNumber, newPulse.Duration())
+		return
+	}
+
 	// Ignore core.ErrNotFound because
 	// sometimes we can't fetch current pulse in new nodes
 	// (for fresh bootstrapped light-material with in-memory pulse-tracker)
@@ -259,6 +349,11 @@ func (n *ServiceNetwork) HandlePulse(ctx context.Context, newPulse core.Pulse) {
 	if err != nil {
 		logger.Error(errors.Wrap(err, "Failed to call OnPulse on NetworkSwitcher"))
 	}
+	if n.NetworkSwitcher.GetState() == core.CompleteNetworkState {
+		n.advanceNodeMode(ctx, core.NodeModeParticipant, newPulse.PulseNumber, "network is complete")
+	} else {
+		n.advanceNodeMode(ctx, core.NodeModeSyncing, newPulse.PulseNumber, "network is not complete")
+	}
 
 	logger.Debugf("Before set new current pulse number: %d", newPulse.PulseNumber)
 	err = n.PulseManager.Set(ctx, newPulse, n.NetworkSwitcher.GetState() == core.CompleteNetworkState)
@@ -266,19 +361,65 @@ func (n *ServiceNetwork) HandlePulse(ctx context.Context, newPulse core.Pulse) {
 		logger.Fatalf("Failed to set new pulse: %s", err.Error())
 	}
 	logger.Infof("Set new current pulse number: %d", newPulse.PulseNumber)
+	n.updateJoinPulses(newPulse.PulseNumber)
 
 	go n.phaseManagerOnPulse(ctx, newPulse, currentTime)
 }
 
 func (n *ServiceNetwork) phaseManagerOnPulse(ctx context.Context, newPulse core.Pulse, pulseStartTime time.Time) {
+	defer crashreport.Recover(ctx, "ServiceNetwork.phaseManagerOnPulse")
 	logger := inslogger.FromContext(ctx)
 
-	if err := n.PhaseManager.OnPulse(ctx, &newPulse, pulseStartTime); err != nil {
+	n.reportLoad(ctx)
+	n.reportStorage(ctx)
+	n.reportCordon(ctx)
+	n.refreshRevocations(ctx, newPulse)
+	n.profiler.RecordRTT(newPulse.PrevPulseNumber, participantsRTT(transport.TakeRTT(), n.NodeKeeper.GetActiveNodes()))
+	err := n.PhaseManager.OnPulse(ctx, &newPulse, pulseStartTime)
+	if err == nil {
+		atomic.AddInt64(&n.consensusStreak, 1)
+	} else {
+		atomic.StoreInt64(&n.consensusStreak, 0)
+	}
+	if n.notifier != nil {
+		n.notifier.ObserveConsensus(ctx, newPulse.PulseNumber, err)
+	}
+	if err != nil {
 		logger.Error("Failed to pass consensus: " + err.Error())
 		n.TerminationHandler.Abort()
 	}
 }
 
+// reportLoad adds claim with load of the node to the next consensus.
+func (n *ServiceNetwork) reportLoad(ctx context.Context) {
+	if !n.cfg.Service.ReportLoad || n.loadReporter == nil {
+		return
+	}
+	load := n.loadReporter.NodeLoad(ctx)
+	if err := load.Validate(); err != nil {
+		inslogger.FromContext(ctx).Warn("[ reportLoad ] node load is not reported: ", err)
+		return
+	}
+	n.NodeKeeper.AddPendingClaim(packets.NewNodeLoadClaim(load))
+}
+
+// reportStorage adds claim about exhausted storage to the next consensus, so the node doesn't take roles.
+func (n *ServiceNetwork) reportStorage(ctx context.Context) {
+	if n.storage == nil || n.storage.StorageLevel() != core.StorageLevelCritical {
+		return
+	}
+	n.NodeKeeper.AddPendingClaim(packets.NewNodeStorageClaim(n.storage.Headroom()))
+}
+
 func isNextPulse(currentPulse, newPulse *core.Pulse) bool {
 	return newPulse.PulseNumber > currentPulse.PulseNumber && newPulse.PulseNumber >= currentPulse.NextPulseNumber
 }
+
+func pulseDurationAllowed(cert core.Certificate, pulse *core.Pulse) bool {
+	bounds, ok := cert.(core.PulseDurationBoundsProvider)
+	if !ok {
+		return true
+	}
+	min, max := bounds.GetPulseDurationBounds()
+	return core.PulseDurationAllowed(pulse, min, max)
+}
@@ -0,0 +1,56 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package servicenetwork
+
+import (
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/network/transport"
+)
+
+// GetTraffic implements core.TrafficProvider.
+func (n *ServiceNetwork) GetTraffic() []core.PeerTraffic {
+	return resolvePeers(transport.Traffic(), n.NodeKeeper.GetActiveNodes())
+}
+
+// GetCapturedPackets implements core.PacketCapture.
+func (n *ServiceNetwork) GetCapturedPackets() []core.CapturedPacket {
+	return transport.CapturedPackets()
+}
+
+// resolvePeers fills node and role of peers which are found in active list by reference or address.
+func resolvePeers(traffic []core.PeerTraffic, active []core.Node) []core.PeerTraffic {
+	byRef := make(map[core.RecordRef]core.Node, len(active))
+	byAddress := make(map[string]core.Node, 2*len(active))
+	for _, node := range active {
+		byRef[node.ID()] = node
+		byAddress[node.Address()] = node
+		byAddress[node.ConsensusAddress()] = node
+	}
+
+	for i := range traffic {
+		node, ok := byRef[traffic[i].Node]
+		if !ok {
+			node, ok = byAddress[traffic[i].Address]
+		}
+		if !ok {
+			continue
+		}
+		traffic[i].Node = node.ID()
+		traffic[i].Role = node.Role()
+	}
+	return traffic
+}
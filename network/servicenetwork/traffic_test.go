@@ -0,0 +1,46 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package servicenetwork
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/network/nodenetwork"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestResolvePeers(t *testing.T) {
+	virtual := nodenetwork.NewNode(testutils.RandomRef(), core.StaticRoleVirtual, nil, "127.0.0.1:1", "")
+	// consensus address is 127.0.0.1:3
+	heavy := nodenetwork.NewNode(testutils.RandomRef(), core.StaticRoleHeavyMaterial, nil, "127.0.0.1:2", "")
+	unknown := testutils.RandomRef()
+
+	traffic := resolvePeers([]core.PeerTraffic{
+		{Node: virtual.ID(), Address: "127.0.0.1:1"},
+		{Address: "127.0.0.1:3"},
+		{Node: unknown, Address: "127.0.0.1:4"},
+	}, []core.Node{virtual, heavy})
+
+	require.Equal(t, []core.PeerTraffic{
+		{Node: virtual.ID(), Role: core.StaticRoleVirtual, Address: "127.0.0.1:1"},
+		{Node: heavy.ID(), Role: core.StaticRoleHeavyMaterial, Address: "127.0.0.1:3"},
+		{Node: unknown, Address: "127.0.0.1:4"},
+	}, traffic)
+}
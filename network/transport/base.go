@@ -23,7 +23,9 @@ import (
 	"net"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/insolar/insolar/core/message"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/metrics"
 	"github.com/insolar/insolar/network"
@@ -52,6 +54,9 @@ type baseTransport struct {
 	serializer    transportSerializer
 	proxy         relay.Proxy
 	packetHandler packetHandler
+	guard         *packetGuard
+	// shaper caps bandwidth of traffic classes, nil if transport isn't shaped
+	shaper *trafficShaper
 
 	disconnectStarted  chan bool
 	disconnectFinished chan bool
@@ -59,7 +64,7 @@ type baseTransport struct {
 	mutex *sync.RWMutex
 
 	publicAddress string
-	sendFunc      func(recvAddress string, data []byte) error
+	sendFunc      func(ctx context.Context, recvAddress string, data []byte) error
 }
 
 func newBaseTransport(proxy relay.Proxy, publicAddress string) baseTransport {
@@ -67,6 +72,7 @@ func newBaseTransport(proxy relay.Proxy, publicAddress string) baseTransport {
 	return baseTransport{
 		futureManager: futureManager,
 		packetHandler: newPacketHandler(futureManager),
+		guard:         newPacketGuard(),
 		proxy:         proxy,
 		serializer:    &baseSerializer{},
 
@@ -147,6 +153,21 @@ func (t *baseTransport) SendPacket(ctx context.Context, p *packet.Packet) error
 		return errors.Wrap(err, "Failed to serialize packet")
 	}
 
+	class := packetClass(ctx, p)
+	if class == message.TrafficConsensus {
+		markConsensus(time.Now())
+	}
+	if t.shaper != nil {
+		if err := t.shaper.wait(ctx, class, len(data)); err != nil {
+			return err
+		}
+	}
+
 	inslogger.FromContext(ctx).Debugf("Send %s packet to %s with RequestID = %d", p.Type, recvAddress, p.RequestID)
-	return t.sendFunc(recvAddress, data)
+	if err := t.sendFunc(ctx, recvAddress, data); err != nil {
+		return err
+	}
+	traffic.sent(p, len(data))
+	capture.sent(p, len(data))
+	return nil
 }
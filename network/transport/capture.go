@@ -0,0 +1,162 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package transport
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/consensus/packets"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/insolar/insolar/network/transport/packet"
+)
+
+// capturedHashSize is a size of hash identifying captured packet.
+const capturedHashSize = 16
+
+// packetCapture is a ring buffer of metadata of packets sent and received by transports of the node.
+type packetCapture struct {
+	lock   sync.Mutex
+	window time.Duration
+	now    func() time.Time
+	pulse  core.PulseNumber
+
+	ring  []core.CapturedPacket
+	next  int
+	count int
+}
+
+// capture is shared by all transports of the process, like traffic. It's disabled until configured.
+var capture = newPacketCapture()
+
+func newPacketCapture() *packetCapture {
+	return &packetCapture{now: time.Now}
+}
+
+// ConfigureCapture sets capture window and size, captured packets are dropped.
+func ConfigureCapture(cfg configuration.PacketCapture) {
+	capture.configure(cfg)
+}
+
+// SetCapturePulse sets pulse node is in, it's recorded with packets captured next.
+func SetCapturePulse(pulse core.PulseNumber) {
+	capture.lock.Lock()
+	capture.pulse = pulse
+	capture.lock.Unlock()
+}
+
+// CapturedPackets returns packets sent and received by transports of the process in capture window
+// in order of capture.
+func CapturedPackets() []core.CapturedPacket {
+	return capture.get()
+}
+
+func (pc *packetCapture) configure(cfg configuration.PacketCapture) {
+	pc.lock.Lock()
+	defer pc.lock.Unlock()
+
+	pc.window = cfg.Window
+	pc.ring = nil
+	if cfg.MaxPackets > 0 {
+		pc.ring = make([]core.CapturedPacket, cfg.MaxPackets)
+	}
+	pc.next, pc.count = 0, 0
+}
+
+func (pc *packetCapture) sent(p *packet.Packet, size int) {
+	address, node := peerOf(p.Receiver)
+	pc.record(p, address, node, size, true)
+}
+
+func (pc *packetCapture) received(p *packet.Packet, remoteAddress string, size int) {
+	address, node := peerOf(p.Sender)
+	if address == "" {
+		address = remoteAddress
+	}
+	pc.record(p, address, node, size, false)
+}
+
+func (pc *packetCapture) record(p *packet.Packet, address string, node core.RecordRef, size int, sent bool) {
+	pc.lock.Lock()
+	defer pc.lock.Unlock()
+
+	if len(pc.ring) == 0 {
+		return
+	}
+	pc.ring[pc.next] = core.CapturedPacket{
+		Time:    pc.now(),
+		Sent:    sent,
+		Type:    packetType(p),
+		Size:    size,
+		Node:    node,
+		Address: address,
+		Pulse:   pc.pulse,
+		Hash:    packetHash(p),
+	}
+	pc.next = (pc.next + 1) % len(pc.ring)
+	if pc.count < len(pc.ring) {
+		pc.count++
+	}
+}
+
+func (pc *packetCapture) get() []core.CapturedPacket {
+	pc.lock.Lock()
+	defer pc.lock.Unlock()
+
+	since := pc.now().Add(-pc.window)
+	result := make([]core.CapturedPacket, 0, pc.count)
+	for i := 0; i < pc.count; i++ {
+		captured := pc.ring[(pc.next-pc.count+i+len(pc.ring))%len(pc.ring)]
+		if pc.window > 0 && captured.Time.Before(since) {
+			continue
+		}
+		result = append(result, captured)
+	}
+	return result
+}
+
+// packetHash hashes parts of packet which don't change in transit, so the same packet has the same hash on both
+// ends. Consensus packets carry no hosts and are hashed by content, other packets by their routing fields.
+func packetHash(p *packet.Packet) []byte {
+	h := sha256.New()
+	if data, ok := p.Data.(packets.ConsensusPacket); ok {
+		if payload, err := data.Serialize(); err == nil {
+			h.Write(payload) // nolint: errcheck
+		}
+		return h.Sum(nil)[:capturedHashSize]
+	}
+
+	for _, hst := range []*host.Host{p.Sender, p.Receiver} {
+		if hst != nil {
+			h.Write(hst.NodeID[:]) // nolint: errcheck
+		}
+	}
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(p.Type))
+	h.Write(buf[:]) // nolint: errcheck
+	binary.BigEndian.PutUint64(buf[:], uint64(p.RequestID))
+	h.Write(buf[:]) // nolint: errcheck
+	if p.IsResponse {
+		h.Write([]byte{1}) // nolint: errcheck
+	}
+	h.Write([]byte(p.TraceID)) // nolint: errcheck
+	return h.Sum(nil)[:capturedHashSize]
+}
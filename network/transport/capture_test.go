@@ -0,0 +1,104 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package transport
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/consensus/packets"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/insolar/insolar/network/transport/packet"
+	"github.com/insolar/insolar/network/transport/packet/types"
+	"github.com/insolar/insolar/testutils"
+)
+
+func newPhase2Packet(t *testing.T, pulse core.PulseNumber) *packets.Phase2Packet {
+	bitset, err := packets.NewBitSet(10)
+	require.NoError(t, err)
+	result := packets.NewPhase2Packet(pulse)
+	result.SetBitSet(bitset)
+	return result
+}
+
+func TestPacketCapture(t *testing.T) {
+	now := time.Now()
+	pc := newPacketCapture()
+	pc.now = func() time.Time { return now }
+
+	ref := testutils.RandomRef()
+	peer, err := host.NewHostN("127.0.0.1:31337", ref)
+	require.NoError(t, err)
+	request := &packet.Packet{Receiver: peer, Type: types.RPC, RequestID: 1}
+
+	pc.sent(request, 100)
+	require.Empty(t, pc.get(), "capture isn't configured")
+
+	pc.configure(configuration.PacketCapture{Window: time.Minute, MaxPackets: 3})
+	pc.pulse = core.FirstPulseNumber
+	pc.sent(request, 100)
+	pc.received(&packet.Packet{Sender: peer, Type: types.RPC, RequestID: 1, IsResponse: true}, "127.0.0.1", 10)
+	// consensus packets have no sender host
+	pc.received(&packet.Packet{Data: newPhase2Packet(t, core.FirstPulseNumber)}, "127.0.0.2:31338", 7)
+
+	captured := pc.get()
+	require.Len(t, captured, 3)
+	require.Equal(t, core.CapturedPacket{
+		Time: now, Sent: true, Type: types.RPC.String(), Size: 100, Node: ref, Address: "127.0.0.1:31337",
+		Pulse: core.FirstPulseNumber, Hash: packetHash(request),
+	}, captured[0])
+	require.False(t, captured[1].Sent)
+	require.NotEqual(t, captured[0].Hash, captured[1].Hash, "response differs from request")
+	require.Equal(t, "127.0.0.2:31338", captured[2].Address)
+	require.Equal(t, packets.Phase2.String(), captured[2].Type)
+
+	// ring keeps the latest packets
+	now = now.Add(30 * time.Second)
+	pc.sent(&packet.Packet{Receiver: peer, Type: types.Ping}, 5)
+	captured = pc.get()
+	require.Len(t, captured, 3)
+	require.Equal(t, types.Ping.String(), captured[2].Type)
+	require.Equal(t, 10, captured[0].Size)
+
+	// packets out of window are skipped
+	now = now.Add(45 * time.Second)
+	captured = pc.get()
+	require.Len(t, captured, 1)
+	require.Equal(t, types.Ping.String(), captured[0].Type)
+}
+
+func TestPacketHash(t *testing.T) {
+	ref := testutils.RandomRef()
+	peer, err := host.NewHostN("127.0.0.1:31337", ref)
+	require.NoError(t, err)
+
+	sent := &packet.Packet{Receiver: peer, Type: types.RPC, RequestID: 7, TraceID: "trace"}
+	received := &packet.Packet{Receiver: peer, Type: types.RPC, RequestID: 7, TraceID: "trace"}
+	received.RemoteAddress = "127.0.0.2"
+	require.Equal(t, packetHash(sent), packetHash(received), "transit fields aren't hashed")
+	require.Len(t, packetHash(sent), capturedHashSize)
+
+	phase2 := &packet.Packet{Data: newPhase2Packet(t, core.FirstPulseNumber)}
+	sentPhase2 := &packet.Packet{Receiver: peer, Data: newPhase2Packet(t, core.FirstPulseNumber)}
+	otherPhase2 := &packet.Packet{Data: newPhase2Packet(t, core.FirstPulseNumber+1)}
+	require.Equal(t, packetHash(phase2), packetHash(sentPhase2), "consensus packets are hashed by content")
+	require.NotEqual(t, packetHash(phase2), packetHash(otherPhase2))
+}
@@ -70,6 +70,7 @@ type future struct {
 	requestID      network.RequestID
 	cancelCallback CancelCallback
 	finished       uint32
+	created        time.Time
 }
 
 // NewFuture creates new Future.
@@ -81,6 +82,7 @@ func NewFuture(requestID network.RequestID, actor *host.Host, msg *packet.Packet
 		request:        msg,
 		requestID:      requestID,
 		cancelCallback: cancelCallback,
+		created:        time.Now(),
 	}
 }
 
@@ -107,6 +109,7 @@ func (future *future) Result() <-chan *packet.Packet {
 // SetResult write packet to the result channel.
 func (future *future) SetResult(msg *packet.Packet) {
 	if atomic.CompareAndSwapUint32(&future.finished, 0, 1) {
+		rtt.observe(future.actor, time.Since(future.created))
 		future.result <- msg
 		future.finish()
 	}
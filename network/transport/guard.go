@@ -0,0 +1,136 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package transport
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/log"
+	"github.com/insolar/insolar/metrics"
+	"github.com/insolar/insolar/network/transport/packet"
+)
+
+const (
+	// malformedThreshold is a number of malformed packets in malformedWindow after which peer is quarantined.
+	malformedThreshold = 5
+	malformedWindow    = time.Minute
+	// quarantineTime is a time while packets of quarantined peer are ignored.
+	quarantineTime = 5 * time.Minute
+	// maxGuardedPeers is a number of peers after which expired peer states are forgotten.
+	maxGuardedPeers = 1024
+)
+
+type peerState struct {
+	malformed        int
+	windowStart      time.Time
+	quarantinedUntil time.Time
+}
+
+// packetGuard counts malformed packets of remote peers and quarantines peers which send them repeatedly.
+// Peers are identified by IP, because port of incoming connection is random.
+type packetGuard struct {
+	lock  sync.Mutex
+	peers map[string]*peerState
+	now   func() time.Time
+}
+
+func newPacketGuard() *packetGuard {
+	return &packetGuard{peers: make(map[string]*peerState), now: time.Now}
+}
+
+// quarantined returns true if packets from address should be ignored.
+func (g *packetGuard) quarantined(address string) bool {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	state, ok := g.peers[peerIP(address)]
+	if !ok || !g.now().Before(state.quarantinedUntil) {
+		return false
+	}
+	metrics.NetworkPacketQuarantinedTotal.Inc()
+	return true
+}
+
+// malformed registers malformed packet from address and quarantines peer if threshold is reached.
+func (g *packetGuard) malformed(address string, err error) {
+	metrics.NetworkPacketMalformedTotal.Inc()
+	ip := peerIP(address)
+	now := g.now()
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if len(g.peers) >= maxGuardedPeers {
+		g.forgetExpired(now)
+	}
+	state, ok := g.peers[ip]
+	if !ok {
+		state = &peerState{}
+		g.peers[ip] = state
+	}
+	if now.Sub(state.windowStart) > malformedWindow {
+		state.windowStart = now
+		state.malformed = 0
+	}
+	state.malformed++
+
+	switch {
+	case state.malformed == 1:
+		log.Warnf("[ packetGuard ] malformed packet from %s: %s", address, err)
+	case state.malformed == malformedThreshold:
+		state.quarantinedUntil = now.Add(quarantineTime)
+		metrics.NetworkPeersQuarantinedTotal.Inc()
+		log.Warnf("[ packetGuard ] %s sent %d malformed packets, ignoring it for %s. Last error: %s",
+			address, state.malformed, quarantineTime, err)
+	default:
+		log.Debugf("[ packetGuard ] malformed packet from %s: %s", address, err)
+	}
+}
+
+func (g *packetGuard) forgetExpired(now time.Time) {
+	for ip, state := range g.peers {
+		if now.Sub(state.windowStart) > malformedWindow && !now.Before(state.quarantinedUntil) {
+			delete(g.peers, ip)
+		}
+	}
+}
+
+func peerIP(address string) string {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+	return host
+}
+
+// validatePacket checks that packet received by stream transport can be dispatched.
+func validatePacket(p *packet.Packet) error {
+	if p.Sender == nil || p.Sender.Address == nil {
+		return errors.New("packet has no sender")
+	}
+	if p.Receiver == nil || p.Receiver.Address == nil {
+		return errors.New("packet has no receiver")
+	}
+	if p.Type <= 0 {
+		return errors.Errorf("packet has invalid type %d", p.Type)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package transport
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/insolar/insolar/network/transport/packet"
+	"github.com/insolar/insolar/network/transport/packet/types"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestPacketGuard(t *testing.T) {
+	now := time.Now()
+	g := newPacketGuard()
+	g.now = func() time.Time { return now }
+	err := errors.New("test error")
+
+	for i := 0; i < malformedThreshold-1; i++ {
+		g.malformed("127.0.0.1:1000", err)
+	}
+	require.False(t, g.quarantined("127.0.0.1:1001"))
+
+	// counter is reset after window
+	now = now.Add(malformedWindow + time.Second)
+	g.malformed("127.0.0.1:1000", err)
+	require.False(t, g.quarantined("127.0.0.1:1000"))
+
+	for i := 0; i < malformedThreshold-1; i++ {
+		g.malformed("127.0.0.1:1000", err)
+	}
+	// other ports of the same host are quarantined too, other hosts aren't
+	require.True(t, g.quarantined("127.0.0.1:1001"))
+	require.False(t, g.quarantined("127.0.0.2:1000"))
+
+	now = now.Add(quarantineTime)
+	require.False(t, g.quarantined("127.0.0.1:1000"))
+}
+
+func TestPacketGuard_ForgetExpired(t *testing.T) {
+	now := time.Now()
+	g := newPacketGuard()
+	g.now = func() time.Time { return now }
+
+	g.malformed("127.0.0.1:1000", errors.New("test error"))
+	now = now.Add(malformedWindow + time.Second)
+	g.forgetExpired(now)
+	require.Empty(t, g.peers)
+}
+
+func TestValidatePacket(t *testing.T) {
+	h, err := host.NewHostN("127.0.0.1:31337", testutils.RandomRef())
+	require.NoError(t, err)
+
+	require.NoError(t, validatePacket(packet.NewBuilder(h).Receiver(h).Type(types.Ping).Build()))
+	require.Error(t, validatePacket(&packet.Packet{Receiver: h, Type: types.Ping}))
+	require.Error(t, validatePacket(&packet.Packet{Sender: h, Type: types.Ping}))
+	require.Error(t, validatePacket(&packet.Packet{Sender: h, Receiver: h}))
+}
@@ -30,6 +30,9 @@ import (
 	"github.com/pkg/errors"
 )
 
+// MaxPacketSize is a maximum length of serialized packet, longer packets are rejected before reading.
+const MaxPacketSize = 128 * 1024 * 1024
+
 // Packet is DHT packet object.
 type Packet struct {
 	Sender        *host.Host
@@ -78,11 +81,14 @@ func DeserializePacket(conn io.Reader) (*Packet, error) {
 		return nil, io.ErrUnexpectedEOF
 	}
 
+	if length > MaxPacketSize {
+		return nil, errors.Errorf("packet length %d exceeds limit %d", length, MaxPacketSize)
+	}
+
 	log.Debugf("[ DeserializePacket ] packet length %d", length)
 	buf := make([]byte, length)
 	if _, err := io.ReadFull(conn, buf); err != nil {
-		log.Error("[ DeserializePacket ] couldn't read packet: ", err)
-		return nil, err
+		return nil, errors.Wrap(err, "couldn't read packet")
 	}
 	log.Debugf("[ DeserializePacket ] read packet")
 
@@ -91,8 +97,7 @@ func DeserializePacket(conn io.Reader) (*Packet, error) {
 
 	err = dec.Decode(msg)
 	if err != nil {
-		log.Error("[ DeserializePacket ] couldn't decode packet: ", err)
-		return nil, err
+		return nil, errors.Wrap(err, "couldn't decode packet")
 	}
 
 	log.Debugf("[ DeserializePacket ] decoded packet to %#v", msg)
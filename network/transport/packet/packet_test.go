@@ -23,6 +23,7 @@ import (
 	"encoding/gob"
 	"testing"
 
+	"encoding/binary"
 	"github.com/insolar/insolar/network/transport/host"
 	"github.com/insolar/insolar/testutils"
 	"github.com/stretchr/testify/require"
@@ -82,3 +83,11 @@ func TestDeserializeBigPacket(t *testing.T) {
 	deserializedData := deserializedMsg.Data.(*RequestTest).Data
 	require.Equal(t, data, deserializedData)
 }
+
+func TestDeserializePacket_TooLong(t *testing.T) {
+	var lengthBytes [8]byte
+	binary.PutUvarint(lengthBytes[:], MaxPacketSize+1)
+
+	_, err := DeserializePacket(bytes.NewReader(lengthBytes[:]))
+	require.Error(t, err)
+}
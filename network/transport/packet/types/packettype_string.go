@@ -4,9 +4,9 @@ package types
 
 import "strconv"
 
-const _PacketType_name = "PingRPCCascadePulseGetRandomHostsBootstrapAuthorizeRegisterGenesisChallenge1Challenge2Disconnect"
+const _PacketType_name = "PingRPCCascadePulseGetRandomHostsBootstrapAuthorizeRegisterGenesisChallenge1Challenge2DisconnectNodeMessage"
 
-var _PacketType_index = [...]uint8{0, 4, 7, 14, 19, 33, 42, 51, 59, 66, 76, 86, 96}
+var _PacketType_index = [...]uint8{0, 4, 7, 14, 19, 33, 42, 51, 59, 66, 76, 86, 96, 107}
 
 func (i PacketType) String() string {
 	i -= 1
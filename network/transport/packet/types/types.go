@@ -45,4 +45,6 @@ const (
 	Challenge2
 	// Disconnect is packet type to gracefully disconnect from network.
 	Disconnect
+	// NodeMessage is packet type to deliver application message of platform component to a node.
+	NodeMessage
 )
@@ -28,14 +28,18 @@ import (
 
 type connectionPool struct {
 	connectionFactory connectionFactory
+	reconnect         ReconnectPolicy
+	maxStreams        int
 
 	entryHolder entryHolder
 	mutex       sync.RWMutex
 }
 
-func newConnectionPool(connectionFactory connectionFactory) *connectionPool {
+func newConnectionPool(connectionFactory connectionFactory, reconnect ReconnectPolicy, maxStreams int) *connectionPool {
 	return &connectionPool{
 		connectionFactory: connectionFactory,
+		reconnect:         reconnect,
+		maxStreams:        maxStreams,
 
 		entryHolder: newEntryHolder(),
 	}
@@ -98,7 +102,7 @@ func (cp *connectionPool) getOrCreateEntry(ctx context.Context, address net.Addr
 
 	logger.Debugf("[ getOrCreateEntry ] Failed to retrieve entry for connection to %s, creating it", address)
 
-	entry = newEntry(cp.connectionFactory, address, cp.CloseConnection)
+	entry = newEntry(cp.connectionFactory, address, cp.CloseConnection, cp.reconnect, cp.maxStreams)
 
 	cp.entryHolder.Add(address, entry)
 	size := cp.entryHolder.Size()
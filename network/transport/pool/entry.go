@@ -24,6 +24,7 @@ import (
 
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/instrumentation/instracer"
+	"github.com/insolar/insolar/instrumentation/retry"
 	"github.com/insolar/insolar/network/utils"
 	"github.com/pkg/errors"
 	"go.opencensus.io/trace"
@@ -33,40 +34,65 @@ type entryImpl struct {
 	connectionFactory connectionFactory
 	address           net.Addr
 	onClose           onClose
+	reconnect         ReconnectPolicy
+	maxStreams        int
 
 	mutex *sync.Mutex
 
-	conn net.Conn
+	conn    net.Conn
+	session *session
+	closed  chan struct{}
 }
 
-func newEntryImpl(connectionFactory connectionFactory, address net.Addr, onClose onClose) *entryImpl {
+func newEntryImpl(
+	connectionFactory connectionFactory,
+	address net.Addr,
+	onClose onClose,
+	reconnect ReconnectPolicy,
+	maxStreams int,
+) *entryImpl {
 	return &entryImpl{
 		connectionFactory: connectionFactory,
 		address:           address,
 		mutex:             &sync.Mutex{},
 		onClose:           onClose,
+		reconnect:         reconnect,
+		maxStreams:        maxStreams,
+		closed:            make(chan struct{}),
 	}
 }
 
+// Open opens new stream over connection, connection is created if it isn't opened yet.
 func (e *entryImpl) Open(ctx context.Context) (net.Conn, error) {
 	e.mutex.Lock()
-	defer e.mutex.Unlock()
-
-	if e.conn != nil {
-		return e.conn, nil
+	if e.conn == nil {
+		conn, err := e.open(ctx)
+		if err != nil {
+			e.mutex.Unlock()
+			return nil, err
+		}
+		e.setConn(conn)
 	}
+	session := e.session
+	e.mutex.Unlock()
 
-	conn, err := e.open(ctx)
-	if err != nil {
-		return nil, err
-	}
+	return session.open(ctx)
+}
 
+func (e *entryImpl) setConn(conn net.Conn) {
 	e.conn = conn
-	return e.conn, nil
+	e.session = newSession(conn, e.maxStreams)
+}
+
+func (e *entryImpl) resetConn() {
+	if e.session != nil {
+		e.session.close()
+	}
+	e.conn = nil
+	e.session = nil
 }
 
 func (e *entryImpl) open(ctx context.Context) (net.Conn, error) {
-	logger := inslogger.FromContext(ctx)
 	ctx, span := instracer.StartSpan(ctx, "connectionPool.open")
 	span.AddAttributes(
 		trace.StringAttribute("create connect to", e.address.String()),
@@ -78,26 +104,100 @@ func (e *entryImpl) open(ctx context.Context) (net.Conn, error) {
 		return nil, errors.Wrap(err, "[ Open ] Failed to create TCP connection")
 	}
 
-	go func(e *entryImpl, conn net.Conn) {
-		b := make([]byte, 1)
-		_, err := conn.Read(b)
-		if err != nil {
-			logger.Infof("[ Open ] remote host 'closed' connection to %s: %s", e.address, err)
-			e.onClose(ctx, e.address)
-			return
-		}
+	go e.watch(ctx, conn)
+
+	return conn, nil
+}
+
+// watch waits until remote host closes connection and restores it according to reconnect policy.
+func (e *entryImpl) watch(ctx context.Context, conn net.Conn) {
+	logger := inslogger.FromContext(ctx)
 
+	b := make([]byte, 1)
+	_, err := conn.Read(b)
+	if err == nil {
 		logger.Errorf("[ Open ] unexpected data on connection to %s", e.address)
-	}(e, conn)
+		return
+	}
 
-	return conn, nil
+	e.mutex.Lock()
+	current := e.conn == conn && !e.isClosed()
+	if current {
+		e.resetConn()
+	}
+	e.mutex.Unlock()
+	if !current {
+		return
+	}
+
+	logger.Infof("[ Open ] remote host 'closed' connection to %s: %s", e.address, err)
+	utils.CloseVerbose(conn)
+	if !e.restore(ctx) {
+		e.onClose(ctx, e.address)
+	}
+}
+
+// restore tries to reconnect with backoff. It returns false if connection isn't restored and entry isn't closed.
+func (e *entryImpl) restore(ctx context.Context) bool {
+	if e.reconnect.Attempts <= 0 {
+		return false
+	}
+	logger := inslogger.FromContext(ctx)
+
+	// connection outlives retries, so it's opened with parent context
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-e.closed:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	policy := retry.Policy{Attempts: e.reconnect.Attempts, DelayFirst: true}
+	if e.reconnect.Backoff != nil {
+		policy.Backoff = *e.reconnect.Backoff
+	}
+	err := retry.Do(ctx, "transport.reconnect", policy, func(ctx context.Context, attempt int) error {
+		e.mutex.Lock()
+		defer e.mutex.Unlock()
+
+		if e.conn != nil || e.isClosed() {
+			// connection is opened by sender or entry is closed
+			return nil
+		}
+		conn, err := e.open(parent)
+		if err != nil {
+			logger.Debugf("[ restore ] failed to reconnect to %s: %s", e.address, err)
+			return retry.Retryable(err)
+		}
+		e.setConn(conn)
+		logger.Infof("[ restore ] connection to %s is restored after %d attempts", e.address, attempt+1)
+		return nil
+	})
+	return err == nil || e.isClosed()
+}
+
+func (e *entryImpl) isClosed() bool {
+	select {
+	case <-e.closed:
+		return true
+	default:
+		return false
+	}
 }
 
 func (e *entryImpl) Close() {
 	e.mutex.Lock()
 	defer e.mutex.Unlock()
 
+	if !e.isClosed() {
+		close(e.closed)
+	}
 	if e.conn != nil {
 		utils.CloseVerbose(e.conn)
+		e.resetConn()
 	}
 }
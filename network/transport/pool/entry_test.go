@@ -0,0 +1,142 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package pool
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/utils/backoff"
+)
+
+// pipeFactory creates in-memory connections and keeps their remote ends.
+type pipeFactory struct {
+	lock    sync.Mutex
+	fail    bool
+	remotes []net.Conn
+}
+
+func (f *pipeFactory) CreateConnection(ctx context.Context, address net.Addr) (net.Conn, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.fail {
+		return nil, errors.New("test error")
+	}
+	local, remote := net.Pipe()
+	f.remotes = append(f.remotes, remote)
+	return local, nil
+}
+
+func (f *pipeFactory) closeRemote(t *testing.T) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	require.NotEmpty(t, f.remotes)
+	require.NoError(t, f.remotes[len(f.remotes)-1].Close())
+}
+
+func (f *pipeFactory) opened() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return len(f.remotes)
+}
+
+func testReconnectPolicy(attempts int) ReconnectPolicy {
+	return ReconnectPolicy{
+		Attempts: attempts,
+		Backoff:  &backoff.Backoff{Min: time.Millisecond, Max: 10 * time.Millisecond, Factor: 2},
+	}
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	deadline := time.Now().Add(time.Second)
+	for !condition() && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	require.True(t, condition())
+}
+
+func TestEntry_Reconnect(t *testing.T) {
+	ctx := context.Background()
+	factory := &pipeFactory{}
+	addr := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 1}
+	closed := make(chan struct{}, 1)
+	e := newEntryImpl(factory, addr, func(context.Context, net.Addr) { closed <- struct{}{} }, testReconnectPolicy(3), 0)
+
+	conn, err := e.Open(ctx)
+	require.NoError(t, err)
+
+	factory.closeRemote(t)
+	waitFor(t, func() bool { return factory.opened() == 2 })
+
+	restored, err := e.Open(ctx)
+	require.NoError(t, err)
+	require.NotEqual(t, conn, restored)
+	require.Empty(t, closed)
+
+	// connection isn't restored after it is closed locally
+	e.Close()
+	time.Sleep(20 * time.Millisecond)
+	require.Equal(t, 2, factory.opened())
+	require.Empty(t, closed)
+}
+
+func TestEntry_ReconnectFailed(t *testing.T) {
+	ctx := context.Background()
+	factory := &pipeFactory{}
+	addr := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 1}
+	closed := make(chan struct{}, 1)
+	e := newEntryImpl(factory, addr, func(context.Context, net.Addr) { closed <- struct{}{} }, testReconnectPolicy(2), 0)
+
+	_, err := e.Open(ctx)
+	require.NoError(t, err)
+
+	factory.lock.Lock()
+	factory.fail = true
+	factory.lock.Unlock()
+	factory.closeRemote(t)
+
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("entry isn't closed after failed reconnect")
+	}
+}
+
+func TestEntry_ReconnectDisabled(t *testing.T) {
+	ctx := context.Background()
+	factory := &pipeFactory{}
+	addr := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 1}
+	closed := make(chan struct{}, 1)
+	e := newEntryImpl(factory, addr, func(context.Context, net.Addr) { closed <- struct{}{} }, testReconnectPolicy(0), 0)
+
+	_, err := e.Open(ctx)
+	require.NoError(t, err)
+	factory.closeRemote(t)
+
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("entry isn't closed")
+	}
+	require.Equal(t, 1, factory.opened())
+}
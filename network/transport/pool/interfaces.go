@@ -20,9 +20,12 @@ package pool
 import (
 	"context"
 	"net"
+
+	"github.com/insolar/insolar/utils/backoff"
 )
 
 type ConnectionPool interface {
+	// GetConnection opens new write-only stream over connection to address, stream must be closed after use.
 	GetConnection(ctx context.Context, address net.Addr) (net.Conn, error)
 	CloseConnection(ctx context.Context, address net.Addr)
 	Reset()
@@ -39,8 +42,8 @@ type entry interface {
 
 type onClose func(ctx context.Context, addr net.Addr)
 
-func newEntry(connectionFactory connectionFactory, address net.Addr, onClose onClose) entry {
-	return newEntryImpl(connectionFactory, address, onClose)
+func newEntry(connectionFactory connectionFactory, address net.Addr, onClose onClose, reconnect ReconnectPolicy, maxStreams int) entry {
+	return newEntryImpl(connectionFactory, address, onClose, reconnect, maxStreams)
 }
 
 type iterateFunc func(entry entry)
@@ -58,6 +61,16 @@ func newEntryHolder() entryHolder {
 	return newEntryHolderImpl()
 }
 
-func NewConnectionPool(connectionFactory connectionFactory) ConnectionPool {
-	return newConnectionPool(connectionFactory)
+// ReconnectPolicy configures restoring of connections closed by remote host.
+type ReconnectPolicy struct {
+	// Attempts is a number of reconnect attempts, zero disables reconnect.
+	Attempts int
+	// Backoff calculates delay before each attempt.
+	Backoff *backoff.Backoff
+}
+
+// NewConnectionPool creates pool which keeps one connection per address and multiplexes streams over it.
+// maxStreams limits number of simultaneously opened streams per connection, zero means no limit.
+func NewConnectionPool(connectionFactory connectionFactory, reconnect ReconnectPolicy, maxStreams int) ConnectionPool {
+	return newConnectionPool(connectionFactory, reconnect, maxStreams)
 }
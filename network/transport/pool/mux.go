@@ -0,0 +1,320 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package pool
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/insolar/insolar/network/utils"
+	"github.com/pkg/errors"
+)
+
+// Connections of pool are multiplexed: every sender gets its own stream and data of streams is split to frames,
+// so concurrent packets to the same peer don't wait until previous ones are completely written.
+// Streams are one-directional, they are written by side which opened connection and read by another side.
+//
+// Frame is a header with stream id (4 bytes), frame type (1 byte) and payload size (2 bytes) followed by payload.
+//
+// Streams opened with priority context (see utils.ContextWithPriority) form a priority lane: they don't wait for
+// free slot and their frames are written before frames of regular streams waiting for connection.
+const (
+	frameHeaderSize = 7
+	maxFramePayload = 16 * 1024
+
+	frameData  byte = 0
+	frameClose byte = 1
+)
+
+var errWriteOnly = errors.New("stream is write-only")
+
+// session opens streams over connection.
+type session struct {
+	conn      net.Conn
+	slots     chan struct{}
+	closed    chan struct{}
+	closeOnce sync.Once
+
+	writeLock *writeLock
+	lastID    uint32
+}
+
+// newSession creates session with limited number of simultaneously opened streams, zero means no limit.
+func newSession(conn net.Conn, maxStreams int) *session {
+	s := &session{
+		conn:      conn,
+		closed:    make(chan struct{}),
+		writeLock: newWriteLock(),
+	}
+	if maxStreams > 0 {
+		s.slots = make(chan struct{}, maxStreams)
+	}
+	return s
+}
+
+// open opens new stream. It waits for free slot if session has maximum number of opened streams.
+// Priority streams are opened immediately.
+func (s *session) open(ctx context.Context) (*stream, error) {
+	if utils.PriorityFromContext(ctx) {
+		select {
+		case <-s.closed:
+			return nil, errors.New("[ open ] connection is closed")
+		default:
+		}
+		return &stream{session: s, id: atomic.AddUint32(&s.lastID, 1), priority: true}, nil
+	}
+	if s.slots != nil {
+		select {
+		case s.slots <- struct{}{}:
+		case <-s.closed:
+			return nil, errors.New("[ open ] connection is closed")
+		case <-ctx.Done():
+			return nil, errors.Wrap(ctx.Err(), "[ open ] failed to wait for free stream")
+		}
+	}
+	return &stream{session: s, id: atomic.AddUint32(&s.lastID, 1)}, nil
+}
+
+func (s *session) release() {
+	if s.slots != nil {
+		<-s.slots
+	}
+}
+
+// close wakes up streams waiting for free slot. Connection itself is closed by its owner.
+func (s *session) close() {
+	s.closeOnce.Do(func() {
+		close(s.closed)
+	})
+}
+
+// writeFrame writes frame to connection. Connection is closed if frame isn't written completely,
+// because stream can't be parsed by remote side after partial frame.
+func (s *session) writeFrame(id uint32, kind byte, payload []byte, deadline time.Time, priority bool) error {
+	frame := make([]byte, frameHeaderSize+len(payload))
+	binary.BigEndian.PutUint32(frame, id)
+	frame[4] = kind
+	binary.BigEndian.PutUint16(frame[5:], uint16(len(payload)))
+	copy(frame[frameHeaderSize:], payload)
+
+	s.writeLock.lock(priority)
+	defer s.writeLock.unlock()
+
+	err := s.conn.SetWriteDeadline(deadline)
+	if err != nil {
+		return errors.Wrap(err, "[ writeFrame ] failed to set write deadline")
+	}
+	_, err = s.conn.Write(frame)
+	if err != nil {
+		utils.CloseVerbose(s.conn)
+		return errors.Wrap(err, "[ writeFrame ] failed to write frame")
+	}
+	return nil
+}
+
+// writeLock serializes writes to connection. Priority writers acquire it before regular ones waiting for it.
+type writeLock struct {
+	mutex           sync.Mutex
+	cond            *sync.Cond
+	busy            bool
+	priorityWaiting int
+}
+
+func newWriteLock() *writeLock {
+	l := &writeLock{}
+	l.cond = sync.NewCond(&l.mutex)
+	return l
+}
+
+func (l *writeLock) lock(priority bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if priority {
+		l.priorityWaiting++
+		defer func() { l.priorityWaiting-- }()
+	}
+	for l.busy || (!priority && l.priorityWaiting > 0) {
+		l.cond.Wait()
+	}
+	l.busy = true
+}
+
+func (l *writeLock) unlock() {
+	l.mutex.Lock()
+	l.busy = false
+	l.mutex.Unlock()
+	l.cond.Broadcast()
+}
+
+// stream is a write-only net.Conn which sends data as frames of session.
+type stream struct {
+	session   *session
+	id        uint32
+	priority  bool
+	deadline  time.Time
+	closeOnce sync.Once
+}
+
+func (s *stream) Write(b []byte) (int, error) {
+	n := 0
+	for len(b) > 0 {
+		size := len(b)
+		if size > maxFramePayload {
+			size = maxFramePayload
+		}
+		err := s.session.writeFrame(s.id, frameData, b[:size], s.deadline, s.priority)
+		if err != nil {
+			return n, err
+		}
+		n += size
+		b = b[size:]
+	}
+	return n, nil
+}
+
+func (s *stream) Read(b []byte) (int, error) {
+	return 0, errWriteOnly
+}
+
+// Close notifies remote side that stream is finished and frees its slot.
+func (s *stream) Close() error {
+	var err error
+	s.closeOnce.Do(func() {
+		err = s.session.writeFrame(s.id, frameClose, nil, s.deadline, s.priority)
+		if !s.priority {
+			s.session.release()
+		}
+	})
+	return err
+}
+
+func (s *stream) LocalAddr() net.Addr {
+	return s.session.conn.LocalAddr()
+}
+
+func (s *stream) RemoteAddr() net.Addr {
+	return s.session.conn.RemoteAddr()
+}
+
+func (s *stream) SetDeadline(t time.Time) error {
+	return s.SetWriteDeadline(t)
+}
+
+func (s *stream) SetReadDeadline(t time.Time) error {
+	return errWriteOnly
+}
+
+// SetWriteDeadline sets deadline for writing data of this stream, other streams of connection aren't affected
+// unless write of the stream times out in the middle of frame.
+func (s *stream) SetWriteDeadline(t time.Time) error {
+	s.deadline = t
+	return nil
+}
+
+// ServeStreams reads streams multiplexed over connection and calls handle in new goroutine for every new stream.
+// Stream reader returns io.EOF when remote side closes stream or connection is broken.
+// ServeStreams returns when connection can't be read anymore.
+func ServeStreams(conn net.Conn, handle func(stream io.Reader)) error {
+	streams := make(map[uint32]*incomingStream)
+	defer func() {
+		for _, s := range streams {
+			s.finish()
+		}
+	}()
+
+	header := make([]byte, frameHeaderSize)
+	for {
+		_, err := io.ReadFull(conn, header)
+		if err != nil {
+			return errors.Wrap(err, "[ ServeStreams ] failed to read frame header")
+		}
+		id := binary.BigEndian.Uint32(header)
+		kind := header[4]
+		payload := make([]byte, binary.BigEndian.Uint16(header[5:]))
+		_, err = io.ReadFull(conn, payload)
+		if err != nil {
+			return errors.Wrap(err, "[ ServeStreams ] failed to read frame payload")
+		}
+
+		s, ok := streams[id]
+		switch kind {
+		case frameData:
+			if !ok {
+				s = newIncomingStream()
+				streams[id] = s
+				go handle(s)
+			}
+			s.push(payload)
+		case frameClose:
+			if ok {
+				s.finish()
+				delete(streams, id)
+			}
+		default:
+			return errors.Errorf("[ ServeStreams ] unknown frame type %d", kind)
+		}
+	}
+}
+
+// incomingStream buffers data of stream until it's read by handler, so slow handler doesn't block other streams.
+type incomingStream struct {
+	lock sync.Mutex
+	cond *sync.Cond
+	buf  bytes.Buffer
+	done bool
+}
+
+func newIncomingStream() *incomingStream {
+	s := &incomingStream{}
+	s.cond = sync.NewCond(&s.lock)
+	return s
+}
+
+func (s *incomingStream) Read(b []byte) (int, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for s.buf.Len() == 0 && !s.done {
+		s.cond.Wait()
+	}
+	if s.buf.Len() == 0 {
+		return 0, io.EOF
+	}
+	return s.buf.Read(b)
+}
+
+func (s *incomingStream) push(b []byte) {
+	s.lock.Lock()
+	s.buf.Write(b)
+	s.lock.Unlock()
+	s.cond.Signal()
+}
+
+func (s *incomingStream) finish() {
+	s.lock.Lock()
+	s.done = true
+	s.lock.Unlock()
+	s.cond.Broadcast()
+}
@@ -0,0 +1,187 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package pool
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/network/utils"
+)
+
+// serve reads streams from conn and sends their content to returned channel.
+func serve(conn net.Conn) <-chan []byte {
+	received := make(chan []byte, 10)
+	go ServeStreams(conn, func(stream io.Reader) {
+		data, _ := ioutil.ReadAll(stream)
+		received <- data
+	})
+	return received
+}
+
+func TestSession_Streams(t *testing.T) {
+	local, remote := net.Pipe()
+	defer local.Close()
+	received := serve(remote)
+	s := newSession(local, 0)
+
+	small := []byte("small packet")
+	big := bytes.Repeat([]byte{42}, 5*maxFramePayload+1)
+
+	wg := sync.WaitGroup{}
+	for _, data := range [][]byte{big, small} {
+		wg.Add(1)
+		go func(data []byte) {
+			defer wg.Done()
+			st, err := s.open(context.Background())
+			require.NoError(t, err)
+			n, err := st.Write(data)
+			require.NoError(t, err)
+			require.Equal(t, len(data), n)
+			require.NoError(t, st.Close())
+		}(data)
+	}
+	wg.Wait()
+
+	var got [][]byte
+	for i := 0; i < 2; i++ {
+		select {
+		case data := <-received:
+			got = append(got, data)
+		case <-time.After(time.Second):
+			t.Fatal("stream isn't received")
+		}
+	}
+	require.ElementsMatch(t, [][]byte{big, small}, got)
+}
+
+func TestSession_MaxStreams(t *testing.T) {
+	local, remote := net.Pipe()
+	defer local.Close()
+	received := serve(remote)
+	s := newSession(local, 1)
+
+	first, err := s.open(context.Background())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = s.open(ctx)
+	require.Error(t, err)
+
+	_, err = first.Write([]byte{1})
+	require.NoError(t, err)
+	require.NoError(t, first.Close())
+	require.Equal(t, []byte{1}, <-received)
+
+	second, err := s.open(context.Background())
+	require.NoError(t, err)
+	require.NotEqual(t, first.id, second.id)
+
+	s.close()
+	_, err = s.open(context.Background())
+	require.Error(t, err)
+}
+
+func TestSession_PriorityStream(t *testing.T) {
+	local, remote := net.Pipe()
+	defer local.Close()
+	received := serve(remote)
+	s := newSession(local, 1)
+
+	regular, err := s.open(context.Background())
+	require.NoError(t, err)
+
+	// all slots are taken, but priority stream doesn't wait for them
+	ctx, cancel := context.WithTimeout(utils.ContextWithPriority(context.Background()), 10*time.Millisecond)
+	defer cancel()
+	priority, err := s.open(ctx)
+	require.NoError(t, err)
+	_, err = priority.Write([]byte{2})
+	require.NoError(t, err)
+	require.NoError(t, priority.Close())
+	require.Equal(t, []byte{2}, <-received)
+
+	// closing priority stream doesn't free slot of regular one
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = s.open(ctx)
+	require.Error(t, err)
+	require.NoError(t, regular.Close())
+
+	s.close()
+	_, err = s.open(utils.ContextWithPriority(context.Background()))
+	require.Error(t, err)
+}
+
+func TestWriteLock_Priority(t *testing.T) {
+	l := newWriteLock()
+	l.lock(false)
+
+	order := make(chan string, 2)
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		l.lock(false)
+		order <- "regular"
+		l.unlock()
+	}()
+	// let regular writer start waiting before priority one
+	time.Sleep(10 * time.Millisecond)
+	go func() {
+		defer wg.Done()
+		l.lock(true)
+		order <- "priority"
+		l.unlock()
+	}()
+	for waiting := 0; waiting == 0; time.Sleep(time.Millisecond) {
+		l.mutex.Lock()
+		waiting = l.priorityWaiting
+		l.mutex.Unlock()
+	}
+
+	l.unlock()
+	wg.Wait()
+	require.Equal(t, "priority", <-order)
+	require.Equal(t, "regular", <-order)
+}
+
+func TestStream_WriteDeadline(t *testing.T) {
+	local, remote := net.Pipe()
+	defer remote.Close()
+	s := newSession(local, 0)
+
+	// nobody reads remote side, so write can't complete
+	st, err := s.open(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, st.SetWriteDeadline(time.Now().Add(10*time.Millisecond)))
+	_, err = st.Write([]byte("data"))
+	require.Error(t, err)
+
+	_, err = st.Read(make([]byte, 1))
+	require.Equal(t, errWriteOnly, err)
+}
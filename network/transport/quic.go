@@ -63,7 +63,7 @@ func newQuicTransport(conn net.PacketConn, proxy relay.Proxy, publicAddress stri
 	return transport, nil
 }
 
-func (t *quicTransport) send(recvAddress string, data []byte) error {
+func (t *quicTransport) send(ctx context.Context, recvAddress string, data []byte) error {
 	conn, ok := t.connections[recvAddress]
 	var stream quic.Stream
 	var err error
@@ -128,16 +128,29 @@ func (t *quicTransport) handleAcceptedConnection(session quic.Session) {
 	stream, err := session.AcceptStream()
 	if err != nil {
 		log.Error(err, "[ handleAcceptedConnection ] failed to get a stream")
+		return
 	}
+	defer utils.CloseVerbose(stream)
 
-	msg, err := t.serializer.DeserializePacket(stream)
+	remoteAddress := session.RemoteAddr().String()
+	if t.guard.quarantined(remoteAddress) {
+		return
+	}
+
+	reader := &countingReader{Reader: stream}
+	msg, err := t.serializer.DeserializePacket(reader)
+	if err == nil {
+		err = validatePacket(msg)
+	}
 	if err != nil {
-		log.Error(err, "[ handleAcceptedConnection ] failed to deserialize a packet")
+		t.guard.malformed(remoteAddress, err)
+		return
 	}
+	size := reader.reset()
+	traffic.received(msg, remoteAddress, size)
+	capture.received(msg, remoteAddress, size)
 
 	go t.packetHandler.Handle(context.TODO(), msg)
-
-	utils.CloseVerbose(stream)
 }
 
 func createConnection(addr string) (quic.Session, quic.Stream, error) {
@@ -0,0 +1,98 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package transport
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/network/transport/host"
+)
+
+// maxRTTSamples limits number of samples kept per peer between takes.
+const maxRTTSamples = 1024
+
+type rttSamples struct {
+	node      core.RecordRef
+	durations []time.Duration
+}
+
+// rttAccounting collects round trip times of request/response exchanges of transports of the node per remote peer.
+// Samples include processing of request by peer, so minimum and median are closer to network round trip than maximum.
+type rttAccounting struct {
+	lock    sync.Mutex
+	samples map[string]*rttSamples
+}
+
+// rtt is shared by all transports of the process, like traffic.
+var rtt = newRTTAccounting()
+
+func newRTTAccounting() *rttAccounting {
+	return &rttAccounting{samples: make(map[string]*rttSamples)}
+}
+
+// TakeRTT returns round trip times observed by transports of the process since previous call sorted by address.
+func TakeRTT() []core.PeerRTT {
+	return rtt.take()
+}
+
+func (ra *rttAccounting) observe(h *host.Host, d time.Duration) {
+	address, node := peerOf(h)
+	if address == "" {
+		return
+	}
+
+	ra.lock.Lock()
+	defer ra.lock.Unlock()
+
+	samples, ok := ra.samples[address]
+	if !ok {
+		samples = &rttSamples{}
+		ra.samples[address] = samples
+	}
+	if node != (core.RecordRef{}) {
+		samples.node = node
+	}
+	if len(samples.durations) < maxRTTSamples {
+		samples.durations = append(samples.durations, d)
+	}
+}
+
+func (ra *rttAccounting) take() []core.PeerRTT {
+	ra.lock.Lock()
+	taken := ra.samples
+	ra.samples = make(map[string]*rttSamples)
+	ra.lock.Unlock()
+
+	result := make([]core.PeerRTT, 0, len(taken))
+	for address, samples := range taken {
+		durations := samples.durations
+		sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+		result = append(result, core.PeerRTT{
+			Node:    samples.node,
+			Address: address,
+			Samples: len(durations),
+			Min:     durations[0],
+			P50:     durations[(len(durations)-1)/2],
+			Max:     durations[len(durations)-1],
+		})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Address < result[j].Address })
+	return result
+}
@@ -0,0 +1,50 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package transport
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestRTTAccounting(t *testing.T) {
+	ra := newRTTAccounting()
+	ref := testutils.RandomRef()
+	peer, err := host.NewHostN("127.0.0.1:31337", ref)
+	require.NoError(t, err)
+
+	for _, d := range []time.Duration{5, 1, 3, 10} {
+		ra.observe(peer, d*time.Millisecond)
+	}
+	ra.observe(nil, time.Second)
+
+	require.Equal(t, []core.PeerRTT{{
+		Node:    ref,
+		Address: "127.0.0.1:31337",
+		Samples: 4,
+		Min:     time.Millisecond,
+		P50:     3 * time.Millisecond,
+		Max:     10 * time.Millisecond,
+	}}, ra.take())
+	require.Empty(t, ra.take())
+}
@@ -0,0 +1,141 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package transport
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/consensus/packets"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/network/transport/packet"
+	"github.com/insolar/insolar/network/utils"
+)
+
+const (
+	// consensusQuiet is a time after the last consensus packet while consensus is considered active.
+	consensusQuiet = 20 * time.Millisecond
+	// maxConsensusYield limits delay of capped classes yielding to consensus.
+	maxConsensusYield = time.Second
+)
+
+// lastConsensus is a time of the last consensus packet sent by any transport of the process in unix nanoseconds.
+// It's shared like traffic accounting, because consensus and other classes are sent by different transports.
+var lastConsensus int64
+
+func markConsensus(now time.Time) {
+	atomic.StoreInt64(&lastConsensus, now.UnixNano())
+}
+
+// packetClass returns traffic class of outgoing packet.
+func packetClass(ctx context.Context, p *packet.Packet) string {
+	if _, ok := p.Data.(packets.ConsensusPacket); ok {
+		return message.TrafficConsensus
+	}
+	return utils.TrafficClassFromContext(ctx)
+}
+
+// bucket is a token bucket refilled by rate bytes per second, burst is one second of traffic.
+type bucket struct {
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+// trafficShaper delays packets of traffic classes which exceed their bandwidth caps.
+type trafficShaper struct {
+	consensusPriority bool
+	now               func() time.Time
+
+	lock    sync.Mutex
+	buckets map[string]*bucket
+}
+
+func newTrafficShaper(caps map[string]int64, consensusPriority bool) *trafficShaper {
+	s := &trafficShaper{
+		consensusPriority: consensusPriority,
+		now:               time.Now,
+		buckets:           map[string]*bucket{},
+	}
+	now := s.now()
+	for class, rate := range caps {
+		if rate <= 0 {
+			continue
+		}
+		// config keys are lowercased by viper
+		s.buckets[strings.ToLower(class)] = &bucket{rate: float64(rate), tokens: float64(rate), last: now}
+	}
+	return s
+}
+
+// delay reserves size bytes of class and returns time to wait before sending them.
+// Classes without cap are never delayed, capped classes yield to consensus if it has priority.
+func (s *trafficShaper) delay(class string, size int) time.Duration {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	b, ok := s.buckets[class]
+	if !ok {
+		return 0
+	}
+	now := s.now()
+
+	var wait time.Duration
+	if s.consensusPriority && class != message.TrafficConsensus {
+		idle := time.Unix(0, atomic.LoadInt64(&lastConsensus)).Add(consensusQuiet)
+		if idle.After(now) {
+			wait = idle.Sub(now)
+			if wait > maxConsensusYield {
+				wait = maxConsensusYield
+			}
+		}
+	}
+
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.rate {
+		b.tokens = b.rate
+	}
+	b.last = now
+	b.tokens -= float64(size)
+	if b.tokens < 0 {
+		if d := time.Duration(-b.tokens / b.rate * float64(time.Second)); d > wait {
+			wait = d
+		}
+	}
+	return wait
+}
+
+// wait blocks until size bytes of class may be sent.
+func (s *trafficShaper) wait(ctx context.Context, class string, size int) error {
+	d := s.delay(class, size)
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return errors.Wrapf(ctx.Err(), "[ wait ] failed to wait for bandwidth of %s traffic", class)
+	}
+}
@@ -0,0 +1,53 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package transport
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core/message"
+)
+
+func TestTrafficShaper(t *testing.T) {
+	s := newTrafficShaper(map[string]int64{"Replication": 1000, "contract": 0}, false)
+	now := time.Now()
+	s.now = func() time.Time { return now }
+
+	require.Zero(t, s.delay(message.TrafficReplication, 1000), "burst of one second is allowed")
+	require.Equal(t, 500*time.Millisecond, s.delay(message.TrafficReplication, 500))
+	require.Zero(t, s.delay(message.TrafficContract, 1000000), "zero cap doesn't limit class")
+	require.Zero(t, s.delay(message.TrafficConsensus, 1000000), "class without cap isn't limited")
+
+	now = now.Add(2 * time.Second)
+	require.Zero(t, s.delay(message.TrafficReplication, 1000), "bucket is refilled")
+}
+
+func TestTrafficShaper_ConsensusPriority(t *testing.T) {
+	s := newTrafficShaper(map[string]int64{"replication": 1000000}, true)
+	now := time.Now()
+	s.now = func() time.Time { return now }
+
+	markConsensus(now.Add(-consensusQuiet))
+	require.Zero(t, s.delay(message.TrafficReplication, 1), "consensus is idle")
+
+	markConsensus(now)
+	require.Equal(t, consensusQuiet, s.delay(message.TrafficReplication, 1), "capped class yields to consensus")
+	require.Zero(t, s.delay(message.TrafficContract, 1), "classes without cap don't yield")
+}
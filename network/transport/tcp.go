@@ -21,7 +21,9 @@ import (
 	"context"
 	"io"
 	"net"
+	"time"
 
+	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/metrics"
 	"github.com/pkg/errors"
 
@@ -30,30 +32,42 @@ import (
 	"github.com/insolar/insolar/network/transport/pool"
 	"github.com/insolar/insolar/network/transport/relay"
 	"github.com/insolar/insolar/network/utils"
+	"github.com/insolar/insolar/utils/backoff"
 )
 
 type tcpTransport struct {
 	baseTransport
 
-	pool     pool.ConnectionPool
-	listener net.Listener
-	addr     string
+	pool          pool.ConnectionPool
+	listener      net.Listener
+	addr          string
+	streamTimeout time.Duration
 }
 
-func newTCPTransport(addr string, proxy relay.Proxy, publicAddress string) (*tcpTransport, error) {
+func newTCPTransport(addr string, proxy relay.Proxy, publicAddress string, cfg configuration.Transport) (*tcpTransport, error) {
+	reconnect := pool.ReconnectPolicy{
+		Attempts: cfg.ReconnectAttempts,
+		Backoff: &backoff.Backoff{
+			Factor: cfg.ReconnectBackoff.Factor,
+			Jitter: cfg.ReconnectBackoff.Jitter,
+			Min:    cfg.ReconnectBackoff.Min,
+			Max:    cfg.ReconnectBackoff.Max,
+		},
+	}
 	transport := &tcpTransport{
 		baseTransport: newBaseTransport(proxy, publicAddress),
 		addr:          addr,
-		pool:          pool.NewConnectionPool(&tcpConnectionFactory{}),
+		pool:          pool.NewConnectionPool(&tcpConnectionFactory{keepAlive: cfg.KeepAlive}, reconnect, cfg.MaxStreams),
+		streamTimeout: cfg.StreamTimeout,
 	}
 
 	transport.sendFunc = transport.send
+	transport.shaper = newTrafficShaper(cfg.Bandwidth, cfg.ConsensusPriority)
 
 	return transport, nil
 }
 
-func (t *tcpTransport) send(address string, data []byte) error {
-	ctx := context.Background()
+func (t *tcpTransport) send(ctx context.Context, address string, data []byte) error {
 	logger := inslogger.FromContext(ctx)
 
 	addr, err := net.ResolveTCPAddr("tcp", address)
@@ -61,14 +75,14 @@ func (t *tcpTransport) send(address string, data []byte) error {
 		return errors.Wrap(err, "[ send ] Failed to resolve net address")
 	}
 
-	conn, err := t.pool.GetConnection(ctx, addr)
+	stream, err := t.pool.GetConnection(ctx, addr)
 	if err != nil {
 		return errors.Wrap(err, "[ send ] Failed to get connection")
 	}
 
 	logger.Debug("[ send ] len = ", len(data))
 
-	n, err := conn.Write(data)
+	n, err := t.write(stream, data)
 
 	if err != nil {
 		// All this to check is error EPIPE
@@ -77,11 +91,11 @@ func (t *tcpTransport) send(address string, data []byte) error {
 		// 	case *os.SyscallError:
 		// 		if realNetErr.Err == syscall.EPIPE {
 		t.pool.CloseConnection(ctx, addr)
-		conn, err = t.pool.GetConnection(ctx, addr)
+		stream, err = t.pool.GetConnection(ctx, addr)
 		if err != nil {
 			return errors.Wrap(err, "[ send ] Failed to get connection")
 		}
-		n, err = conn.Write(data)
+		n, err = t.write(stream, data)
 		// 		}
 		// 	}
 		// }
@@ -94,6 +108,23 @@ func (t *tcpTransport) send(address string, data []byte) error {
 	return errors.Wrap(err, "[ send ] Failed to write data")
 }
 
+// write sends data over stream and closes it. Stream deadline doesn't let stuck peer hold sender forever.
+func (t *tcpTransport) write(stream net.Conn, data []byte) (int, error) {
+	if t.streamTimeout > 0 {
+		err := stream.SetWriteDeadline(time.Now().Add(t.streamTimeout))
+		if err != nil {
+			utils.CloseVerbose(stream)
+			return 0, err
+		}
+	}
+	n, err := stream.Write(data)
+	if err != nil {
+		utils.CloseVerbose(stream)
+		return n, err
+	}
+	return n, stream.Close()
+}
+
 func (t *tcpTransport) prepareListen() error {
 	t.mutex.Lock()
 	defer t.mutex.Unlock()
@@ -150,28 +181,59 @@ func (t *tcpTransport) Stop() {
 func (t *tcpTransport) handleAcceptedConnection(conn net.Conn) {
 	defer utils.CloseVerbose(conn)
 
+	remoteAddress := conn.RemoteAddr().String()
+	if t.guard.quarantined(remoteAddress) {
+		log.Debugf("[ handleAcceptedConnection ] Connection from quarantined peer %s is closed", remoteAddress)
+		return
+	}
+
+	err := pool.ServeStreams(conn, func(stream io.Reader) {
+		t.handleStream(conn, stream)
+	})
+	log.Debugf("[ handleAcceptedConnection ] Connection from %s is closed: %s", remoteAddress, err)
+}
+
+// handleStream reads packets from stream until it's closed by peer.
+func (t *tcpTransport) handleStream(conn net.Conn, stream io.Reader) {
+	remoteAddress := conn.RemoteAddr().String()
+	reader := &countingReader{Reader: stream}
 	for {
-		msg, err := t.serializer.DeserializePacket(conn)
+		msg, err := t.serializer.DeserializePacket(reader)
+		size := reader.reset()
+		if err == nil {
+			err = validatePacket(msg)
+		}
 
 		if err != nil {
-			if err == io.EOF || err == io.ErrUnexpectedEOF {
-				log.Warn("[ handleAcceptedConnection ] Connection closed by peer")
+			cause := errors.Cause(err)
+			if cause == io.EOF {
 				return
 			}
+			if _, ok := cause.(net.Error); ok || cause == io.ErrUnexpectedEOF {
+				log.Warn("[ handleStream ] Connection closed by peer")
+				return
+			}
+			// connection can't be trusted after malformed packet, so it's closed with all its streams
+			t.guard.malformed(remoteAddress, err)
+			utils.CloseVerbose(conn)
+			return
+		}
 
-			log.Error("[ handleAcceptedConnection ] Failed to deserialize packet: ", err.Error())
-		} else {
-			ctx, logger := inslogger.WithTraceField(context.Background(), msg.TraceID)
-			logger.Debug("[ handleAcceptedConnection ] Handling packet: ", msg.RequestID)
+		ctx, logger := inslogger.WithTraceField(context.Background(), msg.TraceID)
+		logger.Debug("[ handleStream ] Handling packet: ", msg.RequestID)
+		traffic.received(msg, t.getRemoteAddress(conn), size)
+		capture.received(msg, t.getRemoteAddress(conn), size)
 
-			go t.packetHandler.Handle(ctx, msg)
-		}
+		go t.packetHandler.Handle(ctx, msg)
 	}
 }
 
-type tcpConnectionFactory struct{}
+type tcpConnectionFactory struct {
+	// keepAlive is a period of keep-alive probes, zero means system default
+	keepAlive time.Duration
+}
 
-func (*tcpConnectionFactory) CreateConnection(ctx context.Context, address net.Addr) (net.Conn, error) {
+func (f *tcpConnectionFactory) CreateConnection(ctx context.Context, address net.Addr) (net.Conn, error) {
 	logger := inslogger.FromContext(ctx)
 	tcpAddress, ok := address.(*net.TCPAddr)
 	if !ok {
@@ -188,6 +250,12 @@ func (*tcpConnectionFactory) CreateConnection(ctx context.Context, address net.A
 	if err != nil {
 		logger.Error("[ createConnection ] Failed to set keep alive")
 	}
+	if f.keepAlive > 0 {
+		err = conn.SetKeepAlivePeriod(f.keepAlive)
+		if err != nil {
+			logger.Errorln("[ createConnection ] Failed to set keep alive period: ", err.Error())
+		}
+	}
 
 	err = conn.SetNoDelay(true)
 	if err != nil {
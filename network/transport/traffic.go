@@ -0,0 +1,144 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package transport
+
+import (
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/insolar/insolar/consensus/packets"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/metrics"
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/insolar/insolar/network/transport/packet"
+)
+
+type trafficKey struct {
+	address    string
+	packetType string
+}
+
+// trafficAccounting aggregates bytes sent and received by transports of the node per remote peer and packet type.
+type trafficAccounting struct {
+	lock     sync.Mutex
+	counters map[trafficKey]*core.PeerTraffic
+}
+
+// traffic is shared by all transports of the process, like metrics.
+var traffic = newTrafficAccounting()
+
+func newTrafficAccounting() *trafficAccounting {
+	return &trafficAccounting{counters: make(map[trafficKey]*core.PeerTraffic)}
+}
+
+// Traffic returns traffic of all transports of the process sorted by address and packet type.
+func Traffic() []core.PeerTraffic {
+	return traffic.get()
+}
+
+func (ta *trafficAccounting) sent(p *packet.Packet, size int) {
+	address, node := peerOf(p.Receiver)
+	ta.add(address, node, packetType(p), size, true)
+}
+
+func (ta *trafficAccounting) received(p *packet.Packet, remoteAddress string, size int) {
+	address, node := peerOf(p.Sender)
+	if address == "" {
+		address = remoteAddress
+	}
+	ta.add(address, node, packetType(p), size, false)
+}
+
+func (ta *trafficAccounting) add(address string, node core.RecordRef, packetType string, size int, sent bool) {
+	ta.lock.Lock()
+	key := trafficKey{address: address, packetType: packetType}
+	counter, ok := ta.counters[key]
+	if !ok {
+		counter = &core.PeerTraffic{Address: address, Type: packetType}
+		ta.counters[key] = counter
+	}
+	if node != (core.RecordRef{}) {
+		counter.Node = node
+	}
+	if sent {
+		counter.SentBytes += uint64(size)
+		counter.SentPackets++
+	} else {
+		counter.RecvBytes += uint64(size)
+		counter.RecvPackets++
+	}
+	ta.lock.Unlock()
+
+	if sent {
+		metrics.NetworkPeerSentBytes.WithLabelValues(address, packetType).Add(float64(size))
+	} else {
+		metrics.NetworkPeerRecvBytes.WithLabelValues(address, packetType).Add(float64(size))
+		metrics.NetworkRecvSize.Add(float64(size))
+	}
+}
+
+func (ta *trafficAccounting) get() []core.PeerTraffic {
+	ta.lock.Lock()
+	result := make([]core.PeerTraffic, 0, len(ta.counters))
+	for _, counter := range ta.counters {
+		result = append(result, *counter)
+	}
+	ta.lock.Unlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Address != result[j].Address {
+			return result[i].Address < result[j].Address
+		}
+		return result[i].Type < result[j].Type
+	})
+	return result
+}
+
+func peerOf(h *host.Host) (string, core.RecordRef) {
+	if h == nil || h.Address == nil {
+		return "", core.RecordRef{}
+	}
+	return h.Address.String(), h.NodeID
+}
+
+// packetType returns phase of consensus packets and transport type of other packets.
+func packetType(p *packet.Packet) string {
+	if data, ok := p.Data.(packets.ConsensusPacket); ok {
+		return data.GetType().String()
+	}
+	return p.Type.String()
+}
+
+// countingReader counts bytes read from underlying reader.
+type countingReader struct {
+	io.Reader
+	n int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.n += n
+	return n, err
+}
+
+// reset returns number of bytes read since previous call.
+func (r *countingReader) reset() int {
+	n := r.n
+	r.n = 0
+	return n
+}
@@ -0,0 +1,71 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package transport
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/consensus/packets"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/insolar/insolar/network/transport/packet"
+	"github.com/insolar/insolar/network/transport/packet/types"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestTrafficAccounting(t *testing.T) {
+	ta := newTrafficAccounting()
+	ref := testutils.RandomRef()
+	peer, err := host.NewHostN("127.0.0.1:31337", ref)
+	require.NoError(t, err)
+
+	ta.sent(&packet.Packet{Receiver: peer, Type: types.RPC}, 100)
+	ta.sent(&packet.Packet{Receiver: peer, Type: types.RPC}, 50)
+	ta.received(&packet.Packet{Sender: peer, Type: types.RPC}, "127.0.0.1", 10)
+	ta.received(&packet.Packet{Sender: peer, Type: types.Ping}, "127.0.0.1", 5)
+	// consensus packets have no sender host
+	ta.received(&packet.Packet{Data: packets.NewPhase2Packet(core.FirstPulseNumber)}, "127.0.0.2:31338", 7)
+
+	require.Equal(t, []core.PeerTraffic{
+		{
+			Node: ref, Address: "127.0.0.1:31337", Type: types.Ping.String(),
+			RecvBytes: 5, RecvPackets: 1,
+		},
+		{
+			Node: ref, Address: "127.0.0.1:31337", Type: types.RPC.String(),
+			SentBytes: 150, SentPackets: 2, RecvBytes: 10, RecvPackets: 1,
+		},
+		{
+			Address: "127.0.0.2:31338", Type: packets.Phase2.String(),
+			RecvBytes: 7, RecvPackets: 1,
+		},
+	}, ta.get())
+}
+
+func TestCountingReader(t *testing.T) {
+	r := &countingReader{Reader: bytes.NewReader(make([]byte, 42))}
+	_, err := r.Read(make([]byte, 40))
+	require.NoError(t, err)
+	require.Equal(t, 40, r.reset())
+	_, err = ioutil.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, 2, r.reset())
+}
@@ -75,7 +75,7 @@ func NewTransport(cfg configuration.Transport, proxy relay.Proxy) (Transport, er
 		// TODO: little hack: It's better to change interface for NewConnection
 		utils.CloseVerbose(conn)
 
-		return newTCPTransport(conn.LocalAddr().String(), proxy, publicAddress)
+		return newTCPTransport(conn.LocalAddr().String(), proxy, publicAddress, cfg)
 	case "PURE_UDP":
 		// TODO: not little hack: @AndreyBronin rewrite all this mess, please!
 		localAddress := conn.LocalAddr().String()
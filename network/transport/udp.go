@@ -71,7 +71,7 @@ func newUDPTransport(addr string, proxy relay.Proxy, publicAddress string) (*udp
 	return transport, nil
 }
 
-func (t *udpTransport) send(recvAddress string, data []byte) error {
+func (t *udpTransport) send(ctx context.Context, recvAddress string, data []byte) error {
 	log.Debug("Sending PURE_UDP request")
 	if len(data) > udpMaxPacketSize {
 		return errors.New(fmt.Sprintf("udpTransport.send: too big input data. Maximum: %d. Current: %d",
@@ -145,13 +145,19 @@ func (t *udpTransport) Stop() {
 }
 
 func (t *udpTransport) handleAcceptedConnection(data []byte, addr net.Addr) {
+	if t.guard.quarantined(addr.String()) {
+		return
+	}
+
 	r := bytes.NewReader(data)
 	msg, err := t.serializer.DeserializePacket(r)
 	if err != nil {
-		log.Error("[ handleAcceptedConnection ] ", err)
+		t.guard.malformed(addr.String(), err)
 		return
 	}
 	log.Debug("[ handleAcceptedConnection ] Packet processed. size: ", len(data), ". Address: ", addr)
+	traffic.received(msg, addr.String(), len(data))
+	capture.received(msg, addr.String(), len(data))
 
 	go t.packetHandler.Handle(context.TODO(), msg)
 }
@@ -18,6 +18,7 @@
 package utils
 
 import (
+	"context"
 	"hash/crc32"
 	"io"
 	"sync"
@@ -78,3 +79,29 @@ func CloseVerbose(closer io.Closer) {
 		log.Errorf("[ CloseVerbose ] Failed to close: %s", err.Error())
 	}
 }
+
+type priorityKey struct{}
+
+// ContextWithPriority marks context of outgoing packet as pulse-critical, transport sends such packets in priority lane.
+func ContextWithPriority(ctx context.Context) context.Context {
+	return context.WithValue(ctx, priorityKey{}, true)
+}
+
+// PriorityFromContext returns true if context is marked by ContextWithPriority.
+func PriorityFromContext(ctx context.Context) bool {
+	priority, _ := ctx.Value(priorityKey{}).(bool)
+	return priority
+}
+
+type trafficClassKey struct{}
+
+// ContextWithTrafficClass marks context of outgoing packet with traffic class, transport shapes bandwidth by classes.
+func ContextWithTrafficClass(ctx context.Context, class string) context.Context {
+	return context.WithValue(ctx, trafficClassKey{}, class)
+}
+
+// TrafficClassFromContext returns traffic class set by ContextWithTrafficClass.
+func TrafficClassFromContext(ctx context.Context) string {
+	class, _ := ctx.Value(trafficClassKey{}).(string)
+	return class
+}
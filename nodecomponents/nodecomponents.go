@@ -0,0 +1,168 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+// Package nodecomponents creates components which every insolar node runs: network, ledger, message bus,
+// logic runner and contract requester with their dependencies. It is shared by insolard and embedded node,
+// so both binaries are wired the same way.
+package nodecomponents
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/certificate"
+	"github.com/insolar/insolar/component"
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/contractrequester"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/delegationtoken"
+	"github.com/insolar/insolar/genesisdataprovider"
+	"github.com/insolar/insolar/instrumentation/nodeload"
+	"github.com/insolar/insolar/instrumentation/notifier"
+	"github.com/insolar/insolar/instrumentation/timeline"
+	"github.com/insolar/insolar/ledger"
+	"github.com/insolar/insolar/ledger/storage"
+	"github.com/insolar/insolar/logicrunner"
+	"github.com/insolar/insolar/messagebus"
+	"github.com/insolar/insolar/netparams"
+	"github.com/insolar/insolar/network/clockskew"
+	"github.com/insolar/insolar/network/membership"
+	"github.com/insolar/insolar/network/nodenetwork"
+	"github.com/insolar/insolar/network/servicenetwork"
+	"github.com/insolar/insolar/network/state"
+	"github.com/insolar/insolar/networkcoordinator"
+	"github.com/insolar/insolar/pulsar"
+	"github.com/insolar/insolar/pulsar/entropygenerator"
+)
+
+// Register creates common node components. Network components are registered in cm, the rest are returned and
+// must be injected by caller together with node specific components.
+func Register(
+	ctx context.Context,
+	cfg configuration.Configuration,
+	cm *component.Manager,
+	cryptographyService core.CryptographyService,
+	platformCryptographyScheme core.PlatformCryptographyScheme,
+	keyStore core.KeyStore,
+	keyProcessor core.KeyProcessor,
+	certManager *certificate.CertificateManager,
+	isGenesis bool,
+) ([]interface{}, error) {
+	cert := certManager.GetCertificate()
+
+	nodeNetwork, err := nodenetwork.NewNodeNetwork(cfg.Host, cert)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ nodecomponents.Register ] failed to start NodeNetwork")
+	}
+
+	if role := cert.GetRole(); role == core.StaticRoleAPIGateway || role == core.StaticRoleObserver {
+		// API gateways and observers never execute contracts, calls are routed to virtual nodes
+		cfg.LogicRunner.BuiltIn = nil
+		cfg.LogicRunner.GoPlugin = nil
+	}
+	logicRunner, err := logicrunner.NewLogicRunner(&cfg.LogicRunner)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ nodecomponents.Register ] failed to start LogicRunner")
+	}
+
+	nw, err := servicenetwork.NewServiceNetwork(cfg, cm, isGenesis)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ nodecomponents.Register ] failed to start Network")
+	}
+
+	loadReporter := nodeload.NewReporter(cfg.Ledger.Storage.DataDirectory)
+	nw.SetNodeLoadReporter(loadReporter)
+
+	quotaMonitor := storage.NewQuotaMonitor(cfg.Ledger.Storage)
+	nw.SetStorageMonitor(quotaMonitor)
+	loadShedder := nodeload.NewShedder(cfg.LoadShedding)
+
+	notifierComponent := notifier.New(cfg.Notifier)
+	nw.SetNotifier(notifierComponent)
+
+	membershipWatcher, err := membership.NewWatcher(cfg.Membership)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ nodecomponents.Register ] failed to start membership Watcher")
+	}
+
+	messageBus, err := messagebus.NewMessageBus(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ nodecomponents.Register ] failed to start MessageBus")
+	}
+
+	contractRequester, err := contractrequester.New(cfg.Requester)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ nodecomponents.Register ] failed to start ContractRequester")
+	}
+
+	genesisDataProvider, err := genesisdataprovider.New()
+	if err != nil {
+		return nil, errors.Wrap(err, "[ nodecomponents.Register ] failed to start GenesisDataProvider")
+	}
+
+	networkSwitcher, err := state.NewNetworkSwitcher()
+	if err != nil {
+		return nil, errors.Wrap(err, "[ nodecomponents.Register ] failed to start NetworkSwitcher")
+	}
+
+	networkCoordinator, err := networkcoordinator.New()
+	if err != nil {
+		return nil, errors.Wrap(err, "[ nodecomponents.Register ] failed to start NetworkCoordinator")
+	}
+
+	// move to logic runner ??
+	err = logicRunner.OnPulse(ctx, *pulsar.NewPulse(cfg.Pulsar.NumberDelta, 0, &entropygenerator.StandardEntropyGenerator{}))
+	if err != nil {
+		return nil, errors.Wrap(err, "[ nodecomponents.Register ] failed init pulse for LogicRunner")
+	}
+
+	cm.Register(
+		core.NewTerminationHandler(),
+		platformCryptographyScheme,
+		keyStore,
+		cryptographyService,
+		keyProcessor,
+		certManager,
+		nodeNetwork,
+		clockskew.NewMonitor(cfg.ClockSkew),
+		timeline.NewJournal(cfg.Timeline),
+		nw,
+	)
+
+	components := ledger.GetLedgerComponents(cfg.Ledger, cert)
+	components = append(components, []interface{}{
+		messageBus,
+		contractRequester,
+		&ledger.Ledger{}, // TODO: remove me with cmOld
+		logicRunner,
+		delegationtoken.NewDelegationTokenFactory(),
+		messagebus.NewParcelFactory(),
+		genesisDataProvider,
+		storage.NewDisputeStorage(),
+		netparams.New(),
+		networkSwitcher,
+		networkCoordinator,
+		loadReporter,
+		quotaMonitor,
+		loadShedder,
+		notifierComponent,
+		membershipWatcher,
+		cryptographyService,
+	}...)
+
+	return components, nil
+}
@@ -54,6 +54,10 @@ func (pcs *platformCryptographyScheme) Verifier(publicKey crypto.PublicKey) core
 	return pcs.SignProvider.Verify(publicKey)
 }
 
+func (pcs *platformCryptographyScheme) BatchVerifier(workers int) core.BatchVerifier {
+	return pcs.SignProvider.BatchVerify(workers)
+}
+
 func NewPlatformCryptographyScheme() core.PlatformCryptographyScheme {
 	platformCryptographyScheme := &platformCryptographyScheme{}
 
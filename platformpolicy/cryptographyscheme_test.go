@@ -17,9 +17,12 @@
 package platformpolicy
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
 )
 
 func TestNewPlatformPolicy(t *testing.T) {
@@ -31,3 +34,36 @@ func TestNewPlatformPolicy(t *testing.T) {
 	require.NotNil(t, pcsImpl.HashProvider)
 	require.NotNil(t, pcsImpl.SignProvider)
 }
+
+func TestPlatformCryptographyScheme_BatchVerifier(t *testing.T) {
+	pcs := NewPlatformCryptographyScheme()
+	kp := NewKeyProcessor()
+
+	checks := make([]core.SignatureCheck, 0)
+	expected := make([]bool, 0)
+	for i := 0; i < 10; i++ {
+		privateKey, err := kp.GeneratePrivateKey()
+		require.NoError(t, err)
+		data := []byte(fmt.Sprintf("packet %d", i))
+		signature, err := pcs.Signer(privateKey).Sign(data)
+		require.NoError(t, err)
+
+		valid := i%3 != 0
+		if !valid {
+			data = []byte("forged")
+		}
+		checks = append(checks, core.SignatureCheck{
+			PublicKey: kp.ExtractPublicKey(privateKey),
+			Signature: *signature,
+			Data:      data,
+		})
+		expected = append(expected, valid)
+	}
+	checks = append(checks, core.SignatureCheck{PublicKey: "not a key", Data: []byte("data")})
+	expected = append(expected, false)
+
+	for _, workers := range []int{0, 1, 4, 100} {
+		require.Equal(t, expected, pcs.BatchVerifier(workers).VerifyBatch(checks), "workers: %d", workers)
+	}
+	require.Empty(t, pcs.BatchVerifier(4).VerifyBatch(nil))
+}
@@ -0,0 +1,156 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package platformpolicy
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/insolar/insolar/core"
+)
+
+var (
+	oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidCurveSecp256k1 = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+)
+
+// ethereumSignaturePrefix is prepended to signed data by wallets so signed message can't be a transaction.
+const ethereumSignaturePrefix = "\x19Ethereum Signed Message:\n"
+
+type publicKeyInfo struct {
+	Algorithm struct {
+		Algorithm  asn1.ObjectIdentifier
+		Parameters asn1.ObjectIdentifier
+	}
+	PublicKey asn1.BitString
+}
+
+// Secp256k1 returns curve used by keys of Bitcoin and Ethereum wallets.
+func Secp256k1() elliptic.Curve {
+	return btcec.S256()
+}
+
+// IsSecp256k1Key reports whether public key is a secp256k1 key of Ethereum-style wallet.
+func IsSecp256k1Key(publicKey crypto.PublicKey) bool {
+	ecdsaPublicKey, ok := publicKey.(*ecdsa.PublicKey)
+	return ok && ecdsaPublicKey.Curve == Secp256k1()
+}
+
+// ImportMemberPublicKey parses public key of member. Besides PEM keys of platform it accepts secp256k1 keys
+// either in PEM or in hex form wallets export them: 33 bytes compressed or 65 bytes uncompressed point
+// with optional "0x" prefix.
+func ImportMemberPublicKey(data []byte) (crypto.PublicKey, error) {
+	raw := strings.TrimPrefix(strings.TrimSpace(string(data)), "0x")
+	if decoded, err := hex.DecodeString(raw); err == nil {
+		return ImportSecp256k1PublicKey(decoded)
+	}
+
+	block, _ := pem.Decode(data)
+	if block != nil {
+		var info publicKeyInfo
+		_, err := asn1.Unmarshal(block.Bytes, &info)
+		if err == nil && info.Algorithm.Algorithm.Equal(oidPublicKeyECDSA) &&
+			info.Algorithm.Parameters.Equal(oidCurveSecp256k1) {
+			return ImportSecp256k1PublicKey(info.PublicKey.RightAlign())
+		}
+	}
+	return NewKeyProcessor().ImportPublicKeyPEM(data)
+}
+
+// ImportSecp256k1PublicKey parses secp256k1 public key in SEC 1 form, compressed or uncompressed.
+func ImportSecp256k1PublicKey(data []byte) (crypto.PublicKey, error) {
+	publicKey, err := btcec.ParsePubKey(data, btcec.S256())
+	if err != nil {
+		return nil, errors.Wrap(err, "[ ImportSecp256k1PublicKey ] invalid key")
+	}
+	return publicKey.ToECDSA(), nil
+}
+
+// EthereumAddress derives address of public key the way Ethereum wallets do: last 20 bytes of Keccak-256 hash
+// of uncompressed point, hex-encoded with EIP-55 mixed-case checksum.
+func EthereumAddress(publicKey crypto.PublicKey) (string, error) {
+	if !IsSecp256k1Key(publicKey) {
+		return "", errors.New("[ EthereumAddress ] key is not a secp256k1 key")
+	}
+	ecdsaPublicKey := publicKey.(*ecdsa.PublicKey)
+
+	point := make([]byte, 64)
+	x, y := ecdsaPublicKey.X.Bytes(), ecdsaPublicKey.Y.Bytes()
+	copy(point[32-len(x):32], x)
+	copy(point[64-len(y):], y)
+	address := hex.EncodeToString(keccak256(point)[12:])
+
+	checksum := keccak256([]byte(address))
+	result := []byte(address)
+	for i, c := range result {
+		nibble := checksum[i/2] >> 4
+		if i%2 == 1 {
+			nibble = checksum[i/2] & 0x0f
+		}
+		if c >= 'a' && nibble >= 8 {
+			result[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(result), nil
+}
+
+type ethereumVerifier struct {
+	publicKey *ecdsa.PublicKey
+}
+
+// NewEthereumVerifier creates verifier of signatures made by Ethereum wallets with personal_sign:
+// signature is r || s || v of Keccak-256 hash of data prefixed with "\x19Ethereum Signed Message:\n" and length.
+func NewEthereumVerifier(publicKey crypto.PublicKey) core.Verifier {
+	return &ethereumVerifier{publicKey: publicKey.(*ecdsa.PublicKey)}
+}
+
+func (v *ethereumVerifier) Verify(signature core.Signature, data []byte) bool {
+	sig := signature.Bytes()
+	// recovery id v is optional, key is known
+	if len(sig) != 64 && len(sig) != 65 {
+		return false
+	}
+	parsed := &btcec.Signature{
+		R: new(big.Int).SetBytes(sig[:32]),
+		S: new(big.Int).SetBytes(sig[32:64]),
+	}
+	return parsed.Verify(EthereumMessageHash(data), (*btcec.PublicKey)(v.publicKey))
+}
+
+// EthereumMessageHash returns hash of data wallets sign with personal_sign.
+func EthereumMessageHash(data []byte) []byte {
+	prefix := fmt.Sprintf("%s%d", ethereumSignaturePrefix, len(data))
+	return keccak256([]byte(prefix), data)
+}
+
+func keccak256(data ...[]byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, d := range data {
+		h.Write(d) // nolint: errcheck
+	}
+	return h.Sum(nil)
+}
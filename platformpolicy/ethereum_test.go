@@ -0,0 +1,90 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package platformpolicy
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+)
+
+// publicKeyOfOne is an uncompressed public key of private key 1, i.e. generator of secp256k1.
+const publicKeyOfOne = "0x04" +
+	"79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798" +
+	"483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8"
+
+func TestEthereumAddress(t *testing.T) {
+	publicKey, err := ImportMemberPublicKey([]byte(publicKeyOfOne))
+	require.NoError(t, err)
+	require.True(t, IsSecp256k1Key(publicKey))
+
+	address, err := EthereumAddress(publicKey)
+	require.NoError(t, err)
+	assert.Equal(t, "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf", address)
+
+	compressed, err := ImportMemberPublicKey([]byte("0x0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"))
+	require.NoError(t, err)
+	assert.Equal(t, publicKey, compressed)
+}
+
+func TestImportMemberPublicKey_PEM(t *testing.T) {
+	point, err := hex.DecodeString(publicKeyOfOne[2:])
+	require.NoError(t, err)
+	var info publicKeyInfo
+	info.Algorithm.Algorithm = oidPublicKeyECDSA
+	info.Algorithm.Parameters = oidCurveSecp256k1
+	info.PublicKey = asn1.BitString{Bytes: point, BitLength: len(point) * 8}
+	der, err := asn1.Marshal(info)
+	require.NoError(t, err)
+
+	publicKey, err := ImportMemberPublicKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	require.NoError(t, err)
+	assert.True(t, IsSecp256k1Key(publicKey))
+
+	ks := NewKeyProcessor()
+	privateKey, _ := ks.GeneratePrivateKey()
+	platformKey, err := ks.ExportPublicKeyPEM(ks.ExtractPublicKey(privateKey))
+	require.NoError(t, err)
+	publicKey, err = ImportMemberPublicKey(platformKey)
+	require.NoError(t, err)
+	assert.False(t, IsSecp256k1Key(publicKey))
+}
+
+func TestEthereumVerifier(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(Secp256k1(), rand.Reader)
+	require.NoError(t, err)
+	data := []byte("signed request")
+
+	r, s, err := ecdsa.Sign(rand.Reader, privateKey, EthereumMessageHash(data))
+	require.NoError(t, err)
+	signature := make([]byte, 65)
+	copy(signature[32-len(r.Bytes()):32], r.Bytes())
+	copy(signature[64-len(s.Bytes()):64], s.Bytes())
+	signature[64] = 27
+
+	verifier := NewEthereumVerifier(&privateKey.PublicKey)
+	assert.True(t, verifier.Verify(core.SignatureFromBytes(signature), data))
+	assert.True(t, verifier.Verify(core.SignatureFromBytes(signature[:64]), data), "recovery id is optional")
+	assert.False(t, verifier.Verify(core.SignatureFromBytes(signature), []byte("other request")))
+}
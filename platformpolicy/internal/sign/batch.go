@@ -0,0 +1,80 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package sign
+
+import (
+	"crypto"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/insolar/insolar/core"
+)
+
+// parallelVerifier verifies signatures of batch one by one, spreading them among workers.
+type parallelVerifier struct {
+	verifier func(crypto.PublicKey) core.Verifier
+	workers  int
+}
+
+// newParallelVerifier creates verifier with up to workers goroutines, non-positive workers means number of CPUs.
+func newParallelVerifier(verifier func(crypto.PublicKey) core.Verifier, workers int) *parallelVerifier {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	return &parallelVerifier{
+		verifier: verifier,
+		workers:  workers,
+	}
+}
+
+// VerifyBatch implements core.BatchVerifier.
+func (v *parallelVerifier) VerifyBatch(checks []core.SignatureCheck) []bool {
+	result := make([]bool, len(checks))
+	workers := v.workers
+	if workers > len(checks) {
+		workers = len(checks)
+	}
+
+	next := int64(-1)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(atomic.AddInt64(&next, 1))
+				if i >= len(checks) {
+					return
+				}
+				result[i] = v.verify(checks[i])
+			}
+		}()
+	}
+	wg.Wait()
+	return result
+}
+
+func (v *parallelVerifier) verify(check core.SignatureCheck) (valid bool) {
+	// Conversion of public key panics if the key is of another algorithm, the check is just invalid then.
+	defer func() {
+		if recover() != nil {
+			valid = false
+		}
+	}()
+	return v.verifier(check.PublicKey).Verify(check.Signature, check.Data)
+}
@@ -25,4 +25,5 @@ import (
 type AlgorithmProvider interface {
 	Sign(crypto.PrivateKey) core.Signer
 	Verify(crypto.PublicKey) core.Verifier
+	BatchVerify(workers int) core.BatchVerifier
 }
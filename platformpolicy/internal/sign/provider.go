@@ -44,3 +44,8 @@ func (p *ecdsaProvider) Verify(publicKey crypto.PublicKey) core.Verifier {
 		hasher:    p.HashProvider.Hash512bits(),
 	}
 }
+
+// BatchVerify returns parallel verifier, ECDSA signatures can't be verified in a single batch operation.
+func (p *ecdsaProvider) BatchVerify(workers int) core.BatchVerifier {
+	return newParallelVerifier(p.Verify, workers)
+}
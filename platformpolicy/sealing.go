@@ -0,0 +1,99 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package platformpolicy
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+// SealToKey encrypts data so only owner of private key of ECDSA public key can decrypt it (ECIES).
+// Shared secret of ephemeral key and public key derives AES-256-GCM key, sealed data is
+// uncompressed ephemeral public key followed by nonce and ciphertext.
+func SealToKey(publicKey crypto.PublicKey, data []byte) ([]byte, error) {
+	pub, ok := publicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.New("[ SealToKey ] public key is not ECDSA key")
+	}
+
+	ephemeral, err := ecdsa.GenerateKey(pub.Curve, rand.Reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ SealToKey ] failed to generate ephemeral key")
+	}
+	aead, err := sealingCipher(pub.Curve, pub.X, pub.Y, ephemeral.D.Bytes())
+	if err != nil {
+		return nil, errors.Wrap(err, "[ SealToKey ]")
+	}
+
+	sealed := elliptic.Marshal(pub.Curve, ephemeral.X, ephemeral.Y)
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "[ SealToKey ] failed to generate nonce")
+	}
+	sealed = append(sealed, nonce...)
+	return aead.Seal(sealed, nonce, data, nil), nil
+}
+
+// OpenWithKey decrypts data sealed by SealToKey to public key of private key.
+func OpenWithKey(privateKey crypto.PrivateKey, sealed []byte) ([]byte, error) {
+	priv, ok := privateKey.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("[ OpenWithKey ] private key is not ECDSA key")
+	}
+
+	pointSize := 1 + 2*((priv.Curve.Params().BitSize+7)/8)
+	if len(sealed) < pointSize {
+		return nil, errors.New("[ OpenWithKey ] sealed data is too short")
+	}
+	x, y := elliptic.Unmarshal(priv.Curve, sealed[:pointSize])
+	if x == nil {
+		return nil, errors.New("[ OpenWithKey ] invalid ephemeral key")
+	}
+	aead, err := sealingCipher(priv.Curve, x, y, priv.D.Bytes())
+	if err != nil {
+		return nil, errors.Wrap(err, "[ OpenWithKey ]")
+	}
+
+	sealed = sealed[pointSize:]
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("[ OpenWithKey ] sealed data is too short")
+	}
+	data, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ OpenWithKey ] failed to decrypt")
+	}
+	return data, nil
+}
+
+// sealingCipher derives AES-256-GCM cipher from shared secret of curve point and scalar.
+func sealingCipher(curve elliptic.Curve, x, y *big.Int, scalar []byte) (cipher.AEAD, error) {
+	sx, _ := curve.ScalarMult(x, y, scalar)
+	key := sha256.Sum256(sx.Bytes())
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create cipher")
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,56 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package platformpolicy
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSealToKey(t *testing.T) {
+	for name, curve := range map[string]elliptic.Curve{"P256": elliptic.P256(), "secp256k1": Secp256k1()} {
+		t.Run(name, func(t *testing.T) {
+			key, err := ecdsa.GenerateKey(curve, rand.Reader)
+			require.NoError(t, err)
+			other, err := ecdsa.GenerateKey(curve, rand.Reader)
+			require.NoError(t, err)
+			data := []byte("data key")
+
+			sealed, err := SealToKey(&key.PublicKey, data)
+			require.NoError(t, err)
+			require.NotContains(t, string(sealed), string(data))
+
+			opened, err := OpenWithKey(key, sealed)
+			require.NoError(t, err)
+			require.Equal(t, data, opened)
+
+			_, err = OpenWithKey(other, sealed)
+			require.Error(t, err)
+
+			sealed[len(sealed)-1] ^= 1
+			_, err = OpenWithKey(key, sealed)
+			require.Error(t, err)
+
+			_, err = OpenWithKey(key, sealed[:10])
+			require.Error(t, err)
+		})
+	}
+}
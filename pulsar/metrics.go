@@ -19,6 +19,8 @@ package pulsar
 import (
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
+
+	"github.com/insolar/insolar/instrumentation/insmetrics"
 )
 
 var (
@@ -26,7 +28,7 @@ var (
 )
 
 func init() {
-	err := view.Register(
+	err := insmetrics.Register(
 		&view.View{
 			Name:        statPulseGenerated.Name(),
 			Description: statPulseGenerated.Description(),
@@ -24,6 +24,7 @@ import (
 	"net/rpc"
 	"runtime/debug"
 	"sync"
+	"time"
 
 	"github.com/insolar/insolar/certificate"
 	"github.com/insolar/insolar/instrumentation/inslogger"
@@ -74,6 +75,8 @@ type Pulsar struct {
 
 	ProcessingPulseNumber core.PulseNumber
 
+	duration pulseDuration
+
 	lastPulseLock sync.RWMutex
 	lastPulse     *core.Pulse
 
@@ -321,6 +324,7 @@ func (currentPulsar *Pulsar) StartConsensusProcess(ctx context.Context, pulseNum
 		return err
 	}
 	currentPulsar.ProcessingPulseNumber = pulseNumber
+	currentPulsar.duration.startRound(time.Now())
 
 	inslog := inslogger.FromContext(ctx)
 
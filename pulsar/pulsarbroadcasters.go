@@ -257,12 +257,13 @@ func (currentPulsar *Pulsar) sendPulseToNodesAndPulsars(ctx context.Context) {
 		return
 	}
 
+	delta := currentPulsar.duration.next(currentPulsar.Config, time.Now())
 	currentPulsar.currentSlotSenderConfirmationsLock.RLock()
 	pulseForSending := core.Pulse{
 		PulseNumber:      currentPulsar.ProcessingPulseNumber,
 		Entropy:          *currentPulsar.GetCurrentSlotEntropy(),
 		Signs:            currentPulsar.CurrentSlotSenderConfirmations,
-		NextPulseNumber:  currentPulsar.ProcessingPulseNumber + core.PulseNumber(currentPulsar.Config.NumberDelta),
+		NextPulseNumber:  currentPulsar.ProcessingPulseNumber + core.PulseNumber(delta),
 		PrevPulseNumber:  currentPulsar.lastPulse.PulseNumber,
 		EpochPulseNumber: 1,
 		OriginID:         [16]byte{206, 41, 229, 190, 7, 240, 162, 155, 121, 245, 207, 56, 161, 67, 189, 0},
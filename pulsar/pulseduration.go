@@ -0,0 +1,84 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package pulsar
+
+import (
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/configuration"
+)
+
+const (
+	// pulse is prolonged if consensus round took more than this fraction of it
+	growThreshold = 0.5
+	// pulse is shortened if consensus round would take less than this fraction of shortened pulse
+	shrinkThreshold = 0.25
+)
+
+// pulseDuration adapts delta between pulse numbers, i.e. pulse duration in seconds, to duration of consensus rounds.
+type pulseDuration struct {
+	lock       sync.Mutex
+	delta      uint32
+	roundStart time.Time
+}
+
+func adaptive(cfg configuration.Pulsar) bool {
+	return cfg.MinNumberDelta != 0 && cfg.MaxNumberDelta != 0
+}
+
+// startRound marks start of consensus round.
+func (d *pulseDuration) startRound(now time.Time) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.roundStart = now
+}
+
+// next returns delta between numbers of current and next pulses, it's adjusted by duration
+// of the round finished at now within configured bounds.
+func (d *pulseDuration) next(cfg configuration.Pulsar, now time.Time) uint32 {
+	if !adaptive(cfg) {
+		return cfg.NumberDelta
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.delta == 0 {
+		d.delta = cfg.NumberDelta
+	}
+	if !d.roundStart.IsZero() {
+		round := now.Sub(d.roundStart)
+		switch {
+		case round > fraction(d.delta, growThreshold):
+			d.delta++
+		case d.delta > 1 && round < fraction(d.delta-1, shrinkThreshold):
+			d.delta--
+		}
+	}
+	if d.delta < cfg.MinNumberDelta {
+		d.delta = cfg.MinNumberDelta
+	}
+	if d.delta > cfg.MaxNumberDelta {
+		d.delta = cfg.MaxNumberDelta
+	}
+	return d.delta
+}
+
+func fraction(delta uint32, k float64) time.Duration {
+	return time.Duration(k * float64(time.Duration(delta)*time.Second))
+}
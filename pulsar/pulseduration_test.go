@@ -0,0 +1,64 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package pulsar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPulseDuration_Fixed(t *testing.T) {
+	cfg := configuration.NewPulsar()
+	d := pulseDuration{}
+
+	now := time.Now()
+	d.startRound(now)
+	require.Equal(t, cfg.NumberDelta, d.next(cfg, now.Add(time.Hour)))
+}
+
+func TestPulseDuration_Adaptive(t *testing.T) {
+	cfg := configuration.NewPulsar()
+	cfg.NumberDelta = 10
+	cfg.MinNumberDelta = 9
+	cfg.MaxNumberDelta = 11
+	d := pulseDuration{}
+
+	now := time.Now()
+	// round is unknown
+	require.Equal(t, uint32(10), d.next(cfg, now))
+
+	// long round prolongs pulse up to max
+	d.startRound(now)
+	require.Equal(t, uint32(11), d.next(cfg, now.Add(6*time.Second)))
+	d.startRound(now)
+	require.Equal(t, uint32(11), d.next(cfg, now.Add(6*time.Second)))
+
+	// moderate round keeps duration
+	d.startRound(now)
+	require.Equal(t, uint32(11), d.next(cfg, now.Add(3*time.Second)))
+
+	// short round shortens pulse down to min
+	d.startRound(now)
+	require.Equal(t, uint32(10), d.next(cfg, now.Add(time.Second)))
+	d.startRound(now)
+	require.Equal(t, uint32(9), d.next(cfg, now.Add(time.Second)))
+	d.startRound(now)
+	require.Equal(t, uint32(9), d.next(cfg, now.Add(time.Second)))
+}
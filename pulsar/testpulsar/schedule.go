@@ -0,0 +1,149 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package testpulsar
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Schedule returns intervals between pulses emitted by test pulsar.
+type Schedule interface {
+	// Next returns interval before the next pulse.
+	Next() time.Duration
+}
+
+type step struct {
+	interval time.Duration
+	jitter   time.Duration
+	count    int
+}
+
+func (s step) next() time.Duration {
+	if s.jitter == 0 {
+		return s.interval
+	}
+	d := s.interval - s.jitter + time.Duration(rand.Int63n(int64(2*s.jitter)+1))
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+// script is a schedule of steps repeated in a loop.
+type script struct {
+	steps   []step
+	current int
+	emitted int
+}
+
+func (s *script) Next() time.Duration {
+	st := s.steps[s.current]
+	d := st.next()
+	s.emitted++
+	if s.emitted >= st.count {
+		s.emitted = 0
+		s.current = (s.current + 1) % len(s.steps)
+	}
+	return d
+}
+
+// Fixed emits pulses with constant interval.
+func Fixed(interval time.Duration) Schedule {
+	return &script{steps: []step{{interval: interval, count: 1}}}
+}
+
+// Jittered emits pulses with interval randomly deviating from given one by up to jitter in both directions.
+func Jittered(interval, jitter time.Duration) Schedule {
+	return &script{steps: []step{{interval: interval, jitter: jitter, count: 1}}}
+}
+
+// ParseSchedule parses schedule script. Script is a comma separated list of steps which is repeated in a loop:
+//
+//	fixed:<interval>          one pulse after interval
+//	jitter:<interval>:<delta> one pulse after interval deviating by up to delta
+//	burst:<count>:<interval>  count pulses with short interval
+//	gap:<interval>            one pulse after long pause
+//
+// Any step may be repeated with *<count> suffix, intervals are in time.ParseDuration format.
+// E.g. "fixed:10s*5,burst:3:100ms,gap:1m" emits five regular pulses, three pulses in a burst
+// and waits a minute before the next one, then starts over.
+func ParseSchedule(s string) (Schedule, error) {
+	var steps []step
+	for _, raw := range strings.Split(s, ",") {
+		st, err := parseStep(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse step %q", raw)
+		}
+		steps = append(steps, st)
+	}
+	return &script{steps: steps}, nil
+}
+
+func parseStep(s string) (step, error) {
+	repeat := 1
+	if i := strings.LastIndex(s, "*"); i >= 0 {
+		n, err := strconv.Atoi(s[i+1:])
+		if err != nil || n < 1 {
+			return step{}, errors.New("repeat count should be positive number")
+		}
+		repeat = n
+		s = s[:i]
+	}
+
+	args := strings.Split(s, ":")
+	kind, args := args[0], args[1:]
+
+	var (
+		st  step
+		err error
+	)
+	switch {
+	case (kind == "fixed" || kind == "gap") && len(args) == 1:
+		st.count = 1
+		st.interval, err = time.ParseDuration(args[0])
+	case kind == "jitter" && len(args) == 2:
+		st.count = 1
+		st.interval, err = time.ParseDuration(args[0])
+		if err == nil {
+			st.jitter, err = time.ParseDuration(args[1])
+		}
+	case kind == "burst" && len(args) == 2:
+		st.count, err = strconv.Atoi(args[0])
+		if err == nil && st.count < 1 {
+			err = errors.New("burst size should be positive number")
+		}
+		if err == nil {
+			st.interval, err = time.ParseDuration(args[1])
+		}
+	default:
+		return step{}, errors.Errorf("unknown step %q", s)
+	}
+	if err != nil {
+		return step{}, err
+	}
+	if st.interval < 0 || st.jitter < 0 {
+		return step{}, errors.New("intervals should not be negative")
+	}
+
+	st.count *= repeat
+	return st, nil
+}
@@ -0,0 +1,109 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package testpulsar
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/pulsar/entropygenerator"
+)
+
+// TestPulsar emits pulses on scriptable schedule without consensus between pulsars. It's intended for integration
+// tests and devnet to reproduce pulse-edge behavior, it should never be used in production network.
+type TestPulsar struct {
+	Distributor core.PulseDistributor `inject:""`
+
+	generator   entropygenerator.EntropyGenerator
+	schedule    Schedule
+	numberDelta uint32
+
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+// New creates test pulsar. Delta between pulse numbers is fixed to numberDelta, if it's zero delta follows
+// interval between pulses, so pulse numbers stay close to time.
+func New(schedule Schedule, numberDelta uint32) *TestPulsar {
+	return &TestPulsar{
+		generator:   &entropygenerator.StandardEntropyGenerator{},
+		schedule:    schedule,
+		numberDelta: numberDelta,
+		stop:        make(chan struct{}),
+	}
+}
+
+// Start implements component.Starter.
+func (tp *TestPulsar) Start(ctx context.Context) error {
+	go tp.run(ctx)
+	return nil
+}
+
+// Stop implements component.Stopper.
+func (tp *TestPulsar) Stop(ctx context.Context) error {
+	tp.stopOnce.Do(func() {
+		close(tp.stop)
+	})
+	return nil
+}
+
+func (tp *TestPulsar) run(ctx context.Context) {
+	logger := inslogger.FromContext(ctx)
+
+	wait := tp.schedule.Next()
+	number := core.CalculatePulseNumber(time.Now())
+	pulse := core.Pulse{
+		PulseNumber:      number,
+		NextPulseNumber:  number + tp.delta(wait),
+		PrevPulseNumber:  number - tp.delta(wait),
+		EpochPulseNumber: 1,
+		OriginID:         [16]byte{206, 41, 229, 190, 7, 240, 162, 155, 121, 245, 207, 56, 161, 67, 189, 0},
+	}
+
+	for {
+		select {
+		case <-time.After(wait):
+		case <-tp.stop:
+			return
+		}
+
+		pulse.Entropy = tp.generator.GenerateEntropy()
+		pulse.PulseTimestamp = time.Now().Unix()
+		logger.Debugf("[ TestPulsar ] distributing pulse %d after %s", pulse.PulseNumber, wait)
+		go tp.Distributor.Distribute(ctx, pulse)
+
+		wait = tp.schedule.Next()
+		pulse = core.Pulse{
+			PulseNumber:      pulse.NextPulseNumber,
+			NextPulseNumber:  pulse.NextPulseNumber + tp.delta(wait),
+			PrevPulseNumber:  pulse.PulseNumber,
+			EpochPulseNumber: pulse.EpochPulseNumber,
+			OriginID:         pulse.OriginID,
+		}
+	}
+}
+
+func (tp *TestPulsar) delta(wait time.Duration) core.PulseNumber {
+	if tp.numberDelta != 0 {
+		return core.PulseNumber(tp.numberDelta)
+	}
+	return core.PulseNumber(math.Max(1, math.Ceil(wait.Seconds())))
+}
@@ -0,0 +1,83 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package testpulsar
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestParseSchedule(t *testing.T) {
+	s, err := ParseSchedule("fixed:10s*2, burst:3:100ms, gap:1m")
+	require.NoError(t, err)
+
+	var got []time.Duration
+	for i := 0; i < 7; i++ {
+		got = append(got, s.Next())
+	}
+	assert.Equal(t, []time.Duration{
+		10 * time.Second, 10 * time.Second,
+		100 * time.Millisecond, 100 * time.Millisecond, 100 * time.Millisecond,
+		time.Minute,
+		10 * time.Second,
+	}, got)
+}
+
+func TestParseSchedule_Jitter(t *testing.T) {
+	s, err := ParseSchedule("jitter:10s:2s")
+	require.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		d := s.Next()
+		assert.True(t, d >= 8*time.Second && d <= 12*time.Second, "interval %s is out of bounds", d)
+	}
+}
+
+func TestParseSchedule_Invalid(t *testing.T) {
+	for _, script := range []string{"", "fixed", "fixed:1x", "burst:0:1s", "gap:1s*0", "jitter:1s", "sometimes:1s"} {
+		_, err := ParseSchedule(script)
+		assert.Error(t, err, script)
+	}
+}
+
+func TestTestPulsar(t *testing.T) {
+	pulses := make(chan core.Pulse, 10)
+	distributor := testutils.NewPulseDistributorMock(t)
+	distributor.DistributeFunc = func(ctx context.Context, pulse core.Pulse) {
+		pulses <- pulse
+	}
+
+	s, err := ParseSchedule("fixed:10ms,fixed:1500ms")
+	require.NoError(t, err)
+	tp := New(s, 0)
+	tp.Distributor = distributor
+	require.NoError(t, tp.Start(context.Background()))
+	defer tp.Stop(context.Background())
+
+	first := <-pulses
+	second := <-pulses
+	assert.Equal(t, first.NextPulseNumber, second.PulseNumber)
+	assert.Equal(t, first.PulseNumber, second.PrevPulseNumber)
+	assert.Equal(t, first.PulseNumber+1, second.PulseNumber, "delta is at least 1")
+	assert.Equal(t, second.PulseNumber+2, second.NextPulseNumber, "delta follows interval")
+}
@@ -0,0 +1,60 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package scheduler
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"github.com/insolar/insolar/instrumentation/insmetrics"
+)
+
+var (
+	tagTask = insmetrics.MustTagKey("task")
+)
+
+var (
+	statTaskTime = stats.Float64(
+		"scheduler/task/time",
+		"time spent on running task",
+		stats.UnitMilliseconds,
+	)
+	statTaskFailedTotal = stats.Int64(
+		"scheduler/task/failed/count",
+		"number of failed task runs",
+		stats.UnitDimensionless,
+	)
+)
+
+func init() {
+	err := insmetrics.Register(
+		&view.View{
+			Measure:     statTaskTime,
+			Aggregation: view.Distribution(1, 10, 100, 1000, 10000, 60000, 600000),
+			TagKeys:     []tag.Key{tagTask},
+		},
+		&view.View{
+			Measure:     statTaskFailedTotal,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{tagTask},
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+}
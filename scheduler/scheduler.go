@@ -0,0 +1,256 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+// Package scheduler runs periodic tasks of the node, like storage garbage collection.
+// Tasks are scheduled in wall time or in pulses.
+package scheduler
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opencensus.io/stats"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/crashreport"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/instrumentation/insmetrics"
+)
+
+type task struct {
+	core.PeriodicTask
+	info core.TaskInfo
+
+	// lastStart is a time of last scheduled start, used for wall time schedule.
+	lastStart time.Time
+	// pulses is a number of pulses passed since last start, used for pulse schedule.
+	pulses   int
+	disabled bool
+}
+
+// Scheduler is a node-local scheduler of periodic tasks.
+type Scheduler struct {
+	PulseStorage core.PulseStorage `inject:""`
+
+	cfg configuration.Scheduler
+
+	lock      sync.Mutex
+	tasks     map[string]*task
+	lastPulse core.PulseNumber
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewScheduler creates new Scheduler.
+func NewScheduler(cfg configuration.Scheduler) *Scheduler {
+	return &Scheduler{
+		cfg:   cfg,
+		tasks: make(map[string]*task),
+		stop:  make(chan struct{}),
+	}
+}
+
+// AddTasksFrom schedules tasks of components which implement core.PeriodicTasksProvider, other components are skipped.
+func (s *Scheduler) AddTasksFrom(components ...interface{}) error {
+	for _, c := range components {
+		provider, ok := c.(core.PeriodicTasksProvider)
+		if !ok {
+			continue
+		}
+		for _, t := range provider.PeriodicTasks() {
+			if err := s.Schedule(t); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Schedule registers task.
+func (s *Scheduler) Schedule(t core.PeriodicTask) error {
+	if t.Name == "" || t.Run == nil {
+		return errors.New("[ Scheduler.Schedule ] task must have name and function")
+	}
+	if t.Schedule.Every < 0 || t.Schedule.Pulses < 0 {
+		return errors.Errorf("[ Scheduler.Schedule ] task %s has negative interval", t.Name)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, ok := s.tasks[t.Name]; ok {
+		return errors.Errorf("[ Scheduler.Schedule ] task %s is already scheduled", t.Name)
+	}
+
+	disabled := false
+	for _, name := range s.cfg.Disabled {
+		if name == t.Name {
+			disabled = true
+		}
+	}
+	s.tasks[t.Name] = &task{
+		PeriodicTask: t,
+		info:         core.TaskInfo{Name: t.Name, Schedule: t.Schedule},
+		lastStart:    time.Now(),
+		disabled:     disabled,
+	}
+	return nil
+}
+
+// Tasks returns state of all tasks sorted by name.
+func (s *Scheduler) Tasks() []core.TaskInfo {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	infos := make([]core.TaskInfo, 0, len(s.tasks))
+	for _, t := range s.tasks {
+		infos = append(infos, t.info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Name < infos[j].Name
+	})
+	return infos
+}
+
+// Trigger runs task out of schedule and waits for it to finish.
+func (s *Scheduler) Trigger(ctx context.Context, name string) error {
+	s.lock.Lock()
+	t, ok := s.tasks[name]
+	if !ok {
+		s.lock.Unlock()
+		return errors.Errorf("[ Scheduler.Trigger ] unknown task %s", name)
+	}
+	if t.info.Running {
+		s.lock.Unlock()
+		return errors.Errorf("[ Scheduler.Trigger ] task %s is already running", name)
+	}
+	t.info.Running = true
+	s.lock.Unlock()
+
+	return s.run(ctx, t)
+}
+
+// Start starts scheduling.
+func (s *Scheduler) Start(ctx context.Context) error {
+	if s.cfg.Tick <= 0 {
+		inslogger.FromContext(ctx).Info("[ Scheduler.Start ] scheduling is disabled")
+		return nil
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer crashreport.Recover(ctx, "Scheduler")
+
+		ticker := time.NewTicker(s.cfg.Tick)
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				s.tick(ctx, now)
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop stops scheduling and waits for running tasks.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	close(s.stop)
+	s.wg.Wait()
+	return nil
+}
+
+// tick starts tasks which are due at the moment.
+func (s *Scheduler) tick(ctx context.Context, now time.Time) {
+	var current core.PulseNumber
+	if s.PulseStorage != nil {
+		if pulse, err := s.PulseStorage.Current(ctx); err == nil {
+			current = pulse.PulseNumber
+		}
+	}
+
+	s.lock.Lock()
+	newPulse := current != 0 && s.lastPulse != 0 && current != s.lastPulse
+	if current != 0 {
+		s.lastPulse = current
+	}
+	var due []*task
+	for _, t := range s.tasks {
+		if newPulse {
+			t.pulses++
+		}
+		if t.disabled || t.info.Running {
+			continue
+		}
+		byTime := t.Schedule.Every > 0 && now.Sub(t.lastStart) >= t.Schedule.Every
+		byPulses := t.Schedule.Pulses > 0 && t.pulses >= t.Schedule.Pulses
+		if !byTime && !byPulses {
+			continue
+		}
+		t.lastStart = now
+		t.pulses = 0
+		t.info.Running = true
+		due = append(due, t)
+	}
+	s.lock.Unlock()
+
+	for _, t := range due {
+		s.wg.Add(1)
+		go func(t *task) {
+			defer s.wg.Done()
+			s.run(ctx, t) // nolint: errcheck
+		}(t)
+	}
+}
+
+// run runs task marked as running and records the result.
+func (s *Scheduler) run(ctx context.Context, t *task) (err error) {
+	ctx = insmetrics.InsertTag(ctx, tagTask, t.Name)
+	ctx, logger := inslogger.WithField(ctx, "task", t.Name)
+	start := time.Now()
+
+	func() {
+		defer crashreport.RecoverError(ctx, "Scheduler task "+t.Name, &err)
+		err = t.Run(ctx)
+	}()
+
+	stats.Record(ctx, statTaskTime.M(float64(time.Since(start).Nanoseconds())/1e6))
+	if err != nil {
+		stats.Record(ctx, statTaskFailedTotal.M(1))
+		logger.Error("[ Scheduler ] task failed: ", err)
+	} else {
+		logger.Debugf("[ Scheduler ] task finished in %s", time.Since(start))
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	t.info.Running = false
+	t.info.Runs++
+	t.info.LastRun = start
+	t.info.LastPulse = s.lastPulse
+	t.info.LastError = ""
+	if err != nil {
+		t.info.LastError = err.Error()
+	}
+	return err
+}
@@ -0,0 +1,174 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/testutils"
+)
+
+type tasksProvider []core.PeriodicTask
+
+func (p tasksProvider) PeriodicTasks() []core.PeriodicTask {
+	return p
+}
+
+func counterTask(counter *int32) core.Task {
+	return func(ctx context.Context) error {
+		atomic.AddInt32(counter, 1)
+		return nil
+	}
+}
+
+func TestScheduler_Schedule(t *testing.T) {
+	s := NewScheduler(configuration.NewScheduler())
+	noop := func(ctx context.Context) error { return nil }
+
+	require.NoError(t, s.Schedule(core.PeriodicTask{Name: "b", Run: noop}))
+	require.Error(t, s.Schedule(core.PeriodicTask{Name: "b", Run: noop}))
+	require.Error(t, s.Schedule(core.PeriodicTask{Name: "", Run: noop}))
+	require.Error(t, s.Schedule(core.PeriodicTask{Name: "c"}))
+	require.Error(t, s.Schedule(core.PeriodicTask{Name: "c", Run: noop, Schedule: core.TaskSchedule{Pulses: -1}}))
+
+	err := s.AddTasksFrom(
+		"not a provider",
+		tasksProvider{{Name: "a", Run: noop, Schedule: core.TaskSchedule{Every: time.Minute}}},
+	)
+	require.NoError(t, err)
+
+	tasks := s.Tasks()
+	require.Len(t, tasks, 2)
+	require.Equal(t, "a", tasks[0].Name)
+	require.Equal(t, time.Minute, tasks[0].Schedule.Every)
+	require.Equal(t, "b", tasks[1].Name)
+}
+
+func TestScheduler_tick(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	cfg := configuration.NewScheduler()
+	cfg.Disabled = []string{"disabled"}
+	s := NewScheduler(cfg)
+
+	pulse := core.Pulse{PulseNumber: core.FirstPulseNumber}
+	ps := testutils.NewPulseStorageMock(t)
+	ps.CurrentFunc = func(context.Context) (*core.Pulse, error) {
+		p := pulse
+		return &p, nil
+	}
+	s.PulseStorage = ps
+
+	var byTime, byPulses, disabled int32
+	require.NoError(t, s.Schedule(core.PeriodicTask{
+		Name: "time", Run: counterTask(&byTime), Schedule: core.TaskSchedule{Every: time.Minute},
+	}))
+	require.NoError(t, s.Schedule(core.PeriodicTask{
+		Name: "pulses", Run: counterTask(&byPulses), Schedule: core.TaskSchedule{Pulses: 2},
+	}))
+	require.NoError(t, s.Schedule(core.PeriodicTask{
+		Name: "disabled", Run: counterTask(&disabled), Schedule: core.TaskSchedule{Pulses: 1},
+	}))
+
+	now := time.Now()
+	s.tick(ctx, now)
+	pulse.PulseNumber++
+	s.tick(ctx, now.Add(30*time.Second))
+	s.wg.Wait()
+	require.Equal(t, int32(0), atomic.LoadInt32(&byTime))
+	require.Equal(t, int32(0), atomic.LoadInt32(&byPulses))
+
+	pulse.PulseNumber++
+	s.tick(ctx, now.Add(time.Minute))
+	s.wg.Wait()
+	require.Equal(t, int32(1), atomic.LoadInt32(&byTime))
+	require.Equal(t, int32(1), atomic.LoadInt32(&byPulses))
+	require.Equal(t, int32(0), atomic.LoadInt32(&disabled))
+
+	// same pulse and less than interval since last run
+	s.tick(ctx, now.Add(90*time.Second))
+	s.wg.Wait()
+	require.Equal(t, int32(1), atomic.LoadInt32(&byTime))
+	require.Equal(t, int32(1), atomic.LoadInt32(&byPulses))
+
+	for _, info := range s.Tasks() {
+		if info.Name == "disabled" {
+			require.Equal(t, 0, info.Runs)
+			continue
+		}
+		require.Equal(t, 1, info.Runs)
+		require.Equal(t, pulse.PulseNumber, info.LastPulse)
+		require.False(t, info.Running)
+	}
+}
+
+func TestScheduler_Trigger(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	s := NewScheduler(configuration.NewScheduler())
+
+	var counter int32
+	require.NoError(t, s.Schedule(core.PeriodicTask{Name: "ok", Run: counterTask(&counter)}))
+	require.NoError(t, s.Schedule(core.PeriodicTask{Name: "fail", Run: func(ctx context.Context) error {
+		return errors.New("test error")
+	}}))
+	require.NoError(t, s.Schedule(core.PeriodicTask{Name: "panic", Run: func(ctx context.Context) error {
+		panic("test panic")
+	}}))
+
+	require.NoError(t, s.Trigger(ctx, "ok"))
+	require.Equal(t, int32(1), counter)
+	require.Error(t, s.Trigger(ctx, "unknown"))
+	require.Error(t, s.Trigger(ctx, "fail"))
+	require.Error(t, s.Trigger(ctx, "panic"))
+
+	tasks := s.Tasks()
+	require.Equal(t, "fail", tasks[0].Name)
+	require.Equal(t, "test error", tasks[0].LastError)
+	require.Equal(t, 1, tasks[0].Runs)
+	require.Equal(t, "ok", tasks[1].Name)
+	require.Empty(t, tasks[1].LastError)
+	require.Equal(t, "panic", tasks[2].Name)
+	require.Contains(t, tasks[2].LastError, "test panic")
+}
+
+func TestScheduler_StartStop(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	cfg := configuration.NewScheduler()
+	cfg.Tick = 10 * time.Millisecond
+	s := NewScheduler(cfg)
+
+	var counter int32
+	require.NoError(t, s.Schedule(core.PeriodicTask{
+		Name: "task", Run: counterTask(&counter), Schedule: core.TaskSchedule{Every: 10 * time.Millisecond},
+	}))
+
+	require.NoError(t, s.Start(ctx))
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt32(&counter) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.NoError(t, s.Stop(ctx))
+	require.True(t, atomic.LoadInt32(&counter) > 0)
+}
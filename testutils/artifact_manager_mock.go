@@ -40,7 +40,7 @@ type ArtifactManagerMock struct {
 	DeclareTypePreCounter uint64
 	DeclareTypeMock       mArtifactManagerMockDeclareType
 
-	DeployCodeFunc       func(p context.Context, p1 core.RecordRef, p2 core.RecordRef, p3 []byte, p4 core.MachineType) (r *core.RecordID, r1 error)
+	DeployCodeFunc       func(p context.Context, p1 core.RecordRef, p2 core.RecordRef, p3 []byte, p4 core.MachineType, p5 []byte) (r *core.RecordID, r1 error)
 	DeployCodeCounter    uint64
 	DeployCodePreCounter uint64
 	DeployCodeMock       mArtifactManagerMockDeployCode
@@ -70,17 +70,27 @@ type ArtifactManagerMock struct {
 	GetObjectPreCounter uint64
 	GetObjectMock       mArtifactManagerMockGetObject
 
-	GetPendingRequestFunc       func(p context.Context, p1 core.RecordID) (r core.Parcel, r1 error)
+	GetObjectsFunc       func(p context.Context, p1 []core.RecordRef, p2 []core.RecordRef) (r map[core.RecordRef]core.ObjectDescriptor, r1 map[core.RecordRef]core.CodeDescriptor, r2 error)
+	GetObjectsCounter    uint64
+	GetObjectsPreCounter uint64
+	GetObjectsMock       mArtifactManagerMockGetObjects
+
+	GetPendingRequestFunc       func(p context.Context, p1 core.RecordID) (r core.Parcel, r1 uint64, r2 error)
 	GetPendingRequestCounter    uint64
 	GetPendingRequestPreCounter uint64
 	GetPendingRequestMock       mArtifactManagerMockGetPendingRequest
 
+	GetResultFunc       func(p context.Context, p1 core.RecordRef) (r []byte, r1 error)
+	GetResultCounter    uint64
+	GetResultPreCounter uint64
+	GetResultMock       mArtifactManagerMockGetResult
+
 	HasPendingRequestsFunc       func(p context.Context, p1 core.RecordRef) (r bool, r1 error)
 	HasPendingRequestsCounter    uint64
 	HasPendingRequestsPreCounter uint64
 	HasPendingRequestsMock       mArtifactManagerMockHasPendingRequests
 
-	RegisterRequestFunc       func(p context.Context, p1 core.RecordRef, p2 core.Parcel) (r *core.RecordID, r1 error)
+	RegisterRequestFunc       func(p context.Context, p1 core.RecordRef, p2 core.Parcel) (r *core.RecordID, r1 uint64, r2 error)
 	RegisterRequestCounter    uint64
 	RegisterRequestPreCounter uint64
 	RegisterRequestMock       mArtifactManagerMockRegisterRequest
@@ -90,6 +100,11 @@ type ArtifactManagerMock struct {
 	RegisterResultPreCounter uint64
 	RegisterResultMock       mArtifactManagerMockRegisterResult
 
+	RegisterTimerFunc       func(p context.Context, p1 core.RecordRef, p2 core.PulseNumber, p3 core.Message) (r *core.RecordID, r1 error)
+	RegisterTimerCounter    uint64
+	RegisterTimerPreCounter uint64
+	RegisterTimerMock       mArtifactManagerMockRegisterTimer
+
 	RegisterValidationFunc       func(p context.Context, p1 core.RecordRef, p2 core.RecordID, p3 bool, p4 []core.Message) (r error)
 	RegisterValidationCounter    uint64
 	RegisterValidationPreCounter uint64
@@ -129,10 +144,13 @@ func NewArtifactManagerMock(t minimock.Tester) *ArtifactManagerMock {
 	m.GetCodeMock = mArtifactManagerMockGetCode{mock: m}
 	m.GetDelegateMock = mArtifactManagerMockGetDelegate{mock: m}
 	m.GetObjectMock = mArtifactManagerMockGetObject{mock: m}
+	m.GetObjectsMock = mArtifactManagerMockGetObjects{mock: m}
 	m.GetPendingRequestMock = mArtifactManagerMockGetPendingRequest{mock: m}
+	m.GetResultMock = mArtifactManagerMockGetResult{mock: m}
 	m.HasPendingRequestsMock = mArtifactManagerMockHasPendingRequests{mock: m}
 	m.RegisterRequestMock = mArtifactManagerMockRegisterRequest{mock: m}
 	m.RegisterResultMock = mArtifactManagerMockRegisterResult{mock: m}
+	m.RegisterTimerMock = mArtifactManagerMockRegisterTimer{mock: m}
 	m.RegisterValidationMock = mArtifactManagerMockRegisterValidation{mock: m}
 	m.StateMock = mArtifactManagerMockState{mock: m}
 	m.UpdateObjectMock = mArtifactManagerMockUpdateObject{mock: m}
@@ -775,6 +793,7 @@ type ArtifactManagerMockDeployCodeInput struct {
 	p2 core.RecordRef
 	p3 []byte
 	p4 core.MachineType
+	p5 []byte
 }
 
 type ArtifactManagerMockDeployCodeResult struct {
@@ -783,14 +802,14 @@ type ArtifactManagerMockDeployCodeResult struct {
 }
 
 //Expect specifies that invocation of ArtifactManager.DeployCode is expected from 1 to Infinity times
-func (m *mArtifactManagerMockDeployCode) Expect(p context.Context, p1 core.RecordRef, p2 core.RecordRef, p3 []byte, p4 core.MachineType) *mArtifactManagerMockDeployCode {
+func (m *mArtifactManagerMockDeployCode) Expect(p context.Context, p1 core.RecordRef, p2 core.RecordRef, p3 []byte, p4 core.MachineType, p5 []byte) *mArtifactManagerMockDeployCode {
 	m.mock.DeployCodeFunc = nil
 	m.expectationSeries = nil
 
 	if m.mainExpectation == nil {
 		m.mainExpectation = &ArtifactManagerMockDeployCodeExpectation{}
 	}
-	m.mainExpectation.input = &ArtifactManagerMockDeployCodeInput{p, p1, p2, p3, p4}
+	m.mainExpectation.input = &ArtifactManagerMockDeployCodeInput{p, p1, p2, p3, p4, p5}
 	return m
 }
 
@@ -807,12 +826,12 @@ func (m *mArtifactManagerMockDeployCode) Return(r *core.RecordID, r1 error) *Art
 }
 
 //ExpectOnce specifies that invocation of ArtifactManager.DeployCode is expected once
-func (m *mArtifactManagerMockDeployCode) ExpectOnce(p context.Context, p1 core.RecordRef, p2 core.RecordRef, p3 []byte, p4 core.MachineType) *ArtifactManagerMockDeployCodeExpectation {
+func (m *mArtifactManagerMockDeployCode) ExpectOnce(p context.Context, p1 core.RecordRef, p2 core.RecordRef, p3 []byte, p4 core.MachineType, p5 []byte) *ArtifactManagerMockDeployCodeExpectation {
 	m.mock.DeployCodeFunc = nil
 	m.mainExpectation = nil
 
 	expectation := &ArtifactManagerMockDeployCodeExpectation{}
-	expectation.input = &ArtifactManagerMockDeployCodeInput{p, p1, p2, p3, p4}
+	expectation.input = &ArtifactManagerMockDeployCodeInput{p, p1, p2, p3, p4, p5}
 	m.expectationSeries = append(m.expectationSeries, expectation)
 	return expectation
 }
@@ -822,7 +841,7 @@ func (e *ArtifactManagerMockDeployCodeExpectation) Return(r *core.RecordID, r1 e
 }
 
 //Set uses given function f as a mock of ArtifactManager.DeployCode method
-func (m *mArtifactManagerMockDeployCode) Set(f func(p context.Context, p1 core.RecordRef, p2 core.RecordRef, p3 []byte, p4 core.MachineType) (r *core.RecordID, r1 error)) *ArtifactManagerMock {
+func (m *mArtifactManagerMockDeployCode) Set(f func(p context.Context, p1 core.RecordRef, p2 core.RecordRef, p3 []byte, p4 core.MachineType, p5 []byte) (r *core.RecordID, r1 error)) *ArtifactManagerMock {
 	m.mainExpectation = nil
 	m.expectationSeries = nil
 
@@ -831,18 +850,18 @@ func (m *mArtifactManagerMockDeployCode) Set(f func(p context.Context, p1 core.R
 }
 
 //DeployCode implements github.com/insolar/insolar/core.ArtifactManager interface
-func (m *ArtifactManagerMock) DeployCode(p context.Context, p1 core.RecordRef, p2 core.RecordRef, p3 []byte, p4 core.MachineType) (r *core.RecordID, r1 error) {
+func (m *ArtifactManagerMock) DeployCode(p context.Context, p1 core.RecordRef, p2 core.RecordRef, p3 []byte, p4 core.MachineType, p5 []byte) (r *core.RecordID, r1 error) {
 	counter := atomic.AddUint64(&m.DeployCodePreCounter, 1)
 	defer atomic.AddUint64(&m.DeployCodeCounter, 1)
 
 	if len(m.DeployCodeMock.expectationSeries) > 0 {
 		if counter > uint64(len(m.DeployCodeMock.expectationSeries)) {
-			m.t.Fatalf("Unexpected call to ArtifactManagerMock.DeployCode. %v %v %v %v %v", p, p1, p2, p3, p4)
+			m.t.Fatalf("Unexpected call to ArtifactManagerMock.DeployCode. %v %v %v %v %v %v", p, p1, p2, p3, p4, p5)
 			return
 		}
 
 		input := m.DeployCodeMock.expectationSeries[counter-1].input
-		testify_assert.Equal(m.t, *input, ArtifactManagerMockDeployCodeInput{p, p1, p2, p3, p4}, "ArtifactManager.DeployCode got unexpected parameters")
+		testify_assert.Equal(m.t, *input, ArtifactManagerMockDeployCodeInput{p, p1, p2, p3, p4, p5}, "ArtifactManager.DeployCode got unexpected parameters")
 
 		result := m.DeployCodeMock.expectationSeries[counter-1].result
 		if result == nil {
@@ -860,7 +879,7 @@ func (m *ArtifactManagerMock) DeployCode(p context.Context, p1 core.RecordRef, p
 
 		input := m.DeployCodeMock.mainExpectation.input
 		if input != nil {
-			testify_assert.Equal(m.t, *input, ArtifactManagerMockDeployCodeInput{p, p1, p2, p3, p4}, "ArtifactManager.DeployCode got unexpected parameters")
+			testify_assert.Equal(m.t, *input, ArtifactManagerMockDeployCodeInput{p, p1, p2, p3, p4, p5}, "ArtifactManager.DeployCode got unexpected parameters")
 		}
 
 		result := m.DeployCodeMock.mainExpectation.result
@@ -875,11 +894,11 @@ func (m *ArtifactManagerMock) DeployCode(p context.Context, p1 core.RecordRef, p
 	}
 
 	if m.DeployCodeFunc == nil {
-		m.t.Fatalf("Unexpected call to ArtifactManagerMock.DeployCode. %v %v %v %v %v", p, p1, p2, p3, p4)
+		m.t.Fatalf("Unexpected call to ArtifactManagerMock.DeployCode. %v %v %v %v %v %v", p, p1, p2, p3, p4, p5)
 		return
 	}
 
-	return m.DeployCodeFunc(p, p1, p2, p3, p4)
+	return m.DeployCodeFunc(p, p1, p2, p3, p4, p5)
 }
 
 //DeployCodeMinimockCounter returns a count of ArtifactManagerMock.DeployCodeFunc invocations
@@ -1654,6 +1673,161 @@ func (m *ArtifactManagerMock) GetObjectFinished() bool {
 	return true
 }
 
+type mArtifactManagerMockGetObjects struct {
+	mock              *ArtifactManagerMock
+	mainExpectation   *ArtifactManagerMockGetObjectsExpectation
+	expectationSeries []*ArtifactManagerMockGetObjectsExpectation
+}
+
+type ArtifactManagerMockGetObjectsExpectation struct {
+	input  *ArtifactManagerMockGetObjectsInput
+	result *ArtifactManagerMockGetObjectsResult
+}
+
+type ArtifactManagerMockGetObjectsInput struct {
+	p  context.Context
+	p1 []core.RecordRef
+	p2 []core.RecordRef
+}
+
+type ArtifactManagerMockGetObjectsResult struct {
+	r  map[core.RecordRef]core.ObjectDescriptor
+	r1 map[core.RecordRef]core.CodeDescriptor
+	r2 error
+}
+
+//Expect specifies that invocation of ArtifactManager.GetObjects is expected from 1 to Infinity times
+func (m *mArtifactManagerMockGetObjects) Expect(p context.Context, p1 []core.RecordRef, p2 []core.RecordRef) *mArtifactManagerMockGetObjects {
+	m.mock.GetObjectsFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ArtifactManagerMockGetObjectsExpectation{}
+	}
+	m.mainExpectation.input = &ArtifactManagerMockGetObjectsInput{p, p1, p2}
+	return m
+}
+
+//Return specifies results of invocation of ArtifactManager.GetObjects
+func (m *mArtifactManagerMockGetObjects) Return(r map[core.RecordRef]core.ObjectDescriptor, r1 map[core.RecordRef]core.CodeDescriptor, r2 error) *ArtifactManagerMock {
+	m.mock.GetObjectsFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ArtifactManagerMockGetObjectsExpectation{}
+	}
+	m.mainExpectation.result = &ArtifactManagerMockGetObjectsResult{r, r1, r2}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of ArtifactManager.GetObjects is expected once
+func (m *mArtifactManagerMockGetObjects) ExpectOnce(p context.Context, p1 []core.RecordRef, p2 []core.RecordRef) *ArtifactManagerMockGetObjectsExpectation {
+	m.mock.GetObjectsFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &ArtifactManagerMockGetObjectsExpectation{}
+	expectation.input = &ArtifactManagerMockGetObjectsInput{p, p1, p2}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *ArtifactManagerMockGetObjectsExpectation) Return(r map[core.RecordRef]core.ObjectDescriptor, r1 map[core.RecordRef]core.CodeDescriptor, r2 error) {
+	e.result = &ArtifactManagerMockGetObjectsResult{r, r1, r2}
+}
+
+//Set uses given function f as a mock of ArtifactManager.GetObjects method
+func (m *mArtifactManagerMockGetObjects) Set(f func(p context.Context, p1 []core.RecordRef, p2 []core.RecordRef) (r map[core.RecordRef]core.ObjectDescriptor, r1 map[core.RecordRef]core.CodeDescriptor, r2 error)) *ArtifactManagerMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.GetObjectsFunc = f
+	return m.mock
+}
+
+//GetObjects implements github.com/insolar/insolar/core.ArtifactManager interface
+func (m *ArtifactManagerMock) GetObjects(p context.Context, p1 []core.RecordRef, p2 []core.RecordRef) (r map[core.RecordRef]core.ObjectDescriptor, r1 map[core.RecordRef]core.CodeDescriptor, r2 error) {
+	counter := atomic.AddUint64(&m.GetObjectsPreCounter, 1)
+	defer atomic.AddUint64(&m.GetObjectsCounter, 1)
+
+	if len(m.GetObjectsMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.GetObjectsMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to ArtifactManagerMock.GetObjects. %v %v %v", p, p1, p2)
+			return
+		}
+
+		input := m.GetObjectsMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, ArtifactManagerMockGetObjectsInput{p, p1, p2}, "ArtifactManager.GetObjects got unexpected parameters")
+
+		result := m.GetObjectsMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the ArtifactManagerMock.GetObjects")
+			return
+		}
+
+		r = result.r
+		r1 = result.r1
+		r2 = result.r2
+
+		return
+	}
+
+	if m.GetObjectsMock.mainExpectation != nil {
+
+		input := m.GetObjectsMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, ArtifactManagerMockGetObjectsInput{p, p1, p2}, "ArtifactManager.GetObjects got unexpected parameters")
+		}
+
+		result := m.GetObjectsMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the ArtifactManagerMock.GetObjects")
+		}
+
+		r = result.r
+		r1 = result.r1
+		r2 = result.r2
+
+		return
+	}
+
+	if m.GetObjectsFunc == nil {
+		m.t.Fatalf("Unexpected call to ArtifactManagerMock.GetObjects. %v %v %v", p, p1, p2)
+		return
+	}
+
+	return m.GetObjectsFunc(p, p1, p2)
+}
+
+//GetObjectsMinimockCounter returns a count of ArtifactManagerMock.GetObjectsFunc invocations
+func (m *ArtifactManagerMock) GetObjectsMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.GetObjectsCounter)
+}
+
+//GetObjectsMinimockPreCounter returns the value of ArtifactManagerMock.GetObjects invocations
+func (m *ArtifactManagerMock) GetObjectsMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.GetObjectsPreCounter)
+}
+
+//GetObjectsFinished returns true if mock invocations count is ok
+func (m *ArtifactManagerMock) GetObjectsFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.GetObjectsMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.GetObjectsCounter) == uint64(len(m.GetObjectsMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.GetObjectsMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.GetObjectsCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.GetObjectsFunc != nil {
+		return atomic.LoadUint64(&m.GetObjectsCounter) > 0
+	}
+
+	return true
+}
+
 type mArtifactManagerMockGetPendingRequest struct {
 	mock              *ArtifactManagerMock
 	mainExpectation   *ArtifactManagerMockGetPendingRequestExpectation
@@ -1672,7 +1846,8 @@ type ArtifactManagerMockGetPendingRequestInput struct {
 
 type ArtifactManagerMockGetPendingRequestResult struct {
 	r  core.Parcel
-	r1 error
+	r1 uint64
+	r2 error
 }
 
 //Expect specifies that invocation of ArtifactManager.GetPendingRequest is expected from 1 to Infinity times
@@ -1688,14 +1863,14 @@ func (m *mArtifactManagerMockGetPendingRequest) Expect(p context.Context, p1 cor
 }
 
 //Return specifies results of invocation of ArtifactManager.GetPendingRequest
-func (m *mArtifactManagerMockGetPendingRequest) Return(r core.Parcel, r1 error) *ArtifactManagerMock {
+func (m *mArtifactManagerMockGetPendingRequest) Return(r core.Parcel, r1 uint64, r2 error) *ArtifactManagerMock {
 	m.mock.GetPendingRequestFunc = nil
 	m.expectationSeries = nil
 
 	if m.mainExpectation == nil {
 		m.mainExpectation = &ArtifactManagerMockGetPendingRequestExpectation{}
 	}
-	m.mainExpectation.result = &ArtifactManagerMockGetPendingRequestResult{r, r1}
+	m.mainExpectation.result = &ArtifactManagerMockGetPendingRequestResult{r, r1, r2}
 	return m.mock
 }
 
@@ -1710,12 +1885,12 @@ func (m *mArtifactManagerMockGetPendingRequest) ExpectOnce(p context.Context, p1
 	return expectation
 }
 
-func (e *ArtifactManagerMockGetPendingRequestExpectation) Return(r core.Parcel, r1 error) {
-	e.result = &ArtifactManagerMockGetPendingRequestResult{r, r1}
+func (e *ArtifactManagerMockGetPendingRequestExpectation) Return(r core.Parcel, r1 uint64, r2 error) {
+	e.result = &ArtifactManagerMockGetPendingRequestResult{r, r1, r2}
 }
 
 //Set uses given function f as a mock of ArtifactManager.GetPendingRequest method
-func (m *mArtifactManagerMockGetPendingRequest) Set(f func(p context.Context, p1 core.RecordID) (r core.Parcel, r1 error)) *ArtifactManagerMock {
+func (m *mArtifactManagerMockGetPendingRequest) Set(f func(p context.Context, p1 core.RecordID) (r core.Parcel, r1 uint64, r2 error)) *ArtifactManagerMock {
 	m.mainExpectation = nil
 	m.expectationSeries = nil
 
@@ -1724,7 +1899,7 @@ func (m *mArtifactManagerMockGetPendingRequest) Set(f func(p context.Context, p1
 }
 
 //GetPendingRequest implements github.com/insolar/insolar/core.ArtifactManager interface
-func (m *ArtifactManagerMock) GetPendingRequest(p context.Context, p1 core.RecordID) (r core.Parcel, r1 error) {
+func (m *ArtifactManagerMock) GetPendingRequest(p context.Context, p1 core.RecordID) (r core.Parcel, r1 uint64, r2 error) {
 	counter := atomic.AddUint64(&m.GetPendingRequestPreCounter, 1)
 	defer atomic.AddUint64(&m.GetPendingRequestCounter, 1)
 
@@ -1745,6 +1920,7 @@ func (m *ArtifactManagerMock) GetPendingRequest(p context.Context, p1 core.Recor
 
 		r = result.r
 		r1 = result.r1
+		r2 = result.r2
 
 		return
 	}
@@ -1763,6 +1939,7 @@ func (m *ArtifactManagerMock) GetPendingRequest(p context.Context, p1 core.Recor
 
 		r = result.r
 		r1 = result.r1
+		r2 = result.r2
 
 		return
 	}
@@ -1805,6 +1982,157 @@ func (m *ArtifactManagerMock) GetPendingRequestFinished() bool {
 	return true
 }
 
+type mArtifactManagerMockGetResult struct {
+	mock              *ArtifactManagerMock
+	mainExpectation   *ArtifactManagerMockGetResultExpectation
+	expectationSeries []*ArtifactManagerMockGetResultExpectation
+}
+
+type ArtifactManagerMockGetResultExpectation struct {
+	input  *ArtifactManagerMockGetResultInput
+	result *ArtifactManagerMockGetResultResult
+}
+
+type ArtifactManagerMockGetResultInput struct {
+	p  context.Context
+	p1 core.RecordRef
+}
+
+type ArtifactManagerMockGetResultResult struct {
+	r  []byte
+	r1 error
+}
+
+//Expect specifies that invocation of ArtifactManager.GetResult is expected from 1 to Infinity times
+func (m *mArtifactManagerMockGetResult) Expect(p context.Context, p1 core.RecordRef) *mArtifactManagerMockGetResult {
+	m.mock.GetResultFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ArtifactManagerMockGetResultExpectation{}
+	}
+	m.mainExpectation.input = &ArtifactManagerMockGetResultInput{p, p1}
+	return m
+}
+
+//Return specifies results of invocation of ArtifactManager.GetResult
+func (m *mArtifactManagerMockGetResult) Return(r []byte, r1 error) *ArtifactManagerMock {
+	m.mock.GetResultFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ArtifactManagerMockGetResultExpectation{}
+	}
+	m.mainExpectation.result = &ArtifactManagerMockGetResultResult{r, r1}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of ArtifactManager.GetResult is expected once
+func (m *mArtifactManagerMockGetResult) ExpectOnce(p context.Context, p1 core.RecordRef) *ArtifactManagerMockGetResultExpectation {
+	m.mock.GetResultFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &ArtifactManagerMockGetResultExpectation{}
+	expectation.input = &ArtifactManagerMockGetResultInput{p, p1}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *ArtifactManagerMockGetResultExpectation) Return(r []byte, r1 error) {
+	e.result = &ArtifactManagerMockGetResultResult{r, r1}
+}
+
+//Set uses given function f as a mock of ArtifactManager.GetResult method
+func (m *mArtifactManagerMockGetResult) Set(f func(p context.Context, p1 core.RecordRef) (r []byte, r1 error)) *ArtifactManagerMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.GetResultFunc = f
+	return m.mock
+}
+
+//GetResult implements github.com/insolar/insolar/core.ArtifactManager interface
+func (m *ArtifactManagerMock) GetResult(p context.Context, p1 core.RecordRef) (r []byte, r1 error) {
+	counter := atomic.AddUint64(&m.GetResultPreCounter, 1)
+	defer atomic.AddUint64(&m.GetResultCounter, 1)
+
+	if len(m.GetResultMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.GetResultMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to ArtifactManagerMock.GetResult. %v %v", p, p1)
+			return
+		}
+
+		input := m.GetResultMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, ArtifactManagerMockGetResultInput{p, p1}, "ArtifactManager.GetResult got unexpected parameters")
+
+		result := m.GetResultMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the ArtifactManagerMock.GetResult")
+			return
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.GetResultMock.mainExpectation != nil {
+
+		input := m.GetResultMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, ArtifactManagerMockGetResultInput{p, p1}, "ArtifactManager.GetResult got unexpected parameters")
+		}
+
+		result := m.GetResultMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the ArtifactManagerMock.GetResult")
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.GetResultFunc == nil {
+		m.t.Fatalf("Unexpected call to ArtifactManagerMock.GetResult. %v %v", p, p1)
+		return
+	}
+
+	return m.GetResultFunc(p, p1)
+}
+
+//GetResultMinimockCounter returns a count of ArtifactManagerMock.GetResultFunc invocations
+func (m *ArtifactManagerMock) GetResultMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.GetResultCounter)
+}
+
+//GetResultMinimockPreCounter returns the value of ArtifactManagerMock.GetResult invocations
+func (m *ArtifactManagerMock) GetResultMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.GetResultPreCounter)
+}
+
+//GetResultFinished returns true if mock invocations count is ok
+func (m *ArtifactManagerMock) GetResultFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.GetResultMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.GetResultCounter) == uint64(len(m.GetResultMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.GetResultMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.GetResultCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.GetResultFunc != nil {
+		return atomic.LoadUint64(&m.GetResultCounter) > 0
+	}
+
+	return true
+}
+
 type mArtifactManagerMockHasPendingRequests struct {
 	mock              *ArtifactManagerMock
 	mainExpectation   *ArtifactManagerMockHasPendingRequestsExpectation
@@ -1975,7 +2303,8 @@ type ArtifactManagerMockRegisterRequestInput struct {
 
 type ArtifactManagerMockRegisterRequestResult struct {
 	r  *core.RecordID
-	r1 error
+	r1 uint64
+	r2 error
 }
 
 //Expect specifies that invocation of ArtifactManager.RegisterRequest is expected from 1 to Infinity times
@@ -1991,14 +2320,14 @@ func (m *mArtifactManagerMockRegisterRequest) Expect(p context.Context, p1 core.
 }
 
 //Return specifies results of invocation of ArtifactManager.RegisterRequest
-func (m *mArtifactManagerMockRegisterRequest) Return(r *core.RecordID, r1 error) *ArtifactManagerMock {
+func (m *mArtifactManagerMockRegisterRequest) Return(r *core.RecordID, r1 uint64, r2 error) *ArtifactManagerMock {
 	m.mock.RegisterRequestFunc = nil
 	m.expectationSeries = nil
 
 	if m.mainExpectation == nil {
 		m.mainExpectation = &ArtifactManagerMockRegisterRequestExpectation{}
 	}
-	m.mainExpectation.result = &ArtifactManagerMockRegisterRequestResult{r, r1}
+	m.mainExpectation.result = &ArtifactManagerMockRegisterRequestResult{r, r1, r2}
 	return m.mock
 }
 
@@ -2013,12 +2342,12 @@ func (m *mArtifactManagerMockRegisterRequest) ExpectOnce(p context.Context, p1 c
 	return expectation
 }
 
-func (e *ArtifactManagerMockRegisterRequestExpectation) Return(r *core.RecordID, r1 error) {
-	e.result = &ArtifactManagerMockRegisterRequestResult{r, r1}
+func (e *ArtifactManagerMockRegisterRequestExpectation) Return(r *core.RecordID, r1 uint64, r2 error) {
+	e.result = &ArtifactManagerMockRegisterRequestResult{r, r1, r2}
 }
 
 //Set uses given function f as a mock of ArtifactManager.RegisterRequest method
-func (m *mArtifactManagerMockRegisterRequest) Set(f func(p context.Context, p1 core.RecordRef, p2 core.Parcel) (r *core.RecordID, r1 error)) *ArtifactManagerMock {
+func (m *mArtifactManagerMockRegisterRequest) Set(f func(p context.Context, p1 core.RecordRef, p2 core.Parcel) (r *core.RecordID, r1 uint64, r2 error)) *ArtifactManagerMock {
 	m.mainExpectation = nil
 	m.expectationSeries = nil
 
@@ -2027,7 +2356,7 @@ func (m *mArtifactManagerMockRegisterRequest) Set(f func(p context.Context, p1 c
 }
 
 //RegisterRequest implements github.com/insolar/insolar/core.ArtifactManager interface
-func (m *ArtifactManagerMock) RegisterRequest(p context.Context, p1 core.RecordRef, p2 core.Parcel) (r *core.RecordID, r1 error) {
+func (m *ArtifactManagerMock) RegisterRequest(p context.Context, p1 core.RecordRef, p2 core.Parcel) (r *core.RecordID, r1 uint64, r2 error) {
 	counter := atomic.AddUint64(&m.RegisterRequestPreCounter, 1)
 	defer atomic.AddUint64(&m.RegisterRequestCounter, 1)
 
@@ -2048,6 +2377,7 @@ func (m *ArtifactManagerMock) RegisterRequest(p context.Context, p1 core.RecordR
 
 		r = result.r
 		r1 = result.r1
+		r2 = result.r2
 
 		return
 	}
@@ -2066,6 +2396,7 @@ func (m *ArtifactManagerMock) RegisterRequest(p context.Context, p1 core.RecordR
 
 		r = result.r
 		r1 = result.r1
+		r2 = result.r2
 
 		return
 	}
@@ -2261,6 +2592,159 @@ func (m *ArtifactManagerMock) RegisterResultFinished() bool {
 	return true
 }
 
+type mArtifactManagerMockRegisterTimer struct {
+	mock              *ArtifactManagerMock
+	mainExpectation   *ArtifactManagerMockRegisterTimerExpectation
+	expectationSeries []*ArtifactManagerMockRegisterTimerExpectation
+}
+
+type ArtifactManagerMockRegisterTimerExpectation struct {
+	input  *ArtifactManagerMockRegisterTimerInput
+	result *ArtifactManagerMockRegisterTimerResult
+}
+
+type ArtifactManagerMockRegisterTimerInput struct {
+	p  context.Context
+	p1 core.RecordRef
+	p2 core.PulseNumber
+	p3 core.Message
+}
+
+type ArtifactManagerMockRegisterTimerResult struct {
+	r  *core.RecordID
+	r1 error
+}
+
+//Expect specifies that invocation of ArtifactManager.RegisterTimer is expected from 1 to Infinity times
+func (m *mArtifactManagerMockRegisterTimer) Expect(p context.Context, p1 core.RecordRef, p2 core.PulseNumber, p3 core.Message) *mArtifactManagerMockRegisterTimer {
+	m.mock.RegisterTimerFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ArtifactManagerMockRegisterTimerExpectation{}
+	}
+	m.mainExpectation.input = &ArtifactManagerMockRegisterTimerInput{p, p1, p2, p3}
+	return m
+}
+
+//Return specifies results of invocation of ArtifactManager.RegisterTimer
+func (m *mArtifactManagerMockRegisterTimer) Return(r *core.RecordID, r1 error) *ArtifactManagerMock {
+	m.mock.RegisterTimerFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ArtifactManagerMockRegisterTimerExpectation{}
+	}
+	m.mainExpectation.result = &ArtifactManagerMockRegisterTimerResult{r, r1}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of ArtifactManager.RegisterTimer is expected once
+func (m *mArtifactManagerMockRegisterTimer) ExpectOnce(p context.Context, p1 core.RecordRef, p2 core.PulseNumber, p3 core.Message) *ArtifactManagerMockRegisterTimerExpectation {
+	m.mock.RegisterTimerFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &ArtifactManagerMockRegisterTimerExpectation{}
+	expectation.input = &ArtifactManagerMockRegisterTimerInput{p, p1, p2, p3}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *ArtifactManagerMockRegisterTimerExpectation) Return(r *core.RecordID, r1 error) {
+	e.result = &ArtifactManagerMockRegisterTimerResult{r, r1}
+}
+
+//Set uses given function f as a mock of ArtifactManager.RegisterTimer method
+func (m *mArtifactManagerMockRegisterTimer) Set(f func(p context.Context, p1 core.RecordRef, p2 core.PulseNumber, p3 core.Message) (r *core.RecordID, r1 error)) *ArtifactManagerMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.RegisterTimerFunc = f
+	return m.mock
+}
+
+//RegisterTimer implements github.com/insolar/insolar/core.ArtifactManager interface
+func (m *ArtifactManagerMock) RegisterTimer(p context.Context, p1 core.RecordRef, p2 core.PulseNumber, p3 core.Message) (r *core.RecordID, r1 error) {
+	counter := atomic.AddUint64(&m.RegisterTimerPreCounter, 1)
+	defer atomic.AddUint64(&m.RegisterTimerCounter, 1)
+
+	if len(m.RegisterTimerMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.RegisterTimerMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to ArtifactManagerMock.RegisterTimer. %v %v %v %v", p, p1, p2, p3)
+			return
+		}
+
+		input := m.RegisterTimerMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, ArtifactManagerMockRegisterTimerInput{p, p1, p2, p3}, "ArtifactManager.RegisterTimer got unexpected parameters")
+
+		result := m.RegisterTimerMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the ArtifactManagerMock.RegisterTimer")
+			return
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.RegisterTimerMock.mainExpectation != nil {
+
+		input := m.RegisterTimerMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, ArtifactManagerMockRegisterTimerInput{p, p1, p2, p3}, "ArtifactManager.RegisterTimer got unexpected parameters")
+		}
+
+		result := m.RegisterTimerMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the ArtifactManagerMock.RegisterTimer")
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.RegisterTimerFunc == nil {
+		m.t.Fatalf("Unexpected call to ArtifactManagerMock.RegisterTimer. %v %v %v %v", p, p1, p2, p3)
+		return
+	}
+
+	return m.RegisterTimerFunc(p, p1, p2, p3)
+}
+
+//RegisterTimerMinimockCounter returns a count of ArtifactManagerMock.RegisterTimerFunc invocations
+func (m *ArtifactManagerMock) RegisterTimerMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.RegisterTimerCounter)
+}
+
+//RegisterTimerMinimockPreCounter returns the value of ArtifactManagerMock.RegisterTimer invocations
+func (m *ArtifactManagerMock) RegisterTimerMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.RegisterTimerPreCounter)
+}
+
+//RegisterTimerFinished returns true if mock invocations count is ok
+func (m *ArtifactManagerMock) RegisterTimerFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.RegisterTimerMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.RegisterTimerCounter) == uint64(len(m.RegisterTimerMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.RegisterTimerMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.RegisterTimerCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.RegisterTimerFunc != nil {
+		return atomic.LoadUint64(&m.RegisterTimerCounter) > 0
+	}
+
+	return true
+}
+
 type mArtifactManagerMockRegisterValidation struct {
 	mock              *ArtifactManagerMock
 	mainExpectation   *ArtifactManagerMockRegisterValidationExpectation
@@ -2902,10 +3386,18 @@ func (m *ArtifactManagerMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to ArtifactManagerMock.GetObject")
 	}
 
+	if !m.GetObjectsFinished() {
+		m.t.Fatal("Expected call to ArtifactManagerMock.GetObjects")
+	}
+
 	if !m.GetPendingRequestFinished() {
 		m.t.Fatal("Expected call to ArtifactManagerMock.GetPendingRequest")
 	}
 
+	if !m.GetResultFinished() {
+		m.t.Fatal("Expected call to ArtifactManagerMock.GetResult")
+	}
+
 	if !m.HasPendingRequestsFinished() {
 		m.t.Fatal("Expected call to ArtifactManagerMock.HasPendingRequests")
 	}
@@ -2918,6 +3410,10 @@ func (m *ArtifactManagerMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to ArtifactManagerMock.RegisterResult")
 	}
 
+	if !m.RegisterTimerFinished() {
+		m.t.Fatal("Expected call to ArtifactManagerMock.RegisterTimer")
+	}
+
 	if !m.RegisterValidationFinished() {
 		m.t.Fatal("Expected call to ArtifactManagerMock.RegisterValidation")
 	}
@@ -2991,10 +3487,18 @@ func (m *ArtifactManagerMock) MinimockFinish() {
 		m.t.Fatal("Expected call to ArtifactManagerMock.GetObject")
 	}
 
+	if !m.GetObjectsFinished() {
+		m.t.Fatal("Expected call to ArtifactManagerMock.GetObjects")
+	}
+
 	if !m.GetPendingRequestFinished() {
 		m.t.Fatal("Expected call to ArtifactManagerMock.GetPendingRequest")
 	}
 
+	if !m.GetResultFinished() {
+		m.t.Fatal("Expected call to ArtifactManagerMock.GetResult")
+	}
+
 	if !m.HasPendingRequestsFinished() {
 		m.t.Fatal("Expected call to ArtifactManagerMock.HasPendingRequests")
 	}
@@ -3007,6 +3511,10 @@ func (m *ArtifactManagerMock) MinimockFinish() {
 		m.t.Fatal("Expected call to ArtifactManagerMock.RegisterResult")
 	}
 
+	if !m.RegisterTimerFinished() {
+		m.t.Fatal("Expected call to ArtifactManagerMock.RegisterTimer")
+	}
+
 	if !m.RegisterValidationFinished() {
 		m.t.Fatal("Expected call to ArtifactManagerMock.RegisterValidation")
 	}
@@ -3047,10 +3555,13 @@ func (m *ArtifactManagerMock) MinimockWait(timeout time.Duration) {
 		ok = ok && m.GetCodeFinished()
 		ok = ok && m.GetDelegateFinished()
 		ok = ok && m.GetObjectFinished()
+		ok = ok && m.GetObjectsFinished()
 		ok = ok && m.GetPendingRequestFinished()
+		ok = ok && m.GetResultFinished()
 		ok = ok && m.HasPendingRequestsFinished()
 		ok = ok && m.RegisterRequestFinished()
 		ok = ok && m.RegisterResultFinished()
+		ok = ok && m.RegisterTimerFinished()
 		ok = ok && m.RegisterValidationFinished()
 		ok = ok && m.StateFinished()
 		ok = ok && m.UpdateObjectFinished()
@@ -3103,10 +3614,18 @@ func (m *ArtifactManagerMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to ArtifactManagerMock.GetObject")
 			}
 
+			if !m.GetObjectsFinished() {
+				m.t.Error("Expected call to ArtifactManagerMock.GetObjects")
+			}
+
 			if !m.GetPendingRequestFinished() {
 				m.t.Error("Expected call to ArtifactManagerMock.GetPendingRequest")
 			}
 
+			if !m.GetResultFinished() {
+				m.t.Error("Expected call to ArtifactManagerMock.GetResult")
+			}
+
 			if !m.HasPendingRequestsFinished() {
 				m.t.Error("Expected call to ArtifactManagerMock.HasPendingRequests")
 			}
@@ -3119,6 +3638,10 @@ func (m *ArtifactManagerMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to ArtifactManagerMock.RegisterResult")
 			}
 
+			if !m.RegisterTimerFinished() {
+				m.t.Error("Expected call to ArtifactManagerMock.RegisterTimer")
+			}
+
 			if !m.RegisterValidationFinished() {
 				m.t.Error("Expected call to ArtifactManagerMock.RegisterValidation")
 			}
@@ -3187,10 +3710,18 @@ func (m *ArtifactManagerMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.GetObjectsFinished() {
+		return false
+	}
+
 	if !m.GetPendingRequestFinished() {
 		return false
 	}
 
+	if !m.GetResultFinished() {
+		return false
+	}
+
 	if !m.HasPendingRequestsFinished() {
 		return false
 	}
@@ -3203,6 +3734,10 @@ func (m *ArtifactManagerMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.RegisterTimerFinished() {
+		return false
+	}
+
 	if !m.RegisterValidationFinished() {
 		return false
 	}
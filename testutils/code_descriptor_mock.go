@@ -31,6 +31,11 @@ type CodeDescriptorMock struct {
 	RefCounter    uint64
 	RefPreCounter uint64
 	RefMock       mCodeDescriptorMockRef
+
+	SchemaFunc       func() (r []byte)
+	SchemaCounter    uint64
+	SchemaPreCounter uint64
+	SchemaMock       mCodeDescriptorMockSchema
 }
 
 //NewCodeDescriptorMock returns a mock for github.com/insolar/insolar/core.CodeDescriptor
@@ -44,6 +49,7 @@ func NewCodeDescriptorMock(t minimock.Tester) *CodeDescriptorMock {
 	m.CodeMock = mCodeDescriptorMockCode{mock: m}
 	m.MachineTypeMock = mCodeDescriptorMockMachineType{mock: m}
 	m.RefMock = mCodeDescriptorMockRef{mock: m}
+	m.SchemaMock = mCodeDescriptorMockSchema{mock: m}
 
 	return m
 }
@@ -453,6 +459,140 @@ func (m *CodeDescriptorMock) RefFinished() bool {
 	return true
 }
 
+type mCodeDescriptorMockSchema struct {
+	mock              *CodeDescriptorMock
+	mainExpectation   *CodeDescriptorMockSchemaExpectation
+	expectationSeries []*CodeDescriptorMockSchemaExpectation
+}
+
+type CodeDescriptorMockSchemaExpectation struct {
+	result *CodeDescriptorMockSchemaResult
+}
+
+type CodeDescriptorMockSchemaResult struct {
+	r []byte
+}
+
+//Expect specifies that invocation of CodeDescriptor.Schema is expected from 1 to Infinity times
+func (m *mCodeDescriptorMockSchema) Expect() *mCodeDescriptorMockSchema {
+	m.mock.SchemaFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &CodeDescriptorMockSchemaExpectation{}
+	}
+
+	return m
+}
+
+//Return specifies results of invocation of CodeDescriptor.Schema
+func (m *mCodeDescriptorMockSchema) Return(r []byte) *CodeDescriptorMock {
+	m.mock.SchemaFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &CodeDescriptorMockSchemaExpectation{}
+	}
+	m.mainExpectation.result = &CodeDescriptorMockSchemaResult{r}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of CodeDescriptor.Schema is expected once
+func (m *mCodeDescriptorMockSchema) ExpectOnce() *CodeDescriptorMockSchemaExpectation {
+	m.mock.SchemaFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &CodeDescriptorMockSchemaExpectation{}
+
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *CodeDescriptorMockSchemaExpectation) Return(r []byte) {
+	e.result = &CodeDescriptorMockSchemaResult{r}
+}
+
+//Set uses given function f as a mock of CodeDescriptor.Schema method
+func (m *mCodeDescriptorMockSchema) Set(f func() (r []byte)) *CodeDescriptorMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.SchemaFunc = f
+	return m.mock
+}
+
+//Schema implements github.com/insolar/insolar/core.CodeDescriptor interface
+func (m *CodeDescriptorMock) Schema() (r []byte) {
+	counter := atomic.AddUint64(&m.SchemaPreCounter, 1)
+	defer atomic.AddUint64(&m.SchemaCounter, 1)
+
+	if len(m.SchemaMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.SchemaMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to CodeDescriptorMock.Schema.")
+			return
+		}
+
+		result := m.SchemaMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the CodeDescriptorMock.Schema")
+			return
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.SchemaMock.mainExpectation != nil {
+
+		result := m.SchemaMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the CodeDescriptorMock.Schema")
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.SchemaFunc == nil {
+		m.t.Fatalf("Unexpected call to CodeDescriptorMock.Schema.")
+		return
+	}
+
+	return m.SchemaFunc()
+}
+
+//SchemaMinimockCounter returns a count of CodeDescriptorMock.SchemaFunc invocations
+func (m *CodeDescriptorMock) SchemaMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.SchemaCounter)
+}
+
+//SchemaMinimockPreCounter returns the value of CodeDescriptorMock.Schema invocations
+func (m *CodeDescriptorMock) SchemaMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.SchemaPreCounter)
+}
+
+//SchemaFinished returns true if mock invocations count is ok
+func (m *CodeDescriptorMock) SchemaFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.SchemaMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.SchemaCounter) == uint64(len(m.SchemaMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.SchemaMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.SchemaCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.SchemaFunc != nil {
+		return atomic.LoadUint64(&m.SchemaCounter) > 0
+	}
+
+	return true
+}
+
 //ValidateCallCounters checks that all mocked methods of the interface have been called at least once
 //Deprecated: please use MinimockFinish method or use Finish method of minimock.Controller
 func (m *CodeDescriptorMock) ValidateCallCounters() {
@@ -469,6 +609,10 @@ func (m *CodeDescriptorMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to CodeDescriptorMock.Ref")
 	}
 
+	if !m.SchemaFinished() {
+		m.t.Fatal("Expected call to CodeDescriptorMock.Schema")
+	}
+
 }
 
 //CheckMocksCalled checks that all mocked methods of the interface have been called at least once
@@ -498,6 +642,10 @@ func (m *CodeDescriptorMock) MinimockFinish() {
 		m.t.Fatal("Expected call to CodeDescriptorMock.Ref")
 	}
 
+	if !m.SchemaFinished() {
+		m.t.Fatal("Expected call to CodeDescriptorMock.Schema")
+	}
+
 }
 
 //Wait waits for all mocked methods to be called at least once
@@ -515,6 +663,7 @@ func (m *CodeDescriptorMock) MinimockWait(timeout time.Duration) {
 		ok = ok && m.CodeFinished()
 		ok = ok && m.MachineTypeFinished()
 		ok = ok && m.RefFinished()
+		ok = ok && m.SchemaFinished()
 
 		if ok {
 			return
@@ -535,6 +684,10 @@ func (m *CodeDescriptorMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to CodeDescriptorMock.Ref")
 			}
 
+			if !m.SchemaFinished() {
+				m.t.Error("Expected call to CodeDescriptorMock.Schema")
+			}
+
 			m.t.Fatalf("Some mocks were not called on time: %s", timeout)
 			return
 		default:
@@ -559,5 +712,9 @@ func (m *CodeDescriptorMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.SchemaFinished() {
+		return false
+	}
+
 	return true
 }
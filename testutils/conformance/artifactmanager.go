@@ -0,0 +1,155 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package conformance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ArtifactManagerSetup builds artifact manager under test. Genesis object returned by GenesisRef must exist.
+type ArtifactManagerSetup func(t *testing.T) (context.Context, core.ArtifactManager)
+
+// ArtifactManager runs contract tests every core.ArtifactManager implementation must pass.
+func ArtifactManager(t *testing.T, setup ArtifactManagerSetup) {
+	domain := testutils.RandomRef()
+
+	t.Run("registers request", func(t *testing.T) {
+		ctx, am := setup(t)
+
+		id, _, err := am.RegisterRequest(ctx, *am.GenesisRef(), genesisParcel())
+		require.NoError(t, err)
+		require.NotNil(t, id)
+	})
+
+	t.Run("returns deployed code", func(t *testing.T) {
+		ctx, am := setup(t)
+		code := []byte(testutils.RandomString())
+
+		codeID, err := am.DeployCode(ctx, domain, testutils.RandomRef(), code, core.MachineTypeBuiltin, nil)
+		require.NoError(t, err)
+
+		desc, err := am.GetCode(ctx, *core.NewRecordRef(*domain.Record(), *codeID))
+		require.NoError(t, err)
+		received, err := desc.Code()
+		require.NoError(t, err)
+		assert.Equal(t, code, received)
+		assert.Equal(t, core.MachineTypeBuiltin, desc.MachineType())
+	})
+
+	t.Run("tracks object lifecycle", func(t *testing.T) {
+		ctx, am := setup(t)
+		objRef := registerObject(ctx, t, am, domain)
+		prototype := testutils.RandomRef()
+
+		activated, err := am.ActivateObject(ctx, domain, objRef, *am.GenesisRef(), prototype, false, []byte{1})
+		require.NoError(t, err)
+
+		desc, err := am.GetObject(ctx, objRef, nil, false)
+		require.NoError(t, err)
+		assert.Equal(t, objRef, *desc.HeadRef())
+		assert.Equal(t, *activated.StateID(), *desc.StateID())
+		assert.Equal(t, []byte{1}, desc.Memory())
+
+		updated, err := am.UpdateObject(ctx, domain, testutils.RandomRef(), desc, []byte{2})
+		require.NoError(t, err)
+		assert.NotEqual(t, *activated.StateID(), *updated.StateID())
+
+		desc, err = am.GetObject(ctx, objRef, nil, false)
+		require.NoError(t, err)
+		assert.Equal(t, *updated.StateID(), *desc.StateID())
+		assert.Equal(t, []byte{2}, desc.Memory())
+
+		desc, err = am.GetObject(ctx, objRef, activated.StateID(), false)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{1}, desc.Memory())
+
+		_, err = am.DeactivateObject(ctx, domain, testutils.RandomRef(), updated)
+		require.NoError(t, err)
+
+		_, err = am.GetObject(ctx, objRef, nil, false)
+		assert.Equal(t, core.ErrDeactivated, err)
+	})
+
+	t.Run("lists children of parent", func(t *testing.T) {
+		ctx, am := setup(t)
+		objRef := registerObject(ctx, t, am, domain)
+
+		_, err := am.ActivateObject(ctx, domain, objRef, *am.GenesisRef(), testutils.RandomRef(), false, []byte{1})
+		require.NoError(t, err)
+
+		it, err := am.GetChildren(ctx, *am.GenesisRef(), nil)
+		require.NoError(t, err)
+		found := false
+		for it.HasNext() {
+			child, err := it.Next()
+			require.NoError(t, err)
+			if *child == objRef {
+				found = true
+			}
+		}
+		assert.True(t, found, "activated object is not listed in children of its parent")
+	})
+
+	t.Run("returns delegate by type", func(t *testing.T) {
+		ctx, am := setup(t)
+		objRef := registerObject(ctx, t, am, domain)
+
+		_, err := am.ActivateObject(ctx, domain, objRef, *am.GenesisRef(), testutils.RandomRef(), false, []byte{1})
+		require.NoError(t, err)
+
+		delegateRef := registerObject(ctx, t, am, domain)
+		delegateType := testutils.RandomRef()
+		_, err = am.ActivateObject(ctx, domain, delegateRef, objRef, delegateType, true, []byte{2})
+		require.NoError(t, err)
+
+		got, err := am.GetDelegate(ctx, objRef, delegateType)
+		require.NoError(t, err)
+		assert.Equal(t, delegateRef, *got)
+	})
+
+	t.Run("returns registered result", func(t *testing.T) {
+		ctx, am := setup(t)
+		objRef := registerObject(ctx, t, am, domain)
+		requestID, _, err := am.RegisterRequest(ctx, objRef, genesisParcel())
+		require.NoError(t, err)
+		requestRef := *core.NewRecordRef(*domain.Record(), *requestID)
+
+		_, err = am.RegisterResult(ctx, objRef, requestRef, []byte{1, 2, 3})
+		require.NoError(t, err)
+
+		payload, err := am.GetResult(ctx, requestRef)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{1, 2, 3}, payload)
+	})
+}
+
+func genesisParcel() core.Parcel {
+	return &message.Parcel{Msg: &message.GenesisRequest{Name: testutils.RandomString()}}
+}
+
+func registerObject(ctx context.Context, t *testing.T, am core.ArtifactManager, domain core.RecordRef) core.RecordRef {
+	id, _, err := am.RegisterRequest(ctx, *am.GenesisRef(), genesisParcel())
+	require.NoError(t, err)
+	return *core.NewRecordRef(*domain.Record(), *id)
+}
@@ -0,0 +1,28 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+// Package conformance contains contract-test suites for core interfaces.
+//
+// Every implementation of a core interface (real component, caching layer, remote bridge) is expected
+// to pass the suite of the interface it implements. Suites receive a setup function that builds
+// a fresh implementation under test, so the same checks run against any of them:
+//
+//	func TestCachedArtifactManager(t *testing.T) {
+//	    conformance.ArtifactManager(t, func(t *testing.T) (context.Context, core.ArtifactManager) {
+//	        return ctx, newCachedArtifactManager(t)
+//	    })
+//	}
+package conformance
@@ -0,0 +1,142 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// JetCoordinatorSetup builds jet coordinator under test and returns pulse it has active nodes for.
+// There must be enough virtual and light material nodes to select validators and at least one heavy material node.
+type JetCoordinatorSetup func(t *testing.T) (context.Context, core.JetCoordinator, core.PulseNumber)
+
+// JetCoordinator runs contract tests every core.JetCoordinator implementation must pass.
+func JetCoordinator(t *testing.T, setup JetCoordinatorSetup) {
+	objects := func(pulse core.PulseNumber) []core.RecordID {
+		var ids []core.RecordID
+		for i := 0; i < 10; i++ {
+			ids = append(ids, *core.NewRecordID(pulse, []byte(fmt.Sprintf("conformance-%d", i))))
+		}
+		return ids
+	}
+
+	t.Run("virtual executor is authorized", func(t *testing.T) {
+		ctx, jc, pulse := setup(t)
+		for _, obj := range objects(pulse) {
+			executor, err := jc.VirtualExecutorForObject(ctx, obj, pulse)
+			require.NoError(t, err)
+
+			nodes, err := jc.QueryRole(ctx, core.DynamicRoleVirtualExecutor, obj, pulse)
+			require.NoError(t, err)
+			assert.Equal(t, []core.RecordRef{*executor}, nodes)
+
+			ok, err := jc.IsAuthorized(ctx, core.DynamicRoleVirtualExecutor, obj, pulse, *executor)
+			require.NoError(t, err)
+			assert.True(t, ok)
+		}
+	})
+
+	t.Run("light executor is authorized", func(t *testing.T) {
+		ctx, jc, pulse := setup(t)
+		for _, obj := range objects(pulse) {
+			executor, err := jc.LightExecutorForObject(ctx, obj, pulse)
+			require.NoError(t, err)
+
+			nodes, err := jc.QueryRole(ctx, core.DynamicRoleLightExecutor, obj, pulse)
+			require.NoError(t, err)
+			assert.Equal(t, []core.RecordRef{*executor}, nodes)
+
+			ok, err := jc.IsAuthorized(ctx, core.DynamicRoleLightExecutor, obj, pulse, *executor)
+			require.NoError(t, err)
+			assert.True(t, ok)
+		}
+	})
+
+	t.Run("validators are authorized and differ from executor", func(t *testing.T) {
+		ctx, jc, pulse := setup(t)
+		for _, obj := range objects(pulse) {
+			executor, err := jc.VirtualExecutorForObject(ctx, obj, pulse)
+			require.NoError(t, err)
+			validators, err := jc.VirtualValidatorsForObject(ctx, obj, pulse)
+			require.NoError(t, err)
+			require.NotEmpty(t, validators)
+			for _, v := range validators {
+				assert.NotEqual(t, *executor, v)
+				ok, err := jc.IsAuthorized(ctx, core.DynamicRoleVirtualValidator, obj, pulse, v)
+				require.NoError(t, err)
+				assert.True(t, ok)
+			}
+
+			executor, err = jc.LightExecutorForObject(ctx, obj, pulse)
+			require.NoError(t, err)
+			validators, err = jc.LightValidatorsForObject(ctx, obj, pulse)
+			require.NoError(t, err)
+			require.NotEmpty(t, validators)
+			for _, v := range validators {
+				assert.NotEqual(t, *executor, v)
+				ok, err := jc.IsAuthorized(ctx, core.DynamicRoleLightValidator, obj, pulse, v)
+				require.NoError(t, err)
+				assert.True(t, ok)
+			}
+		}
+	})
+
+	t.Run("selection is deterministic", func(t *testing.T) {
+		ctx, jc, pulse := setup(t)
+		for _, obj := range objects(pulse) {
+			first, err := jc.VirtualExecutorForObject(ctx, obj, pulse)
+			require.NoError(t, err)
+			second, err := jc.VirtualExecutorForObject(ctx, obj, pulse)
+			require.NoError(t, err)
+			assert.Equal(t, first, second)
+
+			first, err = jc.LightExecutorForObject(ctx, obj, pulse)
+			require.NoError(t, err)
+			second, err = jc.LightExecutorForObject(ctx, obj, pulse)
+			require.NoError(t, err)
+			assert.Equal(t, first, second)
+		}
+	})
+
+	t.Run("heavy is authorized", func(t *testing.T) {
+		ctx, jc, pulse := setup(t)
+		heavy, err := jc.Heavy(ctx, pulse)
+		require.NoError(t, err)
+		require.NotNil(t, heavy)
+
+		obj := objects(pulse)[0]
+		ok, err := jc.IsAuthorized(ctx, core.DynamicRoleHeavyExecutor, obj, pulse, *heavy)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("unknown node is not authorized", func(t *testing.T) {
+		ctx, jc, pulse := setup(t)
+		stranger := *core.NewRecordRef(core.DomainID, *core.NewRecordID(pulse, []byte("stranger")))
+		for _, role := range []core.DynamicRole{core.DynamicRoleVirtualExecutor, core.DynamicRoleLightExecutor} {
+			ok, err := jc.IsAuthorized(ctx, role, objects(pulse)[0], pulse, stranger)
+			require.NoError(t, err)
+			assert.False(t, ok)
+		}
+	})
+}
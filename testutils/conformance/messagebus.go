@@ -0,0 +1,78 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package conformance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MessageBusSetup builds message bus under test. Returned bus must have no registered handlers
+// and must be able to deliver messages to itself.
+type MessageBusSetup func(t *testing.T) (context.Context, core.MessageBus)
+
+// MessageBus runs contract tests every core.MessageBus implementation must pass.
+func MessageBus(t *testing.T, setup MessageBusSetup) {
+	noop := func(context.Context, core.Parcel) (core.Reply, error) {
+		return &reply.OK{}, nil
+	}
+
+	t.Run("rejects second handler for type", func(t *testing.T) {
+		_, mb := setup(t)
+
+		require.NoError(t, mb.Register(core.TypeBootstrapRequest, noop))
+		assert.Error(t, mb.Register(core.TypeBootstrapRequest, noop))
+	})
+
+	t.Run("panics on second mandatory handler for type", func(t *testing.T) {
+		_, mb := setup(t)
+
+		mb.MustRegister(core.TypeBootstrapRequest, noop)
+		assert.Panics(t, func() {
+			mb.MustRegister(core.TypeBootstrapRequest, noop)
+		})
+	})
+
+	t.Run("delivers message to registered handler", func(t *testing.T) {
+		ctx, mb := setup(t)
+		msg := &message.GenesisRequest{Name: "conformance"}
+
+		var received core.Message
+		mb.MustRegister(core.TypeBootstrapRequest, func(_ context.Context, parcel core.Parcel) (core.Reply, error) {
+			received = parcel.Message()
+			return &reply.ID{}, nil
+		})
+
+		rep, err := mb.Send(ctx, msg, nil)
+		require.NoError(t, err)
+		assert.Equal(t, &reply.ID{}, rep)
+		assert.Equal(t, msg, received)
+	})
+
+	t.Run("fails to send message without handler", func(t *testing.T) {
+		ctx, mb := setup(t)
+
+		_, err := mb.Send(ctx, &message.GenesisRequest{Name: "conformance"}, nil)
+		assert.Error(t, err)
+	})
+}
@@ -20,6 +20,11 @@ import (
 type ContractRequesterMock struct {
 	t minimock.Tester
 
+	CallByDelegateFunc       func(p context.Context, p1 *core.RecordRef, p2 *core.RecordRef, p3 string, p4 []interface{}) (r core.Reply, r1 error)
+	CallByDelegateCounter    uint64
+	CallByDelegatePreCounter uint64
+	CallByDelegateMock       mContractRequesterMockCallByDelegate
+
 	CallConstructorFunc       func(p context.Context, p1 core.Message, p2 bool, p3 *core.RecordRef, p4 *core.RecordRef, p5 string, p6 core.Arguments, p7 int) (r *core.RecordRef, r1 error)
 	CallConstructorCounter    uint64
 	CallConstructorPreCounter uint64
@@ -44,6 +49,7 @@ func NewContractRequesterMock(t minimock.Tester) *ContractRequesterMock {
 		controller.RegisterMocker(m)
 	}
 
+	m.CallByDelegateMock = mContractRequesterMockCallByDelegate{mock: m}
 	m.CallConstructorMock = mContractRequesterMockCallConstructor{mock: m}
 	m.CallMethodMock = mContractRequesterMockCallMethod{mock: m}
 	m.SendRequestMock = mContractRequesterMockSendRequest{mock: m}
@@ -51,6 +57,160 @@ func NewContractRequesterMock(t minimock.Tester) *ContractRequesterMock {
 	return m
 }
 
+type mContractRequesterMockCallByDelegate struct {
+	mock              *ContractRequesterMock
+	mainExpectation   *ContractRequesterMockCallByDelegateExpectation
+	expectationSeries []*ContractRequesterMockCallByDelegateExpectation
+}
+
+type ContractRequesterMockCallByDelegateExpectation struct {
+	input  *ContractRequesterMockCallByDelegateInput
+	result *ContractRequesterMockCallByDelegateResult
+}
+
+type ContractRequesterMockCallByDelegateInput struct {
+	p  context.Context
+	p1 *core.RecordRef
+	p2 *core.RecordRef
+	p3 string
+	p4 []interface{}
+}
+
+type ContractRequesterMockCallByDelegateResult struct {
+	r  core.Reply
+	r1 error
+}
+
+//Expect specifies that invocation of ContractRequester.CallByDelegate is expected from 1 to Infinity times
+func (m *mContractRequesterMockCallByDelegate) Expect(p context.Context, p1 *core.RecordRef, p2 *core.RecordRef, p3 string, p4 []interface{}) *mContractRequesterMockCallByDelegate {
+	m.mock.CallByDelegateFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ContractRequesterMockCallByDelegateExpectation{}
+	}
+	m.mainExpectation.input = &ContractRequesterMockCallByDelegateInput{p, p1, p2, p3, p4}
+	return m
+}
+
+//Return specifies results of invocation of ContractRequester.CallByDelegate
+func (m *mContractRequesterMockCallByDelegate) Return(r core.Reply, r1 error) *ContractRequesterMock {
+	m.mock.CallByDelegateFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ContractRequesterMockCallByDelegateExpectation{}
+	}
+	m.mainExpectation.result = &ContractRequesterMockCallByDelegateResult{r, r1}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of ContractRequester.CallByDelegate is expected once
+func (m *mContractRequesterMockCallByDelegate) ExpectOnce(p context.Context, p1 *core.RecordRef, p2 *core.RecordRef, p3 string, p4 []interface{}) *ContractRequesterMockCallByDelegateExpectation {
+	m.mock.CallByDelegateFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &ContractRequesterMockCallByDelegateExpectation{}
+	expectation.input = &ContractRequesterMockCallByDelegateInput{p, p1, p2, p3, p4}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *ContractRequesterMockCallByDelegateExpectation) Return(r core.Reply, r1 error) {
+	e.result = &ContractRequesterMockCallByDelegateResult{r, r1}
+}
+
+//Set uses given function f as a mock of ContractRequester.CallByDelegate method
+func (m *mContractRequesterMockCallByDelegate) Set(f func(p context.Context, p1 *core.RecordRef, p2 *core.RecordRef, p3 string, p4 []interface{}) (r core.Reply, r1 error)) *ContractRequesterMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.CallByDelegateFunc = f
+	return m.mock
+}
+
+//CallByDelegate implements github.com/insolar/insolar/core.ContractRequester interface
+func (m *ContractRequesterMock) CallByDelegate(p context.Context, p1 *core.RecordRef, p2 *core.RecordRef, p3 string, p4 []interface{}) (r core.Reply, r1 error) {
+	counter := atomic.AddUint64(&m.CallByDelegatePreCounter, 1)
+	defer atomic.AddUint64(&m.CallByDelegateCounter, 1)
+
+	if len(m.CallByDelegateMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.CallByDelegateMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to ContractRequesterMock.CallByDelegate. %v %v %v %v %v", p, p1, p2, p3, p4)
+			return
+		}
+
+		input := m.CallByDelegateMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, ContractRequesterMockCallByDelegateInput{p, p1, p2, p3, p4}, "ContractRequester.CallByDelegate got unexpected parameters")
+
+		result := m.CallByDelegateMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the ContractRequesterMock.CallByDelegate")
+			return
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.CallByDelegateMock.mainExpectation != nil {
+
+		input := m.CallByDelegateMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, ContractRequesterMockCallByDelegateInput{p, p1, p2, p3, p4}, "ContractRequester.CallByDelegate got unexpected parameters")
+		}
+
+		result := m.CallByDelegateMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the ContractRequesterMock.CallByDelegate")
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.CallByDelegateFunc == nil {
+		m.t.Fatalf("Unexpected call to ContractRequesterMock.CallByDelegate. %v %v %v %v %v", p, p1, p2, p3, p4)
+		return
+	}
+
+	return m.CallByDelegateFunc(p, p1, p2, p3, p4)
+}
+
+//CallByDelegateMinimockCounter returns a count of ContractRequesterMock.CallByDelegateFunc invocations
+func (m *ContractRequesterMock) CallByDelegateMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.CallByDelegateCounter)
+}
+
+//CallByDelegateMinimockPreCounter returns the value of ContractRequesterMock.CallByDelegate invocations
+func (m *ContractRequesterMock) CallByDelegateMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.CallByDelegatePreCounter)
+}
+
+//CallByDelegateFinished returns true if mock invocations count is ok
+func (m *ContractRequesterMock) CallByDelegateFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.CallByDelegateMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.CallByDelegateCounter) == uint64(len(m.CallByDelegateMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.CallByDelegateMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.CallByDelegateCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.CallByDelegateFunc != nil {
+		return atomic.LoadUint64(&m.CallByDelegateCounter) > 0
+	}
+
+	return true
+}
+
 type mContractRequesterMockCallConstructor struct {
 	mock              *ContractRequesterMock
 	mainExpectation   *ContractRequesterMockCallConstructorExpectation
@@ -521,6 +681,10 @@ func (m *ContractRequesterMock) SendRequestFinished() bool {
 //Deprecated: please use MinimockFinish method or use Finish method of minimock.Controller
 func (m *ContractRequesterMock) ValidateCallCounters() {
 
+	if !m.CallByDelegateFinished() {
+		m.t.Fatal("Expected call to ContractRequesterMock.CallByDelegate")
+	}
+
 	if !m.CallConstructorFinished() {
 		m.t.Fatal("Expected call to ContractRequesterMock.CallConstructor")
 	}
@@ -550,6 +714,10 @@ func (m *ContractRequesterMock) Finish() {
 //MinimockFinish checks that all mocked methods of the interface have been called at least once
 func (m *ContractRequesterMock) MinimockFinish() {
 
+	if !m.CallByDelegateFinished() {
+		m.t.Fatal("Expected call to ContractRequesterMock.CallByDelegate")
+	}
+
 	if !m.CallConstructorFinished() {
 		m.t.Fatal("Expected call to ContractRequesterMock.CallConstructor")
 	}
@@ -576,6 +744,7 @@ func (m *ContractRequesterMock) MinimockWait(timeout time.Duration) {
 	timeoutCh := time.After(timeout)
 	for {
 		ok := true
+		ok = ok && m.CallByDelegateFinished()
 		ok = ok && m.CallConstructorFinished()
 		ok = ok && m.CallMethodFinished()
 		ok = ok && m.SendRequestFinished()
@@ -587,6 +756,10 @@ func (m *ContractRequesterMock) MinimockWait(timeout time.Duration) {
 		select {
 		case <-timeoutCh:
 
+			if !m.CallByDelegateFinished() {
+				m.t.Error("Expected call to ContractRequesterMock.CallByDelegate")
+			}
+
 			if !m.CallConstructorFinished() {
 				m.t.Error("Expected call to ContractRequesterMock.CallConstructor")
 			}
@@ -611,6 +784,10 @@ func (m *ContractRequesterMock) MinimockWait(timeout time.Duration) {
 //it can be used with assert/require, i.e. assert.True(mock.AllMocksCalled())
 func (m *ContractRequesterMock) AllMocksCalled() bool {
 
+	if !m.CallByDelegateFinished() {
+		return false
+	}
+
 	if !m.CallConstructorFinished() {
 		return false
 	}
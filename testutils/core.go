@@ -142,6 +142,10 @@ func (m *cryptographySchemeMock) Verifier(publicKey crypto.PublicKey) core.Verif
 	panic("not implemented")
 }
 
+func (m *cryptographySchemeMock) BatchVerifier(workers int) core.BatchVerifier {
+	panic("not implemented")
+}
+
 func (m *cryptographySchemeMock) PublicKeySize() int {
 	panic("not implemented")
 }
@@ -0,0 +1,354 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+// Package devnet runs a network of in-process nodes with test pulsar.
+//
+// Devnet starts nodes with generated certificates, waits for consensus, runs scenario and shuts everything down
+// collecting artifacts. It is used by network integration tests and by cmd/devnet to run a local network by hand:
+//
+//	d := devnet.New(t, 3, 0)
+//	d.CollectArtifactsTo(dir)
+//	err := d.Run(func(d *devnet.Devnet) error {
+//	    return d.WaitForConsensus(2)
+//	})
+package devnet
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/gojuno/minimock"
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/log"
+	"github.com/insolar/insolar/network/servicenetwork"
+)
+
+const (
+	// DefaultPulseTimeMs is a pulse length of devnet, it is shorter than production one for faster tests
+	DefaultPulseTimeMs int32 = 5000
+
+	reqTimeoutMs int32 = 2000
+	pulseDelta   int32 = 5
+
+	setupTimeout = time.Second * 20
+)
+
+// Devnet is a network of in-process nodes with test pulsar.
+type Devnet struct {
+	t   minimock.Tester
+	ctx context.Context
+
+	BootstrapNodes []*Node
+	NetworkNodes   []*Node
+	PulseTimeMs    int32
+
+	pulsar servicenetwork.TestPulsar
+
+	// topology describes roles, links and partitions of nodes, nil means all nodes are connected directly
+	topology *Topology
+	started  time.Time
+
+	// artifactsDir is a directory logs and metrics snapshots are collected to, empty disables collection
+	artifactsDir string
+	logFile      *os.File
+}
+
+// New creates devnet of bootstrap and common nodes, mocks of external dependencies report to t.
+func New(t minimock.Tester, bootstrapCount, nodesCount int) *Devnet {
+	d := &Devnet{
+		t:              t,
+		ctx:            context.Background(),
+		BootstrapNodes: make([]*Node, 0, bootstrapCount),
+		NetworkNodes:   make([]*Node, 0, nodesCount),
+		PulseTimeMs:    DefaultPulseTimeMs,
+	}
+	for i := 0; i < bootstrapCount; i++ {
+		d.BootstrapNodes = append(d.BootstrapNodes, NewNode())
+	}
+	for i := 0; i < nodesCount; i++ {
+		d.NetworkNodes = append(d.NetworkNodes, NewNode())
+	}
+	return d
+}
+
+// CollectArtifactsTo makes devnet write log of the run and metrics snapshots of nodes to dir.
+func (d *Devnet) CollectArtifactsTo(dir string) {
+	d.artifactsDir = dir
+}
+
+// Context returns context nodes of devnet are started with.
+func (d *Devnet) Context() context.Context {
+	return d.ctx
+}
+
+// Nodes returns bootstrap nodes followed by common nodes.
+func (d *Devnet) Nodes() []*Node {
+	return append(append([]*Node{}, d.BootstrapNodes...), d.NetworkNodes...)
+}
+
+// Run starts devnet, runs scenario and stops devnet whatever scenario result is.
+func (d *Devnet) Run(scenario func(d *Devnet) error) error {
+	var result error
+	if err := d.Start(); err != nil {
+		result = multierror.Append(result, errors.Wrap(err, "failed to start devnet"))
+	} else if err := scenario(d); err != nil {
+		result = multierror.Append(result, errors.Wrap(err, "scenario failed"))
+	}
+	if err := d.Stop(); err != nil {
+		result = multierror.Append(result, err)
+	}
+	return result
+}
+
+// Start starts pulsar and bootstrap nodes, then joins common nodes and waits until all of them are active.
+func (d *Devnet) Start() error {
+	if d.artifactsDir != "" {
+		if err := os.MkdirAll(d.artifactsDir, 0755); err != nil {
+			return errors.Wrap(err, "failed to create artifacts directory")
+		}
+		logFile, err := os.Create(filepath.Join(d.artifactsDir, "devnet.log"))
+		if err != nil {
+			return errors.Wrap(err, "failed to create log file")
+		}
+		d.logFile = logFile
+		log.SetOutput(io.MultiWriter(os.Stderr, logFile))
+	}
+
+	var err error
+	d.started = time.Now()
+	d.pulsar, err = servicenetwork.NewTestPulsar(d.PulseTimeMs, reqTimeoutMs, pulseDelta)
+	if err != nil {
+		return err
+	}
+	pulseReceivers := make([]string, 0, len(d.BootstrapNodes))
+	for _, node := range d.BootstrapNodes {
+		pulseReceivers = append(pulseReceivers, node.Host)
+	}
+	log.Info("Start test pulsar")
+	if err := d.pulsar.Start(d.ctx, pulseReceivers); err != nil {
+		return err
+	}
+
+	log.Infoln("Setup bootstrap nodes")
+	if err := d.SetupNodes(d.BootstrapNodes); err != nil {
+		return errors.Wrap(err, "failed to setup bootstrap nodes")
+	}
+	<-time.After(time.Second * 2)
+	if err := d.checkActiveNodes(d.BootstrapNodes[0], len(d.BootstrapNodes)); err != nil {
+		return err
+	}
+
+	if len(d.NetworkNodes) > 0 {
+		log.Infoln("Setup network nodes")
+		if err := d.SetupNodes(d.NetworkNodes); err != nil {
+			return errors.Wrap(err, "failed to setup network nodes")
+		}
+		if err := d.WaitForConsensus(2); err != nil {
+			return err
+		}
+		for _, node := range []*Node{d.BootstrapNodes[0], d.NetworkNodes[0]} {
+			if err := d.checkActiveNodes(node, len(d.Nodes())); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *Devnet) checkActiveNodes(node *Node, expected int) error {
+	active := len(node.ServiceNetwork.NodeKeeper.GetActiveNodes())
+	if active != expected {
+		return errors.Errorf("node %s sees %d active nodes instead of %d", node.ID, active, expected)
+	}
+	return nil
+}
+
+// SetupNodes initializes and starts nodes concurrently.
+func (d *Devnet) SetupNodes(nodes []*Node) error {
+	for _, node := range nodes {
+		if err := d.PreInitNode(node); err != nil {
+			return err
+		}
+	}
+
+	results := make(chan error, len(nodes))
+	waitResults := func() error {
+		var result error
+		for range nodes {
+			select {
+			case err := <-results:
+				if err != nil {
+					result = multierror.Append(result, err)
+				}
+			case <-time.After(setupTimeout):
+				return multierror.Append(result, errors.New("timeout"))
+			}
+		}
+		return result
+	}
+
+	log.Infoln("Init nodes")
+	for _, node := range nodes {
+		go func(node *Node) {
+			results <- node.Init(d.ctx)
+		}(node)
+	}
+	if err := waitResults(); err != nil {
+		return err
+	}
+	if d.topology != nil {
+		for _, node := range nodes {
+			d.applyTopology(node)
+		}
+	}
+
+	log.Infoln("Start nodes")
+	for _, node := range nodes {
+		go func(node *Node) {
+			results <- node.ComponentManager.Start(d.ctx)
+		}(node)
+	}
+	return waitResults()
+}
+
+// WaitForConsensus waits for consensusCount consensus results of every node.
+func (d *Devnet) WaitForConsensus(consensusCount int) error {
+	return d.WaitForConsensusExcept(consensusCount, core.RecordRef{})
+}
+
+// WaitForConsensusExcept waits for consensusCount consensus results of every node except exception node.
+func (d *Devnet) WaitForConsensusExcept(consensusCount int, exception core.RecordRef) error {
+	var result error
+	timeout := d.consensusTimeout()
+	for i := 0; i < consensusCount; i++ {
+		for _, n := range d.Nodes() {
+			if n.ID.Equal(exception) {
+				continue
+			}
+			select {
+			case err := <-n.consensusResult:
+				if err != nil {
+					result = multierror.Append(result, errors.Wrapf(err, "consensus failed on node %s", n.ID))
+				}
+			case <-time.After(timeout):
+				return multierror.Append(result, errors.Errorf("no consensus on node %s in %s", n.ID, timeout))
+			}
+		}
+	}
+	return result
+}
+
+func (d *Devnet) consensusTimeout() time.Duration {
+	return time.Duration(d.PulseTimeMs) * time.Millisecond * 4
+}
+
+// Stop collects artifacts while nodes still have their state, then shuts down nodes and pulsar.
+func (d *Devnet) Stop() error {
+	log.Info("=================== Stop devnet")
+	var result error
+	if d.artifactsDir != "" {
+		if err := d.snapshotNodes(); err != nil {
+			result = multierror.Append(result, errors.Wrap(err, "failed to collect metrics snapshots"))
+		}
+	}
+
+	log.Infoln("Stop network nodes")
+	for _, n := range d.NetworkNodes {
+		if err := d.StopNode(n); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+	log.Infoln("Stop bootstrap nodes")
+	for _, n := range d.BootstrapNodes {
+		if err := d.StopNode(n); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+	if d.pulsar != nil {
+		log.Info("Stop test pulsar")
+		if err := d.pulsar.Stop(d.ctx); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+
+	if d.logFile != nil {
+		log.SetOutput(os.Stderr)
+		if err := d.logFile.Close(); err != nil {
+			result = multierror.Append(result, errors.Wrap(err, "failed to close log file"))
+		}
+		d.logFile = nil
+	}
+	return result
+}
+
+// StopNode stops components of the node if it was initialized.
+func (d *Devnet) StopNode(node *Node) error {
+	if node.ComponentManager == nil {
+		return nil
+	}
+	return errors.Wrapf(node.ComponentManager.Stop(d.ctx), "failed to stop node %s", node.ID)
+}
+
+// nodeSnapshot is a state of node saved to artifacts.
+type nodeSnapshot struct {
+	ID              string
+	Role            string
+	Host            string
+	ActiveNodes     int
+	WorkingNodes    int
+	ConsensusStreak int
+	Traffic         []core.PeerTraffic
+	PhaseTimings    []core.PhaseTimings
+	RTT             []core.RTTSummary
+}
+
+// snapshotNodes writes metrics snapshot of every started node to its own file.
+func (d *Devnet) snapshotNodes() error {
+	for i, n := range d.Nodes() {
+		if n.ServiceNetwork == nil {
+			continue
+		}
+		data, err := json.MarshalIndent(nodeSnapshot{
+			ID:              n.ID.String(),
+			Role:            n.Role.String(),
+			Host:            n.Host,
+			ActiveNodes:     len(n.ServiceNetwork.NodeKeeper.GetActiveNodes()),
+			WorkingNodes:    len(n.ServiceNetwork.NodeKeeper.GetWorkingNodes()),
+			ConsensusStreak: n.ServiceNetwork.ConsensusStreak(),
+			Traffic:         n.ServiceNetwork.GetTraffic(),
+			PhaseTimings:    n.ServiceNetwork.GetPhaseTimings(),
+			RTT:             n.ServiceNetwork.GetRTT(),
+		}, "", "  ")
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(d.artifactsDir, "node-"+strconv.Itoa(i)+".json")
+		if err := ioutil.WriteFile(path, data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
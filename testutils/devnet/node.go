@@ -0,0 +1,252 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package devnet
+
+import (
+	"context"
+	"crypto"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/certificate"
+	"github.com/insolar/insolar/component"
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/consensus/packets"
+	"github.com/insolar/insolar/consensus/phases"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/cryptography"
+	"github.com/insolar/insolar/log"
+	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/clockskew"
+	"github.com/insolar/insolar/network/nodenetwork"
+	"github.com/insolar/insolar/network/servicenetwork"
+	"github.com/insolar/insolar/network/utils"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+)
+
+var testNetworkPort = 10010
+
+// Node is an in-process node of devnet.
+type Node struct {
+	// Name and Group identify node in topology
+	Name  string
+	Group string
+	ID    core.RecordRef
+	Role  core.StaticRole
+	Host  string
+
+	ComponentManager *component.Manager
+	ServiceNetwork   *servicenetwork.ServiceNetwork
+
+	privateKey          crypto.PrivateKey
+	cryptographyService core.CryptographyService
+	consensusResult     chan error
+}
+
+// NewNode returns Node initialized only with id, random role, host address and key pair.
+func NewNode() *Node {
+	key, err := platformpolicy.NewKeyProcessor().GeneratePrivateKey()
+	if err != nil {
+		panic(err.Error())
+	}
+	address := "127.0.0.1:" + strconv.Itoa(testNetworkPort)
+	testNetworkPort += 2 // coz consensus transport port+=1
+
+	return &Node{
+		ID:                  testutils.RandomRef(),
+		Role:                randomRole(),
+		privateKey:          key,
+		cryptographyService: cryptography.NewKeyBoundCryptographyService(key),
+		Host:                address,
+		consensusResult:     make(chan error, 30),
+	}
+}
+
+// Init calls Init for node component manager and wraps PhaseManager to collect consensus results.
+func (n *Node) Init(ctx context.Context) error {
+	err := n.ComponentManager.Init(ctx)
+	n.ServiceNetwork.PhaseManager = &phaseManagerWrapper{
+		original: n.ServiceNetwork.PhaseManager,
+		result:   n.consensusResult,
+	}
+	n.ServiceNetwork.NodeKeeper = &nodeKeeperWrapper{original: n.ServiceNetwork.NodeKeeper}
+	return err
+}
+
+// Phases returns consensus phases of initialized node, tests replace their communicators to inject failures.
+func (n *Node) Phases() *phases.Phases {
+	return n.ServiceNetwork.PhaseManager.(*phaseManagerWrapper).original.(*phases.Phases)
+}
+
+// SetPhaseManager replaces phase manager of initialized node keeping consensus results collected.
+func (n *Node) SetPhaseManager(pm phases.PhaseManager) {
+	n.ServiceNetwork.PhaseManager.(*phaseManagerWrapper).original = pm
+}
+
+// WipeNodeKeeper removes all active nodes from node keeper of initialized node.
+func (n *Node) WipeNodeKeeper(isDiscovery bool) {
+	n.ServiceNetwork.NodeKeeper.(*nodeKeeperWrapper).Wipe(isDiscovery)
+}
+
+// PreInitNode inits previously created node with mocks and external dependencies.
+func (d *Devnet) PreInitNode(node *Node) error {
+	cfg := configuration.NewConfiguration()
+	cfg.Pulsar.PulseTime = d.PulseTimeMs
+	cfg.Host.Transport.Address = node.Host
+	cfg.Service.Skip = 5
+
+	node.ComponentManager = &component.Manager{}
+	node.ComponentManager.Register(platformpolicy.NewPlatformCryptographyScheme())
+	serviceNetwork, err := servicenetwork.NewServiceNetwork(cfg, node.ComponentManager, false)
+	if err != nil {
+		return err
+	}
+
+	netCoordinator := testutils.NewNetworkCoordinatorMock(d.t)
+	netCoordinator.ValidateCertMock.Set(func(p context.Context, p1 core.AuthorizationCertificate) (bool, error) {
+		return true, nil
+	})
+
+	netCoordinator.IsStartedMock.Set(func() (r bool) {
+		return true
+	})
+
+	amMock := testutils.NewArtifactManagerMock(d.t)
+	amMock.StateMock.Set(func() (r []byte, r1 error) {
+		return make([]byte, packets.HashLength), nil
+	})
+
+	pubKey, _ := node.cryptographyService.GetPublicKey()
+
+	origin := nodenetwork.NewNode(node.ID, node.Role, pubKey, node.Host, "")
+	certManager, cryptographyService, err := d.initCrypto(node)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate certificate")
+	}
+
+	realKeeper := nodenetwork.NewNodeKeeper(origin)
+	terminationHandler := &terminationHandler{NodeID: origin.ID()}
+
+	realKeeper.SetState(core.WaitingNodeNetworkState)
+	if len(certManager.GetCertificate().GetDiscoveryNodes()) == 0 || utils.OriginIsDiscovery(certManager.GetCertificate()) {
+		realKeeper.SetState(core.ReadyNodeNetworkState)
+		realKeeper.AddActiveNodes([]core.Node{origin})
+	}
+
+	clockSkewMonitor := clockskew.NewMonitor(cfg.ClockSkew)
+
+	node.ComponentManager.Register(terminationHandler, realKeeper, newPulseManagerMock(realKeeper), netCoordinator, amMock, clockSkewMonitor)
+	node.ComponentManager.Register(certManager, cryptographyService)
+	node.ComponentManager.Inject(serviceNetwork, servicenetwork.NewTestNetworkSwitcher())
+	node.ServiceNetwork = serviceNetwork
+	return nil
+}
+
+// initCrypto generates certificate of node with bootstrap nodes of devnet as discovery nodes.
+func (d *Devnet) initCrypto(node *Node) (*certificate.CertificateManager, core.CryptographyService, error) {
+	pubKey, err := node.cryptographyService.GetPublicKey()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// init certificate
+
+	proc := platformpolicy.NewKeyProcessor()
+	publicKey, err := proc.ExportPublicKeyPEM(pubKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cert := &certificate.Certificate{}
+	cert.PublicKey = string(publicKey[:])
+	cert.Reference = node.ID.String()
+	cert.Role = node.Role.String()
+	cert.BootstrapNodes = make([]certificate.BootstrapNode, 0)
+
+	for _, b := range d.BootstrapNodes {
+		pubKey, _ := b.cryptographyService.GetPublicKey()
+		pubKeyBuf, err := proc.ExportPublicKeyPEM(pubKey)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		bootstrapNode := certificate.NewBootstrapNode(
+			pubKey,
+			string(pubKeyBuf[:]),
+			b.Host,
+			b.ID.String())
+
+		cert.BootstrapNodes = append(cert.BootstrapNodes, *bootstrapNode)
+	}
+
+	// dump cert and read it again from json for correct private files initialization
+	jsonCert, err := cert.Dump()
+	if err != nil {
+		return nil, nil, err
+	}
+	log.Infof("cert: %s", jsonCert)
+
+	cert, err = certificate.ReadCertificateFromReader(pubKey, proc, strings.NewReader(jsonCert))
+	if err != nil {
+		return nil, nil, err
+	}
+	return certificate.NewCertificateManager(cert), node.cryptographyService, nil
+}
+
+func randomRole() core.StaticRole {
+	i := rand.Int()%3 + 1
+	return core.StaticRole(i)
+}
+
+type terminationHandler struct {
+	NodeID core.RecordRef
+}
+
+func (t *terminationHandler) Abort() {
+	log.Errorf("Abort node: %s", t.NodeID)
+}
+
+type pulseManagerMock struct {
+	pulse core.Pulse
+	lock  sync.Mutex
+
+	keeper network.NodeKeeper
+}
+
+func newPulseManagerMock(keeper network.NodeKeeper) *pulseManagerMock {
+	return &pulseManagerMock{pulse: *core.GenesisPulse, keeper: keeper}
+}
+
+func (p *pulseManagerMock) Current(ctx context.Context) (*core.Pulse, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return &p.pulse, nil
+}
+
+func (p *pulseManagerMock) Set(ctx context.Context, pulse core.Pulse, persist bool) error {
+	p.lock.Lock()
+	p.pulse = pulse
+	p.lock.Unlock()
+
+	return p.keeper.MoveSyncToActive(ctx)
+}
@@ -0,0 +1,357 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package devnet
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"strconv"
+	"time"
+
+	"github.com/gojuno/minimock"
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/consensus/packets"
+	"github.com/insolar/insolar/consensus/phases"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/network"
+)
+
+// Topology is a declarative description of devnet. It lets failure scenarios (asymmetric partitions, slow links)
+// be written as data files and shared by test suites.
+type Topology struct {
+	// PulseTimeMs is a pulse length of the network, default one is used when it is zero
+	PulseTimeMs int32               `json:"pulse_time_ms"`
+	Groups      []TopologyGroup     `json:"groups"`
+	Links       []TopologyLink      `json:"links"`
+	Partitions  []TopologyPartition `json:"partitions"`
+}
+
+// TopologyGroup is a set of nodes with the same role. Nodes are named by group name and index, e.g. "virtual-0".
+type TopologyGroup struct {
+	Name      string `json:"name"`
+	Role      string `json:"role"`
+	Count     int    `json:"count"`
+	Discovery bool   `json:"discovery"`
+}
+
+// TopologyRoute selects packets sent from nodes of From to nodes of To. Both lists contain group or node names.
+type TopologyRoute struct {
+	From      []string `json:"from"`
+	To        []string `json:"to"`
+	Symmetric bool     `json:"symmetric"`
+}
+
+// TopologyLink delays packets of the route.
+type TopologyLink struct {
+	TopologyRoute
+	LatencyMs int `json:"latency_ms"`
+}
+
+// TopologyPartition drops packets of the route during pulses [FromPulse, ToPulse) counted from devnet start,
+// zero ToPulse means the partition lasts till the end of the run.
+type TopologyPartition struct {
+	TopologyRoute
+	FromPulse int `json:"from_pulse"`
+	ToPulse   int `json:"to_pulse"`
+}
+
+// LoadTopology reads topology from json file and validates it.
+func LoadTopology(path string) (*Topology, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read topology")
+	}
+	t := &Topology{}
+	if err := json.Unmarshal(data, t); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse topology %s", path)
+	}
+	if err := t.validate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid topology %s", path)
+	}
+	return t, nil
+}
+
+func (t *Topology) validate() error {
+	if t.PulseTimeMs < 0 {
+		return errors.New("negative pulse time")
+	}
+	names := map[string]bool{}
+	discovery := false
+	for _, g := range t.Groups {
+		if g.Name == "" {
+			return errors.New("group without name")
+		}
+		if core.GetStaticRoleFromString(g.Role) == core.StaticRoleUnknown {
+			return errors.Errorf("unknown role %q of group %s", g.Role, g.Name)
+		}
+		if g.Count <= 0 {
+			return errors.Errorf("group %s has no nodes", g.Name)
+		}
+		for _, name := range append(g.nodeNames(), g.Name) {
+			if names[name] {
+				return errors.Errorf("duplicate name %s", name)
+			}
+			names[name] = true
+		}
+		discovery = discovery || g.Discovery
+	}
+	if !discovery {
+		return errors.New("no discovery group")
+	}
+
+	routes := make([]TopologyRoute, 0, len(t.Links)+len(t.Partitions))
+	for _, l := range t.Links {
+		if l.LatencyMs < 0 {
+			return errors.New("negative link latency")
+		}
+		routes = append(routes, l.TopologyRoute)
+	}
+	for _, p := range t.Partitions {
+		if p.FromPulse < 0 || (p.ToPulse != 0 && p.ToPulse <= p.FromPulse) {
+			return errors.Errorf("invalid partition pulses [%d, %d)", p.FromPulse, p.ToPulse)
+		}
+		routes = append(routes, p.TopologyRoute)
+	}
+	for _, r := range routes {
+		for _, name := range append(append([]string{}, r.From...), r.To...) {
+			if !names[name] {
+				return errors.Errorf("unknown group or node %s", name)
+			}
+		}
+	}
+	return nil
+}
+
+func (g TopologyGroup) nodeNames() []string {
+	names := make([]string, 0, g.Count)
+	for i := 0; i < g.Count; i++ {
+		names = append(names, g.Name+"-"+strconv.Itoa(i))
+	}
+	return names
+}
+
+// matches checks if packets from sender to receiver belong to the route.
+func (r TopologyRoute) matches(sender, receiver *Node) bool {
+	if selected(r.From, sender) && selected(r.To, receiver) {
+		return true
+	}
+	return r.Symmetric && selected(r.From, receiver) && selected(r.To, sender)
+}
+
+func selected(names []string, node *Node) bool {
+	for _, name := range names {
+		if name == node.Name || name == node.Group {
+			return true
+		}
+	}
+	return false
+}
+
+// route returns latency of packets sent from sender to receiver at elapsed time after devnet start. It returns false
+// if the packets are dropped by partition. Latencies of several matching links are summed.
+func (t *Topology) route(sender, receiver *Node, elapsed, pulseTime time.Duration) (time.Duration, bool) {
+	pulse := int(elapsed / pulseTime)
+	for _, p := range t.Partitions {
+		if p.matches(sender, receiver) && pulse >= p.FromPulse && (p.ToPulse == 0 || pulse < p.ToPulse) {
+			return 0, false
+		}
+	}
+	var latency time.Duration
+	for _, l := range t.Links {
+		if l.matches(sender, receiver) {
+			latency += time.Duration(l.LatencyMs) * time.Millisecond
+		}
+	}
+	return latency, true
+}
+
+// NewFromTopology creates devnet with nodes of topology groups, discovery groups become bootstrap nodes.
+func NewFromTopology(t minimock.Tester, topo *Topology) *Devnet {
+	d := New(t, 0, 0)
+	d.topology = topo
+	if topo.PulseTimeMs != 0 {
+		d.PulseTimeMs = topo.PulseTimeMs
+	}
+	for _, g := range topo.Groups {
+		for _, name := range g.nodeNames() {
+			node := NewNode()
+			node.Name = name
+			node.Group = g.Name
+			node.Role = core.GetStaticRoleFromString(g.Role)
+			if g.Discovery {
+				d.BootstrapNodes = append(d.BootstrapNodes, node)
+			} else {
+				d.NetworkNodes = append(d.NetworkNodes, node)
+			}
+		}
+	}
+	return d
+}
+
+// applyTopology makes consensus of initialized node receive packets according to devnet topology.
+func (d *Devnet) applyTopology(node *Node) {
+	phaseManager := node.Phases()
+	first := phaseManager.FirstPhase.(*phases.FirstPhaseImpl)
+	communicator := &topologyCommunicator{communicator: first.Communicator, devnet: d, receiver: node}
+	first.Communicator = communicator
+	phaseManager.SecondPhase.(*phases.SecondPhaseImpl).Communicator = communicator
+	phaseManager.ThirdPhase.(*phases.ThirdPhaseImpl).Communicator = communicator
+}
+
+func (d *Devnet) nodeByID(id core.RecordRef) *Node {
+	for _, node := range d.Nodes() {
+		if node.ID.Equal(id) {
+			return node
+		}
+	}
+	return nil
+}
+
+// topologyCommunicator delays and drops consensus packets received by node according to devnet topology.
+type topologyCommunicator struct {
+	communicator phases.Communicator
+	devnet       *Devnet
+	receiver     *Node
+}
+
+// deliver waits until packets of senders arrive through their links and returns senders which packets arrived.
+// Packets which latency exceeds deadline of ctx are lost.
+func (tc *topologyCommunicator) deliver(
+	ctx context.Context,
+	started time.Time,
+	senders []core.RecordRef,
+) map[core.RecordRef]bool {
+	elapsed := started.Sub(tc.devnet.started)
+	pulseTime := time.Duration(tc.devnet.PulseTimeMs) * time.Millisecond
+	latencies := make(map[core.RecordRef]time.Duration, len(senders))
+	var longest time.Duration
+	for _, id := range senders {
+		sender := tc.devnet.nodeByID(id)
+		if sender == nil || sender == tc.receiver {
+			latencies[id] = 0
+			continue
+		}
+		latency, ok := tc.devnet.topology.route(sender, tc.receiver, elapsed, pulseTime)
+		if !ok {
+			continue
+		}
+		latencies[id] = latency
+		if latency > longest {
+			longest = latency
+		}
+	}
+
+	select {
+	case <-time.After(time.Until(started.Add(longest))):
+	case <-ctx.Done():
+	}
+	waited := time.Since(started)
+	delivered := make(map[core.RecordRef]bool, len(latencies))
+	for id, latency := range latencies {
+		if latency <= waited {
+			delivered[id] = true
+		}
+	}
+	return delivered
+}
+
+func (tc *topologyCommunicator) ExchangePhase1(
+	ctx context.Context,
+	originClaim *packets.NodeAnnounceClaim,
+	participants []core.Node,
+	packet *packets.Phase1Packet,
+) (map[core.RecordRef]*packets.Phase1Packet, error) {
+	started := time.Now()
+	pckts, err := tc.communicator.ExchangePhase1(ctx, originClaim, participants, packet)
+	if err != nil {
+		return nil, err
+	}
+	senders := make([]core.RecordRef, 0, len(pckts))
+	for id := range pckts {
+		senders = append(senders, id)
+	}
+	delivered := tc.deliver(ctx, started, senders)
+	for id := range pckts {
+		if !delivered[id] {
+			delete(pckts, id)
+		}
+	}
+	return pckts, nil
+}
+
+func (tc *topologyCommunicator) ExchangePhase2(
+	ctx context.Context,
+	list network.UnsyncList,
+	participants []core.Node,
+	packet *packets.Phase2Packet,
+) (map[core.RecordRef]*packets.Phase2Packet, error) {
+	started := time.Now()
+	pckts, err := tc.communicator.ExchangePhase2(ctx, list, participants, packet)
+	if err != nil {
+		return nil, err
+	}
+	senders := make([]core.RecordRef, 0, len(pckts))
+	for id := range pckts {
+		senders = append(senders, id)
+	}
+	delivered := tc.deliver(ctx, started, senders)
+	for id := range pckts {
+		if !delivered[id] {
+			delete(pckts, id)
+		}
+	}
+	return pckts, nil
+}
+
+func (tc *topologyCommunicator) ExchangePhase21(
+	ctx context.Context,
+	list network.UnsyncList,
+	packet *packets.Phase2Packet,
+	additionalRequests []*phases.AdditionalRequest,
+) ([]packets.ReferendumVote, error) {
+	return tc.communicator.ExchangePhase21(ctx, list, packet, additionalRequests)
+}
+
+func (tc *topologyCommunicator) ExchangePhase3(
+	ctx context.Context,
+	participants []core.Node,
+	packet *packets.Phase3Packet,
+) (map[core.RecordRef]*packets.Phase3Packet, error) {
+	started := time.Now()
+	pckts, err := tc.communicator.ExchangePhase3(ctx, participants, packet)
+	if err != nil {
+		return nil, err
+	}
+	senders := make([]core.RecordRef, 0, len(pckts))
+	for id := range pckts {
+		senders = append(senders, id)
+	}
+	delivered := tc.deliver(ctx, started, senders)
+	for id := range pckts {
+		if !delivered[id] {
+			delete(pckts, id)
+		}
+	}
+	return pckts, nil
+}
+
+func (tc *topologyCommunicator) Init(ctx context.Context) error {
+	return tc.communicator.Init(ctx)
+}
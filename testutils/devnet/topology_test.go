@@ -0,0 +1,86 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package devnet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+)
+
+func TestLoadTopology(t *testing.T) {
+	topo, err := LoadTopology("testdata/topology/slow_links.json")
+	require.NoError(t, err)
+
+	d := NewFromTopology(t, topo)
+	require.Len(t, d.BootstrapNodes, 3)
+	require.Len(t, d.NetworkNodes, 2)
+	assert.Equal(t, int32(5000), d.PulseTimeMs)
+	assert.Equal(t, "discovery-2", d.BootstrapNodes[2].Name)
+	assert.Equal(t, core.StaticRoleVirtual, d.BootstrapNodes[0].Role)
+	assert.Equal(t, "light-1", d.NetworkNodes[1].Name)
+	assert.Equal(t, core.StaticRoleLightMaterial, d.NetworkNodes[1].Role)
+}
+
+func TestLoadTopology_Invalid(t *testing.T) {
+	discovery := TopologyGroup{Name: "a", Role: "virtual", Count: 1, Discovery: true}
+	for name, topo := range map[string]Topology{
+		"no discovery": {Groups: []TopologyGroup{{Name: "a", Role: "virtual", Count: 1}}},
+		"unknown role": {Groups: []TopologyGroup{{Name: "a", Role: "pulsar", Count: 1, Discovery: true}}},
+		"duplicate":    {Groups: []TopologyGroup{discovery, {Name: "a-0", Role: "virtual", Count: 1}}},
+		"unknown group": {
+			Groups: []TopologyGroup{discovery},
+			Links:  []TopologyLink{{TopologyRoute: TopologyRoute{From: []string{"b"}}}},
+		},
+		"bad pulses": {
+			Groups:     []TopologyGroup{discovery},
+			Partitions: []TopologyPartition{{FromPulse: 3, ToPulse: 2}},
+		},
+	} {
+		assert.Error(t, topo.validate(), name)
+	}
+}
+
+func TestTopology_Route(t *testing.T) {
+	topo, err := LoadTopology("testdata/topology/asymmetric_partition.json")
+	require.NoError(t, err)
+	d := NewFromTopology(t, topo)
+	n := d.BootstrapNodes
+	pulse := time.Duration(topo.PulseTimeMs) * time.Millisecond
+
+	latency, ok := topo.route(n[0], n[1], 0, pulse)
+	assert.True(t, ok)
+	assert.Equal(t, 20*time.Millisecond, latency)
+
+	_, ok = topo.route(n[0], n[1], 7*pulse, pulse)
+	assert.False(t, ok)
+	_, ok = topo.route(n[0], n[2], 6*pulse, pulse)
+	assert.False(t, ok)
+
+	// partition is asymmetric and heals at to_pulse
+	_, ok = topo.route(n[1], n[0], 7*pulse, pulse)
+	assert.True(t, ok)
+	_, ok = topo.route(n[0], n[3], 7*pulse, pulse)
+	assert.True(t, ok)
+	_, ok = topo.route(n[0], n[1], 9*pulse, pulse)
+	assert.True(t, ok)
+}
@@ -15,7 +15,7 @@
  *
  */
 
-package servicenetwork
+package devnet
 
 import (
 	"context"
@@ -20,17 +20,32 @@ import (
 type HeavySyncMock struct {
 	t minimock.Tester
 
+	CongestionFunc       func(p context.Context) (r core.HeavyCongestion)
+	CongestionCounter    uint64
+	CongestionPreCounter uint64
+	CongestionMock       mHeavySyncMockCongestion
+
+	ProgressFunc       func(p context.Context) (r []core.HeavySyncProgress)
+	ProgressCounter    uint64
+	ProgressPreCounter uint64
+	ProgressMock       mHeavySyncMockProgress
+
 	ResetFunc       func(p context.Context, p1 core.RecordID, p2 core.PulseNumber) (r error)
 	ResetCounter    uint64
 	ResetPreCounter uint64
 	ResetMock       mHeavySyncMockReset
 
+	ResumeFunc       func(p context.Context, p1 core.RecordID, p2 core.PulseNumber) (r int, r1 error)
+	ResumeCounter    uint64
+	ResumePreCounter uint64
+	ResumeMock       mHeavySyncMockResume
+
 	StartFunc       func(p context.Context, p1 core.RecordID, p2 core.PulseNumber) (r error)
 	StartCounter    uint64
 	StartPreCounter uint64
 	StartMock       mHeavySyncMockStart
 
-	StopFunc       func(p context.Context, p1 core.RecordID, p2 core.PulseNumber) (r error)
+	StopFunc       func(p context.Context, p1 core.RecordID, p2 core.PulseNumber, p3 core.RecordRef) (r error)
 	StopCounter    uint64
 	StopPreCounter uint64
 	StopMock       mHeavySyncMockStop
@@ -49,7 +64,10 @@ func NewHeavySyncMock(t minimock.Tester) *HeavySyncMock {
 		controller.RegisterMocker(m)
 	}
 
+	m.CongestionMock = mHeavySyncMockCongestion{mock: m}
+	m.ProgressMock = mHeavySyncMockProgress{mock: m}
 	m.ResetMock = mHeavySyncMockReset{mock: m}
+	m.ResumeMock = mHeavySyncMockResume{mock: m}
 	m.StartMock = mHeavySyncMockStart{mock: m}
 	m.StopMock = mHeavySyncMockStop{mock: m}
 	m.StoreMock = mHeavySyncMockStore{mock: m}
@@ -57,6 +75,300 @@ func NewHeavySyncMock(t minimock.Tester) *HeavySyncMock {
 	return m
 }
 
+type mHeavySyncMockCongestion struct {
+	mock              *HeavySyncMock
+	mainExpectation   *HeavySyncMockCongestionExpectation
+	expectationSeries []*HeavySyncMockCongestionExpectation
+}
+
+type HeavySyncMockCongestionExpectation struct {
+	input  *HeavySyncMockCongestionInput
+	result *HeavySyncMockCongestionResult
+}
+
+type HeavySyncMockCongestionInput struct {
+	p context.Context
+}
+
+type HeavySyncMockCongestionResult struct {
+	r core.HeavyCongestion
+}
+
+//Expect specifies that invocation of HeavySync.Congestion is expected from 1 to Infinity times
+func (m *mHeavySyncMockCongestion) Expect(p context.Context) *mHeavySyncMockCongestion {
+	m.mock.CongestionFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &HeavySyncMockCongestionExpectation{}
+	}
+	m.mainExpectation.input = &HeavySyncMockCongestionInput{p}
+	return m
+}
+
+//Return specifies results of invocation of HeavySync.Congestion
+func (m *mHeavySyncMockCongestion) Return(r core.HeavyCongestion) *HeavySyncMock {
+	m.mock.CongestionFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &HeavySyncMockCongestionExpectation{}
+	}
+	m.mainExpectation.result = &HeavySyncMockCongestionResult{r}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of HeavySync.Congestion is expected once
+func (m *mHeavySyncMockCongestion) ExpectOnce(p context.Context) *HeavySyncMockCongestionExpectation {
+	m.mock.CongestionFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &HeavySyncMockCongestionExpectation{}
+	expectation.input = &HeavySyncMockCongestionInput{p}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *HeavySyncMockCongestionExpectation) Return(r core.HeavyCongestion) {
+	e.result = &HeavySyncMockCongestionResult{r}
+}
+
+//Set uses given function f as a mock of HeavySync.Congestion method
+func (m *mHeavySyncMockCongestion) Set(f func(p context.Context) (r core.HeavyCongestion)) *HeavySyncMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.CongestionFunc = f
+	return m.mock
+}
+
+//Congestion implements github.com/insolar/insolar/core.HeavySync interface
+func (m *HeavySyncMock) Congestion(p context.Context) (r core.HeavyCongestion) {
+	counter := atomic.AddUint64(&m.CongestionPreCounter, 1)
+	defer atomic.AddUint64(&m.CongestionCounter, 1)
+
+	if len(m.CongestionMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.CongestionMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to HeavySyncMock.Congestion. %v", p)
+			return
+		}
+
+		input := m.CongestionMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, HeavySyncMockCongestionInput{p}, "HeavySync.Congestion got unexpected parameters")
+
+		result := m.CongestionMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the HeavySyncMock.Congestion")
+			return
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.CongestionMock.mainExpectation != nil {
+
+		input := m.CongestionMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, HeavySyncMockCongestionInput{p}, "HeavySync.Congestion got unexpected parameters")
+		}
+
+		result := m.CongestionMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the HeavySyncMock.Congestion")
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.CongestionFunc == nil {
+		m.t.Fatalf("Unexpected call to HeavySyncMock.Congestion. %v", p)
+		return
+	}
+
+	return m.CongestionFunc(p)
+}
+
+//CongestionMinimockCounter returns a count of HeavySyncMock.CongestionFunc invocations
+func (m *HeavySyncMock) CongestionMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.CongestionCounter)
+}
+
+//CongestionMinimockPreCounter returns the value of HeavySyncMock.Congestion invocations
+func (m *HeavySyncMock) CongestionMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.CongestionPreCounter)
+}
+
+//CongestionFinished returns true if mock invocations count is ok
+func (m *HeavySyncMock) CongestionFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.CongestionMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.CongestionCounter) == uint64(len(m.CongestionMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.CongestionMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.CongestionCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.CongestionFunc != nil {
+		return atomic.LoadUint64(&m.CongestionCounter) > 0
+	}
+
+	return true
+}
+
+type mHeavySyncMockProgress struct {
+	mock              *HeavySyncMock
+	mainExpectation   *HeavySyncMockProgressExpectation
+	expectationSeries []*HeavySyncMockProgressExpectation
+}
+
+type HeavySyncMockProgressExpectation struct {
+	input  *HeavySyncMockProgressInput
+	result *HeavySyncMockProgressResult
+}
+
+type HeavySyncMockProgressInput struct {
+	p context.Context
+}
+
+type HeavySyncMockProgressResult struct {
+	r []core.HeavySyncProgress
+}
+
+//Expect specifies that invocation of HeavySync.Progress is expected from 1 to Infinity times
+func (m *mHeavySyncMockProgress) Expect(p context.Context) *mHeavySyncMockProgress {
+	m.mock.ProgressFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &HeavySyncMockProgressExpectation{}
+	}
+	m.mainExpectation.input = &HeavySyncMockProgressInput{p}
+	return m
+}
+
+//Return specifies results of invocation of HeavySync.Progress
+func (m *mHeavySyncMockProgress) Return(r []core.HeavySyncProgress) *HeavySyncMock {
+	m.mock.ProgressFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &HeavySyncMockProgressExpectation{}
+	}
+	m.mainExpectation.result = &HeavySyncMockProgressResult{r}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of HeavySync.Progress is expected once
+func (m *mHeavySyncMockProgress) ExpectOnce(p context.Context) *HeavySyncMockProgressExpectation {
+	m.mock.ProgressFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &HeavySyncMockProgressExpectation{}
+	expectation.input = &HeavySyncMockProgressInput{p}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *HeavySyncMockProgressExpectation) Return(r []core.HeavySyncProgress) {
+	e.result = &HeavySyncMockProgressResult{r}
+}
+
+//Set uses given function f as a mock of HeavySync.Progress method
+func (m *mHeavySyncMockProgress) Set(f func(p context.Context) (r []core.HeavySyncProgress)) *HeavySyncMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.ProgressFunc = f
+	return m.mock
+}
+
+//Progress implements github.com/insolar/insolar/core.HeavySync interface
+func (m *HeavySyncMock) Progress(p context.Context) (r []core.HeavySyncProgress) {
+	counter := atomic.AddUint64(&m.ProgressPreCounter, 1)
+	defer atomic.AddUint64(&m.ProgressCounter, 1)
+
+	if len(m.ProgressMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.ProgressMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to HeavySyncMock.Progress. %v", p)
+			return
+		}
+
+		input := m.ProgressMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, HeavySyncMockProgressInput{p}, "HeavySync.Progress got unexpected parameters")
+
+		result := m.ProgressMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the HeavySyncMock.Progress")
+			return
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.ProgressMock.mainExpectation != nil {
+
+		input := m.ProgressMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, HeavySyncMockProgressInput{p}, "HeavySync.Progress got unexpected parameters")
+		}
+
+		result := m.ProgressMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the HeavySyncMock.Progress")
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.ProgressFunc == nil {
+		m.t.Fatalf("Unexpected call to HeavySyncMock.Progress. %v", p)
+		return
+	}
+
+	return m.ProgressFunc(p)
+}
+
+//ProgressMinimockCounter returns a count of HeavySyncMock.ProgressFunc invocations
+func (m *HeavySyncMock) ProgressMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.ProgressCounter)
+}
+
+//ProgressMinimockPreCounter returns the value of HeavySyncMock.Progress invocations
+func (m *HeavySyncMock) ProgressMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.ProgressPreCounter)
+}
+
+//ProgressFinished returns true if mock invocations count is ok
+func (m *HeavySyncMock) ProgressFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.ProgressMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.ProgressCounter) == uint64(len(m.ProgressMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.ProgressMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.ProgressCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.ProgressFunc != nil {
+		return atomic.LoadUint64(&m.ProgressCounter) > 0
+	}
+
+	return true
+}
+
 type mHeavySyncMockReset struct {
 	mock              *HeavySyncMock
 	mainExpectation   *HeavySyncMockResetExpectation
@@ -206,6 +518,158 @@ func (m *HeavySyncMock) ResetFinished() bool {
 	return true
 }
 
+type mHeavySyncMockResume struct {
+	mock              *HeavySyncMock
+	mainExpectation   *HeavySyncMockResumeExpectation
+	expectationSeries []*HeavySyncMockResumeExpectation
+}
+
+type HeavySyncMockResumeExpectation struct {
+	input  *HeavySyncMockResumeInput
+	result *HeavySyncMockResumeResult
+}
+
+type HeavySyncMockResumeInput struct {
+	p  context.Context
+	p1 core.RecordID
+	p2 core.PulseNumber
+}
+
+type HeavySyncMockResumeResult struct {
+	r  int
+	r1 error
+}
+
+//Expect specifies that invocation of HeavySync.Resume is expected from 1 to Infinity times
+func (m *mHeavySyncMockResume) Expect(p context.Context, p1 core.RecordID, p2 core.PulseNumber) *mHeavySyncMockResume {
+	m.mock.ResumeFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &HeavySyncMockResumeExpectation{}
+	}
+	m.mainExpectation.input = &HeavySyncMockResumeInput{p, p1, p2}
+	return m
+}
+
+//Return specifies results of invocation of HeavySync.Resume
+func (m *mHeavySyncMockResume) Return(r int, r1 error) *HeavySyncMock {
+	m.mock.ResumeFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &HeavySyncMockResumeExpectation{}
+	}
+	m.mainExpectation.result = &HeavySyncMockResumeResult{r, r1}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of HeavySync.Resume is expected once
+func (m *mHeavySyncMockResume) ExpectOnce(p context.Context, p1 core.RecordID, p2 core.PulseNumber) *HeavySyncMockResumeExpectation {
+	m.mock.ResumeFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &HeavySyncMockResumeExpectation{}
+	expectation.input = &HeavySyncMockResumeInput{p, p1, p2}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *HeavySyncMockResumeExpectation) Return(r int, r1 error) {
+	e.result = &HeavySyncMockResumeResult{r, r1}
+}
+
+//Set uses given function f as a mock of HeavySync.Resume method
+func (m *mHeavySyncMockResume) Set(f func(p context.Context, p1 core.RecordID, p2 core.PulseNumber) (r int, r1 error)) *HeavySyncMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.ResumeFunc = f
+	return m.mock
+}
+
+//Resume implements github.com/insolar/insolar/core.HeavySync interface
+func (m *HeavySyncMock) Resume(p context.Context, p1 core.RecordID, p2 core.PulseNumber) (r int, r1 error) {
+	counter := atomic.AddUint64(&m.ResumePreCounter, 1)
+	defer atomic.AddUint64(&m.ResumeCounter, 1)
+
+	if len(m.ResumeMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.ResumeMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to HeavySyncMock.Resume. %v %v %v", p, p1, p2)
+			return
+		}
+
+		input := m.ResumeMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, HeavySyncMockResumeInput{p, p1, p2}, "HeavySync.Resume got unexpected parameters")
+
+		result := m.ResumeMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the HeavySyncMock.Resume")
+			return
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.ResumeMock.mainExpectation != nil {
+
+		input := m.ResumeMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, HeavySyncMockResumeInput{p, p1, p2}, "HeavySync.Resume got unexpected parameters")
+		}
+
+		result := m.ResumeMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the HeavySyncMock.Resume")
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.ResumeFunc == nil {
+		m.t.Fatalf("Unexpected call to HeavySyncMock.Resume. %v %v %v", p, p1, p2)
+		return
+	}
+
+	return m.ResumeFunc(p, p1, p2)
+}
+
+//ResumeMinimockCounter returns a count of HeavySyncMock.ResumeFunc invocations
+func (m *HeavySyncMock) ResumeMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.ResumeCounter)
+}
+
+//ResumeMinimockPreCounter returns the value of HeavySyncMock.Resume invocations
+func (m *HeavySyncMock) ResumeMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.ResumePreCounter)
+}
+
+//ResumeFinished returns true if mock invocations count is ok
+func (m *HeavySyncMock) ResumeFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.ResumeMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.ResumeCounter) == uint64(len(m.ResumeMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.ResumeMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.ResumeCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.ResumeFunc != nil {
+		return atomic.LoadUint64(&m.ResumeCounter) > 0
+	}
+
+	return true
+}
+
 type mHeavySyncMockStart struct {
 	mock              *HeavySyncMock
 	mainExpectation   *HeavySyncMockStartExpectation
@@ -370,6 +834,7 @@ type HeavySyncMockStopInput struct {
 	p  context.Context
 	p1 core.RecordID
 	p2 core.PulseNumber
+	p3 core.RecordRef
 }
 
 type HeavySyncMockStopResult struct {
@@ -377,14 +842,14 @@ type HeavySyncMockStopResult struct {
 }
 
 //Expect specifies that invocation of HeavySync.Stop is expected from 1 to Infinity times
-func (m *mHeavySyncMockStop) Expect(p context.Context, p1 core.RecordID, p2 core.PulseNumber) *mHeavySyncMockStop {
+func (m *mHeavySyncMockStop) Expect(p context.Context, p1 core.RecordID, p2 core.PulseNumber, p3 core.RecordRef) *mHeavySyncMockStop {
 	m.mock.StopFunc = nil
 	m.expectationSeries = nil
 
 	if m.mainExpectation == nil {
 		m.mainExpectation = &HeavySyncMockStopExpectation{}
 	}
-	m.mainExpectation.input = &HeavySyncMockStopInput{p, p1, p2}
+	m.mainExpectation.input = &HeavySyncMockStopInput{p, p1, p2, p3}
 	return m
 }
 
@@ -401,12 +866,12 @@ func (m *mHeavySyncMockStop) Return(r error) *HeavySyncMock {
 }
 
 //ExpectOnce specifies that invocation of HeavySync.Stop is expected once
-func (m *mHeavySyncMockStop) ExpectOnce(p context.Context, p1 core.RecordID, p2 core.PulseNumber) *HeavySyncMockStopExpectation {
+func (m *mHeavySyncMockStop) ExpectOnce(p context.Context, p1 core.RecordID, p2 core.PulseNumber, p3 core.RecordRef) *HeavySyncMockStopExpectation {
 	m.mock.StopFunc = nil
 	m.mainExpectation = nil
 
 	expectation := &HeavySyncMockStopExpectation{}
-	expectation.input = &HeavySyncMockStopInput{p, p1, p2}
+	expectation.input = &HeavySyncMockStopInput{p, p1, p2, p3}
 	m.expectationSeries = append(m.expectationSeries, expectation)
 	return expectation
 }
@@ -416,7 +881,7 @@ func (e *HeavySyncMockStopExpectation) Return(r error) {
 }
 
 //Set uses given function f as a mock of HeavySync.Stop method
-func (m *mHeavySyncMockStop) Set(f func(p context.Context, p1 core.RecordID, p2 core.PulseNumber) (r error)) *HeavySyncMock {
+func (m *mHeavySyncMockStop) Set(f func(p context.Context, p1 core.RecordID, p2 core.PulseNumber, p3 core.RecordRef) (r error)) *HeavySyncMock {
 	m.mainExpectation = nil
 	m.expectationSeries = nil
 
@@ -425,18 +890,18 @@ func (m *mHeavySyncMockStop) Set(f func(p context.Context, p1 core.RecordID, p2
 }
 
 //Stop implements github.com/insolar/insolar/core.HeavySync interface
-func (m *HeavySyncMock) Stop(p context.Context, p1 core.RecordID, p2 core.PulseNumber) (r error) {
+func (m *HeavySyncMock) Stop(p context.Context, p1 core.RecordID, p2 core.PulseNumber, p3 core.RecordRef) (r error) {
 	counter := atomic.AddUint64(&m.StopPreCounter, 1)
 	defer atomic.AddUint64(&m.StopCounter, 1)
 
 	if len(m.StopMock.expectationSeries) > 0 {
 		if counter > uint64(len(m.StopMock.expectationSeries)) {
-			m.t.Fatalf("Unexpected call to HeavySyncMock.Stop. %v %v %v", p, p1, p2)
+			m.t.Fatalf("Unexpected call to HeavySyncMock.Stop. %v %v %v %v", p, p1, p2, p3)
 			return
 		}
 
 		input := m.StopMock.expectationSeries[counter-1].input
-		testify_assert.Equal(m.t, *input, HeavySyncMockStopInput{p, p1, p2}, "HeavySync.Stop got unexpected parameters")
+		testify_assert.Equal(m.t, *input, HeavySyncMockStopInput{p, p1, p2, p3}, "HeavySync.Stop got unexpected parameters")
 
 		result := m.StopMock.expectationSeries[counter-1].result
 		if result == nil {
@@ -453,7 +918,7 @@ func (m *HeavySyncMock) Stop(p context.Context, p1 core.RecordID, p2 core.PulseN
 
 		input := m.StopMock.mainExpectation.input
 		if input != nil {
-			testify_assert.Equal(m.t, *input, HeavySyncMockStopInput{p, p1, p2}, "HeavySync.Stop got unexpected parameters")
+			testify_assert.Equal(m.t, *input, HeavySyncMockStopInput{p, p1, p2, p3}, "HeavySync.Stop got unexpected parameters")
 		}
 
 		result := m.StopMock.mainExpectation.result
@@ -467,11 +932,11 @@ func (m *HeavySyncMock) Stop(p context.Context, p1 core.RecordID, p2 core.PulseN
 	}
 
 	if m.StopFunc == nil {
-		m.t.Fatalf("Unexpected call to HeavySyncMock.Stop. %v %v %v", p, p1, p2)
+		m.t.Fatalf("Unexpected call to HeavySyncMock.Stop. %v %v %v %v", p, p1, p2, p3)
 		return
 	}
 
-	return m.StopFunc(p, p1, p2)
+	return m.StopFunc(p, p1, p2, p3)
 }
 
 //StopMinimockCounter returns a count of HeavySyncMock.StopFunc invocations
@@ -658,10 +1123,22 @@ func (m *HeavySyncMock) StoreFinished() bool {
 //Deprecated: please use MinimockFinish method or use Finish method of minimock.Controller
 func (m *HeavySyncMock) ValidateCallCounters() {
 
+	if !m.CongestionFinished() {
+		m.t.Fatal("Expected call to HeavySyncMock.Congestion")
+	}
+
+	if !m.ProgressFinished() {
+		m.t.Fatal("Expected call to HeavySyncMock.Progress")
+	}
+
 	if !m.ResetFinished() {
 		m.t.Fatal("Expected call to HeavySyncMock.Reset")
 	}
 
+	if !m.ResumeFinished() {
+		m.t.Fatal("Expected call to HeavySyncMock.Resume")
+	}
+
 	if !m.StartFinished() {
 		m.t.Fatal("Expected call to HeavySyncMock.Start")
 	}
@@ -691,10 +1168,22 @@ func (m *HeavySyncMock) Finish() {
 //MinimockFinish checks that all mocked methods of the interface have been called at least once
 func (m *HeavySyncMock) MinimockFinish() {
 
+	if !m.CongestionFinished() {
+		m.t.Fatal("Expected call to HeavySyncMock.Congestion")
+	}
+
+	if !m.ProgressFinished() {
+		m.t.Fatal("Expected call to HeavySyncMock.Progress")
+	}
+
 	if !m.ResetFinished() {
 		m.t.Fatal("Expected call to HeavySyncMock.Reset")
 	}
 
+	if !m.ResumeFinished() {
+		m.t.Fatal("Expected call to HeavySyncMock.Resume")
+	}
+
 	if !m.StartFinished() {
 		m.t.Fatal("Expected call to HeavySyncMock.Start")
 	}
@@ -721,7 +1210,10 @@ func (m *HeavySyncMock) MinimockWait(timeout time.Duration) {
 	timeoutCh := time.After(timeout)
 	for {
 		ok := true
+		ok = ok && m.CongestionFinished()
+		ok = ok && m.ProgressFinished()
 		ok = ok && m.ResetFinished()
+		ok = ok && m.ResumeFinished()
 		ok = ok && m.StartFinished()
 		ok = ok && m.StopFinished()
 		ok = ok && m.StoreFinished()
@@ -733,10 +1225,22 @@ func (m *HeavySyncMock) MinimockWait(timeout time.Duration) {
 		select {
 		case <-timeoutCh:
 
+			if !m.CongestionFinished() {
+				m.t.Error("Expected call to HeavySyncMock.Congestion")
+			}
+
+			if !m.ProgressFinished() {
+				m.t.Error("Expected call to HeavySyncMock.Progress")
+			}
+
 			if !m.ResetFinished() {
 				m.t.Error("Expected call to HeavySyncMock.Reset")
 			}
 
+			if !m.ResumeFinished() {
+				m.t.Error("Expected call to HeavySyncMock.Resume")
+			}
+
 			if !m.StartFinished() {
 				m.t.Error("Expected call to HeavySyncMock.Start")
 			}
@@ -761,10 +1265,22 @@ func (m *HeavySyncMock) MinimockWait(timeout time.Duration) {
 //it can be used with assert/require, i.e. assert.True(mock.AllMocksCalled())
 func (m *HeavySyncMock) AllMocksCalled() bool {
 
+	if !m.CongestionFinished() {
+		return false
+	}
+
+	if !m.ProgressFinished() {
+		return false
+	}
+
 	if !m.ResetFinished() {
 		return false
 	}
 
+	if !m.ResumeFinished() {
+		return false
+	}
+
 	if !m.StartFinished() {
 		return false
 	}
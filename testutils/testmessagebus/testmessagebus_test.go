@@ -0,0 +1,38 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package testmessagebus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/testutils"
+	"github.com/insolar/insolar/testutils/conformance"
+)
+
+func TestTestMessageBus_Conformance(t *testing.T) {
+	conformance.MessageBus(t, func(t *testing.T) (context.Context, core.MessageBus) {
+		pulseStorage := testutils.NewPulseStorageMock(t)
+		pulseStorage.CurrentMock.Return(core.GenesisPulse, nil)
+
+		mb := NewTestMessageBus(t)
+		mb.PulseStorage = pulseStorage
+		return inslogger.TestContext(t), mb
+	})
+}
@@ -36,6 +36,11 @@ var (
 	GitHash = "unset"
 )
 
+// GetNodeVersion returns version of node software announced to the network, release version with git hash.
+func GetNodeVersion() string {
+	return Version + "-" + GitHash
+}
+
 // GetFullVersion returns multi line full version information
 func GetFullVersion() string {
 